@@ -0,0 +1,372 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type assetAddFlags struct {
+	name     string
+	kind     string
+	amount   string
+	currency string
+	note     string
+}
+
+type assetListFlags struct {
+	kind           string
+	includeDeleted bool
+}
+
+type assetUpdateFlags struct {
+	name   string
+	amount string
+	note   string
+}
+
+type assetCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *assetCLIError) Error() string {
+	if e == nil {
+		return "asset command error"
+	}
+	return e.Message
+}
+
+func NewAssetCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "asset",
+		Short: "Track holdings and loans entered by balance",
+		Long: `Track holdings and fixed liabilities that do not move through entries:
+an investment account, a savings account at another bank, a mortgage.
+Balances are entered by hand and updated when they change; networth show
+adds assets to holdings and liabilities to loans.`,
+	}
+
+	cmd.AddCommand(
+		newAssetAddCmd(opts),
+		newAssetListCmd(opts),
+		newAssetUpdateCmd(opts),
+		newAssetDeleteCmd(opts),
+	)
+
+	return cmd
+}
+
+func newAssetAddCmd(opts *RootOptions) *cobra.Command {
+	flags := &assetAddFlags{kind: domain.AssetKindAsset}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a holding or liability with its current balance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printAssetError(cmd, opts.Output, &assetCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "asset add does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if strings.TrimSpace(flags.amount) == "" {
+				return printAssetError(cmd, opts.Output, &assetCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "amount is required",
+					Details: map[string]any{"field": "amount"},
+				})
+			}
+
+			currencyCode := flags.currency
+			if !cmd.Flags().Changed("currency") {
+				currencyCode = defaultCurrency(opts)
+			}
+			balanceMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, currencyCode, amountFormat(opts))
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			svc, err := newAssetService(opts)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			asset, err := svc.Add(cmd.Context(), domain.AssetAddInput{
+				Name:         flags.name,
+				Kind:         flags.kind,
+				CurrencyCode: currencyCode,
+				BalanceMinor: balanceMinor,
+				Note:         flags.note,
+			})
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"asset": asset,
+			}, nil), assetListTables([]domain.Asset{asset}))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.name, "name", "", "Unique asset name")
+	cmd.Flags().StringVar(&flags.kind, "kind", domain.AssetKindAsset, "Asset kind: asset|liability")
+	cmd.Flags().StringVar(&flags.amount, "amount", "", "Current balance in major units (e.g. 2500.00)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
+	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
+
+	return cmd
+}
+
+func newAssetListCmd(opts *RootOptions) *cobra.Command {
+	flags := &assetListFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List assets and liabilities with per-currency totals",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printAssetError(cmd, opts.Output, &assetCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "asset list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newAssetService(opts)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			assets, err := svc.List(cmd.Context(), domain.AssetListFilter{
+				Kind:           flags.kind,
+				IncludeDeleted: flags.includeDeleted,
+			})
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+			totals := domain.TotalAssets(assets)
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"assets": assets,
+				"totals": totals,
+				"count":  len(assets),
+			}, nil), append(assetListTables(assets), assetTotalTables(totals)...))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.kind, "kind", "", "Only list one kind: asset|liability")
+	cmd.Flags().BoolVar(&flags.includeDeleted, "include-deleted", false, "Include soft-deleted assets")
+
+	return cmd
+}
+
+func newAssetUpdateCmd(opts *RootOptions) *cobra.Command {
+	flags := &assetUpdateFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update an asset name, balance or note",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printAssetError(cmd, opts.Output, &assetCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "asset update requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveAssetID(args[0])
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			svc, err := newAssetService(opts)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			input := domain.AssetUpdateInput{ID: id}
+			if cmd.Flags().Changed("name") {
+				value := flags.name
+				input.Name = &value
+			}
+			if cmd.Flags().Changed("amount") {
+				current, err := svc.Get(cmd.Context(), id)
+				if err != nil {
+					return printAssetError(cmd, opts.Output, err)
+				}
+				value, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, current.CurrencyCode, amountFormat(opts))
+				if err != nil {
+					return printAssetError(cmd, opts.Output, err)
+				}
+				input.BalanceMinor = &value
+			}
+			if cmd.Flags().Changed("note") {
+				value := flags.note
+				input.Note = &value
+			}
+
+			asset, err := svc.Update(cmd.Context(), input)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"asset": asset,
+			}, nil), assetListTables([]domain.Asset{asset}))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.name, "name", "", "New asset name")
+	cmd.Flags().StringVar(&flags.amount, "amount", "", "New balance in major units, in the asset currency")
+	cmd.Flags().StringVar(&flags.note, "note", "", "New note (empty clears it)")
+
+	return cmd
+}
+
+func newAssetDeleteCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Soft-delete an asset",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printAssetError(cmd, opts.Output, &assetCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "asset delete requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveAssetID(args[0])
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			svc, err := newAssetService(opts)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			deleted, err := svc.Delete(cmd.Context(), id)
+			if err != nil {
+				return printAssetError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"asset_delete": deleted,
+			}, nil))
+		},
+	}
+}
+
+func newAssetService(opts *RootOptions) (*service.AssetService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &assetCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewAssetService(sqlitestore.NewAssetRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("asset service init: %w", err)
+	}
+	return svc, nil
+}
+
+func parsePositiveAssetID(raw string) (int64, error) {
+	parsed, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, &assetCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "id must be a positive integer",
+			Details: map[string]any{"field": "id", "value": raw},
+		}
+	}
+	return parsed, nil
+}
+
+func printAssetError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *assetCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromAssetError(err), messageFromAssetError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromAssetError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrAssetNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrAssetNameConflict):
+		return "CONFLICT"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidAssetID),
+		errors.Is(err, domain.ErrAssetNameRequired),
+		errors.Is(err, domain.ErrInvalidAssetKind),
+		errors.Is(err, domain.ErrInvalidAssetBalance),
+		errors.Is(err, domain.ErrNoAssetUpdateFields),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromAssetError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrAssetNotFound):
+		return "asset not found"
+	case errors.Is(err, domain.ErrAssetNameConflict):
+		return "asset name already exists"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidAssetID):
+		return "asset id must be a positive integer"
+	case errors.Is(err, domain.ErrAssetNameRequired):
+		return "name is required"
+	case errors.Is(err, domain.ErrInvalidAssetKind):
+		return "kind must be one of: asset|liability"
+	case errors.Is(err, domain.ErrInvalidAssetBalance):
+		return "amount must not be negative"
+	case errors.Is(err, domain.ErrNoAssetUpdateFields):
+		return "at least one update field is required"
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return "amount must be a valid decimal number"
+	case errors.Is(err, domain.ErrInvalidAmountPrecision):
+		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrAmountOverflow):
+		return "amount is too large"
+	default:
+		return "database operation failed"
+	}
+}
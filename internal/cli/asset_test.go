@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestAssetLifecycleFeedsNetWorthAndReport(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "income", "--amount", "1000.00", "--currency", "USD", "--date", "2026-02-01",
+	}))
+
+	brokerage := mustMap(t, mustMap(t, executeAssetCmdJSON(t, db, []string{
+		"add", "--name", "Brokerage", "--amount", "2500.00", "--currency", "USD",
+	})["data"])["asset"])
+	if brokerage["kind"] != "asset" || brokerage["balance_minor"].(float64) != 250000 {
+		t.Fatalf("unexpected brokerage asset: %v", brokerage)
+	}
+	assertSuccessJSONEnvelope(t, executeAssetCmdJSON(t, db, []string{
+		"add", "--name", "Car loan", "--kind", "liability", "--amount", "800.00", "--currency", "USD",
+	}))
+
+	conflict := executeAssetCmdJSON(t, db, []string{"add", "--name", "brokerage", "--amount", "1.00", "--currency", "USD"})
+	if code := mustMap(t, conflict["error"])["code"]; code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT for duplicate name, got %v", conflict)
+	}
+	invalidKind := executeAssetCmdJSON(t, db, []string{"add", "--name", "House", "--kind", "property", "--amount", "1.00"})
+	if code := mustMap(t, invalidKind["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown kind, got %v", invalidKind)
+	}
+
+	brokerageID := strconv.FormatInt(int64(brokerage["id"].(float64)), 10)
+	updated := mustMap(t, mustMap(t, executeAssetCmdJSON(t, db, []string{"update", brokerageID, "--amount", "3000.00"})["data"])["asset"])
+	if updated["balance_minor"].(float64) != 300000 {
+		t.Fatalf("expected updated balance, got %v", updated)
+	}
+
+	listed := mustMap(t, executeAssetCmdJSON(t, db, []string{"list"})["data"])
+	totals := mustAnySlice(t, listed["totals"])
+	if listed["count"].(float64) != 2 || len(totals) != 1 {
+		t.Fatalf("unexpected asset list: %v", listed)
+	}
+	if usd := mustMap(t, totals[0]); usd["net_minor"].(float64) != 220000 {
+		t.Fatalf("unexpected asset totals: %v", usd)
+	}
+
+	shown := executeNetWorthCmdJSON(t, db, []string{"show", "--date", "2026-02-28"})
+	position := mustMap(t, mustAnySlice(t, mustMap(t, shown["data"])["by_currency"])[0])
+	if position["holdings_minor"].(float64) != 300000 || position["loans_minor"].(float64) != 80000 || position["net_worth_minor"].(float64) != 320000 {
+		t.Fatalf("expected net worth to include holdings and loans, got %v", position)
+	}
+
+	withoutAssets := mustMap(t, executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02"})["data"])
+	if _, ok := withoutAssets["assets"]; ok {
+		t.Fatalf("expected no assets section without --include-assets, got %v", withoutAssets["assets"])
+	}
+	withAssets := mustMap(t, executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02", "--include-assets"})["data"])
+	section := mustMap(t, withAssets["assets"])
+	if len(mustAnySlice(t, section["items"])) != 2 || len(mustAnySlice(t, section["totals"])) != 1 {
+		t.Fatalf("unexpected report assets section: %v", section)
+	}
+
+	assertSuccessJSONEnvelope(t, executeAssetCmdJSON(t, db, []string{"delete", brokerageID}))
+	afterDelete := mustMap(t, executeAssetCmdJSON(t, db, []string{"list"})["data"])
+	if afterDelete["count"].(float64) != 1 {
+		t.Fatalf("expected deleted asset to be hidden, got %v", afterDelete)
+	}
+	missing := executeAssetCmdJSON(t, db, []string{"update", brokerageID, "--note", "gone"})
+	if code := mustMap(t, missing["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for deleted asset, got %v", missing)
+	}
+}
+
+func executeAssetCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewAssetCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute asset cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal asset payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
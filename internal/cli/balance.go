@@ -6,7 +6,6 @@ import (
 
 	"boring-budget/internal/cli/output"
 	"boring-budget/internal/domain"
-	"boring-budget/internal/fx"
 	"boring-budget/internal/service"
 	sqlitestore "boring-budget/internal/store/sqlite"
 	"github.com/spf13/cobra"
@@ -25,7 +24,9 @@ type balanceShowFlags struct {
 	categoryIDRaw string
 	labelIDRaw    []string
 	labelMode     string
+	currency      string
 	convertTo     string
+	revalueAsOf   string
 }
 
 type balanceCurrencyNet struct {
@@ -34,13 +35,15 @@ type balanceCurrencyNet struct {
 }
 
 type balanceView struct {
-	ByCurrency []balanceCurrencyNet `json:"by_currency"`
+	ByCurrency  []balanceCurrencyNet      `json:"by_currency"`
+	Revaluation *domain.ReportRevaluation `json:"revaluation,omitempty"`
 }
 
 type balanceRangeView struct {
-	FromUTC    string               `json:"from_utc,omitempty"`
-	ToUTC      string               `json:"to_utc,omitempty"`
-	ByCurrency []balanceCurrencyNet `json:"by_currency"`
+	FromUTC     string                    `json:"from_utc,omitempty"`
+	ToUTC       string                    `json:"to_utc,omitempty"`
+	ByCurrency  []balanceCurrencyNet      `json:"by_currency"`
+	Revaluation *domain.ReportRevaluation `json:"revaluation,omitempty"`
 }
 
 type balanceData struct {
@@ -58,7 +61,9 @@ type balanceRequest struct {
 	CategoryID    *int64
 	LabelIDs      []int64
 	LabelMode     string
+	CurrencyCode  string
 	ConvertTo     string
+	RevalueAsOf   string
 	IncludeRange  bool
 	IncludeAll    bool
 	IncludeGlobal bool
@@ -68,6 +73,7 @@ type balanceConvertedView struct {
 	TargetCurrency   string `json:"target_currency"`
 	NetMinor         int64  `json:"net_minor"`
 	UsedEstimateRate bool   `json:"used_estimate_rate"`
+	UsedStaleRate    bool   `json:"used_stale_rate"`
 }
 
 func NewBalanceCmd(opts *RootOptions) *cobra.Command {
@@ -113,7 +119,9 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 				CategoryID:      req.CategoryID,
 				LabelIDs:        req.LabelIDs,
 				LabelMode:       req.LabelMode,
+				CurrencyCode:    req.CurrencyCode,
 				ConvertTo:       req.ConvertTo,
+				RevalueAsOf:     req.RevalueAsOf,
 			})
 			if err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
@@ -121,13 +129,17 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 
 			payload := balanceData{Scope: req.Scope}
 			if result.Lifetime != nil {
-				payload.Lifetime = &balanceView{ByCurrency: toBalanceCurrencyRows(result.Lifetime.ByCurrency)}
+				payload.Lifetime = &balanceView{
+					ByCurrency:  toBalanceCurrencyRows(result.Lifetime.ByCurrency),
+					Revaluation: result.Lifetime.Revaluation,
+				}
 			}
 			if result.Range != nil {
 				payload.Range = &balanceRangeView{
-					FromUTC:    req.FromUTC,
-					ToUTC:      req.ToUTC,
-					ByCurrency: toBalanceCurrencyRows(result.Range.ByCurrency),
+					FromUTC:     req.FromUTC,
+					ToUTC:       req.ToUTC,
+					ByCurrency:  toBalanceCurrencyRows(result.Range.ByCurrency),
+					Revaluation: result.Range.Revaluation,
 				}
 			}
 			if result.LifetimeConverted != nil {
@@ -135,6 +147,7 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 					TargetCurrency:   result.LifetimeConverted.TargetCurrency,
 					NetMinor:         result.LifetimeConverted.NetMinor,
 					UsedEstimateRate: result.LifetimeConverted.UsedEstimateRate,
+					UsedStaleRate:    result.LifetimeConverted.UsedStaleRate,
 				}
 			}
 			if result.RangeConverted != nil {
@@ -142,10 +155,11 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 					TargetCurrency:   result.RangeConverted.TargetCurrency,
 					NetMinor:         result.RangeConverted.NetMinor,
 					UsedEstimateRate: result.RangeConverted.UsedEstimateRate,
+					UsedStaleRate:    result.RangeConverted.UsedStaleRate,
 				}
 			}
 
-			warnings := []domain.Warning{}
+			warnings := append([]domain.Warning{}, result.Warnings...)
 			if (result.LifetimeConverted != nil && result.LifetimeConverted.UsedEstimateRate) ||
 				(result.RangeConverted != nil && result.RangeConverted.UsedEstimateRate) {
 				warnings = append(warnings, domain.Warning{
@@ -156,9 +170,19 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 					},
 				})
 			}
+			if (result.LifetimeConverted != nil && result.LifetimeConverted.UsedStaleRate) ||
+				(result.RangeConverted != nil && result.RangeConverted.UsedStaleRate) {
+				warnings = append(warnings, domain.Warning{
+					Code:    domain.WarningCodeFXRateStale,
+					Message: domain.FXRateStaleWarningMessage,
+					Details: map[string]any{
+						"target_currency": req.ConvertTo,
+					},
+				})
+			}
 
 			env := output.NewSuccessEnvelope(payload, toOutputWarnings(warnings))
-			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+			return output.PrintTables(cmd.OutOrStdout(), reportOutputFormat(opts), env, balanceTables(payload))
 		},
 	}
 
@@ -168,7 +192,9 @@ func newBalanceShowCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Filter by category ID")
 	cmd.Flags().StringArrayVar(&flags.labelIDRaw, "label-id", nil, "Filter by label ID (repeatable)")
 	cmd.Flags().StringVar(&flags.labelMode, "label-mode", "any", "Label filter mode: any|all|none")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only include entries in this currency (ISO code)")
 	cmd.Flags().StringVar(&flags.convertTo, "convert-to", "", "Optional target currency (ISO code) for converted net")
+	cmd.Flags().StringVar(&flags.revalueAsOf, "revalue-as-of", "", "Restate per-currency nets in YYYY-MM-DD terms using imported inflation indexes")
 
 	return cmd
 }
@@ -188,14 +214,16 @@ func newBalanceService(opts *RootOptions) (*service.BalanceService, error) {
 		return nil, fmt.Errorf("entry service init: %w", err)
 	}
 
-	fxRepo := sqlitestore.NewFXRepo(opts.db)
-	fxClient := fx.NewFrankfurterClient(nil)
-	converter, err := fx.NewConverter(fxClient, fxRepo)
+	converter, err := newFXConverter(opts)
 	if err != nil {
 		return nil, fmt.Errorf("fx converter init: %w", err)
 	}
 
-	balanceSvc, err := service.NewBalanceService(entrySvc, service.WithBalanceFXConverter(converter))
+	balanceSvc, err := service.NewBalanceService(entrySvc,
+		service.WithBalanceFXConverter(converter),
+		service.WithBalanceMonthTotals(entryRepo),
+		service.WithBalanceInflationIndexReader(sqlitestore.NewInflationRepo(opts.db)),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("balance service init: %w", err)
 	}
@@ -252,14 +280,16 @@ func buildBalanceRequest(flags *balanceShowFlags) (balanceRequest, error) {
 	}
 
 	req := balanceRequest{
-		Scope:      scope,
-		FromUTC:    fromUTC,
-		ToUTC:      toUTC,
-		CategoryID: categoryID,
-		LabelIDs:   labelIDs,
-		LabelMode:  flags.labelMode,
-		ConvertTo:  flags.convertTo,
-		IncludeAll: scope == balanceScopeBoth,
+		Scope:        scope,
+		FromUTC:      fromUTC,
+		ToUTC:        toUTC,
+		CategoryID:   categoryID,
+		LabelIDs:     labelIDs,
+		LabelMode:    flags.labelMode,
+		CurrencyCode: flags.currency,
+		ConvertTo:    flags.convertTo,
+		RevalueAsOf:  flags.revalueAsOf,
+		IncludeAll:   scope == balanceScopeBoth,
 	}
 
 	switch scope {
@@ -93,6 +93,30 @@ func TestBalanceShowJSONScopesAndFilters(t *testing.T) {
 	}
 }
 
+func TestBalanceShowJSONCurrencyFilter(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "100.00", "--currency", "USD", "--date", "2026-02-01"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "7.00", "--currency", "EUR", "--date", "2026-02-10"}))
+
+	payload := executeBalanceCmdJSON(t, db, []string{"show", "--scope", "lifetime", "--currency", "eur"})
+	if ok, _ := payload["ok"].(bool); !ok {
+		t.Fatalf("expected balance show ok=true payload=%v", payload)
+	}
+	byCurrency := mustAnySlice(t, mustMap(t, mustMap(t, payload["data"])["lifetime"])["by_currency"])
+	if len(byCurrency) != 1 || balanceNetForCurrency(t, byCurrency, "EUR") != 700 {
+		t.Fatalf("expected only EUR net 700, got %v", byCurrency)
+	}
+
+	invalid := executeBalanceCmdJSON(t, db, []string{"show", "--currency", "EURO"})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_CURRENCY_CODE" {
+		t.Fatalf("expected INVALID_CURRENCY_CODE, got %v", invalid)
+	}
+}
+
 func TestBalanceShowJSONInvalidScope(t *testing.T) {
 	t.Parallel()
 
@@ -169,8 +193,11 @@ func TestBalanceShowHumanOutput(t *testing.T) {
 	if !strings.Contains(out, "[OK] boring-budget") {
 		t.Fatalf("expected human output status line, got %q", out)
 	}
-	if !strings.Contains(out, "\"scope\": \"both\"") {
-		t.Fatalf("expected human output to include scope, got %q", out)
+	if !strings.Contains(out, "Lifetime balance") || !strings.Contains(out, "Range balance") {
+		t.Fatalf("expected human output to include both scopes, got %q", out)
+	}
+	if !strings.Contains(out, "USD       $1.00") {
+		t.Fatalf("expected human output to render currency-formatted net, got %q", out)
 	}
 }
 
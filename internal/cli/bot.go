@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/telegram"
+	"github.com/spf13/cobra"
+)
+
+// botPollWait is how long one getUpdates call waits for a message.
+const botPollWait = 30 * time.Second
+
+type botTelegramFlags struct {
+	chatIDRaw []string
+	once      bool
+	apiURL    string
+}
+
+type botCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *botCLIError) Error() string {
+	if e == nil {
+		return "bot command error"
+	}
+	return e.Message
+}
+
+// botRunSummary counts the messages a bot run handled.
+type botRunSummary struct {
+	Added   int `json:"added"`
+	Failed  int `json:"failed"`
+	Ignored int `json:"ignored"`
+}
+
+func NewBotCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bot",
+		Short: "Add entries from chat messages",
+	}
+
+	cmd.AddCommand(newBotTelegramCmd(opts))
+
+	return cmd
+}
+
+func newBotTelegramCmd(opts *RootOptions) *cobra.Command {
+	flags := &botTelegramFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "telegram",
+		Short: "Add entries from Telegram messages",
+		Long: `Poll a Telegram bot for messages and add each one as an entry, parsed like
+entry quick ("coffee 4.50 #work @visa"). The bot replies with the created
+entry, its warnings and the month's cap status, or with why the message was
+rejected; /help replies with the message format.
+
+The bot token is read from ` + envTelegramToken + `. Only messages from the chats
+passed with --chat-id are handled; others are ignored, since anyone can find
+and message a bot.
+
+The bot runs until interrupted. --once handles the messages waiting now and
+exits with a summary.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printBotError(cmd, outputFormat(opts), &botCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "bot telegram does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			chatIDs, err := parseBotChatIDs(flags.chatIDRaw)
+			if err != nil {
+				return printBotError(cmd, outputFormat(opts), err)
+			}
+
+			client, err := telegram.NewClient(flags.apiURL, os.Getenv(envTelegramToken), nil)
+			if err != nil {
+				return printBotError(cmd, outputFormat(opts), &botCLIError{
+					Code:    "CONFIG_ERROR",
+					Message: envTelegramToken + " must hold the bot token",
+					Details: map[string]any{"field": envTelegramToken},
+				})
+			}
+
+			summary, err := runTelegramBot(cmd, opts, client, chatIDs, flags.once)
+			if err != nil {
+				return printBotError(cmd, outputFormat(opts), err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), output.NewSuccessEnvelope(summary, nil))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&flags.chatIDRaw, "chat-id", nil, "Telegram chat ID allowed to add entries (repeatable, required)")
+	cmd.Flags().BoolVar(&flags.once, "once", false, "Handle the waiting messages and exit")
+	cmd.Flags().StringVar(&flags.apiURL, "api-url", telegram.BaseURL, "Telegram Bot API base URL")
+	_ = cmd.Flags().MarkHidden("api-url")
+
+	return cmd
+}
+
+// runTelegramBot handles messages until the command's context ends, or once
+// with once set. Each handled update is confirmed on the next poll, so a
+// restarted bot does not add an entry twice.
+func runTelegramBot(cmd *cobra.Command, opts *RootOptions, client *telegram.Client, chatIDs []int64, once bool) (botRunSummary, error) {
+	ctx := cmd.Context()
+	summary := botRunSummary{}
+	wait := botPollWait
+	if once {
+		wait = 0
+	}
+
+	var offset int64
+	for {
+		updates, err := client.GetUpdates(ctx, offset, wait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return summary, nil
+			}
+			return summary, botProviderError(err)
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			message := update.Message
+			if message == nil || strings.TrimSpace(message.Text) == "" || !slices.Contains(chatIDs, message.Chat.ID) {
+				summary.Ignored++
+				continue
+			}
+
+			reply, added := botReply(cmd, opts, message.Text)
+			if added {
+				summary.Added++
+			} else {
+				summary.Failed++
+			}
+			if err := client.SendMessage(ctx, message.Chat.ID, reply); err != nil && ctx.Err() == nil {
+				return summary, botProviderError(err)
+			}
+		}
+
+		if once {
+			// Confirm what was handled without waiting for more.
+			if len(updates) > 0 {
+				if _, err := client.GetUpdates(ctx, offset, 0); err != nil {
+					return summary, botProviderError(err)
+				}
+			}
+			return summary, nil
+		}
+	}
+}
+
+// botReply adds the entry text describes and returns the reply, reporting
+// whether an entry was added.
+func botReply(cmd *cobra.Command, opts *RootOptions, text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "/start" || text == "/help" {
+		return `Send an entry like "coffee 4.50 #work @visa": an amount (+ for income), optional currency, date (yesterday, friday, 2026-03-01), #label and @card. Other words become the note; a word matching a category sets it.`, false
+	}
+
+	svc, err := newEntryService(opts)
+	if err != nil {
+		return botErrorReply(err), false
+	}
+	parsed, err := domain.ParseQuickEntry(text, displayNow(opts))
+	if err != nil {
+		return botErrorReply(quickEntryParseError(err)), false
+	}
+	input, _, err := resolveQuickEntry(cmd, opts, &entryQuickFlags{}, parsed)
+	if err != nil {
+		return botErrorReply(err), false
+	}
+	result, err := svc.AddWithWarnings(cmd.Context(), input)
+	if err != nil {
+		return botErrorReply(err), false
+	}
+
+	entry := result.Entry
+	lines := []string{fmt.Sprintf("Added %s #%d: %s on %s", entry.Type, entry.ID, formatHumanMoney(entry.AmountMinor, entry.CurrencyCode), entry.TransactionDateUTC[:10])}
+	if entry.Note != "" {
+		lines[0] += " (" + entry.Note + ")"
+	}
+	for _, warning := range result.Warnings {
+		lines = append(lines, "Warning: "+warning.Message)
+	}
+	lines = append(lines, botCapStatusLines(cmd, opts, entry)...)
+	return strings.Join(lines, "\n"), true
+}
+
+// botCapStatusLines describes the caps of the entry's month in its currency.
+// Cap lookups that fail are left out of the reply rather than failing it.
+func botCapStatusLines(cmd *cobra.Command, opts *RootOptions, entry domain.Entry) []string {
+	if entry.Type != domain.EntryTypeExpense {
+		return nil
+	}
+	capSvc, err := newCapService(opts)
+	if err != nil {
+		return nil
+	}
+	result, err := capSvc.Status(cmd.Context(), entry.TransactionDateUTC[:7], nil, entry.CurrencyCode, displayNow(opts))
+	if err != nil {
+		return nil
+	}
+
+	lines := make([]string, 0, len(result.Statuses))
+	for _, status := range result.Statuses {
+		scope := "Cap"
+		if status.CategoryID != nil {
+			scope = "Category #" + strconv.FormatInt(*status.CategoryID, 10) + " cap"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s of %s spent, %s left",
+			scope,
+			status.MonthKey,
+			formatHumanMoney(status.SpendToDateMinor, status.CurrencyCode),
+			formatHumanMoney(status.CapAmountMinor, status.CurrencyCode),
+			formatHumanMoney(status.RemainingMinor, status.CurrencyCode),
+		))
+	}
+	return lines
+}
+
+func botErrorReply(err error) string {
+	var cliErr *entryCLIError
+	if errors.As(err, &cliErr) {
+		return "Not added: " + cliErr.Message
+	}
+	var strictErr *domain.StrictWarningError
+	if errors.As(err, &strictErr) {
+		messages := make([]string, 0, len(strictErr.Warnings))
+		for _, warning := range strictErr.Warnings {
+			messages = append(messages, warning.Message)
+		}
+		return "Not added: " + strings.Join(messages, "; ")
+	}
+	return "Not added: " + messageFromEntryError(err)
+}
+
+func botProviderError(err error) error {
+	return &botCLIError{
+		Code:    "BOT_PROVIDER_UNAVAILABLE",
+		Message: "telegram request failed",
+		Details: map[string]any{"reason": err.Error()},
+	}
+}
+
+func parseBotChatIDs(raw []string) ([]int64, error) {
+	if len(raw) == 0 {
+		return nil, &botCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "chat-id is required",
+			Details: map[string]any{"field": "chat-id"},
+		}
+	}
+	ids := make([]int64, 0, len(raw))
+	for _, value := range raw {
+		id, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, &botCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "chat-id must be an integer",
+				Details: map[string]any{"field": "chat-id", "value": value},
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func printBotError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	var cliErr *botCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{"reason": fmt.Sprint(err)}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
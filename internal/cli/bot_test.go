@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+// fakeTelegram serves queued updates from getUpdates and records the
+// sendMessage calls and the offsets getUpdates was called with.
+type fakeTelegram struct {
+	mu      sync.Mutex
+	updates []map[string]any
+	offsets []float64
+	sent    []map[string]any
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	params := map[string]any{}
+	_ = json.NewDecoder(r.Body).Decode(&params)
+
+	var result any = true
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/getUpdates"):
+		offset, _ := params["offset"].(float64)
+		f.offsets = append(f.offsets, offset)
+		pending := []map[string]any{}
+		for _, update := range f.updates {
+			if update["update_id"].(int) >= int(offset) {
+				pending = append(pending, update)
+			}
+		}
+		result = pending
+	case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+		f.sent = append(f.sent, params)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func TestBotTelegramOnceAddsEntriesFromAllowedChats(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	insertTestCategory(t, db, "Coffee")
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--amount", "100.00", "--currency", "USD"}))
+
+	fake := &fakeTelegram{updates: []map[string]any{
+		{"update_id": 10, "message": map[string]any{"message_id": 1, "chat": map[string]any{"id": 42}, "text": "coffee 4.50 2026-03-01"}},
+		{"update_id": 11, "message": map[string]any{"message_id": 2, "chat": map[string]any{"id": 7}, "text": "coffee 9.00 2026-03-01"}},
+		{"update_id": 12, "message": map[string]any{"message_id": 3, "chat": map[string]any{"id": 42}, "text": "no amount here"}},
+	}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	t.Setenv(envTelegramToken, "test-token")
+
+	cmd := NewBotCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"telegram", "--chat-id", "42", "--once", "--api-url", server.URL})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute bot telegram: %v", err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal bot payload: %v raw=%s", err, buf.String())
+	}
+	assertSuccessJSONEnvelope(t, payload)
+	summary := mustMap(t, payload["data"])
+	if summary["added"].(float64) != 1 || summary["failed"].(float64) != 1 || summary["ignored"].(float64) != 1 {
+		t.Fatalf("unexpected bot summary: %v", summary)
+	}
+
+	if len(fake.sent) != 2 {
+		t.Fatalf("expected replies to the two allowed messages, got %v", fake.sent)
+	}
+	added := fake.sent[0]["text"].(string)
+	if fake.sent[0]["chat_id"].(float64) != 42 || !strings.Contains(added, "Added expense #1") || !strings.Contains(added, "Cap 2026-03") {
+		t.Fatalf("unexpected reply to the entry message: %q", added)
+	}
+	if rejected := fake.sent[1]["text"].(string); !strings.HasPrefix(rejected, "Not added: quick entry needs an amount") {
+		t.Fatalf("unexpected reply to the invalid message: %q", rejected)
+	}
+	if last := fake.offsets[len(fake.offsets)-1]; last != 13 {
+		t.Fatalf("expected handled updates to be confirmed with offset 13, got %v", fake.offsets)
+	}
+
+	entries := executeEntryCmdJSON(t, db, []string{"list"})
+	if count := mustMap(t, entries["data"])["count"].(float64); count != 1 {
+		t.Fatalf("expected one entry added by the bot, got %v", count)
+	}
+}
+
+func TestBotTelegramRequiresTokenAndChatID(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	t.Setenv(envTelegramToken, "")
+
+	for _, tc := range []struct {
+		args []string
+		code string
+	}{
+		{args: []string{"telegram", "--once"}, code: "INVALID_ARGUMENT"},
+		{args: []string{"telegram", "--once", "--chat-id", "42"}, code: "CONFIG_ERROR"},
+	} {
+		cmd := NewBotCmd(&RootOptions{Output: output.FormatJSON, db: db})
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(tc.args)
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("execute bot %v: %v", tc.args, err)
+		}
+		payload := map[string]any{}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("unmarshal bot payload: %v raw=%s", err, buf.String())
+		}
+		if code := mustMap(t, payload["error"])["code"]; code != tc.code {
+			t.Fatalf("expected %s for %v, got %v", tc.code, tc.args, payload)
+		}
+	}
+}
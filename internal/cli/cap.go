@@ -3,6 +3,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"boring-budget/internal/cli/output"
@@ -13,13 +14,24 @@ import (
 )
 
 type capSetFlags struct {
-	monthRaw    string
-	amount      string
-	currencyRaw string
+	monthRaw      string
+	categoryIDRaw string
+	amount        string
+	currencyRaw   string
+	copyPrevious  bool
+	ifUpdatedAt   string
+	jsonInput     string
+}
+
+type capRollFlags struct {
+	fromRaw string
+	toRaw   string
 }
 
 type capMonthFlags struct {
-	monthRaw string
+	monthRaw      string
+	categoryIDRaw string
+	currency      string
 }
 
 type capCLIError struct {
@@ -44,7 +56,9 @@ func NewCapCmd(opts *RootOptions) *cobra.Command {
 	cmd.AddCommand(
 		newCapSetCmd(opts),
 		newCapShowCmd(opts),
+		newCapStatusCmd(opts),
 		newCapHistoryCmd(opts),
+		newCapRollCmd(opts),
 	)
 
 	return cmd
@@ -56,6 +70,18 @@ func newCapSetCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "Create or update a monthly cap",
+		Long: `Create or update the month's global cap, or with --category-id a cap for
+one category. Category caps are checked alongside the global cap: an expense
+can exceed either, both, or neither.
+
+--copy-previous takes the amount and currency from the previous month's cap
+of the same scope instead of --amount/--currency.
+
+--if-updated-at rejects the write with CONFLICT unless the cap still has the
+updated_at_utc you last read; pass it to avoid overwriting a concurrent change.
+
+--json-input reads month_key, category_id, amount_minor and currency_code
+from a cap object (such as cap show JSON output) instead of the flags.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
 				return printCapError(cmd, capOutputFormat(opts), &capCLIError{
@@ -70,12 +96,25 @@ func newCapSetCmd(opts *RootOptions) *cobra.Command {
 				return printCapError(cmd, capOutputFormat(opts), err)
 			}
 
-			input, err := buildCapSetInput(cmd, flags)
-			if err != nil {
-				return printCapError(cmd, capOutputFormat(opts), err)
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currencyRaw)
+
+			var (
+				capValue domain.MonthlyCap
+				change   domain.MonthlyCapChange
+			)
+			if flags.copyPrevious && !cmd.Flags().Changed(jsonInputFlag) {
+				capValue, change, err = copyPreviousCap(cmd, svc, flags)
+			} else {
+				var input domain.CapSetInput
+				if cmd.Flags().Changed(jsonInputFlag) {
+					input, err = buildCapSetInputFromJSON(cmd, flags)
+				} else {
+					input, err = buildCapSetInput(cmd, flags, amountFormat(opts))
+				}
+				if err == nil {
+					capValue, change, err = svc.Set(cmd.Context(), input)
+				}
 			}
-
-			capValue, change, err := svc.Set(cmd.Context(), input)
 			if err != nil {
 				return printCapError(cmd, capOutputFormat(opts), err)
 			}
@@ -89,8 +128,60 @@ func newCapSetCmd(opts *RootOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&flags.monthRaw, "month", "", "Target month in YYYY-MM")
+	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Cap one category instead of the whole month")
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Cap amount in major units (e.g. 500.00)")
-	cmd.Flags().StringVar(&flags.currencyRaw, "currency", defaultEntryCurrency, "ISO currency code (e.g. USD)")
+	cmd.Flags().StringVar(&flags.currencyRaw, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
+	cmd.Flags().BoolVar(&flags.copyPrevious, "copy-previous", false, "Copy the previous month's cap instead of passing --amount")
+	cmd.Flags().StringVar(&flags.ifUpdatedAt, "if-updated-at", "", "Only update if the cap's updated_at_utc still matches this value")
+	cmd.Flags().StringVar(&flags.jsonInput, jsonInputFlag, "", "Read the cap as JSON from this file, or - for stdin (accepts cap JSON output)")
+
+	return cmd
+}
+
+func newCapRollCmd(opts *RootOptions) *cobra.Command {
+	flags := &capRollFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "roll",
+		Short: "Copy a month's global and category caps into another month",
+		Long: `Copy every cap of --from (the global cap and each category cap) into --to.
+Caps that --to already has are kept and listed as skipped. Each copy is
+recorded in cap history with copied_from_month_key.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCapError(cmd, capOutputFormat(opts), &capCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "cap roll does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newCapService(opts)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			fromMonth, err := normalizeMonthKeyFlag(flags.fromRaw, "from")
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+			toMonth, err := normalizeMonthKeyFlag(flags.toRaw, "to")
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			result, err := svc.Roll(cmd.Context(), fromMonth, toMonth)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(result, nil)
+			return output.Print(cmd.OutOrStdout(), capOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Source month in YYYY-MM")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Target month in YYYY-MM")
 
 	return cmd
 }
@@ -120,7 +211,17 @@ func newCapShowCmd(opts *RootOptions) *cobra.Command {
 				return printCapError(cmd, capOutputFormat(opts), err)
 			}
 
-			capValue, err := svc.Show(cmd.Context(), monthKey)
+			categoryID, err := parseCapCategoryID(flags.categoryIDRaw)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			var capValue domain.MonthlyCap
+			if categoryID != nil {
+				capValue, err = svc.ShowCategory(cmd.Context(), monthKey, *categoryID)
+			} else {
+				capValue, err = svc.Show(cmd.Context(), monthKey)
+			}
 			if err != nil {
 				return printCapError(cmd, capOutputFormat(opts), err)
 			}
@@ -131,6 +232,62 @@ func newCapShowCmd(opts *RootOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&flags.monthRaw, "month", "", "Target month in YYYY-MM")
+	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Show the cap for one category")
+
+	return cmd
+}
+
+func newCapStatusCmd(opts *RootOptions) *cobra.Command {
+	flags := &capMonthFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show spend against caps with a month-end projection",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCapError(cmd, capOutputFormat(opts), &capCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "cap status does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newCapService(opts)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			now := displayNow(opts)
+			monthKey := now.Format("2006-01")
+			if cmd.Flags().Changed("month") {
+				monthKey, err = normalizeMonthKey(flags.monthRaw)
+				if err != nil {
+					return printCapError(cmd, capOutputFormat(opts), err)
+				}
+			}
+
+			categoryID, err := parseCapCategoryID(flags.categoryIDRaw)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			result, err := svc.Status(cmd.Context(), monthKey, categoryID, flags.currency, now)
+			if err != nil {
+				return printCapError(cmd, capOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"month_key": monthKey,
+				"statuses":  result.Statuses,
+				"count":     len(result.Statuses),
+			}, toOutputWarnings(result.Warnings))
+			return output.PrintTables(cmd.OutOrStdout(), capOutputFormat(opts), env, capStatusTables(result.Statuses))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.monthRaw, "month", "", "Target month in YYYY-MM (defaults to the current month)")
+	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Show only the cap for one category")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Show only caps in this currency (ISO code)")
 
 	return cmd
 }
@@ -196,7 +353,7 @@ func newCapService(opts *RootOptions) (*service.CapService, error) {
 	return svc, nil
 }
 
-func buildCapSetInput(cmd *cobra.Command, flags *capSetFlags) (domain.CapSetInput, error) {
+func buildCapSetInput(cmd *cobra.Command, flags *capSetFlags, amountFormat string) (domain.CapSetInput, error) {
 	if flags == nil {
 		return domain.CapSetInput{}, &capCLIError{
 			Code:    "INTERNAL_ERROR",
@@ -217,25 +374,80 @@ func buildCapSetInput(cmd *cobra.Command, flags *capSetFlags) (domain.CapSetInpu
 		return domain.CapSetInput{}, err
 	}
 
-	amountMinor, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currencyRaw)
+	categoryID, err := parseCapCategoryID(flags.categoryIDRaw)
 	if err != nil {
 		return domain.CapSetInput{}, err
 	}
 
-	return domain.CapSetInput{
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currencyRaw, amountFormat)
+	if err != nil {
+		return domain.CapSetInput{}, err
+	}
+
+	input := domain.CapSetInput{
 		MonthKey:     monthKey,
+		CategoryID:   categoryID,
 		AmountMinor:  amountMinor,
 		CurrencyCode: flags.currencyRaw,
-	}, nil
+	}
+	if cmd != nil && cmd.Flags().Changed("if-updated-at") {
+		value := flags.ifUpdatedAt
+		input.ExpectedUpdatedAtUTC = &value
+	}
+	return input, nil
+}
+
+func copyPreviousCap(cmd *cobra.Command, svc *service.CapService, flags *capSetFlags) (domain.MonthlyCap, domain.MonthlyCapChange, error) {
+	for _, name := range []string{"amount", "currency", "if-updated-at"} {
+		if cmd.Flags().Changed(name) {
+			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, &capCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "copy-previous cannot be combined with amount, currency or if-updated-at",
+				Details: map[string]any{"fields": []string{"copy-previous", name}},
+			}
+		}
+	}
+
+	monthKey, err := normalizeMonthKey(flags.monthRaw)
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+	}
+	categoryID, err := parseCapCategoryID(flags.categoryIDRaw)
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+	}
+	return svc.CopyPrevious(cmd.Context(), monthKey, categoryID)
+}
+
+// parseCapCategoryID returns nil for an empty flag, meaning the global cap.
+func parseCapCategoryID(raw string) (*int64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		return nil, &capCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "category-id must be a positive integer",
+			Details: map[string]any{"field": "category-id", "value": raw},
+		}
+	}
+	return &parsed, nil
 }
 
 func normalizeMonthKey(raw string) (string, error) {
+	return normalizeMonthKeyFlag(raw, "month")
+}
+
+func normalizeMonthKeyFlag(raw, field string) (string, error) {
 	month := strings.TrimSpace(raw)
 	if month == "" {
 		return "", &capCLIError{
 			Code:    "INVALID_ARGUMENT",
-			Message: "month is required",
-			Details: map[string]any{"field": "month"},
+			Message: field + " is required",
+			Details: map[string]any{"field": field},
 		}
 	}
 
@@ -243,8 +455,8 @@ func normalizeMonthKey(raw string) (string, error) {
 	if err != nil {
 		return "", &capCLIError{
 			Code:    "INVALID_ARGUMENT",
-			Message: "month must use YYYY-MM",
-			Details: map[string]any{"field": "month", "value": raw},
+			Message: field + " must use YYYY-MM",
+			Details: map[string]any{"field": field, "value": raw},
 		}
 	}
 	return normalized, nil
@@ -283,12 +495,18 @@ func codeFromCapError(err error) string {
 		errors.Is(err, domain.ErrInvalidCapAmount),
 		errors.Is(err, domain.ErrInvalidAmount),
 		errors.Is(err, domain.ErrInvalidAmountPrecision),
-		errors.Is(err, domain.ErrAmountOverflow):
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidCategoryID),
+		errors.Is(err, domain.ErrCapRollSameMonth),
+		errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC):
 		return "INVALID_ARGUMENT"
 	case errors.Is(err, domain.ErrInvalidCurrencyCode):
 		return "INVALID_CURRENCY_CODE"
-	case errors.Is(err, domain.ErrCapNotFound):
+	case errors.Is(err, domain.ErrCapNotFound), errors.Is(err, domain.ErrCategoryNotFound):
 		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrUpdateConflict):
+		return "CONFLICT"
 	default:
 		message := strings.ToLower(err.Error())
 		if strings.Contains(message, "unique constraint") || strings.Contains(message, "constraint failed") {
@@ -306,14 +524,26 @@ func messageFromCapError(err error) string {
 		return "amount must be a valid decimal number"
 	case errors.Is(err, domain.ErrInvalidAmountPrecision):
 		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
 	case errors.Is(err, domain.ErrAmountOverflow):
 		return "amount is too large"
 	case errors.Is(err, domain.ErrInvalidCapAmount):
 		return "amount must be greater than zero"
 	case errors.Is(err, domain.ErrInvalidCurrencyCode):
 		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidCategoryID):
+		return "category-id must be a positive integer"
+	case errors.Is(err, domain.ErrCapRollSameMonth):
+		return "from and to must be different months"
+	case errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC):
+		return "if-updated-at cannot be empty"
+	case errors.Is(err, domain.ErrUpdateConflict):
+		return "cap changed since it was read; reload and retry"
 	case errors.Is(err, domain.ErrCapNotFound):
 		return "cap not found"
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		return "category not found"
 	default:
 		message := strings.ToLower(err.Error())
 		if strings.Contains(message, "unique constraint") || strings.Contains(message, "constraint failed") {
@@ -5,10 +5,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
 )
 
 func TestCapCommandJSONSetShowHistory(t *testing.T) {
@@ -194,6 +198,185 @@ func TestCapCommandJSONInvalidMonth(t *testing.T) {
 	}
 }
 
+func TestCapCommandJSONCategoryCapsWarnAndReport(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := fmt.Sprint(insertTestCategory(t, db, "Food"))
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--amount", "500.00", "--currency", "USD"}))
+	categorySet := executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--category-id", foodID, "--amount", "50.00", "--currency", "USD"})
+	assertSuccessJSONEnvelope(t, categorySet)
+	if categoryCap := mustMap(t, mustMap(t, categorySet["data"])["cap"]); fmt.Sprint(categoryCap["category_id"]) != foodID {
+		t.Fatalf("expected category cap for %s, got %v", foodID, categoryCap)
+	}
+
+	added := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "60.00", "--currency", "USD", "--date", "2026-03-05", "--category-id", foodID})
+	mustEntrySuccess(t, added)
+	warnings := mustAnySlice(t, added["warnings"])
+	if len(warnings) != 1 || mustMap(t, warnings[0])["code"] != "CATEGORY_CAP_EXCEEDED" {
+		t.Fatalf("expected only CATEGORY_CAP_EXCEEDED, got %v", warnings)
+	}
+	if overspend := mustMap(t, mustMap(t, mustMap(t, warnings[0])["details"])["overspend_amount"]); overspend["amount_minor"].(float64) != 1000 {
+		t.Fatalf("expected 10.00 category overspend, got %v", overspend)
+	}
+
+	report := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03"})
+	capStatus := mustAnySlice(t, mustMap(t, report["data"])["cap_status"])
+	if len(capStatus) != 2 {
+		t.Fatalf("expected global and category cap status, got %v", capStatus)
+	}
+	if global := mustMap(t, capStatus[0]); global["category_id"] != nil || global["is_exceeded"] != false {
+		t.Fatalf("expected global cap first and not exceeded, got %v", global)
+	}
+	if category := mustMap(t, capStatus[1]); category["category_name"] != "Food" || category["is_exceeded"] != true {
+		t.Fatalf("expected exceeded Food cap, got %v", category)
+	}
+
+	history := executeCapCmdJSON(t, db, []string{"history", "--month", "2026-03"})
+	if changes := mustAnySlice(t, mustMap(t, history["data"])["changes"]); len(changes) != 2 || fmt.Sprint(mustMap(t, changes[1])["category_id"]) != foodID {
+		t.Fatalf("expected global and category cap changes, got %v", changes)
+	}
+
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--category-id", "999", "--amount", "1.00"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for an unknown category, got %v", code)
+	}
+}
+
+func TestCapCommandJSONStatusProjectsAndWarns(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := fmt.Sprint(insertTestCategory(t, db, "Food"))
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--amount", "500.00", "--currency", "USD"}))
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-03", "--category-id", foodID, "--amount", "50.00", "--currency", "USD"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "62.00", "--currency", "USD", "--date", "2026-03-05", "--category-id", foodID}))
+
+	status := executeCapCmdJSON(t, db, []string{"status", "--month", "2026-03"})
+	mustEntrySuccess(t, status)
+	statuses := mustAnySlice(t, mustMap(t, status["data"])["statuses"])
+	if len(statuses) != 2 {
+		t.Fatalf("expected global and category cap status, got %v", statuses)
+	}
+	global := mustMap(t, statuses[0])
+	if global["category_id"] != nil || global["remaining_minor"].(float64) != 43800 || global["days_elapsed"].(float64) != 31 {
+		t.Fatalf("expected finished global cap with 438.00 remaining, got %v", global)
+	}
+	if global["average_daily_burn_minor"].(float64) != 200 || global["is_at_risk"] != false {
+		t.Fatalf("expected 2.00 daily burn and no risk, got %v", global)
+	}
+	category := mustMap(t, statuses[1])
+	if category["remaining_minor"].(float64) != -1200 || category["is_at_risk"] != true {
+		t.Fatalf("expected Food cap over by 12.00 and at risk, got %v", category)
+	}
+	warnings := mustAnySlice(t, status["warnings"])
+	if len(warnings) != 1 || mustMap(t, warnings[0])["code"] != domain.WarningCodeCapAtRisk {
+		t.Fatalf("expected one CAP_AT_RISK warning, got %v", warnings)
+	}
+
+	only := executeCapCmdJSON(t, db, []string{"status", "--month", "2026-03", "--category-id", foodID})
+	if count := mustMap(t, only["data"])["count"]; count != float64(1) {
+		t.Fatalf("expected only the category cap, got %v", count)
+	}
+
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"status", "--month", "2026-03", "--currency", "EUR"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND without caps in EUR, got %v", code)
+	}
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"status", "--month", "2026-04"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for a month without caps, got %v", code)
+	}
+}
+
+func TestCapCommandJSONRollAndCopyPrevious(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := fmt.Sprint(insertTestCategory(t, db, "Food"))
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--amount", "500.00", "--currency", "USD"}))
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--category-id", foodID, "--amount", "50.00", "--currency", "USD"}))
+
+	rolled := executeCapCmdJSON(t, db, []string{"roll", "--from", "2026-02", "--to", "2026-03"})
+	assertSuccessJSONEnvelope(t, rolled)
+	rollData := mustMap(t, rolled["data"])
+	if copied := mustAnySlice(t, rollData["copied"]); len(copied) != 2 {
+		t.Fatalf("expected global and category caps copied, got %v", copied)
+	}
+
+	history := executeCapCmdJSON(t, db, []string{"history", "--month", "2026-03"})
+	changes := mustAnySlice(t, mustMap(t, history["data"])["changes"])
+	if len(changes) != 2 {
+		t.Fatalf("expected two copied cap changes, got %v", changes)
+	}
+	for _, change := range changes {
+		if mustMap(t, change)["copied_from_month_key"] != "2026-02" {
+			t.Fatalf("expected copied_from_month_key=2026-02, got %v", change)
+		}
+	}
+
+	again := mustMap(t, executeCapCmdJSON(t, db, []string{"roll", "--from", "2026-02", "--to", "2026-03"})["data"])
+	if copied, skipped := mustAnySlice(t, again["copied"]), mustAnySlice(t, again["skipped"]); len(copied) != 0 || len(skipped) != 2 {
+		t.Fatalf("expected second roll to skip existing caps, got copied=%v skipped=%v", copied, skipped)
+	}
+
+	copiedSet := executeCapCmdJSON(t, db, []string{"set", "--month", "2026-04", "--category-id", foodID, "--copy-previous"})
+	assertSuccessJSONEnvelope(t, copiedSet)
+	if change := mustMap(t, mustMap(t, copiedSet["data"])["cap_change"]); change["new_amount_minor"].(float64) != 5000 || change["copied_from_month_key"] != "2026-03" {
+		t.Fatalf("expected category cap copied from 2026-03, got %v", change)
+	}
+
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-04", "--copy-previous", "--amount", "1.00"})["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for copy-previous with amount, got %v", code)
+	}
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"roll", "--from", "2026-03", "--to", "2026-03"})["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for same-month roll, got %v", code)
+	}
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"roll", "--from", "2025-01", "--to", "2025-02"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND when the source month has no caps, got %v", code)
+	}
+}
+
+func TestCapCommandJSONSetFromJSONInput(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	assertSuccessJSONEnvelope(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--amount", "500.00", "--currency", "EUR"}))
+
+	show := executeCapCmdJSON(t, db, []string{"show", "--month", "2026-02"})
+	shownCap := mustMap(t, mustMap(t, show["data"])["cap"])
+	shownCap["month_key"] = "2026-03"
+	shownCap["amount_minor"] = 65000
+	raw, err := json.Marshal(show)
+	if err != nil {
+		t.Fatalf("marshal cap json: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "cap.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("write cap json: %v", err)
+	}
+
+	setPayload := executeCapCmdJSON(t, db, []string{"set", "--json-input", path})
+	assertSuccessJSONEnvelope(t, setPayload)
+	setCap := mustMap(t, mustMap(t, setPayload["data"])["cap"])
+	if setCap["month_key"] != "2026-03" || setCap["amount_minor"].(float64) != 65000 || setCap["currency_code"] != "EUR" {
+		t.Fatalf("unexpected cap set from json: %v", setCap)
+	}
+
+	if code := mustMap(t, executeCapCmdJSON(t, db, []string{"set", "--json-input", path, "--copy-previous"})["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for json-input with copy-previous, got %v", code)
+	}
+	stale := executeCapCmdJSON(t, db, []string{"set", "--json-input", path, "--if-updated-at", "2000-01-01T00:00:00Z"})
+	if code := mustMap(t, stale["error"])["code"]; code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT for stale if-updated-at, got %v", stale)
+	}
+}
+
 func TestCapCommandJSONStorageErrorMapsDBError(t *testing.T) {
 	t.Parallel()
 
@@ -20,23 +20,33 @@ type cardAddFlags struct {
 	brand       string
 	cardType    string
 	dueDayRaw   string
+	currency    string
+	fxFeeRaw    string
+	jsonInput   string
 }
 
 type cardListFlags struct {
-	lookup         string
-	cardType       string
-	includeDeleted bool
+	lookup          string
+	cardType        string
+	includeDeleted  bool
+	includeArchived bool
+	sort            string
 }
 
 type cardUpdateFlags struct {
-	nickname    string
-	description string
-	clearDesc   bool
-	last4       string
-	brand       string
-	cardType    string
-	dueDayRaw   string
-	clearDueDay bool
+	nickname      string
+	description   string
+	clearDesc     bool
+	last4         string
+	brand         string
+	cardType      string
+	dueDayRaw     string
+	clearDueDay   bool
+	currency      string
+	clearCurrency bool
+	fxFeeRaw      string
+	clearFXFee    bool
+	ifUpdatedAt   string
 }
 
 type cardSelectorFlags struct {
@@ -52,6 +62,20 @@ type cardDueFlags struct {
 
 type cardDebtFlags struct {
 	cardSelectorFlags
+	sort string
+}
+
+type cardDebtHistoryFlags struct {
+	cardSelectorFlags
+	currency string
+	groupBy  string
+}
+
+type cardFXFeesFlags struct {
+	cardSelectorFlags
+	fromRaw string
+	toRaw   string
+	groupBy string
 }
 
 type cardPaymentFlags struct {
@@ -61,6 +85,18 @@ type cardPaymentFlags struct {
 	note     string
 }
 
+type cardLimitSetFlags struct {
+	cardSelectorFlags
+	month    string
+	amount   string
+	currency string
+}
+
+type cardLimitShowFlags struct {
+	cardSelectorFlags
+	month string
+}
+
 type cardCLIError struct {
 	Code    string
 	Message string
@@ -90,7 +126,7 @@ func NewCardCmd(opts *RootOptions) *cobra.Command {
 		Use:   "debt",
 		Short: "Card debt queries",
 	}
-	debtCmd.AddCommand(newCardDebtShowCmd(opts))
+	debtCmd.AddCommand(newCardDebtShowCmd(opts), newCardDebtHistoryCmd(opts))
 
 	paymentCmd := &cobra.Command{
 		Use:   "payment",
@@ -98,14 +134,25 @@ func NewCardCmd(opts *RootOptions) *cobra.Command {
 	}
 	paymentCmd.AddCommand(newCardPaymentAddCmd(opts))
 
+	limitCmd := &cobra.Command{
+		Use:   "limit",
+		Short: "Card monthly spending limits",
+	}
+	limitCmd.AddCommand(newCardLimitSetCmd(opts), newCardLimitShowCmd(opts), newCardLimitListCmd(opts))
+
 	cmd.AddCommand(
 		newCardAddCmd(opts),
 		newCardListCmd(opts),
 		newCardUpdateCmd(opts),
+		newCardArchiveCmd(opts, true),
+		newCardArchiveCmd(opts, false),
 		newCardDeleteCmd(opts),
+		newCardAliasCmd(opts),
+		newCardFXFeesCmd(opts),
 		dueCmd,
 		debtCmd,
 		paymentCmd,
+		limitCmd,
 	)
 
 	return cmd
@@ -131,6 +178,12 @@ func newCardAddCmd(opts *RootOptions) *cobra.Command {
 				return printCardError(cmd, opts.Output, err)
 			}
 
+			flags := flags
+			if cmd.Flags().Changed(jsonInputFlag) {
+				if flags, err = cardAddFlagsFromJSON(cmd, flags.jsonInput); err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+			}
 			if strings.TrimSpace(flags.nickname) == "" {
 				return printCardError(cmd, opts.Output, &cardCLIError{Code: "INVALID_ARGUMENT", Message: "nickname is required", Details: map[string]any{"field": "nickname"}})
 			}
@@ -148,14 +201,24 @@ func newCardAddCmd(opts *RootOptions) *cobra.Command {
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
 			}
+			var fxFee *int64
+			if strings.TrimSpace(flags.fxFeeRaw) != "" {
+				basisPoints, err := domain.ParseCardFXFeePercent(flags.fxFeeRaw)
+				if err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+				fxFee = &basisPoints
+			}
 
 			card, err := svc.Add(cmd.Context(), domain.CardAddInput{
-				Nickname:    flags.nickname,
-				Description: flags.description,
-				Last4:       flags.last4,
-				Brand:       flags.brand,
-				CardType:    flags.cardType,
-				DueDay:      dueDay,
+				Nickname:            flags.nickname,
+				Description:         flags.description,
+				Last4:               flags.last4,
+				Brand:               flags.brand,
+				CardType:            flags.cardType,
+				DueDay:              dueDay,
+				DefaultCurrencyCode: flags.currency,
+				FXFeeBasisPoints:    fxFee,
 			})
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
@@ -171,6 +234,9 @@ func newCardAddCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVar(&flags.brand, "brand", "", "Card brand (e.g. VISA, MASTERCARD, DINERS)")
 	cmd.Flags().StringVar(&flags.cardType, "card-type", "", "Card type: credit|debit")
 	cmd.Flags().StringVar(&flags.dueDayRaw, "due-day", "", "Due day of month (1..28), required for credit cards")
+	cmd.Flags().StringVar(&flags.currency, "default-currency", "", "Optional currency for card entries and payments added without --currency")
+	cmd.Flags().StringVar(&flags.fxFeeRaw, "fx-fee-percent", "", "Optional foreign transaction fee percent added to charges in other currencies (e.g. 2.5)")
+	cmd.Flags().StringVar(&flags.jsonInput, jsonInputFlag, "", "Read the card as JSON from this file, or - for stdin (accepts card JSON output)")
 
 	return cmd
 }
@@ -190,15 +256,22 @@ func newCardListCmd(opts *RootOptions) *cobra.Command {
 				})
 			}
 
+			sortKey, err := parseCardSortFlag(flags.sort, domain.CardSortKeys)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
 			svc, err := newCardService(opts)
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
 			}
 
 			cards, err := svc.List(cmd.Context(), domain.CardListFilter{
-				Lookup:         flags.lookup,
-				CardType:       flags.cardType,
-				IncludeDeleted: flags.includeDeleted,
+				Lookup:          flags.lookup,
+				CardType:        flags.cardType,
+				IncludeDeleted:  flags.includeDeleted,
+				IncludeArchived: flags.includeArchived,
+				Sort:            sortKey,
 			})
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
@@ -214,6 +287,8 @@ func newCardListCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVar(&flags.lookup, "lookup", "", "Optional lookup text over nickname/description/last4")
 	cmd.Flags().StringVar(&flags.cardType, "card-type", "", "Optional card type filter: credit|debit")
 	cmd.Flags().BoolVar(&flags.includeDeleted, "include-deleted", false, "Include soft-deleted cards")
+	cmd.Flags().BoolVar(&flags.includeArchived, "include-archived", false, "Include archived cards")
+	cmd.Flags().StringVar(&flags.sort, "sort", domain.CardSortNickname, "Sort order: nickname|id|due-day|created")
 
 	return cmd
 }
@@ -252,6 +327,20 @@ func newCardUpdateCmd(opts *RootOptions) *cobra.Command {
 					Details: map[string]any{"fields": []string{"clear-due-day", "due-day"}},
 				})
 			}
+			if cmd.Flags().Changed("clear-default-currency") && cmd.Flags().Changed("default-currency") {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "clear-default-currency cannot be used with default-currency",
+					Details: map[string]any{"fields": []string{"clear-default-currency", "default-currency"}},
+				})
+			}
+			if cmd.Flags().Changed("clear-fx-fee") && cmd.Flags().Changed("fx-fee-percent") {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "clear-fx-fee cannot be used with fx-fee-percent",
+					Details: map[string]any{"fields": []string{"clear-fx-fee", "fx-fee-percent"}},
+				})
+			}
 
 			svc, err := newCardService(opts)
 			if err != nil {
@@ -296,6 +385,28 @@ func newCardUpdateCmd(opts *RootOptions) *cobra.Command {
 				input.SetDueDay = true
 				input.DueDay = dueDay
 			}
+			if cmd.Flags().Changed("clear-default-currency") {
+				input.SetDefaultCurrency = true
+			}
+			if cmd.Flags().Changed("default-currency") {
+				input.SetDefaultCurrency = true
+				input.DefaultCurrencyCode = flags.currency
+			}
+			if cmd.Flags().Changed("clear-fx-fee") {
+				input.SetFXFee = true
+			}
+			if cmd.Flags().Changed("fx-fee-percent") {
+				basisPoints, err := domain.ParseCardFXFeePercent(flags.fxFeeRaw)
+				if err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+				input.SetFXFee = true
+				input.FXFeeBasisPoints = &basisPoints
+			}
+			if cmd.Flags().Changed("if-updated-at") {
+				value := flags.ifUpdatedAt
+				input.ExpectedUpdatedAtUTC = &value
+			}
 
 			card, err := svc.Update(cmd.Context(), input)
 			if err != nil {
@@ -314,14 +425,69 @@ func newCardUpdateCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVar(&flags.cardType, "card-type", "", "New card type: credit|debit")
 	cmd.Flags().StringVar(&flags.dueDayRaw, "due-day", "", "New due day (1..28)")
 	cmd.Flags().BoolVar(&flags.clearDueDay, "clear-due-day", false, "Clear due day")
+	cmd.Flags().StringVar(&flags.currency, "default-currency", "", "New default currency for card entries and payments")
+	cmd.Flags().BoolVar(&flags.clearCurrency, "clear-default-currency", false, "Clear default currency")
+	cmd.Flags().StringVar(&flags.fxFeeRaw, "fx-fee-percent", "", "New foreign transaction fee percent (e.g. 2.5)")
+	cmd.Flags().BoolVar(&flags.clearFXFee, "clear-fx-fee", false, "Clear foreign transaction fee")
+	cmd.Flags().StringVar(&flags.ifUpdatedAt, "if-updated-at", "", "Only update if updated_at_utc still matches this value")
 
 	return cmd
 }
 
-func newCardDeleteCmd(opts *RootOptions) *cobra.Command {
+func newCardArchiveCmd(opts *RootOptions, archive bool) *cobra.Command {
+	use := "unarchive"
+	short := "Restore an archived card"
+	if archive {
+		use = "archive"
+		short = "Archive a card, hiding it from selectors while keeping its history"
+	}
+
 	return &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card " + use + " requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			cardID, err := parsePositiveCardID(args[0], "id")
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			var card domain.Card
+			if archive {
+				card, err = svc.Archive(cmd.Context(), cardID)
+			} else {
+				card, err = svc.Unarchive(cmd.Context(), cardID)
+			}
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{"card": card}, nil))
+		},
+	}
+}
+
+func newCardDeleteCmd(opts *RootOptions) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
 		Use:   "delete <id>",
 		Short: "Soft-delete a card",
+		Long: `Soft-delete a card. A card that still owes or is owed money in any
+currency is refused with CONFLICT unless --force is set; use card archive to
+hide such a card instead.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return printCardError(cmd, opts.Output, &cardCLIError{
@@ -341,7 +507,7 @@ func newCardDeleteCmd(opts *RootOptions) *cobra.Command {
 				return printCardError(cmd, opts.Output, err)
 			}
 
-			deleted, err := svc.Delete(cmd.Context(), cardID)
+			deleted, err := svc.Delete(cmd.Context(), cardID, force)
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
 			}
@@ -349,6 +515,9 @@ func newCardDeleteCmd(opts *RootOptions) *cobra.Command {
 			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{"card_delete": deleted}, nil))
 		},
 	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Delete even if the card has an outstanding debt balance")
+	return cmd
 }
 
 func newCardDueShowCmd(opts *RootOptions) *cobra.Command {
@@ -446,6 +615,11 @@ func newCardDebtShowCmd(opts *RootOptions) *cobra.Command {
 				})
 			}
 
+			sortKey, err := parseCardSortFlag(flags.sort, domain.CardDebtSortKeys)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
 			svc, err := newCardService(opts)
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
@@ -467,29 +641,172 @@ func newCardDebtShowCmd(opts *RootOptions) *cobra.Command {
 					return printCardError(cmd, opts.Output, err)
 				}
 
-				return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
 					"debt": debt,
-				}, nil))
+				}, nil), cardDebtTables([]service.CardDebtCardSummary{debt}))
 			}
 
-			allDebt, err := svc.ShowDebtAll(cmd.Context())
+			allDebt, err := svc.ShowDebtAllSorted(cmd.Context(), sortKey)
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
 			}
 
-			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
 				"debts": allDebt,
 				"count": len(allDebt),
+			}, nil), cardDebtTables(allDebt))
+		},
+	}
+
+	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
+	cmd.Flags().StringVar(&flags.sort, "sort", domain.CardDebtSortID, "Sort order for all cards: id|nickname|balance (balance puts the largest debt first)")
+	return cmd
+}
+
+func parseCardSortFlag(raw string, keys []string) (string, error) {
+	sortKey, err := domain.NormalizeListSort(raw, keys)
+	if err != nil {
+		return "", &cardCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "sort must be one of: " + strings.Join(keys, "|"),
+			Details: map[string]any{"field": "sort", "value": raw},
+		}
+	}
+	return sortKey, nil
+}
+
+func newCardDebtHistoryCmd(opts *RootOptions) *cobra.Command {
+	flags := &cardDebtHistoryFlags{groupBy: domain.ReportGroupingMonth}
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List a card's liability events with a running balance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card debt history does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			selector, err := buildCardSelector(flags.cardSelectorFlags)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			card, err := svc.Resolve(cmd.Context(), selector)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			history, err := svc.DebtHistory(cmd.Context(), card.ID, flags.currency, flags.groupBy)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"history": history,
+				"count":   len(history.Events),
+			}, nil), cardDebtHistoryTables(history))
+		},
+	}
+
+	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only include one currency bucket (ISO code)")
+	cmd.Flags().StringVar(&flags.groupBy, "group-by", domain.ReportGroupingMonth, "Net change grouping: day|week|month")
+	return cmd
+}
+
+func newCardFXFeesCmd(opts *RootOptions) *cobra.Command {
+	flags := &cardFXFeesFlags{groupBy: domain.ReportGroupingMonth}
+
+	cmd := &cobra.Command{
+		Use:   "fx-fees",
+		Short: "Total the foreign transaction fees charged per card and period",
+		Long: "Total the foreign transaction fees charged per card and period.\n\n" +
+			"Fees are added to the card's debt only; report spending and balances show the charged amounts without them.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card fx-fees does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if strings.TrimSpace(flags.fromRaw) == "" || strings.TrimSpace(flags.toRaw) == "" {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "from and to are required",
+					Details: map[string]any{"required_flags": []string{"from", "to"}},
+				})
+			}
+
+			fromUTC, err := normalizeListDateBound(flags.fromRaw, false)
+			if err != nil {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "from must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "from", "value": flags.fromRaw},
+				})
+			}
+			toUTC, err := normalizeListDateBound(flags.toRaw, true)
+			if err != nil {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "to must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "to", "value": flags.toRaw},
+				})
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			input := domain.CardFXFeeReportInput{
+				FromUTC:  fromUTC,
+				ToUTC:    toUTC,
+				Grouping: flags.groupBy,
+			}
+			if cmd.Flags().Changed("card-id") || cmd.Flags().Changed("card-nickname") || cmd.Flags().Changed("card-lookup") {
+				selector, err := buildCardSelector(flags.cardSelectorFlags)
+				if err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+				card, err := svc.Resolve(cmd.Context(), selector)
+				if err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+				input.CardID = &card.ID
+			}
+
+			report, err := svc.FXFees(cmd.Context(), input)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"fx_fees": report,
+				"count":   len(report.Totals),
 			}, nil))
 		},
 	}
 
 	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Start date of the charged entries (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "End date of the charged entries (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.groupBy, "group-by", domain.ReportGroupingMonth, "Period grouping: day|week|month")
 	return cmd
 }
 
 func newCardPaymentAddCmd(opts *RootOptions) *cobra.Command {
-	flags := &cardPaymentFlags{currency: defaultEntryCurrency}
+	flags := &cardPaymentFlags{}
 
 	cmd := &cobra.Command{
 		Use:   "add",
@@ -526,14 +843,22 @@ func newCardPaymentAddCmd(opts *RootOptions) *cobra.Command {
 				return printCardError(cmd, opts.Output, err)
 			}
 
-			amountMinor, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currency)
+			currency := flags.currency
+			if !cmd.Flags().Changed("currency") {
+				currency = card.DefaultCurrencyCode
+				if currency == "" {
+					currency = defaultCurrency(opts)
+				}
+			}
+
+			amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, currency, amountFormat(opts))
 			if err != nil {
 				return printCardError(cmd, opts.Output, err)
 			}
 
 			result, err := svc.AddPayment(cmd.Context(), domain.CardPaymentAddInput{
 				CardID:            card.ID,
-				CurrencyCode:      flags.currency,
+				CurrencyCode:      currency,
 				AmountMinorSigned: amountMinor,
 				Note:              flags.note,
 			})
@@ -549,12 +874,186 @@ func newCardPaymentAddCmd(opts *RootOptions) *cobra.Command {
 
 	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Payment amount in major units (required)")
-	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "Payment currency")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Payment currency (defaults to the card's default currency, then the default from settings)")
 	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
 
 	return cmd
 }
 
+func newCardLimitSetCmd(opts *RootOptions) *cobra.Command {
+	flags := &cardLimitSetFlags{currency: defaultEntryCurrency}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Create or update a card's monthly limit",
+		Long: `Create or update the limit on a card's expenses for one month. Entry add and
+update warn with CARD_LIMIT_EXCEEDED when the card's expenses in the limit's
+currency go over it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card limit set does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			if !cmd.Flags().Changed("amount") {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "amount is required",
+					Details: map[string]any{"field": "amount"},
+				})
+			}
+			if strings.TrimSpace(flags.month) == "" {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "month is required",
+					Details: map[string]any{"field": "month"},
+				})
+			}
+
+			selector, err := buildCardSelector(flags.cardSelectorFlags)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			card, err := svc.Resolve(cmd.Context(), selector)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currency)
+			amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat(opts))
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			limit, err := svc.SetLimit(cmd.Context(), domain.CardLimitSetInput{
+				CardID:       card.ID,
+				MonthKey:     flags.month,
+				AmountMinor:  amountMinor,
+				CurrencyCode: flags.currency,
+			})
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"limit": limit,
+			}, nil))
+		},
+	}
+
+	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
+	cmd.Flags().StringVar(&flags.month, "month", "", "Target month in YYYY-MM (required)")
+	cmd.Flags().StringVar(&flags.amount, "amount", "", "Limit amount in major units (required)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "Limit currency (default from settings)")
+	return cmd
+}
+
+func newCardLimitShowCmd(opts *RootOptions) *cobra.Command {
+	flags := &cardLimitShowFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show a card's spend against its monthly limit",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card limit show does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			selector, err := buildCardSelector(flags.cardSelectorFlags)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			card, err := svc.Resolve(cmd.Context(), selector)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			monthKey := displayNow(opts).Format("2006-01")
+			if cmd.Flags().Changed("month") {
+				monthKey = flags.month
+			}
+
+			status, err := svc.LimitStatus(cmd.Context(), card.ID, monthKey)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"limit_status": status,
+			}, nil))
+		},
+	}
+
+	bindCardSelectorFlags(cmd, &flags.cardSelectorFlags)
+	cmd.Flags().StringVar(&flags.month, "month", "", "Target month in YYYY-MM (defaults to the current month)")
+	return cmd
+}
+
+func newCardLimitListCmd(opts *RootOptions) *cobra.Command {
+	flags := &cardSelectorFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List a card's monthly limits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card limit list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			selector, err := buildCardSelector(*flags)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			card, err := svc.Resolve(cmd.Context(), selector)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			limits, err := svc.ListLimits(cmd.Context(), card.ID)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"limits": limits,
+				"count":  len(limits),
+			}, nil))
+		},
+	}
+
+	bindCardSelectorFlags(cmd, flags)
+	return cmd
+}
+
 func bindCardSelectorFlags(cmd *cobra.Command, flags *cardSelectorFlags) {
 	if cmd == nil || flags == nil {
 		return
@@ -655,15 +1154,39 @@ func printCardError(cmd *cobra.Command, format string, err error) error {
 		return output.Print(cmd.OutOrStdout(), format, env)
 	}
 
+	var debtErr *service.CardOutstandingDebtError
+	if errors.As(err, &debtErr) {
+		env := output.NewErrorEnvelope(codeFromCardError(err), messageFromCardError(err), map[string]any{
+			"card_id": debtErr.CardID,
+			"buckets": debtErr.Buckets,
+		}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var lookupErr *service.CardLookupConflictError
+	if errors.As(err, &lookupErr) {
+		env := output.NewErrorEnvelope(codeFromCardError(err), messageFromCardError(err), map[string]any{
+			"lookup":     lookupErr.Lookup,
+			"candidates": lookupErr.Candidates,
+		}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
 	env := output.NewErrorEnvelope(codeFromCardError(err), messageFromCardError(err), map[string]any{"reason": err.Error()}, nil)
 	return output.Print(cmd.OutOrStdout(), format, env)
 }
 
 func codeFromCardError(err error) string {
 	switch {
-	case errors.Is(err, domain.ErrCardNotFound):
+	case errors.Is(err, domain.ErrCardNotFound),
+		errors.Is(err, domain.ErrCardLimitNotFound),
+		errors.Is(err, domain.ErrCardAliasNotFound):
 		return "NOT_FOUND"
-	case errors.Is(err, domain.ErrCardNicknameConflict), errors.Is(err, domain.ErrCardLookupAmbiguous):
+	case errors.Is(err, domain.ErrCardNicknameConflict),
+		errors.Is(err, domain.ErrCardAliasExists),
+		errors.Is(err, domain.ErrCardLookupAmbiguous),
+		errors.Is(err, domain.ErrCardHasOutstandingDebt),
+		errors.Is(err, domain.ErrUpdateConflict):
 		return "CONFLICT"
 	case errors.Is(err, domain.ErrInvalidCurrencyCode):
 		return "INVALID_CURRENCY_CODE"
@@ -680,9 +1203,21 @@ func codeFromCardError(err error) string {
 		errors.Is(err, domain.ErrCardLookupRequired),
 		errors.Is(err, domain.ErrCardLookupSelectorConflict),
 		errors.Is(err, domain.ErrInvalidCardLookupText),
+		errors.Is(err, domain.ErrInvalidCardAlias),
+		errors.Is(err, domain.ErrInvalidCardFXFee),
+		errors.Is(err, domain.ErrInvalidDateRange),
 		errors.Is(err, domain.ErrInvalidCardAsOfDate),
 		errors.Is(err, domain.ErrCardPaymentRequiresCredit),
-		errors.Is(err, domain.ErrInvalidCardPaymentAmount):
+		errors.Is(err, domain.ErrInvalidCardPaymentAmount),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC),
+		errors.Is(err, domain.ErrInvalidReportGrouping),
+		errors.Is(err, domain.ErrInvalidCardLimitAmount),
+		errors.Is(err, domain.ErrInvalidListSort),
+		errors.Is(err, domain.ErrInvalidMonthKey):
 		return "INVALID_ARGUMENT"
 	default:
 		return "DB_ERROR"
@@ -697,6 +1232,20 @@ func messageFromCardError(err error) string {
 		return "card nickname already exists"
 	case errors.Is(err, domain.ErrCardLookupAmbiguous):
 		return "card lookup matches multiple cards"
+	case errors.Is(err, domain.ErrCardAliasNotFound):
+		return "card alias not found"
+	case errors.Is(err, domain.ErrCardAliasExists):
+		return "card already has this alias"
+	case errors.Is(err, domain.ErrInvalidCardAlias):
+		return fmt.Sprintf("alias must be 1 to %d characters without control characters", domain.CardAliasMaxLength)
+	case errors.Is(err, domain.ErrInvalidCardFXFee):
+		return "fx-fee-percent must be between 0 and 10 with at most two decimals"
+	case errors.Is(err, domain.ErrCardHasOutstandingDebt):
+		return "card has an outstanding debt balance; settle it, archive the card, or pass --force"
+	case errors.Is(err, domain.ErrUpdateConflict):
+		return "card changed since it was read; reload and retry"
+	case errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC):
+		return "if-updated-at cannot be empty"
 	case errors.Is(err, domain.ErrInvalidCurrencyCode):
 		return "currency must be a 3-letter ISO code"
 	case errors.Is(err, domain.ErrInvalidCardID):
@@ -725,12 +1274,32 @@ func messageFromCardError(err error) string {
 		return "card-id, card-nickname and card-lookup are mutually exclusive"
 	case errors.Is(err, domain.ErrInvalidCardLookupText):
 		return "card-lookup cannot be empty"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "from must be less than or equal to to"
 	case errors.Is(err, domain.ErrInvalidCardAsOfDate):
 		return "as-of must be YYYY-MM-DD or RFC3339"
 	case errors.Is(err, domain.ErrCardPaymentRequiresCredit):
 		return "card payment requires a credit card"
 	case errors.Is(err, domain.ErrInvalidCardPaymentAmount):
 		return "payment amount must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return "amount must be a valid decimal number"
+	case errors.Is(err, domain.ErrInvalidAmountPrecision):
+		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrAmountOverflow):
+		return "amount is too large"
+	case errors.Is(err, domain.ErrInvalidReportGrouping):
+		return "group-by must be one of: day|week|month"
+	case errors.Is(err, domain.ErrCardLimitNotFound):
+		return "card limit not found for month"
+	case errors.Is(err, domain.ErrInvalidCardLimitAmount):
+		return "limit amount must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidMonthKey):
+		return "month must use YYYY-MM"
+	case errors.Is(err, domain.ErrInvalidListSort):
+		return "sort key is not supported"
 	default:
 		return "database operation failed"
 	}
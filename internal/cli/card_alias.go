@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func newCardAliasCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Extra names a card answers to in selectors",
+		Long: `Aliases are short personal names for a card. --card-nickname matches them
+exactly and --card-lookup matches them like nicknames. An alias may not repeat
+the nickname or alias of another card.`,
+	}
+
+	cmd.AddCommand(newCardAliasAddCmd(opts), newCardAliasListCmd(opts), newCardAliasRemoveCmd(opts))
+	return cmd
+}
+
+func newCardAliasAddCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <card-id> <alias>",
+		Short: "Add an alias to a card",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card alias add requires exactly two arguments: <card-id> <alias>",
+					Details: map[string]any{"required_args": []string{"card-id", "alias"}},
+				})
+			}
+
+			cardID, err := parsePositiveCardID(args[0], "card-id")
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			alias, err := svc.AddAlias(cmd.Context(), cardID, args[1])
+			var lookupErr *service.CardLookupConflictError
+			if errors.As(err, &lookupErr) {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "CONFLICT",
+					Message: "alias is already the nickname or an alias of another card",
+					Details: map[string]any{
+						"lookup":     lookupErr.Lookup,
+						"candidates": lookupErr.Candidates,
+					},
+				})
+			}
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{"alias": alias}, nil))
+		},
+	}
+}
+
+func newCardAliasListCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [<card-id>]",
+		Short: "List card aliases, optionally for one card",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card alias list accepts at most one argument: <card-id>",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			var cardID *int64
+			if len(args) == 1 {
+				id, err := parsePositiveCardID(args[0], "card-id")
+				if err != nil {
+					return printCardError(cmd, opts.Output, err)
+				}
+				cardID = &id
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			aliases, err := svc.ListAliases(cmd.Context(), cardID)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"aliases": aliases,
+				"count":   len(aliases),
+			}, nil))
+		},
+	}
+}
+
+func newCardAliasRemoveCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <card-id> <alias>",
+		Short: "Remove an alias from a card",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return printCardError(cmd, opts.Output, &cardCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "card alias remove requires exactly two arguments: <card-id> <alias>",
+					Details: map[string]any{"required_args": []string{"card-id", "alias"}},
+				})
+			}
+
+			cardID, err := parsePositiveCardID(args[0], "card-id")
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			svc, err := newCardService(opts)
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			removed, err := svc.RemoveAlias(cmd.Context(), cardID, args[1])
+			if err != nil {
+				return printCardError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{"alias_removed": removed}, nil))
+		},
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -111,6 +113,478 @@ func TestCardCommandJSONLifecycleAndDebt(t *testing.T) {
 	}
 }
 
+func TestCardCommandJSONListAndDebtSort(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	cardIDs := map[string]int64{}
+	for _, card := range []struct {
+		nickname string
+		last4    string
+		cardType string
+		dueDay   string
+	}{
+		{nickname: "Zeta", last4: "1111", cardType: "credit", dueDay: "20"},
+		{nickname: "Alpha", last4: "2222", cardType: "debit"},
+		{nickname: "Mid", last4: "3333", cardType: "credit", dueDay: "5"},
+	} {
+		args := []string{"add", "--nickname", card.nickname, "--last4", card.last4, "--brand", "visa", "--card-type", card.cardType}
+		if card.dueDay != "" {
+			args = append(args, "--due-day", card.dueDay)
+		}
+		payload := executeCardCmdJSON(t, db, args)
+		cardIDs[card.nickname] = int64(mustMap(t, mustMap(t, payload["data"])["card"])["id"].(float64))
+	}
+
+	listNicknames := func(key string, args []string) []string {
+		t.Helper()
+		payload := executeCardCmdJSON(t, db, args)
+		if ok, _ := payload["ok"].(bool); !ok {
+			t.Fatalf("expected %v ok=true payload=%v", args, payload)
+		}
+		items := mustAnySlice(t, mustMap(t, payload["data"])[key])
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			value := mustMap(t, item)
+			if card, ok := value["card"]; ok {
+				value = mustMap(t, card)
+			}
+			out = append(out, value["nickname"].(string))
+		}
+		return out
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"list"}, want: "Alpha,Mid,Zeta"},
+		{args: []string{"list", "--sort", "id"}, want: "Zeta,Alpha,Mid"},
+		{args: []string{"list", "--sort", "due-day"}, want: "Mid,Zeta,Alpha"},
+		{args: []string{"list", "--sort", "created"}, want: "Zeta,Alpha,Mid"},
+	} {
+		if got := strings.Join(listNicknames("cards", tc.args), ","); got != tc.want {
+			t.Fatalf("%v: expected %s, got %s", tc.args, tc.want, got)
+		}
+	}
+
+	for nickname, amount := range map[string]string{"Zeta": "10.00", "Mid": "50.00"} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add",
+			"--type", "expense",
+			"--amount", amount,
+			"--currency", "USD",
+			"--date", "2026-02-01",
+			"--payment-method", "card",
+			"--card-id", strconv.FormatInt(cardIDs[nickname], 10),
+		}))
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"debt", "show"}, want: "Zeta,Mid"},
+		{args: []string{"debt", "show", "--sort", "nickname"}, want: "Mid,Zeta"},
+		{args: []string{"debt", "show", "--sort", "balance"}, want: "Mid,Zeta"},
+	} {
+		if got := strings.Join(listNicknames("debts", tc.args), ","); got != tc.want {
+			t.Fatalf("%v: expected %s, got %s", tc.args, tc.want, got)
+		}
+	}
+
+	payload := executeCardCmdJSON(t, db, []string{"debt", "show", "--sort", "due-day"})
+	errPayload := mustMap(t, payload["error"])
+	if errPayload["code"].(string) != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unsupported debt sort, got %v", errPayload["code"])
+	}
+}
+
+func TestCardCommandJSONDebtHistory(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	cardID := insertTestCard(t, db, "History Credit", "", "4321", "VISA", "credit", 10)
+	cardIDText := strconv.FormatInt(cardID, 10)
+
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "20.00", "--currency", "USD", "--date", "2026-02-01", "--payment-method", "card", "--card-id", cardIDText},
+		{"add", "--type", "expense", "--amount", "10.00", "--currency", "EUR", "--date", "2026-02-02", "--payment-method", "card", "--card-id", cardIDText},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, args))
+	}
+	paymentPayload := executeCardCmdJSON(t, db, []string{"payment", "add", "--card-id", cardIDText, "--amount", "5.00", "--currency", "USD"})
+	if ok, _ := paymentPayload["ok"].(bool); !ok {
+		t.Fatalf("expected payment add ok=true payload=%v", paymentPayload)
+	}
+
+	historyPayload := executeCardCmdJSON(t, db, []string{"debt", "history", "--card-id", cardIDText, "--currency", "usd", "--group-by", "month"})
+	if ok, _ := historyPayload["ok"].(bool); !ok {
+		t.Fatalf("expected debt history ok=true payload=%v", historyPayload)
+	}
+	historyData := mustMap(t, historyPayload["data"])
+	if int(historyData["count"].(float64)) != 2 {
+		t.Fatalf("expected 2 USD events, got %v", historyData["count"])
+	}
+	history := mustMap(t, historyData["history"])
+	if history["grouping"] != "month" {
+		t.Fatalf("expected month grouping, got %v", history["grouping"])
+	}
+	events := mustAnySlice(t, history["events"])
+	wantBalances := []int64{2000, 1500}
+	for i, raw := range events {
+		event := mustMap(t, raw)
+		if event["currency_code"] != "USD" {
+			t.Fatalf("expected only USD events, got %v", event)
+		}
+		if int64(event["balance_after_minor_signed"].(float64)) != wantBalances[i] {
+			t.Fatalf("expected balance %d after event %d, got %v", wantBalances[i], i, event["balance_after_minor_signed"])
+		}
+	}
+	periods := mustAnySlice(t, history["periods"])
+	if len(periods) != 1 {
+		t.Fatalf("expected one monthly period, got %v", periods)
+	}
+	period := mustMap(t, periods[0])
+	if int64(period["net_change_minor_signed"].(float64)) != 1500 || int64(period["closing_balance_minor_signed"].(float64)) != 1500 {
+		t.Fatalf("unexpected period totals %v", period)
+	}
+
+	allPayload := executeCardCmdJSON(t, db, []string{"debt", "history", "--card-id", cardIDText})
+	allData := mustMap(t, allPayload["data"])
+	if int(allData["count"].(float64)) != 3 {
+		t.Fatalf("expected 3 events across currencies, got %v", allData["count"])
+	}
+
+	invalidPayload := executeCardCmdJSON(t, db, []string{"debt", "history", "--card-id", cardIDText, "--group-by", "weekday"})
+	if ok, _ := invalidPayload["ok"].(bool); ok {
+		t.Fatalf("expected weekday grouping to fail, got %v", invalidPayload)
+	}
+	if code := mustMap(t, invalidPayload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %v", code)
+	}
+}
+
+func TestCardCommandJSONArchiveAndDeleteGuard(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	cardID := insertTestCard(t, db, "Old Credit", "", "1111", "VISA", "credit", 5)
+	cardIDText := strconv.FormatInt(cardID, 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "20.00", "--currency", "USD", "--date", "2026-02-01",
+		"--payment-method", "card", "--card-id", cardIDText,
+	}))
+
+	deletePayload := executeCardCmdJSON(t, db, []string{"delete", cardIDText})
+	deleteErr := mustMap(t, deletePayload["error"])
+	if deleteErr["code"] != "CONFLICT" {
+		t.Fatalf("expected CONFLICT deleting a card with debt, got %v", deletePayload)
+	}
+	if buckets := mustAnySlice(t, mustMap(t, deleteErr["details"])["buckets"]); len(buckets) != 1 {
+		t.Fatalf("expected the outstanding bucket in details, got %v", buckets)
+	}
+
+	archivePayload := executeCardCmdJSON(t, db, []string{"archive", cardIDText})
+	if ok, _ := archivePayload["ok"].(bool); !ok {
+		t.Fatalf("expected archive ok=true payload=%v", archivePayload)
+	}
+	if _, ok := mustMap(t, mustMap(t, archivePayload["data"])["card"])["archived_at_utc"].(string); !ok {
+		t.Fatalf("expected archived_at_utc on archived card, got %v", archivePayload)
+	}
+
+	if count := mustMap(t, executeCardCmdJSON(t, db, []string{"list"})["data"])["count"]; count.(float64) != 0 {
+		t.Fatalf("expected archived card hidden from list, got %v", count)
+	}
+	if count := mustMap(t, executeCardCmdJSON(t, db, []string{"list", "--include-archived"})["data"])["count"]; count.(float64) != 1 {
+		t.Fatalf("expected archived card with --include-archived, got %v", count)
+	}
+	nicknamePayload := executeCardCmdJSON(t, db, []string{"debt", "show", "--card-nickname", "Old Credit"})
+	if code := mustMap(t, nicknamePayload["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected archived card hidden from nickname selector, got %v", nicknamePayload)
+	}
+
+	mustCardSuccess := func(args []string) {
+		t.Helper()
+		payload := executeCardCmdJSON(t, db, args)
+		if ok, _ := payload["ok"].(bool); !ok {
+			t.Fatalf("expected %v ok=true payload=%v", args, payload)
+		}
+	}
+	mustCardSuccess([]string{"payment", "add", "--card-id", cardIDText, "--amount", "20.00", "--currency", "USD"})
+	mustCardSuccess([]string{"delete", cardIDText})
+
+	otherID := insertTestCard(t, db, "Other Credit", "", "2222", "VISA", "credit", 5)
+	otherIDText := strconv.FormatInt(otherID, 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "5.00", "--currency", "USD", "--date", "2026-02-01",
+		"--payment-method", "card", "--card-id", otherIDText,
+	}))
+	mustCardSuccess([]string{"delete", otherIDText, "--force"})
+}
+
+func TestCardCommandJSONDefaultCurrency(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	addPayload := executeCardCmdJSON(t, db, []string{
+		"add", "--nickname", "EUR travel", "--last4", "4242", "--brand", "visa",
+		"--card-type", "credit", "--due-day", "10", "--default-currency", "eur",
+	})
+	card := mustMap(t, mustMap(t, addPayload["data"])["card"])
+	if card["default_currency_code"] != "EUR" {
+		t.Fatalf("expected default currency EUR, got %v", addPayload)
+	}
+	cardIDText := strconv.FormatInt(int64(card["id"].(float64)), 10)
+
+	entryPayload := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "30.00", "--date", "2026-02-01",
+		"--payment-method", "card", "--card-nickname", "EUR travel",
+	})
+	mustEntrySuccess(t, entryPayload)
+	if currency := mustMap(t, mustMap(t, entryPayload["data"])["entry"])["currency_code"]; currency != "EUR" {
+		t.Fatalf("expected card expense in EUR, got %v", currency)
+	}
+
+	paymentPayload := executeCardCmdJSON(t, db, []string{"payment", "add", "--card-id", cardIDText, "--amount", "10.00"})
+	if ok, _ := paymentPayload["ok"].(bool); !ok {
+		t.Fatalf("expected payment add ok=true payload=%v", paymentPayload)
+	}
+	balance := mustMap(t, mustMap(t, mustMap(t, paymentPayload["data"])["payment"])["balance"])
+	if balance["currency_code"] != "EUR" || int64(balance["balance_minor_signed"].(float64)) != 2000 {
+		t.Fatalf("expected EUR balance 2000 after payment, got %v", balance)
+	}
+
+	updatePayload := executeCardCmdJSON(t, db, []string{"update", cardIDText, "--clear-default-currency"})
+	if _, ok := mustMap(t, mustMap(t, updatePayload["data"])["card"])["default_currency_code"]; ok {
+		t.Fatalf("expected default currency cleared, got %v", updatePayload)
+	}
+
+	invalidPayload := executeCardCmdJSON(t, db, []string{"update", cardIDText, "--default-currency", "EURO"})
+	if code := mustMap(t, invalidPayload["error"])["code"]; code != "INVALID_CURRENCY_CODE" {
+		t.Fatalf("expected INVALID_CURRENCY_CODE, got %v", invalidPayload)
+	}
+}
+
+func TestCardCommandJSONFXFee(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	addPayload := executeCardCmdJSON(t, db, []string{
+		"add", "--nickname", "Travel", "--last4", "4242", "--brand", "visa",
+		"--card-type", "credit", "--due-day", "10", "--fx-fee-percent", "2.5",
+	})
+	card := mustMap(t, mustMap(t, addPayload["data"])["card"])
+	if int64(card["fx_fee_bp"].(float64)) != 250 {
+		t.Fatalf("expected fx fee 250 bp, got %v", addPayload)
+	}
+	cardIDText := strconv.FormatInt(int64(card["id"].(float64)), 10)
+
+	for _, args := range [][]string{
+		{"--amount", "40.00", "--currency", "EUR", "--date", "2026-02-03"},
+		{"--amount", "10.00", "--currency", "EUR", "--date", "2026-03-01"},
+		{"--amount", "25.00", "--currency", "USD", "--date", "2026-02-04"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{
+			"add", "--type", "expense", "--payment-method", "card", "--card-id", cardIDText,
+		}, args...)))
+	}
+
+	debtPayload := executeCardCmdJSON(t, db, []string{"debt", "show", "--card-id", cardIDText})
+	balances := map[string]int64{}
+	for _, raw := range mustAnySlice(t, mustMap(t, mustMap(t, debtPayload["data"])["debt"])["buckets"]) {
+		bucket := mustMap(t, raw)
+		balances[bucket["currency_code"].(string)] = int64(bucket["balance_minor_signed"].(float64))
+	}
+	if balances["EUR"] != 5125 || balances["USD"] != 2500 {
+		t.Fatalf("expected EUR debt to include the fee and USD debt not to, got %v", balances)
+	}
+
+	// The fee is card debt only: spending and balances stay at the charged
+	// amounts, and card fx-fees is where the fees are reported.
+	monthlyReport := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02"})
+	if ok, _ := monthlyReport["ok"].(bool); !ok {
+		t.Fatalf("expected report ok=true, got %v", monthlyReport)
+	}
+	spending := mustAnySlice(t, mustMap(t, mustMap(t, monthlyReport["data"])["spending"])["by_currency"])
+	if got := reportTotalForCurrency(t, spending, "EUR"); got != 4000 {
+		t.Fatalf("expected February EUR spending without the fee, got %d", got)
+	}
+	balancePayload := executeBalanceCmdJSON(t, db, []string{"show", "--scope", "lifetime"})
+	assertSuccessJSONEnvelope(t, balancePayload)
+	lifetime := mustAnySlice(t, mustMap(t, mustMap(t, balancePayload["data"])["lifetime"])["by_currency"])
+	if got := balanceNetForCurrency(t, lifetime, "EUR"); got != -5000 {
+		t.Fatalf("expected lifetime EUR net without the fee, got %d", got)
+	}
+
+	reportPayload := executeCardCmdJSON(t, db, []string{"fx-fees", "--from", "2026-01-01", "--to", "2026-03-31", "--card-nickname", "travel"})
+	totals := mustAnySlice(t, mustMap(t, mustMap(t, reportPayload["data"])["fx_fees"])["totals"])
+	if len(totals) != 2 {
+		t.Fatalf("expected 2 fx fee totals, got %v", reportPayload)
+	}
+	february := mustMap(t, totals[0])
+	if february["period_key"] != "2026-02" || february["currency_code"] != "EUR" || int64(february["fee_minor"].(float64)) != 100 {
+		t.Fatalf("unexpected february fx fee total %v", february)
+	}
+	if march := mustMap(t, totals[1]); march["period_key"] != "2026-03" || int64(march["fee_minor"].(float64)) != 25 {
+		t.Fatalf("unexpected march fx fee total %v", march)
+	}
+
+	updatePayload := executeCardCmdJSON(t, db, []string{"update", cardIDText, "--clear-fx-fee"})
+	if _, ok := mustMap(t, mustMap(t, updatePayload["data"])["card"])["fx_fee_bp"]; ok {
+		t.Fatalf("expected fx fee cleared, got %v", updatePayload)
+	}
+
+	invalidPayload := executeCardCmdJSON(t, db, []string{"update", cardIDText, "--fx-fee-percent", "12"})
+	if code := mustMap(t, invalidPayload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %v", invalidPayload)
+	}
+}
+
+func TestCardCommandJSONAliases(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	blueID := insertTestCard(t, db, "Amex Blue Cash", "", "1001", "AMEX", "credit", 10)
+	goldID := insertTestCard(t, db, "Amex Gold", "", "2002", "AMEX", "credit", 12)
+	blueIDText := strconv.FormatInt(blueID, 10)
+	goldIDText := strconv.FormatInt(goldID, 10)
+
+	addPayload := executeCardCmdJSON(t, db, []string{"alias", "add", blueIDText, " amex-blue "})
+	alias := mustMap(t, mustMap(t, addPayload["data"])["alias"])
+	if alias["alias"] != "amex-blue" || int64(alias["card_id"].(float64)) != blueID {
+		t.Fatalf("unexpected alias %v", addPayload)
+	}
+
+	entryPayload := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "12.00", "--currency", "USD", "--date", "2026-02-01",
+		"--payment-method", "card", "--card-lookup", "amex-blue",
+	})
+	mustEntrySuccess(t, entryPayload)
+	if cardID := mustMap(t, mustMap(t, entryPayload["data"])["entry"])["payment_card_id"]; int64(cardID.(float64)) != blueID {
+		t.Fatalf("expected lookup by alias to pick card %d, got %v", blueID, cardID)
+	}
+
+	limitPayload := executeCardCmdJSON(t, db, []string{"limit", "list", "--card-nickname", "AMEX-BLUE"})
+	if ok, _ := limitPayload["ok"].(bool); !ok {
+		t.Fatalf("expected nickname selector to match alias, got %v", limitPayload)
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list", "--card-nickname", "amex-blue"})
+	if count := mustMap(t, listPayload["data"])["count"]; int64(count.(float64)) != 1 {
+		t.Fatalf("expected entry list filter to match alias, got %v", listPayload)
+	}
+
+	for _, taken := range []string{"Amex-Blue", "amex blue cash"} {
+		conflictPayload := executeCardCmdJSON(t, db, []string{"alias", "add", goldIDText, taken})
+		conflict := mustMap(t, conflictPayload["error"])
+		if conflict["code"] != "CONFLICT" {
+			t.Fatalf("expected CONFLICT for alias %q, got %v", taken, conflictPayload)
+		}
+		candidates := mustAnySlice(t, mustMap(t, conflict["details"])["candidates"])
+		if len(candidates) != 1 {
+			t.Fatalf("expected one conflicting card for alias %q, got %v", taken, candidates)
+		}
+	}
+
+	executeCardCmdJSON(t, db, []string{"alias", "add", goldIDText, "amex"})
+	goldPayload := executeCardCmdJSON(t, db, []string{"limit", "list", "--card-lookup", "amex"})
+	if ok, _ := goldPayload["ok"].(bool); !ok {
+		t.Fatalf("expected exact alias to settle an ambiguous lookup, got %v", goldPayload)
+	}
+
+	duplicatePayload := executeCardCmdJSON(t, db, []string{"alias", "add", blueIDText, "AMEX-BLUE"})
+	if code := mustMap(t, duplicatePayload["error"])["code"]; code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT for a repeated alias, got %v", duplicatePayload)
+	}
+
+	aliasesPayload := executeCardCmdJSON(t, db, []string{"alias", "list"})
+	if count := mustMap(t, aliasesPayload["data"])["count"]; int64(count.(float64)) != 2 {
+		t.Fatalf("expected two aliases, got %v", aliasesPayload)
+	}
+
+	removePayload := executeCardCmdJSON(t, db, []string{"alias", "remove", blueIDText, "Amex-Blue"})
+	if ok, _ := removePayload["ok"].(bool); !ok {
+		t.Fatalf("expected alias remove ok=true payload=%v", removePayload)
+	}
+	missingPayload := executeCardCmdJSON(t, db, []string{"alias", "remove", blueIDText, "amex-blue"})
+	if code := mustMap(t, missingPayload["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND after removal, got %v", missingPayload)
+	}
+}
+
+func TestCardCommandJSONMonthlyLimitWarnsOnEntryAdd(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	cardID := insertTestCard(t, db, "Limit Credit", "", "2468", "VISA", "credit", 10)
+	cardIDText := strconv.FormatInt(cardID, 10)
+
+	setPayload := executeCardCmdJSON(t, db, []string{"limit", "set", "--card-id", cardIDText, "--amount", "50.00", "--currency", "USD", "--month", "2026-03"})
+	if ok, _ := setPayload["ok"].(bool); !ok {
+		t.Fatalf("expected limit set ok=true payload=%v", setPayload)
+	}
+	limit := mustMap(t, mustMap(t, setPayload["data"])["limit"])
+	if int64(limit["amount_minor"].(float64)) != 5000 || limit["month_key"] != "2026-03" {
+		t.Fatalf("unexpected limit %v", limit)
+	}
+
+	underPayload := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-03-02", "--payment-method", "card", "--card-id", cardIDText})
+	mustEntrySuccess(t, underPayload)
+	if warnings := mustAnySlice(t, underPayload["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no warnings under the limit, got %v", warnings)
+	}
+
+	overPayload := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "15.00", "--currency", "USD", "--date", "2026-03-05", "--payment-method", "card", "--card-id", cardIDText})
+	mustEntrySuccess(t, overPayload)
+	warnings := mustAnySlice(t, overPayload["warnings"])
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	warning := mustMap(t, warnings[0])
+	if warning["code"] != "CARD_LIMIT_EXCEEDED" {
+		t.Fatalf("expected CARD_LIMIT_EXCEEDED, got %v", warning["code"])
+	}
+	overspend := mustMap(t, mustMap(t, warning["details"])["overspend_amount"])
+	if int64(overspend["amount_minor"].(float64)) != 500 {
+		t.Fatalf("expected 500 overspend, got %v", overspend)
+	}
+
+	otherMonthPayload := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "90.00", "--currency", "USD", "--date", "2026-04-01", "--payment-method", "card", "--card-id", cardIDText})
+	mustEntrySuccess(t, otherMonthPayload)
+	if warnings := mustAnySlice(t, otherMonthPayload["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no warnings without a limit for the month, got %v", warnings)
+	}
+
+	showPayload := executeCardCmdJSON(t, db, []string{"limit", "show", "--card-id", cardIDText, "--month", "2026-03"})
+	if ok, _ := showPayload["ok"].(bool); !ok {
+		t.Fatalf("expected limit show ok=true payload=%v", showPayload)
+	}
+	status := mustMap(t, mustMap(t, showPayload["data"])["limit_status"])
+	if int64(status["spend_minor"].(float64)) != 5500 || status["exceeded"] != true {
+		t.Fatalf("unexpected limit status %v", status)
+	}
+
+	missingPayload := executeCardCmdJSON(t, db, []string{"limit", "show", "--card-id", cardIDText, "--month", "2026-04"})
+	if code := mustMap(t, missingPayload["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for a month without a limit, got %v", code)
+	}
+}
+
 func TestCardCommandJSONValidatesCardRules(t *testing.T) {
 	t.Parallel()
 
@@ -174,3 +648,44 @@ func executeCardCmdRaw(t *testing.T, db *sql.DB, format string, args []string) s
 
 	return strings.TrimSpace(buf.String())
 }
+
+func TestCardCommandJSONAddFromJSONInput(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	added := executeCardCmdJSON(t, db, []string{
+		"add",
+		"--nickname", "Main Credit",
+		"--last4", "1234",
+		"--brand", "VISA",
+		"--card-type", "credit",
+		"--due-day", "12",
+	})
+	card := mustMap(t, mustMap(t, added["data"])["card"])
+	card["nickname"] = "Spare Credit"
+	card["last4"] = "9876"
+	raw, err := json.Marshal(added)
+	if err != nil {
+		t.Fatalf("marshal card json: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "card.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatalf("write card json: %v", err)
+	}
+
+	copied := executeCardCmdJSON(t, db, []string{"add", "--json-input", path})
+	if ok, _ := copied["ok"].(bool); !ok {
+		t.Fatalf("expected card add from json to succeed, got %v", copied)
+	}
+	copiedCard := mustMap(t, mustMap(t, copied["data"])["card"])
+	if copiedCard["nickname"] != "Spare Credit" || copiedCard["last4"] != "9876" || copiedCard["card_type"] != "credit" || copiedCard["due_day"].(float64) != 12 {
+		t.Fatalf("unexpected card added from json: %v", copiedCard)
+	}
+
+	conflict := executeCardCmdJSON(t, db, []string{"add", "--json-input", path, "--nickname", "Other"})
+	if code := mustMap(t, conflict["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT when mixing json-input with flags, got %v", conflict)
+	}
+}
@@ -23,7 +23,11 @@ func NewCategoryCmd(opts *RootOptions) *cobra.Command {
 		newCategoryAddCmd(opts),
 		newCategoryListCmd(opts),
 		newCategoryRenameCmd(opts),
+		newCategoryUpdateCmd(opts),
+		newCategoryArchiveCmd(opts, true),
+		newCategoryArchiveCmd(opts, false),
 		newCategoryDeleteCmd(opts),
+		newCategoryBootstrapCmd(opts),
 	)
 
 	return command
@@ -50,7 +54,10 @@ func newCategoryAddCmd(opts *RootOptions) *cobra.Command {
 }
 
 func newCategoryListCmd(opts *RootOptions) *cobra.Command {
-	return &cobra.Command{
+	var includeArchived bool
+	var sortKey string
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List active categories",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -59,17 +66,24 @@ func newCategoryListCmd(opts *RootOptions) *cobra.Command {
 			}
 
 			categoryService := service.NewCategoryService(sqlitestore.NewCategoryRepo(opts.db))
-			categories, err := categoryService.List(cmd.Context())
+			categories, err := categoryService.ListSorted(cmd.Context(), sortKey)
 			if err != nil {
 				return printCategoryServiceError(cmd, opts.Output, err)
 			}
+			if !includeArchived {
+				categories = domain.UnarchivedCategories(categories)
+			}
 
-			return printCategorySuccess(cmd, opts.Output, map[string]any{
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
 				"categories": categories,
 				"count":      len(categories),
-			})
+			}, nil), categoryTables(categories))
 		},
 	}
+
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived categories")
+	cmd.Flags().StringVar(&sortKey, "sort", domain.CategorySortName, "Sort order: name|id|created")
+	return cmd
 }
 
 func newCategoryRenameCmd(opts *RootOptions) *cobra.Command {
@@ -103,6 +117,86 @@ func newCategoryRenameCmd(opts *RootOptions) *cobra.Command {
 	}
 }
 
+func newCategoryArchiveCmd(opts *RootOptions, archive bool) *cobra.Command {
+	use := "unarchive"
+	short := "Restore an archived category"
+	if archive {
+		use = "archive"
+		short = "Archive a category, hiding it from listings and new entries"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCategoryError(
+					cmd,
+					opts.Output,
+					"INVALID_ARGUMENT",
+					use+" requires exactly one argument: <id>",
+					map[string]any{"required_args": []string{"id"}},
+				)
+			}
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return printCategoryError(cmd, opts.Output, "INVALID_ARGUMENT", "id must be a positive integer", map[string]any{"field": "id", "value": args[0]})
+			}
+
+			categoryService := service.NewCategoryService(sqlitestore.NewCategoryRepo(opts.db))
+			var category domain.Category
+			if archive {
+				category, err = categoryService.Archive(cmd.Context(), id)
+			} else {
+				category, err = categoryService.Unarchive(cmd.Context(), id)
+			}
+			if err != nil {
+				return printCategoryServiceError(cmd, opts.Output, err)
+			}
+
+			return printCategorySuccess(cmd, opts.Output, map[string]any{"category": category})
+		},
+	}
+}
+
+func newCategoryUpdateCmd(opts *RootOptions) *cobra.Command {
+	var color string
+	var icon string
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Set a category's display color and icon",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCategoryError(
+					cmd,
+					opts.Output,
+					"INVALID_ARGUMENT",
+					"update requires exactly one argument: <id>",
+					map[string]any{"required_args": []string{"id"}},
+				)
+			}
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return printCategoryError(cmd, opts.Output, "INVALID_ARGUMENT", "id must be a positive integer", map[string]any{"field": "id", "value": args[0]})
+			}
+
+			categoryService := service.NewCategoryService(sqlitestore.NewCategoryRepo(opts.db))
+			category, err := categoryService.UpdateAppearance(cmd.Context(), id, appearanceUpdateFromFlags(cmd, color, icon))
+			if err != nil {
+				return printCategoryServiceError(cmd, opts.Output, err)
+			}
+
+			return printCategorySuccess(cmd, opts.Output, map[string]any{"category": category})
+		},
+	}
+
+	bindAppearanceFlags(cmd, &color, &icon)
+	return cmd
+}
+
 func newCategoryDeleteCmd(opts *RootOptions) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete <id>",
@@ -134,6 +228,44 @@ func newCategoryDeleteCmd(opts *RootOptions) *cobra.Command {
 	}
 }
 
+func newCategoryBootstrapCmd(opts *RootOptions) *cobra.Command {
+	var preset string
+
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Install a starter set of categories and labels",
+		Long: `Install a curated set of categories and labels. Names that already exist
+(case-insensitive) are skipped, so the command can be re-run safely.
+
+Presets: standard, minimal, family.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCategoryError(cmd, opts.Output, "INVALID_ARGUMENT", "bootstrap does not accept positional arguments", map[string]any{"args": args})
+			}
+
+			labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+			if err != nil {
+				return printCategoryServiceError(cmd, opts.Output, err)
+			}
+			labelService, err := service.NewLabelService(labelRepo)
+			if err != nil {
+				return printCategoryServiceError(cmd, opts.Output, err)
+			}
+
+			categoryService := service.NewCategoryService(sqlitestore.NewCategoryRepo(opts.db))
+			result, err := categoryService.Bootstrap(cmd.Context(), preset, labelService)
+			if err != nil {
+				return printCategoryServiceError(cmd, opts.Output, err)
+			}
+
+			return printCategorySuccess(cmd, opts.Output, map[string]any{"bootstrap": result})
+		},
+	}
+
+	cmd.Flags().StringVar(&preset, "preset", domain.CategoryPresetStandard, "Preset to install: "+strings.Join(domain.CategoryPresetNames, "|"))
+	return cmd
+}
+
 func printCategorySuccess(cmd *cobra.Command, format string, data any) error {
 	envelope := output.NewSuccessEnvelope(data, nil)
 	return output.Print(cmd.OutOrStdout(), format, envelope)
@@ -160,6 +292,17 @@ func printCategoryServiceError(cmd *cobra.Command, format string, err error) err
 	case errors.Is(err, domain.ErrCategoryNameConflict):
 		code = "CONFLICT"
 		message = "category name already exists"
+	case errors.Is(err, domain.ErrInvalidCategoryPreset):
+		code = "INVALID_ARGUMENT"
+		message = "preset must be one of: " + strings.Join(domain.CategoryPresetNames, "|")
+	case errors.Is(err, domain.ErrInvalidAppearanceColor),
+		errors.Is(err, domain.ErrInvalidAppearanceIcon),
+		errors.Is(err, domain.ErrNoAppearanceFields):
+		code = "INVALID_ARGUMENT"
+		message = messageFromAppearanceErr(err)
+	case errors.Is(err, domain.ErrInvalidListSort):
+		code = "INVALID_ARGUMENT"
+		message = "sort must be one of: " + strings.Join(domain.CategorySortKeys, "|")
 	}
 
 	return printCategoryError(cmd, format, code, message, details)
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type cleanupCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *cleanupCLIError) Error() string {
+	if e == nil {
+		return "cleanup command error"
+	}
+	return e.Message
+}
+
+func NewCleanupCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Find unused categories, labels and cards",
+	}
+
+	cmd.AddCommand(newCleanupSuggestCmd(opts))
+	return cmd
+}
+
+func newCleanupSuggestCmd(opts *RootOptions) *cobra.Command {
+	var unusedMonths int
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "suggest",
+		Short: "List categories, labels and cards without recent entries",
+		Long: `List active categories, labels and cards that no active entry uses, or,
+with --unused-months, that no entry has used in that many months.
+
+--apply archives the suggested categories, labels and cards in one
+transaction.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCleanupError(cmd, outputFormat(opts), &cleanupCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "cleanup suggest does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newCleanupService(opts)
+			if err != nil {
+				return printCleanupError(cmd, outputFormat(opts), err)
+			}
+
+			suggestion, err := svc.Suggest(cmd.Context(), domain.CleanupSuggestInput{
+				UnusedMonths: unusedMonths,
+				AsOf:         time.Now(),
+			})
+			if err != nil {
+				return printCleanupError(cmd, outputFormat(opts), err)
+			}
+
+			data := map[string]any{
+				"suggestions": suggestion,
+				"count":       suggestion.Count(),
+			}
+			var applied *domain.CleanupApplyResult
+			if apply {
+				var snapshot *domain.SafetySnapshot
+				if suggestion.Count() > 0 {
+					snapshot, err = takeSafetySnapshot(cmd, opts, "cleanup suggest --apply")
+					if err != nil {
+						return printCleanupError(cmd, outputFormat(opts), err)
+					}
+				}
+				result, err := svc.Apply(cmd.Context(), suggestion)
+				if err != nil {
+					return printCleanupError(cmd, outputFormat(opts), err)
+				}
+				applied = &result
+				data["applied"] = result
+				data = withSafetySnapshot(data, snapshot)
+			}
+
+			env := output.NewSuccessEnvelope(data, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, cleanupTables(suggestion, applied))
+		},
+	}
+
+	cmd.Flags().IntVar(&unusedMonths, "unused-months", 0, "Also suggest anything whose newest entry is older than this many months (0 = only never used)")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Archive the suggested categories, labels and cards")
+
+	return cmd
+}
+
+func newCleanupService(opts *RootOptions) (*service.CleanupService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &cleanupCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewCleanupService(sqlitestore.NewCleanupRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("cleanup service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printCleanupError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *cleanupCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromCleanupError(err), messageFromCleanupError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromCleanupError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCleanupUnusedMonths):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrCategoryNotFound),
+		errors.Is(err, domain.ErrLabelNotFound),
+		errors.Is(err, domain.ErrCardNotFound):
+		return "CONFLICT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromCleanupError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCleanupUnusedMonths):
+		return fmt.Sprintf("unused-months must be between 0 and %d", domain.MaxCleanupUnusedMonths)
+	case errors.Is(err, domain.ErrCategoryNotFound),
+		errors.Is(err, domain.ErrLabelNotFound),
+		errors.Is(err, domain.ErrCardNotFound):
+		return "a suggestion changed before it could be archived; nothing was archived"
+	default:
+		return "database operation failed"
+	}
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestCleanupSuggestListsAndArchivesUnused(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	groceriesID := insertTestCategory(t, db, "Groceries")
+	insertTestCategory(t, db, "Hobbies")
+	oldID := insertTestCategory(t, db, "Old Gym")
+	workID := insertTestLabel(t, db, "work")
+	insertTestLabel(t, db, "unused")
+	insertTestCard(t, db, "Spare", "", "9999", "VISA", "debit", 0)
+
+	recent := time.Now().UTC().AddDate(0, -1, 0).Format("2006-01-02")
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", recent,
+		"--category-id", strconv.FormatInt(groceriesID, 10), "--label-id", strconv.FormatInt(workID, 10),
+	}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2020-01-15",
+		"--category-id", strconv.FormatInt(oldID, 10),
+	}))
+
+	names := func(data map[string]any, group string) []string {
+		t.Helper()
+		out := []string{}
+		for _, item := range mustAnySlice(t, data[group]) {
+			out = append(out, mustMap(t, item)["name"].(string))
+		}
+		return out
+	}
+
+	data := mustMap(t, executeCleanupCmdJSON(t, db, []string{"suggest"})["data"])
+	suggestions := mustMap(t, data["suggestions"])
+	if got := names(suggestions, "categories"); len(got) != 1 || got[0] != "Hobbies" {
+		t.Fatalf("expected only Hobbies suggested, got %v", got)
+	}
+	if got := names(suggestions, "labels"); len(got) != 1 || got[0] != "unused" {
+		t.Fatalf("expected only unused label suggested, got %v", got)
+	}
+	if got := names(suggestions, "cards"); len(got) != 1 || got[0] != "Spare" {
+		t.Fatalf("expected Spare card suggested, got %v", got)
+	}
+	if _, ok := data["applied"]; ok {
+		t.Fatalf("expected no applied result without --apply, got %v", data)
+	}
+
+	data = mustMap(t, executeCleanupCmdJSON(t, db, []string{"suggest", "--unused-months", "12", "--apply"})["data"])
+	if int(data["count"].(float64)) != 4 {
+		t.Fatalf("expected 4 suggestions with --unused-months, got %v", data["count"])
+	}
+	applied := mustMap(t, data["applied"])
+	if got := names(applied, "archived_categories"); len(got) != 2 || got[0] != "Hobbies" || got[1] != "Old Gym" {
+		t.Fatalf("expected Hobbies and Old Gym archived, got %v", got)
+	}
+	if got := names(applied, "archived_cards"); len(got) != 1 || got[0] != "Spare" {
+		t.Fatalf("expected Spare archived, got %v", got)
+	}
+
+	var archived int
+	if err := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM categories WHERE archived_at_utc IS NOT NULL`).Scan(&archived); err != nil {
+		t.Fatalf("count archived categories: %v", err)
+	}
+	if archived != 2 {
+		t.Fatalf("expected 2 archived categories, got %d", archived)
+	}
+
+	data = mustMap(t, executeCleanupCmdJSON(t, db, []string{"suggest"})["data"])
+	if int(data["count"].(float64)) != 0 {
+		t.Fatalf("expected nothing left after archiving, got %v", data["count"])
+	}
+
+	payload := executeCleanupCmdJSON(t, db, []string{"suggest", "--unused-months", "-1"})
+	if code := mustMap(t, payload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for negative months, got %v", code)
+	}
+}
+
+func executeCleanupCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewCleanupCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute cleanup cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal cleanup payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type currencyAddFlags struct {
+	code         string
+	name         string
+	minorUnitRaw string
+}
+
+type currencyCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *currencyCLIError) Error() string {
+	if e == nil {
+		return "currency command error"
+	}
+	return e.Message
+}
+
+func NewCurrencyCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "currency",
+		Short: "Manage custom currency codes and their precision",
+	}
+
+	cmd.AddCommand(
+		newCurrencyAddCmd(opts),
+		newCurrencyListCmd(opts),
+		newCurrencyRemoveCmd(opts),
+	)
+
+	return cmd
+}
+
+func newCurrencyAddCmd(opts *RootOptions) *cobra.Command {
+	flags := &currencyAddFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Create or update a custom currency (e.g. BTC with 8 decimals)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCurrencyError(cmd, outputFormat(opts), &currencyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "currency add does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			minorUnit, err := parseCurrencyMinorUnit(flags.minorUnitRaw)
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			svc, err := newCurrencyService(opts)
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			currency, err := svc.Add(cmd.Context(), domain.CustomCurrencyInput{
+				Code:      flags.code,
+				Name:      flags.name,
+				MinorUnit: minorUnit,
+			})
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"currency": currency}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.code, "code", "", "Currency code (3 letters/digits, starting with a letter)")
+	cmd.Flags().StringVar(&flags.name, "name", "", "Optional display name")
+	cmd.Flags().StringVar(&flags.minorUnitRaw, "minor-unit", "", "Decimal places (0-18)")
+
+	return cmd
+}
+
+func newCurrencyListCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List custom currencies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printCurrencyError(cmd, outputFormat(opts), &currencyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "currency list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newCurrencyService(opts)
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			currencies, err := svc.List(cmd.Context())
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"currencies": currencies,
+				"count":      len(currencies),
+			}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newCurrencyRemoveCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <code>",
+		Short: "Remove a custom currency that no stored amount uses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCurrencyError(cmd, outputFormat(opts), &currencyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "remove requires exactly one argument: <code>",
+					Details: map[string]any{"required_args": []string{"code"}},
+				})
+			}
+
+			svc, err := newCurrencyService(opts)
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			result, err := svc.Remove(cmd.Context(), args[0])
+			if err != nil {
+				return printCurrencyError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"deleted": result}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func parseCurrencyMinorUnit(raw string) (int, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, &currencyCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "minor-unit is required",
+			Details: map[string]any{"field": "minor-unit"},
+		}
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &currencyCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "minor-unit must be an integer between 0 and 18",
+			Details: map[string]any{"field": "minor-unit", "value": raw},
+		}
+	}
+	return parsed, nil
+}
+
+func newCurrencyService(opts *RootOptions) (*service.CurrencyService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &currencyCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewCurrencyService(sqlitestore.NewCurrencyRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("currency service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printCurrencyError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *currencyCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromCurrencyError(err), messageFromCurrencyError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromCurrencyError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidCurrencyMinorUnit):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrCustomCurrencyNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrCurrencyCodeReserved):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrCustomCurrencyInUse):
+		return "CONFLICT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromCurrencyError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "code must be 3 letters/digits starting with a letter"
+	case errors.Is(err, domain.ErrInvalidCurrencyMinorUnit):
+		return "minor-unit must be an integer between 0 and 18"
+	case errors.Is(err, domain.ErrCustomCurrencyNotFound):
+		return "custom currency not found"
+	case errors.Is(err, domain.ErrCurrencyCodeReserved):
+		return "ISO 4217 codes cannot be registered as custom currencies"
+	case errors.Is(err, domain.ErrCustomCurrencyInUse):
+		return "custom currency has stored amounts in its current precision"
+	default:
+		return "database operation failed"
+	}
+}
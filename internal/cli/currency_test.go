@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+)
+
+func TestCurrencyAddEnablesCustomPrecisionForEntries(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() {
+		domain.SetCustomCurrencies(nil)
+		_ = db.Close()
+	})
+
+	added := executeCurrencyCmdJSON(t, db, []string{"add", "--code", "xc1", "--minor-unit", "8", "--name", "Test Coin"})
+	assertSuccessJSONEnvelope(t, added)
+	currency := mustMap(t, mustMap(t, added["data"])["currency"])
+	if currency["code"].(string) != "XC1" || currency["minor_unit"].(float64) != 8 {
+		t.Fatalf("unexpected currency payload: %v", currency)
+	}
+
+	entry := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "income",
+		"--amount", "0.00012345",
+		"--currency", "XC1",
+		"--date", "2026-02-01",
+	})
+	mustEntrySuccess(t, entry)
+	if got := mustMap(t, mustMap(t, entry["data"])["entry"])["amount_minor"].(float64); got != 12345 {
+		t.Fatalf("expected amount_minor 12345, got %v", got)
+	}
+
+	removed := executeCurrencyCmdJSON(t, db, []string{"remove", "XC1"})
+	if ok, _ := removed["ok"].(bool); ok {
+		t.Fatalf("expected in-use currency removal to fail payload=%v", removed)
+	}
+	if code := mustMap(t, removed["error"])["code"].(string); code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT, got %s", code)
+	}
+
+	listed := executeCurrencyCmdJSON(t, db, []string{"list"})
+	if count := mustMap(t, listed["data"])["count"].(float64); count != 1 {
+		t.Fatalf("expected one custom currency, got %v", count)
+	}
+}
+
+func TestCurrencyAddRejectsInvalidMinorUnit(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	payload := executeCurrencyCmdJSON(t, db, []string{"add", "--code", "PTZ", "--minor-unit", "19"})
+	if ok, _ := payload["ok"].(bool); ok {
+		t.Fatalf("expected failure payload=%v", payload)
+	}
+	if code := mustMap(t, payload["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %s", code)
+	}
+}
+
+func TestCurrencyAddRejectsISOCodes(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	payload := executeCurrencyCmdJSON(t, db, []string{"add", "--code", "usd", "--minor-unit", "0"})
+	if ok, _ := payload["ok"].(bool); ok {
+		t.Fatalf("expected failure payload=%v", payload)
+	}
+	if code := mustMap(t, payload["error"])["code"].(string); code != "INVALID_CURRENCY_CODE" {
+		t.Fatalf("expected INVALID_CURRENCY_CODE, got %s", code)
+	}
+}
+
+func TestCurrencyPrecisionLockedByStoredCapAmounts(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() {
+		domain.SetCustomCurrencies(nil)
+		_ = db.Close()
+	})
+
+	assertSuccessJSONEnvelope(t, executeCurrencyCmdJSON(t, db, []string{"add", "--code", "XBT", "--minor-unit", "8"}))
+	renamed := executeCurrencyCmdJSON(t, db, []string{"add", "--code", "XBT", "--minor-unit", "8", "--name", "Bitcoin"})
+	assertSuccessJSONEnvelope(t, renamed)
+
+	capSet := executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--amount", "0.5", "--currency", "XBT"})
+	if ok, _ := capSet["ok"].(bool); !ok {
+		t.Fatalf("expected cap set ok=true payload=%v", capSet)
+	}
+
+	for _, args := range [][]string{
+		{"add", "--code", "XBT", "--minor-unit", "2"},
+		{"remove", "XBT"},
+	} {
+		payload := executeCurrencyCmdJSON(t, db, args)
+		if ok, _ := payload["ok"].(bool); ok {
+			t.Fatalf("expected %v to fail payload=%v", args, payload)
+		}
+		if code := mustMap(t, payload["error"])["code"].(string); code != "CONFLICT" {
+			t.Fatalf("expected CONFLICT for %v, got %s", args, code)
+		}
+	}
+}
+
+func executeCurrencyCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewCurrencyCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute currency cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	raw := strings.TrimSpace(buf.String())
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal currency payload: %v raw=%s", err, raw)
+	}
+	return payload
+}
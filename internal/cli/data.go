@@ -3,8 +3,10 @@ package cli
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +24,7 @@ type dataExportFlags struct {
 	resource            string
 	from                string
 	to                  string
+	payee               string
 	reportScope         string
 	reportMonth         string
 	reportFrom          string
@@ -31,20 +34,44 @@ type dataExportFlags struct {
 	reportLabelIDRaw    []string
 	reportLabelMode     string
 	reportConvertTo     string
+	reportPayee         string
+	keys                string
+	csvShape            string
+	progress            string
+	live                bool
 }
 
 type dataImportFlags struct {
-	format     string
-	file       string
-	idempotent bool
+	resource      string
+	format        string
+	file          string
+	idempotent    bool
+	createMissing bool
+	matchBy       string
+	onConflict    string
+	source        string
+	currency      string
+	progress      string
+	reportFile    string
 }
 
 type dataBackupFlags struct {
-	file string
+	file         string
+	online       bool
+	pagesPerStep int
+	progress     string
 }
 
 type dataRestoreFlags struct {
-	file string
+	file       string
+	fromBackup string
+	only       string
+	diff       bool
+}
+
+type dataMirrorFlags struct {
+	dir        string
+	idempotent bool
 }
 
 func NewDataCmd(opts *RootOptions) *cobra.Command {
@@ -58,6 +85,8 @@ func NewDataCmd(opts *RootOptions) *cobra.Command {
 		newDataImportCmd(opts),
 		newDataBackupCmd(opts),
 		newDataRestoreCmd(opts),
+		newDataMirrorCmd(opts),
+		newDataMaintainCmd(opts),
 	)
 
 	return cmd
@@ -81,24 +110,93 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 				})
 			}
 
-			portabilitySvc, err := newPortabilityService(opts)
+			progressMode, err := domain.NormalizeProgressMode(flags.progress)
 			if err != nil {
-				return printReportError(cmd, reportOutputFormat(opts), err)
+				return printReportError(cmd, reportOutputFormat(opts), progressModeFlagError(flags.progress))
+			}
+
+			// With --file - the export owns stdout, so the envelope moves to stderr.
+			exportOut := cmd.OutOrStdout()
+			if flags.file == service.PortabilityStdioPath {
+				cmd.SetOut(cmd.ErrOrStderr())
 			}
 
 			resource := normalizeDataExportResource(flags.resource)
 			if resource == "" {
 				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
 					Code:    "INVALID_ARGUMENT",
-					Message: "resource must be one of: entries|report",
+					Message: "resource must be one of: entries|report|flows|card-events|audit|all",
 					Details: map[string]any{"field": "resource", "value": flags.resource},
 				})
 			}
+			if (resource == dataExportResourceAll || resource == dataExportResourceFlows || resource == dataExportResourceAudit) && normalizeDataFormat(flags.format) != service.PortabilityFormatJSON {
+				return printReportError(cmd, reportOutputFormat(opts), dataJSONOnlyFormatError(resource, flags.format))
+			}
+
+			keyMode, err := service.NormalizePortabilityKeyMode(flags.keys)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "keys must be one of: id|natural",
+					Details: map[string]any{"field": "keys", "value": flags.keys},
+				})
+			}
+			if resource != dataExportResourceEntries && cmd.Flags().Changed("keys") {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "keys applies only to --resource entries",
+					Details: map[string]any{"field": "keys", "resource": resource},
+				})
+			}
+
+			csvShape, err := service.NormalizePortabilityCSVShape(flags.csvShape)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "csv-shape must be one of: wide|tidy",
+					Details: map[string]any{"field": "csv-shape", "value": flags.csvShape},
+				})
+			}
+			if cmd.Flags().Changed("csv-shape") && (resource != dataExportResourceReport || normalizeDataFormat(flags.format) != service.PortabilityFormatCSV) {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "csv-shape applies only to --resource report with --format csv",
+					Details: map[string]any{"field": "csv-shape", "resource": resource, "format": flags.format},
+				})
+			}
+
+			// Exports read a point-in-time copy, so writes from other processes
+			// cannot land between the queries of one export.
+			exportOpts := opts
+			if !flags.live && opts != nil && opts.db != nil {
+				snapshotDB, closeSnapshot, err := sqlitestore.OpenSnapshot(cmd.Context(), opts.db)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+				defer func() {
+					if err := closeSnapshot(); err != nil {
+						slog.Debug("export snapshot cleanup failed", "error", err)
+					}
+				}()
+				snapshotOpts := *opts
+				snapshotOpts.db = snapshotDB
+				exportOpts = &snapshotOpts
+			}
+
+			serviceOptions := []service.PortabilityServiceOption{service.WithPortabilityStdio(nil, exportOut)}
+			progress := newProgressReporter(cmd, opts, progressMode)
+			if progress != nil {
+				serviceOptions = append(serviceOptions, service.WithPortabilityProgress(progress.Report))
+			}
+			portabilitySvc, err := newPortabilityService(exportOpts, serviceOptions...)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
 
 			var data map[string]any
 			var warnings []output.WarningPayload
 			switch resource {
-			case dataExportResourceEntries:
+			case dataExportResourceEntries, dataExportResourceAll:
 				fromUTC, err := normalizeListDateBound(flags.from, false)
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "from must be RFC3339 or YYYY-MM-DD", Details: map[string]any{"field": "from", "value": flags.from}})
@@ -111,7 +209,24 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
 
-				count, err := portabilitySvc.Export(cmd.Context(), flags.format, flags.file, domain.EntryListFilter{DateFromUTC: fromUTC, DateToUTC: toUTC})
+				filter := domain.EntryListFilter{DateFromUTC: fromUTC, DateToUTC: toUTC, Payee: strings.TrimSpace(flags.payee)}
+				if resource == dataExportResourceAll {
+					result, err := portabilitySvc.ExportDataset(cmd.Context(), flags.file, filter)
+					if err != nil {
+						return printReportError(cmd, reportOutputFormat(opts), err)
+					}
+					data = map[string]any{
+						"resource": resource,
+						"exported": result,
+						"format":   service.PortabilityFormatJSON,
+						"file":     flags.file,
+						"keys":     service.PortabilityKeyModeNatural,
+					}
+					break
+				}
+
+				count, err := portabilitySvc.ExportWithKeys(cmd.Context(), flags.format, flags.file, keyMode, filter)
+				progress.Finish()
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
@@ -121,6 +236,7 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 					"exported": count,
 					"format":   strings.ToLower(flags.format),
 					"file":     flags.file,
+					"keys":     keyMode,
 				}
 			case dataExportResourceReport:
 				reportReq, err := buildDataExportReportRequest(flags)
@@ -128,7 +244,7 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
 
-				result, err := portabilitySvc.ExportReport(cmd.Context(), flags.format, flags.file, reportReq)
+				result, err := portabilitySvc.ExportReportWithShape(cmd.Context(), flags.format, csvShape, flags.file, reportReq)
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
@@ -149,6 +265,49 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
+			case dataExportResourceFlows:
+				reportReq, err := buildDataExportReportRequest(flags)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				graph, err := portabilitySvc.ExportFlows(cmd.Context(), flags.format, flags.file, reportReq)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				data = map[string]any{
+					"resource": resource,
+					"format":   service.PortabilityFormatJSON,
+					"file":     flags.file,
+					"period":   graph.Period,
+					"nodes":    len(graph.Nodes),
+					"links":    len(graph.Links),
+				}
+			case dataExportResourceCardEvents:
+				count, err := portabilitySvc.ExportCardEvents(cmd.Context(), flags.format, flags.file)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				data = map[string]any{
+					"resource": resource,
+					"exported": count,
+					"format":   strings.ToLower(flags.format),
+					"file":     flags.file,
+				}
+			case dataExportResourceAudit:
+				count, err := portabilitySvc.ExportAudit(cmd.Context(), flags.file)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				data = map[string]any{
+					"resource": resource,
+					"exported": count,
+					"format":   service.PortabilityFormatJSON,
+					"file":     flags.file,
+				}
 			}
 
 			env := output.NewSuccessEnvelope(data, warnings)
@@ -156,20 +315,26 @@ func newDataExportCmd(opts *RootOptions) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&flags.resource, "resource", dataExportResourceEntries, "Export resource: entries|report")
+	cmd.Flags().StringVar(&flags.resource, "resource", dataExportResourceEntries, "Export resource: entries|report|flows|card-events|all (flows writes a Sankey node/link graph for the --report-* period; card-events writes card payments and adjustments keyed by card nickname; audit writes the change history with before/after snapshots and a hash chain; all writes one JSON archive with reference data, settings, and entries)")
 	cmd.Flags().StringVar(&flags.format, "format", "", "Export format: json|csv")
-	cmd.Flags().StringVar(&flags.file, "file", "", "Output file path")
+	cmd.Flags().StringVar(&flags.file, "file", "", "Output file path, or - for stdout (the envelope is then written to stderr)")
 	cmd.Flags().StringVar(&flags.from, "from", "", "Optional filter start date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.to, "to", "", "Optional filter end date (RFC3339 or YYYY-MM-DD)")
-	cmd.Flags().StringVar(&flags.reportScope, "report-scope", "", "Report scope for --resource report: range|monthly|bimonthly|quarterly")
+	cmd.Flags().StringVar(&flags.payee, "payee", "", "Optional filter by exact payee (case-insensitive)")
+	cmd.Flags().StringVar(&flags.reportScope, "report-scope", "", "Report scope for --resource report|flows: range|monthly|bimonthly|quarterly")
 	cmd.Flags().StringVar(&flags.reportMonth, "report-month", "", "Report month in YYYY-MM for preset scopes")
 	cmd.Flags().StringVar(&flags.reportFrom, "report-from", "", "Report start date for range scope (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.reportTo, "report-to", "", "Report end date for range scope (RFC3339 or YYYY-MM-DD)")
-	cmd.Flags().StringVar(&flags.reportGroupBy, "report-group-by", reportGroupByMonth, "Report grouping: day|week|month")
+	cmd.Flags().StringVar(&flags.reportGroupBy, "report-group-by", reportGroupByMonth, "Report grouping: day|week|month|weekday|day-of-month")
 	cmd.Flags().StringVar(&flags.reportCategoryIDRaw, "report-category-id", "", "Optional report category filter")
 	cmd.Flags().StringArrayVar(&flags.reportLabelIDRaw, "report-label-id", nil, "Optional report label filter (repeatable)")
 	cmd.Flags().StringVar(&flags.reportLabelMode, "report-label-mode", domain.LabelFilterModeAny, "Report label filter mode: any|all|none")
 	cmd.Flags().StringVar(&flags.reportConvertTo, "report-convert-to", "", "Optional report target currency (ISO code)")
+	cmd.Flags().StringVar(&flags.reportPayee, "report-payee", "", "Optional report payee filter")
+	cmd.Flags().StringVar(&flags.keys, "keys", service.PortabilityKeyModeID, "Entry key mode: id (numeric IDs) or natural (category/label names, card nicknames, fingerprints)")
+	cmd.Flags().StringVar(&flags.csvShape, "csv-shape", service.PortabilityCSVShapeWide, "Report CSV layout: wide (one row per record) or tidy (section,dimension,key,currency,amount_major per measure)")
+	cmd.Flags().StringVar(&flags.progress, "progress", domain.ProgressModeAuto, progressFlagUsage+" (entries exports only)")
+	cmd.Flags().BoolVar(&flags.live, "live", false, "Read the live database instead of a point-in-time copy (skips copying large databases; writes made meanwhile may show up part-way)")
 
 	return cmd
 }
@@ -179,11 +344,69 @@ func newDataImportCmd(opts *RootOptions) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "import",
-		Short: "Import entries from JSON or CSV",
+		Short: "Import entries from JSON or CSV, or a full-dataset archive",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
 				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data import", args))
 			}
+			matchBy, err := service.NormalizePortabilityMatchBy(flags.matchBy)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "match-by must be one of: signature|uid",
+					Details: map[string]any{"field": "match-by", "value": flags.matchBy},
+				})
+			}
+			onConflict, err := service.NormalizePortabilityOnConflict(flags.onConflict)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "on-conflict must be one of: skip|update|duplicate",
+					Details: map[string]any{"field": "on-conflict", "value": flags.onConflict},
+				})
+			}
+			if cmd.Flags().Changed("match-by") && !flags.idempotent && onConflict == "" {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "match-by requires --idempotent or --on-conflict",
+					Details: map[string]any{"field": "match-by", "required_flags": []string{"idempotent", "on-conflict"}},
+				})
+			}
+			if onConflict != "" && normalizeDataExportResource(flags.resource) != dataExportResourceEntries {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "on-conflict applies only to --resource entries",
+					Details: map[string]any{"field": "on-conflict", "resource": flags.resource},
+				})
+			}
+			if cmd.Flags().Changed("match-by") && (strings.TrimSpace(flags.source) != "" || normalizeDataExportResource(flags.resource) != dataExportResourceEntries) {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "match-by applies only to --resource entries imports without --source",
+					Details: map[string]any{"field": "match-by", "resource": flags.resource, "source": flags.source},
+				})
+			}
+			if strings.TrimSpace(flags.reportFile) != "" && (strings.TrimSpace(flags.source) != "" || normalizeDataExportResource(flags.resource) != dataExportResourceEntries) {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report-file applies only to --resource entries imports without --source",
+					Details: map[string]any{"field": "report-file", "resource": flags.resource, "source": flags.source},
+				})
+			}
+			progressMode, err := domain.NormalizeProgressMode(flags.progress)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), progressModeFlagError(flags.progress))
+			}
+			if strings.TrimSpace(flags.source) != "" {
+				return runDataImportSource(cmd, opts, flags)
+			}
+			if cmd.Flags().Changed("currency") {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "currency applies only to --source imports",
+					Details: map[string]any{"field": "currency"},
+				})
+			}
 			if strings.TrimSpace(flags.format) == "" || strings.TrimSpace(flags.file) == "" {
 				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
 					Code:    "INVALID_ARGUMENT",
@@ -192,43 +415,272 @@ func newDataImportCmd(opts *RootOptions) *cobra.Command {
 				})
 			}
 
-			portabilitySvc, err := newPortabilityService(opts)
+			resource := normalizeDataExportResource(flags.resource)
+			if resource != dataExportResourceEntries && resource != dataExportResourceCardEvents && resource != dataExportResourceAudit && resource != dataExportResourceAll {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "resource must be one of: entries|card-events|audit|all",
+					Details: map[string]any{"field": "resource", "value": flags.resource},
+				})
+			}
+			if (resource == dataExportResourceAll || resource == dataExportResourceAudit) && normalizeDataFormat(flags.format) != service.PortabilityFormatJSON {
+				return printReportError(cmd, reportOutputFormat(opts), dataJSONOnlyFormatError(resource, flags.format))
+			}
+			if resource != dataExportResourceEntries && flags.createMissing {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "create-missing applies only to --resource entries",
+					Details: map[string]any{"field": "create-missing", "resource": resource},
+				})
+			}
+
+			snapshot, err := takeSafetySnapshot(cmd, opts, "data import")
 			if err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
 			}
 
-			result, err := portabilitySvc.Import(cmd.Context(), flags.format, flags.file, flags.idempotent)
+			serviceOptions := []service.PortabilityServiceOption{service.WithPortabilityStdio(cmd.InOrStdin(), nil)}
+			progress := newProgressReporter(cmd, opts, progressMode)
+			if progress != nil {
+				serviceOptions = append(serviceOptions, service.WithPortabilityProgress(progress.Report))
+			}
+			portabilitySvc, err := newPortabilityService(opts, serviceOptions...)
 			if err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
 			}
 
-			env := output.NewSuccessEnvelope(
-				map[string]any{
-					"imported":   result.Imported,
-					"skipped":    result.Skipped,
+			if resource == dataExportResourceCardEvents {
+				restored, err := portabilitySvc.ImportCardEvents(cmd.Context(), flags.format, flags.file, flags.idempotent)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				env := output.NewSuccessEnvelope(withSafetySnapshot(map[string]any{
+					"resource":   resource,
+					"imported":   restored.LiabilityEventsCreated,
+					"skipped":    restored.LiabilityEventsSkipped,
 					"format":     strings.ToLower(flags.format),
 					"file":       flags.file,
 					"idempotent": flags.idempotent,
-				},
-				toOutputWarnings(result.Warnings),
-			)
+				}, snapshot), nil)
+				return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+			}
+
+			if resource == dataExportResourceAudit {
+				result, err := portabilitySvc.ImportAudit(cmd.Context(), flags.file)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				env := output.NewSuccessEnvelope(withSafetySnapshot(map[string]any{
+					"resource": resource,
+					"imported": result.Imported,
+					"skipped":  result.Skipped,
+					"format":   service.PortabilityFormatJSON,
+					"file":     flags.file,
+				}, snapshot), nil)
+				return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+			}
+
+			if resource == dataExportResourceAll {
+				result, err := portabilitySvc.ImportDataset(cmd.Context(), flags.file, flags.idempotent)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				env := output.NewSuccessEnvelope(
+					withSafetySnapshot(map[string]any{
+						"resource":   resource,
+						"imported":   result.Imported,
+						"skipped":    result.Skipped,
+						"restored":   result.Restored,
+						"format":     service.PortabilityFormatJSON,
+						"file":       flags.file,
+						"idempotent": flags.idempotent,
+					}, snapshot),
+					toOutputWarnings(result.Warnings),
+				)
+				return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+			}
+
+			// The report file is created up front so an unwritable path fails
+			// before anything is imported.
+			var reportFile *os.File
+			if flags.reportFile != "" {
+				reportFile, err = createDataImportReportFile(flags.reportFile)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+				defer reportFile.Close()
+			}
+
+			result, created, err := portabilitySvc.ImportWithOptions(cmd.Context(), flags.format, flags.file, service.PortabilityImportOptions{
+				Idempotent:    flags.idempotent,
+				CreateMissing: flags.createMissing,
+				MatchBy:       matchBy,
+				OnConflict:    onConflict,
+				RecordRows:    flags.reportFile != "",
+			})
+			progress.Finish()
+			if err != nil {
+				if reportFile != nil {
+					_ = reportFile.Close()
+					_ = os.Remove(flags.reportFile)
+				}
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+			if reportFile != nil {
+				if err := writeDataImportReport(reportFile, flags.file, result.Rows); err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+			}
+
+			data := map[string]any{
+				"imported":   result.Imported,
+				"updated":    result.Updated,
+				"skipped":    result.Skipped,
+				"duplicated": result.Duplicated,
+				"format":     strings.ToLower(flags.format),
+				"file":       flags.file,
+				"idempotent": flags.idempotent,
+			}
+			if flags.idempotent || onConflict != "" {
+				data["match_by"] = matchBy
+			}
+			if onConflict != "" {
+				data["on_conflict"] = onConflict
+			}
+			if flags.createMissing {
+				data["created_categories"] = created.CreatedCategories
+				data["created_labels"] = created.CreatedLabels
+			}
+			if flags.reportFile != "" {
+				data["report_file"] = flags.reportFile
+			}
+			env := output.NewSuccessEnvelope(withSafetySnapshot(data, snapshot), toOutputWarnings(result.Warnings))
 			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
 		},
 	}
 
+	cmd.Flags().StringVar(&flags.resource, "resource", dataExportResourceEntries, "Import resource: entries|card-events|audit|all (card-events adds payments and adjustments to cards matched by nickname; audit appends missing events from a verified audit export; all reads a full-dataset archive from data export --resource all)")
 	cmd.Flags().StringVar(&flags.format, "format", "", "Import format: json|csv")
-	cmd.Flags().StringVar(&flags.file, "file", "", "Input file path")
-	cmd.Flags().BoolVar(&flags.idempotent, "idempotent", false, "Skip records matching existing entry fingerprints")
+	cmd.Flags().StringVar(&flags.file, "file", "", "Input file path, or - for stdin")
+	cmd.Flags().BoolVar(&flags.idempotent, "idempotent", false, "Skip records matching existing entry fingerprints (or identical card events)")
+	cmd.Flags().BoolVar(&flags.createMissing, "create-missing", false, "Create categories and labels named by category_name/label_names (or category/labels) that do not exist yet")
+	cmd.Flags().StringVar(&flags.matchBy, "match-by", service.PortabilityMatchBySignature, "How --idempotent and --on-conflict match records: signature (identical entries) or uid (the entry with the record's uid; records without one fall back to signature)")
+	cmd.Flags().StringVar(&flags.onConflict, "on-conflict", "", "What to do with records matching an existing entry: skip|update|duplicate (implies matching; without it --idempotent updates uid matches and skips signature matches)")
+	cmd.Flags().StringVar(&flags.source, "source", "", "Read another app's CSV export instead: ynab|mint|gnucash (unmatched categories, labels and accounts are listed under unmapped)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Currency for --source ynab|mint rows (defaults to the configured default currency)")
+	cmd.Flags().StringVar(&flags.progress, "progress", domain.ProgressModeAuto, progressFlagUsage+" (entries imports only)")
+	cmd.Flags().StringVar(&flags.reportFile, "report-file", "", "Write every record's outcome (row, outcome, entry_id, skip reason, warnings) as JSON to this path (--resource entries only)")
 
 	return cmd
 }
 
+func createDataImportReportFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
+// writeDataImportReport writes the per-record outcomes of an entries import,
+// with the input file they came from, and closes file.
+func writeDataImportReport(file *os.File, inputFile string, rows []service.PortabilityImportRow) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]any{"file": inputFile, "rows": rows}); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// runDataImportSource imports a YNAB, Mint or GnuCash CSV export as entries.
+func runDataImportSource(cmd *cobra.Command, opts *RootOptions, flags *dataImportFlags) error {
+	if strings.TrimSpace(flags.file) == "" {
+		return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "file is required",
+			Details: map[string]any{"required_flags": []string{"file"}},
+		})
+	}
+	if normalizeDataExportResource(flags.resource) != dataExportResourceEntries {
+		return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "source applies only to --resource entries",
+			Details: map[string]any{"field": "source", "resource": flags.resource},
+		})
+	}
+	if format := normalizeDataFormat(flags.format); format != "" && format != service.PortabilityFormatCSV {
+		return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "source imports support only --format csv",
+			Details: map[string]any{"field": "format", "value": flags.format},
+		})
+	}
+	source, err := domain.NormalizeImportSource(flags.source)
+	if err != nil {
+		return printReportError(cmd, reportOutputFormat(opts), err)
+	}
+
+	currencyCode := flags.currency
+	if strings.TrimSpace(currencyCode) == "" && source != domain.ImportSourceGnuCash {
+		currencyCode = defaultCurrency(opts)
+	}
+
+	snapshot, err := takeSafetySnapshot(cmd, opts, "data import")
+	if err != nil {
+		return printReportError(cmd, reportOutputFormat(opts), err)
+	}
+
+	portabilitySvc, err := newPortabilityService(opts, service.WithPortabilityStdio(cmd.InOrStdin(), nil))
+	if err != nil {
+		return printReportError(cmd, reportOutputFormat(opts), err)
+	}
+
+	result, err := portabilitySvc.ImportSource(cmd.Context(), source, flags.file, service.PortabilitySourceImportOptions{
+		Idempotent:    flags.idempotent,
+		CreateMissing: flags.createMissing,
+		OnConflict:    strings.ToLower(strings.TrimSpace(flags.onConflict)),
+		CurrencyCode:  currencyCode,
+	})
+	if err != nil {
+		return printReportError(cmd, reportOutputFormat(opts), err)
+	}
+
+	data := map[string]any{
+		"source":         source,
+		"imported":       result.Imported,
+		"updated":        result.Updated,
+		"skipped":        result.Skipped,
+		"duplicated":     result.Duplicated,
+		"source_skipped": result.SourceSkipped,
+		"unmapped":       result.Unmapped,
+		"format":         service.PortabilityFormatCSV,
+		"file":           flags.file,
+		"idempotent":     flags.idempotent,
+	}
+	if flags.createMissing {
+		data["created_categories"] = result.CreatedCategories
+		data["created_labels"] = result.CreatedLabels
+	}
+	env := output.NewSuccessEnvelope(withSafetySnapshot(data, snapshot), toOutputWarnings(result.Warnings))
+	return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+}
+
 func newDataBackupCmd(opts *RootOptions) *cobra.Command {
 	flags := &dataBackupFlags{}
 
 	cmd := &cobra.Command{
 		Use:   "backup",
 		Short: "Create a SQLite backup file",
+		Long: `Create a SQLite backup file.
+
+By default the backup is written with VACUUM INTO, which produces a compact
+file but holds a read transaction for the whole copy. With --online the SQLite
+backup API copies --pages-per-step pages at a time instead, so other processes
+can keep using the database in WAL mode, and --progress reports pages copied
+on stderr.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
 				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data backup", args))
@@ -236,22 +688,73 @@ func newDataBackupCmd(opts *RootOptions) *cobra.Command {
 			if strings.TrimSpace(flags.file) == "" {
 				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "file is required", Details: map[string]any{"field": "file"}})
 			}
+			progressMode, err := domain.NormalizeProgressMode(flags.progress)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), progressModeFlagError(flags.progress))
+			}
+			if cmd.Flags().Changed("pages-per-step") && (!flags.online || flags.pagesPerStep <= 0) {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "pages-per-step must be a positive integer and requires --online", Details: map[string]any{"field": "pages-per-step"}})
+			}
 
 			portabilitySvc, err := newPortabilityService(opts)
 			if err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
 			}
 
-			if err := portabilitySvc.Backup(cmd.Context(), flags.file); err != nil {
+			backupOptions := service.PortabilityBackupOptions{Online: flags.online, PagesPerStep: flags.pagesPerStep}
+			progress := newProgressReporter(cmd, opts, progressMode)
+			if flags.online && progress != nil {
+				backupOptions.Progress = progress.ReportBackup
+			}
+			result, err := portabilitySvc.BackupWithOptions(cmd.Context(), flags.file, backupOptions)
+			progress.Finish()
+			if err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
 			}
 
-			env := output.NewSuccessEnvelope(map[string]any{"backup_file": flags.file}, nil)
+			env := output.NewSuccessEnvelope(result, nil)
 			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
 		},
 	}
 
 	cmd.Flags().StringVar(&flags.file, "file", "", "Backup output file path")
+	cmd.Flags().BoolVar(&flags.online, "online", false, "Copy with the SQLite online backup API instead of VACUUM INTO")
+	cmd.Flags().IntVar(&flags.pagesPerStep, "pages-per-step", domain.DefaultBackupPagesPerStep, "Pages copied per online backup step")
+	cmd.Flags().StringVar(&flags.progress, "progress", domain.ProgressModeAuto, progressFlagUsage+" (--online backups only)")
+	return cmd
+}
+
+func newDataMaintainCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintain",
+		Short: "Compact the database and refresh query statistics",
+		Long: `Compact the database and refresh query statistics.
+
+Runs VACUUM (dropping free pages left by deleted rows), ANALYZE and a
+truncating WAL checkpoint, then reports the database size before and after
+and the row count of every table, with soft-deleted rows counted separately.
+VACUUM rewrites the whole file and needs as much free disk space again.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data maintain", args))
+			}
+			if opts == nil || opts.db == nil {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "DB_ERROR",
+					Message: "database operation failed",
+					Details: map[string]any{"reason": "database connection unavailable"},
+				})
+			}
+
+			report, err := sqlitestore.Maintain(cmd.Context(), opts.db)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"maintenance": report}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), reportOutputFormat(opts), env, maintenanceTables(report))
+		},
+	}
 	return cmd
 }
 
@@ -261,35 +764,197 @@ func newDataRestoreCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore",
 		Short: "Restore SQLite DB from a backup file",
+		Long: `Restore SQLite DB from a backup file.
+
+Without --only the whole database file is replaced by the backup. With
+--only the backup is attached and just the listed table groups are copied
+into the live database in one transaction, leaving everything else as it is:
+
+  data restore --only caps --from-backup backup.sqlite
+
+Groups: entries (with loan payment links, card liability events and
+settlements), categories, labels, cards, currencies, caps, loans, settings. The restore is rolled back if the copied
+rows would reference rows that only exist in the other database; restore the
+related groups together.
+
+--diff changes nothing: it opens the backup read-only, checks its integrity
+and reports what a full restore would change (active entries per month,
+cards added or removed, settings that differ).`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
 				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data restore", args))
 			}
-			if strings.TrimSpace(flags.file) == "" {
+			file := strings.TrimSpace(flags.file)
+			fromBackup := strings.TrimSpace(flags.fromBackup)
+			if file != "" && fromBackup != "" && file != fromBackup {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "file and from-backup name different backups; pass one of them", Details: map[string]any{"fields": []string{"file", "from-backup"}}})
+			}
+			if file == "" {
+				file = fromBackup
+			}
+			if file == "" {
 				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "file is required", Details: map[string]any{"field": "file"}})
 			}
 
-			if err := restoreDatabase(cmd.Context(), opts, flags.file); err != nil {
+			if flags.diff {
+				if cmd.Flags().Changed("only") {
+					return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "diff previews a full restore and cannot be combined with only", Details: map[string]any{"fields": []string{"diff", "only"}}})
+				}
+				diff, err := diffRestore(cmd.Context(), opts, file)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				env := output.NewSuccessEnvelope(map[string]any{"backup_file": file, "db_path": opts.DBPath, "diff": diff}, nil)
+				return output.PrintTables(cmd.OutOrStdout(), reportOutputFormat(opts), env, restoreDiffTables(diff))
+			}
+
+			if cmd.Flags().Changed("only") {
+				groups, err := domain.ParseRestoreGroups(flags.only)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+				if opts == nil || opts.db == nil {
+					return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "DB_ERROR", Message: "database operation failed", Details: map[string]any{"reason": "database connection unavailable"}})
+				}
+
+				snapshot, err := takeSafetySnapshot(cmd, opts, "data restore")
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+				report, err := sqlitestore.RestoreTables(cmd.Context(), opts.db, file, groups)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+
+				env := output.NewSuccessEnvelope(withSafetySnapshot(map[string]any{"restored_from": file, "db_path": opts.DBPath, "only": report.Groups, "tables": report.Tables}, snapshot), nil)
+				return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+			}
+
+			if sqlitestore.IsMemoryPath(opts.DBPath) {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "data restore needs a database file; use data import or --only with an in-memory database", Details: map[string]any{"field": "db-path", "value": opts.DBPath}})
+			}
+
+			snapshot, err := takeSafetySnapshot(cmd, opts, "data restore")
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+			if err := restoreDatabase(cmd.Context(), opts, file); err != nil {
 				return printReportError(cmd, reportOutputFormat(opts), err)
 			}
 
-			env := output.NewSuccessEnvelope(map[string]any{"restored_from": flags.file, "db_path": opts.DBPath}, nil)
+			env := output.NewSuccessEnvelope(withSafetySnapshot(map[string]any{"restored_from": file, "db_path": opts.DBPath}, snapshot), nil)
 			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
 		},
 	}
 
 	cmd.Flags().StringVar(&flags.file, "file", "", "Backup file path to restore from")
+	cmd.Flags().StringVar(&flags.fromBackup, "from-backup", "", "Backup file path to restore from (same as --file)")
+	cmd.Flags().BoolVar(&flags.diff, "diff", false, "Report what a full restore would change without restoring")
+	cmd.Flags().StringVar(&flags.only, "only", "", "Comma-separated table groups to restore instead of the whole file (entries,categories,labels,cards,currencies,caps,loans,settings)")
 	return cmd
 }
 
-func newPortabilityService(opts *RootOptions) (*service.PortabilityService, error) {
+func newDataMirrorCmd(opts *RootOptions) *cobra.Command {
+	flags := &dataMirrorFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Write one natural-key JSON file per month into a directory for version control",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data mirror", args))
+			}
+			if strings.TrimSpace(flags.dir) == "" {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "dir is required", Details: map[string]any{"field": "dir"}})
+			}
+
+			operationSvc, err := newOperationService(opts)
+			if err != nil {
+				return printOpsError(cmd, reportOutputFormat(opts), err)
+			}
+			run, err := operationSvc.Start(cmd.Context(), domain.OperationKindDataMirror, dataMirrorOperationParams{Dir: flags.dir})
+			if err != nil {
+				return printOpsError(cmd, reportOutputFormat(opts), err)
+			}
+
+			result, err := runDataMirrorOperation(cmd.Context(), opts, run)
+			if err != nil {
+				return printOpsError(cmd, reportOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(result, nil)
+			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.dir, "dir", "", "Mirror directory (month files are written as <YYYY>/<YYYY-MM>.json)")
+	cmd.AddCommand(newDataMirrorImportCmd(opts))
+	return cmd
+}
+
+func newDataMirrorImportCmd(opts *RootOptions) *cobra.Command {
+	flags := &dataMirrorFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Rebuild entries from a mirror directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printReportError(cmd, reportOutputFormat(opts), invalidArgsError("data mirror import", args))
+			}
+			if strings.TrimSpace(flags.dir) == "" {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{Code: "INVALID_ARGUMENT", Message: "dir is required", Details: map[string]any{"field": "dir"}})
+			}
+
+			snapshot, err := takeSafetySnapshot(cmd, opts, "data mirror import")
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			portabilitySvc, err := newPortabilityService(opts)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			result, err := portabilitySvc.MirrorImport(cmd.Context(), flags.dir, flags.idempotent)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(
+				withSafetySnapshot(map[string]any{
+					"dir":                result.Dir,
+					"files":              result.Files,
+					"imported":           result.Imported,
+					"skipped":            result.Skipped,
+					"idempotent":         flags.idempotent,
+					"created_categories": result.CreatedCategories,
+					"created_labels":     result.CreatedLabels,
+				}, snapshot),
+				toOutputWarnings(result.Warnings),
+			)
+			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.dir, "dir", "", "Mirror directory to import from")
+	cmd.Flags().BoolVar(&flags.idempotent, "idempotent", false, "Skip records matching existing entry fingerprints")
+	return cmd
+}
+
+func newPortabilityService(opts *RootOptions, portabilityOpts ...service.PortabilityServiceOption) (*service.PortabilityService, error) {
 	if opts == nil || opts.db == nil {
 		return nil, &reportCLIError{Code: "DB_ERROR", Message: "database operation failed", Details: map[string]any{"reason": "database connection unavailable"}}
 	}
 
 	entryRepo := sqlitestore.NewEntryRepo(opts.db)
 	capRepo := sqlitestore.NewCapRepo(opts.db)
-	entrySvc, err := service.NewEntryService(entryRepo, service.WithEntryCapLookup(capRepo))
+	entrySvc, err := service.NewEntryService(
+		entryRepo,
+		service.WithEntryCapLookup(capRepo),
+		service.WithEntryStrictWarnings(opts.db, opts.strictWarnings),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("entry service init: %w", err)
 	}
@@ -299,7 +964,19 @@ func newPortabilityService(opts *RootOptions) (*service.PortabilityService, erro
 		return nil, fmt.Errorf("report service init: %w", err)
 	}
 
-	portabilitySvc, err := service.NewPortabilityService(entrySvc, opts.db, service.WithPortabilityReportService(reportSvc))
+	labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+
+	portabilityOpts = append([]service.PortabilityServiceOption{
+		service.WithPortabilityReportService(reportSvc),
+		service.WithPortabilityNaturalKeys(sqlitestore.NewCategoryRepo(opts.db), labelRepo, sqlitestore.NewCardRepo(opts.db)),
+		service.WithPortabilityDataset(sqlitestore.NewDatasetRepo(opts.db)),
+		service.WithPortabilityAudit(sqlitestore.NewAuditEventRepo(opts.db)),
+		service.WithPortabilityOnlineBackup(sqlitestore.NewBackupRepo(opts.db)),
+	}, portabilityOpts...)
+	portabilitySvc, err := service.NewPortabilityService(entrySvc, opts.db, portabilityOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("portability service init: %w", err)
 	}
@@ -308,8 +985,12 @@ func newPortabilityService(opts *RootOptions) (*service.PortabilityService, erro
 }
 
 const (
-	dataExportResourceEntries = "entries"
-	dataExportResourceReport  = "report"
+	dataExportResourceEntries    = "entries"
+	dataExportResourceReport     = "report"
+	dataExportResourceFlows      = "flows"
+	dataExportResourceCardEvents = "card-events"
+	dataExportResourceAudit      = "audit"
+	dataExportResourceAll        = "all"
 )
 
 func normalizeDataExportResource(raw string) string {
@@ -318,11 +999,31 @@ func normalizeDataExportResource(raw string) string {
 		return dataExportResourceEntries
 	case dataExportResourceReport:
 		return dataExportResourceReport
+	case dataExportResourceFlows:
+		return dataExportResourceFlows
+	case dataExportResourceCardEvents:
+		return dataExportResourceCardEvents
+	case dataExportResourceAudit:
+		return dataExportResourceAudit
+	case dataExportResourceAll:
+		return dataExportResourceAll
 	default:
 		return ""
 	}
 }
 
+func normalizeDataFormat(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+func dataJSONOnlyFormatError(resource, format string) error {
+	return &reportCLIError{
+		Code:    "INVALID_ARGUMENT",
+		Message: "resource " + resource + " supports only --format json",
+		Details: map[string]any{"field": "format", "value": format},
+	}
+}
+
 func buildDataExportReportRequest(flags *dataExportFlags) (service.ReportRequest, error) {
 	if flags == nil {
 		return service.ReportRequest{}, &reportCLIError{
@@ -376,6 +1077,7 @@ func buildDataExportReportRequest(flags *dataExportFlags) (service.ReportRequest
 		labelIDRaw:    flags.reportLabelIDRaw,
 		labelMode:     flags.reportLabelMode,
 		convertTo:     flags.reportConvertTo,
+		payee:         flags.reportPayee,
 	}, period)
 }
 
@@ -530,6 +1232,25 @@ func restoreDatabase(ctx context.Context, opts *RootOptions, backupPath string)
 	return nil
 }
 
+// diffRestore opens the backup read-only, runs the same integrity check a
+// restore validates with, and compares it with the live database.
+func diffRestore(ctx context.Context, opts *RootOptions, backupPath string) (domain.RestoreDiff, error) {
+	if opts == nil || opts.db == nil {
+		return domain.RestoreDiff{}, &reportCLIError{Code: "DB_ERROR", Message: "database operation failed", Details: map[string]any{"reason": "database connection unavailable"}}
+	}
+
+	backupDB, err := sqlitestore.OpenReadOnly(ctx, backupPath)
+	if err != nil {
+		return domain.RestoreDiff{}, fmt.Errorf("restore diff: %w", err)
+	}
+	defer backupDB.Close()
+
+	if err := validateSQLiteIntegrity(ctx, backupDB); err != nil {
+		return domain.RestoreDiff{}, fmt.Errorf("restore diff validation: %w", err)
+	}
+	return sqlitestore.DiffRestore(ctx, opts.db, backupDB)
+}
+
 func openAndValidateSQLite(ctx context.Context, dbPath, migrationsDir string) (*sql.DB, error) {
 	db, err := sqlitestore.OpenAndMigrate(ctx, dbPath, migrationsDir)
 	if err != nil {
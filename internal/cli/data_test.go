@@ -6,8 +6,10 @@ import (
 	"database/sql"
 	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -199,7 +201,7 @@ func TestDataCommandCSVExportImportIdempotent(t *testing.T) {
 		t.Fatalf("expected header + 2 rows in csv export, got %d rows", len(rows))
 	}
 
-	expectedHeader := []string{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_id", "label_ids", "note"}
+	expectedHeader := []string{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_id", "label_ids", "note", "payee", "recorded_by", "uid", "income_source", "location", "payment_method", "payment_card_nickname", "amount", "schema_version"}
 	assertCSVRowEqual(t, rows[0], expectedHeader)
 	for _, row := range rows[1:] {
 		if len(row) != len(expectedHeader) {
@@ -214,6 +216,9 @@ func TestDataCommandCSVExportImportIdempotent(t *testing.T) {
 	if salaryRow[5] != "" {
 		t.Fatalf("expected empty label_ids for salary row, got %q", salaryRow[5])
 	}
+	if salaryRow[1] != "9000" || salaryRow[14] != "90.00" {
+		t.Fatalf("expected amount_minor 9000 and amount 90.00 for salary row, got %q and %q", salaryRow[1], salaryRow[14])
+	}
 
 	expenseRow, found := findEntryCSVRowByNote(rows, "subway pass")
 	if !found {
@@ -287,6 +292,450 @@ func TestDataCommandCSVExportImportIdempotent(t *testing.T) {
 	assertJSONInt64SliceEqual(t, labels, []int64{targetWorkLabelID, targetTripLabelID})
 }
 
+func TestDataCommandImportMatchByUIDUpdatesEditedEntries(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "income",
+		"--amount", "90.00",
+		"--currency", "USD",
+		"--date", "2026-03-01",
+		"--note", "salary",
+	}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "27.00",
+		"--currency", "USD",
+		"--date", "2026-03-02",
+		"--note", "subway pass",
+	}))
+
+	exportPath := filepath.Join(t.TempDir(), "entries.csv")
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{
+		"export",
+		"--format", "csv",
+		"--file", exportPath,
+	}))
+
+	rows := readCSVFile(t, exportPath)
+	if rows[0][9] != "uid" {
+		t.Fatalf("expected uid column in csv header, got %v", rows[0])
+	}
+	for index, row := range rows[1:] {
+		if row[9] == "" {
+			t.Fatalf("expected uid on exported row %d, got %v", index+1, row)
+		}
+		if row[6] == "subway pass" {
+			row[6] = "monthly subway pass"
+		}
+	}
+	writeCSVFile(t, exportPath, rows)
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--format", "csv",
+		"--file", exportPath,
+		"--match-by", "uid",
+	})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for match-by without idempotent, got %v", invalid)
+	}
+
+	importPayload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--format", "csv",
+		"--file", exportPath,
+		"--idempotent",
+		"--match-by", "uid",
+	})
+	assertSuccessJSONEnvelope(t, importPayload)
+	importData := mustMap(t, importPayload["data"])
+	if importData["imported"] != float64(0) || importData["updated"] != float64(1) || importData["skipped"] != float64(1) {
+		t.Fatalf("expected imported=0 updated=1 skipped=1, got %v", importData)
+	}
+	if importData["match_by"] != "uid" {
+		t.Fatalf("expected match_by=uid, got %v", importData["match_by"])
+	}
+	if count := activeTransactionCount(t, db); count != 2 {
+		t.Fatalf("expected edited entry to be updated in place, got %d active entries", count)
+	}
+	if count := activeTransactionCountByNote(t, db, "monthly subway pass"); count != 1 {
+		t.Fatalf("expected edited note to be applied, got %d matching entries", count)
+	}
+
+	duplicatePayload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--format", "csv",
+		"--file", exportPath,
+	})
+	assertSuccessJSONEnvelope(t, duplicatePayload)
+	if imported := mustMap(t, duplicatePayload["data"])["imported"]; imported != float64(2) {
+		t.Fatalf("expected non-idempotent import to add copies with new uids, got %v", duplicatePayload)
+	}
+	if count := activeTransactionCount(t, db); count != 4 {
+		t.Fatalf("expected 4 active entries after duplicate import, got %d", count)
+	}
+}
+
+func TestDataCommandImportOnConflictStrategies(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "4.50",
+		"--currency", "USD",
+		"--date", "2026-03-03",
+		"--note", "coffee",
+		"--payee", "Corner Cafe",
+	}))
+
+	exportPath := filepath.Join(t.TempDir(), "entries.csv")
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{
+		"export",
+		"--format", "csv",
+		"--file", exportPath,
+	}))
+
+	// Drop the uid so records match by signature and edit a field the
+	// signature ignores.
+	rows := readCSVFile(t, exportPath)
+	rows[1][7] = "Harbor Cafe"
+	rows[1][9] = ""
+	writeCSVFile(t, exportPath, rows)
+
+	importWith := func(strategy string) map[string]any {
+		t.Helper()
+		payload := executeDataCmdJSONWithOptions(t, opts, []string{
+			"import",
+			"--format", "csv",
+			"--file", exportPath,
+			"--on-conflict", strategy,
+		})
+		assertSuccessJSONEnvelope(t, payload)
+		data := mustMap(t, payload["data"])
+		if data["on_conflict"] != strategy || data["match_by"] != "signature" {
+			t.Fatalf("expected on_conflict=%s match_by=signature, got %v", strategy, data)
+		}
+		return data
+	}
+	payeeOf := func() string {
+		t.Helper()
+		var payee string
+		if err := db.QueryRowContext(context.Background(), `SELECT COALESCE(payee, '') FROM transactions WHERE deleted_at_utc IS NULL ORDER BY id LIMIT 1;`).Scan(&payee); err != nil {
+			t.Fatalf("read payee: %v", err)
+		}
+		return payee
+	}
+
+	skipped := importWith("skip")
+	if skipped["skipped"] != float64(1) || skipped["imported"] != float64(0) || skipped["updated"] != float64(0) || skipped["duplicated"] != float64(0) {
+		t.Fatalf("expected skip to leave the match alone, got %v", skipped)
+	}
+	if payee := payeeOf(); payee != "Corner Cafe" {
+		t.Fatalf("expected payee unchanged after skip, got %q", payee)
+	}
+
+	updated := importWith("update")
+	if updated["updated"] != float64(1) || updated["imported"] != float64(0) || updated["skipped"] != float64(0) {
+		t.Fatalf("expected update to change the match in place, got %v", updated)
+	}
+	if payee := payeeOf(); payee != "Harbor Cafe" {
+		t.Fatalf("expected payee updated from import, got %q", payee)
+	}
+	if count := activeTransactionCount(t, db); count != 1 {
+		t.Fatalf("expected 1 active entry after update, got %d", count)
+	}
+
+	duplicated := importWith("duplicate")
+	if duplicated["duplicated"] != float64(1) || duplicated["imported"] != float64(0) {
+		t.Fatalf("expected duplicate to add a copy, got %v", duplicated)
+	}
+	if count := activeTransactionCount(t, db); count != 2 {
+		t.Fatalf("expected 2 active entries after duplicate, got %d", count)
+	}
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--format", "csv",
+		"--file", exportPath,
+		"--on-conflict", "merge",
+	})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown on-conflict strategy, got %v", invalid)
+	}
+}
+
+func TestDataCommandImportReportFileListsRowOutcomes(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "4.50",
+		"--currency", "USD",
+		"--date", "2026-03-03",
+		"--note", "coffee",
+	}))
+
+	tempDir := t.TempDir()
+	importPath := filepath.Join(tempDir, "entries.json")
+	if err := os.WriteFile(importPath, []byte(`{"entries":[
+		{"type":"expense","amount_minor":450,"currency_code":"USD","transaction_date_utc":"2026-03-03T00:00:00Z","note":"coffee"},
+		{"type":"income","amount_minor":9000,"currency_code":"USD","transaction_date_utc":"2026-03-01T00:00:00Z","note":"salary"}
+	]}`), 0o644); err != nil {
+		t.Fatalf("write import file: %v", err)
+	}
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	reportPath := filepath.Join(tempDir, "results", "import.json")
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--format", "json",
+		"--file", importPath,
+		"--idempotent",
+		"--report-file", reportPath,
+	})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["report_file"] != reportPath || data["imported"] != float64(1) || data["skipped"] != float64(1) {
+		t.Fatalf("unexpected import payload: %v", data)
+	}
+
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("read report file: %v", err)
+	}
+	var report struct {
+		File string `json:"file"`
+		Rows []struct {
+			Row     int64  `json:"row"`
+			Outcome string `json:"outcome"`
+			EntryID int64  `json:"entry_id"`
+			Reason  string `json:"reason"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("unmarshal report file: %v content=%s", err, content)
+	}
+	if report.File != importPath || len(report.Rows) != 2 {
+		t.Fatalf("expected one row per record, got %+v", report)
+	}
+	skipped, imported := report.Rows[0], report.Rows[1]
+	if skipped.Row != 1 || skipped.Outcome != "skipped" || skipped.Reason != "matched_signature" || skipped.EntryID != 1 {
+		t.Fatalf("expected the first record skipped as a match of entry 1, got %+v", skipped)
+	}
+	var salaryID int64
+	if err := db.QueryRowContext(context.Background(), `SELECT id FROM transactions WHERE note = 'salary';`).Scan(&salaryID); err != nil {
+		t.Fatalf("read imported entry id: %v", err)
+	}
+	if imported.Row != 2 || imported.Outcome != "imported" || imported.EntryID != salaryID {
+		t.Fatalf("expected the second record imported as entry %d, got %+v", salaryID, imported)
+	}
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{
+		"import",
+		"--resource", "card-events",
+		"--format", "json",
+		"--file", importPath,
+		"--report-file", reportPath,
+	})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected report-file outside entries imports to be rejected, got %v", invalid)
+	}
+}
+
+func TestDataCommandExportImportKeepsPaymentCard(t *testing.T) {
+	t.Parallel()
+
+	sourceDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = sourceDB.Close() })
+	sourceCardID := insertTestCard(t, sourceDB, "Main Visa", "", "1234", "VISA", "credit", 15)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, sourceDB, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "30.00",
+		"--currency", "USD",
+		"--date", "2026-02-03",
+		"--note", "groceries",
+		"--payment-method", "card",
+		"--card-id", strconv.FormatInt(sourceCardID, 10),
+	}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, sourceDB, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "5.00",
+		"--currency", "USD",
+		"--date", "2026-02-04",
+		"--note", "snack",
+	}))
+
+	tempDir := t.TempDir()
+	targetDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = targetDB.Close() })
+	insertTestCard(t, targetDB, "Spare Debit", "", "9999", "VISA", "debit", 0)
+	targetCardID := insertTestCard(t, targetDB, "main visa", "", "1234", "VISA", "credit", 15)
+
+	for _, format := range []string{"json", "csv"} {
+		exportPath := filepath.Join(tempDir, "entries."+format)
+		assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: sourceDB}, []string{
+			"export",
+			"--format", format,
+			"--file", exportPath,
+		}))
+		content, err := os.ReadFile(exportPath)
+		if err != nil {
+			t.Fatalf("%s: read export: %v", format, err)
+		}
+		if !strings.Contains(string(content), "payment_card_nickname") || !strings.Contains(string(content), "Main Visa") {
+			t.Fatalf("%s: expected the export to name the payment card, got %s", format, content)
+		}
+
+		payload := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: targetDB}, []string{
+			"import",
+			"--format", format,
+			"--file", exportPath,
+			"--on-conflict", "duplicate",
+		})
+		assertSuccessJSONEnvelope(t, payload)
+	}
+
+	rows, err := targetDB.QueryContext(context.Background(), `
+SELECT t.note, COALESCE(tpm.method_type, ''), COALESCE(tpm.card_id, 0)
+FROM transactions t
+LEFT JOIN transaction_payment_methods tpm ON tpm.transaction_id = t.id
+WHERE t.deleted_at_utc IS NULL
+ORDER BY t.id;`)
+	if err != nil {
+		t.Fatalf("query imported payments: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var note, method string
+		var cardID int64
+		if err := rows.Scan(&note, &method, &cardID); err != nil {
+			t.Fatalf("scan imported payment: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%s:%s:%d", note, method, cardID))
+	}
+	want := fmt.Sprintf("groceries:card:%d snack:cash:0 groceries:card:%d snack:cash:0", targetCardID, targetCardID)
+	if strings.Join(got, " ") != want {
+		t.Fatalf("expected imports to link the card by nickname, got %v want %s", got, want)
+	}
+
+	debt := mustMap(t, mustMap(t, executeCardCmdJSON(t, targetDB, []string{"debt", "show", "--card-id", strconv.FormatInt(targetCardID, 10)})["data"])["debt"])
+	bucket := mustMap(t, mustAnySlice(t, debt["buckets"])[0])
+	if int64(bucket["balance_minor_signed"].(float64)) != 6000 {
+		t.Fatalf("expected both imported charges on the card's debt, got %v", bucket["balance_minor_signed"])
+	}
+
+	missingPath := filepath.Join(tempDir, "missing.json")
+	if err := os.WriteFile(missingPath, []byte(`{"schema_version":5,"entries":[{"type":"expense","amount_minor":100,"currency_code":"USD","transaction_date_utc":"2026-02-05T00:00:00Z","payment_method":"card","payment_card_nickname":"Unknown"}]}`), 0o644); err != nil {
+		t.Fatalf("write missing card import: %v", err)
+	}
+	missing := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: targetDB}, []string{"import", "--format", "json", "--file", missingPath})
+	if missing["ok"] != false || mustMap(t, missing["error"])["code"] != "NOT_FOUND" {
+		t.Fatalf("expected an unknown card nickname to fail with NOT_FOUND, got %v", missing)
+	}
+}
+
+func TestDataCommandImportRejectsNewerSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	importPath := filepath.Join(t.TempDir(), "entries.json")
+	if err := os.WriteFile(importPath, []byte(`{"schema_version":99,"entries":[]}`), 0o644); err != nil {
+		t.Fatalf("write import file: %v", err)
+	}
+
+	payload := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: db}, []string{
+		"import",
+		"--format", "json",
+		"--file", importPath,
+	})
+	if payload["ok"] != false || mustMap(t, payload["error"])["code"] != "UPGRADE_REQUIRED" {
+		t.Fatalf("expected UPGRADE_REQUIRED for newer schema_version, got %v", payload)
+	}
+}
+
+func TestDataCommandCSVExportImportThroughStdio(t *testing.T) {
+	t.Parallel()
+
+	sourceDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = sourceDB.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, sourceDB, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "12.50",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+		"--note", "lunch",
+	}))
+
+	exportCmd := NewDataCmd(&RootOptions{Output: output.FormatJSON, db: sourceDB})
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	exportCmd.SetOut(stdout)
+	exportCmd.SetErr(stderr)
+	exportCmd.SetArgs([]string{"export", "--format", "csv", "--file", "-"})
+	if err := exportCmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute stdout export: %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(stdout.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("stdout must contain only csv data: %v raw=%s", err, stdout.String())
+	}
+	if len(rows) != 2 || rows[1][6] != "lunch" {
+		t.Fatalf("expected header plus one exported row, got %v", rows)
+	}
+
+	envelope := map[string]any{}
+	if err := json.Unmarshal(stderr.Bytes(), &envelope); err != nil {
+		t.Fatalf("expected envelope on stderr: %v raw=%s", err, stderr.String())
+	}
+	assertSuccessJSONEnvelope(t, envelope)
+
+	targetDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = targetDB.Close() })
+
+	importCmd := NewDataCmd(&RootOptions{Output: output.FormatJSON, db: targetDB})
+	importOut := &bytes.Buffer{}
+	importCmd.SetIn(bytes.NewReader(stdout.Bytes()))
+	importCmd.SetOut(importOut)
+	importCmd.SetErr(importOut)
+	importCmd.SetArgs([]string{"import", "--format", "csv", "--file", "-"})
+	if err := importCmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute stdin import: %v", err)
+	}
+
+	imported := map[string]any{}
+	if err := json.Unmarshal(importOut.Bytes(), &imported); err != nil {
+		t.Fatalf("unmarshal import payload: %v raw=%s", err, importOut.String())
+	}
+	assertSuccessJSONEnvelope(t, imported)
+	if got := mustMap(t, imported["data"])["imported"].(float64); got != 1 {
+		t.Fatalf("expected 1 imported entry, got %v", got)
+	}
+}
+
 func TestDataCommandCSVImportParsesLabelDelimiter(t *testing.T) {
 	t.Parallel()
 
@@ -356,43 +805,167 @@ func TestDataCommandCSVImportParsesLabelDelimiter(t *testing.T) {
 	assertJSONInt64SliceEqual(t, labels, []int64{labelA, labelB})
 }
 
-func TestDataCommandJSONBackupRestore(t *testing.T) {
+func TestDataCommandImportResolvesNamesAndCreatesMissing(t *testing.T) {
 	t.Parallel()
 
-	tempDir := t.TempDir()
-	dbPath := filepath.Join(tempDir, "boring-budget.db")
-	migrationsDir := cliMigrationsPath(t)
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
 
-	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
-	if err != nil {
-		t.Fatalf("open and migrate db for backup/restore: %v", err)
+	foodID := insertTestCategory(t, db, "Food")
+	importDir := t.TempDir()
+	csvPath := filepath.Join(importDir, "entries.csv")
+	writeCSVFile(t, csvPath, [][]string{
+		{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_name", "label_names", "note"},
+		{"expense", "1200", "USD", "2026-03-03T00:00:00Z", "food", "Weekly | Shared", "lunch"},
+		{"expense", "4500", "USD", "2026-03-04T00:00:00Z", "Transport", "", "fuel"},
+	})
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	missing := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--format", "csv", "--file", csvPath})
+	if ok, _ := missing["ok"].(bool); ok {
+		t.Fatalf("expected import without --create-missing to fail, got %v", missing)
+	}
+	if code := mustMap(t, missing["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unknown names, got %v", code)
+	}
+	if count := activeTransactionCount(t, db); count != 0 {
+		t.Fatalf("expected failed import to write nothing, got %d entries", count)
 	}
 
-	opts := &RootOptions{
-		Output:        output.FormatJSON,
-		DBPath:        dbPath,
-		MigrationsDir: migrationsDir,
-		db:            db,
+	created := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--format", "csv", "--file", csvPath, "--create-missing"})
+	assertSuccessJSONEnvelope(t, created)
+	createdData := mustMap(t, created["data"])
+	if createdData["imported"].(float64) != 2 {
+		t.Fatalf("expected imported=2, got %v", createdData["imported"])
+	}
+	if got := strings.Join(toStringSlice(mustAnySlice(t, createdData["created_categories"])), ","); got != "Transport" {
+		t.Fatalf("expected created_categories=[Transport], got %v", createdData["created_categories"])
+	}
+	if got := strings.Join(toStringSlice(mustAnySlice(t, createdData["created_labels"])), ","); got != "Shared,Weekly" {
+		t.Fatalf("expected created_labels=[Shared Weekly], got %v", createdData["created_labels"])
 	}
-	t.Cleanup(func() {
-		if opts.db != nil {
-			_ = opts.db.Close()
-		}
-	})
 
-	mustEntrySuccess(t, executeEntryCmdJSON(t, opts.db, []string{
-		"add",
-		"--type", "income",
-		"--amount", "10.00",
-		"--currency", "USD",
-		"--date", "2026-02-01",
-		"--note", "before-backup",
-	}))
-	if count := activeTransactionCount(t, opts.db); count != 1 {
-		t.Fatalf("expected one transaction before backup, got %d", count)
+	entries := mustAnySlice(t, mustMap(t, executeEntryCmdJSON(t, db, []string{"list"})["data"])["entries"])
+	lunch, found := findJSONEntryByNote(t, entries, "lunch")
+	if !found {
+		t.Fatalf("expected lunch entry, got %v", entries)
+	}
+	if int64(lunch["category_id"].(float64)) != foodID {
+		t.Fatalf("expected lunch matched to existing category %d, got %v", foodID, lunch["category_id"])
+	}
+	if labels := mustAnySlice(t, lunch["label_ids"]); len(labels) != 2 {
+		t.Fatalf("expected two labels on lunch, got %v", labels)
 	}
 
-	backupPath := filepath.Join(tempDir, "snapshots", "boring-budget-backup.sqlite")
+	jsonPath := filepath.Join(importDir, "entries.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"entries":[{"type":"expense","amount_minor":300,"currency_code":"USD","transaction_date_utc":"2026-03-05T00:00:00Z","category_name":"TRANSPORT","label_names":["weekly"],"note":"bus"}]}`), 0o600); err != nil {
+		t.Fatalf("write json import: %v", err)
+	}
+	jsonImport := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--format", "json", "--file", jsonPath})
+	assertSuccessJSONEnvelope(t, jsonImport)
+	if imported := mustMap(t, jsonImport["data"])["imported"].(float64); imported != 1 {
+		t.Fatalf("expected json import by name to add one entry, got %v", imported)
+	}
+}
+
+func TestDataCommandImportSourceYNABMapsCardsAndReportsUnmapped(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	groceriesID := insertTestCategory(t, db, "Groceries")
+	cardID := insertTestCard(t, db, "Visa Gold", "", "1234", "visa", "credit", 10)
+	importDir := t.TempDir()
+	csvPath := filepath.Join(importDir, "ynab.csv")
+	writeCSVFile(t, csvPath, [][]string{
+		{"Account", "Flag", "Date", "Payee", "Category Group/Category", "Category Group", "Category", "Memo", "Outflow", "Inflow", "Cleared"},
+		{"Visa Gold", "", "03/02/2026", "Corner Market", "Everyday: Groceries", "Everyday", "groceries", "weekly shop", "$42.10", "$0.00", "Cleared"},
+		{"Checking", "Red", "03/05/2026", "Employer", "Inflow: Ready to Assign", "Inflow", "Ready to Assign", "", "$0.00", "$1,500.00", "Cleared"},
+		{"Checking", "", "03/06/2026", "Cinema", "Fun: Movies", "Fun", "Movies", "", "$12.00", "$0.00", "Cleared"},
+		{"Checking", "", "03/07/2026", "Transfer : Visa Gold", "", "", "", "", "$42.10", "$0.00", "Cleared"},
+	})
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--source", "ynab", "--file", csvPath, "--currency", "USD"})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["imported"].(float64) != 3 || data["source_skipped"].(float64) != 1 {
+		t.Fatalf("expected 3 imported and the transfer skipped, got %v", data)
+	}
+	unmapped := mustMap(t, data["unmapped"])
+	if got := strings.Join(toStringSlice(mustAnySlice(t, unmapped["categories"])), ","); got != "Movies" {
+		t.Fatalf("expected unmapped categories [Movies], got %v", unmapped)
+	}
+	if got := strings.Join(toStringSlice(mustAnySlice(t, unmapped["accounts"])), ","); got != "Checking" {
+		t.Fatalf("expected unmapped accounts [Checking], got %v", unmapped)
+	}
+	if got := strings.Join(toStringSlice(mustAnySlice(t, unmapped["labels"])), ","); got != "Red" {
+		t.Fatalf("expected unmapped labels [Red], got %v", unmapped)
+	}
+
+	entries := mustAnySlice(t, mustMap(t, executeEntryCmdJSON(t, db, []string{"list"})["data"])["entries"])
+	shop, found := findJSONEntryByNote(t, entries, "weekly shop")
+	if !found {
+		t.Fatalf("expected weekly shop entry, got %v", entries)
+	}
+	if int64(shop["category_id"].(float64)) != groceriesID || int64(shop["payment_card_id"].(float64)) != cardID || shop["payee"] != "Corner Market" {
+		t.Fatalf("expected groceries paid with Visa Gold at Corner Market, got %v", shop)
+	}
+
+	again := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--source", "ynab", "--file", csvPath, "--currency", "USD", "--idempotent", "--create-missing"})
+	assertSuccessJSONEnvelope(t, again)
+	againData := mustMap(t, again["data"])
+	if againData["skipped"].(float64) != 1 || againData["imported"].(float64) != 2 {
+		t.Fatalf("expected only the unchanged groceries entry skipped, got %v", againData)
+	}
+	if got := strings.Join(toStringSlice(mustAnySlice(t, againData["created_categories"])), ","); got != "Movies" {
+		t.Fatalf("expected created_categories=[Movies], got %v", againData)
+	}
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--source", "quicken", "--file", csvPath})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown source, got %v", invalid)
+	}
+}
+
+func TestDataCommandJSONBackupRestore(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for backup/restore: %v", err)
+	}
+
+	opts := &RootOptions{
+		Output:        output.FormatJSON,
+		DBPath:        dbPath,
+		MigrationsDir: migrationsDir,
+		db:            db,
+	}
+	t.Cleanup(func() {
+		if opts.db != nil {
+			_ = opts.db.Close()
+		}
+	})
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, opts.db, []string{
+		"add",
+		"--type", "income",
+		"--amount", "10.00",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+		"--note", "before-backup",
+	}))
+	if count := activeTransactionCount(t, opts.db); count != 1 {
+		t.Fatalf("expected one transaction before backup, got %d", count)
+	}
+
+	backupPath := filepath.Join(tempDir, "snapshots", "boring-budget-backup.sqlite")
 	backupPayload := executeDataCmdJSONWithOptions(t, opts, []string{
 		"backup",
 		"--file", backupPath,
@@ -458,6 +1031,146 @@ func TestDataCommandJSONBackupRestore(t *testing.T) {
 	}
 }
 
+func TestDataCommandJSONOnlineBackup(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for online backup: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "4.00",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+		"--note", "online-backup",
+	}))
+
+	backupPath := filepath.Join(tempDir, "snapshots", "online.sqlite")
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"backup",
+		"--file", backupPath,
+		"--online",
+		"--pages-per-step", "5",
+	})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["method"] != "online" || data["backup_file"] != backupPath {
+		t.Fatalf("unexpected online backup payload: %v", data)
+	}
+	pages := mustMap(t, data["pages"])
+	if pages["pages_total"].(float64) <= 5 || pages["pages_copied"] != pages["pages_total"] {
+		t.Fatalf("expected a multi-step backup to copy every page, got %v", pages)
+	}
+
+	backupDB, err := sqlitestore.Open(context.Background(), backupPath)
+	if err != nil {
+		t.Fatalf("open online backup: %v", err)
+	}
+	defer backupDB.Close()
+	if count := activeTransactionCountByNote(t, backupDB, "online-backup"); count != 1 {
+		t.Fatalf("expected backup to contain the entry, got %d", count)
+	}
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{
+		"backup",
+		"--file", filepath.Join(tempDir, "invalid.sqlite"),
+		"--pages-per-step", "5",
+	})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected --pages-per-step without --online to be rejected, got %v", invalid)
+	}
+}
+
+func TestDataCommandProgressJSONEvents(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for progress: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	for _, note := range []string{"first", "second", "third"} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add",
+			"--type", "expense",
+			"--amount", "4.00",
+			"--currency", "USD",
+			"--date", "2026-02-01",
+			"--note", note,
+		}))
+	}
+
+	lastEvent := func(step string, stderr string) map[string]any {
+		t.Helper()
+		lines := strings.Split(strings.TrimSpace(stderr), "\n")
+		event := map[string]any{}
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &event); err != nil {
+			t.Fatalf("%s: unmarshal progress event: %v stderr=%s", step, err, stderr)
+		}
+		if event["event"] != "progress" || event["finished"] != true {
+			t.Fatalf("%s: expected a finished progress event last, got %v", step, event)
+		}
+		return event
+	}
+
+	exportPath := filepath.Join(tempDir, "entries.json")
+	stdout, stderr := executeDataCmdSplitWithOptions(t, opts, []string{"export", "--format", "json", "--file", exportPath, "--progress", "json"})
+	assertSuccessJSONEnvelope(t, decodeDataPayload(t, stdout))
+	event := lastEvent("export", stderr)
+	if event["operation"] != "export" || event["unit"] != "rows" || event["done"] != float64(3) || event["total"] != float64(3) || event["percent"] != float64(100) {
+		t.Fatalf("unexpected final export progress: %v", event)
+	}
+
+	stdout, stderr = executeDataCmdSplitWithOptions(t, opts, []string{"import", "--format", "json", "--file", exportPath, "--idempotent", "--progress", "json"})
+	assertSuccessJSONEnvelope(t, decodeDataPayload(t, stdout))
+	event = lastEvent("import", stderr)
+	info, err := os.Stat(exportPath)
+	if err != nil {
+		t.Fatalf("stat export: %v", err)
+	}
+	if event["operation"] != "import" || event["done"] != float64(3) || event["bytes_total"] != float64(info.Size()) {
+		t.Fatalf("unexpected final import progress: %v", event)
+	}
+
+	stdout, stderr = executeDataCmdSplitWithOptions(t, opts, []string{"backup", "--file", filepath.Join(tempDir, "online.sqlite"), "--online", "--pages-per-step", "5", "--progress", "json"})
+	assertSuccessJSONEnvelope(t, decodeDataPayload(t, stdout))
+	event = lastEvent("backup", stderr)
+	if event["operation"] != "backup" || event["unit"] != "pages" || event["done"] != event["total"] {
+		t.Fatalf("unexpected final backup progress: %v", event)
+	}
+
+	stdout, stderr = executeDataCmdSplitWithOptions(t, opts, []string{"export", "--format", "json", "--file", exportPath})
+	assertSuccessJSONEnvelope(t, decodeDataPayload(t, stdout))
+	if stderr != "" {
+		t.Fatalf("expected auto progress to stay quiet off a terminal, got %q", stderr)
+	}
+
+	stdout, _ = executeDataCmdSplitWithOptions(t, opts, []string{"export", "--format", "json", "--file", exportPath, "--progress", "bar"})
+	invalid := decodeDataPayload(t, stdout)
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected an unknown progress mode to be rejected, got %v", invalid)
+	}
+}
+
 func TestDataCommandJSONRestoreFailureRollsBackDatabase(t *testing.T) {
 	t.Parallel()
 
@@ -515,37 +1228,478 @@ func TestDataCommandJSONRestoreFailureRollsBackDatabase(t *testing.T) {
 		"--file", invalidBackupPath,
 	})
 
-	if ok, _ := restorePayload["ok"].(bool); ok {
-		t.Fatalf("expected ok=false for invalid restore payload=%v", restorePayload)
-	}
+	if ok, _ := restorePayload["ok"].(bool); ok {
+		t.Fatalf("expected ok=false for invalid restore payload=%v", restorePayload)
+	}
+
+	errorMap := mustMap(t, restorePayload["error"])
+	if code, _ := errorMap["code"].(string); strings.TrimSpace(code) == "" {
+		t.Fatalf("expected non-empty error code in restore failure payload=%v", restorePayload)
+	}
+
+	if opts.db == nil {
+		t.Fatalf("expected rollback to reopen original database handle")
+	}
+	if count := activeTransactionCount(t, opts.db); count != 2 {
+		t.Fatalf("expected rollback to preserve two transactions, got %d", count)
+	}
+	if count := activeTransactionCountByNote(t, opts.db, "pre-rollback-check"); count != 1 {
+		t.Fatalf("expected pre-rollback-check entry after failed restore, got %d", count)
+	}
+	if count := activeTransactionCountByNote(t, opts.db, "still-present-after-failure"); count != 1 {
+		t.Fatalf("expected still-present-after-failure entry after failed restore, got %d", count)
+	}
+
+	for _, path := range []string{
+		dbPath + ".restore.tmp",
+		dbPath + ".restore.rollback",
+		dbPath + ".restore.rollback-wal",
+		dbPath + ".restore.rollback-shm",
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected restore artifact %q to be cleaned up after rollback; stat err=%v", path, err)
+		}
+	}
+}
+
+func TestDataCommandJSONRestoreOnlySelectedGroups(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for selective restore: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	mustCapSet := func(amount string) {
+		t.Helper()
+		payload := executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--amount", amount, "--currency", "USD"})
+		if ok, _ := payload["ok"].(bool); !ok {
+			t.Fatalf("expected cap set ok=true payload=%v", payload)
+		}
+	}
+	mustCapSet("450.00")
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-02-01", "--note", "before-backup",
+	}))
+
+	backupPath := filepath.Join(tempDir, "backup.sqlite")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"backup", "--file", backupPath}))
+
+	mustCapSet("900.00")
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "2.00", "--currency", "USD", "--date", "2026-02-02", "--note", "after-backup",
+	}))
+
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "caps", "--from-backup", backupPath})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["restored_from"] != backupPath {
+		t.Fatalf("expected restored_from %q, got %v", backupPath, data["restored_from"])
+	}
+	tables := data["tables"].([]any)
+	if len(tables) != 3 || mustMap(t, tables[0])["table"] != "monthly_caps" || mustMap(t, tables[0])["restored"].(float64) != 1 {
+		t.Fatalf("unexpected restored tables: %v", tables)
+	}
+
+	var capAmount int64
+	if err := db.QueryRow(`SELECT amount_minor FROM monthly_caps WHERE month_key = '2026-02';`).Scan(&capAmount); err != nil {
+		t.Fatalf("query restored cap: %v", err)
+	}
+	if capAmount != 45000 {
+		t.Fatalf("expected cap restored to 45000, got %d", capAmount)
+	}
+	if count := activeTransactionCount(t, db); count != 2 {
+		t.Fatalf("expected caps-only restore to keep both entries, got %d", count)
+	}
+
+	entriesPayload := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "entries", "--file", backupPath})
+	assertSuccessJSONEnvelope(t, entriesPayload)
+	if count := activeTransactionCountByNote(t, db, "after-backup"); count != 0 {
+		t.Fatalf("expected entries restore to drop after-backup entry, got %d", count)
+	}
+	if count := activeTransactionCountByNote(t, db, "before-backup"); count != 1 {
+		t.Fatalf("expected entries restore to keep before-backup entry, got %d", count)
+	}
+
+	var foreignKeys int
+	if err := db.QueryRow("PRAGMA foreign_keys;").Scan(&foreignKeys); err != nil {
+		t.Fatalf("query foreign_keys pragma: %v", err)
+	}
+	if foreignKeys != 1 {
+		t.Fatalf("expected foreign keys back on after restore, got %d", foreignKeys)
+	}
+
+	invalid := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "caps,budgets", "--file", backupPath})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected unknown restore group to be rejected, got %v", invalid)
+	}
+}
+
+func TestDataCommandJSONRestoreOnlyCardsBringsBackAliases(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for cards restore: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	card := executeCardCmdJSON(t, db, []string{"add", "--nickname", "Travel", "--last4", "4242", "--brand", "visa", "--card-type", "credit", "--due-day", "10"})
+	assertSuccessJSONEnvelope(t, card)
+	cardID := strconv.FormatInt(int64(mustMap(t, mustMap(t, card["data"])["card"])["id"].(float64)), 10)
+	assertSuccessJSONEnvelope(t, executeCardCmdJSON(t, db, []string{"alias", "add", cardID, "trip"}))
+
+	backupPath := filepath.Join(tempDir, "backup.sqlite")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"backup", "--file", backupPath}))
+
+	assertSuccessJSONEnvelope(t, executeCardCmdJSON(t, db, []string{"alias", "remove", cardID, "trip"}))
+	assertSuccessJSONEnvelope(t, executeCardCmdJSON(t, db, []string{"alias", "add", cardID, "holiday"}))
+
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "cards", "--from-backup", backupPath}))
+
+	var aliases []string
+	rows, err := db.Query(`SELECT alias FROM card_aliases ORDER BY alias;`)
+	if err != nil {
+		t.Fatalf("query restored aliases: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			t.Fatalf("scan restored alias: %v", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	if len(aliases) != 1 || aliases[0] != "trip" {
+		t.Fatalf("expected cards restore to bring back alias trip only, got %v", aliases)
+	}
+}
+
+func TestDataCommandJSONRestoreOnlyEntriesKeepsLoanPaymentLinks(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for entries restore: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	loan := mustMap(t, mustMap(t, executeLoanCmdJSON(t, db, []string{
+		"add", "--principal", "10000", "--rate", "7.5", "--term-months", "36", "--start", "2026-01", "--currency", "USD",
+	})["data"])["loan_status"])
+	loanID := strconv.FormatInt(int64(mustMap(t, loan["loan"])["id"].(float64)), 10)
+	linkPayment := func(date string) {
+		t.Helper()
+		entry := mustMap(t, mustMap(t, executeEntryCmdJSON(t, db, []string{
+			"add", "--type", "expense", "--amount", "311.06", "--currency", "USD", "--date", date,
+		})["data"])["entry"])
+		assertSuccessJSONEnvelope(t, executeLoanCmdJSON(t, db, []string{"payment", "link", loanID, "--entry-id", strconv.FormatInt(int64(entry["id"].(float64)), 10)}))
+	}
+	linkPayment("2026-01-15")
+
+	backupPath := filepath.Join(tempDir, "backup.sqlite")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"backup", "--file", backupPath}))
+
+	linkPayment("2026-02-15")
+
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "entries", "--from-backup", backupPath}))
+
+	var links int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM loan_payments;`).Scan(&links); err != nil {
+		t.Fatalf("query restored loan payments: %v", err)
+	}
+	if links != 1 {
+		t.Fatalf("expected entries restore to bring back the backup's single loan payment link, got %d", links)
+	}
+
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "loans,entries", "--from-backup", backupPath}))
+}
+
+func TestDataCommandJSONRestoreOnlyEntriesKeepsCardDebtInStep(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for entries restore: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	card := mustMap(t, mustMap(t, executeCardCmdJSON(t, db, []string{"add", "--nickname", "Visa", "--last4", "4242", "--brand", "visa", "--card-type", "credit", "--due-day", "15"})["data"])["card"])
+	cardID := strconv.FormatInt(int64(card["id"].(float64)), 10)
+	charge := func(amount, date string) {
+		t.Helper()
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add", "--type", "expense", "--amount", amount, "--currency", "USD", "--date", date,
+			"--payment-method", "card", "--card-id", cardID,
+		}))
+	}
+	charge("40.00", "2026-01-10")
+
+	backupPath := filepath.Join(tempDir, "backup.sqlite")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"backup", "--file", backupPath}))
+
+	charge("25.00", "2026-01-20")
+	mustExec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := db.Exec(query, args...); err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+	}
+	mustExec(`INSERT INTO scheduled_payments (name, amount_minor, currency_code, day_of_month, start_month_key) VALUES ('Rent', 1000, 'USD', 1, '2026-01');`)
+	mustExec(`INSERT INTO scheduled_payment_executions (schedule_id, month_key, entry_id) SELECT id, '2026-01', (SELECT MAX(id) FROM transactions) FROM scheduled_payments;`)
+
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "entries", "--from-backup", backupPath}))
+
+	var debtMinor int64
+	if err := db.QueryRow(`SELECT COALESCE(SUM(amount_minor_signed), 0) FROM credit_liability_events WHERE card_id = ?;`, cardID).Scan(&debtMinor); err != nil {
+		t.Fatalf("query card debt: %v", err)
+	}
+	if debtMinor != 4000 {
+		t.Fatalf("expected card debt to match the restored entries, got %d", debtMinor)
+	}
+	var unlinked int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM scheduled_payment_executions WHERE entry_id IS NULL;`).Scan(&unlinked); err != nil {
+		t.Fatalf("query schedule runs: %v", err)
+	}
+	if unlinked != 1 {
+		t.Fatalf("expected the schedule run of the dropped entry to be unlinked, got %d", unlinked)
+	}
+	rows, err := db.Query(`PRAGMA foreign_key_check;`)
+	if err != nil {
+		t.Fatalf("foreign key check: %v", err)
+	}
+	defer rows.Close()
+	if rows.Next() {
+		t.Fatalf("expected no dangling references after entries restore")
+	}
+
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--only", "cards,entries", "--from-backup", backupPath}))
+}
+
+func TestDataCommandJSONRestoreDiffPreviewsChanges(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for restore diff: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	mustExec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := db.Exec(query, args...); err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+	}
+	mustExec(`INSERT INTO settings (id, default_currency_code, display_timezone) VALUES (1, 'USD', 'UTC');`)
+	mustExec(`INSERT INTO cards (nickname, last4, brand, card_type) VALUES ('old-visa', '1111', 'visa', 'debit');`)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-02-01", "--note", "february",
+	}))
+
+	backupPath := filepath.Join(tempDir, "backup.sqlite")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"backup", "--file", backupPath}))
+
+	mustExec(`UPDATE settings SET default_currency_code = 'EUR' WHERE id = 1;`)
+	mustExec(`UPDATE cards SET deleted_at_utc = '2026-03-01T00:00:00Z' WHERE nickname = 'old-visa';`)
+	mustExec(`INSERT INTO cards (nickname, last4, brand, card_type) VALUES ('new-amex', '2222', 'amex', 'debit');`)
+	for _, date := range []string{"2026-03-01", "2026-03-02"} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add", "--type", "expense", "--amount", "1.00", "--currency", "USD", "--date", date, "--note", "march",
+		}))
+	}
+
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--diff", "--file", backupPath})
+	assertSuccessJSONEnvelope(t, payload)
+	diff := mustMap(t, mustMap(t, payload["data"])["diff"])
+	if diff["entries_current"].(float64) != 3 || diff["entries_backup"].(float64) != 1 {
+		t.Fatalf("unexpected entry totals: %v", diff)
+	}
+	months := diff["entry_months"].([]any)
+	if len(months) != 1 {
+		t.Fatalf("expected only 2026-03 to differ, got %v", months)
+	}
+	march := mustMap(t, months[0])
+	if march["month"] != "2026-03" || march["current"].(float64) != 2 || march["backup"].(float64) != 0 || march["delta"].(float64) != -2 {
+		t.Fatalf("unexpected month diff: %v", march)
+	}
+	if added := diff["cards_added"].([]any); len(added) != 1 || added[0] != "old-visa" {
+		t.Fatalf("expected restore to bring back old-visa, got %v", added)
+	}
+	if removed := diff["cards_removed"].([]any); len(removed) != 1 || removed[0] != "new-amex" {
+		t.Fatalf("expected restore to drop new-amex, got %v", removed)
+	}
+	settings := diff["settings"].([]any)
+	if len(settings) != 1 {
+		t.Fatalf("expected one settings difference, got %v", settings)
+	}
+	currency := mustMap(t, settings[0])
+	if currency["field"] != "default_currency_code" || currency["current"] != "EUR" || currency["backup"] != "USD" {
+		t.Fatalf("unexpected settings diff: %v", currency)
+	}
+
+	if count := activeTransactionCount(t, db); count != 3 {
+		t.Fatalf("expected diff to leave the database unchanged, got %d entries", count)
+	}
+
+	combined := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--diff", "--only", "caps", "--file", backupPath})
+	if combined["ok"] != false || mustMap(t, combined["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected --diff with --only to be rejected, got %v", combined)
+	}
+}
+
+func TestDataCommandImportTakesSafetySnapshot(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for safety snapshot: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		if opts.db != nil {
+			_ = opts.db.Close()
+		}
+	})
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "3.00", "--currency", "USD", "--date", "2026-02-01", "--note", "original",
+	}))
+	exportPath := filepath.Join(tempDir, "entries.json")
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"export", "--resource", "entries", "--format", "json", "--file", exportPath}))
+
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--format", "json", "--file", exportPath})
+	assertSuccessJSONEnvelope(t, payload)
+	snapshot := mustMap(t, mustMap(t, payload["data"])["snapshot"])
+	snapshotPath, _ := snapshot["path"].(string)
+	if filepath.Dir(snapshotPath) != filepath.Join(tempDir, "snapshots") || snapshot["reason"] != "data import" {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
+	}
+	if count := activeTransactionCount(t, opts.db); count != 2 {
+		t.Fatalf("expected the import to duplicate the entry, got %d", count)
+	}
+
+	opts.NoSnapshot = true
+	restorePayload := executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--file", snapshotPath})
+	assertSuccessJSONEnvelope(t, restorePayload)
+	if _, ok := mustMap(t, restorePayload["data"])["snapshot"]; ok {
+		t.Fatalf("expected --no-snapshot to skip the snapshot, got %v", restorePayload)
+	}
+	opts.NoSnapshot = false
+	if count := activeTransactionCount(t, opts.db); count != 1 {
+		t.Fatalf("expected restoring the snapshot to undo the import, got %d", count)
+	}
+
+	opts.snapshotOff = true
+	disabled := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--format", "json", "--file", exportPath})
+	assertSuccessJSONEnvelope(t, disabled)
+	if _, ok := mustMap(t, disabled["data"])["snapshot"]; ok {
+		t.Fatalf("expected auto_snapshot off to skip the snapshot, got %v", disabled)
+	}
+}
+
+func TestEntryFixCurrencyTakesSafetySnapshot(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	dbPath := filepath.Join(tempDir, "boring-budget.db")
+	migrationsDir := cliMigrationsPath(t)
+
+	db, err := sqlitestore.OpenAndMigrate(context.Background(), dbPath, migrationsDir)
+	if err != nil {
+		t.Fatalf("open and migrate db for safety snapshot: %v", err)
+	}
+	opts := &RootOptions{Output: output.FormatJSON, DBPath: dbPath, MigrationsDir: migrationsDir, db: db}
+	t.Cleanup(func() {
+		if opts.db != nil {
+			_ = opts.db.Close()
+		}
+	})
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "7.50", "--currency", "UDS", "--date", "2026-02-06",
+	}))
 
-	errorMap := mustMap(t, restorePayload["error"])
-	if code, _ := errorMap["code"].(string); strings.TrimSpace(code) == "" {
-		t.Fatalf("expected non-empty error code in restore failure payload=%v", restorePayload)
-	}
+	executeFixCurrency := func(args ...string) map[string]any {
+		t.Helper()
 
-	if opts.db == nil {
-		t.Fatalf("expected rollback to reopen original database handle")
-	}
-	if count := activeTransactionCount(t, opts.db); count != 2 {
-		t.Fatalf("expected rollback to preserve two transactions, got %d", count)
+		cmd := NewEntryCmd(opts)
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"fix-currency", "--from", "UDS", "--to", "USD"}, args...))
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("execute entry fix-currency %v: %v", args, err)
+		}
+		payload := map[string]any{}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("unmarshal entry fix-currency payload: %v raw=%s", err, buf.String())
+		}
+		return payload
 	}
-	if count := activeTransactionCountByNote(t, opts.db, "pre-rollback-check"); count != 1 {
-		t.Fatalf("expected pre-rollback-check entry after failed restore, got %d", count)
+
+	dryRun := executeFixCurrency("--dry-run")
+	mustEntrySuccess(t, dryRun)
+	if _, ok := mustMap(t, dryRun["data"])["snapshot"]; ok {
+		t.Fatalf("expected --dry-run not to snapshot, got %v", dryRun)
 	}
-	if count := activeTransactionCountByNote(t, opts.db, "still-present-after-failure"); count != 1 {
-		t.Fatalf("expected still-present-after-failure entry after failed restore, got %d", count)
+
+	fixed := executeFixCurrency()
+	mustEntrySuccess(t, fixed)
+	snapshot := mustMap(t, mustMap(t, fixed["data"])["snapshot"])
+	snapshotPath, _ := snapshot["path"].(string)
+	if filepath.Dir(snapshotPath) != filepath.Join(tempDir, "snapshots") || snapshot["reason"] != "entry fix-currency" {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
 	}
 
-	for _, path := range []string{
-		dbPath + ".restore.tmp",
-		dbPath + ".restore.rollback",
-		dbPath + ".restore.rollback-wal",
-		dbPath + ".restore.rollback-shm",
-	} {
-		if _, err := os.Stat(path); !os.IsNotExist(err) {
-			t.Fatalf("expected restore artifact %q to be cleaned up after rollback; stat err=%v", path, err)
-		}
+	opts.NoSnapshot = true
+	assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, opts, []string{"restore", "--file", snapshotPath}))
+	var currencyCode string
+	if err := opts.db.QueryRow(`SELECT currency_code FROM transactions WHERE deleted_at_utc IS NULL`).Scan(&currencyCode); err != nil {
+		t.Fatalf("query restored entry currency: %v", err)
+	}
+	if currencyCode != "UDS" {
+		t.Fatalf("expected restoring the snapshot to undo the fix, got %s", currencyCode)
 	}
 }
 
@@ -796,6 +1950,460 @@ func TestDataCommandCSVExportReportShape(t *testing.T) {
 	}
 }
 
+func TestDataCommandCSVExportReportTidyShape(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := strconv.FormatInt(insertTestCategory(t, db, "Food"), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "120.00", "--currency", "USD", "--date", "2026-02-01"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2026-02-05", "--category-id", foodID}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "1500", "--currency", "JPY", "--date", "2026-02-06", "--category-id", foodID}))
+
+	exportPath := filepath.Join(t.TempDir(), "report.csv")
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"export",
+		"--resource", "report",
+		"--format", "csv",
+		"--csv-shape", "tidy",
+		"--file", exportPath,
+		"--report-scope", "monthly",
+		"--report-month", "2026-02",
+	})
+	if ok, _ := payload["ok"].(bool); !ok {
+		t.Fatalf("expected ok=true payload=%v", payload)
+	}
+
+	rows := readCSVFile(t, exportPath)
+	assertCSVRowEqual(t, rows[0], []string{"section", "dimension", "key", "currency", "amount_major"})
+
+	found := map[string]string{}
+	for _, row := range rows[1:] {
+		if len(row) != 5 {
+			t.Fatalf("expected 5 columns per tidy row, got %v", row)
+		}
+		found[strings.Join(row[:4], "|")] = row[4]
+	}
+	for key, want := range map[string]string{
+		"earnings|total||USD":        "120.00",
+		"spending|total||USD":        "30.00",
+		"spending|total||JPY":        "1500",
+		"spending|month|2026-02|USD": "30.00",
+		"spending|category|Food|JPY": "1500",
+		"net|total||USD":             "90.00",
+	} {
+		if got, ok := found[key]; !ok || got != want {
+			t.Fatalf("expected tidy row %s = %s, got %q (rows=%v)", key, want, got, rows)
+		}
+	}
+
+	misplaced := executeDataCmdJSONWithOptions(t, opts, []string{
+		"export",
+		"--format", "csv",
+		"--csv-shape", "tidy",
+		"--file", filepath.Join(t.TempDir(), "entries.csv"),
+	})
+	if code := mustMap(t, misplaced["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for csv-shape on entries, got %v", misplaced)
+	}
+}
+
+func TestDataCommandJSONExportFlows(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	salaryID := strconv.FormatInt(insertTestCategory(t, db, "Salary"), 10)
+	foodID := strconv.FormatInt(insertTestCategory(t, db, "Food"), 10)
+	cardID := strconv.FormatInt(insertTestCard(t, db, "Visa", "", "1234", "visa", "debit", 0), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "100.00", "--currency", "USD", "--date", "2026-02-01", "--category-id", salaryID}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2026-02-02", "--category-id", foodID}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "12.50", "--currency", "USD", "--date", "2026-02-03", "--category-id", foodID, "--payment-method", "card", "--card-id", cardID}))
+
+	exportPath := filepath.Join(t.TempDir(), "flows.json")
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"export",
+		"--resource", "flows",
+		"--format", "json",
+		"--file", exportPath,
+		"--report-scope", "monthly",
+		"--report-month", "2026-02",
+	})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["nodes"] != float64(5) || data["links"] != float64(4) {
+		t.Fatalf("expected 5 nodes and 4 links, got %v", data)
+	}
+
+	raw, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("read flows export: %v", err)
+	}
+	graph := map[string]any{}
+	if err := json.Unmarshal(raw, &graph); err != nil {
+		t.Fatalf("unmarshal flows export: %v raw=%s", err, raw)
+	}
+	links := map[string]string{}
+	for _, item := range mustAnySlice(t, graph["links"]) {
+		link := mustMap(t, item)
+		links[link["source"].(string)+"->"+link["target"].(string)] = link["value_major"].(string)
+	}
+	expected := map[string]string{
+		"income:category:" + salaryID + "->budget":                 "100.00",
+		"budget->spending:category:" + foodID:                      "42.50",
+		"spending:category:" + foodID + "->payment:cash":           "30.00",
+		"spending:category:" + foodID + "->payment:card:" + cardID: "12.50",
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("expected links %v, got %v", expected, links)
+	}
+	for key, value := range expected {
+		if links[key] != value {
+			t.Fatalf("expected link %s=%s, got %v", key, value, links)
+		}
+	}
+
+	csvPayload := executeDataCmdJSONWithOptions(t, opts, []string{
+		"export", "--resource", "flows", "--format", "csv", "--file", exportPath,
+		"--report-scope", "monthly", "--report-month", "2026-02",
+	})
+	if code := mustMap(t, csvPayload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for csv flows export, got %v", code)
+	}
+}
+
+func TestDataCommandJSONExportImportAllResources(t *testing.T) {
+	t.Parallel()
+
+	sourceDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = sourceDB.Close() })
+
+	executeSetupCmdRaw(t, sourceDB, output.FormatJSON, []string{"init", "--default-currency", "USD", "--timezone", "America/New_York"})
+	categoryID := insertTestCategory(t, sourceDB, "Food")
+	labelID := insertTestLabel(t, sourceDB, "Recurring")
+	cardID := insertTestCard(t, sourceDB, "Main Visa", "Primary", "1234", "VISA", "credit", 15)
+	mustEntrySuccess(t, executeCapCmdJSON(t, sourceDB, []string{"set", "--month", "2026-02", "--category-id", strconv.FormatInt(categoryID, 10), "--amount", "200.00", "--currency", "USD"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, sourceDB, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "40.00",
+		"--currency", "USD",
+		"--date", "2026-02-10",
+		"--category-id", strconv.FormatInt(categoryID, 10),
+		"--label-id", strconv.FormatInt(labelID, 10),
+		"--payment-method", "card",
+		"--card-id", strconv.FormatInt(cardID, 10),
+		"--note", "groceries",
+	}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{
+		"payment", "add",
+		"--card-id", strconv.FormatInt(cardID, 10),
+		"--amount", "15.00",
+		"--currency", "USD",
+	}))
+
+	exportPath := filepath.Join(t.TempDir(), "dataset.json")
+	exportPayload := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: sourceDB}, []string{
+		"export",
+		"--resource", "all",
+		"--format", "json",
+		"--file", exportPath,
+	})
+	assertSuccessJSONEnvelope(t, exportPayload)
+	exported := mustMap(t, mustMap(t, exportPayload["data"])["exported"])
+	if exported["entries"].(float64) != 1 || exported["categories"].(float64) != 1 || exported["caps"].(float64) != 1 || exported["liability_events"].(float64) != 1 || exported["settings"] != true {
+		t.Fatalf("unexpected export counts: %v", exported)
+	}
+
+	targetDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = targetDB.Close() })
+	existingFoodID := insertTestCategory(t, targetDB, "food")
+	insertTestCategory(t, targetDB, "Placeholder")
+	targetOpts := &RootOptions{Output: output.FormatJSON, db: targetDB}
+
+	importPayload := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+		"import",
+		"--resource", "all",
+		"--format", "json",
+		"--file", exportPath,
+	})
+	assertSuccessJSONEnvelope(t, importPayload)
+	importData := mustMap(t, importPayload["data"])
+	if importData["imported"].(float64) != 1 {
+		t.Fatalf("expected imported=1, got %v", importData["imported"])
+	}
+	restored := mustMap(t, importData["restored"])
+	if restored["categories_matched"].(float64) != 1 || restored["categories_created"].(float64) != 0 || restored["labels_created"].(float64) != 1 || restored["cards_created"].(float64) != 1 || restored["caps_restored"].(float64) != 1 || restored["liability_events_created"].(float64) != 1 || restored["settings_restored"] != true {
+		t.Fatalf("unexpected restore counts: %v", restored)
+	}
+
+	listPayload := executeEntryCmdJSON(t, targetDB, []string{"list"})
+	mustEntrySuccess(t, listPayload)
+	entries := mustAnySlice(t, mustMap(t, listPayload["data"])["entries"])
+	if len(entries) != 1 {
+		t.Fatalf("expected one imported entry, got %d", len(entries))
+	}
+	entry := mustMap(t, entries[0])
+	if int64(entry["category_id"].(float64)) != existingFoodID {
+		t.Fatalf("expected entry matched to existing category %d, got %v", existingFoodID, entry["category_id"])
+	}
+
+	debt := mustMap(t, mustMap(t, executeCardCmdJSON(t, targetDB, []string{"debt", "show", "--card-id", "1"})["data"])["debt"])
+	bucket := mustMap(t, mustAnySlice(t, debt["buckets"])[0])
+	if int64(bucket["balance_minor_signed"].(float64)) != 2500 {
+		t.Fatalf("expected restored debt 2500 (charge minus payment), got %v", bucket["balance_minor_signed"])
+	}
+
+	reimport := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+		"import",
+		"--resource", "all",
+		"--format", "json",
+		"--file", exportPath,
+		"--idempotent",
+	})
+	assertSuccessJSONEnvelope(t, reimport)
+	reimportData := mustMap(t, reimport["data"])
+	if reimportData["imported"].(float64) != 0 || reimportData["skipped"].(float64) != 1 {
+		t.Fatalf("expected idempotent re-import to skip the entry, got %v", reimportData)
+	}
+	if skipped := mustMap(t, reimportData["restored"])["liability_events_skipped"].(float64); skipped != 1 {
+		t.Fatalf("expected idempotent re-import to skip the payment, got %v", skipped)
+	}
+
+	csvPayload := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+		"export",
+		"--resource", "all",
+		"--format", "csv",
+		"--file", exportPath,
+	})
+	if csvPayload["ok"] != false || mustMap(t, csvPayload["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected csv dataset export to fail with INVALID_ARGUMENT, got %v", csvPayload)
+	}
+}
+
+func TestDataCommandJSONExportImportAllResourcesFullState(t *testing.T) {
+	t.Parallel()
+
+	sourceDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = sourceDB.Close() })
+
+	executeSetupCmdRaw(t, sourceDB, output.FormatJSON, []string{"init", "--default-currency", "USD", "--timezone", "UTC"})
+	categoryArg := strconv.FormatInt(insertTestCategory(t, sourceDB, "Travel"), 10)
+	labelArg := strconv.FormatInt(insertTestLabel(t, sourceDB, "Trips"), 10)
+	oldCardArg := strconv.FormatInt(insertTestCard(t, sourceDB, "Old Amex", "Retired", "1111", "AMEX", "credit", 10), 10)
+	mainCardArg := strconv.FormatInt(insertTestCard(t, sourceDB, "Main Visa", "Everyday", "2222", "VISA", "credit", 15), 10)
+
+	mustEntrySuccess(t, executeCategoryCmdJSON(t, sourceDB, []string{"update", categoryArg, "--color", "#336699", "--icon", "\u2708\ufe0f"}))
+	mustEntrySuccess(t, executeCategoryCmdJSON(t, sourceDB, []string{"archive", categoryArg}))
+	mustEntrySuccess(t, executeLabelCmdJSON(t, sourceDB, []string{"update", labelArg, "--color", "#f80"}))
+	mustEntrySuccess(t, executeLabelCmdJSON(t, sourceDB, []string{"archive", labelArg}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{"alias", "add", oldCardArg, "green card"}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{"alias", "add", mainCardArg, "visa"}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{"limit", "set", "--card-id", mainCardArg, "--amount", "500.00", "--currency", "USD", "--month", "2026-03"}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{"archive", oldCardArg}))
+	for key, value := range map[string]string{
+		"default_output":         "json",
+		"default_card_id":        mainCardArg,
+		"fiscal_month_start_day": "25",
+		"default_recorded_by":    "Sam",
+		"auto_snapshot":          "off",
+		"fx_cache_ttl_hours":     "6",
+		"fx_stale_after_days":    "3",
+		"report_cache":           "on",
+	} {
+		assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, sourceDB, []string{"set", key, value}))
+	}
+
+	exportArchive := func(db *sql.DB) (string, map[string]any) {
+		t.Helper()
+		exportPath := filepath.Join(t.TempDir(), "dataset.json")
+		assertSuccessJSONEnvelope(t, executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: db}, []string{
+			"export", "--resource", "all", "--format", "json", "--file", exportPath,
+		}))
+		raw, err := os.ReadFile(exportPath)
+		if err != nil {
+			t.Fatalf("read dataset export: %v", err)
+		}
+		archive := map[string]any{}
+		if err := json.Unmarshal(raw, &archive); err != nil {
+			t.Fatalf("unmarshal dataset export: %v raw=%s", err, raw)
+		}
+		return exportPath, archive
+	}
+
+	archivePath, sourceArchive := exportArchive(sourceDB)
+
+	targetDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = targetDB.Close() })
+	importPayload := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: targetDB}, []string{
+		"import", "--resource", "all", "--format", "json", "--file", archivePath,
+	})
+	assertSuccessJSONEnvelope(t, importPayload)
+	restored := mustMap(t, mustMap(t, importPayload["data"])["restored"])
+	if restored["card_aliases_created"] != float64(2) || restored["card_limits_restored"] != float64(1) {
+		t.Fatalf("unexpected restore counts: %v", restored)
+	}
+
+	_, targetArchive := exportArchive(targetDB)
+	if !reflect.DeepEqual(sourceArchive, targetArchive) {
+		t.Fatalf("expected the restored dataset to export unchanged\nsource: %v\ntarget: %v", sourceArchive, targetArchive)
+	}
+
+	cards := map[string]map[string]any{}
+	for _, item := range mustAnySlice(t, targetArchive["cards"]) {
+		card := mustMap(t, item)
+		cards[card["nickname"].(string)] = card
+	}
+	if _, ok := cards["Old Amex"]["archived_at_utc"]; !ok {
+		t.Fatalf("expected the archived card to stay archived, got %v", cards["Old Amex"])
+	}
+	if limits := mustAnySlice(t, cards["Main Visa"]["monthly_limits"]); len(limits) != 1 || mustMap(t, limits[0])["amount_minor"] != float64(50000) {
+		t.Fatalf("expected the card limit restored, got %v", cards["Main Visa"])
+	}
+	category := mustMap(t, mustAnySlice(t, targetArchive["categories"])[0])
+	if category["color"] != "#336699" || category["icon"] != "\u2708\ufe0f" || category["archived_at_utc"] == nil {
+		t.Fatalf("expected category appearance and archive restored, got %v", category)
+	}
+	preferences := mustMap(t, mustMap(t, targetArchive["settings"])["preferences"])
+	if preferences["default_card"] != "Main Visa" || preferences["fiscal_month_start_day"] != float64(25) || preferences["report_cache"] != true {
+		t.Fatalf("expected settings preferences restored, got %v", preferences)
+	}
+
+	mustEntrySuccess(t, executeCardCmdJSON(t, targetDB, []string{"limit", "list", "--card-nickname", "VISA"}))
+}
+
+func TestDataCommandJSONCardEventsExportImport(t *testing.T) {
+	t.Parallel()
+
+	sourceDB := newCLITestDB(t)
+	t.Cleanup(func() { _ = sourceDB.Close() })
+	cardID := insertTestCard(t, sourceDB, "Main Visa", "", "1234", "VISA", "credit", 15)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, sourceDB, []string{
+		"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-02-10",
+		"--payment-method", "card", "--card-id", strconv.FormatInt(cardID, 10),
+	}))
+	mustEntrySuccess(t, executeCardCmdJSON(t, sourceDB, []string{
+		"payment", "add", "--card-id", strconv.FormatInt(cardID, 10), "--amount", "15.00", "--currency", "USD", "--note", "statement",
+	}))
+
+	for _, format := range []string{"json", "csv"} {
+		exportPath := filepath.Join(t.TempDir(), "card-events."+format)
+		exportPayload := executeDataCmdJSONWithOptions(t, &RootOptions{Output: output.FormatJSON, db: sourceDB}, []string{
+			"export", "--resource", "card-events", "--format", format, "--file", exportPath,
+		})
+		assertSuccessJSONEnvelope(t, exportPayload)
+		if exported := mustMap(t, exportPayload["data"])["exported"]; exported != float64(1) {
+			t.Fatalf("%s: expected only the payment exported, got %v", format, exported)
+		}
+
+		targetDB := newCLITestDB(t)
+		t.Cleanup(func() { _ = targetDB.Close() })
+		targetOpts := &RootOptions{Output: output.FormatJSON, db: targetDB}
+
+		missingCard := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+			"import", "--resource", "card-events", "--format", format, "--file", exportPath,
+		})
+		if missingCard["ok"] != false || mustMap(t, missingCard["error"])["code"] != "NOT_FOUND" {
+			t.Fatalf("%s: expected NOT_FOUND without a matching card, got %v", format, missingCard)
+		}
+
+		targetCardID := insertTestCard(t, targetDB, "main visa", "", "9999", "VISA", "credit", 5)
+		importPayload := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+			"import", "--resource", "card-events", "--format", format, "--file", exportPath,
+		})
+		assertSuccessJSONEnvelope(t, importPayload)
+		if imported := mustMap(t, importPayload["data"])["imported"]; imported != float64(1) {
+			t.Fatalf("%s: expected one imported event, got %v", format, imported)
+		}
+
+		history := mustMap(t, mustMap(t, executeCardCmdJSON(t, targetDB, []string{"debt", "history", "--card-id", strconv.FormatInt(targetCardID, 10)})["data"])["history"])
+		event := mustMap(t, mustAnySlice(t, history["events"])[0])
+		if event["event_type"] != "payment" || event["amount_minor_signed"] != float64(-1500) || event["note"] != "statement" {
+			t.Fatalf("%s: unexpected imported event %v", format, event)
+		}
+
+		reimport := executeDataCmdJSONWithOptions(t, targetOpts, []string{
+			"import", "--resource", "card-events", "--format", format, "--file", exportPath, "--idempotent",
+		})
+		assertSuccessJSONEnvelope(t, reimport)
+		if data := mustMap(t, reimport["data"]); data["imported"] != float64(0) || data["skipped"] != float64(1) {
+			t.Fatalf("%s: expected idempotent re-import to skip the event, got %v", format, data)
+		}
+	}
+}
+
+func TestDataCommandJSONAuditExportImport(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+
+	categoryID := insertTestCategory(t, db, "Food")
+	if _, err := db.ExecContext(context.Background(), `UPDATE categories SET name = 'Groceries' WHERE id = ?;`, categoryID); err != nil {
+		t.Fatalf("rename category: %v", err)
+	}
+
+	exportPath := filepath.Join(t.TempDir(), "audit.json")
+	exportPayload := executeDataCmdJSONWithOptions(t, opts, []string{"export", "--resource", "audit", "--format", "json", "--file", exportPath})
+	assertSuccessJSONEnvelope(t, exportPayload)
+
+	content, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("read audit export: %v", err)
+	}
+	var exported struct {
+		AuditEvents []map[string]any `json:"audit_events"`
+	}
+	if err := json.Unmarshal(content, &exported); err != nil {
+		t.Fatalf("unmarshal audit export: %v", err)
+	}
+	if count := mustMap(t, exportPayload["data"])["exported"]; count != float64(len(exported.AuditEvents)) {
+		t.Fatalf("expected exported count %d, got %v", len(exported.AuditEvents), count)
+	}
+
+	var rename map[string]any
+	for _, event := range exported.AuditEvents {
+		if event["entity_type"] == "category" && event["operation"] == "update" {
+			rename = event
+		}
+	}
+	if rename == nil {
+		t.Fatalf("expected category rename in audit export, got %v", exported.AuditEvents)
+	}
+	if mustMap(t, rename["before"])["name"] != "Food" || mustMap(t, rename["after"])["name"] != "Groceries" {
+		t.Fatalf("expected before/after snapshots of the rename, got %v", rename)
+	}
+	if rename["actor"] != "db_trigger" || rename["hash"] == "" {
+		t.Fatalf("expected actor and hash on audit record, got %v", rename)
+	}
+
+	// Simulate restoring a backup taken before the rename.
+	if _, err := db.ExecContext(context.Background(), `DELETE FROM audit_events WHERE entity_type = 'category' AND action = 'update';`); err != nil {
+		t.Fatalf("drop audit events: %v", err)
+	}
+
+	importPayload := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--resource", "audit", "--format", "json", "--file", exportPath})
+	assertSuccessJSONEnvelope(t, importPayload)
+	if data := mustMap(t, importPayload["data"]); data["imported"] != float64(1) || data["skipped"] != float64(len(exported.AuditEvents)-1) {
+		t.Fatalf("expected only the missing event imported, got %v", data)
+	}
+
+	tampered := strings.Replace(string(content), `"Groceries"`, `"Rent"`, 1)
+	tamperedPath := filepath.Join(t.TempDir(), "tampered.json")
+	if err := os.WriteFile(tamperedPath, []byte(tampered), 0o600); err != nil {
+		t.Fatalf("write tampered audit file: %v", err)
+	}
+	rejected := executeDataCmdJSONWithOptions(t, opts, []string{"import", "--resource", "audit", "--format", "json", "--file", tamperedPath})
+	if rejected["ok"] != false || mustMap(t, rejected["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for a tampered audit file, got %v", rejected)
+	}
+}
+
 func executeDataCmdJSONWithOptions(t *testing.T, opts *RootOptions, args []string) map[string]any {
 	t.Helper()
 
@@ -823,6 +2431,33 @@ func executeDataCmdRawWithOptions(t *testing.T, opts *RootOptions, args []string
 	return strings.TrimSpace(buf.String())
 }
 
+func executeDataCmdSplitWithOptions(t *testing.T, opts *RootOptions, args []string) (string, string) {
+	t.Helper()
+
+	cmd := NewDataCmd(opts)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute data cmd %v: %v", args, err)
+	}
+
+	return strings.TrimSpace(stdout.String()), stderr.String()
+}
+
+func decodeDataPayload(t *testing.T, raw string) map[string]any {
+	t.Helper()
+
+	payload := map[string]any{}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal data payload: %v raw=%s", err, raw)
+	}
+	return payload
+}
+
 func readExportFile(t *testing.T, filePath string) dataExportFile {
 	t.Helper()
 
@@ -1050,3 +2685,49 @@ func activeTransactionCountByNote(t *testing.T, db *sql.DB, note string) int64 {
 
 	return count
 }
+
+func TestDataMaintainReportsSizesAndTableCounts(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+
+	var deletedID int64
+	for i, note := range []string{"keep", "drop"} {
+		added := executeEntryCmdJSON(t, db, []string{
+			"add",
+			"--type", "expense",
+			"--amount", "3.00",
+			"--currency", "USD",
+			"--date", "2026-02-0" + strconv.Itoa(i+1),
+			"--note", note,
+		})
+		mustEntrySuccess(t, added)
+		deletedID = int64(mustMap(t, mustMap(t, added["data"])["entry"])["id"].(float64))
+	}
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"delete", strconv.FormatInt(deletedID, 10)}))
+
+	payload := executeDataCmdJSONWithOptions(t, opts, []string{"maintain"})
+	assertSuccessJSONEnvelope(t, payload)
+	report := mustMap(t, mustMap(t, payload["data"])["maintenance"])
+
+	after := mustMap(t, report["size_after"])
+	if after["free_pages"].(float64) != 0 || after["bytes"].(float64) <= 0 {
+		t.Fatalf("expected a compacted database after maintenance, got %v", after)
+	}
+	if _, ok := report["reclaimed_bytes"]; !ok {
+		t.Fatalf("expected reclaimed_bytes in %v", report)
+	}
+
+	var transactions map[string]any
+	for _, raw := range mustAnySlice(t, report["tables"]) {
+		table := mustMap(t, raw)
+		if table["table"] == "transactions" {
+			transactions = table
+		}
+	}
+	if transactions == nil || transactions["rows"].(float64) != 2 || transactions["soft_deleted"].(float64) != 1 {
+		t.Fatalf("expected 2 transaction rows with 1 soft-deleted, got %v", transactions)
+	}
+}
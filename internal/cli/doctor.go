@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type doctorCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *doctorCLIError) Error() string {
+	if e == nil {
+		return "doctor command error"
+	}
+	return e.Message
+}
+
+func NewDoctorCmd(opts *RootOptions) *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check database health and optionally apply safe repairs",
+		Long: `Run SQLite integrity and foreign-key checks, look for label links and
+payment-method rows whose entry is gone, find card liability events that
+reference deleted entries, and compare the schema version with the latest
+migration.
+
+With --fix, pending migrations are applied and the orphaned rows are repaired
+in a single transaction. Integrity errors and payment-method rows pointing at
+a missing card are reported but never changed; restore a backup instead.`,
+		Annotations: map[string]string{skipAutoMigrateAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printDoctorError(cmd, outputFormat(opts), &doctorCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "doctor does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if opts == nil || opts.db == nil {
+				return printDoctorError(cmd, outputFormat(opts), &doctorCLIError{
+					Code:    "DB_ERROR",
+					Message: "database operation failed",
+					Details: map[string]any{"reason": "database connection unavailable"},
+				})
+			}
+
+			report, err := sqlitestore.Diagnose(cmd.Context(), opts.db, opts.MigrationsDir, fix)
+			if err != nil {
+				return printDoctorError(cmd, outputFormat(opts), err)
+			}
+
+			warnings := []output.WarningPayload{}
+			if !report.Healthy {
+				warnings = append(warnings, output.WarningPayload{
+					Code:    domain.WarningCodeDatabaseIssuesFound,
+					Message: domain.DatabaseIssuesFoundWarningMessage,
+				})
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"doctor": report}, warnings)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, doctorTables(report))
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply pending migrations and repair orphaned rows")
+
+	return cmd
+}
+
+func printDoctorError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *doctorCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope("DB_ERROR", "database operation failed", map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
@@ -28,10 +28,21 @@ type entryAddFlags struct {
 	bankAccountIDRaw string
 	labelIDRaw       []string
 	note             string
+	payee            string
+	by               string
+	source           string
+	location         string
+	recordIn         string
 	paymentMethod    string
 	cardIDRaw        string
 	cardNickname     string
 	cardLookupText   string
+	refundOfRaw      string
+	allowArchived    bool
+	interactive      bool
+	shared           bool
+	splitWithRaw     []string
+	jsonInput        string
 }
 
 type entryListFlags struct {
@@ -41,12 +52,21 @@ type entryListFlags struct {
 	fromRaw          string
 	toRaw            string
 	noteContains     string
+	payee            string
+	by               string
+	source           string
+	locationContains string
+	currency         string
+	amountMin        string
+	amountMax        string
+	amountFormat     string
 	labelIDRaw       []string
 	labelMode        string
 	paymentMethod    string
 	cardIDRaw        string
 	cardNickname     string
 	cardLookupText   string
+	expand           string
 }
 
 type entryUpdateFlags struct {
@@ -62,10 +82,21 @@ type entryUpdateFlags struct {
 	clearLabels      bool
 	note             string
 	clearNote        bool
+	payee            string
+	clearPayee       bool
+	by               string
+	clearBy          bool
+	source           string
+	clearSource      bool
+	location         string
+	clearLocation    bool
 	paymentMethod    string
 	cardIDRaw        string
 	cardNickname     string
 	cardLookupText   string
+	ifUpdatedAt      string
+	allowArchived    bool
+	jsonInput        string
 }
 
 type entryCLIError struct {
@@ -89,9 +120,16 @@ func NewEntryCmd(opts *RootOptions) *cobra.Command {
 
 	cmd.AddCommand(
 		newEntryAddCmd(opts),
+		newEntryAddBatchCmd(opts),
+		newEntryQuickCmd(opts),
 		newEntryUpdateCmd(opts),
 		newEntryListCmd(opts),
+		newEntryShowCmd(opts),
+		newEntryHistoryCmd(opts),
+		newEntryRevertCmd(opts),
 		newEntryDeleteCmd(opts),
+		newEntryFixCurrencyCmd(opts),
+		newEntryTriageCmd(opts),
 	)
 
 	return cmd
@@ -122,7 +160,12 @@ func newEntryUpdateCmd(opts *RootOptions) *cobra.Command {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
 
-			input, err := buildEntryUpdateInput(cmd, id, flags)
+			var input domain.EntryUpdateInput
+			if cmd.Flags().Changed(jsonInputFlag) {
+				input, err = buildEntryUpdateInputFromJSON(cmd, id, flags)
+			} else {
+				input, err = buildEntryUpdateInput(cmd, id, flags, amountFormat(opts))
+			}
 			if err != nil {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
@@ -152,10 +195,21 @@ func newEntryUpdateCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().BoolVar(&flags.clearLabels, "clear-labels", false, "Clear all labels")
 	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note value to set")
 	cmd.Flags().BoolVar(&flags.clearNote, "clear-note", false, "Clear note")
+	cmd.Flags().StringVar(&flags.payee, "payee", "", "Optional payee (merchant) to set")
+	cmd.Flags().BoolVar(&flags.clearPayee, "clear-payee", false, "Clear payee")
+	cmd.Flags().StringVar(&flags.by, "by", "", "Optional person the entry is recorded by")
+	cmd.Flags().BoolVar(&flags.clearBy, "clear-by", false, "Clear recorded by")
+	cmd.Flags().StringVar(&flags.source, "source", "", "Optional income source to set (income only)")
+	cmd.Flags().BoolVar(&flags.clearSource, "clear-source", false, "Clear income source")
+	cmd.Flags().StringVar(&flags.location, "location", "", "Optional location to set (free text or lat,long)")
+	cmd.Flags().BoolVar(&flags.clearLocation, "clear-location", false, "Clear location")
 	cmd.Flags().StringVar(&flags.paymentMethod, "payment-method", "", "Optional payment method: cash|card")
 	cmd.Flags().StringVar(&flags.cardIDRaw, "card-id", "", "Optional card ID selector")
 	cmd.Flags().StringVar(&flags.cardNickname, "card-nickname", "", "Optional card nickname selector")
 	cmd.Flags().StringVar(&flags.cardLookupText, "card-lookup", "", "Optional card lookup selector")
+	cmd.Flags().StringVar(&flags.ifUpdatedAt, "if-updated-at", "", "Only update if updated_at_utc still matches this value")
+	cmd.Flags().BoolVar(&flags.allowArchived, "allow-archived", false, "Accept archived categories and labels")
+	cmd.Flags().StringVar(&flags.jsonInput, jsonInputFlag, "", "Read the changes as an entry JSON object from this file, or - for stdin; keys left out stay unchanged and null clears")
 
 	return cmd
 }
@@ -180,10 +234,33 @@ func newEntryAddCmd(opts *RootOptions) *cobra.Command {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
 
-			input, err := buildEntryAddInput(cmd, flags)
+			if flags.interactive && !cmd.Flags().Changed(jsonInputFlag) {
+				if err := promptEntryAddFlags(cmd, opts); err != nil {
+					if errors.Is(err, errInteractiveInputEnded) {
+						return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+							Code:    "INVALID_ARGUMENT",
+							Message: "interactive input ended before all fields were answered",
+							Details: map[string]any{"field": "interactive"},
+						})
+					}
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+			}
+
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currency)
+
+			var input domain.EntryAddInput
+			if cmd.Flags().Changed(jsonInputFlag) {
+				input, err = buildEntryAddInputFromJSON(cmd, flags)
+			} else {
+				applyCardEntryCurrency(cmd, flags, opts)
+				input, err = buildEntryAddInput(cmd, flags, amountFormat(opts))
+			}
 			if err != nil {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
+			applyDefaultEntryCard(&input, opts)
+			applyDefaultEntryRecordedBy(&input, opts)
 
 			result, err := svc.AddWithWarnings(cmd.Context(), input)
 			if err != nil {
@@ -200,16 +277,27 @@ func newEntryAddCmd(opts *RootOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.entryType, "type", "", "Entry type: income|expense")
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Amount in major units (e.g. 74.25)")
-	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (e.g. USD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings); card expenses default to the card's currency")
 	cmd.Flags().StringVar(&flags.dateRaw, "date", "", "Transaction date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Optional category ID")
 	cmd.Flags().StringVar(&flags.bankAccountIDRaw, "bank-account-id", "", "Optional bank account ID")
 	cmd.Flags().StringArrayVar(&flags.labelIDRaw, "label-id", nil, "Optional label ID (repeatable)")
 	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
+	cmd.Flags().StringVar(&flags.payee, "payee", "", "Optional payee (merchant)")
+	cmd.Flags().StringVar(&flags.by, "by", "", "Person recording the entry (defaults to the default_recorded_by setting)")
+	cmd.Flags().StringVar(&flags.source, "source", "", "Income source, e.g. salary|freelance|dividends (income only)")
+	cmd.Flags().StringVar(&flags.location, "location", "", "Optional location, free text (\"Lisbon\") or lat,long")
+	cmd.Flags().StringVar(&flags.recordIn, "record-in", "", "Convert the amount into this currency at the entry date and keep the original (ISO code)")
 	cmd.Flags().StringVar(&flags.paymentMethod, "payment-method", "", "Payment method: cash|card (expense only)")
 	cmd.Flags().StringVar(&flags.cardIDRaw, "card-id", "", "Card ID selector")
 	cmd.Flags().StringVar(&flags.cardNickname, "card-nickname", "", "Card nickname selector")
 	cmd.Flags().StringVar(&flags.cardLookupText, "card-lookup", "", "Card lookup selector")
+	cmd.Flags().StringVar(&flags.refundOfRaw, "refund-of", "", "Record this expense as a refund of an earlier expense entry ID")
+	cmd.Flags().BoolVar(&flags.allowArchived, "allow-archived", false, "Accept archived categories and labels")
+	cmd.Flags().BoolVar(&flags.shared, "shared", false, "Record a shared expense paid by --by and split with other people")
+	cmd.Flags().StringArrayVar(&flags.splitWithRaw, "split-with", nil, "Person and share of a shared expense, e.g. ana:50% (repeatable; requires --shared)")
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "Prompt for fields not given as flags (category, label and card accept fuzzy names)")
+	cmd.Flags().StringVar(&flags.jsonInput, jsonInputFlag, "", "Read the entry as JSON from this file, or - for stdin (accepts entry JSON output)")
 
 	return cmd
 }
@@ -234,21 +322,37 @@ func newEntryListCmd(opts *RootOptions) *cobra.Command {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
 
+			flags.amountFormat = amountFormat(opts)
 			filter, err := buildEntryListFilter(flags)
 			if err != nil {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
 
+			expand, err := domain.ParseEntryExpand(flags.expand)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
 			entries, err := svc.List(cmd.Context(), filter)
 			if err != nil {
 				return printEntryError(cmd, entryOutputFormat(opts), err)
 			}
 
+			var entriesPayload any = entries
+			if expand.Any() {
+				expanded, err := svc.Expand(cmd.Context(), entries, expand)
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+				entriesPayload = expanded
+			}
+
 			env := output.NewSuccessEnvelope(map[string]any{
-				"entries": entries,
+				"entries": entriesPayload,
 				"count":   len(entries),
 			}, nil)
-			return output.Print(cmd.OutOrStdout(), entryOutputFormat(opts), env)
+			env.Meta.Aggregates = domain.BuildEntryListAggregates(entries)
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, entryListTables(entries))
 		},
 	}
 
@@ -258,13 +362,74 @@ func newEntryListCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Filter start date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Filter end date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.noteContains, "note-contains", "", "Filter entries whose note contains this text (case-insensitive)")
+	cmd.Flags().StringVar(&flags.payee, "payee", "", "Filter by exact payee (case-insensitive)")
+	cmd.Flags().StringVar(&flags.by, "by", "", "Filter by who recorded the entry (case-insensitive)")
+	cmd.Flags().StringVar(&flags.source, "source", "", "Filter by income source (case-insensitive)")
+	cmd.Flags().StringVar(&flags.locationContains, "location-contains", "", "Filter entries whose location contains this text (case-insensitive)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Filter by currency code (ISO 4217)")
+	cmd.Flags().StringVar(&flags.amountMin, "amount-min", "", "Filter entries of at least this amount in major units (requires --currency)")
+	cmd.Flags().StringVar(&flags.amountMax, "amount-max", "", "Filter entries of at most this amount in major units (requires --currency)")
 	cmd.Flags().StringArrayVar(&flags.labelIDRaw, "label-id", nil, "Filter by label ID (repeatable)")
 	cmd.Flags().StringVar(&flags.labelMode, "label-mode", "any", "Label filter mode: any|all|none")
 	cmd.Flags().StringVar(&flags.paymentMethod, "payment-method", "", "Payment filter: cash|card|credit|debit")
 	cmd.Flags().StringVar(&flags.cardIDRaw, "card-id", "", "Filter by card ID")
 	cmd.Flags().StringVar(&flags.cardNickname, "card-nickname", "", "Filter by exact card nickname")
 	cmd.Flags().StringVar(&flags.cardLookupText, "card-lookup", "", "Filter by card lookup text")
+	cmd.Flags().StringVar(&flags.expand, "expand", "", "Embed related records in JSON output: comma-separated card,category,labels")
+
+	return cmd
+}
+
+func newEntryShowCmd(opts *RootOptions) *cobra.Command {
+	var expandRaw string
+
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show one entry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "show requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			id, err := parsePositiveInt64(args[0], "id")
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			expand, err := domain.ParseEntryExpand(expandRaw)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			entry, err := svc.Get(cmd.Context(), id)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			var entryPayload any = entry
+			if expand.Any() {
+				expanded, err := svc.Expand(cmd.Context(), []domain.Entry{entry}, expand)
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+				entryPayload = expanded[0]
+			}
 
+			env := output.NewSuccessEnvelope(map[string]any{"entry": entryPayload}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, entryListTables([]domain.Entry{entry}))
+		},
+	}
+
+	cmd.Flags().StringVar(&expandRaw, "expand", "", "Embed related records in JSON output: comma-separated card,category,labels")
 	return cmd
 }
 
@@ -319,12 +484,26 @@ func newEntryService(opts *RootOptions) (*service.EntryService, error) {
 	if err != nil {
 		return nil, fmt.Errorf("card service init: %w", err)
 	}
+	labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+	converter, err := newFXConverter(opts)
+	if err != nil {
+		return nil, fmt.Errorf("fx converter init: %w", err)
+	}
 
 	svc, err := service.NewEntryService(
 		entryRepo,
 		service.WithEntryCapLookup(capRepo),
 		service.WithEntryCardResolver(cardSvc),
+		service.WithEntryCardLimitLookup(cardRepo),
+		service.WithEntrySubscriptionAlerts(),
 		service.WithEntryBalanceLinkReader(bankAccountRepo),
+		service.WithEntryExpansion(sqlitestore.NewCategoryRepo(opts.db), labelRepo, cardSvc),
+		service.WithEntryStrictWarnings(opts.db, opts.strictWarnings),
+		service.WithEntryBatchDB(opts.db),
+		service.WithEntryFXConverter(converter),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("entry service init: %w", err)
@@ -333,6 +512,16 @@ func newEntryService(opts *RootOptions) (*service.EntryService, error) {
 	return svc, nil
 }
 
+// strictWarningEnvelope reports a write the strict-warnings policy refused.
+func strictWarningEnvelope(err error) (output.Envelope, bool) {
+	var strictErr *domain.StrictWarningError
+	if !errors.As(err, &strictErr) {
+		return output.Envelope{}, false
+	}
+	warnings := toOutputWarnings(strictErr.Warnings)
+	return output.NewStrictWarningEnvelope(warnings, warnings), true
+}
+
 func toOutputWarnings(warnings []domain.Warning) []output.WarningPayload {
 	if len(warnings) == 0 {
 		return []output.WarningPayload{}
@@ -349,7 +538,76 @@ func toOutputWarnings(warnings []domain.Warning) []output.WarningPayload {
 	return out
 }
 
-func buildEntryAddInput(cmd *cobra.Command, flags *entryAddFlags) (domain.EntryAddInput, error) {
+// applyDefaultEntryCard fills the settings default card into card expenses
+// added without a card selector.
+func applyDefaultEntryCard(input *domain.EntryAddInput, opts *RootOptions) {
+	if input == nil || opts == nil || opts.defaultCardID == nil {
+		return
+	}
+	if !strings.EqualFold(input.PaymentMethod, domain.PaymentMethodCard) {
+		return
+	}
+	if domain.HasCardSelector(input.PaymentCardID, input.PaymentCardNickname, input.PaymentCardLookup) {
+		return
+	}
+	cardID := *opts.defaultCardID
+	input.PaymentCardID = &cardID
+}
+
+// applyCardEntryCurrency switches a card expense added without --currency to
+// the paying card's default currency, if it has one.
+func applyCardEntryCurrency(cmd *cobra.Command, flags *entryAddFlags, opts *RootOptions) {
+	if cmd == nil || flags == nil || cmd.Flags().Changed("currency") {
+		return
+	}
+	if !strings.EqualFold(strings.TrimSpace(flags.paymentMethod), domain.PaymentMethodCard) {
+		return
+	}
+
+	selector := domain.CardSelector{Nickname: flags.cardNickname, Lookup: flags.cardLookupText}
+	if strings.TrimSpace(flags.cardIDRaw) != "" {
+		id, err := parsePositiveInt64(flags.cardIDRaw, "card-id")
+		if err != nil {
+			return
+		}
+		selector.ID = &id
+	} else if !domain.HasCardSelector(nil, flags.cardNickname, flags.cardLookupText) && opts != nil {
+		selector.ID = opts.defaultCardID
+	}
+
+	if currency := cardDefaultCurrency(cmd, opts, selector); currency != "" {
+		flags.currency = currency
+	}
+}
+
+// cardDefaultCurrency returns the default currency of the card selector
+// names. Selector errors are left for the entry service to report, so any
+// failure returns "".
+func cardDefaultCurrency(cmd *cobra.Command, opts *RootOptions, selector domain.CardSelector) string {
+	svc, err := newCardService(opts)
+	if err != nil {
+		return ""
+	}
+	card, err := svc.Resolve(cmd.Context(), selector)
+	if err != nil {
+		return ""
+	}
+	return card.DefaultCurrencyCode
+}
+
+// applyDefaultEntryRecordedBy attributes entries added without --by to the
+// settings default person.
+func applyDefaultEntryRecordedBy(input *domain.EntryAddInput, opts *RootOptions) {
+	if input == nil || opts == nil || opts.defaultBy == "" {
+		return
+	}
+	if strings.TrimSpace(input.RecordedBy) != "" {
+		return
+	}
+	input.RecordedBy = opts.defaultBy
+}
+
+func buildEntryAddInput(cmd *cobra.Command, flags *entryAddFlags, amountFormat string) (domain.EntryAddInput, error) {
 	if flags == nil {
 		return domain.EntryAddInput{}, &entryCLIError{Code: "INTERNAL_ERROR", Message: "entry add flags unavailable", Details: map[string]any{}}
 	}
@@ -398,7 +656,7 @@ func buildEntryAddInput(cmd *cobra.Command, flags *entryAddFlags) (domain.EntryA
 		bankAccountID = &id
 	}
 
-	amountMinor, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currency)
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat)
 	if err != nil {
 		return domain.EntryAddInput{}, err
 	}
@@ -412,6 +670,20 @@ func buildEntryAddInput(cmd *cobra.Command, flags *entryAddFlags) (domain.EntryA
 		paymentCardID = &id
 	}
 
+	var refundOfEntryID *int64
+	if cmd != nil && cmd.Flags().Changed("refund-of") {
+		id, err := parsePositiveInt64(flags.refundOfRaw, "refund-of")
+		if err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		refundOfEntryID = &id
+	}
+
+	splits, err := buildEntrySplits(flags)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
+
 	return domain.EntryAddInput{
 		Type:                flags.entryType,
 		AmountMinor:         amountMinor,
@@ -421,14 +693,51 @@ func buildEntryAddInput(cmd *cobra.Command, flags *entryAddFlags) (domain.EntryA
 		BankAccountID:       bankAccountID,
 		LabelIDs:            labelIDs,
 		Note:                flags.note,
+		Payee:               flags.payee,
+		RecordedBy:          flags.by,
+		IncomeSource:        flags.source,
+		Location:            flags.location,
+		RecordInCurrency:    flags.recordIn,
 		PaymentMethod:       strings.TrimSpace(flags.paymentMethod),
 		PaymentCardID:       paymentCardID,
 		PaymentCardNickname: strings.TrimSpace(flags.cardNickname),
 		PaymentCardLookup:   strings.TrimSpace(flags.cardLookupText),
+		RefundOfEntryID:     refundOfEntryID,
+		AllowArchived:       flags.allowArchived,
+		Splits:              splits,
 	}, nil
 }
 
-func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags) (domain.EntryUpdateInput, error) {
+// buildEntrySplits parses --split-with values; --shared and --split-with
+// must be given together.
+func buildEntrySplits(flags *entryAddFlags) ([]domain.EntrySplit, error) {
+	if !flags.shared && len(flags.splitWithRaw) == 0 {
+		return nil, nil
+	}
+	if !flags.shared || len(flags.splitWithRaw) == 0 {
+		return nil, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "shared and split-with must be used together",
+			Details: map[string]any{"fields": []string{"shared", "split-with"}},
+		}
+	}
+
+	splits := make([]domain.EntrySplit, 0, len(flags.splitWithRaw))
+	for _, raw := range flags.splitWithRaw {
+		split, err := domain.ParseEntrySplit(raw)
+		if err != nil {
+			return nil, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "split-with must be person:share with a share between 0.01% and 100%",
+				Details: map[string]any{"field": "split-with", "value": raw},
+			}
+		}
+		splits = append(splits, split)
+	}
+	return splits, nil
+}
+
+func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags, amountFormat string) (domain.EntryUpdateInput, error) {
 	if flags == nil {
 		return domain.EntryUpdateInput{}, &entryCLIError{
 			Code:    "INTERNAL_ERROR",
@@ -465,6 +774,34 @@ func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags
 			Details: map[string]any{"fields": []string{"clear-note", "note"}},
 		}
 	}
+	if cmd != nil && cmd.Flags().Changed("clear-payee") && cmd.Flags().Changed("payee") {
+		return domain.EntryUpdateInput{}, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "clear-payee cannot be used with payee",
+			Details: map[string]any{"fields": []string{"clear-payee", "payee"}},
+		}
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-by") && cmd.Flags().Changed("by") {
+		return domain.EntryUpdateInput{}, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "clear-by cannot be used with by",
+			Details: map[string]any{"fields": []string{"clear-by", "by"}},
+		}
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-source") && cmd.Flags().Changed("source") {
+		return domain.EntryUpdateInput{}, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "clear-source cannot be used with source",
+			Details: map[string]any{"fields": []string{"clear-source", "source"}},
+		}
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-location") && cmd.Flags().Changed("location") {
+		return domain.EntryUpdateInput{}, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "clear-location cannot be used with location",
+			Details: map[string]any{"fields": []string{"clear-location", "location"}},
+		}
+	}
 	if cmd != nil && cmd.Flags().Changed("card-id") && (cmd.Flags().Changed("card-nickname") || cmd.Flags().Changed("card-lookup")) {
 		return domain.EntryUpdateInput{}, &entryCLIError{
 			Code:    "INVALID_ARGUMENT",
@@ -487,9 +824,14 @@ func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags
 		}
 	}
 
-	input := domain.EntryUpdateInput{ID: id}
+	input := domain.EntryUpdateInput{ID: id, AllowArchived: flags.allowArchived}
 	changed := false
 
+	if cmd != nil && cmd.Flags().Changed("if-updated-at") {
+		value := flags.ifUpdatedAt
+		input.ExpectedUpdatedAtUTC = &value
+	}
+
 	if cmd != nil && cmd.Flags().Changed("type") {
 		changed = true
 		value := flags.entryType
@@ -497,7 +839,7 @@ func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags
 	}
 	if cmd != nil && cmd.Flags().Changed("amount") {
 		changed = true
-		value, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currency)
+		value, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat)
 		if err != nil {
 			return domain.EntryUpdateInput{}, err
 		}
@@ -566,6 +908,50 @@ func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags
 		input.SetNote = true
 		input.Note = &value
 	}
+	if cmd != nil && cmd.Flags().Changed("clear-payee") {
+		changed = true
+		input.SetPayee = true
+		input.Payee = nil
+	}
+	if cmd != nil && cmd.Flags().Changed("payee") {
+		changed = true
+		value := flags.payee
+		input.SetPayee = true
+		input.Payee = &value
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-by") {
+		changed = true
+		input.SetRecordedBy = true
+		input.RecordedBy = nil
+	}
+	if cmd != nil && cmd.Flags().Changed("by") {
+		changed = true
+		value := flags.by
+		input.SetRecordedBy = true
+		input.RecordedBy = &value
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-source") {
+		changed = true
+		input.SetIncomeSource = true
+		input.IncomeSource = nil
+	}
+	if cmd != nil && cmd.Flags().Changed("source") {
+		changed = true
+		value := flags.source
+		input.SetIncomeSource = true
+		input.IncomeSource = &value
+	}
+	if cmd != nil && cmd.Flags().Changed("clear-location") {
+		changed = true
+		input.SetLocation = true
+		input.Location = nil
+	}
+	if cmd != nil && cmd.Flags().Changed("location") {
+		changed = true
+		value := flags.location
+		input.SetLocation = true
+		input.Location = &value
+	}
 	if cmd != nil && cmd.Flags().Changed("payment-method") {
 		changed = true
 		value := strings.TrimSpace(flags.paymentMethod)
@@ -606,6 +992,10 @@ func buildEntryUpdateInput(cmd *cobra.Command, id int64, flags *entryUpdateFlags
 					"bank-account-id|clear-bank-account",
 					"label-id|clear-labels",
 					"note|clear-note",
+					"payee|clear-payee",
+					"by|clear-by",
+					"source|clear-source",
+					"location|clear-location",
 					"payment-method",
 					"card-id|card-nickname|card-lookup",
 				},
@@ -669,6 +1059,11 @@ func buildEntryListFilter(flags *entryListFlags) (domain.EntryListFilter, error)
 		paymentCardID = &id
 	}
 
+	amountMinMinor, amountMaxMinor, err := parseAmountFilterBounds(flags.amountMin, flags.amountMax, flags.currency, flags.amountFormat)
+	if err != nil {
+		return domain.EntryListFilter{}, err
+	}
+
 	return domain.EntryListFilter{
 		Type:                flags.entryType,
 		CategoryID:          categoryID,
@@ -676,6 +1071,13 @@ func buildEntryListFilter(flags *entryListFlags) (domain.EntryListFilter, error)
 		DateFromUTC:         fromUTC,
 		DateToUTC:           toUTC,
 		NoteContains:        strings.TrimSpace(flags.noteContains),
+		Payee:               strings.TrimSpace(flags.payee),
+		RecordedBy:          strings.TrimSpace(flags.by),
+		IncomeSource:        strings.TrimSpace(flags.source),
+		LocationContains:    strings.TrimSpace(flags.locationContains),
+		CurrencyCode:        strings.TrimSpace(flags.currency),
+		AmountMinMinor:      amountMinMinor,
+		AmountMaxMinor:      amountMaxMinor,
 		LabelIDs:            labelIDs,
 		LabelMode:           flags.labelMode,
 		PaymentMethod:       strings.TrimSpace(flags.paymentMethod),
@@ -685,6 +1087,51 @@ func buildEntryListFilter(flags *entryListFlags) (domain.EntryListFilter, error)
 	}, nil
 }
 
+// parseAmountFilterBounds converts --amount-min/--amount-max from major units
+// of currency, which is required because minor units differ per currency.
+func parseAmountFilterBounds(minRaw, maxRaw, currency, amountFormat string) (*int64, *int64, error) {
+	minValue := strings.TrimSpace(minRaw)
+	maxValue := strings.TrimSpace(maxRaw)
+	if minValue == "" && maxValue == "" {
+		return nil, nil, nil
+	}
+	if strings.TrimSpace(currency) == "" {
+		return nil, nil, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "currency is required when amount-min or amount-max is provided",
+			Details: map[string]any{"fields": []string{"amount-min", "amount-max", "currency"}},
+		}
+	}
+
+	parse := func(raw, field string) (*int64, error) {
+		if raw == "" {
+			return nil, nil
+		}
+		value, err := domain.ParseLocalizedMajorAmountToMinor(raw, currency, amountFormat)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidCurrencyCode) {
+				return nil, err
+			}
+			return nil, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: fmt.Sprintf("%s must be a valid decimal amount for %s", field, strings.ToUpper(strings.TrimSpace(currency))),
+				Details: map[string]any{"field": field, "value": raw, "reason": err.Error()},
+			}
+		}
+		return &value, nil
+	}
+
+	minMinor, err := parse(minValue, "amount-min")
+	if err != nil {
+		return nil, nil, err
+	}
+	maxMinor, err := parse(maxValue, "amount-max")
+	if err != nil {
+		return nil, nil, err
+	}
+	return minMinor, maxMinor, nil
+}
+
 func parsePositiveInt64(raw, field string) (int64, error) {
 	value := strings.TrimSpace(raw)
 	if value == "" {
@@ -777,6 +1224,15 @@ func printEntryError(cmd *cobra.Command, format string, err error) error {
 		return output.Print(cmd.OutOrStdout(), format, env)
 	}
 
+	if env, ok := strictWarningEnvelope(err); ok {
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var batchErr *domain.EntryBatchError
+	if errors.As(err, &batchErr) {
+		return output.Print(cmd.OutOrStdout(), format, entryBatchErrorEnvelope(batchErr))
+	}
+
 	env := output.NewErrorEnvelope(codeFromEntryError(err), messageFromEntryError(err), map[string]any{"reason": err.Error()}, nil)
 	return output.Print(cmd.OutOrStdout(), format, env)
 }
@@ -787,11 +1243,15 @@ func codeFromEntryError(err error) string {
 		return "INVALID_CURRENCY_CODE"
 	case errors.Is(err, domain.ErrInvalidDateRange):
 		return "INVALID_DATE_RANGE"
+	case errors.Is(err, domain.ErrFXRateUnavailable):
+		return "FX_RATE_UNAVAILABLE"
 	case errors.Is(err, domain.ErrInvalidEntryType),
 		errors.Is(err, domain.ErrInvalidAmountMinor),
 		errors.Is(err, domain.ErrInvalidAmount),
 		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
 		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidAmountRange),
 		errors.Is(err, domain.ErrInvalidTransactionDate),
 		errors.Is(err, domain.ErrInvalidEntryID),
 		errors.Is(err, domain.ErrNoEntryUpdateFields),
@@ -799,21 +1259,43 @@ func codeFromEntryError(err error) string {
 		errors.Is(err, domain.ErrInvalidBankAccountID),
 		errors.Is(err, domain.ErrInvalidLabelID),
 		errors.Is(err, domain.ErrInvalidLabelMode),
+		errors.Is(err, domain.ErrInvalidEntryExpand),
 		errors.Is(err, domain.ErrInvalidCardID),
 		errors.Is(err, domain.ErrInvalidPaymentMethod),
 		errors.Is(err, domain.ErrInvalidPaymentFilter),
 		errors.Is(err, domain.ErrCardSelectorConflict),
 		errors.Is(err, domain.ErrCardRequired),
 		errors.Is(err, domain.ErrCardNotAllowed),
-		errors.Is(err, domain.ErrPaymentNotAllowed):
+		errors.Is(err, domain.ErrPaymentNotAllowed),
+		errors.Is(err, domain.ErrSourceNotAllowed),
+		errors.Is(err, domain.ErrInvalidLocation),
+		errors.Is(err, domain.ErrCurrencyFixSameCode),
+		errors.Is(err, domain.ErrCurrencyFixMinorUnit),
+		errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC),
+		errors.Is(err, domain.ErrRefundRequiresExpense),
+		errors.Is(err, domain.ErrInvalidRefundTarget),
+		errors.Is(err, domain.ErrRefundCurrencyMismatch),
+		errors.Is(err, domain.ErrRefundExceedsOriginal),
+		errors.Is(err, domain.ErrEmptyEntryBatch),
+		errors.Is(err, domain.ErrCategoryArchived),
+		errors.Is(err, domain.ErrLabelArchived),
+		errors.Is(err, domain.ErrInvalidEntrySplit),
+		errors.Is(err, domain.ErrSplitRequiresPayer),
+		errors.Is(err, domain.ErrSplitRequiresExpense),
+		errors.Is(err, domain.ErrSplitIncludesPayer),
+		errors.Is(err, domain.ErrSplitExceedsTotal),
+		errors.Is(err, domain.ErrInvalidEntryRevision):
 		return "INVALID_ARGUMENT"
 	case errors.Is(err, domain.ErrCategoryNotFound),
 		errors.Is(err, domain.ErrBankAccountNotFound),
 		errors.Is(err, domain.ErrLabelNotFound),
 		errors.Is(err, domain.ErrEntryNotFound),
+		errors.Is(err, domain.ErrEntryRevisionNotFound),
 		errors.Is(err, domain.ErrCardNotFound):
 		return "NOT_FOUND"
-	case errors.Is(err, domain.ErrCardLookupAmbiguous):
+	case errors.Is(err, domain.ErrCardLookupAmbiguous),
+		errors.Is(err, domain.ErrUpdateConflict),
+		errors.Is(err, domain.ErrEntryHasRefunds):
 		return "CONFLICT"
 	default:
 		msg := strings.ToLower(err.Error())
@@ -830,14 +1312,20 @@ func messageFromEntryError(err error) string {
 		return "currency must be a 3-letter ISO code"
 	case errors.Is(err, domain.ErrInvalidDateRange):
 		return "from must be less than or equal to to"
+	case errors.Is(err, domain.ErrFXRateUnavailable):
+		return "required FX rate could not be resolved"
 	case errors.Is(err, domain.ErrInvalidEntryType):
 		return "type must be one of: income|expense"
 	case errors.Is(err, domain.ErrInvalidAmount):
 		return "amount must be a valid decimal number"
 	case errors.Is(err, domain.ErrInvalidAmountPrecision):
 		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
 	case errors.Is(err, domain.ErrAmountOverflow):
 		return "amount is too large"
+	case errors.Is(err, domain.ErrInvalidAmountRange):
+		return "amount-min must not exceed amount-max"
 	case errors.Is(err, domain.ErrInvalidAmountMinor):
 		return "amount must be greater than zero"
 	case errors.Is(err, domain.ErrInvalidTransactionDate):
@@ -854,6 +1342,8 @@ func messageFromEntryError(err error) string {
 		return "label-id must be a positive integer"
 	case errors.Is(err, domain.ErrInvalidLabelMode):
 		return "label-mode must be one of: any|all|none"
+	case errors.Is(err, domain.ErrInvalidEntryExpand):
+		return "expand must be a comma-separated list of: card|category|labels"
 	case errors.Is(err, domain.ErrInvalidCardID):
 		return "card-id must be a positive integer"
 	case errors.Is(err, domain.ErrInvalidPaymentMethod):
@@ -868,6 +1358,46 @@ func messageFromEntryError(err error) string {
 		return "card selector cannot be used when payment-method is cash"
 	case errors.Is(err, domain.ErrPaymentNotAllowed):
 		return "payment method options are only valid for expense entries"
+	case errors.Is(err, domain.ErrSourceNotAllowed):
+		return "source is only valid for income entries"
+	case errors.Is(err, domain.ErrInvalidLocation):
+		return "location coordinates must be lat,long within -90..90 and -180..180"
+	case errors.Is(err, domain.ErrCurrencyFixSameCode):
+		return "from and to must be different currencies"
+	case errors.Is(err, domain.ErrCurrencyFixMinorUnit):
+		return "from and to currencies must use the same number of decimal places"
+	case errors.Is(err, domain.ErrInvalidExpectedUpdatedAtUTC):
+		return "if-updated-at cannot be empty"
+	case errors.Is(err, domain.ErrUpdateConflict):
+		return "entry changed since it was read; reload and retry"
+	case errors.Is(err, domain.ErrRefundRequiresExpense):
+		return "refund-of is only valid for expense entries"
+	case errors.Is(err, domain.ErrInvalidRefundTarget):
+		return "refund-of must reference an expense that is not itself a refund"
+	case errors.Is(err, domain.ErrRefundCurrencyMismatch):
+		return "refund currency must match the refunded entry"
+	case errors.Is(err, domain.ErrRefundExceedsOriginal):
+		return "refunds cannot exceed the refunded entry amount"
+	case errors.Is(err, domain.ErrEntryHasRefunds):
+		return "entry has refunds; delete them first"
+	case errors.Is(err, domain.ErrEmptyEntryBatch):
+		return "entry batch has no rows"
+	case errors.Is(err, domain.ErrCategoryArchived):
+		return "category is archived; pass --allow-archived to use it"
+	case errors.Is(err, domain.ErrLabelArchived):
+		return "label is archived; pass --allow-archived to use it"
+	case errors.Is(err, domain.ErrInvalidEntrySplit):
+		return "split-with must name each person once with a positive share"
+	case errors.Is(err, domain.ErrSplitRequiresPayer):
+		return "shared entries require --by (or default_recorded_by) to name the payer"
+	case errors.Is(err, domain.ErrSplitRequiresExpense):
+		return "only expenses that are not refunds can be shared"
+	case errors.Is(err, domain.ErrSplitIncludesPayer):
+		return "split-with cannot name the payer"
+	case errors.Is(err, domain.ErrSplitExceedsTotal):
+		return "split-with shares cannot add up to more than 100%"
+	case errors.Is(err, domain.ErrInvalidEntryRevision):
+		return "to must be a positive revision number"
 	case errors.Is(err, domain.ErrCategoryNotFound):
 		return "category not found"
 	case errors.Is(err, domain.ErrBankAccountNotFound):
@@ -876,6 +1406,8 @@ func messageFromEntryError(err error) string {
 		return "label not found"
 	case errors.Is(err, domain.ErrEntryNotFound):
 		return "entry not found"
+	case errors.Is(err, domain.ErrEntryRevisionNotFound):
+		return "entry revision not found; see entry history"
 	case errors.Is(err, domain.ErrCardNotFound):
 		return "card not found"
 	case errors.Is(err, domain.ErrCardLookupAmbiguous):
@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+const (
+	entryBatchFormatCSV  = "csv"
+	entryBatchFormatJSON = "json"
+)
+
+// entryBatchColumns are the fields a batch row may set, matching the entry
+// add flags. currency_code, transaction_date_utc and source are accepted as
+// aliases so rows exported elsewhere can be fed back.
+var entryBatchColumns = map[string]string{
+	"type":                 "type",
+	"amount":               "amount",
+	"amount_minor":         "amount_minor",
+	"currency":             "currency",
+	"currency_code":        "currency",
+	"date":                 "date",
+	"transaction_date_utc": "date",
+	"category_id":          "category_id",
+	"bank_account_id":      "bank_account_id",
+	"label_ids":            "label_ids",
+	"note":                 "note",
+	"payee":                "payee",
+	"recorded_by":          "recorded_by",
+	"income_source":        "income_source",
+	"source":               "income_source",
+	"location":             "location",
+	"payment_method":       "payment_method",
+	"card_id":              "card_id",
+	"card_nickname":        "card_nickname",
+	"card_lookup":          "card_lookup",
+	"refund_of":            "refund_of",
+}
+
+type entryAddBatchFlags struct {
+	file   string
+	format string
+}
+
+func newEntryAddBatchCmd(opts *RootOptions) *cobra.Command {
+	flags := &entryAddBatchFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add-batch",
+		Short: "Add many entries in one transaction",
+		Long: `Add many entries from a CSV or JSON file (or stdin with --file -).
+
+CSV files need a header row; JSON files hold an array of objects. Columns
+and keys match the entry add flags: type, amount (major units) or
+amount_minor, currency, date, category_id, bank_account_id, label_ids
+("|"-separated in CSV, an array in JSON), note, payee, recorded_by,
+income_source (or source), location, payment_method, card_id,
+card_nickname, card_lookup and refund_of.
+
+Every row goes through the same checks and cap warnings as entry add. If any
+row fails, nothing is added and the error lists each failing row.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "entry add-batch does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if strings.TrimSpace(flags.file) == "" {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "file is required",
+					Details: map[string]any{"required_flags": []string{"file"}},
+				})
+			}
+
+			format, err := entryBatchFormat(flags)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			raw, err := readEntryBatchInput(cmd, flags.file)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			rows, err := decodeEntryBatchRows(raw, format)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			inputs, err := buildEntryBatchInputs(rows, amountFormat(opts), defaultCurrency(opts))
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			result, err := svc.AddBatch(cmd.Context(), inputs)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"entries": result.Entries,
+				"count":   len(result.Entries),
+			}, toOutputWarnings(result.Warnings))
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, entryListTables(result.Entries))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.file, "file", "", "Input file path, or - for stdin")
+	cmd.Flags().StringVar(&flags.format, "format", "", "Input format: csv|json (defaults to json for .json files, csv otherwise)")
+
+	return cmd
+}
+
+func entryBatchFormat(flags *entryAddBatchFlags) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(flags.format))
+	switch format {
+	case entryBatchFormatCSV, entryBatchFormatJSON:
+		return format, nil
+	case "":
+		if strings.EqualFold(filepath.Ext(strings.TrimSpace(flags.file)), ".json") {
+			return entryBatchFormatJSON, nil
+		}
+		return entryBatchFormatCSV, nil
+	default:
+		return "", &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "format must be one of: csv|json",
+			Details: map[string]any{"field": "format", "value": flags.format},
+		}
+	}
+}
+
+func readEntryBatchInput(cmd *cobra.Command, path string) ([]byte, error) {
+	path = strings.TrimSpace(path)
+	if path == "-" {
+		raw, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("read entry batch from stdin: %w", err)
+		}
+		return raw, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "file could not be read",
+			Details: map[string]any{"field": "file", "value": path, "reason": err.Error()},
+		}
+	}
+	return raw, nil
+}
+
+// decodeEntryBatchRows turns the input into one field map per row, keyed by
+// the canonical names in entryBatchColumns.
+func decodeEntryBatchRows(raw []byte, format string) ([]map[string]string, error) {
+	if format == entryBatchFormatJSON {
+		return decodeEntryBatchJSON(raw)
+	}
+	return decodeEntryBatchCSV(raw)
+}
+
+func decodeEntryBatchCSV(raw []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(raw))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, entryBatchDecodeError(err)
+	}
+	if len(records) == 0 {
+		return nil, domain.ErrEmptyEntryBatch
+	}
+
+	columns := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		column, err := entryBatchColumn(name)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = column
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(columns))
+		for i, value := range record {
+			if i < len(columns) {
+				row[columns[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decodeEntryBatchJSON(raw []byte) ([]map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	objects := []map[string]any{}
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, entryBatchDecodeError(err)
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for key, value := range object {
+			column, err := entryBatchColumn(key)
+			if err != nil {
+				return nil, err
+			}
+			row[column] = entryBatchJSONValue(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func entryBatchJSONValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(typed)
+	case []any:
+		parts := make([]string, 0, len(typed))
+		for _, item := range typed {
+			parts = append(parts, entryBatchJSONValue(item))
+		}
+		return strings.Join(parts, "|")
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func entryBatchColumn(name string) (string, error) {
+	column, ok := entryBatchColumns[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		supported := make([]string, 0, len(entryBatchColumns))
+		for key := range entryBatchColumns {
+			supported = append(supported, key)
+		}
+		sort.Strings(supported)
+		return "", &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "unknown entry batch column",
+			Details: map[string]any{"column": name, "supported_columns": supported},
+		}
+	}
+	return column, nil
+}
+
+func entryBatchDecodeError(err error) error {
+	return &entryCLIError{
+		Code:    "INVALID_ARGUMENT",
+		Message: "entry batch input could not be parsed",
+		Details: map[string]any{"field": "file", "reason": err.Error()},
+	}
+}
+
+// buildEntryBatchInputs parses every row before anything is written, so one
+// response reports all malformed rows. Rows without a currency use
+// defaultCurrency.
+func buildEntryBatchInputs(rows []map[string]string, amountFormat, defaultCurrency string) ([]domain.EntryAddInput, error) {
+	if len(rows) == 0 {
+		return nil, domain.ErrEmptyEntryBatch
+	}
+
+	batchErr := &domain.EntryBatchError{RowCount: len(rows)}
+	inputs := make([]domain.EntryAddInput, 0, len(rows))
+	for i, row := range rows {
+		input, err := buildEntryBatchInput(row, amountFormat, defaultCurrency)
+		if err != nil {
+			batchErr.Rows = append(batchErr.Rows, domain.EntryBatchRowError{Row: i + 1, Err: err})
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+	if len(batchErr.Rows) > 0 {
+		return nil, batchErr
+	}
+	return inputs, nil
+}
+
+func buildEntryBatchInput(row map[string]string, amountFormat, defaultCurrency string) (domain.EntryAddInput, error) {
+	currency := row["currency"]
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	input := domain.EntryAddInput{
+		Type:                row["type"],
+		CurrencyCode:        currency,
+		TransactionDateUTC:  row["date"],
+		Note:                row["note"],
+		Payee:               row["payee"],
+		RecordedBy:          row["recorded_by"],
+		IncomeSource:        row["income_source"],
+		Location:            row["location"],
+		PaymentMethod:       row["payment_method"],
+		PaymentCardNickname: row["card_nickname"],
+		PaymentCardLookup:   row["card_lookup"],
+	}
+
+	switch {
+	case row["amount"] != "" && row["amount_minor"] != "":
+		return domain.EntryAddInput{}, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "amount cannot be combined with amount_minor",
+			Details: map[string]any{"fields": []string{"amount", "amount_minor"}},
+		}
+	case row["amount_minor"] != "":
+		amountMinor, err := strconv.ParseInt(row["amount_minor"], 10, 64)
+		if err != nil {
+			return domain.EntryAddInput{}, domain.ErrInvalidAmountMinor
+		}
+		input.AmountMinor = amountMinor
+	default:
+		amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(row["amount"], currency, amountFormat)
+		if err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		input.AmountMinor = amountMinor
+	}
+
+	optionalIDs := []struct {
+		column string
+		target **int64
+	}{
+		{"category_id", &input.CategoryID},
+		{"bank_account_id", &input.BankAccountID},
+		{"card_id", &input.PaymentCardID},
+		{"refund_of", &input.RefundOfEntryID},
+	}
+	for _, optional := range optionalIDs {
+		if row[optional.column] == "" {
+			continue
+		}
+		id, err := parsePositiveInt64(row[optional.column], optional.column)
+		if err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		*optional.target = &id
+	}
+
+	if raw := row["label_ids"]; raw != "" {
+		labelIDs, err := parsePositiveInt64List(strings.Split(raw, "|"), "label_ids")
+		if err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		input.LabelIDs = labelIDs
+	}
+
+	return input, nil
+}
+
+func entryBatchErrorEnvelope(batchErr *domain.EntryBatchError) output.Envelope {
+	rows := make([]map[string]any, 0, len(batchErr.Rows))
+	for _, row := range batchErr.Rows {
+		code, message := codeFromEntryError(row.Err), messageFromEntryError(row.Err)
+		var cliErr *entryCLIError
+		if errors.As(row.Err, &cliErr) {
+			code, message = cliErr.Code, cliErr.Message
+		}
+		rows = append(rows, map[string]any{
+			"row":     row.Row,
+			"code":    code,
+			"message": message,
+			"reason":  row.Err.Error(),
+		})
+	}
+
+	return output.NewErrorEnvelope(
+		"INVALID_ARGUMENT",
+		fmt.Sprintf("%d of %d rows were rejected; no entries were added", len(batchErr.Rows), batchErr.RowCount),
+		map[string]any{"row_count": batchErr.RowCount, "rows": rows},
+		nil,
+	)
+}
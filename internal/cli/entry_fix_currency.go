@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+type entryFixCurrencyFlags struct {
+	from   string
+	to     string
+	dryRun bool
+}
+
+func newEntryFixCurrencyCmd(opts *RootOptions) *cobra.Command {
+	flags := &entryFixCurrencyFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "fix-currency",
+		Short: "Re-code all active entries from one currency to another (e.g. UDS to USD)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "entry fix-currency does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			var snapshot *domain.SafetySnapshot
+			if !flags.dryRun {
+				snapshot, err = takeSafetySnapshot(cmd, opts, "entry fix-currency")
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+			}
+
+			fix, err := svc.FixCurrency(cmd.Context(), flags.from, flags.to, flags.dryRun)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(withSafetySnapshot(map[string]any{"fix": fix}, snapshot), nil)
+			return output.Print(cmd.OutOrStdout(), entryOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.from, "from", "", "Currency code to replace")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Replacement currency code")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "List affected entries without changing them")
+
+	return cmd
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"boring-budget/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+func newEntryHistoryCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <id>",
+		Short: "Show the revisions of an entry and what each update changed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "history requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			id, err := parsePositiveInt64(args[0], "id")
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			history, err := svc.History(cmd.Context(), id)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"entry":     history.Entry,
+				"revisions": history.Revisions,
+				"count":     len(history.Revisions),
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, entryHistoryTables(history))
+		},
+	}
+}
+
+func newEntryRevertCmd(opts *RootOptions) *cobra.Command {
+	var revision int64
+
+	cmd := &cobra.Command{
+		Use:   "revert <id>",
+		Short: "Put an entry back into the state of an earlier revision",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "revert requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+			if !cmd.Flags().Changed("to") {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "--to is required",
+					Details: map[string]any{"required_flags": []string{"to"}},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			id, err := parsePositiveInt64(args[0], "id")
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			result, err := svc.Revert(cmd.Context(), id, revision)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(
+				map[string]any{"entry": result.Entry, "reverted_to": revision},
+				toOutputWarnings(result.Warnings),
+			)
+			return output.Print(cmd.OutOrStdout(), entryOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().Int64Var(&revision, "to", 0, "Revision number to restore (see entry history)")
+	return cmd
+}
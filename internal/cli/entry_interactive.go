@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+// entryPickOption is one selectable value in an interactive fuzzy pick.
+type entryPickOption struct {
+	ID   int64
+	Name string
+}
+
+// entryPrompter asks for entry add fields one at a time. Prompts go to stderr
+// so stdout keeps carrying only the result envelope.
+type entryPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+var errInteractiveInputEnded = errors.New("interactive input ended")
+
+// promptEntryAddFlags fills every entry add flag the user did not pass on the
+// command line by prompting for it. Values are applied through the flag set so
+// buildEntryAddInput sees them exactly like typed flags.
+func promptEntryAddFlags(cmd *cobra.Command, opts *RootOptions) error {
+	prompter := &entryPrompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+	flags := cmd.Flags()
+
+	entryType := ""
+	if flags.Changed("type") {
+		entryType, _ = flags.GetString("type")
+	} else {
+		value, err := prompter.ask("Type (income|expense)", domain.EntryTypeExpense)
+		if err != nil {
+			return err
+		}
+		entryType = value
+		if err := flags.Set("type", value); err != nil {
+			return err
+		}
+	}
+	isExpense := strings.EqualFold(strings.TrimSpace(entryType), domain.EntryTypeExpense)
+
+	if !flags.Changed("amount") {
+		value, err := prompter.askRequired("Amount")
+		if err != nil {
+			return err
+		}
+		if err := flags.Set("amount", value); err != nil {
+			return err
+		}
+	}
+
+	if !flags.Changed("currency") {
+		value, err := prompter.ask("Currency", defaultCurrency(opts))
+		if err != nil {
+			return err
+		}
+		if err := flags.Set("currency", value); err != nil {
+			return err
+		}
+	}
+
+	if !flags.Changed("date") {
+		value, err := prompter.ask("Date (YYYY-MM-DD)", displayNow(opts).Format("2006-01-02"))
+		if err != nil {
+			return err
+		}
+		if err := flags.Set("date", value); err != nil {
+			return err
+		}
+	}
+
+	if !flags.Changed("category-id") {
+		categories, err := sqlitestore.NewCategoryRepo(opts.db).List(cmd.Context())
+		if err != nil {
+			return err
+		}
+		options := make([]entryPickOption, 0, len(categories))
+		for _, category := range domain.UnarchivedCategories(categories) {
+			options = append(options, entryPickOption{ID: category.ID, Name: category.Name})
+		}
+		picked, err := prompter.pick("Category", options)
+		if err != nil {
+			return err
+		}
+		if picked != nil {
+			if err := flags.Set("category-id", strconv.FormatInt(picked.ID, 10)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !flags.Changed("label-id") {
+		labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+		if err != nil {
+			return err
+		}
+		labels, err := labelRepo.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+		options := make([]entryPickOption, 0, len(labels))
+		for _, label := range domain.UnarchivedLabels(labels) {
+			options = append(options, entryPickOption{ID: label.ID, Name: label.Name})
+		}
+		for {
+			picked, err := prompter.pick("Label (blank to finish)", options)
+			if err != nil {
+				return err
+			}
+			if picked == nil {
+				break
+			}
+			if err := flags.Set("label-id", strconv.FormatInt(picked.ID, 10)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if isExpense && !flags.Changed("payment-method") && !hasEntryCardFlag(cmd) {
+		value, err := prompter.ask("Payment method (cash|card)", domain.PaymentMethodCash)
+		if err != nil {
+			return err
+		}
+		if err := flags.Set("payment-method", value); err != nil {
+			return err
+		}
+
+		if strings.EqualFold(strings.TrimSpace(value), domain.PaymentMethodCard) {
+			cards, err := sqlitestore.NewCardRepo(opts.db).ListCards(cmd.Context(), ports.CardListFilter{})
+			if err != nil {
+				return err
+			}
+			options := make([]entryPickOption, 0, len(cards))
+			for _, card := range cards {
+				options = append(options, entryPickOption{ID: card.ID, Name: card.Nickname})
+			}
+			picked, err := prompter.pick("Card", options)
+			if err != nil {
+				return err
+			}
+			if picked != nil {
+				if err := flags.Set("card-id", strconv.FormatInt(picked.ID, 10)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !flags.Changed("note") {
+		value, err := prompter.ask("Note", "")
+		if err != nil {
+			return err
+		}
+		if err := flags.Set("note", value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasEntryCardFlag(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("card-id") || cmd.Flags().Changed("card-nickname") || cmd.Flags().Changed("card-lookup")
+}
+
+// displayNow is the current time in the display timezone, which decides what
+// "today" means for prompts and relative dates.
+func displayNow(opts *RootOptions) time.Time {
+	location := time.UTC
+	if opts != nil {
+		if loaded, err := time.LoadLocation(opts.Timezone); err == nil {
+			location = loaded
+		}
+	}
+	return time.Now().In(location)
+}
+
+// ask prints label with its default and returns the trimmed answer, or the
+// default when the answer is blank.
+func (p *entryPrompter) ask(label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", errInteractiveInputEnded
+		}
+		return "", err
+	}
+
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+func (p *entryPrompter) askRequired(label string) (string, error) {
+	for {
+		answer, err := p.ask(label, "")
+		if err != nil {
+			return "", err
+		}
+		if answer != "" {
+			return answer, nil
+		}
+		fmt.Fprintf(p.out, "%s is required.\n", strings.ToLower(label))
+	}
+}
+
+// pick resolves a typed query against options. A blank answer picks nothing;
+// several matches are listed by number so the next answer can choose one.
+func (p *entryPrompter) pick(label string, options []entryPickOption) (*entryPickOption, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	var listed []entryPickOption
+	for {
+		answer, err := p.ask(label, "")
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return nil, nil
+		}
+
+		if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(listed) {
+			picked := listed[index-1]
+			return &picked, nil
+		}
+
+		matches := fuzzyMatchPickOptions(answer, options)
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(p.out, "no match for %q.\n", answer)
+			listed = nil
+		case 1:
+			picked := matches[0]
+			fmt.Fprintf(p.out, "  -> %s\n", picked.Name)
+			return &picked, nil
+		default:
+			for i, match := range matches {
+				fmt.Fprintf(p.out, "  %d) %s\n", i+1, match.Name)
+			}
+			listed = matches
+		}
+	}
+}
+
+// fuzzyMatchPickOptions prefers an exact (case-insensitive) name, then
+// substring matches, then names containing the query letters in order.
+func fuzzyMatchPickOptions(query string, options []entryPickOption) []entryPickOption {
+	needle := strings.ToLower(strings.TrimSpace(query))
+
+	for _, option := range options {
+		if strings.ToLower(option.Name) == needle {
+			return []entryPickOption{option}
+		}
+	}
+
+	matches := []entryPickOption{}
+	for _, option := range options {
+		if strings.Contains(strings.ToLower(option.Name), needle) {
+			matches = append(matches, option)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+
+	for _, option := range options {
+		if isSubsequence(needle, strings.ToLower(option.Name)) {
+			matches = append(matches, option)
+		}
+	}
+	return matches
+}
+
+func isSubsequence(needle, haystack string) bool {
+	remaining := []rune(needle)
+	for _, r := range haystack {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type entryQuickFlags struct {
+	currency string
+}
+
+func newEntryQuickCmd(opts *RootOptions) *cobra.Command {
+	flags := &entryQuickFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "quick <text>",
+		Short: "Add an entry from one line of text",
+		Long: `Add an entry from one line such as "coffee 4.50 yesterday #work @visa".
+
+Tokens:
+  4.50 / +3500         amount (a leading + records income)
+  EUR                  upper-case currency code (defaults to the settings currency)
+  today, yesterday     relative dates; also "friday" or "last friday", or YYYY-MM-DD
+  #name                label by name
+  @name                card by nickname or lookup text (sets payment method card)
+
+Remaining words become the note, and the first word (or two-word phrase)
+matching a category name sets the category.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "entry quick requires text",
+					Details: map[string]any{"field": "text"},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			parsed, err := domain.ParseQuickEntry(strings.Join(args, " "), displayNow(opts))
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), quickEntryParseError(err))
+			}
+
+			input, categoryKeyword, err := resolveQuickEntry(cmd, opts, flags, parsed)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			result, err := svc.AddWithWarnings(cmd.Context(), input)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(
+				map[string]any{
+					"entry":            result.Entry,
+					"parsed":           parsed,
+					"category_keyword": categoryKeyword,
+				},
+				toOutputWarnings(result.Warnings),
+			)
+			return output.Print(cmd.OutOrStdout(), entryOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Currency when the text has none (defaults to the @card's currency, then the settings currency)")
+
+	return cmd
+}
+
+// resolveQuickEntry maps parsed names to stored records and builds the add
+// input. It returns the word that selected the category, if any.
+func resolveQuickEntry(cmd *cobra.Command, opts *RootOptions, flags *entryQuickFlags, parsed domain.QuickEntry) (domain.EntryAddInput, string, error) {
+	currency := parsed.CurrencyCode
+	if currency == "" {
+		currency = strings.TrimSpace(flags.currency)
+	}
+	if currency == "" && parsed.Card != "" {
+		currency = cardDefaultCurrency(cmd, opts, domain.CardSelector{Lookup: parsed.Card})
+	}
+	if currency == "" {
+		currency = defaultCurrency(opts)
+	}
+
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(parsed.Amount, currency, amountFormat(opts))
+	if err != nil {
+		return domain.EntryAddInput{}, "", err
+	}
+
+	input := domain.EntryAddInput{
+		Type:               parsed.Type,
+		AmountMinor:        amountMinor,
+		CurrencyCode:       currency,
+		TransactionDateUTC: parsed.TransactionDate,
+		Note:               strings.Join(parsed.Words, " "),
+	}
+
+	if len(parsed.Labels) > 0 {
+		labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+		if err != nil {
+			return domain.EntryAddInput{}, "", err
+		}
+		labels, err := labelRepo.List(cmd.Context())
+		if err != nil {
+			return domain.EntryAddInput{}, "", err
+		}
+		options := make([]entryPickOption, 0, len(labels))
+		for _, label := range domain.UnarchivedLabels(labels) {
+			options = append(options, entryPickOption{ID: label.ID, Name: label.Name})
+		}
+		for _, name := range parsed.Labels {
+			matches := fuzzyMatchPickOptions(name, options)
+			switch len(matches) {
+			case 0:
+				return domain.EntryAddInput{}, "", &entryCLIError{Code: "NOT_FOUND", Message: "label not found", Details: map[string]any{"label": name}}
+			case 1:
+				input.LabelIDs = append(input.LabelIDs, matches[0].ID)
+			default:
+				return domain.EntryAddInput{}, "", &entryCLIError{Code: "CONFLICT", Message: "label name matches multiple labels", Details: map[string]any{"label": name, "matches": pickOptionNames(matches)}}
+			}
+		}
+	}
+
+	if parsed.Card != "" {
+		if parsed.Type != domain.EntryTypeExpense {
+			return domain.EntryAddInput{}, "", domain.ErrPaymentNotAllowed
+		}
+		input.PaymentMethod = domain.PaymentMethodCard
+		input.PaymentCardLookup = parsed.Card
+	}
+
+	categories, err := sqlitestore.NewCategoryRepo(opts.db).List(cmd.Context())
+	if err != nil {
+		return domain.EntryAddInput{}, "", err
+	}
+	categoryIDs := make(map[string]int64, len(categories))
+	for _, category := range domain.UnarchivedCategories(categories) {
+		categoryIDs[strings.ToLower(category.Name)] = category.ID
+	}
+	keyword, categoryID, ok := matchQuickEntryCategory(parsed.Words, categoryIDs)
+	if ok {
+		input.CategoryID = &categoryID
+	}
+
+	return input, keyword, nil
+}
+
+// matchQuickEntryCategory checks two-word phrases before single words so
+// "coffee shop" wins over "coffee" when both categories exist.
+func matchQuickEntryCategory(words []string, categoryIDs map[string]int64) (string, int64, bool) {
+	cleaned := make([]string, len(words))
+	for i, word := range words {
+		cleaned[i] = strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+	}
+
+	for i := 0; i+1 < len(cleaned); i++ {
+		phrase := cleaned[i] + " " + cleaned[i+1]
+		if id, ok := categoryIDs[phrase]; ok {
+			return phrase, id, true
+		}
+	}
+	for _, word := range cleaned {
+		if id, ok := categoryIDs[word]; ok && word != "" {
+			return word, id, true
+		}
+	}
+	return "", 0, false
+}
+
+func quickEntryParseError(err error) error {
+	message := "quick entry text could not be parsed"
+	switch {
+	case errors.Is(err, domain.ErrQuickEntryEmpty):
+		message = "entry quick requires text"
+	case errors.Is(err, domain.ErrQuickEntryAmountRequired):
+		message = "quick entry needs an amount (e.g. 4.50)"
+	case errors.Is(err, domain.ErrQuickEntryAmountConflict):
+		message = "quick entry has more than one amount"
+	case errors.Is(err, domain.ErrQuickEntryDateConflict):
+		message = "quick entry has more than one date"
+	case errors.Is(err, domain.ErrQuickEntryCardConflict):
+		message = "quick entry has more than one @card"
+	}
+	return &entryCLIError{Code: "INVALID_ARGUMENT", Message: message, Details: map[string]any{"reason": err.Error()}}
+}
+
+func pickOptionNames(options []entryPickOption) []string {
+	names := make([]string, 0, len(options))
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+	return names
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 
 	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
 )
 
 func TestEntryCommandJSONLifecycleAndFilters(t *testing.T) {
@@ -199,6 +203,48 @@ func TestEntryCommandJSONAddIncludesCapExceededWarning(t *testing.T) {
 	}
 }
 
+func TestEntryCommandJSONStrictWarningsRollBackCapExceeded(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO monthly_caps (month_key, amount_minor, currency_code)
+		VALUES ('2026-02', 5000, 'USD');
+	`); err != nil {
+		t.Fatalf("insert monthly cap: %v", err)
+	}
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db, strictWarnings: []string{"CAP_EXCEEDED"}}
+	cmd := NewEntryCmd(opts)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"add", "--type", "expense", "--amount", "62.00", "--currency", "USD", "--date", "2026-02-10"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute strict entry add: %v", err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal strict payload: %v raw=%s", err, buf.String())
+	}
+	if code := mustMap(t, payload["error"])["code"]; code != "STRICT_WARNING" {
+		t.Fatalf("expected STRICT_WARNING, got %v", payload)
+	}
+	if warnings := mustAnySlice(t, payload["warnings"]); len(warnings) != 1 || mustMap(t, warnings[0])["code"] != "CAP_EXCEEDED" {
+		t.Fatalf("expected the escalated CAP_EXCEEDED warning, got %v", warnings)
+	}
+
+	listed := executeEntryCmdJSON(t, db, []string{"list"})
+	if count := mustMap(t, listed["data"])["count"]; count != float64(0) {
+		t.Fatalf("expected strict failure to roll back the entry, got count=%v", count)
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "62.00", "--currency", "USD", "--date", "2026-02-10"}))
+}
+
 func TestEntryCommandJSONInvalidCurrencyCode(t *testing.T) {
 	t.Parallel()
 
@@ -247,6 +293,215 @@ func TestEntryCommandJSONAddConvertsAmountToMinor(t *testing.T) {
 	}
 }
 
+func TestEntryCommandJSONAddUsesConfiguredAmountFormat(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	run := func(args []string) map[string]any {
+		t.Helper()
+
+		opts := &RootOptions{Output: output.FormatJSON, db: db, amountFormat: domain.AmountFormatCommaDecimal}
+		cmd := NewEntryCmd(opts)
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("execute entry cmd %v: %v", args, err)
+		}
+
+		payload := map[string]any{}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("unmarshal entry payload: %v raw=%s", err, buf.String())
+		}
+		return payload
+	}
+
+	added := run([]string{"add", "--type", "expense", "--amount", "1.234,56", "--currency", "EUR", "--date", "2026-02-01"})
+	mustEntrySuccess(t, added)
+	entry := mustMap(t, mustMap(t, added["data"])["entry"])
+	if got := int64(entry["amount_minor"].(float64)); got != 123456 {
+		t.Fatalf("expected amount_minor 123456, got %d", got)
+	}
+
+	ambiguous := run([]string{"add", "--type", "expense", "--amount", "12.5", "--currency", "EUR", "--date", "2026-02-01"})
+	if ok, _ := ambiguous["ok"].(bool); ok {
+		t.Fatalf("expected ambiguous amount to fail payload=%v", ambiguous)
+	}
+	if code := mustMap(t, ambiguous["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %s", code)
+	}
+}
+
+func TestEntryCommandJSONTriageAssignsCategoriesAndReportsOrphanStatus(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := insertTestCategory(t, db, "Food")
+	insertTestCategory(t, db, "Rent")
+
+	ids := make([]int64, 0, 3)
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-02-03"},
+		{"add", "--type", "expense", "--amount", "900.00", "--currency", "USD", "--date", "2026-02-04"},
+		{"add", "--type", "expense", "--amount", "60.00", "--currency", "USD", "--date", "2026-02-05", "--category-id", strconv.FormatInt(foodID, 10)},
+	} {
+		added := executeEntryCmdJSON(t, db, args)
+		mustEntrySuccess(t, added)
+		ids = append(ids, int64(mustMap(t, mustMap(t, added["data"])["entry"])["id"].(float64)))
+	}
+
+	listed := executeEntryCmdJSON(t, db, []string{"triage"})
+	mustEntrySuccess(t, listed)
+	status := mustMap(t, mustMap(t, listed["data"])["orphan_status"])
+	spending := mustMap(t, mustAnySlice(t, status["spending"])[0])
+	if mustMap(t, listed["data"])["count"].(float64) != 2 || spending["orphan_spend_minor"].(float64) != 94000 || spending["exceeded"] != true {
+		t.Fatalf("unexpected triage listing: %v", listed["data"])
+	}
+
+	rejected := executeEntryCmdJSON(t, db, []string{"triage", "--assign", fmt.Sprintf("%d:Food,%d:Travel", ids[0], ids[1])})
+	if code := mustMap(t, rejected["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unknown category, got %v", rejected)
+	}
+	categorized := executeEntryCmdJSON(t, db, []string{"triage", "--assign", fmt.Sprintf("%d:Food", ids[2])})
+	if code := mustMap(t, categorized["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for already categorized entry, got %v", categorized)
+	}
+
+	assigned := executeEntryCmdJSON(t, db, []string{"triage", "--assign", fmt.Sprintf("%d:food,%d:rnt", ids[0], ids[1])})
+	mustEntrySuccess(t, assigned)
+	data := mustMap(t, assigned["data"])
+	if data["count"].(float64) != 0 || len(mustAnySlice(t, data["assigned"])) != 2 {
+		t.Fatalf("expected both orphans assigned, got %v", data)
+	}
+	spending = mustMap(t, mustAnySlice(t, mustMap(t, data["orphan_status"])["spending"])[0])
+	if spending["orphan_spend_minor"].(float64) != 0 || spending["exceeded"] != false {
+		t.Fatalf("expected no remaining orphan spend, got %v", spending)
+	}
+	if warnings := mustAnySlice(t, assigned["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no orphan warnings after triage, got %v", warnings)
+	}
+}
+
+func TestEntryCommandJSONAddInteractivePromptsForMissingFields(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	groceriesID := insertTestCategory(t, db, "Groceries")
+	insertTestCategory(t, db, "Gifts")
+	weeklyID := insertTestLabel(t, db, "weekly")
+	cardID := insertTestCard(t, db, "Credit One", "Travel card", "9999", "VISA", "credit", 18)
+
+	run := func(stdin string, args []string) (map[string]any, string) {
+		t.Helper()
+
+		opts := &RootOptions{Output: output.FormatJSON, db: db, defaultCurrency: "EUR"}
+		cmd := NewEntryCmd(opts)
+		stdout := &bytes.Buffer{}
+		prompts := &bytes.Buffer{}
+		cmd.SetOut(stdout)
+		cmd.SetErr(prompts)
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetArgs(args)
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("execute entry cmd %v: %v", args, err)
+		}
+
+		payload := map[string]any{}
+		if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+			t.Fatalf("unmarshal entry payload: %v raw=%s", err, stdout.String())
+		}
+		return payload, prompts.String()
+	}
+
+	// type default, amount, currency default, date, "g" matches two categories
+	// so the second answer picks from the list, one label, card by fuzzy name.
+	answers := strings.Join([]string{"", "12.50", "", "2026-02-01", "g", "2", "wkly", "", "card", "cred", "market run"}, "\n") + "\n"
+	added, prompts := run(answers, []string{"add", "--interactive"})
+	mustEntrySuccess(t, added)
+
+	entry := mustMap(t, mustMap(t, added["data"])["entry"])
+	if entry["type"].(string) != "expense" || int64(entry["amount_minor"].(float64)) != 1250 || entry["currency_code"].(string) != "EUR" {
+		t.Fatalf("unexpected interactive entry %v", entry)
+	}
+	if int64(entry["category_id"].(float64)) != groceriesID {
+		t.Fatalf("expected category %d from numbered pick, got %v", groceriesID, entry["category_id"])
+	}
+	labels := mustAnySlice(t, entry["label_ids"])
+	if len(labels) != 1 || int64(labels[0].(float64)) != weeklyID {
+		t.Fatalf("expected fuzzy label %d, got %v", weeklyID, labels)
+	}
+	if int64(entry["payment_card_id"].(float64)) != cardID || entry["note"].(string) != "market run" {
+		t.Fatalf("expected card %d and note, got %v", cardID, entry)
+	}
+	if !strings.Contains(prompts, "Currency [EUR]: ") || !strings.Contains(prompts, "2) Groceries") {
+		t.Fatalf("expected settings default and pick list in prompts, got %q", prompts)
+	}
+
+	truncated, _ := run("income\n", []string{"add", "-i"})
+	if ok, _ := truncated["ok"].(bool); ok {
+		t.Fatalf("expected truncated interactive input to fail payload=%v", truncated)
+	}
+	if code := mustMap(t, truncated["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %s", code)
+	}
+}
+
+func TestEntryCommandJSONQuickParsesTextIntoEntry(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	coffeeID := insertTestCategory(t, db, "Coffee")
+	workID := insertTestLabel(t, db, "work")
+	cardID := insertTestCard(t, db, "Visa Gold", "Daily card", "4242", "VISA", "credit", 10)
+
+	payload := executeEntryCmdJSON(t, db, []string{"quick", "coffee 4.50 2026-02-10 #work @visa"})
+	mustEntrySuccess(t, payload)
+
+	data := mustMap(t, payload["data"])
+	entry := mustMap(t, data["entry"])
+	if int64(entry["amount_minor"].(float64)) != 450 || entry["currency_code"].(string) != "USD" || entry["type"].(string) != "expense" {
+		t.Fatalf("unexpected quick entry %v", entry)
+	}
+	if entry["transaction_date_utc"].(string) != "2026-02-10T00:00:00Z" || entry["note"].(string) != "coffee" {
+		t.Fatalf("unexpected quick entry date/note %v", entry)
+	}
+	if int64(entry["category_id"].(float64)) != coffeeID || data["category_keyword"].(string) != "coffee" {
+		t.Fatalf("expected category keyword match %d, got %v", coffeeID, data)
+	}
+	labels := mustAnySlice(t, entry["label_ids"])
+	if len(labels) != 1 || int64(labels[0].(float64)) != workID {
+		t.Fatalf("expected #work label %d, got %v", workID, labels)
+	}
+	if entry["payment_method"].(string) != "card" || int64(entry["payment_card_id"].(float64)) != cardID {
+		t.Fatalf("expected @visa card %d, got %v", cardID, entry)
+	}
+
+	income := executeEntryCmdJSON(t, db, []string{"quick", "+3500", "salary", "EUR"})
+	mustEntrySuccess(t, income)
+	incomeEntry := mustMap(t, mustMap(t, income["data"])["entry"])
+	if incomeEntry["type"].(string) != "income" || incomeEntry["currency_code"].(string) != "EUR" || incomeEntry["category_id"] != nil {
+		t.Fatalf("unexpected income quick entry %v", incomeEntry)
+	}
+
+	missingLabel := executeEntryCmdJSON(t, db, []string{"quick", "lunch 12 #nope"})
+	if code := mustMap(t, missingLabel["error"])["code"].(string); code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unknown label, got %s", code)
+	}
+	missingAmount := executeEntryCmdJSON(t, db, []string{"quick", "lunch yesterday"})
+	if code := mustMap(t, missingAmount["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT without amount, got %s", code)
+	}
+}
+
 func TestEntryCommandJSONAddRequiresAmount(t *testing.T) {
 	t.Parallel()
 
@@ -664,6 +919,820 @@ func TestEntryCommandJSONUpdateAmountRequiresCurrency(t *testing.T) {
 	}
 }
 
+func TestEntryCommandJSONUpdateIfUpdatedAtRejectsStaleWrites(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	addPayload := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "12.00",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+	})
+	if ok, _ := addPayload["ok"].(bool); !ok {
+		t.Fatalf("expected add ok=true payload=%v", addPayload)
+	}
+	added := mustMap(t, mustMap(t, addPayload["data"])["entry"])
+	entryID := strconv.FormatInt(int64(added["id"].(float64)), 10)
+	readAt := added["updated_at_utc"].(string)
+
+	updatePayload := executeEntryCmdJSON(t, db, []string{
+		"update", entryID,
+		"--note", "first",
+		"--if-updated-at", readAt,
+	})
+	if ok, _ := updatePayload["ok"].(bool); !ok {
+		t.Fatalf("expected update ok=true payload=%v", updatePayload)
+	}
+
+	if _, err := db.Exec("UPDATE transactions SET updated_at_utc = '2099-01-01T00:00:00Z' WHERE id = ?;", entryID); err != nil {
+		t.Fatalf("simulate concurrent write: %v", err)
+	}
+
+	stalePayload := executeEntryCmdJSON(t, db, []string{
+		"update", entryID,
+		"--note", "second",
+		"--if-updated-at", readAt,
+	})
+	if ok, _ := stalePayload["ok"].(bool); ok {
+		t.Fatalf("expected ok=false payload=%v", stalePayload)
+	}
+	if code := mustMap(t, stalePayload["error"])["code"].(string); code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT, got %v", code)
+	}
+
+	var note string
+	if err := db.QueryRow("SELECT note FROM transactions WHERE id = ?;", entryID).Scan(&note); err != nil {
+		t.Fatalf("read note: %v", err)
+	}
+	if note != "first" {
+		t.Fatalf("expected stale update to leave note untouched, got %q", note)
+	}
+}
+
+func TestEntryCommandJSONHistoryAndRevert(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	addPayload := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "12.00",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+		"--note", "lunch",
+	})
+	mustEntrySuccess(t, addPayload)
+	entryID := strconv.FormatInt(int64(mustMap(t, mustMap(t, addPayload["data"])["entry"])["id"].(float64)), 10)
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"update", entryID, "--amount", "15.50", "--currency", "USD"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"update", entryID, "--note", "team lunch"}))
+	// An update that changes nothing leaves no revision.
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"update", entryID, "--note", "team lunch"}))
+
+	historyPayload := executeEntryCmdJSON(t, db, []string{"history", entryID})
+	mustEntrySuccess(t, historyPayload)
+	revisions := mustAnySlice(t, mustMap(t, historyPayload["data"])["revisions"])
+	if len(revisions) != 2 {
+		t.Fatalf("expected 2 revisions, got %d payload=%v", len(revisions), historyPayload)
+	}
+	first := mustMap(t, revisions[0])
+	if first["revision"].(float64) != 1 || mustMap(t, first["state"])["amount_minor"].(float64) != 1200 {
+		t.Fatalf("unexpected first revision: %v", first)
+	}
+	changes := mustAnySlice(t, first["changes"])
+	if len(changes) != 1 || mustMap(t, changes[0])["field"] != "amount_minor" || mustMap(t, changes[0])["to"].(float64) != 1550 {
+		t.Fatalf("expected first revision to record the amount change, got %v", changes)
+	}
+	changes = mustAnySlice(t, mustMap(t, revisions[1])["changes"])
+	if len(changes) != 1 || mustMap(t, changes[0])["field"] != "note" || mustMap(t, changes[0])["from"] != "lunch" || mustMap(t, changes[0])["to"] != "team lunch" {
+		t.Fatalf("expected second revision to record the note change, got %v", changes)
+	}
+
+	revertPayload := executeEntryCmdJSON(t, db, []string{"revert", entryID, "--to", "1"})
+	mustEntrySuccess(t, revertPayload)
+	reverted := mustMap(t, mustMap(t, revertPayload["data"])["entry"])
+	if reverted["amount_minor"].(float64) != 1200 || reverted["note"] != "lunch" {
+		t.Fatalf("expected revert to restore revision 1, got %v", reverted)
+	}
+
+	historyPayload = executeEntryCmdJSON(t, db, []string{"history", entryID})
+	if count := mustMap(t, historyPayload["data"])["count"].(float64); count != 3 {
+		t.Fatalf("expected the revert to add a revision, got %v", count)
+	}
+
+	missingPayload := executeEntryCmdJSON(t, db, []string{"revert", entryID, "--to", "9"})
+	if code := mustMap(t, missingPayload["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unknown revision, got %v", missingPayload)
+	}
+	invalidPayload := executeEntryCmdJSON(t, db, []string{"revert", entryID, "--to", "0"})
+	if code := mustMap(t, invalidPayload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for revision 0, got %v", invalidPayload)
+	}
+}
+
+func TestEntryCommandJSONInputRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Food")
+	dir := t.TempDir()
+
+	addPath := filepath.Join(dir, "add.json")
+	addJSON := fmt.Sprintf(`{"type":"expense","amount_minor":1250,"transaction_date_utc":"2026-02-01T00:00:00Z","category_id":%d,"note":"lunch","payee":"Cafe","id":99,"created_at_utc":"ignored"}`, categoryID)
+	if err := os.WriteFile(addPath, []byte(addJSON), 0o600); err != nil {
+		t.Fatalf("write add json: %v", err)
+	}
+	addPayload := executeEntryCmdJSON(t, db, []string{"add", "--json-input", addPath})
+	mustEntrySuccess(t, addPayload)
+	added := mustMap(t, mustMap(t, addPayload["data"])["entry"])
+	if added["amount_minor"].(float64) != 1250 || added["currency_code"] != "USD" || added["note"] != "lunch" || added["category_id"].(float64) != float64(categoryID) {
+		t.Fatalf("unexpected entry added from json: %v", added)
+	}
+	entryID := strconv.FormatInt(int64(added["id"].(float64)), 10)
+
+	// Feed the whole show envelope back with an edit, as a script would.
+	showRaw := executeEntryCmdRaw(t, db, output.FormatJSON, []string{"show", entryID})
+	showPayload := map[string]any{}
+	if err := json.Unmarshal([]byte(showRaw), &showPayload); err != nil {
+		t.Fatalf("unmarshal show payload: %v", err)
+	}
+	shown := mustMap(t, mustMap(t, showPayload["data"])["entry"])
+	shown["amount_minor"] = 1500
+	shown["note"] = nil
+	updateRaw, err := json.Marshal(showPayload)
+	if err != nil {
+		t.Fatalf("marshal update json: %v", err)
+	}
+	updatePath := filepath.Join(dir, "update.json")
+	if err := os.WriteFile(updatePath, updateRaw, 0o600); err != nil {
+		t.Fatalf("write update json: %v", err)
+	}
+	updatePayload := executeEntryCmdJSON(t, db, []string{"update", entryID, "--json-input", updatePath})
+	mustEntrySuccess(t, updatePayload)
+	updated := mustMap(t, mustMap(t, updatePayload["data"])["entry"])
+	if updated["amount_minor"].(float64) != 1500 || updated["note"] != nil || updated["payee"] != "Cafe" {
+		t.Fatalf("unexpected entry updated from json: %v", updated)
+	}
+
+	// Keys left out stay unchanged.
+	patchPath := filepath.Join(dir, "patch.json")
+	if err := os.WriteFile(patchPath, []byte(`{"entry":{"payee":"Bistro"}}`), 0o600); err != nil {
+		t.Fatalf("write patch json: %v", err)
+	}
+	patchPayload := executeEntryCmdJSON(t, db, []string{"update", entryID, "--json-input", patchPath})
+	mustEntrySuccess(t, patchPayload)
+	patched := mustMap(t, mustMap(t, patchPayload["data"])["entry"])
+	if patched["payee"] != "Bistro" || patched["amount_minor"].(float64) != 1500 || patched["category_id"].(float64) != float64(categoryID) {
+		t.Fatalf("unexpected entry patched from json: %v", patched)
+	}
+
+	stdinCmd := NewEntryCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	stdinOut := &bytes.Buffer{}
+	stdinCmd.SetOut(stdinOut)
+	stdinCmd.SetErr(stdinOut)
+	stdinCmd.SetIn(strings.NewReader(`{"type":"income","amount_minor":300000,"currency_code":"EUR","transaction_date_utc":"2026-02-02"}`))
+	stdinCmd.SetArgs([]string{"add", "--json-input", "-"})
+	if err := stdinCmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute entry add from stdin: %v", err)
+	}
+	stdinPayload := map[string]any{}
+	if err := json.Unmarshal(stdinOut.Bytes(), &stdinPayload); err != nil {
+		t.Fatalf("unmarshal stdin payload: %v raw=%s", err, stdinOut.String())
+	}
+	mustEntrySuccess(t, stdinPayload)
+	if entry := mustMap(t, mustMap(t, stdinPayload["data"])["entry"]); entry["type"] != "income" || entry["currency_code"] != "EUR" {
+		t.Fatalf("unexpected entry added from stdin: %v", entry)
+	}
+
+	conflictPayload := executeEntryCmdJSON(t, db, []string{"add", "--json-input", addPath, "--amount", "3.00"})
+	if code := mustMap(t, conflictPayload["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT when mixing json-input with flags, got %v", conflictPayload)
+	}
+	missingPath := filepath.Join(dir, "missing.json")
+	if err := os.WriteFile(missingPath, []byte(`{"type":"expense","amount_minor":100}`), 0o600); err != nil {
+		t.Fatalf("write missing json: %v", err)
+	}
+	missingPayload := executeEntryCmdJSON(t, db, []string{"add", "--json-input", missingPath})
+	if details := mustMap(t, mustMap(t, missingPayload["error"])["details"]); details["field"] != "transaction_date_utc" {
+		t.Fatalf("expected missing transaction_date_utc error, got %v", missingPayload)
+	}
+	badPayload := executeEntryCmdJSON(t, db, []string{"add", "--json-input", filepath.Join(dir, "absent.json")})
+	if details := mustMap(t, mustMap(t, badPayload["error"])["details"]); details["field"] != "json-input" {
+		t.Fatalf("expected json-input parse error, got %v", badPayload)
+	}
+}
+
+func TestEntryCommandJSONRefundNetsSpendingAndCaps(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Shoes")
+	mustEntrySuccess(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-02", "--amount", "90.00", "--currency", "USD"}))
+
+	addPayload := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "100.00",
+		"--currency", "USD",
+		"--date", "2026-02-01",
+		"--category-id", strconv.FormatInt(categoryID, 10),
+	})
+	mustEntrySuccess(t, addPayload)
+	originalID := strconv.FormatInt(int64(mustMap(t, mustMap(t, addPayload["data"])["entry"])["id"].(float64)), 10)
+
+	refundPayload := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "30.00",
+		"--currency", "USD",
+		"--date", "2026-02-10",
+		"--refund-of", originalID,
+	})
+	mustEntrySuccess(t, refundPayload)
+	refund := mustMap(t, mustMap(t, refundPayload["data"])["entry"])
+	if got := strconv.FormatInt(int64(refund["refund_of_entry_id"].(float64)), 10); got != originalID {
+		t.Fatalf("expected refund_of_entry_id %s, got %s", originalID, got)
+	}
+	if got := int64(refund["category_id"].(float64)); got != categoryID {
+		t.Fatalf("expected refund to inherit category %d, got %d", categoryID, got)
+	}
+	if warnings := mustAnySlice(t, refundPayload["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected refund to carry no cap warnings, got %v", warnings)
+	}
+
+	tooLarge := executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "80.00",
+		"--currency", "USD",
+		"--date", "2026-02-11",
+		"--refund-of", originalID,
+	})
+	if code := mustMap(t, tooLarge["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for over-refund, got %v", code)
+	}
+
+	monthly := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02"})
+	mustEntrySuccess(t, monthly)
+	monthlyData := mustMap(t, monthly["data"])
+	spending := mustMap(t, monthlyData["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 7000 {
+		t.Fatalf("expected spending net of refund USD=7000, got %d", got)
+	}
+	capStatus := mustMap(t, mustAnySlice(t, monthlyData["cap_status"])[0])
+	if capStatus["is_exceeded"].(bool) {
+		t.Fatalf("expected refund to bring spend under cap, got %v", capStatus)
+	}
+
+	deletePayload := executeEntryCmdJSON(t, db, []string{"delete", originalID})
+	if code := mustMap(t, deletePayload["error"])["code"].(string); code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT deleting refunded entry, got %v", code)
+	}
+}
+
+func TestEntryCommandJSONShowAndExpand(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Food")
+	labelID := insertTestLabel(t, db, "weekly")
+	cardID := insertTestCard(t, db, "Main Visa", "", "1234", "VISA", "credit", 15)
+
+	addPayload := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "12.00", "--currency", "USD", "--date", "2026-02-01",
+		"--category-id", strconv.FormatInt(categoryID, 10),
+		"--label-id", strconv.FormatInt(labelID, 10),
+		"--payment-method", "card", "--card-id", strconv.FormatInt(cardID, 10),
+	})
+	mustEntrySuccess(t, addPayload)
+	entryID := int64(mustMap(t, mustMap(t, addPayload["data"])["entry"])["id"].(float64))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "50.00", "--currency", "USD", "--date", "2026-02-02"}))
+
+	plain := executeEntryCmdJSON(t, db, []string{"show", strconv.FormatInt(entryID, 10)})
+	assertSuccessJSONEnvelope(t, plain)
+	plainEntry := mustMap(t, mustMap(t, plain["data"])["entry"])
+	if _, ok := plainEntry["payment_card"]; ok {
+		t.Fatalf("expected no embedded card without --expand, got %v", plainEntry)
+	}
+
+	shown := executeEntryCmdJSON(t, db, []string{"show", strconv.FormatInt(entryID, 10), "--expand", "card,category,labels"})
+	assertSuccessJSONEnvelope(t, shown)
+	entry := mustMap(t, mustMap(t, shown["data"])["entry"])
+	card := mustMap(t, entry["payment_card"])
+	if card["nickname"] != "Main Visa" || card["last4"] != "1234" || card["card_type"] != "credit" {
+		t.Fatalf("unexpected embedded card %v", card)
+	}
+	if category := mustMap(t, entry["category"]); category["name"] != "Food" {
+		t.Fatalf("unexpected embedded category %v", category)
+	}
+	labels := mustAnySlice(t, entry["labels"])
+	if len(labels) != 1 || mustMap(t, labels[0])["name"] != "weekly" {
+		t.Fatalf("unexpected embedded labels %v", labels)
+	}
+
+	listed := executeEntryCmdJSON(t, db, []string{"list", "--expand", "labels"})
+	assertSuccessJSONEnvelope(t, listed)
+	for _, raw := range mustAnySlice(t, mustMap(t, listed["data"])["entries"]) {
+		listedEntry := mustMap(t, raw)
+		if _, ok := listedEntry["labels"]; !ok {
+			t.Fatalf("expected labels on every expanded entry, got %v", listedEntry)
+		}
+		if _, ok := listedEntry["category"]; ok {
+			t.Fatalf("expected category only when requested, got %v", listedEntry)
+		}
+	}
+
+	invalid := executeEntryCmdJSON(t, db, []string{"list", "--expand", "payee"})
+	if invalid["ok"] != false || mustMap(t, invalid["error"])["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown expand, got %v", invalid)
+	}
+
+	missing := executeEntryCmdJSON(t, db, []string{"show", "9999"})
+	if missing["ok"] != false || mustMap(t, missing["error"])["code"] != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for missing entry, got %v", missing)
+	}
+}
+
+func TestEntryCommandJSONAmountCurrencyAndNoteFilters(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"--amount", "5.00", "--currency", "USD", "--note", "coffee"},
+		{"--amount", "45.00", "--currency", "USD", "--note", "Groceries"},
+		{"--amount", "120.00", "--currency", "USD", "--note", "groceries bulk"},
+		{"--amount", "45.00", "--currency", "EUR", "--note", "groceries"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{"add", "--type", "expense", "--date", "2026-02-10"}, args...)))
+	}
+
+	listed := executeEntryCmdJSON(t, db, []string{"list", "--currency", "usd", "--amount-min", "10", "--amount-max", "100.00"})
+	mustEntrySuccess(t, listed)
+	entries := mustAnySlice(t, mustMap(t, listed["data"])["entries"])
+	if len(entries) != 1 || mustMap(t, entries[0])["amount_minor"].(float64) != 4500 || mustMap(t, entries[0])["currency_code"] != "USD" {
+		t.Fatalf("expected only the 45.00 USD entry, got %v", entries)
+	}
+
+	listed = executeEntryCmdJSON(t, db, []string{"list", "--note-contains", "grocer", "--currency", "USD"})
+	if count := mustMap(t, listed["data"])["count"]; count != float64(2) {
+		t.Fatalf("expected 2 USD grocery entries, got %v", count)
+	}
+
+	missingCurrency := executeEntryCmdJSON(t, db, []string{"list", "--amount-min", "10"})
+	if code := mustMap(t, missingCurrency["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT without currency, got %v", missingCurrency)
+	}
+	inverted := executeEntryCmdJSON(t, db, []string{"list", "--currency", "USD", "--amount-min", "50", "--amount-max", "10"})
+	if code := mustMap(t, inverted["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for inverted range, got %v", inverted)
+	}
+
+	report := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02", "--currency", "USD", "--amount-min", "10", "--note-contains", "grocer"})
+	mustEntrySuccess(t, report)
+	spending := mustAnySlice(t, mustMap(t, mustMap(t, report["data"])["spending"])["by_currency"])
+	if len(spending) != 1 || mustMap(t, spending[0])["total_major"] != "165.00" {
+		t.Fatalf("expected 165.00 USD grocery spending over 10.00, got %v", spending)
+	}
+}
+
+func TestEntryCommandJSONPayeeFiltersAndPayeeList(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"--amount", "12.50", "--date", "2026-03-01", "--payee", "  Corner   Cafe "},
+		{"--amount", "7.50", "--date", "2026-03-05", "--payee", "corner cafe"},
+		{"--amount", "40.00", "--date", "2026-03-06", "--payee", "Grocer"},
+		{"--amount", "3.00", "--date", "2026-03-07"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{"add", "--type", "expense", "--currency", "USD"}, args...)))
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list", "--payee", "CORNER CAFE"})
+	mustEntrySuccess(t, listPayload)
+	entries := mustAnySlice(t, mustMap(t, listPayload["data"])["entries"])
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries for payee filter, got %d", len(entries))
+	}
+	if got := mustMap(t, entries[0])["payee"]; got != "Corner Cafe" {
+		t.Fatalf("expected normalized payee %q, got %v", "Corner Cafe", got)
+	}
+
+	monthly := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03", "--payee", "grocer"})
+	mustEntrySuccess(t, monthly)
+	spending := mustMap(t, mustMap(t, monthly["data"])["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 4000 {
+		t.Fatalf("expected payee-filtered spending USD=4000, got %d", got)
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := NewPayeeCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"list", "--from", "2026-03-01", "--to", "2026-03-31"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute payee list: %v", err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal payee payload: %v raw=%s", err, buf.String())
+	}
+	mustEntrySuccess(t, payload)
+	payees := mustAnySlice(t, mustMap(t, payload["data"])["payees"])
+	if len(payees) != 2 {
+		t.Fatalf("expected 2 payees, got %v", payees)
+	}
+	top := mustMap(t, payees[0])
+	if top["payee"] != "Grocer" || top["spend_minor"].(float64) != 4000 {
+		t.Fatalf("expected Grocer first with 4000 spend, got %v", top)
+	}
+	cafe := mustMap(t, payees[1])
+	if cafe["entry_count"].(float64) != 2 || cafe["spend_minor"].(float64) != 2000 {
+		t.Fatalf("expected Corner Cafe grouped case-insensitively, got %v", cafe)
+	}
+}
+
+func TestEntryCommandJSONRecordedByFiltersAndPersonBreakdown(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"--type", "expense", "--amount", "12.00", "--date", "2026-03-01", "--by", " Ana "},
+		{"--type", "income", "--amount", "100.00", "--date", "2026-03-02", "--by", "ana"},
+		{"--type", "expense", "--amount", "30.00", "--date", "2026-03-03", "--by", "Ben"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{"add", "--currency", "USD"}, args...)))
+	}
+
+	// Entries added without --by take the settings default.
+	buf := &bytes.Buffer{}
+	cmd := NewEntryCmd(&RootOptions{Output: output.FormatJSON, db: db, defaultBy: "Ben"})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"add", "--type", "expense", "--currency", "USD", "--amount", "5.00", "--date", "2026-03-04"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute entry add: %v", err)
+	}
+	defaulted := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &defaulted); err != nil {
+		t.Fatalf("unmarshal entry payload: %v raw=%s", err, buf.String())
+	}
+	mustEntrySuccess(t, defaulted)
+	if got := mustMap(t, mustMap(t, defaulted["data"])["entry"])["recorded_by"]; got != "Ben" {
+		t.Fatalf("expected default recorded_by Ben, got %v", got)
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list", "--by", "ANA"})
+	mustEntrySuccess(t, listPayload)
+	entries := mustAnySlice(t, mustMap(t, listPayload["data"])["entries"])
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries recorded by Ana, got %d", len(entries))
+	}
+	if got := mustMap(t, entries[0])["recorded_by"]; got != "Ana" {
+		t.Fatalf("expected normalized recorded_by %q, got %v", "Ana", got)
+	}
+
+	cleared := executeEntryCmdJSON(t, db, []string{"update", "4", "--clear-by"})
+	mustEntrySuccess(t, cleared)
+	if _, ok := mustMap(t, mustMap(t, cleared["data"])["entry"])["recorded_by"]; ok {
+		t.Fatalf("expected recorded_by cleared, got %v", cleared)
+	}
+
+	monthly := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03"})
+	mustEntrySuccess(t, monthly)
+	byPerson := mustAnySlice(t, mustMap(t, monthly["data"])["by_person"])
+	if len(byPerson) != 3 {
+		t.Fatalf("expected unattributed, Ana and Ben buckets, got %v", byPerson)
+	}
+	ana := mustMap(t, byPerson[1])
+	if ana["person"] != "Ana" || ana["entry_count"].(float64) != 2 || reportAmountForItem(t, ana, "net") != 8800 {
+		t.Fatalf("expected Ana with 2 entries and net 8800, got %v", ana)
+	}
+
+	filtered := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03", "--by", "ben"})
+	mustEntrySuccess(t, filtered)
+	spending := mustMap(t, mustMap(t, filtered["data"])["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 3000 {
+		t.Fatalf("expected Ben-filtered spending USD=3000, got %d", got)
+	}
+}
+
+func TestEntryCommandJSONIncomeSourceFilterAndReportBreakdown(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"--type", "income", "--amount", "3000.00", "--date", "2026-03-01", "--source", " Salary "},
+		{"--type", "income", "--amount", "400.00", "--date", "2026-03-10", "--source", "freelance"},
+		{"--type", "income", "--amount", "600.00", "--date", "2026-03-20", "--source", "FREELANCE"},
+		{"--type", "income", "--amount", "20.00", "--date", "2026-03-21"},
+		{"--type", "expense", "--amount", "50.00", "--date", "2026-03-22"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{"add", "--currency", "USD"}, args...)))
+	}
+
+	rejected := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--currency", "USD", "--amount", "5.00", "--date", "2026-03-23", "--source", "salary"})
+	if code := mustMap(t, rejected["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for a source on an expense, got %v", rejected)
+	}
+	rejected = executeEntryCmdJSON(t, db, []string{"update", "5", "--source", "salary"})
+	if code := mustMap(t, rejected["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for a source on an expense, got %v", rejected)
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list", "--source", "Freelance"})
+	mustEntrySuccess(t, listPayload)
+	entries := mustAnySlice(t, mustMap(t, listPayload["data"])["entries"])
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 freelance entries, got %d", len(entries))
+	}
+	if got := mustMap(t, entries[0])["income_source"]; got != "freelance" {
+		t.Fatalf("expected income_source freelance, got %v", got)
+	}
+
+	monthly := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03"})
+	mustEntrySuccess(t, monthly)
+	bySource := mustAnySlice(t, mustMap(t, monthly["data"])["earnings_by_source"])
+	if len(bySource) != 3 {
+		t.Fatalf("expected salary, freelance and unsourced buckets, got %v", bySource)
+	}
+	salary, freelance, unsourced := mustMap(t, bySource[0]), mustMap(t, bySource[1]), mustMap(t, bySource[2])
+	if salary["source"] != "Salary" || reportAmountForItem(t, salary, "total") != 300000 {
+		t.Fatalf("expected Salary first with 300000, got %v", salary)
+	}
+	if freelance["entry_count"].(float64) != 2 || reportAmountForItem(t, freelance, "total") != 100000 {
+		t.Fatalf("expected freelance with 2 entries and 100000, got %v", freelance)
+	}
+	if unsourced["source"] != "" || reportAmountForItem(t, unsourced, "total") != 2000 {
+		t.Fatalf("expected unsourced income of 2000, got %v", unsourced)
+	}
+
+	// Income turned into an expense drops its source.
+	changed := executeEntryCmdJSON(t, db, []string{"update", "2", "--type", "expense"})
+	mustEntrySuccess(t, changed)
+	if _, ok := mustMap(t, mustMap(t, changed["data"])["entry"])["income_source"]; ok {
+		t.Fatalf("expected income_source dropped for an expense, got %v", changed)
+	}
+}
+
+func TestEntryCommandJSONRecordInConvertsAndKeepsOriginal(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	// A cached rate keeps the converter off the network.
+	if _, err := db.Exec(`INSERT INTO fx_rate_snapshots (provider, base_currency, quote_currency, rate, rate_date, is_estimate)
+		VALUES ('frankfurter', 'EUR', 'USD', '1.1', '2026-02-03', 0)`); err != nil {
+		t.Fatalf("insert fx snapshot: %v", err)
+	}
+	cardID := insertTestCard(t, db, "Travel", "", "4242", "VISA", "credit", 10)
+	if _, err := db.Exec(`UPDATE cards SET fx_fee_bp = 250 WHERE id = ?`, cardID); err != nil {
+		t.Fatalf("set card fx fee: %v", err)
+	}
+	cardIDText := strconv.FormatInt(cardID, 10)
+
+	addPayload := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "100.00", "--currency", "EUR", "--record-in", "usd", "--date", "2026-02-03",
+		"--payment-method", "card", "--card-id", cardIDText,
+	})
+	mustEntrySuccess(t, addPayload)
+	entry := mustMap(t, mustMap(t, addPayload["data"])["entry"])
+	if entry["currency_code"] != "USD" || int64(entry["amount_minor"].(float64)) != 11000 {
+		t.Fatalf("expected entry recorded as 110.00 USD, got %v", entry)
+	}
+	original := mustMap(t, entry["original"])
+	if original["currency_code"] != "EUR" || int64(original["amount_minor"].(float64)) != 10000 ||
+		original["fx_rate"] != "1.1" || original["fx_rate_date"] != "2026-02-03" {
+		t.Fatalf("unexpected original amount %v", original)
+	}
+
+	// The charge was made in EUR, so the card's fee applies to the USD amount.
+	debtPayload := executeCardCmdJSON(t, db, []string{"debt", "show", "--card-id", cardIDText})
+	bucket := mustMap(t, mustAnySlice(t, mustMap(t, mustMap(t, debtPayload["data"])["debt"])["buckets"])[0])
+	if bucket["currency_code"] != "USD" || int64(bucket["balance_minor_signed"].(float64)) != 11275 {
+		t.Fatalf("expected USD debt including the fx fee, got %v", bucket)
+	}
+
+	sameCurrency := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "income", "--amount", "5.00", "--currency", "USD", "--record-in", "USD", "--date", "2026-02-03",
+	})
+	mustEntrySuccess(t, sameCurrency)
+	if _, ok := mustMap(t, mustMap(t, sameCurrency["data"])["entry"])["original"]; ok {
+		t.Fatalf("expected no original amount when currencies match, got %v", sameCurrency)
+	}
+
+	entryIDText := strconv.FormatInt(int64(entry["id"].(float64)), 10)
+	updatePayload := executeEntryCmdJSON(t, db, []string{"update", entryIDText, "--note", "dinner"})
+	mustEntrySuccess(t, updatePayload)
+	if _, ok := mustMap(t, mustMap(t, updatePayload["data"])["entry"])["original"]; !ok {
+		t.Fatalf("expected original amount kept on a note update, got %v", updatePayload)
+	}
+	updatePayload = executeEntryCmdJSON(t, db, []string{"update", entryIDText, "--amount", "120.00", "--currency", "USD"})
+	mustEntrySuccess(t, updatePayload)
+	if _, ok := mustMap(t, mustMap(t, updatePayload["data"])["entry"])["original"]; ok {
+		t.Fatalf("expected original amount cleared once the amount changes, got %v", updatePayload)
+	}
+
+	invalid := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "1.00", "--currency", "EUR", "--record-in", "DOLLARS", "--date", "2026-02-03",
+	})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_CURRENCY_CODE" {
+		t.Fatalf("expected INVALID_CURRENCY_CODE, got %v", invalid)
+	}
+}
+
+func TestEntryCommandJSONLocationFilterAndReportBreakdown(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"--type", "expense", "--amount", "30.00", "--date", "2026-03-01", "--location", " Lisbon,  Portugal "},
+		{"--type", "expense", "--amount", "12.00", "--date", "2026-03-02", "--location", "lisbon airport"},
+		{"--type", "expense", "--amount", "8.00", "--date", "2026-03-03", "--location", "38.7223, -9.1393"},
+		{"--type", "expense", "--amount", "5.00", "--date", "2026-03-04"},
+		{"--type", "income", "--amount", "100.00", "--date", "2026-03-05", "--location", "Lisbon, Portugal"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, append([]string{"add", "--currency", "USD"}, args...)))
+	}
+
+	rejected := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--currency", "USD", "--amount", "5.00", "--date", "2026-03-06", "--location", "95,10"})
+	if code := mustMap(t, rejected["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for an out-of-range latitude, got %v", rejected)
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list", "--location-contains", "LISBON"})
+	mustEntrySuccess(t, listPayload)
+	entries := mustAnySlice(t, mustMap(t, listPayload["data"])["entries"])
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries in Lisbon, got %d", len(entries))
+	}
+	if got := mustMap(t, entries[0])["location"]; got != "Lisbon, Portugal" {
+		t.Fatalf("expected collapsed location, got %v", got)
+	}
+
+	coordinates := executeEntryCmdJSON(t, db, []string{"list", "--location-contains", "38.7223"})
+	mustEntrySuccess(t, coordinates)
+	entries = mustAnySlice(t, mustMap(t, coordinates["data"])["entries"])
+	if len(entries) != 1 || mustMap(t, entries[0])["location"] != "38.7223,-9.1393" {
+		t.Fatalf("expected one entry with normalized coordinates, got %v", entries)
+	}
+
+	monthly := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03"})
+	mustEntrySuccess(t, monthly)
+	byLocation := mustAnySlice(t, mustMap(t, monthly["data"])["spending_by_location"])
+	if len(byLocation) != 4 {
+		t.Fatalf("expected three locations and one unlocated bucket, got %v", byLocation)
+	}
+	first, last := mustMap(t, byLocation[0]), mustMap(t, byLocation[3])
+	if first["location"] != "Lisbon, Portugal" || reportAmountForItem(t, first, "total") != 3000 || first["entry_count"].(float64) != 1 {
+		t.Fatalf("expected expense-only Lisbon, Portugal first with 3000, got %v", first)
+	}
+	if last["location"] != "" || reportAmountForItem(t, last, "total") != 500 {
+		t.Fatalf("expected unlocated spending of 500, got %v", last)
+	}
+
+	filtered := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03", "--location-contains", "airport"})
+	mustEntrySuccess(t, filtered)
+	if got := mustAnySlice(t, mustMap(t, filtered["data"])["spending_by_location"]); len(got) != 1 {
+		t.Fatalf("expected only the airport location, got %v", got)
+	}
+
+	cleared := executeEntryCmdJSON(t, db, []string{"update", "2", "--clear-location"})
+	mustEntrySuccess(t, cleared)
+	if _, ok := mustMap(t, mustMap(t, cleared["data"])["entry"])["location"]; ok {
+		t.Fatalf("expected location cleared, got %v", cleared)
+	}
+}
+
+func TestEntryCommandJSONAddBatchIsAllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := strconv.FormatInt(insertTestCategory(t, db, "Food"), 10)
+	mustEntrySuccess(t, executeCapCmdJSON(t, db, []string{"set", "--month", "2026-04", "--amount", "50.00", "--currency", "USD"}))
+
+	csvPath := filepath.Join(t.TempDir(), "entries.csv")
+	csvBody := "type,amount,currency,date,category_id,payee,note\n" +
+		"expense,30.00,USD,2026-04-02," + categoryID + ",Grocer,week one\n" +
+		"expense,25.00,USD,2026-04-09," + categoryID + ",Grocer,week two\n" +
+		"income,1000.00,USD,2026-04-01,,,salary\n"
+	if err := os.WriteFile(csvPath, []byte(csvBody), 0o600); err != nil {
+		t.Fatalf("write batch csv: %v", err)
+	}
+
+	payload := executeEntryCmdJSON(t, db, []string{"add-batch", "--file", csvPath})
+	mustEntrySuccess(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["count"].(float64) != 3 {
+		t.Fatalf("expected 3 created entries, got %v", data["count"])
+	}
+	for _, raw := range mustAnySlice(t, data["entries"]) {
+		if mustMap(t, raw)["id"].(float64) <= 0 {
+			t.Fatalf("expected created entries to carry IDs, got %v", raw)
+		}
+	}
+	warnings := mustAnySlice(t, payload["warnings"])
+	if len(warnings) != 1 || mustMap(t, warnings[0])["code"] != domain.WarningCodeCapExceeded {
+		t.Fatalf("expected one CAP_EXCEEDED warning from the second row, got %v", warnings)
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := NewEntryCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader(`[
+		{"type": "expense", "amount_minor": 500, "currency": "USD", "date": "2026-04-10"},
+		{"type": "expense", "amount": "5.00", "currency": "USD", "date": "2026-04-11", "category_id": 9999},
+		{"type": "bogus", "amount": "1.00", "currency": "USD", "date": "2026-04-12"}
+	]`))
+	cmd.SetArgs([]string{"add-batch", "--file", "-", "--format", "json"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute add-batch: %v", err)
+	}
+	failed := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &failed); err != nil {
+		t.Fatalf("unmarshal add-batch payload: %v raw=%s", err, buf.String())
+	}
+	errPayload := mustMap(t, failed["error"])
+	if errPayload["code"] != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for rejected batch, got %v", errPayload)
+	}
+	rows := mustAnySlice(t, mustMap(t, errPayload["details"])["rows"])
+	if len(rows) != 1 || mustMap(t, rows[0])["row"].(float64) != 3 {
+		t.Fatalf("expected only row 3 to fail validation, got %v", rows)
+	}
+
+	listPayload := executeEntryCmdJSON(t, db, []string{"list"})
+	if count := mustMap(t, listPayload["data"])["count"].(float64); count != 3 {
+		t.Fatalf("expected rejected batch to add nothing, got %v entries", count)
+	}
+
+	buf.Reset()
+	cmd.SetIn(strings.NewReader(`[{"type": "expense", "amount": "5.00", "currency": "USD", "date": "2026-04-11", "category_id": 9999}]`))
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute add-batch: %v", err)
+	}
+	failed = map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &failed); err != nil {
+		t.Fatalf("unmarshal add-batch payload: %v raw=%s", err, buf.String())
+	}
+	rows = mustAnySlice(t, mustMap(t, mustMap(t, failed["error"])["details"])["rows"])
+	if len(rows) != 1 || mustMap(t, rows[0])["code"] != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND row error for unknown category, got %v", rows)
+	}
+}
+
+func TestEntryCommandJSONAddBatchSetsAttributionColumns(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	csvPath := filepath.Join(t.TempDir(), "entries.csv")
+	csvBody := "type,amount,currency,date,recorded_by,source,location\n" +
+		"income,1000.00,USD,2026-04-01,Ana,salary,\n" +
+		"expense,12.50,USD,2026-04-02,Ben,,Lisbon\n"
+	if err := os.WriteFile(csvPath, []byte(csvBody), 0o600); err != nil {
+		t.Fatalf("write batch csv: %v", err)
+	}
+
+	payload := executeEntryCmdJSON(t, db, []string{"add-batch", "--file", csvPath})
+	mustEntrySuccess(t, payload)
+	entries := mustAnySlice(t, mustMap(t, payload["data"])["entries"])
+	income, expense := mustMap(t, entries[0]), mustMap(t, entries[1])
+	if income["recorded_by"] != "Ana" || income["income_source"] != "salary" {
+		t.Fatalf("expected recorded_by and income_source on the income row, got %v", income)
+	}
+	if expense["recorded_by"] != "Ben" || expense["location"] != "Lisbon" {
+		t.Fatalf("expected recorded_by and location on the expense row, got %v", expense)
+	}
+}
+
 func TestEntryCommandHumanOutput(t *testing.T) {
 	t.Parallel()
 
@@ -684,6 +1753,87 @@ func TestEntryCommandHumanOutput(t *testing.T) {
 	if !strings.Contains(out, "\"amount_minor\": 2500") {
 		t.Fatalf("expected human output to include amount_minor, got %q", out)
 	}
+
+	listOut := executeEntryCmdRaw(t, db, output.FormatHuman, []string{"list"})
+	if !strings.Contains(listOut, "Amount") || !strings.Contains(listOut, "$25.00") {
+		t.Fatalf("expected human entry list table with formatted amount, got %q", listOut)
+	}
+	if strings.Contains(listOut, "\x1b[") {
+		t.Fatalf("expected no ANSI colors when not writing to a terminal, got %q", listOut)
+	}
+}
+
+func TestEntryCommandJSONArchivedCategoryAndLabel(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Gym")
+	labelID := insertTestLabel(t, db, "fitness")
+	categoryArg := strconv.FormatInt(categoryID, 10)
+	labelArg := strconv.FormatInt(labelID, 10)
+
+	baseAdd := []string{"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2026-02-01"}
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, append(baseAdd, "--category-id", categoryArg, "--label-id", labelArg)))
+
+	archivedCategory := mustMap(t, mustMap(t, executeCategoryCmdJSON(t, db, []string{"archive", categoryArg})["data"])["category"])
+	if archivedCategory["archived_at_utc"] == nil {
+		t.Fatalf("expected archived_at_utc on archived category, got %v", archivedCategory)
+	}
+	mustMap(t, executeLabelCmdJSON(t, db, []string{"archive", labelArg})["data"])
+
+	if count := mustMap(t, executeCategoryCmdJSON(t, db, []string{"list"})["data"])["count"]; count != float64(0) {
+		t.Fatalf("expected archived category hidden from list, got count=%v", count)
+	}
+	if count := mustMap(t, executeCategoryCmdJSON(t, db, []string{"list", "--include-archived"})["data"])["count"]; count != float64(1) {
+		t.Fatalf("expected archived category with --include-archived, got count=%v", count)
+	}
+	if count := mustMap(t, executeLabelCmdJSON(t, db, []string{"list"})["data"])["count"]; count != float64(0) {
+		t.Fatalf("expected archived label hidden from list, got count=%v", count)
+	}
+
+	rejected := executeEntryCmdJSON(t, db, append(baseAdd, "--category-id", categoryArg))
+	if code := mustMap(t, rejected["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for archived category, got payload=%v", rejected)
+	}
+	rejected = executeEntryCmdJSON(t, db, append(baseAdd, "--label-id", labelArg))
+	if code := mustMap(t, rejected["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for archived label, got payload=%v", rejected)
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, append(baseAdd, "--category-id", categoryArg, "--label-id", labelArg, "--allow-archived")))
+
+	// Existing entries keep their archived category and label.
+	entries := mustAnySlice(t, mustMap(t, executeEntryCmdJSON(t, db, []string{"list", "--category-id", categoryArg})["data"])["entries"])
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries in archived category, got %d", len(entries))
+	}
+
+	mustMap(t, executeCategoryCmdJSON(t, db, []string{"unarchive", categoryArg})["data"])
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, append(baseAdd, "--category-id", categoryArg)))
+}
+
+func executeCategoryCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewCategoryCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute category cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal category payload: %v raw=%s", err, buf.String())
+	}
+	return payload
 }
 
 func executeEntryCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
@@ -786,3 +1936,43 @@ func mustAnySlice(t *testing.T, value any) []any {
 	}
 	return slice
 }
+
+func TestEntryListJSONMetaAggregates(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "100.00", "--currency", "USD", "--date", "2026-03-01"}))
+	groceries := mustMap(t, mustMap(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-03-04"})["data"])["entry"])
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-03-06", "--refund-of", strconv.FormatInt(int64(groceries["id"].(float64)), 10)}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "12.00", "--currency", "EUR", "--date", "2026-03-09"}))
+
+	payload := executeEntryCmdJSON(t, db, []string{"list"})
+	assertSuccessJSONEnvelope(t, payload)
+	aggregates := mustMap(t, mustMap(t, payload["meta"])["aggregates"])
+	if aggregates["count"] != float64(4) || aggregates["min_date_utc"] != "2026-03-01T00:00:00Z" || aggregates["max_date_utc"] != "2026-03-09T00:00:00Z" {
+		t.Fatalf("unexpected list aggregates: %v", aggregates)
+	}
+	if counts := mustMap(t, aggregates["counts_by_type"]); counts["income"] != float64(1) || counts["expense"] != float64(3) {
+		t.Fatalf("unexpected counts by type: %v", counts)
+	}
+
+	byCurrency := mustAnySlice(t, aggregates["by_currency"])
+	if len(byCurrency) != 2 {
+		t.Fatalf("expected two currencies, got %v", byCurrency)
+	}
+	eur, usd := mustMap(t, byCurrency[0]), mustMap(t, byCurrency[1])
+	if eur["currency_code"] != "EUR" || eur["expense_minor"] != float64(1200) || eur["net_minor"] != float64(-1200) {
+		t.Fatalf("unexpected EUR aggregate: %v", eur)
+	}
+	if usd["income_minor"] != float64(10000) || usd["expense_minor"] != float64(3000) || usd["net_minor"] != float64(7000) || usd["entry_count"] != float64(3) {
+		t.Fatalf("unexpected USD aggregate: %v", usd)
+	}
+
+	empty := executeEntryCmdJSON(t, db, []string{"list", "--from", "2027-01-01"})
+	emptyAggregates := mustMap(t, mustMap(t, empty["meta"])["aggregates"])
+	if emptyAggregates["count"] != float64(0) || len(mustAnySlice(t, emptyAggregates["by_currency"])) != 0 || emptyAggregates["min_date_utc"] != nil {
+		t.Fatalf("unexpected empty aggregates: %v", emptyAggregates)
+	}
+}
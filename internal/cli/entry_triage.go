@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type entryTriageFlags struct {
+	fromRaw     string
+	toRaw       string
+	assignRaw   string
+	interactive bool
+}
+
+type entryTriageAssignment struct {
+	EntryID      int64  `json:"entry_id"`
+	CategoryID   int64  `json:"category_id"`
+	CategoryName string `json:"category_name"`
+}
+
+// entryTriageRequest is one parsed --assign pair before the category name is
+// resolved.
+type entryTriageRequest struct {
+	entryID  int64
+	category string
+}
+
+func newEntryTriageCmd(opts *RootOptions) *cobra.Command {
+	flags := &entryTriageFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "triage",
+		Short: "List uncategorized entries and assign categories in bulk",
+		Long: `List entries without a category and optionally assign categories to them.
+
+Assign with --assign 12:Food,13:Rent (category names match like interactive
+picks: exact, then substring, then in-order letters), or walk every entry with
+--interactive. The result reports the orphan spending that remains per month
+against the orphan-spending warning threshold.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "entry triage does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if flags.interactive && strings.TrimSpace(flags.assignRaw) != "" {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "assign and interactive are mutually exclusive",
+					Details: map[string]any{"fields": []string{"assign", "interactive"}},
+				})
+			}
+
+			requests, err := parseEntryTriageAssignments(flags.assignRaw)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			entries, err := svc.List(cmd.Context(), domain.EntryListFilter{
+				DateFromUTC: flags.fromRaw,
+				DateToUTC:   flags.toRaw,
+			})
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+			orphans := make([]domain.Entry, 0)
+			for _, entry := range entries {
+				if entry.CategoryID == nil {
+					orphans = append(orphans, entry)
+				}
+			}
+
+			categories, err := sqlitestore.NewCategoryRepo(opts.db).List(cmd.Context())
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+			options := make([]entryPickOption, 0, len(categories))
+			for _, category := range domain.UnarchivedCategories(categories) {
+				options = append(options, entryPickOption{ID: category.ID, Name: category.Name})
+			}
+
+			var assignments []entryTriageAssignment
+			if flags.interactive {
+				assignments, err = promptEntryTriageAssignments(cmd, orphans, options)
+				if errors.Is(err, errInteractiveInputEnded) {
+					err = nil
+				}
+			} else {
+				assignments, err = resolveEntryTriageAssignments(requests, orphans, options)
+			}
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			var operationID *int64
+			var snapshot *domain.SafetySnapshot
+			if len(assignments) > 0 {
+				snapshot, err = takeSafetySnapshot(cmd, opts, "entry triage")
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+				operationSvc, err := newOperationService(opts)
+				if err != nil {
+					return printEntryTriageOperationError(cmd, opts, err)
+				}
+				run, err := operationSvc.Start(cmd.Context(), domain.OperationKindEntryTriage, entryTriageOperationParams{Assignments: assignments})
+				if err != nil {
+					return printEntryTriageOperationError(cmd, opts, err)
+				}
+				id := run.Operation().ID
+				operationID = &id
+				if _, err := runEntryTriageOperation(cmd.Context(), opts, run); err != nil {
+					return printEntryTriageOperationError(cmd, opts, err)
+				}
+			}
+
+			assigned := make(map[int64]struct{}, len(assignments))
+			for _, assignment := range assignments {
+				assigned[assignment.EntryID] = struct{}{}
+			}
+
+			remaining := make([]domain.Entry, 0, len(orphans))
+			for _, entry := range orphans {
+				if _, ok := assigned[entry.ID]; !ok {
+					remaining = append(remaining, entry)
+				}
+			}
+
+			data := map[string]any{
+				"entries":       remaining,
+				"count":         len(remaining),
+				"assigned":      assignments,
+				"orphan_status": nil,
+				"operation_id":  operationID,
+			}
+			var warnings []domain.Warning
+			if len(orphans) > 0 {
+				reportSvc, err := newReportService(opts)
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+				status, err := reportSvc.OrphanStatus(cmd.Context(), entryTriageStatusPeriod(orphans))
+				if err != nil {
+					return printEntryError(cmd, entryOutputFormat(opts), err)
+				}
+				data["orphan_status"] = status.Status
+				warnings = status.Warnings
+			}
+
+			env := output.NewSuccessEnvelope(withSafetySnapshot(data, snapshot), toOutputWarnings(warnings))
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, entryListTables(remaining))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Filter start date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Filter end date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.assignRaw, "assign", "", "Comma-separated entry-id:category pairs (e.g. 12:Food,13:Rent)")
+	cmd.Flags().BoolVarP(&flags.interactive, "interactive", "i", false, "Prompt for a category for each uncategorized entry")
+
+	return cmd
+}
+
+// printEntryTriageOperationError reports journal errors, such as a cancel
+// from another process, before falling back to entry error mapping.
+func printEntryTriageOperationError(cmd *cobra.Command, opts *RootOptions, err error) error {
+	if code, message, ok := operationErrorCode(err); ok {
+		return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{Code: code, Message: message, Details: map[string]any{"reason": err.Error()}})
+	}
+	return printEntryError(cmd, entryOutputFormat(opts), err)
+}
+
+func parseEntryTriageAssignments(raw string) ([]entryTriageRequest, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	requests := []entryTriageRequest{}
+	seen := map[int64]struct{}{}
+	for _, pair := range strings.Split(raw, ",") {
+		idRaw, category, ok := strings.Cut(pair, ":")
+		if !ok || strings.TrimSpace(category) == "" {
+			return nil, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "assign must be comma-separated entry-id:category pairs",
+				Details: map[string]any{"field": "assign", "value": pair},
+			}
+		}
+		entryID, err := parsePositiveInt64(idRaw, "assign entry id")
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[entryID]; dup {
+			return nil, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "assign lists an entry more than once",
+				Details: map[string]any{"field": "assign", "entry_id": entryID},
+			}
+		}
+		seen[entryID] = struct{}{}
+		requests = append(requests, entryTriageRequest{entryID: entryID, category: strings.TrimSpace(category)})
+	}
+	return requests, nil
+}
+
+// resolveEntryTriageAssignments checks every pair before anything is written,
+// so a bad id or category name leaves all entries untouched.
+func resolveEntryTriageAssignments(requests []entryTriageRequest, orphans []domain.Entry, options []entryPickOption) ([]entryTriageAssignment, error) {
+	orphanIDs := make(map[int64]struct{}, len(orphans))
+	for _, entry := range orphans {
+		orphanIDs[entry.ID] = struct{}{}
+	}
+
+	assignments := make([]entryTriageAssignment, 0, len(requests))
+	for _, request := range requests {
+		if _, ok := orphanIDs[request.entryID]; !ok {
+			return nil, &entryCLIError{
+				Code:    "NOT_FOUND",
+				Message: "entry is not an uncategorized entry in the triage window",
+				Details: map[string]any{"entry_id": request.entryID},
+			}
+		}
+		matches := fuzzyMatchPickOptions(request.category, options)
+		switch len(matches) {
+		case 0:
+			return nil, &entryCLIError{Code: "NOT_FOUND", Message: "category not found", Details: map[string]any{"category": request.category}}
+		case 1:
+			assignments = append(assignments, entryTriageAssignment{
+				EntryID:      request.entryID,
+				CategoryID:   matches[0].ID,
+				CategoryName: matches[0].Name,
+			})
+		default:
+			return nil, &entryCLIError{Code: "CONFLICT", Message: "category name matches multiple categories", Details: map[string]any{"category": request.category, "matches": pickOptionNames(matches)}}
+		}
+	}
+	return assignments, nil
+}
+
+// promptEntryTriageAssignments asks for a category per entry; a blank answer
+// skips the entry. Ending input early keeps the answers given so far.
+func promptEntryTriageAssignments(cmd *cobra.Command, orphans []domain.Entry, options []entryPickOption) ([]entryTriageAssignment, error) {
+	prompter := &entryPrompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+
+	assignments := []entryTriageAssignment{}
+	for _, entry := range orphans {
+		amount, err := domain.FormatMinorToMajorString(entry.AmountMinor, entry.CurrencyCode)
+		if err != nil {
+			return assignments, err
+		}
+		fmt.Fprintf(prompter.out, "#%d %s %s %s %s %s\n", entry.ID, entry.TransactionDateUTC[:10], entry.Type, amount, entry.CurrencyCode, entry.Note)
+		picked, err := prompter.pick("Category (blank to skip)", options)
+		if err != nil {
+			return assignments, err
+		}
+		if picked == nil {
+			continue
+		}
+		assignments = append(assignments, entryTriageAssignment{
+			EntryID:      entry.ID,
+			CategoryID:   picked.ID,
+			CategoryName: picked.Name,
+		})
+	}
+	return assignments, nil
+}
+
+// entryTriageStatusPeriod covers whole months from the first to the last
+// orphan entry, because orphan thresholds compare against monthly spend.
+func entryTriageStatusPeriod(orphans []domain.Entry) domain.ReportPeriodInput {
+	dates := make([]string, 0, len(orphans))
+	for _, entry := range orphans {
+		dates = append(dates, entry.TransactionDateUTC)
+	}
+	sort.Strings(dates)
+
+	first, _ := time.Parse(time.RFC3339Nano, dates[0])
+	last, _ := time.Parse(time.RFC3339Nano, dates[len(dates)-1])
+	from := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, -1)
+
+	return domain.ReportPeriodInput{
+		Scope:       domain.ReportScopeRange,
+		DateFromUTC: from.Format("2006-01-02"),
+		DateToUTC:   to.Format("2006-01-02"),
+	}
+}
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type envelopeAddFlags struct {
+	name     string
+	budget   string
+	currency string
+	from     string
+	to       string
+	labelID  string
+	note     string
+}
+
+type envelopeCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *envelopeCLIError) Error() string {
+	if e == nil {
+		return "envelope command error"
+	}
+	return e.Message
+}
+
+func NewEnvelopeCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "envelope",
+		Short: "Track trip and project budgets that span categories",
+		Long: `Track trip and project envelopes such as "Japan trip 2026". An envelope has
+a budget, a start and end date, and a label: every expense carrying that label
+and dated within the range counts against it, whatever its category. Envelope
+status is independent from monthly caps.`,
+	}
+
+	cmd.AddCommand(
+		newEnvelopeAddCmd(opts),
+		newEnvelopeListCmd(opts),
+		newEnvelopeShowCmd(opts),
+		newEnvelopeDeleteCmd(opts),
+	)
+
+	return cmd
+}
+
+func newEnvelopeAddCmd(opts *RootOptions) *cobra.Command {
+	flags := &envelopeAddFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add an envelope and print its status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEnvelopeError(cmd, opts.Output, &envelopeCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "envelope add does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			for _, field := range []string{"name", "budget", "from", "to", "label-id"} {
+				if !cmd.Flags().Changed(field) {
+					return printEnvelopeError(cmd, opts.Output, &envelopeCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: field + " is required",
+						Details: map[string]any{"field": field},
+					})
+				}
+			}
+
+			labelID, err := parsePositiveEnvelopeID(flags.labelID, "label-id")
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+			currencyCode := flags.currency
+			if !cmd.Flags().Changed("currency") {
+				currencyCode = defaultCurrency(opts)
+			}
+			budgetMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.budget, currencyCode, amountFormat(opts))
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			svc, err := newEnvelopeService(opts)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			status, err := svc.Add(cmd.Context(), domain.EnvelopeAddInput{
+				Name:         flags.name,
+				CurrencyCode: currencyCode,
+				BudgetMinor:  budgetMinor,
+				StartDate:    flags.from,
+				EndDate:      flags.to,
+				LabelID:      labelID,
+				Note:         flags.note,
+			})
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"envelope_status": status,
+			}, nil), envelopeTables([]domain.EnvelopeStatus{status}))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.name, "name", "", "Envelope name (e.g. \"Japan trip 2026\")")
+	cmd.Flags().StringVar(&flags.budget, "budget", "", "Budget in major units (e.g. 3000)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
+	cmd.Flags().StringVar(&flags.from, "from", "", "First day of the envelope in YYYY-MM-DD")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Last day of the envelope in YYYY-MM-DD")
+	cmd.Flags().StringVar(&flags.labelID, "label-id", "", "Label whose expenses count against the envelope")
+	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
+
+	return cmd
+}
+
+func newEnvelopeListCmd(opts *RootOptions) *cobra.Command {
+	includeDeleted := false
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List envelopes with budget, spent and remaining",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEnvelopeError(cmd, opts.Output, &envelopeCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "envelope list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newEnvelopeService(opts)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			envelopes, err := svc.List(cmd.Context(), includeDeleted)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"envelopes": envelopes,
+				"count":     len(envelopes),
+			}, nil), envelopeTables(envelopes))
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted envelopes")
+
+	return cmd
+}
+
+func newEnvelopeShowCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show an envelope's budget, spent and remaining",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printEnvelopeError(cmd, opts.Output, &envelopeCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "envelope show requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveEnvelopeID(args[0], "id")
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			svc, err := newEnvelopeService(opts)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			status, err := svc.Show(cmd.Context(), id)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"envelope_status": status,
+			}, nil), envelopeTables([]domain.EnvelopeStatus{status}))
+		},
+	}
+}
+
+func newEnvelopeDeleteCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Soft-delete an envelope, keeping its entries and label",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printEnvelopeError(cmd, opts.Output, &envelopeCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "envelope delete requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveEnvelopeID(args[0], "id")
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			svc, err := newEnvelopeService(opts)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			deleted, err := svc.Delete(cmd.Context(), id)
+			if err != nil {
+				return printEnvelopeError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"envelope_delete": deleted,
+			}, nil))
+		},
+	}
+}
+
+func newEnvelopeService(opts *RootOptions) (*service.EnvelopeService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &envelopeCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewEnvelopeService(sqlitestore.NewEnvelopeRepo(opts.db), sqlitestore.NewEntryRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("envelope service init: %w", err)
+	}
+	return svc, nil
+}
+
+func parsePositiveEnvelopeID(raw, field string) (int64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, &envelopeCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: fmt.Sprintf("%s is required", field),
+			Details: map[string]any{"field": field},
+		}
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, &envelopeCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: fmt.Sprintf("%s must be a positive integer", field),
+			Details: map[string]any{"field": field, "value": raw},
+		}
+	}
+	return parsed, nil
+}
+
+func printEnvelopeError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *envelopeCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromEnvelopeError(err), messageFromEnvelopeError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromEnvelopeError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrEnvelopeNotFound),
+		errors.Is(err, domain.ErrLabelNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidEnvelopeID),
+		errors.Is(err, domain.ErrInvalidLabelID),
+		errors.Is(err, domain.ErrEnvelopeNameRequired),
+		errors.Is(err, domain.ErrInvalidEnvelopeBudget),
+		errors.Is(err, domain.ErrInvalidEnvelopeDate),
+		errors.Is(err, domain.ErrInvalidDateRange),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromEnvelopeError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrEnvelopeNotFound):
+		return "envelope not found"
+	case errors.Is(err, domain.ErrLabelNotFound):
+		return "label not found"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidEnvelopeID):
+		return "envelope id must be a positive integer"
+	case errors.Is(err, domain.ErrInvalidLabelID):
+		return "label id must be a positive integer"
+	case errors.Is(err, domain.ErrEnvelopeNameRequired):
+		return "name is required"
+	case errors.Is(err, domain.ErrInvalidEnvelopeBudget):
+		return "budget must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidEnvelopeDate):
+		return "from and to must use YYYY-MM-DD"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "to must not be before from"
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return "budget must be a valid decimal number"
+	case errors.Is(err, domain.ErrInvalidAmountPrecision):
+		return "budget has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "budget separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrAmountOverflow):
+		return "budget is too large"
+	default:
+		return "database operation failed"
+	}
+}
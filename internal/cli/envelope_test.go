@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestEnvelopeTracksLabeledSpendingAcrossCategories(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	tripLabelID := strconv.FormatInt(insertTestLabel(t, db, "japan-2026"), 10)
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "1200.00", "--currency", "USD", "--date", "2026-04-02", "--label-id", tripLabelID, "--note", "flights"},
+		{"add", "--type", "expense", "--amount", "900.00", "--currency", "USD", "--date", "2026-04-10", "--label-id", tripLabelID, "--note", "hotel"},
+		{"add", "--type", "expense", "--amount", "80.00", "--currency", "USD", "--date", "2026-04-20", "--label-id", tripLabelID, "--note", "after the trip"},
+		{"add", "--type", "expense", "--amount", "50.00", "--currency", "USD", "--date", "2026-04-05", "--note", "unlabeled"},
+		{"add", "--type", "expense", "--amount", "15000", "--currency", "JPY", "--date", "2026-04-06", "--label-id", tripLabelID, "--note", "ramen"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, args))
+	}
+
+	added := mustMap(t, mustMap(t, executeEnvelopeCmdJSON(t, db, []string{
+		"add", "--name", "Japan trip 2026", "--budget", "2000", "--currency", "USD",
+		"--from", "2026-04-01", "--to", "2026-04-15", "--label-id", tripLabelID,
+	})["data"])["envelope_status"])
+	if added["spent_minor"].(float64) != 210000 || added["remaining_minor"].(float64) != -10000 || added["overspent"] != true {
+		t.Fatalf("unexpected envelope status: %v", added)
+	}
+	if added["entry_count"].(float64) != 2 || added["other_currency_entry_count"].(float64) != 1 {
+		t.Fatalf("unexpected envelope entry counts: %v", added)
+	}
+	envelopeID := strconv.FormatInt(int64(mustMap(t, added["envelope"])["id"].(float64)), 10)
+
+	shown := mustMap(t, mustMap(t, executeEnvelopeCmdJSON(t, db, []string{"show", envelopeID})["data"])["envelope_status"])
+	if shown["spent_minor"].(float64) != 210000 {
+		t.Fatalf("unexpected envelope show: %v", shown)
+	}
+
+	listed := mustMap(t, executeEnvelopeCmdJSON(t, db, []string{"list"})["data"])
+	if listed["count"].(float64) != 1 {
+		t.Fatalf("expected one envelope, got %v", listed)
+	}
+
+	missingLabel := executeEnvelopeCmdJSON(t, db, []string{
+		"add", "--name", "Other", "--budget", "10", "--from", "2026-04-01", "--to", "2026-04-15", "--label-id", "999",
+	})
+	if code := mustMap(t, missingLabel["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for an unknown label, got %v", missingLabel)
+	}
+
+	badRange := executeEnvelopeCmdJSON(t, db, []string{
+		"add", "--name", "Other", "--budget", "10", "--from", "2026-04-15", "--to", "2026-04-01", "--label-id", tripLabelID,
+	})
+	if code := mustMap(t, badRange["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for an inverted range, got %v", badRange)
+	}
+
+	deleted := executeEnvelopeCmdJSON(t, db, []string{"delete", envelopeID})
+	if deleted["ok"] != true {
+		t.Fatalf("expected delete to succeed, got %v", deleted)
+	}
+	if missing := executeEnvelopeCmdJSON(t, db, []string{"show", envelopeID}); mustMap(t, missing["error"])["code"] != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND after delete, got %v", missing)
+	}
+}
+
+func executeEnvelopeCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewEnvelopeCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute envelope cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal envelope payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
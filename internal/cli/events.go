@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type eventsTailFlags struct {
+	sinceIDRaw      string
+	limitRaw        string
+	follow          bool
+	pollIntervalRaw string
+}
+
+type eventsCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *eventsCLIError) Error() string {
+	if e == nil {
+		return "events command error"
+	}
+	return e.Message
+}
+
+func NewEventsCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Read the local change feed",
+	}
+
+	cmd.AddCommand(newEventsTailCmd(opts))
+
+	return cmd
+}
+
+func newEventsTailCmd(opts *RootOptions) *cobra.Command {
+	flags := &eventsTailFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print change events (entry.created, cap.updated, card.payment, ...)",
+		Long: `Print change events recorded by the database, oldest first.
+
+With --output json each event is one NDJSON line:
+  {"id":12,"event":"entry.created","entity_type":"entry","entity_id":"7",...}
+
+--follow keeps polling and prints new events as they are written by this or
+any other process using the same database, until interrupted. Pass the last
+seen id to --since-id to resume without gaps.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEventsError(cmd, outputFormat(opts), &eventsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "events tail does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			req, pollInterval, err := buildEventsTailRequest(cmd, flags)
+			if err != nil {
+				return printEventsError(cmd, outputFormat(opts), err)
+			}
+
+			svc, err := newEventService(opts, pollInterval)
+			if err != nil {
+				return printEventsError(cmd, outputFormat(opts), err)
+			}
+
+			format := outputFormat(opts)
+			out := cmd.OutOrStdout()
+			if _, err := svc.Tail(cmd.Context(), req, func(event domain.ChangeEvent) error {
+				return writeChangeEvent(out, format, event)
+			}); err != nil {
+				return printEventsError(cmd, format, err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.sinceIDRaw, "since-id", "", "Only print events with an id greater than this")
+	cmd.Flags().StringVar(&flags.limitRaw, "limit", "", "Maximum events per batch (default 100, max 1000)")
+	cmd.Flags().BoolVar(&flags.follow, "follow", false, "Keep printing new events until interrupted")
+	cmd.Flags().StringVar(&flags.pollIntervalRaw, "poll-interval", "1s", "How often --follow checks for new events")
+
+	return cmd
+}
+
+func buildEventsTailRequest(cmd *cobra.Command, flags *eventsTailFlags) (service.EventTailRequest, time.Duration, error) {
+	req := service.EventTailRequest{Follow: flags.follow}
+
+	if cmd.Flags().Changed("since-id") {
+		sinceID, err := strconv.ParseInt(strings.TrimSpace(flags.sinceIDRaw), 10, 64)
+		if err != nil {
+			return service.EventTailRequest{}, 0, &eventsCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "since-id must be a non-negative integer",
+				Details: map[string]any{"field": "since-id", "value": flags.sinceIDRaw},
+			}
+		}
+		req.AfterID = &sinceID
+	}
+
+	if value := strings.TrimSpace(flags.limitRaw); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > domain.MaxChangeEventLimit {
+			return service.EventTailRequest{}, 0, &eventsCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "limit must be an integer between 1 and 1000",
+				Details: map[string]any{"field": "limit", "value": flags.limitRaw},
+			}
+		}
+		req.Limit = limit
+	}
+
+	pollInterval, err := time.ParseDuration(strings.TrimSpace(flags.pollIntervalRaw))
+	if err != nil || pollInterval <= 0 {
+		return service.EventTailRequest{}, 0, &eventsCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "poll-interval must be a positive duration (e.g. 500ms, 2s)",
+			Details: map[string]any{"field": "poll-interval", "value": flags.pollIntervalRaw},
+		}
+	}
+
+	return req, pollInterval, nil
+}
+
+// writeChangeEvent prints one event per line so followers can process the
+// stream incrementally: compact JSON in json mode, a short summary otherwise.
+func writeChangeEvent(w io.Writer, format string, event domain.ChangeEvent) error {
+	if strings.ToLower(strings.TrimSpace(format)) == output.FormatJSON {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal change event: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d  %s  %s  %s#%s  %s\n", event.ID, event.OccurredAtUTC, event.Event, event.EntityType, event.EntityID, event.Payload)
+	return err
+}
+
+func newEventService(opts *RootOptions, pollInterval time.Duration) (*service.EventService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &eventsCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewEventService(sqlitestore.NewAuditEventRepo(opts.db), service.WithEventPollInterval(pollInterval))
+	if err != nil {
+		return nil, fmt.Errorf("event service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printEventsError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *eventsCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromEventsError(err), messageFromEventsError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromEventsError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidChangeEventCursor), errors.Is(err, domain.ErrInvalidChangeEventLimit):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromEventsError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidChangeEventCursor):
+		return "since-id must be a non-negative integer"
+	case errors.Is(err, domain.ErrInvalidChangeEventLimit):
+		return "limit must be an integer between 1 and 1000"
+	default:
+		return "database operation failed"
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestEventsTailPrintsNDJSONChangeFeed(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	cardID := insertTestCard(t, db, "Visa", "", "1234", "visa", "credit", 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "12.00", "--currency", "USD", "--date", "2026-02-10",
+	}))
+	if _, err := db.ExecContext(context.Background(),
+		`INSERT INTO credit_liability_events (card_id, currency_code, event_type, amount_minor_signed) VALUES (?, 'USD', 'payment', -500);`,
+		cardID,
+	); err != nil {
+		t.Fatalf("insert card payment: %v", err)
+	}
+
+	events := executeEventsTail(t, context.Background(), db, []string{"tail"})
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event["event"].(string))
+	}
+	if strings.Join(names, ",") != "entry.created,card.payment" {
+		t.Fatalf("unexpected event names: %v", names)
+	}
+	if amount := mustMap(t, events[1]["payload"])["amount_minor"].(float64); amount != 500 {
+		t.Fatalf("expected card payment amount 500, got %v", amount)
+	}
+
+	firstID := int64(events[0]["id"].(float64))
+	resumed := executeEventsTail(t, context.Background(), db, []string{"tail", "--since-id", strconv.FormatInt(firstID, 10)})
+	if len(resumed) != 1 || resumed[0]["event"] != "card.payment" {
+		t.Fatalf("expected resume after first event, got %v", resumed)
+	}
+}
+
+func TestEventsTailFollowStreamsNewEventsUntilCancelled(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "1.00", "--currency", "USD", "--date", "2026-02-10",
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = db.ExecContext(context.Background(),
+			`INSERT INTO monthly_caps (month_key, amount_minor, currency_code) VALUES ('2026-02', 5000, 'USD');`,
+		)
+	}()
+
+	events := executeEventsTail(t, ctx, db, []string{"tail", "--follow", "--poll-interval", "20ms"})
+	if len(events) != 1 || events[0]["event"] != "cap.created" {
+		t.Fatalf("expected only the new cap event, got %v", events)
+	}
+}
+
+func executeEventsTail(t *testing.T, ctx context.Context, db *sql.DB, args []string) []map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewEventsCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("execute events cmd %v: %v", args, err)
+	}
+
+	events := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		event := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("unmarshal event line: %v raw=%s", err, line)
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/fx"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type fxCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *fxCLIError) Error() string {
+	if e == nil {
+		return "fx command error"
+	}
+	return e.Message
+}
+
+func NewFXCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fx",
+		Short: "Inspect the FX rate cache",
+	}
+
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect cached FX rate snapshots",
+	}
+	cacheCmd.AddCommand(newFXCacheStatusCmd(opts))
+
+	cmd.AddCommand(cacheCmd)
+	return cmd
+}
+
+func newFXCacheStatusCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List cached FX rate pairs with their dates and ages",
+		Long: `List every cached provider and currency pair with its snapshot count,
+oldest and newest rate dates, last fetch time and the age of its newest rate
+in days. Pairs older than fx_stale_after_days are flagged stale.
+
+fx_cache_ttl_hours and fx_stale_after_days are changed with settings set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printFXError(cmd, outputFormat(opts), &fxCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "fx cache status does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newFXService(opts)
+			if err != nil {
+				return printFXError(cmd, outputFormat(opts), err)
+			}
+
+			status, err := svc.CacheStatus(cmd.Context(), fxCachePolicy(opts), time.Now())
+			if err != nil {
+				return printFXError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"policy": status.Policy,
+				"pairs":  status.Pairs,
+				"count":  len(status.Pairs),
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, fxCacheStatusTables(status))
+		},
+	}
+}
+
+// newFXConverter builds the rate converter used by conversions, with the
+// cache policy from settings.
+func newFXConverter(opts *RootOptions) (*fx.Converter, error) {
+	return fx.NewConverter(
+		fx.NewFrankfurterClient(nil),
+		sqlitestore.NewFXRepo(opts.db),
+		fx.WithCachePolicy(fxCachePolicy(opts)),
+	)
+}
+
+// fxCachePolicy returns the policy loaded from settings, or the defaults when
+// none was loaded.
+func fxCachePolicy(opts *RootOptions) domain.FXCachePolicy {
+	if opts == nil || opts.fxCachePolicy.StaleAfterDays == 0 {
+		return domain.DefaultFXCachePolicy()
+	}
+	return opts.fxCachePolicy
+}
+
+func newFXService(opts *RootOptions) (*service.FXService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &fxCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewFXService(sqlitestore.NewFXRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("fx service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printFXError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *fxCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope("DB_ERROR", "database operation failed", map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+)
+
+func TestFXCacheStatusListsPairsWithAges(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	today := time.Now().UTC()
+	recent := today.AddDate(0, 0, -2).Format("2006-01-02")
+	old := today.AddDate(0, 0, -30).Format("2006-01-02")
+	for _, row := range [][]string{
+		{"EUR", "USD", old},
+		{"EUR", "USD", recent},
+		{"GBP", "USD", old},
+	} {
+		if _, err := db.Exec(`INSERT INTO fx_rate_snapshots (provider, base_currency, quote_currency, rate, rate_date, is_estimate)
+			VALUES ('frankfurter', ?, ?, '1.1', ?, 0)`, row[0], row[1], row[2]); err != nil {
+			t.Fatalf("insert fx snapshot: %v", err)
+		}
+	}
+
+	opts := &RootOptions{
+		Output:        output.FormatJSON,
+		Timezone:      "UTC",
+		db:            db,
+		fxCachePolicy: domain.FXCachePolicy{TTLHours: 12, StaleAfterDays: 10},
+	}
+	data := mustMap(t, executeFXCmdJSON(t, opts, []string{"cache", "status"})["data"])
+	policy := mustMap(t, data["policy"])
+	if policy["ttl_hours"] != float64(12) || policy["stale_after_days"] != float64(10) {
+		t.Fatalf("unexpected policy %v", policy)
+	}
+
+	pairs := mustAnySlice(t, data["pairs"])
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 cached pairs, got %v", pairs)
+	}
+	eur := mustMap(t, pairs[0])
+	if eur["base_currency"] != "EUR" || eur["snapshot_count"] != float64(2) || eur["oldest_rate_date"] != old ||
+		eur["newest_rate_date"] != recent || eur["age_days"] != float64(2) || eur["stale"] != false {
+		t.Fatalf("unexpected EUR/USD pair %v", eur)
+	}
+	gbp := mustMap(t, pairs[1])
+	if gbp["base_currency"] != "GBP" || gbp["age_days"] != float64(30) || gbp["stale"] != true {
+		t.Fatalf("expected stale GBP/USD pair, got %v", gbp)
+	}
+}
+
+func executeFXCmdJSON(t *testing.T, opts *RootOptions, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewFXCmd(opts)
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute fx cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal fx payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
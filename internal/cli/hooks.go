@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type settingsHooksAddFlags struct {
+	event         string
+	command       string
+	url           string
+	withinDaysRaw string
+}
+
+func newSettingsHooksCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Run commands or call URLs when warnings and events fire",
+		Long: `Hooks receive the command's JSON envelope when one of these events fires:
+
+  cap_exceeded       a command reported CAP_EXCEEDED or CATEGORY_CAP_EXCEEDED
+  card_due           card due show|list found a card due within --within-days
+  import_completed   data import or data mirror import succeeded
+
+Command hooks run through the shell with the envelope on stdin and the event
+name in BORING_BUDGET_HOOK_EVENT. URL hooks receive it as a JSON POST with the
+event name in the X-Boring-Budget-Event header. Hook failures are logged to
+stderr and never change the command's result.`,
+	}
+
+	cmd.AddCommand(
+		newSettingsHooksAddCmd(opts),
+		newSettingsHooksListCmd(opts),
+		newSettingsHooksRemoveCmd(opts),
+	)
+
+	return cmd
+}
+
+func newSettingsHooksAddCmd(opts *RootOptions) *cobra.Command {
+	flags := &settingsHooksAddFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Register a command or URL hook for an event",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings hooks add does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if cmd.Flags().Changed("command") == cmd.Flags().Changed("url") {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "pass exactly one of command or url",
+					Details: map[string]any{"fields": []string{"command", "url"}},
+				})
+			}
+
+			input := domain.HookAddInput{Event: flags.event, Kind: domain.HookKindCommand, Target: flags.command}
+			if cmd.Flags().Changed("url") {
+				input.Kind = domain.HookKindHTTP
+				input.Target = flags.url
+			}
+			if strings.TrimSpace(flags.withinDaysRaw) != "" {
+				days, err := strconv.ParseInt(strings.TrimSpace(flags.withinDaysRaw), 10, 64)
+				if err != nil {
+					return printSettingsError(cmd, outputFormat(opts), domain.ErrInvalidHookWithinDays)
+				}
+				input.WithinDays = &days
+			}
+
+			svc, err := newHookService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			hook, err := svc.Add(cmd.Context(), input)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"hook": hook}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.event, "event", "", "Event: cap_exceeded|card_due|import_completed")
+	cmd.Flags().StringVar(&flags.command, "command", "", "Shell command that receives the envelope on stdin")
+	cmd.Flags().StringVar(&flags.url, "url", "", "http(s) URL that receives the envelope as a JSON POST")
+	cmd.Flags().StringVar(&flags.withinDaysRaw, "within-days", "", "card_due only: fire when a card is due within this many days (default 3)")
+
+	return cmd
+}
+
+func newSettingsHooksListCmd(opts *RootOptions) *cobra.Command {
+	var event string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List registered hooks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings hooks list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newHookService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			hooks, err := svc.List(cmd.Context(), event)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"hooks": hooks, "count": len(hooks)}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&event, "event", "", "Only list hooks for this event")
+
+	return cmd
+}
+
+func newSettingsHooksRemoveCmd(opts *RootOptions) *cobra.Command {
+	var idRaw string
+
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a hook",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings hooks remove does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			id, err := strconv.ParseInt(strings.TrimSpace(idRaw), 10, 64)
+			if err != nil || id <= 0 {
+				return printSettingsError(cmd, outputFormat(opts), domain.ErrInvalidHookID)
+			}
+
+			svc, err := newHookService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			if err := svc.Remove(cmd.Context(), id); err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"hook_id": id, "removed": true}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&idRaw, "id", "", "Hook ID")
+
+	return cmd
+}
+
+func newHookService(opts *RootOptions) (*service.HookService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &settingsCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewHookService(sqlitestore.NewHookRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("hook service init: %w", err)
+	}
+	return svc, nil
+}
+
+type hookTrigger struct {
+	event  string
+	accept func(domain.Hook) bool
+}
+
+// dispatchHooks delivers the envelope the command printed to the hooks of
+// every event it triggered. It runs after the command, so failures are only
+// logged.
+func dispatchHooks(cmd *cobra.Command, opts *RootOptions) {
+	envelope, ok := output.LastEnvelope()
+	if !ok || opts == nil || opts.db == nil {
+		return
+	}
+
+	triggers := hookTriggersForEnvelope(cmd.CommandPath(), envelope)
+	if len(triggers) == 0 {
+		return
+	}
+
+	svc, err := newHookService(opts)
+	if err != nil {
+		slog.Warn("hooks unavailable", "error", err)
+		return
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		slog.Warn("hook payload encoding failed", "error", err)
+		return
+	}
+
+	for _, trigger := range triggers {
+		deliveries, err := svc.Dispatch(cmd.Context(), trigger.event, payload, trigger.accept)
+		if err != nil {
+			slog.Warn("hooks could not be loaded", "event", trigger.event, "error", err)
+			continue
+		}
+		for _, delivery := range deliveries {
+			if delivery.Error != "" {
+				slog.Warn("hook failed", "hook_id", delivery.HookID, "event", delivery.Event, "kind", delivery.Kind, "error", delivery.Error)
+				continue
+			}
+			slog.Debug("hook delivered", "hook_id", delivery.HookID, "event", delivery.Event, "kind", delivery.Kind)
+		}
+	}
+}
+
+func hookTriggersForEnvelope(commandPath string, envelope output.Envelope) []hookTrigger {
+	triggers := []hookTrigger{}
+
+	for _, warning := range envelope.Warnings {
+		if warning.Code == domain.WarningCodeCapExceeded || warning.Code == domain.WarningCodeCategoryCapExceeded {
+			triggers = append(triggers, hookTrigger{event: domain.HookEventCapExceeded})
+			break
+		}
+	}
+
+	if !envelope.Ok {
+		return triggers
+	}
+
+	path := strings.Join(strings.Fields(commandPath)[1:], " ")
+	switch path {
+	case "data import", "data mirror import":
+		triggers = append(triggers, hookTrigger{event: domain.HookEventImportCompleted})
+	case "card due show", "card due list":
+		if soonest, ok := soonestCardDueDays(envelope.Data); ok {
+			triggers = append(triggers, hookTrigger{
+				event: domain.HookEventCardDue,
+				accept: func(hook domain.Hook) bool {
+					return hook.WithinDays != nil && soonest <= *hook.WithinDays
+				},
+			})
+		}
+	}
+
+	return triggers
+}
+
+func soonestCardDueDays(data any) (int64, bool) {
+	payload, ok := data.(map[string]any)
+	if !ok {
+		return 0, false
+	}
+
+	dues := []domain.CardDueInfo{}
+	if due, ok := payload["due"].(domain.CardDueInfo); ok {
+		dues = append(dues, due)
+	}
+	if list, ok := payload["dues"].([]domain.CardDueInfo); ok {
+		dues = append(dues, list...)
+	}
+
+	soonest, found := int64(0), false
+	for _, due := range dues {
+		days, err := due.DaysUntilDue()
+		if err != nil {
+			continue
+		}
+		if !found || days < soonest {
+			soonest, found = days, true
+		}
+	}
+	return soonest, found
+}
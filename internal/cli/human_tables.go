@@ -0,0 +1,1785 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+)
+
+func entryListTables(entries []domain.Entry) []output.Table {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		payment := entry.PaymentMethod
+		if entry.PaymentCardNickname != "" {
+			payment += " (" + entry.PaymentCardNickname + ")"
+		}
+		entryType := entry.Type
+		if entry.RefundOfEntryID != nil {
+			entryType = "refund of " + strconv.FormatInt(*entry.RefundOfEntryID, 10)
+		}
+		amount := formatHumanMoney(entry.AmountMinor, entry.CurrencyCode)
+		if entry.Original != nil {
+			amount += " (" + formatHumanMoney(entry.Original.AmountMinor, entry.Original.CurrencyCode) + ")"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(entry.ID, 10),
+			output.FormatHumanDate(entry.TransactionDateUTC),
+			entryType,
+			amount,
+			formatOptionalID(entry.CategoryID),
+			entry.Payee,
+			entry.RecordedBy,
+			entry.IncomeSource,
+			entry.Location,
+			payment,
+			entry.Note,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Entries",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Date"},
+			{Header: "Type"},
+			{Header: "Amount", AlignRight: true},
+			{Header: "Category"},
+			{Header: "Payee"},
+			{Header: "By"},
+			{Header: "Source"},
+			{Header: "Location"},
+			{Header: "Payment"},
+			{Header: "Note"},
+		},
+		Rows: rows,
+	}}
+}
+
+func payeeListTables(payees []domain.PayeeSummary) []output.Table {
+	rows := make([][]string, 0, len(payees))
+	for _, payee := range payees {
+		rows = append(rows, []string{
+			payee.Payee,
+			payee.CurrencyCode,
+			strconv.Itoa(payee.EntryCount),
+			formatHumanMoney(payee.SpendMinor, payee.CurrencyCode),
+			formatHumanMoney(payee.IncomeMinor, payee.CurrencyCode),
+			output.FormatHumanDate(payee.LastEntryDateUTC),
+		})
+	}
+
+	return []output.Table{{
+		Title: "Payees",
+		Columns: []output.TableColumn{
+			{Header: "Payee"},
+			{Header: "Currency"},
+			{Header: "Entries", AlignRight: true},
+			{Header: "Spent", AlignRight: true},
+			{Header: "Received", AlignRight: true},
+			{Header: "Last entry"},
+		},
+		Rows: rows,
+	}}
+}
+
+func capStatusTables(statuses []domain.CapStatus) []output.Table {
+	rows := make([][]string, 0, len(statuses))
+	for _, status := range statuses {
+		category := "all"
+		if status.CategoryID != nil {
+			category = "#" + strconv.FormatInt(*status.CategoryID, 10)
+		}
+		atRisk := "no"
+		if status.IsAtRisk {
+			atRisk = "yes"
+		}
+		rows = append(rows, []string{
+			category,
+			formatHumanMoney(status.CapAmountMinor, status.CurrencyCode),
+			formatHumanMoney(status.SpendToDateMinor, status.CurrencyCode),
+			formatHumanMoney(status.RemainingMinor, status.CurrencyCode),
+			formatHumanMoney(status.AverageDailyBurnMinor, status.CurrencyCode),
+			formatHumanMoney(status.ProjectedTotalMinor, status.CurrencyCode),
+			strconv.Itoa(status.DaysElapsed) + "/" + strconv.Itoa(status.DaysInMonth),
+			atRisk,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Cap status",
+		Columns: []output.TableColumn{
+			{Header: "Category"},
+			{Header: "Cap", AlignRight: true},
+			{Header: "Spent", AlignRight: true},
+			{Header: "Remaining", AlignRight: true},
+			{Header: "Daily burn", AlignRight: true},
+			{Header: "Projected", AlignRight: true},
+			{Header: "Days", AlignRight: true},
+			{Header: "At risk"},
+		},
+		Rows: rows,
+	}}
+}
+
+func statsTables(stats domain.EntryStats) []output.Table {
+	summaryRows := make([][]string, 0, len(stats.Currencies))
+	largestRows := [][]string{}
+	for _, currency := range stats.Currencies {
+		summaryRows = append(summaryRows, []string{
+			currency.CurrencyCode,
+			formatHumanMoney(currency.SpendMinor, currency.CurrencyCode),
+			formatHumanMoney(currency.AverageMonthlySpendMinor, currency.CurrencyCode),
+			strconv.Itoa(currency.ExpenseCount),
+			formatHumanMoney(currency.MedianExpenseMinor, currency.CurrencyCode),
+			currency.BusiestWeekday,
+		})
+		for _, entry := range currency.LargestExpenses {
+			largestRows = append(largestRows, []string{
+				strconv.FormatInt(entry.ID, 10),
+				output.FormatHumanDate(entry.TransactionDateUTC),
+				formatHumanMoney(entry.AmountMinor, entry.CurrencyCode),
+				entry.Payee,
+				entry.Note,
+			})
+		}
+	}
+
+	categoryRows := make([][]string, 0, len(stats.Categories))
+	for _, category := range stats.Categories {
+		label := "uncategorized"
+		if category.CategoryID != nil {
+			label = "#" + strconv.FormatInt(*category.CategoryID, 10)
+		}
+		categoryRows = append(categoryRows, []string{label, strconv.Itoa(category.EntryCount)})
+	}
+
+	return []output.Table{
+		{
+			Title: "Spending",
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Spent", AlignRight: true},
+				{Header: "Monthly avg", AlignRight: true},
+				{Header: "Expenses", AlignRight: true},
+				{Header: "Median", AlignRight: true},
+				{Header: "Busiest day"},
+			},
+			Rows: summaryRows,
+		},
+		{
+			Title: "Largest expenses",
+			Columns: []output.TableColumn{
+				{Header: "ID", AlignRight: true},
+				{Header: "Date"},
+				{Header: "Amount", AlignRight: true},
+				{Header: "Payee"},
+				{Header: "Note"},
+			},
+			Rows: largestRows,
+		},
+		{
+			Title: "Entries per category",
+			Columns: []output.TableColumn{
+				{Header: "Category"},
+				{Header: "Entries", AlignRight: true},
+			},
+			Rows: categoryRows,
+		},
+	}
+}
+
+func cardDebtTables(summaries []service.CardDebtCardSummary) []output.Table {
+	rows := [][]string{}
+	for _, summary := range summaries {
+		for _, bucket := range summary.Buckets {
+			rows = append(rows, []string{
+				summary.Card.Nickname,
+				bucket.CurrencyCode,
+				formatHumanMoney(bucket.BalanceMinorSigned, bucket.CurrencyCode),
+				bucket.State,
+			})
+		}
+	}
+
+	return []output.Table{{
+		Title: "Card debt",
+		Columns: []output.TableColumn{
+			{Header: "Card"},
+			{Header: "Currency"},
+			{Header: "Balance", AlignRight: true},
+			{Header: "State"},
+		},
+		Rows: rows,
+	}}
+}
+
+func cardDebtHistoryTables(history service.CardDebtHistory) []output.Table {
+	eventRows := make([][]string, 0, len(history.Events))
+	for _, event := range history.Events {
+		eventRows = append(eventRows, []string{
+			output.FormatHumanDate(event.CreatedAtUTC),
+			event.EventType,
+			event.CurrencyCode,
+			formatHumanMoney(event.AmountMinorSigned, event.CurrencyCode),
+			formatHumanMoney(event.BalanceAfterMinorSigned, event.CurrencyCode),
+			event.Note,
+		})
+	}
+
+	periodRows := make([][]string, 0, len(history.Periods))
+	for _, period := range history.Periods {
+		periodRows = append(periodRows, []string{
+			period.PeriodKey,
+			period.CurrencyCode,
+			strconv.Itoa(period.EventCount),
+			formatHumanMoney(period.NetChangeMinorSigned, period.CurrencyCode),
+			formatHumanMoney(period.ClosingBalanceMinorSigned, period.CurrencyCode),
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: "Debt history: " + history.Card.Nickname,
+			Columns: []output.TableColumn{
+				{Header: "Date"},
+				{Header: "Event"},
+				{Header: "Currency"},
+				{Header: "Amount", AlignRight: true},
+				{Header: "Balance", AlignRight: true},
+				{Header: "Note"},
+			},
+			Rows: eventRows,
+		},
+		{
+			Title: "Net change by " + history.Grouping,
+			Columns: []output.TableColumn{
+				{Header: "Period"},
+				{Header: "Currency"},
+				{Header: "Events", AlignRight: true},
+				{Header: "Net change", AlignRight: true},
+				{Header: "Closing balance", AlignRight: true},
+			},
+			Rows: periodRows,
+		},
+	}
+}
+
+func netWorthTables(netWorth domain.NetWorth) []output.Table {
+	rows := make([][]string, 0, len(netWorth.ByCurrency))
+	for _, position := range netWorth.ByCurrency {
+		rows = append(rows, []string{
+			position.CurrencyCode,
+			formatHumanMoney(position.CashMinor, position.CurrencyCode),
+			formatHumanMoney(position.SavingsMinor, position.CurrencyCode),
+			formatHumanMoney(position.HoldingsMinor, position.CurrencyCode),
+			formatHumanMoney(position.LiabilitiesMinor, position.CurrencyCode),
+			formatHumanMoney(position.NetWorthMinor, position.CurrencyCode),
+		})
+	}
+
+	tables := []output.Table{{
+		Title: "Net worth on " + netWorth.AsOfDate,
+		Columns: []output.TableColumn{
+			{Header: "Currency"},
+			{Header: "Cash", AlignRight: true},
+			{Header: "Savings", AlignRight: true},
+			{Header: "Holdings", AlignRight: true},
+			{Header: "Liabilities", AlignRight: true},
+			{Header: "Net worth", AlignRight: true},
+		},
+		Rows: rows,
+	}}
+
+	if len(netWorth.Accounts) > 0 {
+		accountRows := make([][]string, 0, len(netWorth.Accounts))
+		for _, account := range netWorth.Accounts {
+			accountRows = append(accountRows, []string{
+				account.Alias,
+				account.CurrencyCode,
+				formatHumanMoney(account.BalanceMinor, account.CurrencyCode),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Bank accounts",
+			Columns: []output.TableColumn{
+				{Header: "Account"},
+				{Header: "Currency"},
+				{Header: "Balance", AlignRight: true},
+			},
+			Rows: accountRows,
+		})
+	}
+	if len(netWorth.Assets) > 0 {
+		tables = append(tables, assetListTables(netWorth.Assets)...)
+	}
+	return tables
+}
+
+func assetListTables(assets []domain.Asset) []output.Table {
+	rows := make([][]string, 0, len(assets))
+	for _, asset := range assets {
+		rows = append(rows, []string{
+			strconv.FormatInt(asset.ID, 10),
+			asset.Name,
+			asset.Kind,
+			formatHumanMoney(asset.BalanceMinor, asset.CurrencyCode),
+			output.FormatHumanDate(asset.UpdatedAtUTC),
+			asset.Note,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Assets and liabilities",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Name"},
+			{Header: "Kind"},
+			{Header: "Balance", AlignRight: true},
+			{Header: "Updated"},
+			{Header: "Note"},
+		},
+		Rows: rows,
+	}}
+}
+
+func assetTotalTables(totals []domain.AssetTotal) []output.Table {
+	rows := make([][]string, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, []string{
+			total.CurrencyCode,
+			formatHumanMoney(total.AssetsMinor, total.CurrencyCode),
+			formatHumanMoney(total.LiabilitiesMinor, total.CurrencyCode),
+			formatHumanMoney(total.NetMinor, total.CurrencyCode),
+		})
+	}
+
+	return []output.Table{{
+		Title: "Totals",
+		Columns: []output.TableColumn{
+			{Header: "Currency"},
+			{Header: "Assets", AlignRight: true},
+			{Header: "Liabilities", AlignRight: true},
+			{Header: "Net", AlignRight: true},
+		},
+		Rows: rows,
+	}}
+}
+
+func netWorthHistoryTables(history domain.NetWorthHistory) []output.Table {
+	rows := make([][]string, 0, len(history.Periods))
+	for _, period := range history.Periods {
+		rows = append(rows, []string{
+			period.PeriodKey,
+			period.CurrencyCode,
+			formatHumanMoney(period.AssetsMinor, period.CurrencyCode),
+			formatHumanMoney(period.LiabilitiesMinor, period.CurrencyCode),
+			formatHumanMoney(period.NetWorthMinor, period.CurrencyCode),
+			formatHumanMoney(period.ChangeMinor, period.CurrencyCode),
+		})
+	}
+
+	return []output.Table{{
+		Title: "Net worth by " + history.Grouping,
+		Columns: []output.TableColumn{
+			{Header: "Period"},
+			{Header: "Currency"},
+			{Header: "Assets", AlignRight: true},
+			{Header: "Liabilities", AlignRight: true},
+			{Header: "Net worth", AlignRight: true},
+			{Header: "Change", AlignRight: true},
+		},
+		Rows: rows,
+	}}
+}
+
+func balanceTables(payload balanceData) []output.Table {
+	tables := []output.Table{}
+	if payload.Lifetime != nil {
+		tables = append(tables, balanceNetTable("Lifetime balance", payload.Lifetime.ByCurrency, payload.LifetimeConverted))
+	}
+	if payload.Range != nil {
+		title := "Range balance"
+		if payload.Range.FromUTC != "" || payload.Range.ToUTC != "" {
+			title += " (" + output.FormatHumanDate(payload.Range.FromUTC) + " to " + output.FormatHumanDate(payload.Range.ToUTC) + ")"
+		}
+		tables = append(tables, balanceNetTable(title, payload.Range.ByCurrency, payload.RangeConverted))
+	}
+	return tables
+}
+
+func balanceNetTable(title string, byCurrency []balanceCurrencyNet, converted *balanceConvertedView) output.Table {
+	rows := make([][]string, 0, len(byCurrency)+1)
+	for _, row := range byCurrency {
+		rows = append(rows, []string{row.CurrencyCode, formatHumanMoney(row.NetMinor, row.CurrencyCode)})
+	}
+	if converted != nil {
+		rows = append(rows, []string{"= " + converted.TargetCurrency, formatHumanMoney(converted.NetMinor, converted.TargetCurrency)})
+	}
+
+	return output.Table{
+		Title: title,
+		Columns: []output.TableColumn{
+			{Header: "Currency"},
+			{Header: "Net", AlignRight: true},
+		},
+		Rows: rows,
+	}
+}
+
+// reportTables renders every section of the report JSON data: links are the
+// linked_accounts the report command adds next to the report.
+func reportTables(report domain.Report, links []domain.BalanceAccountLink) []output.Table {
+	earnings := currencyTotalsByCode(report.Earnings.ByCurrency)
+	spending := currencyTotalsByCode(report.Spending.ByCurrency)
+	net := currencyTotalsByCode(report.Net.ByCurrency)
+
+	codes := make([]string, 0, len(net))
+	for code := range net {
+		codes = append(codes, code)
+	}
+	for code := range earnings {
+		if _, ok := net[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+	for code := range spending {
+		if _, ok := net[code]; !ok {
+			if _, seen := earnings[code]; !seen {
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+
+	summaryRows := make([][]string, 0, len(codes))
+	for _, code := range codes {
+		summaryRows = append(summaryRows, []string{
+			code,
+			formatHumanMoney(earnings[code], code),
+			formatHumanMoney(spending[code], code),
+			formatHumanMoney(net[code], code),
+		})
+	}
+
+	periodTitle := "Report " + report.Period.Scope
+	if report.Period.MonthKey != "" {
+		periodTitle += " " + report.Period.MonthKey
+	}
+	periodTitle += " (" + output.FormatHumanDate(report.Period.FromUTC) + " to " + output.FormatHumanDate(report.Period.ToUTC) + ", grouping: " + report.Grouping + ")"
+
+	tables := []output.Table{
+		{
+			Title: periodTitle,
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Earnings", AlignRight: true},
+				{Header: "Spending", AlignRight: true},
+				{Header: "Net", AlignRight: true},
+			},
+			Rows: summaryRows,
+		},
+		reportCategoryTable("Spending by category", report.Spending.Categories),
+		reportCategoryTable("Earnings by category", report.Earnings.Categories),
+		reportGroupTable("By "+report.Grouping, report.Earnings.Groups, "Earnings", report.Spending.Groups, "Spending"),
+		reportBalanceTable(report),
+	}
+
+	if report.Converted != nil {
+		converted := report.Converted
+		rates := "-"
+		switch {
+		case converted.UsedEstimateRate && converted.UsedStaleRate:
+			rates = "estimate, stale"
+		case converted.UsedEstimateRate:
+			rates = "estimate"
+		case converted.UsedStaleRate:
+			rates = "stale"
+		}
+		tables = append(tables, output.Table{
+			Title: "Converted to " + converted.TargetCurrency,
+			Columns: []output.TableColumn{
+				{Header: "Earnings", AlignRight: true},
+				{Header: "Spending", AlignRight: true},
+				{Header: "Net", AlignRight: true},
+				{Header: "Rates"},
+			},
+			Rows: [][]string{{
+				formatHumanMoney(converted.EarningsMinor, converted.TargetCurrency),
+				formatHumanMoney(converted.SpendingMinor, converted.TargetCurrency),
+				formatHumanMoney(converted.NetMinor, converted.TargetCurrency),
+				rates,
+			}},
+		})
+	}
+
+	if report.Revaluation != nil {
+		revaluationRows := make([][]string, 0, len(report.Revaluation.Currencies))
+		for _, currency := range report.Revaluation.Currencies {
+			revaluationRows = append(revaluationRows, []string{
+				currency.CurrencyCode,
+				currency.AsOfIndexDate,
+				currency.AsOfIndexValue,
+				strconv.Itoa(currency.RevaluedEntries),
+				strconv.Itoa(currency.NominalEntryCount),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Revalued as of " + report.Revaluation.AsOfDate,
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Index date"},
+				{Header: "Index value", AlignRight: true},
+				{Header: "Revalued", AlignRight: true},
+				{Header: "Nominal", AlignRight: true},
+			},
+			Rows: revaluationRows,
+		})
+	}
+
+	if len(report.CapStatus) > 0 {
+		capRows := make([][]string, 0, len(report.CapStatus))
+		for _, status := range report.CapStatus {
+			exceeded := "no"
+			if status.IsExceeded {
+				exceeded = "yes"
+			}
+			category := "all"
+			if status.CategoryID != nil {
+				category = status.CategoryName
+				if category == "" {
+					category = "#" + strconv.FormatInt(*status.CategoryID, 10)
+				}
+			}
+			capRows = append(capRows, []string{
+				status.MonthKey,
+				category,
+				formatHumanMoney(status.CapAmountMinor, status.CurrencyCode),
+				formatHumanMoney(status.SpendTotalMinor, status.CurrencyCode),
+				formatHumanMoney(status.OverspendMinor, status.CurrencyCode),
+				exceeded,
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Caps",
+			Columns: []output.TableColumn{
+				{Header: "Month"},
+				{Header: "Category"},
+				{Header: "Cap", AlignRight: true},
+				{Header: "Spent", AlignRight: true},
+				{Header: "Overspend", AlignRight: true},
+				{Header: "Exceeded"},
+			},
+			Rows: capRows,
+		})
+	}
+
+	if len(report.CapChanges) > 0 {
+		changeRows := make([][]string, 0, len(report.CapChanges))
+		for _, change := range report.CapChanges {
+			category := "all"
+			if change.CategoryID != nil {
+				category = "#" + strconv.FormatInt(*change.CategoryID, 10)
+			}
+			oldAmount := "-"
+			if change.OldAmountMinor != nil {
+				oldAmount = formatHumanMoney(*change.OldAmountMinor, change.CurrencyCode)
+			}
+			changeRows = append(changeRows, []string{
+				change.MonthKey,
+				category,
+				oldAmount,
+				formatHumanMoney(change.NewAmountMinor, change.CurrencyCode),
+				output.FormatHumanDate(change.ChangedAtUTC),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Cap changes",
+			Columns: []output.TableColumn{
+				{Header: "Month"},
+				{Header: "Category"},
+				{Header: "Old cap", AlignRight: true},
+				{Header: "New cap", AlignRight: true},
+				{Header: "Changed"},
+			},
+			Rows: changeRows,
+		})
+	}
+
+	if reportHasAttribution(report.ByPerson) {
+		personRows := make([][]string, 0, len(report.ByPerson))
+		for _, person := range report.ByPerson {
+			name := person.Person
+			if name == "" {
+				name = "(unattributed)"
+			}
+			personRows = append(personRows, []string{
+				name,
+				person.CurrencyCode,
+				formatHumanMoney(person.EarningsMinor, person.CurrencyCode),
+				formatHumanMoney(person.SpendingMinor, person.CurrencyCode),
+				formatHumanMoney(person.NetMinor, person.CurrencyCode),
+				strconv.FormatInt(person.EntryCount, 10),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "By person",
+			Columns: []output.TableColumn{
+				{Header: "Person"},
+				{Header: "Currency"},
+				{Header: "Earnings", AlignRight: true},
+				{Header: "Spending", AlignRight: true},
+				{Header: "Net", AlignRight: true},
+				{Header: "Entries", AlignRight: true},
+			},
+			Rows: personRows,
+		})
+	}
+
+	if reportHasIncomeSources(report.BySource) {
+		sourceRows := make([][]string, 0, len(report.BySource))
+		for _, source := range report.BySource {
+			name := source.Source
+			if name == "" {
+				name = "(no source)"
+			}
+			sourceRows = append(sourceRows, []string{
+				name,
+				source.CurrencyCode,
+				formatHumanMoney(source.TotalMinor, source.CurrencyCode),
+				strconv.FormatInt(source.EntryCount, 10),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Earnings by source",
+			Columns: []output.TableColumn{
+				{Header: "Source"},
+				{Header: "Currency"},
+				{Header: "Earnings", AlignRight: true},
+				{Header: "Entries", AlignRight: true},
+			},
+			Rows: sourceRows,
+		})
+	}
+
+	if reportHasLocations(report.ByLocation) {
+		locationRows := make([][]string, 0, len(report.ByLocation))
+		for _, location := range report.ByLocation {
+			name := location.Location
+			if name == "" {
+				name = "(no location)"
+			}
+			locationRows = append(locationRows, []string{
+				name,
+				location.CurrencyCode,
+				formatHumanMoney(location.TotalMinor, location.CurrencyCode),
+				strconv.FormatInt(location.EntryCount, 10),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Spending by location",
+			Columns: []output.TableColumn{
+				{Header: "Location"},
+				{Header: "Currency"},
+				{Header: "Spending", AlignRight: true},
+				{Header: "Entries", AlignRight: true},
+			},
+			Rows: locationRows,
+		})
+	}
+
+	if report.PaymentMethods != nil {
+		tables = append(tables, reportPaymentMethodTables(*report.PaymentMethods, report.Grouping)...)
+	}
+
+	if report.Assets != nil {
+		tables = append(tables, assetListTables(report.Assets.Items)...)
+		tables = append(tables, assetTotalTables(report.Assets.Totals)...)
+	}
+
+	if len(links) > 0 {
+		linkRows := make([][]string, 0, len(links))
+		for _, link := range links {
+			account := "-"
+			if link.BankAccount != nil {
+				account = link.BankAccount.Alias
+				if link.BankAccount.Last4 != "" {
+					account += " (*" + link.BankAccount.Last4 + ")"
+				}
+			}
+			linkRows = append(linkRows, []string{link.Target, account})
+		}
+		tables = append(tables, output.Table{
+			Title: "Linked accounts",
+			Columns: []output.TableColumn{
+				{Header: "Balance"},
+				{Header: "Bank account"},
+			},
+			Rows: linkRows,
+		})
+	}
+
+	return tables
+}
+
+func reportCategoryTable(title string, categories []domain.CategoryTotal) output.Table {
+	rows := make([][]string, 0, len(categories))
+	for _, category := range categories {
+		rows = append(rows, []string{
+			category.CategoryLabel,
+			category.CurrencyCode,
+			formatHumanMoney(category.TotalMinor, category.CurrencyCode),
+		})
+	}
+	return output.Table{
+		Title: title,
+		Columns: []output.TableColumn{
+			{Header: "Category"},
+			{Header: "Currency"},
+			{Header: "Total", AlignRight: true},
+		},
+		Rows: rows,
+	}
+}
+
+// reportGroupTable lines up two sets of period totals by period and currency.
+func reportGroupTable(title string, left []domain.GroupTotal, leftHeader string, right []domain.GroupTotal, rightHeader string) output.Table {
+	type groupKey struct {
+		period   string
+		currency string
+	}
+	totals := map[groupKey][2]int64{}
+	keys := []groupKey{}
+	add := func(groups []domain.GroupTotal, column int) {
+		for _, group := range groups {
+			key := groupKey{period: group.PeriodKey, currency: group.CurrencyCode}
+			value, ok := totals[key]
+			if !ok {
+				keys = append(keys, key)
+			}
+			value[column] += group.TotalMinor
+			totals[key] = value
+		}
+	}
+	add(left, 0)
+	add(right, 1)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].period != keys[j].period {
+			return keys[i].period < keys[j].period
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{
+			key.period,
+			key.currency,
+			formatHumanMoney(totals[key][0], key.currency),
+			formatHumanMoney(totals[key][1], key.currency),
+		})
+	}
+	return output.Table{
+		Title: title,
+		Columns: []output.TableColumn{
+			{Header: "Period"},
+			{Header: "Currency"},
+			{Header: leftHeader, AlignRight: true},
+			{Header: rightHeader, AlignRight: true},
+		},
+		Rows: rows,
+	}
+}
+
+// reportBalanceTable lists the period, monthly (when present) and general
+// balances per currency.
+func reportBalanceTable(report domain.Report) output.Table {
+	period := currencyTotalsByCode(report.PeriodBalance.ByCurrency)
+	general := currencyTotalsByCode(report.GeneralBalance.ByCurrency)
+	monthly := map[string]int64{}
+	if report.MonthlyBalance != nil {
+		monthly = currencyTotalsByCode(report.MonthlyBalance.ByCurrency)
+	}
+
+	seen := map[string]bool{}
+	codes := []string{}
+	for _, values := range []map[string]int64{period, monthly, general} {
+		for code := range values {
+			if !seen[code] {
+				seen[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+
+	columns := []output.TableColumn{
+		{Header: "Currency"},
+		{Header: "Period", AlignRight: true},
+	}
+	if report.MonthlyBalance != nil {
+		columns = append(columns, output.TableColumn{Header: "Monthly", AlignRight: true})
+	}
+	columns = append(columns, output.TableColumn{Header: "General", AlignRight: true})
+
+	rows := make([][]string, 0, len(codes))
+	for _, code := range codes {
+		row := []string{code, formatHumanMoney(period[code], code)}
+		if report.MonthlyBalance != nil {
+			row = append(row, formatHumanMoney(monthly[code], code))
+		}
+		rows = append(rows, append(row, formatHumanMoney(general[code], code)))
+	}
+	return output.Table{Title: "Balances", Columns: columns, Rows: rows}
+}
+
+func reportPaymentMethodTables(methods domain.ReportPaymentMethods, grouping string) []output.Table {
+	cash := currencyTotalsByCode(methods.Totals.Cash)
+	debit := currencyTotalsByCode(methods.Totals.Debit)
+	credit := currencyTotalsByCode(methods.Totals.Credit)
+	seen := map[string]bool{}
+	codes := []string{}
+	for _, values := range []map[string]int64{cash, debit, credit} {
+		for code := range values {
+			if !seen[code] {
+				seen[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	totalRows := make([][]string, 0, len(codes))
+	for _, code := range codes {
+		totalRows = append(totalRows, []string{
+			code,
+			formatHumanMoney(cash[code], code),
+			formatHumanMoney(debit[code], code),
+			formatHumanMoney(credit[code], code),
+		})
+	}
+
+	instrumentRows := make([][]string, 0, len(methods.ByInstrument))
+	for _, instrument := range methods.ByInstrument {
+		instrumentRows = append(instrumentRows, []string{
+			instrument.InstrumentLabel,
+			instrument.CurrencyCode,
+			formatHumanMoney(instrument.TotalMinor, instrument.CurrencyCode),
+		})
+	}
+
+	brandRows := make([][]string, 0, len(methods.ByBrand))
+	for _, brand := range methods.ByBrand {
+		brandRows = append(brandRows, []string{
+			brand.Brand,
+			brand.CurrencyCode,
+			formatHumanMoney(brand.TotalMinor, brand.CurrencyCode),
+		})
+	}
+
+	cashUsageRows := make([][]string, 0, len(methods.CashUsage))
+	for _, usage := range methods.CashUsage {
+		cashUsageRows = append(cashUsageRows, []string{
+			usage.CurrencyCode,
+			formatHumanMoney(usage.CashTotalMinor, usage.CurrencyCode),
+			formatHumanMoney(usage.SpendingTotalMinor, usage.CurrencyCode),
+			fmt.Sprintf("%d.%02d%%", usage.ShareBPS/100, usage.ShareBPS%100),
+		})
+	}
+
+	liabilityRows := make([][]string, 0, len(methods.CreditLiability))
+	for _, liability := range methods.CreditLiability {
+		liabilityRows = append(liabilityRows, []string{
+			liability.CardNickname,
+			liability.CurrencyCode,
+			formatHumanMoney(liability.BalanceMinorSigned, liability.CurrencyCode),
+			liability.State,
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: "Spending by payment method",
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Cash", AlignRight: true},
+				{Header: "Debit", AlignRight: true},
+				{Header: "Credit", AlignRight: true},
+			},
+			Rows: totalRows,
+		},
+		{
+			Title: "Spending by instrument",
+			Columns: []output.TableColumn{
+				{Header: "Instrument"},
+				{Header: "Currency"},
+				{Header: "Total", AlignRight: true},
+			},
+			Rows: instrumentRows,
+		},
+		{
+			Title: "Card spending by brand",
+			Columns: []output.TableColumn{
+				{Header: "Brand"},
+				{Header: "Currency"},
+				{Header: "Total", AlignRight: true},
+			},
+			Rows: brandRows,
+		},
+		reportGroupTable("Card spending by "+grouping, methods.CreditGroups, "Credit", methods.DebitGroups, "Debit"),
+		{
+			Title: "Cash usage",
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Cash", AlignRight: true},
+				{Header: "Spending", AlignRight: true},
+				{Header: "Share", AlignRight: true},
+			},
+			Rows: cashUsageRows,
+		},
+		{
+			Title: "Credit card debt",
+			Columns: []output.TableColumn{
+				{Header: "Card"},
+				{Header: "Currency"},
+				{Header: "Balance", AlignRight: true},
+				{Header: "State"},
+			},
+			Rows: liabilityRows,
+		},
+	}
+}
+
+// reportHasAttribution reports whether any entry carried recorded_by; ledgers
+// kept by one person skip the per-person table.
+func reportHasAttribution(totals []domain.ReportPersonTotal) bool {
+	for _, total := range totals {
+		if total.Person != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// reportHasIncomeSources reports whether any income carried income_source.
+func reportHasIncomeSources(totals []domain.ReportSourceTotal) bool {
+	for _, total := range totals {
+		if total.Source != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// reportHasLocations reports whether any expense carried a location.
+func reportHasLocations(totals []domain.ReportLocationTotal) bool {
+	for _, total := range totals {
+		if total.Location != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func currencyTotalsByCode(totals []domain.CurrencyTotal) map[string]int64 {
+	out := make(map[string]int64, len(totals))
+	for _, total := range totals {
+		out[total.CurrencyCode] += total.TotalMinor
+	}
+	return out
+}
+
+func formatHumanMoney(amountMinor int64, currencyCode string) string {
+	major, err := domain.FormatMinorToMajorString(amountMinor, currencyCode)
+	if err != nil {
+		return strconv.FormatInt(amountMinor, 10) + " " + currencyCode
+	}
+	return output.FormatMoney(major, currencyCode)
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+func migratePlanTables(plan domain.MigrationPlan) []output.Table {
+	backup := "not needed"
+	if plan.BackupRecommended {
+		backup = "recommended: " + strings.Join(plan.BackupReasons, "; ")
+	}
+
+	rows := [][]string{}
+	for _, migration := range plan.Pending {
+		version := strconv.FormatInt(migration.Version, 10)
+		if len(migration.Tables) == 0 {
+			rows = append(rows, []string{version, migration.Name, "-", "-", "0", "0"})
+			continue
+		}
+		for _, table := range migration.Tables {
+			rows = append(rows, []string{
+				version,
+				migration.Name,
+				table.Table,
+				strings.Join(table.Operations, ","),
+				strconv.FormatInt(table.Rows, 10),
+				strconv.FormatInt(table.RowRewrites, 10),
+			})
+		}
+	}
+
+	return []output.Table{
+		{
+			Title: "Migration plan",
+			Columns: []output.TableColumn{
+				{Header: "Current", AlignRight: true},
+				{Header: "Target", AlignRight: true},
+				{Header: "Pending", AlignRight: true},
+				{Header: "Row rewrites", AlignRight: true},
+				{Header: "Est. duration", AlignRight: true},
+				{Header: "Backup"},
+			},
+			Rows: [][]string{{
+				strconv.FormatInt(plan.CurrentVersion, 10),
+				strconv.FormatInt(plan.TargetVersion, 10),
+				strconv.Itoa(len(plan.Pending)),
+				strconv.FormatInt(plan.EstimatedRowRewrites, 10),
+				strconv.FormatInt(plan.EstimatedDurationMS, 10) + "ms",
+				backup,
+			}},
+		},
+		{
+			Title: "Pending migrations",
+			Columns: []output.TableColumn{
+				{Header: "Version", AlignRight: true},
+				{Header: "Name"},
+				{Header: "Table"},
+				{Header: "Operations"},
+				{Header: "Rows", AlignRight: true},
+				{Header: "Rewrites", AlignRight: true},
+			},
+			Rows: rows,
+		},
+	}
+}
+
+func doctorTables(report domain.DoctorReport) []output.Table {
+	rows := make([][]string, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		rows = append(rows, []string{
+			check.Name,
+			check.Status,
+			strconv.FormatInt(check.Issues, 10),
+			strconv.FormatInt(check.Fixed, 10),
+			check.Message,
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: "Database health",
+			Columns: []output.TableColumn{
+				{Header: "Check"},
+				{Header: "Status"},
+				{Header: "Issues", AlignRight: true},
+				{Header: "Fixed", AlignRight: true},
+				{Header: "Note"},
+			},
+			Rows: rows,
+		},
+	}
+}
+
+func maintenanceTables(report domain.MaintenanceReport) []output.Table {
+	rows := make([][]string, 0, len(report.Tables))
+	for _, table := range report.Tables {
+		softDeleted := ""
+		if table.SoftDeleted != nil {
+			softDeleted = strconv.FormatInt(*table.SoftDeleted, 10)
+		}
+		rows = append(rows, []string{table.Table, strconv.FormatInt(table.Rows, 10), softDeleted})
+	}
+
+	return []output.Table{
+		{
+			Title: "Database size",
+			Columns: []output.TableColumn{
+				{Header: "When"},
+				{Header: "Bytes", AlignRight: true},
+				{Header: "Pages", AlignRight: true},
+				{Header: "Free pages", AlignRight: true},
+			},
+			Rows: [][]string{
+				{"before", strconv.FormatInt(report.SizeBefore.Bytes, 10), strconv.FormatInt(report.SizeBefore.PageCount, 10), strconv.FormatInt(report.SizeBefore.FreePages, 10)},
+				{"after", strconv.FormatInt(report.SizeAfter.Bytes, 10), strconv.FormatInt(report.SizeAfter.PageCount, 10), strconv.FormatInt(report.SizeAfter.FreePages, 10)},
+			},
+		},
+		{
+			Title: "Table rows",
+			Columns: []output.TableColumn{
+				{Header: "Table"},
+				{Header: "Rows", AlignRight: true},
+				{Header: "Soft-deleted", AlignRight: true},
+			},
+			Rows: rows,
+		},
+	}
+}
+
+func restoreDiffTables(diff domain.RestoreDiff) []output.Table {
+	months := make([][]string, 0, len(diff.EntryMonths))
+	for _, month := range diff.EntryMonths {
+		months = append(months, []string{month.Month, strconv.FormatInt(month.Current, 10), strconv.FormatInt(month.Backup, 10), strconv.FormatInt(month.Delta, 10)})
+	}
+	months = append(months, []string{"total", strconv.FormatInt(diff.EntriesCurrent, 10), strconv.FormatInt(diff.EntriesBackup, 10), strconv.FormatInt(diff.EntriesBackup-diff.EntriesCurrent, 10)})
+
+	cards := make([][]string, 0, len(diff.CardsAdded)+len(diff.CardsRemoved))
+	for _, nickname := range diff.CardsAdded {
+		cards = append(cards, []string{nickname, "added"})
+	}
+	for _, nickname := range diff.CardsRemoved {
+		cards = append(cards, []string{nickname, "removed"})
+	}
+
+	settings := make([][]string, 0, len(diff.Settings))
+	for _, setting := range diff.Settings {
+		settings = append(settings, []string{setting.Field, restoreDiffValue(setting.Current), restoreDiffValue(setting.Backup)})
+	}
+
+	return []output.Table{
+		{
+			Title: "Entries per month",
+			Columns: []output.TableColumn{
+				{Header: "Month"},
+				{Header: "Current", AlignRight: true},
+				{Header: "Backup", AlignRight: true},
+				{Header: "Delta", AlignRight: true},
+			},
+			Rows: months,
+		},
+		{
+			Title:   "Cards",
+			Columns: []output.TableColumn{{Header: "Nickname"}, {Header: "Change"}},
+			Rows:    cards,
+		},
+		{
+			Title:   "Settings",
+			Columns: []output.TableColumn{{Header: "Field"}, {Header: "Current"}, {Header: "Backup"}},
+			Rows:    settings,
+		},
+	}
+}
+
+func restoreDiffValue(value any) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(value)
+}
+
+func entryHistoryTables(history domain.EntryHistory) []output.Table {
+	rows := [][]string{}
+	for _, revision := range history.Revisions {
+		for _, change := range revision.Changes {
+			rows = append(rows, []string{
+				strconv.FormatInt(revision.Revision, 10),
+				revision.ReplacedAtUTC,
+				change.Field,
+				entryHistoryValue(change.From),
+				entryHistoryValue(change.To),
+			})
+		}
+	}
+	return []output.Table{{
+		Title: fmt.Sprintf("Entry %d history", history.Entry.ID),
+		Columns: []output.TableColumn{
+			{Header: "Revision", AlignRight: true},
+			{Header: "Replaced At"},
+			{Header: "Field"},
+			{Header: "From"},
+			{Header: "To"},
+		},
+		Rows: rows,
+	}}
+}
+
+func entryHistoryValue(value any) string {
+	switch typed := value.(type) {
+	case *int64:
+		if typed == nil {
+			return "-"
+		}
+		return strconv.FormatInt(*typed, 10)
+	case []int64:
+		if len(typed) == 0 {
+			return "-"
+		}
+		parts := make([]string, 0, len(typed))
+		for _, id := range typed {
+			parts = append(parts, strconv.FormatInt(id, 10))
+		}
+		return strings.Join(parts, ",")
+	case string:
+		if typed == "" {
+			return "-"
+		}
+		return typed
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func searchTables(result domain.SearchResult) []output.Table {
+	entries := make([][]string, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		entries = append(entries, []string{
+			strconv.FormatInt(entry.ID, 10),
+			output.FormatHumanDate(entry.TransactionDateUTC),
+			entry.Type,
+			formatHumanMoney(entry.AmountMinor, entry.CurrencyCode),
+			entry.Note,
+		})
+	}
+
+	names := func(matches []domain.SearchNameMatch) [][]string {
+		rows := make([][]string, 0, len(matches))
+		for _, match := range matches {
+			archived := ""
+			if match.Archived {
+				archived = "yes"
+			}
+			rows = append(rows, []string{strconv.FormatInt(match.ID, 10), match.Name, archived})
+		}
+		return rows
+	}
+
+	cards := make([][]string, 0, len(result.Cards))
+	for _, card := range result.Cards {
+		cards = append(cards, []string{strconv.FormatInt(card.ID, 10), card.Nickname, card.Description, card.Last4, card.CardType})
+	}
+
+	return []output.Table{
+		{
+			Title: "Entries",
+			Columns: []output.TableColumn{
+				{Header: "ID", AlignRight: true},
+				{Header: "Date"},
+				{Header: "Type"},
+				{Header: "Amount", AlignRight: true},
+				{Header: "Note"},
+			},
+			Rows: entries,
+		},
+		{
+			Title:   "Categories",
+			Columns: []output.TableColumn{{Header: "ID", AlignRight: true}, {Header: "Name"}, {Header: "Archived"}},
+			Rows:    names(result.Categories),
+		},
+		{
+			Title:   "Labels",
+			Columns: []output.TableColumn{{Header: "ID", AlignRight: true}, {Header: "Name"}, {Header: "Archived"}},
+			Rows:    names(result.Labels),
+		},
+		{
+			Title: "Cards",
+			Columns: []output.TableColumn{
+				{Header: "ID", AlignRight: true},
+				{Header: "Nickname"},
+				{Header: "Description"},
+				{Header: "Last4"},
+				{Header: "Type"},
+			},
+			Rows: cards,
+		},
+	}
+}
+
+func migrateStatusTables(status domain.MigrationStatus) []output.Table {
+	rows := make([][]string, 0, len(status.Migrations))
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.Applied {
+			state = "applied"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(migration.Version, 10),
+			migration.Name,
+			state,
+			migration.AppliedAtUTC,
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: fmt.Sprintf("Migrations (version %d of %d)", status.CurrentVersion, status.LatestVersion),
+			Columns: []output.TableColumn{
+				{Header: "Version", AlignRight: true},
+				{Header: "Name"},
+				{Header: "State"},
+				{Header: "Applied at (UTC)"},
+			},
+			Rows: rows,
+		},
+	}
+}
+
+func migrateRunTables(run domain.MigrationRun) []output.Table {
+	versions := make([]string, 0, len(run.Versions))
+	for _, version := range run.Versions {
+		versions = append(versions, strconv.FormatInt(version, 10))
+	}
+	ran := "none"
+	if len(versions) > 0 {
+		ran = strings.Join(versions, ", ")
+	}
+
+	return []output.Table{
+		{
+			Title: "Migration run",
+			Columns: []output.TableColumn{
+				{Header: "Direction"},
+				{Header: "From", AlignRight: true},
+				{Header: "To", AlignRight: true},
+				{Header: "Versions"},
+			},
+			Rows: [][]string{{
+				run.Direction,
+				strconv.FormatInt(run.FromVersion, 10),
+				strconv.FormatInt(run.ToVersion, 10),
+				ran,
+			}},
+		},
+	}
+}
+
+func settleBalanceTables(balances []domain.SettleBalance) []output.Table {
+	rows := make([][]string, 0, len(balances))
+	for _, balance := range balances {
+		rows = append(rows, []string{
+			balance.From,
+			balance.To,
+			formatHumanMoney(balance.AmountMinor, balance.CurrencyCode),
+		})
+	}
+
+	return []output.Table{{
+		Title: "Settle up",
+		Columns: []output.TableColumn{
+			{Header: "Owes"},
+			{Header: "To"},
+			{Header: "Amount", AlignRight: true},
+		},
+		Rows: rows,
+	}}
+}
+
+func verifyTables(result domain.StatementReconciliation) []output.Table {
+	rows := make([][]string, 0, len(result.Missing)+len(result.Extra)+len(result.AmountMismatched))
+	for _, line := range result.Missing {
+		rows = append(rows, []string{
+			"missing",
+			output.FormatHumanDate(line.TransactionDateUTC),
+			formatHumanMoney(line.AmountMinor, line.CurrencyCode),
+			"-",
+			"-",
+			line.Description,
+		})
+	}
+	for _, entry := range result.Extra {
+		rows = append(rows, []string{
+			"extra",
+			output.FormatHumanDate(entry.TransactionDateUTC),
+			"-",
+			formatHumanMoney(entry.AmountMinor, entry.CurrencyCode),
+			strconv.FormatInt(entry.EntryID, 10),
+			entry.Note,
+		})
+	}
+	for _, mismatch := range result.AmountMismatched {
+		rows = append(rows, []string{
+			"amount mismatch",
+			output.FormatHumanDate(mismatch.Statement.TransactionDateUTC),
+			formatHumanMoney(mismatch.Statement.AmountMinor, mismatch.CurrencyCode),
+			formatHumanMoney(mismatch.Entry.AmountMinor, mismatch.CurrencyCode),
+			strconv.FormatInt(mismatch.Entry.EntryID, 10),
+			mismatch.Statement.Description,
+		})
+	}
+
+	return []output.Table{{
+		Title: fmt.Sprintf("Statement check %s: %d matched", result.MonthKey, result.Summary.Matched),
+		Columns: []output.TableColumn{
+			{Header: "Issue"},
+			{Header: "Date"},
+			{Header: "Statement", AlignRight: true},
+			{Header: "Recorded", AlignRight: true},
+			{Header: "Entry", AlignRight: true},
+			{Header: "Description"},
+		},
+		Rows: rows,
+	}}
+}
+
+func loanListTables(loans []domain.LoanStatus) []output.Table {
+	rows := make([][]string, 0, len(loans))
+	for _, status := range loans {
+		loan := status.Loan
+		rows = append(rows, []string{
+			strconv.FormatInt(loan.ID, 10),
+			loan.Name,
+			formatHumanMoney(loan.PrincipalMinor, loan.CurrencyCode),
+			formatLoanRate(loan.AnnualRateBasisPoints),
+			strconv.FormatInt(status.InstallmentsCovered, 10) + "/" + strconv.FormatInt(loan.TermMonths, 10),
+			formatHumanMoney(status.InterestPaidMinor, loan.CurrencyCode),
+			formatHumanMoney(status.RemainingPrincipalMinor, loan.CurrencyCode),
+			status.NextDueMonth,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Loans",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Name"},
+			{Header: "Principal", AlignRight: true},
+			{Header: "Rate", AlignRight: true},
+			{Header: "Covered", AlignRight: true},
+			{Header: "Interest paid", AlignRight: true},
+			{Header: "Remaining", AlignRight: true},
+			{Header: "Next due"},
+		},
+		Rows: rows,
+	}}
+}
+
+func loanStatusTables(status domain.LoanStatus) []output.Table {
+	loan := status.Loan
+	currency := loan.CurrencyCode
+
+	scheduleRows := make([][]string, 0, len(status.Schedule))
+	for _, installment := range status.Schedule {
+		covered := ""
+		if installment.Covered {
+			covered = "yes"
+		}
+		scheduleRows = append(scheduleRows, []string{
+			strconv.FormatInt(installment.Number, 10),
+			installment.MonthKey,
+			formatHumanMoney(installment.PaymentMinor, currency),
+			formatHumanMoney(installment.InterestMinor, currency),
+			formatHumanMoney(installment.PrincipalMinor, currency),
+			formatHumanMoney(installment.RemainingPrincipalMinor, currency),
+			covered,
+		})
+	}
+
+	tables := append(loanListTables([]domain.LoanStatus{status}), output.Table{
+		Title: "Schedule (" + formatHumanMoney(status.MonthlyPaymentMinor, currency) + " a month)",
+		Columns: []output.TableColumn{
+			{Header: "#", AlignRight: true},
+			{Header: "Month"},
+			{Header: "Payment", AlignRight: true},
+			{Header: "Interest", AlignRight: true},
+			{Header: "Principal", AlignRight: true},
+			{Header: "Remaining", AlignRight: true},
+			{Header: "Covered"},
+		},
+		Rows: scheduleRows,
+	})
+
+	if len(status.Payments) > 0 {
+		paymentRows := make([][]string, 0, len(status.Payments))
+		for _, payment := range status.Payments {
+			paymentRows = append(paymentRows, []string{
+				strconv.FormatInt(payment.EntryID, 10),
+				output.FormatHumanDate(payment.TransactionDateUTC),
+				formatHumanMoney(payment.AmountMinor, currency),
+				formatHumanMoney(payment.InterestMinor, currency),
+				formatHumanMoney(payment.PrincipalMinor, currency),
+				formatHumanMoney(payment.RemainingPrincipalMinor, currency),
+			})
+		}
+		tables = append(tables, output.Table{
+			Title: "Payments",
+			Columns: []output.TableColumn{
+				{Header: "Entry", AlignRight: true},
+				{Header: "Date"},
+				{Header: "Amount", AlignRight: true},
+				{Header: "Interest", AlignRight: true},
+				{Header: "Principal", AlignRight: true},
+				{Header: "Remaining", AlignRight: true},
+			},
+			Rows: paymentRows,
+		})
+	}
+	return tables
+}
+
+func formatLoanRate(basisPoints int64) string {
+	return fmt.Sprintf("%d.%02d%%", basisPoints/100, basisPoints%100)
+}
+
+func envelopeTables(envelopes []domain.EnvelopeStatus) []output.Table {
+	rows := make([][]string, 0, len(envelopes))
+	for _, status := range envelopes {
+		envelope := status.Envelope
+		state := ""
+		if status.Overspent {
+			state = "overspent"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(envelope.ID, 10),
+			envelope.Name,
+			envelope.StartDate,
+			envelope.EndDate,
+			strconv.FormatInt(envelope.LabelID, 10),
+			formatHumanMoney(envelope.BudgetMinor, envelope.CurrencyCode),
+			formatHumanMoney(status.SpentMinor, envelope.CurrencyCode),
+			formatHumanMoney(status.RemainingMinor, envelope.CurrencyCode),
+			strconv.FormatInt(status.EntryCount, 10),
+			state,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Envelopes",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Name"},
+			{Header: "From"},
+			{Header: "To"},
+			{Header: "Label", AlignRight: true},
+			{Header: "Budget", AlignRight: true},
+			{Header: "Spent", AlignRight: true},
+			{Header: "Remaining", AlignRight: true},
+			{Header: "Entries", AlignRight: true},
+			{Header: "Status"},
+		},
+		Rows: rows,
+	}}
+}
+
+func subscriptionTables(detection domain.SubscriptionDetection) []output.Table {
+	rows := make([][]string, 0, len(detection.Candidates))
+	for _, candidate := range detection.Candidates {
+		status := "active"
+		if !candidate.Active {
+			status = "lapsed"
+		}
+		schedule := ""
+		switch {
+		case candidate.ScheduleID != nil:
+			schedule = "#" + strconv.FormatInt(*candidate.ScheduleID, 10)
+		case candidate.ProposedSchedule != nil:
+			schedule = fmt.Sprintf("propose day %d from %s", candidate.ProposedSchedule.DayOfMonth, candidate.ProposedSchedule.StartMonthKey)
+		}
+		rows = append(rows, []string{
+			candidate.Name,
+			candidate.Interval,
+			strconv.Itoa(candidate.Occurrences),
+			formatHumanMoney(candidate.AmountMinor, candidate.CurrencyCode),
+			formatHumanMoney(candidate.AnnualizedMinor, candidate.CurrencyCode),
+			candidate.LastDate,
+			candidate.NextExpectedDate,
+			status,
+			schedule,
+		})
+	}
+
+	totalRows := make([][]string, 0, len(detection.AnnualizedTotals))
+	for _, total := range detection.AnnualizedTotals {
+		totalRows = append(totalRows, []string{
+			total.CurrencyCode,
+			formatHumanMoney(total.TotalMinor, total.CurrencyCode),
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: "Recurring charges as of " + detection.AsOfDate,
+			Columns: []output.TableColumn{
+				{Header: "Name"},
+				{Header: "Interval"},
+				{Header: "Seen", AlignRight: true},
+				{Header: "Amount", AlignRight: true},
+				{Header: "Per year", AlignRight: true},
+				{Header: "Last"},
+				{Header: "Next"},
+				{Header: "Status"},
+				{Header: "Schedule"},
+			},
+			Rows: rows,
+		},
+		{
+			Title: "Annualized cost of active charges",
+			Columns: []output.TableColumn{
+				{Header: "Currency"},
+				{Header: "Per year", AlignRight: true},
+			},
+			Rows: totalRows,
+		},
+	}
+}
+
+func subscriptionChangeTables(changes []domain.SubscriptionPriceChange) []output.Table {
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []string{
+			change.Date,
+			change.Name,
+			change.Interval,
+			formatHumanMoney(change.PreviousAmountMinor, change.CurrencyCode),
+			formatHumanMoney(change.AmountMinor, change.CurrencyCode),
+			formatHumanMoney(change.ChangeMinor, change.CurrencyCode),
+			formatHumanMoney(change.AnnualizedChangeMinor, change.CurrencyCode),
+		})
+	}
+
+	return []output.Table{
+		{
+			Title: "Recurring charge price changes",
+			Columns: []output.TableColumn{
+				{Header: "Date"},
+				{Header: "Name"},
+				{Header: "Interval"},
+				{Header: "Was", AlignRight: true},
+				{Header: "Now", AlignRight: true},
+				{Header: "Change", AlignRight: true},
+				{Header: "Per year", AlignRight: true},
+			},
+			Rows: rows,
+		},
+	}
+}
+
+func labelTables(labels []domain.Label) []output.Table {
+	rows := make([][]string, 0, len(labels))
+	for _, label := range labels {
+		state := ""
+		if label.Archived() {
+			state = "archived"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(label.ID, 10),
+			label.Icon,
+			label.Name,
+			label.Color,
+			state,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Labels",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Icon"},
+			{Header: "Name"},
+			{Header: "Color"},
+			{Header: "Status"},
+		},
+		Rows: rows,
+	}}
+}
+
+func categoryTables(categories []domain.Category) []output.Table {
+	rows := make([][]string, 0, len(categories))
+	for _, category := range categories {
+		state := ""
+		if category.Archived() {
+			state = "archived"
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(category.ID, 10),
+			category.Icon,
+			category.Name,
+			category.Color,
+			state,
+		})
+	}
+
+	return []output.Table{{
+		Title: "Categories",
+		Columns: []output.TableColumn{
+			{Header: "ID", AlignRight: true},
+			{Header: "Icon"},
+			{Header: "Name"},
+			{Header: "Color"},
+			{Header: "Status"},
+		},
+		Rows: rows,
+	}}
+}
+
+func cleanupTables(suggestion domain.CleanupSuggestion, applied *domain.CleanupApplyResult) []output.Table {
+	rows := func(candidates []domain.CleanupCandidate) [][]string {
+		out := make([][]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			lastUsed := "never"
+			if candidate.LastUsedAtUTC != "" {
+				lastUsed = output.FormatHumanDate(candidate.LastUsedAtUTC)
+			}
+			out = append(out, []string{
+				strconv.FormatInt(candidate.ID, 10),
+				candidate.Name,
+				strconv.FormatInt(candidate.EntryCount, 10),
+				lastUsed,
+			})
+		}
+		return out
+	}
+	columns := []output.TableColumn{
+		{Header: "ID", AlignRight: true},
+		{Header: "Name"},
+		{Header: "Entries", AlignRight: true},
+		{Header: "Last used"},
+	}
+
+	categoryTitle, labelTitle, cardTitle := "Unused categories", "Unused labels", "Unused cards"
+	if applied != nil {
+		categoryTitle, labelTitle, cardTitle = "Archived categories", "Archived labels", "Archived cards"
+	}
+	return []output.Table{
+		{Title: categoryTitle, Columns: columns, Rows: rows(suggestion.Categories)},
+		{Title: labelTitle, Columns: columns, Rows: rows(suggestion.Labels)},
+		{Title: cardTitle, Columns: columns, Rows: rows(suggestion.Cards)},
+	}
+}
+
+func fxCacheStatusTables(status domain.FXCacheStatus) []output.Table {
+	rows := make([][]string, 0, len(status.Pairs))
+	for _, pair := range status.Pairs {
+		stale := ""
+		if pair.Stale {
+			stale = "stale"
+		}
+		rows = append(rows, []string{
+			pair.BaseCurrency + "/" + pair.QuoteCurrency,
+			pair.Provider,
+			strconv.FormatInt(pair.SnapshotCount, 10),
+			pair.OldestRateDate,
+			pair.NewestRateDate,
+			output.FormatHumanDate(pair.LastFetchedAtUTC),
+			strconv.FormatInt(pair.AgeDays, 10),
+			stale,
+		})
+	}
+	title := fmt.Sprintf("FX rate cache (ttl %dh, stale after %d days)", status.Policy.TTLHours, status.Policy.StaleAfterDays)
+	return []output.Table{{
+		Title: title,
+		Columns: []output.TableColumn{
+			{Header: "Pair"},
+			{Header: "Provider"},
+			{Header: "Snapshots", AlignRight: true},
+			{Header: "Oldest"},
+			{Header: "Newest"},
+			{Header: "Last fetched"},
+			{Header: "Age (days)", AlignRight: true},
+			{Header: "Status"},
+		},
+		Rows: rows,
+	}}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type inflationImportFlags struct {
+	filePath string
+}
+
+type inflationListFlags struct {
+	currencyRaw string
+}
+
+type inflationCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *inflationCLIError) Error() string {
+	if e == nil {
+		return "inflation command error"
+	}
+	return e.Message
+}
+
+func NewInflationCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inflation",
+		Short: "Manage inflation index points used for report revaluation",
+	}
+
+	cmd.AddCommand(
+		newInflationImportCmd(opts),
+		newInflationListCmd(opts),
+	)
+
+	return cmd
+}
+
+func newInflationImportCmd(opts *RootOptions) *cobra.Command {
+	flags := &inflationImportFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import index points from CSV (currency_code,index_date,index_value)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printInflationError(cmd, outputFormat(opts), &inflationCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "inflation import does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			filePath := strings.TrimSpace(flags.filePath)
+			if filePath == "" {
+				return printInflationError(cmd, outputFormat(opts), &inflationCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "file is required",
+					Details: map[string]any{"field": "file"},
+				})
+			}
+
+			svc, err := newInflationService(opts)
+			if err != nil {
+				return printInflationError(cmd, outputFormat(opts), err)
+			}
+
+			result, err := svc.ImportCSV(cmd.Context(), filePath)
+			if err != nil {
+				return printInflationError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"file":     filePath,
+				"imported": result.Imported,
+			}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.filePath, "file", "", "CSV file path")
+
+	return cmd
+}
+
+func newInflationListCmd(opts *RootOptions) *cobra.Command {
+	flags := &inflationListFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List imported index points",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printInflationError(cmd, outputFormat(opts), &inflationCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "inflation list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newInflationService(opts)
+			if err != nil {
+				return printInflationError(cmd, outputFormat(opts), err)
+			}
+
+			points, err := svc.List(cmd.Context(), flags.currencyRaw)
+			if err != nil {
+				return printInflationError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"points": points,
+				"count":  len(points),
+			}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.currencyRaw, "currency", "", "Filter by currency code")
+
+	return cmd
+}
+
+func newInflationService(opts *RootOptions) (*service.InflationService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &inflationCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewInflationService(sqlitestore.NewInflationRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("inflation service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printInflationError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *inflationCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromInflationError(err), messageFromInflationError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromInflationError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidInflationIndexDate),
+		errors.Is(err, domain.ErrInvalidInflationIndexValue),
+		errors.Is(err, os.ErrNotExist),
+		strings.Contains(err.Error(), "invalid csv row"):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromInflationError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidInflationIndexDate):
+		return "index_date must be YYYY-MM-DD or RFC3339"
+	case errors.Is(err, domain.ErrInvalidInflationIndexValue):
+		return "index_value must be a positive decimal number"
+	case errors.Is(err, os.ErrNotExist):
+		return "file not found"
+	case strings.Contains(err.Error(), "invalid csv row"):
+		return "invalid inflation index csv"
+	default:
+		return "database operation failed"
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestInflationImportAndReportRevaluation(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	csvPath := filepath.Join(t.TempDir(), "index.csv")
+	writeCSVFile(t, csvPath, [][]string{
+		{"currency_code", "index_date", "index_value"},
+		{"ars", "2026-02-01", "100"},
+		{"ARS", "2026-03-01", "125.5"},
+	})
+
+	imported := executeInflationCmdJSON(t, db, []string{"import", "--file", csvPath})
+	assertSuccessJSONEnvelope(t, imported)
+	if got := mustMap(t, imported["data"])["imported"].(float64); got != 2 {
+		t.Fatalf("expected 2 imported points, got %v", got)
+	}
+
+	listed := executeInflationCmdJSON(t, db, []string{"list", "--currency", "ARS"})
+	assertSuccessJSONEnvelope(t, listed)
+	points := mustAnySlice(t, mustMap(t, listed["data"])["points"])
+	if len(points) != 2 {
+		t.Fatalf("expected 2 listed points, got %v", points)
+	}
+	if mustMap(t, points[0])["currency_code"].(string) != "ARS" {
+		t.Fatalf("expected normalized currency code, got %v", points[0])
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add",
+		"--type", "expense",
+		"--amount", "10.00",
+		"--currency", "ARS",
+		"--date", "2026-02-10",
+	}))
+
+	report := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02", "--revalue-as-of", "2026-03-15"})
+	if ok, _ := report["ok"].(bool); !ok {
+		t.Fatalf("expected revalued report ok=true payload=%v", report)
+	}
+	reportData := mustMap(t, report["data"])
+	assertNoMinorFieldsInReportPayload(t, reportData)
+	spending := mustAnySlice(t, mustMap(t, reportData["spending"])["by_currency"])
+	if got := reportTotalForCurrency(t, spending, "ARS"); got != 1255 {
+		t.Fatalf("expected revalued ARS spending 1255, got %d", got)
+	}
+	general := mustAnySlice(t, mustMap(t, reportData["general_balance"])["by_currency"])
+	if got := reportTotalForCurrency(t, general, "ARS"); got != -1255 {
+		t.Fatalf("expected revalued ARS general balance -1255, got %d", got)
+	}
+	revaluation := mustMap(t, reportData["revaluation"])
+	if revaluation["as_of_date"].(string) != "2026-03-15" {
+		t.Fatalf("expected as_of_date 2026-03-15, got %v", revaluation["as_of_date"])
+	}
+
+	balance := executeBalanceCmdJSON(t, db, []string{"show", "--scope", "lifetime", "--revalue-as-of", "2026-03-15"})
+	assertSuccessJSONEnvelope(t, balance)
+	lifetime := mustMap(t, mustMap(t, balance["data"])["lifetime"])
+	if got := balanceNetForCurrency(t, mustAnySlice(t, lifetime["by_currency"]), "ARS"); got != -1255 {
+		t.Fatalf("expected revalued ARS lifetime net -1255, got %d", got)
+	}
+	if mustMap(t, lifetime["revaluation"])["as_of_date"].(string) != "2026-03-15" {
+		t.Fatalf("expected balance revaluation as_of_date 2026-03-15, got %v", lifetime["revaluation"])
+	}
+}
+
+func TestInflationImportRejectsInvalidIndexValue(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	csvPath := filepath.Join(t.TempDir(), "index.csv")
+	writeCSVFile(t, csvPath, [][]string{
+		{"ARS", "2026-02-01", "100"},
+		{"ARS", "2026-03-01", "-3"},
+	})
+
+	payload := executeInflationCmdJSON(t, db, []string{"import", "--file", csvPath})
+	if ok, _ := payload["ok"].(bool); ok {
+		t.Fatalf("expected import failure payload=%v", payload)
+	}
+	if code := mustMap(t, payload["error"])["code"].(string); code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT, got %s", code)
+	}
+
+	listed := executeInflationCmdJSON(t, db, []string{"list"})
+	if count := mustMap(t, listed["data"])["count"].(float64); count != 0 {
+		t.Fatalf("expected no points after rejected import, got %v", count)
+	}
+}
+
+func executeInflationCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewInflationCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute inflation cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	raw := strings.TrimSpace(buf.String())
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal inflation payload: %v raw=%s", err, raw)
+	}
+	return payload
+}
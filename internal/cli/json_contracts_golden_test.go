@@ -624,6 +624,8 @@ func normalizeJSONContractValue(key string, value any) any {
 		switch {
 		case key == "timestamp_utc" || strings.HasSuffix(key, "_at_utc"):
 			return "<timestamp_utc>"
+		case key == "uid":
+			return "<uid>"
 		case isPathField(key):
 			return filepath.Base(typed)
 		default:
@@ -0,0 +1,455 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+const jsonInputFlag = "json-input"
+
+// readJSONInput reads the object a mutating command takes from --json-input
+// (a path, or - for stdin) into fields. It accepts the bare object, the
+// envelope data holding it under key ({"entry": {...}}) or the whole success
+// envelope, so JSON a command printed can be edited and written back. Keys a
+// command does not use, such as id and timestamps, are ignored by the callers.
+func readJSONInput(cmd *cobra.Command, path, key string) (map[string]json.RawMessage, error) {
+	var (
+		raw []byte
+		err error
+	)
+	if path = strings.TrimSpace(path); path == "-" {
+		raw, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	fields, err := decodeJSONInputObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := fields["data"]; ok {
+		if _, isEnvelope := fields["ok"]; isEnvelope {
+			if fields, err = decodeJSONInputObject(data); err != nil {
+				return nil, fmt.Errorf("envelope data: %w", err)
+			}
+		}
+	}
+	if nested, ok := fields[key]; ok {
+		if fields, err = decodeJSONInputObject(nested); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return fields, nil
+}
+
+func decodeJSONInputObject(raw []byte) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bytes.TrimSpace(raw), &fields); err != nil {
+		return nil, fmt.Errorf("expected a JSON object: %w", err)
+	}
+	if fields == nil {
+		return nil, errors.New("expected a JSON object, got null")
+	}
+	return fields, nil
+}
+
+// jsonInputField decodes fields[key] into target. It reports whether the key
+// was present and whether its value was null, leaving target untouched then.
+func jsonInputField(fields map[string]json.RawMessage, key string, target any) (present, null bool, err error) {
+	raw, ok := fields[key]
+	if !ok {
+		return false, false, nil
+	}
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return true, true, nil
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return true, false, fmt.Errorf("%s: %w", key, err)
+	}
+	return true, false, nil
+}
+
+// Flags the JSON object replaces; passing one next to --json-input is an
+// error rather than a silent override.
+var (
+	entryAddJSONInputConflicts = []string{
+		"type", "amount", "currency", "date", "category-id", "bank-account-id", "label-id", "note", "payee", "by",
+		"source", "location", "record-in", "payment-method", "card-id", "card-nickname", "card-lookup", "refund-of", "shared", "split-with", "interactive",
+	}
+	entryUpdateJSONInputConflicts = []string{
+		"type", "amount", "currency", "date", "category-id", "clear-category", "bank-account-id", "clear-bank-account",
+		"label-id", "clear-labels", "note", "clear-note", "payee", "clear-payee", "by", "clear-by",
+		"source", "clear-source", "location", "clear-location", "payment-method", "card-id", "card-nickname", "card-lookup",
+	}
+	cardAddJSONInputConflicts = []string{"nickname", "description", "last4", "brand", "card-type", "due-day", "default-currency", "fx-fee-percent"}
+	capSetJSONInputConflicts  = []string{"month", "category-id", "amount", "currency", "copy-previous"}
+)
+
+// jsonInputConflict returns the first of names set next to --json-input, or
+// "" when there is none.
+func jsonInputConflict(cmd *cobra.Command, names []string) string {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+func jsonInputConflictDetails(conflict string) map[string]any {
+	return map[string]any{"fields": []string{jsonInputFlag, conflict}}
+}
+
+func jsonInputParseDetails(err error) map[string]any {
+	return map[string]any{"field": jsonInputFlag, "reason": err.Error()}
+}
+
+// readEntryJSONInput reads the entry object of entry add or update after
+// checking none of conflicts was passed.
+func readEntryJSONInput(cmd *cobra.Command, path string, conflicts []string) (map[string]json.RawMessage, error) {
+	if conflict := jsonInputConflict(cmd, conflicts); conflict != "" {
+		return nil, &entryCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "json-input cannot be combined with " + conflict,
+			Details: jsonInputConflictDetails(conflict),
+		}
+	}
+	fields, err := readJSONInput(cmd, path, "entry")
+	if err != nil {
+		return nil, entryJSONInputError(err)
+	}
+	return fields, nil
+}
+
+// buildEntryAddInputFromJSON maps the entry object read from flags.jsonInput
+// to an add input. type, amount_minor and transaction_date_utc are required;
+// currency_code defaults like --currency does. Only --allow-archived may be
+// passed alongside.
+func buildEntryAddInputFromJSON(cmd *cobra.Command, flags *entryAddFlags) (domain.EntryAddInput, error) {
+	fields, err := readEntryJSONInput(cmd, flags.jsonInput, entryAddJSONInputConflicts)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
+
+	var (
+		input       = domain.EntryAddInput{AllowArchived: flags.allowArchived}
+		amountMinor int64
+	)
+	required := []struct {
+		key    string
+		target any
+	}{
+		{"type", &input.Type},
+		{"amount_minor", &amountMinor},
+		{"transaction_date_utc", &input.TransactionDateUTC},
+	}
+	for _, field := range required {
+		present, null, err := jsonInputField(fields, field.key, field.target)
+		if err != nil {
+			return domain.EntryAddInput{}, entryJSONInputError(err)
+		}
+		if !present || null {
+			return domain.EntryAddInput{}, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: field.key + " is required",
+				Details: map[string]any{"field": field.key},
+			}
+		}
+	}
+	input.AmountMinor = amountMinor
+
+	optional := []struct {
+		key    string
+		target any
+	}{
+		{"currency_code", &input.CurrencyCode},
+		{"category_id", &input.CategoryID},
+		{"bank_account_id", &input.BankAccountID},
+		{"refund_of_entry_id", &input.RefundOfEntryID},
+		{"label_ids", &input.LabelIDs},
+		{"note", &input.Note},
+		{"payee", &input.Payee},
+		{"recorded_by", &input.RecordedBy},
+		{"income_source", &input.IncomeSource},
+		{"location", &input.Location},
+		{"record_in_currency", &input.RecordInCurrency},
+		{"splits", &input.Splits},
+		{"payment_method", &input.PaymentMethod},
+		{"payment_card_id", &input.PaymentCardID},
+	}
+	for _, field := range optional {
+		if _, _, err := jsonInputField(fields, field.key, field.target); err != nil {
+			return domain.EntryAddInput{}, entryJSONInputError(err)
+		}
+	}
+	if strings.TrimSpace(input.CurrencyCode) == "" {
+		input.CurrencyCode = flags.currency
+	}
+	return input, nil
+}
+
+// buildEntryUpdateInputFromJSON maps the entry object read from
+// flags.jsonInput to an update of entry id. Only keys present are changed;
+// null (or an empty string or list) clears the optional ones, and read-only
+// keys such as splits and timestamps are ignored. Payment keys are ignored
+// when the object makes the entry an income, since income entries carry no
+// payment method. Only --if-updated-at and --allow-archived may be passed
+// alongside.
+func buildEntryUpdateInputFromJSON(cmd *cobra.Command, id int64, flags *entryUpdateFlags) (domain.EntryUpdateInput, error) {
+	fields, err := readEntryJSONInput(cmd, flags.jsonInput, entryUpdateJSONInputConflicts)
+	if err != nil {
+		return domain.EntryUpdateInput{}, err
+	}
+
+	input := domain.EntryUpdateInput{ID: id, AllowArchived: flags.allowArchived}
+	if cmd.Flags().Changed("if-updated-at") {
+		value := flags.ifUpdatedAt
+		input.ExpectedUpdatedAtUTC = &value
+	}
+
+	var (
+		entryType, currencyCode, transactionDateUTC string
+		amountMinor                                 int64
+	)
+	values := []struct {
+		key    string
+		target any
+		set    func()
+	}{
+		{"type", &entryType, func() { input.Type = &entryType }},
+		{"amount_minor", &amountMinor, func() { input.AmountMinor = &amountMinor }},
+		{"currency_code", &currencyCode, func() { input.CurrencyCode = &currencyCode }},
+		{"transaction_date_utc", &transactionDateUTC, func() { input.TransactionDateUTC = &transactionDateUTC }},
+	}
+	for _, field := range values {
+		present, null, err := jsonInputField(fields, field.key, field.target)
+		if err != nil {
+			return domain.EntryUpdateInput{}, entryJSONInputError(err)
+		}
+		if present && null {
+			return domain.EntryUpdateInput{}, &entryCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: field.key + " cannot be null",
+				Details: map[string]any{"field": field.key},
+			}
+		}
+		if present {
+			field.set()
+		}
+	}
+
+	ids := []struct {
+		key    string
+		set    *bool
+		target **int64
+	}{
+		{"category_id", &input.SetCategory, &input.CategoryID},
+		{"bank_account_id", &input.SetBankAccount, &input.BankAccountID},
+	}
+	for _, field := range ids {
+		present, _, err := jsonInputField(fields, field.key, field.target)
+		if err != nil {
+			return domain.EntryUpdateInput{}, entryJSONInputError(err)
+		}
+		*field.set = present
+	}
+
+	present, _, err := jsonInputField(fields, "label_ids", &input.LabelIDs)
+	if err != nil {
+		return domain.EntryUpdateInput{}, entryJSONInputError(err)
+	}
+	input.SetLabelIDs = present
+
+	texts := []struct {
+		key    string
+		set    *bool
+		target **string
+	}{
+		{"note", &input.SetNote, &input.Note},
+		{"payee", &input.SetPayee, &input.Payee},
+		{"recorded_by", &input.SetRecordedBy, &input.RecordedBy},
+		{"income_source", &input.SetIncomeSource, &input.IncomeSource},
+		{"location", &input.SetLocation, &input.Location},
+	}
+	for _, field := range texts {
+		var value string
+		present, null, err := jsonInputField(fields, field.key, &value)
+		if err != nil {
+			return domain.EntryUpdateInput{}, entryJSONInputError(err)
+		}
+		*field.set = present
+		if present && !null && strings.TrimSpace(value) != "" {
+			*field.target = &value
+		}
+	}
+
+	if input.Type == nil || *input.Type != domain.EntryTypeIncome {
+		var method string
+		present, null, err := jsonInputField(fields, "payment_method", &method)
+		if err != nil {
+			return domain.EntryUpdateInput{}, entryJSONInputError(err)
+		}
+		if present && !null {
+			input.SetPaymentMethod = true
+			input.PaymentMethod = &method
+		}
+		var cardID int64
+		present, null, err = jsonInputField(fields, "payment_card_id", &cardID)
+		if err != nil {
+			return domain.EntryUpdateInput{}, entryJSONInputError(err)
+		}
+		if present && !null {
+			input.SetPaymentCard = true
+			input.PaymentCardID = &cardID
+		}
+	}
+	return input, nil
+}
+
+func entryJSONInputError(err error) error {
+	return &entryCLIError{
+		Code:    "INVALID_ARGUMENT",
+		Message: "json-input could not be parsed",
+		Details: jsonInputParseDetails(err),
+	}
+}
+
+// cardAddFlagsFromJSON fills the card add flags from the card object read
+// from path, so the JSON goes through the same checks as the flags.
+func cardAddFlagsFromJSON(cmd *cobra.Command, path string) (*cardAddFlags, error) {
+	if conflict := jsonInputConflict(cmd, cardAddJSONInputConflicts); conflict != "" {
+		return nil, &cardCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "json-input cannot be combined with " + conflict,
+			Details: jsonInputConflictDetails(conflict),
+		}
+	}
+	fields, err := readJSONInput(cmd, path, "card")
+	if err != nil {
+		return nil, &cardCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "json-input could not be parsed",
+			Details: jsonInputParseDetails(err),
+		}
+	}
+
+	flags := &cardAddFlags{}
+	var dueDay *int
+	var fxFee *int64
+	keys := []struct {
+		key    string
+		target any
+	}{
+		{"nickname", &flags.nickname},
+		{"description", &flags.description},
+		{"last4", &flags.last4},
+		{"brand", &flags.brand},
+		{"card_type", &flags.cardType},
+		{"due_day", &dueDay},
+		{"default_currency_code", &flags.currency},
+		{"fx_fee_bp", &fxFee},
+	}
+	for _, field := range keys {
+		if _, _, err := jsonInputField(fields, field.key, field.target); err != nil {
+			return nil, &cardCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "json-input could not be parsed",
+				Details: jsonInputParseDetails(err),
+			}
+		}
+	}
+	if dueDay != nil {
+		flags.dueDayRaw = strconv.Itoa(*dueDay)
+	}
+	if fxFee != nil {
+		flags.fxFeeRaw = formatCardFXFeePercent(*fxFee)
+	}
+	return flags, nil
+}
+
+// buildCapSetInputFromJSON maps the cap object read from flags.jsonInput to a
+// set input. month_key and amount_minor are required; currency_code defaults
+// like --currency does. Only --if-updated-at may be passed alongside.
+func buildCapSetInputFromJSON(cmd *cobra.Command, flags *capSetFlags) (domain.CapSetInput, error) {
+	if conflict := jsonInputConflict(cmd, capSetJSONInputConflicts); conflict != "" {
+		return domain.CapSetInput{}, &capCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "json-input cannot be combined with " + conflict,
+			Details: jsonInputConflictDetails(conflict),
+		}
+	}
+	fields, err := readJSONInput(cmd, flags.jsonInput, "cap")
+	if err != nil {
+		return domain.CapSetInput{}, &capCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "json-input could not be parsed",
+			Details: jsonInputParseDetails(err),
+		}
+	}
+
+	var (
+		input       domain.CapSetInput
+		amountMinor *int64
+	)
+	keys := []struct {
+		key    string
+		target any
+	}{
+		{"month_key", &input.MonthKey},
+		{"category_id", &input.CategoryID},
+		{"amount_minor", &amountMinor},
+		{"currency_code", &input.CurrencyCode},
+	}
+	for _, field := range keys {
+		if _, _, err := jsonInputField(fields, field.key, field.target); err != nil {
+			return domain.CapSetInput{}, &capCLIError{
+				Code:    "INVALID_ARGUMENT",
+				Message: "json-input could not be parsed",
+				Details: jsonInputParseDetails(err),
+			}
+		}
+	}
+	if amountMinor == nil {
+		return domain.CapSetInput{}, &capCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "amount_minor is required",
+			Details: map[string]any{"field": "amount_minor"},
+		}
+	}
+	input.AmountMinor = *amountMinor
+
+	monthKey, err := normalizeMonthKeyFlag(input.MonthKey, "month_key")
+	if err != nil {
+		return domain.CapSetInput{}, err
+	}
+	input.MonthKey = monthKey
+	if strings.TrimSpace(input.CurrencyCode) == "" {
+		input.CurrencyCode = flags.currencyRaw
+	}
+	if cmd.Flags().Changed("if-updated-at") {
+		value := flags.ifUpdatedAt
+		input.ExpectedUpdatedAtUTC = &value
+	}
+	return input, nil
+}
+
+// formatCardFXFeePercent turns fx_fee_bp back into the percent --fx-fee-percent
+// takes; a negative value is kept so the parser rejects it.
+func formatCardFXFeePercent(basisPoints int64) string {
+	if basisPoints < 0 {
+		return strconv.FormatInt(basisPoints, 10)
+	}
+	return fmt.Sprintf("%d.%02d", basisPoints/100, basisPoints%100)
+}
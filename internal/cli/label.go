@@ -23,6 +23,9 @@ func NewLabelCmd(opts *RootOptions) *cobra.Command {
 		newLabelAddCmd(opts),
 		newLabelListCmd(opts),
 		newLabelRenameCmd(opts),
+		newLabelUpdateCmd(opts),
+		newLabelArchiveCmd(opts, true),
+		newLabelArchiveCmd(opts, false),
 		newLabelDeleteCmd(opts),
 	)
 
@@ -67,7 +70,10 @@ func newLabelAddCmd(opts *RootOptions) *cobra.Command {
 }
 
 func newLabelListCmd(opts *RootOptions) *cobra.Command {
-	return &cobra.Command{
+	var includeArchived bool
+	var sortKey string
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List active labels",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -85,18 +91,25 @@ func newLabelListCmd(opts *RootOptions) *cobra.Command {
 				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
 			}
 
-			labels, err := svc.List(cmd.Context())
+			labels, err := svc.ListSorted(cmd.Context(), sortKey)
 			if err != nil {
 				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
 			}
+			if !includeArchived {
+				labels = domain.UnarchivedLabels(labels)
+			}
 
 			env := output.NewSuccessEnvelope(map[string]any{
 				"labels": labels,
 				"count":  len(labels),
 			}, nil)
-			return printCommandEnvelope(cmd, outputFormat(opts), env)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, labelTables(labels))
 		},
 	}
+
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived labels")
+	cmd.Flags().StringVar(&sortKey, "sort", domain.LabelSortName, "Sort order: name|id|created")
+	return cmd
 }
 
 func newLabelRenameCmd(opts *RootOptions) *cobra.Command {
@@ -134,6 +147,112 @@ func newLabelRenameCmd(opts *RootOptions) *cobra.Command {
 	}
 }
 
+func newLabelUpdateCmd(opts *RootOptions) *cobra.Command {
+	var color string
+	var icon string
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Set a label's display color and icon",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCommandEnvelope(cmd, outputFormat(opts), output.NewErrorEnvelope(
+					"INVALID_ARGUMENT",
+					"update requires exactly one argument: <id>",
+					map[string]any{"required_args": []string{"id"}},
+					nil,
+				))
+			}
+
+			svc, err := newLabelService(opts)
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			id, err := parseLabelID(args[0])
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			label, err := svc.UpdateAppearance(cmd.Context(), id, appearanceUpdateFromFlags(cmd, color, icon))
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"label": label}, nil)
+			return printCommandEnvelope(cmd, outputFormat(opts), env)
+		},
+	}
+
+	bindAppearanceFlags(cmd, &color, &icon)
+	return cmd
+}
+
+// bindAppearanceFlags registers --color and --icon; passing an empty value
+// clears the stored one.
+func bindAppearanceFlags(cmd *cobra.Command, color, icon *string) {
+	cmd.Flags().StringVar(color, "color", "", "Display color as #rgb or #rrggbb (empty clears it)")
+	cmd.Flags().StringVar(icon, "icon", "", "Display icon, such as an emoji (empty clears it)")
+}
+
+func appearanceUpdateFromFlags(cmd *cobra.Command, color, icon string) domain.AppearanceUpdate {
+	update := domain.AppearanceUpdate{}
+	if cmd.Flags().Changed("color") {
+		update.Color = &color
+	}
+	if cmd.Flags().Changed("icon") {
+		update.Icon = &icon
+	}
+	return update
+}
+
+func newLabelArchiveCmd(opts *RootOptions, archive bool) *cobra.Command {
+	use := "unarchive"
+	short := "Restore an archived label"
+	if archive {
+		use = "archive"
+		short = "Archive a label, hiding it from listings and new entries"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printCommandEnvelope(cmd, outputFormat(opts), output.NewErrorEnvelope(
+					"INVALID_ARGUMENT",
+					use+" requires exactly one argument: <id>",
+					map[string]any{"required_args": []string{"id"}},
+					nil,
+				))
+			}
+
+			svc, err := newLabelService(opts)
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			id, err := parseLabelID(args[0])
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			var label domain.Label
+			if archive {
+				label, err = svc.Archive(cmd.Context(), id)
+			} else {
+				label, err = svc.Unarchive(cmd.Context(), id)
+			}
+			if err != nil {
+				return printCommandEnvelope(cmd, outputFormat(opts), envelopeFromLabelErr(err))
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"label": label}, nil)
+			return printCommandEnvelope(cmd, outputFormat(opts), env)
+		},
+	}
+}
+
 func newLabelDeleteCmd(opts *RootOptions) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete <id>",
@@ -244,6 +363,22 @@ func envelopeFromLabelErr(err error) output.Envelope {
 			map[string]any{"field": "name"},
 			nil,
 		)
+	case errors.Is(err, domain.ErrInvalidAppearanceColor),
+		errors.Is(err, domain.ErrInvalidAppearanceIcon),
+		errors.Is(err, domain.ErrNoAppearanceFields):
+		return output.NewErrorEnvelope(
+			"INVALID_ARGUMENT",
+			messageFromAppearanceErr(err),
+			map[string]any{},
+			nil,
+		)
+	case errors.Is(err, domain.ErrInvalidListSort):
+		return output.NewErrorEnvelope(
+			"INVALID_ARGUMENT",
+			"sort must be one of: "+strings.Join(domain.LabelSortKeys, "|"),
+			map[string]any{"field": "sort"},
+			nil,
+		)
 	case errors.Is(err, domain.ErrStorage):
 		return output.NewErrorEnvelope(
 			"DB_ERROR",
@@ -260,3 +395,14 @@ func envelopeFromLabelErr(err error) output.Envelope {
 		)
 	}
 }
+
+func messageFromAppearanceErr(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAppearanceColor):
+		return "color must be a hex color such as #ff8800"
+	case errors.Is(err, domain.ErrInvalidAppearanceIcon):
+		return fmt.Sprintf("icon must be at most %d bytes without spaces", domain.AppearanceIconMaxLength)
+	default:
+		return "at least one of --color or --icon is required"
+	}
+}
@@ -76,6 +76,79 @@ func TestLabelCommandJSONInvalidID(t *testing.T) {
 	}
 }
 
+func TestLabelCommandJSONListSort(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, name := range []string{"travel", "Bills", "groceries"} {
+		executeLabelCmdJSON(t, db, []string{"add", name})
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"list"}, want: []string{"Bills", "groceries", "travel"}},
+		{args: []string{"list", "--sort", "id"}, want: []string{"travel", "Bills", "groceries"}},
+		{args: []string{"list", "--sort", "created"}, want: []string{"travel", "Bills", "groceries"}},
+	} {
+		payload := executeLabelCmdJSON(t, db, tc.args)
+		labels := mustAnySlice(t, mustMap(t, payload["data"])["labels"])
+		got := make([]string, 0, len(labels))
+		for _, label := range labels {
+			got = append(got, mustMap(t, label)["name"].(string))
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("%v: expected %v, got %v", tc.args, tc.want, got)
+		}
+	}
+
+	payload := executeLabelCmdJSON(t, db, []string{"list", "--sort", "color"})
+	errPayload := mustMap(t, payload["error"])
+	if errPayload["code"].(string) != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown sort, got %v", errPayload["code"])
+	}
+}
+
+func TestLabelCommandJSONUpdateAppearance(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	executeLabelCmdJSON(t, db, []string{"add", "travel"})
+
+	payload := executeLabelCmdJSON(t, db, []string{"update", "1", "--color", "#FF8800", "--icon", "\u2708\ufe0f"})
+	label := mustMap(t, mustMap(t, payload["data"])["label"])
+	if label["color"] != "#ff8800" || label["icon"] != "\u2708\ufe0f" {
+		t.Fatalf("expected normalized color and icon, got %v", label)
+	}
+
+	out := executeLabelCmdRaw(t, db, output.FormatHuman, []string{"list"})
+	if !strings.Contains(out, "#ff8800") || !strings.Contains(out, "\u2708\ufe0f") {
+		t.Fatalf("expected human list to show color and icon, got %q", out)
+	}
+
+	payload = executeLabelCmdJSON(t, db, []string{"update", "1", "--color", ""})
+	label = mustMap(t, mustMap(t, payload["data"])["label"])
+	if _, ok := label["color"]; ok || label["icon"] != "\u2708\ufe0f" {
+		t.Fatalf("expected color cleared and icon kept, got %v", label)
+	}
+
+	for _, args := range [][]string{
+		{"update", "1"},
+		{"update", "1", "--color", "orange"},
+	} {
+		payload := executeLabelCmdJSON(t, db, args)
+		errPayload := mustMap(t, payload["error"])
+		if errPayload["code"].(string) != "INVALID_ARGUMENT" {
+			t.Fatalf("%v: expected INVALID_ARGUMENT, got %v", args, errPayload["code"])
+		}
+	}
+}
+
 func TestLabelCommandHumanOutput(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,424 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type loanAddFlags struct {
+	name       string
+	principal  string
+	rate       string
+	termMonths int64
+	startMonth string
+	currency   string
+	note       string
+}
+
+type loanCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *loanCLIError) Error() string {
+	if e == nil {
+		return "loan command error"
+	}
+	return e.Message
+}
+
+func NewLoanCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loan",
+		Short: "Track amortizing loans and the payments made on them",
+		Long: `Track amortizing loans repaid in equal monthly installments. Payments are
+ordinary expense entries linked to a loan; each one settles the interest
+accrued up to its month first and then principal, so loan show reports the
+remaining principal and the interest paid to date.`,
+	}
+
+	cmd.AddCommand(
+		newLoanAddCmd(opts),
+		newLoanListCmd(opts),
+		newLoanShowCmd(opts),
+		newLoanDeleteCmd(opts),
+		newLoanPaymentCmd(opts),
+	)
+
+	return cmd
+}
+
+func newLoanAddCmd(opts *RootOptions) *cobra.Command {
+	flags := &loanAddFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a loan and print its amortization schedule",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printLoanError(cmd, opts.Output, &loanCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "loan add does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			for _, field := range []string{"principal", "rate", "term-months", "start"} {
+				if !cmd.Flags().Changed(field) {
+					return printLoanError(cmd, opts.Output, &loanCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: field + " is required",
+						Details: map[string]any{"field": field},
+					})
+				}
+			}
+
+			currencyCode := flags.currency
+			if !cmd.Flags().Changed("currency") {
+				currencyCode = defaultCurrency(opts)
+			}
+			principalMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.principal, currencyCode, amountFormat(opts))
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+			rateBasisPoints, err := domain.ParseLoanRatePercent(flags.rate)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			svc, err := newLoanService(opts)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			status, err := svc.Add(cmd.Context(), domain.LoanAddInput{
+				Name:                  flags.name,
+				CurrencyCode:          currencyCode,
+				PrincipalMinor:        principalMinor,
+				AnnualRateBasisPoints: rateBasisPoints,
+				TermMonths:            flags.termMonths,
+				StartMonth:            flags.startMonth,
+				Note:                  flags.note,
+			})
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"loan_status": status,
+			}, nil), loanStatusTables(status))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.name, "name", "", "Optional loan name")
+	cmd.Flags().StringVar(&flags.principal, "principal", "", "Amount borrowed in major units (e.g. 10000)")
+	cmd.Flags().StringVar(&flags.rate, "rate", "", "Annual interest rate in percent (e.g. 7.5)")
+	cmd.Flags().Int64Var(&flags.termMonths, "term-months", 0, "Number of monthly installments")
+	cmd.Flags().StringVar(&flags.startMonth, "start", "", "Month of the first installment in YYYY-MM")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
+	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
+
+	return cmd
+}
+
+func newLoanListCmd(opts *RootOptions) *cobra.Command {
+	includeDeleted := false
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List loans with remaining principal and interest paid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printLoanError(cmd, opts.Output, &loanCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "loan list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newLoanService(opts)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			loans, err := svc.List(cmd.Context(), includeDeleted)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"loans": loans,
+				"count": len(loans),
+			}, nil), loanListTables(loans))
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted loans")
+
+	return cmd
+}
+
+func newLoanShowCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a loan's schedule, linked payments and progress",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printLoanError(cmd, opts.Output, &loanCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "loan show requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveLoanID(args[0], "id")
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			svc, err := newLoanService(opts)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			status, err := svc.Show(cmd.Context(), id)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"loan_status": status,
+			}, nil), loanStatusTables(status))
+		},
+	}
+}
+
+func newLoanDeleteCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Soft-delete a loan",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printLoanError(cmd, opts.Output, &loanCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "loan delete requires exactly one argument: <id>",
+					Details: map[string]any{"required_args": []string{"id"}},
+				})
+			}
+
+			id, err := parsePositiveLoanID(args[0], "id")
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			svc, err := newLoanService(opts)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			deleted, err := svc.Delete(cmd.Context(), id)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			return output.Print(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"loan_delete": deleted,
+			}, nil))
+		},
+	}
+}
+
+func newLoanPaymentCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "payment",
+		Short: "Link expense entries to a loan as payments",
+	}
+
+	cmd.AddCommand(
+		newLoanPaymentLinkCmd(opts, "link", "Link an expense entry to a loan as a payment"),
+		newLoanPaymentLinkCmd(opts, "unlink", "Remove a payment link, keeping the entry"),
+	)
+
+	return cmd
+}
+
+func newLoanPaymentLinkCmd(opts *RootOptions, action, short string) *cobra.Command {
+	entryIDRaw := ""
+
+	cmd := &cobra.Command{
+		Use:   action + " <loan-id>",
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printLoanError(cmd, opts.Output, &loanCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "loan payment " + action + " requires exactly one argument: <loan-id>",
+					Details: map[string]any{"required_args": []string{"loan-id"}},
+				})
+			}
+
+			loanID, err := parsePositiveLoanID(args[0], "loan-id")
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+			entryID, err := parsePositiveLoanID(entryIDRaw, "entry-id")
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			svc, err := newLoanService(opts)
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			var status domain.LoanStatus
+			if action == "link" {
+				status, err = svc.LinkPayment(cmd.Context(), loanID, entryID)
+			} else {
+				status, err = svc.UnlinkPayment(cmd.Context(), loanID, entryID)
+			}
+			if err != nil {
+				return printLoanError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"loan_status": status,
+			}, nil), loanStatusTables(status))
+		},
+	}
+
+	cmd.Flags().StringVar(&entryIDRaw, "entry-id", "", "Expense entry ID")
+
+	return cmd
+}
+
+func newLoanService(opts *RootOptions) (*service.LoanService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &loanCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewLoanService(sqlitestore.NewLoanRepo(opts.db), sqlitestore.NewEntryRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("loan service init: %w", err)
+	}
+	return svc, nil
+}
+
+func parsePositiveLoanID(raw, field string) (int64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, &loanCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: fmt.Sprintf("%s is required", field),
+			Details: map[string]any{"field": field},
+		}
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, &loanCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: fmt.Sprintf("%s must be a positive integer", field),
+			Details: map[string]any{"field": field, "value": raw},
+		}
+	}
+	return parsed, nil
+}
+
+func printLoanError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *loanCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromLoanError(err), messageFromLoanError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromLoanError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrLoanNotFound),
+		errors.Is(err, domain.ErrEntryNotFound),
+		errors.Is(err, domain.ErrLoanPaymentNotLinked):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrLoanPaymentLinked):
+		return "CONFLICT"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidLoanID),
+		errors.Is(err, domain.ErrInvalidEntryID),
+		errors.Is(err, domain.ErrInvalidLoanPrincipal),
+		errors.Is(err, domain.ErrInvalidLoanRate),
+		errors.Is(err, domain.ErrInvalidLoanTerm),
+		errors.Is(err, domain.ErrInvalidMonthKey),
+		errors.Is(err, domain.ErrLoanPaymentEntryInvalid),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromLoanError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrLoanNotFound):
+		return "loan not found"
+	case errors.Is(err, domain.ErrEntryNotFound):
+		return "entry not found"
+	case errors.Is(err, domain.ErrLoanPaymentNotLinked):
+		return "entry is not linked to this loan"
+	case errors.Is(err, domain.ErrLoanPaymentLinked):
+		return "entry is already linked to a loan"
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidLoanID):
+		return "loan id must be a positive integer"
+	case errors.Is(err, domain.ErrInvalidEntryID):
+		return "entry id must be a positive integer"
+	case errors.Is(err, domain.ErrInvalidLoanPrincipal):
+		return "principal must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidLoanRate):
+		return "rate must be a percentage between 0 and 100 with at most two decimals"
+	case errors.Is(err, domain.ErrInvalidLoanTerm):
+		return fmt.Sprintf("term-months must be between 1 and %d", domain.MaxLoanTermMonths)
+	case errors.Is(err, domain.ErrInvalidMonthKey):
+		return "start must use YYYY-MM"
+	case errors.Is(err, domain.ErrLoanPaymentEntryInvalid):
+		return "payment must be an active expense entry in the loan currency"
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return "principal must be a valid decimal number"
+	case errors.Is(err, domain.ErrInvalidAmountPrecision):
+		return "principal has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "principal separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrAmountOverflow):
+		return "principal is too large"
+	default:
+		return "database operation failed"
+	}
+}
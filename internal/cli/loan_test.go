@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestLoanAddLinkPaymentsAndShowProgress(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	added := mustMap(t, mustMap(t, executeLoanCmdJSON(t, db, []string{
+		"add", "--name", "Car", "--principal", "10000", "--rate", "7.5", "--term-months", "36", "--start", "2026-01", "--currency", "USD",
+	})["data"])["loan_status"])
+	if added["monthly_payment_minor"].(float64) != 31106 || len(mustAnySlice(t, added["schedule"])) != 36 {
+		t.Fatalf("unexpected loan schedule: %v", added)
+	}
+	loanID := strconv.FormatInt(int64(mustMap(t, added["loan"])["id"].(float64)), 10)
+
+	payment := mustMap(t, mustMap(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "311.06", "--currency", "USD", "--date", "2026-01-15",
+	})["data"])["entry"])
+	paymentID := strconv.FormatInt(int64(payment["id"].(float64)), 10)
+
+	linked := mustMap(t, mustMap(t, executeLoanCmdJSON(t, db, []string{"payment", "link", loanID, "--entry-id", paymentID})["data"])["loan_status"])
+	if linked["interest_paid_minor"].(float64) != 6250 || linked["remaining_principal_minor"].(float64) != 975144 || linked["next_due_month"] != "2026-02" {
+		t.Fatalf("unexpected progress after first payment: %v", linked)
+	}
+
+	duplicate := executeLoanCmdJSON(t, db, []string{"payment", "link", loanID, "--entry-id", paymentID})
+	if code := mustMap(t, duplicate["error"])["code"]; code != "CONFLICT" {
+		t.Fatalf("expected CONFLICT for a second link, got %v", duplicate)
+	}
+
+	income := mustMap(t, mustMap(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "income", "--amount", "50.00", "--currency", "USD", "--date", "2026-01-20",
+	})["data"])["entry"])
+	invalid := executeLoanCmdJSON(t, db, []string{"payment", "link", loanID, "--entry-id", strconv.FormatInt(int64(income["id"].(float64)), 10)})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for an income entry, got %v", invalid)
+	}
+
+	listed := mustMap(t, executeLoanCmdJSON(t, db, []string{"list"})["data"])
+	if listed["count"].(float64) != 1 {
+		t.Fatalf("expected one loan, got %v", listed)
+	}
+	if row := mustMap(t, mustAnySlice(t, listed["loans"])[0]); row["installments_covered"].(float64) != 1 || row["schedule"] != nil {
+		t.Fatalf("unexpected loan list row: %v", row)
+	}
+
+	unlinked := mustMap(t, mustMap(t, executeLoanCmdJSON(t, db, []string{"payment", "unlink", loanID, "--entry-id", paymentID})["data"])["loan_status"])
+	if unlinked["remaining_principal_minor"].(float64) != 1000000 {
+		t.Fatalf("expected unlink to restore the principal, got %v", unlinked)
+	}
+
+	badRate := executeLoanCmdJSON(t, db, []string{"add", "--principal", "100", "--rate", "7.125", "--term-months", "12", "--start", "2026-01"})
+	if code := mustMap(t, badRate["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for three-decimal rate, got %v", badRate)
+	}
+}
+
+func executeLoanCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewLoanCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute loan cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal loan payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+// skipAutoMigrateAnnotation marks commands that must see the database before
+// pending migrations are applied.
+const skipAutoMigrateAnnotation = "boring-budget/skip-auto-migrate"
+
+type migrateCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *migrateCLIError) Error() string {
+	if e == nil {
+		return "migrate command error"
+	}
+	return e.Message
+}
+
+func NewMigrateCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Inspect, apply and roll back schema migrations",
+	}
+
+	cmd.AddCommand(
+		newMigratePlanCmd(opts),
+		newMigrateStatusCmd(opts),
+		newMigrateUpCmd(opts),
+		newMigrateDownCmd(opts),
+	)
+
+	return cmd
+}
+
+func newMigratePlanCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan",
+		Short: "Show pending migrations and their estimated impact without applying them",
+		Long: `Show the migrations the next command would apply, the tables each one
+touches, how many existing rows they rewrite, an estimated duration based on
+current table sizes, and whether a backup is recommended first.
+
+Nothing is written to the database, including the migration version table.`,
+		Annotations: map[string]string{skipAutoMigrateAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printMigrateError(cmd, outputFormat(opts), &migrateCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "migrate plan does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if opts == nil || opts.db == nil {
+				return printMigrateError(cmd, outputFormat(opts), &migrateCLIError{
+					Code:    "DB_ERROR",
+					Message: "database operation failed",
+					Details: map[string]any{"reason": "database connection unavailable"},
+				})
+			}
+
+			plan, err := sqlitestore.PlanMigrations(cmd.Context(), opts.db, opts.MigrationsDir)
+			if err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"plan": plan}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, migratePlanTables(plan))
+		},
+	}
+}
+
+func newMigrateStatusCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:         "status",
+		Short:       "List migrations with whether and when each was applied",
+		Annotations: map[string]string{skipAutoMigrateAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateMigrateCmd(opts, "migrate status", args); err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			status, err := sqlitestore.MigrationStatus(cmd.Context(), opts.db, opts.MigrationsDir)
+			if err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"status": status}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, migrateStatusTables(status))
+		},
+	}
+}
+
+func newMigrateUpCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:         "up",
+		Short:       "Apply every pending migration",
+		Annotations: map[string]string{skipAutoMigrateAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateMigrateCmd(opts, "migrate up", args); err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			run, err := sqlitestore.MigrateUp(cmd.Context(), opts.db, opts.MigrationsDir)
+			if err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"migration": run}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, migrateRunTables(run))
+		},
+	}
+}
+
+func newMigrateDownCmd(opts *RootOptions) *cobra.Command {
+	var toRaw string
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back migrations down to a schema version",
+		Long: `Run the Down section of every applied migration above --to, newest first.
+
+Use it to downgrade after a bad upgrade: take a backup, roll back to the
+version the older binary expects (see migrate status), then switch binaries.
+Any later command run by this binary applies the rolled back migrations again.`,
+		Annotations: map[string]string{skipAutoMigrateAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateMigrateCmd(opts, "migrate down", args); err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			version, err := strconv.ParseInt(strings.TrimSpace(toRaw), 10, 64)
+			if err != nil {
+				return printMigrateError(cmd, outputFormat(opts), domain.ErrInvalidMigrationVersion)
+			}
+
+			run, err := sqlitestore.MigrateDownTo(cmd.Context(), opts.db, opts.MigrationsDir, version)
+			if err != nil {
+				return printMigrateError(cmd, outputFormat(opts), err)
+			}
+
+			warnings := []output.WarningPayload{}
+			if len(run.Versions) > 0 {
+				warnings = append(warnings, output.WarningPayload{
+					Code:    domain.WarningCodeMigrationsRolledBack,
+					Message: domain.MigrationsRolledBackWarningMessage,
+					Details: map[string]any{"versions": run.Versions},
+				})
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"migration": run}, warnings)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, migrateRunTables(run))
+		},
+	}
+
+	cmd.Flags().StringVar(&toRaw, "to", "", "Schema version to roll back to (0 removes every migration)")
+
+	return cmd
+}
+
+func validateMigrateCmd(opts *RootOptions, name string, args []string) error {
+	if len(args) != 0 {
+		return &migrateCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: name + " does not accept positional arguments",
+			Details: map[string]any{"args": args},
+		}
+	}
+	if opts == nil || opts.db == nil {
+		return &migrateCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+	return nil
+}
+
+func printMigrateError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *migrateCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	if errors.Is(err, domain.ErrInvalidMigrationVersion) {
+		env := output.NewErrorEnvelope("INVALID_ARGUMENT", "to must be a non-negative schema version", map[string]any{"field": "to"}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope("DB_ERROR", "database operation failed", map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type netWorthShowFlags struct {
+	dateRaw string
+}
+
+type netWorthHistoryFlags struct {
+	groupBy  string
+	fromRaw  string
+	toRaw    string
+	currency string
+}
+
+type netWorthCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *netWorthCLIError) Error() string {
+	if e == nil {
+		return "networth command error"
+	}
+	return e.Message
+}
+
+func NewNetWorthCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "networth",
+		Short: "Track net position across balances, savings, assets and debt",
+	}
+
+	cmd.AddCommand(
+		newNetWorthShowCmd(opts),
+		newNetWorthHistoryCmd(opts),
+	)
+
+	return cmd
+}
+
+func newNetWorthShowCmd(opts *RootOptions) *cobra.Command {
+	flags := &netWorthShowFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the net position per currency and record the day's snapshot",
+		Long: `Show the net position per currency: general balance (opening balances
+included), savings and asset holdings as assets, and the debt owed on cards
+and loans as liabilities. Card purchases already lower the balance when
+recorded, so the unpaid debt is counted back into cash until the card is
+paid.
+
+Each run records the position as today's snapshot, replacing one recorded
+earlier that day; networth history reads them. Balances are always current,
+so a --date other than today shows them without recording a snapshot.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printNetWorthError(cmd, opts.Output, &netWorthCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "networth show does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			today := displayNow(opts).Format("2006-01-02")
+			asOfDate := strings.TrimSpace(flags.dateRaw)
+			if asOfDate == "" {
+				asOfDate = today
+			}
+
+			svc, err := newNetWorthService(opts)
+			if err != nil {
+				return printNetWorthError(cmd, opts.Output, err)
+			}
+
+			netWorth, err := svc.Show(cmd.Context(), asOfDate, today)
+			if err != nil {
+				return printNetWorthError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(netWorth, nil), netWorthTables(netWorth))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.dateRaw, "date", "", "Snapshot date in YYYY-MM-DD (default today); only today is recorded")
+
+	return cmd
+}
+
+func newNetWorthHistoryCmd(opts *RootOptions) *cobra.Command {
+	flags := &netWorthHistoryFlags{groupBy: domain.ReportGroupingMonth}
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recorded net worth snapshots by day, week or month",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printNetWorthError(cmd, opts.Output, &netWorthCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "networth history does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newNetWorthService(opts)
+			if err != nil {
+				return printNetWorthError(cmd, opts.Output, err)
+			}
+
+			history, err := svc.History(cmd.Context(), service.NetWorthHistoryRequest{
+				Grouping:     flags.groupBy,
+				DateFrom:     flags.fromRaw,
+				DateTo:       flags.toRaw,
+				CurrencyCode: flags.currency,
+			})
+			if err != nil {
+				return printNetWorthError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"history": history,
+				"count":   len(history.Periods),
+			}, nil), netWorthHistoryTables(history))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.groupBy, "group-by", domain.ReportGroupingMonth, "Snapshot grouping: day|week|month")
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "First snapshot date in YYYY-MM-DD")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Last snapshot date in YYYY-MM-DD")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only include one currency (ISO code)")
+
+	return cmd
+}
+
+func newNetWorthService(opts *RootOptions) (*service.NetWorthService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &netWorthCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	savingsSvc, err := newSavingsService(opts)
+	if err != nil {
+		return nil, err
+	}
+	cardSvc, err := service.NewCardService(sqlitestore.NewCardRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("card service init: %w", err)
+	}
+
+	svc, err := service.NewNetWorthService(
+		savingsSvc,
+		cardSvc,
+		sqlitestore.NewNetWorthRepo(opts.db),
+		service.WithNetWorthAccounts(sqlitestore.NewEntryRepo(opts.db), sqlitestore.NewBankAccountRepo(opts.db)),
+		service.WithNetWorthAssets(sqlitestore.NewAssetRepo(opts.db)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("net worth service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printNetWorthError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *netWorthCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromNetWorthError(err), messageFromNetWorthError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromNetWorthError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "INVALID_DATE_RANGE"
+	case errors.Is(err, domain.ErrInvalidTransactionDate),
+		errors.Is(err, domain.ErrInvalidReportGrouping):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromNetWorthError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "from must be less than or equal to to"
+	case errors.Is(err, domain.ErrInvalidTransactionDate):
+		return "date must use YYYY-MM-DD"
+	case errors.Is(err, domain.ErrInvalidReportGrouping):
+		return "group-by must be one of: day|week|month"
+	default:
+		return "database operation failed"
+	}
+}
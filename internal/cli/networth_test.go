@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+)
+
+func TestNetWorthShowCountsCardDebtAndRecordsHistory(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "income", "--amount", "1000.00", "--currency", "USD", "--date", "2026-01-01", "--note", "Opening balance",
+	}))
+	card := mustMap(t, mustMap(t, executeCardCmdJSON(t, db, []string{"add", "--nickname", "Visa", "--last4", "4242", "--brand", "visa", "--card-type", "credit", "--due-day", "15"})["data"])["card"])
+	cardID := strconv.FormatInt(int64(card["id"].(float64)), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "200.00", "--currency", "USD", "--date", "2026-01-05",
+		"--payment-method", "card", "--card-id", cardID,
+	}))
+
+	shown := executeNetWorthCmdJSON(t, db, []string{"show"})
+	assertSuccessJSONEnvelope(t, shown)
+	if recorded, _ := mustMap(t, shown["data"])["recorded"].(bool); !recorded {
+		t.Fatalf("expected today's show to be recorded, got %v", shown)
+	}
+	positions := mustAnySlice(t, mustMap(t, shown["data"])["by_currency"])
+	if len(positions) != 1 {
+		t.Fatalf("expected one currency position, got %v", positions)
+	}
+	usd := mustMap(t, positions[0])
+	if usd["assets_minor"].(float64) != 100000 || usd["liabilities_minor"].(float64) != 20000 || usd["net_worth_minor"].(float64) != 80000 {
+		t.Fatalf("unexpected USD position: %v", usd)
+	}
+
+	assertSuccessJSONEnvelope(t, executeCardCmdJSON(t, db, []string{"payment", "add", "--card-id", cardID, "--amount", "200.00", "--currency", "USD"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "50.00", "--currency", "USD", "--date", "2026-02-10",
+	}))
+	assertSuccessJSONEnvelope(t, executeNetWorthCmdJSON(t, db, []string{"show"}))
+
+	history := executeNetWorthCmdJSON(t, db, []string{"history", "--group-by", "month"})
+	assertSuccessJSONEnvelope(t, history)
+	periods := mustAnySlice(t, mustMap(t, mustMap(t, history["data"])["history"])["periods"])
+	if len(periods) != 1 {
+		t.Fatalf("expected today's snapshot to be replaced, got %v", periods)
+	}
+	current := mustMap(t, periods[0])
+	if current["liabilities_minor"].(float64) != 0 || current["net_worth_minor"].(float64) != 75000 {
+		t.Fatalf("unexpected current period: %v", current)
+	}
+
+	invalid := executeNetWorthCmdJSON(t, db, []string{"history", "--group-by", "weekday"})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for weekday grouping, got %v", invalid)
+	}
+}
+
+func TestNetWorthShowBackDatedDoesNotOverwriteSnapshot(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := sqlitestore.NewNetWorthRepo(db).SaveSnapshots(context.Background(), "2025-01-01", []domain.NetWorthPosition{
+		{CurrencyCode: "USD", AssetsMinor: 12300, NetWorthMinor: 12300},
+	}); err != nil {
+		t.Fatalf("seed net worth snapshot: %v", err)
+	}
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "income", "--amount", "1000.00", "--currency", "USD", "--date", "2026-01-01",
+	}))
+
+	shown := executeNetWorthCmdJSON(t, db, []string{"show", "--date", "2025-01-01"})
+	assertSuccessJSONEnvelope(t, shown)
+	if recorded, _ := mustMap(t, shown["data"])["recorded"].(bool); recorded {
+		t.Fatalf("expected a back-dated show not to be recorded, got %v", shown)
+	}
+
+	history := executeNetWorthCmdJSON(t, db, []string{"history", "--group-by", "day", "--to", "2025-01-01"})
+	assertSuccessJSONEnvelope(t, history)
+	periods := mustAnySlice(t, mustMap(t, mustMap(t, history["data"])["history"])["periods"])
+	if len(periods) != 1 || mustMap(t, periods[0])["net_worth_minor"].(float64) != 12300 {
+		t.Fatalf("expected the 2025-01-01 snapshot to keep its position, got %v", periods)
+	}
+}
+
+func executeNetWorthCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewNetWorthCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute networth cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal networth payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -0,0 +1,312 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type opsListFlags struct {
+	status string
+}
+
+type opsCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *opsCLIError) Error() string {
+	if e == nil {
+		return "ops command error"
+	}
+	return e.Message
+}
+
+// dataMirrorOperationParams and entryTriageOperationParams are the journaled
+// inputs that ops resume replays.
+type dataMirrorOperationParams struct {
+	Dir string `json:"dir"`
+}
+
+type entryTriageOperationParams struct {
+	Assignments []entryTriageAssignment `json:"assignments"`
+}
+
+func NewOpsCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ops",
+		Short: "List, resume and cancel journaled long-running operations",
+		Long: `Long-running tasks (data mirror, entry triage assignments) record their
+progress in an operations journal. An operation interrupted by a crash or
+Ctrl-C stays running or failed and can be resumed from its last checkpoint.`,
+	}
+
+	cmd.AddCommand(
+		newOpsListCmd(opts),
+		newOpsResumeCmd(opts),
+		newOpsCancelCmd(opts),
+	)
+
+	return cmd
+}
+
+func newOpsListCmd(opts *RootOptions) *cobra.Command {
+	flags := &opsListFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent operations, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printOpsError(cmd, outputFormat(opts), &opsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "ops list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newOperationService(opts)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			operations, err := svc.List(cmd.Context(), flags.status)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"operations": operations, "count": len(operations)}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.status, "status", "", "Filter by status: running|completed|failed|cancelled")
+
+	return cmd
+}
+
+func newOpsResumeCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Resume a running or failed operation from its last checkpoint",
+		Long: `Resume a running or failed operation from its last checkpoint.
+
+Only resume a running operation after the process that started it has
+stopped; the journal cannot tell a live process from a killed one.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseOperationIDArg(args, "ops resume")
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			svc, err := newOperationService(opts)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			run, err := svc.Resume(cmd.Context(), id)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			var result any
+			switch run.Operation().Kind {
+			case domain.OperationKindDataMirror:
+				result, err = runDataMirrorOperation(cmd.Context(), opts, run)
+			case domain.OperationKindEntryTriage:
+				var assigned []entryTriageAssignment
+				assigned, err = runEntryTriageOperation(cmd.Context(), opts, run)
+				result = map[string]any{"assigned": assigned}
+			default:
+				_, finishErr := run.Finish(cmd.Context(), domain.ErrUnknownOperationKind)
+				err = errors.Join(domain.ErrUnknownOperationKind, finishErr)
+			}
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"operation": run.Operation(), "result": result}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newOpsCancelCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a running or failed operation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseOperationIDArg(args, "ops cancel")
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			svc, err := newOperationService(opts)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			operation, err := svc.Cancel(cmd.Context(), id)
+			if err != nil {
+				return printOpsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"operation": operation}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+// runDataMirrorOperation mirrors into the journaled directory, skipping months
+// already written by an earlier attempt, and records the outcome.
+func runDataMirrorOperation(ctx context.Context, opts *RootOptions, run *service.OperationRun) (service.PortabilityMirrorResult, error) {
+	var params dataMirrorOperationParams
+	if err := json.Unmarshal(run.Operation().Params, &params); err != nil {
+		_, _ = run.Finish(ctx, err)
+		return service.PortabilityMirrorResult{}, fmt.Errorf("decode mirror operation params: %w", err)
+	}
+
+	portabilitySvc, err := newPortabilityService(opts)
+	if err != nil {
+		_, _ = run.Finish(ctx, err)
+		return service.PortabilityMirrorResult{}, err
+	}
+
+	result, err := portabilitySvc.Mirror(ctx, params.Dir, run)
+	if _, finishErr := run.Finish(ctx, err); err == nil {
+		err = finishErr
+	}
+	result.OperationID = run.Operation().ID
+	return result, err
+}
+
+// runEntryTriageOperation applies the journaled category assignments that
+// were not applied yet and returns the ones applied by this run.
+func runEntryTriageOperation(ctx context.Context, opts *RootOptions, run *service.OperationRun) ([]entryTriageAssignment, error) {
+	applied := []entryTriageAssignment{}
+
+	var params entryTriageOperationParams
+	if err := json.Unmarshal(run.Operation().Params, &params); err != nil {
+		_, _ = run.Finish(ctx, err)
+		return applied, fmt.Errorf("decode triage operation params: %w", err)
+	}
+
+	svc, err := newEntryService(opts)
+	if err != nil {
+		_, _ = run.Finish(ctx, err)
+		return applied, err
+	}
+
+	total := int64(len(params.Assignments))
+	for i := run.Done(); i < total && err == nil; i++ {
+		assignment := params.Assignments[i]
+		categoryID := assignment.CategoryID
+		if _, err = svc.Update(ctx, domain.EntryUpdateInput{
+			ID:          assignment.EntryID,
+			SetCategory: true,
+			CategoryID:  &categoryID,
+		}); err != nil {
+			break
+		}
+		applied = append(applied, assignment)
+		err = run.Advance(ctx, domain.OperationProgress{
+			Total:      total,
+			Done:       i + 1,
+			Checkpoint: fmt.Sprint(assignment.EntryID),
+		})
+	}
+
+	if _, finishErr := run.Finish(ctx, err); err == nil {
+		err = finishErr
+	}
+	return applied, err
+}
+
+func parseOperationIDArg(args []string, command string) (int64, error) {
+	if len(args) != 1 {
+		return 0, &opsCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: command + " requires exactly one operation id",
+			Details: map[string]any{"args": args},
+		}
+	}
+	id, err := parsePositiveInt64(args[0], "operation id")
+	if err != nil {
+		return 0, &opsCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "operation id must be a positive integer",
+			Details: map[string]any{"field": "id", "value": args[0]},
+		}
+	}
+	return id, nil
+}
+
+func newOperationService(opts *RootOptions) (*service.OperationService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &opsCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewOperationService(sqlitestore.NewOperationRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("operation service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printOpsError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *opsCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	if code, message, ok := operationErrorCode(err); ok {
+		env := output.NewErrorEnvelope(code, message, map[string]any{"reason": err.Error()}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	// Resumed work fails with the same errors as the command that started it.
+	return printReportError(cmd, format, err)
+}
+
+// operationErrorCode maps journal errors; other commands that run journaled
+// work use it before their own error mapping.
+func operationErrorCode(err error) (string, string, bool) {
+	switch {
+	case errors.Is(err, domain.ErrOperationNotFound):
+		return "NOT_FOUND", "operation not found", true
+	case errors.Is(err, domain.ErrOperationCancelled):
+		return "CONFLICT", "operation was cancelled", true
+	case errors.Is(err, domain.ErrOperationNotResumable):
+		return "CONFLICT", "only running or failed operations can be resumed", true
+	case errors.Is(err, domain.ErrOperationNotCancelable):
+		return "CONFLICT", "only running or failed operations can be cancelled", true
+	case errors.Is(err, domain.ErrInvalidOperationStatus):
+		return "INVALID_ARGUMENT", "status must be one of running|completed|failed|cancelled", true
+	case errors.Is(err, domain.ErrUnknownOperationKind):
+		return "CONFLICT", "operation kind cannot be resumed by this version", true
+	default:
+		return "", "", false
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+)
+
+func TestOpsResumeFinishesInterruptedTriage(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Groceries")
+	assignments := []entryTriageAssignment{}
+	for _, amount := range []string{"10.00", "20.00", "30.00"} {
+		added := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", amount, "--currency", "USD", "--date", "2026-03-02"})
+		mustEntrySuccess(t, added)
+		entryID := int64(mustMap(t, mustMap(t, added["data"])["entry"])["id"].(float64))
+		assignments = append(assignments, entryTriageAssignment{EntryID: entryID, CategoryID: categoryID, CategoryName: "Groceries"})
+	}
+
+	// Simulate a triage run that applied one assignment and then failed.
+	ctx := context.Background()
+	svc, err := newOperationService(&RootOptions{db: db})
+	if err != nil {
+		t.Fatalf("operation service: %v", err)
+	}
+	run, err := svc.Start(ctx, domain.OperationKindEntryTriage, entryTriageOperationParams{Assignments: assignments})
+	if err != nil {
+		t.Fatalf("start operation: %v", err)
+	}
+	if err := run.Advance(ctx, domain.OperationProgress{Total: 3, Done: 1, Checkpoint: fmt.Sprint(assignments[0].EntryID)}); err != nil {
+		t.Fatalf("advance operation: %v", err)
+	}
+	if _, err := run.Finish(ctx, errors.New("interrupted")); err != nil {
+		t.Fatalf("finish operation: %v", err)
+	}
+	operationID := fmt.Sprint(run.Operation().ID)
+
+	failed := mustAnySlice(t, mustMap(t, executeOpsCmdJSON(t, db, []string{"list", "--status", "failed"})["data"])["operations"])
+	if len(failed) != 1 || mustMap(t, failed[0])["last_error"] != "interrupted" {
+		t.Fatalf("expected one failed operation, got %v", failed)
+	}
+
+	resumed := executeOpsCmdJSON(t, db, []string{"resume", operationID})
+	assertSuccessJSONEnvelope(t, resumed)
+	data := mustMap(t, resumed["data"])
+	if operation := mustMap(t, data["operation"]); operation["status"] != "completed" || operation["done"].(float64) != 3 {
+		t.Fatalf("expected completed operation with 3 done, got %v", operation)
+	}
+	if applied := mustAnySlice(t, mustMap(t, data["result"])["assigned"]); len(applied) != 2 {
+		t.Fatalf("expected resume to apply the remaining two assignments, got %v", applied)
+	}
+
+	for _, args := range [][]string{{"resume", operationID}, {"cancel", operationID}} {
+		if code := mustMap(t, executeOpsCmdJSON(t, db, args)["error"])["code"]; code != "CONFLICT" {
+			t.Fatalf("expected CONFLICT for %v on a completed operation, got %v", args, code)
+		}
+	}
+	if code := mustMap(t, executeOpsCmdJSON(t, db, []string{"cancel", "999"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for a missing operation, got %v", code)
+	}
+}
+
+func executeOpsCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewOpsCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute ops cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal ops payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+var colorEnabled atomic.Bool
+
+func init() {
+	colorEnabled.Store(true)
+}
+
+// SetColorEnabled toggles ANSI colors in human output. Colors are only ever
+// written to terminals, so pipes and files stay plain regardless.
+func SetColorEnabled(enabled bool) {
+	colorEnabled.Store(enabled)
+}
+
+func ColorEnabled() bool {
+	return colorEnabled.Load()
+}
+
+func colorize(w io.Writer, color, text string) string {
+	if !ColorEnabled() || !IsTerminal(w) {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// IsTerminal reports whether w is a character device such as a terminal.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
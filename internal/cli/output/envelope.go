@@ -31,6 +31,8 @@ type ErrorPayload struct {
 type Meta struct {
 	APIVersion   string `json:"api_version"`
 	TimestampUTC string `json:"timestamp_utc"`
+	// Aggregates summarizes list results for commands that set it.
+	Aggregates any `json:"aggregates,omitempty"`
 }
 
 func NewSuccessEnvelope(data any, warnings []WarningPayload) Envelope {
@@ -34,10 +34,12 @@ func ExitCodeForErrorCode(errorCode string) int {
 		return 4
 	case "DB_ERROR":
 		return 5
-	case "FX_RATE_UNAVAILABLE":
+	case "FX_RATE_UNAVAILABLE", "BOT_PROVIDER_UNAVAILABLE":
 		return 6
-	case "CONFIG_ERROR":
+	case "CONFIG_ERROR", "UPGRADE_REQUIRED":
 		return 7
+	case ErrorCodeStrictWarning:
+		return 8
 	case "INTERNAL_ERROR":
 		return 1
 	default:
@@ -15,6 +15,8 @@ func TestExitCodeForErrorCode(t *testing.T) {
 		{code: "DB_ERROR", exit: 5},
 		{code: "FX_RATE_UNAVAILABLE", exit: 6},
 		{code: "CONFIG_ERROR", exit: 7},
+		{code: "UPGRADE_REQUIRED", exit: 7},
+		{code: "STRICT_WARNING", exit: 8},
 		{code: "INTERNAL_ERROR", exit: 1},
 		{code: "UNKNOWN", exit: 1},
 	}
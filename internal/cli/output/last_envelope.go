@@ -0,0 +1,24 @@
+package output
+
+import "sync/atomic"
+
+var lastEnvelope atomic.Pointer[Envelope]
+
+// LastEnvelope returns the envelope most recently printed by Print or
+// PrintTables, after strict-warning escalation, so post-run hooks can react
+// to what the command reported.
+func LastEnvelope() (Envelope, bool) {
+	envelope := lastEnvelope.Load()
+	if envelope == nil {
+		return Envelope{}, false
+	}
+	return *envelope, true
+}
+
+func ResetLastEnvelope() {
+	lastEnvelope.Store(nil)
+}
+
+func recordEnvelope(envelope Envelope) {
+	lastEnvelope.Store(&envelope)
+}
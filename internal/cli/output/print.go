@@ -18,7 +18,9 @@ func IsValidFormat(value string) bool {
 }
 
 func Print(w io.Writer, format string, envelope Envelope) error {
+	envelope = escalateStrictWarnings(envelope)
 	SetProcessExitCodeFromEnvelope(envelope)
+	recordEnvelope(envelope)
 
 	switch strings.ToLower(strings.TrimSpace(format)) {
 	case FormatJSON:
@@ -38,25 +40,13 @@ func Print(w io.Writer, format string, envelope Envelope) error {
 }
 
 func printHuman(w io.Writer, envelope Envelope) error {
-	status := "OK"
-	if !envelope.Ok {
-		status = "ERROR"
-	}
-
-	if _, err := fmt.Fprintf(w, "[%s] boring-budget\n", status); err != nil {
-		return err
-	}
-
-	if !envelope.Ok && envelope.Error != nil {
-		if _, err := fmt.Fprintf(w, "%s: %s\n", envelope.Error.Code, envelope.Error.Message); err != nil {
-			return err
-		}
-	}
-
-	for _, warning := range envelope.Warnings {
-		if _, err := fmt.Fprintf(w, "warning[%s]: %s\n", warning.Code, warning.Message); err != nil {
+	quiet := Quiet() && envelope.Ok
+	if quiet {
+		if err := printHumanWarnings(w, envelope); err != nil {
 			return err
 		}
+	} else if err := printHumanHeader(w, envelope); err != nil {
+		return err
 	}
 
 	if envelope.Data != nil {
@@ -70,13 +60,46 @@ func printHuman(w io.Writer, envelope Envelope) error {
 		}
 	}
 
-	if _, err := fmt.Fprintf(w, "api=%s timestamp_utc=%s\n", envelope.Meta.APIVersion, envelope.Meta.TimestampUTC); err != nil {
+	if quiet {
+		return nil
+	}
+	return printHumanFooter(w, envelope)
+}
+
+func printHumanHeader(w io.Writer, envelope Envelope) error {
+	status := colorize(w, ansiGreen, "OK")
+	if !envelope.Ok {
+		status = colorize(w, ansiRed, "ERROR")
+	}
+
+	if _, err := fmt.Fprintf(w, "[%s] boring-budget\n", status); err != nil {
 		return err
 	}
 
+	if !envelope.Ok && envelope.Error != nil {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", colorize(w, ansiRed, envelope.Error.Code), envelope.Error.Message); err != nil {
+			return err
+		}
+	}
+
+	return printHumanWarnings(w, envelope)
+}
+
+func printHumanWarnings(w io.Writer, envelope Envelope) error {
+	for _, warning := range envelope.Warnings {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", colorize(w, ansiYellow, "warning["+warning.Code+"]"), warning.Message); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func printHumanFooter(w io.Writer, envelope Envelope) error {
+	_, err := fmt.Fprintf(w, "api=%s timestamp_utc=%s\n", envelope.Meta.APIVersion, envelope.Meta.TimestampUTC)
+	return err
+}
+
 func localizeDataForHuman(data any) any {
 	displayTZ := CurrentDisplayTimezone()
 	if strings.EqualFold(displayTZ, "UTC") {
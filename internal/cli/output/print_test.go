@@ -53,3 +53,81 @@ func TestPrintJSONKeepsUTCTimestamps(t *testing.T) {
 		t.Fatalf("expected JSON output to keep UTC timestamp, got %s", output)
 	}
 }
+
+func TestPrintHumanQuietKeepsWarningsAndData(t *testing.T) {
+	SetQuiet(true)
+	t.Cleanup(func() {
+		SetQuiet(false)
+	})
+
+	env := NewSuccessEnvelope(map[string]any{"note": "kept"}, []WarningPayload{{Code: "CAP_EXCEEDED", Message: "over"}})
+
+	var out bytes.Buffer
+	if err := Print(&out, FormatHuman, env); err != nil {
+		t.Fatalf("print quiet human: %v", err)
+	}
+
+	output := out.String()
+	if strings.Contains(output, "[OK]") || strings.Contains(output, "api=") {
+		t.Fatalf("expected quiet output without status or footer, got %s", output)
+	}
+	if !strings.Contains(output, "warning[CAP_EXCEEDED]") || !strings.Contains(output, "kept") {
+		t.Fatalf("expected quiet output to keep warnings and data, got %s", output)
+	}
+
+	out.Reset()
+	if err := Print(&out, FormatHuman, NewErrorEnvelope("NOT_FOUND", "missing", nil, nil)); err != nil {
+		t.Fatalf("print quiet error: %v", err)
+	}
+	if !strings.Contains(out.String(), "[ERROR]") {
+		t.Fatalf("expected quiet errors to print in full, got %s", out.String())
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		major    string
+		currency string
+		want     string
+	}{
+		{major: "1234567.89", currency: "USD", want: "$1,234,567.89"},
+		{major: "-0.99", currency: "EUR", want: "-€0.99"},
+		{major: "500", currency: "JPY", want: "¥500"},
+		{major: "1234.500", currency: "BHD", want: "1,234.500 BHD"},
+	}
+
+	for _, tc := range cases {
+		if got := FormatMoney(tc.major, tc.currency); got != tc.want {
+			t.Fatalf("FormatMoney(%q, %q): expected %q, got %q", tc.major, tc.currency, tc.want, got)
+		}
+	}
+}
+
+func TestPrintTablesKeepsJSONEnvelope(t *testing.T) {
+	t.Parallel()
+
+	env := NewSuccessEnvelope(map[string]any{"count": 1}, nil)
+	tables := []Table{{
+		Title:   "Rows",
+		Columns: []TableColumn{{Header: "Name"}, {Header: "Amount", AlignRight: true}},
+		Rows:    [][]string{{"coffee", "$3.50"}, {"rent", "$1,200.00"}},
+	}}
+
+	var jsonOut bytes.Buffer
+	if err := PrintTables(&jsonOut, FormatJSON, env, tables); err != nil {
+		t.Fatalf("print json: %v", err)
+	}
+	if strings.Contains(jsonOut.String(), "coffee") || !strings.Contains(jsonOut.String(), "\"count\": 1") {
+		t.Fatalf("expected JSON output to ignore tables, got %s", jsonOut.String())
+	}
+
+	var humanOut bytes.Buffer
+	if err := PrintTables(&humanOut, FormatHuman, env, tables); err != nil {
+		t.Fatalf("print human: %v", err)
+	}
+	if !strings.Contains(humanOut.String(), "coffee      $3.50\nrent    $1,200.00") {
+		t.Fatalf("expected aligned table rows, got %q", humanOut.String())
+	}
+}
@@ -0,0 +1,15 @@
+package output
+
+import "sync/atomic"
+
+var quietHuman atomic.Bool
+
+// SetQuiet drops the status line and footer from successful human output,
+// leaving warnings and data. Errors and JSON envelopes are printed in full.
+func SetQuiet(quiet bool) {
+	quietHuman.Store(quiet)
+}
+
+func Quiet() bool {
+	return quietHuman.Load()
+}
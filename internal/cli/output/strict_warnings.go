@@ -0,0 +1,66 @@
+package output
+
+import "sync/atomic"
+
+// ErrorCodeStrictWarning marks a result refused because it raised a warning
+// the strict-warnings policy escalates.
+const ErrorCodeStrictWarning = "STRICT_WARNING"
+
+var strictWarningCodes atomic.Value
+
+func init() {
+	strictWarningCodes.Store([]string{})
+}
+
+// SetStrictWarnings sets the warning codes that turn an otherwise successful
+// envelope into a STRICT_WARNING error.
+func SetStrictWarnings(codes []string) {
+	if codes == nil {
+		codes = []string{}
+	}
+	strictWarningCodes.Store(append([]string(nil), codes...))
+}
+
+func CurrentStrictWarnings() []string {
+	codes, _ := strictWarningCodes.Load().([]string)
+	return codes
+}
+
+// NewStrictWarningEnvelope reports the escalated warnings as a failure while
+// keeping every warning in warnings[].
+func NewStrictWarningEnvelope(escalated, warnings []WarningPayload) Envelope {
+	codes := make([]string, 0, len(escalated))
+	for _, warning := range escalated {
+		codes = append(codes, warning.Code)
+	}
+	return NewErrorEnvelope(
+		ErrorCodeStrictWarning,
+		"warning escalated by strict warnings policy",
+		map[string]any{"warning_codes": codes},
+		warnings,
+	)
+}
+
+func escalateStrictWarnings(envelope Envelope) Envelope {
+	if !envelope.Ok {
+		return envelope
+	}
+	codes := CurrentStrictWarnings()
+	if len(codes) == 0 {
+		return envelope
+	}
+
+	escalated := []WarningPayload{}
+	for _, warning := range envelope.Warnings {
+		for _, code := range codes {
+			if warning.Code == code {
+				escalated = append(escalated, warning)
+				break
+			}
+		}
+	}
+	if len(escalated) == 0 {
+		return envelope
+	}
+	return NewStrictWarningEnvelope(escalated, envelope.Warnings)
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+	"JPY": "¥",
+	"KRW": "₩",
+	"USD": "$",
+}
+
+type TableColumn struct {
+	Header     string
+	AlignRight bool
+}
+
+// Table is a human-only rendering of envelope data. JSON output never uses it.
+type Table struct {
+	Title   string
+	Columns []TableColumn
+	Rows    [][]string
+}
+
+// PrintTables prints the envelope like Print, except that human output renders
+// the given tables instead of the raw data dump.
+func PrintTables(w io.Writer, format string, envelope Envelope, tables []Table) error {
+	envelope = escalateStrictWarnings(envelope)
+	if strings.ToLower(strings.TrimSpace(format)) != FormatHuman || !envelope.Ok {
+		return Print(w, format, envelope)
+	}
+
+	SetProcessExitCodeFromEnvelope(envelope)
+	recordEnvelope(envelope)
+
+	if Quiet() {
+		if err := printHumanWarnings(w, envelope); err != nil {
+			return err
+		}
+	} else if err := printHumanHeader(w, envelope); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := writeTable(w, table); err != nil {
+			return err
+		}
+	}
+	if Quiet() {
+		return nil
+	}
+	return printHumanFooter(w, envelope)
+}
+
+// FormatMoney renders a major-unit amount string with thousands grouping and a
+// currency symbol when one is unambiguous, falling back to a code suffix.
+func FormatMoney(amountMajor, currencyCode string) string {
+	value := strings.TrimSpace(amountMajor)
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+
+	integerPart, fractionalPart, hasFraction := strings.Cut(value, ".")
+	grouped := groupThousands(integerPart)
+	if hasFraction {
+		grouped += "." + fractionalPart
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(currencyCode))
+	if symbol, ok := currencySymbols[code]; ok {
+		return sign + symbol + grouped
+	}
+	return sign + grouped + " " + code
+}
+
+// FormatHumanDate renders a UTC timestamp as a date in the display timezone.
+func FormatHumanDate(valueUTC string) string {
+	location, err := time.LoadLocation(CurrentDisplayTimezone())
+	if err != nil {
+		location = time.UTC
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+		parsed, err := time.Parse(layout, valueUTC)
+		if err == nil {
+			return parsed.In(location).Format("2006-01-02")
+		}
+	}
+	return valueUTC
+}
+
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var builder strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		builder.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if builder.Len() > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(digits[i : i+3])
+	}
+	return builder.String()
+}
+
+func writeTable(w io.Writer, table Table) error {
+	if strings.TrimSpace(table.Title) != "" {
+		if _, err := fmt.Fprintf(w, "\n%s\n", table.Title); err != nil {
+			return err
+		}
+	}
+
+	widths := make([]int, len(table.Columns))
+	for i, column := range table.Columns {
+		widths[i] = utf8.RuneCountInString(column.Header)
+	}
+	for _, row := range table.Rows {
+		for i := 0; i < len(row) && i < len(widths); i++ {
+			if width := utf8.RuneCountInString(row[i]); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	headers := make([]string, len(table.Columns))
+	separators := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		headers[i] = column.Header
+		separators[i] = strings.Repeat("-", widths[i])
+	}
+	if err := writeTableRow(w, table.Columns, widths, headers); err != nil {
+		return err
+	}
+	if err := writeTableRow(w, table.Columns, widths, separators); err != nil {
+		return err
+	}
+
+	if len(table.Rows) == 0 {
+		_, err := fmt.Fprintln(w, "(none)")
+		return err
+	}
+	for _, row := range table.Rows {
+		if err := writeTableRow(w, table.Columns, widths, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTableRow(w io.Writer, columns []TableColumn, widths []int, cells []string) error {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if column.AlignRight {
+			parts[i] = padding + cell
+		} else {
+			parts[i] = cell + padding
+		}
+	}
+
+	_, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(parts, "  "), " "))
+	return err
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"boring-budget/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type payeeListFlags struct {
+	entryType     string
+	categoryIDRaw string
+	fromRaw       string
+	toRaw         string
+}
+
+func NewPayeeCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "payee",
+		Short: "Inspect payees recorded on entries",
+	}
+
+	cmd.AddCommand(newPayeeListCmd(opts))
+	return cmd
+}
+
+func newPayeeListCmd(opts *RootOptions) *cobra.Command {
+	flags := &payeeListFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List payees with aggregated spend per currency",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "payee list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			filter, err := buildEntryListFilter(&entryListFlags{
+				entryType:     flags.entryType,
+				categoryIDRaw: flags.categoryIDRaw,
+				fromRaw:       flags.fromRaw,
+				toRaw:         flags.toRaw,
+			})
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			payees, err := svc.ListPayees(cmd.Context(), filter)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"payees": payees,
+				"count":  len(payees),
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, payeeListTables(payees))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.entryType, "type", "", "Only count entries of this type: income|expense")
+	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Only count entries in this category ID")
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Start date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "End date (RFC3339 or YYYY-MM-DD)")
+
+	return cmd
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// progressInterval is the least time between two progress updates; the
+// final update is always written.
+const progressInterval = 500 * time.Millisecond
+
+const progressFlagUsage = "Progress on stderr: auto (a status line when stderr is a terminal)|json (one NDJSON event per update)|off"
+
+// progressReporter writes throttled progress updates to stderr, either as a
+// status line rewritten in place or as NDJSON events for wrapping UIs.
+type progressReporter struct {
+	w       io.Writer
+	json    bool
+	started time.Time
+	emitted time.Time
+	latest  domain.Progress
+	pending bool
+	now     func() time.Time
+}
+
+type progressEvent struct {
+	Event      string   `json:"event"`
+	Operation  string   `json:"operation"`
+	Unit       string   `json:"unit"`
+	Done       int64    `json:"done"`
+	Total      int64    `json:"total,omitempty"`
+	BytesDone  int64    `json:"bytes_done,omitempty"`
+	BytesTotal int64    `json:"bytes_total,omitempty"`
+	Percent    *float64 `json:"percent,omitempty"`
+	ETASeconds *int64   `json:"eta_seconds,omitempty"`
+	ElapsedMS  int64    `json:"elapsed_ms"`
+	Finished   bool     `json:"finished"`
+}
+
+// newProgressReporter returns nil when mode turns progress off, or when it is
+// auto and stderr is not a terminal or --quiet is set.
+func newProgressReporter(cmd *cobra.Command, opts *RootOptions, mode string) *progressReporter {
+	w := cmd.ErrOrStderr()
+	switch mode {
+	case domain.ProgressModeJSON:
+	case domain.ProgressModeAuto:
+		if (opts != nil && opts.Quiet) || !output.IsTerminal(w) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	now := time.Now
+	return &progressReporter{w: w, json: mode == domain.ProgressModeJSON, started: now(), now: now}
+}
+
+// Report records progress and writes it once progressInterval has passed
+// since the previous update.
+func (r *progressReporter) Report(progress domain.Progress) {
+	slog.Debug("progress", "operation", progress.Operation, "unit", progress.Unit, "done", progress.Done, "total", progress.Total)
+	r.latest = progress
+	r.pending = true
+	if !r.emitted.IsZero() && r.now().Sub(r.emitted) < progressInterval {
+		return
+	}
+	r.write(false)
+}
+
+// ReportBackup adapts online backup page counts to Report.
+func (r *progressReporter) ReportBackup(progress domain.BackupProgress) {
+	r.Report(domain.Progress{
+		Operation: "backup",
+		Unit:      domain.ProgressUnitPages,
+		Done:      progress.PagesCopied,
+		Total:     progress.PagesTotal,
+	})
+}
+
+// Finish writes the latest progress as the final update and ends the status
+// line. It does nothing when nothing was reported.
+func (r *progressReporter) Finish() {
+	if r == nil || (!r.pending && r.emitted.IsZero()) {
+		return
+	}
+	r.write(true)
+	if !r.json {
+		fmt.Fprintln(r.w)
+	}
+}
+
+func (r *progressReporter) write(finished bool) {
+	now := r.now()
+	elapsed := now.Sub(r.started)
+	r.emitted = now
+	r.pending = false
+
+	fraction, known := r.latest.Fraction()
+	var eta time.Duration
+	etaKnown := false
+	if !finished {
+		eta, etaKnown = r.latest.ETA(elapsed)
+	}
+
+	if r.json {
+		event := progressEvent{
+			Event:      "progress",
+			Operation:  r.latest.Operation,
+			Unit:       r.latest.Unit,
+			Done:       r.latest.Done,
+			Total:      r.latest.Total,
+			BytesDone:  r.latest.BytesDone,
+			BytesTotal: r.latest.BytesTotal,
+			ElapsedMS:  elapsed.Milliseconds(),
+			Finished:   finished,
+		}
+		if known {
+			percent := float64(int64(fraction*1000)) / 10
+			event.Percent = &percent
+		}
+		if etaKnown {
+			seconds := int64(eta / time.Second)
+			event.ETASeconds = &seconds
+		}
+		line, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(r.w, string(line))
+		return
+	}
+
+	var status strings.Builder
+	fmt.Fprintf(&status, "%s: %d", r.latest.Operation, r.latest.Done)
+	if r.latest.Total > 0 {
+		fmt.Fprintf(&status, "/%d", r.latest.Total)
+	}
+	fmt.Fprintf(&status, " %s", r.latest.Unit)
+	if known {
+		fmt.Fprintf(&status, " (%d%%", int64(fraction*100))
+		if etaKnown {
+			fmt.Fprintf(&status, ", ETA %s", eta)
+		}
+		status.WriteString(")")
+	}
+	// \r plus erase-line rewrites the status in place.
+	fmt.Fprintf(r.w, "\r\x1b[K%s", status.String())
+}
+
+func progressModeFlagError(value string) error {
+	return &reportCLIError{
+		Code:    "INVALID_ARGUMENT",
+		Message: "progress must be one of: auto|json|off",
+		Details: map[string]any{"field": "progress", "value": value},
+	}
+}
@@ -9,7 +9,6 @@ import (
 
 	"boring-budget/internal/cli/output"
 	"boring-budget/internal/domain"
-	"boring-budget/internal/fx"
 	"boring-budget/internal/service"
 	sqlitestore "boring-budget/internal/store/sqlite"
 	"github.com/spf13/cobra"
@@ -21,9 +20,11 @@ const (
 	reportScopeBimonthly = "bimonthly"
 	reportScopeQuarterly = "quarterly"
 
-	reportGroupByDay   = "day"
-	reportGroupByWeek  = "week"
-	reportGroupByMonth = "month"
+	reportGroupByDay        = "day"
+	reportGroupByWeek       = "week"
+	reportGroupByMonth      = "month"
+	reportGroupByWeekday    = "weekday"
+	reportGroupByDayOfMonth = "day-of-month"
 )
 
 type reportCommonFlags struct {
@@ -36,6 +37,17 @@ type reportCommonFlags struct {
 	cardIDRaw     string
 	cardNickname  string
 	cardLookup    string
+	payee         string
+	by            string
+	source        string
+	location      string
+	noteContains  string
+	currency      string
+	amountMin     string
+	amountMax     string
+	amountFormat  string
+	revalueAsOf   string
+	includeAssets bool
 }
 
 type reportRangeFlags struct {
@@ -80,6 +92,11 @@ func NewReportCmd(opts *RootOptions) *cobra.Command {
 		newReportMonthlyCmd(opts),
 		newReportBimonthlyCmd(opts),
 		newReportQuarterlyCmd(opts),
+		newReportCurrencyMixCmd(opts),
+		newReportFreezeCmd(opts),
+		newReportTaxCmd(opts),
+		newReportShowCmd(opts),
+		newReportScheduleCmd(opts),
 	)
 
 	return cmd
@@ -170,12 +187,52 @@ func newReportQuarterlyCmd(opts *RootOptions) *cobra.Command {
 	return cmd
 }
 
+func newReportCurrencyMixCmd(opts *RootOptions) *cobra.Command {
+	var monthRaw string
+
+	cmd := &cobra.Command{
+		Use:   "currency-mix",
+		Short: "Count a month's entries per currency and flag one-off codes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printReportError(cmd, reportOutputFormat(opts), &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report currency-mix does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			reportSvc, err := newReportService(opts)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			result, err := reportSvc.CurrencyMix(cmd.Context(), monthRaw)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			reportWarnings, err := toReportWarningPayloads(result.Warnings)
+			if err != nil {
+				return printReportError(cmd, reportOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(result.Report, reportWarnings)
+			return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&monthRaw, "month", "", "Target month in YYYY-MM")
+
+	return cmd
+}
+
 func bindReportCommonFlags(cmd *cobra.Command, flags *reportCommonFlags) {
 	if cmd == nil || flags == nil {
 		return
 	}
 
-	cmd.Flags().StringVar(&flags.groupBy, "group-by", reportGroupByMonth, "Grouping: day|week|month")
+	cmd.Flags().StringVar(&flags.groupBy, "group-by", reportGroupByMonth, "Grouping: day|week|month|weekday|day-of-month")
 	cmd.Flags().StringVar(&flags.categoryIDRaw, "category-id", "", "Filter by category ID")
 	cmd.Flags().StringArrayVar(&flags.labelIDRaw, "label-id", nil, "Filter by label ID (repeatable)")
 	cmd.Flags().StringVar(&flags.labelMode, "label-mode", "any", "Label filter mode: any|all|none")
@@ -184,6 +241,16 @@ func bindReportCommonFlags(cmd *cobra.Command, flags *reportCommonFlags) {
 	cmd.Flags().StringVar(&flags.cardIDRaw, "card-id", "", "Filter by card ID")
 	cmd.Flags().StringVar(&flags.cardNickname, "card-nickname", "", "Filter by exact card nickname")
 	cmd.Flags().StringVar(&flags.cardLookup, "card-lookup", "", "Filter by card lookup text")
+	cmd.Flags().StringVar(&flags.payee, "payee", "", "Filter by exact payee (case-insensitive)")
+	cmd.Flags().StringVar(&flags.by, "by", "", "Filter by who recorded the entry (case-insensitive)")
+	cmd.Flags().StringVar(&flags.source, "source", "", "Filter by income source (case-insensitive)")
+	cmd.Flags().StringVar(&flags.location, "location-contains", "", "Filter entries whose location contains this text (case-insensitive)")
+	cmd.Flags().StringVar(&flags.noteContains, "note-contains", "", "Filter entries whose note contains this text (case-insensitive)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Filter by currency code (ISO 4217)")
+	cmd.Flags().StringVar(&flags.amountMin, "amount-min", "", "Filter entries of at least this amount in major units (requires --currency)")
+	cmd.Flags().StringVar(&flags.amountMax, "amount-max", "", "Filter entries of at most this amount in major units (requires --currency)")
+	cmd.Flags().StringVar(&flags.revalueAsOf, "revalue-as-of", "", "Restate amounts in YYYY-MM-DD terms using imported inflation indexes")
+	cmd.Flags().BoolVar(&flags.includeAssets, "include-assets", false, "Add the asset registry (holdings and loans at current balances)")
 }
 
 func runReportCommand(cmd *cobra.Command, args []string, opts *RootOptions, flags reportCommonFlags, period reportPeriodInput) error {
@@ -200,6 +267,7 @@ func runReportCommand(cmd *cobra.Command, args []string, opts *RootOptions, flag
 		return printReportError(cmd, reportOutputFormat(opts), err)
 	}
 
+	flags.amountFormat = amountFormat(opts)
 	req, err := buildReportRequest(flags, period)
 	if err != nil {
 		return printReportError(cmd, reportOutputFormat(opts), err)
@@ -210,6 +278,12 @@ func runReportCommand(cmd *cobra.Command, args []string, opts *RootOptions, flag
 		return printReportError(cmd, reportOutputFormat(opts), err)
 	}
 
+	// Savings balances are nominal, so a revalued report keeps its own.
+	if result.Report.Revaluation == nil {
+		if generalBalance, ok := savingsGeneralBalance(cmd, opts); ok {
+			result.Report.GeneralBalance = generalBalance
+		}
+	}
 	reportData, err := toReportOutputData(result.Report)
 	if err != nil {
 		return printReportError(cmd, reportOutputFormat(opts), err)
@@ -218,23 +292,25 @@ func runReportCommand(cmd *cobra.Command, args []string, opts *RootOptions, flag
 	if err != nil {
 		return printReportError(cmd, reportOutputFormat(opts), err)
 	}
-	enhanceReportDataWithSavings(cmd, opts, reportData)
-	if links, err := loadBalanceLinks(cmd.Context(), opts); err == nil {
+	links, err := loadBalanceLinks(cmd.Context(), opts)
+	if err == nil {
 		reportData["linked_accounts"] = links
 	}
 
 	env := output.NewSuccessEnvelope(reportData, reportWarnings)
-	return output.Print(cmd.OutOrStdout(), reportOutputFormat(opts), env)
+	return output.PrintTables(cmd.OutOrStdout(), reportOutputFormat(opts), env, reportTables(result.Report, links))
 }
 
-func enhanceReportDataWithSavings(cmd *cobra.Command, opts *RootOptions, reportData map[string]any) {
-	if cmd == nil || opts == nil || reportData == nil {
-		return
+// savingsGeneralBalance returns the lifetime general funds, net of transfers
+// to savings, that the report shows as its general balance.
+func savingsGeneralBalance(cmd *cobra.Command, opts *RootOptions) (domain.ReportNet, bool) {
+	if cmd == nil || opts == nil {
+		return domain.ReportNet{}, false
 	}
 
 	svc, err := newSavingsService(opts)
 	if err != nil {
-		return
+		return domain.ReportNet{}, false
 	}
 
 	views, err := svc.Show(cmd.Context(), service.SavingsShowRequest{
@@ -242,21 +318,20 @@ func enhanceReportDataWithSavings(cmd *cobra.Command, opts *RootOptions, reportD
 		IncludeRange:    false,
 	})
 	if err != nil || views.Lifetime == nil {
-		return
+		return domain.ReportNet{}, false
 	}
 
-	byCurrency := make([]map[string]any, 0, len(views.Lifetime.ByCurrency))
+	byCurrency := make([]domain.CurrencyTotal, 0, len(views.Lifetime.ByCurrency))
 	for _, row := range views.Lifetime.ByCurrency {
-		major, err := domain.FormatMinorToMajorString(row.GeneralBalanceMinor, row.CurrencyCode)
-		if err != nil {
+		if _, err := domain.CurrencyMinorUnit(row.CurrencyCode); err != nil {
 			continue
 		}
-		byCurrency = append(byCurrency, map[string]any{
-			"currency_code": row.CurrencyCode,
-			"total_major":   major,
+		byCurrency = append(byCurrency, domain.CurrencyTotal{
+			CurrencyCode: row.CurrencyCode,
+			TotalMinor:   row.GeneralBalanceMinor,
 		})
 	}
-	reportData["general_balance"] = map[string]any{"by_currency": byCurrency}
+	return domain.ReportNet{ByCurrency: byCurrency}, true
 }
 
 func newReportService(opts *RootOptions) (*service.ReportService, error) {
@@ -291,6 +366,17 @@ func newReportService(opts *RootOptions) (*service.ReportService, error) {
 		service.WithReportSettingsReader(settingsRepo),
 		service.WithReportCategoryReader(categoryRepo),
 		service.WithReportCardDebtReader(cardSvc),
+		service.WithReportInflationIndexReader(sqlitestore.NewInflationRepo(opts.db)),
+		service.WithReportMonthTotals(entryRepo),
+	}
+	labelRepo, err := sqlitestore.NewLabelRepo(opts.db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+	reportOptions = append(reportOptions, service.WithReportLabelReader(labelRepo))
+	reportOptions = append(reportOptions, service.WithReportAssetLister(sqlitestore.NewAssetRepo(opts.db)))
+	if opts.reportCache {
+		reportOptions = append(reportOptions, service.WithReportCache(sqlitestore.NewReportCacheRepo(opts.db)))
 	}
 
 	reportSvc, err := service.NewReportService(entrySvc, capSvc, reportOptions...)
@@ -298,9 +384,7 @@ func newReportService(opts *RootOptions) (*service.ReportService, error) {
 		return nil, fmt.Errorf("report service init: %w", err)
 	}
 
-	fxRepo := sqlitestore.NewFXRepo(opts.db)
-	fxClient := fx.NewFrankfurterClient(nil)
-	converter, err := fx.NewConverter(fxClient, fxRepo)
+	converter, err := newFXConverter(opts)
 	if err == nil {
 		reportOptions = append(reportOptions, service.WithReportFXConverter(converter))
 		reportSvc, err = service.NewReportService(entrySvc, capSvc, reportOptions...)
@@ -340,6 +424,11 @@ func buildReportRequest(flags reportCommonFlags, period reportPeriodInput) (serv
 		paymentCardID = &id
 	}
 
+	amountMinMinor, amountMaxMinor, err := parseAmountFilterBounds(flags.amountMin, flags.amountMax, flags.currency, flags.amountFormat)
+	if err != nil {
+		return service.ReportRequest{}, err
+	}
+
 	return service.ReportRequest{
 		Period: domain.ReportPeriodInput{
 			Scope:       period.Scope,
@@ -356,6 +445,16 @@ func buildReportRequest(flags reportCommonFlags, period reportPeriodInput) (serv
 		PaymentCardID:       paymentCardID,
 		PaymentCardNickname: flags.cardNickname,
 		PaymentCardLookup:   flags.cardLookup,
+		Payee:               flags.payee,
+		RecordedBy:          flags.by,
+		IncomeSource:        flags.source,
+		LocationContains:    flags.location,
+		NoteContains:        flags.noteContains,
+		CurrencyCode:        flags.currency,
+		AmountMinMinor:      amountMinMinor,
+		AmountMaxMinor:      amountMaxMinor,
+		RevalueAsOf:         flags.revalueAsOf,
+		IncludeAssets:       flags.includeAssets,
 	}, nil
 }
 
@@ -445,12 +544,12 @@ func normalizeReportGroupBy(raw string) (string, error) {
 	}
 
 	switch normalized {
-	case reportGroupByDay, reportGroupByWeek, reportGroupByMonth:
+	case reportGroupByDay, reportGroupByWeek, reportGroupByMonth, reportGroupByWeekday, reportGroupByDayOfMonth:
 		return normalized, nil
 	default:
 		return "", &reportCLIError{
 			Code:    "INVALID_ARGUMENT",
-			Message: "group-by must be one of: day|week|month",
+			Message: "group-by must be one of: day|week|month|weekday|day-of-month",
 			Details: map[string]any{"field": "group-by", "value": raw},
 		}
 	}
@@ -526,6 +625,10 @@ func printReportError(cmd *cobra.Command, format string, err error) error {
 		return output.Print(cmd.OutOrStdout(), format, env)
 	}
 
+	if env, ok := strictWarningEnvelope(err); ok {
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
 	var entryErr *entryCLIError
 	if errors.As(err, &entryErr) {
 		env := output.NewErrorEnvelope(entryErr.Code, entryErr.Message, entryErr.Details, nil)
@@ -554,7 +657,10 @@ func codeFromReportingError(err error) string {
 		errors.Is(err, domain.ErrInvalidAmountMinor),
 		errors.Is(err, domain.ErrInvalidAmount),
 		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrInvalidAmountFormat),
 		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidAmountRange),
 		errors.Is(err, domain.ErrInvalidTransactionDate),
 		errors.Is(err, domain.ErrInvalidEntryID),
 		errors.Is(err, domain.ErrInvalidCategoryID),
@@ -567,19 +673,43 @@ func codeFromReportingError(err error) string {
 		errors.Is(err, domain.ErrInvalidReportPeriod),
 		errors.Is(err, domain.ErrInvalidPaymentMethod),
 		errors.Is(err, domain.ErrInvalidPaymentFilter),
+		errors.Is(err, domain.ErrInvalidInflationIndexDate),
 		errors.Is(err, domain.ErrCardSelectorConflict),
 		errors.Is(err, domain.ErrCardNotAllowed),
-		errors.Is(err, domain.ErrCardRequired):
+		errors.Is(err, domain.ErrCardRequired),
+		errors.Is(err, domain.ErrInvalidDatasetArchive),
+		errors.Is(err, domain.ErrInvalidSettingValue),
+		errors.Is(err, domain.ErrInvalidAppearanceColor),
+		errors.Is(err, domain.ErrInvalidAppearanceIcon),
+		errors.Is(err, domain.ErrInvalidCardAlias),
+		errors.Is(err, domain.ErrInvalidCardEventsFile),
+		errors.Is(err, domain.ErrInvalidAuditFile),
+		errors.Is(err, domain.ErrInvalidTaxYear),
+		errors.Is(err, domain.ErrInvalidTaxSelection),
+		errors.Is(err, domain.ErrInvalidImportSource),
+		errors.Is(err, domain.ErrInvalidImportSourceFile),
+		errors.Is(err, domain.ErrInvalidEntryUID),
+		errors.Is(err, domain.ErrInvalidExportSchemaVersion),
+		errors.Is(err, domain.ErrInvalidRestoreGroup):
 		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrExportUpgradeRequired):
+		return "UPGRADE_REQUIRED"
 	case errors.Is(err, domain.ErrCategoryNotFound),
 		errors.Is(err, domain.ErrLabelNotFound),
 		errors.Is(err, domain.ErrEntryNotFound),
 		errors.Is(err, domain.ErrCapNotFound),
 		errors.Is(err, domain.ErrSettingsNotFound),
-		errors.Is(err, domain.ErrCardNotFound):
+		errors.Is(err, domain.ErrCardNotFound),
+		errors.Is(err, domain.ErrInflationIndexUnavailable),
+		errors.Is(err, domain.ErrReportSnapshotNotFound):
 		return "NOT_FOUND"
-	case errors.Is(err, domain.ErrCardLookupAmbiguous):
+	case errors.Is(err, domain.ErrCardLookupAmbiguous),
+		errors.Is(err, domain.ErrCardAliasExists),
+		errors.Is(err, domain.ErrReportSnapshotExists),
+		errors.Is(err, domain.ErrRestoreForeignKeyViolation):
 		return "CONFLICT"
+	case errors.Is(err, domain.ErrInvalidReportScheduleConfig):
+		return "CONFIG_ERROR"
 	default:
 		message := strings.ToLower(err.Error())
 		if strings.Contains(message, "unique constraint") || strings.Contains(message, "constraint failed") {
@@ -603,8 +733,14 @@ func messageFromReportingError(err error) string {
 		return "amount must be a valid decimal number"
 	case errors.Is(err, domain.ErrInvalidAmountPrecision):
 		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrInvalidAmountFormat):
+		return "amount-format must be one of: dot_decimal|comma_decimal"
 	case errors.Is(err, domain.ErrAmountOverflow):
 		return "amount is too large"
+	case errors.Is(err, domain.ErrInvalidAmountRange):
+		return "amount-min must not exceed amount-max"
 	case errors.Is(err, domain.ErrInvalidAmountMinor):
 		return "amount must be greater than zero"
 	case errors.Is(err, domain.ErrInvalidTransactionDate):
@@ -624,13 +760,17 @@ func messageFromReportingError(err error) string {
 	case errors.Is(err, domain.ErrInvalidReportScope):
 		return "report scope is invalid"
 	case errors.Is(err, domain.ErrInvalidReportGrouping):
-		return "group-by must be one of: day|week|month"
+		return "group-by must be one of: day|week|month|weekday|day-of-month"
 	case errors.Is(err, domain.ErrInvalidReportPeriod):
 		return "report period is invalid"
 	case errors.Is(err, domain.ErrInvalidPaymentMethod):
 		return "payment-method must be one of: cash|card"
 	case errors.Is(err, domain.ErrInvalidPaymentFilter):
 		return "payment-method must be one of: cash|card|credit|debit"
+	case errors.Is(err, domain.ErrInvalidInflationIndexDate):
+		return "revalue-as-of must be YYYY-MM-DD or RFC3339"
+	case errors.Is(err, domain.ErrInflationIndexUnavailable):
+		return "inflation index unavailable"
 	case errors.Is(err, domain.ErrCardSelectorConflict):
 		return "card-id, card-nickname and card-lookup are mutually exclusive"
 	case errors.Is(err, domain.ErrCardNotAllowed):
@@ -651,6 +791,34 @@ func messageFromReportingError(err error) string {
 		return "settings not found"
 	case errors.Is(err, domain.ErrCardNotFound):
 		return "card not found"
+	case errors.Is(err, domain.ErrInvalidCardEventsFile):
+		return "card events file is invalid"
+	case errors.Is(err, domain.ErrInvalidAuditFile):
+		return "audit file is invalid"
+	case errors.Is(err, domain.ErrInvalidTaxYear):
+		return "year must be a four-digit calendar year"
+	case errors.Is(err, domain.ErrInvalidTaxSelection):
+		return "categories file must list at least one category or label name"
+	case errors.Is(err, domain.ErrInvalidImportSource):
+		return "source must be one of: ynab|mint|gnucash"
+	case errors.Is(err, domain.ErrInvalidImportSourceFile):
+		return "source file is invalid"
+	case errors.Is(err, domain.ErrInvalidEntryUID):
+		return "uid must be a UUID"
+	case errors.Is(err, domain.ErrInvalidExportSchemaVersion):
+		return "schema_version must be a positive integer"
+	case errors.Is(err, domain.ErrExportUpgradeRequired):
+		return "file was exported by a newer version; upgrade boring-budget to import it"
+	case errors.Is(err, domain.ErrInvalidRestoreGroup):
+		return "only must list restore groups: " + strings.Join(domain.RestoreGroups(), "|")
+	case errors.Is(err, domain.ErrRestoreForeignKeyViolation):
+		return "restored tables would leave dangling references; include the related groups in --only"
+	case errors.Is(err, domain.ErrInvalidReportScheduleConfig):
+		return "report schedule config is invalid"
+	case errors.Is(err, domain.ErrReportSnapshotExists):
+		return "month is already frozen; pass --replace to re-freeze it"
+	case errors.Is(err, domain.ErrReportSnapshotNotFound):
+		return "month has no frozen report"
 	default:
 		message := strings.ToLower(err.Error())
 		if strings.Contains(message, "unique constraint") || strings.Contains(message, "constraint failed") {
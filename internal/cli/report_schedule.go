@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/config"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type reportScheduleRunFlags struct {
+	configPath string
+	asOfRaw    string
+	dryRun     bool
+}
+
+func newReportScheduleCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Run recurring report and entry exports from a config file",
+	}
+
+	cmd.AddCommand(newReportScheduleRunCmd(opts))
+
+	return cmd
+}
+
+func newReportScheduleRunCmd(opts *RootOptions) *cobra.Command {
+	flags := &reportScheduleRunFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Execute every due scheduled export and record its run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := reportOutputFormat(opts)
+			if len(args) != 0 {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report schedule run does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			asOf := time.Now().UTC()
+			if raw := strings.TrimSpace(flags.asOfRaw); raw != "" {
+				parsed, err := time.Parse("2006-01-02", raw)
+				if err != nil {
+					return printReportError(cmd, format, &reportCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: "as-of must be YYYY-MM-DD",
+						Details: map[string]any{"as_of": raw},
+					})
+				}
+				asOf = parsed
+			}
+
+			scheduleConfig, configPath, err := loadReportScheduleConfig(flags.configPath)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			svc, err := newReportScheduleService(opts)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			result, err := svc.Run(cmd.Context(), service.ReportScheduleRunRequest{
+				Config: scheduleConfig,
+				AsOf:   asOf,
+				DryRun: flags.dryRun,
+			})
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			warnings, err := toReportWarningPayloads(result.Warnings)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"config_path": configPath,
+				"as_of_date":  result.AsOfDate,
+				"dry_run":     result.DryRun,
+				"ran":         result.Ran,
+				"jobs":        result.Jobs,
+			}, warnings)
+			return output.Print(cmd.OutOrStdout(), format, env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.configPath, "config", "", "Schedule config path (default ~/.boring-budget/report-schedule.json)")
+	cmd.Flags().StringVar(&flags.asOfRaw, "as-of", "", "Reference date in YYYY-MM-DD (UTC), default today")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Report which jobs are due without exporting or recording runs")
+
+	return cmd
+}
+
+func loadReportScheduleConfig(rawPath string) (domain.ReportScheduleConfig, string, error) {
+	configPath := strings.TrimSpace(rawPath)
+	if configPath == "" {
+		defaultPath, err := config.DefaultReportSchedulePath()
+		if err != nil {
+			return domain.ReportScheduleConfig{}, "", &reportCLIError{
+				Code:    "CONFIG_ERROR",
+				Message: "report schedule config path could not be resolved",
+				Details: map[string]any{"reason": err.Error()},
+			}
+		}
+		configPath = defaultPath
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return domain.ReportScheduleConfig{}, "", &reportCLIError{
+			Code:    "CONFIG_ERROR",
+			Message: "report schedule config could not be read",
+			Details: map[string]any{"config_path": configPath, "reason": err.Error()},
+		}
+	}
+
+	scheduleConfig, err := domain.ParseReportScheduleConfig(data)
+	if err != nil {
+		return domain.ReportScheduleConfig{}, "", &reportCLIError{
+			Code:    "CONFIG_ERROR",
+			Message: "report schedule config is invalid",
+			Details: map[string]any{"config_path": configPath, "reason": err.Error()},
+		}
+	}
+
+	return scheduleConfig, configPath, nil
+}
+
+func newReportScheduleService(opts *RootOptions) (*service.ReportScheduleService, error) {
+	portabilitySvc, err := newPortabilityService(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := service.NewReportScheduleService(sqlitestore.NewSettingsRepo(opts.db), portabilitySvc)
+	if err != nil {
+		return nil, fmt.Errorf("report schedule service init: %w", err)
+	}
+	return svc, nil
+}
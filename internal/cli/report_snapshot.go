@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type reportFreezeFlags struct {
+	monthRaw string
+	replace  bool
+}
+
+type reportShowFlags struct {
+	monthRaw string
+	frozen   bool
+}
+
+func newReportFreezeCmd(opts *RootOptions) *cobra.Command {
+	flags := &reportFreezeFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Short: "Store a month's report so later entry edits do not change it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := reportOutputFormat(opts)
+			if len(args) != 0 {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report freeze does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			period, err := buildPresetReportPeriod(flags.monthRaw, reportScopeMonthly)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			svc, err := newReportSnapshotService(opts)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			result, err := svc.Freeze(cmd.Context(), period.MonthKey, flags.replace)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+			return printReportSnapshot(cmd, format, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.monthRaw, "month", "", "Month to freeze in YYYY-MM")
+	cmd.Flags().BoolVar(&flags.replace, "replace", false, "Re-freeze a month that already has a snapshot")
+
+	return cmd
+}
+
+func newReportShowCmd(opts *RootOptions) *cobra.Command {
+	flags := &reportShowFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show a month's report, from its frozen snapshot with --frozen",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := reportOutputFormat(opts)
+			period, err := buildPresetReportPeriod(flags.monthRaw, reportScopeMonthly)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+			if !flags.frozen {
+				return runReportCommand(cmd, args, opts, reportCommonFlags{groupBy: reportGroupByMonth, labelMode: "any"}, period)
+			}
+			if len(args) != 0 {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report show does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newReportSnapshotService(opts)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			result, err := svc.Show(cmd.Context(), period.MonthKey)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+			return printReportSnapshot(cmd, format, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.monthRaw, "month", "", "Target month in YYYY-MM")
+	cmd.Flags().BoolVar(&flags.frozen, "frozen", false, "Read the frozen snapshot and warn if live entries drifted from it")
+
+	return cmd
+}
+
+func printReportSnapshot(cmd *cobra.Command, format string, result service.ReportSnapshotResult) error {
+	reportData, err := toReportOutputData(result.Snapshot.Report)
+	if err != nil {
+		return printReportError(cmd, format, err)
+	}
+	reportWarnings, err := toReportWarningPayloads(result.Warnings)
+	if err != nil {
+		return printReportError(cmd, format, err)
+	}
+	reportData["snapshot_id"] = result.Snapshot.ID
+	reportData["frozen_at_utc"] = result.Snapshot.FrozenAtUTC
+
+	env := output.NewSuccessEnvelope(reportData, reportWarnings)
+	return output.PrintTables(cmd.OutOrStdout(), format, env, reportTables(result.Snapshot.Report, nil))
+}
+
+func newReportSnapshotService(opts *RootOptions) (*service.ReportSnapshotService, error) {
+	reportSvc, err := newReportService(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := service.NewReportSnapshotService(sqlitestore.NewReportSnapshotRepo(opts.db), reportSvc)
+	if err != nil {
+		return nil, fmt.Errorf("report snapshot service init: %w", err)
+	}
+	return svc, nil
+}
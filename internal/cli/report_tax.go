@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/reporting"
+	"boring-budget/internal/service"
+	"github.com/spf13/cobra"
+)
+
+type reportTaxFlags struct {
+	year       int
+	categories string
+	format     string
+	file       string
+}
+
+func newReportTaxCmd(opts *RootOptions) *cobra.Command {
+	flags := &reportTaxFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "tax",
+		Short: "Summarize a year's tax-relevant entries for an accountant",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := reportOutputFormat(opts)
+			if len(args) != 0 {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "report tax does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if !cmd.Flags().Changed("year") || strings.TrimSpace(flags.categories) == "" {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "year and categories are required",
+					Details: map[string]any{"required_flags": []string{"year", "categories"}},
+				})
+			}
+			if strings.TrimSpace(flags.file) != "" && strings.TrimSpace(flags.format) == "" {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "format is required when file is provided",
+					Details: map[string]any{"required_flags": []string{"format", "file"}},
+				})
+			}
+
+			raw, err := os.ReadFile(strings.TrimSpace(flags.categories))
+			if err != nil {
+				return printReportError(cmd, format, &reportCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "categories file could not be read",
+					Details: map[string]any{"field": "categories", "value": flags.categories, "reason": err.Error()},
+				})
+			}
+			selection, err := domain.ParseTaxSelection(raw)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+
+			var summary domain.TaxSummary
+			data := map[string]any{}
+			if strings.TrimSpace(flags.file) != "" {
+				exportFormat := normalizeDataFormat(flags.format)
+				if exportFormat != service.PortabilityFormatJSON && exportFormat != service.PortabilityFormatCSV {
+					return printReportError(cmd, format, &reportCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: "format must be one of: json|csv",
+						Details: map[string]any{"field": "format", "value": flags.format},
+					})
+				}
+
+				// With --file - the export owns stdout, so the envelope moves to stderr.
+				exportOut := cmd.OutOrStdout()
+				if flags.file == service.PortabilityStdioPath {
+					cmd.SetOut(cmd.ErrOrStderr())
+				}
+				portabilitySvc, err := newPortabilityService(opts, service.WithPortabilityStdio(nil, exportOut))
+				if err != nil {
+					return printReportError(cmd, format, err)
+				}
+				summary, err = portabilitySvc.ExportTaxSummary(cmd.Context(), flags.format, flags.file, flags.year, selection)
+				if err != nil {
+					return printReportError(cmd, format, err)
+				}
+				data["format"] = exportFormat
+				data["file"] = flags.file
+			} else {
+				reportSvc, err := newReportService(opts)
+				if err != nil {
+					return printReportError(cmd, format, err)
+				}
+				summary, err = reportSvc.TaxSummary(cmd.Context(), flags.year, selection)
+				if err != nil {
+					return printReportError(cmd, format, err)
+				}
+			}
+
+			payload, err := reporting.ToMajorUnitMap(summary)
+			if err != nil {
+				return printReportError(cmd, format, err)
+			}
+			for key, value := range data {
+				payload[key] = value
+			}
+			env := output.NewSuccessEnvelope(payload, nil)
+			return output.Print(cmd.OutOrStdout(), format, env)
+		},
+	}
+
+	cmd.Flags().IntVar(&flags.year, "year", 0, "Calendar year to summarize (YYYY)")
+	cmd.Flags().StringVar(&flags.categories, "categories", "", "YAML or JSON file listing tax-relevant `categories` and `labels` by name")
+	cmd.Flags().StringVar(&flags.format, "format", "", "Export format when --file is set: json|csv")
+	cmd.Flags().StringVar(&flags.file, "file", "", "Write the summary to this path, or - for stdout (the envelope is then written to stderr)")
+
+	return cmd
+}
@@ -6,6 +6,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
@@ -194,7 +198,7 @@ func TestReportCommandJSONIncludesPaymentMethodSummaryAndLiability(t *testing.T)
 	t.Cleanup(func() { _ = db.Close() })
 
 	creditCardID := insertTestCard(t, db, "Credit One", "travel", "1234", "VISA", "credit", 15)
-	debitCardID := insertTestCard(t, db, "Debit One", "daily", "5678", "VISA", "debit", 0)
+	debitCardID := insertTestCard(t, db, "Debit One", "daily", "5678", "MASTERCARD", "debit", 0)
 
 	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
 		"add",
@@ -250,6 +254,27 @@ func TestReportCommandJSONIncludesPaymentMethodSummaryAndLiability(t *testing.T)
 		t.Fatalf("expected debit total USD=500, got %d", reportTotalForCurrency(t, debitTotals, "USD"))
 	}
 
+	byBrand := mustAnySlice(t, paymentMethods["by_brand"])
+	if len(byBrand) != 2 {
+		t.Fatalf("expected 2 brand buckets, got %v", byBrand)
+	}
+	wantBrandTotals := map[string]int64{"MASTERCARD": 500, "VISA": 2000}
+	for _, raw := range byBrand {
+		item := mustMap(t, raw)
+		brand, _ := item["brand"].(string)
+		if got := reportAmountForItem(t, item, "total"); got != wantBrandTotals[brand] {
+			t.Fatalf("expected %s brand total %d, got %d", brand, wantBrandTotals[brand], got)
+		}
+	}
+	creditGroups := mustAnySlice(t, paymentMethods["credit_groups"])
+	debitGroups := mustAnySlice(t, paymentMethods["debit_groups"])
+	if len(creditGroups) != 1 || len(debitGroups) != 1 {
+		t.Fatalf("expected one credit and one debit period, got credit=%v debit=%v", creditGroups, debitGroups)
+	}
+	if got := reportAmountForItem(t, mustMap(t, creditGroups[0]), "total"); got != 2000 {
+		t.Fatalf("expected credit period total 2000, got %d", got)
+	}
+
 	liability := mustAnySlice(t, paymentMethods["credit_liability"])
 	if len(liability) != 1 {
 		t.Fatalf("expected one credit liability bucket, got %d (%v)", len(liability), liability)
@@ -266,6 +291,147 @@ func TestReportCommandJSONIncludesPaymentMethodSummaryAndLiability(t *testing.T)
 	}
 }
 
+func TestReportCurrencyMixFlagsOneOffCodesAndFixCurrencyCorrectsThem(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-02-03"},
+		{"add", "--type", "expense", "--amount", "12.00", "--currency", "USD", "--date", "2026-02-04"},
+		{"add", "--type", "income", "--amount", "50.00", "--currency", "USD", "--date", "2026-02-05"},
+		{"add", "--type", "expense", "--amount", "7.50", "--currency", "UDS", "--date", "2026-02-06"},
+		{"add", "--type", "expense", "--amount", "5.00", "--currency", "EUR", "--date", "2026-01-20"},
+		{"add", "--type", "expense", "--amount", "6.00", "--currency", "EUR", "--date", "2026-02-07"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, args))
+	}
+
+	mix := executeReportCmdJSON(t, db, []string{"currency-mix", "--month", "2026-02"})
+	data := mustMap(t, mix["data"])
+	if count := data["entry_count"].(float64); count != 5 {
+		t.Fatalf("expected 5 february entries, got %v", count)
+	}
+	currencies := mustAnySlice(t, data["currencies"])
+	first := mustMap(t, currencies[0])
+	if first["currency_code"] != "USD" || first["entry_count"].(float64) != 3 || first["income_count"].(float64) != 1 {
+		t.Fatalf("unexpected leading currency row: %v", first)
+	}
+	for _, raw := range currencies {
+		row := mustMap(t, raw)
+		if seenOnce := row["seen_once"].(bool); seenOnce != (row["currency_code"] == "UDS") {
+			t.Fatalf("unexpected seen_once flag: %v", row)
+		}
+	}
+	warnings := mustAnySlice(t, mix["warnings"])
+	if len(warnings) != 1 || mustMap(t, warnings[0])["code"] != domain.WarningCodeCurrencySeenOnce {
+		t.Fatalf("expected one CURRENCY_SEEN_ONCE warning, got %v", warnings)
+	}
+
+	dryRun := executeEntryCmdJSON(t, db, []string{"fix-currency", "--from", "uds", "--to", "USD", "--dry-run"})
+	mustEntrySuccess(t, dryRun)
+	if fix := mustMap(t, mustMap(t, dryRun["data"])["fix"]); fix["updated"].(float64) != 0 || len(mustAnySlice(t, fix["entry_ids"])) != 1 {
+		t.Fatalf("unexpected dry-run fix: %v", fix)
+	}
+
+	mismatch := executeEntryCmdJSON(t, db, []string{"fix-currency", "--from", "UDS", "--to", "JPY"})
+	if code := mustMap(t, mismatch["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for minor unit mismatch, got %v", code)
+	}
+
+	fixed := executeEntryCmdJSON(t, db, []string{"fix-currency", "--from", "UDS", "--to", "USD"})
+	mustEntrySuccess(t, fixed)
+	if updated := mustMap(t, mustMap(t, fixed["data"])["fix"])["updated"].(float64); updated != 1 {
+		t.Fatalf("expected one updated entry, got %v", updated)
+	}
+
+	clean := executeReportCmdJSON(t, db, []string{"currency-mix", "--month", "2026-02"})
+	if warnings := mustAnySlice(t, clean["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no warnings after fix, got %v", warnings)
+	}
+	if rows := mustAnySlice(t, mustMap(t, clean["data"])["currencies"]); len(rows) != 2 {
+		t.Fatalf("expected USD and EUR rows after fix, got %v", rows)
+	}
+}
+
+func TestReportScheduleRunExportsDueJobsOncePerPeriod(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-02-03"},
+		{"add", "--type", "income", "--amount", "50.00", "--currency", "USD", "--date", "2026-02-27"},
+		{"add", "--type", "expense", "--amount", "4.00", "--currency", "USD", "--date", "2026-03-02"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, args))
+	}
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "schedule.json")
+	config := fmt.Sprintf(`{"jobs": [
+		{"name": "monthly-report", "resource": "report", "every": "monthly", "format": "json", "file": %q},
+		{"name": "weekly-entries", "resource": "entries", "every": "weekly", "format": "csv", "file": %q, "keys": "natural"}
+	]}`, filepath.Join(dir, "reports", "{period}.json"), filepath.Join(dir, "entries", "{period}.csv"))
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write schedule config: %v", err)
+	}
+
+	dryRun := executeReportCmdJSON(t, db, []string{"schedule", "run", "--config", configPath, "--as-of", "2026-03-04", "--dry-run"})
+	assertSuccessJSONEnvelope(t, dryRun)
+	for _, raw := range mustAnySlice(t, mustMap(t, dryRun["data"])["jobs"]) {
+		if status := mustMap(t, raw)["status"]; status != domain.ReportScheduleStatusDue {
+			t.Fatalf("expected due job in dry run, got %v", raw)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "reports", "2026-02.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected dry run to write nothing, stat err=%v", err)
+	}
+
+	ran := executeReportCmdJSON(t, db, []string{"schedule", "run", "--config", configPath, "--as-of", "2026-03-04"})
+	if ok, _ := ran["ok"].(bool); !ok {
+		t.Fatalf("expected ok=true payload=%v", ran)
+	}
+	data := mustMap(t, ran["data"])
+	if data["ran"].(float64) != 2 {
+		t.Fatalf("expected two jobs to run, got %v", data)
+	}
+	jobs := mustAnySlice(t, data["jobs"])
+	if period := mustMap(t, mustMap(t, jobs[0])["period"])["key"]; period != "2026-02" {
+		t.Fatalf("expected monthly job for 2026-02, got %v", period)
+	}
+	weekly := mustMap(t, jobs[1])
+	if period := mustMap(t, weekly["period"])["key"]; period != "2026-W09" || weekly["rows"].(float64) != 1 {
+		t.Fatalf("expected weekly job for 2026-W09 with one entry, got %v", weekly)
+	}
+
+	report, err := os.ReadFile(filepath.Join(dir, "reports", "2026-02.json"))
+	if err != nil {
+		t.Fatalf("read scheduled report: %v", err)
+	}
+	if !strings.Contains(string(report), `"month_key": "2026-02"`) {
+		t.Fatalf("expected february report, got %s", report)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "entries", "2026-W09.csv")); err != nil {
+		t.Fatalf("expected weekly entries export: %v", err)
+	}
+
+	again := executeReportCmdJSON(t, db, []string{"schedule", "run", "--config", configPath, "--as-of", "2026-03-05"})
+	if ran := mustMap(t, again["data"])["ran"].(float64); ran != 0 {
+		t.Fatalf("expected no jobs to rerun within the same period, got %v", again)
+	}
+
+	if err := os.WriteFile(configPath, []byte(`{"jobs": [{"name": "x", "resource": "report", "every": "yearly", "format": "json", "file": "x.json"}]}`), 0o600); err != nil {
+		t.Fatalf("write invalid schedule config: %v", err)
+	}
+	invalid := executeReportCmdJSON(t, db, []string{"schedule", "run", "--config", configPath})
+	if code := mustMap(t, invalid["error"])["code"]; code != "CONFIG_ERROR" {
+		t.Fatalf("expected CONFIG_ERROR for invalid config, got %v", invalid)
+	}
+}
+
 func TestReportCommandJSONRangeRequiresFromAndTo(t *testing.T) {
 	t.Parallel()
 
@@ -283,6 +449,42 @@ func TestReportCommandJSONRangeRequiresFromAndTo(t *testing.T) {
 	}
 }
 
+func TestReportCommandJSONGroupByWeekdayAndDayOfMonth(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, date := range []string{"2026-02-01", "2026-02-02", "2026-02-09", "2026-02-09"} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "5.00", "--currency", "USD", "--date", date}))
+	}
+
+	weekday := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02", "--group-by", "weekday"})
+	mustEntrySuccess(t, weekday)
+	groups := mustAnySlice(t, mustMap(t, mustMap(t, weekday["data"])["spending"])["groups"])
+	if len(groups) != 2 {
+		t.Fatalf("expected monday and sunday groups, got %v", groups)
+	}
+	monday := mustMap(t, groups[0])
+	if monday["period_key"] != "monday" || monday["total_major"] != "15.00" {
+		t.Fatalf("expected monday first with 15.00, got %v", monday)
+	}
+	if sunday := mustMap(t, groups[1]); sunday["period_key"] != "sunday" || sunday["total_major"] != "5.00" {
+		t.Fatalf("expected sunday last with 5.00, got %v", sunday)
+	}
+
+	dayOfMonth := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-02", "--group-by", "day-of-month"})
+	mustEntrySuccess(t, dayOfMonth)
+	groups = mustAnySlice(t, mustMap(t, mustMap(t, dayOfMonth["data"])["spending"])["groups"])
+	keys := make([]string, 0, len(groups))
+	for _, group := range groups {
+		keys = append(keys, mustMap(t, group)["period_key"].(string))
+	}
+	if strings.Join(keys, ",") != "01,02,09" {
+		t.Fatalf("expected day-of-month groups 01,02,09, got %v", keys)
+	}
+}
+
 func TestReportCommandJSONInvalidGroupBy(t *testing.T) {
 	t.Parallel()
 
@@ -337,9 +539,172 @@ func TestReportCommandHumanOutput(t *testing.T) {
 	if !strings.Contains(out, "[OK] boring-budget") {
 		t.Fatalf("expected human output status line, got %q", out)
 	}
-	if !strings.Contains(out, "\"grouping\": \"month\"") {
+	if !strings.Contains(out, "grouping: month") {
 		t.Fatalf("expected human output to include grouping, got %q", out)
 	}
+	if !strings.Contains(out, "USD          $1.00") {
+		t.Fatalf("expected human output to render an aligned summary row, got %q", out)
+	}
+}
+
+func TestReportCommandJSONFreezeAndShowFrozenWarnsOnDrift(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	added := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-02-10"})
+	mustEntrySuccess(t, added)
+	entryID := strconv.FormatInt(int64(mustMap(t, mustMap(t, added["data"])["entry"])["id"].(float64)), 10)
+
+	frozen := executeReportCmdJSON(t, db, []string{"freeze", "--month", "2026-02"})
+	mustEntrySuccess(t, frozen)
+	if mustMap(t, frozen["data"])["frozen_at_utc"] == "" {
+		t.Fatalf("expected frozen_at_utc, got %v", frozen)
+	}
+
+	again := executeReportCmdJSON(t, db, []string{"freeze", "--month", "2026-02"})
+	if got := mustMap(t, again["error"])["code"]; got != "CONFLICT" {
+		t.Fatalf("expected CONFLICT when re-freezing, got %v", again)
+	}
+
+	clean := executeReportCmdJSON(t, db, []string{"show", "--month", "2026-02", "--frozen"})
+	mustEntrySuccess(t, clean)
+	if warnings := mustAnySlice(t, clean["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no drift before edits, got %v", warnings)
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"update", entryID, "--amount", "55.00", "--currency", "USD"}))
+
+	drifted := executeReportCmdJSON(t, db, []string{"show", "--month", "2026-02", "--frozen"})
+	mustEntrySuccess(t, drifted)
+	spending := mustMap(t, mustMap(t, drifted["data"])["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 4000 {
+		t.Fatalf("expected frozen spending USD=4000, got %d", got)
+	}
+	warnings := mustAnySlice(t, drifted["warnings"])
+	if len(warnings) != 1 || mustMap(t, warnings[0])["code"] != "REPORT_SNAPSHOT_DRIFT" {
+		t.Fatalf("expected REPORT_SNAPSHOT_DRIFT, got %v", warnings)
+	}
+
+	live := executeReportCmdJSON(t, db, []string{"show", "--month", "2026-02"})
+	mustEntrySuccess(t, live)
+	spending = mustMap(t, mustMap(t, live["data"])["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 5500 {
+		t.Fatalf("expected live spending USD=5500, got %d", got)
+	}
+
+	missing := executeReportCmdJSON(t, db, []string{"show", "--month", "2026-03", "--frozen"})
+	if got := mustMap(t, missing["error"])["code"]; got != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unfrozen month, got %v", missing)
+	}
+}
+
+func TestReportCommandJSONTaxSummarizesSelectedCategoriesAndLabels(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	medicalID := insertTestCategory(t, db, "Medical")
+	groceriesID := insertTestCategory(t, db, "Groceries")
+	donationID := insertTestLabel(t, db, "donation")
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "120.00", "--currency", "USD", "--date", "2026-03-04", "--category-id", strconv.FormatInt(medicalID, 10), "--note", "dentist invoice 88"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "50.00", "--currency", "USD", "--date", "2026-07-01", "--category-id", strconv.FormatInt(groceriesID, 10), "--label-id", strconv.FormatInt(donationID, 10)}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2026-08-01", "--category-id", strconv.FormatInt(groceriesID, 10)}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "75.00", "--currency", "USD", "--date", "2025-12-31", "--category-id", strconv.FormatInt(medicalID, 10)}))
+
+	dir := t.TempDir()
+	selectionPath := filepath.Join(dir, "deductible.yaml")
+	if err := os.WriteFile(selectionPath, []byte("categories:\n  - medical\nlabels:\n  - Donation\n"), 0o600); err != nil {
+		t.Fatalf("write selection: %v", err)
+	}
+
+	payload := executeReportCmdJSON(t, db, []string{"tax", "--year", "2026", "--categories", selectionPath})
+	mustEntrySuccess(t, payload)
+	data := mustMap(t, payload["data"])
+	entries := mustAnySlice(t, data["entries"])
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 tax entries, got %v", entries)
+	}
+	if note := mustMap(t, entries[0])["note"]; note != "dentist invoice 88" {
+		t.Fatalf("expected attached note, got %v", entries[0])
+	}
+	if got := reportAmountForItem(t, mustMap(t, mustAnySlice(t, data["totals"])[0]), "expense"); got != 17000 {
+		t.Fatalf("expected USD tax expense 17000, got %d", got)
+	}
+	if categories := mustAnySlice(t, data["categories"]); len(categories) != 2 {
+		t.Fatalf("expected 2 category totals, got %v", categories)
+	}
+
+	csvPath := filepath.Join(dir, "tax.csv")
+	exported := executeReportCmdJSON(t, db, []string{"tax", "--year", "2026", "--categories", selectionPath, "--format", "csv", "--file", csvPath})
+	mustEntrySuccess(t, exported)
+	content, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read tax csv: %v", err)
+	}
+	if !strings.Contains(string(content), "dentist invoice 88") || !strings.Contains(string(content), "currency_total,2026") {
+		t.Fatalf("unexpected tax csv:\n%s", content)
+	}
+
+	if err := os.WriteFile(selectionPath, []byte("categories: [Travel]\n"), 0o600); err != nil {
+		t.Fatalf("write selection: %v", err)
+	}
+	unknown := executeReportCmdJSON(t, db, []string{"tax", "--year", "2026", "--categories", selectionPath})
+	if got := mustMap(t, unknown["error"])["code"]; got != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for unknown category, got %v", unknown)
+	}
+}
+
+func TestReportCommandJSONCacheRebuildsAfterWrites(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	showCached := func() map[string]any {
+		t.Helper()
+		opts := &RootOptions{Output: output.FormatJSON, db: db, reportCache: true}
+		cmd := NewReportCmd(opts)
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs([]string{"show", "--month", "2026-02"})
+		if err := cmd.ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("execute report show: %v", err)
+		}
+		payload := map[string]any{}
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("unmarshal report payload: %v raw=%s", err, buf.String())
+		}
+		mustEntrySuccess(t, payload)
+		return payload
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-02-10"}))
+
+	first := showCached()
+	second := showCached()
+	if !reflect.DeepEqual(first["data"], second["data"]) {
+		t.Fatalf("expected the cached report to match:\nfirst:  %v\nsecond: %v", first["data"], second["data"])
+	}
+	var cachedRows int
+	if err := db.QueryRow("SELECT COUNT(*) FROM report_cache").Scan(&cachedRows); err != nil {
+		t.Fatalf("count report cache rows: %v", err)
+	}
+	if cachedRows != 1 {
+		t.Fatalf("expected one cached report, got %d", cachedRows)
+	}
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "15.00", "--currency", "USD", "--date", "2026-02-11"}))
+
+	after := showCached()
+	spending := mustMap(t, mustMap(t, after["data"])["spending"])
+	if got := reportTotalForCurrency(t, mustAnySlice(t, spending["by_currency"]), "USD"); got != 5500 {
+		t.Fatalf("expected the report to include the new entry, spending USD=%d", got)
+	}
 }
 
 func executeReportCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
@@ -456,3 +821,98 @@ func mustEntrySuccess(t *testing.T, payload map[string]any) {
 		t.Fatalf("expected entry command success payload=%v", payload)
 	}
 }
+
+// TestReportHumanTablesRenderEverySection renders a report with every section
+// set and compares it with testdata/human/report_all_sections.golden.txt
+// (rewritten with BUDGETTO_UPDATE_GOLDEN=1).
+func TestReportHumanTablesRenderEverySection(t *testing.T) {
+	t.Parallel()
+
+	categoryID := int64(3)
+	cardID := int64(7)
+	oldCap := int64(40000)
+	report := domain.Report{
+		Period:   domain.ReportPeriod{Scope: "monthly", MonthKey: "2026-02", FromUTC: "2026-02-01T00:00:00Z", ToUTC: "2026-02-28T23:59:59Z"},
+		Grouping: "month",
+		Earnings: domain.ReportSection{
+			ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "USD", TotalMinor: 500000}},
+			Groups:     []domain.GroupTotal{{PeriodKey: "2026-02", CurrencyCode: "USD", TotalMinor: 500000}},
+			Categories: []domain.CategoryTotal{{CategoryKey: "orphan", CategoryLabel: "Orphan", CurrencyCode: "USD", TotalMinor: 500000}},
+		},
+		Spending: domain.ReportSection{
+			ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "ARS", TotalMinor: 1250000}, {CurrencyCode: "USD", TotalMinor: 45000}},
+			Groups:     []domain.GroupTotal{{PeriodKey: "2026-02", CurrencyCode: "ARS", TotalMinor: 1250000}, {PeriodKey: "2026-02", CurrencyCode: "USD", TotalMinor: 45000}},
+			Categories: []domain.CategoryTotal{{CategoryID: &categoryID, CategoryKey: "category:3", CategoryLabel: "Groceries", CurrencyCode: "USD", TotalMinor: 45000}},
+		},
+		Net:            domain.ReportNet{ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "ARS", TotalMinor: -1250000}, {CurrencyCode: "USD", TotalMinor: 455000}}},
+		PeriodBalance:  domain.ReportNet{ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "USD", TotalMinor: 455000}}},
+		MonthlyBalance: &domain.ReportNet{ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "USD", TotalMinor: 455000}}},
+		GeneralBalance: domain.ReportNet{ByCurrency: []domain.CurrencyTotal{{CurrencyCode: "ARS", TotalMinor: 80000}, {CurrencyCode: "USD", TotalMinor: 1200000}}},
+		PaymentMethods: &domain.ReportPaymentMethods{
+			ByInstrument: []domain.ReportPaymentInstrumentTotal{
+				{PaymentMethod: "cash", CurrencyCode: "ARS", TotalMinor: 1250000, InstrumentKey: "cash", InstrumentLabel: "Cash"},
+				{PaymentMethod: "card", CurrencyCode: "USD", TotalMinor: 45000, CardID: &cardID, CardNickname: "Visa", CardType: "credit", InstrumentKey: "card:7", InstrumentLabel: "Visa (credit)"},
+			},
+			Totals: domain.ReportPaymentMethodTotals{
+				Cash:   []domain.CurrencyTotal{{CurrencyCode: "ARS", TotalMinor: 1250000}},
+				Debit:  []domain.CurrencyTotal{},
+				Credit: []domain.CurrencyTotal{{CurrencyCode: "USD", TotalMinor: 45000}},
+			},
+			ByBrand:         []domain.ReportCardBrandTotal{{Brand: "visa", CurrencyCode: "USD", TotalMinor: 45000}},
+			CreditGroups:    []domain.GroupTotal{{PeriodKey: "2026-02", CurrencyCode: "USD", TotalMinor: 45000}},
+			DebitGroups:     []domain.GroupTotal{},
+			CashUsage:       []domain.ReportCashUsage{{CurrencyCode: "ARS", CashTotalMinor: 1250000, SpendingTotalMinor: 1250000, ShareBPS: 10000}, {CurrencyCode: "USD", SpendingTotalMinor: 45000}},
+			CreditLiability: []domain.ReportCardLiability{{CardID: cardID, CardNickname: "Visa", CurrencyCode: "USD", BalanceMinorSigned: 45000, State: "owes"}},
+		},
+		ByPerson:   []domain.ReportPersonTotal{{Person: "Ana", CurrencyCode: "USD", EarningsMinor: 500000, SpendingMinor: 45000, NetMinor: 455000, EntryCount: 2}},
+		BySource:   []domain.ReportSourceTotal{{Source: "Acme", CurrencyCode: "USD", TotalMinor: 500000, EntryCount: 1}},
+		ByLocation: []domain.ReportLocationTotal{{Location: "Market", CurrencyCode: "ARS", TotalMinor: 1250000, EntryCount: 1}},
+		Assets: &domain.ReportAssets{
+			Items:  []domain.Asset{{ID: 1, Name: "Brokerage", Kind: "asset", CurrencyCode: "USD", BalanceMinor: 1000000, UpdatedAtUTC: "2026-02-10T12:00:00Z"}},
+			Totals: []domain.AssetTotal{{CurrencyCode: "USD", AssetsMinor: 1000000, NetMinor: 1000000}},
+		},
+		Converted: &domain.ConvertedSummary{TargetCurrency: "USD", EarningsMinor: 500000, SpendingMinor: 56000, NetMinor: 444000, UsedEstimateRate: true},
+		Revaluation: &domain.ReportRevaluation{
+			AsOfDate:   "2026-03-01",
+			Currencies: []domain.ReportRevaluationCurrency{{CurrencyCode: "ARS", AsOfIndexDate: "2026-03-01", AsOfIndexValue: "125.5", RevaluedEntries: 1}},
+		},
+		CapStatus:  []domain.ReportCapStatus{{MonthKey: "2026-02", CategoryID: &categoryID, CategoryName: "Groceries", CurrencyCode: "USD", CapAmountMinor: 40000, SpendTotalMinor: 45000, OverspendMinor: 5000, IsExceeded: true}},
+		CapChanges: []domain.MonthlyCapChange{{ID: 1, MonthKey: "2026-02", CategoryID: &categoryID, OldAmountMinor: &oldCap, NewAmountMinor: 50000, CurrencyCode: "USD", ChangedAtUTC: "2026-02-15T09:00:00Z"}},
+	}
+	links := []domain.BalanceAccountLink{{Target: "general", BankAccount: &domain.BankAccount{ID: 2, Alias: "Checking", Last4: "1234"}}}
+
+	reportValue := reflect.ValueOf(report)
+	for i := 0; i < reportValue.NumField(); i++ {
+		if reportValue.Field(i).IsZero() {
+			t.Fatalf("report field %s is unset; set it so the golden file shows how it renders", reportValue.Type().Field(i).Name)
+		}
+	}
+
+	env := output.NewSuccessEnvelope(nil, nil)
+	env.Meta = output.Meta{APIVersion: "v1", TimestampUTC: "2026-03-01T00:00:00Z"}
+	buf := &bytes.Buffer{}
+	if err := output.PrintTables(buf, output.FormatHuman, env, reportTables(report, links)); err != nil {
+		t.Fatalf("print report tables: %v", err)
+	}
+
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatalf("runtime caller failed")
+	}
+	goldenPath := filepath.Join(filepath.Dir(currentFile), "testdata", "human", "report_all_sections.golden.txt")
+	if os.Getenv(updateJSONContractsGoldenEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(goldenPath, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+	}
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("read golden file %q: %v", goldenPath, err)
+	}
+	if !bytes.Equal(expected, buf.Bytes()) {
+		t.Fatalf("golden mismatch for %s\nexpected:\n%s\nactual:\n%s", goldenPath, expected, buf.Bytes())
+	}
+}
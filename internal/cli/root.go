@@ -4,23 +4,138 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
 	"strings"
 	"time"
 
 	"boring-budget/internal/cli/output"
 	"boring-budget/internal/config"
 	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
 	sqlitestore "boring-budget/internal/store/sqlite"
 	"github.com/spf13/cobra"
 )
 
+// Environment variables that stand in for global flags in containers and CI.
+// A flag given on the command line wins over its variable, and the variable
+// wins over the stored settings.
+const (
+	envDBPath   = "BUDGETTO_DB"
+	envOutput   = "BUDGETTO_OUTPUT"
+	envTimezone = "BUDGETTO_TIMEZONE"
+	envCurrency = "BUDGETTO_CURRENCY"
+
+	// envTelegramToken holds the bot token for bot telegram; it is never
+	// taken from a flag so it stays out of shell history and process lists.
+	envTelegramToken = "BUDGETTO_TELEGRAM_TOKEN"
+)
+
 type RootOptions struct {
 	Output        string
 	Timezone      string
 	DBPath        string
 	MigrationsDir string
+	NoColor       bool
+	Verbose       bool
+	Quiet         bool
+	// NoSnapshot skips the automatic snapshot risky commands take first.
+	NoSnapshot bool
+	// StrictWarnings lists the warning codes --strict-warnings escalates to
+	// failures; when the flag is absent the stored settings policy applies.
+	StrictWarnings []string
+
+	db              *sql.DB
+	amountFormat    string
+	defaultCurrency string
+	defaultCardID   *int64
+	defaultBy       string
+	strictWarnings  []string
+	snapshotOff     bool
+	fxCachePolicy   domain.FXCachePolicy
+	reportCache     bool
+}
+
+// rootCLIError is returned for failures outside a command's own envelope
+// handling: flag parsing, startup and shutdown.
+type rootCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *rootCLIError) Error() string {
+	if e == nil {
+		return "boring-budget command error"
+	}
+	return e.Message
+}
+
+func newRootStartupError(code string, err error) error {
+	return &rootCLIError{Code: code, Message: err.Error(), Details: map[string]any{"reason": err.Error()}}
+}
+
+// Execute runs the CLI with args and returns the process exit code. Errors
+// that escape a command's own envelope still map through
+// output.ExitCodeForErrorCode, and --output json still gets an envelope.
+func Execute(args []string, stdout, stderr io.Writer) int {
+	output.ResetProcessExitCode()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+
+	err := cmd.Execute()
+	if err == nil {
+		return output.CurrentProcessExitCode()
+	}
+
+	code, message, details := classifyRootError(err)
+	env := output.NewErrorEnvelope(code, message, details, nil)
+	if requestedOutputFormat(cmd, args) == output.FormatJSON {
+		if printErr := output.Print(stdout, output.FormatJSON, env); printErr == nil {
+			return output.CurrentProcessExitCode()
+		}
+	}
+
+	output.SetProcessExitCodeFromEnvelope(env)
+	_, _ = fmt.Fprintln(stderr, err)
+	return output.CurrentProcessExitCode()
+}
+
+// requestedOutputFormat reads --output even when cobra failed before parsing
+// flags, as it does for unknown commands.
+func requestedOutputFormat(cmd *cobra.Command, args []string) string {
+	if flag := cmd.PersistentFlags().Lookup("output"); flag != nil && flag.Changed {
+		return strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	}
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--output="); ok {
+			return strings.ToLower(strings.TrimSpace(value))
+		}
+		if arg == "--output" && i+1 < len(args) {
+			return strings.ToLower(strings.TrimSpace(args[i+1]))
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv(envOutput)); value != "" {
+		return strings.ToLower(value)
+	}
+	return output.FormatHuman
+}
 
-	db *sql.DB
+func classifyRootError(err error) (string, string, any) {
+	var rootErr *rootCLIError
+	if errors.As(err, &rootErr) {
+		return rootErr.Code, rootErr.Message, rootErr.Details
+	}
+	// cobra reports unknown commands and argument validation as plain errors.
+	message := err.Error()
+	if strings.HasPrefix(message, "unknown command") || strings.Contains(message, "arg(s)") {
+		return "INVALID_ARGUMENT", message, map[string]any{}
+	}
+	return "INTERNAL_ERROR", message, map[string]any{}
 }
 
 func NewRootCmd() *cobra.Command {
@@ -30,10 +145,9 @@ func NewRootCmd() *cobra.Command {
 	}
 
 	opts := &RootOptions{
-		Output:        output.FormatHuman,
-		Timezone:      "UTC",
-		DBPath:        defaultDBPath,
-		MigrationsDir: sqlitestore.DefaultMigrationsDir,
+		Output:   output.FormatHuman,
+		Timezone: "UTC",
+		DBPath:   defaultDBPath,
 	}
 
 	cmd := &cobra.Command{
@@ -42,33 +156,142 @@ func NewRootCmd() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if value, ok := envFlagValue(cmd, "db-path", envDBPath); ok {
+				opts.DBPath = value
+			}
+			outputSource := "--output"
+			outputEnv, outputFromEnv := envFlagValue(cmd, "output", envOutput)
+			if outputFromEnv {
+				opts.Output = outputEnv
+				outputSource = envOutput
+			}
+			timezoneSource := "--timezone"
+			timezoneEnv, timezoneFromEnv := envFlagValue(cmd, "timezone", envTimezone)
+			if timezoneFromEnv {
+				opts.Timezone = timezoneEnv
+				timezoneSource = envTimezone
+			}
+
 			if !output.IsValidFormat(opts.Output) {
-				return fmt.Errorf("invalid --output value %q: supported values are %s|%s", opts.Output, output.FormatHuman, output.FormatJSON)
+				return &rootCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: fmt.Sprintf("invalid %s value %q: supported values are %s|%s", outputSource, opts.Output, output.FormatHuman, output.FormatJSON),
+					Details: map[string]any{"field": "output", "value": opts.Output},
+				}
 			}
 
 			opts.Output = strings.ToLower(strings.TrimSpace(opts.Output))
+			if opts.Verbose && opts.Quiet {
+				return &rootCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "verbose and quiet cannot be combined",
+					Details: map[string]any{"fields": []string{"verbose", "quiet"}},
+				}
+			}
+			configureLogging(cmd.ErrOrStderr(), opts)
+			output.SetQuiet(opts.Quiet)
+			output.ResetLastEnvelope()
+			strictFlag := cmd.Flags().Lookup("strict-warnings")
+			strictProvided := strictFlag != nil && strictFlag.Changed
+			if strictProvided {
+				codes, err := domain.NormalizeStrictWarningCodes(opts.StrictWarnings)
+				if err != nil {
+					return &rootCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: fmt.Sprintf("invalid --strict-warnings value %q: supported values are all|%s", strings.Join(opts.StrictWarnings, ","), strings.Join(domain.StrictableWarningCodes, "|")),
+						Details: map[string]any{"field": "strict-warnings", "value": opts.StrictWarnings},
+					}
+				}
+				opts.strictWarnings = codes
+			}
+			output.SetStrictWarnings(opts.strictWarnings)
+
+			if cmd.Annotations[skipAutoMigrateAnnotation] == "true" {
+				// Settings and custom currencies may live in tables that pending
+				// migrations have not created yet, so only open the database.
+				db, err := sqlitestore.Open(cmd.Context(), opts.DBPath)
+				if err != nil {
+					return newRootStartupError("DB_ERROR", fmt.Errorf("initialize sqlite: %w", err))
+				}
+				output.SetColorEnabled(!opts.NoColor && os.Getenv("NO_COLOR") == "")
+				opts.db = db
+				return nil
+			}
+
 			db, err := sqlitestore.OpenAndMigrate(cmd.Context(), opts.DBPath, opts.MigrationsDir)
 			if err != nil {
-				return fmt.Errorf("initialize sqlite: %w", err)
+				return newRootStartupError("DB_ERROR", fmt.Errorf("initialize sqlite: %w", err))
 			}
 
-			timezoneFlag := cmd.Flags().Lookup("timezone")
-			timezoneProvided := timezoneFlag != nil && timezoneFlag.Changed
-			if !timezoneProvided {
-				settingsRepo := sqlitestore.NewSettingsRepo(db)
-				settings, err := settingsRepo.Get(cmd.Context())
-				if err == nil && strings.TrimSpace(settings.DisplayTimezone) != "" {
-					opts.Timezone = settings.DisplayTimezone
+			currencySvc, err := service.NewCurrencyService(sqlitestore.NewCurrencyRepo(db))
+			if err != nil {
+				return newRootStartupError("INTERNAL_ERROR", fmt.Errorf("currency service init: %w", err))
+			}
+			if _, err := currencySvc.Load(cmd.Context()); err != nil {
+				return newRootStartupError("DB_ERROR", fmt.Errorf("load custom currencies: %w", err))
+			}
+
+			settings, err := sqlitestore.NewSettingsRepo(db).Get(cmd.Context())
+			if err != nil && !errors.Is(err, domain.ErrSettingsNotFound) {
+				return newRootStartupError("DB_ERROR", fmt.Errorf("load settings: %w", err))
+			}
+			settingsFound := err == nil
+			opts.fxCachePolicy = domain.DefaultFXCachePolicy()
+			if settingsFound {
+				opts.amountFormat = settings.AmountFormat
+				opts.defaultCurrency = settings.DefaultCurrencyCode
+				opts.defaultCardID = settings.DefaultCardID
+				if settings.DefaultRecordedBy != nil {
+					opts.defaultBy = *settings.DefaultRecordedBy
+				}
+				opts.snapshotOff = !settings.AutoSnapshot
+				opts.fxCachePolicy = domain.FXCachePolicy{
+					TTLHours:       settings.FXCacheTTLHours,
+					StaleAfterDays: settings.FXStaleAfterDays,
 				}
-				if err != nil && !errors.Is(err, domain.ErrSettingsNotFound) {
-					return fmt.Errorf("load settings timezone: %w", err)
+				opts.reportCache = settings.ReportCache
+				outputFlag := cmd.Flags().Lookup("output")
+				if settings.DefaultOutput != nil && (outputFlag == nil || !outputFlag.Changed) && !outputFromEnv {
+					opts.Output = *settings.DefaultOutput
 				}
 			}
 
+			if value := strings.TrimSpace(os.Getenv(envCurrency)); value != "" {
+				currencyCode, err := domain.NormalizeCurrencyCode(value)
+				if err != nil {
+					return &rootCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: fmt.Sprintf("invalid %s value %q: currency must be a 3-letter ISO code", envCurrency, value),
+						Details: map[string]any{"field": envCurrency, "value": value},
+					}
+				}
+				opts.defaultCurrency = currencyCode
+			}
+
+			if !strictProvided {
+				codes, err := sqlitestore.NewSettingsRepo(db).ListStrictWarningCodes(cmd.Context())
+				if err != nil {
+					return newRootStartupError("DB_ERROR", fmt.Errorf("load strict warnings: %w", err))
+				}
+				opts.strictWarnings = codes
+				output.SetStrictWarnings(codes)
+			}
+
+			timezoneFlag := cmd.Flags().Lookup("timezone")
+			timezoneProvided := timezoneFlag != nil && timezoneFlag.Changed
+			if !timezoneProvided && !timezoneFromEnv && settingsFound && strings.TrimSpace(settings.DisplayTimezone) != "" {
+				opts.Timezone = settings.DisplayTimezone
+			}
+
 			if _, err := time.LoadLocation(opts.Timezone); err != nil {
-				return fmt.Errorf("invalid --timezone value %q: %w", opts.Timezone, err)
+				return &rootCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: fmt.Sprintf("invalid %s value %q: %v", timezoneSource, opts.Timezone, err),
+					Details: map[string]any{"field": "timezone", "value": opts.Timezone},
+				}
 			}
 			output.SetDisplayTimezone(opts.Timezone)
+			output.SetColorEnabled(!opts.NoColor && os.Getenv("NO_COLOR") == "")
 
 			opts.db = db
 			return nil
@@ -89,18 +312,29 @@ func NewRootCmd() *cobra.Command {
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
 			if opts.db != nil {
+				dispatchHooks(cmd, opts)
 				if err := opts.db.Close(); err != nil {
-					return fmt.Errorf("close sqlite db: %w", err)
+					return newRootStartupError("DB_ERROR", fmt.Errorf("close sqlite db: %w", err))
 				}
 			}
 			return nil
 		},
 	}
 
-	cmd.PersistentFlags().StringVar(&opts.Output, "output", output.FormatHuman, "Output format: human|json")
-	cmd.PersistentFlags().StringVar(&opts.Timezone, "timezone", "UTC", "Display timezone (IANA, e.g. America/New_York)")
-	cmd.PersistentFlags().StringVar(&opts.DBPath, "db-path", opts.DBPath, "SQLite database path")
-	cmd.PersistentFlags().StringVar(&opts.MigrationsDir, "migrations-dir", opts.MigrationsDir, "Migrations directory path")
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &rootCLIError{Code: "INVALID_ARGUMENT", Message: err.Error(), Details: map[string]any{}}
+	})
+
+	cmd.PersistentFlags().StringVar(&opts.Output, "output", output.FormatHuman, "Output format: human|json (env BUDGETTO_OUTPUT)")
+	cmd.PersistentFlags().StringVar(&opts.Timezone, "timezone", "UTC", "Display timezone (IANA, e.g. America/New_York; env BUDGETTO_TIMEZONE)")
+	cmd.PersistentFlags().StringVar(&opts.DBPath, "db-path", opts.DBPath, "SQLite database path (env BUDGETTO_DB)")
+	cmd.PersistentFlags().StringVar(&opts.MigrationsDir, "migrations-dir", opts.MigrationsDir, "Read migrations from this directory instead of the ones built into the binary")
+	cmd.PersistentFlags().BoolVar(&opts.NoColor, "no-color", false, "Disable ANSI colors in human output (also honors NO_COLOR)")
+	cmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Log SQL statement timing and FX calls to stderr")
+	cmd.PersistentFlags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Drop status and footer lines from human output; JSON output is unchanged")
+	cmd.PersistentFlags().BoolVar(&opts.NoSnapshot, "no-snapshot", false, "Skip the automatic safety snapshot taken before data import/restore and bulk updates")
+	cmd.PersistentFlags().StringSliceVar(&opts.StrictWarnings, "strict-warnings", nil, "Fail instead of warning for these codes (all|CAP_EXCEEDED|CATEGORY_CAP_EXCEEDED|CARD_LIMIT_EXCEEDED|FX_ESTIMATE_USED); bare flag means all")
+	cmd.PersistentFlags().Lookup("strict-warnings").NoOptDefVal = domain.StrictWarningsAll
 
 	cmd.AddCommand(
 		NewCategoryCmd(opts),
@@ -108,14 +342,85 @@ func NewRootCmd() *cobra.Command {
 		NewCardCmd(opts),
 		NewBankAccountCmd(opts),
 		NewEntryCmd(opts),
+		NewPayeeCmd(opts),
+		NewSearchCmd(opts),
+		NewCleanupCmd(opts),
+		NewBotCmd(opts),
+		NewSettleCmd(opts),
+		NewVerifyCmd(opts),
+		NewStatsCmd(opts),
 		NewSavingsCmd(opts),
+		NewNetWorthCmd(opts),
+		NewAssetCmd(opts),
+		NewLoanCmd(opts),
+		NewSubscriptionsCmd(opts),
+		NewEnvelopeCmd(opts),
 		NewScheduleCmd(opts),
 		NewCapCmd(opts),
 		NewReportCmd(opts),
+		NewInflationCmd(opts),
+		NewCurrencyCmd(opts),
+		NewFXCmd(opts),
 		NewBalanceCmd(opts),
 		NewSetupCmd(opts),
 		NewDataCmd(opts),
+		NewEventsCmd(opts),
+		NewMigrateCmd(opts),
+		NewDoctorCmd(opts),
+		NewOpsCmd(opts),
+		NewSettingsCmd(opts),
 	)
 
 	return cmd
 }
+
+// envFlagValue returns the value of envName when it is set and the flag
+// flagName was not given on the command line.
+func envFlagValue(cmd *cobra.Command, flagName, envName string) (string, bool) {
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		return "", false
+	}
+	value := strings.TrimSpace(os.Getenv(envName))
+	return value, value != ""
+}
+
+// configureLogging routes slog to stderr: debug with --verbose, errors only
+// with --quiet, warnings otherwise. stdout stays reserved for the envelope.
+func configureLogging(w io.Writer, opts *RootOptions) {
+	level := slog.LevelWarn
+	switch {
+	case opts.Verbose:
+		level = slog.LevelDebug
+	case opts.Quiet:
+		level = slog.LevelError
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+// amountFormat returns the saved amount input format, defaulting to dot-decimal
+// when settings have not been initialized.
+func amountFormat(opts *RootOptions) string {
+	if opts == nil || strings.TrimSpace(opts.amountFormat) == "" {
+		return domain.AmountFormatDotDecimal
+	}
+	return opts.amountFormat
+}
+
+// defaultCurrency returns the saved default currency, falling back to the
+// entry flag default when settings have not been initialized.
+func defaultCurrency(opts *RootOptions) string {
+	if opts == nil || strings.TrimSpace(opts.defaultCurrency) == "" {
+		return defaultEntryCurrency
+	}
+	return opts.defaultCurrency
+}
+
+// applyDefaultCurrencyFlag points target, the value of a --currency flag that
+// was left unset, at the resolved default currency (BUDGETTO_CURRENCY, then
+// settings) instead of the flag's static default.
+func applyDefaultCurrencyFlag(cmd *cobra.Command, opts *RootOptions, target *string) {
+	if cmd == nil || target == nil || cmd.Flags().Changed("currency") {
+		return
+	}
+	*target = defaultCurrency(opts)
+}
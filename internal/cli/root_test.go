@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+)
+
+func TestExecuteMapsEscapedErrorsToExitCodes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "budget.db")
+
+	cases := []struct {
+		name string
+		args []string
+		code string
+		exit int
+	}{
+		{name: "unknown flag", args: []string{"entry", "list", "--bogus"}, code: "INVALID_ARGUMENT", exit: 2},
+		{name: "unknown command", args: []string{"bogus"}, code: "INVALID_ARGUMENT", exit: 2},
+		{name: "invalid timezone", args: []string{"--timezone", "Mars/Base", "entry", "list"}, code: "INVALID_ARGUMENT", exit: 2},
+		{name: "not found envelope", args: []string{"entry", "delete", "999"}, code: "NOT_FOUND", exit: 3},
+	}
+
+	for _, tc := range cases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		args := append([]string{"--db-path", dbPath, "--output", "json"}, tc.args...)
+		if exit := Execute(args, stdout, stderr); exit != tc.exit {
+			t.Fatalf("%s: expected exit %d, got %d stdout=%s stderr=%s", tc.name, tc.exit, exit, stdout, stderr)
+		}
+
+		payload := map[string]any{}
+		if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+			t.Fatalf("%s: expected a JSON envelope: %v raw=%s", tc.name, err, stdout)
+		}
+		if code := mustMap(t, payload["error"])["code"]; code != tc.code {
+			t.Fatalf("%s: expected %s, got %v", tc.name, tc.code, payload)
+		}
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exit := Execute([]string{"--db-path", dbPath, "entry", "list", "--bogus"}, stdout, stderr); exit != 2 || stderr.Len() == 0 {
+		t.Fatalf("expected human flag errors on stderr with exit 2, got exit=%d stderr=%q", exit, stderr)
+	}
+}
+
+func TestExecuteReadsGlobalFlagsFromEnvironment(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "budget.db")
+	t.Setenv("BUDGETTO_DB", dbPath)
+	t.Setenv("BUDGETTO_OUTPUT", "json")
+
+	run := func(args ...string) map[string]any {
+		t.Helper()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		Execute(args, stdout, stderr)
+		payload := map[string]any{}
+		if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+			t.Fatalf("%v: expected a JSON envelope from BUDGETTO_OUTPUT: %v raw=%s stderr=%s", args, err, stdout, stderr)
+		}
+		return payload
+	}
+
+	root := mustMap(t, run()["data"])
+	if root["db_path"] != dbPath || root["timezone"] != "UTC" {
+		t.Fatalf("expected BUDGETTO_DB to pick the database, got %v", root)
+	}
+
+	mustEntrySuccess(t, run("setup", "init", "--default-currency", "USD", "--timezone", "Europe/Berlin"))
+	t.Setenv("BUDGETTO_TIMEZONE", "America/New_York")
+	t.Setenv("BUDGETTO_CURRENCY", "eur")
+
+	if timezone := mustMap(t, run()["data"])["timezone"]; timezone != "America/New_York" {
+		t.Fatalf("expected BUDGETTO_TIMEZONE to win over the stored setting, got %v", timezone)
+	}
+	if timezone := mustMap(t, run("--timezone", "Asia/Tokyo")["data"])["timezone"]; timezone != "Asia/Tokyo" {
+		t.Fatalf("expected --timezone to win over BUDGETTO_TIMEZONE, got %v", timezone)
+	}
+
+	added := run("entry", "quick", "coffee", "5.00", "2026-03-01")
+	if currency := mustMap(t, mustMap(t, added["data"])["entry"])["currency_code"]; currency != "EUR" {
+		t.Fatalf("expected BUDGETTO_CURRENCY to win over default_currency, got %v", added)
+	}
+	added = run("entry", "add", "--type", "expense", "--amount", "12.50", "--date", "2026-03-02")
+	if currency := mustMap(t, mustMap(t, added["data"])["entry"])["currency_code"]; currency != "EUR" {
+		t.Fatalf("expected entry add without --currency to use BUDGETTO_CURRENCY, got %v", added)
+	}
+	capSet := run("cap", "set", "--month", "2026-03", "--amount", "400")
+	if currency := mustMap(t, mustMap(t, capSet["data"])["cap"])["currency_code"]; currency != "EUR" {
+		t.Fatalf("expected cap set without --currency to use BUDGETTO_CURRENCY, got %v", capSet)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exit := Execute([]string{"--output", "human"}, stdout, stderr); exit != 0 || strings.HasPrefix(strings.TrimSpace(stdout.String()), "{") {
+		t.Fatalf("expected --output to win over BUDGETTO_OUTPUT, got exit=%d stdout=%s", exit, stdout)
+	}
+
+	t.Setenv("BUDGETTO_CURRENCY", "euros")
+	invalid := run("entry", "list")
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for a bad BUDGETTO_CURRENCY, got %v", invalid)
+	}
+}
+
+func TestExecuteDeliversEnvelopesToHooks(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "budget.db")
+	capPayload := filepath.Join(dir, "cap.json")
+
+	received := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r.Header.Get(service.HookEventHeader) + " " + string(body)
+	}))
+	t.Cleanup(server.Close)
+
+	run := func(args ...string) map[string]any {
+		t.Helper()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		if exit := Execute(append([]string{"--db-path", dbPath, "--output", "json"}, args...), stdout, stderr); exit != 0 {
+			t.Fatalf("%v: expected exit 0, got %d stdout=%s stderr=%s", args, exit, stdout, stderr)
+		}
+		payload := map[string]any{}
+		if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+			t.Fatalf("%v: expected a JSON envelope: %v raw=%s", args, err, stdout)
+		}
+		return payload
+	}
+
+	run("settings", "hooks", "add", "--event", "cap_exceeded", "--command", "cat > "+capPayload)
+	run("settings", "hooks", "add", "--event", "card_due", "--url", server.URL, "--within-days", "5")
+	if count := mustMap(t, run("settings", "hooks", "list")["data"])["count"]; count != float64(2) {
+		t.Fatalf("expected two hooks, got %v", count)
+	}
+
+	run("cap", "set", "--month", "2026-02", "--amount", "5.00", "--currency", "USD")
+	run("entry", "add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-02-03")
+	raw, err := os.ReadFile(capPayload)
+	if err != nil {
+		t.Fatalf("expected cap_exceeded hook to write its payload: %v", err)
+	}
+	if !strings.Contains(string(raw), domain.WarningCodeCapExceeded) {
+		t.Fatalf("expected envelope with CAP_EXCEEDED on stdin, got %s", raw)
+	}
+
+	run("card", "add", "--nickname", "Main", "--last4", "1234", "--brand", "visa", "--card-type", "credit", "--due-day", "15")
+	run("card", "due", "list", "--as-of", "2026-03-01")
+	select {
+	case got := <-received:
+		t.Fatalf("expected no card_due delivery 14 days ahead, got %s", got)
+	default:
+	}
+
+	run("card", "due", "list", "--as-of", "2026-03-12")
+	select {
+	case got := <-received:
+		if !strings.HasPrefix(got, domain.HookEventCardDue+" ") || !strings.Contains(got, `"nickname":"Main"`) {
+			t.Fatalf("unexpected card_due delivery %s", got)
+		}
+	default:
+		t.Fatalf("expected card_due delivery 3 days ahead")
+	}
+}
+
+func TestExecuteWithSharedMemoryDatabaseExportsSession(t *testing.T) {
+	dbPath := "file:root-memory-session?mode=memory&cache=shared"
+
+	run := func(args ...string) (*bytes.Buffer, *bytes.Buffer) {
+		t.Helper()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		if exit := Execute(append([]string{"--db-path", dbPath, "--output", "json"}, args...), stdout, stderr); exit != 0 {
+			t.Fatalf("%v: expected exit 0, got %d stdout=%s stderr=%s", args, exit, stdout, stderr)
+		}
+		return stdout, stderr
+	}
+
+	run("entry", "add", "--type", "expense", "--amount", "12.50", "--currency", "USD", "--date", "2026-02-10", "--note", "in memory")
+
+	exported, _ := run("data", "export", "--resource", "entries", "--format", "json", "--file", "-")
+	payload := map[string]any{}
+	if err := json.Unmarshal(exported.Bytes(), &payload); err != nil {
+		t.Fatalf("decode export: %v raw=%s", err, exported)
+	}
+	entries := mustAnySlice(t, payload["entries"])
+	if len(entries) != 1 || mustMap(t, entries[0])["note"] != "in memory" {
+		t.Fatalf("expected the entry added earlier in the session, got %v", entries)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exit := Execute([]string{"--db-path", dbPath, "--output", "json", "data", "restore", "--file", filepath.Join(t.TempDir(), "backup.db")}, stdout, stderr); exit != 2 {
+		t.Fatalf("expected data restore into memory to exit 2, got %d stdout=%s", exit, stdout)
+	}
+	restore := map[string]any{}
+	if err := json.Unmarshal(stdout.Bytes(), &restore); err != nil {
+		t.Fatalf("decode restore envelope: %v raw=%s", err, stdout)
+	}
+	if code := mustMap(t, restore["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for restore into memory, got %v", restore)
+	}
+}
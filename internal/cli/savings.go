@@ -120,7 +120,8 @@ func newSavingsTransferAddCmd(opts *RootOptions) *cobra.Command {
 				return printSavingsError(cmd, savingsOutputFormat(opts), err)
 			}
 
-			input, err := buildSavingsAddInput(cmd, flags, true)
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currency)
+			input, err := buildSavingsAddInput(cmd, flags, true, amountFormat(opts))
 			if err != nil {
 				return printSavingsError(cmd, savingsOutputFormat(opts), err)
 			}
@@ -137,7 +138,7 @@ func newSavingsTransferAddCmd(opts *RootOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Amount in major units (e.g. 74.25)")
-	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (e.g. USD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
 	cmd.Flags().StringVar(&flags.dateRaw, "date", "", "Savings event date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.sourceAccountIDRaw, "source-account-id", "", "Optional source bank account ID")
 	cmd.Flags().StringVar(&flags.destinationAccountIDRaw, "destination-account-id", "", "Optional destination bank account ID")
@@ -166,7 +167,8 @@ func newSavingsEntryAddCmd(opts *RootOptions) *cobra.Command {
 				return printSavingsError(cmd, savingsOutputFormat(opts), err)
 			}
 
-			input, err := buildSavingsAddInput(cmd, flags, false)
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currency)
+			input, err := buildSavingsAddInput(cmd, flags, false, amountFormat(opts))
 			if err != nil {
 				return printSavingsError(cmd, savingsOutputFormat(opts), err)
 			}
@@ -183,7 +185,7 @@ func newSavingsEntryAddCmd(opts *RootOptions) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Amount in major units (e.g. 74.25)")
-	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (e.g. USD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
 	cmd.Flags().StringVar(&flags.dateRaw, "date", "", "Savings event date (RFC3339 or YYYY-MM-DD)")
 	cmd.Flags().StringVar(&flags.accountIDRaw, "account-id", "", "Optional savings bank account ID")
 	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
@@ -281,7 +283,7 @@ func newSavingsService(opts *RootOptions) (*service.SavingsService, error) {
 	return svc, nil
 }
 
-func buildSavingsAddInput(cmd *cobra.Command, flags *savingsAddFlags, isTransfer bool) (service.SavingsAddInput, error) {
+func buildSavingsAddInput(cmd *cobra.Command, flags *savingsAddFlags, isTransfer bool, amountFormat string) (service.SavingsAddInput, error) {
 	if flags == nil {
 		return service.SavingsAddInput{}, &savingsCLIError{
 			Code:    "INTERNAL_ERROR",
@@ -304,7 +306,7 @@ func buildSavingsAddInput(cmd *cobra.Command, flags *savingsAddFlags, isTransfer
 		}
 	}
 
-	amountMinor, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currency)
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat)
 	if err != nil {
 		return service.SavingsAddInput{}, err
 	}
@@ -466,6 +468,7 @@ func codeFromSavingsError(err error) string {
 		return "INVALID_DATE_RANGE"
 	case errors.Is(err, domain.ErrInvalidAmount),
 		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
 		errors.Is(err, domain.ErrAmountOverflow),
 		errors.Is(err, domain.ErrInvalidAmountMinor),
 		errors.Is(err, domain.ErrInvalidTransactionDate),
@@ -493,6 +496,8 @@ func messageFromSavingsError(err error) string {
 		return "amount must be a valid decimal number"
 	case errors.Is(err, domain.ErrInvalidAmountPrecision):
 		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
 	case errors.Is(err, domain.ErrAmountOverflow):
 		return "amount is too large"
 	case errors.Is(err, domain.ErrInvalidAmountMinor):
@@ -82,7 +82,8 @@ func newScheduleAddCmd(opts *RootOptions) *cobra.Command {
 				return printScheduleError(cmd, scheduleOutputFormat(opts), err)
 			}
 
-			input, err := buildScheduleAddInput(cmd, flags)
+			applyDefaultCurrencyFlag(cmd, opts, &flags.currency)
+			input, err := buildScheduleAddInput(cmd, flags, amountFormat(opts))
 			if err != nil {
 				return printScheduleError(cmd, scheduleOutputFormat(opts), err)
 			}
@@ -106,7 +107,7 @@ func newScheduleAddCmd(opts *RootOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.name, "name", "", "Schedule name")
 	cmd.Flags().StringVar(&flags.amount, "amount", "", "Amount in major units (e.g. 1200.00)")
-	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (e.g. USD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", defaultEntryCurrency, "ISO currency code (default from settings)")
 	cmd.Flags().IntVar(&flags.day, "day", 0, "Day of month (1-28)")
 	cmd.Flags().StringVar(&flags.startMonthRaw, "start-month", "", "Start month in YYYY-MM")
 	cmd.Flags().StringVar(&flags.endMonthRaw, "end-month", "", "Optional end month in YYYY-MM")
@@ -254,7 +255,7 @@ func newScheduleService(opts *RootOptions) (*service.ScheduleService, error) {
 	return svc, nil
 }
 
-func buildScheduleAddInput(cmd *cobra.Command, flags *scheduleAddFlags) (domain.ScheduledPaymentAddInput, error) {
+func buildScheduleAddInput(cmd *cobra.Command, flags *scheduleAddFlags, amountFormat string) (domain.ScheduledPaymentAddInput, error) {
 	if flags == nil {
 		return domain.ScheduledPaymentAddInput{}, &scheduleCLIError{
 			Code:    "INTERNAL_ERROR",
@@ -308,7 +309,7 @@ func buildScheduleAddInput(cmd *cobra.Command, flags *scheduleAddFlags) (domain.
 		categoryID = &id
 	}
 
-	amountMinor, err := domain.ParseMajorAmountToMinor(flags.amount, flags.currency)
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat)
 	if err != nil {
 		return domain.ScheduledPaymentAddInput{}, err
 	}
@@ -420,9 +421,11 @@ func codeFromScheduleError(err error) string {
 		errors.Is(err, domain.ErrInvalidMonthKey),
 		errors.Is(err, domain.ErrInvalidAmount),
 		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
 		errors.Is(err, domain.ErrAmountOverflow),
 		errors.Is(err, domain.ErrInvalidAmountMinor),
-		errors.Is(err, domain.ErrInvalidCategoryID):
+		errors.Is(err, domain.ErrInvalidCategoryID),
+		errors.Is(err, domain.ErrCategoryArchived):
 		return "INVALID_ARGUMENT"
 	case errors.Is(err, domain.ErrScheduleNotFound),
 		errors.Is(err, domain.ErrCategoryNotFound):
@@ -456,6 +459,8 @@ func messageFromScheduleError(err error) string {
 		return "amount must be a valid decimal number"
 	case errors.Is(err, domain.ErrInvalidAmountPrecision):
 		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
 	case errors.Is(err, domain.ErrAmountOverflow):
 		return "amount is too large"
 	case errors.Is(err, domain.ErrInvalidAmountMinor):
@@ -468,6 +473,8 @@ func messageFromScheduleError(err error) string {
 		return "schedule not found"
 	case errors.Is(err, domain.ErrCategoryNotFound):
 		return "category not found"
+	case errors.Is(err, domain.ErrCategoryArchived):
+		return "category is archived"
 	default:
 		message := strings.ToLower(err.Error())
 		if strings.Contains(message, "unique constraint") || strings.Contains(message, "constraint failed") {
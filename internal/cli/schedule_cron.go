@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	sqlitestore "boring-budget/internal/store/sqlite"
 )
 
 var (
@@ -22,7 +24,8 @@ func ensureScheduleCronRegistration(opts *RootOptions) error {
 	}
 
 	dbPath := strings.TrimSpace(opts.DBPath)
-	if dbPath == "" {
+	if dbPath == "" || sqlitestore.IsMemoryPath(dbPath) {
+		// A cron job cannot reach an in-memory database.
 		return nil
 	}
 
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type searchCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *searchCLIError) Error() string {
+	if e == nil {
+		return "search command error"
+	}
+	return e.Message
+}
+
+func NewSearchCmd(opts *RootOptions) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search <text>",
+		Short: "Find entries, categories, labels and cards by text",
+		Long:  "Search entry notes, category and label names, and card nicknames and descriptions for text (case-insensitive substring) and return the matches grouped by kind with their IDs.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return printSearchError(cmd, outputFormat(opts), &searchCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "search requires the text to look for",
+					Details: map[string]any{"required_args": []string{"text"}},
+				})
+			}
+
+			svc, err := newSearchService(opts)
+			if err != nil {
+				return printSearchError(cmd, outputFormat(opts), err)
+			}
+
+			result, err := svc.Search(cmd.Context(), strings.Join(args, " "), limit)
+			if err != nil {
+				return printSearchError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"query":      result.Query,
+				"entries":    result.Entries,
+				"categories": result.Categories,
+				"labels":     result.Labels,
+				"cards":      result.Cards,
+				"count":      result.Count(),
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, searchTables(result))
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", domain.DefaultSearchLimit, "Maximum matches to return per group")
+
+	return cmd
+}
+
+func newSearchService(opts *RootOptions) (*service.SearchService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &searchCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewSearchService(sqlitestore.NewSearchRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("search service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printSearchError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *searchCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromSearchError(err), messageFromSearchError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromSearchError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSearchText),
+		errors.Is(err, domain.ErrInvalidSearchLimit):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromSearchError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSearchText):
+		return "search text cannot be empty"
+	case errors.Is(err, domain.ErrInvalidSearchLimit):
+		return "limit must be greater than zero"
+	default:
+		return "database operation failed"
+	}
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestSearchCommandJSONGroupsMatchesByKind(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Groceries")
+	insertTestCategory(t, db, "Rent")
+	labelID := insertTestLabel(t, db, "grocery-run")
+	cardID := insertTestCard(t, db, "Everyday", "Used for GROCERIES only", "1234", "visa", "debit", 0)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-03-02", "--note", "weekly grocery shop"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "900.00", "--currency", "USD", "--date", "2026-03-01", "--note", "march rent"}))
+
+	payload := executeSearchCmdJSON(t, db, []string{"GROCER"})
+	mustEntrySuccess(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["count"].(float64) != 4 {
+		t.Fatalf("expected 4 matches, got %v", data)
+	}
+
+	entries := mustAnySlice(t, data["entries"])
+	if len(entries) != 1 || mustMap(t, entries[0])["note"] != "weekly grocery shop" {
+		t.Fatalf("expected the grocery entry, got %v", entries)
+	}
+	categories := mustAnySlice(t, data["categories"])
+	if len(categories) != 1 || int64(mustMap(t, categories[0])["id"].(float64)) != categoryID {
+		t.Fatalf("expected category %d, got %v", categoryID, categories)
+	}
+	labels := mustAnySlice(t, data["labels"])
+	if len(labels) != 1 || int64(mustMap(t, labels[0])["id"].(float64)) != labelID {
+		t.Fatalf("expected label %d, got %v", labelID, labels)
+	}
+	cards := mustAnySlice(t, data["cards"])
+	if len(cards) != 1 || int64(mustMap(t, cards[0])["id"].(float64)) != cardID {
+		t.Fatalf("expected card %d matched by description, got %v", cardID, cards)
+	}
+
+	limited := mustMap(t, executeSearchCmdJSON(t, db, []string{"r", "--limit", "1"})["data"])
+	if len(mustAnySlice(t, limited["entries"])) != 1 || len(mustAnySlice(t, limited["categories"])) != 1 {
+		t.Fatalf("expected --limit to cap each group, got %v", limited)
+	}
+
+	blank := executeSearchCmdJSON(t, db, []string{"  "})
+	if code := mustMap(t, blank["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for blank text, got %v", blank)
+	}
+}
+
+func executeSearchCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	cmd := NewSearchCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute search command: %v", err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal search payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
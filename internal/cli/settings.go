@@ -0,0 +1,496 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type settingsWarningsSetFlags struct {
+	currency   string
+	percentRaw string
+	amountRaw  string
+	off        bool
+	reset      bool
+}
+
+type settingsCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *settingsCLIError) Error() string {
+	if e == nil {
+		return "settings command error"
+	}
+	return e.Message
+}
+
+func NewSettingsCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Tune settings after setup",
+	}
+
+	cmd.AddCommand(
+		newSettingsListCmd(opts),
+		newSettingsGetCmd(opts),
+		newSettingsSetCmd(opts),
+		newSettingsWarningsCmd(opts),
+		newSettingsHooksCmd(opts),
+	)
+
+	return cmd
+}
+
+func newSettingsListCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every setting with its value",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			values, err := svc.List(cmd.Context())
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"settings": values, "count": len(values)}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newSettingsGetCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Show one setting",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings get requires exactly one key",
+					Details: map[string]any{"args": args, "keys": domain.SettingKeys},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			value, err := svc.GetValue(cmd.Context(), args[0])
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"setting": value}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newSettingsSetCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change one setting",
+		Long: `Change one setting. Settings must exist (run setup init first).
+
+Keys:
+  default_currency                ISO code used when --currency is omitted
+  timezone                        IANA display timezone
+  default_output                  human|json when --output is omitted (none clears it)
+  default_card_id                 card used by entry add --payment-method card without a card selector (none clears it)
+  amount_format                   dot_decimal|comma_decimal
+  orphan_count_threshold          orphan entries before ORPHAN_COUNT_THRESHOLD_EXCEEDED
+  orphan_spending_threshold_bps   default orphan spending share in basis points (1-10000)
+  fiscal_month_start_day          day (1-28) the fiscal month starts on
+  default_recorded_by             person entry add records entries as when --by is omitted (none clears it)
+  auto_snapshot                   on|off: snapshot the database before data import/restore and bulk updates
+  fx_cache_ttl_hours              hours (0-8760) a cached latest FX rate is reused for future-dated conversions
+  fx_stale_after_days             days (1-3650) after which a fallback FX rate raises FX_RATE_STALE
+  report_cache                    on|off: reuse a generated report until the data changes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings set requires a key and a value",
+					Details: map[string]any{"args": args, "keys": domain.SettingKeys},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			value, err := svc.SetValue(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"setting": value}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newSettingsWarningsCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "warnings",
+		Short: "Configure when report warnings fire",
+	}
+
+	cmd.AddCommand(
+		newSettingsWarningsSetCmd(opts),
+		newSettingsWarningsListCmd(opts),
+		newSettingsWarningsStrictCmd(opts),
+	)
+
+	return cmd
+}
+
+func newSettingsWarningsSetCmd(opts *RootOptions) *cobra.Command {
+	flags := &settingsWarningsSetFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the orphan-spending warning threshold for a currency or all currencies",
+		Long: `Set when ORPHAN_SPENDING_THRESHOLD_EXCEEDED fires for one currency
+(--currency EUR) or for every currency without its own rule (--currency all).
+
+Pass exactly one of:
+  --percent 7.5     uncategorized spend above 7.5% of month spend or cap
+  --amount 200.00   uncategorized spend above a fixed amount (needs a currency)
+  --off             never warn
+  --reset           remove the rule and fall back to the next one
+
+A currency rule wins over the all-currencies rule, which wins over the
+setup default percentage.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings warnings set does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			if flags.reset {
+				if err := validateSettingsWarningsMode(cmd, "reset"); err != nil {
+					return printSettingsError(cmd, outputFormat(opts), err)
+				}
+				currencyCode, err := domain.NormalizeOrphanSpendingThresholdCurrency(flags.currency)
+				if err != nil {
+					return printSettingsError(cmd, outputFormat(opts), err)
+				}
+				if err := svc.ResetOrphanSpendingThreshold(cmd.Context(), currencyCode); err != nil {
+					return printSettingsError(cmd, outputFormat(opts), err)
+				}
+				env := output.NewSuccessEnvelope(map[string]any{
+					"orphan_spending": map[string]any{"currency_code": currencyCode, "reset": true},
+				}, nil)
+				return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+			}
+
+			input, err := buildOrphanSpendingThresholdInput(cmd, opts, flags)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			threshold, err := svc.SetOrphanSpendingThreshold(cmd.Context(), input)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"orphan_spending": threshold}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.currency, "currency", "all", "Currency code, or all for every currency without its own rule")
+	cmd.Flags().StringVar(&flags.percentRaw, "percent", "", "Warn when orphan spend exceeds this percent of month spend or cap (e.g. 7.5)")
+	cmd.Flags().StringVar(&flags.amountRaw, "amount", "", "Warn when orphan spend exceeds this amount in --currency")
+	cmd.Flags().BoolVar(&flags.off, "off", false, "Never warn about orphan spending")
+	cmd.Flags().BoolVar(&flags.reset, "reset", false, "Remove the rule for --currency")
+
+	return cmd
+}
+
+func newSettingsWarningsListCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List warning thresholds",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings warnings list does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			thresholds, err := svc.WarningThresholds(cmd.Context())
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"warnings": thresholds}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+}
+
+func newSettingsWarningsStrictCmd(opts *RootOptions) *cobra.Command {
+	var (
+		codes []string
+		off   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "strict",
+		Short: "Choose which warnings fail commands instead of warning",
+		Long: `Store the strict-warnings policy used when --strict-warnings is not passed.
+
+  --codes CAP_EXCEEDED,FX_ESTIMATE_USED   escalate these codes (or all)
+  --off                                   warn only
+
+Escalated writes are rolled back and the command fails with STRICT_WARNING.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settings warnings strict does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if cmd.Flags().Changed("codes") == off {
+				return printSettingsError(cmd, outputFormat(opts), &settingsCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "pass exactly one of codes or off",
+					Details: map[string]any{"fields": []string{"codes", "off"}},
+				})
+			}
+
+			svc, err := newSettingsService(opts)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			if off {
+				codes = nil
+			}
+			stored, err := svc.SetStrictWarnings(cmd.Context(), codes)
+			if err != nil {
+				return printSettingsError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{"strict_warnings": stored}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&codes, "codes", nil, "Warning codes to escalate: all|CAP_EXCEEDED|CATEGORY_CAP_EXCEEDED|CARD_LIMIT_EXCEEDED|FX_ESTIMATE_USED")
+	cmd.Flags().BoolVar(&off, "off", false, "Turn the stored strict-warnings policy off")
+
+	return cmd
+}
+
+// validateSettingsWarningsMode checks that exactly one of the mode flags was
+// passed and that it is the expected one.
+func validateSettingsWarningsMode(cmd *cobra.Command, expected string) error {
+	modes := []string{}
+	for _, name := range []string{"percent", "amount", "off", "reset"} {
+		if cmd.Flags().Changed(name) {
+			modes = append(modes, name)
+		}
+	}
+	if len(modes) != 1 || (expected != "" && modes[0] != expected) {
+		return &settingsCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "pass exactly one of percent, amount, off or reset",
+			Details: map[string]any{"fields": []string{"percent", "amount", "off", "reset"}, "given": modes},
+		}
+	}
+	return nil
+}
+
+func buildOrphanSpendingThresholdInput(cmd *cobra.Command, opts *RootOptions, flags *settingsWarningsSetFlags) (domain.OrphanSpendingThresholdInput, error) {
+	if err := validateSettingsWarningsMode(cmd, ""); err != nil {
+		return domain.OrphanSpendingThresholdInput{}, err
+	}
+
+	input := domain.OrphanSpendingThresholdInput{CurrencyCode: flags.currency}
+	switch {
+	case flags.off:
+		input.Mode = domain.OrphanSpendingThresholdModeOff
+	case cmd.Flags().Changed("percent"):
+		bps, err := parsePercentToBPS(flags.percentRaw)
+		if err != nil {
+			return domain.OrphanSpendingThresholdInput{}, err
+		}
+		input.Mode = domain.OrphanSpendingThresholdModePercent
+		input.PercentBPS = bps
+	default:
+		currencyCode, err := domain.NormalizeOrphanSpendingThresholdCurrency(flags.currency)
+		if err != nil {
+			return domain.OrphanSpendingThresholdInput{}, err
+		}
+		if currencyCode == domain.OrphanSpendingThresholdAllCurrencies {
+			return domain.OrphanSpendingThresholdInput{}, domain.ErrOrphanThresholdAmountNeedsCurrency
+		}
+		amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amountRaw, currencyCode, amountFormat(opts))
+		if err != nil {
+			return domain.OrphanSpendingThresholdInput{}, err
+		}
+		input.Mode = domain.OrphanSpendingThresholdModeAbsolute
+		input.AmountMinor = amountMinor
+	}
+	return input, nil
+}
+
+// parsePercentToBPS converts "7.5" to 750 basis points; values must be in
+// (0, 100] with at most two decimals.
+func parsePercentToBPS(raw string) (int64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(raw), "%"), 64)
+	bps := math.Round(value * 100)
+	if err != nil || bps <= 0 || bps > 10000 || math.Abs(value*100-bps) > 1e-9 {
+		return 0, &settingsCLIError{
+			Code:    "INVALID_ARGUMENT",
+			Message: "percent must be greater than 0 and at most 100, with up to two decimals",
+			Details: map[string]any{"field": "percent", "value": raw},
+		}
+	}
+	return int64(bps), nil
+}
+
+func newSettingsService(opts *RootOptions) (*service.SettingsService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &settingsCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	cardSvc, err := service.NewCardService(sqlitestore.NewCardRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("card service init: %w", err)
+	}
+
+	svc, err := service.NewSettingsService(sqlitestore.NewSettingsRepo(opts.db), service.WithSettingsCardResolver(cardSvc))
+	if err != nil {
+		return nil, fmt.Errorf("settings service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printSettingsError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *settingsCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromSettingsError(err), messageFromSettingsError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromSettingsError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode), errors.Is(err, domain.ErrInvalidOrphanThresholdMode), errors.Is(err, domain.ErrInvalidOrphanThresholdValue), errors.Is(err, domain.ErrOrphanThresholdAmountNeedsCurrency):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrInvalidStrictWarningCode):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrInvalidSettingKey), errors.Is(err, domain.ErrInvalidSettingValue), errors.Is(err, domain.ErrInvalidAmountFormat), errors.Is(err, domain.ErrInvalidCardID):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrInvalidAmount), errors.Is(err, domain.ErrInvalidAmountPrecision), errors.Is(err, domain.ErrAmountOverflow), errors.Is(err, domain.ErrAmbiguousAmount):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrInvalidHookID), errors.Is(err, domain.ErrInvalidHookEvent), errors.Is(err, domain.ErrInvalidHookTarget), errors.Is(err, domain.ErrInvalidHookWithinDays):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrOrphanThresholdNotFound), errors.Is(err, domain.ErrHookNotFound), errors.Is(err, domain.ErrSettingsNotFound), errors.Is(err, domain.ErrCardNotFound):
+		return "NOT_FOUND"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromSettingsError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter code or all"
+	case errors.Is(err, domain.ErrInvalidSettingKey):
+		return "key must be one of: " + strings.Join(domain.SettingKeys, "|")
+	case errors.Is(err, domain.ErrInvalidSettingValue):
+		return "invalid value for setting; see settings set --help"
+	case errors.Is(err, domain.ErrInvalidAmountFormat):
+		return "amount_format must be one of: dot_decimal|comma_decimal"
+	case errors.Is(err, domain.ErrInvalidCardID):
+		return "default_card_id must be a positive integer or none"
+	case errors.Is(err, domain.ErrCardNotFound):
+		return "card not found"
+	case errors.Is(err, domain.ErrSettingsNotFound):
+		return "settings are not initialized; run setup init first"
+	case errors.Is(err, domain.ErrOrphanThresholdAmountNeedsCurrency):
+		return "amount thresholds need a specific currency"
+	case errors.Is(err, domain.ErrInvalidOrphanThresholdMode), errors.Is(err, domain.ErrInvalidOrphanThresholdValue):
+		return "invalid orphan spending threshold"
+	case errors.Is(err, domain.ErrInvalidAmount), errors.Is(err, domain.ErrInvalidAmountPrecision), errors.Is(err, domain.ErrAmountOverflow), errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount must be a positive amount in the currency's precision"
+	case errors.Is(err, domain.ErrOrphanThresholdNotFound):
+		return "no orphan spending rule for that currency"
+	case errors.Is(err, domain.ErrInvalidStrictWarningCode):
+		return "codes must be all or one of " + strings.Join(domain.StrictableWarningCodes, ", ")
+	case errors.Is(err, domain.ErrInvalidHookID):
+		return "id must be a positive integer"
+	case errors.Is(err, domain.ErrInvalidHookEvent):
+		return "event must be one of: " + strings.Join(domain.HookEvents, "|")
+	case errors.Is(err, domain.ErrInvalidHookTarget):
+		return "command must not be empty and url must be an http(s) URL"
+	case errors.Is(err, domain.ErrInvalidHookWithinDays):
+		return "within-days applies only to card_due and must be between 0 and 31"
+	case errors.Is(err, domain.ErrHookNotFound):
+		return "hook not found"
+	default:
+		return "database operation failed"
+	}
+}
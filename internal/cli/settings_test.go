@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+)
+
+func TestSettingsWarningsSetTunesOrphanSpendingPerCurrency(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	categoryID := insertTestCategory(t, db, "Groceries")
+	for _, args := range [][]string{
+		{"add", "--type", "expense", "--amount", "940.00", "--currency", "EUR", "--date", "2026-03-02", "--category-id", fmt.Sprint(categoryID)},
+		{"add", "--type", "expense", "--amount", "60.00", "--currency", "EUR", "--date", "2026-03-03"},
+		{"add", "--type", "expense", "--amount", "990.00", "--currency", "USD", "--date", "2026-03-02", "--category-id", fmt.Sprint(categoryID)},
+		{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-03-04"},
+	} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, args))
+	}
+
+	// Default 5% rule: EUR orphan spend is 6% of month spend, USD is 1%.
+	assertOrphanSpendingWarnings(t, db, map[string]string{"EUR": "MONTH_SPEND"})
+
+	euro := executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--currency", "eur", "--amount", "100.00"})
+	assertSuccessJSONEnvelope(t, euro)
+	if rule := mustMap(t, mustMap(t, euro["data"])["orphan_spending"]); rule["mode"] != "absolute" || rule["amount_minor"].(float64) != 10000 {
+		t.Fatalf("unexpected EUR rule: %v", rule)
+	}
+	assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--percent", "0.5"}))
+	assertOrphanSpendingWarnings(t, db, map[string]string{"USD": "MONTH_SPEND"})
+
+	assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--currency", "USD", "--off"}))
+	assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--currency", "EUR", "--amount", "50"}))
+	assertOrphanSpendingWarnings(t, db, map[string]string{"EUR": "ABSOLUTE_AMOUNT"})
+
+	listed := executeSettingsCmdJSON(t, db, []string{"warnings", "list"})
+	rules := mustAnySlice(t, mustMap(t, mustMap(t, listed["data"])["warnings"])["orphan_spending"])
+	if len(rules) != 3 {
+		t.Fatalf("expected all-currencies, EUR and USD rules, got %v", rules)
+	}
+
+	assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--currency", "USD", "--reset"}))
+	assertOrphanSpendingWarnings(t, db, map[string]string{"EUR": "ABSOLUTE_AMOUNT", "USD": "MONTH_SPEND"})
+
+	for _, args := range [][]string{
+		{"warnings", "set", "--currency", "all", "--amount", "10.00"},
+		{"warnings", "set", "--percent", "5", "--off"},
+		{"warnings", "set", "--percent", "120"},
+	} {
+		if code := mustMap(t, executeSettingsCmdJSON(t, db, args)["error"])["code"]; code != "INVALID_ARGUMENT" {
+			t.Fatalf("expected INVALID_ARGUMENT for %v, got %v", args, code)
+		}
+	}
+	if code := mustMap(t, executeSettingsCmdJSON(t, db, []string{"warnings", "set", "--currency", "JPY", "--reset"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND resetting a missing rule, got %v", code)
+	}
+}
+
+func TestSettingsGetSetListValidatesKeys(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if code := mustMap(t, executeSettingsCmdJSON(t, db, []string{"list"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND before setup init, got %v", code)
+	}
+	executeSetupCmdRaw(t, db, output.FormatJSON, []string{"init", "--default-currency", "USD", "--timezone", "UTC"})
+	cardID := insertTestCard(t, db, "Default Card", "", "1357", "VISA", "credit", 5)
+
+	for key, value := range map[string]string{
+		"default-currency":       "eur",
+		"timezone":               "Europe/Madrid",
+		"default_output":         "JSON",
+		"default_card_id":        fmt.Sprint(cardID),
+		"fiscal_month_start_day": "25",
+		"orphan_count_threshold": "8",
+		"auto-snapshot":          "off",
+	} {
+		assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"set", key, value}))
+	}
+
+	got := mustMap(t, mustMap(t, executeSettingsCmdJSON(t, db, []string{"get", "default_currency"})["data"])["setting"])
+	if got["key"] != "default_currency" || got["value"] != "EUR" {
+		t.Fatalf("unexpected default_currency setting %v", got)
+	}
+
+	listed := mustMap(t, executeSettingsCmdJSON(t, db, []string{"list"})["data"])
+	values := map[string]any{}
+	for _, raw := range mustAnySlice(t, listed["settings"]) {
+		item := mustMap(t, raw)
+		values[item["key"].(string)] = item["value"]
+	}
+	want := map[string]any{
+		"timezone":               "Europe/Madrid",
+		"default_output":         "json",
+		"default_card_id":        float64(cardID),
+		"fiscal_month_start_day": float64(25),
+		"orphan_count_threshold": float64(8),
+		"amount_format":          "dot_decimal",
+		"auto_snapshot":          false,
+	}
+	for key, value := range want {
+		if values[key] != value {
+			t.Fatalf("expected %s=%v, got %v (all %v)", key, value, values[key], values)
+		}
+	}
+
+	assertSuccessJSONEnvelope(t, executeSettingsCmdJSON(t, db, []string{"set", "default_card_id", "none"}))
+	cleared := mustMap(t, mustMap(t, executeSettingsCmdJSON(t, db, []string{"get", "default_card_id"})["data"])["setting"])
+	if cleared["value"] != nil {
+		t.Fatalf("expected default_card_id cleared, got %v", cleared)
+	}
+
+	for _, args := range [][]string{
+		{"get", "colour"},
+		{"set", "default_output", "yaml"},
+		{"set", "fiscal_month_start_day", "31"},
+		{"set", "timezone", "Mars/Olympus"},
+		{"set", "orphan_spending_threshold_bps", "0"},
+		{"set", "auto_snapshot", "sometimes"},
+	} {
+		if code := mustMap(t, executeSettingsCmdJSON(t, db, args)["error"])["code"]; code != "INVALID_ARGUMENT" {
+			t.Fatalf("expected INVALID_ARGUMENT for %v, got %v", args, code)
+		}
+	}
+	if code := mustMap(t, executeSettingsCmdJSON(t, db, []string{"set", "default_card_id", "999"})["error"])["code"]; code != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND for an unknown card, got %v", code)
+	}
+}
+
+func TestSettingsWarningsStrictStoresPolicy(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	set := executeSettingsCmdJSON(t, db, []string{"warnings", "strict", "--codes", "fx_estimate_used,CAP_EXCEEDED"})
+	assertSuccessJSONEnvelope(t, set)
+	if codes := toStringSlice(mustAnySlice(t, mustMap(t, set["data"])["strict_warnings"])); strings.Join(codes, ",") != "CAP_EXCEEDED,FX_ESTIMATE_USED" {
+		t.Fatalf("unexpected strict warnings: %v", codes)
+	}
+
+	listed := executeSettingsCmdJSON(t, db, []string{"warnings", "list"})
+	if codes := mustAnySlice(t, mustMap(t, mustMap(t, listed["data"])["warnings"])["strict_warnings"]); len(codes) != 2 {
+		t.Fatalf("expected strict warnings in list, got %v", codes)
+	}
+
+	off := executeSettingsCmdJSON(t, db, []string{"warnings", "strict", "--off"})
+	if codes := mustAnySlice(t, mustMap(t, off["data"])["strict_warnings"]); len(codes) != 0 {
+		t.Fatalf("expected strict warnings off, got %v", codes)
+	}
+
+	for _, args := range [][]string{
+		{"warnings", "strict", "--codes", "ORPHAN_COUNT_THRESHOLD_EXCEEDED"},
+		{"warnings", "strict"},
+		{"warnings", "strict", "--codes", "all", "--off"},
+	} {
+		if code := mustMap(t, executeSettingsCmdJSON(t, db, args)["error"])["code"]; code != "INVALID_ARGUMENT" {
+			t.Fatalf("expected INVALID_ARGUMENT for %v, got %v", args, code)
+		}
+	}
+}
+
+// assertOrphanSpendingWarnings checks the March orphan-spending warnings by
+// currency and the trigger each one reports.
+func assertOrphanSpendingWarnings(t *testing.T, db *sql.DB, want map[string]string) {
+	t.Helper()
+
+	report := executeReportCmdJSON(t, db, []string{"monthly", "--month", "2026-03"})
+	got := map[string]string{}
+	for _, raw := range mustAnySlice(t, report["warnings"]) {
+		warning := mustMap(t, raw)
+		if warning["code"] != domain.WarningCodeOrphanSpendingExceeded {
+			continue
+		}
+		details := mustMap(t, warning["details"])
+		got[details["currency_code"].(string)] = strings.Join(toStringSlice(mustAnySlice(t, details["triggered_by"])), ",")
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected orphan spending warnings %v, got %v", want, got)
+	}
+}
+
+func toStringSlice(values []any) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, fmt.Sprint(value))
+	}
+	return out
+}
+
+func executeSettingsCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewSettingsCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute settings cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	raw := strings.TrimSpace(buf.String())
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		t.Fatalf("unmarshal settings payload: %v raw=%s", err, raw)
+	}
+	return payload
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type settleShowFlags struct {
+	currency string
+	person   string
+}
+
+type settleRecordFlags struct {
+	from     string
+	to       string
+	amount   string
+	currency string
+	dateRaw  string
+	note     string
+}
+
+type settleCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *settleCLIError) Error() string {
+	if e == nil {
+		return "settle command error"
+	}
+	return e.Message
+}
+
+func NewSettleCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settle",
+		Short: "Show and clear who owes whom for shared expenses",
+	}
+
+	cmd.AddCommand(
+		newSettleShowCmd(opts),
+		newSettleRecordCmd(opts),
+	)
+
+	return cmd
+}
+
+func newSettleShowCmd(opts *RootOptions) *cobra.Command {
+	flags := &settleShowFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show net balances between people per currency",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettleError(cmd, outputFormat(opts), &settleCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settle show does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newSettleService(opts)
+			if err != nil {
+				return printSettleError(cmd, outputFormat(opts), err)
+			}
+
+			balances, err := svc.Show(cmd.Context(), service.SettleShowFilter{
+				CurrencyCode: flags.currency,
+				Person:       flags.person,
+			})
+			if err != nil {
+				return printSettleError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"balances": balances,
+				"count":    len(balances),
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, settleBalanceTables(balances))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only show balances in this currency code")
+	cmd.Flags().StringVar(&flags.person, "person", "", "Only show balances involving this person (case-insensitive)")
+
+	return cmd
+}
+
+func newSettleRecordCmd(opts *RootOptions) *cobra.Command {
+	flags := &settleRecordFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record a payment that clears what one person owes another",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSettleError(cmd, outputFormat(opts), &settleCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "settle record does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if strings.TrimSpace(flags.from) == "" || strings.TrimSpace(flags.to) == "" {
+				return printSettleError(cmd, outputFormat(opts), &settleCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "from and to are required",
+					Details: map[string]any{"fields": []string{"from", "to"}},
+				})
+			}
+
+			input := service.SettleRecordInput{
+				From:         flags.from,
+				To:           flags.to,
+				CurrencyCode: flags.currency,
+				SettledAtUTC: flags.dateRaw,
+				Note:         flags.note,
+			}
+			if cmd.Flags().Changed("amount") {
+				if strings.TrimSpace(flags.currency) == "" {
+					return printSettleError(cmd, outputFormat(opts), &settleCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: "currency is required when amount is provided",
+						Details: map[string]any{"fields": []string{"amount", "currency"}},
+					})
+				}
+				amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.amount, flags.currency, amountFormat(opts))
+				if err != nil {
+					return printSettleError(cmd, outputFormat(opts), err)
+				}
+				input.AmountMinor = &amountMinor
+			}
+
+			svc, err := newSettleService(opts)
+			if err != nil {
+				return printSettleError(cmd, outputFormat(opts), err)
+			}
+
+			settlements, err := svc.Record(cmd.Context(), input)
+			if err != nil {
+				return printSettleError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"settlements": settlements,
+				"count":       len(settlements),
+			}, nil)
+			return output.Print(cmd.OutOrStdout(), outputFormat(opts), env)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.from, "from", "", "Person paying back")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Person being paid back")
+	cmd.Flags().StringVar(&flags.amount, "amount", "", "Amount in major units (defaults to the whole open balance; requires --currency)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Currency code to settle (defaults to every currency with an open balance)")
+	cmd.Flags().StringVar(&flags.dateRaw, "date", "", "Settlement date (RFC3339 or YYYY-MM-DD; defaults to now)")
+	cmd.Flags().StringVar(&flags.note, "note", "", "Optional note")
+
+	return cmd
+}
+
+func newSettleService(opts *RootOptions) (*service.SettleService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &settleCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewSettleService(sqlitestore.NewSettleRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("settle service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printSettleError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *settleCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromSettleError(err), messageFromSettleError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromSettleError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidSettlePerson),
+		errors.Is(err, domain.ErrInvalidSettlementAmount),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAmountPrecision),
+		errors.Is(err, domain.ErrAmbiguousAmount),
+		errors.Is(err, domain.ErrAmountOverflow),
+		errors.Is(err, domain.ErrInvalidTransactionDate):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, domain.ErrNothingToSettle):
+		return "NOT_FOUND"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromSettleError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidSettlePerson):
+		return "from and to must name two different people"
+	case errors.Is(err, domain.ErrInvalidSettlementAmount):
+		return "amount must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return "amount must be a valid decimal number"
+	case errors.Is(err, domain.ErrInvalidAmountPrecision):
+		return "amount has too many decimal places for currency"
+	case errors.Is(err, domain.ErrAmbiguousAmount):
+		return "amount separators do not match the configured amount format"
+	case errors.Is(err, domain.ErrAmountOverflow):
+		return "amount is too large"
+	case errors.Is(err, domain.ErrInvalidTransactionDate):
+		return "date must be RFC3339 or YYYY-MM-DD"
+	case errors.Is(err, domain.ErrNothingToSettle):
+		return "no open balance between from and to to settle"
+	default:
+		return "database operation failed"
+	}
+}
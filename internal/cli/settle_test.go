@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestSettleCommandJSONNetsSharedExpensesAndRecordsSettlements(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	added := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "60.00", "--currency", "USD", "--date", "2026-03-01", "--by", "Ben", "--shared", "--split-with", "ana:50%", "--split-with", "Cy:25"})
+	mustEntrySuccess(t, added)
+	splits := mustAnySlice(t, mustMap(t, mustMap(t, added["data"])["entry"])["splits"])
+	if len(splits) != 2 || mustMap(t, splits[0])["share_bps"].(float64) != 5000 || mustMap(t, splits[0])["amount_minor"].(float64) != 3000 {
+		t.Fatalf("expected ana to owe half of 60.00, got %v", splits)
+	}
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-03-02", "--by", "Ana", "--shared", "--split-with", "ben:100%"}))
+
+	payload := executeSettleCmdJSON(t, db, []string{"show"})
+	mustEntrySuccess(t, payload)
+	balances := mustAnySlice(t, mustMap(t, payload["data"])["balances"])
+	if len(balances) != 2 {
+		t.Fatalf("expected ana->Ben and Cy->Ben balances, got %v", balances)
+	}
+	if first := mustMap(t, balances[0]); first["from"] != "ana" || first["to"] != "Ben" || first["amount_minor"].(float64) != 2000 {
+		t.Fatalf("expected ana to owe Ben 20.00 net, got %v", first)
+	}
+	if second := mustMap(t, balances[1]); second["from"] != "Cy" || second["amount_minor"].(float64) != 1500 {
+		t.Fatalf("expected Cy to owe Ben 15.00, got %v", second)
+	}
+
+	partial := executeSettleCmdJSON(t, db, []string{"record", "--from", "Cy", "--to", "ben", "--amount", "5.00", "--currency", "USD", "--date", "2026-03-05"})
+	mustEntrySuccess(t, partial)
+	full := executeSettleCmdJSON(t, db, []string{"record", "--from", "ANA", "--to", "Ben"})
+	mustEntrySuccess(t, full)
+	settlements := mustAnySlice(t, mustMap(t, full["data"])["settlements"])
+	if len(settlements) != 1 || mustMap(t, settlements[0])["amount_minor"].(float64) != 2000 {
+		t.Fatalf("expected the whole 20.00 balance settled, got %v", settlements)
+	}
+
+	remaining := executeSettleCmdJSON(t, db, []string{"show", "--person", "cy"})
+	mustEntrySuccess(t, remaining)
+	balances = mustAnySlice(t, mustMap(t, remaining["data"])["balances"])
+	if len(balances) != 1 || mustMap(t, balances[0])["amount_minor"].(float64) != 1000 {
+		t.Fatalf("expected Cy to still owe 10.00, got %v", balances)
+	}
+
+	nothing := executeSettleCmdJSON(t, db, []string{"record", "--from", "ana", "--to", "ben"})
+	if got := mustMap(t, nothing["error"])["code"]; got != "NOT_FOUND" {
+		t.Fatalf("expected NOT_FOUND once settled, got %v", nothing)
+	}
+}
+
+func TestSettleCommandJSONNetsRefundsOutOfSharedExpenses(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	added := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "60.00", "--currency", "USD", "--date", "2026-03-01", "--by", "Ben", "--shared", "--split-with", "ana:50%"})
+	mustEntrySuccess(t, added)
+	sharedID := strconv.FormatInt(int64(mustMap(t, mustMap(t, added["data"])["entry"])["id"].(float64)), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "20.00", "--currency", "USD", "--date", "2026-03-03", "--by", "Ben", "--refund-of", sharedID}))
+
+	payload := executeSettleCmdJSON(t, db, []string{"show"})
+	mustEntrySuccess(t, payload)
+	balances := mustAnySlice(t, mustMap(t, payload["data"])["balances"])
+	if len(balances) != 1 {
+		t.Fatalf("expected one ana->Ben balance, got %v", balances)
+	}
+	if first := mustMap(t, balances[0]); first["from"] != "ana" || first["to"] != "Ben" || first["amount_minor"].(float64) != 2000 {
+		t.Fatalf("expected ana to owe half of the refunded 40.00, got %v", first)
+	}
+}
+
+func TestEntryCommandJSONSharedRejectsInvalidSplits(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	base := []string{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-03-01"}
+	for _, args := range [][]string{
+		{"--shared", "--split-with", "ana:50%"},
+		{"--by", "Ben", "--split-with", "ana:50%"},
+		{"--by", "Ben", "--shared", "--split-with", "ben:50%"},
+		{"--by", "Ben", "--shared", "--split-with", "ana:60%", "--split-with", "cy:50%"},
+		{"--by", "Ben", "--shared", "--split-with", "ana:0.001"},
+	} {
+		payload := executeEntryCmdJSON(t, db, append(append([]string{}, base...), args...))
+		if got := mustMap(t, payload["error"])["code"]; got != "INVALID_ARGUMENT" {
+			t.Fatalf("expected INVALID_ARGUMENT for %v, got %v", args, payload)
+		}
+	}
+}
+
+func executeSettleCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	cmd := NewSettleCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute settle command: %v", err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal settle payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -14,6 +14,7 @@ import (
 type setupInitFlags struct {
 	defaultCurrency      string
 	timezone             string
+	amountFormat         string
 	openingBalance       string
 	openingBalanceCode   string
 	openingBalanceDate   string
@@ -65,6 +66,16 @@ func newSetupInitCmd(opts *RootOptions) *cobra.Command {
 					Details: map[string]any{"field": "timezone"},
 				})
 			}
+
+			parseAmountFormat := amountFormat(opts)
+			if cmd.Flags().Changed("amount-format") {
+				normalizedAmountFormat, err := domain.NormalizeAmountFormat(flags.amountFormat)
+				if err != nil {
+					return printReportError(cmd, reportOutputFormat(opts), err)
+				}
+				parseAmountFormat = normalizedAmountFormat
+			}
+
 			var openingBalanceMinor int64
 			if cmd.Flags().Changed("opening-balance") {
 				openingBalanceCurrency := flags.openingBalanceCode
@@ -72,7 +83,7 @@ func newSetupInitCmd(opts *RootOptions) *cobra.Command {
 					openingBalanceCurrency = flags.defaultCurrency
 				}
 
-				parsedOpeningBalanceMinor, err := domain.ParseMajorAmountToMinor(flags.openingBalance, openingBalanceCurrency)
+				parsedOpeningBalanceMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.openingBalance, openingBalanceCurrency, parseAmountFormat)
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
@@ -86,7 +97,7 @@ func newSetupInitCmd(opts *RootOptions) *cobra.Command {
 					monthCapCurrency = flags.defaultCurrency
 				}
 
-				parsedCurrentMonthCapMinor, err := domain.ParseMajorAmountToMinor(flags.currentMonthCap, monthCapCurrency)
+				parsedCurrentMonthCapMinor, err := domain.ParseLocalizedMajorAmountToMinor(flags.currentMonthCap, monthCapCurrency, parseAmountFormat)
 				if err != nil {
 					return printReportError(cmd, reportOutputFormat(opts), err)
 				}
@@ -101,6 +112,7 @@ func newSetupInitCmd(opts *RootOptions) *cobra.Command {
 			result, err := setupSvc.Init(cmd.Context(), service.SetupInitInput{
 				DefaultCurrencyCode:  flags.defaultCurrency,
 				DisplayTimezone:      flags.timezone,
+				AmountFormat:         parseAmountFormat,
 				OpeningBalanceMinor:  openingBalanceMinor,
 				OpeningBalanceCode:   flags.openingBalanceCode,
 				OpeningBalanceDate:   flags.openingBalanceDate,
@@ -119,6 +131,7 @@ func newSetupInitCmd(opts *RootOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.defaultCurrency, "default-currency", "", "Default currency code (required, e.g. USD)")
 	cmd.Flags().StringVar(&flags.timezone, "timezone", "", "Display timezone (required, e.g. America/New_York)")
+	cmd.Flags().StringVar(&flags.amountFormat, "amount-format", "", "Amount input format: dot_decimal (1,234.56) or comma_decimal (1.234,56); defaults to the saved setting or dot_decimal")
 	cmd.Flags().StringVar(&flags.openingBalance, "opening-balance", "", "Optional opening balance in major units (e.g. 1000.00)")
 	cmd.Flags().StringVar(&flags.openingBalanceCode, "opening-balance-currency", "", "Optional opening balance currency (defaults to default-currency)")
 	cmd.Flags().StringVar(&flags.openingBalanceDate, "opening-balance-date", "", "Optional opening balance date (RFC3339 or YYYY-MM-DD)")
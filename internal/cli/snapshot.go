@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+// takeSafetySnapshot copies the database into the snapshots directory next to
+// it before a risky command changes it. It returns nil without a snapshot
+// when --no-snapshot is set, auto_snapshot is off, or the database has no
+// file to sit next to. Human output names the snapshot on stderr; callers add
+// it to their JSON data with withSafetySnapshot.
+func takeSafetySnapshot(cmd *cobra.Command, opts *RootOptions, reason string) (*domain.SafetySnapshot, error) {
+	if opts == nil || opts.db == nil || opts.NoSnapshot || opts.snapshotOff {
+		return nil, nil
+	}
+	if strings.TrimSpace(opts.DBPath) == "" || sqlitestore.IsMemoryPath(opts.DBPath) {
+		return nil, nil
+	}
+
+	portabilitySvc, err := newPortabilityService(opts)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(filepath.Dir(opts.DBPath), domain.SafetySnapshotDirName)
+	snapshot, err := portabilitySvc.Snapshot(cmd.Context(), dir, reason, domain.DefaultSafetySnapshotKeep)
+	if err != nil {
+		return nil, err
+	}
+
+	if reportOutputFormat(opts) == output.FormatHuman && !opts.Quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "snapshot: %s (undo with: boring-budget data restore --file %s)\n", snapshot.Path, snapshot.Path)
+	}
+	return &snapshot, nil
+}
+
+func withSafetySnapshot(data map[string]any, snapshot *domain.SafetySnapshot) map[string]any {
+	if snapshot != nil {
+		data["snapshot"] = snapshot
+	}
+	return data
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"boring-budget/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type statsFlags struct {
+	fromRaw string
+	toRaw   string
+}
+
+func NewStatsCmd(opts *RootOptions) *cobra.Command {
+	flags := &statsFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show spending statistics: averages, medians and largest expenses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printEntryError(cmd, entryOutputFormat(opts), &entryCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "stats does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			svc, err := newEntryService(opts)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			filter, err := buildEntryListFilter(&entryListFlags{
+				fromRaw: flags.fromRaw,
+				toRaw:   flags.toRaw,
+			})
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			stats, err := svc.Stats(cmd.Context(), filter)
+			if err != nil {
+				return printEntryError(cmd, entryOutputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(map[string]any{
+				"from_utc":   filter.DateFromUTC,
+				"to_utc":     filter.DateToUTC,
+				"currencies": stats.Currencies,
+				"categories": stats.Categories,
+			}, nil)
+			return output.PrintTables(cmd.OutOrStdout(), entryOutputFormat(opts), env, statsTables(stats))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Start date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "End date (RFC3339 or YYYY-MM-DD)")
+
+	return cmd
+}
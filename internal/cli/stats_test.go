@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestStatsCommandJSONSummarizesSpending(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	foodID := strconv.FormatInt(insertTestCategory(t, db, "Food"), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "10.00", "--currency", "USD", "--date", "2026-01-05", "--category-id", foodID}))
+	large := executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "30.00", "--currency", "USD", "--date", "2026-01-12", "--category-id", foodID})
+	mustEntrySuccess(t, large)
+	largeID := strconv.FormatInt(int64(mustMap(t, mustMap(t, large["data"])["entry"])["id"].(float64)), 10)
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "20.00", "--currency", "USD", "--date", "2026-02-04"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "5.00", "--currency", "USD", "--date", "2026-02-04", "--refund-of", largeID}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "100.00", "--currency", "USD", "--date", "2026-02-01"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "99.00", "--currency", "USD", "--date", "2026-04-01"}))
+
+	buf := &bytes.Buffer{}
+	cmd := NewStatsCmd(&RootOptions{Output: output.FormatJSON, db: db})
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"--from", "2026-01-01", "--to", "2026-03-31"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute stats: %v", err)
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal stats payload: %v raw=%s", err, buf.String())
+	}
+	mustEntrySuccess(t, payload)
+	data := mustMap(t, payload["data"])
+
+	currencies := mustAnySlice(t, data["currencies"])
+	if len(currencies) != 1 {
+		t.Fatalf("expected one currency, got %v", currencies)
+	}
+	usd := mustMap(t, currencies[0])
+	if usd["spend_minor"].(float64) != 5500 || usd["month_count"].(float64) != 3 || usd["average_monthly_spend_minor"].(float64) != 1833 {
+		t.Fatalf("expected 55.00 spend averaged over 3 months, got %v", usd)
+	}
+	if usd["expense_count"].(float64) != 3 || usd["median_expense_minor"].(float64) != 2000 {
+		t.Fatalf("expected median of non-refund expenses to be 20.00, got %v", usd)
+	}
+	if usd["busiest_weekday"] != "Monday" || usd["busiest_weekday_spend_minor"].(float64) != 4000 {
+		t.Fatalf("expected Monday as busiest weekday, got %v", usd)
+	}
+	largest := mustAnySlice(t, usd["largest_expenses"])
+	if len(largest) != 3 || mustMap(t, largest[0])["amount_minor"].(float64) != 3000 {
+		t.Fatalf("expected largest expenses ordered by amount, got %v", largest)
+	}
+
+	categories := mustAnySlice(t, data["categories"])
+	if len(categories) != 2 {
+		t.Fatalf("expected uncategorized and Food counts, got %v", categories)
+	}
+	if uncategorized := mustMap(t, categories[0]); uncategorized["category_id"] != nil || uncategorized["entry_count"].(float64) != 2 {
+		t.Fatalf("expected 2 uncategorized entries first, got %v", uncategorized)
+	}
+	if food := mustMap(t, categories[1]); food["entry_count"].(float64) != 3 {
+		t.Fatalf("expected 3 Food entries including the refund, got %v", food)
+	}
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type subscriptionDetectFlags struct {
+	fromRaw         string
+	toRaw           string
+	currency        string
+	minOccurrences  int
+	tolerance       int
+	includeInactive bool
+}
+
+type subscriptionChangesFlags struct {
+	fromRaw        string
+	toRaw          string
+	currency       string
+	minOccurrences int
+	tolerance      int
+}
+
+type subscriptionCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *subscriptionCLIError) Error() string {
+	if e == nil {
+		return "subscriptions command error"
+	}
+	return e.Message
+}
+
+func NewSubscriptionsCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscriptions",
+		Short: "Find recurring charges in entry history",
+	}
+
+	cmd.AddCommand(newSubscriptionsDetectCmd(opts))
+	cmd.AddCommand(newSubscriptionsChangesCmd(opts))
+	return cmd
+}
+
+func newSubscriptionsDetectCmd(opts *RootOptions) *cobra.Command {
+	flags := &subscriptionDetectFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "detect",
+		Short: "List repeating charges with their annualized cost",
+		Long: `Scan expenses for charges to the same payee (or with the same note, when
+there is no payee) of a similar amount at a regular weekly, monthly,
+quarterly or yearly interval. Each candidate reports its latest amount,
+annualized cost and next expected date; active monthly candidates not yet
+covered by a schedule carry a proposed schedule add.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "subscriptions detect does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			fromUTC, err := normalizeListDateBound(flags.fromRaw, false)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "from must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "from", "value": flags.fromRaw},
+				})
+			}
+			toUTC, err := normalizeListDateBound(flags.toRaw, true)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "to must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "to", "value": flags.toRaw},
+				})
+			}
+
+			svc, err := newSubscriptionService(opts)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, err)
+			}
+
+			detection, err := svc.Detect(cmd.Context(), service.SubscriptionDetectRequest{
+				DateFromUTC:  fromUTC,
+				DateToUTC:    toUTC,
+				CurrencyCode: flags.currency,
+				Options: domain.SubscriptionDetectOptions{
+					AsOf:             displayNow(opts),
+					MinOccurrences:   flags.minOccurrences,
+					TolerancePercent: flags.tolerance,
+					IncludeInactive:  flags.includeInactive,
+				},
+			})
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"subscriptions": detection,
+				"count":         len(detection.Candidates),
+			}, nil), subscriptionTables(detection))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Only scan entries on or after this date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Only scan entries on or before this date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only scan one currency (ISO code)")
+	cmd.Flags().IntVar(&flags.minOccurrences, "min-occurrences", domain.DefaultSubscriptionMinOccurrences, "Charges needed before a pattern counts")
+	cmd.Flags().IntVar(&flags.tolerance, "tolerance", domain.DefaultSubscriptionTolerancePercent, "Allowed amount variation in percent of the typical charge")
+	cmd.Flags().BoolVar(&flags.includeInactive, "include-inactive", false, "Also list patterns whose last charge is overdue")
+
+	return cmd
+}
+
+func newSubscriptionsChangesCmd(opts *RootOptions) *cobra.Command {
+	flags := &subscriptionChangesFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "List recurring charges whose amount changed",
+		Long: `Replay expense history and list every recurring charge that arrived on
+its usual cadence with a different amount than the occurrence before it.
+--from and --to bound the dates of the reported changes; earlier charges
+still count towards establishing each pattern.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "subscriptions changes does not accept positional arguments",
+					Details: map[string]any{"args": args},
+				})
+			}
+
+			fromUTC, err := normalizeListDateBound(flags.fromRaw, false)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "from must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "from", "value": flags.fromRaw},
+				})
+			}
+			toUTC, err := normalizeListDateBound(flags.toRaw, true)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, &subscriptionCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "to must be RFC3339 or YYYY-MM-DD",
+					Details: map[string]any{"field": "to", "value": flags.toRaw},
+				})
+			}
+
+			svc, err := newSubscriptionService(opts)
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, err)
+			}
+
+			changes, err := svc.Changes(cmd.Context(), service.SubscriptionDetectRequest{
+				DateFromUTC:  fromUTC,
+				DateToUTC:    toUTC,
+				CurrencyCode: flags.currency,
+				Options: domain.SubscriptionDetectOptions{
+					MinOccurrences:   flags.minOccurrences,
+					TolerancePercent: flags.tolerance,
+				},
+			})
+			if err != nil {
+				return printSubscriptionError(cmd, opts.Output, err)
+			}
+
+			return output.PrintTables(cmd.OutOrStdout(), opts.Output, output.NewSuccessEnvelope(map[string]any{
+				"changes": changes,
+				"count":   len(changes),
+			}, nil), subscriptionChangeTables(changes))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.fromRaw, "from", "", "Only list changes on or after this date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.toRaw, "to", "", "Only list changes on or before this date (RFC3339 or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Only scan one currency (ISO code)")
+	cmd.Flags().IntVar(&flags.minOccurrences, "min-occurrences", domain.DefaultSubscriptionMinOccurrences, "Charges needed before a pattern counts")
+	cmd.Flags().IntVar(&flags.tolerance, "tolerance", domain.DefaultSubscriptionTolerancePercent, "Allowed amount variation in percent of the typical charge")
+
+	return cmd
+}
+
+func newSubscriptionService(opts *RootOptions) (*service.SubscriptionService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &subscriptionCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	svc, err := service.NewSubscriptionService(sqlitestore.NewEntryRepo(opts.db), sqlitestore.NewScheduleRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("subscription service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printSubscriptionError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *subscriptionCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromSubscriptionError(err), messageFromSubscriptionError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromSubscriptionError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "INVALID_CURRENCY_CODE"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "INVALID_DATE_RANGE"
+	case errors.Is(err, domain.ErrInvalidSubscriptionMinOccurrences),
+		errors.Is(err, domain.ErrInvalidSubscriptionTolerance):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromSubscriptionError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCurrencyCode):
+		return "currency must be a 3-letter ISO code"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return "from must be less than or equal to to"
+	case errors.Is(err, domain.ErrInvalidSubscriptionMinOccurrences):
+		return "min-occurrences must be at least 2"
+	case errors.Is(err, domain.ErrInvalidSubscriptionTolerance):
+		return "tolerance must be between 0 and 100"
+	default:
+		return "database operation failed"
+	}
+}
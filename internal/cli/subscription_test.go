@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestSubscriptionsDetectListsMonthlyChargeWithProposal(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	lastCharge := time.Now().UTC().AddDate(0, 0, -10)
+	for months := 3; months >= 0; months-- {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add", "--type", "expense", "--amount", "12.99", "--currency", "USD",
+			"--date", lastCharge.AddDate(0, -months, 0).Format("2006-01-02"), "--payee", "Music Stream",
+		}))
+	}
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", lastCharge.Format("2006-01-02"), "--payee", "Grocer",
+	}))
+
+	payload := executeSubscriptionsCmdJSON(t, db, []string{"detect"})
+	assertSuccessJSONEnvelope(t, payload)
+	data := mustMap(t, payload["data"])
+	if data["count"].(float64) != 1 {
+		t.Fatalf("expected one recurring charge, got %v", data)
+	}
+	detection := mustMap(t, data["subscriptions"])
+	candidate := mustMap(t, mustAnySlice(t, detection["candidates"])[0])
+	if candidate["name"] != "Music Stream" || candidate["interval"] != "monthly" || candidate["annualized_minor"].(float64) != 15588 {
+		t.Fatalf("unexpected candidate: %v", candidate)
+	}
+	if _, ok := candidate["proposed_schedule"]; !ok {
+		t.Fatalf("expected a schedule proposal for an unscheduled monthly charge, got %v", candidate)
+	}
+
+	strict := executeSubscriptionsCmdJSON(t, db, []string{"detect", "--min-occurrences", "5"})
+	if mustMap(t, strict["data"])["count"].(float64) != 0 {
+		t.Fatalf("expected no candidates with five required charges, got %v", strict)
+	}
+
+	invalid := executeSubscriptionsCmdJSON(t, db, []string{"detect", "--tolerance", "150"})
+	if code := mustMap(t, invalid["error"])["code"]; code != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for tolerance above 100, got %v", invalid)
+	}
+}
+
+func TestEntryAddWarnsOnRecurringChargePriceChange(t *testing.T) {
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, date := range []string{"2026-01-05", "2026-02-05", "2026-03-05"} {
+		mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{
+			"add", "--type", "expense", "--amount", "15.49", "--currency", "USD", "--date", date, "--payee", "Netflix",
+		}))
+	}
+
+	payload := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "16.99", "--currency", "USD", "--date", "2026-04-05", "--payee", "Netflix",
+	})
+	mustEntrySuccess(t, payload)
+	warnings := mustAnySlice(t, payload["warnings"])
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", payload["warnings"])
+	}
+	warning := mustMap(t, warnings[0])
+	if warning["code"] != "SUBSCRIPTION_PRICE_CHANGED" {
+		t.Fatalf("expected SUBSCRIPTION_PRICE_CHANGED, got %v", warning)
+	}
+	details := mustMap(t, warning["details"])
+	if mustMap(t, details["previous_amount"])["amount_minor"].(float64) != 1549 || mustMap(t, details["change_amount"])["amount_minor"].(float64) != 150 {
+		t.Fatalf("unexpected warning details: %v", details)
+	}
+
+	unchanged := executeEntryCmdJSON(t, db, []string{
+		"add", "--type", "expense", "--amount", "16.99", "--currency", "USD", "--date", "2026-05-05", "--payee", "Netflix",
+	})
+	if warnings := mustAnySlice(t, unchanged["warnings"]); len(warnings) != 0 {
+		t.Fatalf("expected no warning for a repeated amount, got %v", warnings)
+	}
+
+	changes := executeSubscriptionsCmdJSON(t, db, []string{"changes"})
+	assertSuccessJSONEnvelope(t, changes)
+	data := mustMap(t, changes["data"])
+	if data["count"].(float64) != 1 {
+		t.Fatalf("expected one price change, got %v", data)
+	}
+	change := mustMap(t, mustAnySlice(t, data["changes"])[0])
+	if change["name"] != "Netflix" || change["date"] != "2026-04-05" || change["annualized_change_minor"].(float64) != 1800 {
+		t.Fatalf("unexpected change: %v", change)
+	}
+
+	later := executeSubscriptionsCmdJSON(t, db, []string{"changes", "--from", "2026-04-06"})
+	if mustMap(t, later["data"])["count"].(float64) != 0 {
+		t.Fatalf("expected no changes after the from bound, got %v", later)
+	}
+}
+
+func executeSubscriptionsCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	cmd := NewSubscriptionsCmd(&RootOptions{Output: output.FormatJSON, Timezone: "UTC", db: db})
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute subscriptions cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal subscriptions payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/cli/output"
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type verifyMonthFlags struct {
+	statement     string
+	currency      string
+	cardIDRaw     string
+	toleranceDays int
+}
+
+type verifyCLIError struct {
+	Code    string
+	Message string
+	Details any
+}
+
+func (e *verifyCLIError) Error() string {
+	if e == nil {
+		return "verify command error"
+	}
+	return e.Message
+}
+
+func NewVerifyCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Cross-check recorded entries against bank or card statements",
+	}
+
+	cmd.AddCommand(newVerifyMonthCmd(opts))
+
+	return cmd
+}
+
+func newVerifyMonthCmd(opts *RootOptions) *cobra.Command {
+	flags := &verifyMonthFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "month <YYYY-MM>",
+		Short: "Report statement lines that are missing, extra or differ in amount",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return printVerifyError(cmd, outputFormat(opts), &verifyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "verify month requires exactly one YYYY-MM argument",
+					Details: map[string]any{"args": args},
+				})
+			}
+			if strings.TrimSpace(flags.statement) == "" {
+				return printVerifyError(cmd, outputFormat(opts), &verifyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "statement is required",
+					Details: map[string]any{"field": "statement"},
+				})
+			}
+			if _, err := os.Stat(flags.statement); err != nil {
+				return printVerifyError(cmd, outputFormat(opts), &verifyCLIError{
+					Code:    "INVALID_ARGUMENT",
+					Message: "statement file could not be read",
+					Details: map[string]any{"field": "statement", "value": flags.statement, "reason": err.Error()},
+				})
+			}
+
+			input := service.VerifyMonthInput{
+				MonthKey:        args[0],
+				StatementPath:   flags.statement,
+				DefaultCurrency: flags.currency,
+				AmountFormat:    amountFormat(opts),
+				ToleranceDays:   flags.toleranceDays,
+			}
+			if strings.TrimSpace(input.DefaultCurrency) == "" {
+				input.DefaultCurrency = defaultCurrency(opts)
+			}
+			if strings.TrimSpace(flags.cardIDRaw) != "" {
+				cardID, err := strconv.ParseInt(strings.TrimSpace(flags.cardIDRaw), 10, 64)
+				if err != nil || cardID <= 0 {
+					return printVerifyError(cmd, outputFormat(opts), &verifyCLIError{
+						Code:    "INVALID_ARGUMENT",
+						Message: "card-id must be a positive integer",
+						Details: map[string]any{"field": "card-id", "value": flags.cardIDRaw},
+					})
+				}
+				input.PaymentCardID = &cardID
+			}
+
+			svc, err := newVerifyService(opts)
+			if err != nil {
+				return printVerifyError(cmd, outputFormat(opts), err)
+			}
+
+			result, err := svc.Month(cmd.Context(), input)
+			if err != nil {
+				return printVerifyError(cmd, outputFormat(opts), err)
+			}
+
+			env := output.NewSuccessEnvelope(result, nil)
+			return output.PrintTables(cmd.OutOrStdout(), outputFormat(opts), env, verifyTables(result))
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.statement, "statement", "", "Statement CSV with a header naming date and amount (negative is money out), plus optional currency and description columns")
+	cmd.Flags().StringVar(&flags.currency, "currency", "", "Currency for statement rows without a currency column (defaults to the configured default currency)")
+	cmd.Flags().StringVar(&flags.cardIDRaw, "card-id", "", "Only compare entries paid with this card")
+	cmd.Flags().IntVar(&flags.toleranceDays, "tolerance-days", domain.DefaultStatementToleranceDays, "Days a statement date may differ from the entry date")
+
+	return cmd
+}
+
+func newVerifyService(opts *RootOptions) (*service.VerifyService, error) {
+	if opts == nil || opts.db == nil {
+		return nil, &verifyCLIError{
+			Code:    "DB_ERROR",
+			Message: "database operation failed",
+			Details: map[string]any{"reason": "database connection unavailable"},
+		}
+	}
+
+	entrySvc, err := service.NewEntryService(sqlitestore.NewEntryRepo(opts.db))
+	if err != nil {
+		return nil, fmt.Errorf("entry service init: %w", err)
+	}
+	svc, err := service.NewVerifyService(entrySvc)
+	if err != nil {
+		return nil, fmt.Errorf("verify service init: %w", err)
+	}
+	return svc, nil
+}
+
+func printVerifyError(cmd *cobra.Command, format string, err error) error {
+	if cmd == nil {
+		return fmt.Errorf("nil command")
+	}
+
+	if err == nil {
+		env := output.NewErrorEnvelope("INTERNAL_ERROR", "unexpected internal failure", map[string]any{}, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	var cliErr *verifyCLIError
+	if errors.As(err, &cliErr) {
+		env := output.NewErrorEnvelope(cliErr.Code, cliErr.Message, cliErr.Details, nil)
+		return output.Print(cmd.OutOrStdout(), format, env)
+	}
+
+	env := output.NewErrorEnvelope(codeFromVerifyError(err), messageFromVerifyError(err), map[string]any{"reason": err.Error()}, nil)
+	return output.Print(cmd.OutOrStdout(), format, env)
+}
+
+func codeFromVerifyError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMonthKey),
+		errors.Is(err, domain.ErrInvalidStatementLine),
+		errors.Is(err, domain.ErrInvalidStatementTolerance):
+		return "INVALID_ARGUMENT"
+	default:
+		return "DB_ERROR"
+	}
+}
+
+func messageFromVerifyError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMonthKey):
+		return "month must use YYYY-MM"
+	case errors.Is(err, domain.ErrInvalidStatementLine):
+		return "statement file is invalid"
+	case errors.Is(err, domain.ErrInvalidStatementTolerance):
+		return "tolerance-days must be zero or greater"
+	default:
+		return "database operation failed"
+	}
+}
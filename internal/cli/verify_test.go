@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/cli/output"
+)
+
+func TestVerifyMonthCommandJSONReportsMissingExtraAndMismatched(t *testing.T) {
+	t.Parallel()
+
+	db := newCLITestDB(t)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "12.50", "--currency", "USD", "--date", "2026-02-03"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "40.00", "--currency", "USD", "--date", "2026-02-10", "--note", "groceries"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "income", "--amount", "1000.00", "--currency", "USD", "--date", "2026-02-01"}))
+	mustEntrySuccess(t, executeEntryCmdJSON(t, db, []string{"add", "--type", "expense", "--amount", "9.99", "--currency", "USD", "--date", "2026-02-20", "--note", "cash coffee"}))
+
+	statementPath := filepath.Join(t.TempDir(), "statement.csv")
+	statement := strings.Join([]string{
+		"date,amount,description",
+		"2026-02-04,-12.50,BAKERY",
+		"2026-02-10,-42.00,SUPERMARKET",
+		"2026-02-02,1000.00,PAYROLL",
+		"2026-02-15,-7.00,PARKING",
+		"2026-03-01,-3.00,NEXT MONTH",
+	}, "\n")
+	if err := os.WriteFile(statementPath, []byte(statement), 0o600); err != nil {
+		t.Fatalf("write statement: %v", err)
+	}
+
+	payload := executeVerifyCmdJSON(t, db, []string{"month", "2026-02", "--statement", statementPath})
+	mustEntrySuccess(t, payload)
+	data := mustMap(t, payload["data"])
+	summary := mustMap(t, data["summary"])
+	if summary["matched"].(float64) != 2 || summary["out_of_period"].(float64) != 1 {
+		t.Fatalf("expected 2 matched and 1 out of period, got %v", summary)
+	}
+
+	missing := mustAnySlice(t, data["missing"])
+	if len(missing) != 1 || mustMap(t, missing[0])["description"] != "PARKING" || mustMap(t, missing[0])["amount_minor"].(float64) != -700 {
+		t.Fatalf("expected PARKING missing, got %v", missing)
+	}
+	extra := mustAnySlice(t, data["extra"])
+	if len(extra) != 1 || mustMap(t, extra[0])["note"] != "cash coffee" {
+		t.Fatalf("expected cash coffee extra, got %v", extra)
+	}
+	mismatched := mustAnySlice(t, data["amount_mismatched"])
+	if len(mismatched) != 1 || mustMap(t, mismatched[0])["difference_minor"].(float64) != -200 {
+		t.Fatalf("expected groceries off by -2.00, got %v", mismatched)
+	}
+
+	strict := executeVerifyCmdJSON(t, db, []string{"month", "2026-02", "--statement", statementPath, "--tolerance-days", "0"})
+	mustEntrySuccess(t, strict)
+	if got := mustMap(t, mustMap(t, strict["data"])["summary"])["matched"].(float64); got != 0 {
+		t.Fatalf("expected no matches with zero tolerance, got %v", got)
+	}
+
+	if err := os.WriteFile(statementPath, []byte("when,amount\n2026-02-04,-1.00\n"), 0o600); err != nil {
+		t.Fatalf("write statement: %v", err)
+	}
+	invalid := executeVerifyCmdJSON(t, db, []string{"month", "2026-02", "--statement", statementPath})
+	if got := mustMap(t, invalid["error"])["code"]; got != "INVALID_ARGUMENT" {
+		t.Fatalf("expected INVALID_ARGUMENT for a statement without a date column, got %v", invalid)
+	}
+}
+
+func executeVerifyCmdJSON(t *testing.T, db *sql.DB, args []string) map[string]any {
+	t.Helper()
+
+	opts := &RootOptions{Output: output.FormatJSON, db: db}
+	cmd := NewVerifyCmd(opts)
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("execute verify cmd %v: %v", args, err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &payload); err != nil {
+		t.Fatalf("unmarshal verify payload: %v raw=%s", err, buf.String())
+	}
+	return payload
+}
@@ -10,6 +10,8 @@ const (
 	AppDirName      = ".boring-budget"
 	DefaultDBFile   = "boring-budget.db"
 	DefaultDataPerm = 0o755
+
+	DefaultReportScheduleFile = "report-schedule.json"
 )
 
 func DefaultDataDir() (string, error) {
@@ -34,3 +36,12 @@ func DefaultDBPath() (string, error) {
 
 	return filepath.Join(dir, DefaultDBFile), nil
 }
+
+func DefaultReportSchedulePath() (string, error) {
+	dir, err := DefaultDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, DefaultReportScheduleFile), nil
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AppearanceIconMaxLength bounds icons in bytes; it leaves room for emoji
+// sequences joined with variation selectors or zero-width joiners.
+const AppearanceIconMaxLength = 32
+
+var (
+	ErrInvalidAppearanceColor = errors.New("invalid appearance color")
+	ErrInvalidAppearanceIcon  = errors.New("invalid appearance icon")
+	ErrNoAppearanceFields     = errors.New("no appearance fields")
+)
+
+// AppearanceUpdate sets the display color and icon of a label or category.
+// A nil field is left unchanged and an empty one clears the stored value.
+type AppearanceUpdate struct {
+	Color *string
+	Icon  *string
+}
+
+func NormalizeAppearanceUpdate(update AppearanceUpdate) (AppearanceUpdate, error) {
+	if update.Color == nil && update.Icon == nil {
+		return AppearanceUpdate{}, ErrNoAppearanceFields
+	}
+
+	normalized := AppearanceUpdate{}
+	if update.Color != nil {
+		color, err := NormalizeAppearanceColor(*update.Color)
+		if err != nil {
+			return AppearanceUpdate{}, err
+		}
+		normalized.Color = &color
+	}
+	if update.Icon != nil {
+		icon, err := NormalizeAppearanceIcon(*update.Icon)
+		if err != nil {
+			return AppearanceUpdate{}, err
+		}
+		normalized.Icon = &icon
+	}
+	return normalized, nil
+}
+
+// NormalizeAppearanceColor accepts #rgb or #rrggbb hex colors and returns them
+// lowercased. An empty value is returned as is.
+func NormalizeAppearanceColor(raw string) (string, error) {
+	color := strings.ToLower(strings.TrimSpace(raw))
+	if color == "" {
+		return "", nil
+	}
+	if len(color) != 4 && len(color) != 7 || color[0] != '#' {
+		return "", ErrInvalidAppearanceColor
+	}
+	for _, r := range color[1:] {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return "", ErrInvalidAppearanceColor
+		}
+	}
+	return color, nil
+}
+
+// NormalizeAppearanceIcon trims raw and rejects whitespace or control
+// characters inside it. An empty value is returned as is.
+func NormalizeAppearanceIcon(raw string) (string, error) {
+	icon := strings.TrimSpace(raw)
+	if icon == "" {
+		return "", nil
+	}
+	if len(icon) > AppearanceIconMaxLength || !utf8.ValidString(icon) {
+		return "", ErrInvalidAppearanceIcon
+	}
+	for _, r := range icon {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return "", ErrInvalidAppearanceIcon
+		}
+	}
+	return icon, nil
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeAppearanceColor(t *testing.T) {
+	t.Parallel()
+
+	for raw, want := range map[string]string{
+		" #FF8800 ": "#ff8800",
+		"#abc":      "#abc",
+		"":          "",
+	} {
+		got, err := NormalizeAppearanceColor(raw)
+		if err != nil {
+			t.Fatalf("normalize %q: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("normalize %q: expected %q, got %q", raw, want, got)
+		}
+	}
+
+	for _, raw := range []string{"ff8800", "#ff88", "#gg8800", "orange"} {
+		if _, err := NormalizeAppearanceColor(raw); !errors.Is(err, ErrInvalidAppearanceColor) {
+			t.Fatalf("expected ErrInvalidAppearanceColor for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestNormalizeAppearanceUpdate(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NormalizeAppearanceUpdate(AppearanceUpdate{}); !errors.Is(err, ErrNoAppearanceFields) {
+		t.Fatalf("expected ErrNoAppearanceFields, got %v", err)
+	}
+
+	icon := " ✈️ "
+	update, err := NormalizeAppearanceUpdate(AppearanceUpdate{Icon: &icon})
+	if err != nil {
+		t.Fatalf("normalize icon update: %v", err)
+	}
+	if update.Color != nil || update.Icon == nil || *update.Icon != "✈️" {
+		t.Fatalf("unexpected normalized update %+v", update)
+	}
+
+	spaced := "a b"
+	if _, err := NormalizeAppearanceUpdate(AppearanceUpdate{Icon: &spaced}); !errors.Is(err, ErrInvalidAppearanceIcon) {
+		t.Fatalf("expected ErrInvalidAppearanceIcon, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidAssetID      = errors.New("invalid asset id")
+	ErrAssetNameRequired   = errors.New("asset name is required")
+	ErrAssetNameConflict   = errors.New("asset name conflict")
+	ErrInvalidAssetKind    = errors.New("invalid asset kind")
+	ErrInvalidAssetBalance = errors.New("invalid asset balance")
+	ErrAssetNotFound       = errors.New("asset not found")
+	ErrNoAssetUpdateFields = errors.New("no asset update fields")
+)
+
+const (
+	AssetKindAsset     = "asset"
+	AssetKindLiability = "liability"
+)
+
+// Asset is a holding or fixed liability tracked by its current balance
+// instead of by entries: an investment account, a house, a loan. Balances
+// are entered by hand and are never negative; Kind gives the sign.
+type Asset struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Kind         string  `json:"kind"`
+	CurrencyCode string  `json:"currency_code"`
+	BalanceMinor int64   `json:"balance_minor"`
+	Note         string  `json:"note,omitempty"`
+	CreatedAtUTC string  `json:"created_at_utc"`
+	UpdatedAtUTC string  `json:"updated_at_utc"`
+	DeletedAtUTC *string `json:"deleted_at_utc,omitempty"`
+}
+
+type AssetDeleteResult struct {
+	AssetID      int64  `json:"asset_id"`
+	DeletedAtUTC string `json:"deleted_at_utc"`
+}
+
+type AssetAddInput struct {
+	Name         string
+	Kind         string
+	CurrencyCode string
+	BalanceMinor int64
+	Note         string
+}
+
+type AssetListFilter struct {
+	Kind           string
+	IncludeDeleted bool
+}
+
+type AssetUpdateInput struct {
+	ID           int64
+	Name         *string
+	BalanceMinor *int64
+	Note         *string
+}
+
+// AssetTotal sums the active assets and liabilities of one currency.
+type AssetTotal struct {
+	CurrencyCode     string `json:"currency_code"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetMinor         int64  `json:"net_minor"`
+}
+
+func ValidateAssetID(id int64) error {
+	if id <= 0 {
+		return ErrInvalidAssetID
+	}
+	return nil
+}
+
+func NormalizeAssetName(name string) (string, error) {
+	normalized := strings.TrimSpace(name)
+	if normalized == "" {
+		return "", ErrAssetNameRequired
+	}
+	return normalized, nil
+}
+
+func NormalizeAssetKind(kind string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(kind))
+	switch normalized {
+	case AssetKindAsset, AssetKindLiability:
+		return normalized, nil
+	default:
+		return "", ErrInvalidAssetKind
+	}
+}
+
+func NormalizeOptionalAssetKind(kind string) (string, error) {
+	if strings.TrimSpace(kind) == "" {
+		return "", nil
+	}
+	return NormalizeAssetKind(kind)
+}
+
+func ValidateAssetBalance(balanceMinor int64) error {
+	if balanceMinor < 0 {
+		return ErrInvalidAssetBalance
+	}
+	return nil
+}
+
+func NormalizeAssetAddInput(input AssetAddInput) (AssetAddInput, error) {
+	name, err := NormalizeAssetName(input.Name)
+	if err != nil {
+		return AssetAddInput{}, err
+	}
+	kind, err := NormalizeAssetKind(input.Kind)
+	if err != nil {
+		return AssetAddInput{}, err
+	}
+	currencyCode, err := NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return AssetAddInput{}, err
+	}
+	if err := ValidateAssetBalance(input.BalanceMinor); err != nil {
+		return AssetAddInput{}, err
+	}
+
+	return AssetAddInput{
+		Name:         name,
+		Kind:         kind,
+		CurrencyCode: currencyCode,
+		BalanceMinor: input.BalanceMinor,
+		Note:         strings.TrimSpace(input.Note),
+	}, nil
+}
+
+// TotalAssets sums active assets and liabilities per currency, ordered by
+// currency code.
+func TotalAssets(assets []Asset) []AssetTotal {
+	byCurrency := map[string]*AssetTotal{}
+	currencies := []string{}
+	for _, asset := range assets {
+		if asset.DeletedAtUTC != nil {
+			continue
+		}
+		total, ok := byCurrency[asset.CurrencyCode]
+		if !ok {
+			total = &AssetTotal{CurrencyCode: asset.CurrencyCode}
+			byCurrency[asset.CurrencyCode] = total
+			currencies = append(currencies, asset.CurrencyCode)
+		}
+		switch asset.Kind {
+		case AssetKindAsset:
+			total.AssetsMinor += asset.BalanceMinor
+		case AssetKindLiability:
+			total.LiabilitiesMinor += asset.BalanceMinor
+		}
+		total.NetMinor = total.AssetsMinor - total.LiabilitiesMinor
+	}
+
+	sort.Strings(currencies)
+	totals := make([]AssetTotal, 0, len(currencies))
+	for _, currency := range currencies {
+		totals = append(totals, *byCurrency[currency])
+	}
+	return totals
+}
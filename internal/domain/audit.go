@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidAuditFile = errors.New("invalid audit file")
+
+// AuditRecord is one audit_events row as written by `data export --resource
+// audit`. Actor is the row's source, Operation its action, and Payload the
+// raw trigger payload that imports write back.
+//
+// Before and After are snapshots rebuilt from the entity's earlier events:
+// creates have no Before, deletes no After. Hash chains every record to the
+// previous one so an edited or truncated file fails to import.
+type AuditRecord struct {
+	ID            int64           `json:"id"`
+	OccurredAtUTC string          `json:"occurred_at_utc"`
+	Actor         string          `json:"actor"`
+	Operation     string          `json:"operation"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	Payload       json.RawMessage `json:"payload"`
+	Hash          string          `json:"hash"`
+}
+
+type AuditImportResult struct {
+	Imported int64 `json:"imported"`
+	Skipped  int64 `json:"skipped"`
+}
+
+const (
+	auditOperationCreate = "create"
+	auditOperationUpdate = "update"
+	auditOperationDelete = "delete"
+)
+
+var auditNullSnapshot = json.RawMessage("null")
+
+// BuildAuditTrail fills Before, After and Hash on records given in id order.
+// Payload keys prefixed old_/new_ split into the before and after values of
+// the same field; other keys describe the state after the operation.
+func BuildAuditTrail(records []AuditRecord) []AuditRecord {
+	states := map[string]map[string]any{}
+	trail := make([]AuditRecord, 0, len(records))
+	previousHash := ""
+	for _, record := range records {
+		key := record.EntityType + ":" + record.EntityID
+		oldValues, newValues := splitAuditPayload(record.Payload)
+
+		var before, after map[string]any
+		switch record.Operation {
+		case auditOperationCreate:
+			after = newValues
+			states[key] = after
+		case auditOperationUpdate:
+			before = mergeAuditSnapshot(states[key], oldValues)
+			after = mergeAuditSnapshot(before, newValues)
+			states[key] = after
+		case auditOperationDelete:
+			before = mergeAuditSnapshot(states[key], newValues)
+			delete(states, key)
+		default:
+			after = newValues
+		}
+
+		record.Before = marshalAuditSnapshot(before)
+		record.After = marshalAuditSnapshot(after)
+		record.Hash = AuditRecordHash(previousHash, record)
+		previousHash = record.Hash
+		trail = append(trail, record)
+	}
+	return trail
+}
+
+// VerifyAuditTrail checks the hash chain of an exported trail and returns the
+// id of the first record that does not match.
+func VerifyAuditTrail(records []AuditRecord) (int64, bool) {
+	previousHash := ""
+	for _, record := range records {
+		if record.Hash != AuditRecordHash(previousHash, record) {
+			return record.ID, false
+		}
+		previousHash = record.Hash
+	}
+	return 0, true
+}
+
+// AuditRecordHash hashes a record, minus its own hash, onto the previous
+// hash. JSON fields are compacted first so re-indenting a file keeps it valid.
+func AuditRecordHash(previousHash string, record AuditRecord) string {
+	record.Hash = ""
+	record.Before = compactAuditJSON(record.Before)
+	record.After = compactAuditJSON(record.After)
+	record.Payload = compactAuditJSON(record.Payload)
+
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(previousHash+"\n"), encoded...))
+	return hex.EncodeToString(sum[:])
+}
+
+func splitAuditPayload(payload json.RawMessage) (map[string]any, map[string]any) {
+	values := map[string]any{}
+	if len(payload) > 0 {
+		_ = json.Unmarshal(payload, &values)
+	}
+
+	oldValues := map[string]any{}
+	newValues := map[string]any{}
+	for key, value := range values {
+		switch {
+		case strings.HasPrefix(key, "old_"):
+			oldValues[strings.TrimPrefix(key, "old_")] = value
+		case strings.HasPrefix(key, "new_"):
+			newValues[strings.TrimPrefix(key, "new_")] = value
+		default:
+			newValues[key] = value
+		}
+	}
+	return oldValues, newValues
+}
+
+func mergeAuditSnapshot(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		merged[key] = value
+	}
+	return merged
+}
+
+func marshalAuditSnapshot(snapshot map[string]any) json.RawMessage {
+	if snapshot == nil {
+		return auditNullSnapshot
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return auditNullSnapshot
+	}
+	return encoded
+}
+
+func compactAuditJSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return auditNullSnapshot
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+const (
+	BackupMethodVacuum = "vacuum"
+	BackupMethodOnline = "online"
+
+	// DefaultBackupPagesPerStep is how many pages an online backup copies
+	// before releasing its read lock and reporting progress.
+	DefaultBackupPagesPerStep = 1024
+)
+
+var ErrInvalidBackupPagesPerStep = errors.New("invalid backup pages per step")
+
+// BackupProgress counts database pages copied by an online backup. Total is
+// read when the backup starts and may drift if other writers grow the file.
+type BackupProgress struct {
+	PagesCopied int64 `json:"pages_copied"`
+	PagesTotal  int64 `json:"pages_total"`
+}
+
+type BackupResult struct {
+	File   string          `json:"backup_file"`
+	Method string          `json:"method"`
+	Pages  *BackupProgress `json:"pages,omitempty"`
+}
+
+func ValidateBackupPagesPerStep(pages int) error {
+	if pages <= 0 {
+		return ErrInvalidBackupPagesPerStep
+	}
+	return nil
+}
@@ -10,6 +10,12 @@ import (
 const (
 	WarningCodeCapExceeded    = "CAP_EXCEEDED"
 	CapExceededWarningMessage = "Expense saved, monthly cap exceeded."
+
+	WarningCodeCategoryCapExceeded    = "CATEGORY_CAP_EXCEEDED"
+	CategoryCapExceededWarningMessage = "Expense saved, category cap exceeded."
+
+	WarningCodeCapAtRisk    = "CAP_AT_RISK"
+	CapAtRiskWarningMessage = "Projected month-end spend exceeds the cap."
 )
 
 var (
@@ -17,11 +23,16 @@ var (
 	ErrInvalidCapAmount     = errors.New("invalid cap amount_minor")
 	ErrCapNotFound          = errors.New("cap not found")
 	ErrInvalidMonthDateTime = errors.New("invalid month datetime")
+	ErrCapRollSameMonth     = errors.New("cap roll source and target month are the same")
 )
 
+// MonthlyCap is the global cap for a month, or a category cap when
+// CategoryID is set. Category caps sit alongside the global cap; both are
+// checked independently.
 type MonthlyCap struct {
 	ID           int64  `json:"id"`
 	MonthKey     string `json:"month_key"`
+	CategoryID   *int64 `json:"category_id,omitempty"`
 	AmountMinor  int64  `json:"amount_minor"`
 	CurrencyCode string `json:"currency_code"`
 	CreatedAtUTC string `json:"created_at_utc"`
@@ -29,18 +40,38 @@ type MonthlyCap struct {
 }
 
 type MonthlyCapChange struct {
-	ID             int64  `json:"id"`
-	MonthKey       string `json:"month_key"`
-	OldAmountMinor *int64 `json:"old_amount_minor"`
-	NewAmountMinor int64  `json:"new_amount_minor"`
-	CurrencyCode   string `json:"currency_code"`
-	ChangedAtUTC   string `json:"changed_at_utc"`
+	ID                 int64   `json:"id"`
+	MonthKey           string  `json:"month_key"`
+	CategoryID         *int64  `json:"category_id,omitempty"`
+	OldAmountMinor     *int64  `json:"old_amount_minor"`
+	NewAmountMinor     int64   `json:"new_amount_minor"`
+	CurrencyCode       string  `json:"currency_code"`
+	ChangedAtUTC       string  `json:"changed_at_utc"`
+	CopiedFromMonthKey *string `json:"copied_from_month_key,omitempty"`
 }
 
 type CapSetInput struct {
 	MonthKey     string
+	CategoryID   *int64
 	AmountMinor  int64
 	CurrencyCode string
+	// CopiedFromMonthKey records in cap history that the amount was copied
+	// from another month's cap.
+	CopiedFromMonthKey *string
+	// ExpectedUpdatedAtUTC, when set, rejects the write with ErrUpdateConflict
+	// unless the cap exists and still has this updated_at_utc.
+	ExpectedUpdatedAtUTC *string
+}
+
+// CapRollResult reports a copy of one month's caps into another. Skipped
+// lists the source caps whose target already had a cap (or whose category
+// was deleted); those targets are left unchanged.
+type CapRollResult struct {
+	FromMonthKey string             `json:"from_month_key"`
+	ToMonthKey   string             `json:"to_month_key"`
+	Copied       []MonthlyCap       `json:"copied"`
+	Skipped      []MonthlyCap       `json:"skipped"`
+	Changes      []MonthlyCapChange `json:"changes"`
 }
 
 type MoneyAmount struct {
@@ -55,6 +86,39 @@ type CapExceededWarningDetails struct {
 	OverspendAmount MoneyAmount `json:"overspend_amount"`
 }
 
+type CategoryCapExceededWarningDetails struct {
+	MonthKey        string      `json:"month_key"`
+	CategoryID      int64       `json:"category_id"`
+	CapAmount       MoneyAmount `json:"cap_amount"`
+	NewSpendTotal   MoneyAmount `json:"new_spend_total"`
+	OverspendAmount MoneyAmount `json:"overspend_amount"`
+}
+
+// CapStatus is a cap's month-to-date spend and a straight-line projection to
+// the end of the month. RemainingMinor is negative once the cap is exceeded.
+type CapStatus struct {
+	MonthKey              string `json:"month_key"`
+	CategoryID            *int64 `json:"category_id,omitempty"`
+	CurrencyCode          string `json:"currency_code"`
+	CapAmountMinor        int64  `json:"cap_amount_minor"`
+	SpendToDateMinor      int64  `json:"spend_to_date_minor"`
+	RemainingMinor        int64  `json:"remaining_minor"`
+	AverageDailyBurnMinor int64  `json:"average_daily_burn_minor"`
+	ProjectedTotalMinor   int64  `json:"projected_total_minor"`
+	DaysElapsed           int    `json:"days_elapsed"`
+	DaysInMonth           int    `json:"days_in_month"`
+	IsExceeded            bool   `json:"is_exceeded"`
+	IsAtRisk              bool   `json:"is_at_risk"`
+}
+
+type CapAtRiskWarningDetails struct {
+	MonthKey       string      `json:"month_key"`
+	CategoryID     *int64      `json:"category_id,omitempty"`
+	CapAmount      MoneyAmount `json:"cap_amount"`
+	SpendToDate    MoneyAmount `json:"spend_to_date"`
+	ProjectedTotal MoneyAmount `json:"projected_total"`
+}
+
 type Warning struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -87,6 +151,10 @@ func NormalizeCapSetInput(input CapSetInput) (CapSetInput, error) {
 		return CapSetInput{}, err
 	}
 
+	if err := ValidateOptionalCategoryID(input.CategoryID); err != nil {
+		return CapSetInput{}, err
+	}
+
 	if err := ValidateCapAmountMinor(input.AmountMinor); err != nil {
 		return CapSetInput{}, err
 	}
@@ -96,10 +164,18 @@ func NormalizeCapSetInput(input CapSetInput) (CapSetInput, error) {
 		return CapSetInput{}, err
 	}
 
+	expectedUpdatedAtUTC, err := NormalizeExpectedUpdatedAtUTC(input.ExpectedUpdatedAtUTC)
+	if err != nil {
+		return CapSetInput{}, err
+	}
+
 	return CapSetInput{
-		MonthKey:     monthKey,
-		AmountMinor:  input.AmountMinor,
-		CurrencyCode: currencyCode,
+		MonthKey:             monthKey,
+		CategoryID:           input.CategoryID,
+		AmountMinor:          input.AmountMinor,
+		CurrencyCode:         currencyCode,
+		CopiedFromMonthKey:   input.CopiedFromMonthKey,
+		ExpectedUpdatedAtUTC: expectedUpdatedAtUTC,
 	}, nil
 }
 
@@ -120,6 +196,19 @@ func MonthKeyFromDateTimeUTC(value string) (string, error) {
 	return parsed.UTC().Format("2006-01"), nil
 }
 
+// PreviousMonthKey returns the month before monthKey.
+func PreviousMonthKey(monthKey string) (string, error) {
+	normalized, err := NormalizeMonthKey(monthKey)
+	if err != nil {
+		return "", err
+	}
+	parsed, err := time.Parse("2006-01", normalized)
+	if err != nil {
+		return "", ErrInvalidMonthKey
+	}
+	return parsed.AddDate(0, -1, 0).Format("2006-01"), nil
+}
+
 func MonthRangeUTC(monthKey string) (string, string, error) {
 	normalized, err := NormalizeMonthKey(monthKey)
 	if err != nil {
@@ -135,3 +224,60 @@ func MonthRangeUTC(monthKey string) (string, string, error) {
 
 	return startUTC.Format(time.RFC3339Nano), endUTC.Format(time.RFC3339Nano), nil
 }
+
+// ProjectCapStatus projects spendMinor over the whole month of capValue,
+// counting asOf's calendar day as elapsed. Months that have not started
+// project their current spend; finished months use every day.
+func ProjectCapStatus(capValue MonthlyCap, spendMinor int64, asOf time.Time) (CapStatus, error) {
+	month, err := time.Parse("2006-01", capValue.MonthKey)
+	if err != nil {
+		return CapStatus{}, ErrInvalidMonthKey
+	}
+	daysInMonth := month.AddDate(0, 1, -1).Day()
+
+	asOfMonth := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	daysElapsed := 0
+	switch {
+	case asOfMonth.Before(month):
+		daysElapsed = 0
+	case asOfMonth.After(month):
+		daysElapsed = daysInMonth
+	default:
+		daysElapsed = asOf.Day()
+	}
+
+	status := CapStatus{
+		MonthKey:            capValue.MonthKey,
+		CategoryID:          capValue.CategoryID,
+		CurrencyCode:        capValue.CurrencyCode,
+		CapAmountMinor:      capValue.AmountMinor,
+		SpendToDateMinor:    spendMinor,
+		RemainingMinor:      capValue.AmountMinor - spendMinor,
+		ProjectedTotalMinor: spendMinor,
+		DaysElapsed:         daysElapsed,
+		DaysInMonth:         daysInMonth,
+		IsExceeded:          spendMinor > capValue.AmountMinor,
+	}
+	if daysElapsed > 0 {
+		status.AverageDailyBurnMinor = spendMinor / int64(daysElapsed)
+		status.ProjectedTotalMinor = spendMinor * int64(daysInMonth) / int64(daysElapsed)
+	}
+	status.IsAtRisk = status.ProjectedTotalMinor > capValue.AmountMinor
+
+	return status, nil
+}
+
+// CapAtRiskWarning reports a status whose projection exceeds its cap.
+func CapAtRiskWarning(status CapStatus) Warning {
+	return Warning{
+		Code:    WarningCodeCapAtRisk,
+		Message: CapAtRiskWarningMessage,
+		Details: CapAtRiskWarningDetails{
+			MonthKey:       status.MonthKey,
+			CategoryID:     status.CategoryID,
+			CapAmount:      MoneyAmount{AmountMinor: status.CapAmountMinor, CurrencyCode: status.CurrencyCode},
+			SpendToDate:    MoneyAmount{AmountMinor: status.SpendToDateMinor, CurrencyCode: status.CurrencyCode},
+			ProjectedTotal: MoneyAmount{AmountMinor: status.ProjectedTotalMinor, CurrencyCode: status.CurrencyCode},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestNormalizeMonthKey(t *testing.T) {
@@ -87,3 +88,39 @@ func TestMonthRangeUTC(t *testing.T) {
 		t.Fatalf("unexpected end UTC: %q", endUTC)
 	}
 }
+
+func TestProjectCapStatus(t *testing.T) {
+	t.Parallel()
+
+	capValue := MonthlyCap{MonthKey: "2026-03", AmountMinor: 60000, CurrencyCode: "USD"}
+
+	status, err := ProjectCapStatus(capValue, 30000, time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("project cap status: %v", err)
+	}
+	if status.DaysElapsed != 10 || status.DaysInMonth != 31 {
+		t.Fatalf("expected 10 of 31 days elapsed, got %d of %d", status.DaysElapsed, status.DaysInMonth)
+	}
+	if status.AverageDailyBurnMinor != 3000 || status.ProjectedTotalMinor != 93000 {
+		t.Fatalf("expected burn 3000 and projection 93000, got %d and %d", status.AverageDailyBurnMinor, status.ProjectedTotalMinor)
+	}
+	if status.RemainingMinor != 30000 || status.IsExceeded || !status.IsAtRisk {
+		t.Fatalf("expected 30000 remaining, not exceeded, at risk; got %+v", status)
+	}
+
+	finished, err := ProjectCapStatus(capValue, 30000, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("project finished month: %v", err)
+	}
+	if finished.DaysElapsed != 31 || finished.ProjectedTotalMinor != 30000 || finished.IsAtRisk {
+		t.Fatalf("expected finished month to project its spend, got %+v", finished)
+	}
+
+	upcoming, err := ProjectCapStatus(capValue, 0, time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("project upcoming month: %v", err)
+	}
+	if upcoming.DaysElapsed != 0 || upcoming.AverageDailyBurnMinor != 0 || upcoming.ProjectedTotalMinor != 0 {
+		t.Fatalf("expected upcoming month to project nothing, got %+v", upcoming)
+	}
+}
@@ -28,6 +28,9 @@ const (
 	CardLiabilityEventCharge     = "charge"
 	CardLiabilityEventPayment    = "payment"
 	CardLiabilityEventAdjustment = "adjustment"
+
+	WarningCodeCardLimitExceeded    = "CARD_LIMIT_EXCEEDED"
+	CardLimitExceededWarningMessage = "Expense saved, card monthly limit exceeded."
 )
 
 var (
@@ -50,19 +53,25 @@ var (
 	ErrInvalidCardAsOfDate         = errors.New("invalid card as_of date")
 	ErrCardPaymentRequiresCredit   = errors.New("card payment requires credit card")
 	ErrInvalidCardPaymentAmount    = errors.New("invalid card payment amount")
+	ErrInvalidCardLimitAmount      = errors.New("invalid card limit amount")
+	ErrCardLimitNotFound           = errors.New("card limit not found")
+	ErrCardHasOutstandingDebt      = errors.New("card has outstanding debt")
 )
 
 type Card struct {
-	ID           int64   `json:"id"`
-	Nickname     string  `json:"nickname"`
-	Description  string  `json:"description,omitempty"`
-	Last4        string  `json:"last4"`
-	Brand        string  `json:"brand"`
-	CardType     string  `json:"card_type"`
-	DueDay       *int    `json:"due_day,omitempty"`
-	CreatedAtUTC string  `json:"created_at_utc"`
-	UpdatedAtUTC string  `json:"updated_at_utc"`
-	DeletedAtUTC *string `json:"deleted_at_utc,omitempty"`
+	ID                  int64   `json:"id"`
+	Nickname            string  `json:"nickname"`
+	Description         string  `json:"description,omitempty"`
+	Last4               string  `json:"last4"`
+	Brand               string  `json:"brand"`
+	CardType            string  `json:"card_type"`
+	DueDay              *int    `json:"due_day,omitempty"`
+	CreatedAtUTC        string  `json:"created_at_utc"`
+	UpdatedAtUTC        string  `json:"updated_at_utc"`
+	DeletedAtUTC        *string `json:"deleted_at_utc,omitempty"`
+	ArchivedAtUTC       *string `json:"archived_at_utc,omitempty"`
+	DefaultCurrencyCode string  `json:"default_currency_code,omitempty"`
+	FXFeeBasisPoints    *int64  `json:"fx_fee_bp,omitempty"`
 }
 
 type CardDeleteResult struct {
@@ -77,12 +86,20 @@ type CardAddInput struct {
 	Brand       string
 	CardType    string
 	DueDay      *int
+	// DefaultCurrencyCode is the currency card entries and payments use when
+	// --currency is omitted; empty means none.
+	DefaultCurrencyCode string
+	// FXFeeBasisPoints is the fee charged on foreign-currency expenses; nil
+	// means none.
+	FXFeeBasisPoints *int64
 }
 
 type CardListFilter struct {
-	Lookup         string
-	CardType       string
-	IncludeDeleted bool
+	Lookup          string
+	CardType        string
+	IncludeDeleted  bool
+	IncludeArchived bool
+	Sort            string
 }
 
 type CardSelector struct {
@@ -116,6 +133,17 @@ type CardUpdateInput struct {
 	CardType       *string
 	SetDueDay      bool
 	DueDay         *int
+	// SetDefaultCurrency replaces the card's default currency with
+	// DefaultCurrencyCode; an empty value clears it.
+	SetDefaultCurrency  bool
+	DefaultCurrencyCode string
+	// SetFXFee replaces the card's foreign transaction fee with
+	// FXFeeBasisPoints; nil clears it.
+	SetFXFee         bool
+	FXFeeBasisPoints *int64
+	// ExpectedUpdatedAtUTC, when set, rejects the update with
+	// ErrUpdateConflict unless the card still has this updated_at_utc.
+	ExpectedUpdatedAtUTC *string
 }
 
 type CardDueInfo struct {
@@ -127,6 +155,21 @@ type CardDueInfo struct {
 	NextDueDateUTC string `json:"next_due_date_utc"`
 }
 
+// DaysUntilDue counts whole days from the reference date to the next due
+// date; zero means the payment is due on the reference date.
+func (d CardDueInfo) DaysUntilDue() (int64, error) {
+	reference, err := time.Parse("2006-01-02", d.ReferenceUTC)
+	if err != nil {
+		return 0, err
+	}
+	next, err := time.Parse(time.RFC3339Nano, d.NextDueDateUTC)
+	if err != nil {
+		return 0, err
+	}
+	nextDay := time.Date(next.UTC().Year(), next.UTC().Month(), next.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	return int64(nextDay.Sub(reference).Hours() / 24), nil
+}
+
 type CardDebtBalance struct {
 	CurrencyCode       string `json:"currency_code"`
 	BalanceMinorSigned int64  `json:"balance_minor_signed"`
@@ -141,7 +184,121 @@ type CardLiabilityEvent struct {
 	AmountMinorSigned      int64  `json:"amount_minor_signed"`
 	ReferenceTransactionID *int64 `json:"reference_transaction_id,omitempty"`
 	Note                   string `json:"note,omitempty"`
-	CreatedAtUTC           string `json:"created_at_utc"`
+	// FXFeeMinor is the part of a charge that is the card's foreign
+	// transaction fee.
+	FXFeeMinor   int64  `json:"fx_fee_minor,omitempty"`
+	CreatedAtUTC string `json:"created_at_utc"`
+}
+
+// CardDebtHistoryEvent is a liability event with the card's running balance
+// in the event currency right after it was recorded.
+type CardDebtHistoryEvent struct {
+	CardLiabilityEvent
+	BalanceAfterMinorSigned int64 `json:"balance_after_minor_signed"`
+}
+
+// CardDebtHistoryPeriod is the net change of one currency bucket within a
+// period and the balance it closed at.
+type CardDebtHistoryPeriod struct {
+	PeriodKey                 string `json:"period_key"`
+	CurrencyCode              string `json:"currency_code"`
+	EventCount                int    `json:"event_count"`
+	NetChangeMinorSigned      int64  `json:"net_change_minor_signed"`
+	ClosingBalanceMinorSigned int64  `json:"closing_balance_minor_signed"`
+}
+
+// BuildCardDebtHistory orders events chronologically, tracks a running balance
+// per currency and rolls the changes up by day, week or month.
+func BuildCardDebtHistory(events []CardLiabilityEvent, grouping string) ([]CardDebtHistoryEvent, []CardDebtHistoryPeriod, error) {
+	normalizedGrouping, err := NormalizeReportGrouping(grouping)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch normalizedGrouping {
+	case ReportGroupingDay, ReportGroupingWeek, ReportGroupingMonth:
+	default:
+		return nil, nil, ErrInvalidReportGrouping
+	}
+
+	ordered := append([]CardLiabilityEvent{}, events...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].CreatedAtUTC != ordered[j].CreatedAtUTC {
+			return ordered[i].CreatedAtUTC < ordered[j].CreatedAtUTC
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	type periodKey struct {
+		period   string
+		currency string
+	}
+	balances := map[string]int64{}
+	periodIndex := map[periodKey]int{}
+	history := make([]CardDebtHistoryEvent, 0, len(ordered))
+	periods := []CardDebtHistoryPeriod{}
+	for _, event := range ordered {
+		key, err := PeriodKeyForTransaction(event.CreatedAtUTC, normalizedGrouping)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		balances[event.CurrencyCode] += event.AmountMinorSigned
+		balance := balances[event.CurrencyCode]
+		history = append(history, CardDebtHistoryEvent{CardLiabilityEvent: event, BalanceAfterMinorSigned: balance})
+
+		index, ok := periodIndex[periodKey{period: key, currency: event.CurrencyCode}]
+		if !ok {
+			index = len(periods)
+			periodIndex[periodKey{period: key, currency: event.CurrencyCode}] = index
+			periods = append(periods, CardDebtHistoryPeriod{PeriodKey: key, CurrencyCode: event.CurrencyCode})
+		}
+		periods[index].EventCount++
+		periods[index].NetChangeMinorSigned += event.AmountMinorSigned
+		periods[index].ClosingBalanceMinorSigned = balance
+	}
+
+	sort.SliceStable(periods, func(i, j int) bool {
+		if periods[i].CurrencyCode != periods[j].CurrencyCode {
+			return periods[i].CurrencyCode < periods[j].CurrencyCode
+		}
+		return periods[i].PeriodKey < periods[j].PeriodKey
+	})
+	return history, periods, nil
+}
+
+// CardMonthlyLimit is how much a card may be charged in one month; only
+// expenses in the limit's currency count against it.
+type CardMonthlyLimit struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	MonthKey     string `json:"month_key"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	CreatedAtUTC string `json:"created_at_utc"`
+	UpdatedAtUTC string `json:"updated_at_utc"`
+}
+
+type CardLimitSetInput struct {
+	CardID       int64
+	MonthKey     string
+	AmountMinor  int64
+	CurrencyCode string
+}
+
+// CardLimitStatus is a card's month-to-date spend against its limit.
+type CardLimitStatus struct {
+	Limit          CardMonthlyLimit `json:"limit"`
+	SpendMinor     int64            `json:"spend_minor"`
+	RemainingMinor int64            `json:"remaining_minor"`
+	Exceeded       bool             `json:"exceeded"`
+}
+
+type CardLimitExceededWarningDetails struct {
+	MonthKey        string      `json:"month_key"`
+	CardID          int64       `json:"card_id"`
+	LimitAmount     MoneyAmount `json:"limit_amount"`
+	NewSpendTotal   MoneyAmount `json:"new_spend_total"`
+	OverspendAmount MoneyAmount `json:"overspend_amount"`
 }
 
 type CardPaymentAddInput struct {
@@ -249,6 +406,11 @@ func NormalizeCardAddInput(input CardAddInput) (CardAddInput, error) {
 
 	description := strings.TrimSpace(input.Description)
 
+	defaultCurrency, err := NormalizeCardDefaultCurrency(input.DefaultCurrencyCode)
+	if err != nil {
+		return CardAddInput{}, err
+	}
+
 	var dueDay *int
 	if input.DueDay != nil {
 		value := *input.DueDay
@@ -258,6 +420,15 @@ func NormalizeCardAddInput(input CardAddInput) (CardAddInput, error) {
 		dueDay = &value
 	}
 
+	var fxFee *int64
+	if input.FXFeeBasisPoints != nil {
+		value := *input.FXFeeBasisPoints
+		if err := ValidateCardFXFee(value); err != nil {
+			return CardAddInput{}, err
+		}
+		fxFee = &value
+	}
+
 	if cardType == CardTypeCredit && dueDay == nil {
 		return CardAddInput{}, ErrCardDueDayRequiredForCredit
 	}
@@ -266,15 +437,26 @@ func NormalizeCardAddInput(input CardAddInput) (CardAddInput, error) {
 	}
 
 	return CardAddInput{
-		Nickname:    nickname,
-		Description: description,
-		Last4:       last4,
-		Brand:       brand,
-		CardType:    cardType,
-		DueDay:      dueDay,
+		Nickname:            nickname,
+		Description:         description,
+		Last4:               last4,
+		Brand:               brand,
+		CardType:            cardType,
+		DueDay:              dueDay,
+		DefaultCurrencyCode: defaultCurrency,
+		FXFeeBasisPoints:    fxFee,
 	}, nil
 }
 
+// NormalizeCardDefaultCurrency validates an optional card default currency;
+// an empty value is returned as is.
+func NormalizeCardDefaultCurrency(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+	return NormalizeCurrencyCode(raw)
+}
+
 func HasCardUpdateChanges(changes CardUpdateChanges) bool {
 	return changes.SetNickname ||
 		changes.SetDescription ||
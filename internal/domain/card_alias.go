@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CardAliasMaxLength bounds aliases in characters; they are meant to be short
+// personal nicknames.
+const CardAliasMaxLength = 64
+
+var (
+	ErrInvalidCardAlias  = errors.New("invalid card alias")
+	ErrCardAliasNotFound = errors.New("card alias not found")
+	ErrCardAliasExists   = errors.New("card alias already exists")
+)
+
+// CardAlias is an extra name a card answers to in --card-nickname and
+// --card-lookup selectors.
+type CardAlias struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	Alias        string `json:"alias"`
+	CreatedAtUTC string `json:"created_at_utc"`
+}
+
+// NormalizeCardAlias trims raw and rejects empty aliases, control characters
+// and anything longer than CardAliasMaxLength.
+func NormalizeCardAlias(raw string) (string, error) {
+	alias := strings.TrimSpace(raw)
+	if alias == "" || !utf8.ValidString(alias) || utf8.RuneCountInString(alias) > CardAliasMaxLength {
+		return "", ErrInvalidCardAlias
+	}
+	for _, r := range alias {
+		if unicode.IsControl(r) {
+			return "", ErrInvalidCardAlias
+		}
+	}
+	return alias, nil
+}
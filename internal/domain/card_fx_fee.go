@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+// MaxCardFXFeeBasisPoints caps a card's foreign transaction fee at 10%.
+const MaxCardFXFeeBasisPoints = 1000
+
+var ErrInvalidCardFXFee = errors.New("invalid card fx fee")
+
+// ParseCardFXFeePercent parses a fee percentage such as "2.5" into basis
+// points, allowing at most two decimals.
+func ParseCardFXFeePercent(raw string) (int64, error) {
+	basisPoints, ok := parsePercentBasisPoints(raw, MaxCardFXFeeBasisPoints)
+	if !ok {
+		return 0, ErrInvalidCardFXFee
+	}
+	return basisPoints, nil
+}
+
+func ValidateCardFXFee(basisPoints int64) error {
+	if basisPoints < 0 || basisPoints > MaxCardFXFeeBasisPoints {
+		return ErrInvalidCardFXFee
+	}
+	return nil
+}
+
+// CardFXFeeMinor is the fee on amountMinor at basisPoints, rounded half up to
+// the currency's minor unit.
+func CardFXFeeMinor(amountMinor, basisPoints int64) int64 {
+	if amountMinor <= 0 || basisPoints <= 0 {
+		return 0
+	}
+	return (amountMinor*basisPoints + 5000) / 10000
+}
+
+// CardFXFeeCharge is the fee part of one card charge, dated by the entry that
+// caused it.
+type CardFXFeeCharge struct {
+	CardID             int64
+	CardNickname       string
+	CurrencyCode       string
+	FeeMinor           int64
+	TransactionDateUTC string
+}
+
+// CardFXFeeTotal sums the fees one card was charged in one currency within a
+// period.
+type CardFXFeeTotal struct {
+	CardID       int64  `json:"card_id"`
+	CardNickname string `json:"card_nickname"`
+	PeriodKey    string `json:"period_key"`
+	CurrencyCode string `json:"currency_code"`
+	ChargeCount  int    `json:"charge_count"`
+	FeeMinor     int64  `json:"fee_minor"`
+}
+
+type CardFXFeeReportInput struct {
+	FromUTC  string
+	ToUTC    string
+	Grouping string
+	CardID   *int64
+}
+
+type CardFXFeeReport struct {
+	FromUTC  string           `json:"from_utc"`
+	ToUTC    string           `json:"to_utc"`
+	Grouping string           `json:"grouping"`
+	Totals   []CardFXFeeTotal `json:"totals"`
+}
+
+// BuildCardFXFeeTotals rolls charges up per card, currency and day, week or
+// month, ordered by card, currency and period.
+func BuildCardFXFeeTotals(charges []CardFXFeeCharge, grouping string) ([]CardFXFeeTotal, error) {
+	normalizedGrouping, err := NormalizeReportGrouping(grouping)
+	if err != nil {
+		return nil, err
+	}
+	switch normalizedGrouping {
+	case ReportGroupingDay, ReportGroupingWeek, ReportGroupingMonth:
+	default:
+		return nil, ErrInvalidReportGrouping
+	}
+
+	type totalKey struct {
+		cardID   int64
+		period   string
+		currency string
+	}
+	index := map[totalKey]int{}
+	totals := []CardFXFeeTotal{}
+	for _, charge := range charges {
+		period, err := PeriodKeyForTransaction(charge.TransactionDateUTC, normalizedGrouping)
+		if err != nil {
+			return nil, err
+		}
+		key := totalKey{cardID: charge.CardID, period: period, currency: charge.CurrencyCode}
+		i, ok := index[key]
+		if !ok {
+			i = len(totals)
+			index[key] = i
+			totals = append(totals, CardFXFeeTotal{
+				CardID:       charge.CardID,
+				CardNickname: charge.CardNickname,
+				PeriodKey:    period,
+				CurrencyCode: charge.CurrencyCode,
+			})
+		}
+		totals[i].ChargeCount++
+		totals[i].FeeMinor += charge.FeeMinor
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		if totals[i].CardID != totals[j].CardID {
+			return totals[i].CardID < totals[j].CardID
+		}
+		if totals[i].CurrencyCode != totals[j].CurrencyCode {
+			return totals[i].CurrencyCode < totals[j].CurrencyCode
+		}
+		return totals[i].PeriodKey < totals[j].PeriodKey
+	})
+	return totals, nil
+}
@@ -13,13 +13,24 @@ var (
 	ErrCategoryNameTooLong  = errors.New("category name exceeds maximum length")
 	ErrCategoryNotFound     = errors.New("category not found")
 	ErrCategoryNameConflict = errors.New("category name already exists")
+	ErrCategoryArchived     = errors.New("category is archived")
 )
 
+// Category.ArchivedAtUTC is set while the category is archived. Archived
+// categories stay resolvable for existing entries and reports but are hidden
+// from listings and rejected for new writes unless explicitly allowed.
 type Category struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	CreatedAtUTC string `json:"created_at_utc"`
-	UpdatedAtUTC string `json:"updated_at_utc"`
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	CreatedAtUTC  string  `json:"created_at_utc"`
+	UpdatedAtUTC  string  `json:"updated_at_utc"`
+	ArchivedAtUTC *string `json:"archived_at_utc,omitempty"`
+	Color         string  `json:"color,omitempty"`
+	Icon          string  `json:"icon,omitempty"`
+}
+
+func (c Category) Archived() bool {
+	return c.ArchivedAtUTC != nil
 }
 
 type CategoryDeleteResult struct {
@@ -28,6 +39,18 @@ type CategoryDeleteResult struct {
 	OrphanedTransactions int64  `json:"orphaned_transactions"`
 }
 
+// UnarchivedCategories filters archived categories out of a listing.
+func UnarchivedCategories(categories []Category) []Category {
+	filtered := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		if category.Archived() {
+			continue
+		}
+		filtered = append(filtered, category)
+	}
+	return filtered
+}
+
 func NormalizeCategoryName(name string) (string, error) {
 	normalized := strings.TrimSpace(name)
 	if normalized == "" {
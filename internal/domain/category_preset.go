@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	CategoryPresetStandard = "standard"
+	CategoryPresetMinimal  = "minimal"
+	CategoryPresetFamily   = "family"
+)
+
+var ErrInvalidCategoryPreset = errors.New("invalid category preset")
+
+// CategoryPreset is a curated starter set of categories and labels.
+type CategoryPreset struct {
+	Name       string
+	Categories []string
+	Labels     []string
+}
+
+// CategoryPresetNames lists the presets in display order.
+var CategoryPresetNames = []string{CategoryPresetStandard, CategoryPresetMinimal, CategoryPresetFamily}
+
+var categoryPresets = map[string]CategoryPreset{
+	CategoryPresetMinimal: {
+		Name:       CategoryPresetMinimal,
+		Categories: []string{"Housing", "Food", "Transport", "Bills", "Salary", "Other"},
+		Labels:     []string{"recurring"},
+	},
+	CategoryPresetStandard: {
+		Name: CategoryPresetStandard,
+		Categories: []string{
+			"Rent", "Utilities", "Groceries", "Dining Out", "Transport", "Health",
+			"Insurance", "Subscriptions", "Shopping", "Entertainment", "Travel",
+			"Education", "Gifts", "Fees", "Salary", "Other Income",
+		},
+		Labels: []string{"recurring", "essential", "discretionary", "work", "reimbursable"},
+	},
+	CategoryPresetFamily: {
+		Name: CategoryPresetFamily,
+		Categories: []string{
+			"Rent", "Utilities", "Groceries", "Dining Out", "Transport", "Health",
+			"Insurance", "Childcare", "School", "Kids Activities", "Clothing",
+			"Household", "Pets", "Entertainment", "Travel", "Gifts", "Salary",
+			"Other Income",
+		},
+		Labels: []string{"recurring", "essential", "discretionary", "kids", "shared", "reimbursable"},
+	},
+}
+
+// LookupCategoryPreset resolves a preset name case-insensitively.
+func LookupCategoryPreset(name string) (CategoryPreset, error) {
+	preset, ok := categoryPresets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return CategoryPreset{}, ErrInvalidCategoryPreset
+	}
+	return preset, nil
+}
+
+// CategoryBootstrapResult reports what a preset install created and which
+// names were already present (compared case-insensitively).
+type CategoryBootstrapResult struct {
+	Preset             string     `json:"preset"`
+	CategoriesCreated  []Category `json:"categories_created"`
+	CategoriesExisting []string   `json:"categories_existing"`
+	LabelsCreated      []Label    `json:"labels_created"`
+	LabelsExisting     []string   `json:"labels_existing"`
+}
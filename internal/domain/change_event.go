@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	DefaultChangeEventLimit = 100
+	MaxChangeEventLimit     = 1000
+)
+
+var (
+	ErrInvalidChangeEventCursor = errors.New("invalid change event cursor")
+	ErrInvalidChangeEventLimit  = errors.New("invalid change event limit")
+)
+
+// changeEventEntityNames maps audit entity types to the public feed names.
+var changeEventEntityNames = map[string]string{
+	"monthly_cap":          "cap",
+	"monthly_category_cap": "category_cap",
+}
+
+// changeEventActionNames maps audit actions to past-tense feed verbs; other
+// actions (such as card "payment") pass through unchanged.
+var changeEventActionNames = map[string]string{
+	"create": "created",
+	"update": "updated",
+	"delete": "deleted",
+}
+
+// ChangeEvent is one row of the audit feed as published by events tail.
+type ChangeEvent struct {
+	ID            int64           `json:"id"`
+	Event         string          `json:"event"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Action        string          `json:"action"`
+	OccurredAtUTC string          `json:"occurred_at_utc"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// ChangeEventName builds the dotted event name, e.g. "entry.created",
+// "cap.updated" or "card.payment".
+func ChangeEventName(entityType, action string) string {
+	entity := entityType
+	if mapped, ok := changeEventEntityNames[entityType]; ok {
+		entity = mapped
+	}
+	verb := action
+	if mapped, ok := changeEventActionNames[action]; ok {
+		verb = mapped
+	}
+	return entity + "." + verb
+}
+
+func ValidateChangeEventCursor(afterID int64) error {
+	if afterID < 0 {
+		return ErrInvalidChangeEventCursor
+	}
+	return nil
+}
+
+func NormalizeChangeEventLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultChangeEventLimit, nil
+	}
+	if limit < 0 || limit > MaxChangeEventLimit {
+		return 0, ErrInvalidChangeEventLimit
+	}
+	return limit, nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxCleanupUnusedMonths bounds --unused-months to a hundred years.
+const MaxCleanupUnusedMonths = 1200
+
+var ErrInvalidCleanupUnusedMonths = errors.New("invalid cleanup unused months")
+
+// CleanupCandidate is a category, label or card with the active entries that
+// use it. LastUsedAtUTC is the date of the newest of those entries.
+type CleanupCandidate struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	EntryCount    int64  `json:"entry_count"`
+	LastUsedAtUTC string `json:"last_used_at_utc,omitempty"`
+}
+
+// CleanupUsage lists every active, unarchived category, label and card with
+// its usage.
+type CleanupUsage struct {
+	Categories []CleanupCandidate
+	Labels     []CleanupCandidate
+	Cards      []CleanupCandidate
+}
+
+type CleanupSuggestInput struct {
+	UnusedMonths int
+	AsOf         time.Time
+}
+
+// CleanupSuggestion holds what cleanup considers unused. CutoffUTC is set when
+// UnusedMonths is, and anything last used before it is included.
+type CleanupSuggestion struct {
+	UnusedMonths int                `json:"unused_months"`
+	CutoffUTC    string             `json:"cutoff_utc,omitempty"`
+	Categories   []CleanupCandidate `json:"categories"`
+	Labels       []CleanupCandidate `json:"labels"`
+	Cards        []CleanupCandidate `json:"cards"`
+}
+
+// Count returns the number of suggestions across every group.
+func (s CleanupSuggestion) Count() int {
+	return len(s.Categories) + len(s.Labels) + len(s.Cards)
+}
+
+// CleanupApplyResult lists what --apply archived.
+type CleanupApplyResult struct {
+	ArchivedCategories []CleanupCandidate `json:"archived_categories"`
+	ArchivedLabels     []CleanupCandidate `json:"archived_labels"`
+	ArchivedCards      []CleanupCandidate `json:"archived_cards"`
+	ArchivedAtUTC      string             `json:"archived_at_utc"`
+}
+
+func ValidateCleanupUnusedMonths(months int) error {
+	if months < 0 || months > MaxCleanupUnusedMonths {
+		return ErrInvalidCleanupUnusedMonths
+	}
+	return nil
+}
+
+// BuildCleanupSuggestion keeps the usage rows with no active entries and,
+// when input.UnusedMonths is positive, those last used more than that many
+// months before input.AsOf.
+func BuildCleanupSuggestion(usage CleanupUsage, input CleanupSuggestInput) (CleanupSuggestion, error) {
+	if err := ValidateCleanupUnusedMonths(input.UnusedMonths); err != nil {
+		return CleanupSuggestion{}, err
+	}
+
+	suggestion := CleanupSuggestion{UnusedMonths: input.UnusedMonths}
+	if input.UnusedMonths > 0 {
+		suggestion.CutoffUTC = input.AsOf.UTC().AddDate(0, -input.UnusedMonths, 0).Format(time.RFC3339)
+	}
+	suggestion.Categories = unusedCleanupCandidates(usage.Categories, suggestion.CutoffUTC)
+	suggestion.Labels = unusedCleanupCandidates(usage.Labels, suggestion.CutoffUTC)
+	suggestion.Cards = unusedCleanupCandidates(usage.Cards, suggestion.CutoffUTC)
+	return suggestion, nil
+}
+
+func unusedCleanupCandidates(candidates []CleanupCandidate, cutoffUTC string) []CleanupCandidate {
+	unused := make([]CleanupCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.EntryCount == 0 || (cutoffUTC != "" && candidate.LastUsedAtUTC < cutoffUTC) {
+			unused = append(unused, candidate)
+		}
+	}
+	return unused
+}
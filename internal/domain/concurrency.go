@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrUpdateConflict is returned when a write carries the updated_at_utc
+	// its caller last read and the row has changed since.
+	ErrUpdateConflict              = errors.New("record changed since it was read")
+	ErrInvalidExpectedUpdatedAtUTC = errors.New("invalid expected updated_at_utc")
+)
+
+// NormalizeExpectedUpdatedAtUTC trims an optimistic-concurrency precondition.
+// Nil means the write is unconditional. The value is compared verbatim with
+// the stored updated_at_utc, so it must be copied from a previous read.
+func NormalizeExpectedUpdatedAtUTC(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil, ErrInvalidExpectedUpdatedAtUTC
+	}
+	return &trimmed, nil
+}
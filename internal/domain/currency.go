@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+const MaxCustomCurrencyMinorUnit = 18
+
+var (
+	ErrInvalidCurrencyMinorUnit = errors.New("invalid currency minor unit")
+	ErrCustomCurrencyNotFound   = errors.New("custom currency not found")
+	ErrCustomCurrencyInUse      = errors.New("custom currency is in use")
+	ErrCurrencyCodeReserved     = errors.New("currency code is reserved by ISO 4217")
+)
+
+type CustomCurrency struct {
+	Code         string `json:"code"`
+	Name         string `json:"name,omitempty"`
+	MinorUnit    int    `json:"minor_unit"`
+	CreatedAtUTC string `json:"created_at_utc"`
+	UpdatedAtUTC string `json:"updated_at_utc"`
+}
+
+type CustomCurrencyInput struct {
+	Code      string
+	Name      string
+	MinorUnit int
+}
+
+type CustomCurrencyDeleteResult struct {
+	Code string `json:"code"`
+}
+
+// iso4217Codes lists the active ISO 4217 codes; they cannot be registered as
+// custom currencies because existing amounts already use their standard precision.
+var iso4217Codes = map[string]struct{}{
+	"AED": {}, "AFN": {}, "ALL": {}, "AMD": {}, "ANG": {}, "AOA": {}, "ARS": {}, "AUD": {},
+	"AWG": {}, "AZN": {}, "BAM": {}, "BBD": {}, "BDT": {}, "BGN": {}, "BHD": {}, "BIF": {},
+	"BMD": {}, "BND": {}, "BOB": {}, "BOV": {}, "BRL": {}, "BSD": {}, "BTN": {}, "BWP": {},
+	"BYN": {}, "BZD": {}, "CAD": {}, "CDF": {}, "CHE": {}, "CHF": {}, "CHW": {}, "CLF": {},
+	"CLP": {}, "CNY": {}, "COP": {}, "COU": {}, "CRC": {}, "CUC": {}, "CUP": {}, "CVE": {},
+	"CZK": {}, "DJF": {}, "DKK": {}, "DOP": {}, "DZD": {}, "EGP": {}, "ERN": {}, "ETB": {},
+	"EUR": {}, "FJD": {}, "FKP": {}, "GBP": {}, "GEL": {}, "GHS": {}, "GIP": {}, "GMD": {},
+	"GNF": {}, "GTQ": {}, "GYD": {}, "HKD": {}, "HNL": {}, "HTG": {}, "HUF": {}, "IDR": {},
+	"ILS": {}, "INR": {}, "IQD": {}, "IRR": {}, "ISK": {}, "JMD": {}, "JOD": {}, "JPY": {},
+	"KES": {}, "KGS": {}, "KHR": {}, "KMF": {}, "KPW": {}, "KRW": {}, "KWD": {}, "KYD": {},
+	"KZT": {}, "LAK": {}, "LBP": {}, "LKR": {}, "LRD": {}, "LSL": {}, "LYD": {}, "MAD": {},
+	"MDL": {}, "MGA": {}, "MKD": {}, "MMK": {}, "MNT": {}, "MOP": {}, "MRU": {}, "MUR": {},
+	"MVR": {}, "MWK": {}, "MXN": {}, "MXV": {}, "MYR": {}, "MZN": {}, "NAD": {}, "NGN": {},
+	"NIO": {}, "NOK": {}, "NPR": {}, "NZD": {}, "OMR": {}, "PAB": {}, "PEN": {}, "PGK": {},
+	"PHP": {}, "PKR": {}, "PLN": {}, "PYG": {}, "QAR": {}, "RON": {}, "RSD": {}, "RUB": {},
+	"RWF": {}, "SAR": {}, "SBD": {}, "SCR": {}, "SDG": {}, "SEK": {}, "SGD": {}, "SHP": {},
+	"SLE": {}, "SLL": {}, "SOS": {}, "SRD": {}, "SSP": {}, "STN": {}, "SVC": {}, "SYP": {},
+	"SZL": {}, "THB": {}, "TJS": {}, "TMT": {}, "TND": {}, "TOP": {}, "TRY": {}, "TTD": {},
+	"TWD": {}, "TZS": {}, "UAH": {}, "UGX": {}, "USD": {}, "USN": {}, "UYI": {}, "UYU": {},
+	"UYW": {}, "UZS": {}, "VED": {}, "VES": {}, "VND": {}, "VUV": {}, "WST": {}, "XAF": {},
+	"XAG": {}, "XAU": {}, "XBA": {}, "XBB": {}, "XBC": {}, "XBD": {}, "XCD": {}, "XCG": {},
+	"XDR": {}, "XOF": {}, "XPD": {}, "XPF": {}, "XPT": {}, "XSU": {}, "XTS": {}, "XUA": {},
+	"XXX": {}, "YER": {}, "ZAR": {}, "ZMW": {}, "ZWG": {}, "ZWL": {},
+}
+
+// customCurrencyRegistry holds the custom codes loaded from the database so the
+// stateless money helpers can resolve their precision.
+var customCurrencyRegistry = struct {
+	sync.RWMutex
+	minorUnits map[string]int
+}{minorUnits: map[string]int{}}
+
+// SetCustomCurrencies replaces the in-process registry of custom currencies.
+func SetCustomCurrencies(currencies []CustomCurrency) {
+	minorUnits := make(map[string]int, len(currencies))
+	for _, currency := range currencies {
+		minorUnits[strings.ToUpper(strings.TrimSpace(currency.Code))] = currency.MinorUnit
+	}
+
+	customCurrencyRegistry.Lock()
+	customCurrencyRegistry.minorUnits = minorUnits
+	customCurrencyRegistry.Unlock()
+}
+
+func customCurrencyMinorUnit(code string) (int, bool) {
+	customCurrencyRegistry.RLock()
+	defer customCurrencyRegistry.RUnlock()
+
+	minorUnit, ok := customCurrencyRegistry.minorUnits[code]
+	return minorUnit, ok
+}
+
+// IsISOCurrencyCode reports whether code is an active ISO 4217 currency code.
+func IsISOCurrencyCode(code string) bool {
+	_, ok := iso4217Codes[strings.ToUpper(strings.TrimSpace(code))]
+	return ok
+}
+
+func NormalizeCustomCurrencyInput(input CustomCurrencyInput) (CustomCurrencyInput, error) {
+	code, err := NormalizeCustomCurrencyCode(input.Code)
+	if err != nil {
+		return CustomCurrencyInput{}, err
+	}
+	if input.MinorUnit < 0 || input.MinorUnit > MaxCustomCurrencyMinorUnit {
+		return CustomCurrencyInput{}, ErrInvalidCurrencyMinorUnit
+	}
+
+	return CustomCurrencyInput{
+		Code:      code,
+		Name:      strings.TrimSpace(input.Name),
+		MinorUnit: input.MinorUnit,
+	}, nil
+}
+
+// NormalizeCustomCurrencyCode accepts three uppercase letters or digits starting
+// with a letter, matching the three-character currency columns of the ledger.
+func NormalizeCustomCurrencyCode(code string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if len(normalized) != 3 || normalized[0] < 'A' || normalized[0] > 'Z' {
+		return "", ErrInvalidCurrencyCode
+	}
+	for i := 1; i < len(normalized); i++ {
+		isLetter := normalized[i] >= 'A' && normalized[i] <= 'Z'
+		isDigit := normalized[i] >= '0' && normalized[i] <= '9'
+		if !isLetter && !isDigit {
+			return "", ErrInvalidCurrencyCode
+		}
+	}
+	return normalized, nil
+}
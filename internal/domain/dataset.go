@@ -0,0 +1,137 @@
+package domain
+
+import "errors"
+
+// DatasetFormatVersion is the archive layout written by `data export
+// --resource all`. Import rejects archives with any other version, newer ones
+// with ErrExportUpgradeRequired.
+const DatasetFormatVersion = 1
+
+var (
+	ErrInvalidDatasetArchive = errors.New("invalid dataset archive")
+	ErrInvalidCardEventsFile = errors.New("invalid card events file")
+)
+
+// Dataset is the reference data of a ledger keyed by natural names instead of
+// local IDs, so it can be restored into another database. Entries travel next
+// to it in the archive as natural-key portability records.
+type Dataset struct {
+	Categories      []DatasetCategory       `json:"categories"`
+	Labels          []DatasetLabel          `json:"labels"`
+	Cards           []DatasetCard           `json:"cards"`
+	Currencies      []DatasetCurrency       `json:"currencies"`
+	Caps            []DatasetCap            `json:"caps"`
+	LiabilityEvents []DatasetLiabilityEvent `json:"liability_events"`
+	Settings        *DatasetSettings        `json:"settings,omitempty"`
+}
+
+type DatasetCategory struct {
+	Name          string  `json:"name"`
+	Color         *string `json:"color,omitempty"`
+	Icon          *string `json:"icon,omitempty"`
+	ArchivedAtUTC *string `json:"archived_at_utc,omitempty"`
+}
+
+type DatasetLabel struct {
+	Name          string  `json:"name"`
+	Color         *string `json:"color,omitempty"`
+	Icon          *string `json:"icon,omitempty"`
+	ArchivedAtUTC *string `json:"archived_at_utc,omitempty"`
+}
+
+type DatasetCard struct {
+	Nickname            string             `json:"nickname"`
+	Description         *string            `json:"description,omitempty"`
+	Last4               string             `json:"last4"`
+	Brand               string             `json:"brand"`
+	CardType            string             `json:"card_type"`
+	DueDay              *int64             `json:"due_day,omitempty"`
+	DefaultCurrencyCode *string            `json:"default_currency_code,omitempty"`
+	FXFeeBasisPoints    *int64             `json:"fx_fee_bp,omitempty"`
+	ArchivedAtUTC       *string            `json:"archived_at_utc,omitempty"`
+	Aliases             []string           `json:"aliases,omitempty"`
+	MonthlyLimits       []DatasetCardLimit `json:"monthly_limits,omitempty"`
+}
+
+type DatasetCardLimit struct {
+	MonthKey     string `json:"month_key"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+type DatasetCurrency struct {
+	Code      string `json:"code"`
+	Name      string `json:"name,omitempty"`
+	MinorUnit int    `json:"minor_unit"`
+}
+
+// DatasetCap is a global cap when Category is empty, otherwise the cap of the
+// named category.
+type DatasetCap struct {
+	MonthKey     string `json:"month_key"`
+	Category     string `json:"category,omitempty"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// DatasetLiabilityEvent is a card payment or adjustment. Charges are not
+// archived: importing their card entries recreates them.
+type DatasetLiabilityEvent struct {
+	Card              string `json:"card"`
+	CurrencyCode      string `json:"currency_code"`
+	EventType         string `json:"event_type"`
+	AmountMinorSigned int64  `json:"amount_minor_signed"`
+	Note              string `json:"note,omitempty"`
+	CreatedAtUTC      string `json:"created_at_utc"`
+}
+
+type DatasetSettings struct {
+	DefaultCurrencyCode        string                    `json:"default_currency_code"`
+	DisplayTimezone            string                    `json:"display_timezone"`
+	OrphanCountThreshold       int64                     `json:"orphan_count_threshold"`
+	OrphanSpendingThresholdBPS int64                     `json:"orphan_spending_threshold_bps"`
+	AmountFormat               string                    `json:"amount_format"`
+	OnboardingCompletedAtUTC   *string                   `json:"onboarding_completed_at_utc,omitempty"`
+	OrphanSpendingThresholds   []OrphanSpendingThreshold `json:"orphan_spending_thresholds"`
+	StrictWarnings             []string                  `json:"strict_warnings"`
+	Preferences                *DatasetPreferences       `json:"preferences,omitempty"`
+}
+
+// DatasetPreferences are the settings changed with `settings set`. Archives
+// written before they were exported leave the local preferences alone. The
+// default card travels as its nickname.
+type DatasetPreferences struct {
+	DefaultOutput       *string `json:"default_output,omitempty"`
+	DefaultCard         *string `json:"default_card,omitempty"`
+	FiscalMonthStartDay int64   `json:"fiscal_month_start_day"`
+	DefaultRecordedBy   *string `json:"default_recorded_by,omitempty"`
+	AutoSnapshot        bool    `json:"auto_snapshot"`
+	FXCacheTTLHours     int64   `json:"fx_cache_ttl_hours"`
+	FXStaleAfterDays    int64   `json:"fx_stale_after_days"`
+	ReportCache         bool    `json:"report_cache"`
+}
+
+// DatasetRestoreResult counts the reference rows a restore created or
+// overwrote. Categories, labels, and cards that already exist by name are
+// reused and counted as matched.
+type DatasetRestoreResult struct {
+	CategoriesCreated      int64 `json:"categories_created"`
+	CategoriesMatched      int64 `json:"categories_matched"`
+	LabelsCreated          int64 `json:"labels_created"`
+	LabelsMatched          int64 `json:"labels_matched"`
+	CardsCreated           int64 `json:"cards_created"`
+	CardsMatched           int64 `json:"cards_matched"`
+	CardAliasesCreated     int64 `json:"card_aliases_created"`
+	CardLimitsRestored     int64 `json:"card_limits_restored"`
+	CurrenciesRestored     int64 `json:"currencies_restored"`
+	CapsRestored           int64 `json:"caps_restored"`
+	LiabilityEventsCreated int64 `json:"liability_events_created"`
+	LiabilityEventsSkipped int64 `json:"liability_events_skipped"`
+	SettingsRestored       bool  `json:"settings_restored"`
+
+	// Active IDs by lowercased name after the restore, for resolving the
+	// archive's entries.
+	CategoryIDs map[string]int64 `json:"-"`
+	LabelIDs    map[string]int64 `json:"-"`
+	CardIDs     map[string]int64 `json:"-"`
+}
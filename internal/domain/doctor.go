@@ -0,0 +1,63 @@
+package domain
+
+const (
+	DoctorCheckIntegrity                 = "integrity"
+	DoctorCheckForeignKeys               = "foreign_keys"
+	DoctorCheckOrphanedTransactionLabels = "orphaned_transaction_labels"
+	DoctorCheckOrphanedPaymentMethods    = "orphaned_payment_methods"
+	DoctorCheckDanglingLiabilityEvents   = "dangling_liability_events"
+	DoctorCheckSchemaVersion             = "schema_version"
+
+	DoctorStatusOK      = "ok"
+	DoctorStatusProblem = "problem"
+	DoctorStatusFixed   = "fixed"
+	DoctorStatusSkipped = "skipped"
+
+	// DoctorSampleLimit caps how many offending rows a check lists.
+	DoctorSampleLimit = 20
+
+	WarningCodeDatabaseIssuesFound    = "DATABASE_ISSUES_FOUND"
+	DatabaseIssuesFoundWarningMessage = "Database health checks found problems; see data.doctor.checks."
+)
+
+// DoctorCheck is the outcome of one health check. Fixable checks are repaired
+// by `doctor --fix`; Fixed counts the rows a repair touched.
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Issues  int64  `json:"issues"`
+	Fixable bool   `json:"fixable"`
+	Fixed   int64  `json:"fixed"`
+	Message string `json:"message,omitempty"`
+	Sample  []any  `json:"sample,omitempty"`
+}
+
+type DoctorReport struct {
+	Healthy           bool          `json:"healthy"`
+	Fix               bool          `json:"fix"`
+	SchemaVersion     int64         `json:"schema_version"`
+	LatestVersion     int64         `json:"latest_version"`
+	PendingMigrations int           `json:"pending_migrations"`
+	Checks            []DoctorCheck `json:"checks"`
+}
+
+// Finalize sets each check's status from its counts and the report's overall
+// health: a check is healthy when it found nothing or a fix cleared it.
+func (r *DoctorReport) Finalize() {
+	r.Healthy = true
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		if check.Status == DoctorStatusSkipped {
+			continue
+		}
+		switch {
+		case check.Issues == 0:
+			check.Status = DoctorStatusOK
+		case check.Fixed >= check.Issues:
+			check.Status = DoctorStatusFixed
+		default:
+			check.Status = DoctorStatusProblem
+			r.Healthy = false
+		}
+	}
+}
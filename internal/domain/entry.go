@@ -2,9 +2,13 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -38,24 +42,81 @@ var (
 	ErrCardRequired           = errors.New("card is required for payment method")
 	ErrCardNotAllowed         = errors.New("card selector cannot be used with cash payment method")
 	ErrPaymentNotAllowed      = errors.New("payment method is not allowed for income entries")
+	ErrCurrencyFixSameCode    = errors.New("currency fix source and target are the same")
+	ErrCurrencyFixMinorUnit   = errors.New("currency fix source and target minor units differ")
+	ErrRefundRequiresExpense  = errors.New("refund must be an expense")
+	ErrInvalidRefundTarget    = errors.New("refund target must be an expense that is not a refund")
+	ErrRefundCurrencyMismatch = errors.New("refund currency must match the refunded entry")
+	ErrRefundExceedsOriginal  = errors.New("refunds exceed the refunded entry amount")
+	ErrEntryHasRefunds        = errors.New("entry has active refunds")
+	ErrEmptyEntryBatch        = errors.New("entry batch has no rows")
+	ErrInvalidAmountRange     = errors.New("invalid amount range")
+	ErrInvalidEntryUID        = errors.New("invalid entry uid")
+	ErrSourceNotAllowed       = errors.New("income source is not allowed for expense entries")
+	ErrInvalidLocation        = errors.New("invalid location coordinates")
 )
 
 type Entry struct {
-	ID                  int64   `json:"id"`
-	Type                string  `json:"type"`
-	AmountMinor         int64   `json:"amount_minor"`
-	CurrencyCode        string  `json:"currency_code"`
-	TransactionDateUTC  string  `json:"transaction_date_utc"`
-	CategoryID          *int64  `json:"category_id,omitempty"`
-	BankAccountID       *int64  `json:"bank_account_id,omitempty"`
-	LabelIDs            []int64 `json:"label_ids,omitempty"`
-	Note                string  `json:"note,omitempty"`
-	PaymentMethod       string  `json:"payment_method,omitempty"`
-	PaymentCardID       *int64  `json:"payment_card_id,omitempty"`
-	PaymentCardNickname string  `json:"payment_card_nickname,omitempty"`
-	PaymentCardType     string  `json:"payment_card_type,omitempty"`
-	CreatedAtUTC        string  `json:"created_at_utc"`
-	UpdatedAtUTC        string  `json:"updated_at_utc"`
+	ID                  int64                `json:"id"`
+	UID                 string               `json:"uid,omitempty"`
+	Type                string               `json:"type"`
+	AmountMinor         int64                `json:"amount_minor"`
+	CurrencyCode        string               `json:"currency_code"`
+	TransactionDateUTC  string               `json:"transaction_date_utc"`
+	CategoryID          *int64               `json:"category_id,omitempty"`
+	BankAccountID       *int64               `json:"bank_account_id,omitempty"`
+	RefundOfEntryID     *int64               `json:"refund_of_entry_id,omitempty"`
+	LabelIDs            []int64              `json:"label_ids,omitempty"`
+	Note                string               `json:"note,omitempty"`
+	Payee               string               `json:"payee,omitempty"`
+	RecordedBy          string               `json:"recorded_by,omitempty"`
+	IncomeSource        string               `json:"income_source,omitempty"`
+	Location            string               `json:"location,omitempty"`
+	Original            *EntryOriginalAmount `json:"original,omitempty"`
+	Splits              []EntrySplit         `json:"splits,omitempty"`
+	PaymentMethod       string               `json:"payment_method,omitempty"`
+	PaymentCardID       *int64               `json:"payment_card_id,omitempty"`
+	PaymentCardNickname string               `json:"payment_card_nickname,omitempty"`
+	PaymentCardType     string               `json:"payment_card_type,omitempty"`
+	PaymentCardBrand    string               `json:"payment_card_brand,omitempty"`
+	CreatedAtUTC        string               `json:"created_at_utc"`
+	UpdatedAtUTC        string               `json:"updated_at_utc"`
+}
+
+// EntryOriginalAmount is what an entry recorded in another currency was
+// entered as, with the rate and rate date it was converted at. FXRateStale
+// marks a conversion at a stale fallback rate and is not stored.
+type EntryOriginalAmount struct {
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	FXRate       string `json:"fx_rate"`
+	FXRateDate   string `json:"fx_rate_date"`
+	FXRateStale  bool   `json:"-"`
+}
+
+// IsRefund reports whether the entry gives back part of an earlier expense.
+func (e Entry) IsRefund() bool {
+	return e.RefundOfEntryID != nil
+}
+
+// EffectiveAmountMinor is what the entry adds to its type's totals. Refunds
+// are stored as positive expenses linked to the original and count negative,
+// so spending, net and cap totals come out net of refunds.
+func (e Entry) EffectiveAmountMinor() int64 {
+	if e.IsRefund() {
+		return -e.AmountMinor
+	}
+	return e.AmountMinor
+}
+
+// EntryCurrencyFix reports a bulk currency code correction. EntryIDs lists the
+// active entries that were (or, on a dry run, would be) moved to ToCurrency.
+type EntryCurrencyFix struct {
+	FromCurrency string  `json:"from_currency"`
+	ToCurrency   string  `json:"to_currency"`
+	EntryIDs     []int64 `json:"entry_ids"`
+	Updated      int64   `json:"updated"`
+	DryRun       bool    `json:"dry_run"`
 }
 
 type EntryAddInput struct {
@@ -67,10 +128,27 @@ type EntryAddInput struct {
 	BankAccountID       *int64
 	LabelIDs            []int64
 	Note                string
+	Payee               string
+	RecordedBy          string
+	IncomeSource        string
+	Location            string
 	PaymentMethod       string
 	PaymentCardID       *int64
 	PaymentCardNickname string
 	PaymentCardLookup   string
+	// RecordInCurrency converts the amount into this currency at the
+	// transaction date before it is stored; Original keeps what was entered.
+	RecordInCurrency string
+	Original         *EntryOriginalAmount
+	// RefundOfEntryID links an expense to the expense it refunds. A refund
+	// without category or payment method takes them from the original.
+	RefundOfEntryID *int64
+	// AllowArchived accepts archived categories and labels.
+	AllowArchived bool
+	// Splits makes the expense shared; RecordedBy paid it.
+	Splits []EntrySplit
+	// UID keeps an imported entry's identity; empty generates a new one.
+	UID string
 }
 
 type EntryUpdateInput struct {
@@ -87,12 +165,25 @@ type EntryUpdateInput struct {
 	LabelIDs            []int64
 	SetNote             bool
 	Note                *string
+	SetPayee            bool
+	Payee               *string
+	SetRecordedBy       bool
+	RecordedBy          *string
+	SetIncomeSource     bool
+	IncomeSource        *string
+	SetLocation         bool
+	Location            *string
 	SetPaymentMethod    bool
 	PaymentMethod       *string
 	SetPaymentCard      bool
 	PaymentCardID       *int64
 	PaymentCardNickname *string
 	PaymentCardLookup   *string
+	// ExpectedUpdatedAtUTC, when set, rejects the update with
+	// ErrUpdateConflict unless the entry still has this updated_at_utc.
+	ExpectedUpdatedAtUTC *string
+	// AllowArchived accepts newly assigned archived categories and labels.
+	AllowArchived bool
 }
 
 type EntryListFilter struct {
@@ -102,6 +193,13 @@ type EntryListFilter struct {
 	DateFromUTC         string
 	DateToUTC           string
 	NoteContains        string
+	Payee               string
+	RecordedBy          string
+	IncomeSource        string
+	LocationContains    string
+	CurrencyCode        string
+	AmountMinMinor      *int64
+	AmountMaxMinor      *int64
 	LabelIDs            []int64
 	LabelMode           string
 	PaymentMethod       string
@@ -110,6 +208,87 @@ type EntryListFilter struct {
 	PaymentCardLookup   string
 }
 
+// EntryListAggregates summarizes a list of entries. Currency totals follow
+// EffectiveAmountMinor, so refunds reduce expense_minor.
+type EntryListAggregates struct {
+	Count        int64                    `json:"count"`
+	CountsByType EntryTypeCounts          `json:"counts_by_type"`
+	ByCurrency   []EntryCurrencyAggregate `json:"by_currency"`
+	MinDateUTC   string                   `json:"min_date_utc,omitempty"`
+	MaxDateUTC   string                   `json:"max_date_utc,omitempty"`
+}
+
+type EntryTypeCounts struct {
+	Income  int64 `json:"income"`
+	Expense int64 `json:"expense"`
+}
+
+type EntryCurrencyAggregate struct {
+	CurrencyCode string `json:"currency_code"`
+	IncomeMinor  int64  `json:"income_minor"`
+	ExpenseMinor int64  `json:"expense_minor"`
+	NetMinor     int64  `json:"net_minor"`
+	EntryCount   int64  `json:"entry_count"`
+}
+
+// BuildEntryListAggregates totals entries per currency in currency order.
+func BuildEntryListAggregates(entries []Entry) EntryListAggregates {
+	aggregates := EntryListAggregates{
+		Count:      int64(len(entries)),
+		ByCurrency: []EntryCurrencyAggregate{},
+	}
+	byCurrency := map[string]*EntryCurrencyAggregate{}
+	for _, entry := range entries {
+		total, ok := byCurrency[entry.CurrencyCode]
+		if !ok {
+			total = &EntryCurrencyAggregate{CurrencyCode: entry.CurrencyCode}
+			byCurrency[entry.CurrencyCode] = total
+		}
+		total.EntryCount++
+		if entry.Type == EntryTypeIncome {
+			aggregates.CountsByType.Income++
+			total.IncomeMinor += entry.EffectiveAmountMinor()
+		} else {
+			aggregates.CountsByType.Expense++
+			total.ExpenseMinor += entry.EffectiveAmountMinor()
+		}
+
+		if aggregates.MinDateUTC == "" || entry.TransactionDateUTC < aggregates.MinDateUTC {
+			aggregates.MinDateUTC = entry.TransactionDateUTC
+		}
+		if entry.TransactionDateUTC > aggregates.MaxDateUTC {
+			aggregates.MaxDateUTC = entry.TransactionDateUTC
+		}
+	}
+
+	for _, total := range byCurrency {
+		total.NetMinor = total.IncomeMinor - total.ExpenseMinor
+		aggregates.ByCurrency = append(aggregates.ByCurrency, *total)
+	}
+	sort.Slice(aggregates.ByCurrency, func(i, j int) bool {
+		return aggregates.ByCurrency[i].CurrencyCode < aggregates.ByCurrency[j].CurrencyCode
+	})
+	return aggregates
+}
+
+// EntryBatchRowError is why one row of a batch add was rejected. Row is
+// 1-based in input order.
+type EntryBatchRowError struct {
+	Row int
+	Err error
+}
+
+// EntryBatchError is returned when any row of a batch add fails; the whole
+// batch is rolled back.
+type EntryBatchError struct {
+	RowCount int
+	Rows     []EntryBatchRowError
+}
+
+func (e *EntryBatchError) Error() string {
+	return fmt.Sprintf("%d of %d batch rows rejected", len(e.Rows), e.RowCount)
+}
+
 type EntryDeleteResult struct {
 	EntryID        int64  `json:"entry_id"`
 	DeletedAtUTC   string `json:"deleted_at_utc"`
@@ -132,6 +311,10 @@ func HasEntryUpdateChanges(input EntryUpdateInput) bool {
 		input.SetBankAccount ||
 		input.SetLabelIDs ||
 		input.SetNote ||
+		input.SetPayee ||
+		input.SetRecordedBy ||
+		input.SetIncomeSource ||
+		input.SetLocation ||
 		input.SetPaymentMethod ||
 		input.SetPaymentCard
 }
@@ -156,6 +339,9 @@ func NormalizeCurrencyCode(currencyCode string) (string, error) {
 	if len(normalized) != 3 {
 		return "", ErrInvalidCurrencyCode
 	}
+	if _, ok := customCurrencyMinorUnit(normalized); ok {
+		return normalized, nil
+	}
 	for i := 0; i < len(normalized); i++ {
 		if normalized[i] < 'A' || normalized[i] > 'Z' {
 			return "", ErrInvalidCurrencyCode
@@ -180,6 +366,82 @@ func NormalizeTransactionDateUTC(value string) (string, error) {
 	return "", ErrInvalidTransactionDate
 }
 
+// NormalizePayee trims the payee and collapses inner whitespace so the same
+// merchant typed twice groups together. Matching is case-insensitive.
+func NormalizePayee(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// NormalizeRecordedBy cleans the name of the person an entry is attributed
+// to. Like payees, names match case-insensitively.
+func NormalizeRecordedBy(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// NormalizeIncomeSource cleans the source an income entry came from. Sources
+// are free text like payees and match case-insensitively.
+func NormalizeIncomeSource(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// NormalizeEntryLocation cleans where an entry happened: free text such as
+// "Lisbon" is whitespace-collapsed, while a "lat,long" pair is checked against
+// the coordinate ranges and stored as "lat,long" without spaces.
+func NormalizeEntryLocation(value string) (string, error) {
+	location := strings.Join(strings.Fields(value), " ")
+	latRaw, longRaw, found := strings.Cut(location, ",")
+	if !found {
+		return location, nil
+	}
+	latRaw, longRaw = strings.TrimSpace(latRaw), strings.TrimSpace(longRaw)
+	if !isDecimalCoordinate(latRaw) || !isDecimalCoordinate(longRaw) {
+		// Not a coordinate pair, e.g. "Lisbon, Portugal".
+		return location, nil
+	}
+	lat, latErr := strconv.ParseFloat(latRaw, 64)
+	long, longErr := strconv.ParseFloat(longRaw, 64)
+	if latErr != nil || longErr != nil || lat < -90 || lat > 90 || long < -180 || long > 180 {
+		return "", ErrInvalidLocation
+	}
+	return latRaw + "," + longRaw, nil
+}
+
+// isDecimalCoordinate reports whether value is a plain signed decimal such as
+// "-9.1393".
+func isDecimalCoordinate(value string) bool {
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" {
+		return false
+	}
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewEntryUID returns a fresh identifier that stays with an entry across
+// exports and imports, unlike its numeric id.
+func NewEntryUID() string {
+	return uuid.NewString()
+}
+
+// NormalizeEntryUID lowercases a UUID uid. Empty stays empty so callers can
+// fall back to generating one.
+func NormalizeEntryUID(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", nil
+	}
+	parsed, err := uuid.Parse(trimmed)
+	if err != nil {
+		return "", ErrInvalidEntryUID
+	}
+	return parsed.String(), nil
+}
+
 func ValidateOptionalCategoryID(categoryID *int64) error {
 	if categoryID == nil {
 		return nil
@@ -236,6 +498,18 @@ func NormalizeOptionalTransactionDateUTC(value string) (string, error) {
 	return NormalizeTransactionDateUTC(trimmed)
 }
 
+// ValidateAmountRange checks optional amount filter bounds: both must be
+// non-negative and the minimum cannot exceed the maximum.
+func ValidateAmountRange(minMinor, maxMinor *int64) error {
+	if (minMinor != nil && *minMinor < 0) || (maxMinor != nil && *maxMinor < 0) {
+		return ErrInvalidAmountRange
+	}
+	if minMinor != nil && maxMinor != nil && *minMinor > *maxMinor {
+		return ErrInvalidAmountRange
+	}
+	return nil
+}
+
 func ValidateDateRange(fromUTC, toUTC string) error {
 	if fromUTC == "" || toUTC == "" {
 		return nil
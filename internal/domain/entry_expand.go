@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	EntryExpandCard     = "card"
+	EntryExpandCategory = "category"
+	EntryExpandLabels   = "labels"
+)
+
+var ErrInvalidEntryExpand = errors.New("invalid entry expand")
+
+// EntryExpand selects the related records embedded next to each entry.
+type EntryExpand struct {
+	Card     bool
+	Category bool
+	Labels   bool
+}
+
+// Any reports whether at least one relation is selected.
+func (e EntryExpand) Any() bool {
+	return e.Card || e.Category || e.Labels
+}
+
+// ParseEntryExpand reads a comma-separated list of card, category and labels.
+// An empty value selects nothing.
+func ParseEntryExpand(raw string) (EntryExpand, error) {
+	expand := EntryExpand{}
+	for _, part := range strings.Split(raw, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "":
+		case EntryExpandCard:
+			expand.Card = true
+		case EntryExpandCategory:
+			expand.Category = true
+		case EntryExpandLabels:
+			expand.Labels = true
+		default:
+			return EntryExpand{}, ErrInvalidEntryExpand
+		}
+	}
+	return expand, nil
+}
+
+// ExpandedEntry is an entry with the related records selected by an
+// EntryExpand. A nil PaymentCard or Category means the entry has none or it
+// was not requested; Labels is only set when requested.
+type ExpandedEntry struct {
+	Entry
+	PaymentCard *EntryCardRef     `json:"payment_card,omitempty"`
+	Category    *EntryCategoryRef `json:"category,omitempty"`
+	Labels      *[]EntryLabelRef  `json:"labels,omitempty"`
+}
+
+type EntryCardRef struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+	Last4    string `json:"last4"`
+	Brand    string `json:"brand"`
+	CardType string `json:"card_type"`
+}
+
+type EntryCategoryRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type EntryLabelRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
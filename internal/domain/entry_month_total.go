@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+)
+
+// EntryMonthTotal is the maintained total of one UTC month's active entries
+// in one currency. ExpenseMinor follows EffectiveAmountMinor, so refunds
+// reduce it.
+type EntryMonthTotal struct {
+	MonthKey     string
+	CurrencyCode string
+	IncomeMinor  int64
+	ExpenseMinor int64
+	EntryCount   int64
+}
+
+// EntryMonthTotalsFilter selects month totals between two month keys,
+// inclusive. Empty bounds and currency match everything.
+type EntryMonthTotalsFilter struct {
+	FromMonth    string
+	ToMonth      string
+	CurrencyCode string
+}
+
+// MonthTotalsFilterFor returns the month totals that add up to the same
+// per-currency net as the entries filter matches. It only succeeds for
+// filters on currency and whole UTC months; anything else needs the entries.
+func MonthTotalsFilterFor(filter EntryListFilter) (EntryMonthTotalsFilter, bool) {
+	if !onlyMonthTotalsFilterFields(filter) {
+		return EntryMonthTotalsFilter{}, false
+	}
+
+	totalsFilter := EntryMonthTotalsFilter{CurrencyCode: filter.CurrencyCode}
+	if filter.DateFromUTC != "" {
+		from, err := time.Parse(time.RFC3339Nano, filter.DateFromUTC)
+		if err != nil {
+			return EntryMonthTotalsFilter{}, false
+		}
+		from = from.UTC()
+		if !from.Equal(time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+			return EntryMonthTotalsFilter{}, false
+		}
+		totalsFilter.FromMonth = from.Format("2006-01")
+	}
+	if filter.DateToUTC != "" {
+		to, err := time.Parse(time.RFC3339Nano, filter.DateToUTC)
+		if err != nil {
+			return EntryMonthTotalsFilter{}, false
+		}
+		to = to.UTC()
+		next := to.Add(time.Nanosecond)
+		if !next.Equal(time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+			return EntryMonthTotalsFilter{}, false
+		}
+		totalsFilter.ToMonth = to.Format("2006-01")
+	}
+	return totalsFilter, true
+}
+
+// onlyMonthTotalsFilterFields reports whether filter sets nothing besides the
+// fields month totals can answer. It clears those and checks that the rest is
+// zero, so a field added to EntryListFilter later takes the entries path
+// until it is listed here. LabelMode only narrows LabelIDs.
+func onlyMonthTotalsFilterFields(filter EntryListFilter) bool {
+	rest := filter
+	rest.DateFromUTC = ""
+	rest.DateToUTC = ""
+	rest.CurrencyCode = ""
+	rest.LabelMode = ""
+	if len(rest.LabelIDs) == 0 {
+		rest.LabelIDs = nil
+	}
+	return reflect.ValueOf(rest).IsZero()
+}
+
+// NetByCurrencyFromMonthTotals sums income minus expenses per currency.
+func NetByCurrencyFromMonthTotals(totals []EntryMonthTotal) map[string]int64 {
+	net := map[string]int64{}
+	for _, total := range totals {
+		net[total.CurrencyCode] += total.IncomeMinor - total.ExpenseMinor
+	}
+	return net
+}
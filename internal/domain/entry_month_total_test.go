@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMonthTotalsFilterForWholeMonths(t *testing.T) {
+	t.Parallel()
+
+	got, ok := MonthTotalsFilterFor(EntryListFilter{
+		DateFromUTC:  "2026-01-01T00:00:00Z",
+		DateToUTC:    "2026-03-31T23:59:59.999999999Z",
+		CurrencyCode: "USD",
+		LabelMode:    "any",
+		LabelIDs:     []int64{},
+	})
+	if !ok {
+		t.Fatalf("expected whole-month currency filter to use month totals")
+	}
+	want := EntryMonthTotalsFilter{FromMonth: "2026-01", ToMonth: "2026-03", CurrencyCode: "USD"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if _, ok := MonthTotalsFilterFor(EntryListFilter{DateFromUTC: "2026-01-02T00:00:00Z"}); ok {
+		t.Fatalf("expected a mid-month start to need the entries")
+	}
+	if _, ok := MonthTotalsFilterFor(EntryListFilter{DateToUTC: "2026-01-30T23:59:59Z"}); ok {
+		t.Fatalf("expected a mid-month end to need the entries")
+	}
+}
+
+// TestMonthTotalsFilterForRejectsOtherFields sets each EntryListFilter field
+// in turn, so a field added later cannot reach month totals unnoticed.
+func TestMonthTotalsFilterForRejectsOtherFields(t *testing.T) {
+	t.Parallel()
+
+	supported := map[string]bool{
+		"DateFromUTC":  true,
+		"DateToUTC":    true,
+		"CurrencyCode": true,
+		"LabelMode":    true,
+	}
+
+	filterType := reflect.TypeOf(EntryListFilter{})
+	for i := 0; i < filterType.NumField(); i++ {
+		field := filterType.Field(i)
+		if supported[field.Name] {
+			continue
+		}
+
+		var filter EntryListFilter
+		value := reflect.ValueOf(&filter).Elem().Field(i)
+		switch field.Type.Kind() {
+		case reflect.String:
+			value.SetString("x")
+		case reflect.Pointer:
+			value.Set(reflect.New(field.Type.Elem()))
+		case reflect.Slice:
+			value.Set(reflect.MakeSlice(field.Type, 1, 1))
+		default:
+			t.Fatalf("field %s has unhandled kind %s; extend this test", field.Name, field.Type.Kind())
+		}
+
+		if _, ok := MonthTotalsFilterFor(filter); ok {
+			t.Fatalf("expected a filter on %s to need the entries", field.Name)
+		}
+	}
+}
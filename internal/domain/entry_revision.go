@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrInvalidEntryRevision  = errors.New("invalid entry revision")
+	ErrEntryRevisionNotFound = errors.New("entry revision not found")
+)
+
+// EntryRevisionState is the editable part of an entry that a revision keeps:
+// everything entry update can change. PaymentMethod and PaymentCardID are
+// only set for expenses, IncomeSource only for income.
+type EntryRevisionState struct {
+	Type               string  `json:"type"`
+	AmountMinor        int64   `json:"amount_minor"`
+	CurrencyCode       string  `json:"currency_code"`
+	TransactionDateUTC string  `json:"transaction_date_utc"`
+	CategoryID         *int64  `json:"category_id"`
+	BankAccountID      *int64  `json:"bank_account_id"`
+	LabelIDs           []int64 `json:"label_ids"`
+	Note               string  `json:"note"`
+	Payee              string  `json:"payee"`
+	RecordedBy         string  `json:"recorded_by"`
+	IncomeSource       string  `json:"income_source,omitempty"`
+	Location           string  `json:"location,omitempty"`
+	PaymentMethod      string  `json:"payment_method,omitempty"`
+	PaymentCardID      *int64  `json:"payment_card_id,omitempty"`
+}
+
+// EntryRevision is the state an entry had until an update replaced it at
+// ReplacedAtUTC. Revisions are numbered from 1 per entry.
+type EntryRevision struct {
+	EntryID       int64              `json:"entry_id"`
+	Revision      int64              `json:"revision"`
+	ReplacedAtUTC string             `json:"replaced_at_utc"`
+	State         EntryRevisionState `json:"state"`
+}
+
+// EntryFieldChange is one field an update changed.
+type EntryFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// EntryHistoryItem is one revision with the changes the update that replaced
+// it made, i.e. the diff to the next revision or to the current entry.
+type EntryHistoryItem struct {
+	EntryRevision
+	Changes []EntryFieldChange `json:"changes"`
+}
+
+type EntryHistory struct {
+	Entry     Entry              `json:"entry"`
+	Revisions []EntryHistoryItem `json:"revisions"`
+}
+
+// EntryRevisionStateOf returns the revision state of entry.
+func EntryRevisionStateOf(entry Entry) EntryRevisionState {
+	labelIDs := append([]int64{}, entry.LabelIDs...)
+	slices.Sort(labelIDs)
+	state := EntryRevisionState{
+		Type:               entry.Type,
+		AmountMinor:        entry.AmountMinor,
+		CurrencyCode:       entry.CurrencyCode,
+		TransactionDateUTC: entry.TransactionDateUTC,
+		CategoryID:         entry.CategoryID,
+		BankAccountID:      entry.BankAccountID,
+		LabelIDs:           labelIDs,
+		Note:               entry.Note,
+		Payee:              entry.Payee,
+		RecordedBy:         entry.RecordedBy,
+		Location:           entry.Location,
+	}
+	if entry.Type == EntryTypeIncome {
+		state.IncomeSource = entry.IncomeSource
+	}
+	if entry.Type == EntryTypeExpense {
+		state.PaymentMethod = entry.PaymentMethod
+		state.PaymentCardID = entry.PaymentCardID
+	}
+	return state
+}
+
+// DiffEntryRevisionStates lists the fields that differ from before to after,
+// in a fixed order.
+func DiffEntryRevisionStates(before, after EntryRevisionState) []EntryFieldChange {
+	changes := []EntryFieldChange{}
+	add := func(field string, from, to any, equal bool) {
+		if !equal {
+			changes = append(changes, EntryFieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("type", before.Type, after.Type, before.Type == after.Type)
+	add("amount_minor", before.AmountMinor, after.AmountMinor, before.AmountMinor == after.AmountMinor)
+	add("currency_code", before.CurrencyCode, after.CurrencyCode, before.CurrencyCode == after.CurrencyCode)
+	add("transaction_date_utc", before.TransactionDateUTC, after.TransactionDateUTC, before.TransactionDateUTC == after.TransactionDateUTC)
+	add("category_id", before.CategoryID, after.CategoryID, equalInt64Ptr(before.CategoryID, after.CategoryID))
+	add("bank_account_id", before.BankAccountID, after.BankAccountID, equalInt64Ptr(before.BankAccountID, after.BankAccountID))
+	add("label_ids", before.LabelIDs, after.LabelIDs, slices.Equal(before.LabelIDs, after.LabelIDs))
+	add("note", before.Note, after.Note, before.Note == after.Note)
+	add("payee", before.Payee, after.Payee, before.Payee == after.Payee)
+	add("recorded_by", before.RecordedBy, after.RecordedBy, before.RecordedBy == after.RecordedBy)
+	add("income_source", before.IncomeSource, after.IncomeSource, before.IncomeSource == after.IncomeSource)
+	add("location", before.Location, after.Location, before.Location == after.Location)
+	add("payment_method", before.PaymentMethod, after.PaymentMethod, before.PaymentMethod == after.PaymentMethod)
+	add("payment_card_id", before.PaymentCardID, after.PaymentCardID, equalInt64Ptr(before.PaymentCardID, after.PaymentCardID))
+	return changes
+}
+
+// EntryUpdateForRevision builds the update that puts an entry back into
+// state. Archived categories and labels are allowed because the entry used
+// them before.
+func EntryUpdateForRevision(id int64, state EntryRevisionState) EntryUpdateInput {
+	entryType := state.Type
+	amountMinor := state.AmountMinor
+	currencyCode := state.CurrencyCode
+	transactionDateUTC := state.TransactionDateUTC
+	input := EntryUpdateInput{
+		ID:                 id,
+		Type:               &entryType,
+		AmountMinor:        &amountMinor,
+		CurrencyCode:       &currencyCode,
+		TransactionDateUTC: &transactionDateUTC,
+		SetCategory:        true,
+		CategoryID:         state.CategoryID,
+		SetBankAccount:     true,
+		BankAccountID:      state.BankAccountID,
+		SetLabelIDs:        true,
+		LabelIDs:           append([]int64{}, state.LabelIDs...),
+		SetNote:            true,
+		SetPayee:           true,
+		SetRecordedBy:      true,
+		SetIncomeSource:    true,
+		SetLocation:        true,
+		AllowArchived:      true,
+	}
+	if state.Note != "" {
+		note := state.Note
+		input.Note = &note
+	}
+	if state.Payee != "" {
+		payee := state.Payee
+		input.Payee = &payee
+	}
+	if state.RecordedBy != "" {
+		recordedBy := state.RecordedBy
+		input.RecordedBy = &recordedBy
+	}
+	if state.IncomeSource != "" {
+		incomeSource := state.IncomeSource
+		input.IncomeSource = &incomeSource
+	}
+	if state.Location != "" {
+		location := state.Location
+		input.Location = &location
+	}
+	if state.Type == EntryTypeExpense && state.PaymentMethod != "" {
+		method := state.PaymentMethod
+		input.SetPaymentMethod = true
+		input.PaymentMethod = &method
+		if state.PaymentCardID != nil {
+			cardID := *state.PaymentCardID
+			input.SetPaymentCard = true
+			input.PaymentCardID = &cardID
+		}
+	}
+	return input
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
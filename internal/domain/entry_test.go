@@ -184,3 +184,30 @@ func TestValidateDateRange(t *testing.T) {
 		t.Fatalf("expected ErrInvalidDateRange, got %v", err)
 	}
 }
+
+func TestNormalizeEntryLocation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"  Lisbon,   Portugal ": "Lisbon, Portugal",
+		"38.7223 , -9.1393":     "38.7223,-9.1393",
+		"-33.86,151.2":          "-33.86,151.2",
+		"Terminal 1e5, Gate 2":  "Terminal 1e5, Gate 2",
+		"":                      "",
+	}
+	for raw, want := range cases {
+		got, err := NormalizeEntryLocation(raw)
+		if err != nil {
+			t.Fatalf("normalize location %q: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("normalize location %q: expected %q, got %q", raw, want, got)
+		}
+	}
+
+	for _, raw := range []string{"91,0", "0,-180.5"} {
+		if _, err := NormalizeEntryLocation(raw); !errors.Is(err, ErrInvalidLocation) {
+			t.Fatalf("expected ErrInvalidLocation for %q, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEnvelopeID     = errors.New("invalid envelope id")
+	ErrEnvelopeNameRequired  = errors.New("envelope name is required")
+	ErrInvalidEnvelopeBudget = errors.New("invalid envelope budget")
+	ErrInvalidEnvelopeDate   = errors.New("invalid envelope date")
+	ErrEnvelopeNotFound      = errors.New("envelope not found")
+)
+
+// Envelope is a budget for a trip or project that spans categories. Expenses
+// carrying LabelID and dated StartDate through EndDate (inclusive, UTC) count
+// against it, independently of monthly caps.
+type Envelope struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	CurrencyCode string  `json:"currency_code"`
+	BudgetMinor  int64   `json:"budget_minor"`
+	StartDate    string  `json:"start_date"`
+	EndDate      string  `json:"end_date"`
+	LabelID      int64   `json:"label_id"`
+	Note         string  `json:"note,omitempty"`
+	CreatedAtUTC string  `json:"created_at_utc"`
+	UpdatedAtUTC string  `json:"updated_at_utc"`
+	DeletedAtUTC *string `json:"deleted_at_utc,omitempty"`
+}
+
+type EnvelopeAddInput struct {
+	Name         string
+	CurrencyCode string
+	BudgetMinor  int64
+	StartDate    string
+	EndDate      string
+	LabelID      int64
+	Note         string
+}
+
+type EnvelopeDeleteResult struct {
+	EnvelopeID   int64  `json:"envelope_id"`
+	DeletedAtUTC string `json:"deleted_at_utc"`
+}
+
+// EnvelopeStatus is an envelope with the spending counted against it.
+// Labeled expenses in other currencies are not converted; they are only
+// counted in OtherCurrencyEntryCount.
+type EnvelopeStatus struct {
+	Envelope                Envelope `json:"envelope"`
+	SpentMinor              int64    `json:"spent_minor"`
+	RemainingMinor          int64    `json:"remaining_minor"`
+	EntryCount              int64    `json:"entry_count"`
+	OtherCurrencyEntryCount int64    `json:"other_currency_entry_count"`
+	Overspent               bool     `json:"overspent"`
+}
+
+func ValidateEnvelopeID(id int64) error {
+	if id <= 0 {
+		return ErrInvalidEnvelopeID
+	}
+	return nil
+}
+
+func NormalizeEnvelopeAddInput(input EnvelopeAddInput) (EnvelopeAddInput, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return EnvelopeAddInput{}, ErrEnvelopeNameRequired
+	}
+	currencyCode, err := NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return EnvelopeAddInput{}, err
+	}
+	if input.BudgetMinor <= 0 {
+		return EnvelopeAddInput{}, ErrInvalidEnvelopeBudget
+	}
+	startDate, err := normalizeEnvelopeDate(input.StartDate)
+	if err != nil {
+		return EnvelopeAddInput{}, err
+	}
+	endDate, err := normalizeEnvelopeDate(input.EndDate)
+	if err != nil {
+		return EnvelopeAddInput{}, err
+	}
+	if endDate < startDate {
+		return EnvelopeAddInput{}, ErrInvalidDateRange
+	}
+	if err := ValidateLabelID(input.LabelID); err != nil {
+		return EnvelopeAddInput{}, err
+	}
+
+	return EnvelopeAddInput{
+		Name:         name,
+		CurrencyCode: currencyCode,
+		BudgetMinor:  input.BudgetMinor,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		LabelID:      input.LabelID,
+		Note:         strings.TrimSpace(input.Note),
+	}, nil
+}
+
+// EnvelopeDateRangeUTC returns the RFC3339 bounds covering the envelope's
+// start and end dates in full.
+func EnvelopeDateRangeUTC(envelope Envelope) (string, string, error) {
+	start, err := time.Parse("2006-01-02", envelope.StartDate)
+	if err != nil {
+		return "", "", ErrInvalidEnvelopeDate
+	}
+	end, err := time.Parse("2006-01-02", envelope.EndDate)
+	if err != nil {
+		return "", "", ErrInvalidEnvelopeDate
+	}
+	end = end.Add(24*time.Hour - time.Nanosecond)
+	return start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano), nil
+}
+
+// BuildEnvelopeStatus sums the expenses that carry the envelope label, net of
+// refunds. Callers pass the entries dated within the envelope range.
+func BuildEnvelopeStatus(envelope Envelope, entries []Entry) EnvelopeStatus {
+	status := EnvelopeStatus{Envelope: envelope}
+	for _, entry := range entries {
+		if entry.Type != EntryTypeExpense || !entryHasLabel(entry, envelope.LabelID) {
+			continue
+		}
+		if entry.CurrencyCode != envelope.CurrencyCode {
+			status.OtherCurrencyEntryCount++
+			continue
+		}
+		status.SpentMinor += entry.EffectiveAmountMinor()
+		status.EntryCount++
+	}
+	status.RemainingMinor = envelope.BudgetMinor - status.SpentMinor
+	status.Overspent = status.RemainingMinor < 0
+	return status
+}
+
+func normalizeEnvelopeDate(value string) (string, error) {
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return "", ErrInvalidEnvelopeDate
+	}
+	return parsed.Format("2006-01-02"), nil
+}
+
+func entryHasLabel(entry Entry, labelID int64) bool {
+	for _, id := range entry.LabelIDs {
+		if id == labelID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeEnvelopeAddInput(t *testing.T) {
+	t.Parallel()
+
+	got, err := NormalizeEnvelopeAddInput(EnvelopeAddInput{
+		Name:         "  Japan trip 2026 ",
+		CurrencyCode: "usd",
+		BudgetMinor:  300000,
+		StartDate:    "2026-04-01",
+		EndDate:      "2026-04-15",
+		LabelID:      3,
+	})
+	if err != nil {
+		t.Fatalf("normalize envelope: %v", err)
+	}
+	if got.Name != "Japan trip 2026" || got.CurrencyCode != "USD" {
+		t.Fatalf("unexpected normalized envelope: %+v", got)
+	}
+
+	base := EnvelopeAddInput{Name: "Trip", CurrencyCode: "USD", BudgetMinor: 100, StartDate: "2026-04-01", EndDate: "2026-04-15", LabelID: 1}
+	cases := []struct {
+		mutate func(*EnvelopeAddInput)
+		want   error
+	}{
+		{func(in *EnvelopeAddInput) { in.Name = " " }, ErrEnvelopeNameRequired},
+		{func(in *EnvelopeAddInput) { in.BudgetMinor = 0 }, ErrInvalidEnvelopeBudget},
+		{func(in *EnvelopeAddInput) { in.StartDate = "2026-04" }, ErrInvalidEnvelopeDate},
+		{func(in *EnvelopeAddInput) { in.EndDate = "2026-03-31" }, ErrInvalidDateRange},
+		{func(in *EnvelopeAddInput) { in.LabelID = 0 }, ErrInvalidLabelID},
+	}
+	for i, tc := range cases {
+		input := base
+		tc.mutate(&input)
+		if _, err := NormalizeEnvelopeAddInput(input); !errors.Is(err, tc.want) {
+			t.Fatalf("case %d: expected %v, got %v", i, tc.want, err)
+		}
+	}
+}
+
+func TestBuildEnvelopeStatusCountsLabeledExpenses(t *testing.T) {
+	t.Parallel()
+
+	refundOf := int64(1)
+	envelope := Envelope{CurrencyCode: "USD", BudgetMinor: 10000, LabelID: 7}
+	status := BuildEnvelopeStatus(envelope, []Entry{
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 8000, CurrencyCode: "USD", LabelIDs: []int64{7}},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 5000, CurrencyCode: "USD", LabelIDs: []int64{2, 7}},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 1000, CurrencyCode: "USD", LabelIDs: []int64{7}, RefundOfEntryID: &refundOf},
+		{ID: 4, Type: EntryTypeExpense, AmountMinor: 9000, CurrencyCode: "USD", LabelIDs: []int64{2}},
+		{ID: 5, Type: EntryTypeIncome, AmountMinor: 9000, CurrencyCode: "USD", LabelIDs: []int64{7}},
+		{ID: 6, Type: EntryTypeExpense, AmountMinor: 20000, CurrencyCode: "JPY", LabelIDs: []int64{7}},
+	})
+
+	if status.SpentMinor != 12000 || status.EntryCount != 3 {
+		t.Fatalf("expected 12000 spent over 3 entries, got %+v", status)
+	}
+	if status.RemainingMinor != -2000 || !status.Overspent {
+		t.Fatalf("expected overspent by 2000, got %+v", status)
+	}
+	if status.OtherCurrencyEntryCount != 1 {
+		t.Fatalf("expected 1 other-currency entry, got %d", status.OtherCurrencyEntryCount)
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+// EntryExportSchemaVersion is the layout of entry exports written by `data
+// export --resource entries` and `data mirror`. Version 2 added the
+// schema_version marker itself and entry uids, version 3 income sources,
+// version 4 locations and version 5 payment methods and card nicknames in
+// ID-keyed files; files without a marker are version 1 and are upgraded on
+// import.
+const EntryExportSchemaVersion = 5
+
+// LegacyEntryExportSchemaVersion is assumed for files that carry no
+// schema_version.
+const LegacyEntryExportSchemaVersion = 1
+
+var (
+	ErrInvalidExportSchemaVersion = errors.New("invalid export schema version")
+	// ErrExportUpgradeRequired rejects files written by a newer version of the
+	// app, whose layout this build cannot read safely.
+	ErrExportUpgradeRequired = errors.New("export was written by a newer version")
+)
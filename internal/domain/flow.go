@@ -0,0 +1,34 @@
+package domain
+
+const (
+	FlowNodeKindIncomeSource  = "income_source"
+	FlowNodeKindBudget        = "budget"
+	FlowNodeKindCategory      = "category"
+	FlowNodeKindPaymentMethod = "payment_method"
+
+	FlowBudgetNodeID = "budget"
+)
+
+// FlowGraph is a node/edge view of a period's money flow for Sankey
+// diagrams: income sources feed the budget, the budget feeds spending
+// categories, and each category drains into the payment instruments used.
+type FlowGraph struct {
+	Period ReportPeriod `json:"period"`
+	Nodes  []FlowNode   `json:"nodes"`
+	Links  []FlowLink   `json:"links"`
+}
+
+type FlowNode struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Kind  string `json:"kind"`
+}
+
+// FlowLink carries one currency; a chart should pick a single currency (or
+// a converted report) since amounts in different currencies do not add up.
+type FlowLink struct {
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	CurrencyCode string `json:"currency_code"`
+	ValueMinor   int64  `json:"value_minor"`
+}
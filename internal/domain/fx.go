@@ -4,11 +4,22 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	WarningCodeFXEstimateUsed = "FX_ESTIMATE_USED"
 	FXEstimateWarningMessage  = "Future-dated conversion used latest available FX rate estimate."
+	WarningCodeFXRateStale    = "FX_RATE_STALE"
+	FXRateStaleWarningMessage = "Conversion fell back to a cached FX rate older than the staleness limit."
+)
+
+const (
+	DefaultFXCacheTTLHours  = 24
+	DefaultFXStaleAfterDays = 7
+	MaxFXCacheTTLHours      = 8760
+	MaxFXStaleAfterDays     = 3650
+	fxRateDateLayout        = "2006-01-02"
 )
 
 var (
@@ -37,9 +48,13 @@ type FXRateSnapshotCreateInput struct {
 	FetchedAtUTC  string
 }
 
+// ConvertedAmount is a converted amount and the snapshot it used. Stale is
+// set when the provider could not be reached and the cached rate that was
+// used instead is older than the staleness limit.
 type ConvertedAmount struct {
 	AmountMinor int64
 	Snapshot    FXRateSnapshot
+	Stale       bool
 }
 
 type ConvertedSummary struct {
@@ -48,6 +63,87 @@ type ConvertedSummary struct {
 	SpendingMinor    int64  `json:"spending_minor"`
 	NetMinor         int64  `json:"net_minor"`
 	UsedEstimateRate bool   `json:"used_estimate_rate"`
+	UsedStaleRate    bool   `json:"used_stale_rate"`
+}
+
+// FXCachePolicy tunes the rate cache. Latest rates fetched for future-dated
+// conversions are reused for TTLHours (0 always refetches), and a cached rate
+// used as a fallback is stale once it is more than StaleAfterDays old.
+type FXCachePolicy struct {
+	TTLHours       int64 `json:"ttl_hours"`
+	StaleAfterDays int64 `json:"stale_after_days"`
+}
+
+func DefaultFXCachePolicy() FXCachePolicy {
+	return FXCachePolicy{
+		TTLHours:       DefaultFXCacheTTLHours,
+		StaleAfterDays: DefaultFXStaleAfterDays,
+	}
+}
+
+// FXCachePair summarizes the cached snapshots of one provider and currency
+// pair.
+type FXCachePair struct {
+	Provider         string `json:"provider"`
+	BaseCurrency     string `json:"base_currency"`
+	QuoteCurrency    string `json:"quote_currency"`
+	SnapshotCount    int64  `json:"snapshot_count"`
+	OldestRateDate   string `json:"oldest_rate_date"`
+	NewestRateDate   string `json:"newest_rate_date"`
+	LastFetchedAtUTC string `json:"last_fetched_at_utc"`
+	AgeDays          int64  `json:"age_days"`
+	Stale            bool   `json:"stale"`
+}
+
+type FXCacheStatus struct {
+	Policy FXCachePolicy `json:"policy"`
+	Pairs  []FXCachePair `json:"pairs"`
+}
+
+// BuildFXCacheStatus ages each pair by its newest rate date as of asOf and
+// flags those older than the policy's staleness limit.
+func BuildFXCacheStatus(pairs []FXCachePair, policy FXCachePolicy, asOf time.Time) FXCacheStatus {
+	status := FXCacheStatus{Policy: policy, Pairs: make([]FXCachePair, 0, len(pairs))}
+	for _, pair := range pairs {
+		if ageDays, ok := FXRateAgeDays(pair.NewestRateDate, asOf); ok {
+			pair.AgeDays = ageDays
+			pair.Stale = IsFXRateStale(ageDays, policy)
+		}
+		status.Pairs = append(status.Pairs, pair)
+	}
+	return status
+}
+
+// FXRateAgeDays counts the whole days from rateDate (YYYY-MM-DD) to asOf.
+func FXRateAgeDays(rateDate string, asOf time.Time) (int64, bool) {
+	parsed, err := time.Parse(fxRateDateLayout, strings.TrimSpace(rateDate))
+	if err != nil {
+		return 0, false
+	}
+	day := time.Date(asOf.UTC().Year(), asOf.UTC().Month(), asOf.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	ageDays := int64(day.Sub(parsed) / (24 * time.Hour))
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return ageDays, true
+}
+
+func IsFXRateStale(ageDays int64, policy FXCachePolicy) bool {
+	return policy.StaleAfterDays > 0 && ageDays > policy.StaleAfterDays
+}
+
+func NormalizeFXCacheTTLHours(hours int64) (int64, error) {
+	if hours < 0 || hours > MaxFXCacheTTLHours {
+		return 0, ErrInvalidSettingValue
+	}
+	return hours, nil
+}
+
+func NormalizeFXStaleAfterDays(days int64) (int64, error) {
+	if days < 1 || days > MaxFXStaleAfterDays {
+		return 0, ErrInvalidSettingValue
+	}
+	return days, nil
 }
 
 func ValidateFXRate(rate string) error {
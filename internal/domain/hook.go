@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const (
+	// HookEventCapExceeded fires when a command's envelope carries a
+	// CAP_EXCEEDED or CATEGORY_CAP_EXCEEDED warning.
+	HookEventCapExceeded = "cap_exceeded"
+	// HookEventCardDue fires when `card due show|list` finds a card due
+	// within the hook's WithinDays.
+	HookEventCardDue = "card_due"
+	// HookEventImportCompleted fires after a successful `data import` or
+	// `data mirror import`.
+	HookEventImportCompleted = "import_completed"
+
+	HookKindCommand = "command"
+	HookKindHTTP    = "http"
+
+	DefaultHookCardDueWithinDays = 3
+	maxHookCardDueWithinDays     = 31
+)
+
+var HookEvents = []string{HookEventCapExceeded, HookEventCardDue, HookEventImportCompleted}
+
+var (
+	ErrInvalidHookID         = errors.New("invalid hook id")
+	ErrInvalidHookEvent      = errors.New("invalid hook event")
+	ErrInvalidHookTarget     = errors.New("invalid hook target")
+	ErrInvalidHookWithinDays = errors.New("invalid hook within days")
+	ErrHookNotFound          = errors.New("hook not found")
+)
+
+// Hook runs Target when Event fires: a shell command that receives the JSON
+// envelope on stdin, or an HTTP endpoint that receives it as a POST body.
+type Hook struct {
+	ID           int64  `json:"id"`
+	Event        string `json:"event"`
+	Kind         string `json:"kind"`
+	Target       string `json:"target"`
+	WithinDays   *int64 `json:"within_days,omitempty"`
+	CreatedAtUTC string `json:"created_at_utc"`
+}
+
+type HookAddInput struct {
+	Event      string
+	Kind       string
+	Target     string
+	WithinDays *int64
+}
+
+// HookDelivery is the outcome of running one hook. Failures are reported,
+// never returned, so a broken hook cannot fail the command that fired it.
+type HookDelivery struct {
+	HookID int64  `json:"hook_id"`
+	Event  string `json:"event"`
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	Error  string `json:"error,omitempty"`
+}
+
+func ValidateHookID(id int64) error {
+	if id <= 0 {
+		return ErrInvalidHookID
+	}
+	return nil
+}
+
+func NormalizeHookEvent(event string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(event))
+	for _, known := range HookEvents {
+		if normalized == known {
+			return normalized, nil
+		}
+	}
+	return "", ErrInvalidHookEvent
+}
+
+// NormalizeHookAddInput validates the event and target. Card-due hooks default
+// to DefaultHookCardDueWithinDays; other events take no WithinDays.
+func NormalizeHookAddInput(input HookAddInput) (HookAddInput, error) {
+	event, err := NormalizeHookEvent(input.Event)
+	if err != nil {
+		return HookAddInput{}, err
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(input.Kind))
+	target := strings.TrimSpace(input.Target)
+	if target == "" {
+		return HookAddInput{}, ErrInvalidHookTarget
+	}
+	switch kind {
+	case HookKindCommand:
+	case HookKindHTTP:
+		parsed, err := url.Parse(target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return HookAddInput{}, ErrInvalidHookTarget
+		}
+	default:
+		return HookAddInput{}, ErrInvalidHookTarget
+	}
+
+	withinDays := input.WithinDays
+	if event == HookEventCardDue {
+		if withinDays == nil {
+			days := int64(DefaultHookCardDueWithinDays)
+			withinDays = &days
+		}
+		if *withinDays < 0 || *withinDays > maxHookCardDueWithinDays {
+			return HookAddInput{}, ErrInvalidHookWithinDays
+		}
+	} else if withinDays != nil {
+		return HookAddInput{}, ErrInvalidHookWithinDays
+	}
+
+	return HookAddInput{Event: event, Kind: kind, Target: target, WithinDays: withinDays}, nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	ImportSourceYNAB    = "ynab"
+	ImportSourceMint    = "mint"
+	ImportSourceGnuCash = "gnucash"
+)
+
+var (
+	ErrInvalidImportSource     = errors.New("invalid import source")
+	ErrInvalidImportSourceFile = errors.New("invalid import source file")
+)
+
+// ImportSourceUnmapped lists source values that did not resolve to a local
+// category, label or card and were left off the imported entries.
+type ImportSourceUnmapped struct {
+	Categories []string `json:"categories"`
+	Labels     []string `json:"labels"`
+	Accounts   []string `json:"accounts"`
+}
+
+func NormalizeImportSource(raw string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(raw)); normalized {
+	case ImportSourceYNAB, ImportSourceMint, ImportSourceGnuCash:
+		return normalized, nil
+	default:
+		return "", ErrInvalidImportSource
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	WarningCodeInflationIndexUnavailable = "INFLATION_INDEX_UNAVAILABLE"
+	InflationIndexWarningMessage         = "Some entries were kept at nominal value because no inflation index point was available."
+)
+
+var (
+	ErrInvalidInflationIndexDate  = errors.New("invalid inflation index date")
+	ErrInvalidInflationIndexValue = errors.New("invalid inflation index value")
+	ErrInflationIndexUnavailable  = errors.New("inflation index unavailable")
+)
+
+type InflationIndexPoint struct {
+	ID           int64  `json:"id"`
+	CurrencyCode string `json:"currency_code"`
+	IndexDate    string `json:"index_date"`
+	IndexValue   string `json:"index_value"`
+	CreatedAtUTC string `json:"created_at_utc"`
+	UpdatedAtUTC string `json:"updated_at_utc"`
+}
+
+type InflationIndexPointInput struct {
+	CurrencyCode string
+	IndexDate    string
+	IndexValue   string
+}
+
+type ReportRevaluationCurrency struct {
+	CurrencyCode      string `json:"currency_code"`
+	AsOfIndexDate     string `json:"as_of_index_date"`
+	AsOfIndexValue    string `json:"as_of_index_value"`
+	RevaluedEntries   int    `json:"revalued_entries"`
+	NominalEntryCount int    `json:"nominal_entries"`
+}
+
+type ReportRevaluation struct {
+	AsOfDate   string                      `json:"as_of_date"`
+	Currencies []ReportRevaluationCurrency `json:"currencies"`
+}
+
+type InflationIndexWarningDetails struct {
+	AsOfDate      string   `json:"as_of_date"`
+	CurrencyCodes []string `json:"currency_codes"`
+	EntryCount    int      `json:"entry_count"`
+}
+
+func NormalizeInflationIndexPointInput(input InflationIndexPointInput) (InflationIndexPointInput, error) {
+	currencyCode, err := NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return InflationIndexPointInput{}, err
+	}
+
+	indexDate, err := NormalizeInflationIndexDate(input.IndexDate)
+	if err != nil {
+		return InflationIndexPointInput{}, err
+	}
+
+	indexValue := strings.TrimSpace(input.IndexValue)
+	if _, err := parseInflationIndexValue(indexValue); err != nil {
+		return InflationIndexPointInput{}, err
+	}
+
+	return InflationIndexPointInput{
+		CurrencyCode: currencyCode,
+		IndexDate:    indexDate,
+		IndexValue:   indexValue,
+	}, nil
+}
+
+// NormalizeInflationIndexDate accepts YYYY-MM-DD or an RFC3339 timestamp and
+// returns the UTC calendar date used as the index lookup key.
+func NormalizeInflationIndexDate(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return "", ErrInvalidInflationIndexDate
+	}
+
+	if parsed, err := time.Parse("2006-01-02", trimmed); err == nil {
+		return parsed.Format("2006-01-02"), nil
+	}
+
+	parsed, err := parseTimestampUTC(trimmed)
+	if err != nil {
+		return "", ErrInvalidInflationIndexDate
+	}
+	return parsed.Format("2006-01-02"), nil
+}
+
+// RevalueMinorByIndex scales amountMinor by toIndex/fromIndex, rounding half
+// away from zero to the nearest minor unit.
+func RevalueMinorByIndex(amountMinor int64, fromIndex, toIndex string) (int64, error) {
+	from, err := parseInflationIndexValue(fromIndex)
+	if err != nil {
+		return 0, err
+	}
+	to, err := parseInflationIndexValue(toIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	scaled := new(big.Rat).SetInt64(amountMinor)
+	scaled.Mul(scaled, to)
+	scaled.Quo(scaled, from)
+
+	num := new(big.Int).Set(scaled.Num())
+	den := scaled.Denom()
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if negative {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+	return quotient.Int64(), nil
+}
+
+func parseInflationIndexValue(value string) (*big.Rat, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || strings.ContainsAny(trimmed, "/eE") {
+		return nil, ErrInvalidInflationIndexValue
+	}
+
+	parsed, ok := new(big.Rat).SetString(trimmed)
+	if !ok || parsed.Sign() <= 0 {
+		return nil, ErrInvalidInflationIndexValue
+	}
+	return parsed, nil
+}
@@ -11,15 +11,23 @@ var (
 	ErrInvalidLabelID    = errors.New("invalid label id")
 	ErrLabelNotFound     = errors.New("label not found")
 	ErrLabelNameConflict = errors.New("label name conflict")
+	ErrLabelArchived     = errors.New("label is archived")
 	ErrStorage           = errors.New("label storage error")
 )
 
 type Label struct {
-	ID           int64      `json:"id"`
-	Name         string     `json:"name"`
-	CreatedAtUTC time.Time  `json:"created_at_utc"`
-	UpdatedAtUTC time.Time  `json:"updated_at_utc"`
-	DeletedAtUTC *time.Time `json:"deleted_at_utc,omitempty"`
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	CreatedAtUTC  time.Time  `json:"created_at_utc"`
+	UpdatedAtUTC  time.Time  `json:"updated_at_utc"`
+	DeletedAtUTC  *time.Time `json:"deleted_at_utc,omitempty"`
+	ArchivedAtUTC *time.Time `json:"archived_at_utc,omitempty"`
+	Color         string     `json:"color,omitempty"`
+	Icon          string     `json:"icon,omitempty"`
+}
+
+func (l Label) Archived() bool {
+	return l.ArchivedAtUTC != nil
 }
 
 type LabelDeleteResult struct {
@@ -28,6 +36,18 @@ type LabelDeleteResult struct {
 	DeletedAtUTC  time.Time `json:"deleted_at_utc"`
 }
 
+// UnarchivedLabels filters archived labels out of a listing.
+func UnarchivedLabels(labels []Label) []Label {
+	filtered := make([]Label, 0, len(labels))
+	for _, label := range labels {
+		if label.Archived() {
+			continue
+		}
+		filtered = append(filtered, label)
+	}
+	return filtered
+}
+
 func NormalizeLabelName(name string) (string, error) {
 	normalized := strings.TrimSpace(name)
 	if normalized == "" {
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidListSort = errors.New("invalid list sort")
+
+// Sort keys accepted by the list commands. Ties under created fall back to
+// id; every other ordering falls back to the default key and then to id, so
+// listings are deterministic.
+const (
+	LabelSortName    = "name"
+	LabelSortID      = "id"
+	LabelSortCreated = "created"
+
+	CategorySortName    = "name"
+	CategorySortID      = "id"
+	CategorySortCreated = "created"
+
+	CardSortNickname = "nickname"
+	CardSortID       = "id"
+	CardSortDueDay   = "due-day"
+	CardSortCreated  = "created"
+
+	// CardDebtSortBalance puts the largest amount owed first.
+	CardDebtSortID       = "id"
+	CardDebtSortNickname = "nickname"
+	CardDebtSortBalance  = "balance"
+)
+
+var (
+	LabelSortKeys    = []string{LabelSortName, LabelSortID, LabelSortCreated}
+	CategorySortKeys = []string{CategorySortName, CategorySortID, CategorySortCreated}
+	CardSortKeys     = []string{CardSortNickname, CardSortID, CardSortDueDay, CardSortCreated}
+	CardDebtSortKeys = []string{CardDebtSortID, CardDebtSortNickname, CardDebtSortBalance}
+)
+
+// NormalizeListSort lowercases raw and checks it against keys. An empty value
+// selects the first key, which is the command's default.
+func NormalizeListSort(raw string, keys []string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	if normalized == "" {
+		return keys[0], nil
+	}
+	for _, key := range keys {
+		if normalized == key {
+			return key, nil
+		}
+	}
+	return "", ErrInvalidListSort
+}
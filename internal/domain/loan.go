@@ -0,0 +1,313 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidLoanID           = errors.New("invalid loan id")
+	ErrInvalidLoanPrincipal    = errors.New("invalid loan principal")
+	ErrInvalidLoanRate         = errors.New("invalid loan rate")
+	ErrInvalidLoanTerm         = errors.New("invalid loan term")
+	ErrLoanNotFound            = errors.New("loan not found")
+	ErrLoanPaymentLinked       = errors.New("entry already linked to a loan")
+	ErrLoanPaymentNotLinked    = errors.New("entry not linked to loan")
+	ErrLoanPaymentEntryInvalid = errors.New("loan payment entry invalid")
+)
+
+const (
+	// MaxLoanRateBasisPoints caps the annual rate at 100%.
+	MaxLoanRateBasisPoints = 10000
+	MaxLoanTermMonths      = 600
+)
+
+// Loan is an amortizing loan repaid in equal monthly installments, the first
+// one due in StartMonth. The rate is annual, in basis points.
+type Loan struct {
+	ID                    int64   `json:"id"`
+	Name                  string  `json:"name,omitempty"`
+	CurrencyCode          string  `json:"currency_code"`
+	PrincipalMinor        int64   `json:"principal_minor"`
+	AnnualRateBasisPoints int64   `json:"annual_rate_bp"`
+	TermMonths            int64   `json:"term_months"`
+	StartMonth            string  `json:"start_month"`
+	Note                  string  `json:"note,omitempty"`
+	CreatedAtUTC          string  `json:"created_at_utc"`
+	UpdatedAtUTC          string  `json:"updated_at_utc"`
+	DeletedAtUTC          *string `json:"deleted_at_utc,omitempty"`
+}
+
+type LoanAddInput struct {
+	Name                  string
+	CurrencyCode          string
+	PrincipalMinor        int64
+	AnnualRateBasisPoints int64
+	TermMonths            int64
+	StartMonth            string
+	Note                  string
+}
+
+type LoanDeleteResult struct {
+	LoanID       int64  `json:"loan_id"`
+	DeletedAtUTC string `json:"deleted_at_utc"`
+}
+
+// LoanInstallment is one row of the planned amortization schedule.
+type LoanInstallment struct {
+	Number                  int64  `json:"number"`
+	MonthKey                string `json:"month_key"`
+	PaymentMinor            int64  `json:"payment_minor"`
+	InterestMinor           int64  `json:"interest_minor"`
+	PrincipalMinor          int64  `json:"principal_minor"`
+	RemainingPrincipalMinor int64  `json:"remaining_principal_minor"`
+	Covered                 bool   `json:"covered"`
+}
+
+// LoanPaymentEntry is an expense entry linked to a loan as a payment.
+type LoanPaymentEntry struct {
+	EntryID            int64  `json:"entry_id"`
+	TransactionDateUTC string `json:"transaction_date_utc"`
+	AmountMinor        int64  `json:"amount_minor"`
+	CurrencyCode       string `json:"currency_code"`
+	Note               string `json:"note,omitempty"`
+}
+
+// LoanPayment is a linked payment split into interest and principal.
+type LoanPayment struct {
+	LoanPaymentEntry
+	InterestMinor           int64 `json:"interest_minor"`
+	PrincipalMinor          int64 `json:"principal_minor"`
+	ExcessMinor             int64 `json:"excess_minor"`
+	RemainingPrincipalMinor int64 `json:"remaining_principal_minor"`
+}
+
+type LoanPaymentLink struct {
+	LoanID  int64 `json:"loan_id"`
+	EntryID int64 `json:"entry_id"`
+}
+
+// LoanStatus is a loan with its schedule and the payments applied so far.
+type LoanStatus struct {
+	Loan                    Loan              `json:"loan"`
+	MonthlyPaymentMinor     int64             `json:"monthly_payment_minor"`
+	PaidMinor               int64             `json:"paid_minor"`
+	InterestPaidMinor       int64             `json:"interest_paid_minor"`
+	PrincipalPaidMinor      int64             `json:"principal_paid_minor"`
+	RemainingPrincipalMinor int64             `json:"remaining_principal_minor"`
+	InstallmentsCovered     int64             `json:"installments_covered"`
+	NextDueMonth            string            `json:"next_due_month,omitempty"`
+	Schedule                []LoanInstallment `json:"schedule,omitempty"`
+	Payments                []LoanPayment     `json:"payments,omitempty"`
+}
+
+func ValidateLoanID(id int64) error {
+	if id <= 0 {
+		return ErrInvalidLoanID
+	}
+	return nil
+}
+
+// ParseLoanRatePercent parses an annual percentage such as "7.5" into basis
+// points, allowing at most two decimals.
+func ParseLoanRatePercent(raw string) (int64, error) {
+	basisPoints, ok := parsePercentBasisPoints(raw, MaxLoanRateBasisPoints)
+	if !ok {
+		return 0, ErrInvalidLoanRate
+	}
+	return basisPoints, nil
+}
+
+// parsePercentBasisPoints parses a percentage with at most two decimals and
+// an optional trailing % into basis points no greater than maxBasisPoints.
+func parsePercentBasisPoints(raw string, maxBasisPoints int64) (int64, bool) {
+	value := strings.TrimSuffix(strings.TrimSpace(raw), "%")
+	if value == "" {
+		return 0, false
+	}
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	if whole == "" || (hasFraction && (fraction == "" || len(fraction) > 2)) {
+		return 0, false
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	if len(whole) > 3 {
+		return 0, false
+	}
+	basisPoints, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || basisPoints > maxBasisPoints {
+		return 0, false
+	}
+	return basisPoints, true
+}
+
+func NormalizeLoanAddInput(input LoanAddInput) (LoanAddInput, error) {
+	currencyCode, err := NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return LoanAddInput{}, err
+	}
+	if input.PrincipalMinor <= 0 {
+		return LoanAddInput{}, ErrInvalidLoanPrincipal
+	}
+	if input.AnnualRateBasisPoints < 0 || input.AnnualRateBasisPoints > MaxLoanRateBasisPoints {
+		return LoanAddInput{}, ErrInvalidLoanRate
+	}
+	if input.TermMonths <= 0 || input.TermMonths > MaxLoanTermMonths {
+		return LoanAddInput{}, ErrInvalidLoanTerm
+	}
+	startMonth, err := NormalizeMonthKey(input.StartMonth)
+	if err != nil {
+		return LoanAddInput{}, err
+	}
+
+	return LoanAddInput{
+		Name:                  strings.TrimSpace(input.Name),
+		CurrencyCode:          currencyCode,
+		PrincipalMinor:        input.PrincipalMinor,
+		AnnualRateBasisPoints: input.AnnualRateBasisPoints,
+		TermMonths:            input.TermMonths,
+		StartMonth:            startMonth,
+		Note:                  strings.TrimSpace(input.Note),
+	}, nil
+}
+
+// LoanMonthlyPayment returns the level installment that repays the loan over
+// its term, rounded to the minor unit.
+func LoanMonthlyPayment(loan Loan) int64 {
+	if loan.TermMonths <= 0 {
+		return 0
+	}
+	if loan.AnnualRateBasisPoints == 0 {
+		return (loan.PrincipalMinor + loan.TermMonths - 1) / loan.TermMonths
+	}
+	rate := float64(loan.AnnualRateBasisPoints) / 120000
+	payment := float64(loan.PrincipalMinor) * rate / (1 - math.Pow(1+rate, -float64(loan.TermMonths)))
+	return int64(math.Round(payment))
+}
+
+// loanMonthlyInterest is one month of interest on balanceMinor.
+func loanMonthlyInterest(balanceMinor, annualRateBasisPoints int64) int64 {
+	return int64(math.Round(float64(balanceMinor) * float64(annualRateBasisPoints) / 120000))
+}
+
+// BuildLoanSchedule plans the installments from StartMonth. Rounding drift is
+// settled in the last installment so the schedule ends at zero.
+func BuildLoanSchedule(loan Loan) ([]LoanInstallment, error) {
+	start, err := time.Parse("2006-01", loan.StartMonth)
+	if err != nil {
+		return nil, ErrInvalidMonthKey
+	}
+
+	payment := LoanMonthlyPayment(loan)
+	balance := loan.PrincipalMinor
+	schedule := make([]LoanInstallment, 0, loan.TermMonths)
+	for number := int64(1); number <= loan.TermMonths && balance > 0; number++ {
+		interest := loanMonthlyInterest(balance, loan.AnnualRateBasisPoints)
+		principal := payment - interest
+		if number == loan.TermMonths || principal > balance {
+			principal = balance
+		}
+		balance -= principal
+		schedule = append(schedule, LoanInstallment{
+			Number:                  number,
+			MonthKey:                start.AddDate(0, int(number-1), 0).Format("2006-01"),
+			PaymentMinor:            principal + interest,
+			InterestMinor:           interest,
+			PrincipalMinor:          principal,
+			RemainingPrincipalMinor: balance,
+		})
+	}
+	return schedule, nil
+}
+
+// BuildLoanStatus applies the linked payments in date order. Interest accrues
+// monthly on the outstanding principal from StartMonth through the month of
+// each payment; a payment settles accrued interest first, then principal, and
+// anything left once the principal is cleared is reported as excess.
+// Installments count as covered while the total paid reaches their
+// cumulative scheduled amount.
+func BuildLoanStatus(loan Loan, entries []LoanPaymentEntry) (LoanStatus, error) {
+	schedule, err := BuildLoanSchedule(loan)
+	if err != nil {
+		return LoanStatus{}, err
+	}
+
+	sorted := append([]LoanPaymentEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TransactionDateUTC != sorted[j].TransactionDateUTC {
+			return sorted[i].TransactionDateUTC < sorted[j].TransactionDateUTC
+		}
+		return sorted[i].EntryID < sorted[j].EntryID
+	})
+
+	status := LoanStatus{
+		Loan:                loan,
+		MonthlyPaymentMinor: LoanMonthlyPayment(loan),
+		Payments:            make([]LoanPayment, 0, len(sorted)),
+	}
+	balance := loan.PrincipalMinor
+	interestDue := int64(0)
+	nextAccrual := loan.StartMonth
+	for _, entry := range sorted {
+		monthKey, err := MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
+		if err != nil {
+			return LoanStatus{}, err
+		}
+		for nextAccrual <= monthKey && balance > 0 {
+			interestDue += loanMonthlyInterest(balance, loan.AnnualRateBasisPoints)
+			nextAccrual = addMonths(nextAccrual, 1)
+		}
+
+		remaining := entry.AmountMinor
+		interest := min(remaining, interestDue)
+		interestDue -= interest
+		remaining -= interest
+		principal := min(remaining, balance)
+		balance -= principal
+		remaining -= principal
+
+		status.PaidMinor += entry.AmountMinor
+		status.InterestPaidMinor += interest
+		status.PrincipalPaidMinor += principal
+		status.Payments = append(status.Payments, LoanPayment{
+			LoanPaymentEntry:        entry,
+			InterestMinor:           interest,
+			PrincipalMinor:          principal,
+			ExcessMinor:             remaining,
+			RemainingPrincipalMinor: balance,
+		})
+	}
+	status.RemainingPrincipalMinor = balance
+
+	cumulative := int64(0)
+	for i := range schedule {
+		cumulative += schedule[i].PaymentMinor
+		if balance == 0 || status.PaidMinor >= cumulative {
+			schedule[i].Covered = true
+			status.InstallmentsCovered++
+		} else if status.NextDueMonth == "" {
+			status.NextDueMonth = schedule[i].MonthKey
+		}
+	}
+	status.Schedule = schedule
+
+	return status, nil
+}
+
+func addMonths(monthKey string, months int) string {
+	parsed, err := time.Parse("2006-01", monthKey)
+	if err != nil {
+		return monthKey
+	}
+	return parsed.AddDate(0, months, 0).Format("2006-01")
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLoanRatePercent(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]int64{"7.5": 750, "7.25%": 725, " 0 ": 0, "100": 10000}
+	for raw, want := range cases {
+		got, err := ParseLoanRatePercent(raw)
+		if err != nil || got != want {
+			t.Fatalf("ParseLoanRatePercent(%q) = %d, %v; want %d", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"", "7.", "7.125", "-1", "100.01", "abc"} {
+		if _, err := ParseLoanRatePercent(raw); !errors.Is(err, ErrInvalidLoanRate) {
+			t.Fatalf("expected ErrInvalidLoanRate for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestBuildLoanScheduleAmortizesToZero(t *testing.T) {
+	t.Parallel()
+
+	loan := Loan{PrincipalMinor: 1000000, AnnualRateBasisPoints: 750, TermMonths: 36, StartMonth: "2026-01"}
+	if payment := LoanMonthlyPayment(loan); payment != 31106 {
+		t.Fatalf("expected monthly payment 31106, got %d", payment)
+	}
+
+	schedule, err := BuildLoanSchedule(loan)
+	if err != nil {
+		t.Fatalf("build schedule: %v", err)
+	}
+	if len(schedule) != 36 {
+		t.Fatalf("expected 36 installments, got %d", len(schedule))
+	}
+	if first := schedule[0]; first.MonthKey != "2026-01" || first.InterestMinor != 6250 || first.PrincipalMinor != 24856 {
+		t.Fatalf("unexpected first installment: %+v", first)
+	}
+	principal := int64(0)
+	for _, installment := range schedule {
+		principal += installment.PrincipalMinor
+	}
+	last := schedule[len(schedule)-1]
+	if principal != loan.PrincipalMinor || last.RemainingPrincipalMinor != 0 || last.MonthKey != "2028-12" {
+		t.Fatalf("expected schedule to repay principal by 2028-12, got total %d last %+v", principal, last)
+	}
+}
+
+func TestBuildLoanStatusSplitsPaymentsIntoInterestAndPrincipal(t *testing.T) {
+	t.Parallel()
+
+	loan := Loan{PrincipalMinor: 1000000, AnnualRateBasisPoints: 750, TermMonths: 36, StartMonth: "2026-01"}
+	status, err := BuildLoanStatus(loan, []LoanPaymentEntry{
+		{EntryID: 2, TransactionDateUTC: "2026-02-15T00:00:00Z", AmountMinor: 31106},
+		{EntryID: 1, TransactionDateUTC: "2026-01-15T00:00:00Z", AmountMinor: 31106},
+	})
+	if err != nil {
+		t.Fatalf("build status: %v", err)
+	}
+
+	if status.Payments[0].EntryID != 1 || status.Payments[0].InterestMinor != 6250 || status.Payments[0].PrincipalMinor != 24856 {
+		t.Fatalf("unexpected first payment: %+v", status.Payments[0])
+	}
+	if status.InterestPaidMinor != 6250+6095 || status.RemainingPrincipalMinor != 1000000-24856-25011 {
+		t.Fatalf("unexpected totals: %+v", status)
+	}
+	if status.InstallmentsCovered != 2 || status.NextDueMonth != "2026-03" {
+		t.Fatalf("expected two installments covered and March next, got %d %q", status.InstallmentsCovered, status.NextDueMonth)
+	}
+}
+
+func TestBuildLoanStatusAccruesSkippedMonths(t *testing.T) {
+	t.Parallel()
+
+	loan := Loan{PrincipalMinor: 120000, AnnualRateBasisPoints: 1200, TermMonths: 12, StartMonth: "2026-01"}
+	status, err := BuildLoanStatus(loan, []LoanPaymentEntry{
+		{EntryID: 1, TransactionDateUTC: "2026-02-20T00:00:00Z", AmountMinor: 5000},
+	})
+	if err != nil {
+		t.Fatalf("build status: %v", err)
+	}
+
+	if payment := status.Payments[0]; payment.InterestMinor != 2400 || payment.PrincipalMinor != 2600 {
+		t.Fatalf("expected two months of interest settled first, got %+v", payment)
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+// DatabaseSize measures the main database file in pages. Free pages are
+// reclaimed by VACUUM.
+type DatabaseSize struct {
+	PageSize  int64 `json:"page_size"`
+	PageCount int64 `json:"page_count"`
+	FreePages int64 `json:"free_pages"`
+	Bytes     int64 `json:"bytes"`
+}
+
+// WALCheckpoint is the result of PRAGMA wal_checkpoint. LogFrames and
+// CheckpointedFrames are -1 when the database is not in WAL mode.
+type WALCheckpoint struct {
+	Busy               bool  `json:"busy"`
+	LogFrames          int64 `json:"log_frames"`
+	CheckpointedFrames int64 `json:"checkpointed_frames"`
+}
+
+// MaintenanceTableCount counts one table's rows after maintenance.
+// SoftDeleted is set for tables with a deleted_at_utc column.
+type MaintenanceTableCount struct {
+	Table       string `json:"table"`
+	Rows        int64  `json:"rows"`
+	SoftDeleted *int64 `json:"soft_deleted,omitempty"`
+}
+
+type MaintenanceReport struct {
+	SizeBefore     DatabaseSize            `json:"size_before"`
+	SizeAfter      DatabaseSize            `json:"size_after"`
+	ReclaimedBytes int64                   `json:"reclaimed_bytes"`
+	Checkpoint     WALCheckpoint           `json:"wal_checkpoint"`
+	Tables         []MaintenanceTableCount `json:"tables"`
+}
@@ -0,0 +1,139 @@
+package domain
+
+import "errors"
+
+var ErrInvalidMigrationVersion = errors.New("invalid migration version")
+
+const (
+	// MigrationRowsPerSecond is a conservative rewrite rate for SQLite on
+	// laptop-class storage, used only to estimate migration duration.
+	MigrationRowsPerSecond = 50000
+	// MigrationBackupRowThreshold is the rewrite volume above which a backup is
+	// recommended even when no rows are dropped.
+	MigrationBackupRowThreshold = 10000
+)
+
+const (
+	MigrationOpCreateTable = "create_table"
+	MigrationOpAlterTable  = "alter_table"
+	MigrationOpDropTable   = "drop_table"
+	MigrationOpCreateIndex = "create_index"
+	MigrationOpDropIndex   = "drop_index"
+	MigrationOpTrigger     = "trigger"
+	MigrationOpInsert      = "insert"
+	MigrationOpUpdate      = "update"
+	MigrationOpDelete      = "delete"
+)
+
+const (
+	MigrationDirectionUp   = "up"
+	MigrationDirectionDown = "down"
+
+	WarningCodeMigrationsRolledBack    = "MIGRATIONS_ROLLED_BACK"
+	MigrationsRolledBackWarningMessage = "This binary re-applies the rolled back migrations on its next command; switch to the older binary before running anything else."
+)
+
+// migrationRewriteOps touch every existing row of the target table when the
+// migration runs; the rest only change the schema.
+var migrationRewriteOps = map[string]bool{
+	MigrationOpAlterTable:  true,
+	MigrationOpDropTable:   true,
+	MigrationOpCreateIndex: true,
+	MigrationOpUpdate:      true,
+	MigrationOpDelete:      true,
+}
+
+// IsMigrationRewriteOp reports whether op reads or rewrites existing rows.
+func IsMigrationRewriteOp(op string) bool {
+	return migrationRewriteOps[op]
+}
+
+// MigrationTableImpact is what one pending migration does to one table.
+type MigrationTableImpact struct {
+	Table       string   `json:"table"`
+	Operations  []string `json:"operations"`
+	Rows        int64    `json:"rows"`
+	RowRewrites int64    `json:"row_rewrites"`
+}
+
+type PendingMigration struct {
+	Version int64                  `json:"version"`
+	Name    string                 `json:"name"`
+	Tables  []MigrationTableImpact `json:"tables"`
+}
+
+// MigrationPlan is the dry-run view of the migrations that would run on the
+// next command that opens the database.
+type MigrationPlan struct {
+	CurrentVersion       int64              `json:"current_version"`
+	TargetVersion        int64              `json:"target_version"`
+	Pending              []PendingMigration `json:"pending"`
+	AffectedTables       []string           `json:"affected_tables"`
+	EstimatedRowRewrites int64              `json:"estimated_row_rewrites"`
+	EstimatedDurationMS  int64              `json:"estimated_duration_ms"`
+	BackupRecommended    bool               `json:"backup_recommended"`
+	BackupReasons        []string           `json:"backup_reasons"`
+}
+
+// EstimateMigrationDurationMS converts a rewrite count into milliseconds at
+// MigrationRowsPerSecond, rounding up so any work reports at least 1ms.
+func EstimateMigrationDurationMS(rowRewrites int64) int64 {
+	if rowRewrites <= 0 {
+		return 0
+	}
+	return (rowRewrites*1000 + MigrationRowsPerSecond - 1) / MigrationRowsPerSecond
+}
+
+// MigrationBackupReasons explains why a backup should be taken first; an
+// empty result means the pending migrations only add schema.
+func MigrationBackupReasons(plan MigrationPlan) []string {
+	reasons := []string{}
+	dropsRows := false
+	for _, migration := range plan.Pending {
+		for _, table := range migration.Tables {
+			for _, op := range table.Operations {
+				if (op == MigrationOpDropTable || op == MigrationOpDelete) && table.Rows > 0 {
+					dropsRows = true
+				}
+			}
+		}
+	}
+	if dropsRows {
+		reasons = append(reasons, "pending migrations drop or delete existing rows")
+	}
+	if plan.EstimatedRowRewrites >= MigrationBackupRowThreshold {
+		reasons = append(reasons, "pending migrations rewrite at least 10000 rows")
+	}
+	return reasons
+}
+
+// MigrationStatusEntry is one known migration and whether it is applied.
+type MigrationStatusEntry struct {
+	Version      int64  `json:"version"`
+	Name         string `json:"name"`
+	Applied      bool   `json:"applied"`
+	AppliedAtUTC string `json:"applied_at_utc,omitempty"`
+}
+
+type MigrationStatus struct {
+	CurrentVersion int64                  `json:"current_version"`
+	LatestVersion  int64                  `json:"latest_version"`
+	Pending        int                    `json:"pending"`
+	Migrations     []MigrationStatusEntry `json:"migrations"`
+}
+
+// MigrationRun is the outcome of `migrate up` or `migrate down`: Versions
+// lists the migrations applied or rolled back, in the order they ran.
+type MigrationRun struct {
+	Direction   string  `json:"direction"`
+	FromVersion int64   `json:"from_version"`
+	ToVersion   int64   `json:"to_version"`
+	Versions    []int64 `json:"versions"`
+}
+
+func ValidateMigrationTargetVersion(version int64) error {
+	if version < 0 {
+		return ErrInvalidMigrationVersion
+	}
+	return nil
+}
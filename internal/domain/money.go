@@ -12,6 +12,13 @@ var (
 	ErrInvalidAmount          = errors.New("invalid amount")
 	ErrInvalidAmountPrecision = errors.New("invalid amount precision")
 	ErrAmountOverflow         = errors.New("amount overflow")
+	ErrInvalidAmountFormat    = errors.New("invalid amount format")
+	ErrAmbiguousAmount        = errors.New("ambiguous amount separators")
+)
+
+const (
+	AmountFormatDotDecimal   = "dot_decimal"
+	AmountFormatCommaDecimal = "comma_decimal"
 )
 
 var currencyMinorUnits = map[string]int{
@@ -49,6 +56,9 @@ func CurrencyMinorUnit(currencyCode string) (int, error) {
 		return 0, err
 	}
 
+	if minorUnit, ok := customCurrencyMinorUnit(normalized); ok {
+		return minorUnit, nil
+	}
 	if minorUnit, ok := currencyMinorUnits[normalized]; ok {
 		return minorUnit, nil
 	}
@@ -148,6 +158,79 @@ func ParseMajorAmountToMinor(amount, currencyCode string) (int64, error) {
 	return minor + fractional, nil
 }
 
+// NormalizeAmountFormat maps an empty value to the dot-decimal default.
+func NormalizeAmountFormat(format string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+	switch normalized {
+	case "":
+		return AmountFormatDotDecimal, nil
+	case AmountFormatDotDecimal, AmountFormatCommaDecimal:
+		return normalized, nil
+	default:
+		return "", ErrInvalidAmountFormat
+	}
+}
+
+// ParseLocalizedMajorAmountToMinor parses an amount written with the decimal
+// separator of the given amount format. The other separator is only accepted
+// as thousands grouping (1-3 leading digits without a leading zero, then
+// groups of exactly 3), so inputs such as "12,5" under dot_decimal are
+// rejected as ambiguous instead of being silently read as 125. A lone group
+// with no decimal part ("1,234") reads as a decimal in the other format, so
+// it is rejected too; "1,234.00" and "1,234,567" are not.
+func ParseLocalizedMajorAmountToMinor(amount, currencyCode, format string) (int64, error) {
+	canonical, err := canonicalizeLocalizedAmount(amount, format)
+	if err != nil {
+		return 0, err
+	}
+	return ParseMajorAmountToMinor(canonical, currencyCode)
+}
+
+func canonicalizeLocalizedAmount(amount, format string) (string, error) {
+	normalizedFormat, err := NormalizeAmountFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	decimalSeparator, groupSeparator := ".", ","
+	if normalizedFormat == AmountFormatCommaDecimal {
+		decimalSeparator, groupSeparator = ",", "."
+	}
+
+	value := strings.TrimSpace(amount)
+	sign := ""
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		sign, value = value[:1], value[1:]
+	}
+
+	integerPart, fractionalPart, hasDecimal := strings.Cut(value, decimalSeparator)
+	if strings.Contains(fractionalPart, groupSeparator) {
+		return "", ErrAmbiguousAmount
+	}
+
+	if strings.Contains(integerPart, groupSeparator) {
+		groups := strings.Split(integerPart, groupSeparator)
+		if len(groups[0]) < 1 || len(groups[0]) > 3 || groups[0][0] == '0' {
+			return "", ErrAmbiguousAmount
+		}
+		if len(groups) == 2 && !hasDecimal {
+			return "", ErrAmbiguousAmount
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return "", ErrAmbiguousAmount
+			}
+		}
+		integerPart = strings.Join(groups, "")
+	}
+
+	canonical := sign + integerPart
+	if hasDecimal {
+		canonical += "." + fractionalPart
+	}
+	return canonical, nil
+}
+
 func FormatMinorToMajorString(amountMinor int64, currencyCode string) (string, error) {
 	minorUnit, err := CurrencyMinorUnit(currencyCode)
 	if err != nil {
@@ -101,6 +101,62 @@ func TestParseMajorAmountToMinorRejectsInvalidInput(t *testing.T) {
 	}
 }
 
+func TestParseLocalizedMajorAmountToMinor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		amount string
+		format string
+		want   int64
+		err    error
+	}{
+		{name: "default_format_plain", amount: "1234.56", format: "", want: 123456},
+		{name: "dot_decimal_grouped", amount: "1,234,567.89", format: AmountFormatDotDecimal, want: 123456789},
+		{name: "dot_decimal_grouped_integer", amount: "1,234,567", format: AmountFormatDotDecimal, want: 123456700},
+		{name: "dot_decimal_grouped_with_fraction", amount: "1,234.00", format: AmountFormatDotDecimal, want: 123400},
+		{name: "comma_decimal_grouped", amount: "1.234,56", format: AmountFormatCommaDecimal, want: 123456},
+		{name: "comma_decimal_plain", amount: "12,5", format: AmountFormatCommaDecimal, want: 1250},
+		{name: "comma_decimal_grouped_integer", amount: "1.234.567", format: AmountFormatCommaDecimal, want: 123456700},
+		{name: "comma_decimal_grouped_with_fraction", amount: "1.234,00", format: AmountFormatCommaDecimal, want: 123400},
+		{name: "dot_decimal_rejects_comma_fraction", amount: "12,5", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_comma_locale", amount: "1.234,56", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "comma_decimal_rejects_dot_fraction", amount: "1.5", format: AmountFormatCommaDecimal, err: ErrAmbiguousAmount},
+		{name: "rejects_bad_group_size", amount: "1234,567.00", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_lone_group", amount: "1,234", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_signed_lone_group", amount: "+12,345", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "comma_decimal_rejects_lone_group", amount: "1.234", format: AmountFormatCommaDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_leading_zero_group", amount: "0,123.00", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_zero_padded_group", amount: "01,234,567", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_short_group", amount: "1,23,456", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_empty_group", amount: "1,,234.00", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "dot_decimal_rejects_trailing_separator", amount: "1,234,", format: AmountFormatDotDecimal, err: ErrAmbiguousAmount},
+		{name: "negative_still_rejected", amount: "-1,000.00", format: AmountFormatDotDecimal, err: ErrInvalidAmount},
+		{name: "unknown_format", amount: "1.00", format: "space_decimal", err: ErrInvalidAmountFormat},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseLocalizedMajorAmountToMinor(tc.amount, "USD", tc.format)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLocalizedMajorAmountToMinor(%q, %q): %v", tc.amount, tc.format, err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestFormatMinorToMajorString(t *testing.T) {
 	t.Parallel()
 
@@ -131,3 +187,41 @@ func TestFormatMinorToMajorString(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomCurrencyRegistryPrecision(t *testing.T) {
+	SetCustomCurrencies([]CustomCurrency{
+		{Code: "XB1", MinorUnit: 8},
+		{Code: "PTS", MinorUnit: 0},
+	})
+	t.Cleanup(func() { SetCustomCurrencies(nil) })
+
+	code, err := NormalizeCurrencyCode("xb1")
+	if err != nil || code != "XB1" {
+		t.Fatalf("expected registered code XB1 to normalize, got %q err=%v", code, err)
+	}
+
+	minor, err := ParseMajorAmountToMinor("0.00012345", "XB1")
+	if err != nil {
+		t.Fatalf("parse custom amount: %v", err)
+	}
+	if minor != 12345 {
+		t.Fatalf("expected 12345 minor units, got %d", minor)
+	}
+
+	formatted, err := FormatMinorToMajorString(12345, "XB1")
+	if err != nil {
+		t.Fatalf("format custom amount: %v", err)
+	}
+	if formatted != "0.00012345" {
+		t.Fatalf("expected 0.00012345, got %q", formatted)
+	}
+
+	if _, err := ParseMajorAmountToMinor("10.5", "PTS"); !errors.Is(err, ErrInvalidAmountPrecision) {
+		t.Fatalf("expected ErrInvalidAmountPrecision for fractional points, got %v", err)
+	}
+
+	SetCustomCurrencies(nil)
+	if _, err := NormalizeCurrencyCode("XB1"); !errors.Is(err, ErrInvalidCurrencyCode) {
+		t.Fatalf("expected unregistered XB1 to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package domain
+
+import "sort"
+
+// NetWorthPosition is the net position in one currency. Card purchases
+// already lower the ledger when they are recorded, so the money still owed
+// on cards is counted back into cash and held as a liability until paid.
+// Holdings and loans are the balances of the asset registry.
+type NetWorthPosition struct {
+	CurrencyCode     string `json:"currency_code"`
+	CashMinor        int64  `json:"cash_minor"`
+	SavingsMinor     int64  `json:"savings_minor"`
+	HoldingsMinor    int64  `json:"holdings_minor"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	CardDebtMinor    int64  `json:"card_debt_minor"`
+	LoansMinor       int64  `json:"loans_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetWorthMinor    int64  `json:"net_worth_minor"`
+}
+
+// NetWorthAccountBalance is the lifetime net of the entries booked on one
+// bank account in one currency.
+type NetWorthAccountBalance struct {
+	BankAccountID int64  `json:"bank_account_id"`
+	Alias         string `json:"alias"`
+	CurrencyCode  string `json:"currency_code"`
+	BalanceMinor  int64  `json:"balance_minor"`
+}
+
+// NetWorth is the current position. Recorded reports whether it was saved as
+// the snapshot of AsOfDate, which only happens when AsOfDate is today.
+type NetWorth struct {
+	AsOfDate   string                   `json:"as_of_date"`
+	Recorded   bool                     `json:"recorded"`
+	ByCurrency []NetWorthPosition       `json:"by_currency"`
+	Accounts   []NetWorthAccountBalance `json:"accounts"`
+	Assets     []Asset                  `json:"assets"`
+}
+
+// NetWorthSnapshot is the position of one currency recorded on SnapshotDate.
+// A later recording on the same day replaces it.
+type NetWorthSnapshot struct {
+	SnapshotDate     string `json:"snapshot_date"`
+	CurrencyCode     string `json:"currency_code"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetWorthMinor    int64  `json:"net_worth_minor"`
+	RecordedAtUTC    string `json:"recorded_at_utc"`
+}
+
+// NetWorthHistoryPeriod is the last snapshot of a period in one currency and
+// its change from the previous period.
+type NetWorthHistoryPeriod struct {
+	PeriodKey        string `json:"period_key"`
+	CurrencyCode     string `json:"currency_code"`
+	SnapshotDate     string `json:"snapshot_date"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetWorthMinor    int64  `json:"net_worth_minor"`
+	ChangeMinor      int64  `json:"change_minor"`
+}
+
+type NetWorthHistory struct {
+	Grouping string                  `json:"grouping"`
+	Periods  []NetWorthHistoryPeriod `json:"periods"`
+}
+
+// BuildNetWorthHistory keeps the last snapshot of each day, week or month
+// per currency, ordered by currency and period.
+func BuildNetWorthHistory(snapshots []NetWorthSnapshot, grouping string) (NetWorthHistory, error) {
+	normalizedGrouping, err := NormalizeReportGrouping(grouping)
+	if err != nil {
+		return NetWorthHistory{}, err
+	}
+	switch normalizedGrouping {
+	case ReportGroupingDay, ReportGroupingWeek, ReportGroupingMonth:
+	default:
+		return NetWorthHistory{}, ErrInvalidReportGrouping
+	}
+
+	ordered := append([]NetWorthSnapshot{}, snapshots...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].CurrencyCode != ordered[j].CurrencyCode {
+			return ordered[i].CurrencyCode < ordered[j].CurrencyCode
+		}
+		return ordered[i].SnapshotDate < ordered[j].SnapshotDate
+	})
+
+	periods := []NetWorthHistoryPeriod{}
+	for _, snapshot := range ordered {
+		periodKey, err := PeriodKeyForTransaction(snapshot.SnapshotDate+"T00:00:00Z", normalizedGrouping)
+		if err != nil {
+			return NetWorthHistory{}, err
+		}
+		period := NetWorthHistoryPeriod{
+			PeriodKey:        periodKey,
+			CurrencyCode:     snapshot.CurrencyCode,
+			SnapshotDate:     snapshot.SnapshotDate,
+			AssetsMinor:      snapshot.AssetsMinor,
+			LiabilitiesMinor: snapshot.LiabilitiesMinor,
+			NetWorthMinor:    snapshot.NetWorthMinor,
+		}
+
+		last := len(periods) - 1
+		if last >= 0 && periods[last].CurrencyCode == period.CurrencyCode && periods[last].PeriodKey == period.PeriodKey {
+			periods[last] = period
+			continue
+		}
+		periods = append(periods, period)
+	}
+
+	for i := range periods {
+		if i > 0 && periods[i-1].CurrencyCode == periods[i].CurrencyCode {
+			periods[i].ChangeMinor = periods[i].NetWorthMinor - periods[i-1].NetWorthMinor
+		}
+	}
+
+	return NetWorthHistory{Grouping: normalizedGrouping, Periods: periods}, nil
+}
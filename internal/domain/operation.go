@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	OperationKindDataMirror  = "data_mirror"
+	OperationKindEntryTriage = "entry_triage"
+
+	OperationStatusRunning   = "running"
+	OperationStatusCompleted = "completed"
+	OperationStatusFailed    = "failed"
+	OperationStatusCancelled = "cancelled"
+
+	DefaultOperationListLimit = 50
+)
+
+var (
+	ErrOperationNotFound      = errors.New("operation not found")
+	ErrOperationCancelled     = errors.New("operation cancelled")
+	ErrOperationNotResumable  = errors.New("operation is not resumable")
+	ErrOperationNotCancelable = errors.New("operation is not cancelable")
+	ErrInvalidOperationStatus = errors.New("invalid operation status")
+	ErrUnknownOperationKind   = errors.New("unknown operation kind")
+)
+
+// Operation is one journaled long-running task. Checkpoint is opaque to the
+// journal; each kind stores whatever it needs to skip finished work when the
+// operation is resumed.
+type Operation struct {
+	ID            int64           `json:"id"`
+	Kind          string          `json:"kind"`
+	Status        string          `json:"status"`
+	Params        json.RawMessage `json:"params"`
+	Total         int64           `json:"total"`
+	Done          int64           `json:"done"`
+	Checkpoint    string          `json:"checkpoint"`
+	LastError     *string         `json:"last_error"`
+	CreatedAtUTC  string          `json:"created_at_utc"`
+	UpdatedAtUTC  string          `json:"updated_at_utc"`
+	FinishedAtUTC *string         `json:"finished_at_utc"`
+}
+
+// OperationProgress is one progress update written to the journal.
+type OperationProgress struct {
+	Total      int64
+	Done       int64
+	Checkpoint string
+}
+
+// IsResumable reports whether the operation can be picked up again. Running
+// operations are resumable because a killed process leaves them running.
+func (o Operation) IsResumable() bool {
+	return o.Status == OperationStatusRunning || o.Status == OperationStatusFailed
+}
+
+// NormalizeOperationStatusFilter accepts an empty filter (all statuses) or
+// one of the known statuses.
+func NormalizeOperationStatusFilter(status string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(status))
+	switch normalized {
+	case "", OperationStatusRunning, OperationStatusCompleted, OperationStatusFailed, OperationStatusCancelled:
+		return normalized, nil
+	default:
+		return "", ErrInvalidOperationStatus
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// PayeeSummary aggregates the entries recorded against one payee in one
+// currency. SpendMinor is net of refunds.
+type PayeeSummary struct {
+	Payee            string `json:"payee"`
+	CurrencyCode     string `json:"currency_code"`
+	EntryCount       int    `json:"entry_count"`
+	SpendMinor       int64  `json:"spend_minor"`
+	IncomeMinor      int64  `json:"income_minor"`
+	LastEntryDateUTC string `json:"last_entry_date_utc"`
+}
+
+// SummarizePayees groups entries by payee (case-insensitive) and currency,
+// ordered by spend descending. Entries without a payee are skipped; the
+// first spelling seen names the group.
+func SummarizePayees(entries []Entry) []PayeeSummary {
+	type payeeKey struct {
+		payee        string
+		currencyCode string
+	}
+
+	index := map[payeeKey]int{}
+	summaries := make([]PayeeSummary, 0)
+	for _, entry := range entries {
+		if entry.Payee == "" {
+			continue
+		}
+
+		key := payeeKey{payee: strings.ToLower(entry.Payee), currencyCode: entry.CurrencyCode}
+		position, ok := index[key]
+		if !ok {
+			position = len(summaries)
+			index[key] = position
+			summaries = append(summaries, PayeeSummary{Payee: entry.Payee, CurrencyCode: entry.CurrencyCode})
+		}
+
+		summary := &summaries[position]
+		summary.EntryCount++
+		if entry.Type == EntryTypeIncome {
+			summary.IncomeMinor += entry.AmountMinor
+		} else {
+			summary.SpendMinor += entry.EffectiveAmountMinor()
+		}
+		if entry.TransactionDateUTC > summary.LastEntryDateUTC {
+			summary.LastEntryDateUTC = entry.TransactionDateUTC
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].SpendMinor != summaries[j].SpendMinor {
+			return summaries[i].SpendMinor > summaries[j].SpendMinor
+		}
+		if !strings.EqualFold(summaries[i].Payee, summaries[j].Payee) {
+			return strings.ToLower(summaries[i].Payee) < strings.ToLower(summaries[j].Payee)
+		}
+		return summaries[i].CurrencyCode < summaries[j].CurrencyCode
+	})
+
+	return summaries
+}
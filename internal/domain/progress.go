@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	ProgressModeAuto = "auto"
+	ProgressModeJSON = "json"
+	ProgressModeOff  = "off"
+
+	ProgressUnitRows  = "rows"
+	ProgressUnitPages = "pages"
+)
+
+var ErrInvalidProgressMode = errors.New("invalid progress mode")
+
+// Progress is one update from a long-running import, export or backup. Total
+// is zero when the row count is not known up front; imports then measure how
+// far they have read their input file with BytesDone and BytesTotal.
+type Progress struct {
+	Operation  string `json:"operation"`
+	Unit       string `json:"unit"`
+	Done       int64  `json:"done"`
+	Total      int64  `json:"total,omitempty"`
+	BytesDone  int64  `json:"bytes_done,omitempty"`
+	BytesTotal int64  `json:"bytes_total,omitempty"`
+}
+
+// Fraction returns how much of the work is done, between 0 and 1, and false
+// when neither a row nor a byte total is known.
+func (p Progress) Fraction() (float64, bool) {
+	var fraction float64
+	switch {
+	case p.Total > 0:
+		fraction = float64(p.Done) / float64(p.Total)
+	case p.BytesTotal > 0:
+		fraction = float64(p.BytesDone) / float64(p.BytesTotal)
+	default:
+		return 0, false
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return fraction, true
+}
+
+// ETA extrapolates the time left from the time spent so far. It returns false
+// until some work is done or when the total is unknown.
+func (p Progress) ETA(elapsed time.Duration) (time.Duration, bool) {
+	fraction, ok := p.Fraction()
+	if !ok || fraction <= 0 {
+		return 0, false
+	}
+	remaining := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+	return remaining.Round(time.Second), true
+}
+
+// NormalizeProgressMode defaults to auto when raw is empty.
+func NormalizeProgressMode(raw string) (string, error) {
+	switch value := strings.ToLower(strings.TrimSpace(raw)); value {
+	case "", ProgressModeAuto:
+		return ProgressModeAuto, nil
+	case ProgressModeJSON, ProgressModeOff:
+		return value, nil
+	default:
+		return "", ErrInvalidProgressMode
+	}
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrQuickEntryEmpty          = errors.New("quick entry text is empty")
+	ErrQuickEntryAmountRequired = errors.New("quick entry amount is required")
+	ErrQuickEntryAmountConflict = errors.New("quick entry has more than one amount")
+	ErrQuickEntryDateConflict   = errors.New("quick entry has more than one date")
+	ErrQuickEntryCardConflict   = errors.New("quick entry has more than one card")
+)
+
+// QuickEntry is the parsed form of a one-line entry such as
+// "coffee 4.50 yesterday #work @visa". Names are left unresolved; callers map
+// labels, card and category keywords to stored records.
+type QuickEntry struct {
+	Type            string   `json:"type"`
+	Amount          string   `json:"amount"`
+	CurrencyCode    string   `json:"currency_code,omitempty"`
+	TransactionDate string   `json:"transaction_date"`
+	Labels          []string `json:"labels"`
+	Card            string   `json:"card,omitempty"`
+	Words           []string `json:"words"`
+}
+
+// quickEntryRule consumes tokens starting at index i. It returns how many
+// tokens it used, or zero when it does not apply.
+type quickEntryRule func(entry *QuickEntry, tokens []string, i int, today time.Time) (int, error)
+
+// quickEntryRules run in order against each token; the first rule that
+// consumes the token wins and unmatched tokens become words.
+var quickEntryRules = []quickEntryRule{
+	quickEntryLabelRule,
+	quickEntryCardRule,
+	quickEntryDateRule,
+	quickEntryAmountRule,
+	quickEntryCurrencyRule,
+}
+
+var quickEntryWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseQuickEntry splits text into amount, date, labels, card and free words.
+// Relative dates resolve against today, whose location sets the calendar day.
+// A leading "+" on the amount marks income; everything else is an expense.
+func ParseQuickEntry(text string, today time.Time) (QuickEntry, error) {
+	tokens := strings.Fields(text)
+	if len(tokens) == 0 {
+		return QuickEntry{}, ErrQuickEntryEmpty
+	}
+
+	entry := QuickEntry{
+		Type:   EntryTypeExpense,
+		Labels: []string{},
+		Words:  []string{},
+	}
+
+	for i := 0; i < len(tokens); {
+		consumed := 0
+		for _, rule := range quickEntryRules {
+			used, err := rule(&entry, tokens, i, today)
+			if err != nil {
+				return QuickEntry{}, err
+			}
+			if used > 0 {
+				consumed = used
+				break
+			}
+		}
+		if consumed == 0 {
+			entry.Words = append(entry.Words, tokens[i])
+			consumed = 1
+		}
+		i += consumed
+	}
+
+	if entry.Amount == "" {
+		return QuickEntry{}, ErrQuickEntryAmountRequired
+	}
+	if entry.TransactionDate == "" {
+		entry.TransactionDate = today.Format("2006-01-02")
+	}
+
+	return entry, nil
+}
+
+func quickEntryLabelRule(entry *QuickEntry, tokens []string, i int, _ time.Time) (int, error) {
+	name, ok := strings.CutPrefix(tokens[i], "#")
+	if !ok || name == "" {
+		return 0, nil
+	}
+	entry.Labels = append(entry.Labels, name)
+	return 1, nil
+}
+
+func quickEntryCardRule(entry *QuickEntry, tokens []string, i int, _ time.Time) (int, error) {
+	name, ok := strings.CutPrefix(tokens[i], "@")
+	if !ok || name == "" {
+		return 0, nil
+	}
+	if entry.Card != "" {
+		return 0, ErrQuickEntryCardConflict
+	}
+	entry.Card = name
+	return 1, nil
+}
+
+func quickEntryDateRule(entry *QuickEntry, tokens []string, i int, today time.Time) (int, error) {
+	token := strings.ToLower(tokens[i])
+	var date time.Time
+	consumed := 1
+
+	switch {
+	case token == "today":
+		date = today
+	case token == "yesterday":
+		date = today.AddDate(0, 0, -1)
+	case token == "last" && i+1 < len(tokens):
+		weekday, ok := quickEntryWeekdays[strings.ToLower(tokens[i+1])]
+		if !ok {
+			return 0, nil
+		}
+		date = previousWeekday(today, weekday, false)
+		consumed = 2
+	default:
+		if weekday, ok := quickEntryWeekdays[token]; ok {
+			date = previousWeekday(today, weekday, true)
+			break
+		}
+		parsed, err := time.ParseInLocation("2006-01-02", tokens[i], today.Location())
+		if err != nil {
+			return 0, nil
+		}
+		date = parsed
+	}
+
+	if entry.TransactionDate != "" {
+		return 0, ErrQuickEntryDateConflict
+	}
+	entry.TransactionDate = date.Format("2006-01-02")
+	return consumed, nil
+}
+
+func quickEntryAmountRule(entry *QuickEntry, tokens []string, i int, _ time.Time) (int, error) {
+	token := tokens[i]
+	income := false
+	if rest, ok := strings.CutPrefix(token, "+"); ok {
+		token = rest
+		income = true
+	}
+	if token == "" || !unicode.IsDigit(rune(token[0])) {
+		return 0, nil
+	}
+	for _, r := range token {
+		if !unicode.IsDigit(r) && r != '.' && r != ',' {
+			return 0, nil
+		}
+	}
+
+	if entry.Amount != "" {
+		return 0, ErrQuickEntryAmountConflict
+	}
+	entry.Amount = token
+	if income {
+		entry.Type = EntryTypeIncome
+	}
+	return 1, nil
+}
+
+// quickEntryCurrencyRule only takes upper-case codes so ordinary three-letter
+// words ("tea", "bus") stay in the note.
+func quickEntryCurrencyRule(entry *QuickEntry, tokens []string, i int, _ time.Time) (int, error) {
+	token := tokens[i]
+	if entry.CurrencyCode != "" || len(token) != 3 || strings.ToUpper(token) != token {
+		return 0, nil
+	}
+	code, err := NormalizeCurrencyCode(token)
+	if err != nil {
+		return 0, nil
+	}
+	entry.CurrencyCode = code
+	return 1, nil
+}
+
+// previousWeekday returns the latest day with the given weekday before today,
+// or on today as well when includeToday is set.
+func previousWeekday(today time.Time, weekday time.Weekday, includeToday bool) time.Time {
+	days := (int(today.Weekday()) - int(weekday) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, -days)
+}
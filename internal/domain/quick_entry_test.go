@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuickEntry(t *testing.T) {
+	t.Parallel()
+
+	// 2026-02-11 is a Wednesday.
+	today := time.Date(2026, 2, 11, 9, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		text string
+		want QuickEntry
+	}{
+		{
+			text: "coffee 4.50 yesterday #work @visa",
+			want: QuickEntry{Type: EntryTypeExpense, Amount: "4.50", TransactionDate: "2026-02-10", Labels: []string{"work"}, Card: "visa", Words: []string{"coffee"}},
+		},
+		{
+			text: "dinner with Ana 38,90 EUR last friday #friends #food",
+			want: QuickEntry{Type: EntryTypeExpense, Amount: "38,90", CurrencyCode: "EUR", TransactionDate: "2026-02-06", Labels: []string{"friends", "food"}, Words: []string{"dinner", "with", "Ana"}},
+		},
+		{
+			text: "+3500 salary 2026-02-01",
+			want: QuickEntry{Type: EntryTypeIncome, Amount: "3500", TransactionDate: "2026-02-01", Labels: []string{}, Words: []string{"salary"}},
+		},
+		{
+			text: "tea 2 wednesday",
+			want: QuickEntry{Type: EntryTypeExpense, Amount: "2", TransactionDate: "2026-02-11", Labels: []string{}, Words: []string{"tea"}},
+		},
+		{
+			text: "bus 1.20 last wednesday",
+			want: QuickEntry{Type: EntryTypeExpense, Amount: "1.20", TransactionDate: "2026-02-04", Labels: []string{}, Words: []string{"bus"}},
+		},
+		{
+			text: "lunch 12",
+			want: QuickEntry{Type: EntryTypeExpense, Amount: "12", TransactionDate: "2026-02-11", Labels: []string{}, Words: []string{"lunch"}},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseQuickEntry(tc.text, today)
+		if err != nil {
+			t.Fatalf("ParseQuickEntry(%q): %v", tc.text, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("ParseQuickEntry(%q):\nwant %+v\ngot  %+v", tc.text, tc.want, got)
+		}
+	}
+}
+
+func TestParseQuickEntryRejectsInvalidText(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 2, 11, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		text string
+		want error
+	}{
+		{text: "   ", want: ErrQuickEntryEmpty},
+		{text: "coffee yesterday", want: ErrQuickEntryAmountRequired},
+		{text: "coffee 4.50 5", want: ErrQuickEntryAmountConflict},
+		{text: "coffee 4.50 today yesterday", want: ErrQuickEntryDateConflict},
+		{text: "coffee 4.50 @visa @amex", want: ErrQuickEntryCardConflict},
+	}
+
+	for _, tc := range cases {
+		if _, err := ParseQuickEntry(tc.text, today); !errors.Is(err, tc.want) {
+			t.Fatalf("ParseQuickEntry(%q): expected %v, got %v", tc.text, tc.want, err)
+		}
+	}
+}
@@ -13,9 +13,11 @@ const (
 	ReportScopeBimonthly = "bimonthly"
 	ReportScopeQuarterly = "quarterly"
 
-	ReportGroupingDay   = "day"
-	ReportGroupingWeek  = "week"
-	ReportGroupingMonth = "month"
+	ReportGroupingDay        = "day"
+	ReportGroupingWeek       = "week"
+	ReportGroupingMonth      = "month"
+	ReportGroupingWeekday    = "weekday"
+	ReportGroupingDayOfMonth = "day-of-month"
 
 	CategoryOrphanKey    = "orphan"
 	CategoryOrphanLabel  = "Orphan"
@@ -26,8 +28,10 @@ const (
 
 	WarningCodeOrphanCountExceeded    = "ORPHAN_COUNT_THRESHOLD_EXCEEDED"
 	WarningCodeOrphanSpendingExceeded = "ORPHAN_SPENDING_THRESHOLD_EXCEEDED"
+	WarningCodeCurrencySeenOnce       = "CURRENCY_SEEN_ONCE"
 	OrphanCountWarningMessage         = "Orphan entries exceed the configured threshold for the selected period."
 	OrphanSpendingWarningMessage      = "Orphan spending exceeds the configured threshold for one or more months."
+	CurrencySeenOnceWarningMessage    = "Currency is used by a single entry in the whole ledger; it may be a typo."
 )
 
 var (
@@ -79,8 +83,12 @@ type ReportNet struct {
 	ByCurrency []CurrencyTotal `json:"by_currency"`
 }
 
+// ReportCapStatus is one cap's spend for a month; category caps carry the
+// category they limit and only count that category's expenses.
 type ReportCapStatus struct {
 	MonthKey        string `json:"month_key"`
+	CategoryID      *int64 `json:"category_id,omitempty"`
+	CategoryName    string `json:"category_name,omitempty"`
 	CurrencyCode    string `json:"currency_code"`
 	CapAmountMinor  int64  `json:"cap_amount_minor"`
 	SpendTotalMinor int64  `json:"spend_total_minor"`
@@ -99,6 +107,42 @@ type ReportPaymentInstrumentTotal struct {
 	InstrumentLabel string `json:"instrument_label"`
 }
 
+// ReportCardBrandTotal is card spending for one brand and currency.
+type ReportCardBrandTotal struct {
+	Brand        string `json:"brand"`
+	CurrencyCode string `json:"currency_code"`
+	TotalMinor   int64  `json:"total_minor"`
+}
+
+// ReportPersonTotal is what one person recorded in a currency. Entries without
+// recorded_by are grouped under an empty Person.
+type ReportPersonTotal struct {
+	Person        string `json:"person"`
+	CurrencyCode  string `json:"currency_code"`
+	EarningsMinor int64  `json:"earnings_minor"`
+	SpendingMinor int64  `json:"spending_minor"`
+	NetMinor      int64  `json:"net_minor"`
+	EntryCount    int64  `json:"entry_count"`
+}
+
+// ReportSourceTotal is the income from one source in a currency. Income
+// without income_source is grouped under an empty Source.
+type ReportSourceTotal struct {
+	Source       string `json:"source"`
+	CurrencyCode string `json:"currency_code"`
+	TotalMinor   int64  `json:"total_minor"`
+	EntryCount   int64  `json:"entry_count"`
+}
+
+// ReportLocationTotal is the spending at one location in a currency, net of
+// refunds. Expenses without a location are grouped under an empty Location.
+type ReportLocationTotal struct {
+	Location     string `json:"location"`
+	CurrencyCode string `json:"currency_code"`
+	TotalMinor   int64  `json:"total_minor"`
+	EntryCount   int64  `json:"entry_count"`
+}
+
 type ReportPaymentMethodTotals struct {
 	Cash   []CurrencyTotal `json:"cash"`
 	Debit  []CurrencyTotal `json:"debit"`
@@ -123,6 +167,9 @@ type ReportCardLiability struct {
 type ReportPaymentMethods struct {
 	ByInstrument    []ReportPaymentInstrumentTotal `json:"by_instrument"`
 	Totals          ReportPaymentMethodTotals      `json:"totals"`
+	ByBrand         []ReportCardBrandTotal         `json:"by_brand"`
+	CreditGroups    []GroupTotal                   `json:"credit_groups"`
+	DebitGroups     []GroupTotal                   `json:"debit_groups"`
 	CashUsage       []ReportCashUsage              `json:"cash_usage"`
 	CreditLiability []ReportCardLiability          `json:"credit_liability"`
 }
@@ -137,24 +184,81 @@ type Report struct {
 	MonthlyBalance *ReportNet            `json:"monthly_balance,omitempty"`
 	GeneralBalance ReportNet             `json:"general_balance"`
 	PaymentMethods *ReportPaymentMethods `json:"payment_methods,omitempty"`
+	ByPerson       []ReportPersonTotal   `json:"by_person"`
+	BySource       []ReportSourceTotal   `json:"earnings_by_source"`
+	ByLocation     []ReportLocationTotal `json:"spending_by_location"`
+	Assets         *ReportAssets         `json:"assets,omitempty"`
 	Converted      *ConvertedSummary     `json:"converted,omitempty"`
+	Revaluation    *ReportRevaluation    `json:"revaluation,omitempty"`
 	CapStatus      []ReportCapStatus     `json:"cap_status"`
 	CapChanges     []MonthlyCapChange    `json:"cap_changes"`
 }
 
+// ReportAssets lists the asset registry as it stands when the report is
+// built; balances are current, not as of the report period.
+type ReportAssets struct {
+	Items  []Asset      `json:"items"`
+	Totals []AssetTotal `json:"totals"`
+}
+
+// CurrencyMixRow counts one month's entries in a currency. SeenOnce marks
+// currencies used by exactly one active entry across the ledger.
+type CurrencyMixRow struct {
+	CurrencyCode       string `json:"currency_code"`
+	EntryCount         int64  `json:"entry_count"`
+	IncomeCount        int64  `json:"income_count"`
+	ExpenseCount       int64  `json:"expense_count"`
+	LifetimeEntryCount int64  `json:"lifetime_entry_count"`
+	SeenOnce           bool   `json:"seen_once"`
+}
+
+type CurrencyMixReport struct {
+	Period     ReportPeriod     `json:"period"`
+	EntryCount int64            `json:"entry_count"`
+	Currencies []CurrencyMixRow `json:"currencies"`
+}
+
+type CurrencySeenOnceWarningDetails struct {
+	MonthKey     string `json:"month_key"`
+	CurrencyCode string `json:"currency_code"`
+	EntryID      int64  `json:"entry_id"`
+}
+
+// OrphanSpendingStatus compares one month's uncategorized expense spend with
+// the month's total spend in that currency.
+type OrphanSpendingStatus struct {
+	MonthKey         string `json:"month_key"`
+	CurrencyCode     string `json:"currency_code"`
+	OrphanSpendMinor int64  `json:"orphan_spend_minor"`
+	MonthSpendMinor  int64  `json:"month_spend_minor"`
+	RatioToSpendBP   int64  `json:"ratio_to_month_spend_bps"`
+	ThresholdMode    string `json:"threshold_mode"`
+	Exceeded         bool   `json:"exceeded"`
+}
+
+type OrphanStatus struct {
+	Period               ReportPeriod           `json:"period"`
+	OrphanCount          int                    `json:"orphan_count"`
+	CountThreshold       int                    `json:"count_threshold"`
+	SpendingThresholdBPS int                    `json:"spending_threshold_bps"`
+	Spending             []OrphanSpendingStatus `json:"spending"`
+}
+
 type CurrencyNet struct {
 	CurrencyCode string `json:"currency_code"`
 	NetMinor     int64  `json:"net_minor"`
 }
 
 type BalanceView struct {
-	ByCurrency []CurrencyNet `json:"by_currency"`
+	ByCurrency  []CurrencyNet      `json:"by_currency"`
+	Revaluation *ReportRevaluation `json:"revaluation,omitempty"`
 }
 
 type ConvertedBalanceView struct {
 	TargetCurrency   string `json:"target_currency"`
 	NetMinor         int64  `json:"net_minor"`
 	UsedEstimateRate bool   `json:"used_estimate_rate"`
+	UsedStaleRate    bool   `json:"used_stale_rate"`
 }
 
 type BalanceViews struct {
@@ -162,6 +266,7 @@ type BalanceViews struct {
 	Range             *BalanceView          `json:"range,omitempty"`
 	LifetimeConverted *ConvertedBalanceView `json:"lifetime_converted,omitempty"`
 	RangeConverted    *ConvertedBalanceView `json:"range_converted,omitempty"`
+	Warnings          []Warning             `json:"-"`
 }
 
 type OrphanCountWarningDetails struct {
@@ -172,15 +277,17 @@ type OrphanCountWarningDetails struct {
 }
 
 type OrphanSpendingWarningDetails struct {
-	MonthKey       string   `json:"month_key"`
-	CurrencyCode   string   `json:"currency_code"`
-	OrphanSpend    int64    `json:"orphan_spend_minor"`
-	MonthSpend     int64    `json:"month_spend_minor"`
-	CapAmount      *int64   `json:"cap_amount_minor"`
-	ThresholdBPS   int      `json:"threshold_bps"`
-	TriggeredBy    []string `json:"triggered_by"`
-	RatioToSpendBP int64    `json:"ratio_to_month_spend_bps"`
-	RatioToCapBP   int64    `json:"ratio_to_cap_bps"`
+	MonthKey        string   `json:"month_key"`
+	CurrencyCode    string   `json:"currency_code"`
+	OrphanSpend     int64    `json:"orphan_spend_minor"`
+	MonthSpend      int64    `json:"month_spend_minor"`
+	CapAmount       *int64   `json:"cap_amount_minor"`
+	ThresholdBPS    int      `json:"threshold_bps"`
+	ThresholdMode   string   `json:"threshold_mode"`
+	ThresholdAmount *int64   `json:"threshold_amount_minor"`
+	TriggeredBy     []string `json:"triggered_by"`
+	RatioToSpendBP  int64    `json:"ratio_to_month_spend_bps"`
+	RatioToCapBP    int64    `json:"ratio_to_cap_bps"`
 }
 
 func NormalizeReportScope(scope string) (string, error) {
@@ -204,7 +311,7 @@ func NormalizeReportGrouping(grouping string) (string, error) {
 	}
 
 	switch normalized {
-	case ReportGroupingDay, ReportGroupingWeek, ReportGroupingMonth:
+	case ReportGroupingDay, ReportGroupingWeek, ReportGroupingMonth, ReportGroupingWeekday, ReportGroupingDayOfMonth:
 		return normalized, nil
 	default:
 		return "", ErrInvalidReportGrouping
@@ -279,6 +386,10 @@ func PeriodKeyForTransaction(transactionDateUTC string, grouping string) (string
 		return fmt.Sprintf("%04d-W%02d", year, week), nil
 	case ReportGroupingMonth:
 		return parsedDate.UTC().Format("2006-01"), nil
+	case ReportGroupingWeekday:
+		return strings.ToLower(parsedDate.UTC().Weekday().String()), nil
+	case ReportGroupingDayOfMonth:
+		return parsedDate.UTC().Format("02"), nil
 	default:
 		return "", ErrInvalidReportGrouping
 	}
@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+// ReportCacheFormat is part of every report cache key; bump it whenever the
+// Report shape changes so entries written by an older build are not served.
+const ReportCacheFormat = 1
+
+var ErrReportCacheMiss = errors.New("report cache miss")
+
+// CachedReport is a generated report and its warnings as the report cache
+// keeps them.
+type CachedReport struct {
+	Report   Report    `json:"report"`
+	Warnings []Warning `json:"warnings"`
+}
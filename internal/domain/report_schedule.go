@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ReportScheduleEveryDaily   = "daily"
+	ReportScheduleEveryWeekly  = "weekly"
+	ReportScheduleEveryMonthly = "monthly"
+
+	ReportScheduleResourceReport  = "report"
+	ReportScheduleResourceEntries = "entries"
+
+	ReportScheduleStatusRan    = "ran"
+	ReportScheduleStatusDue    = "due"
+	ReportScheduleStatusNotDue = "not_due"
+
+	// ReportSchedulePeriodPlaceholder is replaced by the period key in a job's
+	// file path, e.g. ~/reports/{period}.json becomes ~/reports/2026-03.json.
+	ReportSchedulePeriodPlaceholder = "{period}"
+)
+
+var ErrInvalidReportScheduleConfig = errors.New("invalid report schedule config")
+
+// ReportScheduleConfig is the file read by `report schedule run`.
+type ReportScheduleConfig struct {
+	Jobs []ReportScheduleJob `json:"jobs"`
+}
+
+// ReportScheduleJob exports one resource for the latest completed period of
+// its cadence. GroupBy and ConvertTo apply to reports, Keys to entries.
+type ReportScheduleJob struct {
+	Name      string `json:"name"`
+	Resource  string `json:"resource"`
+	Every     string `json:"every"`
+	Format    string `json:"format"`
+	File      string `json:"file"`
+	GroupBy   string `json:"group_by,omitempty"`
+	ConvertTo string `json:"convert_to,omitempty"`
+	Keys      string `json:"keys,omitempty"`
+}
+
+// ReportScheduleRun is the last successful run of a job.
+type ReportScheduleRun struct {
+	JobName      string `json:"job_name"`
+	PeriodKey    string `json:"period_key"`
+	FilePath     string `json:"file_path"`
+	LastRunAtUTC string `json:"last_run_at_utc"`
+}
+
+// ReportSchedulePeriod is a completed day, ISO week, or month. MonthKey is
+// set for monthly periods only.
+type ReportSchedulePeriod struct {
+	Key      string `json:"key"`
+	MonthKey string `json:"month_key,omitempty"`
+	FromUTC  string `json:"from_utc"`
+	ToUTC    string `json:"to_utc"`
+}
+
+// ParseReportScheduleConfig decodes and validates a schedule config. Unknown
+// fields are rejected so typos do not silently disable a setting.
+func ParseReportScheduleConfig(data []byte) (ReportScheduleConfig, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var config ReportScheduleConfig
+	if err := decoder.Decode(&config); err != nil {
+		return ReportScheduleConfig{}, fmt.Errorf("%w: %v", ErrInvalidReportScheduleConfig, err)
+	}
+
+	seen := map[string]bool{}
+	for index, job := range config.Jobs {
+		normalized, err := normalizeReportScheduleJob(job)
+		if err != nil {
+			return ReportScheduleConfig{}, fmt.Errorf("%w: job %d: %v", ErrInvalidReportScheduleConfig, index+1, err)
+		}
+		if seen[normalized.Name] {
+			return ReportScheduleConfig{}, fmt.Errorf("%w: duplicate job name %q", ErrInvalidReportScheduleConfig, normalized.Name)
+		}
+		seen[normalized.Name] = true
+		config.Jobs[index] = normalized
+	}
+
+	return config, nil
+}
+
+func normalizeReportScheduleJob(job ReportScheduleJob) (ReportScheduleJob, error) {
+	job.Name = strings.TrimSpace(job.Name)
+	job.Resource = strings.ToLower(strings.TrimSpace(job.Resource))
+	job.Every = strings.ToLower(strings.TrimSpace(job.Every))
+	job.Format = strings.ToLower(strings.TrimSpace(job.Format))
+	job.File = strings.TrimSpace(job.File)
+	job.GroupBy = strings.ToLower(strings.TrimSpace(job.GroupBy))
+	job.ConvertTo = strings.ToUpper(strings.TrimSpace(job.ConvertTo))
+	job.Keys = strings.ToLower(strings.TrimSpace(job.Keys))
+
+	if job.Name == "" {
+		return ReportScheduleJob{}, errors.New("name is required")
+	}
+	if job.File == "" {
+		return ReportScheduleJob{}, errors.New("file is required")
+	}
+	switch job.Every {
+	case ReportScheduleEveryDaily, ReportScheduleEveryWeekly, ReportScheduleEveryMonthly:
+	default:
+		return ReportScheduleJob{}, fmt.Errorf("every must be one of: daily|weekly|monthly")
+	}
+	if job.Format != "json" && job.Format != "csv" {
+		return ReportScheduleJob{}, fmt.Errorf("format must be one of: json|csv")
+	}
+
+	switch job.Resource {
+	case ReportScheduleResourceReport:
+		if job.Keys != "" {
+			return ReportScheduleJob{}, errors.New("keys applies only to entries jobs")
+		}
+		if job.GroupBy == "" {
+			job.GroupBy = ReportGroupingMonth
+		}
+		grouping, err := NormalizeReportGrouping(job.GroupBy)
+		if err != nil {
+			return ReportScheduleJob{}, fmt.Errorf("group_by must be one of: day|week|month|weekday|day-of-month")
+		}
+		job.GroupBy = grouping
+		if job.ConvertTo != "" {
+			if _, err := NormalizeCurrencyCode(job.ConvertTo); err != nil {
+				return ReportScheduleJob{}, fmt.Errorf("convert_to must be an ISO currency code")
+			}
+		}
+	case ReportScheduleResourceEntries:
+		if job.GroupBy != "" || job.ConvertTo != "" {
+			return ReportScheduleJob{}, errors.New("group_by and convert_to apply only to report jobs")
+		}
+		if job.Keys != "" && job.Keys != "id" && job.Keys != "natural" {
+			return ReportScheduleJob{}, fmt.Errorf("keys must be one of: id|natural")
+		}
+	default:
+		return ReportScheduleJob{}, fmt.Errorf("resource must be one of: report|entries")
+	}
+
+	return job, nil
+}
+
+// LatestCompletedReportSchedulePeriod returns the last full period of every
+// that ended before asOf's UTC day: yesterday, last ISO week, or last month.
+func LatestCompletedReportSchedulePeriod(every string, asOf time.Time) (ReportSchedulePeriod, error) {
+	day := time.Date(asOf.UTC().Year(), asOf.UTC().Month(), asOf.UTC().Day(), 0, 0, 0, 0, time.UTC)
+
+	var from, to time.Time
+	period := ReportSchedulePeriod{}
+	switch every {
+	case ReportScheduleEveryDaily:
+		from = day.AddDate(0, 0, -1)
+		to = day
+		period.Key = from.Format("2006-01-02")
+	case ReportScheduleEveryWeekly:
+		weekdayOffset := (int(day.Weekday()) + 6) % 7
+		to = day.AddDate(0, 0, -weekdayOffset)
+		from = to.AddDate(0, 0, -7)
+		year, week := from.ISOWeek()
+		period.Key = fmt.Sprintf("%04d-W%02d", year, week)
+	case ReportScheduleEveryMonthly:
+		to = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		from = to.AddDate(0, -1, 0)
+		period.Key = from.Format("2006-01")
+		period.MonthKey = period.Key
+	default:
+		return ReportSchedulePeriod{}, fmt.Errorf("%w: every must be one of: daily|weekly|monthly", ErrInvalidReportScheduleConfig)
+	}
+
+	period.FromUTC = from.Format(time.RFC3339Nano)
+	period.ToUTC = to.Add(-time.Nanosecond).Format(time.RFC3339Nano)
+	return period, nil
+}
+
+// ReportScheduleFilePath substitutes the period key into a job's file path.
+func ReportScheduleFilePath(job ReportScheduleJob, period ReportSchedulePeriod) string {
+	return strings.ReplaceAll(job.File, ReportSchedulePeriodPlaceholder, period.Key)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+const WarningCodeReportSnapshotDrift = "REPORT_SNAPSHOT_DRIFT"
+
+var (
+	ErrReportSnapshotExists   = errors.New("report snapshot already exists")
+	ErrReportSnapshotNotFound = errors.New("report snapshot not found")
+)
+
+// ReportSnapshot is a monthly report frozen at FrozenAtUTC. Later entry edits
+// do not change it.
+type ReportSnapshot struct {
+	ID          int64  `json:"id"`
+	MonthKey    string `json:"month_key"`
+	Report      Report `json:"report"`
+	FrozenAtUTC string `json:"frozen_at_utc"`
+}
+
+type ReportSnapshotDriftWarningDetails struct {
+	MonthKey    string   `json:"month_key"`
+	FrozenAtUTC string   `json:"frozen_at_utc"`
+	Sections    []string `json:"sections"`
+}
+
+// ReportSnapshotDriftSections lists the entry-derived report sections whose
+// live values no longer match the snapshot. Lifetime balances, card debt and
+// caps move with later activity and are not compared.
+func ReportSnapshotDriftSections(frozen, live Report) ([]string, error) {
+	type section struct {
+		name         string
+		frozen, live any
+	}
+	sections := []section{
+		{"earnings", frozen.Earnings, live.Earnings},
+		{"spending", frozen.Spending, live.Spending},
+		{"net", frozen.Net, live.Net},
+		{"by_person", frozen.ByPerson, live.ByPerson},
+		{"earnings_by_source", frozen.BySource, live.BySource},
+	}
+	// Snapshots frozen before entry locations have no location section.
+	if frozen.ByLocation != nil {
+		sections = append(sections, section{"spending_by_location", frozen.ByLocation, live.ByLocation})
+	}
+
+	drifted := []string{}
+	for _, section := range sections {
+		frozenJSON, err := json.Marshal(section.frozen)
+		if err != nil {
+			return nil, err
+		}
+		liveJSON, err := json.Marshal(section.live)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(frozenJSON, liveJSON) {
+			drifted = append(drifted, section.name)
+		}
+	}
+	return drifted, nil
+}
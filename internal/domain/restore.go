@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Table groups `data restore --only` can copy out of a backup. Each group
+// lists its tables parents first; rows are deleted in reverse order. A table
+// derived from both cards and entries (card liability events) belongs to
+// both groups and is restored once.
+const (
+	RestoreGroupEntries    = "entries"
+	RestoreGroupCategories = "categories"
+	RestoreGroupLabels     = "labels"
+	RestoreGroupCards      = "cards"
+	RestoreGroupCurrencies = "currencies"
+	RestoreGroupCaps       = "caps"
+	RestoreGroupLoans      = "loans"
+	RestoreGroupSettings   = "settings"
+)
+
+var (
+	ErrInvalidRestoreGroup = errors.New("invalid restore group")
+	// ErrRestoreForeignKeyViolation means the restored rows reference rows
+	// the live database no longer has, or the other way around.
+	ErrRestoreForeignKeyViolation = errors.New("restore foreign key violation")
+)
+
+var restoreGroupTables = map[string][]string{
+	RestoreGroupEntries:    {"transactions", "transaction_labels", "transaction_payment_methods", "entry_splits", "transaction_revisions", "loan_payments", "credit_liability_events", "settlements"},
+	RestoreGroupCategories: {"categories"},
+	RestoreGroupLabels:     {"labels"},
+	RestoreGroupCards:      {"cards", "card_aliases", "card_monthly_limits", "credit_liability_events"},
+	RestoreGroupCurrencies: {"custom_currencies"},
+	RestoreGroupCaps:       {"monthly_caps", "monthly_category_caps", "monthly_cap_changes"},
+	RestoreGroupLoans:      {"loans"},
+	RestoreGroupSettings:   {"settings"},
+}
+
+// restoreGroupOrder keeps referenced groups ahead of the groups pointing at
+// them, so a multi-group restore inserts parents first.
+var restoreGroupOrder = []string{
+	RestoreGroupCurrencies,
+	RestoreGroupCategories,
+	RestoreGroupLabels,
+	RestoreGroupCards,
+	RestoreGroupLoans,
+	RestoreGroupEntries,
+	RestoreGroupCaps,
+	RestoreGroupSettings,
+}
+
+// RestoreTableCount reports how many rows a selective restore removed from
+// and copied into one live table.
+type RestoreTableCount struct {
+	Table    string `json:"table"`
+	Deleted  int64  `json:"deleted"`
+	Restored int64  `json:"restored"`
+}
+
+type RestoreReport struct {
+	Groups []string            `json:"groups"`
+	Tables []RestoreTableCount `json:"tables"`
+}
+
+// ParseRestoreGroups splits a comma-separated --only value into known groups
+// in dependency order, dropping duplicates.
+func ParseRestoreGroups(raw string) ([]string, error) {
+	selected := map[string]bool{}
+	for _, part := range strings.Split(raw, ",") {
+		group := strings.ToLower(strings.TrimSpace(part))
+		if group == "" {
+			continue
+		}
+		if _, ok := restoreGroupTables[group]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRestoreGroup, group)
+		}
+		selected[group] = true
+	}
+	if len(selected) == 0 {
+		return nil, ErrInvalidRestoreGroup
+	}
+
+	groups := make([]string, 0, len(selected))
+	for _, group := range restoreGroupOrder {
+		if selected[group] {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// RestoreGroups lists every group name in dependency order.
+func RestoreGroups() []string {
+	return append([]string(nil), restoreGroupOrder...)
+}
+
+// RestoreGroupTables returns the tables of group, parents first.
+func RestoreGroupTables(group string) []string {
+	return append([]string(nil), restoreGroupTables[group]...)
+}
+
+// RestoreMonthDiff compares the active entries of one month in the live
+// database (Current) and in a backup (Backup). Delta is Backup - Current, the
+// change a full restore would make.
+type RestoreMonthDiff struct {
+	Month   string `json:"month"`
+	Current int64  `json:"current"`
+	Backup  int64  `json:"backup"`
+	Delta   int64  `json:"delta"`
+}
+
+// RestoreSettingDiff is one settings field whose value differs between the
+// live database and a backup.
+type RestoreSettingDiff struct {
+	Field   string `json:"field"`
+	Current any    `json:"current"`
+	Backup  any    `json:"backup"`
+}
+
+// RestoreDiff previews a full restore: months whose entry counts change,
+// cards (by nickname) the restore brings back or drops, and settings it
+// overwrites.
+type RestoreDiff struct {
+	EntriesCurrent int64                `json:"entries_current"`
+	EntriesBackup  int64                `json:"entries_backup"`
+	EntryMonths    []RestoreMonthDiff   `json:"entry_months"`
+	CardsAdded     []string             `json:"cards_added"`
+	CardsRemoved   []string             `json:"cards_removed"`
+	Settings       []RestoreSettingDiff `json:"settings"`
+}
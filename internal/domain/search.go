@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultSearchLimit caps how many matches search returns per group.
+const DefaultSearchLimit = 20
+
+var (
+	ErrInvalidSearchText  = errors.New("invalid search text")
+	ErrInvalidSearchLimit = errors.New("invalid search limit")
+)
+
+// SearchEntryMatch is an active entry whose note contains the search text.
+type SearchEntryMatch struct {
+	ID                 int64  `json:"id"`
+	Type               string `json:"type"`
+	AmountMinor        int64  `json:"amount_minor"`
+	CurrencyCode       string `json:"currency_code"`
+	TransactionDateUTC string `json:"transaction_date_utc"`
+	Note               string `json:"note"`
+}
+
+// SearchNameMatch is an active category or label whose name contains the
+// search text.
+type SearchNameMatch struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Archived bool   `json:"archived"`
+}
+
+// SearchCardMatch is an active card whose nickname or description contains
+// the search text.
+type SearchCardMatch struct {
+	ID          int64  `json:"id"`
+	Nickname    string `json:"nickname"`
+	Description string `json:"description,omitempty"`
+	Last4       string `json:"last4"`
+	CardType    string `json:"card_type"`
+}
+
+// SearchResult groups the matches of one search by the kind of record.
+type SearchResult struct {
+	Query      string             `json:"query"`
+	Entries    []SearchEntryMatch `json:"entries"`
+	Categories []SearchNameMatch  `json:"categories"`
+	Labels     []SearchNameMatch  `json:"labels"`
+	Cards      []SearchCardMatch  `json:"cards"`
+}
+
+// Count returns the number of matches across every group.
+func (r SearchResult) Count() int {
+	return len(r.Entries) + len(r.Categories) + len(r.Labels) + len(r.Cards)
+}
+
+// NormalizeSearchText trims text and rejects an empty search.
+func NormalizeSearchText(text string) (string, error) {
+	normalized := strings.TrimSpace(text)
+	if normalized == "" {
+		return "", ErrInvalidSearchText
+	}
+	return normalized, nil
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -10,16 +11,88 @@ const (
 	DefaultOrphanSpendingThresholdBPSValue = 500
 )
 
+const (
+	OrphanSpendingThresholdModePercent  = "percent"
+	OrphanSpendingThresholdModeAbsolute = "absolute"
+	OrphanSpendingThresholdModeOff      = "off"
+
+	// OrphanSpendingThresholdAllCurrencies keys the rule that applies to every
+	// currency without its own rule.
+	OrphanSpendingThresholdAllCurrencies = "*"
+)
+
 var (
-	ErrSettingsNotFound = errors.New("settings not found")
+	ErrSettingsNotFound                   = errors.New("settings not found")
+	ErrInvalidOrphanThresholdMode         = errors.New("invalid orphan spending threshold mode")
+	ErrInvalidOrphanThresholdValue        = errors.New("invalid orphan spending threshold value")
+	ErrOrphanThresholdAmountNeedsCurrency = errors.New("absolute orphan spending threshold requires a currency")
+	ErrOrphanThresholdNotFound            = errors.New("orphan spending threshold not found")
+	ErrInvalidSettingKey                  = errors.New("invalid setting key")
+	ErrInvalidSettingValue                = errors.New("invalid setting value")
 )
 
+// Setting keys accepted by settings get/set. Each maps to one settings column.
+const (
+	SettingKeyDefaultCurrency            = "default_currency"
+	SettingKeyTimezone                   = "timezone"
+	SettingKeyDefaultOutput              = "default_output"
+	SettingKeyDefaultCardID              = "default_card_id"
+	SettingKeyAmountFormat               = "amount_format"
+	SettingKeyOrphanCountThreshold       = "orphan_count_threshold"
+	SettingKeyOrphanSpendingThresholdBPS = "orphan_spending_threshold_bps"
+	SettingKeyFiscalMonthStartDay        = "fiscal_month_start_day"
+	SettingKeyDefaultRecordedBy          = "default_recorded_by"
+	SettingKeyAutoSnapshot               = "auto_snapshot"
+	SettingKeyFXCacheTTLHours            = "fx_cache_ttl_hours"
+	SettingKeyFXStaleAfterDays           = "fx_stale_after_days"
+	SettingKeyReportCache                = "report_cache"
+
+	DefaultFiscalMonthStartDay = 1
+	MaxFiscalMonthStartDay     = 28
+
+	// SettingValueNone clears an optional setting.
+	SettingValueNone = "none"
+)
+
+// SettingKeys lists the settings keys in display order.
+var SettingKeys = []string{
+	SettingKeyDefaultCurrency,
+	SettingKeyTimezone,
+	SettingKeyDefaultOutput,
+	SettingKeyDefaultCardID,
+	SettingKeyAmountFormat,
+	SettingKeyOrphanCountThreshold,
+	SettingKeyOrphanSpendingThresholdBPS,
+	SettingKeyFiscalMonthStartDay,
+	SettingKeyDefaultRecordedBy,
+	SettingKeyAutoSnapshot,
+	SettingKeyFXCacheTTLHours,
+	SettingKeyFXStaleAfterDays,
+	SettingKeyReportCache,
+}
+
+// SettingValue is one key of the settings row. Unset optional keys carry a nil
+// value.
+type SettingValue struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
 type Settings struct {
 	ID                         int64   `json:"id"`
 	DefaultCurrencyCode        string  `json:"default_currency_code"`
 	DisplayTimezone            string  `json:"display_timezone"`
 	OrphanCountThreshold       int64   `json:"orphan_count_threshold"`
 	OrphanSpendingThresholdBPS int64   `json:"orphan_spending_threshold_bps"`
+	AmountFormat               string  `json:"amount_format"`
+	DefaultOutput              *string `json:"default_output"`
+	DefaultCardID              *int64  `json:"default_card_id"`
+	FiscalMonthStartDay        int64   `json:"fiscal_month_start_day"`
+	DefaultRecordedBy          *string `json:"default_recorded_by"`
+	AutoSnapshot               bool    `json:"auto_snapshot"`
+	FXCacheTTLHours            int64   `json:"fx_cache_ttl_hours"`
+	FXStaleAfterDays           int64   `json:"fx_stale_after_days"`
+	ReportCache                bool    `json:"report_cache"`
 	OnboardingCompletedAtUTC   *string `json:"onboarding_completed_at_utc,omitempty"`
 	CreatedAtUTC               string  `json:"created_at_utc"`
 	UpdatedAtUTC               string  `json:"updated_at_utc"`
@@ -30,6 +103,7 @@ type SettingsUpsertInput struct {
 	DisplayTimezone            string
 	OrphanCountThreshold       int64
 	OrphanSpendingThresholdBPS int64
+	AmountFormat               string
 	OnboardingCompletedAtUTC   *string
 }
 
@@ -53,11 +127,221 @@ func NormalizeSettingsInput(input SettingsUpsertInput) (SettingsUpsertInput, err
 		orphanSpendingThresholdBPS = DefaultOrphanSpendingThresholdBPSValue
 	}
 
+	amountFormat, err := NormalizeAmountFormat(input.AmountFormat)
+	if err != nil {
+		return SettingsUpsertInput{}, err
+	}
+
 	return SettingsUpsertInput{
 		DefaultCurrencyCode:        currencyCode,
 		DisplayTimezone:            input.DisplayTimezone,
 		OrphanCountThreshold:       orphanCountThreshold,
 		OrphanSpendingThresholdBPS: orphanSpendingThresholdBPS,
+		AmountFormat:               amountFormat,
 		OnboardingCompletedAtUTC:   input.OnboardingCompletedAtUTC,
 	}, nil
 }
+
+// SettingsPreferences are the settings that setup init does not manage; they
+// are written separately so re-running setup keeps them.
+type SettingsPreferences struct {
+	DefaultOutput       *string
+	DefaultCardID       *int64
+	FiscalMonthStartDay int64
+	DefaultRecordedBy   *string
+	AutoSnapshot        bool
+	FXCacheTTLHours     int64
+	FXStaleAfterDays    int64
+	ReportCache         bool
+}
+
+// NormalizeSettingKey accepts keys case-insensitively, with dashes or
+// underscores.
+func NormalizeSettingKey(key string) (string, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+	for _, known := range SettingKeys {
+		if normalized == known {
+			return normalized, nil
+		}
+	}
+	return "", ErrInvalidSettingKey
+}
+
+// SettingValues flattens settings into key/value pairs in SettingKeys order.
+func SettingValues(settings Settings) []SettingValue {
+	values := make([]SettingValue, 0, len(SettingKeys))
+	for _, key := range SettingKeys {
+		values = append(values, SettingValue{Key: key, Value: SettingValueOf(settings, key)})
+	}
+	return values
+}
+
+func SettingValueOf(settings Settings, key string) any {
+	switch key {
+	case SettingKeyDefaultCurrency:
+		return settings.DefaultCurrencyCode
+	case SettingKeyTimezone:
+		return settings.DisplayTimezone
+	case SettingKeyDefaultOutput:
+		if settings.DefaultOutput == nil {
+			return nil
+		}
+		return *settings.DefaultOutput
+	case SettingKeyDefaultCardID:
+		if settings.DefaultCardID == nil {
+			return nil
+		}
+		return *settings.DefaultCardID
+	case SettingKeyAmountFormat:
+		return settings.AmountFormat
+	case SettingKeyOrphanCountThreshold:
+		return settings.OrphanCountThreshold
+	case SettingKeyOrphanSpendingThresholdBPS:
+		return settings.OrphanSpendingThresholdBPS
+	case SettingKeyFiscalMonthStartDay:
+		return settings.FiscalMonthStartDay
+	case SettingKeyDefaultRecordedBy:
+		if settings.DefaultRecordedBy == nil {
+			return nil
+		}
+		return *settings.DefaultRecordedBy
+	case SettingKeyAutoSnapshot:
+		return settings.AutoSnapshot
+	case SettingKeyFXCacheTTLHours:
+		return settings.FXCacheTTLHours
+	case SettingKeyFXStaleAfterDays:
+		return settings.FXStaleAfterDays
+	case SettingKeyReportCache:
+		return settings.ReportCache
+	default:
+		return nil
+	}
+}
+
+// NormalizeSettingsDefaultOutput accepts the formats --output takes.
+func NormalizeSettingsDefaultOutput(format string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(format))
+	switch normalized {
+	case "human", "json":
+		return normalized, nil
+	default:
+		return "", ErrInvalidSettingValue
+	}
+}
+
+// ParseSettingSwitch reads an on/off setting value.
+func ParseSettingSwitch(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "true", "yes", "1":
+		return true, nil
+	case "off", "false", "no", "0":
+		return false, nil
+	default:
+		return false, ErrInvalidSettingValue
+	}
+}
+
+// NormalizeFiscalMonthStartDay keeps the fiscal month start in 1..28 so every
+// month has that day.
+func NormalizeFiscalMonthStartDay(day int64) (int64, error) {
+	if day < 1 || day > MaxFiscalMonthStartDay {
+		return 0, ErrInvalidSettingValue
+	}
+	return day, nil
+}
+
+// OrphanSpendingThreshold tunes when ORPHAN_SPENDING_THRESHOLD_EXCEEDED fires
+// for one currency (or "*" for all): a share of month spend/cap in basis
+// points, a fixed amount of orphan spend, or never.
+type OrphanSpendingThreshold struct {
+	CurrencyCode string `json:"currency_code"`
+	Mode         string `json:"mode"`
+	PercentBPS   *int64 `json:"percent_bps"`
+	AmountMinor  *int64 `json:"amount_minor"`
+	Source       string `json:"source"`
+	CreatedAtUTC string `json:"created_at_utc,omitempty"`
+	UpdatedAtUTC string `json:"updated_at_utc,omitempty"`
+}
+
+type OrphanSpendingThresholdInput struct {
+	CurrencyCode string
+	Mode         string
+	PercentBPS   int64
+	AmountMinor  int64
+}
+
+const (
+	OrphanThresholdSourceCurrency = "currency"
+	OrphanThresholdSourceAll      = "all_currencies"
+	OrphanThresholdSourceSettings = "settings"
+)
+
+// NormalizeOrphanSpendingThresholdCurrency accepts "*" or "all" for the
+// all-currencies rule and otherwise a currency code.
+func NormalizeOrphanSpendingThresholdCurrency(code string) (string, error) {
+	trimmed := strings.TrimSpace(code)
+	if trimmed == "" || trimmed == OrphanSpendingThresholdAllCurrencies || strings.EqualFold(trimmed, "all") {
+		return OrphanSpendingThresholdAllCurrencies, nil
+	}
+	return NormalizeCurrencyCode(trimmed)
+}
+
+func NormalizeOrphanSpendingThresholdInput(input OrphanSpendingThresholdInput) (OrphanSpendingThresholdInput, error) {
+	currencyCode, err := NormalizeOrphanSpendingThresholdCurrency(input.CurrencyCode)
+	if err != nil {
+		return OrphanSpendingThresholdInput{}, err
+	}
+
+	normalized := OrphanSpendingThresholdInput{
+		CurrencyCode: currencyCode,
+		Mode:         strings.ToLower(strings.TrimSpace(input.Mode)),
+	}
+	switch normalized.Mode {
+	case OrphanSpendingThresholdModePercent:
+		if input.PercentBPS <= 0 {
+			return OrphanSpendingThresholdInput{}, ErrInvalidOrphanThresholdValue
+		}
+		normalized.PercentBPS = input.PercentBPS
+	case OrphanSpendingThresholdModeAbsolute:
+		if currencyCode == OrphanSpendingThresholdAllCurrencies {
+			return OrphanSpendingThresholdInput{}, ErrOrphanThresholdAmountNeedsCurrency
+		}
+		if input.AmountMinor <= 0 {
+			return OrphanSpendingThresholdInput{}, ErrInvalidOrphanThresholdValue
+		}
+		normalized.AmountMinor = input.AmountMinor
+	case OrphanSpendingThresholdModeOff:
+	default:
+		return OrphanSpendingThresholdInput{}, ErrInvalidOrphanThresholdMode
+	}
+	return normalized, nil
+}
+
+// ResolveOrphanSpendingThreshold picks the rule for currencyCode: its own
+// rule, then the all-currencies rule, then the settings percentage.
+func ResolveOrphanSpendingThreshold(rules []OrphanSpendingThreshold, currencyCode string, settingsBPS int) OrphanSpendingThreshold {
+	var all *OrphanSpendingThreshold
+	for i := range rules {
+		switch rules[i].CurrencyCode {
+		case currencyCode:
+			resolved := rules[i]
+			resolved.Source = OrphanThresholdSourceCurrency
+			return resolved
+		case OrphanSpendingThresholdAllCurrencies:
+			all = &rules[i]
+		}
+	}
+	if all != nil {
+		resolved := *all
+		resolved.Source = OrphanThresholdSourceAll
+		return resolved
+	}
+
+	bps := int64(settingsBPS)
+	return OrphanSpendingThreshold{
+		CurrencyCode: OrphanSpendingThresholdAllCurrencies,
+		Mode:         OrphanSpendingThresholdModePercent,
+		PercentBPS:   &bps,
+		Source:       OrphanThresholdSourceSettings,
+	}
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidEntrySplit       = errors.New("invalid entry split")
+	ErrSplitRequiresPayer      = errors.New("shared entry requires recorded_by")
+	ErrSplitRequiresExpense    = errors.New("only expenses can be shared")
+	ErrSplitIncludesPayer      = errors.New("split names the payer")
+	ErrSplitExceedsTotal       = errors.New("split shares exceed 100%")
+	ErrInvalidSettlePerson     = errors.New("invalid settle person")
+	ErrInvalidSettlementAmount = errors.New("invalid settlement amount")
+	ErrNothingToSettle         = errors.New("nothing to settle")
+)
+
+// EntrySplit is one person's share of a shared expense. The payer is the
+// entry's recorded_by; each split person owes ShareBPS of the amount.
+// AmountMinor is derived from the entry's current amount.
+type EntrySplit struct {
+	Person      string `json:"person"`
+	ShareBPS    int64  `json:"share_bps"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// SharedExpenseShare is what Person owes Payer for one shared entry.
+type SharedExpenseShare struct {
+	EntryID      int64
+	Payer        string
+	Person       string
+	CurrencyCode string
+	AmountMinor  int64
+}
+
+type Settlement struct {
+	ID           int64  `json:"id"`
+	FromPerson   string `json:"from_person"`
+	ToPerson     string `json:"to_person"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	SettledAtUTC string `json:"settled_at_utc"`
+	Note         string `json:"note,omitempty"`
+	CreatedAtUTC string `json:"created_at_utc"`
+}
+
+type SettlementInput struct {
+	FromPerson   string
+	ToPerson     string
+	AmountMinor  int64
+	CurrencyCode string
+	SettledAtUTC string
+	Note         string
+}
+
+// SettleBalance is an open debt: From owes To AmountMinor in CurrencyCode.
+type SettleBalance struct {
+	From         string `json:"from"`
+	To           string `json:"to"`
+	CurrencyCode string `json:"currency_code"`
+	AmountMinor  int64  `json:"amount_minor"`
+}
+
+// ParseEntrySplit reads a `person:share` flag value. The share is a percentage
+// with up to two decimals and an optional trailing %, e.g. ana:50% or
+// ben:33.33.
+func ParseEntrySplit(raw string) (EntrySplit, error) {
+	separator := strings.LastIndex(raw, ":")
+	if separator < 0 {
+		return EntrySplit{}, ErrInvalidEntrySplit
+	}
+	person := NormalizeRecordedBy(raw[:separator])
+	if person == "" {
+		return EntrySplit{}, ErrInvalidEntrySplit
+	}
+
+	share := strings.TrimSuffix(strings.TrimSpace(raw[separator+1:]), "%")
+	whole, fraction, _ := strings.Cut(share, ".")
+	if whole == "" || len(fraction) > 2 {
+		return EntrySplit{}, ErrInvalidEntrySplit
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+	for _, digits := range []string{whole, fraction} {
+		for _, r := range digits {
+			if r < '0' || r > '9' {
+				return EntrySplit{}, ErrInvalidEntrySplit
+			}
+		}
+	}
+	bps, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || bps <= 0 || bps > 10000 {
+		return EntrySplit{}, ErrInvalidEntrySplit
+	}
+
+	return EntrySplit{Person: person, ShareBPS: bps}, nil
+}
+
+// NormalizeEntrySplits checks the splits of an entry paid by payer: every
+// person appears once, never the payer, and the shares add up to at most 100%.
+func NormalizeEntrySplits(payer string, splits []EntrySplit) ([]EntrySplit, error) {
+	if len(splits) == 0 {
+		return nil, nil
+	}
+	payer = NormalizeRecordedBy(payer)
+	if payer == "" {
+		return nil, ErrSplitRequiresPayer
+	}
+
+	seen := map[string]bool{}
+	total := int64(0)
+	normalized := make([]EntrySplit, 0, len(splits))
+	for _, split := range splits {
+		person := NormalizeRecordedBy(split.Person)
+		if person == "" || split.ShareBPS <= 0 || split.ShareBPS > 10000 {
+			return nil, ErrInvalidEntrySplit
+		}
+		key := strings.ToLower(person)
+		if key == strings.ToLower(payer) {
+			return nil, ErrSplitIncludesPayer
+		}
+		if seen[key] {
+			return nil, ErrInvalidEntrySplit
+		}
+		seen[key] = true
+		total += split.ShareBPS
+		normalized = append(normalized, EntrySplit{Person: person, ShareBPS: split.ShareBPS})
+	}
+	if total > 10000 {
+		return nil, ErrSplitExceedsTotal
+	}
+	return normalized, nil
+}
+
+// SplitShareMinor is a share of amountMinor, rounded half up.
+func SplitShareMinor(amountMinor, shareBPS int64) int64 {
+	return (amountMinor*shareBPS + 5000) / 10000
+}
+
+// ComputeSettleBalances nets shared-expense debts against settlements per pair
+// of people and currency. Names match case-insensitively and the first
+// spelling seen is reported. Settled pairs are left out.
+func ComputeSettleBalances(shares []SharedExpenseShare, settlements []Settlement) []SettleBalance {
+	type pairKey struct {
+		first, second string
+		currencyCode  string
+	}
+
+	names := map[string]string{}
+	remember := func(person string) string {
+		key := strings.ToLower(person)
+		if _, ok := names[key]; !ok {
+			names[key] = person
+		}
+		return key
+	}
+
+	// Positive totals mean first owes second.
+	totals := map[pairKey]int64{}
+	addDebt := func(debtor, creditor, currencyCode string, amountMinor int64) {
+		debtorKey, creditorKey := remember(debtor), remember(creditor)
+		if debtorKey == creditorKey {
+			return
+		}
+		if debtorKey < creditorKey {
+			totals[pairKey{debtorKey, creditorKey, currencyCode}] += amountMinor
+			return
+		}
+		totals[pairKey{creditorKey, debtorKey, currencyCode}] -= amountMinor
+	}
+
+	for _, share := range shares {
+		addDebt(share.Person, share.Payer, share.CurrencyCode, share.AmountMinor)
+	}
+	for _, settlement := range settlements {
+		addDebt(settlement.FromPerson, settlement.ToPerson, settlement.CurrencyCode, -settlement.AmountMinor)
+	}
+
+	balances := make([]SettleBalance, 0, len(totals))
+	for key, amountMinor := range totals {
+		switch {
+		case amountMinor > 0:
+			balances = append(balances, SettleBalance{From: names[key.first], To: names[key.second], CurrencyCode: key.currencyCode, AmountMinor: amountMinor})
+		case amountMinor < 0:
+			balances = append(balances, SettleBalance{From: names[key.second], To: names[key.first], CurrencyCode: key.currencyCode, AmountMinor: -amountMinor})
+		}
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].CurrencyCode != balances[j].CurrencyCode {
+			return balances[i].CurrencyCode < balances[j].CurrencyCode
+		}
+		if !strings.EqualFold(balances[i].From, balances[j].From) {
+			return strings.ToLower(balances[i].From) < strings.ToLower(balances[j].From)
+		}
+		return strings.ToLower(balances[i].To) < strings.ToLower(balances[j].To)
+	})
+	return balances
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseEntrySplit(t *testing.T) {
+	t.Parallel()
+
+	split, err := ParseEntrySplit(" Ana :33.5%")
+	if err != nil {
+		t.Fatalf("parse split: %v", err)
+	}
+	if split.Person != "Ana" || split.ShareBPS != 3350 {
+		t.Fatalf("expected Ana at 3350 bps, got %+v", split)
+	}
+
+	for _, raw := range []string{"ana", ":50", "ana:0", "ana:100.01", "ana:1.234", "ana:-5", "ana:x%"} {
+		if _, err := ParseEntrySplit(raw); !errors.Is(err, ErrInvalidEntrySplit) {
+			t.Fatalf("expected ErrInvalidEntrySplit for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestComputeSettleBalancesNetsPairsPerCurrency(t *testing.T) {
+	t.Parallel()
+
+	balances := ComputeSettleBalances(
+		[]SharedExpenseShare{
+			{Payer: "Ben", Person: "Ana", CurrencyCode: "USD", AmountMinor: 3000},
+			{Payer: "ana", Person: "ben", CurrencyCode: "USD", AmountMinor: 1000},
+			{Payer: "Ben", Person: "Ana", CurrencyCode: "EUR", AmountMinor: 500},
+		},
+		[]Settlement{{FromPerson: "ANA", ToPerson: "Ben", CurrencyCode: "EUR", AmountMinor: 500}},
+	)
+
+	if len(balances) != 1 {
+		t.Fatalf("expected the settled EUR pair to drop out, got %+v", balances)
+	}
+	if got := balances[0]; got.From != "Ana" || got.To != "Ben" || got.CurrencyCode != "USD" || got.AmountMinor != 2000 {
+		t.Fatalf("expected Ana to owe Ben 2000 USD, got %+v", got)
+	}
+}
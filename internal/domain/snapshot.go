@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// SafetySnapshotDirName is the directory next to the database that
+	// automatic pre-mutation snapshots are written to.
+	SafetySnapshotDirName = "snapshots"
+	// DefaultSafetySnapshotKeep is how many automatic snapshots are kept;
+	// older ones are removed when a new one is taken.
+	DefaultSafetySnapshotKeep = 10
+
+	safetySnapshotExt        = ".sqlite"
+	safetySnapshotTimeLayout = "20060102T150405.000000000Z"
+)
+
+// SafetySnapshot is a copy of the database taken right before a risky
+// command changed it. Restoring Path with `data restore --file` undoes the
+// command.
+type SafetySnapshot struct {
+	Path         string   `json:"path"`
+	Reason       string   `json:"reason"`
+	CreatedAtUTC string   `json:"created_at_utc"`
+	Pruned       []string `json:"pruned,omitempty"`
+}
+
+// SafetySnapshotFileName names a snapshot so names sort by creation time,
+// e.g. 20261016T101500.000000000Z-data-import.sqlite.
+func SafetySnapshotFileName(at time.Time, reason string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(reason))
+	if slug == "" {
+		slug = "snapshot"
+	}
+	return at.UTC().Format(safetySnapshotTimeLayout) + "-" + slug + safetySnapshotExt
+}
+
+// IsSafetySnapshotFileName reports whether name was produced by
+// SafetySnapshotFileName, so pruning leaves other files in the directory
+// alone.
+func IsSafetySnapshotFileName(name string) bool {
+	if !strings.HasSuffix(name, safetySnapshotExt) || len(name) <= len(safetySnapshotTimeLayout) {
+		return false
+	}
+	_, err := time.Parse(safetySnapshotTimeLayout, name[:len(safetySnapshotTimeLayout)])
+	return err == nil
+}
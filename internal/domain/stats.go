@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const StatsLargestExpenseLimit = 10
+
+// EntryStats is a quick statistical summary of a set of entries.
+type EntryStats struct {
+	Currencies []CurrencyStats      `json:"currencies"`
+	Categories []CategoryEntryCount `json:"categories"`
+}
+
+// CurrencyStats summarizes spending in one currency. SpendMinor and the
+// weekday totals are net of refunds; expense sizes and the largest expenses
+// ignore refunds.
+type CurrencyStats struct {
+	CurrencyCode             string  `json:"currency_code"`
+	MonthCount               int     `json:"month_count"`
+	SpendMinor               int64   `json:"spend_minor"`
+	AverageMonthlySpendMinor int64   `json:"average_monthly_spend_minor"`
+	ExpenseCount             int     `json:"expense_count"`
+	MedianExpenseMinor       int64   `json:"median_expense_minor"`
+	BusiestWeekday           string  `json:"busiest_weekday,omitempty"`
+	BusiestWeekdaySpendMinor int64   `json:"busiest_weekday_spend_minor"`
+	LargestExpenses          []Entry `json:"largest_expenses"`
+}
+
+// CategoryEntryCount counts entries of any type per category; a nil
+// CategoryID counts uncategorized entries.
+type CategoryEntryCount struct {
+	CategoryID *int64 `json:"category_id"`
+	EntryCount int    `json:"entry_count"`
+}
+
+// ComputeEntryStats summarizes entries between fromUTC and toUTC. Average
+// monthly spend divides by every calendar month the range touches; when a
+// bound is empty the first or last entry's month is used instead.
+func ComputeEntryStats(entries []Entry, fromUTC, toUTC string) (EntryStats, error) {
+	type currencyState struct {
+		stats    CurrencyStats
+		sizes    []int64
+		expenses []Entry
+		weekdays [7]int64
+	}
+
+	var firstDate, lastDate time.Time
+	states := map[string]*currencyState{}
+	categoryCounts := map[int64]int{}
+	uncategorized := 0
+
+	for _, entry := range entries {
+		transactionDate, err := parseTimestampUTC(entry.TransactionDateUTC)
+		if err != nil {
+			return EntryStats{}, ErrInvalidTransactionDate
+		}
+		if firstDate.IsZero() || transactionDate.Before(firstDate) {
+			firstDate = transactionDate
+		}
+		if transactionDate.After(lastDate) {
+			lastDate = transactionDate
+		}
+
+		if entry.CategoryID == nil {
+			uncategorized++
+		} else {
+			categoryCounts[*entry.CategoryID]++
+		}
+
+		if entry.Type != EntryTypeExpense {
+			continue
+		}
+		state, ok := states[entry.CurrencyCode]
+		if !ok {
+			state = &currencyState{stats: CurrencyStats{CurrencyCode: entry.CurrencyCode}}
+			states[entry.CurrencyCode] = state
+		}
+		state.stats.SpendMinor += entry.EffectiveAmountMinor()
+		state.weekdays[transactionDate.Weekday()] += entry.EffectiveAmountMinor()
+		if !entry.IsRefund() {
+			state.sizes = append(state.sizes, entry.AmountMinor)
+			state.expenses = append(state.expenses, entry)
+		}
+	}
+
+	if fromUTC != "" {
+		parsed, err := parseTimestampUTC(fromUTC)
+		if err != nil {
+			return EntryStats{}, ErrInvalidDateRange
+		}
+		firstDate = parsed
+	}
+	if toUTC != "" {
+		parsed, err := parseTimestampUTC(toUTC)
+		if err != nil {
+			return EntryStats{}, ErrInvalidDateRange
+		}
+		lastDate = parsed
+	}
+	monthCount := 0
+	if !firstDate.IsZero() && !lastDate.IsZero() && !lastDate.Before(firstDate) {
+		monthCount = (lastDate.Year()-firstDate.Year())*12 + int(lastDate.Month()-firstDate.Month()) + 1
+	}
+
+	currencyCodes := make([]string, 0, len(states))
+	for code := range states {
+		currencyCodes = append(currencyCodes, code)
+	}
+	sort.Strings(currencyCodes)
+
+	result := EntryStats{
+		Currencies: make([]CurrencyStats, 0, len(currencyCodes)),
+		Categories: make([]CategoryEntryCount, 0, len(categoryCounts)+1),
+	}
+	for _, code := range currencyCodes {
+		state := states[code]
+		stats := state.stats
+		stats.MonthCount = monthCount
+		if monthCount > 0 {
+			stats.AverageMonthlySpendMinor = stats.SpendMinor / int64(monthCount)
+		}
+
+		stats.ExpenseCount = len(state.sizes)
+		sort.Slice(state.sizes, func(i, j int) bool { return state.sizes[i] < state.sizes[j] })
+		if middle := len(state.sizes) / 2; len(state.sizes)%2 == 1 {
+			stats.MedianExpenseMinor = state.sizes[middle]
+		} else if len(state.sizes) > 0 {
+			stats.MedianExpenseMinor = (state.sizes[middle-1] + state.sizes[middle]) / 2
+		}
+
+		for weekday, spendMinor := range state.weekdays {
+			if spendMinor > stats.BusiestWeekdaySpendMinor {
+				stats.BusiestWeekday = time.Weekday(weekday).String()
+				stats.BusiestWeekdaySpendMinor = spendMinor
+			}
+		}
+
+		sort.SliceStable(state.expenses, func(i, j int) bool {
+			if state.expenses[i].AmountMinor != state.expenses[j].AmountMinor {
+				return state.expenses[i].AmountMinor > state.expenses[j].AmountMinor
+			}
+			if state.expenses[i].TransactionDateUTC != state.expenses[j].TransactionDateUTC {
+				return state.expenses[i].TransactionDateUTC > state.expenses[j].TransactionDateUTC
+			}
+			return state.expenses[i].ID < state.expenses[j].ID
+		})
+		if len(state.expenses) > StatsLargestExpenseLimit {
+			state.expenses = state.expenses[:StatsLargestExpenseLimit]
+		}
+		stats.LargestExpenses = append([]Entry{}, state.expenses...)
+
+		result.Currencies = append(result.Currencies, stats)
+	}
+
+	if uncategorized > 0 {
+		result.Categories = append(result.Categories, CategoryEntryCount{EntryCount: uncategorized})
+	}
+	categoryIDs := make([]int64, 0, len(categoryCounts))
+	for id := range categoryCounts {
+		categoryIDs = append(categoryIDs, id)
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i] < categoryIDs[j] })
+	for _, id := range categoryIDs {
+		categoryID := id
+		result.Categories = append(result.Categories, CategoryEntryCount{CategoryID: &categoryID, EntryCount: categoryCounts[id]})
+	}
+
+	return result, nil
+}
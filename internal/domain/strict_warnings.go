@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// StrictWarningsAll selects every warning code in StrictableWarningCodes.
+const StrictWarningsAll = "all"
+
+// StrictableWarningCodes lists the warnings that a strict-warnings policy can
+// turn into failures.
+var StrictableWarningCodes = []string{
+	WarningCodeCapExceeded,
+	WarningCodeCategoryCapExceeded,
+	WarningCodeCardLimitExceeded,
+	WarningCodeFXEstimateUsed,
+}
+
+var ErrInvalidStrictWarningCode = errors.New("invalid strict warning code")
+
+// StrictWarningError is returned when a write raised a warning the
+// strict-warnings policy escalates; the write is rolled back.
+type StrictWarningError struct {
+	Warnings []Warning
+}
+
+func (e *StrictWarningError) Error() string {
+	codes := make([]string, 0, len(e.Warnings))
+	for _, warning := range e.Warnings {
+		codes = append(codes, warning.Code)
+	}
+	return "strict warnings: " + strings.Join(codes, ", ")
+}
+
+// NormalizeStrictWarningCodes upper-cases, de-duplicates and sorts codes.
+// "all" expands to every strictable code.
+func NormalizeStrictWarningCodes(codes []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, raw := range codes {
+		code := strings.ToUpper(strings.TrimSpace(raw))
+		switch {
+		case code == "":
+			continue
+		case strings.EqualFold(code, StrictWarningsAll):
+			for _, strictable := range StrictableWarningCodes {
+				seen[strictable] = true
+			}
+		case isStrictableWarningCode(code):
+			seen[code] = true
+		default:
+			return nil, ErrInvalidStrictWarningCode
+		}
+	}
+
+	normalized := make([]string, 0, len(seen))
+	for code := range seen {
+		normalized = append(normalized, code)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// FilterStrictWarnings returns the warnings whose code is in codes.
+func FilterStrictWarnings(warnings []Warning, codes []string) []Warning {
+	if len(codes) == 0 {
+		return nil
+	}
+	strict := []Warning{}
+	for _, warning := range warnings {
+		for _, code := range codes {
+			if warning.Code == code {
+				strict = append(strict, warning)
+				break
+			}
+		}
+	}
+	return strict
+}
+
+func isStrictableWarningCode(code string) bool {
+	for _, strictable := range StrictableWarningCodes {
+		if code == strictable {
+			return true
+		}
+	}
+	return false
+}
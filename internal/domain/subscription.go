@@ -0,0 +1,485 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSubscriptionMinOccurrences = errors.New("invalid subscription min occurrences")
+	ErrInvalidSubscriptionTolerance      = errors.New("invalid subscription tolerance")
+)
+
+const (
+	SubscriptionIntervalWeekly    = "weekly"
+	SubscriptionIntervalMonthly   = "monthly"
+	SubscriptionIntervalQuarterly = "quarterly"
+	SubscriptionIntervalYearly    = "yearly"
+
+	DefaultSubscriptionMinOccurrences   = 3
+	DefaultSubscriptionTolerancePercent = 10
+
+	WarningCodeSubscriptionPriceChanged    = "SUBSCRIPTION_PRICE_CHANGED"
+	SubscriptionPriceChangedWarningMessage = "Expense saved, recurring charge amount changed."
+)
+
+// subscriptionInterval is the gap window a cadence accepts between charges.
+type subscriptionInterval struct {
+	name        string
+	minDays     float64
+	maxDays     float64
+	nominalDays float64
+	perYear     int64
+}
+
+var subscriptionIntervals = []subscriptionInterval{
+	{name: SubscriptionIntervalWeekly, minDays: 5, maxDays: 9, nominalDays: 7, perYear: 52},
+	{name: SubscriptionIntervalMonthly, minDays: 25, maxDays: 36, nominalDays: 30.44, perYear: 12},
+	{name: SubscriptionIntervalQuarterly, minDays: 80, maxDays: 100, nominalDays: 91.31, perYear: 4},
+	{name: SubscriptionIntervalYearly, minDays: 350, maxDays: 380, nominalDays: 365.25, perYear: 1},
+}
+
+// SubscriptionScheduleProposal is a monthly schedule that would record the
+// charge going forward; it mirrors schedule add.
+type SubscriptionScheduleProposal struct {
+	Name          string `json:"name"`
+	AmountMinor   int64  `json:"amount_minor"`
+	CurrencyCode  string `json:"currency_code"`
+	DayOfMonth    int    `json:"day_of_month"`
+	StartMonthKey string `json:"start_month_key"`
+	CategoryID    *int64 `json:"category_id,omitempty"`
+}
+
+// SubscriptionCandidate is a payee (or note, for entries without one) charged
+// a similar amount at a regular interval. AmountMinor is the latest charge.
+type SubscriptionCandidate struct {
+	Key              string                        `json:"key"`
+	Name             string                        `json:"name"`
+	CurrencyCode     string                        `json:"currency_code"`
+	Interval         string                        `json:"interval"`
+	MedianGapDays    int64                         `json:"median_gap_days"`
+	Occurrences      int                           `json:"occurrences"`
+	AmountMinor      int64                         `json:"amount_minor"`
+	AnnualizedMinor  int64                         `json:"annualized_minor"`
+	FirstDate        string                        `json:"first_date"`
+	LastDate         string                        `json:"last_date"`
+	NextExpectedDate string                        `json:"next_expected_date"`
+	Active           bool                          `json:"active"`
+	CategoryID       *int64                        `json:"category_id,omitempty"`
+	ScheduleID       *int64                        `json:"schedule_id,omitempty"`
+	EntryIDs         []int64                       `json:"entry_ids"`
+	ProposedSchedule *SubscriptionScheduleProposal `json:"proposed_schedule,omitempty"`
+}
+
+type SubscriptionDetectOptions struct {
+	AsOf             time.Time
+	MinOccurrences   int
+	TolerancePercent int
+	IncludeInactive  bool
+}
+
+type SubscriptionDetection struct {
+	AsOfDate         string                  `json:"as_of_date"`
+	Candidates       []SubscriptionCandidate `json:"candidates"`
+	AnnualizedTotals []CurrencyTotal         `json:"annualized_totals"`
+}
+
+// SubscriptionPriceChange is a recurring charge that arrived on cadence with a
+// different amount than the occurrence before it.
+type SubscriptionPriceChange struct {
+	Key                   string `json:"key"`
+	Name                  string `json:"name"`
+	CurrencyCode          string `json:"currency_code"`
+	Interval              string `json:"interval"`
+	EntryID               int64  `json:"entry_id"`
+	Date                  string `json:"date"`
+	PreviousEntryID       int64  `json:"previous_entry_id"`
+	PreviousDate          string `json:"previous_date"`
+	PreviousAmountMinor   int64  `json:"previous_amount_minor"`
+	AmountMinor           int64  `json:"amount_minor"`
+	ChangeMinor           int64  `json:"change_minor"`
+	AnnualizedChangeMinor int64  `json:"annualized_change_minor"`
+}
+
+type SubscriptionPriceChangedWarningDetails struct {
+	EntryID         int64       `json:"entry_id"`
+	Name            string      `json:"name"`
+	Interval        string      `json:"interval"`
+	PreviousEntryID int64       `json:"previous_entry_id"`
+	PreviousDate    string      `json:"previous_date"`
+	PreviousAmount  MoneyAmount `json:"previous_amount"`
+	NewAmount       MoneyAmount `json:"new_amount"`
+	ChangeAmount    MoneyAmount `json:"change_amount"`
+}
+
+func NormalizeSubscriptionDetectOptions(opts SubscriptionDetectOptions) (SubscriptionDetectOptions, error) {
+	if opts.MinOccurrences == 0 {
+		opts.MinOccurrences = DefaultSubscriptionMinOccurrences
+	}
+	if opts.MinOccurrences < 2 {
+		return SubscriptionDetectOptions{}, ErrInvalidSubscriptionMinOccurrences
+	}
+	if opts.TolerancePercent < 0 || opts.TolerancePercent > 100 {
+		return SubscriptionDetectOptions{}, ErrInvalidSubscriptionTolerance
+	}
+	if opts.AsOf.IsZero() {
+		opts.AsOf = time.Now().UTC()
+	}
+	return opts, nil
+}
+
+type subscriptionCharge struct {
+	entry Entry
+	at    time.Time
+}
+
+// DetectSubscriptions groups expenses by payee (falling back to the note) and
+// currency, keeps the charges within tolerance of the group's median amount,
+// and reports the groups whose gaps settle on one cadence: at least three in
+// four gaps must fall in that cadence's window. Refunds are ignored. A
+// candidate is active while its last charge is no later than expected plus
+// half an interval. Candidates that match an active schedule by name or note
+// carry its ID; monthly ones without a schedule get a proposal.
+func DetectSubscriptions(entries []Entry, schedules []ScheduledPayment, opts SubscriptionDetectOptions) SubscriptionDetection {
+	type groupKey struct {
+		key      string
+		currency string
+	}
+	groups := map[groupKey][]subscriptionCharge{}
+	for _, entry := range entries {
+		if entry.Type != EntryTypeExpense || entry.IsRefund() {
+			continue
+		}
+		key := subscriptionKey(entry)
+		if key == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339Nano, entry.TransactionDateUTC)
+		if err != nil {
+			continue
+		}
+		gk := groupKey{key: key, currency: entry.CurrencyCode}
+		groups[gk] = append(groups[gk], subscriptionCharge{entry: entry, at: at.UTC()})
+	}
+
+	detection := SubscriptionDetection{
+		AsOfDate:         opts.AsOf.Format("2006-01-02"),
+		Candidates:       []SubscriptionCandidate{},
+		AnnualizedTotals: []CurrencyTotal{},
+	}
+	annualized := map[string]int64{}
+	for gk, charges := range groups {
+		candidate, ok := detectSubscription(gk.key, charges, opts)
+		if !ok || (!candidate.Active && !opts.IncludeInactive) {
+			continue
+		}
+		if schedule, ok := matchSubscriptionSchedule(candidate, schedules, opts.AsOf); ok {
+			id := schedule.ID
+			candidate.ScheduleID = &id
+		} else if candidate.Interval == SubscriptionIntervalMonthly && candidate.Active {
+			candidate.ProposedSchedule = proposeSubscriptionSchedule(candidate)
+		}
+		if candidate.Active {
+			annualized[candidate.CurrencyCode] += candidate.AnnualizedMinor
+		}
+		detection.Candidates = append(detection.Candidates, candidate)
+	}
+
+	sort.Slice(detection.Candidates, func(i, j int) bool {
+		left, right := detection.Candidates[i], detection.Candidates[j]
+		if left.CurrencyCode != right.CurrencyCode {
+			return left.CurrencyCode < right.CurrencyCode
+		}
+		if left.AnnualizedMinor != right.AnnualizedMinor {
+			return left.AnnualizedMinor > right.AnnualizedMinor
+		}
+		return left.Key < right.Key
+	})
+	currencies := make([]string, 0, len(annualized))
+	for currency := range annualized {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		detection.AnnualizedTotals = append(detection.AnnualizedTotals, CurrencyTotal{CurrencyCode: currency, TotalMinor: annualized[currency]})
+	}
+
+	return detection
+}
+
+// SubscriptionPriceChangeFor reports whether entry is the next occurrence of a
+// recurring charge found in history with a different amount. history must
+// qualify on its own (see DetectSubscriptions); entry must share its key and
+// currency and follow the last occurrence within the cadence window. Entries
+// dated after entry, or entry itself, are ignored.
+func SubscriptionPriceChangeFor(history []Entry, entry Entry, opts SubscriptionDetectOptions) (SubscriptionPriceChange, bool) {
+	if entry.Type != EntryTypeExpense || entry.IsRefund() {
+		return SubscriptionPriceChange{}, false
+	}
+	key := subscriptionKey(entry)
+	at, err := time.Parse(time.RFC3339Nano, entry.TransactionDateUTC)
+	if key == "" || err != nil {
+		return SubscriptionPriceChange{}, false
+	}
+	at = at.UTC()
+
+	charges := []subscriptionCharge{}
+	for _, previous := range history {
+		if previous.ID == entry.ID || previous.Type != EntryTypeExpense || previous.IsRefund() ||
+			previous.CurrencyCode != entry.CurrencyCode || subscriptionKey(previous) != key {
+			continue
+		}
+		previousAt, err := time.Parse(time.RFC3339Nano, previous.TransactionDateUTC)
+		if err != nil {
+			continue
+		}
+		previousAt = previousAt.UTC()
+		if previousAt.After(at) || (previousAt.Equal(at) && previous.ID > entry.ID) {
+			continue
+		}
+		charges = append(charges, subscriptionCharge{entry: previous, at: previousAt})
+	}
+
+	opts.AsOf = at
+	candidate, ok := detectSubscription(key, charges, opts)
+	if !ok || candidate.AmountMinor == entry.AmountMinor {
+		return SubscriptionPriceChange{}, false
+	}
+	interval := subscriptionIntervalNamed(candidate.Interval)
+	lastAt, err := time.Parse("2006-01-02", candidate.LastDate)
+	if err != nil {
+		return SubscriptionPriceChange{}, false
+	}
+	gap := at.Sub(lastAt).Hours() / 24
+	if gap < interval.minDays || gap > interval.maxDays+1 {
+		return SubscriptionPriceChange{}, false
+	}
+
+	name := strings.TrimSpace(entry.Payee)
+	if name == "" {
+		name = strings.TrimSpace(entry.Note)
+	}
+	change := entry.AmountMinor - candidate.AmountMinor
+	return SubscriptionPriceChange{
+		Key:                   key,
+		Name:                  name,
+		CurrencyCode:          entry.CurrencyCode,
+		Interval:              candidate.Interval,
+		EntryID:               entry.ID,
+		Date:                  at.Format("2006-01-02"),
+		PreviousEntryID:       candidate.EntryIDs[len(candidate.EntryIDs)-1],
+		PreviousDate:          candidate.LastDate,
+		PreviousAmountMinor:   candidate.AmountMinor,
+		AmountMinor:           entry.AmountMinor,
+		ChangeMinor:           change,
+		AnnualizedChangeMinor: change * interval.perYear,
+	}, true
+}
+
+// SubscriptionPriceChanges replays every expense against the charges before
+// it and returns the price changes in date order.
+func SubscriptionPriceChanges(entries []Entry, opts SubscriptionDetectOptions) []SubscriptionPriceChange {
+	groups := map[string][]Entry{}
+	for _, entry := range entries {
+		if entry.Type != EntryTypeExpense || entry.IsRefund() {
+			continue
+		}
+		key := subscriptionKey(entry)
+		if key == "" {
+			continue
+		}
+		groupKey := entry.CurrencyCode + "|" + key
+		groups[groupKey] = append(groups[groupKey], entry)
+	}
+
+	changes := []SubscriptionPriceChange{}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].TransactionDateUTC != group[j].TransactionDateUTC {
+				return group[i].TransactionDateUTC < group[j].TransactionDateUTC
+			}
+			return group[i].ID < group[j].ID
+		})
+		for i := opts.MinOccurrences; i < len(group); i++ {
+			if change, ok := SubscriptionPriceChangeFor(group[:i], group[i], opts); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Date != changes[j].Date {
+			return changes[i].Date < changes[j].Date
+		}
+		return changes[i].EntryID < changes[j].EntryID
+	})
+	return changes
+}
+
+func subscriptionKey(entry Entry) string {
+	if payee := strings.ToLower(strings.TrimSpace(entry.Payee)); payee != "" {
+		return payee
+	}
+	return strings.ToLower(strings.TrimSpace(entry.Note))
+}
+
+func detectSubscription(key string, charges []subscriptionCharge, opts SubscriptionDetectOptions) (SubscriptionCandidate, bool) {
+	if len(charges) < opts.MinOccurrences {
+		return SubscriptionCandidate{}, false
+	}
+
+	amounts := make([]int64, 0, len(charges))
+	for _, charge := range charges {
+		amounts = append(amounts, charge.entry.AmountMinor)
+	}
+	median := medianInt64(amounts)
+	kept := make([]subscriptionCharge, 0, len(charges))
+	for _, charge := range charges {
+		diff := charge.entry.AmountMinor - median
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff*100 <= median*int64(opts.TolerancePercent) {
+			kept = append(kept, charge)
+		}
+	}
+	if len(kept) < opts.MinOccurrences {
+		return SubscriptionCandidate{}, false
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if !kept[i].at.Equal(kept[j].at) {
+			return kept[i].at.Before(kept[j].at)
+		}
+		return kept[i].entry.ID < kept[j].entry.ID
+	})
+
+	gaps := make([]float64, 0, len(kept)-1)
+	for i := 1; i < len(kept); i++ {
+		gaps = append(gaps, kept[i].at.Sub(kept[i-1].at).Hours()/24)
+	}
+	medianGap := medianFloat64(gaps)
+	interval, ok := subscriptionIntervalFor(medianGap)
+	if !ok {
+		return SubscriptionCandidate{}, false
+	}
+	regular := 0
+	for _, gap := range gaps {
+		if gap >= interval.minDays && gap <= interval.maxDays {
+			regular++
+		}
+	}
+	if regular*4 < len(gaps)*3 {
+		return SubscriptionCandidate{}, false
+	}
+
+	first, last := kept[0], kept[len(kept)-1]
+	nextExpected := last.at.Add(time.Duration(math.Round(interval.nominalDays*24)) * time.Hour)
+	activeUntil := nextExpected.Add(time.Duration(math.Round(interval.nominalDays*12)) * time.Hour)
+	entryIDs := make([]int64, 0, len(kept))
+	for _, charge := range kept {
+		entryIDs = append(entryIDs, charge.entry.ID)
+	}
+	name := strings.TrimSpace(last.entry.Payee)
+	if name == "" {
+		name = strings.TrimSpace(last.entry.Note)
+	}
+
+	return SubscriptionCandidate{
+		Key:              key,
+		Name:             name,
+		CurrencyCode:     last.entry.CurrencyCode,
+		Interval:         interval.name,
+		MedianGapDays:    int64(math.Round(medianGap)),
+		Occurrences:      len(kept),
+		AmountMinor:      last.entry.AmountMinor,
+		AnnualizedMinor:  last.entry.AmountMinor * interval.perYear,
+		FirstDate:        first.at.Format("2006-01-02"),
+		LastDate:         last.at.Format("2006-01-02"),
+		NextExpectedDate: nextExpected.Format("2006-01-02"),
+		Active:           !opts.AsOf.After(activeUntil),
+		CategoryID:       last.entry.CategoryID,
+		EntryIDs:         entryIDs,
+	}, true
+}
+
+func subscriptionIntervalFor(gapDays float64) (subscriptionInterval, bool) {
+	for _, interval := range subscriptionIntervals {
+		if gapDays >= interval.minDays && gapDays <= interval.maxDays {
+			return interval, true
+		}
+	}
+	return subscriptionInterval{}, false
+}
+
+func subscriptionIntervalNamed(name string) subscriptionInterval {
+	for _, interval := range subscriptionIntervals {
+		if interval.name == name {
+			return interval
+		}
+	}
+	return subscriptionInterval{}
+}
+
+func matchSubscriptionSchedule(candidate SubscriptionCandidate, schedules []ScheduledPayment, asOf time.Time) (ScheduledPayment, bool) {
+	monthKey := asOf.Format("2006-01")
+	for _, schedule := range schedules {
+		if schedule.DeletedAtUTC != nil || schedule.CurrencyCode != candidate.CurrencyCode {
+			continue
+		}
+		if schedule.EndMonthKey != nil && *schedule.EndMonthKey < monthKey {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(schedule.Name))
+		note := strings.ToLower(strings.TrimSpace(schedule.Note))
+		if name == candidate.Key || note == candidate.Key {
+			return schedule, true
+		}
+	}
+	return ScheduledPayment{}, false
+}
+
+func proposeSubscriptionSchedule(candidate SubscriptionCandidate) *SubscriptionScheduleProposal {
+	last, err := time.Parse("2006-01-02", candidate.LastDate)
+	if err != nil {
+		return nil
+	}
+	next, err := time.Parse("2006-01-02", candidate.NextExpectedDate)
+	if err != nil {
+		return nil
+	}
+	day := last.Day()
+	if day > 28 {
+		day = 28
+	}
+	return &SubscriptionScheduleProposal{
+		Name:          candidate.Name,
+		AmountMinor:   candidate.AmountMinor,
+		CurrencyCode:  candidate.CurrencyCode,
+		DayOfMonth:    day,
+		StartMonthKey: next.Format("2006-01"),
+		CategoryID:    candidate.CategoryID,
+	}
+}
+
+func medianInt64(values []int64) int64 {
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func medianFloat64(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
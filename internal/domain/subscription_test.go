@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectSubscriptionsFindsRegularCharges(t *testing.T) {
+	t.Parallel()
+
+	categoryID := int64(4)
+	entries := []Entry{
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-01-05T00:00:00Z", Payee: "Netflix"},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-02-05T00:00:00Z", Payee: "netflix "},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-03-06T00:00:00Z", Payee: "Netflix", CategoryID: &categoryID},
+		{ID: 4, Type: EntryTypeExpense, AmountMinor: 1599, CurrencyCode: "USD", TransactionDateUTC: "2026-04-05T00:00:00Z", Payee: "Netflix", CategoryID: &categoryID},
+		{ID: 5, Type: EntryTypeExpense, AmountMinor: 8000, CurrencyCode: "USD", TransactionDateUTC: "2026-03-20T00:00:00Z", Payee: "Netflix"},
+		{ID: 6, Type: EntryTypeExpense, AmountMinor: 450, CurrencyCode: "USD", TransactionDateUTC: "2026-01-02T00:00:00Z", Payee: "Cafe"},
+		{ID: 7, Type: EntryTypeExpense, AmountMinor: 450, CurrencyCode: "USD", TransactionDateUTC: "2026-01-03T00:00:00Z", Payee: "Cafe"},
+		{ID: 8, Type: EntryTypeExpense, AmountMinor: 450, CurrencyCode: "USD", TransactionDateUTC: "2026-02-20T00:00:00Z", Payee: "Cafe"},
+	}
+
+	detection := DetectSubscriptions(entries, nil, SubscriptionDetectOptions{
+		AsOf:             time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC),
+		MinOccurrences:   3,
+		TolerancePercent: 10,
+	})
+
+	if len(detection.Candidates) != 1 {
+		t.Fatalf("expected only the monthly charge, got %+v", detection.Candidates)
+	}
+	netflix := detection.Candidates[0]
+	if netflix.Key != "netflix" || netflix.Interval != SubscriptionIntervalMonthly || netflix.Occurrences != 4 || !netflix.Active {
+		t.Fatalf("unexpected candidate: %+v", netflix)
+	}
+	if netflix.AmountMinor != 1599 || netflix.AnnualizedMinor != 1599*12 || netflix.NextExpectedDate != "2026-05-05" {
+		t.Fatalf("expected latest amount annualized, got %+v", netflix)
+	}
+	proposal := netflix.ProposedSchedule
+	if proposal == nil || proposal.DayOfMonth != 5 || proposal.StartMonthKey != "2026-05" || proposal.CategoryID == nil || *proposal.CategoryID != categoryID {
+		t.Fatalf("unexpected schedule proposal: %+v", proposal)
+	}
+	if len(detection.AnnualizedTotals) != 1 || detection.AnnualizedTotals[0].TotalMinor != 1599*12 {
+		t.Fatalf("unexpected annualized totals: %+v", detection.AnnualizedTotals)
+	}
+}
+
+func TestDetectSubscriptionsMarksLapsedAndScheduled(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry{
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "EUR", TransactionDateUTC: "2025-01-10T00:00:00Z", Note: "Gym"},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "EUR", TransactionDateUTC: "2025-02-10T00:00:00Z", Note: "Gym"},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "EUR", TransactionDateUTC: "2025-03-10T00:00:00Z", Note: "Gym"},
+		{ID: 4, Type: EntryTypeExpense, AmountMinor: 2500, CurrencyCode: "EUR", TransactionDateUTC: "2026-01-01T00:00:00Z", Payee: "Rent"},
+		{ID: 5, Type: EntryTypeExpense, AmountMinor: 2500, CurrencyCode: "EUR", TransactionDateUTC: "2026-02-01T00:00:00Z", Payee: "Rent"},
+		{ID: 6, Type: EntryTypeExpense, AmountMinor: 2500, CurrencyCode: "EUR", TransactionDateUTC: "2026-03-01T00:00:00Z", Payee: "Rent"},
+	}
+	schedules := []ScheduledPayment{{ID: 9, Name: "rent", CurrencyCode: "EUR"}}
+	opts := SubscriptionDetectOptions{AsOf: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), MinOccurrences: 3, TolerancePercent: 10}
+
+	active := DetectSubscriptions(entries, schedules, opts)
+	if len(active.Candidates) != 1 || active.Candidates[0].ScheduleID == nil || *active.Candidates[0].ScheduleID != 9 || active.Candidates[0].ProposedSchedule != nil {
+		t.Fatalf("expected only the scheduled rent, got %+v", active.Candidates)
+	}
+
+	opts.IncludeInactive = true
+	all := DetectSubscriptions(entries, schedules, opts)
+	if len(all.Candidates) != 2 {
+		t.Fatalf("expected lapsed gym with --include-inactive, got %+v", all.Candidates)
+	}
+	gym := all.Candidates[1]
+	if gym.Key != "gym" || gym.Active || gym.ProposedSchedule != nil {
+		t.Fatalf("unexpected lapsed candidate: %+v", gym)
+	}
+	if len(all.AnnualizedTotals) != 1 || all.AnnualizedTotals[0].TotalMinor != 2500*12 {
+		t.Fatalf("expected lapsed charges left out of totals, got %+v", all.AnnualizedTotals)
+	}
+}
+
+func TestSubscriptionPriceChangeForComparesWithLastCharge(t *testing.T) {
+	t.Parallel()
+
+	history := []Entry{
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-01-05T00:00:00Z", Payee: "Netflix"},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-02-05T00:00:00Z", Payee: "Netflix"},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 1549, CurrencyCode: "USD", TransactionDateUTC: "2026-03-05T00:00:00Z", Payee: "netflix"},
+	}
+	opts := SubscriptionDetectOptions{MinOccurrences: 3, TolerancePercent: 10}
+
+	raised := Entry{ID: 4, Type: EntryTypeExpense, AmountMinor: 1699, CurrencyCode: "USD", TransactionDateUTC: "2026-04-05T00:00:00Z", Payee: "Netflix"}
+	change, ok := SubscriptionPriceChangeFor(history, raised, opts)
+	if !ok {
+		t.Fatalf("expected a price change for %+v", raised)
+	}
+	if change.PreviousEntryID != 3 || change.PreviousAmountMinor != 1549 || change.ChangeMinor != 150 || change.AnnualizedChangeMinor != 1800 || change.Interval != SubscriptionIntervalMonthly {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+
+	same := raised
+	same.AmountMinor = 1549
+	if _, ok := SubscriptionPriceChangeFor(history, same, opts); ok {
+		t.Fatalf("expected no change for an unchanged amount")
+	}
+	offCadence := raised
+	offCadence.TransactionDateUTC = "2026-03-20T00:00:00Z"
+	if _, ok := SubscriptionPriceChangeFor(history, offCadence, opts); ok {
+		t.Fatalf("expected no change for a charge outside the cadence")
+	}
+	if _, ok := SubscriptionPriceChangeFor(history[:2], raised, opts); ok {
+		t.Fatalf("expected no change before the pattern is established")
+	}
+}
+
+func TestSubscriptionPriceChangesReplaysHistory(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry{
+		{ID: 5, Type: EntryTypeExpense, AmountMinor: 1099, CurrencyCode: "USD", TransactionDateUTC: "2026-05-10T00:00:00Z", Note: "Music"},
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "USD", TransactionDateUTC: "2026-01-10T00:00:00Z", Note: "Music"},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "USD", TransactionDateUTC: "2026-02-10T00:00:00Z", Note: "Music"},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "USD", TransactionDateUTC: "2026-03-10T00:00:00Z", Note: "Music"},
+		{ID: 4, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "USD", TransactionDateUTC: "2026-04-10T00:00:00Z", Note: "Music"},
+		{ID: 6, Type: EntryTypeExpense, AmountMinor: 1099, CurrencyCode: "USD", TransactionDateUTC: "2026-06-10T00:00:00Z", Note: "Music"},
+		{ID: 7, Type: EntryTypeExpense, AmountMinor: 999, CurrencyCode: "EUR", TransactionDateUTC: "2026-06-10T00:00:00Z", Note: "Music"},
+	}
+
+	changes := SubscriptionPriceChanges(entries, SubscriptionDetectOptions{MinOccurrences: 3, TolerancePercent: 10})
+	if len(changes) != 1 {
+		t.Fatalf("expected one change, got %+v", changes)
+	}
+	if changes[0].EntryID != 5 || changes[0].PreviousEntryID != 4 || changes[0].Date != "2026-05-10" || changes[0].ChangeMinor != 100 {
+		t.Fatalf("unexpected change: %+v", changes[0])
+	}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidTaxYear      = errors.New("invalid tax year")
+	ErrInvalidTaxSelection = errors.New("invalid tax selection")
+)
+
+// TaxSelection names the categories and labels whose entries are
+// tax-relevant. Names match case-insensitively, archived ones included.
+type TaxSelection struct {
+	Categories []string `yaml:"categories" json:"categories"`
+	Labels     []string `yaml:"labels" json:"labels"`
+}
+
+type TaxSummary struct {
+	Year       int                `json:"year"`
+	Period     ReportPeriod       `json:"period"`
+	Selection  TaxSelection       `json:"selection"`
+	Totals     []TaxCurrencyTotal `json:"totals"`
+	Categories []TaxCategoryTotal `json:"categories"`
+	Entries    []TaxSummaryEntry  `json:"entries"`
+}
+
+type TaxCurrencyTotal struct {
+	CurrencyCode string `json:"currency_code"`
+	ExpenseMinor int64  `json:"expense_minor"`
+	IncomeMinor  int64  `json:"income_minor"`
+	EntryCount   int    `json:"entry_count"`
+}
+
+// TaxCategoryTotal sums one category's selected entries in one currency.
+// Expenses are net of refunds. Entries without a category report an empty
+// CategoryName.
+type TaxCategoryTotal struct {
+	CategoryID   *int64 `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CurrencyCode string `json:"currency_code"`
+	ExpenseMinor int64  `json:"expense_minor"`
+	IncomeMinor  int64  `json:"income_minor"`
+	EntryCount   int    `json:"entry_count"`
+}
+
+type TaxSummaryEntry struct {
+	ID                 int64    `json:"id"`
+	TransactionDateUTC string   `json:"transaction_date_utc"`
+	Type               string   `json:"type"`
+	AmountMinor        int64    `json:"amount_minor"`
+	CurrencyCode       string   `json:"currency_code"`
+	CategoryID         *int64   `json:"category_id"`
+	CategoryName       string   `json:"category_name"`
+	Labels             []string `json:"labels"`
+	RefundOfEntryID    *int64   `json:"refund_of_entry_id,omitempty"`
+	Payee              string   `json:"payee,omitempty"`
+	Note               string   `json:"note,omitempty"`
+}
+
+// ParseTaxSelection reads a YAML (or JSON) document with `categories` and
+// `labels` name lists. At least one name is required.
+func ParseTaxSelection(data []byte) (TaxSelection, error) {
+	var raw TaxSelection
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&raw); err != nil {
+		return TaxSelection{}, fmt.Errorf("%w: %v", ErrInvalidTaxSelection, err)
+	}
+
+	selection := TaxSelection{
+		Categories: normalizeTaxNames(raw.Categories),
+		Labels:     normalizeTaxNames(raw.Labels),
+	}
+	if len(selection.Categories) == 0 && len(selection.Labels) == 0 {
+		return TaxSelection{}, fmt.Errorf("%w: no categories or labels", ErrInvalidTaxSelection)
+	}
+	return selection, nil
+}
+
+// TaxYearPeriod is the calendar year as an inclusive UTC range.
+func TaxYearPeriod(year int) (ReportPeriod, error) {
+	if year < 1900 || year > 9999 {
+		return ReportPeriod{}, ErrInvalidTaxYear
+	}
+	return BuildReportPeriod(ReportPeriodInput{
+		Scope:       ReportScopeRange,
+		DateFromUTC: fmt.Sprintf("%04d-01-01", year),
+		DateToUTC:   fmt.Sprintf("%04d-12-31", year),
+	})
+}
+
+func normalizeTaxNames(names []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, name := range names {
+		trimmed := strings.TrimSpace(name)
+		key := strings.ToLower(trimmed)
+		if trimmed == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, trimmed)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTaxSelectionNormalizesNames(t *testing.T) {
+	t.Parallel()
+
+	selection, err := ParseTaxSelection([]byte("categories:\n  - ' Medical '\n  - medical\n  - Charity\nlabels: [donation]\n"))
+	if err != nil {
+		t.Fatalf("parse selection: %v", err)
+	}
+	want := TaxSelection{Categories: []string{"Medical", "Charity"}, Labels: []string{"donation"}}
+	if !reflect.DeepEqual(selection, want) {
+		t.Fatalf("expected %v, got %v", want, selection)
+	}
+
+	if _, err := ParseTaxSelection([]byte(`{"categories": ["Medical"]}`)); err != nil {
+		t.Fatalf("expected JSON selection to parse: %v", err)
+	}
+}
+
+func TestParseTaxSelectionRejectsEmptyAndUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{"categories: []\n", "accounts: [Medical]\n", "- Medical\n"} {
+		if _, err := ParseTaxSelection([]byte(raw)); !errors.Is(err, ErrInvalidTaxSelection) {
+			t.Fatalf("expected ErrInvalidTaxSelection for %q, got %v", raw, err)
+		}
+	}
+}
+
+func TestTaxYearPeriodCoversCalendarYear(t *testing.T) {
+	t.Parallel()
+
+	period, err := TaxYearPeriod(2026)
+	if err != nil {
+		t.Fatalf("tax year period: %v", err)
+	}
+	if period.FromUTC != "2026-01-01T00:00:00Z" || period.ToUTC != "2026-12-31T23:59:59.999999999Z" {
+		t.Fatalf("unexpected period %v", period)
+	}
+	if _, err := TaxYearPeriod(26); !errors.Is(err, ErrInvalidTaxYear) {
+		t.Fatalf("expected ErrInvalidTaxYear, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultStatementToleranceDays = 3
+
+var (
+	ErrInvalidStatementLine      = errors.New("invalid statement line")
+	ErrInvalidStatementTolerance = errors.New("invalid statement date tolerance")
+)
+
+// StatementLine is one row of a bank or card statement. AmountMinor is
+// signed from the account holder's view: negative is money out.
+type StatementLine struct {
+	Line               int    `json:"line"`
+	TransactionDateUTC string `json:"transaction_date_utc"`
+	AmountMinor        int64  `json:"amount_minor"`
+	CurrencyCode       string `json:"currency_code"`
+	Description        string `json:"description,omitempty"`
+}
+
+type StatementLineInput struct {
+	Line         int
+	Date         string
+	Amount       string
+	CurrencyCode string
+	Description  string
+}
+
+// StatementEntry is a recorded entry as it would appear on a statement:
+// expenses are negative, income and refunds positive.
+type StatementEntry struct {
+	EntryID            int64  `json:"entry_id"`
+	TransactionDateUTC string `json:"transaction_date_utc"`
+	Type               string `json:"type"`
+	AmountMinor        int64  `json:"amount_minor"`
+	CurrencyCode       string `json:"currency_code"`
+	Payee              string `json:"payee,omitempty"`
+	Note               string `json:"note,omitempty"`
+}
+
+type StatementMismatch struct {
+	CurrencyCode    string         `json:"currency_code"`
+	DifferenceMinor int64          `json:"difference_minor"`
+	Statement       StatementLine  `json:"statement"`
+	Entry           StatementEntry `json:"entry"`
+}
+
+type StatementReconciliationSummary struct {
+	StatementLines   int `json:"statement_lines"`
+	OutOfPeriod      int `json:"out_of_period"`
+	Matched          int `json:"matched"`
+	Missing          int `json:"missing"`
+	Extra            int `json:"extra"`
+	AmountMismatched int `json:"amount_mismatched"`
+}
+
+// StatementReconciliation lists statement lines with no recorded entry
+// (Missing), recorded entries absent from the statement (Extra) and pairs
+// whose dates line up but amounts differ (AmountMismatched).
+type StatementReconciliation struct {
+	MonthKey         string                         `json:"month_key"`
+	ToleranceDays    int                            `json:"tolerance_days"`
+	Summary          StatementReconciliationSummary `json:"summary"`
+	Missing          []StatementLine                `json:"missing"`
+	Extra            []StatementEntry               `json:"extra"`
+	AmountMismatched []StatementMismatch            `json:"amount_mismatched"`
+}
+
+// NormalizeStatementLine parses a statement row. Dates accept RFC3339 or
+// YYYY-MM-DD and amounts are signed major units in the given amount format.
+func NormalizeStatementLine(input StatementLineInput, amountFormat string) (StatementLine, error) {
+	dateUTC, err := NormalizeTransactionDateUTC(input.Date)
+	if err != nil {
+		return StatementLine{}, err
+	}
+	currencyCode, err := NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return StatementLine{}, err
+	}
+
+	amountRaw := strings.TrimSpace(input.Amount)
+	sign := int64(1)
+	if strings.HasPrefix(amountRaw, "-") {
+		sign = -1
+		amountRaw = strings.TrimPrefix(amountRaw, "-")
+	}
+	amountMinor, err := ParseLocalizedMajorAmountToMinor(amountRaw, currencyCode, amountFormat)
+	if err != nil {
+		return StatementLine{}, err
+	}
+	if amountMinor == 0 {
+		return StatementLine{}, ErrInvalidAmountMinor
+	}
+
+	return StatementLine{
+		Line:               input.Line,
+		TransactionDateUTC: dateUTC,
+		AmountMinor:        sign * amountMinor,
+		CurrencyCode:       currencyCode,
+		Description:        strings.TrimSpace(input.Description),
+	}, nil
+}
+
+// NewStatementEntry signs an entry the way a statement shows it.
+func NewStatementEntry(entry Entry) StatementEntry {
+	amountMinor := entry.AmountMinor
+	if entry.Type == EntryTypeExpense && !entry.IsRefund() {
+		amountMinor = -amountMinor
+	}
+	return StatementEntry{
+		EntryID:            entry.ID,
+		TransactionDateUTC: entry.TransactionDateUTC,
+		Type:               entry.Type,
+		AmountMinor:        amountMinor,
+		CurrencyCode:       entry.CurrencyCode,
+		Payee:              entry.Payee,
+		Note:               entry.Note,
+	}
+}
+
+// ReconcileStatement matches statement lines inside the month against
+// entries. A line matches an unused entry with the same currency and amount
+// whose date is within toleranceDays, preferring the closest date. Lines left
+// over are then paired with a leftover entry of the same currency and
+// direction inside the tolerance as amount mismatches. Entries may reach past
+// the month by the tolerance; leftovers outside the month are not reported
+// as extra.
+func ReconcileStatement(monthKey string, lines []StatementLine, entries []Entry, toleranceDays int) (StatementReconciliation, error) {
+	if toleranceDays < 0 {
+		return StatementReconciliation{}, ErrInvalidStatementTolerance
+	}
+	fromUTC, toUTC, err := MonthRangeUTC(monthKey)
+	if err != nil {
+		return StatementReconciliation{}, err
+	}
+	monthStart, _ := time.Parse(time.RFC3339Nano, fromUTC)
+	monthEnd, _ := time.Parse(time.RFC3339Nano, toUTC)
+	inMonth := func(value time.Time) bool {
+		return !value.Before(monthStart) && value.Before(monthEnd)
+	}
+
+	result := StatementReconciliation{
+		MonthKey:         monthKey,
+		ToleranceDays:    toleranceDays,
+		Missing:          []StatementLine{},
+		Extra:            []StatementEntry{},
+		AmountMismatched: []StatementMismatch{},
+	}
+	result.Summary.StatementLines = len(lines)
+
+	type candidate struct {
+		entry StatementEntry
+		date  time.Time
+		used  bool
+	}
+	candidates := make([]*candidate, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse(time.RFC3339Nano, entry.TransactionDateUTC)
+		if err != nil {
+			return StatementReconciliation{}, ErrInvalidTransactionDate
+		}
+		candidates = append(candidates, &candidate{entry: NewStatementEntry(entry), date: date})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].date.Equal(candidates[j].date) {
+			return candidates[i].date.Before(candidates[j].date)
+		}
+		return candidates[i].entry.EntryID < candidates[j].entry.EntryID
+	})
+
+	type pendingLine struct {
+		line StatementLine
+		date time.Time
+	}
+	pending := []pendingLine{}
+	for _, line := range lines {
+		date, err := time.Parse(time.RFC3339Nano, line.TransactionDateUTC)
+		if err != nil {
+			return StatementReconciliation{}, ErrInvalidTransactionDate
+		}
+		if !inMonth(date) {
+			result.Summary.OutOfPeriod++
+			continue
+		}
+		pending = append(pending, pendingLine{line: line, date: date})
+	}
+
+	closest := func(line pendingLine, accept func(*candidate) bool) *candidate {
+		var best *candidate
+		bestGap := 0
+		for _, c := range candidates {
+			if c.used || c.entry.CurrencyCode != line.line.CurrencyCode || !accept(c) {
+				continue
+			}
+			gap := dayGap(c.date, line.date)
+			if gap > toleranceDays {
+				continue
+			}
+			if best == nil || gap < bestGap {
+				best, bestGap = c, gap
+			}
+		}
+		return best
+	}
+
+	unmatched := []pendingLine{}
+	for _, line := range pending {
+		match := closest(line, func(c *candidate) bool { return c.entry.AmountMinor == line.line.AmountMinor })
+		if match == nil {
+			unmatched = append(unmatched, line)
+			continue
+		}
+		match.used = true
+		result.Summary.Matched++
+	}
+
+	for _, line := range unmatched {
+		match := closest(line, func(c *candidate) bool { return (c.entry.AmountMinor < 0) == (line.line.AmountMinor < 0) })
+		if match == nil {
+			result.Missing = append(result.Missing, line.line)
+			continue
+		}
+		match.used = true
+		result.AmountMismatched = append(result.AmountMismatched, StatementMismatch{
+			CurrencyCode:    line.line.CurrencyCode,
+			DifferenceMinor: line.line.AmountMinor - match.entry.AmountMinor,
+			Statement:       line.line,
+			Entry:           match.entry,
+		})
+	}
+
+	for _, c := range candidates {
+		if !c.used && inMonth(c.date) {
+			result.Extra = append(result.Extra, c.entry)
+		}
+	}
+
+	result.Summary.Missing = len(result.Missing)
+	result.Summary.Extra = len(result.Extra)
+	result.Summary.AmountMismatched = len(result.AmountMismatched)
+	return result, nil
+}
+
+// dayGap counts calendar days between two instants in UTC.
+func dayGap(left, right time.Time) int {
+	leftDay := time.Date(left.UTC().Year(), left.UTC().Month(), left.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	rightDay := time.Date(right.UTC().Year(), right.UTC().Month(), right.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	days := int(leftDay.Sub(rightDay).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReconcileStatementPrefersClosestDateAndSignsRefunds(t *testing.T) {
+	t.Parallel()
+
+	refundOf := int64(1)
+	entries := []Entry{
+		{ID: 1, Type: EntryTypeExpense, AmountMinor: 500, CurrencyCode: "USD", TransactionDateUTC: "2026-02-01T00:00:00Z"},
+		{ID: 2, Type: EntryTypeExpense, AmountMinor: 500, CurrencyCode: "USD", TransactionDateUTC: "2026-02-05T00:00:00Z"},
+		{ID: 3, Type: EntryTypeExpense, AmountMinor: 200, CurrencyCode: "USD", TransactionDateUTC: "2026-02-06T00:00:00Z", RefundOfEntryID: &refundOf},
+		{ID: 4, Type: EntryTypeExpense, AmountMinor: 800, CurrencyCode: "USD", TransactionDateUTC: "2026-01-30T00:00:00Z"},
+	}
+	lines := []StatementLine{
+		{Line: 2, TransactionDateUTC: "2026-02-04T00:00:00Z", AmountMinor: -500, CurrencyCode: "USD"},
+		{Line: 3, TransactionDateUTC: "2026-02-07T00:00:00Z", AmountMinor: 200, CurrencyCode: "USD"},
+		{Line: 4, TransactionDateUTC: "2026-02-01T00:00:00Z", AmountMinor: -500, CurrencyCode: "EUR"},
+	}
+
+	result, err := ReconcileStatement("2026-02", lines, entries, 3)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if result.Summary.Matched != 2 {
+		t.Fatalf("expected 2 matched, got %+v", result.Summary)
+	}
+	if len(result.Extra) != 1 || result.Extra[0].EntryID != 1 {
+		t.Fatalf("expected entry 1 left over (entry 4 is outside the month), got %+v", result.Extra)
+	}
+	if len(result.Missing) != 1 || result.Missing[0].Line != 4 {
+		t.Fatalf("expected the EUR line missing, got %+v", result.Missing)
+	}
+}
+
+func TestNormalizeStatementLineParsesSignedAmounts(t *testing.T) {
+	t.Parallel()
+
+	line, err := NormalizeStatementLine(StatementLineInput{Line: 2, Date: "2026-02-04", Amount: "-1.234,50", CurrencyCode: "eur"}, AmountFormatCommaDecimal)
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if line.AmountMinor != -123450 || line.CurrencyCode != "EUR" || line.TransactionDateUTC != "2026-02-04T00:00:00Z" {
+		t.Fatalf("unexpected line %+v", line)
+	}
+
+	if _, err := NormalizeStatementLine(StatementLineInput{Date: "2026-02-04", Amount: "0", CurrencyCode: "USD"}, AmountFormatDotDecimal); !errors.Is(err, ErrInvalidAmountMinor) {
+		t.Fatalf("expected ErrInvalidAmountMinor for zero amount, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package fx
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math"
 	"strconv"
 	"strings"
@@ -27,16 +28,29 @@ type Provider interface {
 
 type SnapshotStore interface {
 	GetSnapshotByKey(ctx context.Context, provider, baseCurrency, quoteCurrency, rateDate string, isEstimate bool) (domain.FXRateSnapshot, error)
+	GetLatestSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency string, isEstimate bool) (domain.FXRateSnapshot, error)
+	GetFallbackSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency, rateDate string) (domain.FXRateSnapshot, error)
 	CreateSnapshot(ctx context.Context, input domain.FXRateSnapshotCreateInput) (domain.FXRateSnapshot, error)
+	RefreshSnapshot(ctx context.Context, id int64, rate, fetchedAtUTC string) (domain.FXRateSnapshot, error)
 }
 
 type Converter struct {
 	provider  Provider
 	snapshots SnapshotStore
+	policy    domain.FXCachePolicy
 	nowFn     func() time.Time
 }
 
-func NewConverter(provider Provider, snapshots SnapshotStore) (*Converter, error) {
+type ConverterOption func(*Converter)
+
+// WithCachePolicy replaces the default cache TTL and staleness limit.
+func WithCachePolicy(policy domain.FXCachePolicy) ConverterOption {
+	return func(c *Converter) {
+		c.policy = policy
+	}
+}
+
+func NewConverter(provider Provider, snapshots SnapshotStore, opts ...ConverterOption) (*Converter, error) {
 	if provider == nil {
 		return nil, fmt.Errorf("fx converter: provider is required")
 	}
@@ -44,13 +58,21 @@ func NewConverter(provider Provider, snapshots SnapshotStore) (*Converter, error
 		return nil, fmt.Errorf("fx converter: snapshot store is required")
 	}
 
-	return &Converter{
+	converter := &Converter{
 		provider:  provider,
 		snapshots: snapshots,
+		policy:    domain.DefaultFXCachePolicy(),
 		nowFn: func() time.Time {
 			return time.Now().UTC()
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(converter)
+		}
+	}
+
+	return converter, nil
 }
 
 func (c *Converter) Convert(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
@@ -104,32 +126,26 @@ func (c *Converter) Convert(ctx context.Context, amountMinor int64, fromCurrency
 	if !isEstimate {
 		cached, err := c.snapshots.GetSnapshotByKey(ctx, c.provider.Name(), from, to, rateDate, false)
 		if err == nil {
-			rateValue, parseErr := strconv.ParseFloat(cached.Rate, 64)
-			if parseErr != nil || rateValue <= 0 {
-				return domain.ConvertedAmount{}, domain.ErrInvalidFXRate
-			}
-			converted := int64(math.Round(float64(amountMinor) * rateValue))
-			if converted < 0 {
-				converted = 0
-			}
-			return domain.ConvertedAmount{
-				AmountMinor: converted,
-				Snapshot:    cached,
-			}, nil
+			slog.DebugContext(ctx, "fx snapshot hit", "from", from, "to", to, "rate_date", rateDate)
+			return convertWithSnapshot(amountMinor, cached)
 		}
+	} else if cached, ok := c.freshEstimate(ctx, from, to, nowUTC); ok {
+		slog.DebugContext(ctx, "fx estimate snapshot hit", "from", from, "to", to, "fetched_at_utc", cached.FetchedAtUTC)
+		return convertWithSnapshot(amountMinor, cached)
 	}
 
+	slog.DebugContext(ctx, "fx snapshot miss", "from", from, "to", to, "rate_date", rateDate, "estimate", isEstimate)
 	quote := RateQuote{}
 	if isEstimate {
 		quote, err = c.provider.LatestRate(ctx, from, to)
 		if err != nil {
-			return domain.ConvertedAmount{}, fmt.Errorf("latest fx rate: %w", domain.ErrFXRateUnavailable)
+			return c.convertWithFallback(ctx, amountMinor, from, to, rateDate, nowUTC, fmt.Errorf("latest fx rate: %w", domain.ErrFXRateUnavailable))
 		}
 		rateDate = strings.TrimSpace(quote.RateDate)
 	} else {
 		quote, err = c.provider.HistoricalRate(ctx, from, to, rateDate)
 		if err != nil {
-			return domain.ConvertedAmount{}, fmt.Errorf("historical fx rate: %w", domain.ErrFXRateUnavailable)
+			return c.convertWithFallback(ctx, amountMinor, from, to, rateDate, txDateUTC, fmt.Errorf("historical fx rate: %w", domain.ErrFXRateUnavailable))
 		}
 	}
 
@@ -143,6 +159,51 @@ func (c *Converter) Convert(ctx context.Context, amountMinor int64, fromCurrency
 		return domain.ConvertedAmount{}, err
 	}
 
+	return convertWithSnapshot(amountMinor, snapshot)
+}
+
+// freshEstimate returns the latest estimate snapshot of the pair when it was
+// fetched within the cache TTL.
+func (c *Converter) freshEstimate(ctx context.Context, from, to string, nowUTC time.Time) (domain.FXRateSnapshot, bool) {
+	if c.policy.TTLHours <= 0 {
+		return domain.FXRateSnapshot{}, false
+	}
+
+	cached, err := c.snapshots.GetLatestSnapshot(ctx, c.provider.Name(), from, to, true)
+	if err != nil {
+		return domain.FXRateSnapshot{}, false
+	}
+	fetchedAt, err := time.Parse(time.RFC3339Nano, cached.FetchedAtUTC)
+	if err != nil {
+		return domain.FXRateSnapshot{}, false
+	}
+	if nowUTC.Sub(fetchedAt) >= time.Duration(c.policy.TTLHours)*time.Hour {
+		return domain.FXRateSnapshot{}, false
+	}
+	return cached, true
+}
+
+// convertWithFallback converts with the newest cached rate dated on or before
+// rateDate when the provider is unreachable, and returns cause when there is
+// none. The result is stale when that rate is older than the staleness limit
+// as of asOf.
+func (c *Converter) convertWithFallback(ctx context.Context, amountMinor int64, from, to, rateDate string, asOf time.Time, cause error) (domain.ConvertedAmount, error) {
+	cached, err := c.snapshots.GetFallbackSnapshot(ctx, c.provider.Name(), from, to, rateDate)
+	if err != nil {
+		return domain.ConvertedAmount{}, cause
+	}
+
+	result, err := convertWithSnapshot(amountMinor, cached)
+	if err != nil {
+		return domain.ConvertedAmount{}, err
+	}
+	ageDays, _ := domain.FXRateAgeDays(cached.RateDate, asOf)
+	result.Stale = domain.IsFXRateStale(ageDays, c.policy)
+	slog.DebugContext(ctx, "fx fallback snapshot", "from", from, "to", to, "rate_date", cached.RateDate, "age_days", ageDays, "stale", result.Stale)
+	return result, nil
+}
+
+func convertWithSnapshot(amountMinor int64, snapshot domain.FXRateSnapshot) (domain.ConvertedAmount, error) {
 	rateValue, err := strconv.ParseFloat(snapshot.Rate, 64)
 	if err != nil || rateValue <= 0 {
 		return domain.ConvertedAmount{}, domain.ErrInvalidFXRate
@@ -166,7 +227,7 @@ func (c *Converter) getOrCreateSnapshot(ctx context.Context, quote RateQuote, ra
 	}
 
 	existing, err := c.snapshots.GetSnapshotByKey(ctx, provider, quote.BaseCurrency, quote.QuoteCurrency, rateDate, isEstimate)
-	if err == nil {
+	if err == nil && !isEstimate {
 		return existing, nil
 	}
 	if err != nil && err != domain.ErrFXRateUnavailable {
@@ -177,6 +238,12 @@ func (c *Converter) getOrCreateSnapshot(ctx context.Context, quote RateQuote, ra
 		return domain.FXRateSnapshot{}, err
 	}
 
+	// An estimate refetched after the TTL often lands on the same rate date;
+	// refresh it so the TTL restarts from this fetch.
+	if err == nil {
+		return c.snapshots.RefreshSnapshot(ctx, existing.ID, quote.Rate, c.nowFn().Format(time.RFC3339Nano))
+	}
+
 	created, err := c.snapshots.CreateSnapshot(ctx, domain.FXRateSnapshotCreateInput{
 		Provider:      provider,
 		BaseCurrency:  quote.BaseCurrency,
@@ -187,6 +254,10 @@ func (c *Converter) getOrCreateSnapshot(ctx context.Context, quote RateQuote, ra
 		FetchedAtUTC:  c.nowFn().Format(time.RFC3339Nano),
 	})
 	if err != nil {
+		// A concurrent conversion may have stored the same snapshot first.
+		if existing, getErr := c.snapshots.GetSnapshotByKey(ctx, provider, quote.BaseCurrency, quote.QuoteCurrency, rateDate, isEstimate); getErr == nil {
+			return existing, nil
+		}
 		return domain.FXRateSnapshot{}, err
 	}
 
@@ -49,6 +49,42 @@ func (s *snapshotStoreStub) GetSnapshotByKey(ctx context.Context, provider, base
 	return row, nil
 }
 
+func (s *snapshotStoreStub) GetLatestSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency string, isEstimate bool) (domain.FXRateSnapshot, error) {
+	found := false
+	latest := domain.FXRateSnapshot{}
+	for _, row := range s.rows {
+		if row.Provider != provider || row.BaseCurrency != baseCurrency || row.QuoteCurrency != quoteCurrency || row.IsEstimate != isEstimate {
+			continue
+		}
+		if !found || row.FetchedAtUTC > latest.FetchedAtUTC {
+			latest = row
+			found = true
+		}
+	}
+	if !found {
+		return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+	}
+	return latest, nil
+}
+
+func (s *snapshotStoreStub) GetFallbackSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency, rateDate string) (domain.FXRateSnapshot, error) {
+	found := false
+	newest := domain.FXRateSnapshot{}
+	for _, row := range s.rows {
+		if row.Provider != provider || row.BaseCurrency != baseCurrency || row.QuoteCurrency != quoteCurrency || row.RateDate > rateDate {
+			continue
+		}
+		if !found || row.RateDate > newest.RateDate {
+			newest = row
+			found = true
+		}
+	}
+	if !found {
+		return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+	}
+	return newest, nil
+}
+
 func (s *snapshotStoreStub) CreateSnapshot(ctx context.Context, input domain.FXRateSnapshotCreateInput) (domain.FXRateSnapshot, error) {
 	row := domain.FXRateSnapshot{
 		ID:            s.next,
@@ -65,6 +101,19 @@ func (s *snapshotStoreStub) CreateSnapshot(ctx context.Context, input domain.FXR
 	return row, nil
 }
 
+func (s *snapshotStoreStub) RefreshSnapshot(ctx context.Context, id int64, rate, fetchedAtUTC string) (domain.FXRateSnapshot, error) {
+	for key, row := range s.rows {
+		if row.ID != id {
+			continue
+		}
+		row.Rate = rate
+		row.FetchedAtUTC = fetchedAtUTC
+		s.rows[key] = row
+		return row, nil
+	}
+	return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+}
+
 func TestConverterUsesHistoricalRateAndCachesSnapshot(t *testing.T) {
 	t.Parallel()
 
@@ -169,3 +218,146 @@ func TestConverterUsesLatestForFutureDatedTransactions(t *testing.T) {
 		t.Fatalf("expected 1 latest call, got %d", provider.latestCalls)
 	}
 }
+
+func TestConverterReusesEstimateWithinTTL(t *testing.T) {
+	t.Parallel()
+
+	provider := &providerStub{
+		historicalFn: func(base, quote, date string) (RateQuote, error) {
+			t.Fatalf("historical should not be called")
+			return RateQuote{}, nil
+		},
+		latestFn: func(base, quote string) (RateQuote, error) {
+			return RateQuote{Provider: "stub", BaseCurrency: base, QuoteCurrency: quote, Rate: "2.0", RateDate: "2026-02-01"}, nil
+		},
+	}
+	store := newSnapshotStoreStub()
+
+	converter, err := NewConverter(provider, store, WithCachePolicy(domain.FXCachePolicy{TTLHours: 6, StaleAfterDays: 7}))
+	if err != nil {
+		t.Fatalf("new converter: %v", err)
+	}
+	now := time.Date(2026, time.February, 1, 8, 0, 0, 0, time.UTC)
+	converter.nowFn = func() time.Time { return now }
+
+	if _, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-01"); err != nil {
+		t.Fatalf("convert future: %v", err)
+	}
+	now = now.Add(5 * time.Hour)
+	if _, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-02"); err != nil {
+		t.Fatalf("convert future within ttl: %v", err)
+	}
+	if provider.latestCalls != 1 {
+		t.Fatalf("expected estimate reused within ttl, got %d latest calls", provider.latestCalls)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-02"); err != nil {
+		t.Fatalf("convert future after ttl: %v", err)
+	}
+	if provider.latestCalls != 2 {
+		t.Fatalf("expected refetch after ttl, got %d latest calls", provider.latestCalls)
+	}
+}
+
+func TestConverterRefreshesEstimateSnapshotAfterTTL(t *testing.T) {
+	t.Parallel()
+
+	rate := "2.0"
+	provider := &providerStub{
+		historicalFn: func(base, quote, date string) (RateQuote, error) {
+			t.Fatalf("historical should not be called")
+			return RateQuote{}, nil
+		},
+		latestFn: func(base, quote string) (RateQuote, error) {
+			return RateQuote{Provider: "stub", BaseCurrency: base, QuoteCurrency: quote, Rate: rate, RateDate: "2026-02-01"}, nil
+		},
+	}
+	store := newSnapshotStoreStub()
+
+	converter, err := NewConverter(provider, store, WithCachePolicy(domain.FXCachePolicy{TTLHours: 6, StaleAfterDays: 7}))
+	if err != nil {
+		t.Fatalf("new converter: %v", err)
+	}
+	now := time.Date(2026, time.February, 1, 8, 0, 0, 0, time.UTC)
+	converter.nowFn = func() time.Time { return now }
+
+	if _, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-01"); err != nil {
+		t.Fatalf("convert future: %v", err)
+	}
+
+	rate = "3.0"
+	now = now.Add(7 * time.Hour)
+	first, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-01")
+	if err != nil {
+		t.Fatalf("convert future after ttl: %v", err)
+	}
+	second, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-03-02")
+	if err != nil {
+		t.Fatalf("convert future again after ttl: %v", err)
+	}
+	if provider.latestCalls != 2 {
+		t.Fatalf("expected one refetch for two conversions after ttl, got %d latest calls", provider.latestCalls)
+	}
+	if first.AmountMinor != 300 || second.AmountMinor != 300 {
+		t.Fatalf("expected the refreshed rate, got %d and %d", first.AmountMinor, second.AmountMinor)
+	}
+	if second.Snapshot.ID != 1 || second.Snapshot.FetchedAtUTC != now.Format(time.RFC3339Nano) {
+		t.Fatalf("expected the existing snapshot refreshed in place, got %+v", second.Snapshot)
+	}
+	if len(store.rows) != 1 {
+		t.Fatalf("expected one stored snapshot, got %d", len(store.rows))
+	}
+}
+
+func TestConverterFallsBackToCachedRateAndFlagsStale(t *testing.T) {
+	t.Parallel()
+
+	provider := &providerStub{
+		historicalFn: func(base, quote, date string) (RateQuote, error) {
+			return RateQuote{}, fmt.Errorf("provider down")
+		},
+		latestFn: func(base, quote string) (RateQuote, error) {
+			return RateQuote{}, fmt.Errorf("provider down")
+		},
+	}
+	store := newSnapshotStoreStub()
+	if _, err := store.CreateSnapshot(context.Background(), domain.FXRateSnapshotCreateInput{
+		Provider:      "stub",
+		BaseCurrency:  "USD",
+		QuoteCurrency: "EUR",
+		Rate:          "1.5",
+		RateDate:      "2026-02-01",
+		FetchedAtUTC:  "2026-02-01T12:00:00Z",
+	}); err != nil {
+		t.Fatalf("seed snapshot: %v", err)
+	}
+
+	converter, err := NewConverter(provider, store, WithCachePolicy(domain.FXCachePolicy{TTLHours: 24, StaleAfterDays: 7}))
+	if err != nil {
+		t.Fatalf("new converter: %v", err)
+	}
+	converter.nowFn = func() time.Time {
+		return time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	fresh, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-02-05")
+	if err != nil {
+		t.Fatalf("convert with recent fallback: %v", err)
+	}
+	if fresh.AmountMinor != 150 || fresh.Stale {
+		t.Fatalf("expected non-stale fallback of 150, got %+v", fresh)
+	}
+
+	stale, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-02-20")
+	if err != nil {
+		t.Fatalf("convert with old fallback: %v", err)
+	}
+	if stale.AmountMinor != 150 || !stale.Stale {
+		t.Fatalf("expected stale fallback of 150, got %+v", stale)
+	}
+
+	if _, err := converter.Convert(context.Background(), 100, "USD", "EUR", "2026-01-20"); err == nil {
+		t.Fatalf("expected no fallback before the oldest cached rate")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -64,11 +65,14 @@ func (c *FrankfurterClient) fetchRate(ctx context.Context, path, baseCurrency, q
 		return RateQuote{}, err
 	}
 
+	started := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		slog.DebugContext(ctx, "fx request failed", "provider", FrankfurterProviderName, "url", u.String(), "duration_ms", time.Since(started).Milliseconds(), "error", err.Error())
 		return RateQuote{}, err
 	}
 	defer resp.Body.Close()
+	slog.DebugContext(ctx, "fx request", "provider", FrankfurterProviderName, "url", u.String(), "status", resp.StatusCode, "duration_ms", time.Since(started).Milliseconds())
 
 	if resp.StatusCode != http.StatusOK {
 		return RateQuote{}, fmt.Errorf("frankfurter response status %d", resp.StatusCode)
@@ -27,6 +27,7 @@ var (
 	ErrCardNicknameRequired             = errors.New("card nickname is required")
 	ErrCardNicknameConflict             = errors.New("card nickname conflict")
 	ErrCardNotFound                     = errors.New("card not found")
+	ErrCardUpdateConflict               = errors.New("card changed since it was read")
 	ErrCardInvalidLast4                 = errors.New("invalid card last4")
 	ErrCardBrandRequired                = errors.New("card brand is required")
 	ErrCardInvalidType                  = errors.New("invalid card type")
@@ -35,6 +36,10 @@ var (
 	ErrCardDueDayNotAllowed             = errors.New("due day is not allowed for debit card")
 	ErrCardInvalidAsOfDate              = errors.New("invalid as_of date")
 	ErrCardLookupTextRequired           = errors.New("lookup text is required")
+	ErrCardAliasRequired                = errors.New("card alias is required")
+	ErrCardAliasNotFound                = errors.New("card alias not found")
+	ErrCardAliasConflict                = errors.New("card alias conflict")
+	ErrCardFXFeeInvalid                 = errors.New("invalid card fx fee")
 	ErrTransactionInvalidID             = errors.New("invalid transaction id")
 	ErrTransactionNotFound              = errors.New("transaction not found")
 	ErrTransactionPaymentMethodNotFound = errors.New("transaction payment method not found")
@@ -44,28 +49,35 @@ var (
 	ErrCurrencyCodeInvalid              = errors.New("invalid currency code")
 	ErrLiabilityEventTypeInvalid        = errors.New("invalid liability event type")
 	ErrLiabilityAmountInvalid           = errors.New("invalid liability amount")
+	ErrCardMonthlyLimitNotFound         = errors.New("card monthly limit not found")
+	ErrCardMonthlyLimitAmountInvalid    = errors.New("invalid card monthly limit amount")
 )
 
 type Card struct {
-	ID           int64   `json:"id"`
-	Nickname     string  `json:"nickname"`
-	Description  *string `json:"description,omitempty"`
-	Last4        string  `json:"last4"`
-	Brand        string  `json:"brand"`
-	CardType     string  `json:"card_type"`
-	DueDay       *int64  `json:"due_day,omitempty"`
-	CreatedAtUTC string  `json:"created_at_utc"`
-	UpdatedAtUTC string  `json:"updated_at_utc"`
-	DeletedAtUTC *string `json:"deleted_at_utc,omitempty"`
+	ID                  int64   `json:"id"`
+	Nickname            string  `json:"nickname"`
+	Description         *string `json:"description,omitempty"`
+	Last4               string  `json:"last4"`
+	Brand               string  `json:"brand"`
+	CardType            string  `json:"card_type"`
+	DueDay              *int64  `json:"due_day,omitempty"`
+	CreatedAtUTC        string  `json:"created_at_utc"`
+	UpdatedAtUTC        string  `json:"updated_at_utc"`
+	DeletedAtUTC        *string `json:"deleted_at_utc,omitempty"`
+	ArchivedAtUTC       *string `json:"archived_at_utc,omitempty"`
+	DefaultCurrencyCode *string `json:"default_currency_code,omitempty"`
+	FXFeeBasisPoints    *int64  `json:"fx_fee_bp,omitempty"`
 }
 
 type CardCreateInput struct {
-	Nickname    string
-	Description *string
-	Last4       string
-	Brand       string
-	CardType    string
-	DueDay      *int64
+	Nickname            string
+	Description         *string
+	Last4               string
+	Brand               string
+	CardType            string
+	DueDay              *int64
+	DefaultCurrencyCode *string
+	FXFeeBasisPoints    *int64
 }
 
 type CardUpdateInput struct {
@@ -78,11 +90,24 @@ type CardUpdateInput struct {
 	CardType       *string
 	SetDueDay      bool
 	DueDay         *int64
+	// SetDefaultCurrency replaces the default currency with
+	// DefaultCurrencyCode; nil clears it.
+	SetDefaultCurrency  bool
+	DefaultCurrencyCode *string
+	// SetFXFee replaces the foreign transaction fee with FXFeeBasisPoints;
+	// nil clears it.
+	SetFXFee         bool
+	FXFeeBasisPoints *int64
+	// ExpectedUpdatedAtUTC makes the update conditional on the stored
+	// updated_at_utc; a mismatch fails with ErrCardUpdateConflict.
+	ExpectedUpdatedAtUTC *string
 }
 
 type CardListFilter struct {
-	CardType       string
-	IncludeDeleted bool
+	CardType        string
+	IncludeDeleted  bool
+	IncludeArchived bool
+	Sort            string
 }
 
 type CardDeleteResult struct {
@@ -120,6 +145,7 @@ type CreditLiabilityEvent struct {
 	AmountMinorSigned      int64   `json:"amount_minor_signed"`
 	ReferenceTransactionID *int64  `json:"reference_transaction_id,omitempty"`
 	Note                   *string `json:"note,omitempty"`
+	FXFeeMinor             int64   `json:"fx_fee_minor,omitempty"`
 	CreatedAtUTC           string  `json:"created_at_utc"`
 }
 
@@ -148,13 +174,56 @@ type CardDebtBucket struct {
 	LastEventAtUTC string `json:"last_event_at_utc"`
 }
 
+// CardMonthlyLimit caps how much one card may be charged in a month, in one
+// currency.
+type CardMonthlyLimit struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	MonthKey     string `json:"month_key"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	CreatedAtUTC string `json:"created_at_utc"`
+	UpdatedAtUTC string `json:"updated_at_utc"`
+}
+
+type CardAlias struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	Alias        string `json:"alias"`
+	CreatedAtUTC string `json:"created_at_utc"`
+}
+
+// CardFXFeeCharge is the fee part of a card charge and the date of the entry
+// that caused it.
+type CardFXFeeCharge struct {
+	CardID             int64
+	CardNickname       string
+	CurrencyCode       string
+	FeeMinor           int64
+	TransactionDateUTC string
+}
+
+type CardMonthlyLimitSetInput struct {
+	CardID       int64
+	MonthKey     string
+	AmountMinor  int64
+	CurrencyCode string
+}
+
 type CardRepository interface {
 	AddCard(ctx context.Context, input CardCreateInput) (Card, error)
 	GetCardByID(ctx context.Context, id int64, includeDeleted bool) (Card, error)
 	ListCards(ctx context.Context, filter CardListFilter) ([]Card, error)
-	SearchCards(ctx context.Context, lookup string, limit int32) ([]Card, error)
+	SearchCards(ctx context.Context, lookup string, includeArchived bool, limit int32) ([]Card, error)
+	// FindCardsByName returns the active cards whose nickname or one of
+	// whose aliases equals name, ignoring case.
+	FindCardsByName(ctx context.Context, name string, includeArchived bool) ([]Card, error)
 	UpdateCard(ctx context.Context, input CardUpdateInput) (Card, error)
+	SetCardArchived(ctx context.Context, id int64, archived bool) (Card, error)
 	DeleteCard(ctx context.Context, id int64) (CardDeleteResult, error)
+	AddCardAlias(ctx context.Context, cardID int64, alias string) (CardAlias, error)
+	RemoveCardAlias(ctx context.Context, cardID int64, alias string) (CardAlias, error)
+	ListCardAliases(ctx context.Context, cardID *int64) ([]CardAlias, error)
 	GetCardDue(ctx context.Context, cardID int64, asOfDate string) (CardDue, error)
 	ListCardDues(ctx context.Context, asOfDate string) ([]CardDue, error)
 	UpsertTransactionPaymentMethod(ctx context.Context, input TransactionPaymentMethodUpsertInput) (TransactionPaymentMethod, error)
@@ -163,8 +232,13 @@ type CardRepository interface {
 	AddPaymentEvent(ctx context.Context, input CardPaymentEventInput) (CreditLiabilityEvent, error)
 	ListLiabilityEvents(ctx context.Context, cardID int64, currencyCode string) ([]CreditLiabilityEvent, error)
 	GetDebtSummaryByCard(ctx context.Context, cardID int64) ([]CardDebtBucket, error)
-	GetDebtSummary(ctx context.Context) ([]CardDebtBucket, error)
+	GetDebtSummary(ctx context.Context, sortKey string) ([]CardDebtBucket, error)
 	GetDebtBalance(ctx context.Context, cardID int64, currencyCode string) (int64, error)
+	ListFXFeeCharges(ctx context.Context, fromUTC, toUTC string, cardID *int64) ([]CardFXFeeCharge, error)
+	SetMonthlyLimit(ctx context.Context, input CardMonthlyLimitSetInput) (CardMonthlyLimit, error)
+	GetMonthlyLimit(ctx context.Context, cardID int64, monthKey string) (CardMonthlyLimit, error)
+	ListMonthlyLimits(ctx context.Context, cardID int64) ([]CardMonthlyLimit, error)
+	GetCardExpenseTotal(ctx context.Context, cardID int64, currencyCode, fromUTC, toUTC string) (int64, error)
 }
 
 type CardRepositoryTxBinder interface {
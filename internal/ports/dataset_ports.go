@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+
+	"boring-budget/internal/domain"
+)
+
+type DatasetStore interface {
+	LoadDataset(ctx context.Context) (domain.Dataset, error)
+	RestoreDataset(ctx context.Context, dataset domain.Dataset, skipDuplicateEvents bool) (domain.DatasetRestoreResult, error)
+}
+
+type DatasetStoreTxBinder interface {
+	BindTx(tx *sql.Tx) DatasetStore
+}
@@ -11,14 +11,49 @@ type EntryRepository interface {
 	Add(ctx context.Context, input domain.EntryAddInput) (domain.Entry, error)
 	Update(ctx context.Context, input domain.EntryUpdateInput) (domain.Entry, error)
 	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+	Get(ctx context.Context, id int64) (domain.Entry, error)
 	Delete(ctx context.Context, id int64) (domain.EntryDeleteResult, error)
 }
 
+// EntryIterator is optionally implemented by an EntryRepository to visit
+// matching entries one at a time instead of returning them as one slice.
+type EntryIterator interface {
+	EachEntry(ctx context.Context, filter domain.EntryListFilter, fn func(domain.Entry) error) error
+}
+
+// EntryUIDLookup is optionally implemented by an EntryRepository to find an
+// entry by its stable uid.
+type EntryUIDLookup interface {
+	GetByUID(ctx context.Context, uid string) (domain.Entry, error)
+}
+
+// EntryRevisionReader is optionally implemented by an EntryRepository that
+// keeps the previous state of updated entries.
+type EntryRevisionReader interface {
+	ListRevisions(ctx context.Context, id int64) ([]domain.EntryRevision, error)
+	GetRevision(ctx context.Context, id, revision int64) (domain.EntryRevision, error)
+}
+
 type EntryCapLookup interface {
 	GetByMonth(ctx context.Context, monthKey string) (domain.MonthlyCap, error)
 	GetExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey, currencyCode string) (int64, error)
 }
 
+// EntryCategoryCapLookup is optionally implemented by an EntryCapLookup to
+// also check per-category caps.
+type EntryCategoryCapLookup interface {
+	GetCategoryCap(ctx context.Context, monthKey string, categoryID int64) (domain.MonthlyCap, error)
+	GetCategoryExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey string, categoryID int64, currencyCode string) (int64, error)
+}
+
+// EntryCardLimitLookup reads card monthly limits and the card spend they are
+// checked against. Implementations that also implement CardRepositoryTxBinder
+// take part in strict-warnings transactions.
+type EntryCardLimitLookup interface {
+	GetMonthlyLimit(ctx context.Context, cardID int64, monthKey string) (CardMonthlyLimit, error)
+	GetCardExpenseTotal(ctx context.Context, cardID int64, currencyCode, fromUTC, toUTC string) (int64, error)
+}
+
 type EntryRepositoryTxBinder interface {
 	BindTx(tx *sql.Tx) EntryRepository
 }
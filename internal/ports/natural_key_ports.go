@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+
+	"boring-budget/internal/domain"
+)
+
+// CategoryCreator adds a category by name. Imports bind it to their
+// transaction to create the categories their records name.
+type CategoryCreator interface {
+	Add(ctx context.Context, name string) (domain.Category, error)
+}
+
+type CategoryCreatorTxBinder interface {
+	BindTx(tx *sql.Tx) CategoryCreator
+}
+
+// LabelCreator adds a label by name, like CategoryCreator.
+type LabelCreator interface {
+	Add(ctx context.Context, name string) (domain.Label, error)
+}
+
+type LabelCreatorTxBinder interface {
+	BindTx(tx *sql.Tx) LabelCreator
+}
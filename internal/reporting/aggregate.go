@@ -1,11 +1,13 @@
 package reporting
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 
 	"boring-budget/internal/domain"
+	"golang.org/x/sync/errgroup"
 )
 
 type AggregateResult struct {
@@ -13,6 +15,9 @@ type AggregateResult struct {
 	Spending       domain.ReportSection
 	Net            domain.ReportNet
 	PaymentMethods domain.ReportPaymentMethods
+	ByPerson       []domain.ReportPersonTotal
+	BySource       []domain.ReportSourceTotal
+	ByLocation     []domain.ReportLocationTotal
 }
 
 type groupCurrencyKey struct {
@@ -26,6 +31,31 @@ type categoryCurrencyKey struct {
 	CurrencyCode string
 }
 
+type brandCurrencyKey struct {
+	Brand        string
+	CurrencyCode string
+}
+
+// personCurrencyKey groups recorded_by case-insensitively; the first spelling
+// seen is the one reported.
+type personCurrencyKey struct {
+	Person       string
+	CurrencyCode string
+}
+
+// sourceCurrencyKey groups income_source like personCurrencyKey groups
+// recorded_by.
+type sourceCurrencyKey struct {
+	Source       string
+	CurrencyCode string
+}
+
+// locationCurrencyKey groups expense locations case-insensitively.
+type locationCurrencyKey struct {
+	Location     string
+	CurrencyCode string
+}
+
 type paymentInstrumentKey struct {
 	PaymentMethod string
 	CurrencyCode  string
@@ -38,85 +68,251 @@ type paymentInstrumentKey struct {
 type CategoryLabelResolver func(categoryID int64) string
 
 func BuildAggregate(entries []domain.Entry, grouping string, categoryLabelResolver CategoryLabelResolver) (AggregateResult, error) {
-	earnByCurrency := map[string]int64{}
-	spendByCurrency := map[string]int64{}
-	earnGroups := map[groupCurrencyKey]int64{}
-	spendGroups := map[groupCurrencyKey]int64{}
-	earnCategories := map[categoryCurrencyKey]int64{}
-	spendCategories := map[categoryCurrencyKey]int64{}
-	paymentInstruments := map[paymentInstrumentKey]int64{}
-	cashByCurrency := map[string]int64{}
-	creditByCurrency := map[string]int64{}
-	debitByCurrency := map[string]int64{}
+	state := newAggregateState()
+	if err := state.addAll(entries, grouping); err != nil {
+		return AggregateResult{}, err
+	}
+	return state.result(grouping, categoryLabelResolver), nil
+}
+
+// BuildAggregateByMonth returns what BuildAggregate does, building the totals
+// of each calendar month of entries on up to workers goroutines and merging
+// them in month order. Entries must be sorted by date, as
+// SortEntriesDeterministic leaves them.
+func BuildAggregateByMonth(ctx context.Context, entries []domain.Entry, grouping string, categoryLabelResolver CategoryLabelResolver, workers int) (AggregateResult, error) {
+	months := splitEntriesByMonth(entries)
+	if len(months) <= 1 || workers <= 1 {
+		return BuildAggregate(entries, grouping, categoryLabelResolver)
+	}
+
+	states := make([]*aggregateState, len(months))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i, monthEntries := range months {
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			state := newAggregateState()
+			if err := state.addAll(monthEntries, grouping); err != nil {
+				return err
+			}
+			states[i] = state
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return AggregateResult{}, err
+	}
+
+	merged := states[0]
+	for _, state := range states[1:] {
+		merged.merge(state)
+	}
+	return merged.result(grouping, categoryLabelResolver), nil
+}
+
+// splitEntriesByMonth cuts date-sorted entries into runs of one calendar month
+// (by the YYYY-MM prefix of the UTC transaction date).
+func splitEntriesByMonth(entries []domain.Entry) [][]domain.Entry {
+	months := [][]domain.Entry{}
+	start := 0
+	for i := 1; i <= len(entries); i++ {
+		if i < len(entries) && monthPrefix(entries[i].TransactionDateUTC) == monthPrefix(entries[start].TransactionDateUTC) {
+			continue
+		}
+		months = append(months, entries[start:i])
+		start = i
+	}
+	return months
+}
+
+func monthPrefix(transactionDateUTC string) string {
+	if len(transactionDateUTC) < 7 {
+		return transactionDateUTC
+	}
+	return transactionDateUTC[:7]
+}
+
+// aggregateState holds the running totals of BuildAggregate. The states of
+// consecutive runs of entries merge into the state of the whole run.
+type aggregateState struct {
+	earnByCurrency     map[string]int64
+	spendByCurrency    map[string]int64
+	earnGroups         map[groupCurrencyKey]int64
+	spendGroups        map[groupCurrencyKey]int64
+	earnCategories     map[categoryCurrencyKey]int64
+	spendCategories    map[categoryCurrencyKey]int64
+	paymentInstruments map[paymentInstrumentKey]int64
+	cashByCurrency     map[string]int64
+	creditByCurrency   map[string]int64
+	debitByCurrency    map[string]int64
+	cardBrands         map[brandCurrencyKey]int64
+	creditGroups       map[groupCurrencyKey]int64
+	debitGroups        map[groupCurrencyKey]int64
+	persons            map[personCurrencyKey]*domain.ReportPersonTotal
+	sources            map[sourceCurrencyKey]*domain.ReportSourceTotal
+	locations          map[locationCurrencyKey]*domain.ReportLocationTotal
+}
 
+func newAggregateState() *aggregateState {
+	return &aggregateState{
+		earnByCurrency:     map[string]int64{},
+		spendByCurrency:    map[string]int64{},
+		earnGroups:         map[groupCurrencyKey]int64{},
+		spendGroups:        map[groupCurrencyKey]int64{},
+		earnCategories:     map[categoryCurrencyKey]int64{},
+		spendCategories:    map[categoryCurrencyKey]int64{},
+		paymentInstruments: map[paymentInstrumentKey]int64{},
+		cashByCurrency:     map[string]int64{},
+		creditByCurrency:   map[string]int64{},
+		debitByCurrency:    map[string]int64{},
+		cardBrands:         map[brandCurrencyKey]int64{},
+		creditGroups:       map[groupCurrencyKey]int64{},
+		debitGroups:        map[groupCurrencyKey]int64{},
+		persons:            map[personCurrencyKey]*domain.ReportPersonTotal{},
+		sources:            map[sourceCurrencyKey]*domain.ReportSourceTotal{},
+		locations:          map[locationCurrencyKey]*domain.ReportLocationTotal{},
+	}
+}
+
+func (s *aggregateState) addAll(entries []domain.Entry, grouping string) error {
 	for _, entry := range entries {
 		periodKey, err := domain.PeriodKeyForTransaction(entry.TransactionDateUTC, grouping)
 		if err != nil {
-			return AggregateResult{}, err
+			return err
 		}
 
+		person := personTotalFor(s.persons, entry)
+		person.EntryCount++
+
 		switch entry.Type {
 		case domain.EntryTypeIncome:
-			earnByCurrency[entry.CurrencyCode] += entry.AmountMinor
-			earnGroups[groupCurrencyKey{PeriodKey: periodKey, CurrencyCode: entry.CurrencyCode}] += entry.AmountMinor
-			earnCategories[toCategoryCurrencyKey(entry)] += entry.AmountMinor
+			person.EarningsMinor += entry.AmountMinor
+			s.earnByCurrency[entry.CurrencyCode] += entry.AmountMinor
+			s.earnGroups[groupCurrencyKey{PeriodKey: periodKey, CurrencyCode: entry.CurrencyCode}] += entry.AmountMinor
+			s.earnCategories[toCategoryCurrencyKey(entry)] += entry.AmountMinor
+			source := sourceTotalFor(s.sources, entry)
+			source.TotalMinor += entry.AmountMinor
+			source.EntryCount++
 		case domain.EntryTypeExpense:
-			spendByCurrency[entry.CurrencyCode] += entry.AmountMinor
-			spendGroups[groupCurrencyKey{PeriodKey: periodKey, CurrencyCode: entry.CurrencyCode}] += entry.AmountMinor
-			spendCategories[toCategoryCurrencyKey(entry)] += entry.AmountMinor
+			amountMinor := entry.EffectiveAmountMinor()
+			person.SpendingMinor += amountMinor
+			s.spendByCurrency[entry.CurrencyCode] += amountMinor
+			s.spendGroups[groupCurrencyKey{PeriodKey: periodKey, CurrencyCode: entry.CurrencyCode}] += amountMinor
+			s.spendCategories[toCategoryCurrencyKey(entry)] += amountMinor
+			location := locationTotalFor(s.locations, entry)
+			location.TotalMinor += amountMinor
+			location.EntryCount++
 			paymentMethod := normalizeEntryPaymentMethod(entry)
 			cardType := normalizeEntryCardType(entry)
 
-			instrument := paymentInstrumentKey{
-				PaymentMethod: paymentMethod,
-				CurrencyCode:  entry.CurrencyCode,
-				CardNickname:  strings.TrimSpace(entry.PaymentCardNickname),
-				CardType:      cardType,
-			}
-			if entry.PaymentCardID != nil {
-				instrument.HasCard = true
-				instrument.CardID = *entry.PaymentCardID
-			}
-			paymentInstruments[instrument] += entry.AmountMinor
+			s.paymentInstruments[toPaymentInstrumentKey(entry)] += amountMinor
 
 			switch paymentMethod {
 			case domain.PaymentMethodCash:
-				cashByCurrency[entry.CurrencyCode] += entry.AmountMinor
+				s.cashByCurrency[entry.CurrencyCode] += amountMinor
 			case domain.PaymentMethodCard:
+				groupKey := groupCurrencyKey{PeriodKey: periodKey, CurrencyCode: entry.CurrencyCode}
 				if cardType == domain.PaymentMethodFilterCredit {
-					creditByCurrency[entry.CurrencyCode] += entry.AmountMinor
+					s.creditByCurrency[entry.CurrencyCode] += amountMinor
+					s.creditGroups[groupKey] += amountMinor
 				} else {
-					debitByCurrency[entry.CurrencyCode] += entry.AmountMinor
+					s.debitByCurrency[entry.CurrencyCode] += amountMinor
+					s.debitGroups[groupKey] += amountMinor
 				}
+				s.cardBrands[brandCurrencyKey{Brand: normalizeEntryCardBrand(entry), CurrencyCode: entry.CurrencyCode}] += amountMinor
 			}
 		}
 	}
+	return nil
+}
 
+// merge adds other, built over the entries after s's, into s. Person, source
+// and location names keep the spelling s saw first.
+func (s *aggregateState) merge(other *aggregateState) {
+	mergeTotals(s.earnByCurrency, other.earnByCurrency)
+	mergeTotals(s.spendByCurrency, other.spendByCurrency)
+	mergeTotals(s.earnGroups, other.earnGroups)
+	mergeTotals(s.spendGroups, other.spendGroups)
+	mergeTotals(s.earnCategories, other.earnCategories)
+	mergeTotals(s.spendCategories, other.spendCategories)
+	mergeTotals(s.paymentInstruments, other.paymentInstruments)
+	mergeTotals(s.cashByCurrency, other.cashByCurrency)
+	mergeTotals(s.creditByCurrency, other.creditByCurrency)
+	mergeTotals(s.debitByCurrency, other.debitByCurrency)
+	mergeTotals(s.cardBrands, other.cardBrands)
+	mergeTotals(s.creditGroups, other.creditGroups)
+	mergeTotals(s.debitGroups, other.debitGroups)
+
+	for key, total := range other.persons {
+		existing, ok := s.persons[key]
+		if !ok {
+			s.persons[key] = total
+			continue
+		}
+		existing.EntryCount += total.EntryCount
+		existing.EarningsMinor += total.EarningsMinor
+		existing.SpendingMinor += total.SpendingMinor
+	}
+	for key, total := range other.sources {
+		existing, ok := s.sources[key]
+		if !ok {
+			s.sources[key] = total
+			continue
+		}
+		existing.EntryCount += total.EntryCount
+		existing.TotalMinor += total.TotalMinor
+	}
+	for key, total := range other.locations {
+		existing, ok := s.locations[key]
+		if !ok {
+			s.locations[key] = total
+			continue
+		}
+		existing.EntryCount += total.EntryCount
+		existing.TotalMinor += total.TotalMinor
+	}
+}
+
+func mergeTotals[K comparable](into, from map[K]int64) {
+	for key, value := range from {
+		into[key] += value
+	}
+}
+
+func (s *aggregateState) result(grouping string, categoryLabelResolver CategoryLabelResolver) AggregateResult {
 	return AggregateResult{
 		Earnings: domain.ReportSection{
-			ByCurrency: mapCurrencyTotals(earnByCurrency),
-			Groups:     mapGroupTotals(earnGroups),
-			Categories: mapCategoryTotals(earnCategories, categoryLabelResolver),
+			ByCurrency: mapCurrencyTotals(s.earnByCurrency),
+			Groups:     mapGroupTotals(s.earnGroups, grouping),
+			Categories: mapCategoryTotals(s.earnCategories, categoryLabelResolver),
 		},
 		Spending: domain.ReportSection{
-			ByCurrency: mapCurrencyTotals(spendByCurrency),
-			Groups:     mapGroupTotals(spendGroups),
-			Categories: mapCategoryTotals(spendCategories, categoryLabelResolver),
+			ByCurrency: mapCurrencyTotals(s.spendByCurrency),
+			Groups:     mapGroupTotals(s.spendGroups, grouping),
+			Categories: mapCategoryTotals(s.spendCategories, categoryLabelResolver),
 		},
 		Net: domain.ReportNet{
-			ByCurrency: mapNetTotals(earnByCurrency, spendByCurrency),
+			ByCurrency: mapNetTotals(s.earnByCurrency, s.spendByCurrency),
 		},
 		PaymentMethods: domain.ReportPaymentMethods{
-			ByInstrument: mapPaymentInstrumentTotals(paymentInstruments),
+			ByInstrument: mapPaymentInstrumentTotals(s.paymentInstruments),
 			Totals: domain.ReportPaymentMethodTotals{
-				Cash:   mapCurrencyTotals(cashByCurrency),
-				Debit:  mapCurrencyTotals(debitByCurrency),
-				Credit: mapCurrencyTotals(creditByCurrency),
+				Cash:   mapCurrencyTotals(s.cashByCurrency),
+				Debit:  mapCurrencyTotals(s.debitByCurrency),
+				Credit: mapCurrencyTotals(s.creditByCurrency),
 			},
-			CashUsage:       mapCashUsage(cashByCurrency, spendByCurrency),
+			ByBrand:         mapCardBrandTotals(s.cardBrands),
+			CreditGroups:    mapGroupTotals(s.creditGroups, grouping),
+			DebitGroups:     mapGroupTotals(s.debitGroups, grouping),
+			CashUsage:       mapCashUsage(s.cashByCurrency, s.spendByCurrency),
 			CreditLiability: []domain.ReportCardLiability{},
 		},
-	}, nil
+		ByPerson:   mapPersonTotals(s.persons),
+		BySource:   mapSourceTotals(s.sources),
+		ByLocation: mapLocationTotals(s.locations),
+	}
 }
 
 func SortEntriesDeterministic(entries []domain.Entry) {
@@ -144,6 +340,20 @@ func toCategoryCurrencyKey(entry domain.Entry) categoryCurrencyKey {
 	return categoryCurrencyKey{CategoryID: *entry.CategoryID, HasCategory: true, CurrencyCode: entry.CurrencyCode}
 }
 
+func toPaymentInstrumentKey(entry domain.Entry) paymentInstrumentKey {
+	instrument := paymentInstrumentKey{
+		PaymentMethod: normalizeEntryPaymentMethod(entry),
+		CurrencyCode:  entry.CurrencyCode,
+		CardNickname:  strings.TrimSpace(entry.PaymentCardNickname),
+		CardType:      normalizeEntryCardType(entry),
+	}
+	if entry.PaymentCardID != nil {
+		instrument.HasCard = true
+		instrument.CardID = *entry.PaymentCardID
+	}
+	return instrument
+}
+
 func mapCurrencyTotals(values map[string]int64) []domain.CurrencyTotal {
 	currencies := make([]string, 0, len(values))
 	for currency := range values {
@@ -158,7 +368,12 @@ func mapCurrencyTotals(values map[string]int64) []domain.CurrencyTotal {
 	return output
 }
 
-func mapGroupTotals(values map[groupCurrencyKey]int64) []domain.GroupTotal {
+// weekdayGroupOrder sorts weekday groups Monday first, like ISO weeks.
+var weekdayGroupOrder = map[string]int{
+	"monday": 1, "tuesday": 2, "wednesday": 3, "thursday": 4, "friday": 5, "saturday": 6, "sunday": 7,
+}
+
+func mapGroupTotals(values map[groupCurrencyKey]int64, grouping string) []domain.GroupTotal {
 	keys := make([]groupCurrencyKey, 0, len(values))
 	for key := range values {
 		keys = append(keys, key)
@@ -166,6 +381,9 @@ func mapGroupTotals(values map[groupCurrencyKey]int64) []domain.GroupTotal {
 
 	sort.Slice(keys, func(i, j int) bool {
 		if keys[i].PeriodKey != keys[j].PeriodKey {
+			if grouping == domain.ReportGroupingWeekday {
+				return weekdayGroupOrder[keys[i].PeriodKey] < weekdayGroupOrder[keys[j].PeriodKey]
+			}
 			return keys[i].PeriodKey < keys[j].PeriodKey
 		}
 		return keys[i].CurrencyCode < keys[j].CurrencyCode
@@ -283,6 +501,129 @@ func mapPaymentInstrumentTotals(values map[paymentInstrumentKey]int64) []domain.
 	return output
 }
 
+func mapCardBrandTotals(values map[brandCurrencyKey]int64) []domain.ReportCardBrandTotal {
+	keys := make([]brandCurrencyKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CurrencyCode != keys[j].CurrencyCode {
+			return keys[i].CurrencyCode < keys[j].CurrencyCode
+		}
+		return keys[i].Brand < keys[j].Brand
+	})
+
+	output := make([]domain.ReportCardBrandTotal, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, domain.ReportCardBrandTotal{Brand: key.Brand, CurrencyCode: key.CurrencyCode, TotalMinor: values[key]})
+	}
+	return output
+}
+
+func personTotalFor(values map[personCurrencyKey]*domain.ReportPersonTotal, entry domain.Entry) *domain.ReportPersonTotal {
+	person := strings.TrimSpace(entry.RecordedBy)
+	key := personCurrencyKey{Person: strings.ToLower(person), CurrencyCode: entry.CurrencyCode}
+	total, ok := values[key]
+	if !ok {
+		total = &domain.ReportPersonTotal{Person: person, CurrencyCode: entry.CurrencyCode}
+		values[key] = total
+	}
+	return total
+}
+
+func mapPersonTotals(values map[personCurrencyKey]*domain.ReportPersonTotal) []domain.ReportPersonTotal {
+	keys := make([]personCurrencyKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CurrencyCode != keys[j].CurrencyCode {
+			return keys[i].CurrencyCode < keys[j].CurrencyCode
+		}
+		return keys[i].Person < keys[j].Person
+	})
+
+	output := make([]domain.ReportPersonTotal, 0, len(keys))
+	for _, key := range keys {
+		total := *values[key]
+		total.NetMinor = total.EarningsMinor - total.SpendingMinor
+		output = append(output, total)
+	}
+	return output
+}
+
+func sourceTotalFor(values map[sourceCurrencyKey]*domain.ReportSourceTotal, entry domain.Entry) *domain.ReportSourceTotal {
+	source := strings.TrimSpace(entry.IncomeSource)
+	key := sourceCurrencyKey{Source: strings.ToLower(source), CurrencyCode: entry.CurrencyCode}
+	total, ok := values[key]
+	if !ok {
+		total = &domain.ReportSourceTotal{Source: source, CurrencyCode: entry.CurrencyCode}
+		values[key] = total
+	}
+	return total
+}
+
+// mapSourceTotals orders sources by currency, then largest total first.
+func mapSourceTotals(values map[sourceCurrencyKey]*domain.ReportSourceTotal) []domain.ReportSourceTotal {
+	keys := make([]sourceCurrencyKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CurrencyCode != keys[j].CurrencyCode {
+			return keys[i].CurrencyCode < keys[j].CurrencyCode
+		}
+		if values[keys[i]].TotalMinor != values[keys[j]].TotalMinor {
+			return values[keys[i]].TotalMinor > values[keys[j]].TotalMinor
+		}
+		return keys[i].Source < keys[j].Source
+	})
+
+	output := make([]domain.ReportSourceTotal, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, *values[key])
+	}
+	return output
+}
+
+func locationTotalFor(values map[locationCurrencyKey]*domain.ReportLocationTotal, entry domain.Entry) *domain.ReportLocationTotal {
+	location := strings.TrimSpace(entry.Location)
+	key := locationCurrencyKey{Location: strings.ToLower(location), CurrencyCode: entry.CurrencyCode}
+	total, ok := values[key]
+	if !ok {
+		total = &domain.ReportLocationTotal{Location: location, CurrencyCode: entry.CurrencyCode}
+		values[key] = total
+	}
+	return total
+}
+
+// mapLocationTotals orders locations like mapSourceTotals orders sources.
+func mapLocationTotals(values map[locationCurrencyKey]*domain.ReportLocationTotal) []domain.ReportLocationTotal {
+	keys := make([]locationCurrencyKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CurrencyCode != keys[j].CurrencyCode {
+			return keys[i].CurrencyCode < keys[j].CurrencyCode
+		}
+		if values[keys[i]].TotalMinor != values[keys[j]].TotalMinor {
+			return values[keys[i]].TotalMinor > values[keys[j]].TotalMinor
+		}
+		return keys[i].Location < keys[j].Location
+	})
+
+	output := make([]domain.ReportLocationTotal, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, *values[key])
+	}
+	return output
+}
+
 func mapCashUsage(cashByCurrency, spendByCurrency map[string]int64) []domain.ReportCashUsage {
 	currencies := make([]string, 0, len(spendByCurrency))
 	for currency := range spendByCurrency {
@@ -324,3 +665,13 @@ func normalizeEntryCardType(entry domain.Entry) string {
 	}
 	return domain.PaymentMethodFilterDebit
 }
+
+// normalizeEntryCardBrand buckets card entries whose card is gone (or was
+// never resolved) under "UNKNOWN" so brand totals still add up to card spend.
+func normalizeEntryCardBrand(entry domain.Entry) string {
+	brand := strings.ToUpper(strings.TrimSpace(entry.PaymentCardBrand))
+	if brand == "" {
+		return "UNKNOWN"
+	}
+	return brand
+}
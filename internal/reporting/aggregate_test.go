@@ -0,0 +1,169 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"boring-budget/internal/domain"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func categoryLabels(categoryID int64) string {
+	return fmt.Sprintf("category-%d", categoryID)
+}
+
+// overlappingMonthEntries spans three months that share currencies,
+// categories, labels, cards and (differently spelled) people, so every merged
+// total has contributions from more than one month.
+func overlappingMonthEntries() []domain.Entry {
+	return []domain.Entry{
+		{ID: 1, Type: domain.EntryTypeIncome, AmountMinor: 500000, CurrencyCode: "USD", TransactionDateUTC: "2026-01-01T00:00:00Z", CategoryID: int64Ptr(1), LabelIDs: []int64{7}, RecordedBy: "Ana", IncomeSource: "Acme"},
+		{ID: 2, Type: domain.EntryTypeExpense, AmountMinor: 12000, CurrencyCode: "USD", TransactionDateUTC: "2026-01-05T00:00:00Z", CategoryID: int64Ptr(2), LabelIDs: []int64{7, 8}, RecordedBy: "Ana", Location: "Market", PaymentMethod: domain.PaymentMethodCash},
+		{ID: 3, Type: domain.EntryTypeExpense, AmountMinor: 30000, CurrencyCode: "USD", TransactionDateUTC: "2026-01-20T00:00:00Z", CategoryID: int64Ptr(2), LabelIDs: []int64{8}, RecordedBy: "Ben", Location: "Mall", PaymentMethod: domain.PaymentMethodCard, PaymentCardID: int64Ptr(4), PaymentCardNickname: "Visa", PaymentCardType: "credit", PaymentCardBrand: "visa"},
+		{ID: 4, Type: domain.EntryTypeExpense, AmountMinor: 900000, CurrencyCode: "ARS", TransactionDateUTC: "2026-01-28T00:00:00Z", RecordedBy: "ana", Location: "market", PaymentMethod: domain.PaymentMethodCard, PaymentCardID: int64Ptr(5), PaymentCardNickname: "Debit", PaymentCardType: "debit", PaymentCardBrand: "mastercard"},
+		{ID: 5, Type: domain.EntryTypeIncome, AmountMinor: 500000, CurrencyCode: "USD", TransactionDateUTC: "2026-02-01T00:00:00Z", CategoryID: int64Ptr(1), LabelIDs: []int64{7}, RecordedBy: "ANA", IncomeSource: "acme"},
+		{ID: 6, Type: domain.EntryTypeExpense, AmountMinor: 10000, CurrencyCode: "USD", TransactionDateUTC: "2026-02-03T00:00:00Z", CategoryID: int64Ptr(2), LabelIDs: []int64{8}, RefundOfEntryID: int64Ptr(3), RecordedBy: "Ben", Location: "MALL", PaymentMethod: domain.PaymentMethodCard, PaymentCardID: int64Ptr(4), PaymentCardNickname: "Visa", PaymentCardType: "credit", PaymentCardBrand: "visa"},
+		{ID: 7, Type: domain.EntryTypeExpense, AmountMinor: 450000, CurrencyCode: "ARS", TransactionDateUTC: "2026-02-14T00:00:00Z", CategoryID: int64Ptr(3), LabelIDs: []int64{7}, RecordedBy: "Ana", Location: "Market", PaymentMethod: domain.PaymentMethodCash},
+		{ID: 8, Type: domain.EntryTypeExpense, AmountMinor: 8000, CurrencyCode: "USD", TransactionDateUTC: "2026-04-02T00:00:00Z", CategoryID: int64Ptr(2), RecordedBy: "ben", Location: "mall", PaymentMethod: domain.PaymentMethodCard, PaymentCardID: int64Ptr(4), PaymentCardNickname: "Visa", PaymentCardType: "credit", PaymentCardBrand: "visa"},
+		{ID: 9, Type: domain.EntryTypeIncome, AmountMinor: 200000, CurrencyCode: "ARS", TransactionDateUTC: "2026-04-30T00:00:00Z", RecordedBy: "Ana", IncomeSource: "Freelance"},
+	}
+}
+
+func TestBuildAggregateByMonthMatchesSerial(t *testing.T) {
+	t.Parallel()
+
+	singleMonth := overlappingMonthEntries()[:4]
+	tests := []struct {
+		name     string
+		entries  []domain.Entry
+		grouping string
+		workers  int
+	}{
+		{name: "no entries", entries: []domain.Entry{}, grouping: "month", workers: 4},
+		{name: "single month", entries: singleMonth, grouping: "month", workers: 4},
+		{name: "overlapping months", entries: overlappingMonthEntries(), grouping: "month", workers: 4},
+		{name: "fewer workers than months", entries: overlappingMonthEntries(), grouping: "month", workers: 2},
+		{name: "one worker", entries: overlappingMonthEntries(), grouping: "month", workers: 1},
+		{name: "grouped by week", entries: overlappingMonthEntries(), grouping: "week", workers: 4},
+		{name: "grouped by day", entries: overlappingMonthEntries(), grouping: "day", workers: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			entries := append([]domain.Entry(nil), test.entries...)
+			SortEntriesDeterministic(entries)
+
+			serial, err := BuildAggregate(entries, test.grouping, categoryLabels)
+			if err != nil {
+				t.Fatalf("build serial aggregate: %v", err)
+			}
+			parallel, err := BuildAggregateByMonth(context.Background(), entries, test.grouping, categoryLabels, test.workers)
+			if err != nil {
+				t.Fatalf("build aggregate by month: %v", err)
+			}
+			if !reflect.DeepEqual(serial, parallel) {
+				t.Fatalf("aggregate by month differs from serial\nserial:   %+v\nparallel: %+v", serial, parallel)
+			}
+		})
+	}
+}
+
+func TestAggregateStateMergeTotals(t *testing.T) {
+	t.Parallel()
+
+	entries := overlappingMonthEntries()
+	SortEntriesDeterministic(entries)
+
+	months := splitEntriesByMonth(entries)
+	if len(months) != 3 {
+		t.Fatalf("expected 3 months, got %d", len(months))
+	}
+	merged := newAggregateState()
+	if err := merged.addAll(months[0], "month"); err != nil {
+		t.Fatalf("add first month: %v", err)
+	}
+	for _, month := range months[1:] {
+		state := newAggregateState()
+		if err := state.addAll(month, "month"); err != nil {
+			t.Fatalf("add month: %v", err)
+		}
+		merged.merge(state)
+	}
+	result := merged.result("month", categoryLabels)
+
+	assertCurrencyTotals(t, "earnings", result.Earnings.ByCurrency, []domain.CurrencyTotal{
+		{CurrencyCode: "ARS", TotalMinor: 200000},
+		{CurrencyCode: "USD", TotalMinor: 1000000},
+	})
+	// The February refund of entry 3 takes 100.00 off January's card spend.
+	assertCurrencyTotals(t, "spending", result.Spending.ByCurrency, []domain.CurrencyTotal{
+		{CurrencyCode: "ARS", TotalMinor: 1350000},
+		{CurrencyCode: "USD", TotalMinor: 40000},
+	})
+	assertCurrencyTotals(t, "credit", result.PaymentMethods.Totals.Credit, []domain.CurrencyTotal{
+		{CurrencyCode: "USD", TotalMinor: 28000},
+	})
+	assertCurrencyTotals(t, "cash", result.PaymentMethods.Totals.Cash, []domain.CurrencyTotal{
+		{CurrencyCode: "ARS", TotalMinor: 450000},
+		{CurrencyCode: "USD", TotalMinor: 12000},
+	})
+
+	spendByCategory := map[string]int64{}
+	for _, category := range result.Spending.Categories {
+		spendByCategory[category.CategoryKey+"/"+category.CurrencyCode] += category.TotalMinor
+	}
+	for key, want := range map[string]int64{"category:2/USD": 40000, "category:3/ARS": 450000} {
+		if got := spendByCategory[key]; got != want {
+			t.Fatalf("expected spending %d for category %s, got %d (%+v)", want, key, got, result.Spending.Categories)
+		}
+	}
+
+	people := map[string]domain.ReportPersonTotal{}
+	for _, person := range result.ByPerson {
+		people[person.Person+"/"+person.CurrencyCode] = person
+	}
+	if ana := people["Ana/USD"]; ana.EntryCount != 3 || ana.EarningsMinor != 1000000 || ana.SpendingMinor != 12000 {
+		t.Fatalf("expected Ana's USD totals merged under the first spelling, got %+v", result.ByPerson)
+	}
+	if ben := people["Ben/USD"]; ben.EntryCount != 3 || ben.SpendingMinor != 28000 || ben.NetMinor != -28000 {
+		t.Fatalf("expected Ben's USD totals merged across months, got %+v", result.ByPerson)
+	}
+	if len(result.ByPerson) != 3 {
+		t.Fatalf("expected one person row per name and currency, got %+v", result.ByPerson)
+	}
+
+	if len(result.BySource) != 2 || result.BySource[1].Source != "Acme" || result.BySource[1].TotalMinor != 1000000 || result.BySource[1].EntryCount != 2 {
+		t.Fatalf("expected Acme merged across months under its first spelling, got %+v", result.BySource)
+	}
+
+	serial, err := BuildAggregate(entries, "month", categoryLabels)
+	if err != nil {
+		t.Fatalf("build serial aggregate: %v", err)
+	}
+	if !reflect.DeepEqual(serial, result) {
+		t.Fatalf("merged state differs from serial aggregate\nserial: %+v\nmerged: %+v", serial, result)
+	}
+}
+
+func TestBuildAggregateByMonthRejectsUnknownGrouping(t *testing.T) {
+	t.Parallel()
+
+	_, err := BuildAggregateByMonth(context.Background(), overlappingMonthEntries(), "fortnight", categoryLabels, 4)
+	if err == nil {
+		t.Fatalf("expected an error for an unknown grouping")
+	}
+}
+
+func assertCurrencyTotals(t *testing.T, name string, got, want []domain.CurrencyTotal) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected %s totals: expected %+v, got %+v", name, want, got)
+	}
+}
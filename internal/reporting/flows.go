@@ -0,0 +1,99 @@
+package reporting
+
+import (
+	"boring-budget/internal/domain"
+)
+
+type categoryInstrumentKey struct {
+	Category   categoryCurrencyKey
+	Instrument paymentInstrumentKey
+}
+
+// BuildFlowGraph links income categories to the budget, the budget to
+// spending categories and spending categories to payment instruments, using
+// the same category and instrument totals as BuildAggregate. Links whose net
+// total is not positive (fully refunded spending) are left out.
+func BuildFlowGraph(entries []domain.Entry, categoryLabelResolver CategoryLabelResolver) domain.FlowGraph {
+	earnCategories := map[categoryCurrencyKey]int64{}
+	spendCategories := map[categoryCurrencyKey]int64{}
+	categoryInstruments := map[categoryInstrumentKey]int64{}
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case domain.EntryTypeIncome:
+			earnCategories[toCategoryCurrencyKey(entry)] += entry.AmountMinor
+		case domain.EntryTypeExpense:
+			amountMinor := entry.EffectiveAmountMinor()
+			spendCategories[toCategoryCurrencyKey(entry)] += amountMinor
+			categoryInstruments[categoryInstrumentKey{
+				Category:   toCategoryCurrencyKey(entry),
+				Instrument: toPaymentInstrumentKey(entry),
+			}] += amountMinor
+		}
+	}
+
+	graph := domain.FlowGraph{Nodes: []domain.FlowNode{}, Links: []domain.FlowLink{}}
+	seen := map[string]bool{}
+	addNode := func(node domain.FlowNode) {
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+	addLink := func(source, target, currencyCode string, valueMinor int64) {
+		if valueMinor > 0 {
+			graph.Links = append(graph.Links, domain.FlowLink{Source: source, Target: target, CurrencyCode: currencyCode, ValueMinor: valueMinor})
+		}
+	}
+
+	earnTotals := mapCategoryTotals(earnCategories, categoryLabelResolver)
+	spendTotals := mapCategoryTotals(spendCategories, categoryLabelResolver)
+	if len(earnTotals) > 0 || len(spendTotals) > 0 {
+		addNode(domain.FlowNode{ID: domain.FlowBudgetNodeID, Label: "Budget", Kind: domain.FlowNodeKindBudget})
+	}
+
+	for _, total := range earnTotals {
+		if total.TotalMinor <= 0 {
+			continue
+		}
+		id := "income:" + total.CategoryKey
+		addNode(domain.FlowNode{ID: id, Label: total.CategoryLabel, Kind: domain.FlowNodeKindIncomeSource})
+		addLink(id, domain.FlowBudgetNodeID, total.CurrencyCode, total.TotalMinor)
+	}
+
+	for _, total := range spendTotals {
+		if total.TotalMinor <= 0 {
+			continue
+		}
+		id := "spending:" + total.CategoryKey
+		addNode(domain.FlowNode{ID: id, Label: total.CategoryLabel, Kind: domain.FlowNodeKindCategory})
+		addLink(domain.FlowBudgetNodeID, id, total.CurrencyCode, total.TotalMinor)
+	}
+
+	for _, total := range spendTotals {
+		if total.TotalMinor <= 0 {
+			continue
+		}
+		category := categoryCurrencyKey{CurrencyCode: total.CurrencyCode}
+		if total.CategoryID != nil {
+			category.CategoryID = *total.CategoryID
+			category.HasCategory = true
+		}
+
+		instruments := map[paymentInstrumentKey]int64{}
+		for key, value := range categoryInstruments {
+			if key.Category == category {
+				instruments[key.Instrument] = value
+			}
+		}
+		for _, instrument := range mapPaymentInstrumentTotals(instruments) {
+			id := "payment:" + instrument.InstrumentKey
+			if instrument.TotalMinor > 0 {
+				addNode(domain.FlowNode{ID: id, Label: instrument.InstrumentLabel, Kind: domain.FlowNodeKindPaymentMethod})
+			}
+			addLink("spending:"+total.CategoryKey, id, instrument.CurrencyCode, instrument.TotalMinor)
+		}
+	}
+
+	return graph
+}
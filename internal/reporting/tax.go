@@ -0,0 +1,119 @@
+package reporting
+
+import (
+	"sort"
+
+	"boring-budget/internal/domain"
+)
+
+type taxCategoryKey struct {
+	CategoryID   int64
+	HasCategory  bool
+	CurrencyCode string
+}
+
+// BuildTaxSummary totals the selected entries per category and currency and
+// per currency. Entries are expected in deterministic order; refunds count
+// against expenses.
+func BuildTaxSummary(entries []domain.Entry, categoryNames, labelNames map[int64]string) domain.TaxSummary {
+	summary := domain.TaxSummary{
+		Totals:     []domain.TaxCurrencyTotal{},
+		Categories: []domain.TaxCategoryTotal{},
+		Entries:    make([]domain.TaxSummaryEntry, 0, len(entries)),
+	}
+
+	categoryTotals := map[taxCategoryKey]*domain.TaxCategoryTotal{}
+	currencyTotals := map[string]*domain.TaxCurrencyTotal{}
+
+	for _, entry := range entries {
+		key := taxCategoryKey{CurrencyCode: entry.CurrencyCode}
+		categoryName := ""
+		if entry.CategoryID != nil {
+			key.CategoryID = *entry.CategoryID
+			key.HasCategory = true
+			categoryName = categoryNames[*entry.CategoryID]
+		}
+
+		categoryTotal, ok := categoryTotals[key]
+		if !ok {
+			categoryTotal = &domain.TaxCategoryTotal{
+				CategoryID:   entry.CategoryID,
+				CategoryName: categoryName,
+				CurrencyCode: entry.CurrencyCode,
+			}
+			categoryTotals[key] = categoryTotal
+		}
+		currencyTotal, ok := currencyTotals[entry.CurrencyCode]
+		if !ok {
+			currencyTotal = &domain.TaxCurrencyTotal{CurrencyCode: entry.CurrencyCode}
+			currencyTotals[entry.CurrencyCode] = currencyTotal
+		}
+
+		switch entry.Type {
+		case domain.EntryTypeIncome:
+			categoryTotal.IncomeMinor += entry.AmountMinor
+			currencyTotal.IncomeMinor += entry.AmountMinor
+		case domain.EntryTypeExpense:
+			categoryTotal.ExpenseMinor += entry.EffectiveAmountMinor()
+			currencyTotal.ExpenseMinor += entry.EffectiveAmountMinor()
+		}
+		categoryTotal.EntryCount++
+		currencyTotal.EntryCount++
+
+		labels := make([]string, 0, len(entry.LabelIDs))
+		for _, labelID := range entry.LabelIDs {
+			if name, ok := labelNames[labelID]; ok {
+				labels = append(labels, name)
+			}
+		}
+		sort.Strings(labels)
+
+		summary.Entries = append(summary.Entries, domain.TaxSummaryEntry{
+			ID:                 entry.ID,
+			TransactionDateUTC: entry.TransactionDateUTC,
+			Type:               entry.Type,
+			AmountMinor:        entry.AmountMinor,
+			CurrencyCode:       entry.CurrencyCode,
+			CategoryID:         entry.CategoryID,
+			CategoryName:       categoryName,
+			Labels:             labels,
+			RefundOfEntryID:    entry.RefundOfEntryID,
+			Payee:              entry.Payee,
+			Note:               entry.Note,
+		})
+	}
+
+	for _, total := range categoryTotals {
+		summary.Categories = append(summary.Categories, *total)
+	}
+	sort.Slice(summary.Categories, func(i, j int) bool {
+		left, right := summary.Categories[i], summary.Categories[j]
+		if left.CurrencyCode != right.CurrencyCode {
+			return left.CurrencyCode < right.CurrencyCode
+		}
+		if left.CategoryName != right.CategoryName {
+			return left.CategoryName < right.CategoryName
+		}
+		return optionalIDLess(left.CategoryID, right.CategoryID)
+	})
+
+	for _, total := range currencyTotals {
+		summary.Totals = append(summary.Totals, *total)
+	}
+	sort.Slice(summary.Totals, func(i, j int) bool {
+		return summary.Totals[i].CurrencyCode < summary.Totals[j].CurrencyCode
+	})
+
+	return summary
+}
+
+func optionalIDLess(left, right *int64) bool {
+	switch {
+	case left == nil:
+		return right != nil
+	case right == nil:
+		return false
+	default:
+		return *left < *right
+	}
+}
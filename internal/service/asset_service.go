@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+type AssetRepository interface {
+	Add(ctx context.Context, input domain.AssetAddInput) (domain.Asset, error)
+	GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Asset, error)
+	List(ctx context.Context, filter domain.AssetListFilter) ([]domain.Asset, error)
+	Update(ctx context.Context, input domain.AssetUpdateInput) (domain.Asset, error)
+	Delete(ctx context.Context, id int64) (domain.AssetDeleteResult, error)
+}
+
+type AssetService struct {
+	repo AssetRepository
+}
+
+func NewAssetService(repo AssetRepository) (*AssetService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("asset service: repo is required")
+	}
+	return &AssetService{repo: repo}, nil
+}
+
+func (s *AssetService) Add(ctx context.Context, input domain.AssetAddInput) (domain.Asset, error) {
+	normalized, err := domain.NormalizeAssetAddInput(input)
+	if err != nil {
+		return domain.Asset{}, err
+	}
+
+	return s.repo.Add(ctx, normalized)
+}
+
+func (s *AssetService) Get(ctx context.Context, id int64) (domain.Asset, error) {
+	if err := domain.ValidateAssetID(id); err != nil {
+		return domain.Asset{}, err
+	}
+	return s.repo.GetByID(ctx, id, false)
+}
+
+func (s *AssetService) List(ctx context.Context, filter domain.AssetListFilter) ([]domain.Asset, error) {
+	kind, err := domain.NormalizeOptionalAssetKind(filter.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, domain.AssetListFilter{Kind: kind, IncludeDeleted: filter.IncludeDeleted})
+}
+
+func (s *AssetService) Update(ctx context.Context, input domain.AssetUpdateInput) (domain.Asset, error) {
+	if err := domain.ValidateAssetID(input.ID); err != nil {
+		return domain.Asset{}, err
+	}
+	if input.Name == nil && input.BalanceMinor == nil && input.Note == nil {
+		return domain.Asset{}, domain.ErrNoAssetUpdateFields
+	}
+
+	normalized := domain.AssetUpdateInput{ID: input.ID}
+	if input.Name != nil {
+		value, err := domain.NormalizeAssetName(*input.Name)
+		if err != nil {
+			return domain.Asset{}, err
+		}
+		normalized.Name = &value
+	}
+	if input.BalanceMinor != nil {
+		if err := domain.ValidateAssetBalance(*input.BalanceMinor); err != nil {
+			return domain.Asset{}, err
+		}
+		value := *input.BalanceMinor
+		normalized.BalanceMinor = &value
+	}
+	if input.Note != nil {
+		value := strings.TrimSpace(*input.Note)
+		normalized.Note = &value
+	}
+
+	return s.repo.Update(ctx, normalized)
+}
+
+func (s *AssetService) Delete(ctx context.Context, id int64) (domain.AssetDeleteResult, error) {
+	if err := domain.ValidateAssetID(id); err != nil {
+		return domain.AssetDeleteResult{}, err
+	}
+	return s.repo.Delete(ctx, id)
+}
@@ -14,9 +14,21 @@ type BalanceEntryReader interface {
 	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
 }
 
+// BalanceMonthTotalsReader reads maintained per-month, per-currency entry
+// totals.
+type BalanceMonthTotalsReader interface {
+	MonthTotals(ctx context.Context, filter domain.EntryMonthTotalsFilter) ([]domain.EntryMonthTotal, error)
+}
+
+type BalanceInflationIndexReader interface {
+	GetOnOrBefore(ctx context.Context, currencyCode, indexDate string) (domain.InflationIndexPoint, error)
+}
+
 type BalanceService struct {
-	entryReader BalanceEntryReader
-	fxConverter BalanceFXConverter
+	entryReader       BalanceEntryReader
+	fxConverter       BalanceFXConverter
+	monthTotalsReader BalanceMonthTotalsReader
+	indexReader       BalanceInflationIndexReader
 }
 
 type BalanceRequest struct {
@@ -27,7 +39,9 @@ type BalanceRequest struct {
 	CategoryID      *int64
 	LabelIDs        []int64
 	LabelMode       string
+	CurrencyCode    string
 	ConvertTo       string
+	RevalueAsOf     string
 }
 
 type BalanceFXConverter interface {
@@ -42,6 +56,22 @@ func WithBalanceFXConverter(converter BalanceFXConverter) BalanceServiceOption {
 	}
 }
 
+// WithBalanceMonthTotals answers unconverted balances filtered at most by
+// currency and whole months from month totals instead of reading entries.
+func WithBalanceMonthTotals(reader BalanceMonthTotalsReader) BalanceServiceOption {
+	return func(s *BalanceService) {
+		s.monthTotalsReader = reader
+	}
+}
+
+// WithBalanceInflationIndexReader enables RevalueAsOf, which restates the
+// per-currency nets in as-of-date terms from imported inflation indexes.
+func WithBalanceInflationIndexReader(reader BalanceInflationIndexReader) BalanceServiceOption {
+	return func(s *BalanceService) {
+		s.indexReader = reader
+	}
+}
+
 func NewBalanceService(entryReader BalanceEntryReader, opts ...BalanceServiceOption) (*BalanceService, error) {
 	if entryReader == nil {
 		return nil, fmt.Errorf("balance service: entry reader is required")
@@ -83,6 +113,13 @@ func (s *BalanceService) Compute(ctx context.Context, req BalanceRequest) (domai
 	if err := domain.ValidateDateRange(fromUTC, toUTC); err != nil {
 		return domain.BalanceViews{}, err
 	}
+	currencyCode := ""
+	if strings.TrimSpace(req.CurrencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(req.CurrencyCode)
+		if err != nil {
+			return domain.BalanceViews{}, err
+		}
+	}
 
 	includeLifetime := req.IncludeLifetime
 	includeRange := req.IncludeRange
@@ -106,77 +143,91 @@ func (s *BalanceService) Compute(ctx context.Context, req BalanceRequest) (domai
 
 	if includeLifetime {
 		lifetimeFilter := domain.EntryListFilter{
-			CategoryID: req.CategoryID,
-			LabelIDs:   normalizedLabelIDs,
-			LabelMode:  normalizedLabelMode,
+			CategoryID:   req.CategoryID,
+			LabelIDs:     normalizedLabelIDs,
+			LabelMode:    normalizedLabelMode,
+			CurrencyCode: currencyCode,
 		}
 
-		netByCurrency, err := s.netByCurrency(ctx, lifetimeFilter)
+		lifetime, warnings, err := s.balanceView(ctx, lifetimeFilter, req.RevalueAsOf)
 		if err != nil {
 			return domain.BalanceViews{}, err
 		}
-		views.Lifetime = &domain.BalanceView{ByCurrency: netByCurrency}
+		views.Lifetime = &lifetime
+		views.Warnings = warnings
 
 		if targetCurrency != "" {
-			converted, usedEstimate, err := s.convertNetByFilter(ctx, lifetimeFilter, targetCurrency)
+			converted, err := s.convertNetByFilter(ctx, lifetimeFilter, targetCurrency, req.RevalueAsOf)
 			if err != nil {
 				return domain.BalanceViews{}, err
 			}
-			views.LifetimeConverted = &domain.ConvertedBalanceView{
-				TargetCurrency:   targetCurrency,
-				NetMinor:         converted,
-				UsedEstimateRate: usedEstimate,
-			}
+			views.LifetimeConverted = &converted
 		}
 	}
 
 	if includeRange {
 		rangeFilter := domain.EntryListFilter{
-			CategoryID:  req.CategoryID,
-			DateFromUTC: fromUTC,
-			DateToUTC:   toUTC,
-			LabelIDs:    normalizedLabelIDs,
-			LabelMode:   normalizedLabelMode,
+			CategoryID:   req.CategoryID,
+			DateFromUTC:  fromUTC,
+			DateToUTC:    toUTC,
+			LabelIDs:     normalizedLabelIDs,
+			LabelMode:    normalizedLabelMode,
+			CurrencyCode: currencyCode,
 		}
 
-		netByCurrency, err := s.netByCurrency(ctx, rangeFilter)
+		rangeView, warnings, err := s.balanceView(ctx, rangeFilter, req.RevalueAsOf)
 		if err != nil {
 			return domain.BalanceViews{}, err
 		}
-		views.Range = &domain.BalanceView{ByCurrency: netByCurrency}
+		views.Range = &rangeView
+		// The lifetime entries include the range ones, so its warnings
+		// already cover them.
+		if !includeLifetime {
+			views.Warnings = warnings
+		}
 
 		if targetCurrency != "" {
-			converted, usedEstimate, err := s.convertNetByFilter(ctx, rangeFilter, targetCurrency)
+			converted, err := s.convertNetByFilter(ctx, rangeFilter, targetCurrency, req.RevalueAsOf)
 			if err != nil {
 				return domain.BalanceViews{}, err
 			}
-			views.RangeConverted = &domain.ConvertedBalanceView{
-				TargetCurrency:   targetCurrency,
-				NetMinor:         converted,
-				UsedEstimateRate: usedEstimate,
-			}
+			views.RangeConverted = &converted
 		}
 	}
 
 	return views, nil
 }
 
-func (s *BalanceService) netByCurrency(ctx context.Context, filter domain.EntryListFilter) ([]domain.CurrencyNet, error) {
+// balanceView sums the per-currency net of the filter, restated in
+// revalueAsOf terms when it is set.
+func (s *BalanceService) balanceView(ctx context.Context, filter domain.EntryListFilter, revalueAsOf string) (domain.BalanceView, []domain.Warning, error) {
+	if strings.TrimSpace(revalueAsOf) == "" {
+		totals, err := s.netTotals(ctx, filter)
+		if err != nil {
+			return domain.BalanceView{}, nil, err
+		}
+		return domain.BalanceView{ByCurrency: netByCurrency(totals)}, nil, nil
+	}
+
+	if s.indexReader == nil {
+		return domain.BalanceView{}, nil, domain.ErrInflationIndexUnavailable
+	}
 	entries, err := s.entryReader.List(ctx, filter)
 	if err != nil {
-		return nil, err
+		return domain.BalanceView{}, nil, err
 	}
-
-	totals := map[string]int64{}
-	for _, entry := range entries {
-		switch entry.Type {
-		case domain.EntryTypeIncome:
-			totals[entry.CurrencyCode] += entry.AmountMinor
-		case domain.EntryTypeExpense:
-			totals[entry.CurrencyCode] -= entry.AmountMinor
-		}
+	revalued, revaluation, warnings, err := revalueEntries(ctx, s.indexReader, entries, revalueAsOf)
+	if err != nil {
+		return domain.BalanceView{}, nil, err
 	}
 
+	return domain.BalanceView{
+		ByCurrency:  netByCurrency(netTotalsOfEntries(revalued)),
+		Revaluation: &revaluation,
+	}, warnings, nil
+}
+
+func netByCurrency(totals map[string]int64) []domain.CurrencyNet {
 	currencies := make([]string, 0, len(totals))
 	for currency := range totals {
 		currencies = append(currencies, currency)
@@ -190,7 +241,40 @@ func (s *BalanceService) netByCurrency(ctx context.Context, filter domain.EntryL
 			NetMinor:     totals[currency],
 		})
 	}
-	return output, nil
+	return output
+}
+
+// netTotals sums the net per currency from month totals when the filter
+// allows it, and from the matching entries otherwise.
+func (s *BalanceService) netTotals(ctx context.Context, filter domain.EntryListFilter) (map[string]int64, error) {
+	if s.monthTotalsReader != nil {
+		if totalsFilter, ok := domain.MonthTotalsFilterFor(filter); ok {
+			monthTotals, err := s.monthTotalsReader.MonthTotals(ctx, totalsFilter)
+			if err != nil {
+				return nil, err
+			}
+			return domain.NetByCurrencyFromMonthTotals(monthTotals), nil
+		}
+	}
+
+	entries, err := s.entryReader.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return netTotalsOfEntries(entries), nil
+}
+
+func netTotalsOfEntries(entries []domain.Entry) map[string]int64 {
+	totals := map[string]int64{}
+	for _, entry := range entries {
+		switch entry.Type {
+		case domain.EntryTypeIncome:
+			totals[entry.CurrencyCode] += entry.AmountMinor
+		case domain.EntryTypeExpense:
+			totals[entry.CurrencyCode] -= entry.EffectiveAmountMinor()
+		}
+	}
+	return totals
 }
 
 func normalizeRangeBoundary(raw string, endOfDay bool) (string, error) {
@@ -217,31 +301,45 @@ func normalizeRangeBoundary(raw string, endOfDay bool) (string, error) {
 	return dateOnly.UTC().Format(time.RFC3339Nano), nil
 }
 
-func (s *BalanceService) convertNetByFilter(ctx context.Context, filter domain.EntryListFilter, targetCurrency string) (int64, bool, error) {
+// convertNetByFilter converts each matching entry at its date's rate, after
+// restating it in revalueAsOf terms when that is set.
+func (s *BalanceService) convertNetByFilter(ctx context.Context, filter domain.EntryListFilter, targetCurrency, revalueAsOf string) (domain.ConvertedBalanceView, error) {
 	entries, err := s.entryReader.List(ctx, filter)
 	if err != nil {
-		return 0, false, err
+		return domain.ConvertedBalanceView{}, err
+	}
+	if strings.TrimSpace(revalueAsOf) != "" {
+		entries, _, _, err = revalueEntries(ctx, s.indexReader, entries, revalueAsOf)
+		if err != nil {
+			return domain.ConvertedBalanceView{}, err
+		}
 	}
 
-	netMinor := int64(0)
-	usedEstimate := false
+	view := domain.ConvertedBalanceView{TargetCurrency: targetCurrency}
 	for _, entry := range entries {
 		converted, err := s.fxConverter.Convert(ctx, entry.AmountMinor, entry.CurrencyCode, targetCurrency, entry.TransactionDateUTC)
 		if err != nil {
-			return 0, false, err
+			return domain.ConvertedBalanceView{}, err
 		}
 
 		if converted.Snapshot.IsEstimate {
-			usedEstimate = true
+			view.UsedEstimateRate = true
+		}
+		if converted.Stale {
+			view.UsedStaleRate = true
 		}
 
 		switch entry.Type {
 		case domain.EntryTypeIncome:
-			netMinor += converted.AmountMinor
+			view.NetMinor += converted.AmountMinor
 		case domain.EntryTypeExpense:
-			netMinor -= converted.AmountMinor
+			if entry.IsRefund() {
+				view.NetMinor += converted.AmountMinor
+			} else {
+				view.NetMinor -= converted.AmountMinor
+			}
 		}
 	}
 
-	return netMinor, usedEstimate, nil
+	return view, nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -174,3 +175,140 @@ func TestBalanceServiceComputeSupportsConvertedViews(t *testing.T) {
 		t.Fatalf("expected converted view to signal estimate usage")
 	}
 }
+
+type balanceMonthTotalsReaderStub struct {
+	filters []domain.EntryMonthTotalsFilter
+	totals  []domain.EntryMonthTotal
+}
+
+func (s *balanceMonthTotalsReaderStub) MonthTotals(ctx context.Context, filter domain.EntryMonthTotalsFilter) ([]domain.EntryMonthTotal, error) {
+	s.filters = append(s.filters, filter)
+	return s.totals, nil
+}
+
+func TestBalanceServiceComputeReadsMonthTotalsForWholeMonths(t *testing.T) {
+	t.Parallel()
+
+	var listed []domain.EntryListFilter
+	monthTotals := &balanceMonthTotalsReaderStub{totals: []domain.EntryMonthTotal{
+		{MonthKey: "2026-01", CurrencyCode: "USD", IncomeMinor: 10000, ExpenseMinor: 3000, EntryCount: 2},
+		{MonthKey: "2026-02", CurrencyCode: "USD", IncomeMinor: 4000, ExpenseMinor: 1000, EntryCount: 2},
+		{MonthKey: "2026-02", CurrencyCode: "EUR", ExpenseMinor: 500, EntryCount: 1},
+	}}
+	svc, err := NewBalanceService(&balanceEntryReaderStub{
+		listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+			listed = append(listed, filter)
+			return []domain.Entry{
+				{ID: 4, Type: domain.EntryTypeIncome, AmountMinor: 4000, CurrencyCode: "USD", TransactionDateUTC: "2026-02-10T00:00:00Z"},
+			}, nil
+		},
+	}, WithBalanceMonthTotals(monthTotals))
+	if err != nil {
+		t.Fatalf("new balance service: %v", err)
+	}
+
+	result, err := svc.Compute(context.Background(), BalanceRequest{
+		RangeFromUTC: "2026-02-01",
+		RangeToUTC:   "2026-02-28",
+	})
+	if err != nil {
+		t.Fatalf("compute balance: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("expected whole-month balances not to read entries, got %d list calls", len(listed))
+	}
+	expectedFilters := []domain.EntryMonthTotalsFilter{{}, {FromMonth: "2026-02", ToMonth: "2026-02"}}
+	if !reflect.DeepEqual(monthTotals.filters, expectedFilters) {
+		t.Fatalf("unexpected month totals filters: %+v", monthTotals.filters)
+	}
+	expectedLifetime := []domain.CurrencyNet{{CurrencyCode: "EUR", NetMinor: -500}, {CurrencyCode: "USD", NetMinor: 10000}}
+	if !reflect.DeepEqual(result.Lifetime.ByCurrency, expectedLifetime) {
+		t.Fatalf("unexpected lifetime balance: %+v", result.Lifetime.ByCurrency)
+	}
+
+	if _, err := svc.Compute(context.Background(), BalanceRequest{
+		IncludeRange: true,
+		RangeFromUTC: "2026-02-01",
+		RangeToUTC:   "2026-02-15",
+	}); err != nil {
+		t.Fatalf("compute partial-month balance: %v", err)
+	}
+	if _, err := svc.Compute(context.Background(), BalanceRequest{
+		IncludeLifetime: true,
+		LabelIDs:        []int64{3},
+	}); err != nil {
+		t.Fatalf("compute labelled balance: %v", err)
+	}
+	if len(listed) != 2 || len(monthTotals.filters) != 2 {
+		t.Fatalf("expected partial months and label filters to scan entries, got %d list calls and %d month totals reads", len(listed), len(monthTotals.filters))
+	}
+}
+
+func TestBalanceServiceComputeRevaluesNetByInflationIndex(t *testing.T) {
+	t.Parallel()
+
+	svc, err := NewBalanceService(
+		&balanceEntryReaderStub{
+			listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+				return []domain.Entry{
+					{ID: 1, Type: domain.EntryTypeIncome, AmountMinor: 10000, CurrencyCode: "ARS", TransactionDateUTC: "2026-02-01T00:00:00Z"},
+					{ID: 2, Type: domain.EntryTypeExpense, AmountMinor: 3000, CurrencyCode: "ARS", TransactionDateUTC: "2026-02-15T00:00:00Z"},
+					{ID: 3, Type: domain.EntryTypeExpense, AmountMinor: 700, CurrencyCode: "USD", TransactionDateUTC: "2026-02-20T00:00:00Z"},
+				}, nil
+			},
+		},
+		WithBalanceMonthTotals(&balanceMonthTotalsReaderStub{}),
+		WithBalanceFXConverter(&balanceFXConverterStub{
+			convertFn: func(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
+				return domain.ConvertedAmount{AmountMinor: amountMinor}, nil
+			},
+		}),
+		WithBalanceInflationIndexReader(&reportInflationIndexReaderStub{
+			points: map[string][]domain.InflationIndexPoint{
+				"ARS": {
+					{CurrencyCode: "ARS", IndexDate: "2026-02-01", IndexValue: "100"},
+					{CurrencyCode: "ARS", IndexDate: "2026-02-15", IndexValue: "120"},
+					{CurrencyCode: "ARS", IndexDate: "2026-03-31", IndexValue: "150"},
+				},
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("new balance service: %v", err)
+	}
+
+	result, err := svc.Compute(context.Background(), BalanceRequest{
+		IncludeLifetime: true,
+		ConvertTo:       "USD",
+		RevalueAsOf:     "2026-04-01",
+	})
+	if err != nil {
+		t.Fatalf("compute revalued balance: %v", err)
+	}
+
+	expected := []domain.CurrencyNet{{CurrencyCode: "ARS", NetMinor: 11250}, {CurrencyCode: "USD", NetMinor: -700}}
+	if !reflect.DeepEqual(result.Lifetime.ByCurrency, expected) {
+		t.Fatalf("unexpected revalued lifetime balance: %+v", result.Lifetime.ByCurrency)
+	}
+	if result.LifetimeConverted == nil || result.LifetimeConverted.NetMinor != 10550 {
+		t.Fatalf("expected the converted net to sum the revalued rows, got %+v", result.LifetimeConverted)
+	}
+	if result.Lifetime.Revaluation == nil || result.Lifetime.Revaluation.AsOfDate != "2026-04-01" {
+		t.Fatalf("expected revaluation summary, got %+v", result.Lifetime.Revaluation)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != domain.WarningCodeInflationIndexUnavailable {
+		t.Fatalf("expected one INFLATION_INDEX_UNAVAILABLE warning, got %+v", result.Warnings)
+	}
+
+	unindexed, err := NewBalanceService(&balanceEntryReaderStub{
+		listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("new balance service: %v", err)
+	}
+	if _, err := unindexed.Compute(context.Background(), BalanceRequest{RevalueAsOf: "2026-04-01"}); !errors.Is(err, domain.ErrInflationIndexUnavailable) {
+		t.Fatalf("expected ErrInflationIndexUnavailable without an index reader, got %v", err)
+	}
+}
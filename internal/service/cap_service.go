@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"boring-budget/internal/domain"
 )
@@ -14,6 +17,27 @@ type CapRepository interface {
 	GetExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey, currencyCode string) (int64, error)
 }
 
+// CapCategoryRepository is optionally implemented by a CapRepository that
+// stores per-category caps.
+type CapCategoryRepository interface {
+	GetCategoryCap(ctx context.Context, monthKey string, categoryID int64) (domain.MonthlyCap, error)
+	ListCategoryCapsByMonth(ctx context.Context, monthKey string) ([]domain.MonthlyCap, error)
+	GetCategoryExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey string, categoryID int64, currencyCode string) (int64, error)
+}
+
+// CapRoller is optionally implemented by a CapRepository that can copy a
+// month's caps into another month in one transaction.
+type CapRoller interface {
+	Roll(ctx context.Context, fromMonth, toMonth string) (domain.CapRollResult, error)
+}
+
+// CapStatusResult lists a month's cap statuses, global cap first, with a
+// CAP_AT_RISK warning for each projection above its cap.
+type CapStatusResult struct {
+	Statuses []domain.CapStatus
+	Warnings []domain.Warning
+}
+
 type CapService struct {
 	repo CapRepository
 }
@@ -30,9 +54,119 @@ func (s *CapService) Set(ctx context.Context, input domain.CapSetInput) (domain.
 	if err != nil {
 		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
 	}
+	if normalized.CategoryID != nil {
+		if _, err := s.categoryRepo(); err != nil {
+			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+		}
+	}
 	return s.repo.Set(ctx, normalized)
 }
 
+// CopyPrevious sets the month's cap (or a category cap) to the previous
+// month's amount and currency, recording the source month in cap history.
+func (s *CapService) CopyPrevious(ctx context.Context, monthKey string, categoryID *int64) (domain.MonthlyCap, domain.MonthlyCapChange, error) {
+	previousMonth, err := domain.PreviousMonthKey(monthKey)
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+	}
+
+	var previous domain.MonthlyCap
+	if categoryID != nil {
+		previous, err = s.ShowCategory(ctx, previousMonth, *categoryID)
+	} else {
+		previous, err = s.repo.GetByMonth(ctx, previousMonth)
+	}
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+	}
+
+	return s.Set(ctx, domain.CapSetInput{
+		MonthKey:           monthKey,
+		CategoryID:         categoryID,
+		AmountMinor:        previous.AmountMinor,
+		CurrencyCode:       previous.CurrencyCode,
+		CopiedFromMonthKey: &previousMonth,
+	})
+}
+
+// Roll copies fromMonth's global and category caps into toMonth, keeping any
+// cap toMonth already has.
+func (s *CapService) Roll(ctx context.Context, fromMonth, toMonth string) (domain.CapRollResult, error) {
+	normalizedFrom, err := domain.NormalizeMonthKey(fromMonth)
+	if err != nil {
+		return domain.CapRollResult{}, err
+	}
+	normalizedTo, err := domain.NormalizeMonthKey(toMonth)
+	if err != nil {
+		return domain.CapRollResult{}, err
+	}
+	if normalizedFrom == normalizedTo {
+		return domain.CapRollResult{}, domain.ErrCapRollSameMonth
+	}
+
+	roller, ok := s.repo.(CapRoller)
+	if !ok {
+		return domain.CapRollResult{}, fmt.Errorf("cap service: repo does not support cap roll")
+	}
+	return roller.Roll(ctx, normalizedFrom, normalizedTo)
+}
+
+// ShowCategory returns the cap for one category in a month.
+func (s *CapService) ShowCategory(ctx context.Context, monthKey string, categoryID int64) (domain.MonthlyCap, error) {
+	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return domain.MonthlyCap{}, err
+	}
+	if err := domain.ValidateOptionalCategoryID(&categoryID); err != nil {
+		return domain.MonthlyCap{}, err
+	}
+	repo, err := s.categoryRepo()
+	if err != nil {
+		return domain.MonthlyCap{}, err
+	}
+	return repo.GetCategoryCap(ctx, normalizedMonth, categoryID)
+}
+
+// CategoryCaps lists the category caps set for a month; repos without
+// category caps report none.
+func (s *CapService) CategoryCaps(ctx context.Context, monthKey string) ([]domain.MonthlyCap, error) {
+	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return nil, err
+	}
+	repo, ok := s.repo.(CapCategoryRepository)
+	if !ok {
+		return []domain.MonthlyCap{}, nil
+	}
+	return repo.ListCategoryCapsByMonth(ctx, normalizedMonth)
+}
+
+func (s *CapService) CategoryExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey string, categoryID int64, currencyCode string) (int64, error) {
+	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return 0, err
+	}
+
+	normalizedCurrency, err := domain.NormalizeCurrencyCode(currencyCode)
+	if err != nil {
+		return 0, err
+	}
+
+	repo, err := s.categoryRepo()
+	if err != nil {
+		return 0, err
+	}
+	return repo.GetCategoryExpenseTotalByMonthAndCurrency(ctx, normalizedMonth, categoryID, normalizedCurrency)
+}
+
+func (s *CapService) categoryRepo() (CapCategoryRepository, error) {
+	repo, ok := s.repo.(CapCategoryRepository)
+	if !ok {
+		return nil, fmt.Errorf("cap service: repo does not support category caps")
+	}
+	return repo, nil
+}
+
 func (s *CapService) Show(ctx context.Context, monthKey string) (domain.MonthlyCap, error) {
 	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
 	if err != nil {
@@ -62,3 +196,92 @@ func (s *CapService) ExpenseTotalByMonthAndCurrency(ctx context.Context, monthKe
 
 	return s.repo.GetExpenseTotalByMonthAndCurrency(ctx, normalizedMonth, normalizedCurrency)
 }
+
+// Status reports spend-to-date and a month-end projection, as of asOf, for the
+// month's global cap and every category cap, or only categoryID's cap when
+// set. A non-empty currencyCode keeps only caps in that currency. It fails
+// with ErrCapNotFound when the month has no matching cap.
+func (s *CapService) Status(ctx context.Context, monthKey string, categoryID *int64, currencyCode string, asOf time.Time) (CapStatusResult, error) {
+	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return CapStatusResult{}, err
+	}
+	if err := domain.ValidateOptionalCategoryID(categoryID); err != nil {
+		return CapStatusResult{}, err
+	}
+	if strings.TrimSpace(currencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(currencyCode)
+		if err != nil {
+			return CapStatusResult{}, err
+		}
+	}
+	inCurrency := func(capValue domain.MonthlyCap) bool {
+		return currencyCode == "" || capValue.CurrencyCode == currencyCode
+	}
+
+	type capSpend struct {
+		cap   domain.MonthlyCap
+		spend int64
+	}
+	caps := []capSpend{}
+
+	if categoryID == nil {
+		globalCap, err := s.repo.GetByMonth(ctx, normalizedMonth)
+		switch {
+		case err == nil && !inCurrency(globalCap):
+		case err == nil:
+			spend, err := s.repo.GetExpenseTotalByMonthAndCurrency(ctx, normalizedMonth, globalCap.CurrencyCode)
+			if err != nil {
+				return CapStatusResult{}, err
+			}
+			caps = append(caps, capSpend{cap: globalCap, spend: spend})
+		case !errors.Is(err, domain.ErrCapNotFound):
+			return CapStatusResult{}, err
+		}
+	}
+
+	if categoryRepo, ok := s.repo.(CapCategoryRepository); ok {
+		categoryCaps := []domain.MonthlyCap{}
+		if categoryID != nil {
+			categoryCap, err := categoryRepo.GetCategoryCap(ctx, normalizedMonth, *categoryID)
+			if err != nil {
+				return CapStatusResult{}, err
+			}
+			categoryCaps = append(categoryCaps, categoryCap)
+		} else {
+			categoryCaps, err = categoryRepo.ListCategoryCapsByMonth(ctx, normalizedMonth)
+			if err != nil {
+				return CapStatusResult{}, err
+			}
+		}
+		for _, categoryCap := range categoryCaps {
+			if !inCurrency(categoryCap) {
+				continue
+			}
+			spend, err := categoryRepo.GetCategoryExpenseTotalByMonthAndCurrency(ctx, normalizedMonth, *categoryCap.CategoryID, categoryCap.CurrencyCode)
+			if err != nil {
+				return CapStatusResult{}, err
+			}
+			caps = append(caps, capSpend{cap: categoryCap, spend: spend})
+		}
+	} else if categoryID != nil {
+		return CapStatusResult{}, fmt.Errorf("cap service: repo does not support category caps")
+	}
+
+	if len(caps) == 0 {
+		return CapStatusResult{}, domain.ErrCapNotFound
+	}
+
+	result := CapStatusResult{Statuses: make([]domain.CapStatus, 0, len(caps)), Warnings: []domain.Warning{}}
+	for _, item := range caps {
+		status, err := domain.ProjectCapStatus(item.cap, item.spend, asOf)
+		if err != nil {
+			return CapStatusResult{}, err
+		}
+		result.Statuses = append(result.Statuses, status)
+		if status.IsAtRisk {
+			result.Warnings = append(result.Warnings, domain.CapAtRiskWarning(status))
+		}
+	}
+	return result, nil
+}
@@ -38,11 +38,36 @@ func (e *CardLookupConflictError) Unwrap() error {
 	return domain.ErrCardLookupAmbiguous
 }
 
+// CardOutstandingDebtError refuses to delete a card while any of its debt
+// buckets is not settled.
+type CardOutstandingDebtError struct {
+	CardID  int64
+	Buckets []domain.CardDebtBalance
+}
+
+func (e *CardOutstandingDebtError) Error() string {
+	if e == nil {
+		return domain.ErrCardHasOutstandingDebt.Error()
+	}
+	return fmt.Sprintf("%s: card %d", domain.ErrCardHasOutstandingDebt.Error(), e.CardID)
+}
+
+func (e *CardOutstandingDebtError) Unwrap() error {
+	return domain.ErrCardHasOutstandingDebt
+}
+
 type CardDebtCardSummary struct {
 	Card    domain.Card              `json:"card"`
 	Buckets []domain.CardDebtBalance `json:"buckets"`
 }
 
+type CardDebtHistory struct {
+	Card     domain.Card                    `json:"card"`
+	Grouping string                         `json:"grouping"`
+	Events   []domain.CardDebtHistoryEvent  `json:"events"`
+	Periods  []domain.CardDebtHistoryPeriod `json:"periods"`
+}
+
 type CardPaymentResult struct {
 	Card    domain.Card               `json:"card"`
 	Event   domain.CardLiabilityEvent `json:"event"`
@@ -74,13 +99,21 @@ func (s *CardService) Add(ctx context.Context, input domain.CardAddInput) (domai
 		dueDay = &value
 	}
 
+	var defaultCurrency *string
+	if normalized.DefaultCurrencyCode != "" {
+		value := normalized.DefaultCurrencyCode
+		defaultCurrency = &value
+	}
+
 	card, err := s.repo.AddCard(ctx, ports.CardCreateInput{
-		Nickname:    normalized.Nickname,
-		Description: description,
-		Last4:       normalized.Last4,
-		Brand:       normalized.Brand,
-		CardType:    normalized.CardType,
-		DueDay:      dueDay,
+		Nickname:            normalized.Nickname,
+		Description:         description,
+		Last4:               normalized.Last4,
+		Brand:               normalized.Brand,
+		CardType:            normalized.CardType,
+		DueDay:              dueDay,
+		DefaultCurrencyCode: defaultCurrency,
+		FXFeeBasisPoints:    normalized.FXFeeBasisPoints,
 	})
 	if err != nil {
 		return domain.Card{}, mapCardRepoError(err)
@@ -90,6 +123,11 @@ func (s *CardService) Add(ctx context.Context, input domain.CardAddInput) (domai
 }
 
 func (s *CardService) List(ctx context.Context, filter domain.CardListFilter) ([]domain.Card, error) {
+	sortKey, err := domain.NormalizeListSort(filter.Sort, domain.CardSortKeys)
+	if err != nil {
+		return nil, err
+	}
+
 	lookup := strings.TrimSpace(filter.Lookup)
 	if lookup != "" {
 		normalizedLookup, err := domain.NormalizeCardLookupText(lookup)
@@ -97,17 +135,19 @@ func (s *CardService) List(ctx context.Context, filter domain.CardListFilter) ([
 			return nil, err
 		}
 
-		matches, err := s.repo.SearchCards(ctx, normalizedLookup, cardLookupSearchLimit)
+		matches, err := s.repo.SearchCards(ctx, normalizedLookup, filter.IncludeArchived, cardLookupSearchLimit)
 		if err != nil {
 			return nil, mapCardRepoError(err)
 		}
 		cards := fromPortsCards(matches)
-		sortCardsDeterministic(cards)
+		sortCardsBy(cards, sortKey)
 		return cards, nil
 	}
 
 	listFilter := ports.CardListFilter{
-		IncludeDeleted: filter.IncludeDeleted,
+		IncludeDeleted:  filter.IncludeDeleted,
+		IncludeArchived: filter.IncludeArchived,
+		Sort:            sortKey,
 	}
 	if strings.TrimSpace(filter.CardType) != "" {
 		cardType, err := domain.NormalizeCardType(filter.CardType)
@@ -122,11 +162,13 @@ func (s *CardService) List(ctx context.Context, filter domain.CardListFilter) ([
 		return nil, mapCardRepoError(err)
 	}
 
-	result := fromPortsCards(cards)
-	sortCardsDeterministic(result)
-	return result, nil
+	return fromPortsCards(cards), nil
 }
 
+// Resolve finds the card a selector names. Nicknames and lookups only match
+// unarchived cards; an explicit id also resolves an archived one. Aliases
+// count as nicknames, and a lookup matching several cards still resolves when
+// exactly one of them has it as its nickname or an alias.
 func (s *CardService) Resolve(ctx context.Context, selector domain.CardSelector) (domain.Card, error) {
 	normalizedSelector, err := domain.NormalizeCardSelector(selector)
 	if err != nil {
@@ -142,17 +184,10 @@ func (s *CardService) Resolve(ctx context.Context, selector domain.CardSelector)
 	}
 
 	if normalizedSelector.Nickname != "" {
-		cards, err := s.repo.ListCards(ctx, ports.CardListFilter{IncludeDeleted: false})
+		matches, err := s.repo.FindCardsByName(ctx, normalizedSelector.Nickname, false)
 		if err != nil {
 			return domain.Card{}, mapCardRepoError(err)
 		}
-
-		matches := make([]ports.Card, 0, 1)
-		for _, card := range cards {
-			if strings.EqualFold(card.Nickname, normalizedSelector.Nickname) {
-				matches = append(matches, card)
-			}
-		}
 		if len(matches) == 0 {
 			return domain.Card{}, domain.ErrCardNotFound
 		}
@@ -167,7 +202,7 @@ func (s *CardService) Resolve(ctx context.Context, selector domain.CardSelector)
 		return fromPortsCard(matches[0]), nil
 	}
 
-	matches, err := s.repo.SearchCards(ctx, normalizedSelector.Lookup, cardLookupSearchLimit)
+	matches, err := s.repo.SearchCards(ctx, normalizedSelector.Lookup, false, cardLookupSearchLimit)
 	if err != nil {
 		return domain.Card{}, mapCardRepoError(err)
 	}
@@ -175,6 +210,14 @@ func (s *CardService) Resolve(ctx context.Context, selector domain.CardSelector)
 		return domain.Card{}, domain.ErrCardNotFound
 	}
 	if len(matches) > 1 {
+		exact, err := s.repo.FindCardsByName(ctx, normalizedSelector.Lookup, false)
+		if err != nil {
+			return domain.Card{}, mapCardRepoError(err)
+		}
+		if len(exact) == 1 {
+			return fromPortsCard(exact[0]), nil
+		}
+
 		candidates := fromPortsCards(matches)
 		sortCardsDeterministic(candidates)
 		return domain.Card{}, &CardLookupConflictError{
@@ -199,8 +242,13 @@ func (s *CardService) Update(ctx context.Context, input domain.CardUpdateInput)
 	}
 	current := fromPortsCard(currentRaw)
 
+	expectedUpdatedAtUTC, err := domain.NormalizeExpectedUpdatedAtUTC(input.ExpectedUpdatedAtUTC)
+	if err != nil {
+		return domain.Card{}, err
+	}
 	normalized := ports.CardUpdateInput{
-		ID: input.ID,
+		ID:                   input.ID,
+		ExpectedUpdatedAtUTC: expectedUpdatedAtUTC,
 	}
 
 	finalType := current.CardType
@@ -263,6 +311,28 @@ func (s *CardService) Update(ctx context.Context, input domain.CardUpdateInput)
 		}
 	}
 
+	if input.SetDefaultCurrency {
+		value, err := domain.NormalizeCardDefaultCurrency(input.DefaultCurrencyCode)
+		if err != nil {
+			return domain.Card{}, err
+		}
+		normalized.SetDefaultCurrency = true
+		if value != "" {
+			normalized.DefaultCurrencyCode = &value
+		}
+	}
+
+	if input.SetFXFee {
+		if input.FXFeeBasisPoints != nil {
+			if err := domain.ValidateCardFXFee(*input.FXFeeBasisPoints); err != nil {
+				return domain.Card{}, err
+			}
+			value := *input.FXFeeBasisPoints
+			normalized.FXFeeBasisPoints = &value
+		}
+		normalized.SetFXFee = true
+	}
+
 	if finalType == domain.CardTypeCredit && finalDueDay == nil {
 		return domain.Card{}, domain.ErrCardDueDayRequiredForCredit
 	}
@@ -277,11 +347,55 @@ func (s *CardService) Update(ctx context.Context, input domain.CardUpdateInput)
 	return fromPortsCard(card), nil
 }
 
-func (s *CardService) Delete(ctx context.Context, id int64) (domain.CardDeleteResult, error) {
+// Archive hides a card from nickname and lookup selectors and from listings
+// while keeping its entries and debt history.
+func (s *CardService) Archive(ctx context.Context, id int64) (domain.Card, error) {
+	return s.setArchived(ctx, id, true)
+}
+
+// Unarchive makes an archived card selectable again.
+func (s *CardService) Unarchive(ctx context.Context, id int64) (domain.Card, error) {
+	return s.setArchived(ctx, id, false)
+}
+
+func (s *CardService) setArchived(ctx context.Context, id int64, archived bool) (domain.Card, error) {
+	if err := domain.ValidateCardID(id); err != nil {
+		return domain.Card{}, err
+	}
+
+	card, err := s.repo.SetCardArchived(ctx, id, archived)
+	if err != nil {
+		return domain.Card{}, mapCardRepoError(err)
+	}
+	return fromPortsCard(card), nil
+}
+
+// Delete soft-deletes a card. Unless force is set, a card with any unsettled
+// debt bucket is refused with a *CardOutstandingDebtError.
+func (s *CardService) Delete(ctx context.Context, id int64, force bool) (domain.CardDeleteResult, error) {
 	if err := domain.ValidateCardID(id); err != nil {
 		return domain.CardDeleteResult{}, err
 	}
 
+	if !force {
+		if _, err := s.repo.GetCardByID(ctx, id, false); err != nil {
+			return domain.CardDeleteResult{}, mapCardRepoError(err)
+		}
+		buckets, err := s.repo.GetDebtSummaryByCard(ctx, id)
+		if err != nil {
+			return domain.CardDeleteResult{}, mapCardRepoError(err)
+		}
+		outstanding := make([]domain.CardDebtBalance, 0, len(buckets))
+		for _, bucket := range fromPortsDebtBuckets(buckets) {
+			if bucket.BalanceMinorSigned != 0 {
+				outstanding = append(outstanding, bucket)
+			}
+		}
+		if len(outstanding) > 0 {
+			return domain.CardDeleteResult{}, &CardOutstandingDebtError{CardID: id, Buckets: outstanding}
+		}
+	}
+
 	result, err := s.repo.DeleteCard(ctx, id)
 	if err != nil {
 		return domain.CardDeleteResult{}, mapCardRepoError(err)
@@ -370,7 +484,18 @@ func (s *CardService) ShowDebtByCard(ctx context.Context, id int64) (CardDebtCar
 }
 
 func (s *CardService) ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, error) {
-	rows, err := s.repo.GetDebtSummary(ctx)
+	return s.ShowDebtAllSorted(ctx, domain.CardDebtSortID)
+}
+
+// ShowDebtAllSorted keeps the card order of the debt summary rows, so cards
+// sorted by balance are ranked by their largest bucket.
+func (s *CardService) ShowDebtAllSorted(ctx context.Context, sortKey string) ([]CardDebtCardSummary, error) {
+	normalizedSort, err := domain.NormalizeListSort(sortKey, domain.CardDebtSortKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetDebtSummary(ctx, normalizedSort)
 	if err != nil {
 		return nil, mapCardRepoError(err)
 	}
@@ -379,13 +504,18 @@ func (s *CardService) ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, e
 		return []CardDebtCardSummary{}, nil
 	}
 
-	cardIDs := make(map[int64]struct{}, len(rows))
+	cardIDs := make([]int64, 0, len(rows))
+	seen := make(map[int64]struct{}, len(rows))
 	for _, row := range rows {
-		cardIDs[row.CardID] = struct{}{}
+		if _, ok := seen[row.CardID]; ok {
+			continue
+		}
+		seen[row.CardID] = struct{}{}
+		cardIDs = append(cardIDs, row.CardID)
 	}
 
 	cardByID := make(map[int64]domain.Card, len(cardIDs))
-	for cardID := range cardIDs {
+	for _, cardID := range cardIDs {
 		cardRaw, err := s.repo.GetCardByID(ctx, cardID, false)
 		if err != nil {
 			return nil, mapCardRepoError(err)
@@ -403,7 +533,8 @@ func (s *CardService) ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, e
 	}
 
 	out := make([]CardDebtCardSummary, 0, len(cardIDs))
-	for cardID, buckets := range summaryByCard {
+	for _, cardID := range cardIDs {
+		buckets := summaryByCard[cardID]
 		sort.Slice(buckets, func(i, j int) bool {
 			return buckets[i].CurrencyCode < buckets[j].CurrencyCode
 		})
@@ -412,13 +543,56 @@ func (s *CardService) ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, e
 			Buckets: buckets,
 		})
 	}
-
-	sort.Slice(out, func(i, j int) bool {
-		return out[i].Card.ID < out[j].Card.ID
-	})
 	return out, nil
 }
 
+func (s *CardService) DebtHistory(ctx context.Context, id int64, currencyCode string, grouping string) (CardDebtHistory, error) {
+	if err := domain.ValidateCardID(id); err != nil {
+		return CardDebtHistory{}, err
+	}
+
+	normalizedCurrency := ""
+	if strings.TrimSpace(currencyCode) != "" {
+		normalized, err := domain.NormalizeCurrencyCode(currencyCode)
+		if err != nil {
+			return CardDebtHistory{}, err
+		}
+		normalizedCurrency = normalized
+	}
+
+	normalizedGrouping, err := domain.NormalizeReportGrouping(grouping)
+	if err != nil {
+		return CardDebtHistory{}, err
+	}
+
+	cardRaw, err := s.repo.GetCardByID(ctx, id, false)
+	if err != nil {
+		return CardDebtHistory{}, mapCardRepoError(err)
+	}
+
+	rows, err := s.repo.ListLiabilityEvents(ctx, id, normalizedCurrency)
+	if err != nil {
+		return CardDebtHistory{}, mapCardRepoError(err)
+	}
+
+	events := make([]domain.CardLiabilityEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, fromPortsLiabilityEvent(row))
+	}
+
+	history, periods, err := domain.BuildCardDebtHistory(events, normalizedGrouping)
+	if err != nil {
+		return CardDebtHistory{}, err
+	}
+
+	return CardDebtHistory{
+		Card:     fromPortsCard(cardRaw),
+		Grouping: normalizedGrouping,
+		Events:   history,
+		Periods:  periods,
+	}, nil
+}
+
 func (s *CardService) AddPayment(ctx context.Context, input domain.CardPaymentAddInput) (CardPaymentResult, error) {
 	if err := domain.ValidateCardID(input.CardID); err != nil {
 		return CardPaymentResult{}, err
@@ -473,13 +647,228 @@ func (s *CardService) AddPayment(ctx context.Context, input domain.CardPaymentAd
 	}, nil
 }
 
+func (s *CardService) SetLimit(ctx context.Context, input domain.CardLimitSetInput) (domain.CardMonthlyLimit, error) {
+	if err := domain.ValidateCardID(input.CardID); err != nil {
+		return domain.CardMonthlyLimit{}, err
+	}
+	monthKey, err := domain.NormalizeMonthKey(input.MonthKey)
+	if err != nil {
+		return domain.CardMonthlyLimit{}, err
+	}
+	if input.AmountMinor <= 0 {
+		return domain.CardMonthlyLimit{}, domain.ErrInvalidCardLimitAmount
+	}
+	currencyCode, err := domain.NormalizeCurrencyCode(input.CurrencyCode)
+	if err != nil {
+		return domain.CardMonthlyLimit{}, err
+	}
+
+	if _, err := s.repo.GetCardByID(ctx, input.CardID, false); err != nil {
+		return domain.CardMonthlyLimit{}, mapCardRepoError(err)
+	}
+
+	limit, err := s.repo.SetMonthlyLimit(ctx, ports.CardMonthlyLimitSetInput{
+		CardID:       input.CardID,
+		MonthKey:     monthKey,
+		AmountMinor:  input.AmountMinor,
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return domain.CardMonthlyLimit{}, mapCardRepoError(err)
+	}
+	return fromPortsCardMonthlyLimit(limit), nil
+}
+
+// LimitStatus reports the card's spend in monthKey against that month's limit.
+func (s *CardService) LimitStatus(ctx context.Context, cardID int64, monthKey string) (domain.CardLimitStatus, error) {
+	if err := domain.ValidateCardID(cardID); err != nil {
+		return domain.CardLimitStatus{}, err
+	}
+	normalizedMonth, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return domain.CardLimitStatus{}, err
+	}
+
+	limit, err := s.repo.GetMonthlyLimit(ctx, cardID, normalizedMonth)
+	if err != nil {
+		return domain.CardLimitStatus{}, mapCardRepoError(err)
+	}
+	monthStartUTC, monthEndUTC, err := domain.MonthRangeUTC(normalizedMonth)
+	if err != nil {
+		return domain.CardLimitStatus{}, err
+	}
+	spend, err := s.repo.GetCardExpenseTotal(ctx, cardID, limit.CurrencyCode, monthStartUTC, monthEndUTC)
+	if err != nil {
+		return domain.CardLimitStatus{}, mapCardRepoError(err)
+	}
+
+	return domain.CardLimitStatus{
+		Limit:          fromPortsCardMonthlyLimit(limit),
+		SpendMinor:     spend,
+		RemainingMinor: limit.AmountMinor - spend,
+		Exceeded:       spend > limit.AmountMinor,
+	}, nil
+}
+
+func (s *CardService) ListLimits(ctx context.Context, cardID int64) ([]domain.CardMonthlyLimit, error) {
+	if err := domain.ValidateCardID(cardID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListMonthlyLimits(ctx, cardID)
+	if err != nil {
+		return nil, mapCardRepoError(err)
+	}
+	out := make([]domain.CardMonthlyLimit, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, fromPortsCardMonthlyLimit(row))
+	}
+	return out, nil
+}
+
+// AddAlias gives a card another name for selectors. An alias already used as
+// the nickname or alias of a different card fails with a
+// CardLookupConflictError naming those cards, archived ones included.
+func (s *CardService) AddAlias(ctx context.Context, cardID int64, alias string) (domain.CardAlias, error) {
+	if err := domain.ValidateCardID(cardID); err != nil {
+		return domain.CardAlias{}, err
+	}
+	normalized, err := domain.NormalizeCardAlias(alias)
+	if err != nil {
+		return domain.CardAlias{}, err
+	}
+
+	if _, err := s.repo.GetCardByID(ctx, cardID, false); err != nil {
+		return domain.CardAlias{}, mapCardRepoError(err)
+	}
+
+	owners, err := s.repo.FindCardsByName(ctx, normalized, true)
+	if err != nil {
+		return domain.CardAlias{}, mapCardRepoError(err)
+	}
+	others := make([]ports.Card, 0, len(owners))
+	for _, owner := range owners {
+		if owner.ID != cardID {
+			others = append(others, owner)
+		}
+	}
+	if len(others) > 0 {
+		candidates := fromPortsCards(others)
+		sortCardsDeterministic(candidates)
+		return domain.CardAlias{}, &CardLookupConflictError{
+			Lookup:     normalized,
+			Candidates: candidates,
+		}
+	}
+	if len(owners) > 0 {
+		return domain.CardAlias{}, domain.ErrCardAliasExists
+	}
+
+	created, err := s.repo.AddCardAlias(ctx, cardID, normalized)
+	if err != nil {
+		return domain.CardAlias{}, mapCardRepoError(err)
+	}
+	return fromPortsCardAlias(created), nil
+}
+
+func (s *CardService) RemoveAlias(ctx context.Context, cardID int64, alias string) (domain.CardAlias, error) {
+	if err := domain.ValidateCardID(cardID); err != nil {
+		return domain.CardAlias{}, err
+	}
+	normalized, err := domain.NormalizeCardAlias(alias)
+	if err != nil {
+		return domain.CardAlias{}, err
+	}
+
+	if _, err := s.repo.GetCardByID(ctx, cardID, false); err != nil {
+		return domain.CardAlias{}, mapCardRepoError(err)
+	}
+
+	removed, err := s.repo.RemoveCardAlias(ctx, cardID, normalized)
+	if err != nil {
+		return domain.CardAlias{}, mapCardRepoError(err)
+	}
+	return fromPortsCardAlias(removed), nil
+}
+
+// ListAliases lists the aliases of every active card, or only of cardID when
+// it is set.
+func (s *CardService) ListAliases(ctx context.Context, cardID *int64) ([]domain.CardAlias, error) {
+	if cardID != nil {
+		if err := domain.ValidateCardID(*cardID); err != nil {
+			return nil, err
+		}
+		if _, err := s.repo.GetCardByID(ctx, *cardID, false); err != nil {
+			return nil, mapCardRepoError(err)
+		}
+	}
+
+	rows, err := s.repo.ListCardAliases(ctx, cardID)
+	if err != nil {
+		return nil, mapCardRepoError(err)
+	}
+	out := make([]domain.CardAlias, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, fromPortsCardAlias(row))
+	}
+	return out, nil
+}
+
+// FXFees totals the foreign transaction fees charged to credit cards per
+// card, currency and period, by the date of the entries that caused them.
+func (s *CardService) FXFees(ctx context.Context, input domain.CardFXFeeReportInput) (domain.CardFXFeeReport, error) {
+	grouping, err := domain.NormalizeReportGrouping(input.Grouping)
+	if err != nil {
+		return domain.CardFXFeeReport{}, err
+	}
+	if input.FromUTC == "" || input.ToUTC == "" {
+		return domain.CardFXFeeReport{}, domain.ErrInvalidDateRange
+	}
+	if err := domain.ValidateDateRange(input.FromUTC, input.ToUTC); err != nil {
+		return domain.CardFXFeeReport{}, err
+	}
+	if input.CardID != nil {
+		if _, err := s.repo.GetCardByID(ctx, *input.CardID, false); err != nil {
+			return domain.CardFXFeeReport{}, mapCardRepoError(err)
+		}
+	}
+
+	rows, err := s.repo.ListFXFeeCharges(ctx, input.FromUTC, input.ToUTC, input.CardID)
+	if err != nil {
+		return domain.CardFXFeeReport{}, mapCardRepoError(err)
+	}
+	charges := make([]domain.CardFXFeeCharge, 0, len(rows))
+	for _, row := range rows {
+		charges = append(charges, domain.CardFXFeeCharge{
+			CardID:             row.CardID,
+			CardNickname:       row.CardNickname,
+			CurrencyCode:       row.CurrencyCode,
+			FeeMinor:           row.FeeMinor,
+			TransactionDateUTC: row.TransactionDateUTC,
+		})
+	}
+	totals, err := domain.BuildCardFXFeeTotals(charges, grouping)
+	if err != nil {
+		return domain.CardFXFeeReport{}, err
+	}
+
+	return domain.CardFXFeeReport{
+		FromUTC:  input.FromUTC,
+		ToUTC:    input.ToUTC,
+		Grouping: grouping,
+		Totals:   totals,
+	}, nil
+}
+
 func hasCardUpdateInputChanges(input domain.CardUpdateInput) bool {
 	return input.Nickname != nil ||
 		input.SetDescription ||
 		input.Last4 != nil ||
 		input.Brand != nil ||
 		input.CardType != nil ||
-		input.SetDueDay
+		input.SetDueDay ||
+		input.SetDefaultCurrency ||
+		input.SetFXFee
 }
 
 func normalizeAsOfDate(value string) (string, error) {
@@ -505,14 +894,15 @@ func normalizeAsOfDate(value string) (string, error) {
 
 func fromPortsCard(card ports.Card) domain.Card {
 	out := domain.Card{
-		ID:           card.ID,
-		Nickname:     card.Nickname,
-		Last4:        card.Last4,
-		Brand:        card.Brand,
-		CardType:     card.CardType,
-		CreatedAtUTC: card.CreatedAtUTC,
-		UpdatedAtUTC: card.UpdatedAtUTC,
-		DeletedAtUTC: card.DeletedAtUTC,
+		ID:            card.ID,
+		Nickname:      card.Nickname,
+		Last4:         card.Last4,
+		Brand:         card.Brand,
+		CardType:      card.CardType,
+		CreatedAtUTC:  card.CreatedAtUTC,
+		UpdatedAtUTC:  card.UpdatedAtUTC,
+		DeletedAtUTC:  card.DeletedAtUTC,
+		ArchivedAtUTC: card.ArchivedAtUTC,
 	}
 	if card.Description != nil {
 		out.Description = *card.Description
@@ -521,6 +911,13 @@ func fromPortsCard(card ports.Card) domain.Card {
 		value := int(*card.DueDay)
 		out.DueDay = &value
 	}
+	if card.DefaultCurrencyCode != nil {
+		out.DefaultCurrencyCode = *card.DefaultCurrencyCode
+	}
+	if card.FXFeeBasisPoints != nil {
+		value := *card.FXFeeBasisPoints
+		out.FXFeeBasisPoints = &value
+	}
 	return out
 }
 
@@ -546,6 +943,37 @@ func sortCardsDeterministic(cards []domain.Card) {
 	})
 }
 
+// sortCardsBy mirrors the ListCards ordering for results that do not come
+// from it, such as lookup matches.
+func sortCardsBy(cards []domain.Card, sortKey string) {
+	sortCardsDeterministic(cards)
+	switch sortKey {
+	case domain.CardSortID:
+		sort.SliceStable(cards, func(i, j int) bool {
+			return cards[i].ID < cards[j].ID
+		})
+	case domain.CardSortCreated:
+		sort.SliceStable(cards, func(i, j int) bool {
+			if cards[i].CreatedAtUTC != cards[j].CreatedAtUTC {
+				return cards[i].CreatedAtUTC < cards[j].CreatedAtUTC
+			}
+			return cards[i].ID < cards[j].ID
+		})
+	case domain.CardSortDueDay:
+		sort.SliceStable(cards, func(i, j int) bool {
+			return cardDueDayRank(cards[i]) < cardDueDayRank(cards[j])
+		})
+	}
+}
+
+// cardDueDayRank places cards without a due day after every credit card.
+func cardDueDayRank(card domain.Card) int {
+	if card.DueDay == nil {
+		return 99
+	}
+	return *card.DueDay
+}
+
 func fromPortsLiabilityEvent(event ports.CreditLiabilityEvent) domain.CardLiabilityEvent {
 	out := domain.CardLiabilityEvent{
 		ID:                     event.ID,
@@ -553,6 +981,7 @@ func fromPortsLiabilityEvent(event ports.CreditLiabilityEvent) domain.CardLiabil
 		CurrencyCode:           event.CurrencyCode,
 		EventType:              event.EventType,
 		AmountMinorSigned:      event.AmountMinorSigned,
+		FXFeeMinor:             event.FXFeeMinor,
 		CreatedAtUTC:           event.CreatedAtUTC,
 		ReferenceTransactionID: event.ReferenceTransactionID,
 	}
@@ -562,6 +991,27 @@ func fromPortsLiabilityEvent(event ports.CreditLiabilityEvent) domain.CardLiabil
 	return out
 }
 
+func fromPortsCardMonthlyLimit(limit ports.CardMonthlyLimit) domain.CardMonthlyLimit {
+	return domain.CardMonthlyLimit{
+		ID:           limit.ID,
+		CardID:       limit.CardID,
+		MonthKey:     limit.MonthKey,
+		AmountMinor:  limit.AmountMinor,
+		CurrencyCode: limit.CurrencyCode,
+		CreatedAtUTC: limit.CreatedAtUTC,
+		UpdatedAtUTC: limit.UpdatedAtUTC,
+	}
+}
+
+func fromPortsCardAlias(alias ports.CardAlias) domain.CardAlias {
+	return domain.CardAlias{
+		ID:           alias.ID,
+		CardID:       alias.CardID,
+		Alias:        alias.Alias,
+		CreatedAtUTC: alias.CreatedAtUTC,
+	}
+}
+
 func fromPortsDebtBuckets(rows []ports.CardDebtBucket) []domain.CardDebtBalance {
 	if len(rows) == 0 {
 		return []domain.CardDebtBalance{}
@@ -595,6 +1045,8 @@ func mapCardRepoError(err error) error {
 		return domain.ErrCardNicknameConflict
 	case errors.Is(err, ports.ErrCardNotFound):
 		return domain.ErrCardNotFound
+	case errors.Is(err, ports.ErrCardUpdateConflict):
+		return domain.ErrUpdateConflict
 	case errors.Is(err, ports.ErrCardInvalidLast4):
 		return domain.ErrCardLast4Invalid
 	case errors.Is(err, ports.ErrCardBrandRequired):
@@ -615,6 +1067,18 @@ func mapCardRepoError(err error) error {
 		return domain.ErrInvalidCurrencyCode
 	case errors.Is(err, ports.ErrLiabilityAmountInvalid):
 		return domain.ErrInvalidCardPaymentAmount
+	case errors.Is(err, ports.ErrCardMonthlyLimitNotFound):
+		return domain.ErrCardLimitNotFound
+	case errors.Is(err, ports.ErrCardMonthlyLimitAmountInvalid):
+		return domain.ErrInvalidCardLimitAmount
+	case errors.Is(err, ports.ErrCardFXFeeInvalid):
+		return domain.ErrInvalidCardFXFee
+	case errors.Is(err, ports.ErrCardAliasRequired):
+		return domain.ErrInvalidCardAlias
+	case errors.Is(err, ports.ErrCardAliasNotFound):
+		return domain.ErrCardAliasNotFound
+	case errors.Is(err, ports.ErrCardAliasConflict):
+		return domain.ErrCardAliasExists
 	}
 
 	msg := strings.ToLower(err.Error())
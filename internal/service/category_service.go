@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"boring-budget/internal/domain"
 )
@@ -9,7 +10,10 @@ import (
 type CategoryRepository interface {
 	Add(ctx context.Context, name string) (domain.Category, error)
 	List(ctx context.Context) ([]domain.Category, error)
+	ListSorted(ctx context.Context, sortKey string) ([]domain.Category, error)
 	Rename(ctx context.Context, id int64, newName string) (domain.Category, error)
+	SetArchived(ctx context.Context, id int64, archived bool) (domain.Category, error)
+	UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Category, error)
 	SoftDelete(ctx context.Context, id int64) (domain.CategoryDeleteResult, error)
 }
 
@@ -33,6 +37,14 @@ func (s *CategoryService) List(ctx context.Context) ([]domain.Category, error) {
 	return s.repo.List(ctx)
 }
 
+func (s *CategoryService) ListSorted(ctx context.Context, sortKey string) ([]domain.Category, error) {
+	normalized, err := domain.NormalizeListSort(sortKey, domain.CategorySortKeys)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListSorted(ctx, normalized)
+}
+
 func (s *CategoryService) Rename(ctx context.Context, id int64, newName string) (domain.Category, error) {
 	if id <= 0 {
 		return domain.Category{}, domain.ErrInvalidCategoryID
@@ -46,9 +58,107 @@ func (s *CategoryService) Rename(ctx context.Context, id int64, newName string)
 	return s.repo.Rename(ctx, id, normalized)
 }
 
+// Archive hides a category from listings and new entries while keeping it
+// attached to existing entries and reports.
+func (s *CategoryService) Archive(ctx context.Context, id int64) (domain.Category, error) {
+	if id <= 0 {
+		return domain.Category{}, domain.ErrInvalidCategoryID
+	}
+	return s.repo.SetArchived(ctx, id, true)
+}
+
+func (s *CategoryService) Unarchive(ctx context.Context, id int64) (domain.Category, error) {
+	if id <= 0 {
+		return domain.Category{}, domain.ErrInvalidCategoryID
+	}
+	return s.repo.SetArchived(ctx, id, false)
+}
+
+// UpdateAppearance sets the display color and icon used by human output.
+func (s *CategoryService) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Category, error) {
+	if id <= 0 {
+		return domain.Category{}, domain.ErrInvalidCategoryID
+	}
+
+	normalized, err := domain.NormalizeAppearanceUpdate(update)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return s.repo.UpdateAppearance(ctx, id, normalized)
+}
+
 func (s *CategoryService) Delete(ctx context.Context, id int64) (domain.CategoryDeleteResult, error) {
 	if id <= 0 {
 		return domain.CategoryDeleteResult{}, domain.ErrInvalidCategoryID
 	}
 	return s.repo.SoftDelete(ctx, id)
 }
+
+// CategoryBootstrapLabels adds the preset's labels next to its categories.
+type CategoryBootstrapLabels interface {
+	Add(ctx context.Context, name string) (domain.Label, error)
+	List(ctx context.Context) ([]domain.Label, error)
+}
+
+// Bootstrap installs a preset's categories and labels, skipping names that
+// already exist so it can be re-run safely.
+func (s *CategoryService) Bootstrap(ctx context.Context, presetName string, labels CategoryBootstrapLabels) (domain.CategoryBootstrapResult, error) {
+	preset, err := domain.LookupCategoryPreset(presetName)
+	if err != nil {
+		return domain.CategoryBootstrapResult{}, err
+	}
+
+	result := domain.CategoryBootstrapResult{
+		Preset:             preset.Name,
+		CategoriesCreated:  []domain.Category{},
+		CategoriesExisting: []string{},
+		LabelsCreated:      []domain.Label{},
+		LabelsExisting:     []string{},
+	}
+
+	categories, err := s.repo.List(ctx)
+	if err != nil {
+		return domain.CategoryBootstrapResult{}, err
+	}
+	existingCategories := make(map[string]struct{}, len(categories))
+	for _, category := range categories {
+		existingCategories[strings.ToLower(category.Name)] = struct{}{}
+	}
+	for _, name := range preset.Categories {
+		if _, ok := existingCategories[strings.ToLower(name)]; ok {
+			result.CategoriesExisting = append(result.CategoriesExisting, name)
+			continue
+		}
+		category, err := s.repo.Add(ctx, name)
+		if err != nil {
+			return domain.CategoryBootstrapResult{}, err
+		}
+		result.CategoriesCreated = append(result.CategoriesCreated, category)
+	}
+
+	if labels == nil {
+		return result, nil
+	}
+	existingLabels, err := labels.List(ctx)
+	if err != nil {
+		return domain.CategoryBootstrapResult{}, err
+	}
+	existingLabelNames := make(map[string]struct{}, len(existingLabels))
+	for _, label := range existingLabels {
+		existingLabelNames[strings.ToLower(label.Name)] = struct{}{}
+	}
+	for _, name := range preset.Labels {
+		if _, ok := existingLabelNames[strings.ToLower(name)]; ok {
+			result.LabelsExisting = append(result.LabelsExisting, name)
+			continue
+		}
+		label, err := labels.Add(ctx, name)
+		if err != nil {
+			return domain.CategoryBootstrapResult{}, err
+		}
+		result.LabelsCreated = append(result.LabelsCreated, label)
+	}
+
+	return result, nil
+}
@@ -9,10 +9,13 @@ import (
 )
 
 type categoryRepoStub struct {
-	addFn        func(ctx context.Context, name string) (domain.Category, error)
-	listFn       func(ctx context.Context) ([]domain.Category, error)
-	renameFn     func(ctx context.Context, id int64, newName string) (domain.Category, error)
-	softDeleteFn func(ctx context.Context, id int64) (domain.CategoryDeleteResult, error)
+	addFn         func(ctx context.Context, name string) (domain.Category, error)
+	listFn        func(ctx context.Context) ([]domain.Category, error)
+	listSortedFn  func(ctx context.Context, sortKey string) ([]domain.Category, error)
+	renameFn      func(ctx context.Context, id int64, newName string) (domain.Category, error)
+	setArchivedFn func(ctx context.Context, id int64, archived bool) (domain.Category, error)
+	appearanceFn  func(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Category, error)
+	softDeleteFn  func(ctx context.Context, id int64) (domain.CategoryDeleteResult, error)
 }
 
 func (s categoryRepoStub) Add(ctx context.Context, name string) (domain.Category, error) {
@@ -23,10 +26,22 @@ func (s categoryRepoStub) List(ctx context.Context) ([]domain.Category, error) {
 	return s.listFn(ctx)
 }
 
+func (s categoryRepoStub) ListSorted(ctx context.Context, sortKey string) ([]domain.Category, error) {
+	return s.listSortedFn(ctx, sortKey)
+}
+
 func (s categoryRepoStub) Rename(ctx context.Context, id int64, newName string) (domain.Category, error) {
 	return s.renameFn(ctx, id, newName)
 }
 
+func (s categoryRepoStub) SetArchived(ctx context.Context, id int64, archived bool) (domain.Category, error) {
+	return s.setArchivedFn(ctx, id, archived)
+}
+
+func (s categoryRepoStub) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Category, error) {
+	return s.appearanceFn(ctx, id, update)
+}
+
 func (s categoryRepoStub) SoftDelete(ctx context.Context, id int64) (domain.CategoryDeleteResult, error) {
 	return s.softDeleteFn(ctx, id)
 }
@@ -135,3 +150,48 @@ func TestCategoryServiceListReturnsRepoResult(t *testing.T) {
 		t.Fatalf("unexpected categories: %+v", categories)
 	}
 }
+
+func TestCategoryServiceBootstrapSkipsExistingNames(t *testing.T) {
+	t.Parallel()
+
+	addedCategories := []string{}
+	categories := NewCategoryService(categoryRepoStub{
+		listFn: func(ctx context.Context) ([]domain.Category, error) {
+			return []domain.Category{{ID: 1, Name: "food"}}, nil
+		},
+		addFn: func(ctx context.Context, name string) (domain.Category, error) {
+			addedCategories = append(addedCategories, name)
+			return domain.Category{ID: int64(len(addedCategories) + 1), Name: name}, nil
+		},
+	})
+	labels := &labelRepoStub{
+		listFn: func(ctx context.Context) ([]domain.Label, error) {
+			return []domain.Label{{ID: 1, Name: "Recurring"}}, nil
+		},
+		addFn: func(ctx context.Context, name string) (domain.Label, error) {
+			t.Fatalf("unexpected label add %q", name)
+			return domain.Label{}, nil
+		},
+	}
+
+	result, err := categories.Bootstrap(context.Background(), " Minimal ", labels)
+	if err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	if result.Preset != domain.CategoryPresetMinimal {
+		t.Fatalf("expected minimal preset, got %q", result.Preset)
+	}
+	if len(result.CategoriesExisting) != 1 || result.CategoriesExisting[0] != "Food" {
+		t.Fatalf("expected Food to be skipped, got %v", result.CategoriesExisting)
+	}
+	if len(addedCategories) != 5 || len(result.CategoriesCreated) != 5 {
+		t.Fatalf("expected 5 categories created, got %v", addedCategories)
+	}
+	if len(result.LabelsExisting) != 1 || len(result.LabelsCreated) != 0 {
+		t.Fatalf("expected the recurring label to be skipped, got %+v", result)
+	}
+
+	if _, err := categories.Bootstrap(context.Background(), "corporate", labels); !errors.Is(err, domain.ErrInvalidCategoryPreset) {
+		t.Fatalf("expected ErrInvalidCategoryPreset, got %v", err)
+	}
+}
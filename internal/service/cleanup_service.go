@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type CleanupRepository interface {
+	Usage(ctx context.Context) (domain.CleanupUsage, error)
+	Archive(ctx context.Context, categoryIDs []int64, labelIDs []int64, cardIDs []int64, archivedAtUTC string) error
+}
+
+type CleanupService struct {
+	repo CleanupRepository
+}
+
+func NewCleanupService(repo CleanupRepository) (*CleanupService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("cleanup service: repo is required")
+	}
+	return &CleanupService{repo: repo}, nil
+}
+
+// Suggest lists the categories, labels and cards no active entry uses, or
+// none has used within input.UnusedMonths.
+func (s *CleanupService) Suggest(ctx context.Context, input domain.CleanupSuggestInput) (domain.CleanupSuggestion, error) {
+	if err := domain.ValidateCleanupUnusedMonths(input.UnusedMonths); err != nil {
+		return domain.CleanupSuggestion{}, err
+	}
+
+	usage, err := s.repo.Usage(ctx)
+	if err != nil {
+		return domain.CleanupSuggestion{}, err
+	}
+	return domain.BuildCleanupSuggestion(usage, input)
+}
+
+// Apply archives the suggested categories, labels and cards together.
+func (s *CleanupService) Apply(ctx context.Context, suggestion domain.CleanupSuggestion) (domain.CleanupApplyResult, error) {
+	categoryIDs := make([]int64, 0, len(suggestion.Categories))
+	for _, category := range suggestion.Categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+	labelIDs := make([]int64, 0, len(suggestion.Labels))
+	for _, label := range suggestion.Labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+	cardIDs := make([]int64, 0, len(suggestion.Cards))
+	for _, card := range suggestion.Cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	archivedAtUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	if suggestion.Count() > 0 {
+		if err := s.repo.Archive(ctx, categoryIDs, labelIDs, cardIDs, archivedAtUTC); err != nil {
+			return domain.CleanupApplyResult{}, err
+		}
+	}
+
+	return domain.CleanupApplyResult{
+		ArchivedCategories: suggestion.Categories,
+		ArchivedLabels:     suggestion.Labels,
+		ArchivedCards:      suggestion.Cards,
+		ArchivedAtUTC:      archivedAtUTC,
+	}, nil
+}
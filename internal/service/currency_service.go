@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type CurrencyRepository interface {
+	Upsert(ctx context.Context, input domain.CustomCurrencyInput) (domain.CustomCurrency, error)
+	List(ctx context.Context) ([]domain.CustomCurrency, error)
+	Delete(ctx context.Context, code string) (domain.CustomCurrencyDeleteResult, error)
+}
+
+// CurrencyService manages custom currency codes and keeps the in-process
+// registry used by amount parsing and formatting in sync with storage.
+type CurrencyService struct {
+	repo CurrencyRepository
+}
+
+func NewCurrencyService(repo CurrencyRepository) (*CurrencyService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("currency service: repo is required")
+	}
+	return &CurrencyService{repo: repo}, nil
+}
+
+func (s *CurrencyService) Add(ctx context.Context, input domain.CustomCurrencyInput) (domain.CustomCurrency, error) {
+	normalized, err := domain.NormalizeCustomCurrencyInput(input)
+	if err != nil {
+		return domain.CustomCurrency{}, err
+	}
+	if domain.IsISOCurrencyCode(normalized.Code) {
+		return domain.CustomCurrency{}, domain.ErrCurrencyCodeReserved
+	}
+
+	currency, err := s.repo.Upsert(ctx, normalized)
+	if err != nil {
+		return domain.CustomCurrency{}, err
+	}
+	if _, err := s.Load(ctx); err != nil {
+		return domain.CustomCurrency{}, err
+	}
+	return currency, nil
+}
+
+func (s *CurrencyService) List(ctx context.Context) ([]domain.CustomCurrency, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *CurrencyService) Remove(ctx context.Context, code string) (domain.CustomCurrencyDeleteResult, error) {
+	result, err := s.repo.Delete(ctx, code)
+	if err != nil {
+		return domain.CustomCurrencyDeleteResult{}, err
+	}
+	if _, err := s.Load(ctx); err != nil {
+		return domain.CustomCurrencyDeleteResult{}, err
+	}
+	return result, nil
+}
+
+// Load refreshes the in-process registry from storage.
+func (s *CurrencyService) Load(ctx context.Context) ([]domain.CustomCurrency, error) {
+	currencies, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain.SetCustomCurrencies(currencies)
+	return currencies, nil
+}
@@ -2,8 +2,9 @@ package service
 
 import (
 	"context"
-	"errors"
+	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"boring-budget/internal/domain"
@@ -15,17 +16,40 @@ type EntryService struct {
 	capLookup    EntryCapLookup
 	cardResolver EntryCardResolver
 	linkReader   EntryBalanceLinkReader
+	cardLimits   EntryCardLimitLookup
+	fxConverter  EntryFXConverter
+
+	subscriptionAlerts bool
+
+	expandCategories EntryCategoryLister
+	expandLabels     EntryLabelLister
+	expandCards      EntryCardLister
+
+	strictDB       *sql.DB
+	strictWarnings []string
+
+	batchDB *sql.DB
 }
 
 type EntryRepository = ports.EntryRepository
 type EntryCapLookup = ports.EntryCapLookup
 type EntryRepositoryTxBinder = ports.EntryRepositoryTxBinder
 type EntryCapLookupTxBinder = ports.EntryCapLookupTxBinder
+type EntryCardLimitLookup = ports.EntryCardLimitLookup
+type EntryIterator = ports.EntryIterator
+
+type EntryUIDLookup = ports.EntryUIDLookup
+
+type EntryRevisionReader = ports.EntryRevisionReader
 
 type EntryCreditLiabilitySyncer interface {
 	SyncCreditLiabilityCharge(ctx context.Context, entryID int64) error
 }
 
+type EntryCurrencyReplacer interface {
+	ReplaceCurrency(ctx context.Context, fromCurrency, toCurrency string) ([]int64, error)
+}
+
 type EntryCardResolver interface {
 	Resolve(ctx context.Context, selector domain.CardSelector) (domain.Card, error)
 }
@@ -34,6 +58,22 @@ type EntryBalanceLinkReader interface {
 	ListBalanceLinks(ctx context.Context) ([]domain.BalanceAccountLink, error)
 }
 
+type EntryFXConverter interface {
+	Convert(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error)
+}
+
+type EntryCategoryLister interface {
+	List(ctx context.Context) ([]domain.Category, error)
+}
+
+type EntryLabelLister interface {
+	List(ctx context.Context) ([]domain.Label, error)
+}
+
+type EntryCardLister interface {
+	List(ctx context.Context, filter domain.CardListFilter) ([]domain.Card, error)
+}
+
 type EntryServiceOption func(*EntryService)
 
 func WithEntryCapLookup(capLookup EntryCapLookup) EntryServiceOption {
@@ -42,6 +82,22 @@ func WithEntryCapLookup(capLookup EntryCapLookup) EntryServiceOption {
 	}
 }
 
+// WithEntryCardLimitLookup checks card expenses against the card's monthly
+// limit and warns with CARD_LIMIT_EXCEEDED.
+func WithEntryCardLimitLookup(cardLimits EntryCardLimitLookup) EntryServiceOption {
+	return func(service *EntryService) {
+		service.cardLimits = cardLimits
+	}
+}
+
+// WithEntrySubscriptionAlerts checks expenses against the recurring charges
+// in their payee's history and warns with SUBSCRIPTION_PRICE_CHANGED.
+func WithEntrySubscriptionAlerts() EntryServiceOption {
+	return func(service *EntryService) {
+		service.subscriptionAlerts = true
+	}
+}
+
 func WithEntryCardResolver(cardResolver EntryCardResolver) EntryServiceOption {
 	return func(service *EntryService) {
 		service.cardResolver = cardResolver
@@ -54,11 +110,53 @@ func WithEntryBalanceLinkReader(linkReader EntryBalanceLinkReader) EntryServiceO
 	}
 }
 
+// WithEntryFXConverter lets Add record an entry in another currency than the
+// one it was entered in (EntryAddInput.RecordInCurrency).
+func WithEntryFXConverter(converter EntryFXConverter) EntryServiceOption {
+	return func(service *EntryService) {
+		service.fxConverter = converter
+	}
+}
+
+// WithEntryExpansion provides the lookups Expand uses to embed categories,
+// labels, and cards next to entries.
+func WithEntryExpansion(categories EntryCategoryLister, labels EntryLabelLister, cards EntryCardLister) EntryServiceOption {
+	return func(service *EntryService) {
+		service.expandCategories = categories
+		service.expandLabels = labels
+		service.expandCards = cards
+	}
+}
+
+// WithEntryStrictWarnings makes writes that raise one of codes fail with
+// domain.StrictWarningError. The write and its cap checks then run in one
+// transaction on db, which is rolled back instead of committed.
+func WithEntryStrictWarnings(db *sql.DB, codes []string) EntryServiceOption {
+	return func(service *EntryService) {
+		service.strictDB = db
+		service.strictWarnings = codes
+	}
+}
+
+// WithEntryBatchDB lets AddBatch write all rows in one transaction on db.
+func WithEntryBatchDB(db *sql.DB) EntryServiceOption {
+	return func(service *EntryService) {
+		service.batchDB = db
+	}
+}
+
 type EntryAddResult struct {
 	Entry    domain.Entry     `json:"entry"`
 	Warnings []domain.Warning `json:"warnings"`
 }
 
+// EntryBatchResult holds the entries a batch add created, in input order, and
+// the warnings raised while writing them.
+type EntryBatchResult struct {
+	Entries  []domain.Entry
+	Warnings []domain.Warning
+}
+
 func NewEntryService(repo EntryRepository, opts ...EntryServiceOption) (*EntryService, error) {
 	if repo == nil {
 		return nil, fmt.Errorf("entry service: repo is required")
@@ -83,213 +181,573 @@ func (s *EntryService) Add(ctx context.Context, input domain.EntryAddInput) (dom
 }
 
 func (s *EntryService) AddWithWarnings(ctx context.Context, input domain.EntryAddInput) (EntryAddResult, error) {
-	normalizedType, err := domain.NormalizeEntryType(input.Type)
+	normalized, err := s.normalizeAddInput(ctx, input)
 	if err != nil {
 		return EntryAddResult{}, err
 	}
-	if err := domain.ValidateAmountMinor(input.AmountMinor); err != nil {
+
+	result, err := s.writeWithWarnings(ctx, func(repo EntryRepository) (domain.Entry, error) {
+		return repo.Add(ctx, normalized)
+	})
+	if err != nil {
 		return EntryAddResult{}, err
 	}
+	result.Warnings = append(result.Warnings, fxRateStaleWarnings(normalized)...)
+	return result, nil
+}
+
+// AddBatch adds inputs in one transaction through the same validation, cap
+// warning and card liability steps as AddWithWarnings. Every row is checked
+// before anything is committed; if any row fails the batch is rolled back
+// and a *domain.EntryBatchError lists each failing row.
+func (s *EntryService) AddBatch(ctx context.Context, inputs []domain.EntryAddInput) (EntryBatchResult, error) {
+	if len(inputs) == 0 {
+		return EntryBatchResult{}, domain.ErrEmptyEntryBatch
+	}
+	if s.batchDB == nil {
+		return EntryBatchResult{}, fmt.Errorf("entry batch: db is required")
+	}
+
+	// Card selectors and balance links are resolved before the transaction
+	// opens: the store allows a single connection.
+	batchErr := &domain.EntryBatchError{RowCount: len(inputs)}
+	normalized := make([]domain.EntryAddInput, len(inputs))
+	for i, input := range inputs {
+		value, err := s.normalizeAddInput(ctx, input)
+		if err != nil {
+			batchErr.Rows = append(batchErr.Rows, domain.EntryBatchRowError{Row: i + 1, Err: err})
+			continue
+		}
+		normalized[i] = value
+	}
+	if len(batchErr.Rows) > 0 {
+		return EntryBatchResult{}, batchErr
+	}
+
+	tx, err := s.batchDB.BeginTx(ctx, nil)
+	if err != nil {
+		return EntryBatchResult{}, fmt.Errorf("entry batch begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	txRepo, ok := bindEntryRepositoryToTx(s.repo, tx)
+	if !ok {
+		return EntryBatchResult{}, fmt.Errorf("entry batch: repository does not support transactions")
+	}
+	txService := &EntryService{repo: txRepo}
+	if s.capLookup != nil {
+		txCapLookup, ok := bindEntryCapLookupToTx(s.capLookup, tx)
+		if !ok {
+			return EntryBatchResult{}, fmt.Errorf("entry batch: cap lookup does not support transactions")
+		}
+		txService.capLookup = txCapLookup
+	}
+	if s.cardLimits != nil {
+		txCardLimits, ok := bindEntryCardLimitLookupToTx(s.cardLimits, tx)
+		if !ok {
+			return EntryBatchResult{}, fmt.Errorf("entry batch: card limit lookup does not support transactions")
+		}
+		txService.cardLimits = txCardLimits
+	}
+
+	result := EntryBatchResult{Entries: make([]domain.Entry, 0, len(normalized)), Warnings: []domain.Warning{}}
+	for i, input := range normalized {
+		written, err := txService.applyWrite(ctx, func(repo EntryRepository) (domain.Entry, error) {
+			return repo.Add(ctx, input)
+		})
+		if err != nil {
+			batchErr.Rows = append(batchErr.Rows, domain.EntryBatchRowError{Row: i + 1, Err: err})
+			continue
+		}
+		result.Entries = append(result.Entries, written.Entry)
+		result.Warnings = append(result.Warnings, written.Warnings...)
+		result.Warnings = append(result.Warnings, fxRateStaleWarnings(input)...)
+	}
+	if len(batchErr.Rows) > 0 {
+		return EntryBatchResult{}, batchErr
+	}
+	if strict := domain.FilterStrictWarnings(result.Warnings, s.strictWarnings); len(strict) > 0 {
+		return EntryBatchResult{}, &domain.StrictWarningError{Warnings: strict}
+	}
+	if err := tx.Commit(); err != nil {
+		return EntryBatchResult{}, fmt.Errorf("entry batch commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// normalizeAddInput validates input and resolves its card selector and
+// linked bank account, returning what the store should insert.
+func (s *EntryService) normalizeAddInput(ctx context.Context, input domain.EntryAddInput) (domain.EntryAddInput, error) {
+	normalizedType, err := domain.NormalizeEntryType(input.Type)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
+	if err := domain.ValidateAmountMinor(input.AmountMinor); err != nil {
+		return domain.EntryAddInput{}, err
+	}
 	normalizedCurrency, err := domain.NormalizeCurrencyCode(input.CurrencyCode)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	normalizedDate, err := domain.NormalizeTransactionDateUTC(input.TransactionDateUTC)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	if err := domain.ValidateOptionalCategoryID(input.CategoryID); err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	if err := domain.ValidateOptionalBankAccountID(input.BankAccountID); err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	normalizedLabelIDs, err := domain.NormalizeLabelIDs(input.LabelIDs)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	normalizedPaymentMethod, err := domain.NormalizePaymentMethod(input.PaymentMethod)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	if err := domain.ValidateCardSelector(input.PaymentCardID, input.PaymentCardNickname, input.PaymentCardLookup); err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	hasCardSelector := domain.HasCardSelector(input.PaymentCardID, input.PaymentCardNickname, input.PaymentCardLookup)
+	if input.RefundOfEntryID != nil {
+		if err := domain.ValidateEntryID(*input.RefundOfEntryID); err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		if normalizedType != domain.EntryTypeExpense {
+			return domain.EntryAddInput{}, domain.ErrRefundRequiresExpense
+		}
+	}
+	if len(input.Splits) > 0 && (normalizedType != domain.EntryTypeExpense || input.RefundOfEntryID != nil) {
+		return domain.EntryAddInput{}, domain.ErrSplitRequiresExpense
+	}
+	normalizedSplits, err := domain.NormalizeEntrySplits(input.RecordedBy, input.Splits)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
+	normalizedUID, err := domain.NormalizeEntryUID(input.UID)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
+	// A refund without payment details is left blank so the store copies
+	// them from the refunded entry.
+	inheritPayment := input.RefundOfEntryID != nil && normalizedPaymentMethod == "" && !hasCardSelector
+	normalizedIncomeSource := domain.NormalizeIncomeSource(input.IncomeSource)
+	if normalizedIncomeSource != "" && normalizedType != domain.EntryTypeIncome {
+		return domain.EntryAddInput{}, domain.ErrSourceNotAllowed
+	}
+	normalizedLocation, err := domain.NormalizeEntryLocation(input.Location)
+	if err != nil {
+		return domain.EntryAddInput{}, err
+	}
 
 	if normalizedType != domain.EntryTypeExpense {
 		if normalizedPaymentMethod != "" || hasCardSelector {
-			return EntryAddResult{}, domain.ErrPaymentNotAllowed
+			return domain.EntryAddInput{}, domain.ErrPaymentNotAllowed
 		}
 	} else {
-		if normalizedPaymentMethod == "" {
+		if normalizedPaymentMethod == "" && !inheritPayment {
 			normalizedPaymentMethod = domain.PaymentMethodCash
 		}
 		if normalizedPaymentMethod == domain.PaymentMethodCash && hasCardSelector {
-			return EntryAddResult{}, domain.ErrCardNotAllowed
+			return domain.EntryAddInput{}, domain.ErrCardNotAllowed
 		}
 		if normalizedPaymentMethod == domain.PaymentMethodCard && !hasCardSelector {
-			return EntryAddResult{}, domain.ErrCardRequired
+			return domain.EntryAddInput{}, domain.ErrCardRequired
 		}
 	}
 
 	resolvedCardID, err := s.resolvePaymentCardID(ctx, input.PaymentCardID, input.PaymentCardNickname, input.PaymentCardLookup)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
 	}
 	resolvedBankAccountID, err := s.resolveEntryBankAccountID(ctx, input.BankAccountID)
 	if err != nil {
-		return EntryAddResult{}, err
+		return domain.EntryAddInput{}, err
+	}
+	amountMinor, currencyCode, original, err := s.convertForRecording(ctx, input.AmountMinor, normalizedCurrency, input.RecordInCurrency, normalizedDate)
+	if err != nil {
+		return domain.EntryAddInput{}, err
 	}
 
-	entry, err := s.repo.Add(ctx, domain.EntryAddInput{
+	return domain.EntryAddInput{
 		Type:               normalizedType,
-		AmountMinor:        input.AmountMinor,
-		CurrencyCode:       normalizedCurrency,
+		AmountMinor:        amountMinor,
+		CurrencyCode:       currencyCode,
+		Original:           original,
 		TransactionDateUTC: normalizedDate,
 		CategoryID:         input.CategoryID,
 		BankAccountID:      resolvedBankAccountID,
 		LabelIDs:           normalizedLabelIDs,
 		Note:               strings.TrimSpace(input.Note),
+		Payee:              domain.NormalizePayee(input.Payee),
+		RecordedBy:         domain.NormalizeRecordedBy(input.RecordedBy),
+		IncomeSource:       normalizedIncomeSource,
+		Location:           normalizedLocation,
 		PaymentMethod:      normalizedPaymentMethod,
 		PaymentCardID:      resolvedCardID,
-	})
+		RefundOfEntryID:    input.RefundOfEntryID,
+		AllowArchived:      input.AllowArchived,
+		Splits:             normalizedSplits,
+		UID:                normalizedUID,
+	}, nil
+}
+
+// convertForRecording converts amountMinor from currencyCode into
+// recordIn at the transaction date. It returns the amount and currency to
+// store and, when they were converted, the original figures.
+func (s *EntryService) convertForRecording(ctx context.Context, amountMinor int64, currencyCode, recordIn, transactionDateUTC string) (int64, string, *domain.EntryOriginalAmount, error) {
+	if strings.TrimSpace(recordIn) == "" {
+		return amountMinor, currencyCode, nil, nil
+	}
+	target, err := domain.NormalizeCurrencyCode(recordIn)
 	if err != nil {
-		return EntryAddResult{}, err
+		return 0, "", nil, err
 	}
-	if err := s.syncCreditLiability(ctx, entry); err != nil {
-		return EntryAddResult{}, err
+	if target == currencyCode {
+		return amountMinor, currencyCode, nil, nil
 	}
-
-	result := EntryAddResult{
-		Entry:    entry,
-		Warnings: []domain.Warning{},
+	if s.fxConverter == nil {
+		return 0, "", nil, domain.ErrFXRateUnavailable
 	}
 
-	if entry.Type != domain.EntryTypeExpense || s.capLookup == nil {
-		return result, nil
+	converted, err := s.fxConverter.Convert(ctx, amountMinor, currencyCode, target, transactionDateUTC)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	if err := domain.ValidateAmountMinor(converted.AmountMinor); err != nil {
+		return 0, "", nil, err
+	}
+	return converted.AmountMinor, target, &domain.EntryOriginalAmount{
+		AmountMinor:  amountMinor,
+		CurrencyCode: currencyCode,
+		FXRate:       converted.Snapshot.Rate,
+		FXRateDate:   converted.Snapshot.RateDate,
+		FXRateStale:  converted.Stale,
+	}, nil
+}
+
+// fxRateStaleWarnings reports an add recorded at a stale fallback FX rate.
+func fxRateStaleWarnings(input domain.EntryAddInput) []domain.Warning {
+	if input.Original == nil || !input.Original.FXRateStale {
+		return nil
 	}
+	return []domain.Warning{{
+		Code:    domain.WarningCodeFXRateStale,
+		Message: domain.FXRateStaleWarningMessage,
+		Details: map[string]any{
+			"currency_code":          input.CurrencyCode,
+			"original_currency_code": input.Original.CurrencyCode,
+			"fx_rate_date":           input.Original.FXRateDate,
+		},
+	}}
+}
 
-	monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
+func (s *EntryService) List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+	normalizedFilter, err := normalizeEntryListFilter(filter)
 	if err != nil {
-		return result, nil
+		return nil, err
 	}
 
-	capValue, err := s.capLookup.GetByMonth(ctx, monthKey)
+	entries, err := s.repo.List(ctx, normalizedFilter)
 	if err != nil {
-		if errors.Is(err, domain.ErrCapNotFound) {
-			return result, nil
-		}
-		return result, nil
+		return nil, err
 	}
 
-	if capValue.CurrencyCode != entry.CurrencyCode {
-		return result, nil
+	if len(normalizedFilter.LabelIDs) == 0 {
+		return entries, nil
 	}
 
-	totalSpend, err := s.capLookup.GetExpenseTotalByMonthAndCurrency(ctx, monthKey, entry.CurrencyCode)
+	return filterEntriesByLabelMode(entries, normalizedFilter.LabelIDs, normalizedFilter.LabelMode), nil
+}
+
+// Each calls fn for every entry List would return, in the same order. When the
+// repo implements EntryIterator entries are streamed instead of loaded at once.
+func (s *EntryService) Each(ctx context.Context, filter domain.EntryListFilter, fn func(domain.Entry) error) error {
+	normalizedFilter, err := normalizeEntryListFilter(filter)
 	if err != nil {
-		return result, nil
+		return err
 	}
 
-	if totalSpend <= capValue.AmountMinor {
-		return result, nil
+	visit := fn
+	if len(normalizedFilter.LabelIDs) > 0 {
+		requestedSet := labelIDSet(normalizedFilter.LabelIDs)
+		visit = func(entry domain.Entry) error {
+			if !entryMatchesLabelMode(entry, requestedSet, normalizedFilter.LabelMode) {
+				return nil
+			}
+			return fn(entry)
+		}
 	}
 
-	result.Warnings = append(result.Warnings, domain.Warning{
-		Code:    domain.WarningCodeCapExceeded,
-		Message: domain.CapExceededWarningMessage,
-		Details: domain.CapExceededWarningDetails{
-			MonthKey: monthKey,
-			CapAmount: domain.MoneyAmount{
-				AmountMinor:  capValue.AmountMinor,
-				CurrencyCode: capValue.CurrencyCode,
-			},
-			NewSpendTotal: domain.MoneyAmount{
-				AmountMinor:  totalSpend,
-				CurrencyCode: entry.CurrencyCode,
-			},
-			OverspendAmount: domain.MoneyAmount{
-				AmountMinor:  totalSpend - capValue.AmountMinor,
-				CurrencyCode: entry.CurrencyCode,
-			},
-		},
-	})
+	if iterator, ok := s.repo.(EntryIterator); ok {
+		return iterator.EachEntry(ctx, normalizedFilter, visit)
+	}
 
-	return result, nil
+	entries, err := s.repo.List(ctx, normalizedFilter)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := visit(entry); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *EntryService) List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+func normalizeEntryListFilter(filter domain.EntryListFilter) (domain.EntryListFilter, error) {
 	normalizedFilter := domain.EntryListFilter{}
 
 	if strings.TrimSpace(filter.Type) != "" {
 		normalizedType, err := domain.NormalizeEntryType(filter.Type)
 		if err != nil {
-			return nil, err
+			return domain.EntryListFilter{}, err
 		}
 		normalizedFilter.Type = normalizedType
 	}
 
 	if err := domain.ValidateOptionalCategoryID(filter.CategoryID); err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	normalizedFilter.CategoryID = filter.CategoryID
 	if err := domain.ValidateOptionalBankAccountID(filter.BankAccountID); err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	normalizedFilter.BankAccountID = filter.BankAccountID
 
 	dateFromUTC, err := domain.NormalizeOptionalTransactionDateUTC(filter.DateFromUTC)
 	if err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	dateToUTC, err := domain.NormalizeOptionalTransactionDateUTC(filter.DateToUTC)
 	if err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	if err := domain.ValidateDateRange(dateFromUTC, dateToUTC); err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	normalizedFilter.DateFromUTC = dateFromUTC
 	normalizedFilter.DateToUTC = dateToUTC
 	normalizedFilter.NoteContains = strings.TrimSpace(filter.NoteContains)
+	normalizedFilter.Payee = domain.NormalizePayee(filter.Payee)
+	normalizedFilter.RecordedBy = domain.NormalizeRecordedBy(filter.RecordedBy)
+	normalizedFilter.IncomeSource = domain.NormalizeIncomeSource(filter.IncomeSource)
+	normalizedFilter.LocationContains = strings.TrimSpace(filter.LocationContains)
+	if strings.TrimSpace(filter.CurrencyCode) != "" {
+		currencyCode, err := domain.NormalizeCurrencyCode(filter.CurrencyCode)
+		if err != nil {
+			return domain.EntryListFilter{}, err
+		}
+		normalizedFilter.CurrencyCode = currencyCode
+	}
+	if err := domain.ValidateAmountRange(filter.AmountMinMinor, filter.AmountMaxMinor); err != nil {
+		return domain.EntryListFilter{}, err
+	}
+	normalizedFilter.AmountMinMinor = filter.AmountMinMinor
+	normalizedFilter.AmountMaxMinor = filter.AmountMaxMinor
 
 	normalizedLabelIDs, err := domain.NormalizeLabelIDs(filter.LabelIDs)
 	if err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	normalizedFilter.LabelIDs = normalizedLabelIDs
 
 	normalizedLabelMode, err := domain.NormalizeLabelMode(filter.LabelMode)
 	if err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	normalizedFilter.LabelMode = normalizedLabelMode
 	normalizedPaymentMethod, err := domain.NormalizePaymentMethodFilter(filter.PaymentMethod)
 	if err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	if err := domain.ValidateCardSelector(filter.PaymentCardID, filter.PaymentCardNickname, filter.PaymentCardLookup); err != nil {
-		return nil, err
+		return domain.EntryListFilter{}, err
 	}
 	if normalizedPaymentMethod == domain.PaymentMethodCash && domain.HasCardSelector(filter.PaymentCardID, filter.PaymentCardNickname, filter.PaymentCardLookup) {
-		return nil, domain.ErrCardNotAllowed
+		return domain.EntryListFilter{}, domain.ErrCardNotAllowed
 	}
 	normalizedFilter.PaymentMethod = normalizedPaymentMethod
 	normalizedFilter.PaymentCardID = filter.PaymentCardID
 	normalizedFilter.PaymentCardNickname = strings.TrimSpace(filter.PaymentCardNickname)
 	normalizedFilter.PaymentCardLookup = strings.TrimSpace(filter.PaymentCardLookup)
 
-	entries, err := s.repo.List(ctx, normalizedFilter)
+	return normalizedFilter, nil
+}
+
+// ListPayees aggregates the entries matching filter per payee and currency.
+func (s *EntryService) Get(ctx context.Context, id int64) (domain.Entry, error) {
+	if err := domain.ValidateEntryID(id); err != nil {
+		return domain.Entry{}, err
+	}
+	return s.repo.Get(ctx, id)
+}
+
+// GetByUID returns the active entry carrying uid, or ErrEntryNotFound when
+// none does or the repository cannot look entries up by uid.
+func (s *EntryService) GetByUID(ctx context.Context, uid string) (domain.Entry, error) {
+	normalizedUID, err := domain.NormalizeEntryUID(uid)
 	if err != nil {
-		return nil, err
+		return domain.Entry{}, err
+	}
+	if normalizedUID == "" {
+		return domain.Entry{}, domain.ErrInvalidEntryUID
+	}
+	lookup, ok := s.repo.(EntryUIDLookup)
+	if !ok {
+		return domain.Entry{}, domain.ErrEntryNotFound
 	}
+	return lookup.GetByUID(ctx, normalizedUID)
+}
 
-	if len(normalizedLabelIDs) == 0 {
-		return entries, nil
+// History returns entry id with every revision it went through, oldest
+// first. Each revision carries the changes the update that replaced it made.
+func (s *EntryService) History(ctx context.Context, id int64) (domain.EntryHistory, error) {
+	entry, err := s.Get(ctx, id)
+	if err != nil {
+		return domain.EntryHistory{}, err
+	}
+	history := domain.EntryHistory{Entry: entry, Revisions: []domain.EntryHistoryItem{}}
+	reader, ok := s.repo.(EntryRevisionReader)
+	if !ok {
+		return history, nil
+	}
+
+	revisions, err := reader.ListRevisions(ctx, id)
+	if err != nil {
+		return domain.EntryHistory{}, err
+	}
+	for i, revision := range revisions {
+		next := domain.EntryRevisionStateOf(entry)
+		if i+1 < len(revisions) {
+			next = revisions[i+1].State
+		}
+		history.Revisions = append(history.Revisions, domain.EntryHistoryItem{
+			EntryRevision: revision,
+			Changes:       domain.DiffEntryRevisionStates(revision.State, next),
+		})
+	}
+	return history, nil
+}
+
+// Revert puts entry id back into the state it had at revision. The revert is
+// an update itself, so it adds a revision and can be reverted in turn.
+func (s *EntryService) Revert(ctx context.Context, id, revision int64) (EntryAddResult, error) {
+	if err := domain.ValidateEntryID(id); err != nil {
+		return EntryAddResult{}, err
+	}
+	if revision <= 0 {
+		return EntryAddResult{}, domain.ErrInvalidEntryRevision
+	}
+	reader, ok := s.repo.(EntryRevisionReader)
+	if !ok {
+		return EntryAddResult{}, domain.ErrEntryRevisionNotFound
+	}
+
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return EntryAddResult{}, err
+	}
+	target, err := reader.GetRevision(ctx, id, revision)
+	if err != nil {
+		return EntryAddResult{}, err
+	}
+	return s.UpdateWithWarnings(ctx, domain.EntryUpdateForRevision(id, target.State))
+}
+
+// Expand embeds the relations selected by expand next to each entry. Cards are
+// looked up including deleted ones so older entries keep their card; deleted
+// categories and labels are already unlinked from entries.
+func (s *EntryService) Expand(ctx context.Context, entries []domain.Entry, expand domain.EntryExpand) ([]domain.ExpandedEntry, error) {
+	cardsByID := map[int64]domain.EntryCardRef{}
+	if expand.Card {
+		if s.expandCards == nil {
+			return nil, fmt.Errorf("entry expand: card lookup is not configured")
+		}
+		cards, err := s.expandCards.List(ctx, domain.CardListFilter{IncludeDeleted: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, card := range cards {
+			cardsByID[card.ID] = domain.EntryCardRef{ID: card.ID, Nickname: card.Nickname, Last4: card.Last4, Brand: card.Brand, CardType: card.CardType}
+		}
+	}
+
+	categoriesByID := map[int64]domain.EntryCategoryRef{}
+	if expand.Category {
+		if s.expandCategories == nil {
+			return nil, fmt.Errorf("entry expand: category lookup is not configured")
+		}
+		categories, err := s.expandCategories.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			categoriesByID[category.ID] = domain.EntryCategoryRef{ID: category.ID, Name: category.Name}
+		}
+	}
+
+	labelsByID := map[int64]domain.EntryLabelRef{}
+	if expand.Labels {
+		if s.expandLabels == nil {
+			return nil, fmt.Errorf("entry expand: label lookup is not configured")
+		}
+		labels, err := s.expandLabels.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			labelsByID[label.ID] = domain.EntryLabelRef{ID: label.ID, Name: label.Name}
+		}
+	}
+
+	out := make([]domain.ExpandedEntry, 0, len(entries))
+	for _, entry := range entries {
+		expanded := domain.ExpandedEntry{Entry: entry}
+		if expand.Card && entry.PaymentCardID != nil {
+			if card, ok := cardsByID[*entry.PaymentCardID]; ok {
+				expanded.PaymentCard = &card
+			}
+		}
+		if expand.Category && entry.CategoryID != nil {
+			if category, ok := categoriesByID[*entry.CategoryID]; ok {
+				expanded.Category = &category
+			}
+		}
+		if expand.Labels {
+			labels := make([]domain.EntryLabelRef, 0, len(entry.LabelIDs))
+			for _, labelID := range entry.LabelIDs {
+				if label, ok := labelsByID[labelID]; ok {
+					labels = append(labels, label)
+				}
+			}
+			expanded.Labels = &labels
+		}
+		out = append(out, expanded)
+	}
+	return out, nil
+}
+
+func (s *EntryService) ListPayees(ctx context.Context, filter domain.EntryListFilter) ([]domain.PayeeSummary, error) {
+	entries, err := s.List(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
+	return domain.SummarizePayees(entries), nil
+}
 
-	return filterEntriesByLabelMode(entries, normalizedLabelIDs, normalizedLabelMode), nil
+// Stats summarizes the entries matching filter; the filter's date bounds set
+// how many months average monthly spend is spread over.
+func (s *EntryService) Stats(ctx context.Context, filter domain.EntryListFilter) (domain.EntryStats, error) {
+	entries, err := s.List(ctx, filter)
+	if err != nil {
+		return domain.EntryStats{}, err
+	}
+	return domain.ComputeEntryStats(entries, filter.DateFromUTC, filter.DateToUTC)
 }
 
 func (s *EntryService) Update(ctx context.Context, input domain.EntryUpdateInput) (domain.Entry, error) {
@@ -308,7 +766,11 @@ func (s *EntryService) UpdateWithWarnings(ctx context.Context, input domain.Entr
 		return EntryAddResult{}, domain.ErrNoEntryUpdateFields
 	}
 
-	normalized := domain.EntryUpdateInput{ID: input.ID}
+	expectedUpdatedAtUTC, err := domain.NormalizeExpectedUpdatedAtUTC(input.ExpectedUpdatedAtUTC)
+	if err != nil {
+		return EntryAddResult{}, err
+	}
+	normalized := domain.EntryUpdateInput{ID: input.ID, ExpectedUpdatedAtUTC: expectedUpdatedAtUTC, AllowArchived: input.AllowArchived}
 
 	if input.Type != nil {
 		normalizedType, err := domain.NormalizeEntryType(*input.Type)
@@ -380,6 +842,46 @@ func (s *EntryService) UpdateWithWarnings(ctx context.Context, input domain.Entr
 		}
 	}
 
+	if input.SetPayee {
+		normalized.SetPayee = true
+		if input.Payee != nil {
+			if value := domain.NormalizePayee(*input.Payee); value != "" {
+				normalized.Payee = &value
+			}
+		}
+	}
+
+	if input.SetRecordedBy {
+		normalized.SetRecordedBy = true
+		if input.RecordedBy != nil {
+			if value := domain.NormalizeRecordedBy(*input.RecordedBy); value != "" {
+				normalized.RecordedBy = &value
+			}
+		}
+	}
+
+	if input.SetIncomeSource {
+		normalized.SetIncomeSource = true
+		if input.IncomeSource != nil {
+			if value := domain.NormalizeIncomeSource(*input.IncomeSource); value != "" {
+				normalized.IncomeSource = &value
+			}
+		}
+	}
+
+	if input.SetLocation {
+		normalized.SetLocation = true
+		if input.Location != nil {
+			value, err := domain.NormalizeEntryLocation(*input.Location)
+			if err != nil {
+				return EntryAddResult{}, err
+			}
+			if value != "" {
+				normalized.Location = &value
+			}
+		}
+	}
+
 	if input.SetPaymentMethod {
 		normalized.SetPaymentMethod = true
 		if input.PaymentMethod != nil {
@@ -417,7 +919,62 @@ func (s *EntryService) UpdateWithWarnings(ctx context.Context, input domain.Entr
 		normalized.PaymentCardID = nil
 	}
 
-	entry, err := s.repo.Update(ctx, normalized)
+	return s.writeWithWarnings(ctx, func(repo EntryRepository) (domain.Entry, error) {
+		return repo.Update(ctx, normalized)
+	})
+}
+
+// writeWithWarnings runs write, syncs the card liability and collects cap
+// warnings. With strict warnings configured all three share a transaction
+// that is rolled back when an escalated warning fires.
+func (s *EntryService) writeWithWarnings(ctx context.Context, write func(repo EntryRepository) (domain.Entry, error)) (EntryAddResult, error) {
+	if len(s.strictWarnings) == 0 || s.strictDB == nil {
+		return s.applyWrite(ctx, write)
+	}
+
+	tx, err := s.strictDB.BeginTx(ctx, nil)
+	if err != nil {
+		return EntryAddResult{}, fmt.Errorf("entry write begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	txRepo, ok := bindEntryRepositoryToTx(s.repo, tx)
+	if !ok {
+		return EntryAddResult{}, fmt.Errorf("entry write: repository does not support strict warnings")
+	}
+	txService := &EntryService{repo: txRepo, subscriptionAlerts: s.subscriptionAlerts}
+	if s.capLookup != nil {
+		txCapLookup, ok := bindEntryCapLookupToTx(s.capLookup, tx)
+		if !ok {
+			return EntryAddResult{}, fmt.Errorf("entry write: cap lookup does not support strict warnings")
+		}
+		txService.capLookup = txCapLookup
+	}
+	if s.cardLimits != nil {
+		txCardLimits, ok := bindEntryCardLimitLookupToTx(s.cardLimits, tx)
+		if !ok {
+			return EntryAddResult{}, fmt.Errorf("entry write: card limit lookup does not support strict warnings")
+		}
+		txService.cardLimits = txCardLimits
+	}
+
+	result, err := txService.applyWrite(ctx, write)
+	if err != nil {
+		return EntryAddResult{}, err
+	}
+	if strict := domain.FilterStrictWarnings(result.Warnings, s.strictWarnings); len(strict) > 0 {
+		return EntryAddResult{}, &domain.StrictWarningError{Warnings: strict}
+	}
+	if err := tx.Commit(); err != nil {
+		return EntryAddResult{}, fmt.Errorf("entry write commit: %w", err)
+	}
+	return result, nil
+}
+
+func (s *EntryService) applyWrite(ctx context.Context, write func(repo EntryRepository) (domain.Entry, error)) (EntryAddResult, error) {
+	entry, err := write(s.repo)
 	if err != nil {
 		return EntryAddResult{}, err
 	}
@@ -425,62 +982,154 @@ func (s *EntryService) UpdateWithWarnings(ctx context.Context, input domain.Entr
 		return EntryAddResult{}, err
 	}
 
-	result := EntryAddResult{
+	warnings := append(s.capExceededWarnings(ctx, entry), s.cardLimitExceededWarnings(ctx, entry)...)
+	return EntryAddResult{
 		Entry:    entry,
-		Warnings: []domain.Warning{},
-	}
+		Warnings: append(warnings, s.subscriptionPriceWarnings(ctx, entry)...),
+	}, nil
+}
 
-	if entry.Type != domain.EntryTypeExpense || s.capLookup == nil {
-		return result, nil
+// capExceededWarnings checks an expense against the month's global cap and,
+// when the entry has a category and the lookup supports it, the category cap.
+// Lookup failures never fail the write, so they yield no warning. Refunds
+// only lower spend and are never checked.
+func (s *EntryService) capExceededWarnings(ctx context.Context, entry domain.Entry) []domain.Warning {
+	warnings := []domain.Warning{}
+	if entry.Type != domain.EntryTypeExpense || entry.IsRefund() || s.capLookup == nil {
+		return warnings
 	}
 
 	monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
 	if err != nil {
-		return result, nil
+		return warnings
 	}
 
 	capValue, err := s.capLookup.GetByMonth(ctx, monthKey)
-	if err != nil {
-		if errors.Is(err, domain.ErrCapNotFound) {
-			return result, nil
+	if err == nil && capValue.CurrencyCode == entry.CurrencyCode {
+		totalSpend, err := s.capLookup.GetExpenseTotalByMonthAndCurrency(ctx, monthKey, entry.CurrencyCode)
+		if err == nil && totalSpend > capValue.AmountMinor {
+			warnings = append(warnings, domain.Warning{
+				Code:    domain.WarningCodeCapExceeded,
+				Message: domain.CapExceededWarningMessage,
+				Details: domain.CapExceededWarningDetails{
+					MonthKey:        monthKey,
+					CapAmount:       domain.MoneyAmount{AmountMinor: capValue.AmountMinor, CurrencyCode: capValue.CurrencyCode},
+					NewSpendTotal:   domain.MoneyAmount{AmountMinor: totalSpend, CurrencyCode: entry.CurrencyCode},
+					OverspendAmount: domain.MoneyAmount{AmountMinor: totalSpend - capValue.AmountMinor, CurrencyCode: entry.CurrencyCode},
+				},
+			})
 		}
-		return result, nil
 	}
 
-	if capValue.CurrencyCode != entry.CurrencyCode {
-		return result, nil
+	categoryLookup, ok := s.capLookup.(ports.EntryCategoryCapLookup)
+	if !ok || entry.CategoryID == nil {
+		return warnings
 	}
+	categoryCap, err := categoryLookup.GetCategoryCap(ctx, monthKey, *entry.CategoryID)
+	if err != nil || categoryCap.CurrencyCode != entry.CurrencyCode {
+		return warnings
+	}
+	categorySpend, err := categoryLookup.GetCategoryExpenseTotalByMonthAndCurrency(ctx, monthKey, *entry.CategoryID, entry.CurrencyCode)
+	if err != nil || categorySpend <= categoryCap.AmountMinor {
+		return warnings
+	}
+	return append(warnings, domain.Warning{
+		Code:    domain.WarningCodeCategoryCapExceeded,
+		Message: domain.CategoryCapExceededWarningMessage,
+		Details: domain.CategoryCapExceededWarningDetails{
+			MonthKey:        monthKey,
+			CategoryID:      *entry.CategoryID,
+			CapAmount:       domain.MoneyAmount{AmountMinor: categoryCap.AmountMinor, CurrencyCode: categoryCap.CurrencyCode},
+			NewSpendTotal:   domain.MoneyAmount{AmountMinor: categorySpend, CurrencyCode: entry.CurrencyCode},
+			OverspendAmount: domain.MoneyAmount{AmountMinor: categorySpend - categoryCap.AmountMinor, CurrencyCode: entry.CurrencyCode},
+		},
+	})
+}
 
-	totalSpend, err := s.capLookup.GetExpenseTotalByMonthAndCurrency(ctx, monthKey, entry.CurrencyCode)
-	if err != nil {
-		return result, nil
+// cardLimitExceededWarnings checks a card expense against the card's limit for
+// the entry's month. Like cap checks, lookup failures and limits in another
+// currency yield no warning.
+func (s *EntryService) cardLimitExceededWarnings(ctx context.Context, entry domain.Entry) []domain.Warning {
+	if entry.Type != domain.EntryTypeExpense || entry.IsRefund() || entry.PaymentCardID == nil || s.cardLimits == nil {
+		return nil
 	}
 
-	if totalSpend <= capValue.AmountMinor {
-		return result, nil
+	monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
+	if err != nil {
+		return nil
+	}
+	limit, err := s.cardLimits.GetMonthlyLimit(ctx, *entry.PaymentCardID, monthKey)
+	if err != nil || limit.CurrencyCode != entry.CurrencyCode {
+		return nil
+	}
+	monthStartUTC, monthEndUTC, err := domain.MonthRangeUTC(monthKey)
+	if err != nil {
+		return nil
+	}
+	spend, err := s.cardLimits.GetCardExpenseTotal(ctx, *entry.PaymentCardID, entry.CurrencyCode, monthStartUTC, monthEndUTC)
+	if err != nil || spend <= limit.AmountMinor {
+		return nil
 	}
 
-	result.Warnings = append(result.Warnings, domain.Warning{
-		Code:    domain.WarningCodeCapExceeded,
-		Message: domain.CapExceededWarningMessage,
-		Details: domain.CapExceededWarningDetails{
-			MonthKey: monthKey,
-			CapAmount: domain.MoneyAmount{
-				AmountMinor:  capValue.AmountMinor,
-				CurrencyCode: capValue.CurrencyCode,
-			},
-			NewSpendTotal: domain.MoneyAmount{
-				AmountMinor:  totalSpend,
-				CurrencyCode: entry.CurrencyCode,
-			},
-			OverspendAmount: domain.MoneyAmount{
-				AmountMinor:  totalSpend - capValue.AmountMinor,
-				CurrencyCode: entry.CurrencyCode,
-			},
+	return []domain.Warning{{
+		Code:    domain.WarningCodeCardLimitExceeded,
+		Message: domain.CardLimitExceededWarningMessage,
+		Details: domain.CardLimitExceededWarningDetails{
+			MonthKey:        monthKey,
+			CardID:          *entry.PaymentCardID,
+			LimitAmount:     domain.MoneyAmount{AmountMinor: limit.AmountMinor, CurrencyCode: limit.CurrencyCode},
+			NewSpendTotal:   domain.MoneyAmount{AmountMinor: spend, CurrencyCode: entry.CurrencyCode},
+			OverspendAmount: domain.MoneyAmount{AmountMinor: spend - limit.AmountMinor, CurrencyCode: entry.CurrencyCode},
 		},
-	})
+	}}
+}
 
-	return result, nil
+// subscriptionPriceWarnings compares an expense with the earlier charges to
+// the same payee (or note) and currency. Lookup failures yield no warning.
+func (s *EntryService) subscriptionPriceWarnings(ctx context.Context, entry domain.Entry) []domain.Warning {
+	if !s.subscriptionAlerts || entry.Type != domain.EntryTypeExpense || entry.IsRefund() {
+		return nil
+	}
+
+	filter := domain.EntryListFilter{
+		Type:         domain.EntryTypeExpense,
+		CurrencyCode: entry.CurrencyCode,
+		DateToUTC:    entry.TransactionDateUTC,
+	}
+	switch {
+	case strings.TrimSpace(entry.Payee) != "":
+		filter.Payee = strings.TrimSpace(entry.Payee)
+	case strings.TrimSpace(entry.Note) != "":
+		filter.NoteContains = strings.TrimSpace(entry.Note)
+	default:
+		return nil
+	}
+	history, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil
+	}
+
+	change, ok := domain.SubscriptionPriceChangeFor(history, entry, domain.SubscriptionDetectOptions{
+		MinOccurrences:   domain.DefaultSubscriptionMinOccurrences,
+		TolerancePercent: domain.DefaultSubscriptionTolerancePercent,
+	})
+	if !ok {
+		return nil
+	}
+	return []domain.Warning{{
+		Code:    domain.WarningCodeSubscriptionPriceChanged,
+		Message: domain.SubscriptionPriceChangedWarningMessage,
+		Details: domain.SubscriptionPriceChangedWarningDetails{
+			EntryID:         change.EntryID,
+			Name:            change.Name,
+			Interval:        change.Interval,
+			PreviousEntryID: change.PreviousEntryID,
+			PreviousDate:    change.PreviousDate,
+			PreviousAmount:  domain.MoneyAmount{AmountMinor: change.PreviousAmountMinor, CurrencyCode: change.CurrencyCode},
+			NewAmount:       domain.MoneyAmount{AmountMinor: change.AmountMinor, CurrencyCode: change.CurrencyCode},
+			ChangeAmount:    domain.MoneyAmount{AmountMinor: change.ChangeMinor, CurrencyCode: change.CurrencyCode},
+		},
+	}}
 }
 
 func (s *EntryService) Delete(ctx context.Context, id int64) (domain.EntryDeleteResult, error) {
@@ -490,47 +1139,71 @@ func (s *EntryService) Delete(ctx context.Context, id int64) (domain.EntryDelete
 	return s.repo.Delete(ctx, id)
 }
 
-func filterEntriesByLabelMode(entries []domain.Entry, labelIDs []int64, mode string) []domain.Entry {
-	if len(entries) == 0 || len(labelIDs) == 0 {
-		return entries
+// FixCurrency re-codes every active entry in fromCurrency as toCurrency. Both
+// codes must share a minor unit so stored amounts keep their meaning. A dry
+// run only reports the entries that would change.
+func (s *EntryService) FixCurrency(ctx context.Context, fromCurrency, toCurrency string, dryRun bool) (domain.EntryCurrencyFix, error) {
+	from, err := domain.NormalizeCurrencyCode(fromCurrency)
+	if err != nil {
+		return domain.EntryCurrencyFix{}, err
+	}
+	to, err := domain.NormalizeCurrencyCode(toCurrency)
+	if err != nil {
+		return domain.EntryCurrencyFix{}, err
+	}
+	if from == to {
+		return domain.EntryCurrencyFix{}, domain.ErrCurrencyFixSameCode
 	}
 
-	requestedSet := make(map[int64]struct{}, len(labelIDs))
-	for _, labelID := range labelIDs {
-		requestedSet[labelID] = struct{}{}
+	fromMinorUnit, err := domain.CurrencyMinorUnit(from)
+	if err != nil {
+		return domain.EntryCurrencyFix{}, err
+	}
+	toMinorUnit, err := domain.CurrencyMinorUnit(to)
+	if err != nil {
+		return domain.EntryCurrencyFix{}, err
+	}
+	if fromMinorUnit != toMinorUnit {
+		return domain.EntryCurrencyFix{}, domain.ErrCurrencyFixMinorUnit
 	}
 
-	filtered := make([]domain.Entry, 0, len(entries))
-	for _, entry := range entries {
-		entrySet := make(map[int64]struct{}, len(entry.LabelIDs))
-		for _, labelID := range entry.LabelIDs {
-			entrySet[labelID] = struct{}{}
-		}
-
-		matchesAny := false
-		missingAny := false
-		for labelID := range requestedSet {
-			_, has := entrySet[labelID]
-			if has {
-				matchesAny = true
-			} else {
-				missingAny = true
+	result := domain.EntryCurrencyFix{FromCurrency: from, ToCurrency: to, EntryIDs: []int64{}, DryRun: dryRun}
+	if dryRun {
+		entries, err := s.repo.List(ctx, domain.EntryListFilter{})
+		if err != nil {
+			return domain.EntryCurrencyFix{}, err
+		}
+		for _, entry := range entries {
+			if entry.CurrencyCode == from {
+				result.EntryIDs = append(result.EntryIDs, entry.ID)
 			}
 		}
+		sort.Slice(result.EntryIDs, func(i, j int) bool { return result.EntryIDs[i] < result.EntryIDs[j] })
+		return result, nil
+	}
 
-		keep := false
-		switch mode {
-		case domain.LabelFilterModeAny:
-			keep = matchesAny
-		case domain.LabelFilterModeAll:
-			keep = !missingAny
-		case domain.LabelFilterModeNone:
-			keep = !matchesAny
-		default:
-			keep = matchesAny
-		}
+	replacer, ok := s.repo.(EntryCurrencyReplacer)
+	if !ok || replacer == nil {
+		return domain.EntryCurrencyFix{}, fmt.Errorf("entry service: repo cannot replace currencies")
+	}
+	entryIDs, err := replacer.ReplaceCurrency(ctx, from, to)
+	if err != nil {
+		return domain.EntryCurrencyFix{}, err
+	}
+	result.EntryIDs = entryIDs
+	result.Updated = int64(len(entryIDs))
+	return result, nil
+}
 
-		if keep {
+func filterEntriesByLabelMode(entries []domain.Entry, labelIDs []int64, mode string) []domain.Entry {
+	if len(entries) == 0 || len(labelIDs) == 0 {
+		return entries
+	}
+
+	requestedSet := labelIDSet(labelIDs)
+	filtered := make([]domain.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if entryMatchesLabelMode(entry, requestedSet, mode) {
 			filtered = append(filtered, entry)
 		}
 	}
@@ -538,6 +1211,41 @@ func filterEntriesByLabelMode(entries []domain.Entry, labelIDs []int64, mode str
 	return filtered
 }
 
+func labelIDSet(labelIDs []int64) map[int64]struct{} {
+	set := make(map[int64]struct{}, len(labelIDs))
+	for _, labelID := range labelIDs {
+		set[labelID] = struct{}{}
+	}
+	return set
+}
+
+func entryMatchesLabelMode(entry domain.Entry, requestedSet map[int64]struct{}, mode string) bool {
+	entrySet := make(map[int64]struct{}, len(entry.LabelIDs))
+	for _, labelID := range entry.LabelIDs {
+		entrySet[labelID] = struct{}{}
+	}
+
+	matchesAny := false
+	missingAny := false
+	for labelID := range requestedSet {
+		_, has := entrySet[labelID]
+		if has {
+			matchesAny = true
+		} else {
+			missingAny = true
+		}
+	}
+
+	switch mode {
+	case domain.LabelFilterModeAll:
+		return !missingAny
+	case domain.LabelFilterModeNone:
+		return !matchesAny
+	default:
+		return matchesAny
+	}
+}
+
 func (s *EntryService) syncCreditLiability(ctx context.Context, entry domain.Entry) error {
 	if strings.TrimSpace(entry.Type) != domain.EntryTypeExpense {
 		return nil
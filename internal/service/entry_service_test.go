@@ -13,6 +13,7 @@ type entryRepoStub struct {
 	addFn    func(ctx context.Context, input domain.EntryAddInput) (domain.Entry, error)
 	updateFn func(ctx context.Context, input domain.EntryUpdateInput) (domain.Entry, error)
 	listFn   func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+	getFn    func(ctx context.Context, id int64) (domain.Entry, error)
 	deleteFn func(ctx context.Context, id int64) (domain.EntryDeleteResult, error)
 }
 
@@ -28,6 +29,10 @@ func (s *entryRepoStub) List(ctx context.Context, filter domain.EntryListFilter)
 	return s.listFn(ctx, filter)
 }
 
+func (s *entryRepoStub) Get(ctx context.Context, id int64) (domain.Entry, error) {
+	return s.getFn(ctx, id)
+}
+
 func (s *entryRepoStub) Delete(ctx context.Context, id int64) (domain.EntryDeleteResult, error) {
 	return s.deleteFn(ctx, id)
 }
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type EnvelopeRepository interface {
+	Add(ctx context.Context, input domain.EnvelopeAddInput) (domain.Envelope, error)
+	GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Envelope, error)
+	List(ctx context.Context, includeDeleted bool) ([]domain.Envelope, error)
+	Delete(ctx context.Context, id int64) (domain.EnvelopeDeleteResult, error)
+}
+
+type EnvelopeEntryReader interface {
+	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+}
+
+type EnvelopeService struct {
+	repo    EnvelopeRepository
+	entries EnvelopeEntryReader
+}
+
+func NewEnvelopeService(repo EnvelopeRepository, entries EnvelopeEntryReader) (*EnvelopeService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("envelope service: repo is required")
+	}
+	if entries == nil {
+		return nil, fmt.Errorf("envelope service: entry reader is required")
+	}
+	return &EnvelopeService{repo: repo, entries: entries}, nil
+}
+
+// Add records the envelope and returns its status, which already counts any
+// labeled expenses in range.
+func (s *EnvelopeService) Add(ctx context.Context, input domain.EnvelopeAddInput) (domain.EnvelopeStatus, error) {
+	normalized, err := domain.NormalizeEnvelopeAddInput(input)
+	if err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+
+	envelope, err := s.repo.Add(ctx, normalized)
+	if err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+	return s.status(ctx, envelope)
+}
+
+func (s *EnvelopeService) List(ctx context.Context, includeDeleted bool) ([]domain.EnvelopeStatus, error) {
+	envelopes, err := s.repo.List(ctx, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]domain.EnvelopeStatus, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		status, err := s.status(ctx, envelope)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *EnvelopeService) Show(ctx context.Context, id int64) (domain.EnvelopeStatus, error) {
+	if err := domain.ValidateEnvelopeID(id); err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+
+	envelope, err := s.repo.GetByID(ctx, id, false)
+	if err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+	return s.status(ctx, envelope)
+}
+
+func (s *EnvelopeService) Delete(ctx context.Context, id int64) (domain.EnvelopeDeleteResult, error) {
+	if err := domain.ValidateEnvelopeID(id); err != nil {
+		return domain.EnvelopeDeleteResult{}, err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *EnvelopeService) status(ctx context.Context, envelope domain.Envelope) (domain.EnvelopeStatus, error) {
+	dateFromUTC, dateToUTC, err := domain.EnvelopeDateRangeUTC(envelope)
+	if err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+
+	entries, err := s.entries.List(ctx, domain.EntryListFilter{
+		Type:        domain.EntryTypeExpense,
+		DateFromUTC: dateFromUTC,
+		DateToUTC:   dateToUTC,
+	})
+	if err != nil {
+		return domain.EnvelopeStatus{}, err
+	}
+	return domain.BuildEnvelopeStatus(envelope, entries), nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+const defaultEventPollInterval = time.Second
+
+type EventRepository interface {
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]domain.ChangeEvent, error)
+	LatestID(ctx context.Context) (int64, error)
+}
+
+// EventService reads the audit feed that database triggers append to, so
+// scripts can react to entry, cap and card changes without polling exports.
+type EventService struct {
+	repo         EventRepository
+	pollInterval time.Duration
+}
+
+type EventServiceOption func(*EventService)
+
+func WithEventPollInterval(interval time.Duration) EventServiceOption {
+	return func(s *EventService) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+// EventTailRequest selects where the feed starts. A nil AfterID replays from
+// the beginning, or starts at the newest event when following.
+type EventTailRequest struct {
+	AfterID *int64
+	Limit   int
+	Follow  bool
+}
+
+type EventTailResult struct {
+	Emitted int64 `json:"emitted"`
+	LastID  int64 `json:"last_id"`
+}
+
+func NewEventService(repo EventRepository, opts ...EventServiceOption) (*EventService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("event service: repo is required")
+	}
+
+	service := &EventService{repo: repo, pollInterval: defaultEventPollInterval}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(service)
+		}
+	}
+
+	return service, nil
+}
+
+// Tail passes events to emit in id order. Without Follow it returns after one
+// batch of at most Limit events; with Follow it keeps polling until ctx is
+// cancelled, which ends the tail without an error.
+func (s *EventService) Tail(ctx context.Context, req EventTailRequest, emit func(domain.ChangeEvent) error) (EventTailResult, error) {
+	limit, err := domain.NormalizeChangeEventLimit(req.Limit)
+	if err != nil {
+		return EventTailResult{}, err
+	}
+
+	result := EventTailResult{}
+	switch {
+	case req.AfterID != nil:
+		if err := domain.ValidateChangeEventCursor(*req.AfterID); err != nil {
+			return EventTailResult{}, err
+		}
+		result.LastID = *req.AfterID
+	case req.Follow:
+		latestID, err := s.repo.LatestID(ctx)
+		if err != nil {
+			return EventTailResult{}, err
+		}
+		result.LastID = latestID
+	}
+
+	for {
+		events, err := s.repo.ListAfter(ctx, result.LastID, limit)
+		if err != nil {
+			if req.Follow && ctx.Err() != nil {
+				return result, nil
+			}
+			return result, err
+		}
+		for _, event := range events {
+			if err := emit(event); err != nil {
+				return result, err
+			}
+			result.Emitted++
+			result.LastID = event.ID
+		}
+
+		if !req.Follow {
+			return result, nil
+		}
+		if len(events) == limit {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
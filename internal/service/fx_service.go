@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type FXCacheRepository interface {
+	ListCachePairs(ctx context.Context) ([]domain.FXCachePair, error)
+}
+
+type FXService struct {
+	repo FXCacheRepository
+}
+
+func NewFXService(repo FXCacheRepository) (*FXService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("fx service: repo is required")
+	}
+	return &FXService{repo: repo}, nil
+}
+
+// CacheStatus lists the cached rate pairs with the age of their newest rate
+// as of asOf, flagged stale under policy.
+func (s *FXService) CacheStatus(ctx context.Context, policy domain.FXCachePolicy, asOf time.Time) (domain.FXCacheStatus, error) {
+	pairs, err := s.repo.ListCachePairs(ctx)
+	if err != nil {
+		return domain.FXCacheStatus{}, err
+	}
+	return domain.BuildFXCacheStatus(pairs, policy, asOf), nil
+}
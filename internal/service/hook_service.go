@@ -0,0 +1,178 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+const defaultHookTimeout = 10 * time.Second
+
+// HookEventHeader and HookEventEnv carry the event name next to the payload
+// for HTTP and command hooks respectively.
+const (
+	HookEventHeader = "X-Boring-Budget-Event"
+	HookEventEnv    = "BORING_BUDGET_HOOK_EVENT"
+)
+
+type HookRepository interface {
+	Add(ctx context.Context, input domain.HookAddInput) (domain.Hook, error)
+	List(ctx context.Context, event string) ([]domain.Hook, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// HookService stores hooks and delivers JSON envelopes to them, turning the
+// otherwise silent CLI into a source of push notifications.
+type HookService struct {
+	repo       HookRepository
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+type HookServiceOption func(*HookService)
+
+func WithHookHTTPClient(client *http.Client) HookServiceOption {
+	return func(s *HookService) {
+		if client != nil {
+			s.httpClient = client
+		}
+	}
+}
+
+// WithHookTimeout bounds each delivery; a hook still running after it is
+// killed and reported as failed.
+func WithHookTimeout(timeout time.Duration) HookServiceOption {
+	return func(s *HookService) {
+		if timeout > 0 {
+			s.timeout = timeout
+		}
+	}
+}
+
+func NewHookService(repo HookRepository, opts ...HookServiceOption) (*HookService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("hook service: repo is required")
+	}
+
+	service := &HookService{
+		repo:       repo,
+		httpClient: http.DefaultClient,
+		timeout:    defaultHookTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(service)
+		}
+	}
+
+	return service, nil
+}
+
+func (s *HookService) Add(ctx context.Context, input domain.HookAddInput) (domain.Hook, error) {
+	normalized, err := domain.NormalizeHookAddInput(input)
+	if err != nil {
+		return domain.Hook{}, err
+	}
+	return s.repo.Add(ctx, normalized)
+}
+
+// List returns every hook, or only the hooks of event when it is not empty.
+func (s *HookService) List(ctx context.Context, event string) ([]domain.Hook, error) {
+	if strings.TrimSpace(event) == "" {
+		return s.repo.List(ctx, "")
+	}
+	normalized, err := domain.NormalizeHookEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, normalized)
+}
+
+func (s *HookService) Remove(ctx context.Context, id int64) error {
+	if err := domain.ValidateHookID(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Dispatch delivers payload to every hook of event that accept admits (nil
+// admits all), in id order. Only loading the hooks can fail; delivery errors
+// are recorded on the returned deliveries.
+func (s *HookService) Dispatch(ctx context.Context, event string, payload []byte, accept func(domain.Hook) bool) ([]domain.HookDelivery, error) {
+	hooks, err := s.repo.List(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]domain.HookDelivery, 0, len(hooks))
+	for _, hook := range hooks {
+		if accept != nil && !accept(hook) {
+			continue
+		}
+
+		delivery := domain.HookDelivery{HookID: hook.ID, Event: hook.Event, Kind: hook.Kind, Target: hook.Target}
+		if err := s.deliver(ctx, hook, payload); err != nil {
+			delivery.Error = err.Error()
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+func (s *HookService) deliver(ctx context.Context, hook domain.Hook, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if hook.Kind == domain.HookKindHTTP {
+		return s.post(ctx, hook, payload)
+	}
+	return runHookCommand(ctx, hook, payload)
+}
+
+func (s *HookService) post(ctx context.Context, hook domain.Hook, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Target, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HookEventHeader, hook.Event)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post hook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("post hook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func runHookCommand(ctx context.Context, hook domain.Hook, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Target)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Target)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), HookEventEnv+"="+hook.Event)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+			return fmt.Errorf("run hook command: %w: %s", err, trimmed)
+		}
+		return fmt.Errorf("run hook command: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+type InflationIndexRepository interface {
+	UpsertPoints(ctx context.Context, inputs []domain.InflationIndexPointInput) (int, error)
+	List(ctx context.Context, currencyCode string) ([]domain.InflationIndexPoint, error)
+}
+
+type InflationService struct {
+	repo InflationIndexRepository
+}
+
+type InflationImportResult struct {
+	Imported int `json:"imported"`
+}
+
+func NewInflationService(repo InflationIndexRepository) (*InflationService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("inflation service: repo is required")
+	}
+	return &InflationService{repo: repo}, nil
+}
+
+// ImportCSV loads index points from a CSV file with the columns
+// currency_code,index_date,index_value. A header row is optional. All rows are
+// validated before anything is written.
+func (s *InflationService) ImportCSV(ctx context.Context, filePath string) (InflationImportResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return InflationImportResult{}, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	inputs := []domain.InflationIndexPointInput{}
+	rowNumber := 0
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return InflationImportResult{}, err
+		}
+
+		rowNumber++
+		if rowNumber == 1 && len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), "currency_code") {
+			continue
+		}
+		if len(row) < 3 {
+			return InflationImportResult{}, fmt.Errorf("invalid csv row %d: expected 3 columns", rowNumber)
+		}
+
+		normalized, err := domain.NormalizeInflationIndexPointInput(domain.InflationIndexPointInput{
+			CurrencyCode: row[0],
+			IndexDate:    row[1],
+			IndexValue:   row[2],
+		})
+		if err != nil {
+			return InflationImportResult{}, fmt.Errorf("invalid csv row %d: %w", rowNumber, err)
+		}
+		inputs = append(inputs, normalized)
+	}
+
+	imported, err := s.repo.UpsertPoints(ctx, inputs)
+	if err != nil {
+		return InflationImportResult{}, err
+	}
+	return InflationImportResult{Imported: imported}, nil
+}
+
+func (s *InflationService) List(ctx context.Context, currencyCode string) ([]domain.InflationIndexPoint, error) {
+	normalized := ""
+	if strings.TrimSpace(currencyCode) != "" {
+		code, err := domain.NormalizeCurrencyCode(currencyCode)
+		if err != nil {
+			return nil, err
+		}
+		normalized = code
+	}
+	return s.repo.List(ctx, normalized)
+}
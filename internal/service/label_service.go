@@ -10,7 +10,10 @@ import (
 type LabelRepository interface {
 	Add(ctx context.Context, name string) (domain.Label, error)
 	List(ctx context.Context) ([]domain.Label, error)
+	ListSorted(ctx context.Context, sortKey string) ([]domain.Label, error)
 	Rename(ctx context.Context, id int64, newName string) (domain.Label, error)
+	SetArchived(ctx context.Context, id int64, archived bool) (domain.Label, error)
+	UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Label, error)
 	Delete(ctx context.Context, id int64) (domain.LabelDeleteResult, error)
 }
 
@@ -37,6 +40,14 @@ func (s *LabelService) List(ctx context.Context) ([]domain.Label, error) {
 	return s.repo.List(ctx)
 }
 
+func (s *LabelService) ListSorted(ctx context.Context, sortKey string) ([]domain.Label, error) {
+	normalized, err := domain.NormalizeListSort(sortKey, domain.LabelSortKeys)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListSorted(ctx, normalized)
+}
+
 func (s *LabelService) Rename(ctx context.Context, id int64, newName string) (domain.Label, error) {
 	if err := domain.ValidateLabelID(id); err != nil {
 		return domain.Label{}, err
@@ -50,6 +61,36 @@ func (s *LabelService) Rename(ctx context.Context, id int64, newName string) (do
 	return s.repo.Rename(ctx, id, normalized)
 }
 
+// Archive hides a label from listings and new entries while keeping its
+// existing entry links.
+func (s *LabelService) Archive(ctx context.Context, id int64) (domain.Label, error) {
+	if err := domain.ValidateLabelID(id); err != nil {
+		return domain.Label{}, err
+	}
+	return s.repo.SetArchived(ctx, id, true)
+}
+
+func (s *LabelService) Unarchive(ctx context.Context, id int64) (domain.Label, error) {
+	if err := domain.ValidateLabelID(id); err != nil {
+		return domain.Label{}, err
+	}
+	return s.repo.SetArchived(ctx, id, false)
+}
+
+// UpdateAppearance sets the display color and icon used by human output.
+func (s *LabelService) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Label, error) {
+	if err := domain.ValidateLabelID(id); err != nil {
+		return domain.Label{}, err
+	}
+
+	normalized, err := domain.NormalizeAppearanceUpdate(update)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	return s.repo.UpdateAppearance(ctx, id, normalized)
+}
+
 func (s *LabelService) Delete(ctx context.Context, id int64) (domain.LabelDeleteResult, error) {
 	if err := domain.ValidateLabelID(id); err != nil {
 		return domain.LabelDeleteResult{}, err
@@ -10,10 +10,13 @@ import (
 )
 
 type labelRepoStub struct {
-	addFn    func(ctx context.Context, name string) (domain.Label, error)
-	listFn   func(ctx context.Context) ([]domain.Label, error)
-	renameFn func(ctx context.Context, id int64, newName string) (domain.Label, error)
-	deleteFn func(ctx context.Context, id int64) (domain.LabelDeleteResult, error)
+	addFn         func(ctx context.Context, name string) (domain.Label, error)
+	listFn        func(ctx context.Context) ([]domain.Label, error)
+	listSortedFn  func(ctx context.Context, sortKey string) ([]domain.Label, error)
+	renameFn      func(ctx context.Context, id int64, newName string) (domain.Label, error)
+	setArchivedFn func(ctx context.Context, id int64, archived bool) (domain.Label, error)
+	appearanceFn  func(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Label, error)
+	deleteFn      func(ctx context.Context, id int64) (domain.LabelDeleteResult, error)
 }
 
 func (s *labelRepoStub) Add(ctx context.Context, name string) (domain.Label, error) {
@@ -24,10 +27,22 @@ func (s *labelRepoStub) List(ctx context.Context) ([]domain.Label, error) {
 	return s.listFn(ctx)
 }
 
+func (s *labelRepoStub) ListSorted(ctx context.Context, sortKey string) ([]domain.Label, error) {
+	return s.listSortedFn(ctx, sortKey)
+}
+
 func (s *labelRepoStub) Rename(ctx context.Context, id int64, newName string) (domain.Label, error) {
 	return s.renameFn(ctx, id, newName)
 }
 
+func (s *labelRepoStub) SetArchived(ctx context.Context, id int64, archived bool) (domain.Label, error) {
+	return s.setArchivedFn(ctx, id, archived)
+}
+
+func (s *labelRepoStub) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Label, error) {
+	return s.appearanceFn(ctx, id, update)
+}
+
 func (s *labelRepoStub) Delete(ctx context.Context, id int64) (domain.LabelDeleteResult, error) {
 	return s.deleteFn(ctx, id)
 }
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type LoanRepository interface {
+	Add(ctx context.Context, input domain.LoanAddInput) (domain.Loan, error)
+	GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Loan, error)
+	List(ctx context.Context, includeDeleted bool) ([]domain.Loan, error)
+	Delete(ctx context.Context, id int64) (domain.LoanDeleteResult, error)
+	LinkPayment(ctx context.Context, loanID, entryID int64) error
+	UnlinkPayment(ctx context.Context, loanID, entryID int64) error
+	ListPaymentEntries(ctx context.Context, loanID int64) ([]domain.LoanPaymentEntry, error)
+}
+
+type LoanEntryReader interface {
+	Get(ctx context.Context, id int64) (domain.Entry, error)
+}
+
+type LoanService struct {
+	repo    LoanRepository
+	entries LoanEntryReader
+}
+
+func NewLoanService(repo LoanRepository, entries LoanEntryReader) (*LoanService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("loan service: repo is required")
+	}
+	if entries == nil {
+		return nil, fmt.Errorf("loan service: entry reader is required")
+	}
+	return &LoanService{repo: repo, entries: entries}, nil
+}
+
+// Add records the loan and returns it with its planned schedule.
+func (s *LoanService) Add(ctx context.Context, input domain.LoanAddInput) (domain.LoanStatus, error) {
+	normalized, err := domain.NormalizeLoanAddInput(input)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+
+	loan, err := s.repo.Add(ctx, normalized)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	return domain.BuildLoanStatus(loan, nil)
+}
+
+// List returns each loan's progress without schedules or payment rows.
+func (s *LoanService) List(ctx context.Context, includeDeleted bool) ([]domain.LoanStatus, error) {
+	loans, err := s.repo.List(ctx, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]domain.LoanStatus, 0, len(loans))
+	for _, loan := range loans {
+		status, err := s.status(ctx, loan)
+		if err != nil {
+			return nil, err
+		}
+		status.Schedule = nil
+		status.Payments = nil
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *LoanService) Show(ctx context.Context, id int64) (domain.LoanStatus, error) {
+	loan, err := s.get(ctx, id)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	return s.status(ctx, loan)
+}
+
+func (s *LoanService) Delete(ctx context.Context, id int64) (domain.LoanDeleteResult, error) {
+	if err := domain.ValidateLoanID(id); err != nil {
+		return domain.LoanDeleteResult{}, err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// LinkPayment attaches an active expense entry in the loan currency as a
+// payment and returns the updated status.
+func (s *LoanService) LinkPayment(ctx context.Context, loanID, entryID int64) (domain.LoanStatus, error) {
+	loan, err := s.get(ctx, loanID)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	if err := domain.ValidateEntryID(entryID); err != nil {
+		return domain.LoanStatus{}, err
+	}
+
+	entry, err := s.entries.Get(ctx, entryID)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	if entry.Type != domain.EntryTypeExpense {
+		return domain.LoanStatus{}, fmt.Errorf("%w: entry %d is not an expense", domain.ErrLoanPaymentEntryInvalid, entryID)
+	}
+	if entry.CurrencyCode != loan.CurrencyCode {
+		return domain.LoanStatus{}, fmt.Errorf("%w: entry currency %s does not match loan currency %s", domain.ErrLoanPaymentEntryInvalid, entry.CurrencyCode, loan.CurrencyCode)
+	}
+
+	if err := s.repo.LinkPayment(ctx, loanID, entryID); err != nil {
+		return domain.LoanStatus{}, err
+	}
+	return s.status(ctx, loan)
+}
+
+func (s *LoanService) UnlinkPayment(ctx context.Context, loanID, entryID int64) (domain.LoanStatus, error) {
+	loan, err := s.get(ctx, loanID)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	if err := domain.ValidateEntryID(entryID); err != nil {
+		return domain.LoanStatus{}, err
+	}
+
+	if err := s.repo.UnlinkPayment(ctx, loanID, entryID); err != nil {
+		return domain.LoanStatus{}, err
+	}
+	return s.status(ctx, loan)
+}
+
+func (s *LoanService) get(ctx context.Context, id int64) (domain.Loan, error) {
+	if err := domain.ValidateLoanID(id); err != nil {
+		return domain.Loan{}, err
+	}
+	return s.repo.GetByID(ctx, id, false)
+}
+
+func (s *LoanService) status(ctx context.Context, loan domain.Loan) (domain.LoanStatus, error) {
+	entries, err := s.repo.ListPaymentEntries(ctx, loan.ID)
+	if err != nil {
+		return domain.LoanStatus{}, err
+	}
+	return domain.BuildLoanStatus(loan, entries)
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type NetWorthLedgerReader interface {
+	Show(ctx context.Context, req SavingsShowRequest) (domain.SavingsBalanceViews, error)
+}
+
+type NetWorthCardDebtReader interface {
+	ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, error)
+}
+
+type NetWorthSnapshotStore interface {
+	SaveSnapshots(ctx context.Context, snapshotDate string, positions []domain.NetWorthPosition) ([]domain.NetWorthSnapshot, error)
+	ListSnapshots(ctx context.Context, dateFrom, dateTo, currencyCode string) ([]domain.NetWorthSnapshot, error)
+}
+
+type NetWorthEntryReader interface {
+	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+}
+
+type NetWorthBankAccountLister interface {
+	List(ctx context.Context, filter domain.BankAccountListFilter) ([]domain.BankAccount, error)
+}
+
+type NetWorthAssetLister interface {
+	List(ctx context.Context, filter domain.AssetListFilter) ([]domain.Asset, error)
+}
+
+type NetWorthService struct {
+	ledger        NetWorthLedgerReader
+	cardDebt      NetWorthCardDebtReader
+	store         NetWorthSnapshotStore
+	entryReader   NetWorthEntryReader
+	accountLister NetWorthBankAccountLister
+	assetLister   NetWorthAssetLister
+}
+
+type NetWorthHistoryRequest struct {
+	Grouping     string
+	DateFrom     string
+	DateTo       string
+	CurrencyCode string
+}
+
+type NetWorthServiceOption func(*NetWorthService)
+
+// WithNetWorthAccounts breaks the position down by the bank accounts entries
+// are booked on.
+func WithNetWorthAccounts(entryReader NetWorthEntryReader, accountLister NetWorthBankAccountLister) NetWorthServiceOption {
+	return func(s *NetWorthService) {
+		s.entryReader = entryReader
+		s.accountLister = accountLister
+	}
+}
+
+// WithNetWorthAssets adds the holdings and loans of the asset registry.
+func WithNetWorthAssets(assetLister NetWorthAssetLister) NetWorthServiceOption {
+	return func(s *NetWorthService) {
+		s.assetLister = assetLister
+	}
+}
+
+func NewNetWorthService(ledger NetWorthLedgerReader, cardDebt NetWorthCardDebtReader, store NetWorthSnapshotStore, opts ...NetWorthServiceOption) (*NetWorthService, error) {
+	if ledger == nil {
+		return nil, fmt.Errorf("net worth service: ledger reader is required")
+	}
+	if cardDebt == nil {
+		return nil, fmt.Errorf("net worth service: card debt reader is required")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("net worth service: snapshot store is required")
+	}
+
+	service := &NetWorthService{
+		ledger:   ledger,
+		cardDebt: cardDebt,
+		store:    store,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(service)
+		}
+	}
+
+	return service, nil
+}
+
+// Show returns the current position per currency. When asOfDate (YYYY-MM-DD)
+// is today it is recorded as the day's snapshot, replacing one taken earlier
+// that day; balances are not computed as of other dates, so their snapshots
+// are left untouched.
+func (s *NetWorthService) Show(ctx context.Context, asOfDate, today string) (domain.NetWorth, error) {
+	normalizedDate, err := normalizeNetWorthDate(asOfDate)
+	if err != nil {
+		return domain.NetWorth{}, err
+	}
+	normalizedToday, err := normalizeNetWorthDate(today)
+	if err != nil {
+		return domain.NetWorth{}, err
+	}
+
+	assets := []domain.Asset{}
+	if s.assetLister != nil {
+		assets, err = s.assetLister.List(ctx, domain.AssetListFilter{})
+		if err != nil {
+			return domain.NetWorth{}, err
+		}
+	}
+	positions, err := s.positions(ctx, assets)
+	if err != nil {
+		return domain.NetWorth{}, err
+	}
+	accounts, err := s.accountBalances(ctx)
+	if err != nil {
+		return domain.NetWorth{}, err
+	}
+
+	recorded := normalizedDate == normalizedToday
+	if recorded {
+		if _, err := s.store.SaveSnapshots(ctx, normalizedDate, positions); err != nil {
+			return domain.NetWorth{}, err
+		}
+	}
+
+	return domain.NetWorth{
+		AsOfDate:   normalizedDate,
+		Recorded:   recorded,
+		ByCurrency: positions,
+		Accounts:   accounts,
+		Assets:     assets,
+	}, nil
+}
+
+// History rolls the recorded snapshots up by day, week or month.
+func (s *NetWorthService) History(ctx context.Context, req NetWorthHistoryRequest) (domain.NetWorthHistory, error) {
+	dateFrom, err := normalizeOptionalNetWorthDate(req.DateFrom)
+	if err != nil {
+		return domain.NetWorthHistory{}, err
+	}
+	dateTo, err := normalizeOptionalNetWorthDate(req.DateTo)
+	if err != nil {
+		return domain.NetWorthHistory{}, err
+	}
+	if dateFrom != "" && dateTo != "" && dateFrom > dateTo {
+		return domain.NetWorthHistory{}, domain.ErrInvalidDateRange
+	}
+	currencyCode := ""
+	if strings.TrimSpace(req.CurrencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(req.CurrencyCode)
+		if err != nil {
+			return domain.NetWorthHistory{}, err
+		}
+	}
+
+	snapshots, err := s.store.ListSnapshots(ctx, dateFrom, dateTo, currencyCode)
+	if err != nil {
+		return domain.NetWorthHistory{}, err
+	}
+	return domain.BuildNetWorthHistory(snapshots, req.Grouping)
+}
+
+func (s *NetWorthService) positions(ctx context.Context, assets []domain.Asset) ([]domain.NetWorthPosition, error) {
+	views, err := s.ledger.Show(ctx, SavingsShowRequest{IncludeLifetime: true})
+	if err != nil {
+		return nil, err
+	}
+	debts, err := s.cardDebt.ShowDebtAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCurrency := map[string]*domain.NetWorthPosition{}
+	positionFor := func(currencyCode string) *domain.NetWorthPosition {
+		position, ok := byCurrency[currencyCode]
+		if !ok {
+			position = &domain.NetWorthPosition{CurrencyCode: currencyCode}
+			byCurrency[currencyCode] = position
+		}
+		return position
+	}
+
+	if views.Lifetime != nil {
+		for _, row := range views.Lifetime.ByCurrency {
+			position := positionFor(row.CurrencyCode)
+			position.CashMinor += row.GeneralBalanceMinor
+			position.SavingsMinor += row.SavingsBalanceMinor
+		}
+	}
+	for _, summary := range debts {
+		for _, bucket := range summary.Buckets {
+			if bucket.BalanceMinorSigned <= 0 {
+				continue
+			}
+			position := positionFor(bucket.CurrencyCode)
+			position.CashMinor += bucket.BalanceMinorSigned
+			position.CardDebtMinor += bucket.BalanceMinorSigned
+		}
+	}
+	for _, total := range domain.TotalAssets(assets) {
+		position := positionFor(total.CurrencyCode)
+		position.HoldingsMinor += total.AssetsMinor
+		position.LoansMinor += total.LiabilitiesMinor
+	}
+
+	currencies := make([]string, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	positions := make([]domain.NetWorthPosition, 0, len(currencies))
+	for _, currency := range currencies {
+		position := byCurrency[currency]
+		position.AssetsMinor = position.CashMinor + position.SavingsMinor + position.HoldingsMinor
+		position.LiabilitiesMinor = position.CardDebtMinor + position.LoansMinor
+		position.NetWorthMinor = position.AssetsMinor - position.LiabilitiesMinor
+		positions = append(positions, *position)
+	}
+	return positions, nil
+}
+
+func (s *NetWorthService) accountBalances(ctx context.Context) ([]domain.NetWorthAccountBalance, error) {
+	balances := []domain.NetWorthAccountBalance{}
+	if s.entryReader == nil || s.accountLister == nil {
+		return balances, nil
+	}
+
+	accounts, err := s.accountLister.List(ctx, domain.BankAccountListFilter{IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	aliases := make(map[int64]string, len(accounts))
+	for _, account := range accounts {
+		aliases[account.ID] = account.Alias
+	}
+
+	entries, err := s.entryReader.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	type accountKey struct {
+		id       int64
+		currency string
+	}
+	totals := map[accountKey]int64{}
+	for _, entry := range entries {
+		if entry.BankAccountID == nil {
+			continue
+		}
+		key := accountKey{id: *entry.BankAccountID, currency: entry.CurrencyCode}
+		switch entry.Type {
+		case domain.EntryTypeIncome:
+			totals[key] += entry.AmountMinor
+		case domain.EntryTypeExpense:
+			totals[key] -= entry.EffectiveAmountMinor()
+		}
+	}
+
+	for key, total := range totals {
+		balances = append(balances, domain.NetWorthAccountBalance{
+			BankAccountID: key.id,
+			Alias:         aliases[key.id],
+			CurrencyCode:  key.currency,
+			BalanceMinor:  total,
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].BankAccountID != balances[j].BankAccountID {
+			return balances[i].BankAccountID < balances[j].BankAccountID
+		}
+		return balances[i].CurrencyCode < balances[j].CurrencyCode
+	})
+	return balances, nil
+}
+
+func normalizeNetWorthDate(value string) (string, error) {
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return "", domain.ErrInvalidTransactionDate
+	}
+	return parsed.Format("2006-01-02"), nil
+}
+
+func normalizeOptionalNetWorthDate(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	return normalizeNetWorthDate(value)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type OperationRepository interface {
+	Create(ctx context.Context, kind string, params json.RawMessage, total int64) (domain.Operation, error)
+	Get(ctx context.Context, id int64) (domain.Operation, error)
+	List(ctx context.Context, status string, limit int) ([]domain.Operation, error)
+	UpdateProgress(ctx context.Context, id int64, progress domain.OperationProgress) (bool, error)
+	Transition(ctx context.Context, id int64, fromStatus, toStatus string, lastError *string) (bool, error)
+}
+
+// OperationProgressRecorder receives progress from a journaled task. Tasks
+// skip work up to Checkpoint when resumed and stop when Advance fails, which
+// happens once the operation is cancelled or the context is done.
+type OperationProgressRecorder interface {
+	Checkpoint() string
+	Done() int64
+	Advance(ctx context.Context, progress domain.OperationProgress) error
+}
+
+// OperationService is the journal for long-running maintenance tasks, so a
+// task interrupted mid-way can be listed, resumed or cancelled later.
+type OperationService struct {
+	repo OperationRepository
+}
+
+func NewOperationService(repo OperationRepository) (*OperationService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("operation service: repo is required")
+	}
+	return &OperationService{repo: repo}, nil
+}
+
+// OperationRun is the handle a running task reports progress through.
+type OperationRun struct {
+	repo      OperationRepository
+	operation domain.Operation
+}
+
+// Start journals a new running operation with the params needed to resume it.
+func (s *OperationService) Start(ctx context.Context, kind string, params any) (*OperationRun, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal operation params: %w", err)
+	}
+	operation, err := s.repo.Create(ctx, kind, raw, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &OperationRun{repo: s.repo, operation: operation}, nil
+}
+
+// Resume reopens a running or failed operation. Running operations are left
+// behind by killed processes, so they are resumable as they are.
+func (s *OperationService) Resume(ctx context.Context, id int64) (*OperationRun, error) {
+	operation, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !operation.IsResumable() {
+		return nil, domain.ErrOperationNotResumable
+	}
+	if operation.Status == domain.OperationStatusFailed {
+		ok, err := s.repo.Transition(ctx, id, domain.OperationStatusFailed, domain.OperationStatusRunning, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, domain.ErrOperationNotResumable
+		}
+		if operation, err = s.repo.Get(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return &OperationRun{repo: s.repo, operation: operation}, nil
+}
+
+// Cancel stops a running or failed operation; a process still working on it
+// stops at its next progress update.
+func (s *OperationService) Cancel(ctx context.Context, id int64) (domain.Operation, error) {
+	operation, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+	if !operation.IsResumable() {
+		return domain.Operation{}, domain.ErrOperationNotCancelable
+	}
+	ok, err := s.repo.Transition(ctx, id, operation.Status, domain.OperationStatusCancelled, operation.LastError)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+	if !ok {
+		return domain.Operation{}, domain.ErrOperationNotCancelable
+	}
+	return s.repo.Get(ctx, id)
+}
+
+func (s *OperationService) Get(ctx context.Context, id int64) (domain.Operation, error) {
+	return s.repo.Get(ctx, id)
+}
+
+func (s *OperationService) List(ctx context.Context, status string) ([]domain.Operation, error) {
+	normalized, err := domain.NormalizeOperationStatusFilter(status)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, normalized, domain.DefaultOperationListLimit)
+}
+
+func (r *OperationRun) Operation() domain.Operation {
+	return r.operation
+}
+
+func (r *OperationRun) Checkpoint() string {
+	return r.operation.Checkpoint
+}
+
+func (r *OperationRun) Done() int64 {
+	return r.operation.Done
+}
+
+func (r *OperationRun) Advance(ctx context.Context, progress domain.OperationProgress) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ok, err := r.repo.UpdateProgress(ctx, r.operation.ID, progress)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		current, err := r.repo.Get(ctx, r.operation.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status == domain.OperationStatusCancelled {
+			return domain.ErrOperationCancelled
+		}
+		return domain.ErrOperationNotResumable
+	}
+
+	r.operation.Total = progress.Total
+	r.operation.Done = progress.Done
+	r.operation.Checkpoint = progress.Checkpoint
+	return nil
+}
+
+// Finish marks the operation completed, or failed with runErr so it can be
+// resumed. A cancelled operation stays cancelled. The write ignores ctx
+// cancellation so an interrupted task still records its outcome.
+func (r *OperationRun) Finish(ctx context.Context, runErr error) (domain.Operation, error) {
+	ctx = context.WithoutCancel(ctx)
+
+	switch {
+	case runErr == nil:
+		if _, err := r.repo.Transition(ctx, r.operation.ID, domain.OperationStatusRunning, domain.OperationStatusCompleted, nil); err != nil {
+			return domain.Operation{}, err
+		}
+	case errors.Is(runErr, domain.ErrOperationCancelled):
+	default:
+		message := runErr.Error()
+		if _, err := r.repo.Transition(ctx, r.operation.ID, domain.OperationStatusRunning, domain.OperationStatusFailed, &message); err != nil {
+			return domain.Operation{}, err
+		}
+	}
+
+	operation, err := r.repo.Get(ctx, r.operation.ID)
+	if err != nil {
+		return domain.Operation{}, err
+	}
+	r.operation = operation
+	return operation, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+// PortabilityAuditStore reads and appends the audit_events feed.
+type PortabilityAuditStore interface {
+	ListAuditRecords(ctx context.Context) ([]domain.AuditRecord, error)
+	AppendAuditRecords(ctx context.Context, records []domain.AuditRecord) (domain.AuditImportResult, error)
+}
+
+// portabilityAuditJSONEnvelope is the document written by `data export
+// --resource audit`.
+type portabilityAuditJSONEnvelope struct {
+	AuditEvents []domain.AuditRecord `json:"audit_events"`
+}
+
+// WithPortabilityAudit provides the store behind audit trail exports.
+func WithPortabilityAudit(store PortabilityAuditStore) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		s.audit = store
+	}
+}
+
+// ExportAudit writes the whole audit trail with before/after snapshots and a
+// hash chain. The trail is JSON only.
+func (s *PortabilityService) ExportAudit(ctx context.Context, filePath string) (int, error) {
+	if s.audit == nil {
+		return 0, fmt.Errorf("audit export unavailable: audit store is not configured")
+	}
+
+	records, err := s.audit.ListAuditRecords(ctx)
+	if err != nil {
+		return 0, err
+	}
+	trail := domain.BuildAuditTrail(records)
+
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(portabilityAuditJSONEnvelope{AuditEvents: trail})
+	}); err != nil {
+		return 0, err
+	}
+	return len(trail), nil
+}
+
+// ImportAudit verifies the hash chain of an audit export and appends the
+// events missing locally, so a trail survives restoring an older backup.
+// Events already present are always skipped.
+func (s *PortabilityService) ImportAudit(ctx context.Context, filePath string) (domain.AuditImportResult, error) {
+	if s.audit == nil {
+		return domain.AuditImportResult{}, fmt.Errorf("audit import unavailable: audit store is not configured")
+	}
+
+	input, err := s.openInput(filePath)
+	if err != nil {
+		return domain.AuditImportResult{}, err
+	}
+	defer input.Close()
+
+	var envelope portabilityAuditJSONEnvelope
+	if err := json.NewDecoder(input).Decode(&envelope); err != nil {
+		return domain.AuditImportResult{}, fmt.Errorf("%w: %v", domain.ErrInvalidAuditFile, err)
+	}
+	for _, record := range envelope.AuditEvents {
+		if strings.TrimSpace(record.OccurredAtUTC) == "" || strings.TrimSpace(record.Operation) == "" ||
+			strings.TrimSpace(record.EntityType) == "" || strings.TrimSpace(record.EntityID) == "" {
+			return domain.AuditImportResult{}, fmt.Errorf("%w: event %d is missing occurred_at_utc, operation or entity", domain.ErrInvalidAuditFile, record.ID)
+		}
+	}
+	if id, ok := domain.VerifyAuditTrail(envelope.AuditEvents); !ok {
+		return domain.AuditImportResult{}, fmt.Errorf("%w: hash chain broken at event %d", domain.ErrInvalidAuditFile, id)
+	}
+
+	return s.audit.AppendAuditRecords(ctx, envelope.AuditEvents)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+var portabilityCardEventsCSVHeader = []string{"card", "currency_code", "event_type", "amount_minor_signed", "note", "created_at_utc"}
+
+// portabilityCardEventsJSONEnvelope is the document written by `data export
+// --resource card-events`. Events name their card by nickname.
+type portabilityCardEventsJSONEnvelope struct {
+	LiabilityEvents []domain.DatasetLiabilityEvent `json:"liability_events"`
+}
+
+// ExportCardEvents writes card payments and adjustments keyed by card
+// nickname. Charges are left out like in full-dataset archives: importing the
+// card entries recreates them.
+func (s *PortabilityService) ExportCardEvents(ctx context.Context, format, filePath string) (int, error) {
+	if s.dataset == nil {
+		return 0, fmt.Errorf("card events export unavailable: dataset store is not configured")
+	}
+	normalizedFormat := normalizePortabilityFormat(format)
+	if normalizedFormat == "" {
+		return 0, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	dataset, err := s.dataset.LoadDataset(ctx)
+	if err != nil {
+		return 0, err
+	}
+	events := dataset.LiabilityEvents
+	if events == nil {
+		events = []domain.DatasetLiabilityEvent{}
+	}
+
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		if normalizedFormat == PortabilityFormatCSV {
+			return writeCardEventsCSV(w, events)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(portabilityCardEventsJSONEnvelope{LiabilityEvents: events})
+	}); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// ImportCardEvents adds the events of a card-events file to the cards with
+// matching nicknames in one transaction. Unknown nicknames fail the whole
+// import; with idempotent, events identical to an existing one are skipped.
+func (s *PortabilityService) ImportCardEvents(ctx context.Context, format, filePath string, idempotent bool) (domain.DatasetRestoreResult, error) {
+	if s.dataset == nil {
+		return domain.DatasetRestoreResult{}, fmt.Errorf("card events import unavailable: dataset store is not configured")
+	}
+	normalizedFormat := normalizePortabilityFormat(format)
+	if normalizedFormat == "" {
+		return domain.DatasetRestoreResult{}, fmt.Errorf("unsupported import format: %s", format)
+	}
+
+	input, err := s.openInput(filePath)
+	if err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+	defer input.Close()
+
+	var events []domain.DatasetLiabilityEvent
+	if normalizedFormat == PortabilityFormatCSV {
+		events, err = readCardEventsCSV(input)
+	} else {
+		var envelope portabilityCardEventsJSONEnvelope
+		if decodeErr := json.NewDecoder(input).Decode(&envelope); decodeErr != nil {
+			err = fmt.Errorf("%w: %v", domain.ErrInvalidCardEventsFile, decodeErr)
+		}
+		events = envelope.LiabilityEvents
+	}
+	if err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+
+	return s.dataset.RestoreDataset(ctx, domain.Dataset{LiabilityEvents: events}, idempotent)
+}
+
+func writeCardEventsCSV(w io.Writer, events []domain.DatasetLiabilityEvent) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write(portabilityCardEventsCSVHeader); err != nil {
+		return err
+	}
+	for _, event := range events {
+		row := []string{
+			event.Card,
+			event.CurrencyCode,
+			event.EventType,
+			strconv.FormatInt(event.AmountMinorSigned, 10),
+			event.Note,
+			event.CreatedAtUTC,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return writer.Error()
+}
+
+func readCardEventsCSV(input io.Reader) ([]domain.DatasetLiabilityEvent, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = len(portabilityCardEventsCSVHeader)
+
+	events := []domain.DatasetLiabilityEvent{}
+	rowNumber := 0
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCardEventsFile, err)
+		}
+
+		rowNumber++
+		if rowNumber == 1 && strings.EqualFold(strings.TrimSpace(row[0]), "card") {
+			continue
+		}
+
+		amount, err := strconv.ParseInt(strings.TrimSpace(row[3]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: invalid amount_minor_signed", domain.ErrInvalidCardEventsFile, rowNumber)
+		}
+		events = append(events, domain.DatasetLiabilityEvent{
+			Card:              row[0],
+			CurrencyCode:      row[1],
+			EventType:         row[2],
+			AmountMinorSigned: amount,
+			Note:              row[4],
+			CreatedAtUTC:      strings.TrimSpace(row[5]),
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
+)
+
+type PortabilityDatasetStore = ports.DatasetStore
+
+// PortabilityDatasetExportResult counts what a full-dataset archive holds.
+type PortabilityDatasetExportResult struct {
+	Categories      int   `json:"categories"`
+	Labels          int   `json:"labels"`
+	Cards           int   `json:"cards"`
+	Currencies      int   `json:"currencies"`
+	Caps            int   `json:"caps"`
+	LiabilityEvents int   `json:"liability_events"`
+	Settings        bool  `json:"settings"`
+	Entries         int64 `json:"entries"`
+}
+
+type PortabilityDatasetImportResult struct {
+	PortabilityImportResult
+	Restored domain.DatasetRestoreResult `json:"restored"`
+}
+
+// portabilityDatasetArchive is the single JSON document written by
+// `data export --resource all`. Entries always use natural keys so the
+// archive restores into any database.
+type portabilityDatasetArchive struct {
+	FormatVersion int `json:"format_version"`
+	domain.Dataset
+	Entries []portabilityEntryRecord `json:"entries"`
+}
+
+// WithPortabilityDataset provides the store behind full-dataset archives.
+func WithPortabilityDataset(store PortabilityDatasetStore) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		s.dataset = store
+	}
+}
+
+// ExportDataset writes categories, labels, cards, custom currencies, caps,
+// card payments and adjustments, settings, and the entries matching filter as
+// one JSON archive.
+func (s *PortabilityService) ExportDataset(ctx context.Context, filePath string, filter domain.EntryListFilter) (PortabilityDatasetExportResult, error) {
+	if s.dataset == nil {
+		return PortabilityDatasetExportResult{}, fmt.Errorf("dataset export unavailable: dataset store is not configured")
+	}
+
+	dataset, err := s.dataset.LoadDataset(ctx)
+	if err != nil {
+		return PortabilityDatasetExportResult{}, err
+	}
+
+	keys, err := s.loadNaturalKeys(ctx)
+	if err != nil {
+		return PortabilityDatasetExportResult{}, err
+	}
+	entries, err := s.entryService.List(ctx, filter)
+	if err != nil {
+		return PortabilityDatasetExportResult{}, err
+	}
+
+	archive := portabilityDatasetArchive{
+		FormatVersion: domain.DatasetFormatVersion,
+		Dataset:       dataset,
+		Entries:       make([]portabilityEntryRecord, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		record, err := keys.naturalRecord(entry)
+		if err != nil {
+			return PortabilityDatasetExportResult{}, err
+		}
+		archive.Entries = append(archive.Entries, record)
+	}
+
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(archive)
+	}); err != nil {
+		return PortabilityDatasetExportResult{}, err
+	}
+
+	return PortabilityDatasetExportResult{
+		Categories:      len(dataset.Categories),
+		Labels:          len(dataset.Labels),
+		Cards:           len(dataset.Cards),
+		Currencies:      len(dataset.Currencies),
+		Caps:            len(dataset.Caps),
+		LiabilityEvents: len(dataset.LiabilityEvents),
+		Settings:        dataset.Settings != nil,
+		Entries:         int64(len(archive.Entries)),
+	}, nil
+}
+
+// ImportDataset restores a full-dataset archive in one transaction: reference
+// data first, matched by name, then the entries resolved against it. With
+// idempotent, entries and liability events already present are skipped.
+func (s *PortabilityService) ImportDataset(ctx context.Context, filePath string, idempotent bool) (PortabilityDatasetImportResult, error) {
+	if s.dataset == nil {
+		return PortabilityDatasetImportResult{}, fmt.Errorf("dataset import unavailable: dataset store is not configured")
+	}
+	binder, ok := s.dataset.(ports.DatasetStoreTxBinder)
+	if !ok {
+		return PortabilityDatasetImportResult{}, fmt.Errorf("portability import: dataset store does not support transactional import")
+	}
+
+	archive, err := s.readDatasetArchive(filePath)
+	if err != nil {
+		return PortabilityDatasetImportResult{}, err
+	}
+
+	var restored domain.DatasetRestoreResult
+	prepare := func(ctx context.Context, tx *sql.Tx) (portabilityNaturalKeys, error) {
+		restored, err = binder.BindTx(tx).RestoreDataset(ctx, archive.Dataset, idempotent)
+		if err != nil {
+			return portabilityNaturalKeys{}, err
+		}
+		return portabilityNaturalKeys{
+			categoryIDs: restored.CategoryIDs,
+			labelIDs:    restored.LabelIDs,
+			cardIDs:     restored.CardIDs,
+		}, nil
+	}
+
+	imported, err := s.importRecordsWith(ctx, PortabilityImportOptions{Idempotent: idempotent}, prepare, func(consume func(portabilityEntryRecord) error) error {
+		for _, record := range archive.Entries {
+			if err := consume(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return PortabilityDatasetImportResult{}, err
+	}
+
+	return PortabilityDatasetImportResult{PortabilityImportResult: imported, Restored: restored}, nil
+}
+
+func (s *PortabilityService) readDatasetArchive(filePath string) (portabilityDatasetArchive, error) {
+	input, err := s.openInput(filePath)
+	if err != nil {
+		return portabilityDatasetArchive{}, err
+	}
+	defer input.Close()
+
+	var archive portabilityDatasetArchive
+	if err := json.NewDecoder(input).Decode(&archive); err != nil {
+		return portabilityDatasetArchive{}, fmt.Errorf("%w: %v", domain.ErrInvalidDatasetArchive, err)
+	}
+	if archive.FormatVersion > domain.DatasetFormatVersion {
+		return portabilityDatasetArchive{}, fmt.Errorf("%w: archive format_version %d, this build reads up to %d", domain.ErrExportUpgradeRequired, archive.FormatVersion, domain.DatasetFormatVersion)
+	}
+	if archive.FormatVersion != domain.DatasetFormatVersion {
+		return portabilityDatasetArchive{}, fmt.Errorf("%w: unsupported format_version %d", domain.ErrInvalidDatasetArchive, archive.FormatVersion)
+	}
+	return archive, nil
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
+)
+
+// Mirror files live at <dir>/<YYYY>/<YYYY-MM>.json and hold that month's
+// entries in the natural-key JSON export format.
+var portabilityMirrorFilePattern = regexp.MustCompile(`^[0-9]{4}/[0-9]{4}-[0-9]{2}\.json$`)
+
+type PortabilityMirrorResult struct {
+	OperationID  int64    `json:"operation_id,omitempty"`
+	ResumedAfter string   `json:"resumed_after,omitempty"`
+	Dir          string   `json:"dir"`
+	Entries      int64    `json:"entries"`
+	Months       int64    `json:"months"`
+	Written      []string `json:"written"`
+	Unchanged    int64    `json:"unchanged"`
+	Removed      []string `json:"removed"`
+}
+
+type PortabilityMirrorImportResult struct {
+	PortabilityImportResult
+	PortabilityCreatedKeys
+	Dir   string   `json:"dir"`
+	Files []string `json:"files"`
+}
+
+// PortabilityCreatedKeys lists the categories and labels an import created
+// because its records named them but they did not exist locally.
+type PortabilityCreatedKeys struct {
+	CreatedCategories []string `json:"created_categories"`
+	CreatedLabels     []string `json:"created_labels"`
+}
+
+// Mirror writes one canonical natural-key file per month into dir. Files are
+// rewritten only when their content changes, and month files without entries
+// are removed, so the directory diffs cleanly under version control.
+//
+// With a recorder, progress is journaled after each month and a resumed run
+// skips months up to the recorded checkpoint, counting them as unchanged.
+func (s *PortabilityService) Mirror(ctx context.Context, dir string, recorder OperationProgressRecorder) (PortabilityMirrorResult, error) {
+	if strings.TrimSpace(dir) == "" {
+		return PortabilityMirrorResult{}, fmt.Errorf("mirror dir is required")
+	}
+
+	keys, err := s.loadNaturalKeys(ctx)
+	if err != nil {
+		return PortabilityMirrorResult{}, err
+	}
+	entries, err := s.entryService.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		return PortabilityMirrorResult{}, err
+	}
+
+	byMonth := map[string][]portabilityEntryRecord{}
+	for _, entry := range entries {
+		monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
+		if err != nil {
+			return PortabilityMirrorResult{}, err
+		}
+		record, err := keys.naturalRecord(entry)
+		if err != nil {
+			return PortabilityMirrorResult{}, err
+		}
+		byMonth[monthKey] = append(byMonth[monthKey], record)
+	}
+
+	result := PortabilityMirrorResult{
+		Dir:     dir,
+		Entries: int64(len(entries)),
+		Months:  int64(len(byMonth)),
+		Written: []string{},
+		Removed: []string{},
+	}
+
+	monthKeys := make([]string, 0, len(byMonth))
+	for monthKey := range byMonth {
+		monthKeys = append(monthKeys, monthKey)
+	}
+	sort.Strings(monthKeys)
+
+	resumeAfter := ""
+	if recorder != nil {
+		resumeAfter = recorder.Checkpoint()
+		result.ResumedAfter = resumeAfter
+	}
+
+	current := map[string]struct{}{}
+	for i, monthKey := range monthKeys {
+		relativePath := filepath.ToSlash(filepath.Join(monthKey[:4], monthKey+".json"))
+		current[relativePath] = struct{}{}
+		if monthKey <= resumeAfter {
+			result.Unchanged++
+			continue
+		}
+
+		records := byMonth[monthKey]
+		sort.SliceStable(records, func(i, j int) bool {
+			if records[i].TransactionDateUTC != records[j].TransactionDateUTC {
+				return records[i].TransactionDateUTC < records[j].TransactionDateUTC
+			}
+			return records[i].Fingerprint < records[j].Fingerprint
+		})
+
+		var content bytes.Buffer
+		if err := writeEntriesJSON(&content, records); err != nil {
+			return PortabilityMirrorResult{}, err
+		}
+		content.WriteByte('\n')
+
+		fullPath := filepath.Join(dir, filepath.FromSlash(relativePath))
+
+		existing, err := os.ReadFile(fullPath)
+		switch {
+		case err == nil && bytes.Equal(existing, content.Bytes()):
+			result.Unchanged++
+		case err != nil && !errors.Is(err, fs.ErrNotExist):
+			return PortabilityMirrorResult{}, err
+		default:
+			if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+				return PortabilityMirrorResult{}, err
+			}
+			if err := os.WriteFile(fullPath, content.Bytes(), 0o644); err != nil {
+				return PortabilityMirrorResult{}, err
+			}
+			result.Written = append(result.Written, relativePath)
+		}
+
+		if recorder != nil {
+			if err := recorder.Advance(ctx, domain.OperationProgress{
+				Total:      int64(len(monthKeys)),
+				Done:       int64(i + 1),
+				Checkpoint: monthKey,
+			}); err != nil {
+				return PortabilityMirrorResult{}, err
+			}
+		}
+	}
+
+	files, err := listPortabilityMirrorFiles(dir)
+	if err != nil {
+		return PortabilityMirrorResult{}, err
+	}
+	for _, relativePath := range files {
+		if _, ok := current[relativePath]; ok {
+			continue
+		}
+		fullPath := filepath.Join(dir, filepath.FromSlash(relativePath))
+		if err := os.Remove(fullPath); err != nil {
+			return PortabilityMirrorResult{}, err
+		}
+		// Drop the year directory once its last month is gone; a non-empty
+		// directory is left alone.
+		_ = os.Remove(filepath.Dir(fullPath))
+		result.Removed = append(result.Removed, relativePath)
+	}
+
+	return result, nil
+}
+
+// MirrorImport rebuilds entries from a mirror directory in one transaction.
+// Categories and labels named in the mirror but missing locally are created
+// in the same transaction; cards must already exist because they carry more
+// than a name.
+func (s *PortabilityService) MirrorImport(ctx context.Context, dir string, idempotent bool) (PortabilityMirrorImportResult, error) {
+	if strings.TrimSpace(dir) == "" {
+		return PortabilityMirrorImportResult{}, fmt.Errorf("mirror dir is required")
+	}
+
+	files, err := listPortabilityMirrorFiles(dir)
+	if err != nil {
+		return PortabilityMirrorImportResult{}, err
+	}
+
+	result := PortabilityMirrorImportResult{
+		PortabilityCreatedKeys: PortabilityCreatedKeys{CreatedCategories: []string{}, CreatedLabels: []string{}},
+		Dir:                    dir,
+		Files:                  files,
+	}
+
+	streamFiles := func(consume func(portabilityEntryRecord) error) error {
+		for _, relativePath := range files {
+			input, err := os.Open(filepath.Join(dir, filepath.FromSlash(relativePath)))
+			if err != nil {
+				return err
+			}
+			err = streamImportRecordsJSON(input, consume)
+			input.Close()
+			if err != nil {
+				return fmt.Errorf("mirror file %s: %w", relativePath, err)
+			}
+		}
+		return nil
+	}
+
+	_, prepare, err := s.createMissingNaturalKeys(ctx, streamFiles, &result.PortabilityCreatedKeys)
+	if err != nil {
+		return PortabilityMirrorImportResult{}, err
+	}
+
+	imported, err := s.importRecordsWith(ctx, PortabilityImportOptions{Idempotent: idempotent}, prepare, streamFiles)
+	if err != nil {
+		return PortabilityMirrorImportResult{}, err
+	}
+	result.PortabilityImportResult = imported
+
+	return result, nil
+}
+
+// createMissingNaturalKeys finds the categories and labels named by the
+// records of stream that do not exist yet and returns the local natural keys
+// with a prepare hook that creates them inside the import transaction, so a
+// failed import leaves none behind. The hook adds the new IDs to the returned
+// keys and the names to created. stream must be replayable.
+func (s *PortabilityService) createMissingNaturalKeys(ctx context.Context, stream func(func(portabilityEntryRecord) error) error, created *PortabilityCreatedKeys) (portabilityNaturalKeys, portabilityImportPrepare, error) {
+	keys, err := s.loadNaturalKeys(ctx)
+	if err != nil {
+		return portabilityNaturalKeys{}, nil, err
+	}
+
+	missingCategories := map[string]string{}
+	missingLabels := map[string]string{}
+	if err := stream(func(record portabilityEntryRecord) error {
+		if name := strings.TrimSpace(record.Category); name != "" {
+			if _, ok := keys.categoryIDs[strings.ToLower(name)]; !ok {
+				missingCategories[strings.ToLower(name)] = name
+			}
+		}
+		for _, label := range record.Labels {
+			if name := strings.TrimSpace(label); name != "" {
+				if _, ok := keys.labelIDs[strings.ToLower(name)]; !ok {
+					missingLabels[strings.ToLower(name)] = name
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return portabilityNaturalKeys{}, nil, err
+	}
+
+	categoryCreator, ok := s.categories.(ports.CategoryCreatorTxBinder)
+	if !ok && len(missingCategories) > 0 {
+		return portabilityNaturalKeys{}, nil, fmt.Errorf("%w: %s", domain.ErrCategoryNotFound, strings.Join(sortedMapValues(missingCategories), ", "))
+	}
+	labelCreator, ok := s.labels.(ports.LabelCreatorTxBinder)
+	if !ok && len(missingLabels) > 0 {
+		return portabilityNaturalKeys{}, nil, fmt.Errorf("%w: %s", domain.ErrLabelNotFound, strings.Join(sortedMapValues(missingLabels), ", "))
+	}
+
+	prepare := func(ctx context.Context, tx *sql.Tx) (portabilityNaturalKeys, error) {
+		for _, name := range sortedMapValues(missingCategories) {
+			category, err := categoryCreator.BindTx(tx).Add(ctx, name)
+			if err != nil {
+				return portabilityNaturalKeys{}, err
+			}
+			keys.categoryIDs[strings.ToLower(name)] = category.ID
+			keys.categoryNames[category.ID] = category.Name
+			created.CreatedCategories = append(created.CreatedCategories, name)
+		}
+		for _, name := range sortedMapValues(missingLabels) {
+			label, err := labelCreator.BindTx(tx).Add(ctx, name)
+			if err != nil {
+				return portabilityNaturalKeys{}, err
+			}
+			keys.labelIDs[strings.ToLower(name)] = label.ID
+			keys.labelNames[label.ID] = label.Name
+			created.CreatedLabels = append(created.CreatedLabels, name)
+		}
+		return keys, nil
+	}
+	return keys, prepare, nil
+}
+
+// listPortabilityMirrorFiles returns mirror month files relative to dir, in
+// chronological order. A missing dir holds no files.
+func listPortabilityMirrorFiles(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if portabilityMirrorFilePattern.MatchString(relativePath) {
+			files = append(files, relativePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func sortedMapValues(values map[string]string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/domain"
+	sqlitestore "boring-budget/internal/store/sqlite"
+)
+
+func TestPortabilityServiceMirrorWritesMonthFilesAndRebuilds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, sourceEntrySvc, sourceDB := newPortabilityServiceTestHarness(t)
+	defer sourceDB.Close()
+
+	sourceSvc := newPortabilityMirrorTestService(t, sourceEntrySvc, sourceDB)
+	groceries, err := sqlitestore.NewCategoryRepo(sourceDB).Add(ctx, "Groceries")
+	if err != nil {
+		t.Fatalf("add category: %v", err)
+	}
+
+	var marchEntryID int64
+	for _, input := range []domain.EntryAddInput{
+		{Type: domain.EntryTypeIncome, AmountMinor: 250000, CurrencyCode: "USD", TransactionDateUTC: "2026-02-01T00:00:00Z", Note: "salary"},
+		{Type: domain.EntryTypeExpense, AmountMinor: 4200, CurrencyCode: "USD", TransactionDateUTC: "2026-02-03T00:00:00Z", CategoryID: &groceries.ID},
+		{Type: domain.EntryTypeExpense, AmountMinor: 1500, CurrencyCode: "USD", TransactionDateUTC: "2026-03-05T00:00:00Z", Note: "lunch"},
+	} {
+		created, err := sourceEntrySvc.Add(ctx, input)
+		if err != nil {
+			t.Fatalf("seed entry: %v", err)
+		}
+		marchEntryID = created.ID
+	}
+
+	dir := filepath.Join(t.TempDir(), "ledger")
+	first, err := sourceSvc.Mirror(ctx, dir, nil)
+	if err != nil {
+		t.Fatalf("first mirror: %v", err)
+	}
+	if first.Entries != 3 || first.Months != 2 || strings.Join(first.Written, ",") != "2026/2026-02.json,2026/2026-03.json" {
+		t.Fatalf("unexpected first mirror result: %+v", first)
+	}
+
+	february, err := os.ReadFile(filepath.Join(dir, "2026", "2026-02.json"))
+	if err != nil {
+		t.Fatalf("read february mirror: %v", err)
+	}
+	if !strings.Contains(string(february), `"category": "Groceries"`) || strings.Contains(string(february), "category_id") {
+		t.Fatalf("expected natural-key month file, got %s", february)
+	}
+
+	second, err := sourceSvc.Mirror(ctx, dir, nil)
+	if err != nil {
+		t.Fatalf("second mirror: %v", err)
+	}
+	if len(second.Written) != 0 || second.Unchanged != 2 {
+		t.Fatalf("expected unchanged re-run, got %+v", second)
+	}
+
+	if _, err := sourceEntrySvc.Delete(ctx, marchEntryID); err != nil {
+		t.Fatalf("delete march entry: %v", err)
+	}
+	third, err := sourceSvc.Mirror(ctx, dir, nil)
+	if err != nil {
+		t.Fatalf("third mirror: %v", err)
+	}
+	if strings.Join(third.Removed, ",") != "2026/2026-03.json" {
+		t.Fatalf("expected empty month file removal, got %+v", third)
+	}
+
+	_, targetEntrySvc, targetDB := newPortabilityServiceTestHarness(t)
+	defer targetDB.Close()
+
+	targetSvc := newPortabilityMirrorTestService(t, targetEntrySvc, targetDB)
+	imported, err := targetSvc.MirrorImport(ctx, dir, true)
+	if err != nil {
+		t.Fatalf("mirror import: %v", err)
+	}
+	if imported.Imported != 2 || strings.Join(imported.CreatedCategories, ",") != "Groceries" {
+		t.Fatalf("unexpected mirror import result: %+v", imported)
+	}
+
+	again, err := targetSvc.MirrorImport(ctx, dir, true)
+	if err != nil {
+		t.Fatalf("repeat mirror import: %v", err)
+	}
+	if again.Imported != 0 || again.Skipped != 2 {
+		t.Fatalf("expected idempotent repeat import, got %+v", again)
+	}
+}
+
+func TestPortabilityServiceMirrorImportRollsBackCreatedKeysOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, entrySvc, db := newPortabilityServiceTestHarness(t)
+	defer db.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "2026"), 0o755); err != nil {
+		t.Fatalf("create mirror year dir: %v", err)
+	}
+	files := map[string]string{
+		"2026-02.json": `[{"type":"expense","amount_minor":1200,"currency_code":"USD","transaction_date_utc":"2026-02-03T00:00:00Z","category":"Travel","labels":["trip"]}]`,
+		"2026-03.json": `[{"type":"expense","amount_minor":1500,"currency_code":"USD","transaction_date_utc":"2026-03-05T00:00:00Z","category":"Travel","payment_method":"card","payment_card":"Missing"}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "2026", name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write mirror file %s: %v", name, err)
+		}
+	}
+
+	svc := newPortabilityMirrorTestService(t, entrySvc, db)
+	if _, err := svc.MirrorImport(ctx, dir, false); err == nil {
+		t.Fatalf("expected mirror import with an unknown card to fail")
+	}
+
+	for _, table := range []string{"categories", "labels", "transactions"} {
+		var count int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+";").Scan(&count); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Fatalf("expected failed mirror import to leave no %s, got %d", table, count)
+		}
+	}
+}
+
+func newPortabilityMirrorTestService(t *testing.T, entrySvc *EntryService, db *sql.DB) *PortabilityService {
+	t.Helper()
+
+	labelRepo, err := sqlitestore.NewLabelRepo(db)
+	if err != nil {
+		t.Fatalf("new label repo: %v", err)
+	}
+	svc, err := NewPortabilityService(entrySvc, db, WithPortabilityNaturalKeys(sqlitestore.NewCategoryRepo(db), labelRepo, sqlitestore.NewCardRepo(db)))
+	if err != nil {
+		t.Fatalf("new portability service: %v", err)
+	}
+	return svc
+}
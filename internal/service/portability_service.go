@@ -1,37 +1,159 @@
 package service
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
 	"boring-budget/internal/reporting"
 )
 
 const (
 	PortabilityFormatJSON = "json"
 	PortabilityFormatCSV  = "csv"
+
+	// PortabilityStdioPath selects stdin for imports and stdout for exports.
+	PortabilityStdioPath = "-"
+
+	// PortabilityKeyModeID exports numeric category and label IDs.
+	PortabilityKeyModeID = "id"
+	// PortabilityKeyModeNatural exports category names, label names, card
+	// nicknames, and entry fingerprints so files are stable across databases.
+	PortabilityKeyModeNatural = "natural"
+
+	// PortabilityCSVShapeWide writes one report CSV row per record with a
+	// column for every measure.
+	PortabilityCSVShapeWide = "wide"
+	// PortabilityCSVShapeTidy writes one report CSV row per measure
+	// (section, dimension, key, currency, amount) for pivoting.
+	PortabilityCSVShapeTidy = "tidy"
+
+	// PortabilityMatchBySignature treats an idempotent import record as
+	// present when an entry with the same fields exists.
+	PortabilityMatchBySignature = "signature"
+	// PortabilityMatchByUID matches idempotent import records to entries by
+	// uid and updates the fields that changed.
+	PortabilityMatchByUID = "uid"
+
+	// PortabilityOnConflictSkip leaves a matched entry alone.
+	PortabilityOnConflictSkip = "skip"
+	// PortabilityOnConflictUpdate updates a matched entry's changed fields.
+	PortabilityOnConflictUpdate = "update"
+	// PortabilityOnConflictDuplicate adds the record next to its match.
+	PortabilityOnConflictDuplicate = "duplicate"
+
+	PortabilityImportOutcomeImported   = "imported"
+	PortabilityImportOutcomeUpdated    = "updated"
+	PortabilityImportOutcomeSkipped    = "skipped"
+	PortabilityImportOutcomeDuplicated = "duplicated"
+
+	// PortabilitySkipReasonMatchedUID and PortabilitySkipReasonMatchedSignature
+	// mark records skipped because they match an existing entry;
+	// PortabilitySkipReasonUnchanged marks matches that --on-conflict update
+	// found identical.
+	PortabilitySkipReasonMatchedUID       = "matched_uid"
+	PortabilitySkipReasonMatchedSignature = "matched_signature"
+	PortabilitySkipReasonUnchanged        = "unchanged"
+)
+
+var (
+	ErrInvalidPortabilityKeyMode    = errors.New("invalid portability key mode")
+	ErrInvalidPortabilityMatchBy    = errors.New("invalid portability match mode")
+	ErrInvalidPortabilityOnConflict = errors.New("invalid portability conflict strategy")
+	ErrInvalidPortabilityCSVShape   = errors.New("invalid report csv shape")
 )
 
+type PortabilityCategoryLister interface {
+	List(ctx context.Context) ([]domain.Category, error)
+}
+
+type PortabilityLabelLister interface {
+	List(ctx context.Context) ([]domain.Label, error)
+}
+
+type PortabilityCardLister interface {
+	ListCards(ctx context.Context, filter ports.CardListFilter) ([]ports.Card, error)
+}
+
 type PortabilityService struct {
 	entryService  *EntryService
 	reportService *ReportService
 	db            *sql.DB
+	stdin         io.Reader
+	stdout        io.Writer
+	categories    PortabilityCategoryLister
+	labels        PortabilityLabelLister
+	cards         PortabilityCardLister
+	dataset       PortabilityDatasetStore
+	audit         PortabilityAuditStore
+	backupper     PortabilityOnlineBackupper
+	progress      func(domain.Progress)
+}
+
+// PortabilityOnlineBackupper copies the live database page by page without
+// blocking other connections.
+type PortabilityOnlineBackupper interface {
+	OnlineBackup(ctx context.Context, outputPath string, pagesPerStep int, progress func(domain.BackupProgress)) (domain.BackupProgress, error)
+}
+
+// PortabilityBackupOptions tunes BackupWithOptions. Online uses the SQLite
+// backup API instead of VACUUM INTO; PagesPerStep and Progress only apply to
+// online backups.
+type PortabilityBackupOptions struct {
+	Online       bool
+	PagesPerStep int
+	Progress     func(domain.BackupProgress)
 }
 
 type PortabilityImportResult struct {
-	Imported int64            `json:"imported"`
-	Skipped  int64            `json:"skipped"`
-	Warnings []domain.Warning `json:"warnings"`
+	Imported   int64                  `json:"imported"`
+	Updated    int64                  `json:"updated"`
+	Skipped    int64                  `json:"skipped"`
+	Duplicated int64                  `json:"duplicated"`
+	Warnings   []domain.Warning       `json:"warnings"`
+	Rows       []PortabilityImportRow `json:"rows,omitempty"`
+}
+
+// PortabilityImportRow is what happened to one input record. Row is the
+// record's 1-based position in the input, CSV header excluded. EntryID is the
+// entry created or updated, or for skipped records the entry matched.
+type PortabilityImportRow struct {
+	Row      int64            `json:"row"`
+	Outcome  string           `json:"outcome"`
+	EntryID  int64            `json:"entry_id,omitempty"`
+	Reason   string           `json:"reason,omitempty"`
+	Warnings []domain.Warning `json:"warnings,omitempty"`
+}
+
+// PortabilityImportOptions tunes ImportWithOptions. CreateMissing creates
+// categories and labels named by records but missing locally instead of
+// failing with NOT_FOUND. MatchBy picks how records that are already present
+// are recognised; it defaults to PortabilityMatchBySignature. OnConflict
+// picks what happens to them and turns matching on by itself; left empty,
+// Idempotent updates uid matches and skips signature matches. RecordRows
+// fills the result's Rows with every record's outcome.
+type PortabilityImportOptions struct {
+	Idempotent    bool
+	CreateMissing bool
+	MatchBy       string
+	OnConflict    string
+	RecordRows    bool
 }
 
 type PortabilityReportExportResult struct {
@@ -41,17 +163,46 @@ type PortabilityReportExportResult struct {
 type PortabilityServiceOption func(*PortabilityService)
 
 type portabilityEntryRecord struct {
-	Type               string  `json:"type"`
-	AmountMinor        int64   `json:"amount_minor"`
-	CurrencyCode       string  `json:"currency_code"`
-	TransactionDateUTC string  `json:"transaction_date_utc"`
-	CategoryID         *int64  `json:"category_id,omitempty"`
-	LabelIDs           []int64 `json:"label_ids,omitempty"`
-	Note               string  `json:"note,omitempty"`
+	UID                string   `json:"uid,omitempty"`
+	Type               string   `json:"type"`
+	AmountMinor        int64    `json:"amount_minor"`
+	Amount             string   `json:"amount,omitempty"`
+	CurrencyCode       string   `json:"currency_code"`
+	TransactionDateUTC string   `json:"transaction_date_utc"`
+	CategoryID         *int64   `json:"category_id,omitempty"`
+	LabelIDs           []int64  `json:"label_ids,omitempty"`
+	Category           string   `json:"category,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	CategoryName       string   `json:"category_name,omitempty"`
+	LabelNames         []string `json:"label_names,omitempty"`
+	Note               string   `json:"note,omitempty"`
+	Payee              string   `json:"payee,omitempty"`
+	RecordedBy         string   `json:"recorded_by,omitempty"`
+	IncomeSource       string   `json:"income_source,omitempty"`
+	Location           string   `json:"location,omitempty"`
+	PaymentMethod      string   `json:"payment_method,omitempty"`
+	PaymentCard        string   `json:"payment_card,omitempty"`
+	// PaymentCardNickname names the card in ID-keyed files, where
+	// PaymentCard would mark the record as natural-keyed.
+	PaymentCardNickname string `json:"payment_card_nickname,omitempty"`
+	Fingerprint         string `json:"fingerprint,omitempty"`
+
+	paymentCardID *int64
+}
+
+// portabilityNaturalKeys maps local IDs to natural keys and back. Name lookups
+// are case-insensitive, matching the active-name uniqueness indexes.
+type portabilityNaturalKeys struct {
+	categoryNames map[int64]string
+	labelNames    map[int64]string
+	categoryIDs   map[string]int64
+	labelIDs      map[string]int64
+	cardIDs       map[string]int64
 }
 
 type portabilityJSONEnvelope struct {
-	Entries []portabilityEntryRecord `json:"entries"`
+	SchemaVersion int                      `json:"schema_version,omitempty"`
+	Entries       []portabilityEntryRecord `json:"entries"`
 }
 
 type portabilityReportJSONEnvelope struct {
@@ -65,6 +216,43 @@ func WithPortabilityReportService(reportService *ReportService) PortabilityServi
 	}
 }
 
+// WithPortabilityStdio overrides the streams used when a file path is "-".
+func WithPortabilityStdio(stdin io.Reader, stdout io.Writer) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		if stdin != nil {
+			s.stdin = stdin
+		}
+		if stdout != nil {
+			s.stdout = stdout
+		}
+	}
+}
+
+// WithPortabilityNaturalKeys provides the lookups used to translate between
+// local IDs and natural keys.
+func WithPortabilityNaturalKeys(categories PortabilityCategoryLister, labels PortabilityLabelLister, cards PortabilityCardLister) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		s.categories = categories
+		s.labels = labels
+		s.cards = cards
+	}
+}
+
+// WithPortabilityOnlineBackup enables BackupWithOptions with Online set.
+func WithPortabilityOnlineBackup(backupper PortabilityOnlineBackupper) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		s.backupper = backupper
+	}
+}
+
+// WithPortabilityProgress reports every row entry exports and imports write
+// or read. Exports count the matching entries first so updates carry a total.
+func WithPortabilityProgress(progress func(domain.Progress)) PortabilityServiceOption {
+	return func(s *PortabilityService) {
+		s.progress = progress
+	}
+}
+
 func NewPortabilityService(entryService *EntryService, db *sql.DB, opts ...PortabilityServiceOption) (*PortabilityService, error) {
 	if entryService == nil {
 		return nil, fmt.Errorf("portability service: entry service is required")
@@ -76,6 +264,8 @@ func NewPortabilityService(entryService *EntryService, db *sql.DB, opts ...Porta
 	service := &PortabilityService{
 		entryService: entryService,
 		db:           db,
+		stdin:        os.Stdin,
+		stdout:       os.Stdout,
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -87,49 +277,211 @@ func NewPortabilityService(entryService *EntryService, db *sql.DB, opts ...Porta
 }
 
 func (s *PortabilityService) Export(ctx context.Context, format, filePath string, filter domain.EntryListFilter) (int64, error) {
+	return s.ExportWithKeys(ctx, format, filePath, PortabilityKeyModeID, filter)
+}
+
+// ExportWithKeys exports entries using either local IDs or natural keys.
+func (s *PortabilityService) ExportWithKeys(ctx context.Context, format, filePath, keyMode string, filter domain.EntryListFilter) (int64, error) {
 	normalizedFormat := normalizePortabilityFormat(format)
 	if normalizedFormat == "" {
 		return 0, fmt.Errorf("unsupported export format: %s", format)
 	}
-
-	entries, err := s.entryService.List(ctx, filter)
+	normalizedKeyMode, err := NormalizePortabilityKeyMode(keyMode)
 	if err != nil {
 		return 0, err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
-		return 0, err
+	toRecord := func(entry domain.Entry) (portabilityEntryRecord, error) {
+		return idPortabilityRecord(entry), nil
 	}
-
-	switch normalizedFormat {
-	case PortabilityFormatJSON:
-		if err := writeEntriesJSON(filePath, entries); err != nil {
+	if normalizedKeyMode == PortabilityKeyModeNatural {
+		keys, err := s.loadNaturalKeys(ctx)
+		if err != nil {
 			return 0, err
 		}
-	case PortabilityFormatCSV:
-		if err := writeEntriesCSV(filePath, entries); err != nil {
+		toRecord = keys.naturalRecord
+	}
+
+	progress := domain.Progress{Operation: "export", Unit: domain.ProgressUnitRows}
+	if s.progress != nil {
+		if err := s.entryService.Each(ctx, filter, func(domain.Entry) error {
+			progress.Total++
+			return nil
+		}); err != nil {
 			return 0, err
 		}
+		s.progress(progress)
 	}
 
-	return int64(len(entries)), nil
+	var exported int64
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		var records portabilityRecordWriter
+		switch {
+		case normalizedFormat == PortabilityFormatCSV && normalizedKeyMode == PortabilityKeyModeNatural:
+			records = newNaturalEntriesCSVWriter(w)
+		case normalizedFormat == PortabilityFormatCSV:
+			records = newEntriesCSVWriter(w)
+		default:
+			records = newEntriesJSONWriter(w)
+		}
+
+		if err := s.entryService.Each(ctx, filter, func(entry domain.Entry) error {
+			record, err := toRecord(entry)
+			if err != nil {
+				return err
+			}
+			exported++
+			if err := records.Write(record); err != nil {
+				return err
+			}
+			if s.progress != nil {
+				progress.Done = exported
+				s.progress(progress)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return records.Close()
+	}); err != nil {
+		return 0, err
+	}
+
+	return exported, nil
 }
 
 func (s *PortabilityService) Import(ctx context.Context, format, filePath string, idempotent bool) (PortabilityImportResult, error) {
+	result, _, err := s.ImportWithOptions(ctx, format, filePath, PortabilityImportOptions{Idempotent: idempotent})
+	return result, err
+}
+
+// ImportWithOptions imports entries like Import. With CreateMissing the input
+// is read fully first, because it is scanned for missing categories and labels
+// before the import transaction creates them.
+func (s *PortabilityService) ImportWithOptions(ctx context.Context, format, filePath string, options PortabilityImportOptions) (PortabilityImportResult, PortabilityCreatedKeys, error) {
 	normalizedFormat := normalizePortabilityFormat(format)
 	if normalizedFormat == "" {
-		return PortabilityImportResult{}, fmt.Errorf("unsupported import format: %s", format)
+		return PortabilityImportResult{}, PortabilityCreatedKeys{}, fmt.Errorf("unsupported import format: %s", format)
+	}
+
+	progress := domain.Progress{Operation: "import", Unit: domain.ProgressUnitRows}
+	if s.progress != nil && filePath != PortabilityStdioPath {
+		if info, err := os.Stat(filePath); err == nil {
+			progress.BytesTotal = info.Size()
+		}
+	}
+
+	stream := func(consume func(portabilityEntryRecord) error) error {
+		input, err := s.openInput(filePath)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		counted := &countingReader{reader: input, count: &progress.BytesDone}
+		return streamImportRecords(normalizedFormat, counted, consume)
+	}
+
+	created := PortabilityCreatedKeys{CreatedCategories: []string{}, CreatedLabels: []string{}}
+	var prepare portabilityImportPrepare
+	if options.CreateMissing {
+		records := []portabilityEntryRecord{}
+		if err := stream(func(record portabilityEntryRecord) error {
+			records = append(records, record)
+			return nil
+		}); err != nil {
+			return PortabilityImportResult{}, PortabilityCreatedKeys{}, err
+		}
+		stream = func(consume func(portabilityEntryRecord) error) error {
+			for _, record := range records {
+				if err := consume(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		var err error
+		_, prepare, err = s.createMissingNaturalKeys(ctx, stream, &created)
+		if err != nil {
+			return PortabilityImportResult{}, PortabilityCreatedKeys{}, err
+		}
+		progress.Total = int64(len(records))
+	}
+
+	if s.progress != nil {
+		read := stream
+		stream = func(consume func(portabilityEntryRecord) error) error {
+			s.progress(progress)
+			return read(func(record portabilityEntryRecord) error {
+				if err := consume(record); err != nil {
+					return err
+				}
+				progress.Done++
+				s.progress(progress)
+				return nil
+			})
+		}
+	}
+
+	result, err := s.importRecordsWith(ctx, options, prepare, stream)
+	if err != nil {
+		return PortabilityImportResult{}, PortabilityCreatedKeys{}, err
+	}
+	return result, created, nil
+}
+
+// importRecords adds every record produced by stream inside one transaction,
+// so a failure anywhere in the stream rolls back the whole batch.
+func (s *PortabilityService) importRecords(ctx context.Context, options PortabilityImportOptions, stream func(consume func(portabilityEntryRecord) error) error) (PortabilityImportResult, error) {
+	return s.importRecordsWith(ctx, options, nil, stream)
+}
+
+// portabilityImportPrepare runs inside the import transaction before any
+// record is added and returns the natural keys records resolve against.
+type portabilityImportPrepare func(ctx context.Context, tx *sql.Tx) (portabilityNaturalKeys, error)
+
+func (s *PortabilityService) importRecordsWith(ctx context.Context, options PortabilityImportOptions, prepare portabilityImportPrepare, stream func(consume func(portabilityEntryRecord) error) error) (result PortabilityImportResult, err error) {
+	started := time.Now()
+	defer func() {
+		attrs := []any{"imported", result.Imported, "updated", result.Updated, "skipped", result.Skipped, "duplicated", result.Duplicated, "duration_ms", time.Since(started).Milliseconds()}
+		if err != nil {
+			attrs = append(attrs, "error", err.Error())
+		}
+		slog.DebugContext(ctx, "portability import", attrs...)
+	}()
+
+	matchBy, err := NormalizePortabilityMatchBy(options.MatchBy)
+	if err != nil {
+		return PortabilityImportResult{}, err
 	}
+	onConflict, err := NormalizePortabilityOnConflict(options.OnConflict)
+	if err != nil {
+		return PortabilityImportResult{}, err
+	}
+	matching := options.Idempotent || onConflict != ""
+	matchByUID := matching && matchBy == PortabilityMatchByUID
 
-	existingSignatures := map[string]struct{}{}
-	if idempotent {
+	existingSignatures := map[string]int64{}
+	if matching {
 		existing, err := s.entryService.List(ctx, domain.EntryListFilter{})
 		if err != nil {
 			return PortabilityImportResult{}, err
 		}
 		for _, entry := range existing {
-			existingSignatures[entrySignature(entry)] = struct{}{}
+			existingSignatures[entrySignature(entry)] = entry.ID
+		}
+	}
+
+	// Natural keys are resolved up front: the store allows a single connection,
+	// so lookups cannot run beside the open import transaction.
+	var keys *portabilityNaturalKeys
+	if prepare == nil && s.categories != nil && s.labels != nil && s.cards != nil {
+		loaded, err := s.loadNaturalKeys(ctx)
+		if err != nil {
+			return PortabilityImportResult{}, err
 		}
+		keys = &loaded
 	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -140,6 +492,14 @@ func (s *PortabilityService) Import(ctx context.Context, format, filePath string
 		_ = tx.Rollback()
 	}()
 
+	if prepare != nil {
+		prepared, err := prepare(ctx, tx)
+		if err != nil {
+			return PortabilityImportResult{}, err
+		}
+		keys = &prepared
+	}
+
 	txEntryRepo, ok := bindEntryRepositoryToTx(s.entryService.repo, tx)
 	if !ok {
 		return PortabilityImportResult{}, fmt.Errorf("portability import: entry repository does not support transactional import")
@@ -159,8 +519,66 @@ func (s *PortabilityService) Import(ctx context.Context, format, filePath string
 		return PortabilityImportResult{}, err
 	}
 
-	result := PortabilityImportResult{Warnings: []domain.Warning{}}
-	if err := streamImportRecords(normalizedFormat, filePath, func(record portabilityEntryRecord) error {
+	result = PortabilityImportResult{Warnings: []domain.Warning{}}
+	if options.RecordRows {
+		result.Rows = []PortabilityImportRow{}
+	}
+	var rowNumber int64
+	recordRow := func(row PortabilityImportRow) {
+		if options.RecordRows {
+			row.Row = rowNumber
+			result.Rows = append(result.Rows, row)
+		}
+	}
+	if err := stream(func(record portabilityEntryRecord) error {
+		rowNumber++
+		if record.usesNaturalKeys() {
+			if keys == nil {
+				return fmt.Errorf("portability import: natural-key records require category, label, and card lookups")
+			}
+			resolved, err := keys.resolveRecord(record)
+			if err != nil {
+				return err
+			}
+			record = resolved
+		}
+		if record.PaymentCardNickname != "" {
+			if record.PaymentCard != "" {
+				return fmt.Errorf("invalid import record: payment_card and payment_card_nickname cannot both be set")
+			}
+			if keys == nil {
+				return fmt.Errorf("portability import: payment_card_nickname requires card lookups")
+			}
+			cardID, err := keys.cardID(record.PaymentCardNickname)
+			if err != nil {
+				return err
+			}
+			record.paymentCardID = &cardID
+		}
+
+		// A uid already taken locally either identifies the matching entry
+		// or, outside uid matching, is dropped so the copy gets its own.
+		recordUID, err := domain.NormalizeEntryUID(record.UID)
+		if err != nil {
+			return err
+		}
+		uid := recordUID
+		var matched *domain.Entry
+		matchedByUID := false
+		if uid != "" {
+			existing, err := txEntryService.GetByUID(ctx, uid)
+			switch {
+			case err == nil && matchByUID:
+				matched = &existing
+				matchedByUID = true
+				uid = ""
+			case err == nil:
+				uid = ""
+			case !errors.Is(err, domain.ErrEntryNotFound):
+				return err
+			}
+		}
+
 		candidate := domain.Entry{
 			Type:               record.Type,
 			AmountMinor:        record.AmountMinor,
@@ -170,11 +588,55 @@ func (s *PortabilityService) Import(ctx context.Context, format, filePath string
 			LabelIDs:           record.LabelIDs,
 			Note:               record.Note,
 		}
+		if matched == nil && matching && (!matchByUID || recordUID == "") {
+			if entryID, exists := existingSignatures[entrySignature(candidate)]; exists {
+				matched = &domain.Entry{ID: entryID}
+			}
+		}
 
-		signature := entrySignature(candidate)
-		if idempotent {
-			if _, exists := existingSignatures[signature]; exists {
+		if matched != nil {
+			strategy := onConflict
+			if strategy == "" {
+				strategy = PortabilityOnConflictSkip
+				if matchedByUID {
+					strategy = PortabilityOnConflictUpdate
+				}
+			}
+
+			switch strategy {
+			case PortabilityOnConflictSkip:
 				result.Skipped++
+				reason := PortabilitySkipReasonMatchedSignature
+				if matchedByUID {
+					reason = PortabilitySkipReasonMatchedUID
+				}
+				recordRow(PortabilityImportRow{Outcome: PortabilityImportOutcomeSkipped, EntryID: matched.ID, Reason: reason})
+				return nil
+			case PortabilityOnConflictUpdate:
+				existing := *matched
+				if !matchedByUID {
+					existing, err = txEntryService.Get(ctx, matched.ID)
+					if err != nil {
+						return err
+					}
+				}
+				update, changed, err := portabilityRecordUpdate(existing, record)
+				if err != nil {
+					return err
+				}
+				if !changed {
+					result.Skipped++
+					recordRow(PortabilityImportRow{Outcome: PortabilityImportOutcomeSkipped, EntryID: existing.ID, Reason: PortabilitySkipReasonUnchanged})
+					return nil
+				}
+				updated, err := txEntryService.UpdateWithWarnings(ctx, update)
+				if err != nil {
+					return err
+				}
+				result.Updated++
+				result.Warnings = append(result.Warnings, updated.Warnings...)
+				recordRow(PortabilityImportRow{Outcome: PortabilityImportOutcomeUpdated, EntryID: updated.Entry.ID, Warnings: updated.Warnings})
+				existingSignatures[entrySignature(updated.Entry)] = updated.Entry.ID
 				return nil
 			}
 		}
@@ -187,19 +649,41 @@ func (s *PortabilityService) Import(ctx context.Context, format, filePath string
 			CategoryID:         record.CategoryID,
 			LabelIDs:           record.LabelIDs,
 			Note:               record.Note,
+			Payee:              record.Payee,
+			RecordedBy:         record.RecordedBy,
+			IncomeSource:       record.IncomeSource,
+			Location:           record.Location,
+			PaymentMethod:      record.PaymentMethod,
+			PaymentCardID:      record.paymentCardID,
+			UID:                uid,
+			// Imported history may reference archived categories and labels.
+			AllowArchived: true,
 		})
 		if err != nil {
 			return err
 		}
 
-		result.Imported++
+		outcome := PortabilityImportOutcomeImported
+		if matched != nil {
+			result.Duplicated++
+			outcome = PortabilityImportOutcomeDuplicated
+		} else {
+			result.Imported++
+		}
 		result.Warnings = append(result.Warnings, created.Warnings...)
-		existingSignatures[entrySignature(created.Entry)] = struct{}{}
+		recordRow(PortabilityImportRow{Outcome: outcome, EntryID: created.Entry.ID, Warnings: created.Warnings})
+		if _, exists := existingSignatures[entrySignature(created.Entry)]; !exists {
+			existingSignatures[entrySignature(created.Entry)] = created.Entry.ID
+		}
 		return nil
 	}); err != nil {
 		return PortabilityImportResult{}, err
 	}
 
+	if strict := domain.FilterStrictWarnings(result.Warnings, s.entryService.strictWarnings); len(strict) > 0 {
+		return PortabilityImportResult{}, &domain.StrictWarningError{Warnings: strict}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return PortabilityImportResult{}, fmt.Errorf("portability import commit: %w", err)
 	}
@@ -208,10 +692,20 @@ func (s *PortabilityService) Import(ctx context.Context, format, filePath string
 }
 
 func (s *PortabilityService) ExportReport(ctx context.Context, format, filePath string, req ReportRequest) (PortabilityReportExportResult, error) {
+	return s.ExportReportWithShape(ctx, format, PortabilityCSVShapeWide, filePath, req)
+}
+
+// ExportReportWithShape exports a report like ExportReport; csvShape picks the
+// wide or tidy layout of CSV files and is ignored for JSON.
+func (s *PortabilityService) ExportReportWithShape(ctx context.Context, format, csvShape, filePath string, req ReportRequest) (PortabilityReportExportResult, error) {
 	normalizedFormat := normalizePortabilityFormat(format)
 	if normalizedFormat == "" {
 		return PortabilityReportExportResult{}, fmt.Errorf("unsupported export format: %s", format)
 	}
+	normalizedShape, err := NormalizePortabilityCSVShape(csvShape)
+	if err != nil {
+		return PortabilityReportExportResult{}, err
+	}
 
 	if s.reportService == nil {
 		return PortabilityReportExportResult{}, fmt.Errorf("report export unavailable: report service is not configured")
@@ -222,22 +716,52 @@ func (s *PortabilityService) ExportReport(ctx context.Context, format, filePath
 		return PortabilityReportExportResult{}, err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		if normalizedFormat == PortabilityFormatCSV && normalizedShape == PortabilityCSVShapeTidy {
+			return writeReportTidyCSV(w, result.Report)
+		}
+		if normalizedFormat == PortabilityFormatCSV {
+			return writeReportCSV(w, result.Report, result.Warnings)
+		}
+		return writeReportJSON(w, result.Report, result.Warnings)
+	}); err != nil {
 		return PortabilityReportExportResult{}, err
 	}
 
-	switch normalizedFormat {
-	case PortabilityFormatJSON:
-		if err := writeReportJSON(filePath, result.Report, result.Warnings); err != nil {
-			return PortabilityReportExportResult{}, err
-		}
-	case PortabilityFormatCSV:
-		if err := writeReportCSV(filePath, result.Report, result.Warnings); err != nil {
-			return PortabilityReportExportResult{}, err
+	return PortabilityReportExportResult{Warnings: result.Warnings}, nil
+}
+
+// ExportFlows writes the flow graph for req as JSON with major-unit values.
+func (s *PortabilityService) ExportFlows(ctx context.Context, format, filePath string, req ReportRequest) (domain.FlowGraph, error) {
+	if normalizePortabilityFormat(format) != PortabilityFormatJSON {
+		return domain.FlowGraph{}, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	if s.reportService == nil {
+		return domain.FlowGraph{}, fmt.Errorf("flows export unavailable: report service is not configured")
+	}
+
+	graph, err := s.reportService.Flows(ctx, req)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+
+	payload, err := reporting.ToMajorUnitMap(graph)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		content, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
 		}
+		_, err = w.Write(content)
+		return err
+	}); err != nil {
+		return domain.FlowGraph{}, err
 	}
 
-	return PortabilityReportExportResult{Warnings: result.Warnings}, nil
+	return graph, nil
 }
 
 func (s *PortabilityService) Backup(ctx context.Context, outputPath string) error {
@@ -251,6 +775,122 @@ func (s *PortabilityService) Backup(ctx context.Context, outputPath string) erro
 	return err
 }
 
+// Snapshot backs the database up into dir before a risky command runs and
+// keeps only the keep newest snapshots there, so the directory does not grow
+// with every import.
+func (s *PortabilityService) Snapshot(ctx context.Context, dir, reason string, keep int) (domain.SafetySnapshot, error) {
+	now := time.Now().UTC()
+	path := filepath.Join(dir, domain.SafetySnapshotFileName(now, reason))
+	if err := s.Backup(ctx, path); err != nil {
+		return domain.SafetySnapshot{}, fmt.Errorf("safety snapshot: %w", err)
+	}
+
+	snapshot := domain.SafetySnapshot{Path: path, Reason: reason, CreatedAtUTC: now.Format(time.RFC3339Nano)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return domain.SafetySnapshot{}, fmt.Errorf("safety snapshot prune: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && domain.IsSafetySnapshotFileName(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for len(names) > keep && keep > 0 {
+		stale := filepath.Join(dir, names[0])
+		if err := os.Remove(stale); err != nil {
+			return domain.SafetySnapshot{}, fmt.Errorf("safety snapshot prune: %w", err)
+		}
+		snapshot.Pruned = append(snapshot.Pruned, stale)
+		names = names[1:]
+	}
+	return snapshot, nil
+}
+
+// BackupWithOptions writes a backup like Backup, or with Online set copies the
+// database through the online backup API, which suits large files that other
+// processes keep writing to in WAL mode.
+func (s *PortabilityService) BackupWithOptions(ctx context.Context, outputPath string, options PortabilityBackupOptions) (domain.BackupResult, error) {
+	if !options.Online {
+		if err := s.Backup(ctx, outputPath); err != nil {
+			return domain.BackupResult{}, err
+		}
+		return domain.BackupResult{File: outputPath, Method: domain.BackupMethodVacuum}, nil
+	}
+
+	if s.backupper == nil {
+		return domain.BackupResult{}, fmt.Errorf("online backup unavailable: backupper is not configured")
+	}
+	pagesPerStep := options.PagesPerStep
+	if pagesPerStep == 0 {
+		pagesPerStep = domain.DefaultBackupPagesPerStep
+	}
+	if err := domain.ValidateBackupPagesPerStep(pagesPerStep); err != nil {
+		return domain.BackupResult{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return domain.BackupResult{}, err
+	}
+
+	pages, err := s.backupper.OnlineBackup(ctx, outputPath, pagesPerStep, options.Progress)
+	if err != nil {
+		return domain.BackupResult{}, err
+	}
+	return domain.BackupResult{File: outputPath, Method: domain.BackupMethodOnline, Pages: &pages}, nil
+}
+
+// writeOutput streams an export through a buffered writer into filePath, or
+// into the configured stdout when filePath is "-".
+func (s *PortabilityService) writeOutput(filePath string, write func(io.Writer) error) error {
+	if filePath == PortabilityStdioPath {
+		buffered := bufio.NewWriter(s.stdout)
+		if err := write(buffered); err != nil {
+			return err
+		}
+		return buffered.Flush()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	if err := write(buffered); err != nil {
+		_ = file.Close()
+		_ = os.Remove(filePath)
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (s *PortabilityService) openInput(filePath string) (io.ReadCloser, error) {
+	if filePath == PortabilityStdioPath {
+		return io.NopCloser(s.stdin), nil
+	}
+	return os.Open(filePath)
+}
+
+// countingReader adds the bytes read through it to count.
+type countingReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	*r.count += int64(n)
+	return n, err
+}
+
 func normalizePortabilityFormat(raw string) string {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case PortabilityFormatJSON:
@@ -262,77 +902,181 @@ func normalizePortabilityFormat(raw string) string {
 	}
 }
 
-func writeEntriesJSON(filePath string, entries []domain.Entry) error {
-	records := make([]portabilityEntryRecord, 0, len(entries))
-	for _, entry := range entries {
-		records = append(records, portabilityEntryRecord{
-			Type:               entry.Type,
-			AmountMinor:        entry.AmountMinor,
-			CurrencyCode:       entry.CurrencyCode,
-			TransactionDateUTC: entry.TransactionDateUTC,
-			CategoryID:         entry.CategoryID,
-			LabelIDs:           entry.LabelIDs,
-			Note:               entry.Note,
-		})
+// portabilityRecordWriter encodes export records one at a time; Close finishes
+// the document once every record has been written.
+type portabilityRecordWriter interface {
+	Write(record portabilityEntryRecord) error
+	Close() error
+}
+
+// writeEntriesJSON emits the same document as indenting a portabilityJSONEnvelope
+// in one go.
+func writeEntriesJSON(w io.Writer, records []portabilityEntryRecord) error {
+	writer := newEntriesJSONWriter(w)
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
 	}
+	return writer.Close()
+}
 
-	payload := portabilityJSONEnvelope{Entries: records}
-	content, err := json.MarshalIndent(payload, "", "  ")
+// entriesJSONWriter encodes one record at a time so large exports are not
+// buffered, while producing the same bytes as writeEntriesJSON.
+type entriesJSONWriter struct {
+	w       io.Writer
+	written int
+}
+
+func newEntriesJSONWriter(w io.Writer) *entriesJSONWriter {
+	return &entriesJSONWriter{w: w}
+}
+
+func (e *entriesJSONWriter) Write(record portabilityEntryRecord) error {
+	content, err := json.MarshalIndent(record, "    ", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(filePath, content, 0o644)
+	separator := ",\n    "
+	if e.written == 0 {
+		separator = fmt.Sprintf("{\n  \"schema_version\": %d,\n  \"entries\": [\n    ", domain.EntryExportSchemaVersion)
+	}
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(content); err != nil {
+		return err
+	}
+	e.written++
+	return nil
 }
 
-func writeEntriesCSV(filePath string, entries []domain.Entry) error {
-	file, err := os.Create(filePath)
-	if err != nil {
+func (e *entriesJSONWriter) Close() error {
+	if e.written == 0 {
+		_, err := fmt.Fprintf(e.w, "{\n  \"schema_version\": %d,\n  \"entries\": []\n}", domain.EntryExportSchemaVersion)
 		return err
 	}
-	defer file.Close()
+	_, err := io.WriteString(e.w, "\n  ]\n}")
+	return err
+}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+type entriesCSVWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
 
-	header := []string{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_id", "label_ids", "note"}
-	if err := writer.Write(header); err != nil {
+func newEntriesCSVWriter(w io.Writer) *entriesCSVWriter {
+	return &entriesCSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (e *entriesCSVWriter) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write([]string{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_id", "label_ids", "note", "payee", "recorded_by", "uid", "income_source", "location", "payment_method", "payment_card_nickname", "amount", "schema_version"})
+}
+
+func (e *entriesCSVWriter) Write(record portabilityEntryRecord) error {
+	if err := e.writeHeader(); err != nil {
 		return err
 	}
 
-	for _, entry := range entries {
-		categoryValue := ""
-		if entry.CategoryID != nil {
-			categoryValue = strconv.FormatInt(*entry.CategoryID, 10)
-		}
+	categoryValue := ""
+	if record.CategoryID != nil {
+		categoryValue = strconv.FormatInt(*record.CategoryID, 10)
+	}
 
-		labelIDs := append([]int64(nil), entry.LabelIDs...)
-		sort.Slice(labelIDs, func(i, j int) bool {
-			return labelIDs[i] < labelIDs[j]
-		})
-		labelValues := make([]string, 0, len(labelIDs))
-		for _, labelID := range labelIDs {
-			labelValues = append(labelValues, strconv.FormatInt(labelID, 10))
-		}
+	labelIDs := append([]int64(nil), record.LabelIDs...)
+	sort.Slice(labelIDs, func(i, j int) bool {
+		return labelIDs[i] < labelIDs[j]
+	})
+	labelValues := make([]string, 0, len(labelIDs))
+	for _, labelID := range labelIDs {
+		labelValues = append(labelValues, strconv.FormatInt(labelID, 10))
+	}
 
-		row := []string{
-			entry.Type,
-			strconv.FormatInt(entry.AmountMinor, 10),
-			entry.CurrencyCode,
-			entry.TransactionDateUTC,
-			categoryValue,
-			strings.Join(labelValues, "|"),
-			entry.Note,
-		}
-		if err := writer.Write(row); err != nil {
-			return err
-		}
+	return e.writer.Write([]string{
+		record.Type,
+		strconv.FormatInt(record.AmountMinor, 10),
+		record.CurrencyCode,
+		record.TransactionDateUTC,
+		categoryValue,
+		strings.Join(labelValues, "|"),
+		record.Note,
+		record.Payee,
+		record.RecordedBy,
+		record.UID,
+		record.IncomeSource,
+		record.Location,
+		record.PaymentMethod,
+		record.PaymentCardNickname,
+		record.Amount,
+		strconv.Itoa(domain.EntryExportSchemaVersion),
+	})
+}
+
+func (e *entriesCSVWriter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
 	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
 
-	return writer.Error()
+type naturalEntriesCSVWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newNaturalEntriesCSVWriter(w io.Writer) *naturalEntriesCSVWriter {
+	return &naturalEntriesCSVWriter{writer: csv.NewWriter(w)}
+}
+
+func (e *naturalEntriesCSVWriter) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write(portabilityNaturalCSVHeader)
+}
+
+func (e *naturalEntriesCSVWriter) Write(record portabilityEntryRecord) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	return e.writer.Write([]string{
+		record.Type,
+		strconv.FormatInt(record.AmountMinor, 10),
+		record.CurrencyCode,
+		record.TransactionDateUTC,
+		record.Category,
+		strings.Join(record.Labels, "|"),
+		record.Note,
+		record.PaymentMethod,
+		record.PaymentCard,
+		record.Fingerprint,
+		record.Payee,
+		record.RecordedBy,
+		record.UID,
+		record.IncomeSource,
+		record.Location,
+		record.Amount,
+		strconv.Itoa(domain.EntryExportSchemaVersion),
+	})
 }
 
-func writeReportJSON(filePath string, report domain.Report, warnings []domain.Warning) error {
+func (e *naturalEntriesCSVWriter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func writeReportJSON(w io.Writer, report domain.Report, warnings []domain.Warning) error {
 	reportPayload, err := reporting.ToMajorUnitMap(report)
 	if err != nil {
 		return err
@@ -352,17 +1096,12 @@ func writeReportJSON(filePath string, report domain.Report, warnings []domain.Wa
 		return err
 	}
 
-	return os.WriteFile(filePath, content, 0o644)
+	_, err = w.Write(content)
+	return err
 }
 
-func writeReportCSV(filePath string, report domain.Report, warnings []domain.Warning) error {
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
+func writeReportCSV(w io.Writer, report domain.Report, warnings []domain.Warning) error {
+	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
 	header := []string{
@@ -554,13 +1293,17 @@ func writeReportCSV(filePath string, report domain.Report, warnings []domain.War
 		if err != nil {
 			return err
 		}
+		categoryID := ""
+		if status.CategoryID != nil {
+			categoryID = strconv.FormatInt(*status.CategoryID, 10)
+		}
 		if err := writeRow(
 			"cap_status",
 			"",
 			"",
+			categoryID,
 			"",
-			"",
-			"",
+			status.CategoryName,
 			status.CurrencyCode,
 			"",
 			status.MonthKey,
@@ -617,22 +1360,145 @@ func writeReportCSV(filePath string, report domain.Report, warnings []domain.War
 		); err != nil {
 			return err
 		}
-	}
-
-	for _, warning := range warnings {
-		detailsJSON := ""
-		if warning.Details != nil {
-			wrapper, err := reporting.ToMajorUnitMap(map[string]any{"details": warning.Details})
-			if err != nil {
-				return err
-			}
-			detailsContent, err := json.Marshal(wrapper["details"])
-			if err != nil {
-				return err
-			}
-			detailsJSON = string(detailsContent)
+	}
+
+	for _, warning := range warnings {
+		detailsJSON := ""
+		if warning.Details != nil {
+			wrapper, err := reporting.ToMajorUnitMap(map[string]any{"details": warning.Details})
+			if err != nil {
+				return err
+			}
+			detailsContent, err := json.Marshal(wrapper["details"])
+			if err != nil {
+				return err
+			}
+			detailsJSON = string(detailsContent)
+		}
+		if err := writeRow("warning", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", warning.Code, warning.Message, detailsJSON); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
+
+// writeReportTidyCSV writes the report's amounts in long format, one measure
+// per row. Section names the measure (earnings, spending, net, balances,
+// converted totals and cap amounts), dimension what key breaks it down by
+// (total, the report grouping, category, person, source, location, month or
+// category_month) and amount_major is in major units like every report
+// export. Cap changes and warnings only appear in the wide layout.
+func writeReportTidyCSV(w io.Writer, report domain.Report) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"section", "dimension", "key", "currency", "amount_major"}); err != nil {
+		return err
+	}
+	writeRow := func(section, dimension, key, currencyCode string, amountMinor int64) error {
+		amountMajor, err := formatReportAmountMajor(amountMinor, currencyCode)
+		if err != nil {
+			return err
+		}
+		return writer.Write([]string{section, dimension, key, currencyCode, amountMajor})
+	}
+	writeTotals := func(section, dimension string, totals []domain.CurrencyTotal) error {
+		for _, total := range totals {
+			if err := writeRow(section, dimension, "", total.CurrencyCode, total.TotalMinor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writeSection := func(section string, values domain.ReportSection) error {
+		if err := writeTotals(section, "total", values.ByCurrency); err != nil {
+			return err
+		}
+		for _, group := range values.Groups {
+			if err := writeRow(section, report.Grouping, group.PeriodKey, group.CurrencyCode, group.TotalMinor); err != nil {
+				return err
+			}
+		}
+		for _, category := range values.Categories {
+			if err := writeRow(section, "category", category.CategoryLabel, category.CurrencyCode, category.TotalMinor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := writeSection("earnings", report.Earnings); err != nil {
+		return err
+	}
+	for _, source := range report.BySource {
+		if err := writeRow("earnings", "source", source.Source, source.CurrencyCode, source.TotalMinor); err != nil {
+			return err
+		}
+	}
+	if err := writeSection("spending", report.Spending); err != nil {
+		return err
+	}
+	for _, location := range report.ByLocation {
+		if err := writeRow("spending", "location", location.Location, location.CurrencyCode, location.TotalMinor); err != nil {
+			return err
+		}
+	}
+	if err := writeTotals("net", "total", report.Net.ByCurrency); err != nil {
+		return err
+	}
+	for _, person := range report.ByPerson {
+		for _, measure := range []struct {
+			section     string
+			amountMinor int64
+		}{
+			{"earnings", person.EarningsMinor},
+			{"spending", person.SpendingMinor},
+			{"net", person.NetMinor},
+		} {
+			if err := writeRow(measure.section, "person", person.Person, person.CurrencyCode, measure.amountMinor); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeTotals("period_balance", "total", report.PeriodBalance.ByCurrency); err != nil {
+		return err
+	}
+	if report.MonthlyBalance != nil {
+		if err := writeTotals("monthly_balance", "total", report.MonthlyBalance.ByCurrency); err != nil {
+			return err
+		}
+	}
+	if err := writeTotals("general_balance", "total", report.GeneralBalance.ByCurrency); err != nil {
+		return err
+	}
+
+	if report.Converted != nil {
+		target := report.Converted.TargetCurrency
+		if err := writeRow("earnings", "converted", "", target, report.Converted.EarningsMinor); err != nil {
+			return err
+		}
+		if err := writeRow("spending", "converted", "", target, report.Converted.SpendingMinor); err != nil {
+			return err
+		}
+		if err := writeRow("net", "converted", "", target, report.Converted.NetMinor); err != nil {
+			return err
+		}
+	}
+
+	for _, status := range report.CapStatus {
+		dimension, key := "month", status.MonthKey
+		if status.CategoryID != nil {
+			dimension, key = "category_month", status.MonthKey+"/"+status.CategoryName
+		}
+		if err := writeRow("cap_amount", dimension, key, status.CurrencyCode, status.CapAmountMinor); err != nil {
+			return err
+		}
+		if err := writeRow("cap_spend", dimension, key, status.CurrencyCode, status.SpendTotalMinor); err != nil {
+			return err
 		}
-		if err := writeRow("warning", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", warning.Code, warning.Message, detailsJSON); err != nil {
+		if err := writeRow("cap_overspend", dimension, key, status.CurrencyCode, status.OverspendMinor); err != nil {
 			return err
 		}
 	}
@@ -651,25 +1517,19 @@ func formatReportOptionalAmountMajor(amountMinor *int64, currencyCode string) (s
 	return formatReportAmountMajor(*amountMinor, currencyCode)
 }
 
-func streamImportRecords(format, filePath string, consume func(portabilityEntryRecord) error) error {
+func streamImportRecords(format string, input io.Reader, consume func(portabilityEntryRecord) error) error {
 	switch format {
 	case PortabilityFormatJSON:
-		return streamImportRecordsJSON(filePath, consume)
+		return streamImportRecordsJSON(input, consume)
 	case PortabilityFormatCSV:
-		return streamImportRecordsCSV(filePath, consume)
+		return streamImportRecordsCSV(input, consume)
 	default:
 		return fmt.Errorf("unsupported format")
 	}
 }
 
-func streamImportRecordsJSON(filePath string, consume func(portabilityEntryRecord) error) error {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	decoder := json.NewDecoder(file)
+func streamImportRecordsJSON(input io.Reader, consume func(portabilityEntryRecord) error) error {
+	decoder := json.NewDecoder(input)
 
 	firstToken, err := decoder.Token()
 	if err != nil {
@@ -683,11 +1543,12 @@ func streamImportRecordsJSON(filePath string, consume func(portabilityEntryRecor
 
 	switch root {
 	case '[':
-		if err := decodeEntryArray(decoder, consume); err != nil {
+		if err := decodeEntryArray(decoder, domain.LegacyEntryExportSchemaVersion, consume); err != nil {
 			return err
 		}
 	case '{':
 		foundEntries := false
+		schemaVersion := domain.LegacyEntryExportSchemaVersion
 		for decoder.More() {
 			keyToken, err := decoder.Token()
 			if err != nil {
@@ -699,6 +1560,20 @@ func streamImportRecordsJSON(filePath string, consume func(portabilityEntryRecor
 				return fmt.Errorf("invalid json import payload: expected object property name")
 			}
 
+			// Exports write schema_version before entries; a marker that only
+			// follows them is still checked, but cannot steer their upgrade.
+			if key == "schema_version" {
+				var version int
+				if err := decoder.Decode(&version); err != nil {
+					return fmt.Errorf("%w: %v", domain.ErrInvalidExportSchemaVersion, err)
+				}
+				if err := checkPortabilitySchemaVersion(version); err != nil {
+					return err
+				}
+				schemaVersion = version
+				continue
+			}
+
 			if key == "entries" {
 				entriesToken, err := decoder.Token()
 				if err != nil {
@@ -708,7 +1583,7 @@ func streamImportRecordsJSON(filePath string, consume func(portabilityEntryRecor
 				if !ok || entriesDelim != '[' {
 					return fmt.Errorf("invalid json import payload: entries must be an array")
 				}
-				if err := decodeEntryArray(decoder, consume); err != nil {
+				if err := decodeEntryArray(decoder, schemaVersion, consume); err != nil {
 					return err
 				}
 				foundEntries = true
@@ -745,12 +1620,20 @@ func streamImportRecordsJSON(filePath string, consume func(portabilityEntryRecor
 	return nil
 }
 
-func decodeEntryArray(decoder *json.Decoder, consume func(portabilityEntryRecord) error) error {
+func decodeEntryArray(decoder *json.Decoder, schemaVersion int, consume func(portabilityEntryRecord) error) error {
 	for decoder.More() {
 		record := portabilityEntryRecord{}
 		if err := decoder.Decode(&record); err != nil {
 			return err
 		}
+		record, err := record.withNameAliases()
+		if err != nil {
+			return err
+		}
+		record, err = upgradePortabilityRecord(record, schemaVersion)
+		if err != nil {
+			return err
+		}
 		if err := consume(record); err != nil {
 			return err
 		}
@@ -818,16 +1701,17 @@ func discardJSONValue(decoder *json.Decoder) error {
 	}
 }
 
-func streamImportRecordsCSV(filePath string, consume func(portabilityEntryRecord) error) error {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
+func streamImportRecordsCSV(input io.Reader, consume func(portabilityEntryRecord) error) error {
+	reader := csv.NewReader(input)
 
 	rowNumber := 0
+	natural := false
+	var named map[string]int
+	versionColumn := -1
+	sourceColumn := -1
+	locationColumn := -1
+	paymentMethodColumn := -1
+	cardNicknameColumn := -1
 	for {
 		row, err := reader.Read()
 		if err == io.EOF {
@@ -839,10 +1723,66 @@ func streamImportRecordsCSV(filePath string, consume func(portabilityEntryRecord
 
 		rowNumber++
 		if rowNumber == 1 && len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), "type") {
+			natural = len(row) > 4 && strings.EqualFold(strings.TrimSpace(row[4]), "category")
+			named = namedImportCSVColumns(row)
+			for index, name := range row {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "schema_version":
+					versionColumn = index
+				case "income_source":
+					sourceColumn = index
+				case "location":
+					locationColumn = index
+				case "payment_method":
+					paymentMethodColumn = index
+				case "payment_card_nickname":
+					cardNicknameColumn = index
+				}
+			}
+			if natural || named != nil {
+				// Trailing payment and fingerprint columns are optional.
+				reader.FieldsPerRecord = -1
+			}
 			continue
 		}
 
-		record, err := parseImportRecordCSVRow(row, rowNumber)
+		parse := parseImportRecordCSVRow
+		switch {
+		case named != nil:
+			parse = func(row []string, rowNumber int) (portabilityEntryRecord, error) {
+				return parseNamedImportRecordCSVRow(row, named, rowNumber)
+			}
+		case natural:
+			parse = parseNaturalImportRecordCSVRow
+		}
+		record, err := parse(row, rowNumber)
+		if err != nil {
+			return err
+		}
+		// income_source is read by header name: it sits between uid and
+		// schema_version, and files before version 3 do not have it.
+		if sourceColumn >= 0 && sourceColumn < len(row) {
+			record.IncomeSource = strings.TrimSpace(row[sourceColumn])
+		}
+		// location follows income_source and arrived in version 4.
+		if locationColumn >= 0 && locationColumn < len(row) {
+			record.Location = strings.TrimSpace(row[locationColumn])
+		}
+		// ID-keyed files carry payment columns by name since version 5.
+		if paymentMethodColumn >= 0 && paymentMethodColumn < len(row) {
+			record.PaymentMethod = strings.TrimSpace(row[paymentMethodColumn])
+		}
+		if cardNicknameColumn >= 0 && cardNicknameColumn < len(row) {
+			record.PaymentCardNickname = strings.TrimSpace(row[cardNicknameColumn])
+		}
+		schemaVersion := domain.LegacyEntryExportSchemaVersion
+		if versionColumn >= 0 && versionColumn < len(row) && strings.TrimSpace(row[versionColumn]) != "" {
+			schemaVersion, err = strconv.Atoi(strings.TrimSpace(row[versionColumn]))
+			if err != nil {
+				return fmt.Errorf("%w: schema_version at row %d: %v", domain.ErrInvalidExportSchemaVersion, rowNumber, err)
+			}
+		}
+		record, err = upgradePortabilityRecord(record, schemaVersion)
 		if err != nil {
 			return err
 		}
@@ -852,9 +1792,59 @@ func streamImportRecordsCSV(filePath string, consume func(portabilityEntryRecord
 	}
 }
 
+// portabilityRecordUpgrades[v] converts a record read from a schema version v
+// file into version v+1. Upgrades run in sequence, so each one only knows the
+// step it introduced.
+var portabilityRecordUpgrades = map[int]func(portabilityEntryRecord) portabilityEntryRecord{
+	// Version 1 predates the schema_version marker and uids. Its fields are a
+	// subset of version 2, and records without a uid get a new one on import.
+	1: func(record portabilityEntryRecord) portabilityEntryRecord {
+		record.UID = ""
+		return record
+	},
+	// Version 2 predates income sources; its records have none.
+	2: func(record portabilityEntryRecord) portabilityEntryRecord {
+		record.IncomeSource = ""
+		return record
+	},
+	// Version 3 predates entry locations.
+	3: func(record portabilityEntryRecord) portabilityEntryRecord {
+		record.Location = ""
+		return record
+	},
+	// Version 4 ID-keyed files carry no payment method or card; natural-key
+	// files already did, so their fields are kept.
+	4: func(record portabilityEntryRecord) portabilityEntryRecord {
+		record.PaymentCardNickname = ""
+		return record
+	},
+}
+
+func checkPortabilitySchemaVersion(version int) error {
+	if version > domain.EntryExportSchemaVersion {
+		return fmt.Errorf("%w: schema_version %d, this build reads up to %d", domain.ErrExportUpgradeRequired, version, domain.EntryExportSchemaVersion)
+	}
+	if version < domain.LegacyEntryExportSchemaVersion {
+		return fmt.Errorf("%w: %d", domain.ErrInvalidExportSchemaVersion, version)
+	}
+	return nil
+}
+
+// upgradePortabilityRecord brings a record read from a schema version file up
+// to EntryExportSchemaVersion, refusing files from newer versions.
+func upgradePortabilityRecord(record portabilityEntryRecord, version int) (portabilityEntryRecord, error) {
+	if err := checkPortabilitySchemaVersion(version); err != nil {
+		return portabilityEntryRecord{}, err
+	}
+	for ; version < domain.EntryExportSchemaVersion; version++ {
+		record = portabilityRecordUpgrades[version](record)
+	}
+	return record, nil
+}
+
 func parseImportRecordCSVRow(row []string, rowNumber int) (portabilityEntryRecord, error) {
 	if len(row) < 7 {
-		return portabilityEntryRecord{}, fmt.Errorf("invalid csv row %d: expected 7 columns", rowNumber)
+		return portabilityEntryRecord{}, fmt.Errorf("invalid csv row %d: expected at least 7 columns", rowNumber)
 	}
 
 	amountMinor, err := strconv.ParseInt(strings.TrimSpace(row[1]), 10, 64)
@@ -884,6 +1874,19 @@ func parseImportRecordCSVRow(row []string, rowNumber int) (portabilityEntryRecor
 		labelIDs = append(labelIDs, parsedLabelID)
 	}
 
+	payee := ""
+	if len(row) > 7 {
+		payee = strings.TrimSpace(row[7])
+	}
+	recordedBy := ""
+	if len(row) > 8 {
+		recordedBy = strings.TrimSpace(row[8])
+	}
+	uid := ""
+	if len(row) > 9 {
+		uid = strings.TrimSpace(row[9])
+	}
+
 	return portabilityEntryRecord{
 		Type:               strings.TrimSpace(row[0]),
 		AmountMinor:        amountMinor,
@@ -892,9 +1895,483 @@ func parseImportRecordCSVRow(row []string, rowNumber int) (portabilityEntryRecor
 		CategoryID:         categoryID,
 		LabelIDs:           labelIDs,
 		Note:               strings.TrimSpace(row[6]),
+		Payee:              payee,
+		RecordedBy:         recordedBy,
+		UID:                uid,
+	}, nil
+}
+
+func parseNaturalImportRecordCSVRow(row []string, rowNumber int) (portabilityEntryRecord, error) {
+	if len(row) < 7 {
+		return portabilityEntryRecord{}, fmt.Errorf("invalid csv row %d: expected at least 7 columns", rowNumber)
+	}
+
+	amountMinor, err := strconv.ParseInt(strings.TrimSpace(row[1]), 10, 64)
+	if err != nil {
+		return portabilityEntryRecord{}, fmt.Errorf("invalid amount_minor at row %d: %w", rowNumber, err)
+	}
+
+	labels := []string{}
+	for _, part := range strings.Split(row[5], "|") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			labels = append(labels, trimmed)
+		}
+	}
+
+	column := func(index int) string {
+		if index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+
+	return portabilityEntryRecord{
+		Type:               strings.TrimSpace(row[0]),
+		AmountMinor:        amountMinor,
+		CurrencyCode:       strings.TrimSpace(row[2]),
+		TransactionDateUTC: strings.TrimSpace(row[3]),
+		Category:           strings.TrimSpace(row[4]),
+		Labels:             labels,
+		Note:               strings.TrimSpace(row[6]),
+		PaymentMethod:      column(7),
+		PaymentCard:        column(8),
+		Fingerprint:        column(9),
+		Payee:              column(10),
+		RecordedBy:         column(11),
+		UID:                column(12),
 	}, nil
 }
 
+// namedImportCSVColumns indexes a header that carries category_name or
+// label_names columns; other headers return nil and keep positional parsing.
+func namedImportCSVColumns(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	_, hasCategoryName := columns["category_name"]
+	_, hasLabelNames := columns["label_names"]
+	if !hasCategoryName && !hasLabelNames {
+		return nil
+	}
+	return columns
+}
+
+// parseNamedImportRecordCSVRow reads a row by header name, so ID columns
+// (category_id, label_ids) and name columns (category_name, label_names) can
+// appear in any order. Labels in both list columns are separated by "|".
+func parseNamedImportRecordCSVRow(row []string, columns map[string]int, rowNumber int) (portabilityEntryRecord, error) {
+	column := func(name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+	splitList := func(raw string) []string {
+		values := []string{}
+		for _, part := range strings.Split(raw, "|") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				values = append(values, trimmed)
+			}
+		}
+		return values
+	}
+
+	amountMinor, err := strconv.ParseInt(column("amount_minor"), 10, 64)
+	if err != nil {
+		return portabilityEntryRecord{}, fmt.Errorf("invalid amount_minor at row %d: %w", rowNumber, err)
+	}
+
+	record := portabilityEntryRecord{
+		Type:               column("type"),
+		AmountMinor:        amountMinor,
+		CurrencyCode:       column("currency_code"),
+		TransactionDateUTC: column("transaction_date_utc"),
+		Category:           column("category_name"),
+		Labels:             splitList(column("label_names")),
+		Note:               column("note"),
+		Payee:              column("payee"),
+		RecordedBy:         column("recorded_by"),
+		PaymentMethod:      column("payment_method"),
+		PaymentCard:        column("payment_card"),
+		UID:                column("uid"),
+	}
+	if len(record.Labels) == 0 {
+		record.Labels = nil
+	}
+
+	if raw := column("category_id"); raw != "" {
+		categoryID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return portabilityEntryRecord{}, fmt.Errorf("invalid category_id at row %d: %w", rowNumber, err)
+		}
+		record.CategoryID = &categoryID
+	}
+	for _, raw := range splitList(column("label_ids")) {
+		labelID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return portabilityEntryRecord{}, fmt.Errorf("invalid label_ids value at row %d: %w", rowNumber, err)
+		}
+		record.LabelIDs = append(record.LabelIDs, labelID)
+	}
+
+	return record, nil
+}
+
+// NormalizePortabilityMatchBy defaults to signature matching when raw is empty.
+func NormalizePortabilityMatchBy(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", PortabilityMatchBySignature:
+		return PortabilityMatchBySignature, nil
+	case PortabilityMatchByUID:
+		return PortabilityMatchByUID, nil
+	default:
+		return "", ErrInvalidPortabilityMatchBy
+	}
+}
+
+// NormalizePortabilityOnConflict returns "" when raw is empty so callers can
+// tell an explicit strategy from the Idempotent defaults.
+func NormalizePortabilityOnConflict(raw string) (string, error) {
+	switch value := strings.ToLower(strings.TrimSpace(raw)); value {
+	case "", PortabilityOnConflictSkip, PortabilityOnConflictUpdate, PortabilityOnConflictDuplicate:
+		return value, nil
+	default:
+		return "", ErrInvalidPortabilityOnConflict
+	}
+}
+
+// NormalizePortabilityCSVShape defaults to the wide report layout when raw is
+// empty.
+func NormalizePortabilityCSVShape(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", PortabilityCSVShapeWide:
+		return PortabilityCSVShapeWide, nil
+	case PortabilityCSVShapeTidy:
+		return PortabilityCSVShapeTidy, nil
+	default:
+		return "", ErrInvalidPortabilityCSVShape
+	}
+}
+
+// NormalizePortabilityKeyMode defaults to ID keys when raw is empty.
+func NormalizePortabilityKeyMode(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", PortabilityKeyModeID:
+		return PortabilityKeyModeID, nil
+	case PortabilityKeyModeNatural:
+		return PortabilityKeyModeNatural, nil
+	default:
+		return "", ErrInvalidPortabilityKeyMode
+	}
+}
+
+var portabilityNaturalCSVHeader = []string{"type", "amount_minor", "currency_code", "transaction_date_utc", "category", "labels", "note", "payment_method", "payment_card", "fingerprint", "payee", "recorded_by", "uid", "income_source", "location", "amount", "schema_version"}
+
+func idPortabilityRecord(entry domain.Entry) portabilityEntryRecord {
+	return portabilityEntryRecord{
+		UID:                 entry.UID,
+		Type:                entry.Type,
+		AmountMinor:         entry.AmountMinor,
+		Amount:              portabilityMajorAmount(entry.AmountMinor, entry.CurrencyCode),
+		CurrencyCode:        entry.CurrencyCode,
+		TransactionDateUTC:  entry.TransactionDateUTC,
+		CategoryID:          entry.CategoryID,
+		LabelIDs:            entry.LabelIDs,
+		Note:                entry.Note,
+		Payee:               entry.Payee,
+		RecordedBy:          entry.RecordedBy,
+		IncomeSource:        entry.IncomeSource,
+		Location:            entry.Location,
+		PaymentMethod:       entry.PaymentMethod,
+		PaymentCardNickname: entry.PaymentCardNickname,
+	}
+}
+
+// portabilityMajorAmount is amountMinor scaled by the currency's minor unit
+// (1250 USD is "12.50", 1250 JPY is "1250"). Exports carry it for readers;
+// imports only read amount_minor.
+func portabilityMajorAmount(amountMinor int64, currencyCode string) string {
+	amount, err := domain.FormatMinorToMajorString(amountMinor, currencyCode)
+	if err != nil {
+		return ""
+	}
+	return amount
+}
+
+func (s *PortabilityService) loadNaturalKeys(ctx context.Context) (portabilityNaturalKeys, error) {
+	if s.categories == nil || s.labels == nil || s.cards == nil {
+		return portabilityNaturalKeys{}, fmt.Errorf("portability: natural keys require category, label, and card lookups")
+	}
+
+	keys := portabilityNaturalKeys{
+		categoryNames: map[int64]string{},
+		labelNames:    map[int64]string{},
+		categoryIDs:   map[string]int64{},
+		labelIDs:      map[string]int64{},
+		cardIDs:       map[string]int64{},
+	}
+
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return portabilityNaturalKeys{}, err
+	}
+	for _, category := range categories {
+		keys.categoryNames[category.ID] = category.Name
+		keys.categoryIDs[strings.ToLower(category.Name)] = category.ID
+	}
+
+	labels, err := s.labels.List(ctx)
+	if err != nil {
+		return portabilityNaturalKeys{}, err
+	}
+	for _, label := range labels {
+		keys.labelNames[label.ID] = label.Name
+		keys.labelIDs[strings.ToLower(label.Name)] = label.ID
+	}
+
+	cards, err := s.cards.ListCards(ctx, ports.CardListFilter{IncludeArchived: true})
+	if err != nil {
+		return portabilityNaturalKeys{}, err
+	}
+	for _, card := range cards {
+		keys.cardIDs[strings.ToLower(card.Nickname)] = card.ID
+	}
+
+	return keys, nil
+}
+
+func (k portabilityNaturalKeys) naturalRecord(entry domain.Entry) (portabilityEntryRecord, error) {
+	record := portabilityEntryRecord{
+		UID:                entry.UID,
+		Type:               entry.Type,
+		AmountMinor:        entry.AmountMinor,
+		Amount:             portabilityMajorAmount(entry.AmountMinor, entry.CurrencyCode),
+		CurrencyCode:       entry.CurrencyCode,
+		TransactionDateUTC: entry.TransactionDateUTC,
+		Note:               entry.Note,
+		Payee:              entry.Payee,
+		RecordedBy:         entry.RecordedBy,
+		IncomeSource:       entry.IncomeSource,
+		Location:           entry.Location,
+		PaymentMethod:      entry.PaymentMethod,
+		PaymentCard:        entry.PaymentCardNickname,
+	}
+
+	if entry.CategoryID != nil {
+		name, ok := k.categoryNames[*entry.CategoryID]
+		if !ok {
+			return portabilityEntryRecord{}, fmt.Errorf("%w: id %d", domain.ErrCategoryNotFound, *entry.CategoryID)
+		}
+		record.Category = name
+	}
+
+	for _, labelID := range entry.LabelIDs {
+		name, ok := k.labelNames[labelID]
+		if !ok {
+			return portabilityEntryRecord{}, fmt.Errorf("%w: id %d", domain.ErrLabelNotFound, labelID)
+		}
+		record.Labels = append(record.Labels, name)
+	}
+	sort.Strings(record.Labels)
+
+	record.Fingerprint = naturalEntryFingerprint(record)
+	return record, nil
+}
+
+func (k portabilityNaturalKeys) resolveRecord(record portabilityEntryRecord) (portabilityEntryRecord, error) {
+	if record.CategoryID != nil || len(record.LabelIDs) > 0 {
+		return portabilityEntryRecord{}, fmt.Errorf("invalid import record: category_id/label_ids cannot be mixed with natural keys")
+	}
+
+	if record.Category != "" {
+		categoryID, ok := k.categoryIDs[strings.ToLower(strings.TrimSpace(record.Category))]
+		if !ok {
+			return portabilityEntryRecord{}, fmt.Errorf("%w: %q", domain.ErrCategoryNotFound, record.Category)
+		}
+		record.CategoryID = &categoryID
+	}
+
+	for _, label := range record.Labels {
+		labelID, ok := k.labelIDs[strings.ToLower(strings.TrimSpace(label))]
+		if !ok {
+			return portabilityEntryRecord{}, fmt.Errorf("%w: %q", domain.ErrLabelNotFound, label)
+		}
+		record.LabelIDs = append(record.LabelIDs, labelID)
+	}
+
+	if record.PaymentCard != "" {
+		cardID, err := k.cardID(record.PaymentCard)
+		if err != nil {
+			return portabilityEntryRecord{}, err
+		}
+		record.paymentCardID = &cardID
+	}
+
+	return record, nil
+}
+
+// cardID looks up a card by nickname, archived cards included.
+func (k portabilityNaturalKeys) cardID(nickname string) (int64, error) {
+	cardID, ok := k.cardIDs[strings.ToLower(strings.TrimSpace(nickname))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", domain.ErrCardNotFound, nickname)
+	}
+	return cardID, nil
+}
+
+// withNameAliases folds category_name/label_names into the natural-key
+// category/labels fields; setting both spellings is rejected.
+func (r portabilityEntryRecord) withNameAliases() (portabilityEntryRecord, error) {
+	if r.CategoryName != "" {
+		if r.Category != "" {
+			return portabilityEntryRecord{}, fmt.Errorf("invalid import record: category and category_name cannot both be set")
+		}
+		r.Category = r.CategoryName
+		r.CategoryName = ""
+	}
+	if len(r.LabelNames) > 0 {
+		if len(r.Labels) > 0 {
+			return portabilityEntryRecord{}, fmt.Errorf("invalid import record: labels and label_names cannot both be set")
+		}
+		r.Labels = r.LabelNames
+		r.LabelNames = nil
+	}
+	return r, nil
+}
+
+func (r portabilityEntryRecord) usesNaturalKeys() bool {
+	return r.Category != "" || len(r.Labels) > 0 || r.PaymentCard != ""
+}
+
+// naturalEntryFingerprint hashes an entry by its natural keys only, so the same
+// entry fingerprints identically in every database.
+func naturalEntryFingerprint(record portabilityEntryRecord) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		record.Type,
+		strconv.FormatInt(record.AmountMinor, 10),
+		record.CurrencyCode,
+		record.TransactionDateUTC,
+		strings.ToLower(record.Category),
+		strings.ToLower(strings.Join(record.Labels, ",")),
+		record.Note,
+		record.PaymentMethod,
+		strings.ToLower(record.PaymentCard),
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// portabilityRecordUpdate builds the update that brings existing in line with
+// record, reporting false when nothing differs. Payment details are only
+// compared when the record carries them, since ID-keyed exports omit them.
+func portabilityRecordUpdate(existing domain.Entry, record portabilityEntryRecord) (domain.EntryUpdateInput, bool, error) {
+	entryType, err := domain.NormalizeEntryType(record.Type)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+	currencyCode, err := domain.NormalizeCurrencyCode(record.CurrencyCode)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+	transactionDateUTC, err := domain.NormalizeTransactionDateUTC(record.TransactionDateUTC)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+
+	update := domain.EntryUpdateInput{ID: existing.ID, AllowArchived: true}
+	if entryType != existing.Type {
+		update.Type = &entryType
+	}
+	if record.AmountMinor != existing.AmountMinor {
+		amountMinor := record.AmountMinor
+		update.AmountMinor = &amountMinor
+	}
+	if currencyCode != existing.CurrencyCode {
+		update.CurrencyCode = &currencyCode
+	}
+	if transactionDateUTC != existing.TransactionDateUTC {
+		update.TransactionDateUTC = &transactionDateUTC
+	}
+	if !equalOptionalInt64(record.CategoryID, existing.CategoryID) {
+		update.SetCategory = true
+		update.CategoryID = record.CategoryID
+	}
+	labelIDs, err := domain.NormalizeLabelIDs(record.LabelIDs)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+	existingLabelIDs, err := domain.NormalizeLabelIDs(existing.LabelIDs)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+	if !equalInt64Slices(labelIDs, existingLabelIDs) {
+		update.SetLabelIDs = true
+		update.LabelIDs = labelIDs
+	}
+	if note := strings.TrimSpace(record.Note); note != existing.Note {
+		update.SetNote = true
+		if note != "" {
+			update.Note = &note
+		}
+	}
+	if payee := domain.NormalizePayee(record.Payee); payee != existing.Payee {
+		update.SetPayee = true
+		update.Payee = &payee
+	}
+	if recordedBy := domain.NormalizeRecordedBy(record.RecordedBy); recordedBy != existing.RecordedBy {
+		update.SetRecordedBy = true
+		update.RecordedBy = &recordedBy
+	}
+	if incomeSource := domain.NormalizeIncomeSource(record.IncomeSource); incomeSource != existing.IncomeSource {
+		update.SetIncomeSource = true
+		if incomeSource != "" {
+			update.IncomeSource = &incomeSource
+		}
+	}
+	location, err := domain.NormalizeEntryLocation(record.Location)
+	if err != nil {
+		return domain.EntryUpdateInput{}, false, err
+	}
+	if location != existing.Location {
+		update.SetLocation = true
+		if location != "" {
+			update.Location = &location
+		}
+	}
+	if paymentMethod := strings.ToLower(strings.TrimSpace(record.PaymentMethod)); paymentMethod != "" {
+		if paymentMethod != existing.PaymentMethod || !equalOptionalInt64(record.paymentCardID, existing.PaymentCardID) {
+			update.SetPaymentMethod = true
+			update.PaymentMethod = &paymentMethod
+			if record.paymentCardID != nil {
+				update.SetPaymentCard = true
+				update.PaymentCardID = record.paymentCardID
+			}
+		}
+	}
+
+	return update, domain.HasEntryUpdateChanges(update), nil
+}
+
+func equalOptionalInt64(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalInt64Slices(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func entrySignature(entry domain.Entry) string {
 	labelIDs := append([]int64(nil), entry.LabelIDs...)
 	sort.Slice(labelIDs, func(i, j int) bool {
@@ -949,3 +2426,17 @@ func bindEntryCapLookupToTx(capLookup EntryCapLookup, tx *sql.Tx) (EntryCapLooku
 
 	return boundCapLookup, true
 }
+
+func bindEntryCardLimitLookupToTx(cardLimits EntryCardLimitLookup, tx *sql.Tx) (EntryCardLimitLookup, bool) {
+	binder, ok := cardLimits.(ports.CardRepositoryTxBinder)
+	if !ok {
+		return nil, false
+	}
+
+	boundCardLimits := binder.BindTx(tx)
+	if boundCardLimits == nil {
+		return nil, false
+	}
+
+	return boundCardLimits, true
+}
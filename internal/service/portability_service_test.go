@@ -266,6 +266,97 @@ func TestPortabilityServiceImportRollsBackWhenCSVParseFailsMidStream(t *testing.
 	}
 }
 
+func TestPortabilityServiceImportChecksSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	portabilitySvc, entrySvc, db := newPortabilityServiceTestHarness(t)
+	defer db.Close()
+
+	// Version 1 files carry no marker; a uid in them is not trusted.
+	legacyPath := filepath.Join(t.TempDir(), "legacy.json")
+	if err := os.WriteFile(legacyPath, []byte(`{"entries":[{"uid":"0b6f4c1e-2f57-4f55-9a4e-3f1e8a4c9d21","type":"income","amount_minor":1000,"currency_code":"USD","transaction_date_utc":"2026-05-01T00:00:00Z","note":"legacy"}]}`), 0o644); err != nil {
+		t.Fatalf("write legacy export: %v", err)
+	}
+	result, err := portabilitySvc.Import(ctx, PortabilityFormatJSON, legacyPath, false)
+	if err != nil {
+		t.Fatalf("import legacy export: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Fatalf("expected legacy export to import 1 entry, got %d", result.Imported)
+	}
+	entries, err := entrySvc.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].UID == "" || entries[0].UID == "0b6f4c1e-2f57-4f55-9a4e-3f1e8a4c9d21" {
+		t.Fatalf("expected legacy entry to get a fresh uid, got %+v", entries)
+	}
+
+	newerJSONPath := filepath.Join(t.TempDir(), "newer.json")
+	if err := os.WriteFile(newerJSONPath, []byte(`{"schema_version":99,"entries":[{"type":"income","amount_minor":1000,"currency_code":"USD","transaction_date_utc":"2026-05-02T00:00:00Z"}]}`), 0o644); err != nil {
+		t.Fatalf("write newer export: %v", err)
+	}
+	if _, err := portabilitySvc.Import(ctx, PortabilityFormatJSON, newerJSONPath, false); !errors.Is(err, domain.ErrExportUpgradeRequired) {
+		t.Fatalf("expected ErrExportUpgradeRequired for newer json export, got %v", err)
+	}
+
+	newerCSVPath := filepath.Join(t.TempDir(), "newer.csv")
+	writePortabilityCSVRows(t, newerCSVPath, [][]string{
+		{"type", "amount_minor", "currency_code", "transaction_date_utc", "category_id", "label_ids", "note", "payee", "recorded_by", "uid", "schema_version"},
+		{"income", "1000", "USD", "2026-05-03T00:00:00Z", "", "", "newer", "", "", "", "99"},
+	})
+	if _, err := portabilitySvc.Import(ctx, PortabilityFormatCSV, newerCSVPath, false); !errors.Is(err, domain.ErrExportUpgradeRequired) {
+		t.Fatalf("expected ErrExportUpgradeRequired for newer csv export, got %v", err)
+	}
+
+	if count := activePortabilityTransactionCount(t, ctx, db); count != 1 {
+		t.Fatalf("expected rejected imports to add nothing, got %d entries", count)
+	}
+}
+
+func TestPortabilityServiceSnapshotKeepsNewestSnapshots(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _, db := newPortabilityServiceTestHarness(t)
+	defer db.Close()
+
+	dir := filepath.Join(t.TempDir(), domain.SafetySnapshotDirName)
+	unrelated := filepath.Join(dir, "keep-me.sqlite")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir snapshots: %v", err)
+	}
+	if err := os.WriteFile(unrelated, []byte("not a snapshot"), 0o644); err != nil {
+		t.Fatalf("write unrelated file: %v", err)
+	}
+
+	var taken []domain.SafetySnapshot
+	for range 3 {
+		snapshot, err := svc.Snapshot(ctx, dir, "data import", 2)
+		if err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		taken = append(taken, snapshot)
+	}
+
+	if len(taken[2].Pruned) != 1 || taken[2].Pruned[0] != taken[0].Path {
+		t.Fatalf("expected the third snapshot to prune the first, got %v", taken[2].Pruned)
+	}
+	for i, snapshot := range taken {
+		_, err := os.Stat(snapshot.Path)
+		if exists := err == nil; exists != (i > 0) {
+			t.Fatalf("snapshot %d exists=%v, stat err=%v", i, exists, err)
+		}
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Fatalf("expected pruning to leave other files alone: %v", err)
+	}
+	if !strings.HasSuffix(taken[1].Path, "-data-import.sqlite") {
+		t.Fatalf("unexpected snapshot name %q", taken[1].Path)
+	}
+}
+
 func TestPortabilityServiceImportRequiresTransactionalEntryRepositoryBinding(t *testing.T) {
 	t.Parallel()
 
@@ -306,6 +397,200 @@ func TestPortabilityServiceImportRequiresTransactionalEntryRepositoryBinding(t *
 	}
 }
 
+func TestPortabilityServiceExportImportRoundTripThroughStdio(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, sourceEntrySvc, sourceDB := newPortabilityServiceTestHarness(t)
+	defer sourceDB.Close()
+
+	for _, input := range []domain.EntryAddInput{
+		{Type: domain.EntryTypeIncome, AmountMinor: 250000, CurrencyCode: "USD", TransactionDateUTC: "2026-02-01T00:00:00Z", Note: "salary"},
+		{Type: domain.EntryTypeExpense, AmountMinor: 4200, CurrencyCode: "EUR", TransactionDateUTC: "2026-02-03T00:00:00Z"},
+	} {
+		if _, err := sourceEntrySvc.Add(ctx, input); err != nil {
+			t.Fatalf("seed entry: %v", err)
+		}
+	}
+
+	exported := &strings.Builder{}
+	exportSvc, err := NewPortabilityService(sourceEntrySvc, sourceDB, WithPortabilityStdio(nil, exported))
+	if err != nil {
+		t.Fatalf("new export service: %v", err)
+	}
+	count, err := exportSvc.Export(ctx, PortabilityFormatJSON, PortabilityStdioPath, domain.EntryListFilter{})
+	if err != nil {
+		t.Fatalf("export to stdout: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 exported entries, got %d", count)
+	}
+
+	entries, err := sourceEntrySvc.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		t.Fatalf("list source entries: %v", err)
+	}
+	records := make([]portabilityEntryRecord, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, portabilityEntryRecord{
+			UID:                entry.UID,
+			Type:               entry.Type,
+			AmountMinor:        entry.AmountMinor,
+			Amount:             portabilityMajorAmount(entry.AmountMinor, entry.CurrencyCode),
+			CurrencyCode:       entry.CurrencyCode,
+			TransactionDateUTC: entry.TransactionDateUTC,
+			CategoryID:         entry.CategoryID,
+			LabelIDs:           entry.LabelIDs,
+			Note:               entry.Note,
+			PaymentMethod:      entry.PaymentMethod,
+		})
+	}
+	expected, err := json.MarshalIndent(portabilityJSONEnvelope{SchemaVersion: domain.EntryExportSchemaVersion, Entries: records}, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal expected export: %v", err)
+	}
+	if exported.String() != string(expected) {
+		t.Fatalf("expected streamed export to match buffered encoding\nwant=%s\ngot=%s", expected, exported.String())
+	}
+
+	_, targetEntrySvc, targetDB := newPortabilityServiceTestHarness(t)
+	defer targetDB.Close()
+
+	importSvc, err := NewPortabilityService(targetEntrySvc, targetDB, WithPortabilityStdio(strings.NewReader(exported.String()), nil))
+	if err != nil {
+		t.Fatalf("new import service: %v", err)
+	}
+	result, err := importSvc.Import(ctx, PortabilityFormatJSON, PortabilityStdioPath, false)
+	if err != nil {
+		t.Fatalf("import from stdin: %v", err)
+	}
+	if result.Imported != 2 {
+		t.Fatalf("expected 2 imported entries, got %d", result.Imported)
+	}
+}
+
+func TestPortabilityServiceNaturalKeyExportImportAcrossDatabases(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, sourceEntrySvc, sourceDB := newPortabilityServiceTestHarness(t)
+	defer sourceDB.Close()
+
+	sourceCategories := sqlitestore.NewCategoryRepo(sourceDB)
+	if _, err := sourceCategories.Add(ctx, "Filler"); err != nil {
+		t.Fatalf("add filler category: %v", err)
+	}
+	groceries, err := sourceCategories.Add(ctx, "Groceries")
+	if err != nil {
+		t.Fatalf("add source category: %v", err)
+	}
+	sourceLabels, err := sqlitestore.NewLabelRepo(sourceDB)
+	if err != nil {
+		t.Fatalf("new source label repo: %v", err)
+	}
+	weekly, err := sourceLabels.Add(ctx, "Weekly")
+	if err != nil {
+		t.Fatalf("add source label: %v", err)
+	}
+	if _, err := sourceEntrySvc.Add(ctx, domain.EntryAddInput{
+		Type:               domain.EntryTypeExpense,
+		AmountMinor:        4200,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-02-03T00:00:00Z",
+		CategoryID:         &groceries.ID,
+		LabelIDs:           []int64{weekly.ID},
+		Note:               "market",
+	}); err != nil {
+		t.Fatalf("seed entry: %v", err)
+	}
+
+	exportSvc, err := NewPortabilityService(sourceEntrySvc, sourceDB, WithPortabilityNaturalKeys(sourceCategories, sourceLabels, sqlitestore.NewCardRepo(sourceDB)))
+	if err != nil {
+		t.Fatalf("new export service: %v", err)
+	}
+
+	for _, format := range []string{PortabilityFormatJSON, PortabilityFormatCSV} {
+		exportPath := filepath.Join(t.TempDir(), "entries."+format)
+		if _, err := exportSvc.ExportWithKeys(ctx, format, exportPath, PortabilityKeyModeNatural, domain.EntryListFilter{}); err != nil {
+			t.Fatalf("natural %s export: %v", format, err)
+		}
+		content, err := os.ReadFile(exportPath)
+		if err != nil {
+			t.Fatalf("read %s export: %v", format, err)
+		}
+		if strings.Contains(string(content), "category_id") || !strings.Contains(string(content), "Groceries") {
+			t.Fatalf("expected %s export to use category names, got %s", format, content)
+		}
+		if !strings.Contains(string(content), "fingerprint") {
+			t.Fatalf("expected %s export to include fingerprints, got %s", format, content)
+		}
+
+		_, targetEntrySvc, targetDB := newPortabilityServiceTestHarness(t)
+		defer targetDB.Close()
+
+		targetCategories := sqlitestore.NewCategoryRepo(targetDB)
+		targetGroceries, err := targetCategories.Add(ctx, "groceries")
+		if err != nil {
+			t.Fatalf("add target category: %v", err)
+		}
+		targetLabels, err := sqlitestore.NewLabelRepo(targetDB)
+		if err != nil {
+			t.Fatalf("new target label repo: %v", err)
+		}
+		if _, err := targetLabels.Add(ctx, "Other"); err != nil {
+			t.Fatalf("add filler label: %v", err)
+		}
+		targetWeekly, err := targetLabels.Add(ctx, "Weekly")
+		if err != nil {
+			t.Fatalf("add target label: %v", err)
+		}
+
+		importSvc, err := NewPortabilityService(targetEntrySvc, targetDB, WithPortabilityNaturalKeys(targetCategories, targetLabels, sqlitestore.NewCardRepo(targetDB)))
+		if err != nil {
+			t.Fatalf("new import service: %v", err)
+		}
+		result, err := importSvc.Import(ctx, format, exportPath, false)
+		if err != nil {
+			t.Fatalf("natural %s import: %v", format, err)
+		}
+		if result.Imported != 1 {
+			t.Fatalf("expected 1 imported entry, got %d", result.Imported)
+		}
+
+		imported, err := targetEntrySvc.List(ctx, domain.EntryListFilter{})
+		if err != nil {
+			t.Fatalf("list target entries: %v", err)
+		}
+		if len(imported) != 1 || imported[0].CategoryID == nil || *imported[0].CategoryID != targetGroceries.ID {
+			t.Fatalf("expected category resolved to local id %d, got %+v", targetGroceries.ID, imported)
+		}
+		if len(imported[0].LabelIDs) != 1 || imported[0].LabelIDs[0] != targetWeekly.ID {
+			t.Fatalf("expected label resolved to local id %d, got %+v", targetWeekly.ID, imported[0].LabelIDs)
+		}
+	}
+
+	_, missingEntrySvc, missingDB := newPortabilityServiceTestHarness(t)
+	defer missingDB.Close()
+	missingLabels, err := sqlitestore.NewLabelRepo(missingDB)
+	if err != nil {
+		t.Fatalf("new label repo: %v", err)
+	}
+	missingSvc, err := NewPortabilityService(missingEntrySvc, missingDB, WithPortabilityNaturalKeys(sqlitestore.NewCategoryRepo(missingDB), missingLabels, sqlitestore.NewCardRepo(missingDB)))
+	if err != nil {
+		t.Fatalf("new portability service: %v", err)
+	}
+	importPath := writePortabilityImportJSON(t, []portabilityEntryRecord{{
+		Type:               domain.EntryTypeExpense,
+		AmountMinor:        100,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-02-03T00:00:00Z",
+		Category:           "Unknown",
+	}})
+	if _, err := missingSvc.Import(ctx, PortabilityFormatJSON, importPath, false); !errors.Is(err, domain.ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound for unknown category, got %v", err)
+	}
+}
+
 func TestPortabilityServiceExportReportJSON(t *testing.T) {
 	t.Parallel()
 
@@ -744,6 +1029,10 @@ func (nonTransactionalEntryRepo) List(context.Context, domain.EntryListFilter) (
 	return nil, errors.New("not implemented")
 }
 
+func (nonTransactionalEntryRepo) Get(context.Context, int64) (domain.Entry, error) {
+	return domain.Entry{}, errors.New("not implemented")
+}
+
 func (nonTransactionalEntryRepo) Delete(context.Context, int64) (domain.EntryDeleteResult, error) {
 	return domain.EntryDeleteResult{}, errors.New("not implemented")
 }
@@ -0,0 +1,514 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type PortabilitySourceImportOptions struct {
+	Idempotent    bool
+	CreateMissing bool
+	OnConflict    string
+	// CurrencyCode applies to sources whose exports carry no currency (YNAB,
+	// Mint).
+	CurrencyCode string
+}
+
+type PortabilitySourceImportResult struct {
+	PortabilityImportResult
+	PortabilityCreatedKeys
+	// SourceSkipped counts rows that are not income or spending, such as
+	// transfers between accounts.
+	SourceSkipped int                         `json:"source_skipped"`
+	Unmapped      domain.ImportSourceUnmapped `json:"unmapped"`
+}
+
+// ImportSource imports another app's CSV export. Categories, labels and
+// accounts are matched by name (case-insensitively) against local
+// categories, labels and card nicknames; values that do not match are left
+// off the entries and listed in Unmapped instead of failing the import.
+// CreateMissing creates unknown categories and labels in the import
+// transaction.
+func (s *PortabilityService) ImportSource(ctx context.Context, source, filePath string, options PortabilitySourceImportOptions) (PortabilitySourceImportResult, error) {
+	normalizedSource, err := domain.NormalizeImportSource(source)
+	if err != nil {
+		return PortabilitySourceImportResult{}, err
+	}
+	currencyCode := ""
+	if strings.TrimSpace(options.CurrencyCode) != "" || normalizedSource != domain.ImportSourceGnuCash {
+		currencyCode, err = domain.NormalizeCurrencyCode(options.CurrencyCode)
+		if err != nil {
+			return PortabilitySourceImportResult{}, err
+		}
+	}
+
+	input, err := s.openInput(filePath)
+	if err != nil {
+		return PortabilitySourceImportResult{}, err
+	}
+	records, sourceSkipped, err := readSourceRecords(normalizedSource, input, currencyCode)
+	_ = input.Close()
+	if err != nil {
+		return PortabilitySourceImportResult{}, err
+	}
+
+	stream := func(records []portabilityEntryRecord) func(func(portabilityEntryRecord) error) error {
+		return func(consume func(portabilityEntryRecord) error) error {
+			for _, record := range records {
+				if err := consume(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	result := PortabilitySourceImportResult{
+		PortabilityCreatedKeys: PortabilityCreatedKeys{CreatedCategories: []string{}, CreatedLabels: []string{}},
+		SourceSkipped:          sourceSkipped,
+	}
+	var keys portabilityNaturalKeys
+	var prepare portabilityImportPrepare
+	if options.CreateMissing {
+		keys, prepare, err = s.createMissingNaturalKeys(ctx, stream(records), &result.PortabilityCreatedKeys)
+	} else {
+		keys, err = s.loadNaturalKeys(ctx)
+	}
+	if err != nil {
+		return PortabilitySourceImportResult{}, err
+	}
+
+	// Records are mapped once the import transaction has created any missing
+	// categories and labels, so the new names count as matched.
+	mappedStream := func(consume func(portabilityEntryRecord) error) error {
+		mapped, unmapped := mapSourceRecords(records, keys)
+		result.Unmapped = unmapped
+		return stream(mapped)(consume)
+	}
+
+	result.PortabilityImportResult, err = s.importRecordsWith(ctx, PortabilityImportOptions{Idempotent: options.Idempotent, OnConflict: options.OnConflict}, prepare, mappedStream)
+	if err != nil {
+		return PortabilitySourceImportResult{}, err
+	}
+	return result, nil
+}
+
+// mapSourceRecords drops the category, labels and account of each record that
+// have no local match and collects them per kind.
+func mapSourceRecords(records []portabilityEntryRecord, keys portabilityNaturalKeys) ([]portabilityEntryRecord, domain.ImportSourceUnmapped) {
+	categories := map[string]string{}
+	labels := map[string]string{}
+	accounts := map[string]string{}
+
+	mapped := make([]portabilityEntryRecord, 0, len(records))
+	for _, record := range records {
+		if name := strings.TrimSpace(record.Category); name != "" {
+			if _, ok := keys.categoryIDs[strings.ToLower(name)]; !ok {
+				categories[strings.ToLower(name)] = name
+				record.Category = ""
+			}
+		}
+
+		knownLabels := []string{}
+		for _, label := range record.Labels {
+			name := strings.TrimSpace(label)
+			if _, ok := keys.labelIDs[strings.ToLower(name)]; ok {
+				knownLabels = append(knownLabels, name)
+				continue
+			}
+			labels[strings.ToLower(name)] = name
+		}
+		record.Labels = knownLabels
+
+		if name := strings.TrimSpace(record.PaymentCard); name != "" {
+			if _, ok := keys.cardIDs[strings.ToLower(name)]; ok {
+				record.PaymentMethod = domain.PaymentMethodCard
+			} else {
+				accounts[strings.ToLower(name)] = name
+				record.PaymentCard = ""
+			}
+		}
+		mapped = append(mapped, record)
+	}
+
+	return mapped, domain.ImportSourceUnmapped{
+		Categories: sortedMapValues(categories),
+		Labels:     sortedMapValues(labels),
+		Accounts:   sortedMapValues(accounts),
+	}
+}
+
+func readSourceRecords(source string, input io.Reader, currencyCode string) ([]portabilityEntryRecord, int, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, 0, fmt.Errorf("%w: file is empty", domain.ErrInvalidImportSourceFile)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	rows := sourceRows{columns: map[string]int{}}
+	for index, name := range header {
+		rows.columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = index
+	}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		rows.rows = append(rows.rows, row)
+	}
+
+	switch source {
+	case domain.ImportSourceYNAB:
+		return readYNABRecords(rows, currencyCode)
+	case domain.ImportSourceMint:
+		return readMintRecords(rows, currencyCode)
+	default:
+		return readGnuCashRecords(rows, currencyCode)
+	}
+}
+
+type sourceRows struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+func (r sourceRows) require(names ...string) error {
+	for _, name := range names {
+		if _, ok := r.columns[name]; !ok {
+			return fmt.Errorf("%w: header is missing the %q column", domain.ErrInvalidImportSourceFile, name)
+		}
+	}
+	return nil
+}
+
+func (r sourceRows) field(row []string, names ...string) string {
+	for _, name := range names {
+		if index, ok := r.columns[name]; ok && index < len(row) {
+			if value := strings.TrimSpace(row[index]); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// readYNABRecords maps a YNAB register export: Outflow rows become expenses
+// and Inflow rows income, the category and payee carry over, the account is
+// matched against card nicknames and a flag becomes a label. Transfers
+// between budget accounts are skipped.
+func readYNABRecords(rows sourceRows, currencyCode string) ([]portabilityEntryRecord, int, error) {
+	if err := rows.require("account", "date", "payee", "outflow", "inflow"); err != nil {
+		return nil, 0, err
+	}
+
+	records := []portabilityEntryRecord{}
+	skipped := 0
+	for index, row := range rows.rows {
+		rowNumber := index + 2
+		payee := rows.field(row, "payee")
+		if strings.HasPrefix(strings.ToLower(payee), "transfer :") {
+			skipped++
+			continue
+		}
+
+		outflow, err := parseSourceAmount(rows.field(row, "outflow"), currencyCode, rowNumber)
+		if err != nil {
+			return nil, 0, err
+		}
+		inflow, err := parseSourceAmount(rows.field(row, "inflow"), currencyCode, rowNumber)
+		if err != nil {
+			return nil, 0, err
+		}
+		record := portabilityEntryRecord{
+			Type:         domain.EntryTypeExpense,
+			AmountMinor:  outflow - inflow,
+			CurrencyCode: currencyCode,
+			Category:     rows.field(row, "category", "sub category"),
+			Payee:        payee,
+			Note:         rows.field(row, "memo"),
+			PaymentCard:  rows.field(row, "account"),
+		}
+		if record.AmountMinor < 0 {
+			record.Type = domain.EntryTypeIncome
+			record.AmountMinor = -record.AmountMinor
+		}
+		if record.AmountMinor == 0 {
+			skipped++
+			continue
+		}
+		if isYNABIncomeCategory(record.Category) {
+			record.Category = ""
+		}
+		if flag := rows.field(row, "flag"); flag != "" {
+			record.Labels = []string{flag}
+		}
+		if record.TransactionDateUTC, err = parseSourceDate(rows.field(row, "date"), rowNumber); err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+	return records, skipped, nil
+}
+
+func isYNABIncomeCategory(category string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(category))
+	return strings.Contains(normalized, "ready to assign") || strings.Contains(normalized, "to be budgeted")
+}
+
+// readMintRecords maps a Mint transactions export: debits become expenses
+// and credits income, Description is the payee, Labels (space separated)
+// become labels and Account Name is matched against card nicknames.
+// Transfers and credit card payments are skipped.
+func readMintRecords(rows sourceRows, currencyCode string) ([]portabilityEntryRecord, int, error) {
+	if err := rows.require("date", "description", "amount", "transaction type", "category", "account name"); err != nil {
+		return nil, 0, err
+	}
+
+	records := []portabilityEntryRecord{}
+	skipped := 0
+	for index, row := range rows.rows {
+		rowNumber := index + 2
+		category := rows.field(row, "category")
+		switch strings.ToLower(category) {
+		case "transfer", "credit card payment", "transfer for cash spending":
+			skipped++
+			continue
+		case "uncategorized":
+			category = ""
+		}
+
+		amountMinor, err := parseSourceAmount(rows.field(row, "amount"), currencyCode, rowNumber)
+		if err != nil {
+			return nil, 0, err
+		}
+		if amountMinor == 0 {
+			skipped++
+			continue
+		}
+		record := portabilityEntryRecord{
+			AmountMinor:  amountMinor,
+			CurrencyCode: currencyCode,
+			Category:     category,
+			Payee:        rows.field(row, "description"),
+			Note:         rows.field(row, "notes"),
+			PaymentCard:  rows.field(row, "account name"),
+			Labels:       strings.Fields(rows.field(row, "labels")),
+		}
+		switch strings.ToLower(rows.field(row, "transaction type")) {
+		case "debit":
+			record.Type = domain.EntryTypeExpense
+		case "credit":
+			record.Type = domain.EntryTypeIncome
+		default:
+			return nil, 0, fmt.Errorf("%w: row %d: transaction type must be debit or credit", domain.ErrInvalidImportSourceFile, rowNumber)
+		}
+		if amountMinor < 0 {
+			record.AmountMinor = -amountMinor
+		}
+		if record.TransactionDateUTC, err = parseSourceDate(rows.field(row, "date"), rowNumber); err != nil {
+			return nil, 0, err
+		}
+		records = append(records, record)
+	}
+	return records, skipped, nil
+}
+
+type gnuCashSplit struct {
+	rowNumber   int
+	account     string
+	fullAccount string
+	amount      string
+}
+
+type gnuCashTransaction struct {
+	rowNumber   int
+	date        string
+	description string
+	notes       string
+	currency    string
+	splits      []gnuCashSplit
+}
+
+// readGnuCashRecords maps a GnuCash transaction export, one row per split.
+// Rows without a date continue the transaction above them. Each split into
+// an Expenses: or Income: account becomes one entry with the account's leaf
+// name as category; the transaction's other account (an asset or liability)
+// is matched against card nicknames. Transactions with no income or expense
+// split are transfers and are skipped.
+func readGnuCashRecords(rows sourceRows, defaultCurrency string) ([]portabilityEntryRecord, int, error) {
+	if err := rows.require("date", "description", "full account name", "amount num."); err != nil {
+		return nil, 0, err
+	}
+
+	transactions := []*gnuCashTransaction{}
+	var current *gnuCashTransaction
+	currentID := ""
+	for index, row := range rows.rows {
+		rowNumber := index + 2
+		transactionID := rows.field(row, "transaction id")
+		date := rows.field(row, "date")
+		if current == nil || (date != "" && (transactionID == "" || transactionID != currentID)) {
+			if date == "" {
+				return nil, 0, fmt.Errorf("%w: row %d: date is required", domain.ErrInvalidImportSourceFile, rowNumber)
+			}
+			current = &gnuCashTransaction{
+				rowNumber:   rowNumber,
+				date:        date,
+				description: rows.field(row, "description"),
+				notes:       rows.field(row, "notes"),
+				currency:    rows.field(row, "commodity/currency"),
+			}
+			currentID = transactionID
+			transactions = append(transactions, current)
+		}
+		current.splits = append(current.splits, gnuCashSplit{
+			rowNumber:   rowNumber,
+			account:     rows.field(row, "account name"),
+			fullAccount: rows.field(row, "full account name"),
+			amount:      rows.field(row, "amount num."),
+		})
+	}
+
+	records := []portabilityEntryRecord{}
+	skipped := 0
+	for _, transaction := range transactions {
+		currencyCode := defaultCurrency
+		if raw := transaction.currency; raw != "" {
+			normalized, err := domain.NormalizeCurrencyCode(strings.TrimPrefix(raw, "CURRENCY::"))
+			if err != nil {
+				return nil, 0, fmt.Errorf("%w: row %d: %v", domain.ErrInvalidImportSourceFile, transaction.rowNumber, err)
+			}
+			currencyCode = normalized
+		}
+		if currencyCode == "" {
+			return nil, 0, fmt.Errorf("%w: row %d: currency is required", domain.ErrInvalidImportSourceFile, transaction.rowNumber)
+		}
+		dateUTC, err := parseSourceDate(transaction.date, transaction.rowNumber)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		fundingAccount := ""
+		for _, split := range transaction.splits {
+			if !isGnuCashCategoryAccount(split.fullAccount) {
+				fundingAccount = gnuCashLeafName(split)
+				break
+			}
+		}
+
+		before := len(records)
+		for _, split := range transaction.splits {
+			if !isGnuCashCategoryAccount(split.fullAccount) {
+				continue
+			}
+			amountMinor, err := parseSourceAmount(split.amount, currencyCode, split.rowNumber)
+			if err != nil {
+				return nil, 0, err
+			}
+			// Expense splits are debits (positive) and income splits credits
+			// (negative); the opposite sign is a refund or reversal.
+			entryType := domain.EntryTypeExpense
+			if strings.HasPrefix(strings.ToLower(split.fullAccount), "income") {
+				amountMinor = -amountMinor
+				entryType = domain.EntryTypeIncome
+			}
+			if amountMinor < 0 {
+				amountMinor = -amountMinor
+				if entryType == domain.EntryTypeExpense {
+					entryType = domain.EntryTypeIncome
+				} else {
+					entryType = domain.EntryTypeExpense
+				}
+			}
+			if amountMinor == 0 {
+				continue
+			}
+			records = append(records, portabilityEntryRecord{
+				Type:               entryType,
+				AmountMinor:        amountMinor,
+				CurrencyCode:       currencyCode,
+				TransactionDateUTC: dateUTC,
+				Category:           gnuCashLeafName(split),
+				Payee:              transaction.description,
+				Note:               transaction.notes,
+				PaymentCard:        fundingAccount,
+			})
+		}
+		if len(records) == before {
+			skipped++
+		}
+	}
+	return records, skipped, nil
+}
+
+func isGnuCashCategoryAccount(fullAccount string) bool {
+	normalized := strings.ToLower(fullAccount)
+	return strings.HasPrefix(normalized, "expenses") || strings.HasPrefix(normalized, "income")
+}
+
+func gnuCashLeafName(split gnuCashSplit) string {
+	if split.account != "" {
+		return split.account
+	}
+	parts := strings.Split(split.fullAccount, ":")
+	return strings.TrimSpace(parts[len(parts)-1])
+}
+
+// parseSourceAmount reads a dot-decimal amount that may carry a currency
+// symbol, thousands separators, a leading minus or accounting parentheses.
+// Empty values are zero.
+func parseSourceAmount(raw, currencyCode string, rowNumber int) (int64, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, nil
+	}
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "("), ")")
+	}
+	value = strings.TrimFunc(value, func(r rune) bool {
+		return r != '-' && r != '.' && (r < '0' || r > '9')
+	})
+	if strings.HasPrefix(value, "-") {
+		negative = !negative
+		value = strings.TrimFunc(strings.TrimPrefix(value, "-"), func(r rune) bool {
+			return r != '.' && (r < '0' || r > '9')
+		})
+	}
+
+	amountMinor, err := domain.ParseLocalizedMajorAmountToMinor(value, currencyCode, domain.AmountFormatDotDecimal)
+	if err != nil {
+		return 0, fmt.Errorf("%w: row %d: amount %q: %v", domain.ErrInvalidImportSourceFile, rowNumber, raw, err)
+	}
+	if negative {
+		return -amountMinor, nil
+	}
+	return amountMinor, nil
+}
+
+// parseSourceDate accepts ISO dates and the US month/day/year layout the
+// supported apps export by default.
+func parseSourceDate(raw string, rowNumber int) (string, error) {
+	value := strings.TrimSpace(raw)
+	for _, layout := range []string{"2006-01-02", "1/2/2006", "2006/01/02", "1/2/06"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC().Format(time.RFC3339Nano), nil
+		}
+	}
+	return "", fmt.Errorf("%w: row %d: date %q must be YYYY-MM-DD or MM/DD/YYYY", domain.ErrInvalidImportSourceFile, rowNumber, raw)
+}
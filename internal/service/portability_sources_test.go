@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"boring-budget/internal/domain"
+)
+
+func TestReadSourceRecordsMintMapsDebitsCreditsAndLabels(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`"Date","Description","Original Description","Amount","Transaction Type","Category","Account Name","Labels","Notes"`,
+		`"1/15/2026","Coffee Shop","COFFEE SHOP #12","4.50","debit","Coffee Shops","Amex","work travel",""`,
+		`"1/16/2026","Payroll","ACME PAYROLL","2000.00","credit","Paycheck","Checking","",""`,
+		`"1/17/2026","Amex Payment","AMEX EPAYMENT","300.00","debit","Credit Card Payment","Checking","",""`,
+	}, "\n")
+
+	records, skipped, err := readSourceRecords(domain.ImportSourceMint, strings.NewReader(input), "USD")
+	if err != nil {
+		t.Fatalf("read mint: %v", err)
+	}
+	if len(records) != 2 || skipped != 1 {
+		t.Fatalf("expected 2 records and 1 skipped, got %d/%d", len(records), skipped)
+	}
+	coffee := records[0]
+	if coffee.Type != domain.EntryTypeExpense || coffee.AmountMinor != 450 || coffee.Category != "Coffee Shops" || coffee.PaymentCard != "Amex" || strings.Join(coffee.Labels, ",") != "work,travel" {
+		t.Fatalf("unexpected coffee record %+v", coffee)
+	}
+	if coffee.TransactionDateUTC != "2026-01-15T00:00:00Z" {
+		t.Fatalf("expected US date parsed, got %q", coffee.TransactionDateUTC)
+	}
+	if records[1].Type != domain.EntryTypeIncome || records[1].AmountMinor != 200000 {
+		t.Fatalf("unexpected payroll record %+v", records[1])
+	}
+}
+
+func TestReadSourceRecordsGnuCashGroupsSplitsIntoEntries(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`Date,Transaction ID,Number,Description,Notes,Commodity/Currency,Void Reason,Action,Memo,Full Account Name,Account Name,Amount With Sym,Amount Num.,Reconcile,Reconcile Date,Rate/Price`,
+		`2026-02-01,t1,,Supermarket,receipt 7,CURRENCY::EUR,,,,Liabilities:Credit Card:Visa,Visa,-€60.00,-60.00,n,,1`,
+		`,,,,,,,,,Expenses:Groceries,Groceries,€45.00,45.00,n,,1`,
+		`,,,,,,,,,Expenses:Household,Household,€15.00,15.00,n,,1`,
+		`2026-02-03,t2,,Salary,,CURRENCY::EUR,,,,Assets:Checking,Checking,"€1,000.00","1,000.00",n,,1`,
+		`,,,,,,,,,Income:Salary,Salary,"-€1,000.00","-1,000.00",n,,1`,
+		`2026-02-04,t3,,Pay card,,CURRENCY::EUR,,,,Assets:Checking,Checking,-€60.00,-60.00,n,,1`,
+		`,,,,,,,,,Liabilities:Credit Card:Visa,Visa,€60.00,60.00,n,,1`,
+	}, "\n")
+
+	records, skipped, err := readSourceRecords(domain.ImportSourceGnuCash, strings.NewReader(input), "")
+	if err != nil {
+		t.Fatalf("read gnucash: %v", err)
+	}
+	if len(records) != 3 || skipped != 1 {
+		t.Fatalf("expected 3 records and the card payment skipped, got %d/%d: %+v", len(records), skipped, records)
+	}
+	groceries := records[0]
+	if groceries.Type != domain.EntryTypeExpense || groceries.AmountMinor != 4500 || groceries.CurrencyCode != "EUR" || groceries.Category != "Groceries" || groceries.PaymentCard != "Visa" || groceries.Note != "receipt 7" {
+		t.Fatalf("unexpected groceries record %+v", groceries)
+	}
+	if salary := records[2]; salary.Type != domain.EntryTypeIncome || salary.AmountMinor != 100000 || salary.Category != "Salary" {
+		t.Fatalf("unexpected salary record %+v", salary)
+	}
+}
+
+func TestReadSourceRecordsRejectsMissingColumns(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := readSourceRecords(domain.ImportSourceYNAB, strings.NewReader("Date,Payee\n01/02/2026,Shop\n"), "USD")
+	if !errors.Is(err, domain.ErrInvalidImportSourceFile) {
+		t.Fatalf("expected ErrInvalidImportSourceFile, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/reporting"
+)
+
+// ExportTaxSummary writes the tax-year summary as JSON (major units) or as
+// CSV with one row per entry followed by category and currency totals.
+func (s *PortabilityService) ExportTaxSummary(ctx context.Context, format, filePath string, year int, selection domain.TaxSelection) (domain.TaxSummary, error) {
+	normalizedFormat := normalizePortabilityFormat(format)
+	if normalizedFormat == "" {
+		return domain.TaxSummary{}, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	if s.reportService == nil {
+		return domain.TaxSummary{}, fmt.Errorf("tax export unavailable: report service is not configured")
+	}
+
+	summary, err := s.reportService.TaxSummary(ctx, year, selection)
+	if err != nil {
+		return domain.TaxSummary{}, err
+	}
+
+	if err := s.writeOutput(filePath, func(w io.Writer) error {
+		if normalizedFormat == PortabilityFormatCSV {
+			return writeTaxSummaryCSV(w, summary)
+		}
+		payload, err := reporting.ToMajorUnitMap(summary)
+		if err != nil {
+			return err
+		}
+		content, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	}); err != nil {
+		return domain.TaxSummary{}, err
+	}
+
+	return summary, nil
+}
+
+func writeTaxSummaryCSV(w io.Writer, summary domain.TaxSummary) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{
+		"record_type",
+		"year",
+		"entry_id",
+		"transaction_date_utc",
+		"type",
+		"category_id",
+		"category_name",
+		"labels",
+		"payee",
+		"note",
+		"refund_of_entry_id",
+		"currency_code",
+		"amount_major",
+		"expense_major",
+		"income_major",
+		"entry_count",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	year := strconv.Itoa(summary.Year)
+	for _, entry := range summary.Entries {
+		amountMajor, err := formatReportAmountMajor(entry.AmountMinor, entry.CurrencyCode)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write([]string{
+			"entry",
+			year,
+			strconv.FormatInt(entry.ID, 10),
+			entry.TransactionDateUTC,
+			entry.Type,
+			formatOptionalID(entry.CategoryID),
+			entry.CategoryName,
+			strings.Join(entry.Labels, "|"),
+			entry.Payee,
+			entry.Note,
+			formatOptionalID(entry.RefundOfEntryID),
+			entry.CurrencyCode,
+			amountMajor,
+			"",
+			"",
+			"",
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, total := range summary.Categories {
+		expenseMajor, incomeMajor, err := formatTaxTotals(total.ExpenseMinor, total.IncomeMinor, total.CurrencyCode)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write([]string{
+			"category_total",
+			year,
+			"",
+			"",
+			"",
+			formatOptionalID(total.CategoryID),
+			total.CategoryName,
+			"",
+			"",
+			"",
+			"",
+			total.CurrencyCode,
+			"",
+			expenseMajor,
+			incomeMajor,
+			strconv.Itoa(total.EntryCount),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, total := range summary.Totals {
+		expenseMajor, incomeMajor, err := formatTaxTotals(total.ExpenseMinor, total.IncomeMinor, total.CurrencyCode)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write([]string{
+			"currency_total",
+			year,
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			total.CurrencyCode,
+			"",
+			expenseMajor,
+			incomeMajor,
+			strconv.Itoa(total.EntryCount),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
+
+func formatTaxTotals(expenseMinor, incomeMinor int64, currencyCode string) (string, string, error) {
+	expenseMajor, err := formatReportAmountMajor(expenseMinor, currencyCode)
+	if err != nil {
+		return "", "", err
+	}
+	incomeMajor, err := formatReportAmountMajor(incomeMinor, currencyCode)
+	if err != nil {
+		return "", "", err
+	}
+	return expenseMajor, incomeMajor, nil
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type ReportScheduleRunStore interface {
+	ListReportScheduleRuns(ctx context.Context) ([]domain.ReportScheduleRun, error)
+	UpsertReportScheduleRun(ctx context.Context, run domain.ReportScheduleRun) error
+}
+
+type ReportScheduleExporter interface {
+	ExportReport(ctx context.Context, format, filePath string, req ReportRequest) (PortabilityReportExportResult, error)
+	ExportWithKeys(ctx context.Context, format, filePath, keyMode string, filter domain.EntryListFilter) (int64, error)
+}
+
+type ReportScheduleService struct {
+	runs     ReportScheduleRunStore
+	exporter ReportScheduleExporter
+}
+
+type ReportScheduleRunRequest struct {
+	Config domain.ReportScheduleConfig
+	AsOf   time.Time
+	DryRun bool
+}
+
+type ReportScheduleJobResult struct {
+	Name         string                      `json:"name"`
+	Resource     string                      `json:"resource"`
+	Every        string                      `json:"every"`
+	Period       domain.ReportSchedulePeriod `json:"period"`
+	FilePath     string                      `json:"file_path"`
+	Status       string                      `json:"status"`
+	LastRunAtUTC string                      `json:"last_run_at_utc,omitempty"`
+	Rows         *int64                      `json:"rows,omitempty"`
+}
+
+type ReportScheduleRunResult struct {
+	AsOfDate string                    `json:"as_of_date"`
+	DryRun   bool                      `json:"dry_run"`
+	Ran      int                       `json:"ran"`
+	Jobs     []ReportScheduleJobResult `json:"jobs"`
+	Warnings []domain.Warning          `json:"-"`
+}
+
+func NewReportScheduleService(runs ReportScheduleRunStore, exporter ReportScheduleExporter) (*ReportScheduleService, error) {
+	if runs == nil {
+		return nil, fmt.Errorf("report schedule service: run store is required")
+	}
+	if exporter == nil {
+		return nil, fmt.Errorf("report schedule service: exporter is required")
+	}
+
+	return &ReportScheduleService{
+		runs:     runs,
+		exporter: exporter,
+	}, nil
+}
+
+// Run executes every job whose latest completed period has not been exported
+// yet and records each success, so repeated invocations from cron within the
+// same period are no-ops. It stops at the first failing job; jobs that ran
+// before it stay recorded.
+func (s *ReportScheduleService) Run(ctx context.Context, req ReportScheduleRunRequest) (ReportScheduleRunResult, error) {
+	asOf := req.AsOf.UTC()
+	if req.AsOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+
+	runs, err := s.runs.ListReportScheduleRuns(ctx)
+	if err != nil {
+		return ReportScheduleRunResult{}, err
+	}
+	lastRuns := make(map[string]domain.ReportScheduleRun, len(runs))
+	for _, run := range runs {
+		lastRuns[run.JobName] = run
+	}
+
+	result := ReportScheduleRunResult{
+		AsOfDate: asOf.Format("2006-01-02"),
+		DryRun:   req.DryRun,
+		Jobs:     make([]ReportScheduleJobResult, 0, len(req.Config.Jobs)),
+		Warnings: []domain.Warning{},
+	}
+
+	for _, job := range req.Config.Jobs {
+		period, err := domain.LatestCompletedReportSchedulePeriod(job.Every, asOf)
+		if err != nil {
+			return ReportScheduleRunResult{}, err
+		}
+		filePath, err := expandReportSchedulePath(domain.ReportScheduleFilePath(job, period))
+		if err != nil {
+			return ReportScheduleRunResult{}, err
+		}
+
+		jobResult := ReportScheduleJobResult{
+			Name:     job.Name,
+			Resource: job.Resource,
+			Every:    job.Every,
+			Period:   period,
+			FilePath: filePath,
+			Status:   domain.ReportScheduleStatusDue,
+		}
+		if last, ok := lastRuns[job.Name]; ok {
+			jobResult.LastRunAtUTC = last.LastRunAtUTC
+			if last.PeriodKey == period.Key {
+				jobResult.Status = domain.ReportScheduleStatusNotDue
+			}
+		}
+		if jobResult.Status == domain.ReportScheduleStatusNotDue || req.DryRun {
+			result.Jobs = append(result.Jobs, jobResult)
+			continue
+		}
+
+		warnings, rows, err := s.runJob(ctx, job, period, filePath)
+		if err != nil {
+			return ReportScheduleRunResult{}, fmt.Errorf("report schedule job %q: %w", job.Name, err)
+		}
+
+		ranAt := time.Now().UTC().Format(time.RFC3339Nano)
+		if err := s.runs.UpsertReportScheduleRun(ctx, domain.ReportScheduleRun{
+			JobName:      job.Name,
+			PeriodKey:    period.Key,
+			FilePath:     filePath,
+			LastRunAtUTC: ranAt,
+		}); err != nil {
+			return ReportScheduleRunResult{}, err
+		}
+
+		jobResult.Status = domain.ReportScheduleStatusRan
+		jobResult.LastRunAtUTC = ranAt
+		jobResult.Rows = rows
+		result.Jobs = append(result.Jobs, jobResult)
+		result.Warnings = append(result.Warnings, warnings...)
+		result.Ran++
+	}
+
+	return result, nil
+}
+
+func (s *ReportScheduleService) runJob(ctx context.Context, job domain.ReportScheduleJob, period domain.ReportSchedulePeriod, filePath string) ([]domain.Warning, *int64, error) {
+	switch job.Resource {
+	case domain.ReportScheduleResourceReport:
+		periodInput := domain.ReportPeriodInput{
+			Scope:       domain.ReportScopeRange,
+			DateFromUTC: period.FromUTC,
+			DateToUTC:   period.ToUTC,
+		}
+		if period.MonthKey != "" {
+			periodInput = domain.ReportPeriodInput{Scope: domain.ReportScopeMonthly, MonthKey: period.MonthKey}
+		}
+		exported, err := s.exporter.ExportReport(ctx, job.Format, filePath, ReportRequest{
+			Period:    periodInput,
+			Grouping:  job.GroupBy,
+			ConvertTo: job.ConvertTo,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return exported.Warnings, nil, nil
+	default:
+		count, err := s.exporter.ExportWithKeys(ctx, job.Format, filePath, job.Keys, domain.EntryListFilter{
+			DateFromUTC: period.FromUTC,
+			DateToUTC:   period.ToUTC,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &count, nil
+	}
+}
+
+// expandReportSchedulePath resolves a leading ~ to the home directory, since
+// cron does not run config paths through a shell.
+func expandReportSchedulePath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
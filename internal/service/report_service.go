@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 
 	"boring-budget/internal/domain"
 	"boring-budget/internal/reporting"
+	"golang.org/x/sync/errgroup"
 )
 
 type ReportEntryReader interface {
@@ -21,10 +26,21 @@ type ReportCapReader interface {
 	ExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey, currencyCode string) (int64, error)
 }
 
+// ReportCategoryCapReader is optionally implemented by a ReportCapReader to
+// add per-category caps to cap_status.
+type ReportCategoryCapReader interface {
+	CategoryCaps(ctx context.Context, monthKey string) ([]domain.MonthlyCap, error)
+	CategoryExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey string, categoryID int64, currencyCode string) (int64, error)
+}
+
 type ReportCategoryReader interface {
 	List(ctx context.Context) ([]domain.Category, error)
 }
 
+type ReportLabelReader interface {
+	List(ctx context.Context) ([]domain.Label, error)
+}
+
 type ReportCategoryByIDsReader interface {
 	ListByIDs(ctx context.Context, ids []int64) ([]domain.Category, error)
 }
@@ -36,6 +52,12 @@ type ReportService struct {
 	settingsReader ReportSettingsReader
 	categoryReader ReportCategoryReader
 	cardDebtReader ReportCardDebtReader
+	indexReader    ReportInflationIndexReader
+	labelReader    ReportLabelReader
+	assetLister    ReportAssetLister
+	cache          ReportCache
+	monthTotals    ReportMonthTotalsReader
+	workers        int
 }
 
 type ReportRequest struct {
@@ -49,6 +71,17 @@ type ReportRequest struct {
 	PaymentCardID       *int64
 	PaymentCardNickname string
 	PaymentCardLookup   string
+	Payee               string
+	RecordedBy          string
+	IncomeSource        string
+	LocationContains    string
+	NoteContains        string
+	CurrencyCode        string
+	AmountMinMinor      *int64
+	AmountMaxMinor      *int64
+	RevalueAsOf         string
+	// IncludeAssets adds the asset registry section.
+	IncludeAssets bool
 }
 
 type ReportResult struct {
@@ -64,10 +97,43 @@ type ReportSettingsReader interface {
 	Get(ctx context.Context) (domain.Settings, error)
 }
 
+// ReportOrphanThresholdLister is implemented by settings readers that store
+// per-currency orphan-spending rules.
+type ReportOrphanThresholdLister interface {
+	ListOrphanSpendingThresholds(ctx context.Context) ([]domain.OrphanSpendingThreshold, error)
+}
+
 type ReportCardDebtReader interface {
 	ShowDebtAll(ctx context.Context) ([]CardDebtCardSummary, error)
 }
 
+type ReportAssetLister interface {
+	List(ctx context.Context, filter domain.AssetListFilter) ([]domain.Asset, error)
+}
+
+type ReportInflationIndexReader interface {
+	GetOnOrBefore(ctx context.Context, currencyCode, indexDate string) (domain.InflationIndexPoint, error)
+}
+
+// ReportMonthTotalsReader reads maintained per-month, per-currency entry
+// totals.
+type ReportMonthTotalsReader interface {
+	MonthTotals(ctx context.Context, filter domain.EntryMonthTotalsFilter) ([]domain.EntryMonthTotal, error)
+}
+
+// ReportCache keeps generated reports keyed by request and data version; the
+// version changes on every write, so a stored report is never served after
+// the data under it changed.
+type ReportCache interface {
+	DataVersion(ctx context.Context) (int64, error)
+	Get(ctx context.Context, key string, dataVersion int64) (domain.CachedReport, error)
+	Put(ctx context.Context, key string, dataVersion int64, report domain.CachedReport) error
+}
+
+// defaultReportWorkers bounds the goroutines a report uses for each of its
+// per-month aggregation, FX conversion and cap lookup steps.
+const defaultReportWorkers = 4
+
 type ReportServiceOption func(*ReportService)
 
 func WithReportFXConverter(converter ReportFXConverter) ReportServiceOption {
@@ -94,6 +160,50 @@ func WithReportCardDebtReader(reader ReportCardDebtReader) ReportServiceOption {
 	}
 }
 
+func WithReportInflationIndexReader(reader ReportInflationIndexReader) ReportServiceOption {
+	return func(s *ReportService) {
+		s.indexReader = reader
+	}
+}
+
+func WithReportLabelReader(reader ReportLabelReader) ReportServiceOption {
+	return func(s *ReportService) {
+		s.labelReader = reader
+	}
+}
+
+func WithReportAssetLister(lister ReportAssetLister) ReportServiceOption {
+	return func(s *ReportService) {
+		s.assetLister = lister
+	}
+}
+
+// WithReportCache serves repeated Generate calls for the same request from
+// cache until the data changes.
+func WithReportCache(cache ReportCache) ReportServiceOption {
+	return func(s *ReportService) {
+		s.cache = cache
+	}
+}
+
+// WithReportMonthTotals builds general_balance from month totals when the
+// report is filtered at most by currency, instead of reading every entry.
+func WithReportMonthTotals(reader ReportMonthTotalsReader) ReportServiceOption {
+	return func(s *ReportService) {
+		s.monthTotals = reader
+	}
+}
+
+// WithReportWorkers sets how many goroutines each concurrent report step may
+// use; 1 runs them serially.
+func WithReportWorkers(workers int) ReportServiceOption {
+	return func(s *ReportService) {
+		if workers > 0 {
+			s.workers = workers
+		}
+	}
+}
+
 func NewReportService(entryReader ReportEntryReader, capReader ReportCapReader, opts ...ReportServiceOption) (*ReportService, error) {
 	if entryReader == nil {
 		return nil, fmt.Errorf("report service: entry reader is required")
@@ -102,6 +212,7 @@ func NewReportService(entryReader ReportEntryReader, capReader ReportCapReader,
 	service := &ReportService{
 		entryReader: entryReader,
 		capReader:   capReader,
+		workers:     defaultReportWorkers,
 	}
 
 	for _, opt := range opts {
@@ -113,52 +224,87 @@ func NewReportService(entryReader ReportEntryReader, capReader ReportCapReader,
 	return service, nil
 }
 
+// Generate builds the report for req, or returns the cached one when a report
+// cache is set and nothing was written since it was built.
 func (s *ReportService) Generate(ctx context.Context, req ReportRequest) (ReportResult, error) {
-	period, err := domain.BuildReportPeriod(req.Period)
-	if err != nil {
-		return ReportResult{}, err
+	if s.cache == nil {
+		return s.generate(ctx, req)
 	}
 
-	grouping, err := domain.NormalizeReportGrouping(req.Grouping)
+	// The cache only saves work, so a cache that cannot be read or written
+	// falls back to building the report.
+	key, err := reportCacheKey(req)
 	if err != nil {
-		return ReportResult{}, err
+		return s.generate(ctx, req)
+	}
+	dataVersion, err := s.cache.DataVersion(ctx)
+	if err != nil {
+		slog.DebugContext(ctx, "report cache unavailable", "error", err.Error())
+		return s.generate(ctx, req)
+	}
+	cached, err := s.cache.Get(ctx, key, dataVersion)
+	if err == nil {
+		slog.DebugContext(ctx, "report cache hit", "data_version", dataVersion)
+		return ReportResult{Report: cached.Report, Warnings: cached.Warnings}, nil
+	}
+	if !errors.Is(err, domain.ErrReportCacheMiss) {
+		slog.DebugContext(ctx, "report cache read failed", "error", err.Error())
 	}
 
-	if err := domain.ValidateOptionalCategoryID(req.CategoryID); err != nil {
+	result, err := s.generate(ctx, req)
+	if err != nil {
 		return ReportResult{}, err
 	}
+	if cacheableReport(result.Report) {
+		if err := s.cache.Put(ctx, key, dataVersion, domain.CachedReport{Report: result.Report, Warnings: result.Warnings}); err != nil {
+			slog.DebugContext(ctx, "report cache write failed", "error", err.Error())
+		}
+	}
+	return result, nil
+}
 
-	normalizedLabelIDs, err := domain.NormalizeLabelIDs(req.LabelIDs)
+// reportCacheKey hashes the whole request with the cache format, so any
+// period, grouping or filter difference gets its own entry.
+func reportCacheKey(req ReportRequest) (string, error) {
+	payload, err := json.Marshal(struct {
+		Format  int
+		Request ReportRequest
+	}{Format: domain.ReportCacheFormat, Request: req})
 	if err != nil {
-		return ReportResult{}, err
+		return "", err
 	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	normalizedLabelMode, err := domain.NormalizeLabelMode(req.LabelMode)
+// cacheableReport leaves out converted reports that used estimate or stale
+// rates: those change with the clock rather than the data.
+func cacheableReport(report domain.Report) bool {
+	if report.Converted == nil {
+		return true
+	}
+	return !report.Converted.UsedEstimateRate && !report.Converted.UsedStaleRate
+}
+
+func (s *ReportService) generate(ctx context.Context, req ReportRequest) (ReportResult, error) {
+	period, err := domain.BuildReportPeriod(req.Period)
 	if err != nil {
 		return ReportResult{}, err
 	}
-	normalizedPaymentMethod, err := domain.NormalizePaymentMethodFilter(req.PaymentMethod)
+
+	grouping, err := domain.NormalizeReportGrouping(req.Grouping)
 	if err != nil {
 		return ReportResult{}, err
 	}
-	if err := domain.ValidateCardSelector(req.PaymentCardID, req.PaymentCardNickname, req.PaymentCardLookup); err != nil {
+
+	filter, err := reportEntryFilter(req)
+	if err != nil {
 		return ReportResult{}, err
 	}
-	if normalizedPaymentMethod == domain.PaymentMethodCash && domain.HasCardSelector(req.PaymentCardID, req.PaymentCardNickname, req.PaymentCardLookup) {
-		return ReportResult{}, domain.ErrCardNotAllowed
-	}
-
-	entries, err := s.entryReader.List(ctx, domain.EntryListFilter{
-		CategoryID:          req.CategoryID,
-		DateFromUTC:         period.FromUTC,
-		DateToUTC:           period.ToUTC,
-		LabelIDs:            normalizedLabelIDs,
-		LabelMode:           normalizedLabelMode,
-		PaymentMethod:       normalizedPaymentMethod,
-		PaymentCardID:       req.PaymentCardID,
-		PaymentCardNickname: strings.TrimSpace(req.PaymentCardNickname),
-		PaymentCardLookup:   strings.TrimSpace(req.PaymentCardLookup),
-	})
+	periodFilter := filter
+	periodFilter.DateFromUTC = period.FromUTC
+	periodFilter.DateToUTC = period.ToUTC
+	entries, err := s.entryReader.List(ctx, periodFilter)
 	if err != nil {
 		return ReportResult{}, err
 	}
@@ -170,7 +316,20 @@ func (s *ReportService) Generate(ctx context.Context, req ReportRequest) (Report
 		return ReportResult{}, err
 	}
 
-	aggregate, err := reporting.BuildAggregate(entries, grouping, categoryLabelResolver)
+	aggregateEntries := entries
+	var revaluation *domain.ReportRevaluation
+	revaluationWarnings := []domain.Warning{}
+	if strings.TrimSpace(req.RevalueAsOf) != "" {
+		revalued, summary, warnings, err := revalueEntries(ctx, s.indexReader, entries, req.RevalueAsOf)
+		if err != nil {
+			return ReportResult{}, err
+		}
+		aggregateEntries = revalued
+		revaluation = &summary
+		revaluationWarnings = warnings
+	}
+
+	aggregate, err := reporting.BuildAggregateByMonth(ctx, aggregateEntries, grouping, categoryLabelResolver, s.workers)
 	if err != nil {
 		return ReportResult{}, err
 	}
@@ -184,56 +343,107 @@ func (s *ReportService) Generate(ctx context.Context, req ReportRequest) (Report
 		PeriodBalance:  aggregate.Net,
 		GeneralBalance: domain.ReportNet{ByCurrency: []domain.CurrencyTotal{}},
 		PaymentMethods: nil,
+		ByPerson:       aggregate.ByPerson,
+		BySource:       aggregate.BySource,
+		ByLocation:     aggregate.ByLocation,
 		CapStatus:      []domain.ReportCapStatus{},
 		CapChanges:     []domain.MonthlyCapChange{},
+		Revaluation:    revaluation,
 	}
 	if period.Scope == domain.ReportScopeMonthly {
 		monthlyBalance := aggregate.Net
 		report.MonthlyBalance = &monthlyBalance
 	}
 
-	lifetimeEntries, err := s.entryReader.List(ctx, domain.EntryListFilter{
-		CategoryID:          req.CategoryID,
-		LabelIDs:            normalizedLabelIDs,
-		LabelMode:           normalizedLabelMode,
-		PaymentMethod:       normalizedPaymentMethod,
-		PaymentCardID:       req.PaymentCardID,
-		PaymentCardNickname: strings.TrimSpace(req.PaymentCardNickname),
-		PaymentCardLookup:   strings.TrimSpace(req.PaymentCardLookup),
+	normalizedTarget := ""
+	if targetCurrency := strings.TrimSpace(req.ConvertTo); targetCurrency != "" {
+		if s.fxConverter == nil {
+			return ReportResult{}, domain.ErrFXRateUnavailable
+		}
+		normalizedTarget, err = domain.NormalizeCurrencyCode(targetCurrency)
+		if err != nil {
+			return ReportResult{}, err
+		}
+	}
+
+	// The sections below read independent data, so they are loaded together.
+	var (
+		lifetimeNet      map[string]int64
+		lifetimeWarnings []domain.Warning
+		cardDebts        []CardDebtCardSummary
+		assets           []domain.Asset
+		convertedSummary domain.ConvertedSummary
+		capStatuses      []domain.ReportCapStatus
+		capChanges       []domain.MonthlyCapChange
+		thresholds       orphanThresholdConfig
+	)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		var err error
+		lifetimeNet, lifetimeWarnings, err = s.lifetimeNetByCurrency(groupCtx, filter, req.RevalueAsOf)
+		return err
 	})
-	if err != nil {
+	if s.cardDebtReader != nil {
+		group.Go(func() error {
+			var err error
+			cardDebts, err = s.cardDebtReader.ShowDebtAll(groupCtx)
+			return err
+		})
+	}
+	if req.IncludeAssets && s.assetLister != nil {
+		group.Go(func() error {
+			var err error
+			assets, err = s.assetLister.List(groupCtx, domain.AssetListFilter{})
+			return err
+		})
+	}
+	if normalizedTarget != "" {
+		group.Go(func() error {
+			var err error
+			convertedSummary, err = s.buildConvertedSummary(groupCtx, aggregateEntries, normalizedTarget)
+			return err
+		})
+	}
+	if s.capReader != nil {
+		group.Go(func() error {
+			var err error
+			capStatuses, capChanges, err = s.buildCapData(groupCtx, period)
+			return err
+		})
+	}
+	group.Go(func() error {
+		var err error
+		thresholds, err = s.orphanThresholds(groupCtx)
+		return err
+	})
+	if err := group.Wait(); err != nil {
 		return ReportResult{}, err
 	}
-	report.GeneralBalance = domain.ReportNet{ByCurrency: netByCurrencyTotals(lifetimeEntries)}
+
+	report.GeneralBalance = domain.ReportNet{ByCurrency: currencyTotalsFromNet(lifetimeNet)}
+	if len(lifetimeWarnings) > 0 {
+		// Every period entry is also a lifetime entry, so the general
+		// balance warning covers the period one.
+		revaluationWarnings = lifetimeWarnings
+	}
 
 	paymentMethods := aggregate.PaymentMethods
 	if s.cardDebtReader != nil {
-		cardDebts, err := s.cardDebtReader.ShowDebtAll(ctx)
-		if err != nil {
-			return ReportResult{}, err
-		}
 		paymentMethods.CreditLiability = toReportCardLiability(cardDebts)
 	}
 	report.PaymentMethods = &paymentMethods
 
-	conversionWarnings := []domain.Warning{}
-	targetCurrency := strings.TrimSpace(req.ConvertTo)
-	if targetCurrency != "" {
-		if s.fxConverter == nil {
-			return ReportResult{}, domain.ErrFXRateUnavailable
-		}
-
-		normalizedTarget, err := domain.NormalizeCurrencyCode(targetCurrency)
-		if err != nil {
-			return ReportResult{}, err
+	if req.IncludeAssets && s.assetLister != nil {
+		if filter.CurrencyCode != "" {
+			assets = filterAssetsByCurrency(assets, filter.CurrencyCode)
 		}
+		report.Assets = &domain.ReportAssets{Items: assets, Totals: domain.TotalAssets(assets)}
+	}
 
-		convertedSummary, usedEstimate, err := s.buildConvertedSummary(ctx, entries, normalizedTarget)
-		if err != nil {
-			return ReportResult{}, err
-		}
+	conversionWarnings := []domain.Warning{}
+	if normalizedTarget != "" {
 		report.Converted = &convertedSummary
-		if usedEstimate {
+		if convertedSummary.UsedEstimateRate {
 			conversionWarnings = append(conversionWarnings, domain.Warning{
 				Code:    domain.WarningCodeFXEstimateUsed,
 				Message: domain.FXEstimateWarningMessage,
@@ -242,53 +452,85 @@ func (s *ReportService) Generate(ctx context.Context, req ReportRequest) (Report
 				},
 			})
 		}
-	}
-
-	if s.capReader != nil {
-		statuses, changes, err := s.buildCapData(ctx, period)
-		if err != nil {
-			return ReportResult{}, err
+		if convertedSummary.UsedStaleRate {
+			conversionWarnings = append(conversionWarnings, domain.Warning{
+				Code:    domain.WarningCodeFXRateStale,
+				Message: domain.FXRateStaleWarningMessage,
+				Details: map[string]any{
+					"target_currency": normalizedTarget,
+				},
+			})
 		}
-		report.CapStatus = statuses
-		report.CapChanges = changes
 	}
 
-	orphanCountThreshold := domain.DefaultOrphanCountThreshold
-	orphanSpendingThresholdBPS := domain.DefaultOrphanSpendingThresholdBPS
-	if s.settingsReader != nil {
-		settings, err := s.settingsReader.Get(ctx)
-		if err == nil {
-			if settings.OrphanCountThreshold > 0 {
-				orphanCountThreshold = int(settings.OrphanCountThreshold)
-			}
-			if settings.OrphanSpendingThresholdBPS > 0 {
-				orphanSpendingThresholdBPS = int(settings.OrphanSpendingThresholdBPS)
-			}
-		} else if !errors.Is(err, domain.ErrSettingsNotFound) {
-			return ReportResult{}, err
+	if s.capReader != nil {
+		if filter.CurrencyCode != "" {
+			capStatuses = filterCapStatusByCurrency(capStatuses, filter.CurrencyCode)
+			capChanges = filterCapChangesByCurrency(capChanges, filter.CurrencyCode)
 		}
+		report.CapStatus = capStatuses
+		report.CapChanges = capChanges
 	}
 
-	warnings, err := s.buildOrphanWarnings(entries, period, report.CapStatus, orphanCountThreshold, orphanSpendingThresholdBPS)
+	warnings, err := s.buildOrphanWarnings(entries, period, report.CapStatus, thresholds)
 	if err != nil {
 		return ReportResult{}, err
 	}
 	warnings = append(warnings, conversionWarnings...)
+	warnings = append(warnings, revaluationWarnings...)
 
 	return ReportResult{Report: report, Warnings: warnings}, nil
 }
 
-func netByCurrencyTotals(entries []domain.Entry) []domain.CurrencyTotal {
+func filterAssetsByCurrency(assets []domain.Asset, currencyCode string) []domain.Asset {
+	filtered := make([]domain.Asset, 0, len(assets))
+	for _, asset := range assets {
+		if asset.CurrencyCode == currencyCode {
+			filtered = append(filtered, asset)
+		}
+	}
+	return filtered
+}
+
+// lifetimeNetByCurrency sums the net per currency of every entry filter
+// matches, from month totals when the filter allows it. With revalueAsOf the
+// entries are restated in as-of-date terms first.
+func (s *ReportService) lifetimeNetByCurrency(ctx context.Context, filter domain.EntryListFilter, revalueAsOf string) (map[string]int64, []domain.Warning, error) {
+	revalue := strings.TrimSpace(revalueAsOf) != ""
+	if s.monthTotals != nil && !revalue {
+		if totalsFilter, ok := domain.MonthTotalsFilterFor(filter); ok {
+			monthTotals, err := s.monthTotals.MonthTotals(ctx, totalsFilter)
+			if err != nil {
+				return nil, nil, err
+			}
+			return domain.NetByCurrencyFromMonthTotals(monthTotals), nil, nil
+		}
+	}
+
+	entries, err := s.entryReader.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []domain.Warning
+	if revalue {
+		entries, _, warnings, err = revalueEntries(ctx, s.indexReader, entries, revalueAsOf)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	totals := map[string]int64{}
 	for _, entry := range entries {
 		switch entry.Type {
 		case domain.EntryTypeIncome:
 			totals[entry.CurrencyCode] += entry.AmountMinor
 		case domain.EntryTypeExpense:
-			totals[entry.CurrencyCode] -= entry.AmountMinor
+			totals[entry.CurrencyCode] -= entry.EffectiveAmountMinor()
 		}
 	}
+	return totals, warnings, nil
+}
 
+func currencyTotalsFromNet(totals map[string]int64) []domain.CurrencyTotal {
 	currencies := make([]string, 0, len(totals))
 	for code := range totals {
 		currencies = append(currencies, code)
@@ -333,6 +575,194 @@ func toReportCardLiability(rows []CardDebtCardSummary) []domain.ReportCardLiabil
 	return out
 }
 
+type CurrencyMixResult struct {
+	Report   domain.CurrencyMixReport
+	Warnings []domain.Warning
+}
+
+// CurrencyMix counts a month's entries per currency and warns about
+// currencies that appear on a single entry across the whole ledger, which
+// usually means a mistyped code such as "UDS".
+// Flows builds the Sankey flow graph for req's period and filters. Grouping,
+// conversion and revaluation do not apply.
+func (s *ReportService) Flows(ctx context.Context, req ReportRequest) (domain.FlowGraph, error) {
+	period, err := domain.BuildReportPeriod(req.Period)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+
+	filter, err := reportEntryFilter(req)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+	filter.DateFromUTC = period.FromUTC
+	filter.DateToUTC = period.ToUTC
+	entries, err := s.entryReader.List(ctx, filter)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+	reporting.SortEntriesDeterministic(entries)
+
+	categoryLabelResolver, err := s.buildCategoryLabelResolver(ctx, entries)
+	if err != nil {
+		return domain.FlowGraph{}, err
+	}
+
+	graph := reporting.BuildFlowGraph(entries, categoryLabelResolver)
+	graph.Period = period
+	return graph, nil
+}
+
+func filterCapStatusByCurrency(statuses []domain.ReportCapStatus, currencyCode string) []domain.ReportCapStatus {
+	filtered := make([]domain.ReportCapStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.CurrencyCode == currencyCode {
+			filtered = append(filtered, status)
+		}
+	}
+	return filtered
+}
+
+func filterCapChangesByCurrency(changes []domain.MonthlyCapChange, currencyCode string) []domain.MonthlyCapChange {
+	filtered := make([]domain.MonthlyCapChange, 0, len(changes))
+	for _, change := range changes {
+		if change.CurrencyCode == currencyCode {
+			filtered = append(filtered, change)
+		}
+	}
+	return filtered
+}
+
+// reportEntryFilter validates req's entry filters and returns them without
+// date bounds; callers add the period they need.
+func reportEntryFilter(req ReportRequest) (domain.EntryListFilter, error) {
+	if err := domain.ValidateOptionalCategoryID(req.CategoryID); err != nil {
+		return domain.EntryListFilter{}, err
+	}
+
+	normalizedLabelIDs, err := domain.NormalizeLabelIDs(req.LabelIDs)
+	if err != nil {
+		return domain.EntryListFilter{}, err
+	}
+
+	normalizedLabelMode, err := domain.NormalizeLabelMode(req.LabelMode)
+	if err != nil {
+		return domain.EntryListFilter{}, err
+	}
+	normalizedPaymentMethod, err := domain.NormalizePaymentMethodFilter(req.PaymentMethod)
+	if err != nil {
+		return domain.EntryListFilter{}, err
+	}
+	if err := domain.ValidateCardSelector(req.PaymentCardID, req.PaymentCardNickname, req.PaymentCardLookup); err != nil {
+		return domain.EntryListFilter{}, err
+	}
+	if normalizedPaymentMethod == domain.PaymentMethodCash && domain.HasCardSelector(req.PaymentCardID, req.PaymentCardNickname, req.PaymentCardLookup) {
+		return domain.EntryListFilter{}, domain.ErrCardNotAllowed
+	}
+	currencyCode := ""
+	if strings.TrimSpace(req.CurrencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(req.CurrencyCode)
+		if err != nil {
+			return domain.EntryListFilter{}, err
+		}
+	}
+	if err := domain.ValidateAmountRange(req.AmountMinMinor, req.AmountMaxMinor); err != nil {
+		return domain.EntryListFilter{}, err
+	}
+
+	return domain.EntryListFilter{
+		CategoryID:          req.CategoryID,
+		LabelIDs:            normalizedLabelIDs,
+		LabelMode:           normalizedLabelMode,
+		PaymentMethod:       normalizedPaymentMethod,
+		PaymentCardID:       req.PaymentCardID,
+		PaymentCardNickname: strings.TrimSpace(req.PaymentCardNickname),
+		PaymentCardLookup:   strings.TrimSpace(req.PaymentCardLookup),
+		Payee:               domain.NormalizePayee(req.Payee),
+		RecordedBy:          domain.NormalizeRecordedBy(req.RecordedBy),
+		IncomeSource:        domain.NormalizeIncomeSource(req.IncomeSource),
+		LocationContains:    strings.TrimSpace(req.LocationContains),
+		NoteContains:        strings.TrimSpace(req.NoteContains),
+		CurrencyCode:        currencyCode,
+		AmountMinMinor:      req.AmountMinMinor,
+		AmountMaxMinor:      req.AmountMaxMinor,
+	}, nil
+}
+
+func (s *ReportService) CurrencyMix(ctx context.Context, monthKey string) (CurrencyMixResult, error) {
+	period, err := domain.BuildReportPeriod(domain.ReportPeriodInput{
+		Scope:    domain.ReportScopeMonthly,
+		MonthKey: monthKey,
+	})
+	if err != nil {
+		return CurrencyMixResult{}, err
+	}
+
+	allEntries, err := s.entryReader.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		return CurrencyMixResult{}, err
+	}
+
+	lifetimeCounts := map[string]int64{}
+	for _, entry := range allEntries {
+		lifetimeCounts[entry.CurrencyCode]++
+	}
+
+	rowsByCurrency := map[string]*domain.CurrencyMixRow{}
+	entryIDsByCurrency := map[string]int64{}
+	report := domain.CurrencyMixReport{Period: period, Currencies: []domain.CurrencyMixRow{}}
+	for _, entry := range allEntries {
+		if entry.TransactionDateUTC < period.FromUTC || entry.TransactionDateUTC > period.ToUTC {
+			continue
+		}
+		row, ok := rowsByCurrency[entry.CurrencyCode]
+		if !ok {
+			row = &domain.CurrencyMixRow{
+				CurrencyCode:       entry.CurrencyCode,
+				LifetimeEntryCount: lifetimeCounts[entry.CurrencyCode],
+				SeenOnce:           lifetimeCounts[entry.CurrencyCode] == 1,
+			}
+			rowsByCurrency[entry.CurrencyCode] = row
+		}
+		row.EntryCount++
+		if entry.Type == domain.EntryTypeIncome {
+			row.IncomeCount++
+		} else {
+			row.ExpenseCount++
+		}
+		entryIDsByCurrency[entry.CurrencyCode] = entry.ID
+		report.EntryCount++
+	}
+
+	for _, row := range rowsByCurrency {
+		report.Currencies = append(report.Currencies, *row)
+	}
+	sort.Slice(report.Currencies, func(i, j int) bool {
+		if report.Currencies[i].EntryCount != report.Currencies[j].EntryCount {
+			return report.Currencies[i].EntryCount > report.Currencies[j].EntryCount
+		}
+		return report.Currencies[i].CurrencyCode < report.Currencies[j].CurrencyCode
+	})
+
+	warnings := []domain.Warning{}
+	for _, row := range report.Currencies {
+		if !row.SeenOnce {
+			continue
+		}
+		warnings = append(warnings, domain.Warning{
+			Code:    domain.WarningCodeCurrencySeenOnce,
+			Message: domain.CurrencySeenOnceWarningMessage,
+			Details: domain.CurrencySeenOnceWarningDetails{
+				MonthKey:     period.MonthKey,
+				CurrencyCode: row.CurrencyCode,
+				EntryID:      entryIDsByCurrency[row.CurrencyCode],
+			},
+		})
+	}
+
+	return CurrencyMixResult{Report: report, Warnings: warnings}, nil
+}
+
 func (s *ReportService) buildCategoryLabelResolver(ctx context.Context, entries []domain.Entry) (reporting.CategoryLabelResolver, error) {
 	categoryIDs := map[int64]struct{}{}
 	for _, entry := range entries {
@@ -393,20 +823,44 @@ func mapKeysSorted(values map[int64]struct{}) []int64 {
 	return keys
 }
 
-func (s *ReportService) buildConvertedSummary(ctx context.Context, entries []domain.Entry, targetCurrency string) (domain.ConvertedSummary, bool, error) {
+// buildConvertedSummary converts entries into targetCurrency. Entries are
+// converted in batches of one currency and day on up to s.workers goroutines,
+// so each batch looks a rate up once and caches it for the rest.
+func (s *ReportService) buildConvertedSummary(ctx context.Context, entries []domain.Entry, targetCurrency string) (domain.ConvertedSummary, error) {
 	converted := domain.ConvertedSummary{
 		TargetCurrency: targetCurrency,
 	}
-	usedEstimate := false
 
-	for _, entry := range entries {
-		amount, err := s.fxConverter.Convert(ctx, entry.AmountMinor, entry.CurrencyCode, targetCurrency, entry.TransactionDateUTC)
-		if err != nil {
-			return domain.ConvertedSummary{}, false, err
-		}
+	amounts := make([]domain.ConvertedAmount, len(entries))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.workers)
+	for _, batch := range fxConversionBatches(entries) {
+		group.Go(func() error {
+			for _, i := range batch {
+				if err := groupCtx.Err(); err != nil {
+					return err
+				}
+				entry := entries[i]
+				amount, err := s.fxConverter.Convert(groupCtx, entry.AmountMinor, entry.CurrencyCode, targetCurrency, entry.TransactionDateUTC)
+				if err != nil {
+					return err
+				}
+				amounts[i] = amount
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return domain.ConvertedSummary{}, err
+	}
 
+	for i, entry := range entries {
+		amount := amounts[i]
 		if amount.Snapshot.IsEstimate {
-			usedEstimate = true
+			converted.UsedEstimateRate = true
+		}
+		if amount.Stale {
+			converted.UsedStaleRate = true
 		}
 
 		switch entry.Type {
@@ -414,63 +868,204 @@ func (s *ReportService) buildConvertedSummary(ctx context.Context, entries []dom
 			converted.EarningsMinor += amount.AmountMinor
 			converted.NetMinor += amount.AmountMinor
 		case domain.EntryTypeExpense:
-			converted.SpendingMinor += amount.AmountMinor
-			converted.NetMinor -= amount.AmountMinor
+			spendMinor := amount.AmountMinor
+			if entry.IsRefund() {
+				spendMinor = -spendMinor
+			}
+			converted.SpendingMinor += spendMinor
+			converted.NetMinor -= spendMinor
 		}
 	}
 
-	converted.UsedEstimateRate = usedEstimate
-	return converted, usedEstimate, nil
+	return converted, nil
 }
 
-func (s *ReportService) buildCapData(ctx context.Context, period domain.ReportPeriod) ([]domain.ReportCapStatus, []domain.MonthlyCapChange, error) {
-	monthKeys, err := domain.MonthKeysInPeriod(period.FromUTC, period.ToUTC)
-	if err != nil {
-		return nil, nil, err
+// fxConversionBatches groups entry indexes by currency and UTC transaction
+// day, in order of first appearance.
+func fxConversionBatches(entries []domain.Entry) [][]int {
+	type batchKey struct {
+		currencyCode string
+		day          string
 	}
 
-	statuses := make([]domain.ReportCapStatus, 0, len(monthKeys))
-	allChanges := []domain.MonthlyCapChange{}
+	positions := map[batchKey]int{}
+	batches := [][]int{}
+	for i, entry := range entries {
+		day := entry.TransactionDateUTC
+		if len(day) > 10 {
+			day = day[:10]
+		}
+		key := batchKey{currencyCode: entry.CurrencyCode, day: day}
+		position, ok := positions[key]
+		if !ok {
+			position = len(batches)
+			positions[key] = position
+			batches = append(batches, nil)
+		}
+		batches[position] = append(batches[position], i)
+	}
+	return batches
+}
+
+// revalueEntries restates each entry amount in as-of-date terms using the
+// imported index of its currency: amount * index(as_of) / index(entry date).
+// Entries without an index point on or before both dates stay nominal and are
+// reported in a single INFLATION_INDEX_UNAVAILABLE warning.
+func revalueEntries(ctx context.Context, indexReader ReportInflationIndexReader, entries []domain.Entry, asOfRaw string) ([]domain.Entry, domain.ReportRevaluation, []domain.Warning, error) {
+	if indexReader == nil {
+		return nil, domain.ReportRevaluation{}, nil, domain.ErrInflationIndexUnavailable
+	}
+
+	asOfDate, err := domain.NormalizeInflationIndexDate(asOfRaw)
+	if err != nil {
+		return nil, domain.ReportRevaluation{}, nil, err
+	}
 
-	for _, monthKey := range monthKeys {
-		changes, err := s.capReader.History(ctx, monthKey)
+	type indexKey struct {
+		CurrencyCode string
+		Date         string
+	}
+	cache := map[indexKey]*domain.InflationIndexPoint{}
+	lookup := func(currencyCode, date string) (*domain.InflationIndexPoint, error) {
+		key := indexKey{CurrencyCode: currencyCode, Date: date}
+		if point, ok := cache[key]; ok {
+			return point, nil
+		}
+		point, err := indexReader.GetOnOrBefore(ctx, currencyCode, date)
 		if err != nil {
-			return nil, nil, err
+			if errors.Is(err, domain.ErrInflationIndexUnavailable) {
+				cache[key] = nil
+				return nil, nil
+			}
+			return nil, err
+		}
+		cache[key] = &point
+		return &point, nil
+	}
+
+	summaries := map[string]*domain.ReportRevaluationCurrency{}
+	nominalCurrencies := map[string]struct{}{}
+	nominalCount := 0
+	revalued := make([]domain.Entry, 0, len(entries))
+	for _, entry := range entries {
+		summary, ok := summaries[entry.CurrencyCode]
+		if !ok {
+			summary = &domain.ReportRevaluationCurrency{CurrencyCode: entry.CurrencyCode}
+			summaries[entry.CurrencyCode] = summary
 		}
-		allChanges = append(allChanges, changes...)
 
-		capValue, err := s.capReader.Show(ctx, monthKey)
+		target, err := lookup(entry.CurrencyCode, asOfDate)
 		if err != nil {
-			if errors.Is(err, domain.ErrCapNotFound) {
-				continue
-			}
-			return nil, nil, err
+			return nil, domain.ReportRevaluation{}, nil, err
+		}
+		entryDate, err := domain.NormalizeInflationIndexDate(entry.TransactionDateUTC)
+		if err != nil {
+			return nil, domain.ReportRevaluation{}, nil, err
+		}
+		source, err := lookup(entry.CurrencyCode, entryDate)
+		if err != nil {
+			return nil, domain.ReportRevaluation{}, nil, err
 		}
 
-		totalSpend, err := s.capReader.ExpenseTotalByMonthAndCurrency(ctx, monthKey, capValue.CurrencyCode)
+		if target == nil || source == nil {
+			summary.NominalEntryCount++
+			nominalCount++
+			nominalCurrencies[entry.CurrencyCode] = struct{}{}
+			revalued = append(revalued, entry)
+			continue
+		}
+
+		amountMinor, err := domain.RevalueMinorByIndex(entry.AmountMinor, source.IndexValue, target.IndexValue)
 		if err != nil {
-			return nil, nil, err
+			return nil, domain.ReportRevaluation{}, nil, err
 		}
+		summary.AsOfIndexDate = target.IndexDate
+		summary.AsOfIndexValue = target.IndexValue
+		summary.RevaluedEntries++
 
-		overspend := totalSpend - capValue.AmountMinor
-		if overspend < 0 {
-			overspend = 0
+		adjusted := entry
+		adjusted.AmountMinor = amountMinor
+		revalued = append(revalued, adjusted)
+	}
+
+	currencies := make([]string, 0, len(summaries))
+	for code := range summaries {
+		currencies = append(currencies, code)
+	}
+	sort.Strings(currencies)
+
+	out := domain.ReportRevaluation{
+		AsOfDate:   asOfDate,
+		Currencies: make([]domain.ReportRevaluationCurrency, 0, len(currencies)),
+	}
+	for _, code := range currencies {
+		out.Currencies = append(out.Currencies, *summaries[code])
+	}
+
+	warnings := []domain.Warning{}
+	if nominalCount > 0 {
+		codes := make([]string, 0, len(nominalCurrencies))
+		for code := range nominalCurrencies {
+			codes = append(codes, code)
 		}
+		sort.Strings(codes)
+		warnings = append(warnings, domain.Warning{
+			Code:    domain.WarningCodeInflationIndexUnavailable,
+			Message: domain.InflationIndexWarningMessage,
+			Details: domain.InflationIndexWarningDetails{
+				AsOfDate:      asOfDate,
+				CurrencyCodes: codes,
+				EntryCount:    nominalCount,
+			},
+		})
+	}
+
+	return revalued, out, warnings, nil
+}
+
+func (s *ReportService) buildCapData(ctx context.Context, period domain.ReportPeriod) ([]domain.ReportCapStatus, []domain.MonthlyCapChange, error) {
+	monthKeys, err := domain.MonthKeysInPeriod(period.FromUTC, period.ToUTC)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		statuses = append(statuses, domain.ReportCapStatus{
-			MonthKey:        monthKey,
-			CurrencyCode:    capValue.CurrencyCode,
-			CapAmountMinor:  capValue.AmountMinor,
-			SpendTotalMinor: totalSpend,
-			OverspendMinor:  overspend,
-			IsExceeded:      overspend > 0,
+	// Each month's caps and spend are looked up on its own goroutine.
+	monthStatuses := make([][]domain.ReportCapStatus, len(monthKeys))
+	monthChanges := make([][]domain.MonthlyCapChange, len(monthKeys))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.workers)
+	for i, monthKey := range monthKeys {
+		group.Go(func() error {
+			statuses, changes, err := s.buildMonthCapData(groupCtx, monthKey)
+			if err != nil {
+				return err
+			}
+			monthStatuses[i] = statuses
+			monthChanges[i] = changes
+			return nil
 		})
 	}
+	if err := group.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	statuses := make([]domain.ReportCapStatus, 0, len(monthKeys))
+	allChanges := []domain.MonthlyCapChange{}
+	for i := range monthKeys {
+		statuses = append(statuses, monthStatuses[i]...)
+		allChanges = append(allChanges, monthChanges[i]...)
+	}
 
-	sort.Slice(statuses, func(i, j int) bool {
+	sort.SliceStable(statuses, func(i, j int) bool {
 		if statuses[i].MonthKey != statuses[j].MonthKey {
 			return statuses[i].MonthKey < statuses[j].MonthKey
 		}
+		if (statuses[i].CategoryID == nil) != (statuses[j].CategoryID == nil) {
+			return statuses[i].CategoryID == nil
+		}
+		if statuses[i].CategoryID != nil && *statuses[i].CategoryID != *statuses[j].CategoryID {
+			return *statuses[i].CategoryID < *statuses[j].CategoryID
+		}
 		return statuses[i].CurrencyCode < statuses[j].CurrencyCode
 	})
 
@@ -487,6 +1082,205 @@ func (s *ReportService) buildCapData(ctx context.Context, period domain.ReportPe
 	return statuses, allChanges, nil
 }
 
+func (s *ReportService) buildMonthCapData(ctx context.Context, monthKey string) ([]domain.ReportCapStatus, []domain.MonthlyCapChange, error) {
+	changes, err := s.capReader.History(ctx, monthKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statuses := []domain.ReportCapStatus{}
+	capValue, err := s.capReader.Show(ctx, monthKey)
+	switch {
+	case err == nil:
+		totalSpend, err := s.capReader.ExpenseTotalByMonthAndCurrency(ctx, monthKey, capValue.CurrencyCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		statuses = append(statuses, newReportCapStatus(capValue, totalSpend))
+	case !errors.Is(err, domain.ErrCapNotFound):
+		return nil, nil, err
+	}
+
+	categoryStatuses, err := s.buildCategoryCapStatuses(ctx, monthKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(statuses, categoryStatuses...), changes, nil
+}
+
+// buildCategoryCapStatuses reports the month's category caps against the
+// spend in each category, when the cap reader supports category caps.
+func (s *ReportService) buildCategoryCapStatuses(ctx context.Context, monthKey string) ([]domain.ReportCapStatus, error) {
+	categoryCapReader, ok := s.capReader.(ReportCategoryCapReader)
+	if !ok {
+		return nil, nil
+	}
+
+	caps, err := categoryCapReader.CategoryCaps(ctx, monthKey)
+	if err != nil || len(caps) == 0 {
+		return nil, err
+	}
+
+	categoryNames := map[int64]string{}
+	if s.categoryReader != nil {
+		categories, err := s.categoryReader.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			categoryNames[category.ID] = strings.TrimSpace(category.Name)
+		}
+	}
+
+	statuses := make([]domain.ReportCapStatus, 0, len(caps))
+	for _, capValue := range caps {
+		totalSpend, err := categoryCapReader.CategoryExpenseTotalByMonthAndCurrency(ctx, monthKey, *capValue.CategoryID, capValue.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		status := newReportCapStatus(capValue, totalSpend)
+		status.CategoryName = categoryNames[*capValue.CategoryID]
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func newReportCapStatus(capValue domain.MonthlyCap, totalSpend int64) domain.ReportCapStatus {
+	overspend := totalSpend - capValue.AmountMinor
+	if overspend < 0 {
+		overspend = 0
+	}
+
+	return domain.ReportCapStatus{
+		MonthKey:        capValue.MonthKey,
+		CategoryID:      capValue.CategoryID,
+		CurrencyCode:    capValue.CurrencyCode,
+		CapAmountMinor:  capValue.AmountMinor,
+		SpendTotalMinor: totalSpend,
+		OverspendMinor:  overspend,
+		IsExceeded:      overspend > 0,
+	}
+}
+
+type OrphanStatusResult struct {
+	Status   domain.OrphanStatus
+	Warnings []domain.Warning
+}
+
+// OrphanStatus measures uncategorized entries in a period against the same
+// thresholds and cap rules that drive report orphan warnings.
+func (s *ReportService) OrphanStatus(ctx context.Context, input domain.ReportPeriodInput) (OrphanStatusResult, error) {
+	period, err := domain.BuildReportPeriod(input)
+	if err != nil {
+		return OrphanStatusResult{}, err
+	}
+
+	entries, err := s.entryReader.List(ctx, domain.EntryListFilter{
+		DateFromUTC: period.FromUTC,
+		DateToUTC:   period.ToUTC,
+	})
+	if err != nil {
+		return OrphanStatusResult{}, err
+	}
+
+	thresholds, err := s.orphanThresholds(ctx)
+	if err != nil {
+		return OrphanStatusResult{}, err
+	}
+
+	capStatus := []domain.ReportCapStatus{}
+	if s.capReader != nil {
+		capStatus, _, err = s.buildCapData(ctx, period)
+		if err != nil {
+			return OrphanStatusResult{}, err
+		}
+	}
+
+	orphanCount, spendByMonthCurrency, err := collectOrphanSpend(entries)
+	if err != nil {
+		return OrphanStatusResult{}, err
+	}
+	warnings, err := s.buildOrphanWarnings(entries, period, capStatus, thresholds)
+	if err != nil {
+		return OrphanStatusResult{}, err
+	}
+
+	exceeded := map[orphanSpendKey]bool{}
+	for _, warning := range warnings {
+		if details, ok := warning.Details.(domain.OrphanSpendingWarningDetails); ok {
+			exceeded[orphanSpendKey{MonthKey: details.MonthKey, CurrencyCode: details.CurrencyCode}] = true
+		}
+	}
+
+	status := domain.OrphanStatus{
+		Period:               period,
+		OrphanCount:          orphanCount,
+		CountThreshold:       thresholds.count,
+		SpendingThresholdBPS: thresholds.spendingBPS,
+		Spending:             []domain.OrphanSpendingStatus{},
+	}
+	for _, key := range sortedOrphanSpendKeys(spendByMonthCurrency) {
+		stats := spendByMonthCurrency[key]
+		status.Spending = append(status.Spending, domain.OrphanSpendingStatus{
+			MonthKey:         key.MonthKey,
+			CurrencyCode:     key.CurrencyCode,
+			OrphanSpendMinor: stats.OrphanSpendMinor,
+			MonthSpendMinor:  stats.MonthSpendMinor,
+			RatioToSpendBP:   ratioBPS(stats.OrphanSpendMinor, stats.MonthSpendMinor),
+			ThresholdMode:    thresholds.spending(key.CurrencyCode).Mode,
+			Exceeded:         exceeded[key],
+		})
+	}
+
+	return OrphanStatusResult{Status: status, Warnings: warnings}, nil
+}
+
+type orphanThresholdConfig struct {
+	count       int
+	spendingBPS int
+	rules       []domain.OrphanSpendingThreshold
+}
+
+// spending resolves the orphan-spending rule for one currency.
+func (c orphanThresholdConfig) spending(currencyCode string) domain.OrphanSpendingThreshold {
+	return domain.ResolveOrphanSpendingThreshold(c.rules, currencyCode, c.spendingBPS)
+}
+
+// orphanThresholds returns the saved orphan count and spending thresholds,
+// falling back to the defaults before setup has run. Per-currency spending
+// rules are loaded when the settings reader can list them.
+func (s *ReportService) orphanThresholds(ctx context.Context) (orphanThresholdConfig, error) {
+	config := orphanThresholdConfig{
+		count:       domain.DefaultOrphanCountThreshold,
+		spendingBPS: domain.DefaultOrphanSpendingThresholdBPS,
+	}
+	if s.settingsReader == nil {
+		return config, nil
+	}
+
+	settings, err := s.settingsReader.Get(ctx)
+	if err != nil && !errors.Is(err, domain.ErrSettingsNotFound) {
+		return orphanThresholdConfig{}, err
+	}
+	if err == nil {
+		if settings.OrphanCountThreshold > 0 {
+			config.count = int(settings.OrphanCountThreshold)
+		}
+		if settings.OrphanSpendingThresholdBPS > 0 {
+			config.spendingBPS = int(settings.OrphanSpendingThresholdBPS)
+		}
+	}
+
+	if lister, ok := s.settingsReader.(ReportOrphanThresholdLister); ok {
+		rules, err := lister.ListOrphanSpendingThresholds(ctx)
+		if err != nil {
+			return orphanThresholdConfig{}, err
+		}
+		config.rules = rules
+	}
+	return config, nil
+}
+
 type orphanSpendKey struct {
 	MonthKey     string
 	CurrencyCode string
@@ -497,9 +1291,9 @@ type orphanSpendStats struct {
 	MonthSpendMinor  int64
 }
 
-func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domain.ReportPeriod, capStatus []domain.ReportCapStatus, countThreshold int, spendingThresholdBPS int) ([]domain.Warning, error) {
-	warnings := make([]domain.Warning, 0)
-
+// collectOrphanSpend counts orphan entries and sums orphan and total expense
+// spend per month and currency.
+func collectOrphanSpend(entries []domain.Entry) (int, map[orphanSpendKey]orphanSpendStats, error) {
 	orphanCount := 0
 	orphanSpendByMonthCurrency := map[orphanSpendKey]orphanSpendStats{}
 	for _, entry := range entries {
@@ -514,12 +1308,12 @@ func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domai
 
 		monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 
 		key := orphanSpendKey{MonthKey: monthKey, CurrencyCode: entry.CurrencyCode}
 		stats := orphanSpendByMonthCurrency[key]
-		stats.OrphanSpendMinor += entry.AmountMinor
+		stats.OrphanSpendMinor += entry.EffectiveAmountMinor()
 		orphanSpendByMonthCurrency[key] = stats
 	}
 
@@ -529,15 +1323,40 @@ func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domai
 		}
 		monthKey, err := domain.MonthKeyFromDateTimeUTC(entry.TransactionDateUTC)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 		key := orphanSpendKey{MonthKey: monthKey, CurrencyCode: entry.CurrencyCode}
 		stats := orphanSpendByMonthCurrency[key]
-		stats.MonthSpendMinor += entry.AmountMinor
+		stats.MonthSpendMinor += entry.EffectiveAmountMinor()
 		orphanSpendByMonthCurrency[key] = stats
 	}
 
-	if orphanCount > countThreshold {
+	return orphanCount, orphanSpendByMonthCurrency, nil
+}
+
+func sortedOrphanSpendKeys(spend map[orphanSpendKey]orphanSpendStats) []orphanSpendKey {
+	keys := make([]orphanSpendKey, 0, len(spend))
+	for key := range spend {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].MonthKey != keys[j].MonthKey {
+			return keys[i].MonthKey < keys[j].MonthKey
+		}
+		return keys[i].CurrencyCode < keys[j].CurrencyCode
+	})
+	return keys
+}
+
+func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domain.ReportPeriod, capStatus []domain.ReportCapStatus, thresholds orphanThresholdConfig) ([]domain.Warning, error) {
+	warnings := make([]domain.Warning, 0)
+
+	orphanCount, orphanSpendByMonthCurrency, err := collectOrphanSpend(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if orphanCount > thresholds.count {
 		warnings = append(warnings, domain.Warning{
 			Code:    domain.WarningCodeOrphanCountExceeded,
 			Message: domain.OrphanCountWarningMessage,
@@ -545,53 +1364,51 @@ func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domai
 				PeriodFromUTC: period.FromUTC,
 				PeriodToUTC:   period.ToUTC,
 				OrphanCount:   orphanCount,
-				Threshold:     countThreshold,
+				Threshold:     thresholds.count,
 			},
 		})
 	}
 
 	caps := make(map[orphanSpendKey]domain.ReportCapStatus, len(capStatus))
 	for _, capItem := range capStatus {
+		if capItem.CategoryID != nil {
+			continue
+		}
 		caps[orphanSpendKey{MonthKey: capItem.MonthKey, CurrencyCode: capItem.CurrencyCode}] = capItem
 	}
 
-	keys := make([]orphanSpendKey, 0, len(orphanSpendByMonthCurrency))
-	for key := range orphanSpendByMonthCurrency {
-		keys = append(keys, key)
-	}
-	sort.Slice(keys, func(i, j int) bool {
-		if keys[i].MonthKey != keys[j].MonthKey {
-			return keys[i].MonthKey < keys[j].MonthKey
-		}
-		return keys[i].CurrencyCode < keys[j].CurrencyCode
-	})
-
-	for _, key := range keys {
+	for _, key := range sortedOrphanSpendKeys(orphanSpendByMonthCurrency) {
 		stats := orphanSpendByMonthCurrency[key]
 		if stats.OrphanSpendMinor == 0 {
 			continue
 		}
 
-		exceededByMonthSpend := stats.MonthSpendMinor > 0 && (stats.OrphanSpendMinor*10000 > int64(spendingThresholdBPS)*stats.MonthSpendMinor)
-		exceededByCap := false
+		rule := thresholds.spending(key.CurrencyCode)
 		capAmountMinor := int64(0)
 		if capItem, ok := caps[key]; ok {
 			capAmountMinor = capItem.CapAmountMinor
-			if capAmountMinor > 0 {
-				exceededByCap = stats.OrphanSpendMinor*10000 > int64(spendingThresholdBPS)*capAmountMinor
-			}
-		}
-
-		if !exceededByMonthSpend && !exceededByCap {
-			continue
 		}
 
 		triggeredBy := []string{}
-		if exceededByMonthSpend {
-			triggeredBy = append(triggeredBy, "MONTH_SPEND")
+		thresholdBPS := 0
+		switch rule.Mode {
+		case domain.OrphanSpendingThresholdModeOff:
+			continue
+		case domain.OrphanSpendingThresholdModeAbsolute:
+			if stats.OrphanSpendMinor > *rule.AmountMinor {
+				triggeredBy = append(triggeredBy, "ABSOLUTE_AMOUNT")
+			}
+		default:
+			thresholdBPS = int(*rule.PercentBPS)
+			if stats.MonthSpendMinor > 0 && stats.OrphanSpendMinor*10000 > int64(thresholdBPS)*stats.MonthSpendMinor {
+				triggeredBy = append(triggeredBy, "MONTH_SPEND")
+			}
+			if capAmountMinor > 0 && stats.OrphanSpendMinor*10000 > int64(thresholdBPS)*capAmountMinor {
+				triggeredBy = append(triggeredBy, "MONTH_CAP")
+			}
 		}
-		if exceededByCap {
-			triggeredBy = append(triggeredBy, "MONTH_CAP")
+		if len(triggeredBy) == 0 {
+			continue
 		}
 
 		var capAmountPtr *int64
@@ -604,15 +1421,17 @@ func (s *ReportService) buildOrphanWarnings(entries []domain.Entry, period domai
 			Code:    domain.WarningCodeOrphanSpendingExceeded,
 			Message: domain.OrphanSpendingWarningMessage,
 			Details: domain.OrphanSpendingWarningDetails{
-				MonthKey:       key.MonthKey,
-				CurrencyCode:   key.CurrencyCode,
-				OrphanSpend:    stats.OrphanSpendMinor,
-				MonthSpend:     stats.MonthSpendMinor,
-				CapAmount:      capAmountPtr,
-				ThresholdBPS:   spendingThresholdBPS,
-				TriggeredBy:    triggeredBy,
-				RatioToSpendBP: ratioBPS(stats.OrphanSpendMinor, stats.MonthSpendMinor),
-				RatioToCapBP:   ratioBPS(stats.OrphanSpendMinor, capAmountMinor),
+				MonthKey:        key.MonthKey,
+				CurrencyCode:    key.CurrencyCode,
+				OrphanSpend:     stats.OrphanSpendMinor,
+				MonthSpend:      stats.MonthSpendMinor,
+				CapAmount:       capAmountPtr,
+				ThresholdBPS:    thresholdBPS,
+				ThresholdMode:   rule.Mode,
+				ThresholdAmount: rule.AmountMinor,
+				TriggeredBy:     triggeredBy,
+				RatioToSpendBP:  ratioBPS(stats.OrphanSpendMinor, stats.MonthSpendMinor),
+				RatioToCapBP:    ratioBPS(stats.OrphanSpendMinor, capAmountMinor),
 			},
 		})
 	}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"boring-budget/internal/domain"
 )
@@ -36,6 +38,24 @@ type reportCategoryReaderStub struct {
 	listByIDsFn func(ctx context.Context, ids []int64) ([]domain.Category, error)
 }
 
+type reportInflationIndexReaderStub struct {
+	points map[string][]domain.InflationIndexPoint
+}
+
+func (s *reportInflationIndexReaderStub) GetOnOrBefore(ctx context.Context, currencyCode, indexDate string) (domain.InflationIndexPoint, error) {
+	var found *domain.InflationIndexPoint
+	for i := range s.points[currencyCode] {
+		point := s.points[currencyCode][i]
+		if point.IndexDate <= indexDate {
+			found = &point
+		}
+	}
+	if found == nil {
+		return domain.InflationIndexPoint{}, domain.ErrInflationIndexUnavailable
+	}
+	return *found, nil
+}
+
 func (s *reportFXConverterStub) Convert(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
 	return s.convertFn(ctx, amountMinor, fromCurrency, toCurrency, transactionDateUTC)
 }
@@ -444,6 +464,327 @@ func TestReportServiceGenerateSupportsConvertedSummary(t *testing.T) {
 	}
 }
 
+func TestReportServiceGenerateConcurrentMatchesSerial(t *testing.T) {
+	t.Parallel()
+
+	catOne := int64(1)
+	entries := []domain.Entry{}
+	for i := int64(0); i < 90; i++ {
+		date := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, int(i))
+		entry := domain.Entry{
+			ID:                 i + 1,
+			Type:               domain.EntryTypeExpense,
+			AmountMinor:        100 + i,
+			CurrencyCode:       []string{"USD", "EUR", "ARS"}[i%3],
+			TransactionDateUTC: date.Format(time.RFC3339),
+			RecordedBy:         []string{"Ana", "ana", "Ben"}[i%3],
+			Location:           []string{"Lisbon", "lisbon"}[i%2],
+		}
+		if i%4 == 0 {
+			entry.Type = domain.EntryTypeIncome
+			entry.IncomeSource = []string{"Salary", "salary"}[i%8/4]
+		}
+		if i%5 == 0 {
+			entry.CategoryID = &catOne
+		}
+		entries = append(entries, entry)
+	}
+
+	newService := func(workers int) *ReportService {
+		t.Helper()
+		svc, err := NewReportService(
+			&reportEntryReaderStub{
+				listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+					return append([]domain.Entry(nil), entries...), nil
+				},
+			},
+			&reportCapReaderStub{
+				showFn: func(ctx context.Context, monthKey string) (domain.MonthlyCap, error) {
+					return domain.MonthlyCap{MonthKey: monthKey, AmountMinor: 2000, CurrencyCode: "USD"}, nil
+				},
+				historyFn: func(ctx context.Context, monthKey string) ([]domain.MonthlyCapChange, error) {
+					return []domain.MonthlyCapChange{{ID: 1, MonthKey: monthKey, NewAmountMinor: 2000, CurrencyCode: "USD"}}, nil
+				},
+				expenseTotalFn: func(ctx context.Context, monthKey, currencyCode string) (int64, error) {
+					return int64(len(monthKey)) * 100, nil
+				},
+			},
+			WithReportWorkers(workers),
+			WithReportFXConverter(&reportFXConverterStub{
+				convertFn: func(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
+					return domain.ConvertedAmount{AmountMinor: amountMinor * 2, Snapshot: domain.FXRateSnapshot{Rate: "2"}}, nil
+				},
+			}),
+		)
+		if err != nil {
+			t.Fatalf("new report service: %v", err)
+		}
+		return svc
+	}
+
+	req := ReportRequest{
+		Period:    domain.ReportPeriodInput{Scope: domain.ReportScopeQuarterly, MonthKey: "2026-01"},
+		Grouping:  domain.ReportGroupingMonth,
+		ConvertTo: "USD",
+	}
+	serial, err := newService(1).Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("generate serial report: %v", err)
+	}
+	concurrent, err := newService(4).Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("generate concurrent report: %v", err)
+	}
+
+	if !reflect.DeepEqual(serial, concurrent) {
+		t.Fatalf("concurrent report differs from serial:\nserial=%+v\nconcurrent=%+v", serial, concurrent)
+	}
+	if len(concurrent.Report.CapStatus) != 3 || len(concurrent.Report.CapChanges) != 3 {
+		t.Fatalf("expected one cap status and change per month, got %+v / %+v", concurrent.Report.CapStatus, concurrent.Report.CapChanges)
+	}
+}
+
+func TestReportServiceGenerateStopsOnConversionError(t *testing.T) {
+	t.Parallel()
+
+	entries := []domain.Entry{}
+	for i := int64(0); i < 20; i++ {
+		entries = append(entries, domain.Entry{
+			ID:                 i + 1,
+			Type:               domain.EntryTypeExpense,
+			AmountMinor:        100,
+			CurrencyCode:       "EUR",
+			TransactionDateUTC: time.Date(2026, time.February, 1+int(i), 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		})
+	}
+
+	svc, err := NewReportService(
+		&reportEntryReaderStub{
+			listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+				return append([]domain.Entry(nil), entries...), nil
+			},
+		},
+		nil,
+		WithReportFXConverter(&reportFXConverterStub{
+			convertFn: func(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
+				if transactionDateUTC == "2026-02-03T00:00:00Z" {
+					return domain.ConvertedAmount{}, domain.ErrFXRateUnavailable
+				}
+				return domain.ConvertedAmount{AmountMinor: amountMinor}, nil
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("new report service: %v", err)
+	}
+
+	_, err = svc.Generate(context.Background(), ReportRequest{
+		Period:    domain.ReportPeriodInput{Scope: domain.ReportScopeMonthly, MonthKey: "2026-02"},
+		ConvertTo: "USD",
+	})
+	if !errors.Is(err, domain.ErrFXRateUnavailable) {
+		t.Fatalf("expected ErrFXRateUnavailable, got %v", err)
+	}
+}
+
+type reportCacheStub struct {
+	version int64
+	entries map[string]domain.CachedReport
+	puts    int
+}
+
+func (s *reportCacheStub) DataVersion(ctx context.Context) (int64, error) {
+	return s.version, nil
+}
+
+func (s *reportCacheStub) Get(ctx context.Context, key string, dataVersion int64) (domain.CachedReport, error) {
+	cached, ok := s.entries[fmt.Sprintf("%s@%d", key, dataVersion)]
+	if !ok {
+		return domain.CachedReport{}, domain.ErrReportCacheMiss
+	}
+	return cached, nil
+}
+
+func (s *reportCacheStub) Put(ctx context.Context, key string, dataVersion int64, report domain.CachedReport) error {
+	s.puts++
+	s.entries[fmt.Sprintf("%s@%d", key, dataVersion)] = report
+	return nil
+}
+
+func TestReportServiceGenerateServesCacheUntilDataVersionChanges(t *testing.T) {
+	t.Parallel()
+
+	listCalls := 0
+	cache := &reportCacheStub{version: 7, entries: map[string]domain.CachedReport{}}
+	svc, err := NewReportService(
+		&reportEntryReaderStub{
+			listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+				listCalls++
+				return []domain.Entry{
+					{ID: 1, Type: domain.EntryTypeExpense, AmountMinor: 500, CurrencyCode: "USD", TransactionDateUTC: "2026-02-03T00:00:00Z"},
+				}, nil
+			},
+		},
+		nil,
+		WithReportWorkers(1),
+		WithReportCache(cache),
+	)
+	if err != nil {
+		t.Fatalf("new report service: %v", err)
+	}
+
+	req := ReportRequest{Period: domain.ReportPeriodInput{Scope: domain.ReportScopeMonthly, MonthKey: "2026-02"}}
+	first, err := svc.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	callsAfterFirst := listCalls
+
+	second, err := svc.Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("generate cached: %v", err)
+	}
+	if listCalls != callsAfterFirst || cache.puts != 1 {
+		t.Fatalf("expected the second report to come from cache, list calls %d -> %d, puts %d", callsAfterFirst, listCalls, cache.puts)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("cached report differs:\nfirst:  %+v\nsecond: %+v", first, second)
+	}
+
+	otherFilter := req
+	otherFilter.Payee = "Market"
+	if _, err := svc.Generate(context.Background(), otherFilter); err != nil {
+		t.Fatalf("generate with filter: %v", err)
+	}
+	if listCalls == callsAfterFirst {
+		t.Fatalf("expected a different filter to miss the cache")
+	}
+
+	cache.version++
+	callsBeforeWrite := listCalls
+	if _, err := svc.Generate(context.Background(), req); err != nil {
+		t.Fatalf("generate after write: %v", err)
+	}
+	if listCalls == callsBeforeWrite {
+		t.Fatalf("expected a new data version to rebuild the report")
+	}
+}
+
+func TestReportServiceGenerateDoesNotCacheEstimatedConversions(t *testing.T) {
+	t.Parallel()
+
+	cache := &reportCacheStub{entries: map[string]domain.CachedReport{}}
+	svc, err := NewReportService(
+		&reportEntryReaderStub{
+			listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+				return []domain.Entry{
+					{ID: 1, Type: domain.EntryTypeExpense, AmountMinor: 500, CurrencyCode: "EUR", TransactionDateUTC: "2026-02-03T00:00:00Z"},
+				}, nil
+			},
+		},
+		nil,
+		WithReportCache(cache),
+		WithReportFXConverter(&reportFXConverterStub{
+			convertFn: func(ctx context.Context, amountMinor int64, fromCurrency, toCurrency, transactionDateUTC string) (domain.ConvertedAmount, error) {
+				return domain.ConvertedAmount{AmountMinor: amountMinor, Snapshot: domain.FXRateSnapshot{Rate: "1", IsEstimate: true}}, nil
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("new report service: %v", err)
+	}
+
+	if _, err := svc.Generate(context.Background(), ReportRequest{
+		Period:    domain.ReportPeriodInput{Scope: domain.ReportScopeMonthly, MonthKey: "2026-02"},
+		ConvertTo: "USD",
+	}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if cache.puts != 0 {
+		t.Fatalf("expected a report converted at estimate rates not to be cached, got %d puts", cache.puts)
+	}
+}
+
+func TestReportServiceGenerateRevaluesEntriesByInflationIndex(t *testing.T) {
+	t.Parallel()
+
+	svc, err := NewReportService(
+		&reportEntryReaderStub{
+			listFn: func(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+				return []domain.Entry{
+					{ID: 1, Type: domain.EntryTypeIncome, AmountMinor: 10000, CurrencyCode: "ARS", TransactionDateUTC: "2026-02-01T00:00:00Z"},
+					{ID: 2, Type: domain.EntryTypeExpense, AmountMinor: 3000, CurrencyCode: "ARS", TransactionDateUTC: "2026-02-15T00:00:00Z"},
+					{ID: 3, Type: domain.EntryTypeExpense, AmountMinor: 700, CurrencyCode: "USD", TransactionDateUTC: "2026-02-20T00:00:00Z"},
+				}, nil
+			},
+		},
+		nil,
+		WithReportInflationIndexReader(&reportInflationIndexReaderStub{
+			points: map[string][]domain.InflationIndexPoint{
+				"ARS": {
+					{CurrencyCode: "ARS", IndexDate: "2026-02-01", IndexValue: "100"},
+					{CurrencyCode: "ARS", IndexDate: "2026-02-15", IndexValue: "120"},
+					{CurrencyCode: "ARS", IndexDate: "2026-03-31", IndexValue: "150"},
+				},
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("new report service: %v", err)
+	}
+
+	result, err := svc.Generate(context.Background(), ReportRequest{
+		Period: domain.ReportPeriodInput{
+			Scope:    domain.ReportScopeMonthly,
+			MonthKey: "2026-02",
+		},
+		RevalueAsOf: "2026-04-01",
+	})
+	if err != nil {
+		t.Fatalf("generate revalued report: %v", err)
+	}
+
+	expectedEarnings := []domain.CurrencyTotal{{CurrencyCode: "ARS", TotalMinor: 15000}}
+	if !reflect.DeepEqual(result.Report.Earnings.ByCurrency, expectedEarnings) {
+		t.Fatalf("unexpected revalued earnings: %+v", result.Report.Earnings.ByCurrency)
+	}
+	expectedSpending := []domain.CurrencyTotal{
+		{CurrencyCode: "ARS", TotalMinor: 3750},
+		{CurrencyCode: "USD", TotalMinor: 700},
+	}
+	if !reflect.DeepEqual(result.Report.Spending.ByCurrency, expectedSpending) {
+		t.Fatalf("unexpected revalued spending: %+v", result.Report.Spending.ByCurrency)
+	}
+	expectedGeneral := []domain.CurrencyTotal{
+		{CurrencyCode: "ARS", TotalMinor: 11250},
+		{CurrencyCode: "USD", TotalMinor: -700},
+	}
+	if !reflect.DeepEqual(result.Report.GeneralBalance.ByCurrency, expectedGeneral) {
+		t.Fatalf("expected the general balance to be revalued too, got %+v", result.Report.GeneralBalance.ByCurrency)
+	}
+
+	if result.Report.Revaluation == nil || result.Report.Revaluation.AsOfDate != "2026-04-01" {
+		t.Fatalf("expected revaluation summary, got %+v", result.Report.Revaluation)
+	}
+	expectedCurrencies := []domain.ReportRevaluationCurrency{
+		{CurrencyCode: "ARS", AsOfIndexDate: "2026-03-31", AsOfIndexValue: "150", RevaluedEntries: 2},
+		{CurrencyCode: "USD", NominalEntryCount: 1},
+	}
+	if !reflect.DeepEqual(result.Report.Revaluation.Currencies, expectedCurrencies) {
+		t.Fatalf("unexpected revaluation currencies: %+v", result.Report.Revaluation.Currencies)
+	}
+
+	foundIndexWarning := false
+	for _, warning := range result.Warnings {
+		if warning.Code == domain.WarningCodeInflationIndexUnavailable {
+			foundIndexWarning = true
+		}
+	}
+	if !foundIndexWarning {
+		t.Fatalf("expected INFLATION_INDEX_UNAVAILABLE warning, got %+v", result.Warnings)
+	}
+}
+
 func TestReportServiceGenerateUsesSettingsThresholdOverrides(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type ReportSnapshotStore interface {
+	Save(ctx context.Context, monthKey string, report domain.Report, replace bool) (domain.ReportSnapshot, error)
+	Get(ctx context.Context, monthKey string) (domain.ReportSnapshot, error)
+}
+
+type ReportGenerator interface {
+	Generate(ctx context.Context, req ReportRequest) (ReportResult, error)
+}
+
+type ReportSnapshotService struct {
+	store     ReportSnapshotStore
+	generator ReportGenerator
+}
+
+type ReportSnapshotResult struct {
+	Snapshot domain.ReportSnapshot
+	Warnings []domain.Warning
+}
+
+func NewReportSnapshotService(store ReportSnapshotStore, generator ReportGenerator) (*ReportSnapshotService, error) {
+	if store == nil {
+		return nil, fmt.Errorf("report snapshot service: store is required")
+	}
+	if generator == nil {
+		return nil, fmt.Errorf("report snapshot service: generator is required")
+	}
+
+	return &ReportSnapshotService{
+		store:     store,
+		generator: generator,
+	}, nil
+}
+
+// Freeze stores the unfiltered monthly report of monthKey. A month is frozen
+// once; replace re-freezes it from live data.
+func (s *ReportSnapshotService) Freeze(ctx context.Context, monthKey string, replace bool) (ReportSnapshotResult, error) {
+	normalized, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+
+	live, err := s.generator.Generate(ctx, frozenReportRequest(normalized))
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+
+	snapshot, err := s.store.Save(ctx, normalized, live.Report, replace)
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+	return ReportSnapshotResult{Snapshot: snapshot, Warnings: live.Warnings}, nil
+}
+
+// Show returns the frozen report of monthKey and warns with
+// REPORT_SNAPSHOT_DRIFT when live entries no longer add up to it.
+func (s *ReportSnapshotService) Show(ctx context.Context, monthKey string) (ReportSnapshotResult, error) {
+	normalized, err := domain.NormalizeMonthKey(monthKey)
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+
+	snapshot, err := s.store.Get(ctx, normalized)
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+
+	live, err := s.generator.Generate(ctx, frozenReportRequest(normalized))
+	if err != nil {
+		return ReportSnapshotResult{}, err
+	}
+	sections, err := domain.ReportSnapshotDriftSections(snapshot.Report, live.Report)
+	if err != nil {
+		return ReportSnapshotResult{}, fmt.Errorf("compare report snapshot: %w", err)
+	}
+
+	warnings := []domain.Warning{}
+	if len(sections) > 0 {
+		warnings = append(warnings, domain.Warning{
+			Code:    domain.WarningCodeReportSnapshotDrift,
+			Message: "live entries no longer match the frozen report",
+			Details: domain.ReportSnapshotDriftWarningDetails{
+				MonthKey:    normalized,
+				FrozenAtUTC: snapshot.FrozenAtUTC,
+				Sections:    sections,
+			},
+		})
+	}
+	return ReportSnapshotResult{Snapshot: snapshot, Warnings: warnings}, nil
+}
+
+func frozenReportRequest(monthKey string) ReportRequest {
+	return ReportRequest{
+		Period: domain.ReportPeriodInput{
+			Scope:    domain.ReportScopeMonthly,
+			MonthKey: monthKey,
+		},
+		Grouping: domain.ReportGroupingMonth,
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/reporting"
+)
+
+// TaxSummary collects the year's entries that sit in a selected category or
+// carry a selected label. Unknown selection names fail with the category or
+// label not-found error so a typo never silently drops entries.
+func (s *ReportService) TaxSummary(ctx context.Context, year int, selection domain.TaxSelection) (domain.TaxSummary, error) {
+	period, err := domain.TaxYearPeriod(year)
+	if err != nil {
+		return domain.TaxSummary{}, err
+	}
+	if s.categoryReader == nil || s.labelReader == nil {
+		return domain.TaxSummary{}, fmt.Errorf("tax summary unavailable: category and label readers are required")
+	}
+
+	categories, err := s.categoryReader.List(ctx)
+	if err != nil {
+		return domain.TaxSummary{}, err
+	}
+	labels, err := s.labelReader.List(ctx)
+	if err != nil {
+		return domain.TaxSummary{}, err
+	}
+
+	categoryNames := make(map[int64]string, len(categories))
+	categoryIDsByName := make(map[string]int64, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+		categoryIDsByName[strings.ToLower(strings.TrimSpace(category.Name))] = category.ID
+	}
+	labelNames := make(map[int64]string, len(labels))
+	labelIDsByName := make(map[string]int64, len(labels))
+	for _, label := range labels {
+		labelNames[label.ID] = label.Name
+		labelIDsByName[strings.ToLower(strings.TrimSpace(label.Name))] = label.ID
+	}
+
+	selectedCategories := map[int64]bool{}
+	for _, name := range selection.Categories {
+		id, ok := categoryIDsByName[strings.ToLower(name)]
+		if !ok {
+			return domain.TaxSummary{}, fmt.Errorf("%w: %s", domain.ErrCategoryNotFound, name)
+		}
+		selectedCategories[id] = true
+	}
+	selectedLabels := map[int64]bool{}
+	for _, name := range selection.Labels {
+		id, ok := labelIDsByName[strings.ToLower(name)]
+		if !ok {
+			return domain.TaxSummary{}, fmt.Errorf("%w: %s", domain.ErrLabelNotFound, name)
+		}
+		selectedLabels[id] = true
+	}
+
+	entries, err := s.entryReader.List(ctx, domain.EntryListFilter{
+		DateFromUTC: period.FromUTC,
+		DateToUTC:   period.ToUTC,
+	})
+	if err != nil {
+		return domain.TaxSummary{}, err
+	}
+	reporting.SortEntriesDeterministic(entries)
+
+	selected := make([]domain.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if taxEntrySelected(entry, selectedCategories, selectedLabels) {
+			selected = append(selected, entry)
+		}
+	}
+
+	summary := reporting.BuildTaxSummary(selected, categoryNames, labelNames)
+	summary.Year = year
+	summary.Period = period
+	summary.Selection = selection
+	return summary, nil
+}
+
+func taxEntrySelected(entry domain.Entry, categoryIDs, labelIDs map[int64]bool) bool {
+	if entry.CategoryID != nil && categoryIDs[*entry.CategoryID] {
+		return true
+	}
+	for _, labelID := range entry.LabelIDs {
+		if labelIDs[labelID] {
+			return true
+		}
+	}
+	return false
+}
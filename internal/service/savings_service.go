@@ -223,7 +223,12 @@ func (s *SavingsService) computeView(ctx context.Context, entryFilter domain.Ent
 			case domain.EntryTypeIncome:
 				state.general += item.amountMinor
 			case domain.EntryTypeExpense:
-				applySavingsExpense(&state, item.amountMinor)
+				if item.amountMinor < 0 {
+					// Refunds come back to general funds.
+					state.general -= item.amountMinor
+				} else {
+					applySavingsExpense(&state, item.amountMinor)
+				}
 			}
 		case savingsReplayKindEvent:
 			switch item.eventType {
@@ -272,7 +277,7 @@ func buildSavingsReplay(entries []domain.Entry, events []domain.SavingsEvent) ([
 			id:          entry.ID,
 			date:        dateValue,
 			currency:    entry.CurrencyCode,
-			amountMinor: entry.AmountMinor,
+			amountMinor: entry.EffectiveAmountMinor(),
 			entryType:   entry.Type,
 		})
 	}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+type SearchRepository interface {
+	Search(ctx context.Context, text string, limit int64) (domain.SearchResult, error)
+}
+
+type SearchService struct {
+	repo SearchRepository
+}
+
+func NewSearchService(repo SearchRepository) (*SearchService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("search service: repo is required")
+	}
+	return &SearchService{repo: repo}, nil
+}
+
+// Search looks text up across entry notes, category and label names and card
+// nicknames and descriptions, returning at most limit matches per group.
+func (s *SearchService) Search(ctx context.Context, text string, limit int) (domain.SearchResult, error) {
+	normalized, err := domain.NormalizeSearchText(text)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	if limit <= 0 {
+		return domain.SearchResult{}, domain.ErrInvalidSearchLimit
+	}
+	return s.repo.Search(ctx, normalized, int64(limit))
+}
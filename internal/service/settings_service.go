@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type SettingsRepository interface {
+	Get(ctx context.Context) (domain.Settings, error)
+	Upsert(ctx context.Context, input domain.SettingsUpsertInput) (domain.Settings, error)
+	UpdatePreferences(ctx context.Context, preferences domain.SettingsPreferences) (domain.Settings, error)
+	UpsertOrphanSpendingThreshold(ctx context.Context, input domain.OrphanSpendingThresholdInput) (domain.OrphanSpendingThreshold, error)
+	ListOrphanSpendingThresholds(ctx context.Context) ([]domain.OrphanSpendingThreshold, error)
+	DeleteOrphanSpendingThreshold(ctx context.Context, currencyCode string) error
+	ListStrictWarningCodes(ctx context.Context) ([]string, error)
+	ReplaceStrictWarningCodes(ctx context.Context, codes []string) ([]string, error)
+}
+
+// SettingsCardResolver checks that default_card_id points at an active card.
+type SettingsCardResolver interface {
+	Resolve(ctx context.Context, selector domain.CardSelector) (domain.Card, error)
+}
+
+type SettingsServiceOption func(*SettingsService)
+
+// SettingsService manages tunable settings outside of first-run setup.
+type SettingsService struct {
+	repo         SettingsRepository
+	cardResolver SettingsCardResolver
+}
+
+func WithSettingsCardResolver(cardResolver SettingsCardResolver) SettingsServiceOption {
+	return func(service *SettingsService) {
+		service.cardResolver = cardResolver
+	}
+}
+
+// WarningThresholds lists the stored orphan-spending rules together with the
+// settings percentage used for currencies without one, and the warning codes
+// escalated to failures.
+type WarningThresholds struct {
+	OrphanCountThreshold       int                              `json:"orphan_count_threshold"`
+	OrphanSpendingThresholdBPS int                              `json:"orphan_spending_threshold_bps"`
+	OrphanSpending             []domain.OrphanSpendingThreshold `json:"orphan_spending"`
+	StrictWarnings             []string                         `json:"strict_warnings"`
+}
+
+func NewSettingsService(repo SettingsRepository, opts ...SettingsServiceOption) (*SettingsService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("settings service: repo is required")
+	}
+	service := &SettingsService{repo: repo}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(service)
+		}
+	}
+	return service, nil
+}
+
+// List returns every settings key with its stored value.
+func (s *SettingsService) List(ctx context.Context) ([]domain.SettingValue, error) {
+	settings, err := s.repo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.SettingValues(settings), nil
+}
+
+func (s *SettingsService) GetValue(ctx context.Context, key string) (domain.SettingValue, error) {
+	normalizedKey, err := domain.NormalizeSettingKey(key)
+	if err != nil {
+		return domain.SettingValue{}, err
+	}
+	settings, err := s.repo.Get(ctx)
+	if err != nil {
+		return domain.SettingValue{}, err
+	}
+	return domain.SettingValue{Key: normalizedKey, Value: domain.SettingValueOf(settings, normalizedKey)}, nil
+}
+
+// SetValue validates raw for key and stores it. Settings must have been
+// initialized by setup init; optional keys are cleared with "none".
+func (s *SettingsService) SetValue(ctx context.Context, key, raw string) (domain.SettingValue, error) {
+	normalizedKey, err := domain.NormalizeSettingKey(key)
+	if err != nil {
+		return domain.SettingValue{}, err
+	}
+	settings, err := s.repo.Get(ctx)
+	if err != nil {
+		return domain.SettingValue{}, err
+	}
+
+	value := strings.TrimSpace(raw)
+	input := domain.SettingsUpsertInput{
+		DefaultCurrencyCode:        settings.DefaultCurrencyCode,
+		DisplayTimezone:            settings.DisplayTimezone,
+		OrphanCountThreshold:       settings.OrphanCountThreshold,
+		OrphanSpendingThresholdBPS: settings.OrphanSpendingThresholdBPS,
+		AmountFormat:               settings.AmountFormat,
+		OnboardingCompletedAtUTC:   settings.OnboardingCompletedAtUTC,
+	}
+	preferences := domain.SettingsPreferences{
+		DefaultOutput:       settings.DefaultOutput,
+		DefaultCardID:       settings.DefaultCardID,
+		FiscalMonthStartDay: settings.FiscalMonthStartDay,
+		DefaultRecordedBy:   settings.DefaultRecordedBy,
+		AutoSnapshot:        settings.AutoSnapshot,
+		FXCacheTTLHours:     settings.FXCacheTTLHours,
+		FXStaleAfterDays:    settings.FXStaleAfterDays,
+		ReportCache:         settings.ReportCache,
+	}
+	writesPreferences := false
+
+	switch normalizedKey {
+	case domain.SettingKeyDefaultCurrency:
+		currencyCode, err := domain.NormalizeCurrencyCode(value)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		input.DefaultCurrencyCode = currencyCode
+	case domain.SettingKeyTimezone:
+		if value == "" {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		if _, err := time.LoadLocation(value); err != nil {
+			return domain.SettingValue{}, fmt.Errorf("%w: %v", domain.ErrInvalidSettingValue, err)
+		}
+		input.DisplayTimezone = value
+	case domain.SettingKeyAmountFormat:
+		if value == "" {
+			return domain.SettingValue{}, domain.ErrInvalidAmountFormat
+		}
+		amountFormat, err := domain.NormalizeAmountFormat(value)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		input.AmountFormat = amountFormat
+	case domain.SettingKeyOrphanCountThreshold:
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count <= 0 {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		input.OrphanCountThreshold = count
+	case domain.SettingKeyOrphanSpendingThresholdBPS:
+		bps, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || bps <= 0 || bps > 10000 {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		input.OrphanSpendingThresholdBPS = bps
+	case domain.SettingKeyDefaultOutput:
+		writesPreferences = true
+		if strings.EqualFold(value, domain.SettingValueNone) {
+			preferences.DefaultOutput = nil
+			break
+		}
+		format, err := domain.NormalizeSettingsDefaultOutput(value)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.DefaultOutput = &format
+	case domain.SettingKeyDefaultCardID:
+		writesPreferences = true
+		if strings.EqualFold(value, domain.SettingValueNone) {
+			preferences.DefaultCardID = nil
+			break
+		}
+		cardID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || cardID <= 0 {
+			return domain.SettingValue{}, domain.ErrInvalidCardID
+		}
+		if s.cardResolver != nil {
+			if _, err := s.cardResolver.Resolve(ctx, domain.CardSelector{ID: &cardID}); err != nil {
+				return domain.SettingValue{}, err
+			}
+		}
+		preferences.DefaultCardID = &cardID
+	case domain.SettingKeyFiscalMonthStartDay:
+		writesPreferences = true
+		day, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		day, err = domain.NormalizeFiscalMonthStartDay(day)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.FiscalMonthStartDay = day
+	case domain.SettingKeyDefaultRecordedBy:
+		writesPreferences = true
+		if strings.EqualFold(value, domain.SettingValueNone) {
+			preferences.DefaultRecordedBy = nil
+			break
+		}
+		recordedBy := domain.NormalizeRecordedBy(value)
+		if recordedBy == "" {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		preferences.DefaultRecordedBy = &recordedBy
+	case domain.SettingKeyAutoSnapshot:
+		writesPreferences = true
+		enabled, err := domain.ParseSettingSwitch(value)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.AutoSnapshot = enabled
+	case domain.SettingKeyFXCacheTTLHours:
+		writesPreferences = true
+		hours, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		hours, err = domain.NormalizeFXCacheTTLHours(hours)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.FXCacheTTLHours = hours
+	case domain.SettingKeyFXStaleAfterDays:
+		writesPreferences = true
+		days, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return domain.SettingValue{}, domain.ErrInvalidSettingValue
+		}
+		days, err = domain.NormalizeFXStaleAfterDays(days)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.FXStaleAfterDays = days
+	case domain.SettingKeyReportCache:
+		writesPreferences = true
+		enabled, err := domain.ParseSettingSwitch(value)
+		if err != nil {
+			return domain.SettingValue{}, err
+		}
+		preferences.ReportCache = enabled
+	}
+
+	if writesPreferences {
+		settings, err = s.repo.UpdatePreferences(ctx, preferences)
+	} else {
+		settings, err = s.repo.Upsert(ctx, input)
+	}
+	if err != nil {
+		return domain.SettingValue{}, err
+	}
+	return domain.SettingValue{Key: normalizedKey, Value: domain.SettingValueOf(settings, normalizedKey)}, nil
+}
+
+func (s *SettingsService) SetOrphanSpendingThreshold(ctx context.Context, input domain.OrphanSpendingThresholdInput) (domain.OrphanSpendingThreshold, error) {
+	normalized, err := domain.NormalizeOrphanSpendingThresholdInput(input)
+	if err != nil {
+		return domain.OrphanSpendingThreshold{}, err
+	}
+	return s.repo.UpsertOrphanSpendingThreshold(ctx, normalized)
+}
+
+func (s *SettingsService) ResetOrphanSpendingThreshold(ctx context.Context, currencyCode string) error {
+	return s.repo.DeleteOrphanSpendingThreshold(ctx, currencyCode)
+}
+
+func (s *SettingsService) WarningThresholds(ctx context.Context) (WarningThresholds, error) {
+	thresholds := WarningThresholds{
+		OrphanCountThreshold:       domain.DefaultOrphanCountThreshold,
+		OrphanSpendingThresholdBPS: domain.DefaultOrphanSpendingThresholdBPS,
+	}
+
+	settings, err := s.repo.Get(ctx)
+	if err != nil && !errors.Is(err, domain.ErrSettingsNotFound) {
+		return WarningThresholds{}, err
+	}
+	if err == nil {
+		if settings.OrphanCountThreshold > 0 {
+			thresholds.OrphanCountThreshold = int(settings.OrphanCountThreshold)
+		}
+		if settings.OrphanSpendingThresholdBPS > 0 {
+			thresholds.OrphanSpendingThresholdBPS = int(settings.OrphanSpendingThresholdBPS)
+		}
+	}
+
+	rules, err := s.repo.ListOrphanSpendingThresholds(ctx)
+	if err != nil {
+		return WarningThresholds{}, err
+	}
+	thresholds.OrphanSpending = rules
+
+	strict, err := s.repo.ListStrictWarningCodes(ctx)
+	if err != nil {
+		return WarningThresholds{}, err
+	}
+	thresholds.StrictWarnings = strict
+	return thresholds, nil
+}
+
+// SetStrictWarnings replaces the stored strict-warnings policy; no codes turns
+// it off.
+func (s *SettingsService) SetStrictWarnings(ctx context.Context, codes []string) ([]string, error) {
+	normalized, err := domain.NormalizeStrictWarningCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ReplaceStrictWarningCodes(ctx, normalized)
+}
+
+func (s *SettingsService) StrictWarnings(ctx context.Context) ([]string, error) {
+	return s.repo.ListStrictWarningCodes(ctx)
+}
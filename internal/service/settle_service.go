@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type SettleRepository interface {
+	ListSharedExpenseShares(ctx context.Context, currencyCode string) ([]domain.SharedExpenseShare, error)
+	ListSettlements(ctx context.Context, currencyCode string) ([]domain.Settlement, error)
+	AddSettlements(ctx context.Context, inputs []domain.SettlementInput) ([]domain.Settlement, error)
+}
+
+type SettleService struct {
+	repo SettleRepository
+}
+
+type SettleShowFilter struct {
+	CurrencyCode string
+	Person       string
+}
+
+// SettleRecordInput clears debt From owes To. Without AmountMinor the whole
+// open balance is settled, in every currency unless CurrencyCode is set.
+type SettleRecordInput struct {
+	From         string
+	To           string
+	CurrencyCode string
+	AmountMinor  *int64
+	SettledAtUTC string
+	Note         string
+}
+
+func NewSettleService(repo SettleRepository) (*SettleService, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("settle service: repo is required")
+	}
+	return &SettleService{repo: repo}, nil
+}
+
+// Show returns the open balances between people, optionally only those in one
+// currency or involving one person.
+func (s *SettleService) Show(ctx context.Context, filter SettleShowFilter) ([]domain.SettleBalance, error) {
+	currencyCode := ""
+	if strings.TrimSpace(filter.CurrencyCode) != "" {
+		normalized, err := domain.NormalizeCurrencyCode(filter.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		currencyCode = normalized
+	}
+
+	balances, err := s.balances(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	person := domain.NormalizeRecordedBy(filter.Person)
+	if person == "" {
+		return balances, nil
+	}
+	filtered := make([]domain.SettleBalance, 0, len(balances))
+	for _, balance := range balances {
+		if strings.EqualFold(balance.From, person) || strings.EqualFold(balance.To, person) {
+			filtered = append(filtered, balance)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *SettleService) Record(ctx context.Context, input SettleRecordInput) ([]domain.Settlement, error) {
+	from := domain.NormalizeRecordedBy(input.From)
+	to := domain.NormalizeRecordedBy(input.To)
+	if from == "" || to == "" || strings.EqualFold(from, to) {
+		return nil, domain.ErrInvalidSettlePerson
+	}
+
+	currencyCode := ""
+	if strings.TrimSpace(input.CurrencyCode) != "" {
+		normalized, err := domain.NormalizeCurrencyCode(input.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		currencyCode = normalized
+	}
+
+	settledAtUTC := time.Now().UTC().Format(time.RFC3339)
+	if strings.TrimSpace(input.SettledAtUTC) != "" {
+		normalized, err := domain.NormalizeTransactionDateUTC(input.SettledAtUTC)
+		if err != nil {
+			return nil, err
+		}
+		settledAtUTC = normalized
+	}
+	note := strings.TrimSpace(input.Note)
+
+	if input.AmountMinor != nil {
+		if *input.AmountMinor <= 0 {
+			return nil, domain.ErrInvalidSettlementAmount
+		}
+		if currencyCode == "" {
+			return nil, domain.ErrInvalidCurrencyCode
+		}
+		return s.repo.AddSettlements(ctx, []domain.SettlementInput{{
+			FromPerson:   from,
+			ToPerson:     to,
+			AmountMinor:  *input.AmountMinor,
+			CurrencyCode: currencyCode,
+			SettledAtUTC: settledAtUTC,
+			Note:         note,
+		}})
+	}
+
+	balances, err := s.balances(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	inputs := []domain.SettlementInput{}
+	for _, balance := range balances {
+		if !strings.EqualFold(balance.From, from) || !strings.EqualFold(balance.To, to) {
+			continue
+		}
+		inputs = append(inputs, domain.SettlementInput{
+			FromPerson:   from,
+			ToPerson:     to,
+			AmountMinor:  balance.AmountMinor,
+			CurrencyCode: balance.CurrencyCode,
+			SettledAtUTC: settledAtUTC,
+			Note:         note,
+		})
+	}
+	if len(inputs) == 0 {
+		return nil, domain.ErrNothingToSettle
+	}
+	return s.repo.AddSettlements(ctx, inputs)
+}
+
+func (s *SettleService) balances(ctx context.Context, currencyCode string) ([]domain.SettleBalance, error) {
+	shares, err := s.repo.ListSharedExpenseShares(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	settlements, err := s.repo.ListSettlements(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ComputeSettleBalances(shares, settlements), nil
+}
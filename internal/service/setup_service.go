@@ -23,6 +23,7 @@ type SetupService struct {
 type SetupInitInput struct {
 	DefaultCurrencyCode  string
 	DisplayTimezone      string
+	AmountFormat         string
 	OpeningBalanceMinor  int64
 	OpeningBalanceCode   string
 	OpeningBalanceDate   string
@@ -62,6 +63,7 @@ func (s *SetupService) Init(ctx context.Context, input SetupInitInput) (SetupIni
 		DisplayTimezone:            input.DisplayTimezone,
 		OrphanCountThreshold:       domain.DefaultOrphanCountThresholdValue,
 		OrphanSpendingThresholdBPS: domain.DefaultOrphanSpendingThresholdBPSValue,
+		AmountFormat:               input.AmountFormat,
 		OnboardingCompletedAtUTC:   &nowUTC,
 	})
 	if err != nil {
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+type SubscriptionEntryReader interface {
+	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+}
+
+type SubscriptionScheduleLister interface {
+	List(ctx context.Context, includeDeleted bool) ([]domain.ScheduledPayment, error)
+}
+
+type SubscriptionService struct {
+	entries   SubscriptionEntryReader
+	schedules SubscriptionScheduleLister
+}
+
+type SubscriptionDetectRequest struct {
+	DateFromUTC  string
+	DateToUTC    string
+	CurrencyCode string
+	Options      domain.SubscriptionDetectOptions
+}
+
+func NewSubscriptionService(entries SubscriptionEntryReader, schedules SubscriptionScheduleLister) (*SubscriptionService, error) {
+	if entries == nil {
+		return nil, fmt.Errorf("subscription service: entry reader is required")
+	}
+	if schedules == nil {
+		return nil, fmt.Errorf("subscription service: schedule lister is required")
+	}
+	return &SubscriptionService{entries: entries, schedules: schedules}, nil
+}
+
+// Detect scans expenses in the requested window for repeating charges.
+func (s *SubscriptionService) Detect(ctx context.Context, req SubscriptionDetectRequest) (domain.SubscriptionDetection, error) {
+	opts, err := domain.NormalizeSubscriptionDetectOptions(req.Options)
+	if err != nil {
+		return domain.SubscriptionDetection{}, err
+	}
+	if req.DateFromUTC != "" && req.DateToUTC != "" && req.DateFromUTC > req.DateToUTC {
+		return domain.SubscriptionDetection{}, domain.ErrInvalidDateRange
+	}
+	currencyCode := ""
+	if strings.TrimSpace(req.CurrencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(req.CurrencyCode)
+		if err != nil {
+			return domain.SubscriptionDetection{}, err
+		}
+	}
+
+	entries, err := s.entries.List(ctx, domain.EntryListFilter{
+		Type:         domain.EntryTypeExpense,
+		DateFromUTC:  req.DateFromUTC,
+		DateToUTC:    req.DateToUTC,
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return domain.SubscriptionDetection{}, err
+	}
+	schedules, err := s.schedules.List(ctx, false)
+	if err != nil {
+		return domain.SubscriptionDetection{}, err
+	}
+
+	return domain.DetectSubscriptions(entries, schedules, opts), nil
+}
+
+// Changes replays the expense history and returns the recurring charges whose
+// amount moved, limited to changes dated inside the requested window.
+func (s *SubscriptionService) Changes(ctx context.Context, req SubscriptionDetectRequest) ([]domain.SubscriptionPriceChange, error) {
+	opts, err := domain.NormalizeSubscriptionDetectOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	if req.DateFromUTC != "" && req.DateToUTC != "" && req.DateFromUTC > req.DateToUTC {
+		return nil, domain.ErrInvalidDateRange
+	}
+	currencyCode := ""
+	if strings.TrimSpace(req.CurrencyCode) != "" {
+		currencyCode, err = domain.NormalizeCurrencyCode(req.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The window only bounds the reported changes; earlier charges are still
+	// needed to establish each pattern.
+	entries, err := s.entries.List(ctx, domain.EntryListFilter{
+		Type:         domain.EntryTypeExpense,
+		DateToUTC:    req.DateToUTC,
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate := ""
+	if len(req.DateFromUTC) >= 10 {
+		fromDate = req.DateFromUTC[:10]
+	}
+	changes := []domain.SubscriptionPriceChange{}
+	for _, change := range domain.SubscriptionPriceChanges(entries, opts) {
+		if fromDate != "" && change.Date < fromDate {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
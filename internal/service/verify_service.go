@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+)
+
+type VerifyEntryReader interface {
+	List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error)
+}
+
+type VerifyService struct {
+	entryReader VerifyEntryReader
+}
+
+type VerifyMonthInput struct {
+	MonthKey        string
+	StatementPath   string
+	DefaultCurrency string
+	AmountFormat    string
+	PaymentCardID   *int64
+	ToleranceDays   int
+}
+
+func NewVerifyService(entryReader VerifyEntryReader) (*VerifyService, error) {
+	if entryReader == nil {
+		return nil, fmt.Errorf("verify service: entry reader is required")
+	}
+	return &VerifyService{entryReader: entryReader}, nil
+}
+
+// Month reconciles a statement CSV against the month's entries, optionally
+// only those paid with one card.
+func (s *VerifyService) Month(ctx context.Context, input VerifyMonthInput) (domain.StatementReconciliation, error) {
+	monthKey, err := domain.NormalizeMonthKey(input.MonthKey)
+	if err != nil {
+		return domain.StatementReconciliation{}, err
+	}
+	if input.ToleranceDays < 0 {
+		return domain.StatementReconciliation{}, domain.ErrInvalidStatementTolerance
+	}
+
+	lines, err := readStatementCSV(input.StatementPath, input.DefaultCurrency, input.AmountFormat)
+	if err != nil {
+		return domain.StatementReconciliation{}, err
+	}
+
+	fromUTC, toUTC, err := domain.MonthRangeUTC(monthKey)
+	if err != nil {
+		return domain.StatementReconciliation{}, err
+	}
+	from, _ := time.Parse(time.RFC3339Nano, fromUTC)
+	to, _ := time.Parse(time.RFC3339Nano, toUTC)
+	tolerance := time.Duration(input.ToleranceDays) * 24 * time.Hour
+
+	entries, err := s.entryReader.List(ctx, domain.EntryListFilter{
+		DateFromUTC:   from.Add(-tolerance).Format(time.RFC3339Nano),
+		DateToUTC:     to.Add(tolerance - time.Nanosecond).Format(time.RFC3339Nano),
+		PaymentCardID: input.PaymentCardID,
+	})
+	if err != nil {
+		return domain.StatementReconciliation{}, err
+	}
+
+	return domain.ReconcileStatement(monthKey, lines, entries, input.ToleranceDays)
+}
+
+// readStatementCSV loads a statement with a header row naming at least the
+// date and amount columns; currency and description are optional. Rows
+// without a currency use defaultCurrency.
+func readStatementCSV(filePath, defaultCurrency, amountFormat string) ([]domain.StatementLine, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: statement is empty", domain.ErrInvalidStatementLine)
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for index, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	for _, required := range []string{"date", "amount"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: header is missing the %s column", domain.ErrInvalidStatementLine, required)
+		}
+	}
+	field := func(row []string, name string) string {
+		index, ok := columns[name]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return row[index]
+	}
+
+	lines := []domain.StatementLine{}
+	rowNumber := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rowNumber++
+		currencyCode := field(row, "currency")
+		if strings.TrimSpace(currencyCode) == "" {
+			currencyCode = defaultCurrency
+		}
+		line, err := domain.NormalizeStatementLine(domain.StatementLineInput{
+			Line:         rowNumber,
+			Date:         field(row, "date"),
+			Amount:       field(row, "amount"),
+			CurrencyCode: currencyCode,
+			Description:  field(row, "description"),
+		}, amountFormat)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", domain.ErrInvalidStatementLine, rowNumber, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type AssetRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewAssetRepo(db *sql.DB) *AssetRepo {
+	return &AssetRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *AssetRepo) Add(ctx context.Context, input domain.AssetAddInput) (domain.Asset, error) {
+	result, err := r.queries.CreateAsset(ctx, queries.CreateAssetParams{
+		Name:         input.Name,
+		Kind:         input.Kind,
+		CurrencyCode: input.CurrencyCode,
+		BalanceMinor: input.BalanceMinor,
+		Note:         nullableString(input.Note),
+		UpdatedAtUtc: nowRFC3339Nano(),
+	})
+	if err != nil {
+		return domain.Asset{}, mapAssetWriteError("add asset", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.Asset{}, fmt.Errorf("add asset read id: %w", err)
+	}
+
+	return r.GetByID(ctx, id, false)
+}
+
+func (r *AssetRepo) GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Asset, error) {
+	if err := domain.ValidateAssetID(id); err != nil {
+		return domain.Asset{}, err
+	}
+
+	var (
+		row queries.Asset
+		err error
+	)
+	if includeDeleted {
+		row, err = r.queries.GetAssetByID(ctx, id)
+	} else {
+		row, err = r.queries.GetActiveAssetByID(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Asset{}, domain.ErrAssetNotFound
+		}
+		return domain.Asset{}, fmt.Errorf("get asset by id: %w", err)
+	}
+
+	return mapSQLCAsset(row), nil
+}
+
+func (r *AssetRepo) List(ctx context.Context, filter domain.AssetListFilter) ([]domain.Asset, error) {
+	rows, err := r.queries.ListAssets(ctx, queries.ListAssetsParams{
+		IncludeDeleted: boolAsInt64(filter.IncludeDeleted),
+		Kind:           nullableString(filter.Kind),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list assets: %w", err)
+	}
+
+	assets := make([]domain.Asset, 0, len(rows))
+	for _, row := range rows {
+		assets = append(assets, mapSQLCAsset(row))
+	}
+
+	return assets, nil
+}
+
+func (r *AssetRepo) Update(ctx context.Context, input domain.AssetUpdateInput) (domain.Asset, error) {
+	if err := domain.ValidateAssetID(input.ID); err != nil {
+		return domain.Asset{}, err
+	}
+
+	params := queries.UpdateAssetByIDParams{
+		SetName:         boolAsInt64(input.Name != nil),
+		SetBalanceMinor: boolAsInt64(input.BalanceMinor != nil),
+		SetNote:         boolAsInt64(input.Note != nil),
+		UpdatedAtUtc:    nowRFC3339Nano(),
+		ID:              input.ID,
+	}
+	if input.Name != nil {
+		params.Name = *input.Name
+	}
+	if input.BalanceMinor != nil {
+		params.BalanceMinor = *input.BalanceMinor
+	}
+	if input.Note != nil {
+		params.Note = nullableString(*input.Note)
+	}
+
+	result, err := r.queries.UpdateAssetByID(ctx, params)
+	if err != nil {
+		return domain.Asset{}, mapAssetWriteError("update asset", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Asset{}, fmt.Errorf("update asset rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.Asset{}, domain.ErrAssetNotFound
+	}
+
+	return r.GetByID(ctx, input.ID, false)
+}
+
+func (r *AssetRepo) Delete(ctx context.Context, id int64) (domain.AssetDeleteResult, error) {
+	if err := domain.ValidateAssetID(id); err != nil {
+		return domain.AssetDeleteResult{}, err
+	}
+
+	deletedAtUTC := nowRFC3339Nano()
+	result, err := r.queries.SoftDeleteAsset(ctx, queries.SoftDeleteAssetParams{
+		DeletedAtUtc: sql.NullString{String: deletedAtUTC, Valid: true},
+		UpdatedAtUtc: deletedAtUTC,
+		ID:           id,
+	})
+	if err != nil {
+		return domain.AssetDeleteResult{}, fmt.Errorf("delete asset: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.AssetDeleteResult{}, fmt.Errorf("delete asset rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.AssetDeleteResult{}, domain.ErrAssetNotFound
+	}
+
+	return domain.AssetDeleteResult{
+		AssetID:      id,
+		DeletedAtUTC: deletedAtUTC,
+	}, nil
+}
+
+func mapSQLCAsset(row queries.Asset) domain.Asset {
+	return domain.Asset{
+		ID:           row.ID,
+		Name:         row.Name,
+		Kind:         row.Kind,
+		CurrencyCode: row.CurrencyCode,
+		BalanceMinor: row.BalanceMinor,
+		Note:         row.Note.String,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+		DeletedAtUTC: ptrStringFromNull(row.DeletedAtUtc),
+	}
+}
+
+func mapAssetWriteError(operation string, err error) error {
+	if isUniqueConstraintErr(err) {
+		return domain.ErrAssetNameConflict
+	}
+	return fmt.Errorf("%s: %w", operation, err)
+}
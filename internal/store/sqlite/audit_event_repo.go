@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type AuditEventRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewAuditEventRepo(db *sql.DB) *AuditEventRepo {
+	return &AuditEventRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ListAfter returns up to limit audit events with an id greater than afterID,
+// oldest first.
+func (r *AuditEventRepo) ListAfter(ctx context.Context, afterID int64, limit int) ([]domain.ChangeEvent, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list audit events: db is nil")
+	}
+
+	rows, err := r.queries.ListAuditEventsAfterID(ctx, queries.ListAuditEventsAfterIDParams{
+		ID:    afterID,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit events: %w", err)
+	}
+
+	events := make([]domain.ChangeEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, mapSQLCAuditEventToDomain(row))
+	}
+	return events, nil
+}
+
+func (r *AuditEventRepo) LatestID(ctx context.Context) (int64, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("latest audit event: db is nil")
+	}
+
+	latestID, err := r.queries.GetLatestAuditEventID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("latest audit event: %w", err)
+	}
+	return latestID, nil
+}
+
+// ListAuditRecords returns every audit event in id order, without the
+// snapshots and hashes that domain.BuildAuditTrail derives.
+func (r *AuditEventRepo) ListAuditRecords(ctx context.Context) ([]domain.AuditRecord, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list audit records: db is nil")
+	}
+
+	rows, err := r.queries.ListAuditEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+
+	records := make([]domain.AuditRecord, 0, len(rows))
+	for _, row := range rows {
+		event := mapSQLCAuditEventToDomain(row)
+		records = append(records, domain.AuditRecord{
+			ID:            event.ID,
+			OccurredAtUTC: event.OccurredAtUTC,
+			Actor:         row.Source,
+			Operation:     event.Action,
+			EntityType:    event.EntityType,
+			EntityID:      event.EntityID,
+			Payload:       event.Payload,
+		})
+	}
+	return records, nil
+}
+
+// AppendAuditRecords inserts the records missing locally in one transaction.
+// A record is already present when an event with the same timestamp, entity,
+// operation and payload exists; such records are counted as skipped.
+func (r *AuditEventRepo) AppendAuditRecords(ctx context.Context, records []domain.AuditRecord) (domain.AuditImportResult, error) {
+	if r.db == nil {
+		return domain.AuditImportResult{}, fmt.Errorf("append audit records: db is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.AuditImportResult{}, fmt.Errorf("append audit records begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	result := domain.AuditImportResult{}
+	for _, record := range records {
+		payload := string(record.Payload)
+		if payload == "" || payload == "null" {
+			payload = "{}"
+		}
+
+		exists, err := qtx.ExistsAuditEvent(ctx, queries.ExistsAuditEventParams{
+			CreatedAtUtc: record.OccurredAtUTC,
+			EntityType:   record.EntityType,
+			EntityID:     record.EntityID,
+			Action:       record.Operation,
+			Json:         payload,
+		})
+		if err != nil {
+			return domain.AuditImportResult{}, fmt.Errorf("append audit records check %d: %w", record.ID, err)
+		}
+		if isTruthy(exists) {
+			result.Skipped++
+			continue
+		}
+
+		if _, err := qtx.InsertAuditEvent(ctx, queries.InsertAuditEventParams{
+			Action:       record.Operation,
+			EntityType:   record.EntityType,
+			EntityID:     record.EntityID,
+			Source:       record.Actor,
+			PayloadJson:  sql.NullString{String: payload, Valid: true},
+			CreatedAtUtc: record.OccurredAtUTC,
+		}); err != nil {
+			return domain.AuditImportResult{}, fmt.Errorf("append audit records insert %d: %w", record.ID, err)
+		}
+		result.Imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.AuditImportResult{}, fmt.Errorf("append audit records commit: %w", err)
+	}
+	return result, nil
+}
+
+func mapSQLCAuditEventToDomain(row queries.AuditEvent) domain.ChangeEvent {
+	payload := json.RawMessage("{}")
+	if row.PayloadJson.Valid && json.Valid([]byte(row.PayloadJson.String)) {
+		payload = json.RawMessage(row.PayloadJson.String)
+	}
+	return domain.ChangeEvent{
+		ID:            row.ID,
+		Event:         domain.ChangeEventName(row.EntityType, row.Action),
+		EntityType:    row.EntityType,
+		EntityID:      row.EntityID,
+		Action:        row.Action,
+		OccurredAtUTC: row.CreatedAtUtc,
+		Payload:       payload,
+	}
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"boring-budget/internal/domain"
+	"modernc.org/sqlite"
+)
+
+// onlineBackuper is implemented by the modernc driver connection.
+type onlineBackuper interface {
+	NewBackup(dstURI string) (*sqlite.Backup, error)
+}
+
+type BackupRepo struct {
+	db *sql.DB
+}
+
+func NewBackupRepo(db *sql.DB) *BackupRepo {
+	return &BackupRepo{db: db}
+}
+
+// OnlineBackup copies the database into outputPath with SQLite's online
+// backup API, pagesPerStep pages at a time. Each step holds only a short read
+// lock, so other processes keep reading and writing in WAL mode; SQLite
+// restarts the copy when another process changes pages already copied.
+// progress, when set, is called after every step.
+func (r *BackupRepo) OnlineBackup(ctx context.Context, outputPath string, pagesPerStep int, progress func(domain.BackupProgress)) (domain.BackupProgress, error) {
+	if r.db == nil {
+		return domain.BackupProgress{}, fmt.Errorf("online backup: db is nil")
+	}
+	if err := domain.ValidateBackupPagesPerStep(pagesPerStep); err != nil {
+		return domain.BackupProgress{}, err
+	}
+
+	state := domain.BackupProgress{}
+	if err := r.db.QueryRowContext(ctx, "PRAGMA page_count;").Scan(&state.PagesTotal); err != nil {
+		return domain.BackupProgress{}, fmt.Errorf("online backup page count: %w", err)
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return domain.BackupProgress{}, fmt.Errorf("online backup conn: %w", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn any) error {
+		source, ok := driverConn.(onlineBackuper)
+		if !ok {
+			return fmt.Errorf("driver does not support online backup")
+		}
+		backup, err := source.NewBackup(outputPath)
+		if err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+
+		for more := true; more; {
+			if err := ctx.Err(); err != nil {
+				_ = backup.Finish()
+				return err
+			}
+			if more, err = backup.Step(int32(pagesPerStep)); err != nil {
+				_ = backup.Finish()
+				return fmt.Errorf("step: %w", err)
+			}
+
+			state.PagesCopied = min(state.PagesCopied+int64(pagesPerStep), state.PagesTotal)
+			if !more {
+				state.PagesCopied = state.PagesTotal
+			}
+			if progress != nil {
+				progress(state)
+			}
+		}
+		return backup.Finish()
+	})
+	if err != nil {
+		return domain.BackupProgress{}, fmt.Errorf("online backup: %w", err)
+	}
+	return state, nil
+}
+
+// OpenSnapshot copies db into a temporary file with VACUUM INTO, which reads
+// the whole database inside one transaction, and opens the copy. Reads
+// against the copy see a single point in time however many queries they
+// take, while writers keep using db. closeSnapshot closes the copy and
+// removes it.
+func OpenSnapshot(ctx context.Context, db *sql.DB) (snapshot *sql.DB, closeSnapshot func() error, err error) {
+	if db == nil {
+		return nil, nil, fmt.Errorf("snapshot: db is nil")
+	}
+
+	dir, err := os.MkdirTemp("", "boring-budget-snapshot-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("snapshot: %w", err)
+	}
+	path := filepath.Join(dir, "snapshot.sqlite")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("VACUUM INTO '%s';", strings.ReplaceAll(path, "'", "''"))); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, nil, fmt.Errorf("snapshot copy: %w", err)
+	}
+
+	snapshot, err = Open(ctx, path)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, nil, err
+	}
+	return snapshot, func() error {
+		closeErr := snapshot.Close()
+		if err := os.RemoveAll(dir); err != nil && closeErr == nil {
+			closeErr = err
+		}
+		return closeErr
+	}, nil
+}
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestOpenSnapshotIsolatesLaterWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openFXTestDB(t)
+	defer db.Close()
+
+	categories := NewCategoryRepo(db)
+	if _, err := categories.Add(ctx, "Food"); err != nil {
+		t.Fatalf("add category: %v", err)
+	}
+
+	snapshot, closeSnapshot, err := OpenSnapshot(ctx, db)
+	if err != nil {
+		t.Fatalf("open snapshot: %v", err)
+	}
+	var path string
+	if err := snapshot.QueryRowContext(ctx, "SELECT file FROM pragma_database_list WHERE name = 'main';").Scan(&path); err != nil {
+		t.Fatalf("snapshot path: %v", err)
+	}
+
+	if _, err := categories.Add(ctx, "Rent"); err != nil {
+		t.Fatalf("add category after snapshot: %v", err)
+	}
+
+	snapshotCategories, err := NewCategoryRepo(snapshot).List(ctx)
+	if err != nil {
+		t.Fatalf("list snapshot categories: %v", err)
+	}
+	if len(snapshotCategories) != 1 || snapshotCategories[0].Name != "Food" {
+		t.Fatalf("expected the snapshot to hold only the category added before it, got %+v", snapshotCategories)
+	}
+
+	if err := closeSnapshot(); err != nil {
+		t.Fatalf("close snapshot: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected snapshot file %q to be removed, stat err=%v", path, err)
+	}
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// busy_timeout already waits for locks held by other processes. These retries
+// cover what it cannot wait out: a deferred transaction whose WAL snapshot
+// went stale because another process committed between its first read and
+// its first write (SQLITE_BUSY_SNAPSHOT).
+const (
+	busyRetryAttempts = 4
+	busyRetryBackoff  = 25 * time.Millisecond
+)
+
+func isBusyErr(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryOnBusy reruns write with a linear backoff while it fails with a busy
+// error. Only writes that own their transaction are retried; a write bound to
+// a caller's transaction runs once because only the caller can restart it.
+func retryOnBusy[T any](ctx context.Context, ownsTx bool, write func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := write()
+		if err == nil || !ownsTx || attempt >= busyRetryAttempts || !isBusyErr(err) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(time.Duration(attempt) * busyRetryBackoff):
+		}
+	}
+}
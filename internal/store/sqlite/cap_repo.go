@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -39,6 +40,18 @@ func (r *CapRepo) BindTx(tx *sql.Tx) ports.EntryCapLookup {
 }
 
 func (r *CapRepo) Set(ctx context.Context, input domain.CapSetInput) (domain.MonthlyCap, domain.MonthlyCapChange, error) {
+	type setResult struct {
+		cap    domain.MonthlyCap
+		change domain.MonthlyCapChange
+	}
+	result, err := retryOnBusy(ctx, r.tx == nil, func() (setResult, error) {
+		capValue, change, err := r.set(ctx, input)
+		return setResult{cap: capValue, change: change}, err
+	})
+	return result.cap, result.change, err
+}
+
+func (r *CapRepo) set(ctx context.Context, input domain.CapSetInput) (domain.MonthlyCap, domain.MonthlyCapChange, error) {
 	tx, qtx, ownsTx, err := r.writeQueries(ctx, "set cap")
 	if err != nil {
 		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
@@ -49,86 +62,191 @@ func (r *CapRepo) Set(ctx context.Context, input domain.CapSetInput) (domain.Mon
 		}()
 	}
 
-	existing, err := qtx.GetMonthlyCapByMonthKey(ctx, input.MonthKey)
-	hasExisting := err == nil
-	if err != nil && err != sql.ErrNoRows {
-		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap load existing: %w", err)
+	capChange, err := setCap(ctx, qtx, input, time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
 	}
 
-	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
-	oldAmount := sql.NullInt64{}
+	if ownsTx {
+		if err := tx.Commit(); err != nil {
+			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap commit: %w", err)
+		}
+	}
 
-	if hasExisting {
-		oldAmount = sql.NullInt64{Int64: existing.AmountMinor, Valid: true}
+	currentCap, err := r.getCap(ctx, input.MonthKey, input.CategoryID)
+	if err != nil {
+		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+	}
 
-		updateResult, err := qtx.UpdateMonthlyCapByMonthKey(ctx, queries.UpdateMonthlyCapByMonthKeyParams{
-			AmountMinor:  input.AmountMinor,
-			CurrencyCode: input.CurrencyCode,
-			UpdatedAtUtc: nowUTC,
-			MonthKey:     input.MonthKey,
-		})
+	return currentCap, capChange, nil
+}
+
+// Roll copies every cap of fromMonth (global and per-category) into toMonth
+// in one transaction. Caps that already exist in toMonth are kept.
+func (r *CapRepo) Roll(ctx context.Context, fromMonth, toMonth string) (domain.CapRollResult, error) {
+	tx, qtx, ownsTx, err := r.writeQueries(ctx, "roll caps")
+	if err != nil {
+		return domain.CapRollResult{}, err
+	}
+	if ownsTx {
+		defer func() {
+			_ = tx.Rollback()
+		}()
+	}
+
+	sources := []domain.MonthlyCap{}
+	globalCap, err := qtx.GetMonthlyCapByMonthKey(ctx, fromMonth)
+	if err != nil && err != sql.ErrNoRows {
+		return domain.CapRollResult{}, fmt.Errorf("roll caps load global cap: %w", err)
+	}
+	if err == nil {
+		sources = append(sources, mapSQLCCapToDomain(globalCap))
+	}
+	categoryCaps, err := qtx.ListMonthlyCategoryCapsByMonthKey(ctx, fromMonth)
+	if err != nil {
+		return domain.CapRollResult{}, fmt.Errorf("roll caps load category caps: %w", err)
+	}
+	for _, row := range categoryCaps {
+		sources = append(sources, mapSQLCCategoryCapToDomain(row))
+	}
+	if len(sources) == 0 {
+		return domain.CapRollResult{}, domain.ErrCapNotFound
+	}
+
+	result := domain.CapRollResult{
+		FromMonthKey: fromMonth,
+		ToMonthKey:   toMonth,
+		Copied:       []domain.MonthlyCap{},
+		Skipped:      []domain.MonthlyCap{},
+		Changes:      []domain.MonthlyCapChange{},
+	}
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	copiedFrom := fromMonth
+	for _, source := range sources {
+		exists, err := capExists(ctx, qtx, toMonth, source.CategoryID)
 		if err != nil {
-			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap update: %w", err)
+			return domain.CapRollResult{}, err
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, source)
+			continue
 		}
 
-		rowsAffected, err := updateResult.RowsAffected()
-		if err != nil {
-			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap update rows: %w", err)
+		capChange, err := setCap(ctx, qtx, domain.CapSetInput{
+			MonthKey:           toMonth,
+			CategoryID:         source.CategoryID,
+			AmountMinor:        source.AmountMinor,
+			CurrencyCode:       source.CurrencyCode,
+			CopiedFromMonthKey: &copiedFrom,
+		}, nowUTC)
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			result.Skipped = append(result.Skipped, source)
+			continue
 		}
-		if rowsAffected == 0 {
-			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, domain.ErrCapNotFound
+		if err != nil {
+			return domain.CapRollResult{}, err
 		}
-	} else {
-		if _, err := qtx.CreateMonthlyCap(ctx, queries.CreateMonthlyCapParams{
-			MonthKey:     input.MonthKey,
-			AmountMinor:  input.AmountMinor,
-			CurrencyCode: input.CurrencyCode,
-			UpdatedAtUtc: nowUTC,
-		}); err != nil {
-			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap create: %w", err)
+		result.Changes = append(result.Changes, capChange)
+	}
+
+	if ownsTx {
+		if err := tx.Commit(); err != nil {
+			return domain.CapRollResult{}, fmt.Errorf("roll caps commit: %w", err)
 		}
 	}
 
-	changeResult, err := qtx.CreateMonthlyCapChange(ctx, queries.CreateMonthlyCapChangeParams{
-		MonthKey:       input.MonthKey,
-		OldAmountMinor: oldAmount,
-		NewAmountMinor: input.AmountMinor,
-		CurrencyCode:   input.CurrencyCode,
-		ChangedAtUtc:   nowUTC,
-	})
-	if err != nil {
-		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap create change: %w", err)
+	for _, capChange := range result.Changes {
+		copied, err := r.getCap(ctx, toMonth, capChange.CategoryID)
+		if err != nil {
+			return domain.CapRollResult{}, err
+		}
+		result.Copied = append(result.Copied, copied)
 	}
+	return result, nil
+}
 
-	changeID, err := changeResult.LastInsertId()
+// setCap writes a global or category cap plus its history row.
+func setCap(ctx context.Context, qtx *queries.Queries, input domain.CapSetInput, nowUTC string) (domain.MonthlyCapChange, error) {
+	var (
+		oldAmount sql.NullInt64
+		err       error
+	)
+	if input.CategoryID != nil {
+		oldAmount, err = upsertMonthlyCategoryCap(ctx, qtx, input, nowUTC)
+	} else {
+		oldAmount, err = upsertMonthlyCap(ctx, qtx, input, nowUTC)
+	}
 	if err != nil {
-		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap read change id: %w", err)
+		return domain.MonthlyCapChange{}, err
 	}
 
-	if ownsTx {
-		if err := tx.Commit(); err != nil {
-			return domain.MonthlyCap{}, domain.MonthlyCapChange{}, fmt.Errorf("set cap commit: %w", err)
-		}
+	changeResult, err := qtx.CreateMonthlyCapChange(ctx, queries.CreateMonthlyCapChangeParams{
+		MonthKey:           input.MonthKey,
+		OldAmountMinor:     oldAmount,
+		NewAmountMinor:     input.AmountMinor,
+		CurrencyCode:       input.CurrencyCode,
+		ChangedAtUtc:       nowUTC,
+		CategoryID:         nullableInt64Ptr(input.CategoryID),
+		CopiedFromMonthKey: nullableStringPtr(input.CopiedFromMonthKey),
+	})
+	if err != nil {
+		return domain.MonthlyCapChange{}, fmt.Errorf("set cap create change: %w", err)
 	}
 
-	currentCap, err := r.GetByMonth(ctx, input.MonthKey)
+	changeID, err := changeResult.LastInsertId()
 	if err != nil {
-		return domain.MonthlyCap{}, domain.MonthlyCapChange{}, err
+		return domain.MonthlyCapChange{}, fmt.Errorf("set cap read change id: %w", err)
 	}
 
 	capChange := domain.MonthlyCapChange{
-		ID:             changeID,
-		MonthKey:       input.MonthKey,
-		NewAmountMinor: input.AmountMinor,
-		CurrencyCode:   input.CurrencyCode,
-		ChangedAtUTC:   nowUTC,
+		ID:                 changeID,
+		MonthKey:           input.MonthKey,
+		CategoryID:         input.CategoryID,
+		NewAmountMinor:     input.AmountMinor,
+		CurrencyCode:       input.CurrencyCode,
+		ChangedAtUTC:       nowUTC,
+		CopiedFromMonthKey: input.CopiedFromMonthKey,
 	}
 	if oldAmount.Valid {
 		old := oldAmount.Int64
 		capChange.OldAmountMinor = &old
 	}
+	return capChange, nil
+}
 
-	return currentCap, capChange, nil
+// checkCapExpectedUpdatedAt enforces CapSetInput.ExpectedUpdatedAtUTC: the cap
+// must exist and still carry the timestamp the caller read.
+func checkCapExpectedUpdatedAt(input domain.CapSetInput, updatedAtUTC string, exists bool) error {
+	if input.ExpectedUpdatedAtUTC == nil {
+		return nil
+	}
+	if !exists || updatedAtUTC != *input.ExpectedUpdatedAtUTC {
+		return domain.ErrUpdateConflict
+	}
+	return nil
+}
+
+func capExists(ctx context.Context, qtx *queries.Queries, monthKey string, categoryID *int64) (bool, error) {
+	var err error
+	if categoryID != nil {
+		_, err = qtx.GetMonthlyCategoryCap(ctx, queries.GetMonthlyCategoryCapParams{MonthKey: monthKey, CategoryID: *categoryID})
+	} else {
+		_, err = qtx.GetMonthlyCapByMonthKey(ctx, monthKey)
+	}
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("load cap: %w", err)
+	}
+	return true, nil
+}
+
+func (r *CapRepo) getCap(ctx context.Context, monthKey string, categoryID *int64) (domain.MonthlyCap, error) {
+	if categoryID != nil {
+		return r.GetCategoryCap(ctx, monthKey, *categoryID)
+	}
+	return r.GetByMonth(ctx, monthKey)
 }
 
 func (r *CapRepo) GetByMonth(ctx context.Context, monthKey string) (domain.MonthlyCap, error) {
@@ -147,6 +265,135 @@ func (r *CapRepo) GetByMonth(ctx context.Context, monthKey string) (domain.Month
 	return mapSQLCCapToDomain(row), nil
 }
 
+// upsertMonthlyCap writes the global cap and returns the amount it replaced.
+func upsertMonthlyCap(ctx context.Context, qtx *queries.Queries, input domain.CapSetInput, nowUTC string) (sql.NullInt64, error) {
+	existing, err := qtx.GetMonthlyCapByMonthKey(ctx, input.MonthKey)
+	if err != nil && err != sql.ErrNoRows {
+		return sql.NullInt64{}, fmt.Errorf("set cap load existing: %w", err)
+	}
+	if err := checkCapExpectedUpdatedAt(input, existing.UpdatedAtUtc, err == nil); err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	if err == sql.ErrNoRows {
+		if _, err := qtx.CreateMonthlyCap(ctx, queries.CreateMonthlyCapParams{
+			MonthKey:     input.MonthKey,
+			AmountMinor:  input.AmountMinor,
+			CurrencyCode: input.CurrencyCode,
+			UpdatedAtUtc: nowUTC,
+		}); err != nil {
+			return sql.NullInt64{}, fmt.Errorf("set cap create: %w", err)
+		}
+		return sql.NullInt64{}, nil
+	}
+
+	updateResult, err := qtx.UpdateMonthlyCapByMonthKey(ctx, queries.UpdateMonthlyCapByMonthKeyParams{
+		AmountMinor:  input.AmountMinor,
+		CurrencyCode: input.CurrencyCode,
+		UpdatedAtUtc: nowUTC,
+		MonthKey:     input.MonthKey,
+	})
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("set cap update: %w", err)
+	}
+	if err := requireCapRowsAffected(updateResult); err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: existing.AmountMinor, Valid: true}, nil
+}
+
+// upsertMonthlyCategoryCap writes a category cap for an active category and
+// returns the amount it replaced.
+func upsertMonthlyCategoryCap(ctx context.Context, qtx *queries.Queries, input domain.CapSetInput, nowUTC string) (sql.NullInt64, error) {
+	categoryID := *input.CategoryID
+	if _, err := qtx.GetActiveCategoryByID(ctx, categoryID); err != nil {
+		if err == sql.ErrNoRows {
+			return sql.NullInt64{}, domain.ErrCategoryNotFound
+		}
+		return sql.NullInt64{}, fmt.Errorf("set category cap load category: %w", err)
+	}
+
+	existing, err := qtx.GetMonthlyCategoryCap(ctx, queries.GetMonthlyCategoryCapParams{MonthKey: input.MonthKey, CategoryID: categoryID})
+	if err != nil && err != sql.ErrNoRows {
+		return sql.NullInt64{}, fmt.Errorf("set category cap load existing: %w", err)
+	}
+	if err := checkCapExpectedUpdatedAt(input, existing.UpdatedAtUtc, err == nil); err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	if err == sql.ErrNoRows {
+		if _, err := qtx.CreateMonthlyCategoryCap(ctx, queries.CreateMonthlyCategoryCapParams{
+			MonthKey:     input.MonthKey,
+			CategoryID:   categoryID,
+			AmountMinor:  input.AmountMinor,
+			CurrencyCode: input.CurrencyCode,
+			UpdatedAtUtc: nowUTC,
+		}); err != nil {
+			return sql.NullInt64{}, fmt.Errorf("set category cap create: %w", err)
+		}
+		return sql.NullInt64{}, nil
+	}
+
+	updateResult, err := qtx.UpdateMonthlyCategoryCap(ctx, queries.UpdateMonthlyCategoryCapParams{
+		AmountMinor:  input.AmountMinor,
+		CurrencyCode: input.CurrencyCode,
+		UpdatedAtUtc: nowUTC,
+		MonthKey:     input.MonthKey,
+		CategoryID:   categoryID,
+	})
+	if err != nil {
+		return sql.NullInt64{}, fmt.Errorf("set category cap update: %w", err)
+	}
+	if err := requireCapRowsAffected(updateResult); err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: existing.AmountMinor, Valid: true}, nil
+}
+
+func requireCapRowsAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("set cap update rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrCapNotFound
+	}
+	return nil
+}
+
+func (r *CapRepo) GetCategoryCap(ctx context.Context, monthKey string, categoryID int64) (domain.MonthlyCap, error) {
+	if r.db == nil && r.tx == nil {
+		return domain.MonthlyCap{}, fmt.Errorf("get category cap: db is nil")
+	}
+
+	row, err := r.queries.GetMonthlyCategoryCap(ctx, queries.GetMonthlyCategoryCapParams{MonthKey: monthKey, CategoryID: categoryID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.MonthlyCap{}, domain.ErrCapNotFound
+		}
+		return domain.MonthlyCap{}, fmt.Errorf("get category cap: %w", err)
+	}
+
+	return mapSQLCCategoryCapToDomain(row), nil
+}
+
+func (r *CapRepo) ListCategoryCapsByMonth(ctx context.Context, monthKey string) ([]domain.MonthlyCap, error) {
+	if r.db == nil && r.tx == nil {
+		return nil, fmt.Errorf("list category caps: db is nil")
+	}
+
+	rows, err := r.queries.ListMonthlyCategoryCapsByMonthKey(ctx, monthKey)
+	if err != nil {
+		return nil, fmt.Errorf("list category caps: %w", err)
+	}
+
+	caps := make([]domain.MonthlyCap, 0, len(rows))
+	for _, row := range rows {
+		caps = append(caps, mapSQLCCategoryCapToDomain(row))
+	}
+	return caps, nil
+}
+
 func (r *CapRepo) ListChangesByMonth(ctx context.Context, monthKey string) ([]domain.MonthlyCapChange, error) {
 	if r.db == nil && r.tx == nil {
 		return nil, fmt.Errorf("list cap changes: db is nil")
@@ -186,6 +433,29 @@ func (r *CapRepo) GetExpenseTotalByMonthAndCurrency(ctx context.Context, monthKe
 	return total, nil
 }
 
+func (r *CapRepo) GetCategoryExpenseTotalByMonthAndCurrency(ctx context.Context, monthKey string, categoryID int64, currencyCode string) (int64, error) {
+	if r.db == nil && r.tx == nil {
+		return 0, fmt.Errorf("sum category expenses by month and currency: db is nil")
+	}
+
+	monthStartUTC, monthEndUTC, err := domain.MonthRangeUTC(monthKey)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := r.queries.SumActiveExpensesByMonthCategoryAndCurrency(ctx, queries.SumActiveExpensesByMonthCategoryAndCurrencyParams{
+		CategoryID:           sql.NullInt64{Int64: categoryID, Valid: true},
+		CurrencyCode:         currencyCode,
+		TransactionDateUtc:   monthStartUTC,
+		TransactionDateUtc_2: monthEndUTC,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("sum category expenses by month and currency: %w", err)
+	}
+
+	return total, nil
+}
+
 func (r *CapRepo) writeQueries(ctx context.Context, operation string) (*sql.Tx, *queries.Queries, bool, error) {
 	if r.tx != nil {
 		return nil, r.queries, false, nil
@@ -214,6 +484,19 @@ func mapSQLCCapToDomain(row queries.MonthlyCap) domain.MonthlyCap {
 	}
 }
 
+func mapSQLCCategoryCapToDomain(row queries.MonthlyCategoryCap) domain.MonthlyCap {
+	categoryID := row.CategoryID
+	return domain.MonthlyCap{
+		ID:           row.ID,
+		MonthKey:     row.MonthKey,
+		CategoryID:   &categoryID,
+		AmountMinor:  row.AmountMinor,
+		CurrencyCode: row.CurrencyCode,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+}
+
 func mapSQLCCapChangeToDomain(row queries.MonthlyCapChange) domain.MonthlyCapChange {
 	change := domain.MonthlyCapChange{
 		ID:             row.ID,
@@ -227,6 +510,14 @@ func mapSQLCCapChangeToDomain(row queries.MonthlyCapChange) domain.MonthlyCapCha
 		old := row.OldAmountMinor.Int64
 		change.OldAmountMinor = &old
 	}
+	if row.CategoryID.Valid {
+		categoryID := row.CategoryID.Int64
+		change.CategoryID = &categoryID
+	}
+	if row.CopiedFromMonthKey.Valid {
+		copiedFrom := row.CopiedFromMonthKey.String
+		change.CopiedFromMonthKey = &copiedFrom
+	}
 
 	return change
 }
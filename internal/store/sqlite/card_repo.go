@@ -46,13 +46,15 @@ func (r *CardRepo) AddCard(ctx context.Context, input ports.CardCreateInput) (po
 	}
 
 	result, err := r.queries.CreateCard(ctx, queries.CreateCardParams{
-		Nickname:     strings.TrimSpace(input.Nickname),
-		Description:  nullableStringPtr(input.Description),
-		Last4:        strings.TrimSpace(input.Last4),
-		Brand:        strings.TrimSpace(input.Brand),
-		CardType:     strings.TrimSpace(input.CardType),
-		DueDay:       nullableInt64Ptr(input.DueDay),
-		UpdatedAtUtc: nowRFC3339Nano(),
+		Nickname:            strings.TrimSpace(input.Nickname),
+		Description:         nullableStringPtr(input.Description),
+		Last4:               strings.TrimSpace(input.Last4),
+		Brand:               strings.TrimSpace(input.Brand),
+		CardType:            strings.TrimSpace(input.CardType),
+		DueDay:              nullableInt64Ptr(input.DueDay),
+		DefaultCurrencyCode: nullableStringPtr(input.DefaultCurrencyCode),
+		FxFeeBp:             nullableInt64Ptr(input.FXFeeBasisPoints),
+		UpdatedAtUtc:        nowRFC3339Nano(),
 	})
 	if err != nil {
 		if isUniqueConstraintErr(err) {
@@ -93,8 +95,10 @@ func (r *CardRepo) GetCardByID(ctx context.Context, id int64, includeDeleted boo
 
 func (r *CardRepo) ListCards(ctx context.Context, filter ports.CardListFilter) ([]ports.Card, error) {
 	params := queries.ListCardsParams{
-		IncludeDeleted: boolAsInt64(filter.IncludeDeleted),
-		CardType:       nullableString(filter.CardType),
+		IncludeDeleted:  boolAsInt64(filter.IncludeDeleted),
+		IncludeArchived: boolAsInt64(filter.IncludeArchived),
+		CardType:        nullableString(filter.CardType),
+		SortKey:         filter.Sort,
 	}
 	rows, err := r.queries.ListCards(ctx, params)
 	if err != nil {
@@ -108,7 +112,7 @@ func (r *CardRepo) ListCards(ctx context.Context, filter ports.CardListFilter) (
 	return out, nil
 }
 
-func (r *CardRepo) SearchCards(ctx context.Context, lookup string, limit int32) ([]ports.Card, error) {
+func (r *CardRepo) SearchCards(ctx context.Context, lookup string, includeArchived bool, limit int32) ([]ports.Card, error) {
 	trimmed := strings.TrimSpace(lookup)
 	if trimmed == "" {
 		return nil, ports.ErrCardLookupTextRequired
@@ -118,8 +122,9 @@ func (r *CardRepo) SearchCards(ctx context.Context, lookup string, limit int32)
 	}
 
 	rows, err := r.queries.SearchActiveCardsByLookup(ctx, queries.SearchActiveCardsByLookupParams{
-		LookupText: trimmed,
-		LimitRows:  int64(limit),
+		IncludeArchived: boolAsInt64(includeArchived),
+		LookupText:      trimmed,
+		LimitRows:       int64(limit),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("search cards: %w", err)
@@ -132,31 +137,68 @@ func (r *CardRepo) SearchCards(ctx context.Context, lookup string, limit int32)
 	return out, nil
 }
 
+func (r *CardRepo) FindCardsByName(ctx context.Context, name string, includeArchived bool) ([]ports.Card, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" {
+		return nil, ports.ErrCardLookupTextRequired
+	}
+
+	rows, err := r.queries.ListActiveCardsByNicknameOrAlias(ctx, queries.ListActiveCardsByNicknameOrAliasParams{
+		IncludeArchived: boolAsInt64(includeArchived),
+		Name:            trimmed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find cards by name: %w", err)
+	}
+
+	out := make([]ports.Card, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapSQLCCard(row))
+	}
+	return out, nil
+}
+
 func (r *CardRepo) UpdateCard(ctx context.Context, input ports.CardUpdateInput) (ports.Card, error) {
+	return retryOnBusy(ctx, r.tx == nil, func() (ports.Card, error) {
+		return r.updateCard(ctx, input)
+	})
+}
+
+func (r *CardRepo) updateCard(ctx context.Context, input ports.CardUpdateInput) (ports.Card, error) {
 	if input.ID <= 0 {
 		return ports.Card{}, ports.ErrCardInvalidID
 	}
 	if err := validateCardTypeDueDay(input.CardType, input.SetDueDay, input.DueDay); err != nil {
 		return ports.Card{}, err
 	}
+	if input.SetFXFee && input.FXFeeBasisPoints != nil {
+		if err := validateCardFXFee(*input.FXFeeBasisPoints); err != nil {
+			return ports.Card{}, err
+		}
+	}
 
 	result, err := r.queries.UpdateCardByID(ctx, queries.UpdateCardByIDParams{
-		SetNickname:      boolAsInt64(input.Nickname != nil),
-		Nickname:         derefString(input.Nickname),
-		ClearDescription: boolAsInt64(input.SetDescription && input.Description == nil),
-		SetDescription:   boolAsInt64(input.SetDescription && input.Description != nil),
-		Description:      nullableStringPtr(input.Description),
-		SetLast4:         boolAsInt64(input.Last4 != nil),
-		Last4:            derefString(input.Last4),
-		SetBrand:         boolAsInt64(input.Brand != nil),
-		Brand:            derefString(input.Brand),
-		SetCardType:      boolAsInt64(input.CardType != nil),
-		CardType:         derefString(input.CardType),
-		ClearDueDay:      boolAsInt64(input.SetDueDay && input.DueDay == nil),
-		SetDueDay:        boolAsInt64(input.SetDueDay && input.DueDay != nil),
-		DueDay:           nullableInt64Ptr(input.DueDay),
-		UpdatedAtUtc:     nowRFC3339Nano(),
-		ID:               input.ID,
+		SetNickname:            boolAsInt64(input.Nickname != nil),
+		Nickname:               derefString(input.Nickname),
+		ClearDescription:       boolAsInt64(input.SetDescription && input.Description == nil),
+		SetDescription:         boolAsInt64(input.SetDescription && input.Description != nil),
+		Description:            nullableStringPtr(input.Description),
+		SetLast4:               boolAsInt64(input.Last4 != nil),
+		Last4:                  derefString(input.Last4),
+		SetBrand:               boolAsInt64(input.Brand != nil),
+		Brand:                  derefString(input.Brand),
+		SetCardType:            boolAsInt64(input.CardType != nil),
+		CardType:               derefString(input.CardType),
+		ClearDueDay:            boolAsInt64(input.SetDueDay && input.DueDay == nil),
+		SetDueDay:              boolAsInt64(input.SetDueDay && input.DueDay != nil),
+		DueDay:                 nullableInt64Ptr(input.DueDay),
+		SetDefaultCurrencyCode: boolAsInt64(input.SetDefaultCurrency),
+		DefaultCurrencyCode:    nullableStringPtr(input.DefaultCurrencyCode),
+		SetFxFeeBp:             boolAsInt64(input.SetFXFee),
+		FxFeeBp:                nullableInt64Ptr(input.FXFeeBasisPoints),
+		UpdatedAtUtc:           nowRFC3339Nano(),
+		ID:                     input.ID,
+		ExpectedUpdatedAtUtc:   nullableStringPtr(input.ExpectedUpdatedAtUTC),
 	})
 	if err != nil {
 		if isUniqueConstraintErr(err) {
@@ -170,12 +212,53 @@ func (r *CardRepo) UpdateCard(ctx context.Context, input ports.CardUpdateInput)
 		return ports.Card{}, fmt.Errorf("update card rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
+		if input.ExpectedUpdatedAtUTC != nil {
+			if _, err := r.GetCardByID(ctx, input.ID, false); err == nil {
+				return ports.Card{}, ports.ErrCardUpdateConflict
+			}
+		}
 		return ports.Card{}, ports.ErrCardNotFound
 	}
 
 	return r.GetCardByID(ctx, input.ID, false)
 }
 
+// SetCardArchived archives or unarchives an active card. Archiving an already
+// archived card keeps its original archived_at_utc.
+func (r *CardRepo) SetCardArchived(ctx context.Context, id int64, archived bool) (ports.Card, error) {
+	current, err := r.GetCardByID(ctx, id, false)
+	if err != nil {
+		return ports.Card{}, err
+	}
+	if (current.ArchivedAtUTC != nil) == archived {
+		return current, nil
+	}
+
+	nowUTC := nowRFC3339Nano()
+	archivedAt := sql.NullString{}
+	if archived {
+		archivedAt = sql.NullString{String: nowUTC, Valid: true}
+	}
+	result, err := r.queries.SetActiveCardArchivedAt(ctx, queries.SetActiveCardArchivedAtParams{
+		ArchivedAtUtc: archivedAt,
+		UpdatedAtUtc:  nowUTC,
+		ID:            id,
+	})
+	if err != nil {
+		return ports.Card{}, fmt.Errorf("set card archived: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ports.Card{}, fmt.Errorf("set card archived rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ports.Card{}, ports.ErrCardNotFound
+	}
+
+	return r.GetCardByID(ctx, id, false)
+}
+
 func (r *CardRepo) DeleteCard(ctx context.Context, id int64) (ports.CardDeleteResult, error) {
 	if id <= 0 {
 		return ports.CardDeleteResult{}, ports.ErrCardInvalidID
@@ -205,6 +288,91 @@ func (r *CardRepo) DeleteCard(ctx context.Context, id int64) (ports.CardDeleteRe
 	}, nil
 }
 
+func (r *CardRepo) AddCardAlias(ctx context.Context, cardID int64, alias string) (ports.CardAlias, error) {
+	if cardID <= 0 {
+		return ports.CardAlias{}, ports.ErrCardInvalidID
+	}
+	trimmed := strings.TrimSpace(alias)
+	if trimmed == "" {
+		return ports.CardAlias{}, ports.ErrCardAliasRequired
+	}
+
+	result, err := r.queries.CreateCardAlias(ctx, queries.CreateCardAliasParams{
+		CardID: cardID,
+		Alias:  trimmed,
+	})
+	if err != nil {
+		if isUniqueConstraintErr(err) {
+			return ports.CardAlias{}, ports.ErrCardAliasConflict
+		}
+		return ports.CardAlias{}, fmt.Errorf("add card alias: %w", err)
+	}
+
+	aliasID, err := result.LastInsertId()
+	if err != nil {
+		return ports.CardAlias{}, fmt.Errorf("add card alias read id: %w", err)
+	}
+
+	row, err := r.queries.GetCardAliasByID(ctx, aliasID)
+	if err != nil {
+		return ports.CardAlias{}, fmt.Errorf("get card alias: %w", err)
+	}
+	return mapCardAliasRow(row), nil
+}
+
+func (r *CardRepo) RemoveCardAlias(ctx context.Context, cardID int64, alias string) (ports.CardAlias, error) {
+	if cardID <= 0 {
+		return ports.CardAlias{}, ports.ErrCardInvalidID
+	}
+	trimmed := strings.TrimSpace(alias)
+	if trimmed == "" {
+		return ports.CardAlias{}, ports.ErrCardAliasRequired
+	}
+
+	row, err := r.queries.GetCardAliasByCardAndAlias(ctx, queries.GetCardAliasByCardAndAliasParams{
+		CardID: cardID,
+		Alias:  trimmed,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.CardAlias{}, ports.ErrCardAliasNotFound
+		}
+		return ports.CardAlias{}, fmt.Errorf("get card alias: %w", err)
+	}
+
+	result, err := r.queries.DeleteCardAlias(ctx, row.ID)
+	if err != nil {
+		return ports.CardAlias{}, fmt.Errorf("remove card alias: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ports.CardAlias{}, fmt.Errorf("remove card alias rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ports.CardAlias{}, ports.ErrCardAliasNotFound
+	}
+	return mapCardAliasRow(row), nil
+}
+
+// ListCardAliases lists the aliases of active cards, only cardID's when it is
+// set.
+func (r *CardRepo) ListCardAliases(ctx context.Context, cardID *int64) ([]ports.CardAlias, error) {
+	if cardID != nil && *cardID <= 0 {
+		return nil, ports.ErrCardInvalidID
+	}
+
+	rows, err := r.queries.ListCardAliases(ctx, nullableInt64Ptr(cardID))
+	if err != nil {
+		return nil, fmt.Errorf("list card aliases: %w", err)
+	}
+
+	out := make([]ports.CardAlias, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapCardAliasRow(row))
+	}
+	return out, nil
+}
+
 func (r *CardRepo) GetCardDue(ctx context.Context, cardID int64, asOfDate string) (ports.CardDue, error) {
 	if cardID <= 0 {
 		return ports.CardDue{}, ports.ErrCardInvalidID
@@ -441,8 +609,8 @@ func (r *CardRepo) GetDebtSummaryByCard(ctx context.Context, cardID int64) ([]po
 	return out, nil
 }
 
-func (r *CardRepo) GetDebtSummary(ctx context.Context) ([]ports.CardDebtBucket, error) {
-	rows, err := r.queries.ListCreditLiabilitySummaryAllCards(ctx)
+func (r *CardRepo) GetDebtSummary(ctx context.Context, sortKey string) ([]ports.CardDebtBucket, error) {
+	rows, err := r.queries.ListCreditLiabilitySummaryAllCards(ctx, sortKey)
 	if err != nil {
 		return nil, fmt.Errorf("get debt summary: %w", err)
 	}
@@ -474,6 +642,137 @@ func (r *CardRepo) GetDebtBalance(ctx context.Context, cardID int64, currencyCod
 	})
 }
 
+// ListFXFeeCharges lists the charges carrying a foreign transaction fee whose
+// active entry is dated within fromUTC and toUTC, inclusive.
+func (r *CardRepo) ListFXFeeCharges(ctx context.Context, fromUTC, toUTC string, cardID *int64) ([]ports.CardFXFeeCharge, error) {
+	if cardID != nil && *cardID <= 0 {
+		return nil, ports.ErrCardInvalidID
+	}
+
+	rows, err := r.queries.ListCardFXFeeCharges(ctx, queries.ListCardFXFeeChargesParams{
+		FromUtc: fromUTC,
+		ToUtc:   toUTC,
+		CardID:  nullableInt64Ptr(cardID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list card fx fee charges: %w", err)
+	}
+
+	out := make([]ports.CardFXFeeCharge, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, ports.CardFXFeeCharge{
+			CardID:             row.CardID,
+			CardNickname:       row.CardNickname,
+			CurrencyCode:       row.CurrencyCode,
+			FeeMinor:           row.FxFeeMinor,
+			TransactionDateUTC: row.TransactionDateUtc,
+		})
+	}
+	return out, nil
+}
+
+func (r *CardRepo) SetMonthlyLimit(ctx context.Context, input ports.CardMonthlyLimitSetInput) (ports.CardMonthlyLimit, error) {
+	if input.CardID <= 0 {
+		return ports.CardMonthlyLimit{}, ports.ErrCardInvalidID
+	}
+	if input.AmountMinor <= 0 {
+		return ports.CardMonthlyLimit{}, ports.ErrCardMonthlyLimitAmountInvalid
+	}
+	if err := validateCurrencyCode(input.CurrencyCode); err != nil {
+		return ports.CardMonthlyLimit{}, err
+	}
+
+	if _, err := r.queries.UpsertCardMonthlyLimit(ctx, queries.UpsertCardMonthlyLimitParams{
+		CardID:       input.CardID,
+		MonthKey:     strings.TrimSpace(input.MonthKey),
+		AmountMinor:  input.AmountMinor,
+		CurrencyCode: strings.ToUpper(strings.TrimSpace(input.CurrencyCode)),
+		UpdatedAtUtc: nowRFC3339Nano(),
+	}); err != nil {
+		return ports.CardMonthlyLimit{}, fmt.Errorf("set card monthly limit: %w", err)
+	}
+
+	return r.GetMonthlyLimit(ctx, input.CardID, input.MonthKey)
+}
+
+func (r *CardRepo) GetMonthlyLimit(ctx context.Context, cardID int64, monthKey string) (ports.CardMonthlyLimit, error) {
+	if cardID <= 0 {
+		return ports.CardMonthlyLimit{}, ports.ErrCardInvalidID
+	}
+
+	row, err := r.queries.GetCardMonthlyLimit(ctx, queries.GetCardMonthlyLimitParams{
+		CardID:   cardID,
+		MonthKey: strings.TrimSpace(monthKey),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ports.CardMonthlyLimit{}, ports.ErrCardMonthlyLimitNotFound
+		}
+		return ports.CardMonthlyLimit{}, fmt.Errorf("get card monthly limit: %w", err)
+	}
+	return mapCardMonthlyLimitRow(row), nil
+}
+
+func (r *CardRepo) ListMonthlyLimits(ctx context.Context, cardID int64) ([]ports.CardMonthlyLimit, error) {
+	if cardID <= 0 {
+		return nil, ports.ErrCardInvalidID
+	}
+
+	rows, err := r.queries.ListCardMonthlyLimitsByCard(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("list card monthly limits: %w", err)
+	}
+
+	out := make([]ports.CardMonthlyLimit, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mapCardMonthlyLimitRow(row))
+	}
+	return out, nil
+}
+
+// GetCardExpenseTotal sums the card's active expenses in one currency between
+// fromUTC (inclusive) and toUTC (exclusive), net of refunds paid back to it.
+func (r *CardRepo) GetCardExpenseTotal(ctx context.Context, cardID int64, currencyCode, fromUTC, toUTC string) (int64, error) {
+	if cardID <= 0 {
+		return 0, ports.ErrCardInvalidID
+	}
+	if err := validateCurrencyCode(currencyCode); err != nil {
+		return 0, err
+	}
+
+	total, err := r.queries.SumActiveCardExpensesByMonthAndCurrency(ctx, queries.SumActiveCardExpensesByMonthAndCurrencyParams{
+		CardID:               sql.NullInt64{Int64: cardID, Valid: true},
+		CurrencyCode:         strings.ToUpper(strings.TrimSpace(currencyCode)),
+		TransactionDateUtc:   fromUTC,
+		TransactionDateUtc_2: toUTC,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("sum card expenses: %w", err)
+	}
+	return total, nil
+}
+
+func mapCardMonthlyLimitRow(row queries.CardMonthlyLimit) ports.CardMonthlyLimit {
+	return ports.CardMonthlyLimit{
+		ID:           row.ID,
+		CardID:       row.CardID,
+		MonthKey:     row.MonthKey,
+		AmountMinor:  row.AmountMinor,
+		CurrencyCode: row.CurrencyCode,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+}
+
+func mapCardAliasRow(row queries.CardAlias) ports.CardAlias {
+	return ports.CardAlias{
+		ID:           row.ID,
+		CardID:       row.CardID,
+		Alias:        row.Alias,
+		CreatedAtUTC: row.CreatedAtUtc,
+	}
+}
+
 func validateCardCreateInput(input ports.CardCreateInput) error {
 	if strings.TrimSpace(input.Nickname) == "" {
 		return ports.ErrCardNicknameRequired
@@ -506,6 +805,16 @@ func validateCardCreateInput(input ports.CardCreateInput) error {
 	if cardType == ports.CardTypeDebit && input.DueDay != nil {
 		return ports.ErrCardDueDayNotAllowed
 	}
+	if input.DefaultCurrencyCode != nil {
+		if err := validateCurrencyCode(*input.DefaultCurrencyCode); err != nil {
+			return err
+		}
+	}
+	if input.FXFeeBasisPoints != nil {
+		if err := validateCardFXFee(*input.FXFeeBasisPoints); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -579,6 +888,15 @@ func validateLiabilityEventInput(input ports.CreditLiabilityEventInput) error {
 	return nil
 }
 
+// validateCardFXFee only rejects rates no percentage can have; the service
+// enforces the tighter product cap.
+func validateCardFXFee(basisPoints int64) error {
+	if basisPoints < 0 || basisPoints > 10000 {
+		return ports.ErrCardFXFeeInvalid
+	}
+	return nil
+}
+
 func validateCurrencyCode(code string) error {
 	normalized := strings.ToUpper(strings.TrimSpace(code))
 	if len(normalized) != 3 {
@@ -622,16 +940,19 @@ func computeNextDueDate(asOf time.Time, dueDay int) time.Time {
 
 func mapSQLCCard(row queries.Card) ports.Card {
 	return ports.Card{
-		ID:           row.ID,
-		Nickname:     row.Nickname,
-		Description:  ptrStringFromNull(row.Description),
-		Last4:        row.Last4,
-		Brand:        row.Brand,
-		CardType:     row.CardType,
-		DueDay:       ptrInt64FromNull(row.DueDay),
-		CreatedAtUTC: row.CreatedAtUtc,
-		UpdatedAtUTC: row.UpdatedAtUtc,
-		DeletedAtUTC: ptrStringFromNull(row.DeletedAtUtc),
+		ID:                  row.ID,
+		Nickname:            row.Nickname,
+		Description:         ptrStringFromNull(row.Description),
+		Last4:               row.Last4,
+		Brand:               row.Brand,
+		CardType:            row.CardType,
+		DueDay:              ptrInt64FromNull(row.DueDay),
+		CreatedAtUTC:        row.CreatedAtUtc,
+		UpdatedAtUTC:        row.UpdatedAtUtc,
+		DeletedAtUTC:        ptrStringFromNull(row.DeletedAtUtc),
+		ArchivedAtUTC:       ptrStringFromNull(row.ArchivedAtUtc),
+		DefaultCurrencyCode: ptrStringFromNull(row.DefaultCurrencyCode),
+		FXFeeBasisPoints:    ptrInt64FromNull(row.FxFeeBp),
 	}
 }
 
@@ -644,6 +965,7 @@ func mapSQLCCreditLiabilityEvent(row queries.CreditLiabilityEvent) ports.CreditL
 		AmountMinorSigned:      row.AmountMinorSigned,
 		ReferenceTransactionID: ptrInt64FromNull(row.ReferenceTransactionID),
 		Note:                   ptrStringFromNull(row.Note),
+		FXFeeMinor:             row.FxFeeMinor,
 		CreatedAtUTC:           row.CreatedAtUtc,
 	}
 }
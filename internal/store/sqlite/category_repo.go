@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
 	queries "boring-budget/internal/store/sqlite/sqlc"
 )
 
@@ -18,6 +19,8 @@ type CategoryRepo struct {
 	queries *queries.Queries
 }
 
+var _ ports.CategoryCreatorTxBinder = (*CategoryRepo)(nil)
+
 func NewCategoryRepo(db *sql.DB) *CategoryRepo {
 	return &CategoryRepo{
 		db:      db,
@@ -25,6 +28,17 @@ func NewCategoryRepo(db *sql.DB) *CategoryRepo {
 	}
 }
 
+func (r *CategoryRepo) BindTx(tx *sql.Tx) ports.CategoryCreator {
+	if tx == nil {
+		return r
+	}
+
+	return &CategoryRepo{
+		db:      r.db,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
 func (r *CategoryRepo) Add(ctx context.Context, name string) (domain.Category, error) {
 	if r.db == nil {
 		return domain.Category{}, fmt.Errorf("add category: db is nil")
@@ -52,11 +66,17 @@ func (r *CategoryRepo) Add(ctx context.Context, name string) (domain.Category, e
 }
 
 func (r *CategoryRepo) List(ctx context.Context) ([]domain.Category, error) {
+	return r.ListSorted(ctx, domain.CategorySortName)
+}
+
+// ListSorted lists active categories ordered by sortKey, one of
+// domain.CategorySortKeys.
+func (r *CategoryRepo) ListSorted(ctx context.Context, sortKey string) ([]domain.Category, error) {
 	if r.db == nil {
 		return nil, fmt.Errorf("list categories: db is nil")
 	}
 
-	rows, err := r.queries.ListActiveCategories(ctx)
+	rows, err := r.queries.ListActiveCategories(ctx, sortKey)
 	if err != nil {
 		return nil, fmt.Errorf("list categories: %w", err)
 	}
@@ -64,10 +84,13 @@ func (r *CategoryRepo) List(ctx context.Context) ([]domain.Category, error) {
 	categories := make([]domain.Category, 0, len(rows))
 	for _, row := range rows {
 		categories = append(categories, domain.Category{
-			ID:           row.ID,
-			Name:         row.Name,
-			CreatedAtUTC: row.CreatedAtUtc,
-			UpdatedAtUTC: row.UpdatedAtUtc,
+			ID:            row.ID,
+			Name:          row.Name,
+			CreatedAtUTC:  row.CreatedAtUtc,
+			UpdatedAtUTC:  row.UpdatedAtUtc,
+			ArchivedAtUTC: ptrStringFromNull(row.ArchivedAtUtc),
+			Color:         row.Color.String,
+			Icon:          row.Icon.String,
 		})
 	}
 
@@ -133,6 +156,76 @@ func (r *CategoryRepo) Rename(ctx context.Context, id int64, newName string) (do
 	return category, nil
 }
 
+// SetArchived archives or unarchives an active category. Archiving an already
+// archived category keeps its original archived_at_utc.
+func (r *CategoryRepo) SetArchived(ctx context.Context, id int64, archived bool) (domain.Category, error) {
+	if r.db == nil {
+		return domain.Category{}, fmt.Errorf("archive category: db is nil")
+	}
+
+	current, err := r.findActiveByID(ctx, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+	if current.Archived() == archived {
+		return current, nil
+	}
+
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	archivedAt := sql.NullString{}
+	if archived {
+		archivedAt = sql.NullString{String: nowUTC, Valid: true}
+	}
+	result, err := r.queries.SetActiveCategoryArchivedAt(ctx, queries.SetActiveCategoryArchivedAtParams{
+		ArchivedAtUtc: archivedAt,
+		UpdatedAtUtc:  nowUTC,
+		ID:            id,
+	})
+	if err != nil {
+		return domain.Category{}, fmt.Errorf("archive category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Category{}, fmt.Errorf("archive category rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.Category{}, domain.ErrCategoryNotFound
+	}
+
+	return r.findActiveByID(ctx, id)
+}
+
+// UpdateAppearance sets the fields of update that are not nil; empty values
+// clear the stored color or icon.
+func (r *CategoryRepo) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Category, error) {
+	if r.db == nil {
+		return domain.Category{}, fmt.Errorf("update category appearance: db is nil")
+	}
+
+	result, err := r.queries.UpdateActiveCategoryAppearance(ctx, queries.UpdateActiveCategoryAppearanceParams{
+		SetColor:     boolAsInt64(update.Color != nil),
+		Color:        nullableStringPtr(update.Color),
+		SetIcon:      boolAsInt64(update.Icon != nil),
+		Icon:         nullableStringPtr(update.Icon),
+		UpdatedAtUtc: nowRFC3339Nano(),
+		ID:           id,
+	})
+	if err != nil {
+		return domain.Category{}, fmt.Errorf("update category appearance: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Category{}, fmt.Errorf("update category appearance rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.Category{}, domain.ErrCategoryNotFound
+	}
+
+	return r.findActiveByID(ctx, id)
+}
+
 func (r *CategoryRepo) SoftDelete(ctx context.Context, id int64) (domain.CategoryDeleteResult, error) {
 	if r.db == nil {
 		return domain.CategoryDeleteResult{}, fmt.Errorf("delete category: db is nil")
@@ -199,10 +292,13 @@ func (r *CategoryRepo) findActiveByID(ctx context.Context, id int64) (domain.Cat
 	}
 
 	return domain.Category{
-		ID:           row.ID,
-		Name:         row.Name,
-		CreatedAtUTC: row.CreatedAtUtc,
-		UpdatedAtUTC: row.UpdatedAtUtc,
+		ID:            row.ID,
+		Name:          row.Name,
+		CreatedAtUTC:  row.CreatedAtUtc,
+		UpdatedAtUTC:  row.UpdatedAtUtc,
+		ArchivedAtUTC: ptrStringFromNull(row.ArchivedAtUtc),
+		Color:         row.Color.String,
+		Icon:          row.Icon.String,
 	}, nil
 }
 
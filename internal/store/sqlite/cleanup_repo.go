@@ -0,0 +1,146 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type CleanupRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewCleanupRepo(db *sql.DB) *CleanupRepo {
+	return &CleanupRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// Usage counts the active entries of every active, unarchived category, label
+// and card.
+func (r *CleanupRepo) Usage(ctx context.Context) (domain.CleanupUsage, error) {
+	if r.db == nil {
+		return domain.CleanupUsage{}, fmt.Errorf("cleanup usage: db is nil")
+	}
+
+	usage := domain.CleanupUsage{
+		Categories: []domain.CleanupCandidate{},
+		Labels:     []domain.CleanupCandidate{},
+		Cards:      []domain.CleanupCandidate{},
+	}
+
+	categoryRows, err := r.queries.ListCategoryUsage(ctx)
+	if err != nil {
+		return domain.CleanupUsage{}, fmt.Errorf("cleanup category usage: %w", err)
+	}
+	for _, row := range categoryRows {
+		usage.Categories = append(usage.Categories, domain.CleanupCandidate{
+			ID:            row.ID,
+			Name:          row.Name,
+			EntryCount:    row.EntryCount,
+			LastUsedAtUTC: stringFromInterface(row.LastUsedAtUtc),
+		})
+	}
+
+	labelRows, err := r.queries.ListLabelUsage(ctx)
+	if err != nil {
+		return domain.CleanupUsage{}, fmt.Errorf("cleanup label usage: %w", err)
+	}
+	for _, row := range labelRows {
+		usage.Labels = append(usage.Labels, domain.CleanupCandidate{
+			ID:            row.ID,
+			Name:          row.Name,
+			EntryCount:    row.EntryCount,
+			LastUsedAtUTC: stringFromInterface(row.LastUsedAtUtc),
+		})
+	}
+
+	cardRows, err := r.queries.ListCardUsage(ctx)
+	if err != nil {
+		return domain.CleanupUsage{}, fmt.Errorf("cleanup card usage: %w", err)
+	}
+	for _, row := range cardRows {
+		usage.Cards = append(usage.Cards, domain.CleanupCandidate{
+			ID:            row.ID,
+			Name:          row.Nickname,
+			EntryCount:    row.EntryCount,
+			LastUsedAtUTC: stringFromInterface(row.LastUsedAtUtc),
+		})
+	}
+
+	return usage, nil
+}
+
+// Archive archives the given categories, labels and cards in one transaction.
+// One deleted in the meantime fails the whole batch.
+func (r *CleanupRepo) Archive(ctx context.Context, categoryIDs []int64, labelIDs []int64, cardIDs []int64, archivedAtUTC string) error {
+	if r.db == nil {
+		return fmt.Errorf("cleanup archive: db is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cleanup archive begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	archivedAt := sql.NullString{String: archivedAtUTC, Valid: true}
+	for _, id := range categoryIDs {
+		result, err := qtx.SetActiveCategoryArchivedAt(ctx, queries.SetActiveCategoryArchivedAtParams{
+			ArchivedAtUtc: archivedAt,
+			UpdatedAtUtc:  archivedAtUTC,
+			ID:            id,
+		})
+		if err != nil {
+			return fmt.Errorf("cleanup archive category %d: %w", id, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("cleanup archive category rows affected: %w", err)
+		} else if rowsAffected == 0 {
+			return domain.ErrCategoryNotFound
+		}
+	}
+	for _, id := range labelIDs {
+		result, err := qtx.SetActiveLabelArchivedAt(ctx, queries.SetActiveLabelArchivedAtParams{
+			ArchivedAtUtc: archivedAt,
+			UpdatedAtUtc:  archivedAtUTC,
+			ID:            id,
+		})
+		if err != nil {
+			return fmt.Errorf("cleanup archive label %d: %w", id, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("cleanup archive label rows affected: %w", err)
+		} else if rowsAffected == 0 {
+			return domain.ErrLabelNotFound
+		}
+	}
+	for _, id := range cardIDs {
+		result, err := qtx.SetActiveCardArchivedAt(ctx, queries.SetActiveCardArchivedAtParams{
+			ArchivedAtUtc: archivedAt,
+			UpdatedAtUtc:  archivedAtUTC,
+			ID:            id,
+		})
+		if err != nil {
+			return fmt.Errorf("cleanup archive card %d: %w", id, err)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("cleanup archive card rows affected: %w", err)
+		} else if rowsAffected == 0 {
+			return domain.ErrCardNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("cleanup archive commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type CurrencyRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewCurrencyRepo(db *sql.DB) *CurrencyRepo {
+	return &CurrencyRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// Upsert creates or renames a custom currency; changing the precision of a code
+// that already has stored amounts fails because they would be rescaled.
+func (r *CurrencyRepo) Upsert(ctx context.Context, input domain.CustomCurrencyInput) (domain.CustomCurrency, error) {
+	if r.db == nil {
+		return domain.CustomCurrency{}, fmt.Errorf("upsert custom currency: db is nil")
+	}
+
+	normalized, err := domain.NormalizeCustomCurrencyInput(input)
+	if err != nil {
+		return domain.CustomCurrency{}, err
+	}
+
+	existing, err := r.queries.GetCustomCurrency(ctx, normalized.Code)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return domain.CustomCurrency{}, fmt.Errorf("upsert custom currency lookup: %w", err)
+	case int(existing.MinorUnit) != normalized.MinorUnit:
+		inUse, err := r.queries.ExistsStoredAmountByCurrency(ctx, normalized.Code)
+		if err != nil {
+			return domain.CustomCurrency{}, fmt.Errorf("upsert custom currency usage check: %w", err)
+		}
+		if inUse != 0 {
+			return domain.CustomCurrency{}, domain.ErrCustomCurrencyInUse
+		}
+	}
+
+	if _, err := r.queries.UpsertCustomCurrency(ctx, queries.UpsertCustomCurrencyParams{
+		Code:         normalized.Code,
+		Name:         nullableString(normalized.Name),
+		MinorUnit:    int64(normalized.MinorUnit),
+		UpdatedAtUtc: time.Now().UTC().Format(time.RFC3339Nano),
+	}); err != nil {
+		return domain.CustomCurrency{}, fmt.Errorf("upsert custom currency: %w", err)
+	}
+
+	row, err := r.queries.GetCustomCurrency(ctx, normalized.Code)
+	if err != nil {
+		return domain.CustomCurrency{}, fmt.Errorf("upsert custom currency reload: %w", err)
+	}
+	return mapSQLCCustomCurrencyToDomain(row), nil
+}
+
+func (r *CurrencyRepo) List(ctx context.Context) ([]domain.CustomCurrency, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list custom currencies: db is nil")
+	}
+
+	rows, err := r.queries.ListCustomCurrencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list custom currencies: %w", err)
+	}
+
+	currencies := make([]domain.CustomCurrency, 0, len(rows))
+	for _, row := range rows {
+		currencies = append(currencies, mapSQLCCustomCurrencyToDomain(row))
+	}
+	return currencies, nil
+}
+
+// Delete removes a custom currency unless any stored amount or default still
+// uses the code, since those values were recorded with its precision.
+func (r *CurrencyRepo) Delete(ctx context.Context, code string) (domain.CustomCurrencyDeleteResult, error) {
+	if r.db == nil {
+		return domain.CustomCurrencyDeleteResult{}, fmt.Errorf("delete custom currency: db is nil")
+	}
+
+	normalized, err := domain.NormalizeCustomCurrencyCode(code)
+	if err != nil {
+		return domain.CustomCurrencyDeleteResult{}, err
+	}
+
+	inUse, err := r.queries.ExistsStoredAmountByCurrency(ctx, normalized)
+	if err != nil {
+		return domain.CustomCurrencyDeleteResult{}, fmt.Errorf("delete custom currency usage check: %w", err)
+	}
+	if inUse != 0 {
+		return domain.CustomCurrencyDeleteResult{}, domain.ErrCustomCurrencyInUse
+	}
+
+	result, err := r.queries.DeleteCustomCurrency(ctx, normalized)
+	if err != nil {
+		return domain.CustomCurrencyDeleteResult{}, fmt.Errorf("delete custom currency: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.CustomCurrencyDeleteResult{}, fmt.Errorf("delete custom currency rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.CustomCurrencyDeleteResult{}, domain.ErrCustomCurrencyNotFound
+	}
+
+	return domain.CustomCurrencyDeleteResult{Code: normalized}, nil
+}
+
+func mapSQLCCustomCurrencyToDomain(row queries.CustomCurrency) domain.CustomCurrency {
+	currency := domain.CustomCurrency{
+		Code:         row.Code,
+		MinorUnit:    int(row.MinorUnit),
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+	if row.Name.Valid {
+		currency.Name = row.Name.String
+	}
+	return currency
+}
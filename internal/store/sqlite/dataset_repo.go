@@ -0,0 +1,800 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+// DatasetRepo reads and restores the reference data of a full-dataset
+// archive: categories, labels, cards, custom currencies, caps, card payments
+// and adjustments, and settings.
+type DatasetRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+	tx      *sql.Tx
+}
+
+var _ ports.DatasetStoreTxBinder = (*DatasetRepo)(nil)
+
+func NewDatasetRepo(db *sql.DB) *DatasetRepo {
+	return &DatasetRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *DatasetRepo) BindTx(tx *sql.Tx) ports.DatasetStore {
+	if tx == nil {
+		return r
+	}
+
+	return &DatasetRepo{
+		db:      r.db,
+		queries: r.queries.WithTx(tx),
+		tx:      tx,
+	}
+}
+
+// LoadDataset returns the active reference data keyed by name. Caps of
+// deleted categories and events of deleted cards are left out because their
+// names cannot be resolved on import.
+func (r *DatasetRepo) LoadDataset(ctx context.Context) (domain.Dataset, error) {
+	dataset := domain.Dataset{
+		Categories:      []domain.DatasetCategory{},
+		Labels:          []domain.DatasetLabel{},
+		Cards:           []domain.DatasetCard{},
+		Currencies:      []domain.DatasetCurrency{},
+		Caps:            []domain.DatasetCap{},
+		LiabilityEvents: []domain.DatasetLiabilityEvent{},
+	}
+
+	categoryRows, err := r.queries.ListActiveCategories(ctx, domain.CategorySortName)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset categories: %w", err)
+	}
+	categoryNames := make(map[int64]string, len(categoryRows))
+	for _, row := range categoryRows {
+		categoryNames[row.ID] = row.Name
+		dataset.Categories = append(dataset.Categories, domain.DatasetCategory{
+			Name:          row.Name,
+			Color:         ptrStringFromNull(row.Color),
+			Icon:          ptrStringFromNull(row.Icon),
+			ArchivedAtUTC: ptrStringFromNull(row.ArchivedAtUtc),
+		})
+	}
+
+	labelRows, err := r.queries.ListActiveLabels(ctx, domain.LabelSortName)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset labels: %w", err)
+	}
+	for _, row := range labelRows {
+		dataset.Labels = append(dataset.Labels, domain.DatasetLabel{
+			Name:          row.Name,
+			Color:         ptrStringFromNull(row.Color),
+			Icon:          ptrStringFromNull(row.Icon),
+			ArchivedAtUTC: ptrStringFromNull(row.ArchivedAtUtc),
+		})
+	}
+
+	cardRows, err := r.queries.ListCards(ctx, queries.ListCardsParams{IncludeDeleted: boolAsInt64(false), IncludeArchived: boolAsInt64(true), SortKey: domain.CardSortNickname})
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset cards: %w", err)
+	}
+	aliasRows, err := r.queries.ListCardAliases(ctx, sql.NullInt64{})
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset card aliases: %w", err)
+	}
+	cardAliases := map[int64][]string{}
+	for _, row := range aliasRows {
+		cardAliases[row.CardID] = append(cardAliases[row.CardID], row.Alias)
+	}
+	cardNames := make(map[int64]string, len(cardRows))
+	for _, row := range cardRows {
+		card := mapSQLCCard(row)
+		cardNames[card.ID] = card.Nickname
+
+		limitRows, err := r.queries.ListCardMonthlyLimitsByCard(ctx, card.ID)
+		if err != nil {
+			return domain.Dataset{}, fmt.Errorf("load dataset card limits: %w", err)
+		}
+		var limits []domain.DatasetCardLimit
+		for _, limit := range limitRows {
+			limits = append(limits, domain.DatasetCardLimit{
+				MonthKey:     limit.MonthKey,
+				AmountMinor:  limit.AmountMinor,
+				CurrencyCode: limit.CurrencyCode,
+			})
+		}
+
+		dataset.Cards = append(dataset.Cards, domain.DatasetCard{
+			Nickname:            card.Nickname,
+			Description:         card.Description,
+			Last4:               card.Last4,
+			Brand:               card.Brand,
+			CardType:            card.CardType,
+			DueDay:              card.DueDay,
+			DefaultCurrencyCode: card.DefaultCurrencyCode,
+			FXFeeBasisPoints:    card.FXFeeBasisPoints,
+			ArchivedAtUTC:       card.ArchivedAtUTC,
+			Aliases:             cardAliases[card.ID],
+			MonthlyLimits:       limits,
+		})
+	}
+
+	currencyRows, err := r.queries.ListCustomCurrencies(ctx)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset currencies: %w", err)
+	}
+	for _, row := range currencyRows {
+		currency := mapSQLCCustomCurrencyToDomain(row)
+		dataset.Currencies = append(dataset.Currencies, domain.DatasetCurrency{
+			Code:      currency.Code,
+			Name:      currency.Name,
+			MinorUnit: currency.MinorUnit,
+		})
+	}
+
+	capRows, err := r.queries.ListMonthlyCaps(ctx)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset caps: %w", err)
+	}
+	for _, row := range capRows {
+		dataset.Caps = append(dataset.Caps, domain.DatasetCap{
+			MonthKey:     row.MonthKey,
+			AmountMinor:  row.AmountMinor,
+			CurrencyCode: row.CurrencyCode,
+		})
+	}
+
+	categoryCapRows, err := r.queries.ListMonthlyCategoryCaps(ctx)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset category caps: %w", err)
+	}
+	for _, row := range categoryCapRows {
+		name, ok := categoryNames[row.CategoryID]
+		if !ok {
+			continue
+		}
+		dataset.Caps = append(dataset.Caps, domain.DatasetCap{
+			MonthKey:     row.MonthKey,
+			Category:     name,
+			AmountMinor:  row.AmountMinor,
+			CurrencyCode: row.CurrencyCode,
+		})
+	}
+
+	eventRows, err := r.queries.ListUnreferencedCreditLiabilityEvents(ctx)
+	if err != nil {
+		return domain.Dataset{}, fmt.Errorf("load dataset liability events: %w", err)
+	}
+	for _, row := range eventRows {
+		name, ok := cardNames[row.CardID]
+		if !ok {
+			continue
+		}
+		dataset.LiabilityEvents = append(dataset.LiabilityEvents, domain.DatasetLiabilityEvent{
+			Card:              name,
+			CurrencyCode:      row.CurrencyCode,
+			EventType:         row.EventType,
+			AmountMinorSigned: row.AmountMinorSigned,
+			Note:              row.Note.String,
+			CreatedAtUTC:      row.CreatedAtUtc,
+		})
+	}
+
+	settingsRow, err := r.queries.GetSettings(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return domain.Dataset{}, fmt.Errorf("load dataset settings: %w", err)
+	}
+	if err == nil {
+		settings := mapSQLCSettingsToDomain(settingsRow)
+		dataset.Settings = &domain.DatasetSettings{
+			DefaultCurrencyCode:        settings.DefaultCurrencyCode,
+			DisplayTimezone:            settings.DisplayTimezone,
+			OrphanCountThreshold:       settings.OrphanCountThreshold,
+			OrphanSpendingThresholdBPS: settings.OrphanSpendingThresholdBPS,
+			AmountFormat:               settings.AmountFormat,
+			OnboardingCompletedAtUTC:   settings.OnboardingCompletedAtUTC,
+			OrphanSpendingThresholds:   []domain.OrphanSpendingThreshold{},
+			Preferences: &domain.DatasetPreferences{
+				DefaultOutput:       settings.DefaultOutput,
+				FiscalMonthStartDay: settings.FiscalMonthStartDay,
+				DefaultRecordedBy:   settings.DefaultRecordedBy,
+				AutoSnapshot:        settings.AutoSnapshot,
+				FXCacheTTLHours:     settings.FXCacheTTLHours,
+				FXStaleAfterDays:    settings.FXStaleAfterDays,
+				ReportCache:         settings.ReportCache,
+			},
+		}
+		if settings.DefaultCardID != nil {
+			if name, ok := cardNames[*settings.DefaultCardID]; ok {
+				dataset.Settings.Preferences.DefaultCard = &name
+			}
+		}
+
+		thresholdRows, err := r.queries.ListOrphanSpendingThresholds(ctx)
+		if err != nil {
+			return domain.Dataset{}, fmt.Errorf("load dataset orphan thresholds: %w", err)
+		}
+		for _, row := range thresholdRows {
+			dataset.Settings.OrphanSpendingThresholds = append(dataset.Settings.OrphanSpendingThresholds, mapSQLCOrphanSpendingThresholdToDomain(row))
+		}
+
+		codes, err := r.queries.ListStrictWarningCodes(ctx)
+		if err != nil {
+			return domain.Dataset{}, fmt.Errorf("load dataset strict warnings: %w", err)
+		}
+		if codes == nil {
+			codes = []string{}
+		}
+		dataset.Settings.StrictWarnings = codes
+	}
+
+	return dataset, nil
+}
+
+// RestoreDataset writes dataset in one transaction. Categories, labels, and
+// cards are matched by case-insensitive name and only created when missing,
+// with the archived state and appearance of the archive; matched ones keep
+// their local state. Missing card aliases are added, while card limits,
+// currencies, caps, and settings overwrite the local values. With
+// skipDuplicateEvents, liability events identical to an existing one are
+// skipped.
+func (r *DatasetRepo) RestoreDataset(ctx context.Context, dataset domain.Dataset, skipDuplicateEvents bool) (domain.DatasetRestoreResult, error) {
+	tx, qtx, ownsTx, err := r.writeQueries(ctx, "restore dataset")
+	if err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+	if ownsTx {
+		defer func() {
+			_ = tx.Rollback()
+		}()
+	}
+
+	result := domain.DatasetRestoreResult{
+		CategoryIDs: map[string]int64{},
+		LabelIDs:    map[string]int64{},
+		CardIDs:     map[string]int64{},
+	}
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+
+	if err := restoreDatasetCategories(ctx, qtx, dataset.Categories, nowUTC, &result); err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+	if err := restoreDatasetLabels(ctx, qtx, dataset.Labels, nowUTC, &result); err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+	if err := restoreDatasetCards(ctx, qtx, dataset.Cards, nowUTC, &result); err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+	if err := restoreDatasetCardExtras(ctx, qtx, dataset.Cards, nowUTC, &result); err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+
+	for _, currency := range dataset.Currencies {
+		normalized, err := domain.NormalizeCustomCurrencyInput(domain.CustomCurrencyInput{
+			Code:      currency.Code,
+			Name:      currency.Name,
+			MinorUnit: currency.MinorUnit,
+		})
+		if err != nil {
+			return domain.DatasetRestoreResult{}, err
+		}
+		if _, err := qtx.UpsertCustomCurrency(ctx, queries.UpsertCustomCurrencyParams{
+			Code:         normalized.Code,
+			Name:         nullableString(normalized.Name),
+			MinorUnit:    int64(normalized.MinorUnit),
+			UpdatedAtUtc: nowUTC,
+		}); err != nil {
+			return domain.DatasetRestoreResult{}, fmt.Errorf("restore dataset currency: %w", err)
+		}
+		result.CurrenciesRestored++
+	}
+
+	for _, datasetCap := range dataset.Caps {
+		input := domain.CapSetInput{
+			MonthKey:     datasetCap.MonthKey,
+			AmountMinor:  datasetCap.AmountMinor,
+			CurrencyCode: datasetCap.CurrencyCode,
+		}
+		if datasetCap.Category != "" {
+			categoryID, ok := result.CategoryIDs[strings.ToLower(strings.TrimSpace(datasetCap.Category))]
+			if !ok {
+				return domain.DatasetRestoreResult{}, fmt.Errorf("%w: %q", domain.ErrCategoryNotFound, datasetCap.Category)
+			}
+			input.CategoryID = &categoryID
+		}
+		normalized, err := domain.NormalizeCapSetInput(input)
+		if err != nil {
+			return domain.DatasetRestoreResult{}, err
+		}
+		if _, err := setCap(ctx, qtx, normalized, nowUTC); err != nil {
+			return domain.DatasetRestoreResult{}, err
+		}
+		result.CapsRestored++
+	}
+
+	if err := restoreDatasetLiabilityEvents(ctx, qtx, dataset.LiabilityEvents, skipDuplicateEvents, nowUTC, &result); err != nil {
+		return domain.DatasetRestoreResult{}, err
+	}
+
+	if dataset.Settings != nil {
+		if err := restoreDatasetSettings(ctx, qtx, *dataset.Settings, result.CardIDs, nowUTC); err != nil {
+			return domain.DatasetRestoreResult{}, err
+		}
+		result.SettingsRestored = true
+	}
+
+	if ownsTx {
+		if err := tx.Commit(); err != nil {
+			return domain.DatasetRestoreResult{}, fmt.Errorf("restore dataset commit: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func restoreDatasetCategories(ctx context.Context, qtx *queries.Queries, categories []domain.DatasetCategory, nowUTC string, result *domain.DatasetRestoreResult) error {
+	rows, err := qtx.ListActiveCategories(ctx, domain.CategorySortName)
+	if err != nil {
+		return fmt.Errorf("restore dataset list categories: %w", err)
+	}
+	for _, row := range rows {
+		result.CategoryIDs[strings.ToLower(row.Name)] = row.ID
+	}
+
+	for _, category := range categories {
+		name, err := domain.NormalizeCategoryName(category.Name)
+		if err != nil {
+			return err
+		}
+		key := strings.ToLower(name)
+		if _, ok := result.CategoryIDs[key]; ok {
+			result.CategoriesMatched++
+			continue
+		}
+
+		created, err := qtx.CreateCategory(ctx, name)
+		if err != nil {
+			return fmt.Errorf("restore dataset category %q: %w", name, err)
+		}
+		id, err := created.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("restore dataset category read id: %w", err)
+		}
+		appearance, ok, err := datasetAppearance(category.Color, category.Icon)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if _, err := qtx.UpdateActiveCategoryAppearance(ctx, queries.UpdateActiveCategoryAppearanceParams{
+				SetColor:     boolAsInt64(appearance.Color != nil),
+				Color:        nullableStringPtr(appearance.Color),
+				SetIcon:      boolAsInt64(appearance.Icon != nil),
+				Icon:         nullableStringPtr(appearance.Icon),
+				UpdatedAtUtc: nowUTC,
+				ID:           id,
+			}); err != nil {
+				return fmt.Errorf("restore dataset category %q appearance: %w", name, err)
+			}
+		}
+		if category.ArchivedAtUTC != nil {
+			if _, err := qtx.SetActiveCategoryArchivedAt(ctx, queries.SetActiveCategoryArchivedAtParams{
+				ArchivedAtUtc: nullableStringPtr(category.ArchivedAtUTC),
+				UpdatedAtUtc:  nowUTC,
+				ID:            id,
+			}); err != nil {
+				return fmt.Errorf("restore dataset category %q archived: %w", name, err)
+			}
+		}
+		result.CategoryIDs[key] = id
+		result.CategoriesCreated++
+	}
+	return nil
+}
+
+func restoreDatasetLabels(ctx context.Context, qtx *queries.Queries, labels []domain.DatasetLabel, nowUTC string, result *domain.DatasetRestoreResult) error {
+	rows, err := qtx.ListActiveLabels(ctx, domain.LabelSortName)
+	if err != nil {
+		return fmt.Errorf("restore dataset list labels: %w", err)
+	}
+	for _, row := range rows {
+		result.LabelIDs[strings.ToLower(row.Name)] = row.ID
+	}
+
+	for _, label := range labels {
+		name, err := domain.NormalizeLabelName(label.Name)
+		if err != nil {
+			return err
+		}
+		key := strings.ToLower(name)
+		if _, ok := result.LabelIDs[key]; ok {
+			result.LabelsMatched++
+			continue
+		}
+
+		created, err := qtx.CreateLabel(ctx, name)
+		if err != nil {
+			return fmt.Errorf("restore dataset label %q: %w", name, err)
+		}
+		id, err := created.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("restore dataset label read id: %w", err)
+		}
+		appearance, ok, err := datasetAppearance(label.Color, label.Icon)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if _, err := qtx.UpdateActiveLabelAppearance(ctx, queries.UpdateActiveLabelAppearanceParams{
+				SetColor:     boolAsInt64(appearance.Color != nil),
+				Color:        nullableStringPtr(appearance.Color),
+				SetIcon:      boolAsInt64(appearance.Icon != nil),
+				Icon:         nullableStringPtr(appearance.Icon),
+				UpdatedAtUtc: nowUTC,
+				ID:           id,
+			}); err != nil {
+				return fmt.Errorf("restore dataset label %q appearance: %w", name, err)
+			}
+		}
+		if label.ArchivedAtUTC != nil {
+			if _, err := qtx.SetActiveLabelArchivedAt(ctx, queries.SetActiveLabelArchivedAtParams{
+				ArchivedAtUtc: nullableStringPtr(label.ArchivedAtUTC),
+				UpdatedAtUtc:  nowUTC,
+				ID:            id,
+			}); err != nil {
+				return fmt.Errorf("restore dataset label %q archived: %w", name, err)
+			}
+		}
+		result.LabelIDs[key] = id
+		result.LabelsCreated++
+	}
+	return nil
+}
+
+func restoreDatasetCards(ctx context.Context, qtx *queries.Queries, cards []domain.DatasetCard, nowUTC string, result *domain.DatasetRestoreResult) error {
+	rows, err := qtx.ListCards(ctx, queries.ListCardsParams{IncludeDeleted: boolAsInt64(false), IncludeArchived: boolAsInt64(true), SortKey: domain.CardSortNickname})
+	if err != nil {
+		return fmt.Errorf("restore dataset list cards: %w", err)
+	}
+	for _, row := range rows {
+		result.CardIDs[strings.ToLower(row.Nickname)] = row.ID
+	}
+
+	for _, card := range cards {
+		input := ports.CardCreateInput{
+			Nickname:            card.Nickname,
+			Description:         card.Description,
+			Last4:               card.Last4,
+			Brand:               card.Brand,
+			CardType:            card.CardType,
+			DueDay:              card.DueDay,
+			DefaultCurrencyCode: card.DefaultCurrencyCode,
+			FXFeeBasisPoints:    card.FXFeeBasisPoints,
+		}
+		if err := validateCardCreateInput(input); err != nil {
+			return err
+		}
+		key := strings.ToLower(strings.TrimSpace(card.Nickname))
+		if _, ok := result.CardIDs[key]; ok {
+			result.CardsMatched++
+			continue
+		}
+
+		created, err := qtx.CreateCard(ctx, queries.CreateCardParams{
+			Nickname:            strings.TrimSpace(input.Nickname),
+			Description:         nullableStringPtr(input.Description),
+			Last4:               strings.TrimSpace(input.Last4),
+			Brand:               strings.TrimSpace(input.Brand),
+			CardType:            strings.TrimSpace(input.CardType),
+			DueDay:              nullableInt64Ptr(input.DueDay),
+			DefaultCurrencyCode: nullableStringPtr(input.DefaultCurrencyCode),
+			FxFeeBp:             nullableInt64Ptr(input.FXFeeBasisPoints),
+			UpdatedAtUtc:        nowUTC,
+		})
+		if err != nil {
+			return fmt.Errorf("restore dataset card %q: %w", input.Nickname, err)
+		}
+		id, err := created.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("restore dataset card read id: %w", err)
+		}
+		if card.ArchivedAtUTC != nil {
+			if _, err := qtx.SetActiveCardArchivedAt(ctx, queries.SetActiveCardArchivedAtParams{
+				ArchivedAtUtc: nullableStringPtr(card.ArchivedAtUTC),
+				UpdatedAtUtc:  nowUTC,
+				ID:            id,
+			}); err != nil {
+				return fmt.Errorf("restore dataset card %q archived: %w", input.Nickname, err)
+			}
+		}
+		result.CardIDs[key] = id
+		result.CardsCreated++
+	}
+	return nil
+}
+
+// restoreDatasetCardExtras adds the archive's card aliases and monthly limits
+// once every card exists, so an alias is checked against all of their names.
+// An alias that already names another card fails with ErrCardAliasExists.
+func restoreDatasetCardExtras(ctx context.Context, qtx *queries.Queries, cards []domain.DatasetCard, nowUTC string, result *domain.DatasetRestoreResult) error {
+	for _, card := range cards {
+		cardID := result.CardIDs[strings.ToLower(strings.TrimSpace(card.Nickname))]
+
+		for _, rawAlias := range card.Aliases {
+			alias, err := domain.NormalizeCardAlias(rawAlias)
+			if err != nil {
+				return err
+			}
+			matches, err := qtx.ListActiveCardsByNicknameOrAlias(ctx, queries.ListActiveCardsByNicknameOrAliasParams{
+				IncludeArchived: boolAsInt64(true),
+				Name:            alias,
+			})
+			if err != nil {
+				return fmt.Errorf("restore dataset card alias lookup: %w", err)
+			}
+			known := false
+			for _, match := range matches {
+				if match.ID != cardID {
+					return fmt.Errorf("%w: %q names card %q", domain.ErrCardAliasExists, alias, match.Nickname)
+				}
+				known = true
+			}
+			if known {
+				continue
+			}
+			if _, err := qtx.CreateCardAlias(ctx, queries.CreateCardAliasParams{CardID: cardID, Alias: alias}); err != nil {
+				return fmt.Errorf("restore dataset card alias %q: %w", alias, err)
+			}
+			result.CardAliasesCreated++
+		}
+
+		for _, limit := range card.MonthlyLimits {
+			monthKey, err := domain.NormalizeMonthKey(limit.MonthKey)
+			if err != nil {
+				return err
+			}
+			if limit.AmountMinor <= 0 {
+				return fmt.Errorf("%w: card %q limit amount must be positive", domain.ErrInvalidDatasetArchive, card.Nickname)
+			}
+			if err := validateCurrencyCode(limit.CurrencyCode); err != nil {
+				return err
+			}
+			if _, err := qtx.UpsertCardMonthlyLimit(ctx, queries.UpsertCardMonthlyLimitParams{
+				CardID:       cardID,
+				MonthKey:     monthKey,
+				AmountMinor:  limit.AmountMinor,
+				CurrencyCode: strings.ToUpper(strings.TrimSpace(limit.CurrencyCode)),
+				UpdatedAtUtc: nowUTC,
+			}); err != nil {
+				return fmt.Errorf("restore dataset card limit: %w", err)
+			}
+			result.CardLimitsRestored++
+		}
+	}
+	return nil
+}
+
+// datasetAppearance normalizes an archived color and icon; ok is false when
+// the archive sets neither.
+func datasetAppearance(color, icon *string) (domain.AppearanceUpdate, bool, error) {
+	if color == nil && icon == nil {
+		return domain.AppearanceUpdate{}, false, nil
+	}
+	appearance, err := domain.NormalizeAppearanceUpdate(domain.AppearanceUpdate{Color: color, Icon: icon})
+	if err != nil {
+		return domain.AppearanceUpdate{}, false, err
+	}
+	return appearance, true, nil
+}
+
+func restoreDatasetLiabilityEvents(ctx context.Context, qtx *queries.Queries, events []domain.DatasetLiabilityEvent, skipDuplicates bool, nowUTC string, result *domain.DatasetRestoreResult) error {
+	existing := map[string]struct{}{}
+	if skipDuplicates {
+		rows, err := qtx.ListUnreferencedCreditLiabilityEvents(ctx)
+		if err != nil {
+			return fmt.Errorf("restore dataset list liability events: %w", err)
+		}
+		for _, row := range rows {
+			existing[liabilityEventSignature(row.CardID, row.CurrencyCode, row.EventType, row.AmountMinorSigned, row.Note.String, row.CreatedAtUtc)] = struct{}{}
+		}
+	}
+
+	for _, event := range events {
+		cardID, ok := result.CardIDs[strings.ToLower(strings.TrimSpace(event.Card))]
+		if !ok {
+			return fmt.Errorf("%w: %q", domain.ErrCardNotFound, event.Card)
+		}
+		createdAt := event.CreatedAtUTC
+		if createdAt == "" {
+			createdAt = nowUTC
+		}
+		var note *string
+		if event.Note != "" {
+			note = &event.Note
+		}
+		input := ports.CreditLiabilityEventInput{
+			CardID:            cardID,
+			CurrencyCode:      strings.ToUpper(strings.TrimSpace(event.CurrencyCode)),
+			EventType:         strings.ToLower(strings.TrimSpace(event.EventType)),
+			AmountMinorSigned: event.AmountMinorSigned,
+			Note:              note,
+		}
+		if err := validateLiabilityEventInput(input); err != nil {
+			return err
+		}
+
+		signature := liabilityEventSignature(cardID, input.CurrencyCode, input.EventType, input.AmountMinorSigned, event.Note, createdAt)
+		if _, ok := existing[signature]; ok {
+			result.LiabilityEventsSkipped++
+			continue
+		}
+
+		if _, err := qtx.CreateCreditLiabilityEvent(ctx, queries.CreateCreditLiabilityEventParams{
+			CardID:            input.CardID,
+			CurrencyCode:      input.CurrencyCode,
+			EventType:         input.EventType,
+			AmountMinorSigned: input.AmountMinorSigned,
+			Note:              nullableStringPtr(input.Note),
+			CreatedAtUtc:      createdAt,
+		}); err != nil {
+			return fmt.Errorf("restore dataset liability event: %w", err)
+		}
+		if skipDuplicates {
+			existing[signature] = struct{}{}
+		}
+		result.LiabilityEventsCreated++
+	}
+	return nil
+}
+
+func restoreDatasetSettings(ctx context.Context, qtx *queries.Queries, settings domain.DatasetSettings, cardIDs map[string]int64, nowUTC string) error {
+	normalized, err := domain.NormalizeSettingsInput(domain.SettingsUpsertInput{
+		DefaultCurrencyCode:        settings.DefaultCurrencyCode,
+		DisplayTimezone:            settings.DisplayTimezone,
+		OrphanCountThreshold:       settings.OrphanCountThreshold,
+		OrphanSpendingThresholdBPS: settings.OrphanSpendingThresholdBPS,
+		AmountFormat:               settings.AmountFormat,
+		OnboardingCompletedAtUTC:   settings.OnboardingCompletedAtUTC,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := qtx.UpsertSettings(ctx, queries.UpsertSettingsParams{
+		DefaultCurrencyCode:        normalized.DefaultCurrencyCode,
+		DisplayTimezone:            normalized.DisplayTimezone,
+		OrphanCountThreshold:       normalized.OrphanCountThreshold,
+		OrphanSpendingThresholdBps: normalized.OrphanSpendingThresholdBPS,
+		OnboardingCompletedAtUtc:   nullableStringPtr(normalized.OnboardingCompletedAtUTC),
+		AmountFormat:               normalized.AmountFormat,
+		UpdatedAtUtc:               nowUTC,
+	}); err != nil {
+		return fmt.Errorf("restore dataset settings: %w", err)
+	}
+
+	for _, threshold := range settings.OrphanSpendingThresholds {
+		input := domain.OrphanSpendingThresholdInput{
+			CurrencyCode: threshold.CurrencyCode,
+			Mode:         threshold.Mode,
+		}
+		if threshold.PercentBPS != nil {
+			input.PercentBPS = *threshold.PercentBPS
+		}
+		if threshold.AmountMinor != nil {
+			input.AmountMinor = *threshold.AmountMinor
+		}
+		normalizedThreshold, err := domain.NormalizeOrphanSpendingThresholdInput(input)
+		if err != nil {
+			return err
+		}
+		params := queries.UpsertOrphanSpendingThresholdParams{
+			CurrencyCode: normalizedThreshold.CurrencyCode,
+			Mode:         normalizedThreshold.Mode,
+			UpdatedAtUtc: nowUTC,
+		}
+		if normalizedThreshold.PercentBPS > 0 {
+			params.PercentBps = sql.NullInt64{Int64: normalizedThreshold.PercentBPS, Valid: true}
+		}
+		if normalizedThreshold.AmountMinor > 0 {
+			params.AmountMinor = sql.NullInt64{Int64: normalizedThreshold.AmountMinor, Valid: true}
+		}
+		if _, err := qtx.UpsertOrphanSpendingThreshold(ctx, params); err != nil {
+			return fmt.Errorf("restore dataset orphan threshold: %w", err)
+		}
+	}
+
+	codes, err := domain.NormalizeStrictWarningCodes(settings.StrictWarnings)
+	if err != nil {
+		return err
+	}
+	if err := qtx.DeleteStrictWarningCodes(ctx); err != nil {
+		return fmt.Errorf("restore dataset strict warnings delete: %w", err)
+	}
+	for _, code := range codes {
+		if err := qtx.InsertStrictWarningCode(ctx, code); err != nil {
+			return fmt.Errorf("restore dataset strict warnings insert: %w", err)
+		}
+	}
+
+	if settings.Preferences != nil {
+		return restoreDatasetPreferences(ctx, qtx, *settings.Preferences, cardIDs, nowUTC)
+	}
+	return nil
+}
+
+func restoreDatasetPreferences(ctx context.Context, qtx *queries.Queries, preferences domain.DatasetPreferences, cardIDs map[string]int64, nowUTC string) error {
+	params := queries.UpdateSettingsPreferencesParams{
+		AutoSnapshot: boolAsInt64(preferences.AutoSnapshot),
+		ReportCache:  boolAsInt64(preferences.ReportCache),
+		UpdatedAtUtc: nowUTC,
+	}
+	if preferences.DefaultOutput != nil {
+		format, err := domain.NormalizeSettingsDefaultOutput(*preferences.DefaultOutput)
+		if err != nil {
+			return err
+		}
+		params.DefaultOutput = sql.NullString{String: format, Valid: true}
+	}
+	if preferences.DefaultCard != nil {
+		cardID, ok := cardIDs[strings.ToLower(strings.TrimSpace(*preferences.DefaultCard))]
+		if !ok {
+			return fmt.Errorf("%w: %q", domain.ErrCardNotFound, *preferences.DefaultCard)
+		}
+		params.DefaultCardID = sql.NullInt64{Int64: cardID, Valid: true}
+	}
+	if preferences.DefaultRecordedBy != nil {
+		params.DefaultRecordedBy = nullableString(domain.NormalizeRecordedBy(*preferences.DefaultRecordedBy))
+	}
+
+	var err error
+	if params.FiscalMonthStartDay, err = domain.NormalizeFiscalMonthStartDay(preferences.FiscalMonthStartDay); err != nil {
+		return err
+	}
+	if params.FxCacheTtlHours, err = domain.NormalizeFXCacheTTLHours(preferences.FXCacheTTLHours); err != nil {
+		return err
+	}
+	if params.FxStaleAfterDays, err = domain.NormalizeFXStaleAfterDays(preferences.FXStaleAfterDays); err != nil {
+		return err
+	}
+
+	if _, err := qtx.UpdateSettingsPreferences(ctx, params); err != nil {
+		return fmt.Errorf("restore dataset settings preferences: %w", err)
+	}
+	return nil
+}
+
+func liabilityEventSignature(cardID int64, currencyCode, eventType string, amountMinorSigned int64, note, createdAtUTC string) string {
+	return strings.Join([]string{
+		strconv.FormatInt(cardID, 10),
+		currencyCode,
+		eventType,
+		strconv.FormatInt(amountMinorSigned, 10),
+		note,
+		createdAtUTC,
+	}, "|")
+}
+
+func (r *DatasetRepo) writeQueries(ctx context.Context, operation string) (*sql.Tx, *queries.Queries, bool, error) {
+	if r.tx != nil {
+		return nil, r.queries, false, nil
+	}
+
+	if r.db == nil {
+		return nil, nil, false, fmt.Errorf("%s: db is nil", operation)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%s begin tx: %w", operation, err)
+	}
+
+	return tx, r.queries.WithTx(tx), true, nil
+}
@@ -5,14 +5,89 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	_ "modernc.org/sqlite"
 )
 
-const (
-	DriverName           = "sqlite"
-	DefaultMigrationsDir = "migrations"
-)
+const DriverName = "sqlite"
+
+// MemoryPath opens a private in-memory database that lives as long as the
+// command that opened it.
+const MemoryPath = ":memory:"
+
+// sharedMemoryAnchors holds one idle connection per shared-cache in-memory
+// database, so its contents outlive the handles that commands open and close
+// and later opens in the same process see the same data.
+var sharedMemoryAnchors = struct {
+	sync.Mutex
+	dbs map[string]*sql.DB
+}{dbs: map[string]*sql.DB{}}
+
+// IsMemoryPath reports whether dbPath names an in-memory database: ":memory:"
+// or a "file:" URI with mode=memory or the ":memory:" file name.
+func IsMemoryPath(dbPath string) bool {
+	dbPath = strings.TrimSpace(dbPath)
+	if dbPath == MemoryPath {
+		return true
+	}
+	name, query, ok := splitFileURI(dbPath)
+	if !ok {
+		return false
+	}
+	return name == MemoryPath || query.Get("mode") == "memory"
+}
+
+// isSharedMemoryPath reports whether dbPath is an in-memory "file:" URI with
+// cache=shared, whose database other connections in the process can join.
+func isSharedMemoryPath(dbPath string) bool {
+	if !IsMemoryPath(dbPath) {
+		return false
+	}
+	_, query, ok := splitFileURI(strings.TrimSpace(dbPath))
+	return ok && query.Get("cache") == "shared"
+}
+
+func splitFileURI(dbPath string) (string, url.Values, bool) {
+	if !strings.HasPrefix(dbPath, "file:") {
+		return "", nil, false
+	}
+	name, rawQuery, _ := strings.Cut(strings.TrimPrefix(dbPath, "file:"), "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", nil, false
+	}
+	return name, query, true
+}
+
+// anchorSharedMemory opens the idle connection that keeps a shared-cache
+// in-memory database alive for the rest of the process.
+func anchorSharedMemory(ctx context.Context, dbPath string) error {
+	sharedMemoryAnchors.Lock()
+	defer sharedMemoryAnchors.Unlock()
+
+	if _, ok := sharedMemoryAnchors.dbs[dbPath]; ok {
+		return nil
+	}
+
+	anchor, err := sql.Open(DriverName, dbPath)
+	if err != nil {
+		return fmt.Errorf("sqlite open: %w", err)
+	}
+	anchor.SetMaxOpenConns(1)
+	anchor.SetMaxIdleConns(1)
+	if err := anchor.PingContext(ctx); err != nil {
+		_ = anchor.Close()
+		return fmt.Errorf("sqlite ping: %w", err)
+	}
+
+	sharedMemoryAnchors.dbs[dbPath] = anchor
+	return nil
+}
 
 // Open initializes a SQLite connection with required pragmas for this app.
 func Open(ctx context.Context, dbPath string) (*sql.DB, error) {
@@ -20,7 +95,13 @@ func Open(ctx context.Context, dbPath string) (*sql.DB, error) {
 		return nil, errors.New("sqlite open: db path is required")
 	}
 
-	db, err := sql.Open(DriverName, dbPath)
+	if isSharedMemoryPath(dbPath) {
+		if err := anchorSharedMemory(ctx, dbPath); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open(driverNameForLogger(ctx), dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("sqlite open: %w", err)
 	}
@@ -34,7 +115,7 @@ func Open(ctx context.Context, dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("sqlite ping: %w", err)
 	}
 
-	if err := applyPragmas(ctx, db); err != nil {
+	if err := applyPragmas(ctx, db, IsMemoryPath(dbPath)); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -42,17 +123,45 @@ func Open(ctx context.Context, dbPath string) (*sql.DB, error) {
 	return db, nil
 }
 
-// OpenAndMigrate opens the database and applies migrations from migrationsDir.
+// OpenReadOnly opens an existing database file with mode=ro and query_only
+// set, without migrating it, for inspecting a backup before restoring it.
+func OpenReadOnly(ctx context.Context, dbPath string) (*sql.DB, error) {
+	if strings.TrimSpace(dbPath) == "" {
+		return nil, errors.New("sqlite open: db path is required")
+	}
+	absPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite open: %w", err)
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return nil, fmt.Errorf("sqlite open: %w", err)
+	}
+
+	uri := (&url.URL{Scheme: "file", Path: filepath.ToSlash(absPath), RawQuery: "mode=ro"}).String()
+	db, err := sql.Open(driverNameForLogger(ctx), uri)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite open: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	if _, err := db.ExecContext(ctx, "PRAGMA query_only = ON;"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite pragma query_only: %w", err)
+	}
+	return db, nil
+}
+
+// OpenAndMigrate opens the database and applies the embedded migrations, or
+// those in migrationsDir when it is not empty. In-memory paths start empty
+// and are migrated like a new file; a shared-cache memory URI is migrated
+// once and then reopened with its data for the rest of the process.
 func OpenAndMigrate(ctx context.Context, dbPath, migrationsDir string) (*sql.DB, error) {
 	db, err := Open(ctx, dbPath)
 	if err != nil {
 		return nil, err
 	}
 
-	if migrationsDir == "" {
-		migrationsDir = DefaultMigrationsDir
-	}
-
 	if err := RunMigrations(ctx, db, migrationsDir); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -61,12 +170,15 @@ func OpenAndMigrate(ctx context.Context, dbPath, migrationsDir string) (*sql.DB,
 	return db, nil
 }
 
-func applyPragmas(ctx context.Context, db *sql.DB) error {
+func applyPragmas(ctx context.Context, db *sql.DB, inMemory bool) error {
 	statements := []string{
-		"PRAGMA journal_mode = WAL;",
 		"PRAGMA foreign_keys = ON;",
 		"PRAGMA busy_timeout = 5000;",
 	}
+	if !inMemory {
+		// In-memory databases have no file to write a WAL next to.
+		statements = append([]string{"PRAGMA journal_mode = WAL;"}, statements...)
+	}
 
 	for _, stmt := range statements {
 		if _, err := db.ExecContext(ctx, stmt); err != nil {
@@ -0,0 +1,282 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+const (
+	orphanedTransactionLabelsWhere = `tl.deleted_at_utc IS NULL AND (
+    t.id IS NULL OR t.deleted_at_utc IS NOT NULL OR
+    l.id IS NULL OR l.deleted_at_utc IS NOT NULL
+)`
+	orphanedTransactionLabelsFrom = `transaction_labels tl
+LEFT JOIN transactions t ON t.id = tl.transaction_id
+LEFT JOIN labels l ON l.id = tl.label_id`
+
+	orphanedPaymentMethodsFrom = `transaction_payment_methods pm
+LEFT JOIN transactions t ON t.id = pm.transaction_id
+LEFT JOIN cards c ON c.id = pm.card_id`
+	orphanedPaymentMethodsWhere = `t.id IS NULL OR (pm.card_id IS NOT NULL AND c.id IS NULL)`
+
+	danglingLiabilityEventsFrom = `credit_liability_events e
+LEFT JOIN transactions t ON t.id = e.reference_transaction_id`
+	danglingLiabilityEventsWhere = `(e.reference_transaction_id IS NOT NULL AND (t.id IS NULL OR t.deleted_at_utc IS NOT NULL))
+    OR (e.event_type = 'charge' AND e.reference_transaction_id IS NULL)`
+)
+
+// Diagnose runs the `doctor` health checks against db. With fix it applies
+// pending migrations and the safe repairs in one transaction: detaching label
+// links of deleted entries or labels, dropping payment-method rows of missing
+// entries, and deleting liability events whose entry is gone. Foreign keys are
+// checked last, so they reflect the database after any repair.
+func Diagnose(ctx context.Context, db *sql.DB, migrationsDir string, fix bool) (domain.DoctorReport, error) {
+	if db == nil {
+		return domain.DoctorReport{}, fmt.Errorf("doctor: db is nil")
+	}
+
+	report := domain.DoctorReport{Fix: fix, Checks: []domain.DoctorCheck{}}
+
+	integrity, err := checkIntegrity(ctx, db)
+	if err != nil {
+		return domain.DoctorReport{}, err
+	}
+	report.Checks = append(report.Checks, integrity)
+
+	plan, err := PlanMigrations(ctx, db, migrationsDir)
+	if err != nil {
+		return domain.DoctorReport{}, err
+	}
+	schema := domain.DoctorCheck{Name: domain.DoctorCheckSchemaVersion, Issues: int64(len(plan.Pending)), Fixable: true}
+	if len(plan.Pending) > 0 {
+		schema.Message = fmt.Sprintf("database is at version %d, migrations go up to %d", plan.CurrentVersion, plan.TargetVersion)
+		if fix {
+			if err := RunMigrations(ctx, db, migrationsDir); err != nil {
+				return domain.DoctorReport{}, fmt.Errorf("doctor apply migrations: %w", err)
+			}
+			schema.Fixed = schema.Issues
+			plan.CurrentVersion = plan.TargetVersion
+			plan.Pending = nil
+		}
+	}
+	report.SchemaVersion = plan.CurrentVersion
+	report.LatestVersion = plan.TargetVersion
+	report.PendingMigrations = len(plan.Pending)
+	report.Checks = append(report.Checks, schema)
+
+	repairs := []struct {
+		name   string
+		tables []string
+		sample string
+		count  string
+		fix    string
+	}{
+		{
+			name:   domain.DoctorCheckOrphanedTransactionLabels,
+			tables: []string{"transaction_labels", "transactions", "labels"},
+			sample: "SELECT tl.id, tl.transaction_id, tl.label_id FROM " + orphanedTransactionLabelsFrom + " WHERE " + orphanedTransactionLabelsWhere + " ORDER BY tl.id LIMIT ?;",
+			count:  "SELECT COUNT(*) FROM " + orphanedTransactionLabelsFrom + " WHERE " + orphanedTransactionLabelsWhere + ";",
+			fix:    "UPDATE transaction_labels SET deleted_at_utc = ? WHERE id IN (SELECT tl.id FROM " + orphanedTransactionLabelsFrom + " WHERE " + orphanedTransactionLabelsWhere + ");",
+		},
+		{
+			name:   domain.DoctorCheckOrphanedPaymentMethods,
+			tables: []string{"transaction_payment_methods", "transactions", "cards"},
+			sample: "SELECT pm.transaction_id, pm.method_type, pm.card_id FROM " + orphanedPaymentMethodsFrom + " WHERE " + orphanedPaymentMethodsWhere + " ORDER BY pm.transaction_id LIMIT ?;",
+			count:  "SELECT COUNT(*) FROM " + orphanedPaymentMethodsFrom + " WHERE " + orphanedPaymentMethodsWhere + ";",
+			// Rows pointing at a missing card cannot be repaired safely; only
+			// rows of missing entries are dropped.
+			fix: "DELETE FROM transaction_payment_methods WHERE NOT EXISTS (SELECT 1 FROM transactions t WHERE t.id = transaction_payment_methods.transaction_id);",
+		},
+		{
+			name:   domain.DoctorCheckDanglingLiabilityEvents,
+			tables: []string{"credit_liability_events", "transactions"},
+			sample: "SELECT e.id, e.event_type, e.reference_transaction_id FROM " + danglingLiabilityEventsFrom + " WHERE " + danglingLiabilityEventsWhere + " ORDER BY e.id LIMIT ?;",
+			count:  "SELECT COUNT(*) FROM " + danglingLiabilityEventsFrom + " WHERE " + danglingLiabilityEventsWhere + ";",
+			fix:    "DELETE FROM credit_liability_events WHERE id IN (SELECT e.id FROM " + danglingLiabilityEventsFrom + " WHERE " + danglingLiabilityEventsWhere + ");",
+		},
+	}
+
+	// Table lookups go through db, so resolve them before a fix transaction
+	// takes the only connection.
+	missingTables := map[string]string{}
+	for _, repair := range repairs {
+		for _, table := range repair.tables {
+			exists, err := tableExists(ctx, db, table)
+			if err != nil {
+				return domain.DoctorReport{}, err
+			}
+			if !exists {
+				missingTables[repair.name] = table
+				break
+			}
+		}
+	}
+
+	var tx *sql.Tx
+	if fix {
+		tx, err = db.BeginTx(ctx, nil)
+		if err != nil {
+			return domain.DoctorReport{}, fmt.Errorf("doctor begin tx: %w", err)
+		}
+		defer func() {
+			_ = tx.Rollback()
+		}()
+	}
+	var runner queries.DBTX = db
+	if tx != nil {
+		runner = tx
+	}
+
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, repair := range repairs {
+		check := domain.DoctorCheck{Name: repair.name, Fixable: true}
+
+		if missing, ok := missingTables[repair.name]; ok {
+			check.Status = domain.DoctorStatusSkipped
+			check.Message = fmt.Sprintf("table %s does not exist yet", missing)
+			report.Checks = append(report.Checks, check)
+			continue
+		}
+
+		if err := runner.QueryRowContext(ctx, repair.count).Scan(&check.Issues); err != nil {
+			return domain.DoctorReport{}, fmt.Errorf("doctor %s count: %w", repair.name, err)
+		}
+		if check.Issues > 0 {
+			check.Sample, err = doctorSample(ctx, runner.QueryContext, repair.sample)
+			if err != nil {
+				return domain.DoctorReport{}, fmt.Errorf("doctor %s sample: %w", repair.name, err)
+			}
+			if fix {
+				args := []any{}
+				if repair.name == domain.DoctorCheckOrphanedTransactionLabels {
+					args = append(args, nowUTC)
+				}
+				result, err := runner.ExecContext(ctx, repair.fix, args...)
+				if err != nil {
+					return domain.DoctorReport{}, fmt.Errorf("doctor %s fix: %w", repair.name, err)
+				}
+				if check.Fixed, err = result.RowsAffected(); err != nil {
+					return domain.DoctorReport{}, fmt.Errorf("doctor %s fix rows affected: %w", repair.name, err)
+				}
+			}
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return domain.DoctorReport{}, fmt.Errorf("doctor commit: %w", err)
+		}
+	}
+
+	foreignKeys, err := checkForeignKeys(ctx, db)
+	if err != nil {
+		return domain.DoctorReport{}, err
+	}
+	report.Checks = append(report.Checks, foreignKeys)
+
+	report.Finalize()
+	return report, nil
+}
+
+func checkIntegrity(ctx context.Context, db *sql.DB) (domain.DoctorCheck, error) {
+	check := domain.DoctorCheck{Name: domain.DoctorCheckIntegrity}
+
+	rows, err := db.QueryContext(ctx, "PRAGMA integrity_check;")
+	if err != nil {
+		return domain.DoctorCheck{}, fmt.Errorf("doctor integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return domain.DoctorCheck{}, fmt.Errorf("doctor scan integrity check: %w", err)
+		}
+		if message == "ok" {
+			continue
+		}
+		check.Issues++
+		if len(check.Sample) < domain.DoctorSampleLimit {
+			check.Sample = append(check.Sample, message)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.DoctorCheck{}, fmt.Errorf("doctor iterate integrity check: %w", err)
+	}
+	if check.Issues > 0 {
+		check.Message = "restore a backup; integrity errors cannot be repaired in place"
+	}
+	return check, nil
+}
+
+func checkForeignKeys(ctx context.Context, db *sql.DB) (domain.DoctorCheck, error) {
+	check := domain.DoctorCheck{Name: domain.DoctorCheckForeignKeys}
+
+	rows, err := db.QueryContext(ctx, "PRAGMA foreign_key_check;")
+	if err != nil {
+		return domain.DoctorCheck{}, fmt.Errorf("doctor foreign key check: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			table  string
+			rowID  sql.NullInt64
+			parent string
+			fkID   int64
+		)
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return domain.DoctorCheck{}, fmt.Errorf("doctor scan foreign key check: %w", err)
+		}
+		check.Issues++
+		if len(check.Sample) < domain.DoctorSampleLimit {
+			violation := map[string]any{"table": table, "parent": parent}
+			if rowID.Valid {
+				violation["rowid"] = rowID.Int64
+			}
+			check.Sample = append(check.Sample, violation)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.DoctorCheck{}, fmt.Errorf("doctor iterate foreign key check: %w", err)
+	}
+	return check, nil
+}
+
+// doctorSample returns up to DoctorSampleLimit offending rows as column maps.
+func doctorSample(ctx context.Context, query func(context.Context, string, ...any) (*sql.Rows, error), statement string) ([]any, error) {
+	rows, err := query(ctx, statement, domain.DoctorSampleLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	sample := []any{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		sample = append(sample, row)
+	}
+	return sample, rows.Err()
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"boring-budget/internal/domain"
+)
+
+func TestDiagnoseReportsAndFixesOrphanedRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := OpenAndMigrate(ctx, filepath.Join(t.TempDir(), "doctor.db"), migrationsDirFromAuditTriggerTest(t))
+	if err != nil {
+		t.Fatalf("open and migrate test db: %v", err)
+	}
+	defer db.Close()
+
+	for _, statement := range []string{
+		"INSERT INTO labels (id, name) VALUES (1, 'trip');",
+		"INSERT INTO cards (id, nickname, last4, brand, card_type, due_day) VALUES (1, 'Main', '1234', 'VISA', 'credit', 10);",
+		"INSERT INTO transactions (id, type, amount_minor, currency_code, transaction_date_utc, deleted_at_utc) VALUES (1, 'expense', 500, 'USD', '2026-01-10T00:00:00Z', '2026-01-11T00:00:00Z');",
+		"INSERT INTO transactions (id, type, amount_minor, currency_code, transaction_date_utc) VALUES (2, 'expense', 700, 'USD', '2026-01-12T00:00:00Z');",
+		"INSERT INTO transaction_labels (transaction_id, label_id) VALUES (1, 1), (2, 1);",
+		"INSERT INTO credit_liability_events (card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id) VALUES (1, 'USD', 'charge', 500, 1), (1, 'USD', 'charge', 700, 2);",
+	} {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("seed %q: %v", statement, err)
+		}
+	}
+
+	report, err := Diagnose(ctx, db, migrationsDirFromAuditTriggerTest(t), false)
+	if err != nil {
+		t.Fatalf("diagnose: %v", err)
+	}
+	if report.Healthy || report.PendingMigrations != 0 || report.SchemaVersion != report.LatestVersion {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	assertDoctorCheck(t, report, domain.DoctorCheckOrphanedTransactionLabels, domain.DoctorStatusProblem, 1, 0)
+	assertDoctorCheck(t, report, domain.DoctorCheckDanglingLiabilityEvents, domain.DoctorStatusProblem, 1, 0)
+	assertDoctorCheck(t, report, domain.DoctorCheckIntegrity, domain.DoctorStatusOK, 0, 0)
+
+	fixed, err := Diagnose(ctx, db, migrationsDirFromAuditTriggerTest(t), true)
+	if err != nil {
+		t.Fatalf("diagnose with fix: %v", err)
+	}
+	if !fixed.Healthy {
+		t.Fatalf("expected fix to leave database healthy: %+v", fixed)
+	}
+	assertDoctorCheck(t, fixed, domain.DoctorCheckOrphanedTransactionLabels, domain.DoctorStatusFixed, 1, 1)
+	assertDoctorCheck(t, fixed, domain.DoctorCheckDanglingLiabilityEvents, domain.DoctorStatusFixed, 1, 1)
+
+	var activeLinks, events int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM transaction_labels WHERE deleted_at_utc IS NULL;").Scan(&activeLinks); err != nil {
+		t.Fatalf("count label links: %v", err)
+	}
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM credit_liability_events;").Scan(&events); err != nil {
+		t.Fatalf("count liability events: %v", err)
+	}
+	if activeLinks != 1 || events != 1 {
+		t.Fatalf("expected the live entry's rows to survive, got links=%d events=%d", activeLinks, events)
+	}
+}
+
+func assertDoctorCheck(t *testing.T, report domain.DoctorReport, name, status string, issues, fixed int64) {
+	t.Helper()
+
+	for _, check := range report.Checks {
+		if check.Name != name {
+			continue
+		}
+		if check.Status != status || check.Issues != issues || check.Fixed != fixed {
+			t.Fatalf("unexpected %s check: %+v", name, check)
+		}
+		return
+	}
+	t.Fatalf("check %s missing from report", name)
+}
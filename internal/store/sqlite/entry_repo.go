@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -19,6 +20,11 @@ type EntryRepo struct {
 }
 
 var _ ports.EntryRepositoryTxBinder = (*EntryRepo)(nil)
+var _ ports.EntryIterator = (*EntryRepo)(nil)
+var _ ports.EntryRevisionReader = (*EntryRepo)(nil)
+
+// entryPageSize bounds how many entries EachEntry reads per query.
+const entryPageSize int64 = 500
 
 func NewEntryRepo(db *sql.DB) *EntryRepo {
 	return &EntryRepo{
@@ -40,6 +46,12 @@ func (r *EntryRepo) BindTx(tx *sql.Tx) ports.EntryRepository {
 }
 
 func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain.Entry, error) {
+	return retryOnBusy(ctx, r.tx == nil, func() (domain.Entry, error) {
+		return r.add(ctx, input)
+	})
+}
+
+func (r *EntryRepo) add(ctx context.Context, input domain.EntryAddInput) (domain.Entry, error) {
 	tx, qtx, ownsTx, err := r.writeQueries(ctx, "add entry")
 	if err != nil {
 		return domain.Entry{}, err
@@ -50,6 +62,22 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 		}()
 	}
 
+	refundOfID := sql.NullInt64{}
+	allowArchivedCategory := input.AllowArchived
+	if input.RefundOfEntryID != nil {
+		original, err := checkRefundTarget(ctx, qtx, *input.RefundOfEntryID, 0, input.Type, input.CurrencyCode, input.AmountMinor)
+		if err != nil {
+			return domain.Entry{}, err
+		}
+		refundOfID = sql.NullInt64{Int64: original.ID, Valid: true}
+		inheritsCategory := input.CategoryID == nil
+		if input, err = inheritRefundDefaults(ctx, r, qtx, input, original); err != nil {
+			return domain.Entry{}, err
+		}
+		// A refund keeps the original's category even after it is archived.
+		allowArchivedCategory = allowArchivedCategory || (inheritsCategory && input.CategoryID != nil)
+	}
+
 	categoryID := sql.NullInt64{}
 	if input.CategoryID != nil {
 		isActive, err := qtx.ExistsActiveCategoryByID(ctx, *input.CategoryID)
@@ -59,6 +87,11 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 		if !isTruthy(isActive) {
 			return domain.Entry{}, domain.ErrCategoryNotFound
 		}
+		if !allowArchivedCategory {
+			if err := checkCategoryNotArchived(ctx, qtx, *input.CategoryID); err != nil {
+				return domain.Entry{}, err
+			}
+		}
 		categoryID = sql.NullInt64{Int64: *input.CategoryID, Valid: true}
 	}
 	bankAccountID := sql.NullInt64{}
@@ -78,14 +111,38 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 		note = sql.NullString{String: input.Note, Valid: true}
 	}
 
+	uid := input.UID
+	if uid == "" {
+		uid = domain.NewEntryUID()
+	}
+
+	var originalAmountMinor sql.NullInt64
+	var originalCurrencyCode, fxRate, fxRateDate sql.NullString
+	if input.Original != nil {
+		originalAmountMinor = sql.NullInt64{Int64: input.Original.AmountMinor, Valid: true}
+		originalCurrencyCode = nullableString(input.Original.CurrencyCode)
+		fxRate = nullableString(input.Original.FXRate)
+		fxRateDate = nullableString(input.Original.FXRateDate)
+	}
+
 	result, err := qtx.CreateEntry(ctx, queries.CreateEntryParams{
-		Type:               input.Type,
-		AmountMinor:        input.AmountMinor,
-		CurrencyCode:       input.CurrencyCode,
-		TransactionDateUtc: input.TransactionDateUTC,
-		CategoryID:         categoryID,
-		BankAccountID:      bankAccountID,
-		Note:               note,
+		Type:                  input.Type,
+		AmountMinor:           input.AmountMinor,
+		CurrencyCode:          input.CurrencyCode,
+		TransactionDateUtc:    input.TransactionDateUTC,
+		CategoryID:            categoryID,
+		BankAccountID:         bankAccountID,
+		RefundOfTransactionID: refundOfID,
+		Note:                  note,
+		Payee:                 nullableString(input.Payee),
+		RecordedBy:            nullableString(input.RecordedBy),
+		Uid:                   nullableString(uid),
+		IncomeSource:          nullableString(input.IncomeSource),
+		Location:              nullableString(input.Location),
+		OriginalAmountMinor:   originalAmountMinor,
+		OriginalCurrencyCode:  originalCurrencyCode,
+		FxRate:                fxRate,
+		FxRateDate:            fxRateDate,
 	})
 	if err != nil {
 		return domain.Entry{}, fmt.Errorf("add entry insert: %w", err)
@@ -104,6 +161,11 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 		if !isTruthy(isActive) {
 			return domain.Entry{}, domain.ErrLabelNotFound
 		}
+		if !input.AllowArchived {
+			if err := checkLabelNotArchived(ctx, qtx, labelID); err != nil {
+				return domain.Entry{}, err
+			}
+		}
 
 		if _, err := qtx.AddEntryLabelLink(ctx, queries.AddEntryLabelLinkParams{
 			TransactionID: entryID,
@@ -113,6 +175,16 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 		}
 	}
 
+	for _, split := range input.Splits {
+		if _, err := qtx.AddEntrySplit(ctx, queries.AddEntrySplitParams{
+			TransactionID: entryID,
+			Person:        split.Person,
+			ShareBps:      split.ShareBPS,
+		}); err != nil {
+			return domain.Entry{}, fmt.Errorf("add entry split: %w", err)
+		}
+	}
+
 	if strings.TrimSpace(input.Type) == domain.EntryTypeExpense {
 		paymentMethod := strings.ToLower(strings.TrimSpace(input.PaymentMethod))
 		if paymentMethod == "" {
@@ -124,6 +196,9 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 	} else if strings.TrimSpace(input.PaymentMethod) != "" || input.PaymentCardID != nil {
 		return domain.Entry{}, domain.ErrPaymentNotAllowed
 	}
+	if input.IncomeSource != "" && strings.TrimSpace(input.Type) != domain.EntryTypeIncome {
+		return domain.Entry{}, domain.ErrSourceNotAllowed
+	}
 
 	if ownsTx {
 		if err := tx.Commit(); err != nil {
@@ -139,6 +214,12 @@ func (r *EntryRepo) Add(ctx context.Context, input domain.EntryAddInput) (domain
 }
 
 func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (domain.Entry, error) {
+	return retryOnBusy(ctx, r.tx == nil, func() (domain.Entry, error) {
+		return r.update(ctx, input)
+	})
+}
+
+func (r *EntryRepo) update(ctx context.Context, input domain.EntryUpdateInput) (domain.Entry, error) {
 	tx, qtx, ownsTx, err := r.writeQueries(ctx, "update entry")
 	if err != nil {
 		return domain.Entry{}, err
@@ -156,6 +237,13 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 		}
 		return domain.Entry{}, fmt.Errorf("update entry load current: %w", err)
 	}
+	if input.ExpectedUpdatedAtUTC != nil && current.UpdatedAtUtc != *input.ExpectedUpdatedAtUTC {
+		return domain.Entry{}, domain.ErrUpdateConflict
+	}
+	before, err := r.loadActiveEntry(ctx, qtx, input.ID)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("update entry load revision state: %w", err)
+	}
 
 	categoryID := current.CategoryID
 	clearCategory := int64(0)
@@ -172,6 +260,13 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 			if !isTruthy(isActive) {
 				return domain.Entry{}, domain.ErrCategoryNotFound
 			}
+			// Keeping the entry's current category is always allowed.
+			keepsCategory := current.CategoryID.Valid && current.CategoryID.Int64 == *input.CategoryID
+			if !input.AllowArchived && !keepsCategory {
+				if err := checkCategoryNotArchived(ctx, qtx, *input.CategoryID); err != nil {
+					return domain.Entry{}, err
+				}
+			}
 			setCategoryID = 1
 			categoryID = sql.NullInt64{Int64: *input.CategoryID, Valid: true}
 		}
@@ -224,6 +319,22 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 		transactionDateUTC = *input.TransactionDateUTC
 	}
 
+	if current.RefundOfTransactionID.Valid {
+		if _, err := checkRefundTarget(ctx, qtx, current.RefundOfTransactionID.Int64, current.ID, entryType, currencyCode, amountMinor); err != nil {
+			return domain.Entry{}, err
+		}
+	}
+	if err := checkRefundedEntryUpdate(ctx, qtx, current, entryType, currencyCode, amountMinor); err != nil {
+		return domain.Entry{}, err
+	}
+
+	// The original figures of a converted entry no longer describe it once
+	// its recorded amount or currency changes.
+	clearOriginal := int64(0)
+	if current.OriginalAmountMinor.Valid && (amountMinor != current.AmountMinor || currencyCode != current.CurrencyCode) {
+		clearOriginal = 1
+	}
+
 	clearNote := int64(0)
 	setNote := int64(0)
 	note := current.Note
@@ -237,6 +348,60 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 		}
 	}
 
+	clearPayee := int64(0)
+	setPayee := int64(0)
+	payee := current.Payee
+	if input.SetPayee {
+		if input.Payee == nil {
+			clearPayee = 1
+			payee = sql.NullString{}
+		} else {
+			setPayee = 1
+			payee = sql.NullString{String: *input.Payee, Valid: true}
+		}
+	}
+
+	clearRecordedBy := int64(0)
+	setRecordedBy := int64(0)
+	recordedBy := current.RecordedBy
+	if input.SetRecordedBy {
+		if input.RecordedBy == nil {
+			clearRecordedBy = 1
+			recordedBy = sql.NullString{}
+		} else {
+			setRecordedBy = 1
+			recordedBy = sql.NullString{String: *input.RecordedBy, Valid: true}
+		}
+	}
+
+	// Only income carries a source; an entry that stops being income drops it.
+	clearIncomeSource := int64(0)
+	setIncomeSource := int64(0)
+	incomeSource := current.IncomeSource
+	if input.SetIncomeSource && input.IncomeSource != nil {
+		if strings.TrimSpace(entryType) != domain.EntryTypeIncome {
+			return domain.Entry{}, domain.ErrSourceNotAllowed
+		}
+		setIncomeSource = 1
+		incomeSource = sql.NullString{String: *input.IncomeSource, Valid: true}
+	} else if input.SetIncomeSource || strings.TrimSpace(entryType) != domain.EntryTypeIncome {
+		clearIncomeSource = 1
+		incomeSource = sql.NullString{}
+	}
+
+	clearLocation := int64(0)
+	setLocation := int64(0)
+	location := current.Location
+	if input.SetLocation {
+		if input.Location == nil {
+			clearLocation = 1
+			location = sql.NullString{}
+		} else {
+			setLocation = 1
+			location = sql.NullString{String: *input.Location, Valid: true}
+		}
+	}
+
 	updatedAtUTC := time.Now().UTC().Format(time.RFC3339Nano)
 	updateResult, err := qtx.UpdateEntryByID(ctx, queries.UpdateEntryByIDParams{
 		SetType:               setType,
@@ -256,6 +421,19 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 		ClearNote:             clearNote,
 		SetNote:               setNote,
 		Note:                  note,
+		ClearPayee:            clearPayee,
+		SetPayee:              setPayee,
+		Payee:                 payee,
+		ClearRecordedBy:       clearRecordedBy,
+		SetRecordedBy:         setRecordedBy,
+		RecordedBy:            recordedBy,
+		ClearIncomeSource:     clearIncomeSource,
+		SetIncomeSource:       setIncomeSource,
+		IncomeSource:          incomeSource,
+		ClearLocation:         clearLocation,
+		SetLocation:           setLocation,
+		Location:              location,
+		ClearOriginal:         clearOriginal,
 		UpdatedAtUtc:          updatedAtUTC,
 		ID:                    input.ID,
 	})
@@ -272,6 +450,16 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 	}
 
 	if input.SetLabelIDs {
+		currentLabels := map[int64]struct{}{}
+		if !input.AllowArchived {
+			labelRows, err := qtx.ListActiveEntryLabelIDs(ctx, input.ID)
+			if err != nil {
+				return domain.Entry{}, fmt.Errorf("update entry load labels: %w", err)
+			}
+			for _, labelRow := range labelRows {
+				currentLabels[labelRow.LabelID] = struct{}{}
+			}
+		}
 		for _, labelID := range input.LabelIDs {
 			isActive, err := qtx.ExistsActiveLabelByID(ctx, labelID)
 			if err != nil {
@@ -280,6 +468,11 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 			if !isTruthy(isActive) {
 				return domain.Entry{}, domain.ErrLabelNotFound
 			}
+			if _, linked := currentLabels[labelID]; !input.AllowArchived && !linked {
+				if err := checkLabelNotArchived(ctx, qtx, labelID); err != nil {
+					return domain.Entry{}, err
+				}
+			}
 		}
 
 		_, err := qtx.SoftDeleteEntryLabelLinks(ctx, queries.SoftDeleteEntryLabelLinksParams{
@@ -344,6 +537,14 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 		}
 	}
 
+	after, err := r.loadActiveEntry(ctx, qtx, input.ID)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("update entry load revision state: %w", err)
+	}
+	if err := createEntryRevision(ctx, qtx, before, after, updatedAtUTC); err != nil {
+		return domain.Entry{}, err
+	}
+
 	if ownsTx {
 		if err := tx.Commit(); err != nil {
 			return domain.Entry{}, fmt.Errorf("update entry commit: %w", err)
@@ -353,62 +554,230 @@ func (r *EntryRepo) Update(ctx context.Context, input domain.EntryUpdateInput) (
 	return r.getActiveByID(ctx, input.ID)
 }
 
-func (r *EntryRepo) List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
-	if r.db == nil {
-		return nil, fmt.Errorf("list entries: db is nil")
+// createEntryRevision keeps the state before had when an update changed any
+// editable field of it. Updates that change nothing leave no revision.
+func createEntryRevision(ctx context.Context, qtx *queries.Queries, before, after domain.Entry, replacedAtUTC string) error {
+	state := domain.EntryRevisionStateOf(before)
+	if len(domain.DiffEntryRevisionStates(state, domain.EntryRevisionStateOf(after))) == 0 {
+		return nil
 	}
 
-	params := queries.ListActiveEntriesParams{
-		EntryType:     nullableString(filter.Type),
-		CategoryID:    nullableInt64(filter.CategoryID),
-		BankAccountID: nullableInt64(filter.BankAccountID),
-		DateFromUtc:   nullableString(filter.DateFromUTC),
-		DateToUtc:     nullableString(filter.DateToUTC),
-		NoteContains:  nullableString(filter.NoteContains),
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("update entry encode revision: %w", err)
 	}
-	rows, err := r.queries.ListActiveEntries(ctx, params)
+	if _, err := qtx.CreateEntryRevision(ctx, queries.CreateEntryRevisionParams{
+		TransactionID: before.ID,
+		StateJson:     string(stateJSON),
+		ReplacedAtUtc: replacedAtUTC,
+	}); err != nil {
+		return fmt.Errorf("update entry create revision: %w", err)
+	}
+	return nil
+}
+
+// ListRevisions returns the revisions of entry id, oldest first. Revisions of
+// deleted entries stay readable.
+func (r *EntryRepo) ListRevisions(ctx context.Context, id int64) ([]domain.EntryRevision, error) {
+	rows, err := r.queries.ListEntryRevisions(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("list entries: %w", err)
+		return nil, fmt.Errorf("list entry revisions: %w", err)
+	}
+
+	revisions := make([]domain.EntryRevision, 0, len(rows))
+	for _, row := range rows {
+		revision, err := mapSQLCEntryRevision(row)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
 	}
+	return revisions, nil
+}
 
-	labelRows, err := r.queries.ListActiveEntryLabelIDsForListFilter(ctx, queries.ListActiveEntryLabelIDsForListFilterParams{
-		EntryType:     params.EntryType,
-		CategoryID:    params.CategoryID,
-		BankAccountID: params.BankAccountID,
-		DateFromUtc:   params.DateFromUtc,
-		DateToUtc:     params.DateToUtc,
-		NoteContains:  params.NoteContains,
+// GetRevision returns one revision of entry id.
+func (r *EntryRepo) GetRevision(ctx context.Context, id, revision int64) (domain.EntryRevision, error) {
+	row, err := r.queries.GetEntryRevision(ctx, queries.GetEntryRevisionParams{
+		TransactionID: id,
+		Revision:      revision,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("list entry labels: %w", err)
+		if err == sql.ErrNoRows {
+			return domain.EntryRevision{}, domain.ErrEntryRevisionNotFound
+		}
+		return domain.EntryRevision{}, fmt.Errorf("get entry revision: %w", err)
 	}
+	return mapSQLCEntryRevision(row)
+}
 
-	labelIDsByTransactionID := make(map[int64][]int64, len(rows))
-	for _, labelRow := range labelRows {
-		labelIDsByTransactionID[labelRow.TransactionID] = append(labelIDsByTransactionID[labelRow.TransactionID], labelRow.LabelID)
+func mapSQLCEntryRevision(row queries.TransactionRevision) (domain.EntryRevision, error) {
+	revision := domain.EntryRevision{
+		EntryID:       row.TransactionID,
+		Revision:      row.Revision,
+		ReplacedAtUTC: row.ReplacedAtUtc,
+	}
+	if err := json.Unmarshal([]byte(row.StateJson), &revision.State); err != nil {
+		return domain.EntryRevision{}, fmt.Errorf("decode entry revision %d of entry %d: %w", row.Revision, row.TransactionID, err)
+	}
+	if revision.State.LabelIDs == nil {
+		revision.State.LabelIDs = []int64{}
+	}
+	return revision, nil
+}
+
+func (r *EntryRepo) Get(ctx context.Context, id int64) (domain.Entry, error) {
+	return r.getActiveByID(ctx, id)
+}
+
+// GetByUID returns the active entry carrying uid.
+func (r *EntryRepo) GetByUID(ctx context.Context, uid string) (domain.Entry, error) {
+	row, err := r.queries.GetActiveEntryByUID(ctx, nullableString(uid))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Entry{}, domain.ErrEntryNotFound
+		}
+		return domain.Entry{}, fmt.Errorf("get active entry by uid: %w", err)
+	}
+	return r.getActiveByID(ctx, row.ID)
+}
+
+func (r *EntryRepo) List(ctx context.Context, filter domain.EntryListFilter) ([]domain.Entry, error) {
+	entries := make([]domain.Entry, 0)
+	if err := r.EachEntry(ctx, filter, func(entry domain.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// MonthTotals reads the per-month, per-currency totals the
+// entry_month_totals triggers keep in step with every entry write.
+func (r *EntryRepo) MonthTotals(ctx context.Context, filter domain.EntryMonthTotalsFilter) ([]domain.EntryMonthTotal, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list entry month totals: db is nil")
+	}
+
+	rows, err := r.queries.ListEntryMonthTotals(ctx, queries.ListEntryMonthTotalsParams{
+		FromMonth:    nullableString(filter.FromMonth),
+		ToMonth:      nullableString(filter.ToMonth),
+		CurrencyCode: nullableString(filter.CurrencyCode),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list entry month totals: %w", err)
 	}
 
-	entries := make([]domain.Entry, 0, len(rows))
+	totals := make([]domain.EntryMonthTotal, 0, len(rows))
 	for _, row := range rows {
-		paymentInfo, err := r.loadPaymentInfo(ctx, r.queries, row.ID)
+		totals = append(totals, domain.EntryMonthTotal{
+			MonthKey:     row.MonthKey,
+			CurrencyCode: row.CurrencyCode,
+			IncomeMinor:  row.IncomeMinor,
+			ExpenseMinor: row.ExpenseMinor,
+			EntryCount:   row.EntryCount,
+		})
+	}
+	return totals, nil
+}
+
+// EachEntry calls fn for every entry matching filter in List order. Entries
+// are read in keyset pages of entryPageSize rows so callers can stream large
+// ledgers without holding them in memory. Labels and payment methods are
+// loaded for each page's IDs in one query each, and the page is fully read
+// before fn runs, leaving the connection free for queries made from fn.
+func (r *EntryRepo) EachEntry(ctx context.Context, filter domain.EntryListFilter, fn func(domain.Entry) error) error {
+	if r.db == nil {
+		return fmt.Errorf("list entries: db is nil")
+	}
+
+	params := queries.ListActiveEntriesPageParams{
+		EntryType:        nullableString(filter.Type),
+		CategoryID:       nullableInt64(filter.CategoryID),
+		BankAccountID:    nullableInt64(filter.BankAccountID),
+		DateFromUtc:      nullableString(filter.DateFromUTC),
+		DateToUtc:        nullableString(filter.DateToUTC),
+		NoteContains:     nullableString(filter.NoteContains),
+		Payee:            nullableString(filter.Payee),
+		CurrencyCode:     nullableString(filter.CurrencyCode),
+		AmountMinMinor:   nullableInt64(filter.AmountMinMinor),
+		AmountMaxMinor:   nullableInt64(filter.AmountMaxMinor),
+		RecordedBy:       nullableString(filter.RecordedBy),
+		IncomeSource:     nullableString(filter.IncomeSource),
+		LocationContains: nullableString(filter.LocationContains),
+		PageSize:         entryPageSize,
+	}
+
+	for {
+		rows, err := r.queries.ListActiveEntriesPage(ctx, params)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("list entries: %w", err)
 		}
-		if !matchesEntryPaymentFilter(row.Type, paymentInfo, filter) {
-			continue
+		if len(rows) == 0 {
+			return nil
 		}
 
-		labelIDs := labelIDsByTransactionID[row.ID]
-		if labelIDs == nil {
-			labelIDs = make([]int64, 0)
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		idsJSON, err := json.Marshal(ids)
+		if err != nil {
+			return fmt.Errorf("list entries: %w", err)
 		}
-		entries = append(entries, mapSQLCTransactionToDomainEntry(row, labelIDs, paymentInfo))
-	}
 
-	return entries, nil
+		labelRows, err := r.queries.ListActiveEntryLabelIDsByTransactionIDs(ctx, string(idsJSON))
+		if err != nil {
+			return fmt.Errorf("list entry labels: %w", err)
+		}
+		labelIDsByTransactionID := make(map[int64][]int64, len(rows))
+		for _, labelRow := range labelRows {
+			labelIDsByTransactionID[labelRow.TransactionID] = append(labelIDsByTransactionID[labelRow.TransactionID], labelRow.LabelID)
+		}
+
+		paymentRows, err := r.queries.ListEntryPaymentInfoByTransactionIDs(ctx, string(idsJSON))
+		if err != nil {
+			return fmt.Errorf("load entry payment method: %w", err)
+		}
+		paymentInfoByTransactionID := make(map[int64]entryPaymentInfo, len(paymentRows))
+		for _, paymentRow := range paymentRows {
+			paymentInfoByTransactionID[paymentRow.TransactionID] = mapEntryPaymentInfoRow(paymentRow)
+		}
+
+		for _, row := range rows {
+			paymentInfo, ok := paymentInfoByTransactionID[row.ID]
+			if !ok {
+				paymentInfo = entryPaymentInfo{Method: domain.PaymentMethodCash}
+			}
+			if !matchesEntryPaymentFilter(row.Type, paymentInfo, filter) {
+				continue
+			}
+
+			labelIDs := labelIDsByTransactionID[row.ID]
+			if labelIDs == nil {
+				labelIDs = make([]int64, 0)
+			}
+			if err := fn(mapSQLCTransactionToDomainEntry(row, labelIDs, paymentInfo)); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(rows)) < entryPageSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		params.AfterDateUtc = last.TransactionDateUtc
+		params.AfterID = last.ID
+	}
 }
 
 func (r *EntryRepo) Delete(ctx context.Context, id int64) (domain.EntryDeleteResult, error) {
+	return retryOnBusy(ctx, r.tx == nil, func() (domain.EntryDeleteResult, error) {
+		return r.delete(ctx, id)
+	})
+}
+
+func (r *EntryRepo) delete(ctx context.Context, id int64) (domain.EntryDeleteResult, error) {
 	tx, qtx, ownsTx, err := r.writeQueries(ctx, "delete entry")
 	if err != nil {
 		return domain.EntryDeleteResult{}, err
@@ -419,6 +788,16 @@ func (r *EntryRepo) Delete(ctx context.Context, id int64) (domain.EntryDeleteRes
 		}()
 	}
 
+	refunded, err := qtx.SumActiveRefundsByTransactionID(ctx, queries.SumActiveRefundsByTransactionIDParams{
+		RefundOfTransactionID: sql.NullInt64{Int64: id, Valid: true},
+	})
+	if err != nil {
+		return domain.EntryDeleteResult{}, fmt.Errorf("delete entry check refunds: %w", err)
+	}
+	if refunded > 0 {
+		return domain.EntryDeleteResult{}, domain.ErrEntryHasRefunds
+	}
+
 	deletedAtUTC := time.Now().UTC().Format(time.RFC3339Nano)
 
 	deleteResult, err := qtx.SoftDeleteEntry(ctx, queries.SoftDeleteEntryParams{
@@ -468,6 +847,50 @@ func (r *EntryRepo) Delete(ctx context.Context, id int64) (domain.EntryDeleteRes
 	}, nil
 }
 
+// ReplaceCurrency moves every active entry in fromCurrency to toCurrency, along
+// with the card charge events those entries produced, and returns the entry IDs.
+func (r *EntryRepo) ReplaceCurrency(ctx context.Context, fromCurrency, toCurrency string) ([]int64, error) {
+	tx, qtx, ownsTx, err := r.writeQueries(ctx, "replace entry currency")
+	if err != nil {
+		return nil, err
+	}
+	if ownsTx {
+		defer func() {
+			_ = tx.Rollback()
+		}()
+	}
+
+	entryIDs, err := qtx.ListActiveEntryIDsByCurrency(ctx, fromCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("replace entry currency list entries: %w", err)
+	}
+	if entryIDs == nil {
+		entryIDs = []int64{}
+	}
+
+	if _, err := qtx.UpdateCreditLiabilityEventsCurrency(ctx, queries.UpdateCreditLiabilityEventsCurrencyParams{
+		ToCurrencyCode:   toCurrency,
+		FromCurrencyCode: fromCurrency,
+	}); err != nil {
+		return nil, fmt.Errorf("replace entry currency liability events: %w", err)
+	}
+
+	if _, err := qtx.UpdateActiveEntriesCurrency(ctx, queries.UpdateActiveEntriesCurrencyParams{
+		ToCurrencyCode:   toCurrency,
+		UpdatedAtUtc:     time.Now().UTC().Format(time.RFC3339Nano),
+		FromCurrencyCode: fromCurrency,
+	}); err != nil {
+		return nil, fmt.Errorf("replace entry currency update entries: %w", err)
+	}
+
+	if ownsTx {
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("replace entry currency commit: %w", err)
+		}
+	}
+	return entryIDs, nil
+}
+
 func (r *EntryRepo) writeQueries(ctx context.Context, operation string) (*sql.Tx, *queries.Queries, bool, error) {
 	if r.tx != nil {
 		return nil, r.queries, false, nil
@@ -486,7 +909,13 @@ func (r *EntryRepo) writeQueries(ctx context.Context, operation string) (*sql.Tx
 }
 
 func (r *EntryRepo) getActiveByID(ctx context.Context, id int64) (domain.Entry, error) {
-	row, err := r.queries.GetActiveEntryByID(ctx, id)
+	return r.loadActiveEntry(ctx, r.queries, id)
+}
+
+// loadActiveEntry reads entry id through q, so writers can see their own
+// uncommitted changes.
+func (r *EntryRepo) loadActiveEntry(ctx context.Context, q *queries.Queries, id int64) (domain.Entry, error) {
+	row, err := q.GetActiveEntryByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.Entry{}, domain.ErrEntryNotFound
@@ -494,7 +923,7 @@ func (r *EntryRepo) getActiveByID(ctx context.Context, id int64) (domain.Entry,
 		return domain.Entry{}, fmt.Errorf("get active entry by id: %w", err)
 	}
 
-	labelRows, err := r.queries.ListActiveEntryLabelIDs(ctx, id)
+	labelRows, err := q.ListActiveEntryLabelIDs(ctx, id)
 	if err != nil {
 		return domain.Entry{}, fmt.Errorf("list labels for entry %d: %w", id, err)
 	}
@@ -504,12 +933,25 @@ func (r *EntryRepo) getActiveByID(ctx context.Context, id int64) (domain.Entry,
 		labelIDs = append(labelIDs, labelRow.LabelID)
 	}
 
-	paymentInfo, err := r.loadPaymentInfo(ctx, r.queries, id)
+	paymentInfo, err := r.loadPaymentInfo(ctx, q, id)
 	if err != nil {
 		return domain.Entry{}, err
 	}
 
-	return mapSQLCTransactionToDomainEntry(row, labelIDs, paymentInfo), nil
+	splitRows, err := q.ListActiveEntrySplits(ctx, id)
+	if err != nil {
+		return domain.Entry{}, fmt.Errorf("list splits for entry %d: %w", id, err)
+	}
+
+	entry := mapSQLCTransactionToDomainEntry(row, labelIDs, paymentInfo)
+	for _, splitRow := range splitRows {
+		entry.Splits = append(entry.Splits, domain.EntrySplit{
+			Person:      splitRow.Person,
+			ShareBPS:    splitRow.ShareBps,
+			AmountMinor: domain.SplitShareMinor(entry.AmountMinor, splitRow.ShareBps),
+		})
+	}
+	return entry, nil
 }
 
 func mapSQLCTransactionToDomainEntry(row queries.Transaction, labelIDs []int64, paymentInfo entryPaymentInfo) domain.Entry {
@@ -525,22 +967,37 @@ func mapSQLCTransactionToDomainEntry(row queries.Transaction, labelIDs []int64,
 
 	entry := domain.Entry{
 		ID:                 row.ID,
+		UID:                row.Uid.String,
 		Type:               row.Type,
 		AmountMinor:        row.AmountMinor,
 		CurrencyCode:       row.CurrencyCode,
 		TransactionDateUTC: row.TransactionDateUtc,
 		CategoryID:         categoryID,
 		BankAccountID:      ptrInt64FromNull(row.BankAccountID),
+		RefundOfEntryID:    ptrInt64FromNull(row.RefundOfTransactionID),
 		LabelIDs:           labelIDs,
 		Note:               note,
+		Payee:              row.Payee.String,
+		RecordedBy:         row.RecordedBy.String,
+		IncomeSource:       row.IncomeSource.String,
+		Location:           row.Location.String,
 		CreatedAtUTC:       row.CreatedAtUtc,
 		UpdatedAtUTC:       row.UpdatedAtUtc,
 	}
+	if row.OriginalAmountMinor.Valid {
+		entry.Original = &domain.EntryOriginalAmount{
+			AmountMinor:  row.OriginalAmountMinor.Int64,
+			CurrencyCode: row.OriginalCurrencyCode.String,
+			FXRate:       row.FxRate.String,
+			FXRateDate:   row.FxRateDate.String,
+		}
+	}
 	if strings.TrimSpace(row.Type) == domain.EntryTypeExpense {
 		entry.PaymentMethod = paymentInfo.Method
 		entry.PaymentCardID = paymentInfo.CardID
 		entry.PaymentCardNickname = paymentInfo.CardNickname
 		entry.PaymentCardType = paymentInfo.CardType
+		entry.PaymentCardBrand = paymentInfo.CardBrand
 	}
 	return entry
 }
@@ -574,14 +1031,34 @@ func (r *EntryRepo) SyncCreditLiabilityCharge(ctx context.Context, entryID int64
 			return err
 		}
 		if paymentInfo.Method == domain.PaymentMethodCard && paymentInfo.CardID != nil && paymentInfo.CardType == domain.PaymentMethodFilterCredit {
+			// A refund credited back to the card lowers its debt, which the
+			// liability ledger records as a negative adjustment.
+			eventType, amountMinorSigned := domain.CardLiabilityEventCharge, entry.AmountMinor
+			var fxFeeMinor int64
+			if entry.RefundOfTransactionID.Valid {
+				eventType, amountMinorSigned = domain.CardLiabilityEventAdjustment, -entry.AmountMinor
+			} else {
+				// A charge converted at record time was still made in its
+				// original currency.
+				chargeCurrency := entry.CurrencyCode
+				if entry.OriginalCurrencyCode.Valid {
+					chargeCurrency = entry.OriginalCurrencyCode.String
+				}
+				fxFeeMinor, err = cardFXFeeForCharge(ctx, qtx, *paymentInfo.CardID, chargeCurrency, entry.AmountMinor)
+				if err != nil {
+					return err
+				}
+				amountMinorSigned += fxFeeMinor
+			}
 			nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
 			_, err = qtx.CreateCreditLiabilityEvent(ctx, queries.CreateCreditLiabilityEventParams{
 				CardID:                 *paymentInfo.CardID,
 				CurrencyCode:           entry.CurrencyCode,
-				EventType:              domain.CardLiabilityEventCharge,
-				AmountMinorSigned:      entry.AmountMinor,
+				EventType:              eventType,
+				AmountMinorSigned:      amountMinorSigned,
 				ReferenceTransactionID: sql.NullInt64{Int64: entryID, Valid: true},
 				Note:                   sql.NullString{},
+				FxFeeMinor:             fxFeeMinor,
 				CreatedAtUtc:           nowUTC,
 			})
 			if err != nil {
@@ -598,13 +1075,190 @@ func (r *EntryRepo) SyncCreditLiabilityCharge(ctx context.Context, entryID int64
 	return nil
 }
 
+// cardFXFeeForCharge is the card's foreign transaction fee on a charge in
+// currencyCode: nothing unless the card has a fee and the currency differs
+// from the card's default currency, or the settings default when it has none.
+// The fee only adds to the charge's liability event, so it is card debt but
+// not spending: reports, balances and caps use the entry amount, and
+// card fx-fees totals the fees.
+func cardFXFeeForCharge(ctx context.Context, qtx *queries.Queries, cardID int64, currencyCode string, amountMinor int64) (int64, error) {
+	terms, err := qtx.GetCardFXFeeTerms(ctx, cardID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("sync credit liability load card fx fee: %w", err)
+	}
+	if !terms.FxFeeBp.Valid {
+		return 0, nil
+	}
+	homeCurrency := stringFromInterface(terms.HomeCurrencyCode)
+	if homeCurrency == "" || strings.EqualFold(homeCurrency, currencyCode) {
+		return 0, nil
+	}
+	return domain.CardFXFeeMinor(amountMinor, terms.FxFeeBp.Int64), nil
+}
+
+// checkRefundTarget validates a refund of amountMinor against the entry it
+// refunds: the original must be an active expense that is not itself a
+// refund, in the same currency, and its refunds (other than excludeID) plus
+// this one may not exceed it.
+func checkRefundTarget(ctx context.Context, qtx *queries.Queries, originalID, excludeID int64, refundType, currencyCode string, amountMinor int64) (queries.Transaction, error) {
+	if strings.TrimSpace(refundType) != domain.EntryTypeExpense {
+		return queries.Transaction{}, domain.ErrRefundRequiresExpense
+	}
+
+	original, err := qtx.GetActiveEntryByID(ctx, originalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return queries.Transaction{}, fmt.Errorf("%w: refund-of %d", domain.ErrEntryNotFound, originalID)
+		}
+		return queries.Transaction{}, fmt.Errorf("load refunded entry: %w", err)
+	}
+	if original.Type != domain.EntryTypeExpense || original.RefundOfTransactionID.Valid {
+		return queries.Transaction{}, domain.ErrInvalidRefundTarget
+	}
+	if original.CurrencyCode != currencyCode {
+		return queries.Transaction{}, domain.ErrRefundCurrencyMismatch
+	}
+
+	refunded, err := qtx.SumActiveRefundsByTransactionID(ctx, queries.SumActiveRefundsByTransactionIDParams{
+		RefundOfTransactionID: sql.NullInt64{Int64: originalID, Valid: true},
+		ExcludeID:             excludeID,
+	})
+	if err != nil {
+		return queries.Transaction{}, fmt.Errorf("sum refunds of entry %d: %w", originalID, err)
+	}
+	if refunded+amountMinor > original.AmountMinor {
+		return queries.Transaction{}, domain.ErrRefundExceedsOriginal
+	}
+	return original, nil
+}
+
+// checkRefundedEntryUpdate keeps an entry that has refunds a valid refund
+// target: it stays an expense in its current currency and at least as large
+// as what was refunded.
+func checkRefundedEntryUpdate(ctx context.Context, qtx *queries.Queries, current queries.Transaction, entryType, currencyCode string, amountMinor int64) error {
+	refunded, err := qtx.SumActiveRefundsByTransactionID(ctx, queries.SumActiveRefundsByTransactionIDParams{
+		RefundOfTransactionID: sql.NullInt64{Int64: current.ID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("sum refunds of entry %d: %w", current.ID, err)
+	}
+	if refunded == 0 {
+		return nil
+	}
+
+	switch {
+	case strings.TrimSpace(entryType) != domain.EntryTypeExpense:
+		return domain.ErrInvalidRefundTarget
+	case currencyCode != current.CurrencyCode:
+		return domain.ErrRefundCurrencyMismatch
+	case refunded > amountMinor:
+		return domain.ErrRefundExceedsOriginal
+	}
+	return nil
+}
+
+// inheritRefundDefaults fills a refund's category and payment method from the
+// original when the caller left them out, so the refund nets against the same
+
+// checkCategoryNotArchived rejects archived categories for new writes.
+func checkCategoryNotArchived(ctx context.Context, qtx *queries.Queries, id int64) error {
+	isArchived, err := qtx.ExistsArchivedCategoryByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("check category archived: %w", err)
+	}
+	if isTruthy(isArchived) {
+		return domain.ErrCategoryArchived
+	}
+	return nil
+}
+
+// checkLabelNotArchived rejects archived labels for new writes.
+func checkLabelNotArchived(ctx context.Context, qtx *queries.Queries, id int64) error {
+	isArchived, err := qtx.ExistsArchivedLabelByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("check label %d archived: %w", id, err)
+	}
+	if isTruthy(isArchived) {
+		return domain.ErrLabelArchived
+	}
+	return nil
+}
+
+// category and card. Deleted categories and cards are not inherited.
+func inheritRefundDefaults(ctx context.Context, r *EntryRepo, qtx *queries.Queries, input domain.EntryAddInput, original queries.Transaction) (domain.EntryAddInput, error) {
+	if input.CategoryID == nil && original.CategoryID.Valid {
+		isActive, err := qtx.ExistsActiveCategoryByID(ctx, original.CategoryID.Int64)
+		if err != nil {
+			return domain.EntryAddInput{}, fmt.Errorf("add refund check category: %w", err)
+		}
+		if isTruthy(isActive) {
+			value := original.CategoryID.Int64
+			input.CategoryID = &value
+		}
+	}
+
+	if strings.TrimSpace(input.PaymentMethod) == "" && input.PaymentCardID == nil {
+		method, cardID, err := r.loadPaymentMethodState(ctx, qtx, original.ID)
+		if err != nil {
+			return domain.EntryAddInput{}, err
+		}
+		if method == domain.PaymentMethodCard && cardID != nil {
+			exists, err := qtx.ExistsActiveCardByID(ctx, *cardID)
+			if err != nil {
+				return domain.EntryAddInput{}, fmt.Errorf("add refund check card: %w", err)
+			}
+			if isTruthy(exists) {
+				input.PaymentMethod = domain.PaymentMethodCard
+				input.PaymentCardID = cardID
+			}
+		}
+	}
+	return input, nil
+}
+
 type entryPaymentInfo struct {
 	Method          string
 	CardID          *int64
 	CardNickname    string
 	CardType        string
+	CardBrand       string
 	CardDescription string
 	CardLast4       string
+	// CardAliases is only read by the bulk list path, for selector filters.
+	CardAliases []string
+}
+
+// mapEntryPaymentInfoRow mirrors loadPaymentInfo for a row read in bulk; a
+// deleted card leaves the card ID set with no card details.
+func mapEntryPaymentInfoRow(row queries.ListEntryPaymentInfoByTransactionIDsRow) entryPaymentInfo {
+	info := entryPaymentInfo{
+		Method: strings.ToLower(strings.TrimSpace(row.MethodType)),
+	}
+	if info.Method == "" {
+		info.Method = domain.PaymentMethodCash
+	}
+	if !row.CardID.Valid {
+		return info
+	}
+
+	cardID := row.CardID.Int64
+	info.CardID = &cardID
+	if !row.CardNickname.Valid {
+		return info
+	}
+
+	info.CardNickname = row.CardNickname.String
+	info.CardType = strings.ToLower(strings.TrimSpace(row.CardType.String))
+	info.CardLast4 = row.CardLast4.String
+	info.CardBrand = row.CardBrand.String
+	info.CardDescription = row.CardDescription.String
+	if aliases := stringFromInterface(row.CardAliases); aliases != "" {
+		info.CardAliases = strings.Split(aliases, "\n")
+	}
+	return info
 }
 
 func (r *EntryRepo) loadPaymentInfo(ctx context.Context, q *queries.Queries, entryID int64) (entryPaymentInfo, error) {
@@ -640,6 +1294,7 @@ func (r *EntryRepo) loadPaymentInfo(ctx context.Context, q *queries.Queries, ent
 	info.CardNickname = card.Nickname
 	info.CardType = strings.ToLower(strings.TrimSpace(card.CardType))
 	info.CardLast4 = card.Last4
+	info.CardBrand = card.Brand
 	if card.Description.Valid {
 		info.CardDescription = card.Description.String
 	}
@@ -742,7 +1397,8 @@ func matchesEntryPaymentFilter(entryType string, info entryPaymentInfo, filter d
 	}
 
 	nicknameFilter := strings.TrimSpace(filter.PaymentCardNickname)
-	if nicknameFilter != "" && !strings.EqualFold(strings.TrimSpace(info.CardNickname), nicknameFilter) {
+	if nicknameFilter != "" && !strings.EqualFold(strings.TrimSpace(info.CardNickname), nicknameFilter) &&
+		!cardAliasMatches(info.CardAliases, func(alias string) bool { return strings.EqualFold(alias, nicknameFilter) }) {
 		return false
 	}
 
@@ -751,7 +1407,8 @@ func matchesEntryPaymentFilter(entryType string, info entryPaymentInfo, filter d
 		lookupLower := strings.ToLower(lookupFilter)
 		if !strings.Contains(strings.ToLower(info.CardNickname), lookupLower) &&
 			!strings.Contains(strings.ToLower(info.CardDescription), lookupLower) &&
-			!strings.Contains(strings.ToLower(info.CardLast4), lookupLower) {
+			!strings.Contains(strings.ToLower(info.CardLast4), lookupLower) &&
+			!cardAliasMatches(info.CardAliases, func(alias string) bool { return strings.Contains(strings.ToLower(alias), lookupLower) }) {
 			return false
 		}
 	}
@@ -759,6 +1416,15 @@ func matchesEntryPaymentFilter(entryType string, info entryPaymentInfo, filter d
 	return true
 }
 
+func cardAliasMatches(aliases []string, match func(alias string) bool) bool {
+	for _, alias := range aliases {
+		if match(alias) {
+			return true
+		}
+	}
+	return false
+}
+
 func nullableString(value string) sql.NullString {
 	if strings.TrimSpace(value) == "" {
 		return sql.NullString{}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
+	"strings"
 	"testing"
 
 	"boring-budget/internal/domain"
@@ -348,7 +351,68 @@ func TestEntryRepoListReturnsDeterministicLabelsWithoutNPlusOneBehaviorChanges(t
 	}
 }
 
-func TestListActiveEntryLabelIDsForListFilterQueryOrderingAndFiltering(t *testing.T) {
+func TestEntryRepoEachEntryMatchesListAcrossPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openEntryTestDB(t)
+	defer db.Close()
+
+	repo := NewEntryRepo(db)
+	labelID := insertLabelForEntryTest(t, ctx, db, "Paged")
+
+	dates := []string{"2026-03-02T00:00:00Z", "2026-03-01T00:00:00Z", "2026-03-03T00:00:00Z"}
+	total := int(entryPageSize)*2 + 3
+	for i := 0; i < total; i++ {
+		input := domain.EntryAddInput{
+			Type:               domain.EntryTypeExpense,
+			AmountMinor:        int64(100 + i),
+			CurrencyCode:       "USD",
+			TransactionDateUTC: dates[i%len(dates)],
+		}
+		if i%7 == 0 {
+			input.LabelIDs = []int64{labelID}
+		}
+		if _, err := repo.Add(ctx, input); err != nil {
+			t.Fatalf("add entry %d: %v", i, err)
+		}
+	}
+
+	listed, err := repo.List(ctx, domain.EntryListFilter{})
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+
+	streamed := make([]domain.Entry, 0, len(listed))
+	if err := repo.EachEntry(ctx, domain.EntryListFilter{}, func(entry domain.Entry) error {
+		streamed = append(streamed, entry)
+		return nil
+	}); err != nil {
+		t.Fatalf("each entry: %v", err)
+	}
+
+	if len(streamed) != total {
+		t.Fatalf("expected %d streamed entries, got %d", total, len(streamed))
+	}
+	if !reflect.DeepEqual(listed, streamed) {
+		t.Fatalf("expected streamed entries to match list order and labels")
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = repo.EachEntry(ctx, domain.EntryListFilter{}, func(domain.Entry) error {
+		visited++
+		if visited == 3 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || visited != 3 {
+		t.Fatalf("expected callback error to stop iteration after 3 entries, got visited=%d err=%v", visited, err)
+	}
+}
+
+func TestListActiveEntryLabelIDsByTransactionIDsQueryOrderingAndFiltering(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
@@ -400,11 +464,11 @@ func TestListActiveEntryLabelIDsForListFilterQueryOrderingAndFiltering(t *testin
 		t.Fatalf("add third entry: %v", err)
 	}
 
-	allRows, err := sqlc.ListActiveEntryLabelIDsForListFilter(ctx, sqlcqueries.ListActiveEntryLabelIDsForListFilterParams{})
+	allRows, err := sqlc.ListActiveEntryLabelIDsByTransactionIDs(ctx, fmt.Sprintf("[%d,%d,%d]", third.ID, first.ID, second.ID))
 	if err != nil {
-		t.Fatalf("list label rows without filter: %v", err)
+		t.Fatalf("list label rows for all entries: %v", err)
 	}
-	expectedAll := []sqlcqueries.ListActiveEntryLabelIDsForListFilterRow{
+	expectedAll := []sqlcqueries.ListActiveEntryLabelIDsByTransactionIDsRow{
 		{TransactionID: first.ID, LabelID: labelA},
 		{TransactionID: first.ID, LabelID: labelC},
 		{TransactionID: second.ID, LabelID: labelB},
@@ -412,26 +476,174 @@ func TestListActiveEntryLabelIDsForListFilterQueryOrderingAndFiltering(t *testin
 		{TransactionID: third.ID, LabelID: labelB},
 	}
 	if !reflect.DeepEqual(allRows, expectedAll) {
-		t.Fatalf("unexpected unfiltered label rows: got %+v want %+v", allRows, expectedAll)
+		t.Fatalf("unexpected label rows for all entries: got %+v want %+v", allRows, expectedAll)
 	}
 
-	filteredRows, err := sqlc.ListActiveEntryLabelIDsForListFilter(ctx, sqlcqueries.ListActiveEntryLabelIDsForListFilterParams{
-		EntryType:   domain.EntryTypeExpense,
-		CategoryID:  categoryA,
-		DateFromUtc: "2026-02-09T00:00:00Z",
-		DateToUtc:   "2026-02-10T23:59:59Z",
-	})
+	filteredRows, err := sqlc.ListActiveEntryLabelIDsByTransactionIDs(ctx, fmt.Sprintf("[%d]", first.ID))
 	if err != nil {
-		t.Fatalf("list label rows with filter: %v", err)
+		t.Fatalf("list label rows for one entry: %v", err)
 	}
 
-	expectedFiltered := []sqlcqueries.ListActiveEntryLabelIDsForListFilterRow{
+	expectedFiltered := []sqlcqueries.ListActiveEntryLabelIDsByTransactionIDsRow{
 		{TransactionID: first.ID, LabelID: labelA},
 		{TransactionID: first.ID, LabelID: labelC},
 	}
 	if !reflect.DeepEqual(filteredRows, expectedFiltered) {
-		t.Fatalf("unexpected filtered label rows: got %+v want %+v", filteredRows, expectedFiltered)
+		t.Fatalf("unexpected label rows for one entry: got %+v want %+v", filteredRows, expectedFiltered)
+	}
+}
+
+func TestEntryRepoAssignsAndKeepsEntryUID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openEntryTestDB(t)
+	defer db.Close()
+
+	repo := NewEntryRepo(db)
+	generated, err := repo.Add(ctx, domain.EntryAddInput{
+		Type:               domain.EntryTypeIncome,
+		AmountMinor:        1000,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-03-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("add entry: %v", err)
+	}
+	if _, err := domain.NormalizeEntryUID(generated.UID); err != nil || generated.UID == "" {
+		t.Fatalf("expected generated uuid uid, got %q", generated.UID)
+	}
+
+	const importedUID = "0b6f4c1e-2f57-4f55-9a4e-3f1e8a4c9d21"
+	imported, err := repo.Add(ctx, domain.EntryAddInput{
+		Type:               domain.EntryTypeIncome,
+		AmountMinor:        2000,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-03-02T00:00:00Z",
+		UID:                importedUID,
+	})
+	if err != nil {
+		t.Fatalf("add entry with uid: %v", err)
+	}
+	if imported.UID != importedUID {
+		t.Fatalf("expected uid %q, got %q", importedUID, imported.UID)
+	}
+
+	found, err := repo.GetByUID(ctx, importedUID)
+	if err != nil {
+		t.Fatalf("get by uid: %v", err)
+	}
+	if found.ID != imported.ID {
+		t.Fatalf("expected entry %d by uid, got %d", imported.ID, found.ID)
+	}
+
+	if _, err := repo.Add(ctx, domain.EntryAddInput{
+		Type:               domain.EntryTypeIncome,
+		AmountMinor:        3000,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-03-03T00:00:00Z",
+		UID:                importedUID,
+	}); err == nil {
+		t.Fatalf("expected duplicate active uid to be rejected")
+	}
+
+	if _, err := repo.Delete(ctx, imported.ID); err != nil {
+		t.Fatalf("delete entry: %v", err)
+	}
+	if _, err := repo.GetByUID(ctx, importedUID); !errors.Is(err, domain.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound for deleted entry uid, got %v", err)
+	}
+}
+
+func TestEntryRepoMonthTotalsFollowEntryWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openEntryTestDB(t)
+	defer db.Close()
+
+	repo := NewEntryRepo(db)
+	assertMonthTotalsMatchEntries := func(step string) {
+		t.Helper()
+
+		entries, err := repo.List(ctx, domain.EntryListFilter{})
+		if err != nil {
+			t.Fatalf("%s: list entries: %v", step, err)
+		}
+		expected := []domain.EntryMonthTotal{}
+		index := map[string]int{}
+		for _, entry := range entries {
+			key := entry.TransactionDateUTC[:7] + "/" + entry.CurrencyCode
+			i, ok := index[key]
+			if !ok {
+				i = len(expected)
+				index[key] = i
+				expected = append(expected, domain.EntryMonthTotal{MonthKey: entry.TransactionDateUTC[:7], CurrencyCode: entry.CurrencyCode})
+			}
+			switch entry.Type {
+			case domain.EntryTypeIncome:
+				expected[i].IncomeMinor += entry.AmountMinor
+			case domain.EntryTypeExpense:
+				expected[i].ExpenseMinor += entry.EffectiveAmountMinor()
+			}
+			expected[i].EntryCount++
+		}
+		sort.Slice(expected, func(a, b int) bool {
+			if expected[a].MonthKey != expected[b].MonthKey {
+				return expected[a].MonthKey < expected[b].MonthKey
+			}
+			return expected[a].CurrencyCode < expected[b].CurrencyCode
+		})
+
+		totals, err := repo.MonthTotals(ctx, domain.EntryMonthTotalsFilter{})
+		if err != nil {
+			t.Fatalf("%s: month totals: %v", step, err)
+		}
+		if !reflect.DeepEqual(totals, expected) {
+			t.Fatalf("%s: month totals drifted from entries:\ngot:  %+v\nwant: %+v", step, totals, expected)
+		}
+	}
+
+	add := func(input domain.EntryAddInput) domain.Entry {
+		t.Helper()
+		entry, err := repo.Add(ctx, input)
+		if err != nil {
+			t.Fatalf("add entry: %v", err)
+		}
+		return entry
+	}
+
+	add(domain.EntryAddInput{Type: domain.EntryTypeIncome, AmountMinor: 5000, CurrencyCode: "USD", TransactionDateUTC: "2026-01-05T00:00:00Z"})
+	groceries := add(domain.EntryAddInput{Type: domain.EntryTypeExpense, AmountMinor: 1200, CurrencyCode: "USD", TransactionDateUTC: "2026-01-10T00:00:00Z"})
+	trip := add(domain.EntryAddInput{Type: domain.EntryTypeExpense, AmountMinor: 800, CurrencyCode: "EUR", TransactionDateUTC: "2026-02-01T00:00:00Z"})
+	add(domain.EntryAddInput{Type: domain.EntryTypeExpense, AmountMinor: 200, CurrencyCode: "USD", TransactionDateUTC: "2026-02-03T00:00:00Z", RefundOfEntryID: &groceries.ID})
+	assertMonthTotalsMatchEntries("after adds")
+
+	newAmount := int64(950)
+	newCurrency := "USD"
+	newDate := "2026-03-02T00:00:00Z"
+	if _, err := repo.Update(ctx, domain.EntryUpdateInput{ID: trip.ID, AmountMinor: &newAmount, CurrencyCode: &newCurrency, TransactionDateUTC: &newDate}); err != nil {
+		t.Fatalf("update entry: %v", err)
+	}
+	assertMonthTotalsMatchEntries("after moving an entry to another month and currency")
+
+	if _, err := repo.Delete(ctx, trip.ID); err != nil {
+		t.Fatalf("delete entry: %v", err)
+	}
+	assertMonthTotalsMatchEntries("after a soft delete")
+
+	february, err := repo.MonthTotals(ctx, domain.EntryMonthTotalsFilter{FromMonth: "2026-02", ToMonth: "2026-02", CurrencyCode: "USD"})
+	if err != nil {
+		t.Fatalf("filtered month totals: %v", err)
+	}
+	if len(february) != 1 || february[0].MonthKey != "2026-02" || february[0].ExpenseMinor != -200 {
+		t.Fatalf("expected the February USD refund only, got %+v", february)
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM transactions WHERE id = ?", groceries.ID); err != nil {
+		t.Fatalf("hard delete entry: %v", err)
 	}
+	assertMonthTotalsMatchEntries("after a hard delete that unlinks the refund")
 }
 
 func TestEntryRepoDeleteNotFound(t *testing.T) {
@@ -915,3 +1127,116 @@ func assertLiabilityChargeTotal(t *testing.T, ctx context.Context, db *sql.DB, e
 		t.Fatalf("expected liability sum %d, got %d", expectedTotal, total)
 	}
 }
+
+func TestEntryListQueryPlansUseIndexes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openEntryTestDB(t)
+	defer db.Close()
+
+	recorder := &queryPlanRecorder{DBTX: db, t: t}
+	q := sqlcqueries.New(recorder)
+	categoryID := int64(1)
+
+	cases := []struct {
+		name      string
+		run       func() error
+		wantIndex string
+	}{
+		{
+			name: "unfiltered page",
+			run: func() error {
+				_, err := q.ListActiveEntriesPage(ctx, sqlcqueries.ListActiveEntriesPageParams{PageSize: 500})
+				return err
+			},
+			wantIndex: "idx_transactions_active_date_type_category",
+		},
+		{
+			name: "filtered page after cursor",
+			run: func() error {
+				_, err := q.ListActiveEntriesPage(ctx, sqlcqueries.ListActiveEntriesPageParams{
+					EntryType:    domain.EntryTypeExpense,
+					CategoryID:   categoryID,
+					DateFromUtc:  "2026-01-01T00:00:00Z",
+					AfterDateUtc: "2026-02-01T00:00:00Z",
+					AfterID:      10,
+					PageSize:     500,
+				})
+				return err
+			},
+			wantIndex: "idx_transactions_active_date_type_category",
+		},
+		{
+			name: "page labels",
+			run: func() error {
+				_, err := q.ListActiveEntryLabelIDsByTransactionIDs(ctx, "[1,2,3]")
+				return err
+			},
+			wantIndex: "idx_transaction_labels_unique_active",
+		},
+		{
+			name: "page payment methods",
+			run: func() error {
+				_, err := q.ListEntryPaymentInfoByTransactionIDs(ctx, "[1,2,3]")
+				return err
+			},
+			wantIndex: "PRIMARY KEY",
+		},
+	}
+
+	for _, tc := range cases {
+		recorder.plans = nil
+		if err := tc.run(); err != nil {
+			t.Fatalf("%s: run query: %v", tc.name, err)
+		}
+		if len(recorder.plans) != 1 {
+			t.Fatalf("%s: expected one recorded plan, got %d", tc.name, len(recorder.plans))
+		}
+		plan := recorder.plans[0]
+		if !strings.Contains(plan, tc.wantIndex) {
+			t.Fatalf("%s: expected plan to use %s, got:\n%s", tc.name, tc.wantIndex, plan)
+		}
+		if strings.Contains(plan, "USE TEMP B-TREE") {
+			t.Fatalf("%s: expected plan without a temp b-tree sort, got:\n%s", tc.name, plan)
+		}
+		// The id lists reach json_each through a one-row params CTE, so its
+		// constant row is the only other scan allowed.
+		for _, line := range strings.Split(plan, "\n") {
+			if strings.HasPrefix(line, "SCAN ") && !strings.Contains(line, "json_each") &&
+				line != "SCAN CONSTANT ROW" && line != "SCAN params" {
+				t.Fatalf("%s: expected no table scans, got:\n%s", tc.name, plan)
+			}
+		}
+	}
+}
+
+// queryPlanRecorder runs EXPLAIN QUERY PLAN for every query sqlc issues
+// through it, so plan assertions follow the generated SQL.
+type queryPlanRecorder struct {
+	sqlcqueries.DBTX
+	t     *testing.T
+	plans []string
+}
+
+func (r *queryPlanRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := r.DBTX.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		r.t.Fatalf("explain query plan: %v", err)
+	}
+	var details []string
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			r.t.Fatalf("scan query plan: %v", err)
+		}
+		details = append(details, detail)
+	}
+	if err := rows.Close(); err != nil {
+		r.t.Fatalf("close query plan: %v", err)
+	}
+	r.plans = append(r.plans, strings.Join(details, "\n"))
+
+	return r.DBTX.QueryContext(ctx, query, args...)
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type EnvelopeRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewEnvelopeRepo(db *sql.DB) *EnvelopeRepo {
+	return &EnvelopeRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *EnvelopeRepo) Add(ctx context.Context, input domain.EnvelopeAddInput) (domain.Envelope, error) {
+	isActive, err := r.queries.ExistsActiveLabelByID(ctx, input.LabelID)
+	if err != nil {
+		return domain.Envelope{}, fmt.Errorf("add envelope check label %d: %w", input.LabelID, err)
+	}
+	if !isTruthy(isActive) {
+		return domain.Envelope{}, domain.ErrLabelNotFound
+	}
+
+	result, err := r.queries.CreateEnvelope(ctx, queries.CreateEnvelopeParams{
+		Name:         input.Name,
+		CurrencyCode: input.CurrencyCode,
+		BudgetMinor:  input.BudgetMinor,
+		StartDate:    input.StartDate,
+		EndDate:      input.EndDate,
+		LabelID:      input.LabelID,
+		Note:         nullableString(input.Note),
+		UpdatedAtUtc: nowRFC3339Nano(),
+	})
+	if err != nil {
+		return domain.Envelope{}, fmt.Errorf("add envelope: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.Envelope{}, fmt.Errorf("add envelope read id: %w", err)
+	}
+
+	return r.GetByID(ctx, id, false)
+}
+
+func (r *EnvelopeRepo) GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Envelope, error) {
+	if err := domain.ValidateEnvelopeID(id); err != nil {
+		return domain.Envelope{}, err
+	}
+
+	var (
+		row queries.Envelope
+		err error
+	)
+	if includeDeleted {
+		row, err = r.queries.GetEnvelopeByID(ctx, id)
+	} else {
+		row, err = r.queries.GetActiveEnvelopeByID(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Envelope{}, domain.ErrEnvelopeNotFound
+		}
+		return domain.Envelope{}, fmt.Errorf("get envelope by id: %w", err)
+	}
+
+	return mapSQLCEnvelope(row), nil
+}
+
+func (r *EnvelopeRepo) List(ctx context.Context, includeDeleted bool) ([]domain.Envelope, error) {
+	rows, err := r.queries.ListEnvelopes(ctx, boolAsInt64(includeDeleted))
+	if err != nil {
+		return nil, fmt.Errorf("list envelopes: %w", err)
+	}
+
+	envelopes := make([]domain.Envelope, 0, len(rows))
+	for _, row := range rows {
+		envelopes = append(envelopes, mapSQLCEnvelope(row))
+	}
+
+	return envelopes, nil
+}
+
+func (r *EnvelopeRepo) Delete(ctx context.Context, id int64) (domain.EnvelopeDeleteResult, error) {
+	if err := domain.ValidateEnvelopeID(id); err != nil {
+		return domain.EnvelopeDeleteResult{}, err
+	}
+
+	deletedAtUTC := nowRFC3339Nano()
+	result, err := r.queries.SoftDeleteEnvelope(ctx, queries.SoftDeleteEnvelopeParams{
+		DeletedAtUtc: sql.NullString{String: deletedAtUTC, Valid: true},
+		UpdatedAtUtc: deletedAtUTC,
+		ID:           id,
+	})
+	if err != nil {
+		return domain.EnvelopeDeleteResult{}, fmt.Errorf("delete envelope: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.EnvelopeDeleteResult{}, fmt.Errorf("delete envelope rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.EnvelopeDeleteResult{}, domain.ErrEnvelopeNotFound
+	}
+
+	return domain.EnvelopeDeleteResult{
+		EnvelopeID:   id,
+		DeletedAtUTC: deletedAtUTC,
+	}, nil
+}
+
+func mapSQLCEnvelope(row queries.Envelope) domain.Envelope {
+	return domain.Envelope{
+		ID:           row.ID,
+		Name:         row.Name,
+		CurrencyCode: row.CurrencyCode,
+		BudgetMinor:  row.BudgetMinor,
+		StartDate:    row.StartDate,
+		EndDate:      row.EndDate,
+		LabelID:      row.LabelID,
+		Note:         row.Note.String,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+		DeletedAtUTC: ptrStringFromNull(row.DeletedAtUtc),
+	}
+}
@@ -44,6 +44,51 @@ func (r *FXRepo) GetSnapshotByKey(ctx context.Context, provider, baseCurrency, q
 	return mapSQLCFXRateSnapshotToDomain(row), nil
 }
 
+// GetLatestSnapshot returns the most recently fetched snapshot of a pair.
+func (r *FXRepo) GetLatestSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency string, isEstimate bool) (domain.FXRateSnapshot, error) {
+	if r.db == nil {
+		return domain.FXRateSnapshot{}, fmt.Errorf("get latest fx snapshot: db is nil")
+	}
+
+	row, err := r.queries.GetLatestFXRateSnapshot(ctx, queries.GetLatestFXRateSnapshotParams{
+		Provider:      strings.TrimSpace(provider),
+		BaseCurrency:  strings.TrimSpace(baseCurrency),
+		QuoteCurrency: strings.TrimSpace(quoteCurrency),
+		IsEstimate:    boolToInt64(isEstimate),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+		}
+		return domain.FXRateSnapshot{}, fmt.Errorf("get latest fx snapshot: %w", err)
+	}
+
+	return mapSQLCFXRateSnapshotToDomain(row), nil
+}
+
+// GetFallbackSnapshot returns the newest snapshot of a pair dated on or before
+// rateDate, estimate or not.
+func (r *FXRepo) GetFallbackSnapshot(ctx context.Context, provider, baseCurrency, quoteCurrency, rateDate string) (domain.FXRateSnapshot, error) {
+	if r.db == nil {
+		return domain.FXRateSnapshot{}, fmt.Errorf("get fallback fx snapshot: db is nil")
+	}
+
+	row, err := r.queries.GetFallbackFXRateSnapshot(ctx, queries.GetFallbackFXRateSnapshotParams{
+		Provider:      strings.TrimSpace(provider),
+		BaseCurrency:  strings.TrimSpace(baseCurrency),
+		QuoteCurrency: strings.TrimSpace(quoteCurrency),
+		RateDate:      strings.TrimSpace(rateDate),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+		}
+		return domain.FXRateSnapshot{}, fmt.Errorf("get fallback fx snapshot: %w", err)
+	}
+
+	return mapSQLCFXRateSnapshotToDomain(row), nil
+}
+
 func (r *FXRepo) CreateSnapshot(ctx context.Context, input domain.FXRateSnapshotCreateInput) (domain.FXRateSnapshot, error) {
 	if r.db == nil {
 		return domain.FXRateSnapshot{}, fmt.Errorf("create fx snapshot: db is nil")
@@ -83,6 +128,57 @@ func (r *FXRepo) CreateSnapshot(ctx context.Context, input domain.FXRateSnapshot
 	}, nil
 }
 
+// RefreshSnapshot replaces the rate of a snapshot and restarts its TTL.
+func (r *FXRepo) RefreshSnapshot(ctx context.Context, id int64, rate, fetchedAtUTC string) (domain.FXRateSnapshot, error) {
+	if r.db == nil {
+		return domain.FXRateSnapshot{}, fmt.Errorf("refresh fx snapshot: db is nil")
+	}
+
+	if err := domain.ValidateFXRate(rate); err != nil {
+		return domain.FXRateSnapshot{}, err
+	}
+
+	row, err := r.queries.RefreshFXRateSnapshot(ctx, queries.RefreshFXRateSnapshotParams{
+		Rate:         strings.TrimSpace(rate),
+		FetchedAtUtc: strings.TrimSpace(fetchedAtUTC),
+		ID:           id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.FXRateSnapshot{}, domain.ErrFXRateUnavailable
+		}
+		return domain.FXRateSnapshot{}, fmt.Errorf("refresh fx snapshot: %w", err)
+	}
+
+	return mapSQLCFXRateSnapshotToDomain(row), nil
+}
+
+// ListCachePairs summarizes the cached snapshots of each provider and pair.
+func (r *FXRepo) ListCachePairs(ctx context.Context) ([]domain.FXCachePair, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list fx cache pairs: db is nil")
+	}
+
+	rows, err := r.queries.ListFXRateCachePairs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list fx cache pairs: %w", err)
+	}
+
+	pairs := make([]domain.FXCachePair, 0, len(rows))
+	for _, row := range rows {
+		pairs = append(pairs, domain.FXCachePair{
+			Provider:         row.Provider,
+			BaseCurrency:     row.BaseCurrency,
+			QuoteCurrency:    row.QuoteCurrency,
+			SnapshotCount:    row.SnapshotCount,
+			OldestRateDate:   stringFromInterface(row.OldestRateDate),
+			NewestRateDate:   stringFromInterface(row.NewestRateDate),
+			LastFetchedAtUTC: stringFromInterface(row.LastFetchedAtUtc),
+		})
+	}
+	return pairs, nil
+}
+
 func mapSQLCFXRateSnapshotToDomain(row queries.FxRateSnapshot) domain.FXRateSnapshot {
 	return domain.FXRateSnapshot{
 		ID:            row.ID,
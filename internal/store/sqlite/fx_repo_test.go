@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -48,6 +49,49 @@ func TestFXRepoCreateAndGetSnapshot(t *testing.T) {
 	}
 }
 
+func TestFXRepoRefreshSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openFXTestDB(t)
+	defer db.Close()
+
+	repo := NewFXRepo(db)
+
+	created, err := repo.CreateSnapshot(ctx, domain.FXRateSnapshotCreateInput{
+		Provider:      "frankfurter",
+		BaseCurrency:  "USD",
+		QuoteCurrency: "EUR",
+		Rate:          "0.92",
+		RateDate:      "2026-02-01",
+		IsEstimate:    true,
+		FetchedAtUTC:  "2026-02-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("create snapshot: %v", err)
+	}
+
+	refreshed, err := repo.RefreshSnapshot(ctx, created.ID, "0.95", "2026-02-02T00:00:00Z")
+	if err != nil {
+		t.Fatalf("refresh snapshot: %v", err)
+	}
+	if refreshed.ID != created.ID || refreshed.Rate != "0.95" || refreshed.FetchedAtUTC != "2026-02-02T00:00:00Z" || !refreshed.IsEstimate {
+		t.Fatalf("unexpected refreshed snapshot: %+v", refreshed)
+	}
+
+	latest, err := repo.GetLatestSnapshot(ctx, "frankfurter", "USD", "EUR", true)
+	if err != nil {
+		t.Fatalf("get latest snapshot: %v", err)
+	}
+	if latest != refreshed {
+		t.Fatalf("expected the refreshed snapshot stored, got %+v", latest)
+	}
+
+	if _, err := repo.RefreshSnapshot(ctx, created.ID+1, "0.95", "2026-02-02T00:00:00Z"); !errors.Is(err, domain.ErrFXRateUnavailable) {
+		t.Fatalf("expected ErrFXRateUnavailable for a missing snapshot, got %v", err)
+	}
+}
+
 func TestFXRepoGetSnapshotByKeyNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type HookRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewHookRepo(db *sql.DB) *HookRepo {
+	return &HookRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *HookRepo) Add(ctx context.Context, input domain.HookAddInput) (domain.Hook, error) {
+	if r.db == nil {
+		return domain.Hook{}, fmt.Errorf("add hook: db is nil")
+	}
+
+	withinDays := sql.NullInt64{}
+	if input.WithinDays != nil {
+		withinDays = sql.NullInt64{Int64: *input.WithinDays, Valid: true}
+	}
+
+	result, err := r.queries.CreateHook(ctx, queries.CreateHookParams{
+		Event:      input.Event,
+		Kind:       input.Kind,
+		Target:     input.Target,
+		WithinDays: withinDays,
+	})
+	if err != nil {
+		return domain.Hook{}, fmt.Errorf("add hook insert: %w", err)
+	}
+
+	hookID, err := result.LastInsertId()
+	if err != nil {
+		return domain.Hook{}, fmt.Errorf("add hook read id: %w", err)
+	}
+
+	row, err := r.queries.GetHookByID(ctx, hookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Hook{}, domain.ErrHookNotFound
+		}
+		return domain.Hook{}, fmt.Errorf("add hook reload: %w", err)
+	}
+	return mapSQLCHookToDomain(row), nil
+}
+
+// List returns every hook, or only those for event when it is not empty.
+func (r *HookRepo) List(ctx context.Context, event string) ([]domain.Hook, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list hooks: db is nil")
+	}
+
+	var (
+		rows []queries.Hook
+		err  error
+	)
+	if event == "" {
+		rows, err = r.queries.ListHooks(ctx)
+	} else {
+		rows, err = r.queries.ListHooksByEvent(ctx, event)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list hooks: %w", err)
+	}
+
+	hooks := make([]domain.Hook, 0, len(rows))
+	for _, row := range rows {
+		hooks = append(hooks, mapSQLCHookToDomain(row))
+	}
+	return hooks, nil
+}
+
+func (r *HookRepo) Delete(ctx context.Context, id int64) error {
+	if r.db == nil {
+		return fmt.Errorf("delete hook: db is nil")
+	}
+
+	result, err := r.queries.DeleteHook(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete hook: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete hook rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrHookNotFound
+	}
+	return nil
+}
+
+func mapSQLCHookToDomain(row queries.Hook) domain.Hook {
+	hook := domain.Hook{
+		ID:           row.ID,
+		Event:        row.Event,
+		Kind:         row.Kind,
+		Target:       row.Target,
+		CreatedAtUTC: row.CreatedAtUtc,
+	}
+	if row.WithinDays.Valid {
+		days := row.WithinDays.Int64
+		hook.WithinDays = &days
+	}
+	return hook
+}
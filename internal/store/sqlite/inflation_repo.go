@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type InflationRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewInflationRepo(db *sql.DB) *InflationRepo {
+	return &InflationRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// UpsertPoints writes all points in one transaction; an existing point for the
+// same currency and date is replaced.
+func (r *InflationRepo) UpsertPoints(ctx context.Context, inputs []domain.InflationIndexPointInput) (int, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("upsert inflation index points: db is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("upsert inflation index points begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, input := range inputs {
+		normalized, err := domain.NormalizeInflationIndexPointInput(input)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := qtx.UpsertInflationIndexPoint(ctx, queries.UpsertInflationIndexPointParams{
+			CurrencyCode: normalized.CurrencyCode,
+			IndexDate:    normalized.IndexDate,
+			IndexValue:   normalized.IndexValue,
+			UpdatedAtUtc: nowUTC,
+		}); err != nil {
+			return 0, fmt.Errorf("upsert inflation index point: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("upsert inflation index points commit: %w", err)
+	}
+
+	return len(inputs), nil
+}
+
+func (r *InflationRepo) List(ctx context.Context, currencyCode string) ([]domain.InflationIndexPoint, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list inflation index points: db is nil")
+	}
+
+	rows, err := r.queries.ListInflationIndexPoints(ctx, nullableString(currencyCode))
+	if err != nil {
+		return nil, fmt.Errorf("list inflation index points: %w", err)
+	}
+
+	points := make([]domain.InflationIndexPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, mapSQLCInflationIndexPointToDomain(row))
+	}
+	return points, nil
+}
+
+// GetOnOrBefore returns the most recent point for the currency whose date is
+// not after indexDate.
+func (r *InflationRepo) GetOnOrBefore(ctx context.Context, currencyCode, indexDate string) (domain.InflationIndexPoint, error) {
+	if r.db == nil {
+		return domain.InflationIndexPoint{}, fmt.Errorf("get inflation index point: db is nil")
+	}
+
+	row, err := r.queries.GetInflationIndexPointOnOrBefore(ctx, queries.GetInflationIndexPointOnOrBeforeParams{
+		CurrencyCode: currencyCode,
+		IndexDate:    indexDate,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.InflationIndexPoint{}, domain.ErrInflationIndexUnavailable
+		}
+		return domain.InflationIndexPoint{}, fmt.Errorf("get inflation index point: %w", err)
+	}
+
+	return mapSQLCInflationIndexPointToDomain(row), nil
+}
+
+func mapSQLCInflationIndexPointToDomain(row queries.InflationIndexPoint) domain.InflationIndexPoint {
+	return domain.InflationIndexPoint{
+		ID:           row.ID,
+		CurrencyCode: row.CurrencyCode,
+		IndexDate:    row.IndexDate,
+		IndexValue:   row.IndexValue,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"boring-budget/internal/domain"
+	"boring-budget/internal/ports"
 	queries "boring-budget/internal/store/sqlite/sqlc"
 )
 
@@ -16,6 +17,8 @@ type LabelRepo struct {
 	queries *queries.Queries
 }
 
+var _ ports.LabelCreatorTxBinder = (*LabelRepo)(nil)
+
 func NewLabelRepo(db *sql.DB) (*LabelRepo, error) {
 	if db == nil {
 		return nil, fmt.Errorf("label repo: db is required")
@@ -26,6 +29,17 @@ func NewLabelRepo(db *sql.DB) (*LabelRepo, error) {
 	}, nil
 }
 
+func (r *LabelRepo) BindTx(tx *sql.Tx) ports.LabelCreator {
+	if tx == nil {
+		return r
+	}
+
+	return &LabelRepo{
+		db:      r.db,
+		queries: r.queries.WithTx(tx),
+	}
+}
+
 func (r *LabelRepo) Add(ctx context.Context, name string) (domain.Label, error) {
 	normalized, err := domain.NormalizeLabelName(name)
 	if err != nil {
@@ -54,7 +68,13 @@ func (r *LabelRepo) Add(ctx context.Context, name string) (domain.Label, error)
 }
 
 func (r *LabelRepo) List(ctx context.Context) ([]domain.Label, error) {
-	rows, err := r.queries.ListActiveLabels(ctx)
+	return r.ListSorted(ctx, domain.LabelSortName)
+}
+
+// ListSorted lists active labels ordered by sortKey, one of
+// domain.LabelSortKeys.
+func (r *LabelRepo) ListSorted(ctx context.Context, sortKey string) ([]domain.Label, error) {
+	rows, err := r.queries.ListActiveLabels(ctx, sortKey)
 	if err != nil {
 		return nil, wrapStorageErr("list labels", err)
 	}
@@ -109,6 +129,76 @@ func (r *LabelRepo) Rename(ctx context.Context, id int64, newName string) (domai
 	return label, nil
 }
 
+// SetArchived archives or unarchives an active label. Archiving an already
+// archived label keeps its original archived_at_utc.
+func (r *LabelRepo) SetArchived(ctx context.Context, id int64, archived bool) (domain.Label, error) {
+	if err := domain.ValidateLabelID(id); err != nil {
+		return domain.Label{}, err
+	}
+
+	current, err := r.getActiveByID(ctx, id)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if current.Archived() == archived {
+		return current, nil
+	}
+
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	archivedAt := sql.NullString{}
+	if archived {
+		archivedAt = sql.NullString{String: nowUTC, Valid: true}
+	}
+	result, err := r.queries.SetActiveLabelArchivedAt(ctx, queries.SetActiveLabelArchivedAtParams{
+		ArchivedAtUtc: archivedAt,
+		UpdatedAtUtc:  nowUTC,
+		ID:            id,
+	})
+	if err != nil {
+		return domain.Label{}, wrapStorageErr("archive label", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Label{}, wrapStorageErr("read archive label rows", err)
+	}
+	if rowsAffected == 0 {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+
+	return r.getActiveByID(ctx, id)
+}
+
+// UpdateAppearance sets the fields of update that are not nil; empty values
+// clear the stored color or icon.
+func (r *LabelRepo) UpdateAppearance(ctx context.Context, id int64, update domain.AppearanceUpdate) (domain.Label, error) {
+	if err := domain.ValidateLabelID(id); err != nil {
+		return domain.Label{}, err
+	}
+
+	result, err := r.queries.UpdateActiveLabelAppearance(ctx, queries.UpdateActiveLabelAppearanceParams{
+		SetColor:     boolAsInt64(update.Color != nil),
+		Color:        nullableStringPtr(update.Color),
+		SetIcon:      boolAsInt64(update.Icon != nil),
+		Icon:         nullableStringPtr(update.Icon),
+		UpdatedAtUtc: nowRFC3339Nano(),
+		ID:           id,
+	})
+	if err != nil {
+		return domain.Label{}, wrapStorageErr("update label appearance", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Label{}, wrapStorageErr("read update label appearance rows", err)
+	}
+	if rowsAffected == 0 {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+
+	return r.getActiveByID(ctx, id)
+}
+
 func (r *LabelRepo) Delete(ctx context.Context, id int64) (domain.LabelDeleteResult, error) {
 	if err := domain.ValidateLabelID(id); err != nil {
 		return domain.LabelDeleteResult{}, err
@@ -199,6 +289,8 @@ func mapSQLCLabelToDomain(row queries.Label) (domain.Label, error) {
 		Name:         row.Name,
 		CreatedAtUTC: createdAt,
 		UpdatedAtUTC: updatedAt,
+		Color:        row.Color.String,
+		Icon:         row.Icon.String,
 	}
 
 	if row.DeletedAtUtc.Valid {
@@ -209,6 +301,14 @@ func mapSQLCLabelToDomain(row queries.Label) (domain.Label, error) {
 		label.DeletedAtUTC = &deletedAt
 	}
 
+	if row.ArchivedAtUtc.Valid {
+		archivedAt, err := parseSQLiteTimestamp(row.ArchivedAtUtc.String)
+		if err != nil {
+			return domain.Label{}, wrapStorageErr("parse label archived_at_utc", err)
+		}
+		label.ArchivedAtUTC = &archivedAt
+	}
+
 	return label, nil
 }
 
@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type LoanRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewLoanRepo(db *sql.DB) *LoanRepo {
+	return &LoanRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *LoanRepo) Add(ctx context.Context, input domain.LoanAddInput) (domain.Loan, error) {
+	result, err := r.queries.CreateLoan(ctx, queries.CreateLoanParams{
+		Name:           nullableString(input.Name),
+		CurrencyCode:   input.CurrencyCode,
+		PrincipalMinor: input.PrincipalMinor,
+		AnnualRateBp:   input.AnnualRateBasisPoints,
+		TermMonths:     input.TermMonths,
+		StartMonth:     input.StartMonth,
+		Note:           nullableString(input.Note),
+		UpdatedAtUtc:   nowRFC3339Nano(),
+	})
+	if err != nil {
+		return domain.Loan{}, fmt.Errorf("add loan: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.Loan{}, fmt.Errorf("add loan read id: %w", err)
+	}
+
+	return r.GetByID(ctx, id, false)
+}
+
+func (r *LoanRepo) GetByID(ctx context.Context, id int64, includeDeleted bool) (domain.Loan, error) {
+	if err := domain.ValidateLoanID(id); err != nil {
+		return domain.Loan{}, err
+	}
+
+	var (
+		row queries.Loan
+		err error
+	)
+	if includeDeleted {
+		row, err = r.queries.GetLoanByID(ctx, id)
+	} else {
+		row, err = r.queries.GetActiveLoanByID(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Loan{}, domain.ErrLoanNotFound
+		}
+		return domain.Loan{}, fmt.Errorf("get loan by id: %w", err)
+	}
+
+	return mapSQLCLoan(row), nil
+}
+
+func (r *LoanRepo) List(ctx context.Context, includeDeleted bool) ([]domain.Loan, error) {
+	rows, err := r.queries.ListLoans(ctx, boolAsInt64(includeDeleted))
+	if err != nil {
+		return nil, fmt.Errorf("list loans: %w", err)
+	}
+
+	loans := make([]domain.Loan, 0, len(rows))
+	for _, row := range rows {
+		loans = append(loans, mapSQLCLoan(row))
+	}
+
+	return loans, nil
+}
+
+func (r *LoanRepo) Delete(ctx context.Context, id int64) (domain.LoanDeleteResult, error) {
+	if err := domain.ValidateLoanID(id); err != nil {
+		return domain.LoanDeleteResult{}, err
+	}
+
+	deletedAtUTC := nowRFC3339Nano()
+	result, err := r.queries.SoftDeleteLoan(ctx, queries.SoftDeleteLoanParams{
+		DeletedAtUtc: sql.NullString{String: deletedAtUTC, Valid: true},
+		UpdatedAtUtc: deletedAtUTC,
+		ID:           id,
+	})
+	if err != nil {
+		return domain.LoanDeleteResult{}, fmt.Errorf("delete loan: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return domain.LoanDeleteResult{}, fmt.Errorf("delete loan rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.LoanDeleteResult{}, domain.ErrLoanNotFound
+	}
+
+	return domain.LoanDeleteResult{
+		LoanID:       id,
+		DeletedAtUTC: deletedAtUTC,
+	}, nil
+}
+
+// LinkPayment records entryID as a payment of loanID. An entry pays at most
+// one loan.
+func (r *LoanRepo) LinkPayment(ctx context.Context, loanID, entryID int64) error {
+	err := r.queries.CreateLoanPayment(ctx, queries.CreateLoanPaymentParams{
+		TransactionID: entryID,
+		LoanID:        loanID,
+	})
+	if err != nil {
+		if isUniqueConstraintErr(err) {
+			return domain.ErrLoanPaymentLinked
+		}
+		return fmt.Errorf("link loan payment: %w", err)
+	}
+	return nil
+}
+
+func (r *LoanRepo) UnlinkPayment(ctx context.Context, loanID, entryID int64) error {
+	result, err := r.queries.DeleteLoanPayment(ctx, queries.DeleteLoanPaymentParams{
+		LoanID:        loanID,
+		TransactionID: entryID,
+	})
+	if err != nil {
+		return fmt.Errorf("unlink loan payment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unlink loan payment rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrLoanPaymentNotLinked
+	}
+	return nil
+}
+
+// ListPaymentEntries returns the active entries linked to loanID.
+func (r *LoanRepo) ListPaymentEntries(ctx context.Context, loanID int64) ([]domain.LoanPaymentEntry, error) {
+	rows, err := r.queries.ListLoanPaymentEntries(ctx, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("list loan payments: %w", err)
+	}
+
+	entries := make([]domain.LoanPaymentEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.LoanPaymentEntry{
+			EntryID:            row.ID,
+			TransactionDateUTC: row.TransactionDateUtc,
+			AmountMinor:        row.AmountMinor,
+			CurrencyCode:       row.CurrencyCode,
+			Note:               row.Note.String,
+		})
+	}
+	return entries, nil
+}
+
+func mapSQLCLoan(row queries.Loan) domain.Loan {
+	return domain.Loan{
+		ID:                    row.ID,
+		Name:                  row.Name.String,
+		CurrencyCode:          row.CurrencyCode,
+		PrincipalMinor:        row.PrincipalMinor,
+		AnnualRateBasisPoints: row.AnnualRateBp,
+		TermMonths:            row.TermMonths,
+		StartMonth:            row.StartMonth,
+		Note:                  row.Note.String,
+		CreatedAtUTC:          row.CreatedAtUtc,
+		UpdatedAtUTC:          row.UpdatedAtUtc,
+		DeletedAtUTC:          ptrStringFromNull(row.DeletedAtUtc),
+	}
+}
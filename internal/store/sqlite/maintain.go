@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"boring-budget/internal/domain"
+)
+
+// Maintain compacts db for `data maintain`: VACUUM rewrites the file without
+// free pages, ANALYZE refreshes the planner statistics, and a truncating WAL
+// checkpoint folds the write-ahead log (which VACUUM just filled) back into
+// the database. Sizes are measured before and after, and every table's rows
+// are counted afterwards.
+func Maintain(ctx context.Context, db *sql.DB) (domain.MaintenanceReport, error) {
+	if db == nil {
+		return domain.MaintenanceReport{}, fmt.Errorf("maintain: db is nil")
+	}
+
+	before, err := databaseSize(ctx, db)
+	if err != nil {
+		return domain.MaintenanceReport{}, err
+	}
+
+	for _, stmt := range []string{"VACUUM;", "ANALYZE;"} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return domain.MaintenanceReport{}, fmt.Errorf("maintain %s: %w", stmt, err)
+		}
+	}
+
+	checkpoint := domain.WALCheckpoint{}
+	var busy int64
+	if err := db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE);").Scan(&busy, &checkpoint.LogFrames, &checkpoint.CheckpointedFrames); err != nil {
+		return domain.MaintenanceReport{}, fmt.Errorf("maintain wal checkpoint: %w", err)
+	}
+	checkpoint.Busy = busy != 0
+
+	after, err := databaseSize(ctx, db)
+	if err != nil {
+		return domain.MaintenanceReport{}, err
+	}
+
+	tables, err := maintenanceTableCounts(ctx, db)
+	if err != nil {
+		return domain.MaintenanceReport{}, err
+	}
+
+	return domain.MaintenanceReport{
+		SizeBefore:     before,
+		SizeAfter:      after,
+		ReclaimedBytes: before.Bytes - after.Bytes,
+		Checkpoint:     checkpoint,
+		Tables:         tables,
+	}, nil
+}
+
+func databaseSize(ctx context.Context, db *sql.DB) (domain.DatabaseSize, error) {
+	size := domain.DatabaseSize{}
+	pragmas := []struct {
+		stmt   string
+		target *int64
+	}{
+		{stmt: "PRAGMA page_size;", target: &size.PageSize},
+		{stmt: "PRAGMA page_count;", target: &size.PageCount},
+		{stmt: "PRAGMA freelist_count;", target: &size.FreePages},
+	}
+	for _, pragma := range pragmas {
+		if err := db.QueryRowContext(ctx, pragma.stmt).Scan(pragma.target); err != nil {
+			return domain.DatabaseSize{}, fmt.Errorf("maintain %s: %w", pragma.stmt, err)
+		}
+	}
+	size.Bytes = size.PageSize * size.PageCount
+	return size, nil
+}
+
+func maintenanceTableCounts(ctx context.Context, db *sql.DB) ([]domain.MaintenanceTableCount, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;")
+	if err != nil {
+		return nil, fmt.Errorf("maintain list tables: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("maintain list tables: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("maintain list tables: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("maintain list tables: %w", err)
+	}
+
+	counts := make([]domain.MaintenanceTableCount, 0, len(names))
+	for _, name := range names {
+		count := domain.MaintenanceTableCount{Table: name}
+		if count.Rows, err = countTableRows(ctx, db, name); err != nil {
+			return nil, err
+		}
+
+		var softDeletable int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'deleted_at_utc';", name).Scan(&softDeletable); err != nil {
+			return nil, fmt.Errorf("maintain inspect %q: %w", name, err)
+		}
+		if softDeletable > 0 {
+			var deleted int64
+			if err := db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %q WHERE deleted_at_utc IS NOT NULL;", name)).Scan(&deleted); err != nil {
+				return nil, fmt.Errorf("maintain count deleted %q: %w", name, err)
+			}
+			count.SoftDeleted = &deleted
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
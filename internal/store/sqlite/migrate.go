@@ -1,29 +1,42 @@
 package sqlite
 
 import (
-	"boring-budget/migrations"
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"sync"
 
+	"boring-budget/migrations"
 	"github.com/pressly/goose/v3"
 )
 
 var gooseMu sync.Mutex
 
+// EmbeddedMigrations holds the Goose SQL migrations compiled into the binary.
+// They are the default source, so an installed binary needs no migrations
+// directory next to it.
+var EmbeddedMigrations fs.FS = migrations.FS
+
+// RunMigrations applies every pending migration from the embedded set, or from
+// migrationsDir when it is not empty.
 func RunMigrations(ctx context.Context, db *sql.DB, migrationsDir string) error {
 	if db == nil {
 		return fmt.Errorf("run migrations: db is nil")
 	}
 
-	dir, baseFS, err := resolveMigrationSource(migrationsDir)
-	if err != nil {
-		return err
-	}
+	return withGoose(migrationsDir, func(dir string) error {
+		if err := goose.UpContext(ctx, db, dir); err != nil {
+			return fmt.Errorf("goose up: %w", err)
+		}
+		return nil
+	})
+}
+
+// withGoose points goose's package-level state at the migration source for
+// the duration of run; the mutex keeps concurrent callers from racing on it.
+func withGoose(migrationsDir string, run func(dir string) error) error {
+	dir, baseFS := resolveMigrationSource(migrationsDir)
 
 	gooseMu.Lock()
 	defer gooseMu.Unlock()
@@ -36,26 +49,15 @@ func RunMigrations(ctx context.Context, db *sql.DB, migrationsDir string) error
 		return fmt.Errorf("set goose sqlite dialect: %w", err)
 	}
 
-	if err := goose.UpContext(ctx, db, dir); err != nil {
-		return fmt.Errorf("goose up: %w", err)
-	}
-	return nil
+	return run(dir)
 }
 
-func resolveMigrationSource(migrationsDir string) (string, fs.FS, error) {
-	if migrationsDir == "" {
-		migrationsDir = DefaultMigrationsDir
-	}
-
-	if migrationsDir != DefaultMigrationsDir {
-		return migrationsDir, nil, nil
+// resolveMigrationSource picks where goose reads migrations from: the
+// directory override when one is given, otherwise the SQL files embedded in
+// the binary.
+func resolveMigrationSource(migrationsDir string) (string, fs.FS) {
+	if migrationsDir != "" {
+		return migrationsDir, nil
 	}
-
-	if _, err := os.Stat(migrationsDir); err == nil {
-		return migrationsDir, nil, nil
-	} else if !errors.Is(err, os.ErrNotExist) {
-		return "", nil, fmt.Errorf("stat migrations dir %q: %w", migrationsDir, err)
-	}
-
-	return ".", migrations.FS, nil
+	return ".", EmbeddedMigrations
 }
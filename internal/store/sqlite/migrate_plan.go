@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+var (
+	migrationFilePattern    = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+	migrationTriggerPattern = regexp.MustCompile(`(?is)CREATE\s+TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?\w+.*?\bON\s+(\w+)\b.*?\bEND\s*;`)
+	migrationCommentPattern = regexp.MustCompile(`--[^\n]*`)
+)
+
+// migrationStatementPatterns map SQL statements to plan operations. Trigger
+// bodies are stripped first so their INSERT/UPDATE statements do not count
+// as work done while migrating.
+var migrationStatementPatterns = []struct {
+	op      string
+	pattern *regexp.Regexp
+}{
+	{domain.MigrationOpCreateTable, regexp.MustCompile(`(?i)\bCREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)},
+	{domain.MigrationOpAlterTable, regexp.MustCompile(`(?i)\bALTER\s+TABLE\s+(\w+)`)},
+	{domain.MigrationOpDropTable, regexp.MustCompile(`(?i)\bDROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(\w+)`)},
+	{domain.MigrationOpCreateIndex, regexp.MustCompile(`(?i)\bCREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?\w+\s+ON\s+(\w+)`)},
+	{domain.MigrationOpInsert, regexp.MustCompile(`(?i)\bINSERT\s+(?:OR\s+\w+\s+)?INTO\s+(\w+)`)},
+	{domain.MigrationOpUpdate, regexp.MustCompile(`(?i)\bUPDATE\s+(\w+)\s+SET\b`)},
+	{domain.MigrationOpDelete, regexp.MustCompile(`(?i)\bDELETE\s+FROM\s+(\w+)`)},
+}
+
+// PlanMigrations reports which migrations (embedded, or in migrationsDir when
+// it is not empty) are not yet applied to db and estimates their cost from
+// current table sizes. It does not write to db, not even the goose version
+// table.
+func PlanMigrations(ctx context.Context, db *sql.DB, migrationsDir string) (domain.MigrationPlan, error) {
+	if db == nil {
+		return domain.MigrationPlan{}, fmt.Errorf("plan migrations: db is nil")
+	}
+
+	dir, baseFS := resolveMigrationSource(migrationsDir)
+	if baseFS == nil {
+		baseFS = os.DirFS(dir)
+		dir = "."
+	}
+
+	applied, err := appliedMigrationVersions(ctx, db)
+	if err != nil {
+		return domain.MigrationPlan{}, err
+	}
+
+	files, err := fs.ReadDir(baseFS, dir)
+	if err != nil {
+		return domain.MigrationPlan{}, fmt.Errorf("read migrations dir %q: %w", migrationsDir, err)
+	}
+
+	plan := domain.MigrationPlan{
+		Pending:        []domain.PendingMigration{},
+		AffectedTables: []string{},
+		BackupReasons:  []string{},
+	}
+	for version := range applied {
+		plan.CurrentVersion = max(plan.CurrentVersion, version)
+	}
+	plan.TargetVersion = plan.CurrentVersion
+
+	rowCounts := map[string]int64{}
+	affected := map[string]struct{}{}
+	for _, file := range files {
+		match := migrationFilePattern.FindStringSubmatch(file.Name())
+		if file.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return domain.MigrationPlan{}, fmt.Errorf("parse migration version %q: %w", file.Name(), err)
+		}
+		plan.TargetVersion = max(plan.TargetVersion, version)
+		if applied[version] {
+			continue
+		}
+
+		source, err := fs.ReadFile(baseFS, path.Join(dir, file.Name()))
+		if err != nil {
+			return domain.MigrationPlan{}, fmt.Errorf("read migration %q: %w", file.Name(), err)
+		}
+
+		pending := domain.PendingMigration{Version: version, Name: match[2], Tables: []domain.MigrationTableImpact{}}
+		for _, impact := range migrationTableImpacts(string(source)) {
+			rows, ok := rowCounts[impact.Table]
+			if !ok {
+				rows, err = countTableRows(ctx, db, impact.Table)
+				if err != nil {
+					return domain.MigrationPlan{}, err
+				}
+				rowCounts[impact.Table] = rows
+			}
+			impact.Rows = rows
+			for _, op := range impact.Operations {
+				if domain.IsMigrationRewriteOp(op) {
+					impact.RowRewrites = rows
+				}
+			}
+			plan.EstimatedRowRewrites += impact.RowRewrites
+			affected[impact.Table] = struct{}{}
+			pending.Tables = append(pending.Tables, impact)
+		}
+		plan.Pending = append(plan.Pending, pending)
+	}
+
+	sort.Slice(plan.Pending, func(i, j int) bool { return plan.Pending[i].Version < plan.Pending[j].Version })
+	for table := range affected {
+		plan.AffectedTables = append(plan.AffectedTables, table)
+	}
+	sort.Strings(plan.AffectedTables)
+
+	plan.EstimatedDurationMS = domain.EstimateMigrationDurationMS(plan.EstimatedRowRewrites)
+	plan.BackupReasons = domain.MigrationBackupReasons(plan)
+	plan.BackupRecommended = len(plan.BackupReasons) > 0
+	return plan, nil
+}
+
+// migrationTableImpacts lists the tables touched by the Up section of a goose
+// SQL migration, in first-seen order.
+func migrationTableImpacts(source string) []domain.MigrationTableImpact {
+	up := source
+	if _, after, ok := strings.Cut(up, "+goose Up"); ok {
+		up = after
+	}
+	if before, _, ok := strings.Cut(up, "-- +goose Down"); ok {
+		up = before
+	}
+	up = migrationCommentPattern.ReplaceAllString(up, "")
+
+	impacts := []domain.MigrationTableImpact{}
+	index := map[string]int{}
+	add := func(table, op string) {
+		table = strings.ToLower(table)
+		i, ok := index[table]
+		if !ok {
+			index[table] = len(impacts)
+			impacts = append(impacts, domain.MigrationTableImpact{Table: table, Operations: []string{op}})
+			return
+		}
+		for _, existing := range impacts[i].Operations {
+			if existing == op {
+				return
+			}
+		}
+		impacts[i].Operations = append(impacts[i].Operations, op)
+	}
+
+	for _, match := range migrationTriggerPattern.FindAllStringSubmatch(up, -1) {
+		add(match[1], domain.MigrationOpTrigger)
+	}
+	up = migrationTriggerPattern.ReplaceAllString(up, "")
+
+	for _, statement := range strings.Split(up, ";") {
+		for _, candidate := range migrationStatementPatterns {
+			if match := candidate.pattern.FindStringSubmatch(statement); match != nil {
+				add(match[1], candidate.op)
+				break
+			}
+		}
+	}
+	return impacts
+}
+
+// appliedMigrationVersions reads goose's version table without creating it;
+// a version counts as applied when its latest row says so.
+func appliedMigrationVersions(ctx context.Context, db *sql.DB) (map[int64]bool, error) {
+	applied := map[int64]bool{}
+
+	exists, err := tableExists(ctx, db, "goose_db_version")
+	if err != nil || !exists {
+		return applied, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version_id, is_applied FROM goose_db_version ORDER BY id DESC;")
+	if err != nil {
+		return nil, fmt.Errorf("read goose versions: %w", err)
+	}
+	defer rows.Close()
+
+	seen := map[int64]struct{}{}
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		if err := rows.Scan(&version, &isApplied); err != nil {
+			return nil, fmt.Errorf("scan goose version: %w", err)
+		}
+		if _, ok := seen[version]; ok {
+			continue
+		}
+		seen[version] = struct{}{}
+		if isApplied && version > 0 {
+			applied[version] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate goose versions: %w", err)
+	}
+	return applied, nil
+}
+
+// countTableRows returns zero for tables that do not exist yet.
+func countTableRows(ctx context.Context, db *sql.DB, table string) (int64, error) {
+	exists, err := tableExists(ctx, db, table)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var count int64
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %q;", table)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count rows in %q: %w", table, err)
+	}
+	return count, nil
+}
+
+func tableExists(ctx context.Context, db *sql.DB, table string) (bool, error) {
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?;", table).Scan(&count); err != nil {
+		return false, fmt.Errorf("check table %q: %w", table, err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+	"github.com/pressly/goose/v3"
+)
+
+// MigrationStatus lists every known migration with whether and when it was
+// applied. Like PlanMigrations it never writes to db.
+func MigrationStatus(ctx context.Context, db *sql.DB, migrationsDir string) (domain.MigrationStatus, error) {
+	if db == nil {
+		return domain.MigrationStatus{}, fmt.Errorf("migration status: db is nil")
+	}
+
+	applied, err := appliedMigrationTimes(ctx, db)
+	if err != nil {
+		return domain.MigrationStatus{}, err
+	}
+
+	dir, baseFS := resolveMigrationSource(migrationsDir)
+	if baseFS == nil {
+		baseFS = os.DirFS(dir)
+		dir = "."
+	}
+	files, err := fs.ReadDir(baseFS, dir)
+	if err != nil {
+		return domain.MigrationStatus{}, fmt.Errorf("read migrations dir %q: %w", migrationsDir, err)
+	}
+
+	status := domain.MigrationStatus{Migrations: []domain.MigrationStatusEntry{}}
+	for version := range applied {
+		status.CurrentVersion = max(status.CurrentVersion, version)
+	}
+	for _, file := range files {
+		match := migrationFilePattern.FindStringSubmatch(file.Name())
+		if file.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return domain.MigrationStatus{}, fmt.Errorf("parse migration version %q: %w", file.Name(), err)
+		}
+
+		appliedAt, ok := applied[version]
+		status.Migrations = append(status.Migrations, domain.MigrationStatusEntry{
+			Version:      version,
+			Name:         match[2],
+			Applied:      ok,
+			AppliedAtUTC: appliedAt,
+		})
+		status.LatestVersion = max(status.LatestVersion, version)
+		if !ok {
+			status.Pending++
+		}
+	}
+
+	sort.Slice(status.Migrations, func(i, j int) bool { return status.Migrations[i].Version < status.Migrations[j].Version })
+	return status, nil
+}
+
+// MigrateUp applies every pending migration and reports which ones ran.
+func MigrateUp(ctx context.Context, db *sql.DB, migrationsDir string) (domain.MigrationRun, error) {
+	if db == nil {
+		return domain.MigrationRun{}, fmt.Errorf("migrate up: db is nil")
+	}
+
+	before, err := appliedMigrationVersions(ctx, db)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	if err := RunMigrations(ctx, db, migrationsDir); err != nil {
+		return domain.MigrationRun{}, err
+	}
+	after, err := appliedMigrationVersions(ctx, db)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+
+	return newMigrationRun(domain.MigrationDirectionUp, before, after), nil
+}
+
+// MigrateDownTo rolls back applied migrations, newest first, until the schema
+// is at version. Every migration's Down section must undo its Up section.
+func MigrateDownTo(ctx context.Context, db *sql.DB, migrationsDir string, version int64) (domain.MigrationRun, error) {
+	if db == nil {
+		return domain.MigrationRun{}, fmt.Errorf("migrate down: db is nil")
+	}
+	if err := domain.ValidateMigrationTargetVersion(version); err != nil {
+		return domain.MigrationRun{}, err
+	}
+
+	before, err := appliedMigrationVersions(ctx, db)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	err = withGoose(migrationsDir, func(dir string) error {
+		if err := goose.DownToContext(ctx, db, dir, version); err != nil {
+			return fmt.Errorf("goose down to %d: %w", version, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	after, err := appliedMigrationVersions(ctx, db)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+
+	return newMigrationRun(domain.MigrationDirectionDown, before, after), nil
+}
+
+func newMigrationRun(direction string, before, after map[int64]bool) domain.MigrationRun {
+	run := domain.MigrationRun{Direction: direction, Versions: []int64{}}
+	for version := range before {
+		run.FromVersion = max(run.FromVersion, version)
+		if !after[version] {
+			run.Versions = append(run.Versions, version)
+		}
+	}
+	for version := range after {
+		run.ToVersion = max(run.ToVersion, version)
+		if !before[version] {
+			run.Versions = append(run.Versions, version)
+		}
+	}
+
+	slices.Sort(run.Versions)
+	if direction == domain.MigrationDirectionDown {
+		slices.Reverse(run.Versions)
+	}
+	return run
+}
+
+// appliedMigrationTimes maps each applied version to when goose recorded it,
+// in RFC3339. Like appliedMigrationVersions it never creates the version table.
+func appliedMigrationTimes(ctx context.Context, db *sql.DB) (map[int64]string, error) {
+	applied := map[int64]string{}
+
+	exists, err := tableExists(ctx, db, "goose_db_version")
+	if err != nil || !exists {
+		return applied, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version_id, is_applied, CAST(tstamp AS TEXT) FROM goose_db_version ORDER BY id DESC;")
+	if err != nil {
+		return nil, fmt.Errorf("read goose versions: %w", err)
+	}
+	defer rows.Close()
+
+	seen := map[int64]struct{}{}
+	for rows.Next() {
+		var (
+			version   int64
+			isApplied bool
+			stamp     sql.NullString
+		)
+		if err := rows.Scan(&version, &isApplied, &stamp); err != nil {
+			return nil, fmt.Errorf("scan goose version: %w", err)
+		}
+		if _, ok := seen[version]; ok {
+			continue
+		}
+		seen[version] = struct{}{}
+		if !isApplied || version <= 0 {
+			continue
+		}
+
+		appliedAt := strings.TrimSpace(stamp.String)
+		if parsed, err := time.Parse(time.DateTime, appliedAt); err == nil {
+			appliedAt = parsed.UTC().Format(time.RFC3339)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate goose versions: %w", err)
+	}
+	return applied, nil
+}
@@ -1,15 +1,43 @@
 package sqlite
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"errors"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"boring-budget/internal/domain"
 	"github.com/pressly/goose/v3"
 )
 
+func TestOpenLogsStatementsWhenDebugEnabled(t *testing.T) {
+	var logs bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() {
+		slog.SetDefault(original)
+	})
+
+	ctx := context.Background()
+	db, err := OpenAndMigrate(ctx, filepath.Join(t.TempDir(), "test.db"), "")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := NewSettingsRepo(db).ListStrictWarningCodes(ctx); err != nil {
+		t.Fatalf("list strict warning codes: %v", err)
+	}
+	if !strings.Contains(logs.String(), `msg="sqlite statement"`) || !strings.Contains(logs.String(), "FROM strict_warning_codes ORDER BY code") {
+		t.Fatalf("expected timed statement logs, got %s", logs.String())
+	}
+}
+
 func TestOpenSetsExpectedPragmas(t *testing.T) {
 	t.Parallel()
 
@@ -90,23 +118,231 @@ DROP TABLE IF EXISTS other_items;
 	assertGooseVersion(t, ctx, db, 2)
 }
 
-func TestRunMigrationsUsesEmbeddedWhenDefaultDirMissing(t *testing.T) {
+func TestRunMigrationsUsesEmbeddedByDefault(t *testing.T) {
 	ctx := context.Background()
 	tempDir := t.TempDir()
 	dbPath := filepath.Join(tempDir, "embedded.db")
 
+	// A migrations directory in the working directory must not shadow the
+	// embedded set.
+	t.Chdir(tempDir)
+	if err := writeFile(filepath.Join(tempDir, "migrations", "0001_unrelated.sql"), `
+-- +goose Up
+CREATE TABLE unrelated (id INTEGER PRIMARY KEY);
+-- +goose Down
+DROP TABLE unrelated;
+`); err != nil {
+		t.Fatalf("write unrelated migration: %v", err)
+	}
+
 	db, err := Open(ctx, dbPath)
 	if err != nil {
 		t.Fatalf("open db: %v", err)
 	}
 	defer db.Close()
 
-	if err := RunMigrations(ctx, db, DefaultMigrationsDir); err != nil {
-		t.Fatalf("run migrations with embedded fallback: %v", err)
+	if err := RunMigrations(ctx, db, ""); err != nil {
+		t.Fatalf("run embedded migrations: %v", err)
 	}
 
 	assertTableExists(t, ctx, db, "transactions")
-	assertGooseVersion(t, ctx, db, 6)
+	assertGooseVersion(t, ctx, db, 45)
+}
+
+func TestOpenAndMigrateKeepsSharedMemoryDatabaseAcrossHandles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dbPath := "file:migrate-shared-memory?mode=memory&cache=shared"
+
+	first, err := OpenAndMigrate(ctx, dbPath, "")
+	if err != nil {
+		t.Fatalf("open shared memory db: %v", err)
+	}
+	if _, err := first.ExecContext(ctx, "INSERT INTO categories (name) VALUES ('Food')"); err != nil {
+		t.Fatalf("insert category: %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("close first handle: %v", err)
+	}
+
+	second, err := OpenAndMigrate(ctx, dbPath, "")
+	if err != nil {
+		t.Fatalf("reopen shared memory db: %v", err)
+	}
+	defer second.Close()
+
+	assertGooseVersion(t, ctx, second, 45)
+	var count int64
+	if err := second.QueryRowContext(ctx, "SELECT COUNT(*) FROM categories WHERE name = 'Food'").Scan(&count); err != nil {
+		t.Fatalf("count categories: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected the category to survive closing the first handle, got %d", count)
+	}
+
+	private, err := OpenAndMigrate(ctx, MemoryPath, "")
+	if err != nil {
+		t.Fatalf("open private memory db: %v", err)
+	}
+	defer private.Close()
+	if err := private.QueryRowContext(ctx, "SELECT COUNT(*) FROM categories").Scan(&count); err != nil {
+		t.Fatalf("count private categories: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected a private memory db to start empty, got %d categories", count)
+	}
+}
+
+func TestIsMemoryPath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		":memory:":                         true,
+		"file::memory:":                    true,
+		"file::memory:?cache=shared":       true,
+		"file:ci?mode=memory&cache=shared": true,
+		"file:budget.db?mode=ro":           false,
+		"budget.db":                        false,
+		"/tmp/:memory:":                    false,
+	}
+	for path, expected := range cases {
+		if got := IsMemoryPath(path); got != expected {
+			t.Fatalf("IsMemoryPath(%q) = %v, want %v", path, got, expected)
+		}
+	}
+}
+
+func TestPlanMigrationsEstimatesPendingImpactWithoutWriting(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	migrationsDir := filepath.Join(tempDir, "migrations")
+
+	if err := writeFile(filepath.Join(migrationsDir, "0001_create_items.sql"), `
+-- +goose Up
+CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+-- +goose Down
+DROP TABLE items;
+`); err != nil {
+		t.Fatalf("write migration 0001: %v", err)
+	}
+
+	db, err := Open(ctx, filepath.Join(tempDir, "plan.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	if err := RunMigrations(ctx, db, migrationsDir); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := db.ExecContext(ctx, "INSERT INTO items (name) VALUES ('item');"); err != nil {
+			t.Fatalf("insert item: %v", err)
+		}
+	}
+
+	if err := writeFile(filepath.Join(migrationsDir, "0002_item_notes.sql"), `
+-- +goose Up
+ALTER TABLE items ADD COLUMN note TEXT NOT NULL DEFAULT '';
+CREATE TABLE item_notes (id INTEGER PRIMARY KEY, item_id INTEGER NOT NULL);
+CREATE TRIGGER trg_items_note AFTER UPDATE ON items
+BEGIN
+	INSERT INTO item_notes (item_id) VALUES (NEW.id);
+END;
+DELETE FROM items WHERE name = '';
+-- +goose Down
+DROP TABLE item_notes;
+`); err != nil {
+		t.Fatalf("write migration 0002: %v", err)
+	}
+
+	plan, err := PlanMigrations(ctx, db, migrationsDir)
+	if err != nil {
+		t.Fatalf("plan migrations: %v", err)
+	}
+
+	if plan.CurrentVersion != 1 || plan.TargetVersion != 2 || len(plan.Pending) != 1 {
+		t.Fatalf("unexpected plan versions: %+v", plan)
+	}
+	tables := plan.Pending[0].Tables
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 affected tables, got %+v", tables)
+	}
+	if tables[0].Table != "items" || tables[0].Rows != 3 || tables[0].RowRewrites != 3 {
+		t.Fatalf("unexpected items impact: %+v", tables[0])
+	}
+	if got := strings.Join(tables[0].Operations, ","); got != "trigger,alter_table,delete" {
+		t.Fatalf("unexpected items operations: %s", got)
+	}
+	if tables[1].Table != "item_notes" || tables[1].RowRewrites != 0 {
+		t.Fatalf("trigger body should not count as an insert into item_notes: %+v", tables[1])
+	}
+	if plan.EstimatedRowRewrites != 3 || plan.EstimatedDurationMS != 1 {
+		t.Fatalf("unexpected estimate: rewrites=%d duration=%d", plan.EstimatedRowRewrites, plan.EstimatedDurationMS)
+	}
+	if !plan.BackupRecommended || len(plan.BackupReasons) != 1 {
+		t.Fatalf("expected backup recommendation for deleting rows, got %+v", plan.BackupReasons)
+	}
+
+	assertGooseVersion(t, ctx, db, 1)
+}
+
+func TestMigrateDownToRollsBackEveryEmbeddedMigration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "down.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	up, err := MigrateUp(ctx, db, "")
+	if err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	if up.FromVersion != 0 || up.ToVersion != 45 || len(up.Versions) != 45 || up.Versions[0] != 1 {
+		t.Fatalf("unexpected up run: %+v", up)
+	}
+
+	status, err := MigrationStatus(ctx, db, "")
+	if err != nil {
+		t.Fatalf("migration status: %v", err)
+	}
+	if status.CurrentVersion != 45 || status.LatestVersion != 45 || status.Pending != 0 || len(status.Migrations) != 45 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if first := status.Migrations[0]; first.Name != "initial" || !first.Applied || first.AppliedAtUTC == "" {
+		t.Fatalf("unexpected first migration: %+v", first)
+	}
+
+	down, err := MigrateDownTo(ctx, db, "", 16)
+	if err != nil {
+		t.Fatalf("migrate down to 16: %v", err)
+	}
+	if down.FromVersion != status.LatestVersion || down.ToVersion != 16 || int64(len(down.Versions)) != status.LatestVersion-16 || down.Versions[0] != status.LatestVersion {
+		t.Fatalf("unexpected down run: %+v", down)
+	}
+	if exists, err := tableExists(ctx, db, "hooks"); err != nil || exists {
+		t.Fatalf("expected hooks table to be dropped, exists=%v err=%v", exists, err)
+	}
+
+	if _, err := MigrateDownTo(ctx, db, "", 0); err != nil {
+		t.Fatalf("migrate down to 0: %v", err)
+	}
+	assertGooseVersion(t, ctx, db, 0)
+
+	if _, err := MigrateUp(ctx, db, ""); err != nil {
+		t.Fatalf("migrate up after full rollback: %v", err)
+	}
+	assertGooseVersion(t, ctx, db, 45)
+
+	if _, err := MigrateDownTo(ctx, db, "", -1); !errors.Is(err, domain.ErrInvalidMigrationVersion) {
+		t.Fatalf("expected invalid version error, got %v", err)
+	}
 }
 
 func assertGooseVersion(t *testing.T, ctx context.Context, db *sql.DB, expected int64) {
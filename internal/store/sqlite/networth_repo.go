@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type NetWorthRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewNetWorthRepo(db *sql.DB) *NetWorthRepo {
+	return &NetWorthRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// SaveSnapshots replaces the snapshots of snapshotDate with positions, so a
+// currency that no longer has a position drops out of that day.
+func (r *NetWorthRepo) SaveSnapshots(ctx context.Context, snapshotDate string, positions []domain.NetWorthPosition) ([]domain.NetWorthSnapshot, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("save net worth snapshots: db is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("save net worth snapshots begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteNetWorthSnapshotsByDate(ctx, snapshotDate); err != nil {
+		return nil, fmt.Errorf("save net worth snapshots clear day: %w", err)
+	}
+
+	recordedAt := nowRFC3339Nano()
+	snapshots := make([]domain.NetWorthSnapshot, 0, len(positions))
+	for _, position := range positions {
+		params := queries.UpsertNetWorthSnapshotParams{
+			SnapshotDate:     snapshotDate,
+			CurrencyCode:     position.CurrencyCode,
+			AssetsMinor:      position.AssetsMinor,
+			LiabilitiesMinor: position.LiabilitiesMinor,
+			NetWorthMinor:    position.NetWorthMinor,
+			RecordedAtUtc:    recordedAt,
+		}
+		if err := qtx.UpsertNetWorthSnapshot(ctx, params); err != nil {
+			return nil, fmt.Errorf("save net worth snapshot %s: %w", position.CurrencyCode, err)
+		}
+		snapshots = append(snapshots, mapSQLCNetWorthSnapshotToDomain(queries.NetWorthSnapshot(params)))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("save net worth snapshots commit: %w", err)
+	}
+	return snapshots, nil
+}
+
+func (r *NetWorthRepo) ListSnapshots(ctx context.Context, dateFrom, dateTo, currencyCode string) ([]domain.NetWorthSnapshot, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list net worth snapshots: db is nil")
+	}
+
+	rows, err := r.queries.ListNetWorthSnapshots(ctx, queries.ListNetWorthSnapshotsParams{
+		DateFrom:     nullableString(dateFrom),
+		DateTo:       nullableString(dateTo),
+		CurrencyCode: nullableString(currencyCode),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list net worth snapshots: %w", err)
+	}
+
+	snapshots := make([]domain.NetWorthSnapshot, 0, len(rows))
+	for _, row := range rows {
+		snapshots = append(snapshots, mapSQLCNetWorthSnapshotToDomain(row))
+	}
+	return snapshots, nil
+}
+
+func mapSQLCNetWorthSnapshotToDomain(row queries.NetWorthSnapshot) domain.NetWorthSnapshot {
+	return domain.NetWorthSnapshot{
+		SnapshotDate:     row.SnapshotDate,
+		CurrencyCode:     row.CurrencyCode,
+		AssetsMinor:      row.AssetsMinor,
+		LiabilitiesMinor: row.LiabilitiesMinor,
+		NetWorthMinor:    row.NetWorthMinor,
+		RecordedAtUTC:    row.RecordedAtUtc,
+	}
+}
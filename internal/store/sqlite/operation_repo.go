@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type OperationRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewOperationRepo(db *sql.DB) *OperationRepo {
+	return &OperationRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+func (r *OperationRepo) Create(ctx context.Context, kind string, params json.RawMessage, total int64) (domain.Operation, error) {
+	if r.db == nil {
+		return domain.Operation{}, fmt.Errorf("create operation: db is nil")
+	}
+
+	result, err := r.queries.CreateOperation(ctx, queries.CreateOperationParams{
+		Kind:         kind,
+		ParamsJson:   string(params),
+		Total:        total,
+		UpdatedAtUtc: time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return domain.Operation{}, fmt.Errorf("create operation: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.Operation{}, fmt.Errorf("create operation id: %w", err)
+	}
+	return r.Get(ctx, id)
+}
+
+func (r *OperationRepo) Get(ctx context.Context, id int64) (domain.Operation, error) {
+	if r.db == nil {
+		return domain.Operation{}, fmt.Errorf("get operation: db is nil")
+	}
+
+	row, err := r.queries.GetOperation(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Operation{}, domain.ErrOperationNotFound
+		}
+		return domain.Operation{}, fmt.Errorf("get operation: %w", err)
+	}
+	return mapSQLCOperationToDomain(row), nil
+}
+
+// List returns the newest operations first, optionally filtered by status.
+func (r *OperationRepo) List(ctx context.Context, status string, limit int) ([]domain.Operation, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list operations: db is nil")
+	}
+
+	rows, err := r.queries.ListOperations(ctx, queries.ListOperationsParams{Status: status, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("list operations: %w", err)
+	}
+
+	operations := make([]domain.Operation, 0, len(rows))
+	for _, row := range rows {
+		operations = append(operations, mapSQLCOperationToDomain(row))
+	}
+	return operations, nil
+}
+
+// UpdateProgress records progress for a running operation. It returns false
+// when the operation is no longer running, for example after a cancel from
+// another process.
+func (r *OperationRepo) UpdateProgress(ctx context.Context, id int64, progress domain.OperationProgress) (bool, error) {
+	if r.db == nil {
+		return false, fmt.Errorf("update operation progress: db is nil")
+	}
+
+	result, err := r.queries.UpdateOperationProgress(ctx, queries.UpdateOperationProgressParams{
+		Total:        progress.Total,
+		Done:         progress.Done,
+		Checkpoint:   progress.Checkpoint,
+		UpdatedAtUtc: time.Now().UTC().Format(time.RFC3339Nano),
+		ID:           id,
+	})
+	if err != nil {
+		return false, fmt.Errorf("update operation progress: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("update operation progress rows affected: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Transition moves an operation from one status to another and reports
+// whether it was in fromStatus. Terminal statuses also set finished_at_utc.
+func (r *OperationRepo) Transition(ctx context.Context, id int64, fromStatus, toStatus string, lastError *string) (bool, error) {
+	if r.db == nil {
+		return false, fmt.Errorf("transition operation: db is nil")
+	}
+
+	nowUTC := time.Now().UTC().Format(time.RFC3339Nano)
+	finishedAt := sql.NullString{}
+	if toStatus != domain.OperationStatusRunning {
+		finishedAt = sql.NullString{String: nowUTC, Valid: true}
+	}
+
+	result, err := r.queries.UpdateOperationStatus(ctx, queries.UpdateOperationStatusParams{
+		Status:        toStatus,
+		LastError:     nullableStringPtr(lastError),
+		FinishedAtUtc: finishedAt,
+		UpdatedAtUtc:  nowUTC,
+		ID:            id,
+		FromStatus:    fromStatus,
+	})
+	if err != nil {
+		return false, fmt.Errorf("transition operation: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("transition operation rows affected: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func mapSQLCOperationToDomain(row queries.Operation) domain.Operation {
+	params := json.RawMessage("{}")
+	if json.Valid([]byte(row.ParamsJson)) {
+		params = json.RawMessage(row.ParamsJson)
+	}
+
+	operation := domain.Operation{
+		ID:           row.ID,
+		Kind:         row.Kind,
+		Status:       row.Status,
+		Params:       params,
+		Total:        row.Total,
+		Done:         row.Done,
+		Checkpoint:   row.Checkpoint,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+	if row.LastError.Valid {
+		lastError := row.LastError.String
+		operation.LastError = &lastError
+	}
+	if row.FinishedAtUtc.Valid {
+		finishedAt := row.FinishedAtUtc.String
+		operation.FinishedAtUTC = &finishedAt
+	}
+	return operation
+}
@@ -0,0 +1,49 @@
+-- name: CreateAsset :execresult
+INSERT INTO assets (
+    name,
+    kind,
+    currency_code,
+    balance_minor,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetAssetByID :one
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE id = ?;
+
+-- name: GetActiveAssetByID :one
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: ListAssets :many
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE (sqlc.arg(include_deleted) = 1 OR deleted_at_utc IS NULL)
+  AND (sqlc.narg(kind) IS NULL OR kind = sqlc.narg(kind))
+ORDER BY kind, lower(name), id;
+
+-- name: UpdateAssetByID :execresult
+UPDATE assets
+SET name = CASE
+    WHEN sqlc.arg(set_name) = 1 THEN sqlc.arg(name)
+    ELSE name
+END,
+    balance_minor = CASE
+    WHEN sqlc.arg(set_balance_minor) = 1 THEN sqlc.arg(balance_minor)
+    ELSE balance_minor
+END,
+    note = CASE
+    WHEN sqlc.arg(set_note) = 1 THEN sqlc.narg(note)
+    ELSE note
+END,
+    updated_at_utc = sqlc.arg(updated_at_utc)
+WHERE id = sqlc.arg(id)
+  AND deleted_at_utc IS NULL;
+
+-- name: SoftDeleteAsset :execresult
+UPDATE assets
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL;
@@ -0,0 +1,30 @@
+-- name: ListAuditEventsAfterID :many
+SELECT id, action, entity_type, entity_id, source, payload_json, created_at_utc
+FROM audit_events
+WHERE id > ?
+ORDER BY id
+LIMIT ?;
+
+-- name: GetLatestAuditEventID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS latest_id
+FROM audit_events;
+
+-- name: ListAuditEvents :many
+SELECT id, action, entity_type, entity_id, source, payload_json, created_at_utc
+FROM audit_events
+ORDER BY id;
+
+-- name: ExistsAuditEvent :one
+SELECT EXISTS(
+    SELECT 1
+    FROM audit_events
+    WHERE created_at_utc = ?
+      AND entity_type = ?
+      AND entity_id = ?
+      AND action = ?
+      AND json(COALESCE(payload_json, '{}')) = json(?)
+);
+
+-- name: InsertAuditEvent :execresult
+INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+VALUES (?, ?, ?, ?, ?, ?);
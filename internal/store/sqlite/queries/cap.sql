@@ -16,26 +16,73 @@ SELECT id, month_key, amount_minor, currency_code, created_at_utc, updated_at_ut
 FROM monthly_caps
 WHERE month_key = ?;
 
+-- name: CreateMonthlyCategoryCap :execresult
+INSERT INTO monthly_category_caps (
+    month_key,
+    category_id,
+    amount_minor,
+    currency_code,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateMonthlyCategoryCap :execresult
+UPDATE monthly_category_caps
+SET amount_minor = ?, currency_code = ?, updated_at_utc = ?
+WHERE month_key = ? AND category_id = ?;
+
+-- name: GetMonthlyCategoryCap :one
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+WHERE month_key = ? AND category_id = ?;
+
+-- name: ListMonthlyCategoryCapsByMonthKey :many
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+WHERE month_key = ?
+ORDER BY category_id;
+
+-- name: ListMonthlyCaps :many
+SELECT id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_caps
+ORDER BY month_key;
+
+-- name: ListMonthlyCategoryCaps :many
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+ORDER BY month_key, category_id;
+
 -- name: CreateMonthlyCapChange :execresult
 INSERT INTO monthly_cap_changes (
     month_key,
     old_amount_minor,
     new_amount_minor,
     currency_code,
-    changed_at_utc
-) VALUES (?, ?, ?, ?, ?);
+    changed_at_utc,
+    category_id,
+    copied_from_month_key
+) VALUES (?, ?, ?, ?, ?, ?, ?);
 
 -- name: ListMonthlyCapChangesByMonthKey :many
-SELECT id, month_key, old_amount_minor, new_amount_minor, currency_code, changed_at_utc
+SELECT id, month_key, old_amount_minor, new_amount_minor, currency_code, changed_at_utc, category_id, copied_from_month_key
 FROM monthly_cap_changes
 WHERE month_key = ?
 ORDER BY changed_at_utc, id;
 
 -- name: SumActiveExpensesByMonthAndCurrency :one
-SELECT CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS total_amount_minor
+SELECT CAST(COALESCE(SUM(CASE WHEN refund_of_transaction_id IS NULL THEN amount_minor ELSE -amount_minor END), 0) AS INTEGER) AS total_amount_minor
+FROM transactions
+WHERE type = 'expense'
+  AND deleted_at_utc IS NULL
+  AND currency_code = ?
+  AND transaction_date_utc >= ?
+  AND transaction_date_utc < ?;
+
+-- name: SumActiveExpensesByMonthCategoryAndCurrency :one
+SELECT CAST(COALESCE(SUM(CASE WHEN refund_of_transaction_id IS NULL THEN amount_minor ELSE -amount_minor END), 0) AS INTEGER) AS total_amount_minor
 FROM transactions
 WHERE type = 'expense'
   AND deleted_at_utc IS NULL
+  AND category_id = ?
   AND currency_code = ?
   AND transaction_date_utc >= ?
   AND transaction_date_utc < ?;
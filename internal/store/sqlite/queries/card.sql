@@ -6,41 +6,58 @@ INSERT INTO cards (
     brand,
     card_type,
     due_day,
+    default_currency_code,
+    fx_fee_bp,
     updated_at_utc
-) VALUES (?, ?, ?, ?, ?, ?, ?);
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetCardByID :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE id = ?;
 
 -- name: GetActiveCardByID :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE id = ?
   AND deleted_at_utc IS NULL;
 
 -- name: GetActiveCardByNickname :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE lower(nickname) = lower(?)
   AND deleted_at_utc IS NULL;
 
 -- name: ListCards :many
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
-FROM cards
-WHERE (sqlc.arg(include_deleted) = 1 OR deleted_at_utc IS NULL)
-  AND (sqlc.narg(card_type) IS NULL OR card_type = sqlc.narg(card_type))
-ORDER BY lower(nickname), id;
+WITH params AS (SELECT CAST(sqlc.arg(sort_key) AS TEXT) AS sort_key)
+SELECT c.id, c.nickname, c.description, c.last4, c.brand, c.card_type, c.due_day, c.created_at_utc, c.updated_at_utc, c.deleted_at_utc, c.archived_at_utc, c.default_currency_code, c.fx_fee_bp
+FROM cards c
+CROSS JOIN params
+WHERE (sqlc.arg(include_deleted) = 1 OR c.deleted_at_utc IS NULL)
+  AND (sqlc.arg(include_archived) = 1 OR c.archived_at_utc IS NULL)
+  AND (sqlc.narg(card_type) IS NULL OR c.card_type = sqlc.narg(card_type))
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN c.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN c.id END,
+    CASE WHEN params.sort_key = 'due-day' THEN COALESCE(c.due_day, 99) END,
+    lower(c.nickname),
+    c.id;
 
 -- name: SearchActiveCardsByLookup :many
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE deleted_at_utc IS NULL
+  AND (sqlc.arg(include_archived) = 1 OR archived_at_utc IS NULL)
   AND (
       instr(lower(nickname), lower(sqlc.arg(lookup_text))) > 0
       OR (description IS NOT NULL AND instr(lower(description), lower(sqlc.arg(lookup_text))) > 0)
       OR instr(last4, sqlc.arg(lookup_text)) > 0
+      OR EXISTS (
+          SELECT 1
+          FROM card_aliases a
+          WHERE a.card_id = cards.id
+            AND instr(lower(a.alias), lower(sqlc.arg(lookup_text))) > 0
+      )
   )
 ORDER BY lower(nickname), id
 LIMIT sqlc.arg(limit_rows);
@@ -72,10 +89,19 @@ END,
     WHEN sqlc.arg(clear_due_day) = 1 THEN NULL
     WHEN sqlc.arg(set_due_day) = 1 THEN sqlc.narg(due_day)
     ELSE due_day
+END,
+    default_currency_code = CASE
+    WHEN sqlc.arg(set_default_currency_code) = 1 THEN sqlc.narg(default_currency_code)
+    ELSE default_currency_code
+END,
+    fx_fee_bp = CASE
+    WHEN sqlc.arg(set_fx_fee_bp) = 1 THEN sqlc.narg(fx_fee_bp)
+    ELSE fx_fee_bp
 END,
     updated_at_utc = sqlc.arg(updated_at_utc)
 WHERE id = sqlc.arg(id)
-  AND deleted_at_utc IS NULL;
+  AND deleted_at_utc IS NULL
+  AND (sqlc.narg(expected_updated_at_utc) IS NULL OR updated_at_utc = sqlc.narg(expected_updated_at_utc));
 
 -- name: SoftDeleteCard :execresult
 UPDATE cards
@@ -84,6 +110,19 @@ SET deleted_at_utc = ?,
 WHERE id = ?
   AND deleted_at_utc IS NULL;
 
+-- name: SetActiveCardArchivedAt :execresult
+UPDATE cards
+SET archived_at_utc = ?,
+    updated_at_utc = ?
+WHERE id = ?
+  AND deleted_at_utc IS NULL;
+
+-- name: GetCardFXFeeTerms :one
+SELECT c.fx_fee_bp,
+       COALESCE(c.default_currency_code, (SELECT s.default_currency_code FROM settings s WHERE s.id = 1), 'USD') AS home_currency_code
+FROM cards c
+WHERE c.id = ?;
+
 -- name: ExistsCardByID :one
 SELECT EXISTS(
     SELECT 1
@@ -146,27 +185,34 @@ INSERT INTO credit_liability_events (
     amount_minor_signed,
     reference_transaction_id,
     note,
+    fx_fee_minor,
     created_at_utc
-) VALUES (?, ?, ?, ?, ?, ?, ?);
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetCreditLiabilityEventByID :one
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE id = ?;
 
 -- name: ListCreditLiabilityEventsByCard :many
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE card_id = ?
 ORDER BY currency_code, created_at_utc, id;
 
 -- name: ListCreditLiabilityEventsByCardAndCurrency :many
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE card_id = ?
   AND currency_code = ?
 ORDER BY created_at_utc, id;
 
+-- name: ListUnreferencedCreditLiabilityEvents :many
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
+FROM credit_liability_events
+WHERE reference_transaction_id IS NULL
+ORDER BY created_at_utc, id;
+
 -- name: GetCreditLiabilityBalanceByCardAndCurrency :one
 SELECT CAST(COALESCE(SUM(amount_minor_signed), 0) AS INTEGER) AS balance_minor
 FROM credit_liability_events
@@ -184,14 +230,71 @@ GROUP BY card_id, currency_code
 ORDER BY currency_code;
 
 -- name: ListCreditLiabilitySummaryAllCards :many
-SELECT card_id,
-       currency_code,
-       CAST(COALESCE(SUM(amount_minor_signed), 0) AS INTEGER) AS balance_minor,
-       MAX(created_at_utc) AS last_event_at_utc
-FROM credit_liability_events
-GROUP BY card_id, currency_code
-ORDER BY card_id, currency_code;
+WITH params AS (SELECT CAST(sqlc.arg(sort_key) AS TEXT) AS sort_key)
+SELECT e.card_id,
+       e.currency_code,
+       CAST(COALESCE(SUM(e.amount_minor_signed), 0) AS INTEGER) AS balance_minor,
+       MAX(e.created_at_utc) AS last_event_at_utc
+FROM credit_liability_events e
+LEFT JOIN cards c ON c.id = e.card_id
+CROSS JOIN params
+GROUP BY e.card_id, e.currency_code
+ORDER BY
+    CASE WHEN params.sort_key = 'balance' THEN -SUM(e.amount_minor_signed) END,
+    CASE WHEN params.sort_key = 'nickname' THEN lower(c.nickname) END,
+    e.card_id,
+    e.currency_code;
+
+-- name: ListCardFXFeeCharges :many
+SELECT e.card_id, c.nickname AS card_nickname, e.currency_code, e.fx_fee_minor, t.transaction_date_utc
+FROM credit_liability_events e
+JOIN transactions t ON t.id = e.reference_transaction_id
+JOIN cards c ON c.id = e.card_id
+WHERE e.fx_fee_minor <> 0
+  AND t.deleted_at_utc IS NULL
+  AND t.transaction_date_utc >= sqlc.arg(from_utc)
+  AND t.transaction_date_utc <= sqlc.arg(to_utc)
+  AND (sqlc.narg(card_id) IS NULL OR e.card_id = sqlc.narg(card_id))
+ORDER BY t.transaction_date_utc, e.id;
 
 -- name: DeleteCreditLiabilityEventsByReferenceTransaction :execresult
 DELETE FROM credit_liability_events
 WHERE reference_transaction_id = ?;
+
+-- name: UpsertCardMonthlyLimit :execresult
+INSERT INTO card_monthly_limits (
+    card_id,
+    month_key,
+    amount_minor,
+    currency_code,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(card_id, month_key)
+DO UPDATE SET
+    amount_minor = excluded.amount_minor,
+    currency_code = excluded.currency_code,
+    updated_at_utc = excluded.updated_at_utc;
+
+-- name: GetCardMonthlyLimit :one
+SELECT id, card_id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM card_monthly_limits
+WHERE card_id = ?
+  AND month_key = ?;
+
+-- name: ListCardMonthlyLimitsByCard :many
+SELECT id, card_id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM card_monthly_limits
+WHERE card_id = ?
+ORDER BY month_key;
+
+-- name: SumActiveCardExpensesByMonthAndCurrency :one
+SELECT CAST(COALESCE(SUM(CASE WHEN t.refund_of_transaction_id IS NULL THEN t.amount_minor ELSE -t.amount_minor END), 0) AS INTEGER) AS total_amount_minor
+FROM transactions t
+INNER JOIN transaction_payment_methods pm ON pm.transaction_id = t.id
+WHERE t.type = 'expense'
+  AND t.deleted_at_utc IS NULL
+  AND pm.method_type = 'card'
+  AND pm.card_id = ?
+  AND t.currency_code = ?
+  AND t.transaction_date_utc >= ?
+  AND t.transaction_date_utc < ?;
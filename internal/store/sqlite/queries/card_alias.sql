@@ -0,0 +1,44 @@
+-- name: CreateCardAlias :execresult
+INSERT INTO card_aliases (
+    card_id,
+    alias
+) VALUES (?, ?);
+
+-- name: GetCardAliasByID :one
+SELECT id, card_id, alias, created_at_utc
+FROM card_aliases
+WHERE id = ?;
+
+-- name: GetCardAliasByCardAndAlias :one
+SELECT id, card_id, alias, created_at_utc
+FROM card_aliases
+WHERE card_id = ?
+  AND lower(alias) = lower(sqlc.arg(alias));
+
+-- name: ListCardAliases :many
+SELECT a.id, a.card_id, a.alias, a.created_at_utc
+FROM card_aliases a
+JOIN cards c ON c.id = a.card_id
+WHERE c.deleted_at_utc IS NULL
+  AND (sqlc.narg(card_id) IS NULL OR a.card_id = sqlc.narg(card_id))
+ORDER BY a.card_id, lower(a.alias), a.id;
+
+-- name: ListActiveCardsByNicknameOrAlias :many
+SELECT c.id, c.nickname, c.description, c.last4, c.brand, c.card_type, c.due_day, c.created_at_utc, c.updated_at_utc, c.deleted_at_utc, c.archived_at_utc, c.default_currency_code, c.fx_fee_bp
+FROM cards c
+WHERE c.deleted_at_utc IS NULL
+  AND (sqlc.arg(include_archived) = 1 OR c.archived_at_utc IS NULL)
+  AND (
+      lower(c.nickname) = lower(sqlc.arg(name))
+      OR EXISTS (
+          SELECT 1
+          FROM card_aliases a
+          WHERE a.card_id = c.id
+            AND lower(a.alias) = lower(sqlc.arg(name))
+      )
+  )
+ORDER BY lower(c.nickname), c.id;
+
+-- name: DeleteCardAlias :execresult
+DELETE FROM card_aliases
+WHERE id = ?;
@@ -2,13 +2,19 @@
 INSERT INTO categories (name) VALUES (?);
 
 -- name: ListActiveCategories :many
-SELECT id, name, created_at_utc, updated_at_utc
-FROM categories
-WHERE deleted_at_utc IS NULL
-ORDER BY lower(name), id;
+WITH params AS (SELECT CAST(sqlc.arg(sort_key) AS TEXT) AS sort_key)
+SELECT c.id, c.name, c.created_at_utc, c.updated_at_utc, c.archived_at_utc, c.color, c.icon
+FROM categories c
+CROSS JOIN params
+WHERE c.deleted_at_utc IS NULL
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN c.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN c.id END,
+    lower(c.name),
+    c.id;
 
 -- name: GetActiveCategoryByID :one
-SELECT id, name, created_at_utc, updated_at_utc
+SELECT id, name, created_at_utc, updated_at_utc, archived_at_utc, color, icon
 FROM categories
 WHERE id = ? AND deleted_at_utc IS NULL;
 
@@ -17,6 +23,18 @@ UPDATE categories
 SET name = ?, updated_at_utc = ?
 WHERE id = ? AND deleted_at_utc IS NULL;
 
+-- name: SetActiveCategoryArchivedAt :execresult
+UPDATE categories
+SET archived_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: UpdateActiveCategoryAppearance :execresult
+UPDATE categories
+SET color = CASE WHEN sqlc.arg(set_color) = 1 THEN sqlc.narg(color) ELSE color END,
+    icon = CASE WHEN sqlc.arg(set_icon) = 1 THEN sqlc.narg(icon) ELSE icon END,
+    updated_at_utc = sqlc.arg(updated_at_utc)
+WHERE id = sqlc.arg(id) AND deleted_at_utc IS NULL;
+
 -- name: SoftDeleteCategory :execresult
 UPDATE categories
 SET deleted_at_utc = ?, updated_at_utc = ?
@@ -0,0 +1,37 @@
+-- name: ListCategoryUsage :many
+SELECT c.id,
+       c.name,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at_utc IS NULL
+WHERE c.deleted_at_utc IS NULL
+  AND c.archived_at_utc IS NULL
+GROUP BY c.id, c.name
+ORDER BY lower(c.name), c.id;
+
+-- name: ListLabelUsage :many
+SELECT l.id,
+       l.name,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM labels l
+LEFT JOIN transaction_labels tl ON tl.label_id = l.id AND tl.deleted_at_utc IS NULL
+LEFT JOIN transactions t ON t.id = tl.transaction_id AND t.deleted_at_utc IS NULL
+WHERE l.deleted_at_utc IS NULL
+  AND l.archived_at_utc IS NULL
+GROUP BY l.id, l.name
+ORDER BY lower(l.name), l.id;
+
+-- name: ListCardUsage :many
+SELECT c.id,
+       c.nickname,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM cards c
+LEFT JOIN transaction_payment_methods pm ON pm.card_id = c.id
+LEFT JOIN transactions t ON t.id = pm.transaction_id AND t.deleted_at_utc IS NULL
+WHERE c.deleted_at_utc IS NULL
+  AND c.archived_at_utc IS NULL
+GROUP BY c.id, c.nickname
+ORDER BY lower(c.nickname), c.id;
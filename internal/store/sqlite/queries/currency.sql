@@ -0,0 +1,43 @@
+-- name: UpsertCustomCurrency :execresult
+INSERT INTO custom_currencies (
+    code,
+    name,
+    minor_unit,
+    updated_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(code) DO UPDATE SET
+    name = excluded.name,
+    minor_unit = excluded.minor_unit,
+    updated_at_utc = excluded.updated_at_utc;
+
+-- name: GetCustomCurrency :one
+SELECT code, name, minor_unit, created_at_utc, updated_at_utc
+FROM custom_currencies
+WHERE code = ?;
+
+-- name: ListCustomCurrencies :many
+SELECT code, name, minor_unit, created_at_utc, updated_at_utc
+FROM custom_currencies
+ORDER BY code;
+
+-- name: DeleteCustomCurrency :execresult
+DELETE FROM custom_currencies
+WHERE code = ?;
+
+-- name: ExistsStoredAmountByCurrency :one
+SELECT CAST(EXISTS(SELECT 1 FROM transactions t WHERE t.currency_code = sqlc.arg(code) OR t.original_currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM monthly_caps mc WHERE mc.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM monthly_cap_changes mcc WHERE mcc.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM monthly_category_caps mcat WHERE mcat.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM card_monthly_limits cml WHERE cml.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM cards c WHERE c.default_currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM credit_liability_events cle WHERE cle.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM savings_events se WHERE se.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM scheduled_payments sp WHERE sp.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM orphan_spending_thresholds ost WHERE ost.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM settlements s WHERE s.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM net_worth_snapshots nws WHERE nws.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM assets a WHERE a.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM loans l WHERE l.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM envelopes e WHERE e.currency_code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM settings st WHERE st.default_currency_code = sqlc.arg(code)) AS INTEGER) AS in_use;
@@ -6,16 +6,31 @@ INSERT INTO transactions (
     transaction_date_utc,
     category_id,
     bank_account_id,
-    note
-) VALUES (?, ?, ?, ?, ?, ?, ?);
+    refund_of_transaction_id,
+    note,
+    payee,
+    recorded_by,
+    uid,
+    income_source,
+    location,
+    original_amount_minor,
+    original_currency_code,
+    fx_rate,
+    fx_rate_date
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetActiveEntryByID :one
-SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
 FROM transactions
 WHERE id = ? AND deleted_at_utc IS NULL;
 
--- name: ListActiveEntries :many
-SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+-- name: GetActiveEntryByUID :one
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
+FROM transactions
+WHERE uid = ? AND deleted_at_utc IS NULL;
+
+-- name: ListActiveEntriesPage :many
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
 FROM transactions
 WHERE deleted_at_utc IS NULL
   AND (sqlc.narg(entry_type) IS NULL OR type = sqlc.narg(entry_type))
@@ -24,7 +39,24 @@ WHERE deleted_at_utc IS NULL
   AND (sqlc.narg(date_from_utc) IS NULL OR transaction_date_utc >= sqlc.narg(date_from_utc))
   AND (sqlc.narg(date_to_utc) IS NULL OR transaction_date_utc <= sqlc.narg(date_to_utc))
   AND (sqlc.narg(note_contains) IS NULL OR (note IS NOT NULL AND instr(lower(note), lower(sqlc.narg(note_contains))) > 0))
-ORDER BY transaction_date_utc, id;
+  AND (sqlc.narg(payee) IS NULL OR payee = sqlc.narg(payee) COLLATE NOCASE)
+  AND (sqlc.narg(currency_code) IS NULL OR currency_code = sqlc.narg(currency_code))
+  AND (sqlc.narg(amount_min_minor) IS NULL OR amount_minor >= sqlc.narg(amount_min_minor))
+  AND (sqlc.narg(amount_max_minor) IS NULL OR amount_minor <= sqlc.narg(amount_max_minor))
+  AND (sqlc.narg(recorded_by) IS NULL OR recorded_by = sqlc.narg(recorded_by) COLLATE NOCASE)
+  AND (sqlc.narg(income_source) IS NULL OR income_source = sqlc.narg(income_source) COLLATE NOCASE)
+  AND (sqlc.narg(location_contains) IS NULL OR (location IS NOT NULL AND instr(lower(location), lower(sqlc.narg(location_contains))) > 0))
+  AND (transaction_date_utc, id) > (sqlc.arg(after_date_utc), CAST(sqlc.arg(after_id) AS INTEGER))
+ORDER BY transaction_date_utc, id
+LIMIT sqlc.arg(page_size);
+
+-- name: ListEntryMonthTotals :many
+SELECT month_key, currency_code, income_minor, expense_minor, entry_count
+FROM entry_month_totals
+WHERE (sqlc.narg(from_month) IS NULL OR month_key >= sqlc.narg(from_month))
+  AND (sqlc.narg(to_month) IS NULL OR month_key <= sqlc.narg(to_month))
+  AND (sqlc.narg(currency_code) IS NULL OR currency_code = sqlc.narg(currency_code))
+ORDER BY month_key, currency_code;
 
 -- name: SoftDeleteEntry :execresult
 UPDATE transactions
@@ -64,6 +96,30 @@ END,
     WHEN sqlc.arg(set_note) = 1 THEN sqlc.narg(note)
     ELSE note
 END,
+    payee = CASE
+    WHEN sqlc.arg(clear_payee) = 1 THEN NULL
+    WHEN sqlc.arg(set_payee) = 1 THEN sqlc.narg(payee)
+    ELSE payee
+END,
+    recorded_by = CASE
+    WHEN sqlc.arg(clear_recorded_by) = 1 THEN NULL
+    WHEN sqlc.arg(set_recorded_by) = 1 THEN sqlc.narg(recorded_by)
+    ELSE recorded_by
+END,
+    income_source = CASE
+    WHEN sqlc.arg(clear_income_source) = 1 THEN NULL
+    WHEN sqlc.arg(set_income_source) = 1 THEN sqlc.narg(income_source)
+    ELSE income_source
+END,
+    location = CASE
+    WHEN sqlc.arg(clear_location) = 1 THEN NULL
+    WHEN sqlc.arg(set_location) = 1 THEN sqlc.narg(location)
+    ELSE location
+END,
+    original_amount_minor = CASE WHEN sqlc.arg(clear_original) = 1 THEN NULL ELSE original_amount_minor END,
+    original_currency_code = CASE WHEN sqlc.arg(clear_original) = 1 THEN NULL ELSE original_currency_code END,
+    fx_rate = CASE WHEN sqlc.arg(clear_original) = 1 THEN NULL ELSE fx_rate END,
+    fx_rate_date = CASE WHEN sqlc.arg(clear_original) = 1 THEN NULL ELSE fx_rate_date END,
     updated_at_utc = sqlc.arg(updated_at_utc)
 WHERE id = sqlc.arg(id)
   AND deleted_at_utc IS NULL;
@@ -78,20 +134,23 @@ FROM transaction_labels
 WHERE transaction_id = ? AND deleted_at_utc IS NULL
 ORDER BY label_id;
 
--- name: ListActiveEntryLabelIDsForListFilter :many
+-- name: ListActiveEntryLabelIDsByTransactionIDs :many
+WITH params AS (SELECT CAST(sqlc.arg(transaction_ids_json) AS TEXT) AS transaction_ids_json)
 SELECT tl.transaction_id, tl.label_id
 FROM transaction_labels tl
-INNER JOIN transactions t ON t.id = tl.transaction_id
 WHERE tl.deleted_at_utc IS NULL
-  AND t.deleted_at_utc IS NULL
-  AND (sqlc.narg(entry_type) IS NULL OR t.type = sqlc.narg(entry_type))
-  AND (sqlc.narg(category_id) IS NULL OR t.category_id = sqlc.narg(category_id))
-  AND (sqlc.narg(bank_account_id) IS NULL OR t.bank_account_id = sqlc.narg(bank_account_id))
-  AND (sqlc.narg(date_from_utc) IS NULL OR t.transaction_date_utc >= sqlc.narg(date_from_utc))
-  AND (sqlc.narg(date_to_utc) IS NULL OR t.transaction_date_utc <= sqlc.narg(date_to_utc))
-  AND (sqlc.narg(note_contains) IS NULL OR (t.note IS NOT NULL AND instr(lower(t.note), lower(sqlc.narg(note_contains))) > 0))
+  AND tl.transaction_id IN (SELECT value FROM params, json_each(params.transaction_ids_json))
 ORDER BY tl.transaction_id, tl.label_id;
 
+-- name: ListEntryPaymentInfoByTransactionIDs :many
+WITH params AS (SELECT CAST(sqlc.arg(transaction_ids_json) AS TEXT) AS transaction_ids_json)
+SELECT pm.transaction_id, pm.method_type, pm.card_id, c.nickname AS card_nickname, c.description AS card_description, c.last4 AS card_last4, c.brand AS card_brand, c.card_type AS card_type,
+    COALESCE((SELECT group_concat(a.alias, char(10)) FROM card_aliases a WHERE a.card_id = c.id), '') AS card_aliases
+FROM transaction_payment_methods pm
+LEFT JOIN cards c ON c.id = pm.card_id AND c.deleted_at_utc IS NULL
+WHERE pm.transaction_id IN (SELECT value FROM params, json_each(params.transaction_ids_json))
+ORDER BY pm.transaction_id;
+
 -- name: SoftDeleteEntryLabelLinks :execresult
 UPDATE transaction_labels
 SET deleted_at_utc = ?
@@ -111,9 +170,54 @@ SELECT EXISTS(
     WHERE id = ? AND deleted_at_utc IS NULL
 );
 
+-- name: ExistsArchivedCategoryByID :one
+SELECT EXISTS(
+    SELECT 1
+    FROM categories
+    WHERE id = ? AND deleted_at_utc IS NULL AND archived_at_utc IS NOT NULL
+);
+
+-- name: ExistsArchivedLabelByID :one
+SELECT EXISTS(
+    SELECT 1
+    FROM labels
+    WHERE id = ? AND deleted_at_utc IS NULL AND archived_at_utc IS NOT NULL
+);
+
 -- name: ExistsActiveBankAccountByID :one
 SELECT EXISTS(
     SELECT 1
     FROM bank_accounts
     WHERE id = ? AND deleted_at_utc IS NULL
 );
+
+-- name: ListActiveEntryIDsByCurrency :many
+SELECT id
+FROM transactions
+WHERE currency_code = ? AND deleted_at_utc IS NULL
+ORDER BY id;
+
+-- name: SumActiveRefundsByTransactionID :one
+SELECT CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS total_amount_minor
+FROM transactions
+WHERE refund_of_transaction_id = sqlc.arg(refund_of_transaction_id)
+  AND id != sqlc.arg(exclude_id)
+  AND deleted_at_utc IS NULL;
+
+-- name: UpdateActiveEntriesCurrency :execresult
+UPDATE transactions
+SET currency_code = sqlc.arg(to_currency_code),
+    updated_at_utc = sqlc.arg(updated_at_utc)
+WHERE currency_code = sqlc.arg(from_currency_code)
+  AND deleted_at_utc IS NULL;
+
+-- name: UpdateCreditLiabilityEventsCurrency :execresult
+UPDATE credit_liability_events
+SET currency_code = sqlc.arg(to_currency_code)
+WHERE credit_liability_events.currency_code = sqlc.arg(from_currency_code)
+  AND reference_transaction_id IN (
+    SELECT t.id
+    FROM transactions t
+    WHERE t.currency_code = sqlc.arg(from_currency_code)
+      AND t.deleted_at_utc IS NULL
+);
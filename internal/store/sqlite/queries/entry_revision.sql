@@ -0,0 +1,16 @@
+-- name: CreateEntryRevision :execresult
+INSERT INTO transaction_revisions (transaction_id, revision, state_json, replaced_at_utc)
+SELECT sqlc.arg(transaction_id), COALESCE(MAX(revision), 0) + 1, sqlc.arg(state_json), sqlc.arg(replaced_at_utc)
+FROM transaction_revisions
+WHERE transaction_id = sqlc.arg(transaction_id);
+
+-- name: ListEntryRevisions :many
+SELECT id, transaction_id, revision, state_json, replaced_at_utc
+FROM transaction_revisions
+WHERE transaction_id = ?
+ORDER BY revision ASC;
+
+-- name: GetEntryRevision :one
+SELECT id, transaction_id, revision, state_json, replaced_at_utc
+FROM transaction_revisions
+WHERE transaction_id = ? AND revision = ?;
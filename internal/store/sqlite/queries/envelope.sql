@@ -0,0 +1,32 @@
+-- name: CreateEnvelope :execresult
+INSERT INTO envelopes (
+    name,
+    currency_code,
+    budget_minor,
+    start_date,
+    end_date,
+    label_id,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetEnvelopeByID :one
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE id = ?;
+
+-- name: GetActiveEnvelopeByID :one
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: ListEnvelopes :many
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE (sqlc.arg(include_deleted) = 1 OR deleted_at_utc IS NULL)
+ORDER BY start_date, id;
+
+-- name: SoftDeleteEnvelope :execresult
+UPDATE envelopes
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL;
@@ -24,3 +24,63 @@ INSERT INTO fx_rate_snapshots (
     is_estimate,
     fetched_at_utc
 ) VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: RefreshFXRateSnapshot :one
+UPDATE fx_rate_snapshots
+SET rate = ?,
+    fetched_at_utc = ?
+WHERE id = ?
+RETURNING id,
+          provider,
+          base_currency,
+          quote_currency,
+          rate,
+          rate_date,
+          is_estimate,
+          fetched_at_utc;
+
+-- name: GetFallbackFXRateSnapshot :one
+SELECT id,
+       provider,
+       base_currency,
+       quote_currency,
+       rate,
+       rate_date,
+       is_estimate,
+       fetched_at_utc
+FROM fx_rate_snapshots
+WHERE provider = ?
+  AND base_currency = ?
+  AND quote_currency = ?
+  AND rate_date <= ?
+ORDER BY rate_date DESC, fetched_at_utc DESC, id DESC
+LIMIT 1;
+
+-- name: GetLatestFXRateSnapshot :one
+SELECT id,
+       provider,
+       base_currency,
+       quote_currency,
+       rate,
+       rate_date,
+       is_estimate,
+       fetched_at_utc
+FROM fx_rate_snapshots
+WHERE provider = ?
+  AND base_currency = ?
+  AND quote_currency = ?
+  AND is_estimate = ?
+ORDER BY fetched_at_utc DESC, id DESC
+LIMIT 1;
+
+-- name: ListFXRateCachePairs :many
+SELECT provider,
+       base_currency,
+       quote_currency,
+       COUNT(*) AS snapshot_count,
+       MIN(rate_date) AS oldest_rate_date,
+       MAX(rate_date) AS newest_rate_date,
+       MAX(fetched_at_utc) AS last_fetched_at_utc
+FROM fx_rate_snapshots
+GROUP BY provider, base_currency, quote_currency
+ORDER BY base_currency, quote_currency, provider;
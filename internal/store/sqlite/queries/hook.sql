@@ -0,0 +1,27 @@
+-- name: CreateHook :execresult
+INSERT INTO hooks (
+    event,
+    kind,
+    target,
+    within_days
+) VALUES (?, ?, ?, ?);
+
+-- name: GetHookByID :one
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+WHERE id = ?;
+
+-- name: ListHooks :many
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+ORDER BY id;
+
+-- name: ListHooksByEvent :many
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+WHERE event = ?
+ORDER BY id;
+
+-- name: DeleteHook :execresult
+DELETE FROM hooks
+WHERE id = ?;
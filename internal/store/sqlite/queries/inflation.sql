@@ -0,0 +1,24 @@
+-- name: UpsertInflationIndexPoint :execresult
+INSERT INTO inflation_index_points (
+    currency_code,
+    index_date,
+    index_value,
+    updated_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(currency_code, index_date) DO UPDATE SET
+    index_value = excluded.index_value,
+    updated_at_utc = excluded.updated_at_utc;
+
+-- name: ListInflationIndexPoints :many
+SELECT id, currency_code, index_date, index_value, created_at_utc, updated_at_utc
+FROM inflation_index_points
+WHERE (sqlc.narg(currency_code) IS NULL OR currency_code = sqlc.narg(currency_code))
+ORDER BY currency_code, index_date;
+
+-- name: GetInflationIndexPointOnOrBefore :one
+SELECT id, currency_code, index_date, index_value, created_at_utc, updated_at_utc
+FROM inflation_index_points
+WHERE currency_code = ?
+  AND index_date <= ?
+ORDER BY index_date DESC
+LIMIT 1;
@@ -2,13 +2,19 @@
 INSERT INTO labels (name) VALUES (?);
 
 -- name: ListActiveLabels :many
-SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc
-FROM labels
-WHERE deleted_at_utc IS NULL
-ORDER BY lower(name), id;
+WITH params AS (SELECT CAST(sqlc.arg(sort_key) AS TEXT) AS sort_key)
+SELECT l.id, l.name, l.created_at_utc, l.updated_at_utc, l.deleted_at_utc, l.archived_at_utc, l.color, l.icon
+FROM labels l
+CROSS JOIN params
+WHERE l.deleted_at_utc IS NULL
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN l.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN l.id END,
+    lower(l.name),
+    l.id;
 
 -- name: GetActiveLabelByID :one
-SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, color, icon
 FROM labels
 WHERE id = ? AND deleted_at_utc IS NULL;
 
@@ -17,6 +23,18 @@ UPDATE labels
 SET name = ?, updated_at_utc = ?
 WHERE id = ? AND deleted_at_utc IS NULL;
 
+-- name: SetActiveLabelArchivedAt :execresult
+UPDATE labels
+SET archived_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: UpdateActiveLabelAppearance :execresult
+UPDATE labels
+SET color = CASE WHEN sqlc.arg(set_color) = 1 THEN sqlc.narg(color) ELSE color END,
+    icon = CASE WHEN sqlc.arg(set_icon) = 1 THEN sqlc.narg(icon) ELSE icon END,
+    updated_at_utc = sqlc.arg(updated_at_utc)
+WHERE id = sqlc.arg(id) AND deleted_at_utc IS NULL;
+
 -- name: SoftDeleteLabel :execresult
 UPDATE labels
 SET deleted_at_utc = ?, updated_at_utc = ?
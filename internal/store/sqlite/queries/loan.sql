@@ -0,0 +1,50 @@
+-- name: CreateLoan :execresult
+INSERT INTO loans (
+    name,
+    currency_code,
+    principal_minor,
+    annual_rate_bp,
+    term_months,
+    start_month,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLoanByID :one
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE id = ?;
+
+-- name: GetActiveLoanByID :one
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: ListLoans :many
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE (sqlc.arg(include_deleted) = 1 OR deleted_at_utc IS NULL)
+ORDER BY start_month, id;
+
+-- name: SoftDeleteLoan :execresult
+UPDATE loans
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: CreateLoanPayment :exec
+INSERT INTO loan_payments (
+    transaction_id,
+    loan_id
+) VALUES (?, ?);
+
+-- name: DeleteLoanPayment :execresult
+DELETE FROM loan_payments
+WHERE loan_id = ? AND transaction_id = ?;
+
+-- name: ListLoanPaymentEntries :many
+SELECT t.id, t.transaction_date_utc, t.amount_minor, t.currency_code, t.note
+FROM loan_payments lp
+JOIN transactions t ON t.id = lp.transaction_id
+WHERE lp.loan_id = ?
+  AND t.deleted_at_utc IS NULL
+ORDER BY t.transaction_date_utc, t.id;
@@ -0,0 +1,20 @@
+-- name: UpsertNetWorthSnapshot :exec
+INSERT INTO net_worth_snapshots (snapshot_date, currency_code, assets_minor, liabilities_minor, net_worth_minor, recorded_at_utc)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (snapshot_date, currency_code) DO UPDATE SET
+    assets_minor = excluded.assets_minor,
+    liabilities_minor = excluded.liabilities_minor,
+    net_worth_minor = excluded.net_worth_minor,
+    recorded_at_utc = excluded.recorded_at_utc;
+
+-- name: DeleteNetWorthSnapshotsByDate :exec
+DELETE FROM net_worth_snapshots
+WHERE snapshot_date = ?;
+
+-- name: ListNetWorthSnapshots :many
+SELECT snapshot_date, currency_code, assets_minor, liabilities_minor, net_worth_minor, recorded_at_utc
+FROM net_worth_snapshots
+WHERE (sqlc.narg(date_from) IS NULL OR snapshot_date >= sqlc.narg(date_from))
+  AND (sqlc.narg(date_to) IS NULL OR snapshot_date <= sqlc.narg(date_to))
+  AND (sqlc.narg(currency_code) IS NULL OR currency_code = sqlc.narg(currency_code))
+ORDER BY currency_code ASC, snapshot_date ASC;
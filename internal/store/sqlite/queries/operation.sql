@@ -0,0 +1,28 @@
+-- name: CreateOperation :execresult
+INSERT INTO operations (kind, status, params_json, total, updated_at_utc)
+VALUES (?, 'running', ?, ?, ?);
+
+-- name: GetOperation :one
+SELECT id, kind, status, params_json, total, done, checkpoint, last_error, created_at_utc, updated_at_utc, finished_at_utc
+FROM operations
+WHERE id = ?;
+
+-- name: ListOperations :many
+SELECT id, kind, status, params_json, total, done, checkpoint, last_error, created_at_utc, updated_at_utc, finished_at_utc
+FROM operations
+WHERE (sqlc.arg(status) = '' OR status = sqlc.arg(status))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateOperationProgress :execresult
+UPDATE operations
+SET total = ?, done = ?, checkpoint = ?, updated_at_utc = ?
+WHERE id = ? AND status = 'running';
+
+-- name: UpdateOperationStatus :execresult
+UPDATE operations
+SET status = sqlc.arg(status),
+    last_error = sqlc.arg(last_error),
+    finished_at_utc = sqlc.arg(finished_at_utc),
+    updated_at_utc = sqlc.arg(updated_at_utc)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
@@ -0,0 +1,25 @@
+-- name: GetDataVersion :one
+SELECT version
+FROM data_version
+WHERE id = 1;
+
+-- name: GetReportCacheEntry :one
+SELECT payload_json
+FROM report_cache
+WHERE cache_key = ? AND data_version = ?;
+
+-- name: UpsertReportCacheEntry :exec
+INSERT INTO report_cache (
+    cache_key,
+    data_version,
+    payload_json,
+    created_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(cache_key) DO UPDATE SET
+    data_version = excluded.data_version,
+    payload_json = excluded.payload_json,
+    created_at_utc = excluded.created_at_utc;
+
+-- name: DeleteStaleReportCacheEntries :exec
+DELETE FROM report_cache
+WHERE data_version <> ?;
@@ -0,0 +1,18 @@
+-- name: CreateReportSnapshot :execresult
+INSERT INTO report_snapshots (month_key, report_json)
+VALUES (?, ?);
+
+-- name: GetActiveReportSnapshotByMonth :one
+SELECT id, month_key, report_json, frozen_at_utc, deleted_at_utc
+FROM report_snapshots
+WHERE month_key = ? AND deleted_at_utc IS NULL;
+
+-- name: GetReportSnapshotByID :one
+SELECT id, month_key, report_json, frozen_at_utc, deleted_at_utc
+FROM report_snapshots
+WHERE id = ?;
+
+-- name: SoftDeleteReportSnapshotByMonth :execresult
+UPDATE report_snapshots
+SET deleted_at_utc = ?
+WHERE month_key = ? AND deleted_at_utc IS NULL;
@@ -0,0 +1,35 @@
+-- name: SearchEntriesByNote :many
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, note
+FROM transactions
+WHERE deleted_at_utc IS NULL
+  AND note IS NOT NULL
+  AND instr(lower(note), lower(sqlc.arg(search_text))) > 0
+ORDER BY transaction_date_utc DESC, id DESC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: SearchCategoriesByName :many
+SELECT id, name, archived_at_utc
+FROM categories
+WHERE deleted_at_utc IS NULL
+  AND instr(lower(name), lower(sqlc.arg(search_text))) > 0
+ORDER BY lower(name), id
+LIMIT sqlc.arg(limit_rows);
+
+-- name: SearchLabelsByName :many
+SELECT id, name, archived_at_utc
+FROM labels
+WHERE deleted_at_utc IS NULL
+  AND instr(lower(name), lower(sqlc.arg(search_text))) > 0
+ORDER BY lower(name), id
+LIMIT sqlc.arg(limit_rows);
+
+-- name: SearchCardsByText :many
+SELECT id, nickname, description, last4, card_type
+FROM cards
+WHERE deleted_at_utc IS NULL
+  AND (
+      instr(lower(nickname), lower(sqlc.arg(search_text))) > 0
+      OR (description IS NOT NULL AND instr(lower(description), lower(sqlc.arg(search_text))) > 0)
+  )
+ORDER BY lower(nickname), id
+LIMIT sqlc.arg(limit_rows);
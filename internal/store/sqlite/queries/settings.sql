@@ -6,14 +6,16 @@ INSERT INTO settings (
     orphan_count_threshold,
     orphan_spending_threshold_bps,
     onboarding_completed_at_utc,
+    amount_format,
     updated_at_utc
-) VALUES (1, ?, ?, ?, ?, ?, ?)
+) VALUES (1, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     default_currency_code = excluded.default_currency_code,
     display_timezone = excluded.display_timezone,
     orphan_count_threshold = excluded.orphan_count_threshold,
     orphan_spending_threshold_bps = excluded.orphan_spending_threshold_bps,
     onboarding_completed_at_utc = excluded.onboarding_completed_at_utc,
+    amount_format = excluded.amount_format,
     updated_at_utc = excluded.updated_at_utc;
 
 -- name: GetSettings :one
@@ -24,6 +26,79 @@ SELECT id,
        orphan_spending_threshold_bps,
        onboarding_completed_at_utc,
        created_at_utc,
-       updated_at_utc
+       updated_at_utc,
+       amount_format,
+       default_output,
+       default_card_id,
+       fiscal_month_start_day,
+       default_recorded_by,
+       auto_snapshot,
+       fx_cache_ttl_hours,
+       fx_stale_after_days,
+       report_cache
 FROM settings
 WHERE id = 1;
+
+-- name: UpdateSettingsPreferences :execresult
+UPDATE settings
+SET default_output = ?,
+    default_card_id = ?,
+    fiscal_month_start_day = ?,
+    default_recorded_by = ?,
+    auto_snapshot = ?,
+    fx_cache_ttl_hours = ?,
+    fx_stale_after_days = ?,
+    report_cache = ?,
+    updated_at_utc = ?
+WHERE id = 1;
+
+-- name: UpsertOrphanSpendingThreshold :execresult
+INSERT INTO orphan_spending_thresholds (
+    currency_code,
+    mode,
+    percent_bps,
+    amount_minor,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(currency_code) DO UPDATE SET
+    mode = excluded.mode,
+    percent_bps = excluded.percent_bps,
+    amount_minor = excluded.amount_minor,
+    updated_at_utc = excluded.updated_at_utc;
+
+-- name: ListOrphanSpendingThresholds :many
+SELECT currency_code, mode, percent_bps, amount_minor, created_at_utc, updated_at_utc
+FROM orphan_spending_thresholds
+ORDER BY currency_code;
+
+-- name: DeleteOrphanSpendingThreshold :execresult
+DELETE FROM orphan_spending_thresholds
+WHERE currency_code = ?;
+
+-- name: ListStrictWarningCodes :many
+SELECT code
+FROM strict_warning_codes
+ORDER BY code;
+
+-- name: DeleteStrictWarningCodes :exec
+DELETE FROM strict_warning_codes;
+
+-- name: InsertStrictWarningCode :exec
+INSERT INTO strict_warning_codes (code) VALUES (?);
+
+-- name: ListReportScheduleRuns :many
+SELECT job_name, period_key, file_path, last_run_at_utc
+FROM report_schedule_runs
+ORDER BY job_name;
+
+-- name: UpsertReportScheduleRun :exec
+INSERT INTO report_schedule_runs (
+    job_name,
+    period_key,
+    file_path,
+    last_run_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(job_name) DO UPDATE SET
+    period_key = excluded.period_key,
+    file_path = excluded.file_path,
+    last_run_at_utc = excluded.last_run_at_utc;
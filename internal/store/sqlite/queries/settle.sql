@@ -0,0 +1,47 @@
+-- name: AddEntrySplit :execresult
+INSERT INTO entry_splits (transaction_id, person, share_bps)
+VALUES (?, ?, ?);
+
+-- name: ListActiveEntrySplits :many
+SELECT id, transaction_id, person, share_bps, created_at_utc, deleted_at_utc
+FROM entry_splits
+WHERE transaction_id = ? AND deleted_at_utc IS NULL
+ORDER BY id;
+
+-- name: ListSharedExpenseShares :many
+SELECT t.id AS transaction_id,
+       t.recorded_by,
+       CAST(t.amount_minor - COALESCE((
+           SELECT SUM(r.amount_minor)
+           FROM transactions r
+           WHERE r.refund_of_transaction_id = t.id
+             AND r.deleted_at_utc IS NULL
+       ), 0) AS INTEGER) AS amount_minor,
+       t.currency_code,
+       s.person,
+       s.share_bps
+FROM entry_splits s
+INNER JOIN transactions t ON t.id = s.transaction_id
+WHERE s.deleted_at_utc IS NULL
+  AND t.deleted_at_utc IS NULL
+  AND t.type = 'expense'
+  AND t.refund_of_transaction_id IS NULL
+  AND t.recorded_by IS NOT NULL
+  AND (sqlc.narg(currency_code) IS NULL OR t.currency_code = sqlc.narg(currency_code))
+ORDER BY t.id, s.id;
+
+-- name: CreateSettlement :execresult
+INSERT INTO settlements (from_person, to_person, amount_minor, currency_code, settled_at_utc, note)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetSettlementByID :one
+SELECT id, from_person, to_person, amount_minor, currency_code, settled_at_utc, note, created_at_utc, deleted_at_utc
+FROM settlements
+WHERE id = ? AND deleted_at_utc IS NULL;
+
+-- name: ListActiveSettlements :many
+SELECT id, from_person, to_person, amount_minor, currency_code, settled_at_utc, note, created_at_utc, deleted_at_utc
+FROM settlements
+WHERE deleted_at_utc IS NULL
+  AND (sqlc.narg(currency_code) IS NULL OR currency_code = sqlc.narg(currency_code))
+ORDER BY settled_at_utc, id;
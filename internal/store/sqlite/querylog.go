@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"strings"
+	"time"
+
+	moderncsqlite "modernc.org/sqlite"
+)
+
+// QueryLogDriverName is the SQLite driver that logs every statement with its
+// duration at slog debug level. Open uses it when the default logger has
+// debug enabled.
+const QueryLogDriverName = "sqlite-querylog"
+
+func init() {
+	sql.Register(QueryLogDriverName, queryLogDriver{base: &moderncsqlite.Driver{}})
+}
+
+type queryLogDriver struct {
+	base driver.Driver
+}
+
+func (d queryLogDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &queryLogConn{Conn: conn}, nil
+}
+
+// queryLogConn forwards to the modernc connection, timing statements run
+// through ExecContext and QueryContext (the path sqlc queries take).
+type queryLogConn struct {
+	driver.Conn
+}
+
+func (c *queryLogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	started := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	logQuery(ctx, "exec", query, len(args), started, err)
+	return result, err
+}
+
+func (c *queryLogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	started := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	logQuery(ctx, "query", query, len(args), started, err)
+	return rows, err
+}
+
+func (c *queryLogConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *queryLogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *queryLogConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func logQuery(ctx context.Context, kind, query string, argCount int, started time.Time, err error) {
+	attrs := []any{
+		"kind", kind,
+		"query", compactQuery(query),
+		"args", argCount,
+		"duration_ms", float64(time.Since(started).Microseconds()) / 1000,
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err.Error())
+	}
+	slog.DebugContext(ctx, "sqlite statement", attrs...)
+}
+
+// compactQuery drops the sqlc name comment and folds whitespace so each
+// statement logs on one line.
+func compactQuery(query string) string {
+	lines := strings.Split(query, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "-- name:") {
+			continue
+		}
+		kept = append(kept, trimmed)
+	}
+	return strings.Join(strings.Fields(strings.Join(kept, " ")), " ")
+}
+
+func driverNameForLogger(ctx context.Context) string {
+	if slog.Default().Enabled(ctx, slog.LevelDebug) {
+		return QueryLogDriverName
+	}
+	return DriverName
+}
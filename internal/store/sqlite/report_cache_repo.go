@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+// ReportCacheRepo keeps generated reports in report_cache, each tagged with the
+// data_version it was built at.
+type ReportCacheRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewReportCacheRepo(db *sql.DB) *ReportCacheRepo {
+	return &ReportCacheRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// DataVersion returns the counter the data_version triggers bump on every
+// write to a user data table.
+func (r *ReportCacheRepo) DataVersion(ctx context.Context) (int64, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("get data version: db is nil")
+	}
+
+	version, err := r.queries.GetDataVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get data version: %w", err)
+	}
+	return version, nil
+}
+
+// Get returns the report cached under key at dataVersion, or
+// domain.ErrReportCacheMiss.
+func (r *ReportCacheRepo) Get(ctx context.Context, key string, dataVersion int64) (domain.CachedReport, error) {
+	if r.db == nil {
+		return domain.CachedReport{}, fmt.Errorf("get cached report: db is nil")
+	}
+
+	payload, err := r.queries.GetReportCacheEntry(ctx, queries.GetReportCacheEntryParams{
+		CacheKey:    key,
+		DataVersion: dataVersion,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CachedReport{}, domain.ErrReportCacheMiss
+		}
+		return domain.CachedReport{}, fmt.Errorf("get cached report: %w", err)
+	}
+
+	// UseNumber keeps warning details such as amounts exactly as they were.
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.UseNumber()
+	var cached domain.CachedReport
+	if err := decoder.Decode(&cached); err != nil {
+		return domain.CachedReport{}, fmt.Errorf("get cached report decode: %w", err)
+	}
+	return cached, nil
+}
+
+// Put stores report under key at dataVersion and drops entries built at any
+// other version, which can no longer be served.
+func (r *ReportCacheRepo) Put(ctx context.Context, key string, dataVersion int64, report domain.CachedReport) error {
+	if r.db == nil {
+		return fmt.Errorf("put cached report: db is nil")
+	}
+
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("put cached report encode: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("put cached report begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteStaleReportCacheEntries(ctx, dataVersion); err != nil {
+		return fmt.Errorf("put cached report prune: %w", err)
+	}
+	if err := qtx.UpsertReportCacheEntry(ctx, queries.UpsertReportCacheEntryParams{
+		CacheKey:     key,
+		DataVersion:  dataVersion,
+		PayloadJson:  string(payload),
+		CreatedAtUtc: time.Now().UTC().Format(time.RFC3339Nano),
+	}); err != nil {
+		return fmt.Errorf("put cached report: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("put cached report commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"boring-budget/internal/domain"
+)
+
+func TestReportCacheRepoTracksDataVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := openFXTestDB(t)
+	defer db.Close()
+
+	repo := NewReportCacheRepo(db)
+	initial, err := repo.DataVersion(ctx)
+	if err != nil {
+		t.Fatalf("data version: %v", err)
+	}
+
+	if _, err := NewCategoryRepo(db).Add(ctx, "Food"); err != nil {
+		t.Fatalf("add category: %v", err)
+	}
+	afterWrite, err := repo.DataVersion(ctx)
+	if err != nil {
+		t.Fatalf("data version after write: %v", err)
+	}
+	if afterWrite <= initial {
+		t.Fatalf("expected a write to bump the data version past %d, got %d", initial, afterWrite)
+	}
+
+	if _, err := NewFXRepo(db).CreateSnapshot(ctx, domain.FXRateSnapshotCreateInput{
+		Provider:      "frankfurter",
+		BaseCurrency:  "USD",
+		QuoteCurrency: "EUR",
+		Rate:          "0.92",
+		RateDate:      "2026-02-01",
+		FetchedAtUTC:  "2026-02-01T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("create fx snapshot: %v", err)
+	}
+	afterRate, err := repo.DataVersion(ctx)
+	if err != nil {
+		t.Fatalf("data version after fx snapshot: %v", err)
+	}
+	if afterRate <= afterWrite {
+		t.Fatalf("expected a new fx rate to bump the data version past %d, got %d", afterWrite, afterRate)
+	}
+	afterWrite = afterRate
+	if _, err := repo.Get(ctx, "missing", afterWrite); !errors.Is(err, domain.ErrReportCacheMiss) {
+		t.Fatalf("expected cache miss, got %v", err)
+	}
+
+	cached := domain.CachedReport{
+		Report: domain.Report{Grouping: "month"},
+		Warnings: []domain.Warning{{
+			Code:    domain.WarningCodeFXEstimateUsed,
+			Message: domain.FXEstimateWarningMessage,
+			Details: map[string]any{"amount_minor": int64(9007199254740993)},
+		}},
+	}
+	if err := repo.Put(ctx, "key", afterWrite, cached); err != nil {
+		t.Fatalf("put cached report: %v", err)
+	}
+	if version, err := repo.DataVersion(ctx); err != nil || version != afterWrite {
+		t.Fatalf("expected the cache to leave the version at %d, got %d (%v)", afterWrite, version, err)
+	}
+
+	got, err := repo.Get(ctx, "key", afterWrite)
+	if err != nil {
+		t.Fatalf("get cached report: %v", err)
+	}
+	if got.Report.Grouping != "month" || len(got.Warnings) != 1 {
+		t.Fatalf("unexpected cached report: %+v", got)
+	}
+	details, ok := got.Warnings[0].Details.(map[string]any)
+	if !ok || details["amount_minor"] != json.Number("9007199254740993") {
+		t.Fatalf("expected warning details to round-trip exactly, got %#v", got.Warnings[0].Details)
+	}
+
+	if err := repo.Put(ctx, "other", afterWrite+1, cached); err != nil {
+		t.Fatalf("put cached report at a newer version: %v", err)
+	}
+	if _, err := repo.Get(ctx, "key", afterWrite); !errors.Is(err, domain.ErrReportCacheMiss) {
+		t.Fatalf("expected entries of older versions to be pruned, got %v", err)
+	}
+}
+
+// dataVersionExemptTables never bump data_version: bookkeeping that reports
+// do not read, and entry_month_totals, which only changes with the entries
+// whose triggers already bump it.
+var dataVersionExemptTables = map[string]bool{
+	"goose_db_version":     true,
+	"data_version":         true,
+	"report_cache":         true,
+	"audit_events":         true,
+	"operations":           true,
+	"report_schedule_runs": true,
+	"entry_month_totals":   true,
+}
+
+func TestEveryUserTableBumpsDataVersion(t *testing.T) {
+	t.Parallel()
+
+	db := openFXTestDB(t)
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;`)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan table: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("close tables: %v", err)
+	}
+
+	for _, table := range tables {
+		if dataVersionExemptTables[table] {
+			continue
+		}
+		for _, event := range []string{"INSERT", "UPDATE", "DELETE"} {
+			var count int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master
+WHERE type = 'trigger' AND tbl_name = ? AND upper(sql) LIKE ? AND sql LIKE '%data_version%';`, table, "%AFTER "+event+" ON%").Scan(&count); err != nil {
+				t.Fatalf("count %s triggers on %s: %v", event, table, err)
+			}
+			if count == 0 {
+				t.Errorf("table %s has no %s trigger bumping data_version; add one or list it in dataVersionExemptTables", table, event)
+			}
+		}
+	}
+}
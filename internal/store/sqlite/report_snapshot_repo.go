@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type ReportSnapshotRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewReportSnapshotRepo(db *sql.DB) *ReportSnapshotRepo {
+	return &ReportSnapshotRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// Save stores report as the snapshot of monthKey. An existing snapshot is
+// only soft-deleted and replaced when replace is set.
+func (r *ReportSnapshotRepo) Save(ctx context.Context, monthKey string, report domain.Report, replace bool) (domain.ReportSnapshot, error) {
+	if r.db == nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot: db is nil")
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot encode: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	if _, err := qtx.GetActiveReportSnapshotByMonth(ctx, monthKey); err == nil {
+		if !replace {
+			return domain.ReportSnapshot{}, domain.ErrReportSnapshotExists
+		}
+		if _, err := qtx.SoftDeleteReportSnapshotByMonth(ctx, queries.SoftDeleteReportSnapshotByMonthParams{
+			DeletedAtUtc: sql.NullString{String: time.Now().UTC().Format(time.RFC3339Nano), Valid: true},
+			MonthKey:     monthKey,
+		}); err != nil {
+			return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot replace: %w", err)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot load current: %w", err)
+	}
+
+	result, err := qtx.CreateReportSnapshot(ctx, queries.CreateReportSnapshotParams{
+		MonthKey:   monthKey,
+		ReportJson: string(reportJSON),
+	})
+	if err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot insert: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot read id: %w", err)
+	}
+	row, err := qtx.GetReportSnapshotByID(ctx, id)
+	if err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot load: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("save report snapshot commit: %w", err)
+	}
+	return mapSQLCReportSnapshotToDomain(row)
+}
+
+func (r *ReportSnapshotRepo) Get(ctx context.Context, monthKey string) (domain.ReportSnapshot, error) {
+	if r.db == nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("get report snapshot: db is nil")
+	}
+
+	row, err := r.queries.GetActiveReportSnapshotByMonth(ctx, monthKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ReportSnapshot{}, domain.ErrReportSnapshotNotFound
+		}
+		return domain.ReportSnapshot{}, fmt.Errorf("get report snapshot: %w", err)
+	}
+	return mapSQLCReportSnapshotToDomain(row)
+}
+
+func mapSQLCReportSnapshotToDomain(row queries.ReportSnapshot) (domain.ReportSnapshot, error) {
+	var report domain.Report
+	if err := json.Unmarshal([]byte(row.ReportJson), &report); err != nil {
+		return domain.ReportSnapshot{}, fmt.Errorf("decode report snapshot %d: %w", row.ID, err)
+	}
+	return domain.ReportSnapshot{
+		ID:          row.ID,
+		MonthKey:    row.MonthKey,
+		Report:      report,
+		FrozenAtUTC: row.FrozenAtUtc,
+	}, nil
+}
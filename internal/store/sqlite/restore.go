@@ -0,0 +1,371 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"boring-budget/internal/domain"
+)
+
+const restoreSchema = "restore_backup"
+
+// RestoreTables copies the tables of groups out of the backup at backupPath
+// into db, leaving every other table alone. The backup is attached on a
+// dedicated connection and each table is emptied and refilled inside one
+// transaction, using the columns both schemas share so older backups still
+// restore. Foreign keys are switched off for the copy (so deleting entries
+// does not cascade into rows that are about to come back) and checked before
+// commit; any dangling reference rolls the whole restore back.
+func RestoreTables(ctx context.Context, db *sql.DB, backupPath string, groups []string) (domain.RestoreReport, error) {
+	if db == nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables: db is nil")
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables: %w", err)
+	}
+
+	var tables []string
+	for _, group := range groups {
+		for _, table := range domain.RestoreGroupTables(group) {
+			if !slices.Contains(tables, table) {
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables conn: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS "+restoreSchema+";", backupPath); err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables attach: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "DETACH DATABASE "+restoreSchema+";")
+	}()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF;"); err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables disable foreign keys: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA foreign_keys = ON;")
+	}()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables begin: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	counts := make([]domain.RestoreTableCount, len(tables))
+	for i := len(tables) - 1; i >= 0; i-- {
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM main.%q;", tables[i]))
+		if err != nil {
+			return domain.RestoreReport{}, fmt.Errorf("restore tables clear %q: %w", tables[i], err)
+		}
+		counts[i].Table = tables[i]
+		if counts[i].Deleted, err = result.RowsAffected(); err != nil {
+			return domain.RestoreReport{}, fmt.Errorf("restore tables clear %q: %w", tables[i], err)
+		}
+	}
+
+	for i, table := range tables {
+		columns, err := restoreSharedColumns(ctx, tx, table)
+		if err != nil {
+			return domain.RestoreReport{}, err
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		list := strings.Join(columns, ", ")
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO main.%q (%s) SELECT %s FROM %s.%q;", table, list, list, restoreSchema, table))
+		if err != nil {
+			return domain.RestoreReport{}, fmt.Errorf("restore tables copy %q: %w", table, err)
+		}
+		if counts[i].Restored, err = result.RowsAffected(); err != nil {
+			return domain.RestoreReport{}, fmt.Errorf("restore tables copy %q: %w", table, err)
+		}
+	}
+
+	if slices.Contains(tables, "transactions") {
+		// Schedule runs stay with the live schedules; like deleting the entry
+		// would, unlink the runs whose entry the backup does not have.
+		if _, err := tx.ExecContext(ctx, `UPDATE main.scheduled_payment_executions SET entry_id = NULL
+WHERE entry_id IS NOT NULL AND entry_id NOT IN (SELECT id FROM main.transactions);`); err != nil {
+			return domain.RestoreReport{}, fmt.Errorf("restore tables unlink schedule runs: %w", err)
+		}
+	}
+
+	if err := restoreForeignKeyCheck(ctx, tx); err != nil {
+		return domain.RestoreReport{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.RestoreReport{}, fmt.Errorf("restore tables commit: %w", err)
+	}
+
+	return domain.RestoreReport{Groups: groups, Tables: counts}, nil
+}
+
+// restoreSharedColumns returns the quoted columns table has in both the live
+// database and the backup. A backup that predates the table yields none.
+func restoreSharedColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	live, err := restoreTableColumns(ctx, tx, table, "main")
+	if err != nil {
+		return nil, err
+	}
+	backup, err := restoreTableColumns(ctx, tx, table, restoreSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]string, 0, len(live))
+	for _, column := range live {
+		if slices.Contains(backup, column) {
+			shared = append(shared, fmt.Sprintf("%q", column))
+		}
+	}
+	return shared, nil
+}
+
+func restoreTableColumns(ctx context.Context, tx *sql.Tx, table, schema string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM pragma_table_info(?, ?) ORDER BY cid;", table, schema)
+	if err != nil {
+		return nil, fmt.Errorf("restore tables inspect %s.%q: %w", schema, table, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("restore tables inspect %s.%q: %w", schema, table, err)
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("restore tables inspect %s.%q: %w", schema, table, err)
+	}
+	return columns, nil
+}
+
+func restoreForeignKeyCheck(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "PRAGMA main.foreign_key_check;")
+	if err != nil {
+		return fmt.Errorf("restore tables foreign key check: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var (
+			table  string
+			rowID  sql.NullInt64
+			parent string
+			fkID   int64
+		)
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return fmt.Errorf("restore tables foreign key check: %w", err)
+		}
+		return fmt.Errorf("%w: %s row %d references a missing %s row", domain.ErrRestoreForeignKeyViolation, table, rowID.Int64, parent)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("restore tables foreign key check: %w", err)
+	}
+	return nil
+}
+
+// DiffRestore compares live with backup to preview what a full restore would
+// change. Entries and cards are read through columns every schema version
+// has, so backups taken before later migrations can still be compared;
+// settings are compared column by column and a column only one side has
+// shows up with a null value on the other.
+func DiffRestore(ctx context.Context, live, backup *sql.DB) (domain.RestoreDiff, error) {
+	if live == nil || backup == nil {
+		return domain.RestoreDiff{}, fmt.Errorf("restore diff: db is nil")
+	}
+
+	diff := domain.RestoreDiff{
+		EntryMonths:  []domain.RestoreMonthDiff{},
+		CardsAdded:   []string{},
+		CardsRemoved: []string{},
+		Settings:     []domain.RestoreSettingDiff{},
+	}
+
+	currentMonths, err := restoreEntryMonths(ctx, live)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	backupMonths, err := restoreEntryMonths(ctx, backup)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	months := make([]string, 0, len(currentMonths)+len(backupMonths))
+	for month, count := range currentMonths {
+		diff.EntriesCurrent += count
+		months = append(months, month)
+	}
+	for month, count := range backupMonths {
+		diff.EntriesBackup += count
+		if _, ok := currentMonths[month]; !ok {
+			months = append(months, month)
+		}
+	}
+	slices.Sort(months)
+	for _, month := range months {
+		if currentMonths[month] == backupMonths[month] {
+			continue
+		}
+		diff.EntryMonths = append(diff.EntryMonths, domain.RestoreMonthDiff{
+			Month:   month,
+			Current: currentMonths[month],
+			Backup:  backupMonths[month],
+			Delta:   backupMonths[month] - currentMonths[month],
+		})
+	}
+
+	currentCards, err := restoreCardNicknames(ctx, live)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	backupCards, err := restoreCardNicknames(ctx, backup)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	for _, nickname := range backupCards {
+		if !slices.Contains(currentCards, nickname) {
+			diff.CardsAdded = append(diff.CardsAdded, nickname)
+		}
+	}
+	for _, nickname := range currentCards {
+		if !slices.Contains(backupCards, nickname) {
+			diff.CardsRemoved = append(diff.CardsRemoved, nickname)
+		}
+	}
+
+	currentSettings, err := restoreSettingsRow(ctx, live)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	backupSettings, err := restoreSettingsRow(ctx, backup)
+	if err != nil {
+		return domain.RestoreDiff{}, err
+	}
+	fields := make([]string, 0, len(currentSettings)+len(backupSettings))
+	for field := range currentSettings {
+		fields = append(fields, field)
+	}
+	for field := range backupSettings {
+		if _, ok := currentSettings[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	for _, field := range fields {
+		if currentSettings[field] == backupSettings[field] {
+			continue
+		}
+		diff.Settings = append(diff.Settings, domain.RestoreSettingDiff{
+			Field:   field,
+			Current: currentSettings[field],
+			Backup:  backupSettings[field],
+		})
+	}
+
+	return diff, nil
+}
+
+func restoreEntryMonths(ctx context.Context, db *sql.DB) (map[string]int64, error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT substr(transaction_date_utc, 1, 7) AS month_key, COUNT(*)
+FROM transactions
+WHERE deleted_at_utc IS NULL
+GROUP BY month_key;`)
+	if err != nil {
+		return nil, fmt.Errorf("restore diff entries: %w", err)
+	}
+	defer rows.Close()
+
+	months := map[string]int64{}
+	for rows.Next() {
+		var (
+			month string
+			count int64
+		)
+		if err := rows.Scan(&month, &count); err != nil {
+			return nil, fmt.Errorf("restore diff entries: %w", err)
+		}
+		months[month] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("restore diff entries: %w", err)
+	}
+	return months, nil
+}
+
+func restoreCardNicknames(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT nickname FROM cards WHERE deleted_at_utc IS NULL ORDER BY nickname COLLATE NOCASE, id;")
+	if err != nil {
+		return nil, fmt.Errorf("restore diff cards: %w", err)
+	}
+	defer rows.Close()
+
+	var nicknames []string
+	for rows.Next() {
+		var nickname string
+		if err := rows.Scan(&nickname); err != nil {
+			return nil, fmt.Errorf("restore diff cards: %w", err)
+		}
+		nicknames = append(nicknames, nickname)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("restore diff cards: %w", err)
+	}
+	return nicknames, nil
+}
+
+// restoreSettingsRow reads the settings row as column → value, leaving out
+// the id and timestamps. A database without settings yields an empty map.
+func restoreSettingsRow(ctx context.Context, db *sql.DB) (map[string]any, error) {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM settings WHERE id = 1;")
+	if err != nil {
+		return nil, fmt.Errorf("restore diff settings: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("restore diff settings: %w", err)
+	}
+	settings := map[string]any{}
+	if !rows.Next() {
+		return settings, rows.Err()
+	}
+
+	values := make([]any, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, fmt.Errorf("restore diff settings: %w", err)
+	}
+	for i, column := range columns {
+		switch column {
+		case "id", "created_at_utc", "updated_at_utc":
+			continue
+		}
+		if raw, ok := values[i].([]byte); ok {
+			values[i] = string(raw)
+		}
+		settings[column] = values[i]
+	}
+	return settings, rows.Err()
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type SearchRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewSearchRepo(db *sql.DB) *SearchRepo {
+	return &SearchRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// Search returns up to limit active entries, categories, labels and cards
+// whose text contains text, compared case-insensitively.
+func (r *SearchRepo) Search(ctx context.Context, text string, limit int64) (domain.SearchResult, error) {
+	if r.db == nil {
+		return domain.SearchResult{}, fmt.Errorf("search: db is nil")
+	}
+
+	result := domain.SearchResult{
+		Query:      text,
+		Entries:    []domain.SearchEntryMatch{},
+		Categories: []domain.SearchNameMatch{},
+		Labels:     []domain.SearchNameMatch{},
+		Cards:      []domain.SearchCardMatch{},
+	}
+
+	entryRows, err := r.queries.SearchEntriesByNote(ctx, queries.SearchEntriesByNoteParams{SearchText: text, LimitRows: limit})
+	if err != nil {
+		return domain.SearchResult{}, fmt.Errorf("search entries: %w", err)
+	}
+	for _, row := range entryRows {
+		result.Entries = append(result.Entries, domain.SearchEntryMatch{
+			ID:                 row.ID,
+			Type:               row.Type,
+			AmountMinor:        row.AmountMinor,
+			CurrencyCode:       row.CurrencyCode,
+			TransactionDateUTC: row.TransactionDateUtc,
+			Note:               row.Note.String,
+		})
+	}
+
+	categoryRows, err := r.queries.SearchCategoriesByName(ctx, queries.SearchCategoriesByNameParams{SearchText: text, LimitRows: limit})
+	if err != nil {
+		return domain.SearchResult{}, fmt.Errorf("search categories: %w", err)
+	}
+	for _, row := range categoryRows {
+		result.Categories = append(result.Categories, domain.SearchNameMatch{ID: row.ID, Name: row.Name, Archived: row.ArchivedAtUtc.Valid})
+	}
+
+	labelRows, err := r.queries.SearchLabelsByName(ctx, queries.SearchLabelsByNameParams{SearchText: text, LimitRows: limit})
+	if err != nil {
+		return domain.SearchResult{}, fmt.Errorf("search labels: %w", err)
+	}
+	for _, row := range labelRows {
+		result.Labels = append(result.Labels, domain.SearchNameMatch{ID: row.ID, Name: row.Name, Archived: row.ArchivedAtUtc.Valid})
+	}
+
+	cardRows, err := r.queries.SearchCardsByText(ctx, queries.SearchCardsByTextParams{SearchText: text, LimitRows: limit})
+	if err != nil {
+		return domain.SearchResult{}, fmt.Errorf("search cards: %w", err)
+	}
+	for _, row := range cardRows {
+		result.Cards = append(result.Cards, domain.SearchCardMatch{
+			ID:          row.ID,
+			Nickname:    row.Nickname,
+			Description: row.Description.String,
+			Last4:       row.Last4,
+			CardType:    row.CardType,
+		})
+	}
+
+	return result, nil
+}
@@ -44,6 +44,7 @@ func (r *SettingsRepo) Upsert(ctx context.Context, input domain.SettingsUpsertIn
 		OrphanCountThreshold:       normalized.OrphanCountThreshold,
 		OrphanSpendingThresholdBps: normalized.OrphanSpendingThresholdBPS,
 		OnboardingCompletedAtUtc:   onboarding,
+		AmountFormat:               normalized.AmountFormat,
 		UpdatedAtUtc:               nowUTC,
 	}); err != nil {
 		return domain.Settings{}, fmt.Errorf("upsert settings: %w", err)
@@ -68,6 +69,38 @@ func (r *SettingsRepo) Get(ctx context.Context) (domain.Settings, error) {
 	return mapSQLCSettingsToDomain(row), nil
 }
 
+// UpdatePreferences writes the settings that setup init leaves alone. Settings
+// must already exist.
+func (r *SettingsRepo) UpdatePreferences(ctx context.Context, preferences domain.SettingsPreferences) (domain.Settings, error) {
+	if r.db == nil {
+		return domain.Settings{}, fmt.Errorf("update settings preferences: db is nil")
+	}
+
+	result, err := r.queries.UpdateSettingsPreferences(ctx, queries.UpdateSettingsPreferencesParams{
+		DefaultOutput:       nullableStringPtr(preferences.DefaultOutput),
+		DefaultCardID:       nullableInt64Ptr(preferences.DefaultCardID),
+		FiscalMonthStartDay: preferences.FiscalMonthStartDay,
+		DefaultRecordedBy:   nullableStringPtr(preferences.DefaultRecordedBy),
+		AutoSnapshot:        boolAsInt64(preferences.AutoSnapshot),
+		FxCacheTtlHours:     preferences.FXCacheTTLHours,
+		FxStaleAfterDays:    preferences.FXStaleAfterDays,
+		ReportCache:         boolAsInt64(preferences.ReportCache),
+		UpdatedAtUtc:        time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("update settings preferences: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Settings{}, fmt.Errorf("update settings preferences rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.Settings{}, domain.ErrSettingsNotFound
+	}
+
+	return r.Get(ctx)
+}
+
 func mapSQLCSettingsToDomain(row queries.Setting) domain.Settings {
 	settings := domain.Settings{
 		ID:                         row.ID,
@@ -75,9 +108,27 @@ func mapSQLCSettingsToDomain(row queries.Setting) domain.Settings {
 		DisplayTimezone:            row.DisplayTimezone,
 		OrphanCountThreshold:       row.OrphanCountThreshold,
 		OrphanSpendingThresholdBPS: row.OrphanSpendingThresholdBps,
+		AmountFormat:               row.AmountFormat,
+		FiscalMonthStartDay:        row.FiscalMonthStartDay,
+		AutoSnapshot:               row.AutoSnapshot != 0,
+		FXCacheTTLHours:            row.FxCacheTtlHours,
+		FXStaleAfterDays:           row.FxStaleAfterDays,
+		ReportCache:                row.ReportCache != 0,
 		CreatedAtUTC:               row.CreatedAtUtc,
 		UpdatedAtUTC:               row.UpdatedAtUtc,
 	}
+	if row.DefaultOutput.Valid {
+		defaultOutput := row.DefaultOutput.String
+		settings.DefaultOutput = &defaultOutput
+	}
+	if row.DefaultCardID.Valid {
+		defaultCardID := row.DefaultCardID.Int64
+		settings.DefaultCardID = &defaultCardID
+	}
+	if row.DefaultRecordedBy.Valid {
+		defaultRecordedBy := row.DefaultRecordedBy.String
+		settings.DefaultRecordedBy = &defaultRecordedBy
+	}
 
 	if row.OnboardingCompletedAtUtc.Valid {
 		completedAt := row.OnboardingCompletedAtUtc.String
@@ -86,3 +137,199 @@ func mapSQLCSettingsToDomain(row queries.Setting) domain.Settings {
 
 	return settings
 }
+
+func (r *SettingsRepo) UpsertOrphanSpendingThreshold(ctx context.Context, input domain.OrphanSpendingThresholdInput) (domain.OrphanSpendingThreshold, error) {
+	if r.db == nil {
+		return domain.OrphanSpendingThreshold{}, fmt.Errorf("upsert orphan spending threshold: db is nil")
+	}
+
+	normalized, err := domain.NormalizeOrphanSpendingThresholdInput(input)
+	if err != nil {
+		return domain.OrphanSpendingThreshold{}, err
+	}
+
+	params := queries.UpsertOrphanSpendingThresholdParams{
+		CurrencyCode: normalized.CurrencyCode,
+		Mode:         normalized.Mode,
+		UpdatedAtUtc: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if normalized.PercentBPS > 0 {
+		params.PercentBps = sql.NullInt64{Int64: normalized.PercentBPS, Valid: true}
+	}
+	if normalized.AmountMinor > 0 {
+		params.AmountMinor = sql.NullInt64{Int64: normalized.AmountMinor, Valid: true}
+	}
+	if _, err := r.queries.UpsertOrphanSpendingThreshold(ctx, params); err != nil {
+		return domain.OrphanSpendingThreshold{}, fmt.Errorf("upsert orphan spending threshold: %w", err)
+	}
+
+	thresholds, err := r.ListOrphanSpendingThresholds(ctx)
+	if err != nil {
+		return domain.OrphanSpendingThreshold{}, err
+	}
+	for _, threshold := range thresholds {
+		if threshold.CurrencyCode == normalized.CurrencyCode {
+			return threshold, nil
+		}
+	}
+	return domain.OrphanSpendingThreshold{}, fmt.Errorf("upsert orphan spending threshold reload: %w", domain.ErrOrphanThresholdNotFound)
+}
+
+func (r *SettingsRepo) ListOrphanSpendingThresholds(ctx context.Context) ([]domain.OrphanSpendingThreshold, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list orphan spending thresholds: db is nil")
+	}
+
+	rows, err := r.queries.ListOrphanSpendingThresholds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list orphan spending thresholds: %w", err)
+	}
+
+	thresholds := make([]domain.OrphanSpendingThreshold, 0, len(rows))
+	for _, row := range rows {
+		thresholds = append(thresholds, mapSQLCOrphanSpendingThresholdToDomain(row))
+	}
+	return thresholds, nil
+}
+
+// DeleteOrphanSpendingThreshold removes a rule so the currency falls back to
+// the all-currencies rule or the settings percentage.
+func (r *SettingsRepo) DeleteOrphanSpendingThreshold(ctx context.Context, currencyCode string) error {
+	if r.db == nil {
+		return fmt.Errorf("delete orphan spending threshold: db is nil")
+	}
+
+	normalized, err := domain.NormalizeOrphanSpendingThresholdCurrency(currencyCode)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.queries.DeleteOrphanSpendingThreshold(ctx, normalized)
+	if err != nil {
+		return fmt.Errorf("delete orphan spending threshold: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete orphan spending threshold rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrOrphanThresholdNotFound
+	}
+	return nil
+}
+
+// ListStrictWarningCodes returns the warning codes the stored strict-warnings
+// policy escalates to failures.
+func (r *SettingsRepo) ListStrictWarningCodes(ctx context.Context) ([]string, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list strict warning codes: db is nil")
+	}
+
+	codes, err := r.queries.ListStrictWarningCodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list strict warning codes: %w", err)
+	}
+	if codes == nil {
+		codes = []string{}
+	}
+	return codes, nil
+}
+
+// ReplaceStrictWarningCodes stores codes as the whole strict-warnings policy;
+// an empty list turns it off.
+func (r *SettingsRepo) ReplaceStrictWarningCodes(ctx context.Context, codes []string) ([]string, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("replace strict warning codes: db is nil")
+	}
+
+	normalized, err := domain.NormalizeStrictWarningCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("replace strict warning codes begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteStrictWarningCodes(ctx); err != nil {
+		return nil, fmt.Errorf("replace strict warning codes delete: %w", err)
+	}
+	for _, code := range normalized {
+		if err := qtx.InsertStrictWarningCode(ctx, code); err != nil {
+			return nil, fmt.Errorf("replace strict warning codes insert: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("replace strict warning codes commit: %w", err)
+	}
+
+	return r.ListStrictWarningCodes(ctx)
+}
+
+// ListReportScheduleRuns returns the last successful run of every report
+// schedule job that has run at least once.
+func (r *SettingsRepo) ListReportScheduleRuns(ctx context.Context) ([]domain.ReportScheduleRun, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list report schedule runs: db is nil")
+	}
+
+	rows, err := r.queries.ListReportScheduleRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list report schedule runs: %w", err)
+	}
+
+	runs := make([]domain.ReportScheduleRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, domain.ReportScheduleRun{
+			JobName:      row.JobName,
+			PeriodKey:    row.PeriodKey,
+			FilePath:     row.FilePath,
+			LastRunAtUTC: row.LastRunAtUtc,
+		})
+	}
+	return runs, nil
+}
+
+// UpsertReportScheduleRun records run as the latest run of its job.
+func (r *SettingsRepo) UpsertReportScheduleRun(ctx context.Context, run domain.ReportScheduleRun) error {
+	if r.db == nil {
+		return fmt.Errorf("upsert report schedule run: db is nil")
+	}
+
+	if err := r.queries.UpsertReportScheduleRun(ctx, queries.UpsertReportScheduleRunParams{
+		JobName:      run.JobName,
+		PeriodKey:    run.PeriodKey,
+		FilePath:     run.FilePath,
+		LastRunAtUtc: run.LastRunAtUTC,
+	}); err != nil {
+		return fmt.Errorf("upsert report schedule run: %w", err)
+	}
+	return nil
+}
+
+func mapSQLCOrphanSpendingThresholdToDomain(row queries.OrphanSpendingThreshold) domain.OrphanSpendingThreshold {
+	threshold := domain.OrphanSpendingThreshold{
+		CurrencyCode: row.CurrencyCode,
+		Mode:         row.Mode,
+		Source:       domain.OrphanThresholdSourceCurrency,
+		CreatedAtUTC: row.CreatedAtUtc,
+		UpdatedAtUTC: row.UpdatedAtUtc,
+	}
+	if row.CurrencyCode == domain.OrphanSpendingThresholdAllCurrencies {
+		threshold.Source = domain.OrphanThresholdSourceAll
+	}
+	if row.PercentBps.Valid {
+		percent := row.PercentBps.Int64
+		threshold.PercentBPS = &percent
+	}
+	if row.AmountMinor.Valid {
+		amount := row.AmountMinor.Int64
+		threshold.AmountMinor = &amount
+	}
+	return threshold
+}
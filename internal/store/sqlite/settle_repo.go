@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"boring-budget/internal/domain"
+	queries "boring-budget/internal/store/sqlite/sqlc"
+)
+
+type SettleRepo struct {
+	db      *sql.DB
+	queries *queries.Queries
+}
+
+func NewSettleRepo(db *sql.DB) *SettleRepo {
+	return &SettleRepo{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ListSharedExpenseShares returns what each split person owes the payer of
+// every active shared expense, net of its refunds, optionally in one currency.
+func (r *SettleRepo) ListSharedExpenseShares(ctx context.Context, currencyCode string) ([]domain.SharedExpenseShare, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list shared expense shares: db is nil")
+	}
+
+	rows, err := r.queries.ListSharedExpenseShares(ctx, nullableString(currencyCode))
+	if err != nil {
+		return nil, fmt.Errorf("list shared expense shares: %w", err)
+	}
+
+	shares := make([]domain.SharedExpenseShare, 0, len(rows))
+	for _, row := range rows {
+		shares = append(shares, domain.SharedExpenseShare{
+			EntryID:      row.TransactionID,
+			Payer:        row.RecordedBy.String,
+			Person:       row.Person,
+			CurrencyCode: row.CurrencyCode,
+			AmountMinor:  domain.SplitShareMinor(row.AmountMinor, row.ShareBps),
+		})
+	}
+	return shares, nil
+}
+
+func (r *SettleRepo) ListSettlements(ctx context.Context, currencyCode string) ([]domain.Settlement, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("list settlements: db is nil")
+	}
+
+	rows, err := r.queries.ListActiveSettlements(ctx, nullableString(currencyCode))
+	if err != nil {
+		return nil, fmt.Errorf("list settlements: %w", err)
+	}
+
+	settlements := make([]domain.Settlement, 0, len(rows))
+	for _, row := range rows {
+		settlements = append(settlements, mapSQLCSettlementToDomain(row))
+	}
+	return settlements, nil
+}
+
+// AddSettlements records every settlement in one transaction.
+func (r *SettleRepo) AddSettlements(ctx context.Context, inputs []domain.SettlementInput) ([]domain.Settlement, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("add settlements: db is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("add settlements begin tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	qtx := r.queries.WithTx(tx)
+	settlements := make([]domain.Settlement, 0, len(inputs))
+	for _, input := range inputs {
+		result, err := qtx.CreateSettlement(ctx, queries.CreateSettlementParams{
+			FromPerson:   input.FromPerson,
+			ToPerson:     input.ToPerson,
+			AmountMinor:  input.AmountMinor,
+			CurrencyCode: input.CurrencyCode,
+			SettledAtUtc: input.SettledAtUTC,
+			Note:         nullableString(input.Note),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add settlement: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("add settlement read id: %w", err)
+		}
+		row, err := qtx.GetSettlementByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("add settlement load: %w", err)
+		}
+		settlements = append(settlements, mapSQLCSettlementToDomain(row))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("add settlements commit: %w", err)
+	}
+	return settlements, nil
+}
+
+func mapSQLCSettlementToDomain(row queries.Settlement) domain.Settlement {
+	return domain.Settlement{
+		ID:           row.ID,
+		FromPerson:   row.FromPerson,
+		ToPerson:     row.ToPerson,
+		AmountMinor:  row.AmountMinor,
+		CurrencyCode: row.CurrencyCode,
+		SettledAtUTC: row.SettledAtUtc,
+		Note:         row.Note.String,
+		CreatedAtUTC: row.CreatedAtUtc,
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: asset.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createAsset = `-- name: CreateAsset :execresult
+INSERT INTO assets (
+    name,
+    kind,
+    currency_code,
+    balance_minor,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateAssetParams struct {
+	Name         string         `json:"name"`
+	Kind         string         `json:"kind"`
+	CurrencyCode string         `json:"currency_code"`
+	BalanceMinor int64          `json:"balance_minor"`
+	Note         sql.NullString `json:"note"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+}
+
+func (q *Queries) CreateAsset(ctx context.Context, arg CreateAssetParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createAsset,
+		arg.Name,
+		arg.Kind,
+		arg.CurrencyCode,
+		arg.BalanceMinor,
+		arg.Note,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const getActiveAssetByID = `-- name: GetActiveAssetByID :one
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetActiveAssetByID(ctx context.Context, id int64) (Asset, error) {
+	row := q.db.QueryRowContext(ctx, getActiveAssetByID, id)
+	var i Asset
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.CurrencyCode,
+		&i.BalanceMinor,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const getAssetByID = `-- name: GetAssetByID :one
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE id = ?
+`
+
+func (q *Queries) GetAssetByID(ctx context.Context, id int64) (Asset, error) {
+	row := q.db.QueryRowContext(ctx, getAssetByID, id)
+	var i Asset
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.CurrencyCode,
+		&i.BalanceMinor,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const listAssets = `-- name: ListAssets :many
+SELECT id, name, kind, currency_code, balance_minor, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM assets
+WHERE (?1 = 1 OR deleted_at_utc IS NULL)
+  AND (?2 IS NULL OR kind = ?2)
+ORDER BY kind, lower(name), id
+`
+
+type ListAssetsParams struct {
+	IncludeDeleted interface{} `json:"include_deleted"`
+	Kind           interface{} `json:"kind"`
+}
+
+func (q *Queries) ListAssets(ctx context.Context, arg ListAssetsParams) ([]Asset, error) {
+	rows, err := q.db.QueryContext(ctx, listAssets, arg.IncludeDeleted, arg.Kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.CurrencyCode,
+			&i.BalanceMinor,
+			&i.Note,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+			&i.DeletedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteAsset = `-- name: SoftDeleteAsset :execresult
+UPDATE assets
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+type SoftDeleteAssetParams struct {
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) SoftDeleteAsset(ctx context.Context, arg SoftDeleteAssetParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, softDeleteAsset, arg.DeletedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
+
+const updateAssetByID = `-- name: UpdateAssetByID :execresult
+UPDATE assets
+SET name = CASE
+    WHEN ?1 = 1 THEN ?2
+    ELSE name
+END,
+    balance_minor = CASE
+    WHEN ?3 = 1 THEN ?4
+    ELSE balance_minor
+END,
+    note = CASE
+    WHEN ?5 = 1 THEN ?6
+    ELSE note
+END,
+    updated_at_utc = ?7
+WHERE id = ?8
+  AND deleted_at_utc IS NULL
+`
+
+type UpdateAssetByIDParams struct {
+	SetName         interface{}    `json:"set_name"`
+	Name            string         `json:"name"`
+	SetBalanceMinor interface{}    `json:"set_balance_minor"`
+	BalanceMinor    int64          `json:"balance_minor"`
+	SetNote         interface{}    `json:"set_note"`
+	Note            sql.NullString `json:"note"`
+	UpdatedAtUtc    string         `json:"updated_at_utc"`
+	ID              int64          `json:"id"`
+}
+
+func (q *Queries) UpdateAssetByID(ctx context.Context, arg UpdateAssetByIDParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateAssetByID,
+		arg.SetName,
+		arg.Name,
+		arg.SetBalanceMinor,
+		arg.BalanceMinor,
+		arg.SetNote,
+		arg.Note,
+		arg.UpdatedAtUtc,
+		arg.ID,
+	)
+}
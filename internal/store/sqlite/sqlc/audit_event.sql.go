@@ -0,0 +1,162 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: audit_event.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const existsAuditEvent = `-- name: ExistsAuditEvent :one
+SELECT EXISTS(
+    SELECT 1
+    FROM audit_events
+    WHERE created_at_utc = ?
+      AND entity_type = ?
+      AND entity_id = ?
+      AND action = ?
+      AND json(COALESCE(payload_json, '{}')) = json(?)
+)
+`
+
+type ExistsAuditEventParams struct {
+	CreatedAtUtc string      `json:"created_at_utc"`
+	EntityType   string      `json:"entity_type"`
+	EntityID     string      `json:"entity_id"`
+	Action       string      `json:"action"`
+	Json         interface{} `json:"json"`
+}
+
+func (q *Queries) ExistsAuditEvent(ctx context.Context, arg ExistsAuditEventParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, existsAuditEvent,
+		arg.CreatedAtUtc,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Action,
+		arg.Json,
+	)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getLatestAuditEventID = `-- name: GetLatestAuditEventID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS latest_id
+FROM audit_events
+`
+
+func (q *Queries) GetLatestAuditEventID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLatestAuditEventID)
+	var latest_id int64
+	err := row.Scan(&latest_id)
+	return latest_id, err
+}
+
+const insertAuditEvent = `-- name: InsertAuditEvent :execresult
+INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type InsertAuditEventParams struct {
+	Action       string         `json:"action"`
+	EntityType   string         `json:"entity_type"`
+	EntityID     string         `json:"entity_id"`
+	Source       string         `json:"source"`
+	PayloadJson  sql.NullString `json:"payload_json"`
+	CreatedAtUtc string         `json:"created_at_utc"`
+}
+
+func (q *Queries) InsertAuditEvent(ctx context.Context, arg InsertAuditEventParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, insertAuditEvent,
+		arg.Action,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Source,
+		arg.PayloadJson,
+		arg.CreatedAtUtc,
+	)
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, action, entity_type, entity_id, source, payload_json, created_at_utc
+FROM audit_events
+ORDER BY id
+`
+
+func (q *Queries) ListAuditEvents(ctx context.Context) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Source,
+			&i.PayloadJson,
+			&i.CreatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEventsAfterID = `-- name: ListAuditEventsAfterID :many
+SELECT id, action, entity_type, entity_id, source, payload_json, created_at_utc
+FROM audit_events
+WHERE id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListAuditEventsAfterIDParams struct {
+	ID    int64 `json:"id"`
+	Limit int64 `json:"limit"`
+}
+
+func (q *Queries) ListAuditEventsAfterID(ctx context.Context, arg ListAuditEventsAfterIDParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEventsAfterID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Source,
+			&i.PayloadJson,
+			&i.CreatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
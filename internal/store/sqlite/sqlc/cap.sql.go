@@ -41,16 +41,20 @@ INSERT INTO monthly_cap_changes (
     old_amount_minor,
     new_amount_minor,
     currency_code,
-    changed_at_utc
-) VALUES (?, ?, ?, ?, ?)
+    changed_at_utc,
+    category_id,
+    copied_from_month_key
+) VALUES (?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateMonthlyCapChangeParams struct {
-	MonthKey       string        `json:"month_key"`
-	OldAmountMinor sql.NullInt64 `json:"old_amount_minor"`
-	NewAmountMinor int64         `json:"new_amount_minor"`
-	CurrencyCode   string        `json:"currency_code"`
-	ChangedAtUtc   string        `json:"changed_at_utc"`
+	MonthKey           string         `json:"month_key"`
+	OldAmountMinor     sql.NullInt64  `json:"old_amount_minor"`
+	NewAmountMinor     int64          `json:"new_amount_minor"`
+	CurrencyCode       string         `json:"currency_code"`
+	ChangedAtUtc       string         `json:"changed_at_utc"`
+	CategoryID         sql.NullInt64  `json:"category_id"`
+	CopiedFromMonthKey sql.NullString `json:"copied_from_month_key"`
 }
 
 func (q *Queries) CreateMonthlyCapChange(ctx context.Context, arg CreateMonthlyCapChangeParams) (sql.Result, error) {
@@ -60,6 +64,36 @@ func (q *Queries) CreateMonthlyCapChange(ctx context.Context, arg CreateMonthlyC
 		arg.NewAmountMinor,
 		arg.CurrencyCode,
 		arg.ChangedAtUtc,
+		arg.CategoryID,
+		arg.CopiedFromMonthKey,
+	)
+}
+
+const createMonthlyCategoryCap = `-- name: CreateMonthlyCategoryCap :execresult
+INSERT INTO monthly_category_caps (
+    month_key,
+    category_id,
+    amount_minor,
+    currency_code,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateMonthlyCategoryCapParams struct {
+	MonthKey     string `json:"month_key"`
+	CategoryID   int64  `json:"category_id"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
+func (q *Queries) CreateMonthlyCategoryCap(ctx context.Context, arg CreateMonthlyCategoryCapParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createMonthlyCategoryCap,
+		arg.MonthKey,
+		arg.CategoryID,
+		arg.AmountMinor,
+		arg.CurrencyCode,
+		arg.UpdatedAtUtc,
 	)
 }
 
@@ -83,8 +117,34 @@ func (q *Queries) GetMonthlyCapByMonthKey(ctx context.Context, monthKey string)
 	return i, err
 }
 
+const getMonthlyCategoryCap = `-- name: GetMonthlyCategoryCap :one
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+WHERE month_key = ? AND category_id = ?
+`
+
+type GetMonthlyCategoryCapParams struct {
+	MonthKey   string `json:"month_key"`
+	CategoryID int64  `json:"category_id"`
+}
+
+func (q *Queries) GetMonthlyCategoryCap(ctx context.Context, arg GetMonthlyCategoryCapParams) (MonthlyCategoryCap, error) {
+	row := q.db.QueryRowContext(ctx, getMonthlyCategoryCap, arg.MonthKey, arg.CategoryID)
+	var i MonthlyCategoryCap
+	err := row.Scan(
+		&i.ID,
+		&i.MonthKey,
+		&i.CategoryID,
+		&i.AmountMinor,
+		&i.CurrencyCode,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+	)
+	return i, err
+}
+
 const listMonthlyCapChangesByMonthKey = `-- name: ListMonthlyCapChangesByMonthKey :many
-SELECT id, month_key, old_amount_minor, new_amount_minor, currency_code, changed_at_utc
+SELECT id, month_key, old_amount_minor, new_amount_minor, currency_code, changed_at_utc, category_id, copied_from_month_key
 FROM monthly_cap_changes
 WHERE month_key = ?
 ORDER BY changed_at_utc, id
@@ -106,6 +166,119 @@ func (q *Queries) ListMonthlyCapChangesByMonthKey(ctx context.Context, monthKey
 			&i.NewAmountMinor,
 			&i.CurrencyCode,
 			&i.ChangedAtUtc,
+			&i.CategoryID,
+			&i.CopiedFromMonthKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlyCaps = `-- name: ListMonthlyCaps :many
+SELECT id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_caps
+ORDER BY month_key
+`
+
+func (q *Queries) ListMonthlyCaps(ctx context.Context) ([]MonthlyCap, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlyCaps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MonthlyCap
+	for rows.Next() {
+		var i MonthlyCap
+		if err := rows.Scan(
+			&i.ID,
+			&i.MonthKey,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlyCategoryCaps = `-- name: ListMonthlyCategoryCaps :many
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+ORDER BY month_key, category_id
+`
+
+func (q *Queries) ListMonthlyCategoryCaps(ctx context.Context) ([]MonthlyCategoryCap, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlyCategoryCaps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MonthlyCategoryCap
+	for rows.Next() {
+		var i MonthlyCategoryCap
+		if err := rows.Scan(
+			&i.ID,
+			&i.MonthKey,
+			&i.CategoryID,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlyCategoryCapsByMonthKey = `-- name: ListMonthlyCategoryCapsByMonthKey :many
+SELECT id, month_key, category_id, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM monthly_category_caps
+WHERE month_key = ?
+ORDER BY category_id
+`
+
+func (q *Queries) ListMonthlyCategoryCapsByMonthKey(ctx context.Context, monthKey string) ([]MonthlyCategoryCap, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlyCategoryCapsByMonthKey, monthKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MonthlyCategoryCap
+	for rows.Next() {
+		var i MonthlyCategoryCap
+		if err := rows.Scan(
+			&i.ID,
+			&i.MonthKey,
+			&i.CategoryID,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +294,7 @@ func (q *Queries) ListMonthlyCapChangesByMonthKey(ctx context.Context, monthKey
 }
 
 const sumActiveExpensesByMonthAndCurrency = `-- name: SumActiveExpensesByMonthAndCurrency :one
-SELECT CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS total_amount_minor
+SELECT CAST(COALESCE(SUM(CASE WHEN refund_of_transaction_id IS NULL THEN amount_minor ELSE -amount_minor END), 0) AS INTEGER) AS total_amount_minor
 FROM transactions
 WHERE type = 'expense'
   AND deleted_at_utc IS NULL
@@ -143,6 +316,36 @@ func (q *Queries) SumActiveExpensesByMonthAndCurrency(ctx context.Context, arg S
 	return total_amount_minor, err
 }
 
+const sumActiveExpensesByMonthCategoryAndCurrency = `-- name: SumActiveExpensesByMonthCategoryAndCurrency :one
+SELECT CAST(COALESCE(SUM(CASE WHEN refund_of_transaction_id IS NULL THEN amount_minor ELSE -amount_minor END), 0) AS INTEGER) AS total_amount_minor
+FROM transactions
+WHERE type = 'expense'
+  AND deleted_at_utc IS NULL
+  AND category_id = ?
+  AND currency_code = ?
+  AND transaction_date_utc >= ?
+  AND transaction_date_utc < ?
+`
+
+type SumActiveExpensesByMonthCategoryAndCurrencyParams struct {
+	CategoryID           sql.NullInt64 `json:"category_id"`
+	CurrencyCode         string        `json:"currency_code"`
+	TransactionDateUtc   string        `json:"transaction_date_utc"`
+	TransactionDateUtc_2 string        `json:"transaction_date_utc_2"`
+}
+
+func (q *Queries) SumActiveExpensesByMonthCategoryAndCurrency(ctx context.Context, arg SumActiveExpensesByMonthCategoryAndCurrencyParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumActiveExpensesByMonthCategoryAndCurrency,
+		arg.CategoryID,
+		arg.CurrencyCode,
+		arg.TransactionDateUtc,
+		arg.TransactionDateUtc_2,
+	)
+	var total_amount_minor int64
+	err := row.Scan(&total_amount_minor)
+	return total_amount_minor, err
+}
+
 const updateMonthlyCapByMonthKey = `-- name: UpdateMonthlyCapByMonthKey :execresult
 UPDATE monthly_caps
 SET amount_minor = ?, currency_code = ?, updated_at_utc = ?
@@ -164,3 +367,27 @@ func (q *Queries) UpdateMonthlyCapByMonthKey(ctx context.Context, arg UpdateMont
 		arg.MonthKey,
 	)
 }
+
+const updateMonthlyCategoryCap = `-- name: UpdateMonthlyCategoryCap :execresult
+UPDATE monthly_category_caps
+SET amount_minor = ?, currency_code = ?, updated_at_utc = ?
+WHERE month_key = ? AND category_id = ?
+`
+
+type UpdateMonthlyCategoryCapParams struct {
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+	MonthKey     string `json:"month_key"`
+	CategoryID   int64  `json:"category_id"`
+}
+
+func (q *Queries) UpdateMonthlyCategoryCap(ctx context.Context, arg UpdateMonthlyCategoryCapParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateMonthlyCategoryCap,
+		arg.AmountMinor,
+		arg.CurrencyCode,
+		arg.UpdatedAtUtc,
+		arg.MonthKey,
+		arg.CategoryID,
+	)
+}
@@ -18,18 +18,22 @@ INSERT INTO cards (
     brand,
     card_type,
     due_day,
+    default_currency_code,
+    fx_fee_bp,
     updated_at_utc
-) VALUES (?, ?, ?, ?, ?, ?, ?)
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateCardParams struct {
-	Nickname     string         `json:"nickname"`
-	Description  sql.NullString `json:"description"`
-	Last4        string         `json:"last4"`
-	Brand        string         `json:"brand"`
-	CardType     string         `json:"card_type"`
-	DueDay       sql.NullInt64  `json:"due_day"`
-	UpdatedAtUtc string         `json:"updated_at_utc"`
+	Nickname            string         `json:"nickname"`
+	Description         sql.NullString `json:"description"`
+	Last4               string         `json:"last4"`
+	Brand               string         `json:"brand"`
+	CardType            string         `json:"card_type"`
+	DueDay              sql.NullInt64  `json:"due_day"`
+	DefaultCurrencyCode sql.NullString `json:"default_currency_code"`
+	FxFeeBp             sql.NullInt64  `json:"fx_fee_bp"`
+	UpdatedAtUtc        string         `json:"updated_at_utc"`
 }
 
 func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (sql.Result, error) {
@@ -40,6 +44,8 @@ func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (sql.Res
 		arg.Brand,
 		arg.CardType,
 		arg.DueDay,
+		arg.DefaultCurrencyCode,
+		arg.FxFeeBp,
 		arg.UpdatedAtUtc,
 	)
 }
@@ -52,8 +58,9 @@ INSERT INTO credit_liability_events (
     amount_minor_signed,
     reference_transaction_id,
     note,
+    fx_fee_minor,
     created_at_utc
-) VALUES (?, ?, ?, ?, ?, ?, ?)
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateCreditLiabilityEventParams struct {
@@ -63,6 +70,7 @@ type CreateCreditLiabilityEventParams struct {
 	AmountMinorSigned      int64          `json:"amount_minor_signed"`
 	ReferenceTransactionID sql.NullInt64  `json:"reference_transaction_id"`
 	Note                   sql.NullString `json:"note"`
+	FxFeeMinor             int64          `json:"fx_fee_minor"`
 	CreatedAtUtc           string         `json:"created_at_utc"`
 }
 
@@ -74,6 +82,7 @@ func (q *Queries) CreateCreditLiabilityEvent(ctx context.Context, arg CreateCred
 		arg.AmountMinorSigned,
 		arg.ReferenceTransactionID,
 		arg.Note,
+		arg.FxFeeMinor,
 		arg.CreatedAtUtc,
 	)
 }
@@ -134,7 +143,7 @@ func (q *Queries) ExistsTransactionByID(ctx context.Context, id int64) (int64, e
 }
 
 const getActiveCardByID = `-- name: GetActiveCardByID :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE id = ?
   AND deleted_at_utc IS NULL
@@ -154,12 +163,15 @@ func (q *Queries) GetActiveCardByID(ctx context.Context, id int64) (Card, error)
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
 		&i.DeletedAtUtc,
+		&i.ArchivedAtUtc,
+		&i.DefaultCurrencyCode,
+		&i.FxFeeBp,
 	)
 	return i, err
 }
 
 const getActiveCardByNickname = `-- name: GetActiveCardByNickname :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE lower(nickname) = lower(?)
   AND deleted_at_utc IS NULL
@@ -179,6 +191,9 @@ func (q *Queries) GetActiveCardByNickname(ctx context.Context, lower string) (Ca
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
 		&i.DeletedAtUtc,
+		&i.ArchivedAtUtc,
+		&i.DefaultCurrencyCode,
+		&i.FxFeeBp,
 	)
 	return i, err
 }
@@ -205,7 +220,7 @@ func (q *Queries) GetActiveCardDueByID(ctx context.Context, id int64) (GetActive
 }
 
 const getCardByID = `-- name: GetCardByID :one
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE id = ?
 `
@@ -224,6 +239,55 @@ func (q *Queries) GetCardByID(ctx context.Context, id int64) (Card, error) {
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
 		&i.DeletedAtUtc,
+		&i.ArchivedAtUtc,
+		&i.DefaultCurrencyCode,
+		&i.FxFeeBp,
+	)
+	return i, err
+}
+
+const getCardFXFeeTerms = `-- name: GetCardFXFeeTerms :one
+SELECT c.fx_fee_bp,
+       COALESCE(c.default_currency_code, (SELECT s.default_currency_code FROM settings s WHERE s.id = 1), 'USD') AS home_currency_code
+FROM cards c
+WHERE c.id = ?
+`
+
+type GetCardFXFeeTermsRow struct {
+	FxFeeBp          sql.NullInt64 `json:"fx_fee_bp"`
+	HomeCurrencyCode string        `json:"home_currency_code"`
+}
+
+func (q *Queries) GetCardFXFeeTerms(ctx context.Context, id int64) (GetCardFXFeeTermsRow, error) {
+	row := q.db.QueryRowContext(ctx, getCardFXFeeTerms, id)
+	var i GetCardFXFeeTermsRow
+	err := row.Scan(&i.FxFeeBp, &i.HomeCurrencyCode)
+	return i, err
+}
+
+const getCardMonthlyLimit = `-- name: GetCardMonthlyLimit :one
+SELECT id, card_id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM card_monthly_limits
+WHERE card_id = ?
+  AND month_key = ?
+`
+
+type GetCardMonthlyLimitParams struct {
+	CardID   int64  `json:"card_id"`
+	MonthKey string `json:"month_key"`
+}
+
+func (q *Queries) GetCardMonthlyLimit(ctx context.Context, arg GetCardMonthlyLimitParams) (CardMonthlyLimit, error) {
+	row := q.db.QueryRowContext(ctx, getCardMonthlyLimit, arg.CardID, arg.MonthKey)
+	var i CardMonthlyLimit
+	err := row.Scan(
+		&i.ID,
+		&i.CardID,
+		&i.MonthKey,
+		&i.AmountMinor,
+		&i.CurrencyCode,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
 	)
 	return i, err
 }
@@ -248,7 +312,7 @@ func (q *Queries) GetCreditLiabilityBalanceByCardAndCurrency(ctx context.Context
 }
 
 const getCreditLiabilityEventByID = `-- name: GetCreditLiabilityEventByID :one
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE id = ?
 `
@@ -265,6 +329,7 @@ func (q *Queries) GetCreditLiabilityEventByID(ctx context.Context, id int64) (Cr
 		&i.ReferenceTransactionID,
 		&i.Note,
 		&i.CreatedAtUtc,
+		&i.FxFeeMinor,
 	)
 	return i, err
 }
@@ -325,21 +390,130 @@ func (q *Queries) ListActiveCreditCardDues(ctx context.Context) ([]ListActiveCre
 	return items, nil
 }
 
+const listCardFXFeeCharges = `-- name: ListCardFXFeeCharges :many
+SELECT e.card_id, c.nickname AS card_nickname, e.currency_code, e.fx_fee_minor, t.transaction_date_utc
+FROM credit_liability_events e
+JOIN transactions t ON t.id = e.reference_transaction_id
+JOIN cards c ON c.id = e.card_id
+WHERE e.fx_fee_minor <> 0
+  AND t.deleted_at_utc IS NULL
+  AND t.transaction_date_utc >= ?1
+  AND t.transaction_date_utc <= ?2
+  AND (?3 IS NULL OR e.card_id = ?3)
+ORDER BY t.transaction_date_utc, e.id
+`
+
+type ListCardFXFeeChargesParams struct {
+	FromUtc string      `json:"from_utc"`
+	ToUtc   string      `json:"to_utc"`
+	CardID  interface{} `json:"card_id"`
+}
+
+type ListCardFXFeeChargesRow struct {
+	CardID             int64  `json:"card_id"`
+	CardNickname       string `json:"card_nickname"`
+	CurrencyCode       string `json:"currency_code"`
+	FxFeeMinor         int64  `json:"fx_fee_minor"`
+	TransactionDateUtc string `json:"transaction_date_utc"`
+}
+
+func (q *Queries) ListCardFXFeeCharges(ctx context.Context, arg ListCardFXFeeChargesParams) ([]ListCardFXFeeChargesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCardFXFeeCharges, arg.FromUtc, arg.ToUtc, arg.CardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCardFXFeeChargesRow
+	for rows.Next() {
+		var i ListCardFXFeeChargesRow
+		if err := rows.Scan(
+			&i.CardID,
+			&i.CardNickname,
+			&i.CurrencyCode,
+			&i.FxFeeMinor,
+			&i.TransactionDateUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardMonthlyLimitsByCard = `-- name: ListCardMonthlyLimitsByCard :many
+SELECT id, card_id, month_key, amount_minor, currency_code, created_at_utc, updated_at_utc
+FROM card_monthly_limits
+WHERE card_id = ?
+ORDER BY month_key
+`
+
+func (q *Queries) ListCardMonthlyLimitsByCard(ctx context.Context, cardID int64) ([]CardMonthlyLimit, error) {
+	rows, err := q.db.QueryContext(ctx, listCardMonthlyLimitsByCard, cardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CardMonthlyLimit
+	for rows.Next() {
+		var i CardMonthlyLimit
+		if err := rows.Scan(
+			&i.ID,
+			&i.CardID,
+			&i.MonthKey,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCards = `-- name: ListCards :many
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
-FROM cards
-WHERE (?1 = 1 OR deleted_at_utc IS NULL)
-  AND (?2 IS NULL OR card_type = ?2)
-ORDER BY lower(nickname), id
+WITH params AS (SELECT CAST(?4 AS TEXT) AS sort_key)
+SELECT c.id, c.nickname, c.description, c.last4, c.brand, c.card_type, c.due_day, c.created_at_utc, c.updated_at_utc, c.deleted_at_utc, c.archived_at_utc, c.default_currency_code, c.fx_fee_bp
+FROM cards c
+CROSS JOIN params
+WHERE (?1 = 1 OR c.deleted_at_utc IS NULL)
+  AND (?2 = 1 OR c.archived_at_utc IS NULL)
+  AND (?3 IS NULL OR c.card_type = ?3)
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN c.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN c.id END,
+    CASE WHEN params.sort_key = 'due-day' THEN COALESCE(c.due_day, 99) END,
+    lower(c.nickname),
+    c.id
 `
 
 type ListCardsParams struct {
-	IncludeDeleted interface{} `json:"include_deleted"`
-	CardType       interface{} `json:"card_type"`
+	IncludeDeleted  interface{} `json:"include_deleted"`
+	IncludeArchived interface{} `json:"include_archived"`
+	CardType        interface{} `json:"card_type"`
+	SortKey         string      `json:"sort_key"`
 }
 
 func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]Card, error) {
-	rows, err := q.db.QueryContext(ctx, listCards, arg.IncludeDeleted, arg.CardType)
+	rows, err := q.db.QueryContext(ctx, listCards,
+		arg.IncludeDeleted,
+		arg.IncludeArchived,
+		arg.CardType,
+		arg.SortKey,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +532,9 @@ func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]Card, e
 			&i.CreatedAtUtc,
 			&i.UpdatedAtUtc,
 			&i.DeletedAtUtc,
+			&i.ArchivedAtUtc,
+			&i.DefaultCurrencyCode,
+			&i.FxFeeBp,
 		); err != nil {
 			return nil, err
 		}
@@ -373,7 +550,7 @@ func (q *Queries) ListCards(ctx context.Context, arg ListCardsParams) ([]Card, e
 }
 
 const listCreditLiabilityEventsByCard = `-- name: ListCreditLiabilityEventsByCard :many
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE card_id = ?
 ORDER BY currency_code, created_at_utc, id
@@ -397,6 +574,7 @@ func (q *Queries) ListCreditLiabilityEventsByCard(ctx context.Context, cardID in
 			&i.ReferenceTransactionID,
 			&i.Note,
 			&i.CreatedAtUtc,
+			&i.FxFeeMinor,
 		); err != nil {
 			return nil, err
 		}
@@ -412,7 +590,7 @@ func (q *Queries) ListCreditLiabilityEventsByCard(ctx context.Context, cardID in
 }
 
 const listCreditLiabilityEventsByCardAndCurrency = `-- name: ListCreditLiabilityEventsByCardAndCurrency :many
-SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
 FROM credit_liability_events
 WHERE card_id = ?
   AND currency_code = ?
@@ -442,6 +620,7 @@ func (q *Queries) ListCreditLiabilityEventsByCardAndCurrency(ctx context.Context
 			&i.ReferenceTransactionID,
 			&i.Note,
 			&i.CreatedAtUtc,
+			&i.FxFeeMinor,
 		); err != nil {
 			return nil, err
 		}
@@ -457,13 +636,20 @@ func (q *Queries) ListCreditLiabilityEventsByCardAndCurrency(ctx context.Context
 }
 
 const listCreditLiabilitySummaryAllCards = `-- name: ListCreditLiabilitySummaryAllCards :many
-SELECT card_id,
-       currency_code,
-       CAST(COALESCE(SUM(amount_minor_signed), 0) AS INTEGER) AS balance_minor,
-       MAX(created_at_utc) AS last_event_at_utc
-FROM credit_liability_events
-GROUP BY card_id, currency_code
-ORDER BY card_id, currency_code
+WITH params AS (SELECT CAST(?1 AS TEXT) AS sort_key)
+SELECT e.card_id,
+       e.currency_code,
+       CAST(COALESCE(SUM(e.amount_minor_signed), 0) AS INTEGER) AS balance_minor,
+       MAX(e.created_at_utc) AS last_event_at_utc
+FROM credit_liability_events e
+LEFT JOIN cards c ON c.id = e.card_id
+CROSS JOIN params
+GROUP BY e.card_id, e.currency_code
+ORDER BY
+    CASE WHEN params.sort_key = 'balance' THEN -SUM(e.amount_minor_signed) END,
+    CASE WHEN params.sort_key = 'nickname' THEN lower(c.nickname) END,
+    e.card_id,
+    e.currency_code
 `
 
 type ListCreditLiabilitySummaryAllCardsRow struct {
@@ -473,8 +659,8 @@ type ListCreditLiabilitySummaryAllCardsRow struct {
 	LastEventAtUtc interface{} `json:"last_event_at_utc"`
 }
 
-func (q *Queries) ListCreditLiabilitySummaryAllCards(ctx context.Context) ([]ListCreditLiabilitySummaryAllCardsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listCreditLiabilitySummaryAllCards)
+func (q *Queries) ListCreditLiabilitySummaryAllCards(ctx context.Context, sortKey string) ([]ListCreditLiabilitySummaryAllCardsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCreditLiabilitySummaryAllCards, sortKey)
 	if err != nil {
 		return nil, err
 	}
@@ -547,26 +733,74 @@ func (q *Queries) ListCreditLiabilitySummaryByCard(ctx context.Context, cardID i
 	return items, nil
 }
 
+const listUnreferencedCreditLiabilityEvents = `-- name: ListUnreferencedCreditLiabilityEvents :many
+SELECT id, card_id, currency_code, event_type, amount_minor_signed, reference_transaction_id, note, created_at_utc, fx_fee_minor
+FROM credit_liability_events
+WHERE reference_transaction_id IS NULL
+ORDER BY created_at_utc, id
+`
+
+func (q *Queries) ListUnreferencedCreditLiabilityEvents(ctx context.Context) ([]CreditLiabilityEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listUnreferencedCreditLiabilityEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CreditLiabilityEvent
+	for rows.Next() {
+		var i CreditLiabilityEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CardID,
+			&i.CurrencyCode,
+			&i.EventType,
+			&i.AmountMinorSigned,
+			&i.ReferenceTransactionID,
+			&i.Note,
+			&i.CreatedAtUtc,
+			&i.FxFeeMinor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchActiveCardsByLookup = `-- name: SearchActiveCardsByLookup :many
-SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, nickname, description, last4, brand, card_type, due_day, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, default_currency_code, fx_fee_bp
 FROM cards
 WHERE deleted_at_utc IS NULL
+  AND (?1 = 1 OR archived_at_utc IS NULL)
   AND (
-      instr(lower(nickname), lower(?1)) > 0
-      OR (description IS NOT NULL AND instr(lower(description), lower(?1)) > 0)
-      OR instr(last4, ?1) > 0
+      instr(lower(nickname), lower(?2)) > 0
+      OR (description IS NOT NULL AND instr(lower(description), lower(?2)) > 0)
+      OR instr(last4, ?2) > 0
+      OR EXISTS (
+          SELECT 1
+          FROM card_aliases a
+          WHERE a.card_id = cards.id
+            AND instr(lower(a.alias), lower(?2)) > 0
+      )
   )
 ORDER BY lower(nickname), id
-LIMIT ?2
+LIMIT ?3
 `
 
 type SearchActiveCardsByLookupParams struct {
-	LookupText string `json:"lookup_text"`
-	LimitRows  int64  `json:"limit_rows"`
+	IncludeArchived interface{} `json:"include_archived"`
+	LookupText      string      `json:"lookup_text"`
+	LimitRows       int64       `json:"limit_rows"`
 }
 
 func (q *Queries) SearchActiveCardsByLookup(ctx context.Context, arg SearchActiveCardsByLookupParams) ([]Card, error) {
-	rows, err := q.db.QueryContext(ctx, searchActiveCardsByLookup, arg.LookupText, arg.LimitRows)
+	rows, err := q.db.QueryContext(ctx, searchActiveCardsByLookup, arg.IncludeArchived, arg.LookupText, arg.LimitRows)
 	if err != nil {
 		return nil, err
 	}
@@ -585,6 +819,9 @@ func (q *Queries) SearchActiveCardsByLookup(ctx context.Context, arg SearchActiv
 			&i.CreatedAtUtc,
 			&i.UpdatedAtUtc,
 			&i.DeletedAtUtc,
+			&i.ArchivedAtUtc,
+			&i.DefaultCurrencyCode,
+			&i.FxFeeBp,
 		); err != nil {
 			return nil, err
 		}
@@ -599,6 +836,24 @@ func (q *Queries) SearchActiveCardsByLookup(ctx context.Context, arg SearchActiv
 	return items, nil
 }
 
+const setActiveCardArchivedAt = `-- name: SetActiveCardArchivedAt :execresult
+UPDATE cards
+SET archived_at_utc = ?,
+    updated_at_utc = ?
+WHERE id = ?
+  AND deleted_at_utc IS NULL
+`
+
+type SetActiveCardArchivedAtParams struct {
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+}
+
+func (q *Queries) SetActiveCardArchivedAt(ctx context.Context, arg SetActiveCardArchivedAtParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setActiveCardArchivedAt, arg.ArchivedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
+
 const softDeleteCard = `-- name: SoftDeleteCard :execresult
 UPDATE cards
 SET deleted_at_utc = ?,
@@ -617,6 +872,38 @@ func (q *Queries) SoftDeleteCard(ctx context.Context, arg SoftDeleteCardParams)
 	return q.db.ExecContext(ctx, softDeleteCard, arg.DeletedAtUtc, arg.UpdatedAtUtc, arg.ID)
 }
 
+const sumActiveCardExpensesByMonthAndCurrency = `-- name: SumActiveCardExpensesByMonthAndCurrency :one
+SELECT CAST(COALESCE(SUM(CASE WHEN t.refund_of_transaction_id IS NULL THEN t.amount_minor ELSE -t.amount_minor END), 0) AS INTEGER) AS total_amount_minor
+FROM transactions t
+INNER JOIN transaction_payment_methods pm ON pm.transaction_id = t.id
+WHERE t.type = 'expense'
+  AND t.deleted_at_utc IS NULL
+  AND pm.method_type = 'card'
+  AND pm.card_id = ?
+  AND t.currency_code = ?
+  AND t.transaction_date_utc >= ?
+  AND t.transaction_date_utc < ?
+`
+
+type SumActiveCardExpensesByMonthAndCurrencyParams struct {
+	CardID               sql.NullInt64 `json:"card_id"`
+	CurrencyCode         string        `json:"currency_code"`
+	TransactionDateUtc   string        `json:"transaction_date_utc"`
+	TransactionDateUtc_2 string        `json:"transaction_date_utc_2"`
+}
+
+func (q *Queries) SumActiveCardExpensesByMonthAndCurrency(ctx context.Context, arg SumActiveCardExpensesByMonthAndCurrencyParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumActiveCardExpensesByMonthAndCurrency,
+		arg.CardID,
+		arg.CurrencyCode,
+		arg.TransactionDateUtc,
+		arg.TransactionDateUtc_2,
+	)
+	var total_amount_minor int64
+	err := row.Scan(&total_amount_minor)
+	return total_amount_minor, err
+}
+
 const updateCardByID = `-- name: UpdateCardByID :execresult
 UPDATE cards
 SET nickname = CASE
@@ -645,28 +932,42 @@ END,
     WHEN ?13 = 1 THEN ?14
     ELSE due_day
 END,
-    updated_at_utc = ?15
-WHERE id = ?16
+    default_currency_code = CASE
+    WHEN ?15 = 1 THEN ?16
+    ELSE default_currency_code
+END,
+    fx_fee_bp = CASE
+    WHEN ?17 = 1 THEN ?18
+    ELSE fx_fee_bp
+END,
+    updated_at_utc = ?19
+WHERE id = ?20
   AND deleted_at_utc IS NULL
+  AND (?21 IS NULL OR updated_at_utc = ?21)
 `
 
 type UpdateCardByIDParams struct {
-	SetNickname      interface{}    `json:"set_nickname"`
-	Nickname         string         `json:"nickname"`
-	ClearDescription interface{}    `json:"clear_description"`
-	SetDescription   interface{}    `json:"set_description"`
-	Description      sql.NullString `json:"description"`
-	SetLast4         interface{}    `json:"set_last4"`
-	Last4            string         `json:"last4"`
-	SetBrand         interface{}    `json:"set_brand"`
-	Brand            string         `json:"brand"`
-	SetCardType      interface{}    `json:"set_card_type"`
-	CardType         string         `json:"card_type"`
-	ClearDueDay      interface{}    `json:"clear_due_day"`
-	SetDueDay        interface{}    `json:"set_due_day"`
-	DueDay           sql.NullInt64  `json:"due_day"`
-	UpdatedAtUtc     string         `json:"updated_at_utc"`
-	ID               int64          `json:"id"`
+	SetNickname            interface{}    `json:"set_nickname"`
+	Nickname               string         `json:"nickname"`
+	ClearDescription       interface{}    `json:"clear_description"`
+	SetDescription         interface{}    `json:"set_description"`
+	Description            sql.NullString `json:"description"`
+	SetLast4               interface{}    `json:"set_last4"`
+	Last4                  string         `json:"last4"`
+	SetBrand               interface{}    `json:"set_brand"`
+	Brand                  string         `json:"brand"`
+	SetCardType            interface{}    `json:"set_card_type"`
+	CardType               string         `json:"card_type"`
+	ClearDueDay            interface{}    `json:"clear_due_day"`
+	SetDueDay              interface{}    `json:"set_due_day"`
+	DueDay                 sql.NullInt64  `json:"due_day"`
+	SetDefaultCurrencyCode interface{}    `json:"set_default_currency_code"`
+	DefaultCurrencyCode    sql.NullString `json:"default_currency_code"`
+	SetFxFeeBp             interface{}    `json:"set_fx_fee_bp"`
+	FxFeeBp                sql.NullInt64  `json:"fx_fee_bp"`
+	UpdatedAtUtc           string         `json:"updated_at_utc"`
+	ID                     int64          `json:"id"`
+	ExpectedUpdatedAtUtc   interface{}    `json:"expected_updated_at_utc"`
 }
 
 func (q *Queries) UpdateCardByID(ctx context.Context, arg UpdateCardByIDParams) (sql.Result, error) {
@@ -685,8 +986,46 @@ func (q *Queries) UpdateCardByID(ctx context.Context, arg UpdateCardByIDParams)
 		arg.ClearDueDay,
 		arg.SetDueDay,
 		arg.DueDay,
+		arg.SetDefaultCurrencyCode,
+		arg.DefaultCurrencyCode,
+		arg.SetFxFeeBp,
+		arg.FxFeeBp,
 		arg.UpdatedAtUtc,
 		arg.ID,
+		arg.ExpectedUpdatedAtUtc,
+	)
+}
+
+const upsertCardMonthlyLimit = `-- name: UpsertCardMonthlyLimit :execresult
+INSERT INTO card_monthly_limits (
+    card_id,
+    month_key,
+    amount_minor,
+    currency_code,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(card_id, month_key)
+DO UPDATE SET
+    amount_minor = excluded.amount_minor,
+    currency_code = excluded.currency_code,
+    updated_at_utc = excluded.updated_at_utc
+`
+
+type UpsertCardMonthlyLimitParams struct {
+	CardID       int64  `json:"card_id"`
+	MonthKey     string `json:"month_key"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
+func (q *Queries) UpsertCardMonthlyLimit(ctx context.Context, arg UpsertCardMonthlyLimitParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, upsertCardMonthlyLimit,
+		arg.CardID,
+		arg.MonthKey,
+		arg.AmountMinor,
+		arg.CurrencyCode,
+		arg.UpdatedAtUtc,
 	)
 }
 
@@ -0,0 +1,174 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: card_alias.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createCardAlias = `-- name: CreateCardAlias :execresult
+INSERT INTO card_aliases (
+    card_id,
+    alias
+) VALUES (?, ?)
+`
+
+type CreateCardAliasParams struct {
+	CardID int64  `json:"card_id"`
+	Alias  string `json:"alias"`
+}
+
+func (q *Queries) CreateCardAlias(ctx context.Context, arg CreateCardAliasParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createCardAlias, arg.CardID, arg.Alias)
+}
+
+const deleteCardAlias = `-- name: DeleteCardAlias :execresult
+DELETE FROM card_aliases
+WHERE id = ?
+`
+
+func (q *Queries) DeleteCardAlias(ctx context.Context, id int64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteCardAlias, id)
+}
+
+const getCardAliasByCardAndAlias = `-- name: GetCardAliasByCardAndAlias :one
+SELECT id, card_id, alias, created_at_utc
+FROM card_aliases
+WHERE card_id = ?
+  AND lower(alias) = lower(?2)
+`
+
+type GetCardAliasByCardAndAliasParams struct {
+	CardID int64  `json:"card_id"`
+	Alias  string `json:"alias"`
+}
+
+func (q *Queries) GetCardAliasByCardAndAlias(ctx context.Context, arg GetCardAliasByCardAndAliasParams) (CardAlias, error) {
+	row := q.db.QueryRowContext(ctx, getCardAliasByCardAndAlias, arg.CardID, arg.Alias)
+	var i CardAlias
+	err := row.Scan(
+		&i.ID,
+		&i.CardID,
+		&i.Alias,
+		&i.CreatedAtUtc,
+	)
+	return i, err
+}
+
+const getCardAliasByID = `-- name: GetCardAliasByID :one
+SELECT id, card_id, alias, created_at_utc
+FROM card_aliases
+WHERE id = ?
+`
+
+func (q *Queries) GetCardAliasByID(ctx context.Context, id int64) (CardAlias, error) {
+	row := q.db.QueryRowContext(ctx, getCardAliasByID, id)
+	var i CardAlias
+	err := row.Scan(
+		&i.ID,
+		&i.CardID,
+		&i.Alias,
+		&i.CreatedAtUtc,
+	)
+	return i, err
+}
+
+const listActiveCardsByNicknameOrAlias = `-- name: ListActiveCardsByNicknameOrAlias :many
+SELECT c.id, c.nickname, c.description, c.last4, c.brand, c.card_type, c.due_day, c.created_at_utc, c.updated_at_utc, c.deleted_at_utc, c.archived_at_utc, c.default_currency_code, c.fx_fee_bp
+FROM cards c
+WHERE c.deleted_at_utc IS NULL
+  AND (?1 = 1 OR c.archived_at_utc IS NULL)
+  AND (
+      lower(c.nickname) = lower(?2)
+      OR EXISTS (
+          SELECT 1
+          FROM card_aliases a
+          WHERE a.card_id = c.id
+            AND lower(a.alias) = lower(?2)
+      )
+  )
+ORDER BY lower(c.nickname), c.id
+`
+
+type ListActiveCardsByNicknameOrAliasParams struct {
+	IncludeArchived interface{} `json:"include_archived"`
+	Name            string      `json:"name"`
+}
+
+func (q *Queries) ListActiveCardsByNicknameOrAlias(ctx context.Context, arg ListActiveCardsByNicknameOrAliasParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveCardsByNicknameOrAlias, arg.IncludeArchived, arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nickname,
+			&i.Description,
+			&i.Last4,
+			&i.Brand,
+			&i.CardType,
+			&i.DueDay,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+			&i.DeletedAtUtc,
+			&i.ArchivedAtUtc,
+			&i.DefaultCurrencyCode,
+			&i.FxFeeBp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardAliases = `-- name: ListCardAliases :many
+SELECT a.id, a.card_id, a.alias, a.created_at_utc
+FROM card_aliases a
+JOIN cards c ON c.id = a.card_id
+WHERE c.deleted_at_utc IS NULL
+  AND (?1 IS NULL OR a.card_id = ?1)
+ORDER BY a.card_id, lower(a.alias), a.id
+`
+
+func (q *Queries) ListCardAliases(ctx context.Context, cardID interface{}) ([]CardAlias, error) {
+	rows, err := q.db.QueryContext(ctx, listCardAliases, cardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CardAlias
+	for rows.Next() {
+		var i CardAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.CardID,
+			&i.Alias,
+			&i.CreatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -19,16 +19,19 @@ func (q *Queries) CreateCategory(ctx context.Context, name string) (sql.Result,
 }
 
 const getActiveCategoryByID = `-- name: GetActiveCategoryByID :one
-SELECT id, name, created_at_utc, updated_at_utc
+SELECT id, name, created_at_utc, updated_at_utc, archived_at_utc, color, icon
 FROM categories
 WHERE id = ? AND deleted_at_utc IS NULL
 `
 
 type GetActiveCategoryByIDRow struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	CreatedAtUtc string `json:"created_at_utc"`
-	UpdatedAtUtc string `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	Color         sql.NullString `json:"color"`
+	Icon          sql.NullString `json:"icon"`
 }
 
 func (q *Queries) GetActiveCategoryByID(ctx context.Context, id int64) (GetActiveCategoryByIDRow, error) {
@@ -39,26 +42,38 @@ func (q *Queries) GetActiveCategoryByID(ctx context.Context, id int64) (GetActiv
 		&i.Name,
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
+		&i.ArchivedAtUtc,
+		&i.Color,
+		&i.Icon,
 	)
 	return i, err
 }
 
 const listActiveCategories = `-- name: ListActiveCategories :many
-SELECT id, name, created_at_utc, updated_at_utc
-FROM categories
-WHERE deleted_at_utc IS NULL
-ORDER BY lower(name), id
+WITH params AS (SELECT CAST(?1 AS TEXT) AS sort_key)
+SELECT c.id, c.name, c.created_at_utc, c.updated_at_utc, c.archived_at_utc, c.color, c.icon
+FROM categories c
+CROSS JOIN params
+WHERE c.deleted_at_utc IS NULL
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN c.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN c.id END,
+    lower(c.name),
+    c.id
 `
 
 type ListActiveCategoriesRow struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	CreatedAtUtc string `json:"created_at_utc"`
-	UpdatedAtUtc string `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	Color         sql.NullString `json:"color"`
+	Icon          sql.NullString `json:"icon"`
 }
 
-func (q *Queries) ListActiveCategories(ctx context.Context) ([]ListActiveCategoriesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveCategories)
+func (q *Queries) ListActiveCategories(ctx context.Context, sortKey string) ([]ListActiveCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveCategories, sortKey)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +86,9 @@ func (q *Queries) ListActiveCategories(ctx context.Context) ([]ListActiveCategor
 			&i.Name,
 			&i.CreatedAtUtc,
 			&i.UpdatedAtUtc,
+			&i.ArchivedAtUtc,
+			&i.Color,
+			&i.Icon,
 		); err != nil {
 			return nil, err
 		}
@@ -116,6 +134,22 @@ func (q *Queries) RenameActiveCategory(ctx context.Context, arg RenameActiveCate
 	return q.db.ExecContext(ctx, renameActiveCategory, arg.Name, arg.UpdatedAtUtc, arg.ID)
 }
 
+const setActiveCategoryArchivedAt = `-- name: SetActiveCategoryArchivedAt :execresult
+UPDATE categories
+SET archived_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+type SetActiveCategoryArchivedAtParams struct {
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+}
+
+func (q *Queries) SetActiveCategoryArchivedAt(ctx context.Context, arg SetActiveCategoryArchivedAtParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setActiveCategoryArchivedAt, arg.ArchivedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
+
 const softDeleteCategory = `-- name: SoftDeleteCategory :execresult
 UPDATE categories
 SET deleted_at_utc = ?, updated_at_utc = ?
@@ -131,3 +165,31 @@ type SoftDeleteCategoryParams struct {
 func (q *Queries) SoftDeleteCategory(ctx context.Context, arg SoftDeleteCategoryParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, softDeleteCategory, arg.DeletedAtUtc, arg.UpdatedAtUtc, arg.ID)
 }
+
+const updateActiveCategoryAppearance = `-- name: UpdateActiveCategoryAppearance :execresult
+UPDATE categories
+SET color = CASE WHEN ?1 = 1 THEN ?2 ELSE color END,
+    icon = CASE WHEN ?3 = 1 THEN ?4 ELSE icon END,
+    updated_at_utc = ?5
+WHERE id = ?6 AND deleted_at_utc IS NULL
+`
+
+type UpdateActiveCategoryAppearanceParams struct {
+	SetColor     interface{}    `json:"set_color"`
+	Color        sql.NullString `json:"color"`
+	SetIcon      interface{}    `json:"set_icon"`
+	Icon         sql.NullString `json:"icon"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) UpdateActiveCategoryAppearance(ctx context.Context, arg UpdateActiveCategoryAppearanceParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateActiveCategoryAppearance,
+		arg.SetColor,
+		arg.Color,
+		arg.SetIcon,
+		arg.Icon,
+		arg.UpdatedAtUtc,
+		arg.ID,
+	)
+}
@@ -0,0 +1,156 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: cleanup.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const listCardUsage = `-- name: ListCardUsage :many
+SELECT c.id,
+       c.nickname,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM cards c
+LEFT JOIN transaction_payment_methods pm ON pm.card_id = c.id
+LEFT JOIN transactions t ON t.id = pm.transaction_id AND t.deleted_at_utc IS NULL
+WHERE c.deleted_at_utc IS NULL
+  AND c.archived_at_utc IS NULL
+GROUP BY c.id, c.nickname
+ORDER BY lower(c.nickname), c.id
+`
+
+type ListCardUsageRow struct {
+	ID            int64       `json:"id"`
+	Nickname      string      `json:"nickname"`
+	EntryCount    int64       `json:"entry_count"`
+	LastUsedAtUtc interface{} `json:"last_used_at_utc"`
+}
+
+func (q *Queries) ListCardUsage(ctx context.Context) ([]ListCardUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCardUsage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCardUsageRow
+	for rows.Next() {
+		var i ListCardUsageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nickname,
+			&i.EntryCount,
+			&i.LastUsedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryUsage = `-- name: ListCategoryUsage :many
+SELECT c.id,
+       c.name,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at_utc IS NULL
+WHERE c.deleted_at_utc IS NULL
+  AND c.archived_at_utc IS NULL
+GROUP BY c.id, c.name
+ORDER BY lower(c.name), c.id
+`
+
+type ListCategoryUsageRow struct {
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	EntryCount    int64       `json:"entry_count"`
+	LastUsedAtUtc interface{} `json:"last_used_at_utc"`
+}
+
+func (q *Queries) ListCategoryUsage(ctx context.Context) ([]ListCategoryUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoryUsage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCategoryUsageRow
+	for rows.Next() {
+		var i ListCategoryUsageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.EntryCount,
+			&i.LastUsedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabelUsage = `-- name: ListLabelUsage :many
+SELECT l.id,
+       l.name,
+       COUNT(t.id) AS entry_count,
+       MAX(t.transaction_date_utc) AS last_used_at_utc
+FROM labels l
+LEFT JOIN transaction_labels tl ON tl.label_id = l.id AND tl.deleted_at_utc IS NULL
+LEFT JOIN transactions t ON t.id = tl.transaction_id AND t.deleted_at_utc IS NULL
+WHERE l.deleted_at_utc IS NULL
+  AND l.archived_at_utc IS NULL
+GROUP BY l.id, l.name
+ORDER BY lower(l.name), l.id
+`
+
+type ListLabelUsageRow struct {
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	EntryCount    int64       `json:"entry_count"`
+	LastUsedAtUtc interface{} `json:"last_used_at_utc"`
+}
+
+func (q *Queries) ListLabelUsage(ctx context.Context) ([]ListLabelUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLabelUsage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLabelUsageRow
+	for rows.Next() {
+		var i ListLabelUsageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.EntryCount,
+			&i.LastUsedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -32,18 +32,38 @@ INSERT INTO transactions (
     transaction_date_utc,
     category_id,
     bank_account_id,
-    note
-) VALUES (?, ?, ?, ?, ?, ?, ?)
+    refund_of_transaction_id,
+    note,
+    payee,
+    recorded_by,
+    uid,
+    income_source,
+    location,
+    original_amount_minor,
+    original_currency_code,
+    fx_rate,
+    fx_rate_date
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateEntryParams struct {
-	Type               string         `json:"type"`
-	AmountMinor        int64          `json:"amount_minor"`
-	CurrencyCode       string         `json:"currency_code"`
-	TransactionDateUtc string         `json:"transaction_date_utc"`
-	CategoryID         sql.NullInt64  `json:"category_id"`
-	BankAccountID      sql.NullInt64  `json:"bank_account_id"`
-	Note               sql.NullString `json:"note"`
+	Type                  string         `json:"type"`
+	AmountMinor           int64          `json:"amount_minor"`
+	CurrencyCode          string         `json:"currency_code"`
+	TransactionDateUtc    string         `json:"transaction_date_utc"`
+	CategoryID            sql.NullInt64  `json:"category_id"`
+	BankAccountID         sql.NullInt64  `json:"bank_account_id"`
+	RefundOfTransactionID sql.NullInt64  `json:"refund_of_transaction_id"`
+	Note                  sql.NullString `json:"note"`
+	Payee                 sql.NullString `json:"payee"`
+	RecordedBy            sql.NullString `json:"recorded_by"`
+	Uid                   sql.NullString `json:"uid"`
+	IncomeSource          sql.NullString `json:"income_source"`
+	Location              sql.NullString `json:"location"`
+	OriginalAmountMinor   sql.NullInt64  `json:"original_amount_minor"`
+	OriginalCurrencyCode  sql.NullString `json:"original_currency_code"`
+	FxRate                sql.NullString `json:"fx_rate"`
+	FxRateDate            sql.NullString `json:"fx_rate_date"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (sql.Result, error) {
@@ -54,7 +74,17 @@ func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (sql.R
 		arg.TransactionDateUtc,
 		arg.CategoryID,
 		arg.BankAccountID,
+		arg.RefundOfTransactionID,
 		arg.Note,
+		arg.Payee,
+		arg.RecordedBy,
+		arg.Uid,
+		arg.IncomeSource,
+		arg.Location,
+		arg.OriginalAmountMinor,
+		arg.OriginalCurrencyCode,
+		arg.FxRate,
+		arg.FxRateDate,
 	)
 }
 
@@ -103,8 +133,38 @@ func (q *Queries) ExistsActiveLabelByID(ctx context.Context, id int64) (int64, e
 	return column_1, err
 }
 
+const existsArchivedCategoryByID = `-- name: ExistsArchivedCategoryByID :one
+SELECT EXISTS(
+    SELECT 1
+    FROM categories
+    WHERE id = ? AND deleted_at_utc IS NULL AND archived_at_utc IS NOT NULL
+)
+`
+
+func (q *Queries) ExistsArchivedCategoryByID(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, existsArchivedCategoryByID, id)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const existsArchivedLabelByID = `-- name: ExistsArchivedLabelByID :one
+SELECT EXISTS(
+    SELECT 1
+    FROM labels
+    WHERE id = ? AND deleted_at_utc IS NULL AND archived_at_utc IS NOT NULL
+)
+`
+
+func (q *Queries) ExistsArchivedLabelByID(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, existsArchivedLabelByID, id)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const getActiveEntryByID = `-- name: GetActiveEntryByID :one
-SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
 FROM transactions
 WHERE id = ? AND deleted_at_utc IS NULL
 `
@@ -120,16 +180,61 @@ func (q *Queries) GetActiveEntryByID(ctx context.Context, id int64) (Transaction
 		&i.TransactionDateUtc,
 		&i.CategoryID,
 		&i.BankAccountID,
+		&i.RefundOfTransactionID,
+		&i.Note,
+		&i.Payee,
+		&i.RecordedBy,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+		&i.Uid,
+		&i.IncomeSource,
+		&i.Location,
+		&i.OriginalAmountMinor,
+		&i.OriginalCurrencyCode,
+		&i.FxRate,
+		&i.FxRateDate,
+	)
+	return i, err
+}
+
+const getActiveEntryByUID = `-- name: GetActiveEntryByUID :one
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
+FROM transactions
+WHERE uid = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetActiveEntryByUID(ctx context.Context, uid sql.NullString) (Transaction, error) {
+	row := q.db.QueryRowContext(ctx, getActiveEntryByUID, uid)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.AmountMinor,
+		&i.CurrencyCode,
+		&i.TransactionDateUtc,
+		&i.CategoryID,
+		&i.BankAccountID,
+		&i.RefundOfTransactionID,
 		&i.Note,
+		&i.Payee,
+		&i.RecordedBy,
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
 		&i.DeletedAtUtc,
+		&i.Uid,
+		&i.IncomeSource,
+		&i.Location,
+		&i.OriginalAmountMinor,
+		&i.OriginalCurrencyCode,
+		&i.FxRate,
+		&i.FxRateDate,
 	)
 	return i, err
 }
 
-const listActiveEntries = `-- name: ListActiveEntries :many
-SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+const listActiveEntriesPage = `-- name: ListActiveEntriesPage :many
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, category_id, bank_account_id, refund_of_transaction_id, note, payee, recorded_by, created_at_utc, updated_at_utc, deleted_at_utc, uid, income_source, location, original_amount_minor, original_currency_code, fx_rate, fx_rate_date
 FROM transactions
 WHERE deleted_at_utc IS NULL
   AND (?1 IS NULL OR type = ?1)
@@ -138,26 +243,55 @@ WHERE deleted_at_utc IS NULL
   AND (?4 IS NULL OR transaction_date_utc >= ?4)
   AND (?5 IS NULL OR transaction_date_utc <= ?5)
   AND (?6 IS NULL OR (note IS NOT NULL AND instr(lower(note), lower(?6)) > 0))
+  AND (?7 IS NULL OR payee = ?7 COLLATE NOCASE)
+  AND (?8 IS NULL OR currency_code = ?8)
+  AND (?9 IS NULL OR amount_minor >= ?9)
+  AND (?10 IS NULL OR amount_minor <= ?10)
+  AND (?11 IS NULL OR recorded_by = ?11 COLLATE NOCASE)
+  AND (?12 IS NULL OR income_source = ?12 COLLATE NOCASE)
+  AND (?13 IS NULL OR (location IS NOT NULL AND instr(lower(location), lower(?13)) > 0))
+  AND (transaction_date_utc, id) > (?14, CAST(?15 AS INTEGER))
 ORDER BY transaction_date_utc, id
+LIMIT ?16
 `
 
-type ListActiveEntriesParams struct {
-	EntryType     interface{} `json:"entry_type"`
-	CategoryID    interface{} `json:"category_id"`
-	BankAccountID interface{} `json:"bank_account_id"`
-	DateFromUtc   interface{} `json:"date_from_utc"`
-	DateToUtc     interface{} `json:"date_to_utc"`
-	NoteContains  interface{} `json:"note_contains"`
+type ListActiveEntriesPageParams struct {
+	EntryType        interface{} `json:"entry_type"`
+	CategoryID       interface{} `json:"category_id"`
+	BankAccountID    interface{} `json:"bank_account_id"`
+	DateFromUtc      interface{} `json:"date_from_utc"`
+	DateToUtc        interface{} `json:"date_to_utc"`
+	NoteContains     interface{} `json:"note_contains"`
+	Payee            interface{} `json:"payee"`
+	CurrencyCode     interface{} `json:"currency_code"`
+	AmountMinMinor   interface{} `json:"amount_min_minor"`
+	AmountMaxMinor   interface{} `json:"amount_max_minor"`
+	RecordedBy       interface{} `json:"recorded_by"`
+	IncomeSource     interface{} `json:"income_source"`
+	LocationContains interface{} `json:"location_contains"`
+	AfterDateUtc     string      `json:"after_date_utc"`
+	AfterID          int64       `json:"after_id"`
+	PageSize         int64       `json:"page_size"`
 }
 
-func (q *Queries) ListActiveEntries(ctx context.Context, arg ListActiveEntriesParams) ([]Transaction, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveEntries,
+func (q *Queries) ListActiveEntriesPage(ctx context.Context, arg ListActiveEntriesPageParams) ([]Transaction, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveEntriesPage,
 		arg.EntryType,
 		arg.CategoryID,
 		arg.BankAccountID,
 		arg.DateFromUtc,
 		arg.DateToUtc,
 		arg.NoteContains,
+		arg.Payee,
+		arg.CurrencyCode,
+		arg.AmountMinMinor,
+		arg.AmountMaxMinor,
+		arg.RecordedBy,
+		arg.IncomeSource,
+		arg.LocationContains,
+		arg.AfterDateUtc,
+		arg.AfterID,
+		arg.PageSize,
 	)
 	if err != nil {
 		return nil, err
@@ -174,10 +308,20 @@ func (q *Queries) ListActiveEntries(ctx context.Context, arg ListActiveEntriesPa
 			&i.TransactionDateUtc,
 			&i.CategoryID,
 			&i.BankAccountID,
+			&i.RefundOfTransactionID,
 			&i.Note,
+			&i.Payee,
+			&i.RecordedBy,
 			&i.CreatedAtUtc,
 			&i.UpdatedAtUtc,
 			&i.DeletedAtUtc,
+			&i.Uid,
+			&i.IncomeSource,
+			&i.Location,
+			&i.OriginalAmountMinor,
+			&i.OriginalCurrencyCode,
+			&i.FxRate,
+			&i.FxRateDate,
 		); err != nil {
 			return nil, err
 		}
@@ -192,6 +336,36 @@ func (q *Queries) ListActiveEntries(ctx context.Context, arg ListActiveEntriesPa
 	return items, nil
 }
 
+const listActiveEntryIDsByCurrency = `-- name: ListActiveEntryIDsByCurrency :many
+SELECT id
+FROM transactions
+WHERE currency_code = ? AND deleted_at_utc IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListActiveEntryIDsByCurrency(ctx context.Context, currencyCode string) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveEntryIDsByCurrency, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listActiveEntryLabelIDs = `-- name: ListActiveEntryLabelIDs :many
 SELECT transaction_id, label_id
 FROM transaction_labels
@@ -227,51 +401,29 @@ func (q *Queries) ListActiveEntryLabelIDs(ctx context.Context, transactionID int
 	return items, nil
 }
 
-const listActiveEntryLabelIDsForListFilter = `-- name: ListActiveEntryLabelIDsForListFilter :many
+const listActiveEntryLabelIDsByTransactionIDs = `-- name: ListActiveEntryLabelIDsByTransactionIDs :many
+WITH params AS (SELECT CAST(?1 AS TEXT) AS transaction_ids_json)
 SELECT tl.transaction_id, tl.label_id
 FROM transaction_labels tl
-INNER JOIN transactions t ON t.id = tl.transaction_id
 WHERE tl.deleted_at_utc IS NULL
-  AND t.deleted_at_utc IS NULL
-  AND (?1 IS NULL OR t.type = ?1)
-  AND (?2 IS NULL OR t.category_id = ?2)
-  AND (?3 IS NULL OR t.bank_account_id = ?3)
-  AND (?4 IS NULL OR t.transaction_date_utc >= ?4)
-  AND (?5 IS NULL OR t.transaction_date_utc <= ?5)
-  AND (?6 IS NULL OR (t.note IS NOT NULL AND instr(lower(t.note), lower(?6)) > 0))
+  AND tl.transaction_id IN (SELECT value FROM params, json_each(params.transaction_ids_json))
 ORDER BY tl.transaction_id, tl.label_id
 `
 
-type ListActiveEntryLabelIDsForListFilterParams struct {
-	EntryType     interface{} `json:"entry_type"`
-	CategoryID    interface{} `json:"category_id"`
-	BankAccountID interface{} `json:"bank_account_id"`
-	DateFromUtc   interface{} `json:"date_from_utc"`
-	DateToUtc     interface{} `json:"date_to_utc"`
-	NoteContains  interface{} `json:"note_contains"`
-}
-
-type ListActiveEntryLabelIDsForListFilterRow struct {
+type ListActiveEntryLabelIDsByTransactionIDsRow struct {
 	TransactionID int64 `json:"transaction_id"`
 	LabelID       int64 `json:"label_id"`
 }
 
-func (q *Queries) ListActiveEntryLabelIDsForListFilter(ctx context.Context, arg ListActiveEntryLabelIDsForListFilterParams) ([]ListActiveEntryLabelIDsForListFilterRow, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveEntryLabelIDsForListFilter,
-		arg.EntryType,
-		arg.CategoryID,
-		arg.BankAccountID,
-		arg.DateFromUtc,
-		arg.DateToUtc,
-		arg.NoteContains,
-	)
+func (q *Queries) ListActiveEntryLabelIDsByTransactionIDs(ctx context.Context, transactionIdsJson string) ([]ListActiveEntryLabelIDsByTransactionIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveEntryLabelIDsByTransactionIDs, transactionIdsJson)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListActiveEntryLabelIDsForListFilterRow
+	var items []ListActiveEntryLabelIDsByTransactionIDsRow
 	for rows.Next() {
-		var i ListActiveEntryLabelIDsForListFilterRow
+		var i ListActiveEntryLabelIDsByTransactionIDsRow
 		if err := rows.Scan(&i.TransactionID, &i.LabelID); err != nil {
 			return nil, err
 		}
@@ -286,6 +438,105 @@ func (q *Queries) ListActiveEntryLabelIDsForListFilter(ctx context.Context, arg
 	return items, nil
 }
 
+const listEntryMonthTotals = `-- name: ListEntryMonthTotals :many
+SELECT month_key, currency_code, income_minor, expense_minor, entry_count
+FROM entry_month_totals
+WHERE (?1 IS NULL OR month_key >= ?1)
+  AND (?2 IS NULL OR month_key <= ?2)
+  AND (?3 IS NULL OR currency_code = ?3)
+ORDER BY month_key, currency_code
+`
+
+type ListEntryMonthTotalsParams struct {
+	FromMonth    interface{} `json:"from_month"`
+	ToMonth      interface{} `json:"to_month"`
+	CurrencyCode interface{} `json:"currency_code"`
+}
+
+func (q *Queries) ListEntryMonthTotals(ctx context.Context, arg ListEntryMonthTotalsParams) ([]EntryMonthTotal, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryMonthTotals, arg.FromMonth, arg.ToMonth, arg.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EntryMonthTotal
+	for rows.Next() {
+		var i EntryMonthTotal
+		if err := rows.Scan(
+			&i.MonthKey,
+			&i.CurrencyCode,
+			&i.IncomeMinor,
+			&i.ExpenseMinor,
+			&i.EntryCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntryPaymentInfoByTransactionIDs = `-- name: ListEntryPaymentInfoByTransactionIDs :many
+WITH params AS (SELECT CAST(?1 AS TEXT) AS transaction_ids_json)
+SELECT pm.transaction_id, pm.method_type, pm.card_id, c.nickname AS card_nickname, c.description AS card_description, c.last4 AS card_last4, c.brand AS card_brand, c.card_type AS card_type,
+    COALESCE((SELECT group_concat(a.alias, char(10)) FROM card_aliases a WHERE a.card_id = c.id), '') AS card_aliases
+FROM transaction_payment_methods pm
+LEFT JOIN cards c ON c.id = pm.card_id AND c.deleted_at_utc IS NULL
+WHERE pm.transaction_id IN (SELECT value FROM params, json_each(params.transaction_ids_json))
+ORDER BY pm.transaction_id
+`
+
+type ListEntryPaymentInfoByTransactionIDsRow struct {
+	TransactionID   int64          `json:"transaction_id"`
+	MethodType      string         `json:"method_type"`
+	CardID          sql.NullInt64  `json:"card_id"`
+	CardNickname    sql.NullString `json:"card_nickname"`
+	CardDescription sql.NullString `json:"card_description"`
+	CardLast4       sql.NullString `json:"card_last4"`
+	CardBrand       sql.NullString `json:"card_brand"`
+	CardType        sql.NullString `json:"card_type"`
+	CardAliases     interface{}    `json:"card_aliases"`
+}
+
+func (q *Queries) ListEntryPaymentInfoByTransactionIDs(ctx context.Context, transactionIdsJson string) ([]ListEntryPaymentInfoByTransactionIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryPaymentInfoByTransactionIDs, transactionIdsJson)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEntryPaymentInfoByTransactionIDsRow
+	for rows.Next() {
+		var i ListEntryPaymentInfoByTransactionIDsRow
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.MethodType,
+			&i.CardID,
+			&i.CardNickname,
+			&i.CardDescription,
+			&i.CardLast4,
+			&i.CardBrand,
+			&i.CardType,
+			&i.CardAliases,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const softDeleteEntry = `-- name: SoftDeleteEntry :execresult
 UPDATE transactions
 SET deleted_at_utc = ?, updated_at_utc = ?
@@ -317,6 +568,65 @@ func (q *Queries) SoftDeleteEntryLabelLinks(ctx context.Context, arg SoftDeleteE
 	return q.db.ExecContext(ctx, softDeleteEntryLabelLinks, arg.DeletedAtUtc, arg.TransactionID)
 }
 
+const sumActiveRefundsByTransactionID = `-- name: SumActiveRefundsByTransactionID :one
+SELECT CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS total_amount_minor
+FROM transactions
+WHERE refund_of_transaction_id = ?1
+  AND id != ?2
+  AND deleted_at_utc IS NULL
+`
+
+type SumActiveRefundsByTransactionIDParams struct {
+	RefundOfTransactionID sql.NullInt64 `json:"refund_of_transaction_id"`
+	ExcludeID             int64         `json:"exclude_id"`
+}
+
+func (q *Queries) SumActiveRefundsByTransactionID(ctx context.Context, arg SumActiveRefundsByTransactionIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumActiveRefundsByTransactionID, arg.RefundOfTransactionID, arg.ExcludeID)
+	var total_amount_minor int64
+	err := row.Scan(&total_amount_minor)
+	return total_amount_minor, err
+}
+
+const updateActiveEntriesCurrency = `-- name: UpdateActiveEntriesCurrency :execresult
+UPDATE transactions
+SET currency_code = ?1,
+    updated_at_utc = ?2
+WHERE currency_code = ?3
+  AND deleted_at_utc IS NULL
+`
+
+type UpdateActiveEntriesCurrencyParams struct {
+	ToCurrencyCode   string `json:"to_currency_code"`
+	UpdatedAtUtc     string `json:"updated_at_utc"`
+	FromCurrencyCode string `json:"from_currency_code"`
+}
+
+func (q *Queries) UpdateActiveEntriesCurrency(ctx context.Context, arg UpdateActiveEntriesCurrencyParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateActiveEntriesCurrency, arg.ToCurrencyCode, arg.UpdatedAtUtc, arg.FromCurrencyCode)
+}
+
+const updateCreditLiabilityEventsCurrency = `-- name: UpdateCreditLiabilityEventsCurrency :execresult
+UPDATE credit_liability_events
+SET currency_code = ?1
+WHERE credit_liability_events.currency_code = ?2
+  AND reference_transaction_id IN (
+    SELECT t.id
+    FROM transactions t
+    WHERE t.currency_code = ?2
+      AND t.deleted_at_utc IS NULL
+)
+`
+
+type UpdateCreditLiabilityEventsCurrencyParams struct {
+	ToCurrencyCode   string `json:"to_currency_code"`
+	FromCurrencyCode string `json:"from_currency_code"`
+}
+
+func (q *Queries) UpdateCreditLiabilityEventsCurrency(ctx context.Context, arg UpdateCreditLiabilityEventsCurrencyParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateCreditLiabilityEventsCurrency, arg.ToCurrencyCode, arg.FromCurrencyCode)
+}
+
 const updateEntryByID = `-- name: UpdateEntryByID :execresult
 UPDATE transactions
 SET type = CASE
@@ -350,8 +660,32 @@ END,
     WHEN ?16 = 1 THEN ?17
     ELSE note
 END,
-    updated_at_utc = ?18
-WHERE id = ?19
+    payee = CASE
+    WHEN ?18 = 1 THEN NULL
+    WHEN ?19 = 1 THEN ?20
+    ELSE payee
+END,
+    recorded_by = CASE
+    WHEN ?21 = 1 THEN NULL
+    WHEN ?22 = 1 THEN ?23
+    ELSE recorded_by
+END,
+    income_source = CASE
+    WHEN ?24 = 1 THEN NULL
+    WHEN ?25 = 1 THEN ?26
+    ELSE income_source
+END,
+    location = CASE
+    WHEN ?27 = 1 THEN NULL
+    WHEN ?28 = 1 THEN ?29
+    ELSE location
+END,
+    original_amount_minor = CASE WHEN ?30 = 1 THEN NULL ELSE original_amount_minor END,
+    original_currency_code = CASE WHEN ?30 = 1 THEN NULL ELSE original_currency_code END,
+    fx_rate = CASE WHEN ?30 = 1 THEN NULL ELSE fx_rate END,
+    fx_rate_date = CASE WHEN ?30 = 1 THEN NULL ELSE fx_rate_date END,
+    updated_at_utc = ?31
+WHERE id = ?32
   AND deleted_at_utc IS NULL
 `
 
@@ -373,6 +707,19 @@ type UpdateEntryByIDParams struct {
 	ClearNote             interface{}    `json:"clear_note"`
 	SetNote               interface{}    `json:"set_note"`
 	Note                  sql.NullString `json:"note"`
+	ClearPayee            interface{}    `json:"clear_payee"`
+	SetPayee              interface{}    `json:"set_payee"`
+	Payee                 sql.NullString `json:"payee"`
+	ClearRecordedBy       interface{}    `json:"clear_recorded_by"`
+	SetRecordedBy         interface{}    `json:"set_recorded_by"`
+	RecordedBy            sql.NullString `json:"recorded_by"`
+	ClearIncomeSource     interface{}    `json:"clear_income_source"`
+	SetIncomeSource       interface{}    `json:"set_income_source"`
+	IncomeSource          sql.NullString `json:"income_source"`
+	ClearLocation         interface{}    `json:"clear_location"`
+	SetLocation           interface{}    `json:"set_location"`
+	Location              sql.NullString `json:"location"`
+	ClearOriginal         interface{}    `json:"clear_original"`
 	UpdatedAtUtc          string         `json:"updated_at_utc"`
 	ID                    int64          `json:"id"`
 }
@@ -396,6 +743,19 @@ func (q *Queries) UpdateEntryByID(ctx context.Context, arg UpdateEntryByIDParams
 		arg.ClearNote,
 		arg.SetNote,
 		arg.Note,
+		arg.ClearPayee,
+		arg.SetPayee,
+		arg.Payee,
+		arg.ClearRecordedBy,
+		arg.SetRecordedBy,
+		arg.RecordedBy,
+		arg.ClearIncomeSource,
+		arg.SetIncomeSource,
+		arg.IncomeSource,
+		arg.ClearLocation,
+		arg.SetLocation,
+		arg.Location,
+		arg.ClearOriginal,
 		arg.UpdatedAtUtc,
 		arg.ID,
 	)
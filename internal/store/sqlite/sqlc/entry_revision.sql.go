@@ -0,0 +1,88 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: entry_revision.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createEntryRevision = `-- name: CreateEntryRevision :execresult
+INSERT INTO transaction_revisions (transaction_id, revision, state_json, replaced_at_utc)
+SELECT ?1, COALESCE(MAX(revision), 0) + 1, ?2, ?3
+FROM transaction_revisions
+WHERE transaction_id = ?1
+`
+
+type CreateEntryRevisionParams struct {
+	TransactionID int64  `json:"transaction_id"`
+	StateJson     string `json:"state_json"`
+	ReplacedAtUtc string `json:"replaced_at_utc"`
+}
+
+func (q *Queries) CreateEntryRevision(ctx context.Context, arg CreateEntryRevisionParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createEntryRevision, arg.TransactionID, arg.StateJson, arg.ReplacedAtUtc)
+}
+
+const getEntryRevision = `-- name: GetEntryRevision :one
+SELECT id, transaction_id, revision, state_json, replaced_at_utc
+FROM transaction_revisions
+WHERE transaction_id = ? AND revision = ?
+`
+
+type GetEntryRevisionParams struct {
+	TransactionID int64 `json:"transaction_id"`
+	Revision      int64 `json:"revision"`
+}
+
+func (q *Queries) GetEntryRevision(ctx context.Context, arg GetEntryRevisionParams) (TransactionRevision, error) {
+	row := q.db.QueryRowContext(ctx, getEntryRevision, arg.TransactionID, arg.Revision)
+	var i TransactionRevision
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.Revision,
+		&i.StateJson,
+		&i.ReplacedAtUtc,
+	)
+	return i, err
+}
+
+const listEntryRevisions = `-- name: ListEntryRevisions :many
+SELECT id, transaction_id, revision, state_json, replaced_at_utc
+FROM transaction_revisions
+WHERE transaction_id = ?
+ORDER BY revision ASC
+`
+
+func (q *Queries) ListEntryRevisions(ctx context.Context, transactionID int64) ([]TransactionRevision, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryRevisions, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TransactionRevision
+	for rows.Next() {
+		var i TransactionRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Revision,
+			&i.StateJson,
+			&i.ReplacedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
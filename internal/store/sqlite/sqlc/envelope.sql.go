@@ -0,0 +1,156 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: envelope.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createEnvelope = `-- name: CreateEnvelope :execresult
+INSERT INTO envelopes (
+    name,
+    currency_code,
+    budget_minor,
+    start_date,
+    end_date,
+    label_id,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateEnvelopeParams struct {
+	Name         string         `json:"name"`
+	CurrencyCode string         `json:"currency_code"`
+	BudgetMinor  int64          `json:"budget_minor"`
+	StartDate    string         `json:"start_date"`
+	EndDate      string         `json:"end_date"`
+	LabelID      int64          `json:"label_id"`
+	Note         sql.NullString `json:"note"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+}
+
+func (q *Queries) CreateEnvelope(ctx context.Context, arg CreateEnvelopeParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createEnvelope,
+		arg.Name,
+		arg.CurrencyCode,
+		arg.BudgetMinor,
+		arg.StartDate,
+		arg.EndDate,
+		arg.LabelID,
+		arg.Note,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const getActiveEnvelopeByID = `-- name: GetActiveEnvelopeByID :one
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetActiveEnvelopeByID(ctx context.Context, id int64) (Envelope, error) {
+	row := q.db.QueryRowContext(ctx, getActiveEnvelopeByID, id)
+	var i Envelope
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.BudgetMinor,
+		&i.StartDate,
+		&i.EndDate,
+		&i.LabelID,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const getEnvelopeByID = `-- name: GetEnvelopeByID :one
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE id = ?
+`
+
+func (q *Queries) GetEnvelopeByID(ctx context.Context, id int64) (Envelope, error) {
+	row := q.db.QueryRowContext(ctx, getEnvelopeByID, id)
+	var i Envelope
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.BudgetMinor,
+		&i.StartDate,
+		&i.EndDate,
+		&i.LabelID,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const listEnvelopes = `-- name: ListEnvelopes :many
+SELECT id, name, currency_code, budget_minor, start_date, end_date, label_id, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM envelopes
+WHERE (?1 = 1 OR deleted_at_utc IS NULL)
+ORDER BY start_date, id
+`
+
+func (q *Queries) ListEnvelopes(ctx context.Context, includeDeleted interface{}) ([]Envelope, error) {
+	rows, err := q.db.QueryContext(ctx, listEnvelopes, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Envelope
+	for rows.Next() {
+		var i Envelope
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CurrencyCode,
+			&i.BudgetMinor,
+			&i.StartDate,
+			&i.EndDate,
+			&i.LabelID,
+			&i.Note,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+			&i.DeletedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteEnvelope = `-- name: SoftDeleteEnvelope :execresult
+UPDATE envelopes
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+type SoftDeleteEnvelopeParams struct {
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) SoftDeleteEnvelope(ctx context.Context, arg SoftDeleteEnvelopeParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, softDeleteEnvelope, arg.DeletedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
@@ -90,3 +90,186 @@ func (q *Queries) GetFXRateSnapshotByKey(ctx context.Context, arg GetFXRateSnaps
 	)
 	return i, err
 }
+
+const getFallbackFXRateSnapshot = `-- name: GetFallbackFXRateSnapshot :one
+SELECT id,
+       provider,
+       base_currency,
+       quote_currency,
+       rate,
+       rate_date,
+       is_estimate,
+       fetched_at_utc
+FROM fx_rate_snapshots
+WHERE provider = ?
+  AND base_currency = ?
+  AND quote_currency = ?
+  AND rate_date <= ?
+ORDER BY rate_date DESC, fetched_at_utc DESC, id DESC
+LIMIT 1
+`
+
+type GetFallbackFXRateSnapshotParams struct {
+	Provider      string `json:"provider"`
+	BaseCurrency  string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+	RateDate      string `json:"rate_date"`
+}
+
+func (q *Queries) GetFallbackFXRateSnapshot(ctx context.Context, arg GetFallbackFXRateSnapshotParams) (FxRateSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getFallbackFXRateSnapshot,
+		arg.Provider,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.RateDate,
+	)
+	var i FxRateSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.Provider,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.RateDate,
+		&i.IsEstimate,
+		&i.FetchedAtUtc,
+	)
+	return i, err
+}
+
+const getLatestFXRateSnapshot = `-- name: GetLatestFXRateSnapshot :one
+SELECT id,
+       provider,
+       base_currency,
+       quote_currency,
+       rate,
+       rate_date,
+       is_estimate,
+       fetched_at_utc
+FROM fx_rate_snapshots
+WHERE provider = ?
+  AND base_currency = ?
+  AND quote_currency = ?
+  AND is_estimate = ?
+ORDER BY fetched_at_utc DESC, id DESC
+LIMIT 1
+`
+
+type GetLatestFXRateSnapshotParams struct {
+	Provider      string `json:"provider"`
+	BaseCurrency  string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+	IsEstimate    int64  `json:"is_estimate"`
+}
+
+func (q *Queries) GetLatestFXRateSnapshot(ctx context.Context, arg GetLatestFXRateSnapshotParams) (FxRateSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getLatestFXRateSnapshot,
+		arg.Provider,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.IsEstimate,
+	)
+	var i FxRateSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.Provider,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.RateDate,
+		&i.IsEstimate,
+		&i.FetchedAtUtc,
+	)
+	return i, err
+}
+
+const listFXRateCachePairs = `-- name: ListFXRateCachePairs :many
+SELECT provider,
+       base_currency,
+       quote_currency,
+       COUNT(*) AS snapshot_count,
+       MIN(rate_date) AS oldest_rate_date,
+       MAX(rate_date) AS newest_rate_date,
+       MAX(fetched_at_utc) AS last_fetched_at_utc
+FROM fx_rate_snapshots
+GROUP BY provider, base_currency, quote_currency
+ORDER BY base_currency, quote_currency, provider
+`
+
+type ListFXRateCachePairsRow struct {
+	Provider         string      `json:"provider"`
+	BaseCurrency     string      `json:"base_currency"`
+	QuoteCurrency    string      `json:"quote_currency"`
+	SnapshotCount    int64       `json:"snapshot_count"`
+	OldestRateDate   interface{} `json:"oldest_rate_date"`
+	NewestRateDate   interface{} `json:"newest_rate_date"`
+	LastFetchedAtUtc interface{} `json:"last_fetched_at_utc"`
+}
+
+func (q *Queries) ListFXRateCachePairs(ctx context.Context) ([]ListFXRateCachePairsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFXRateCachePairs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFXRateCachePairsRow
+	for rows.Next() {
+		var i ListFXRateCachePairsRow
+		if err := rows.Scan(
+			&i.Provider,
+			&i.BaseCurrency,
+			&i.QuoteCurrency,
+			&i.SnapshotCount,
+			&i.OldestRateDate,
+			&i.NewestRateDate,
+			&i.LastFetchedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshFXRateSnapshot = `-- name: RefreshFXRateSnapshot :one
+UPDATE fx_rate_snapshots
+SET rate = ?,
+    fetched_at_utc = ?
+WHERE id = ?
+RETURNING id,
+          provider,
+          base_currency,
+          quote_currency,
+          rate,
+          rate_date,
+          is_estimate,
+          fetched_at_utc
+`
+
+type RefreshFXRateSnapshotParams struct {
+	Rate         string `json:"rate"`
+	FetchedAtUtc string `json:"fetched_at_utc"`
+	ID           int64  `json:"id"`
+}
+
+func (q *Queries) RefreshFXRateSnapshot(ctx context.Context, arg RefreshFXRateSnapshotParams) (FxRateSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, refreshFXRateSnapshot, arg.Rate, arg.FetchedAtUtc, arg.ID)
+	var i FxRateSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.Provider,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.RateDate,
+		&i.IsEstimate,
+		&i.FetchedAtUtc,
+	)
+	return i, err
+}
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: hook.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createHook = `-- name: CreateHook :execresult
+INSERT INTO hooks (
+    event,
+    kind,
+    target,
+    within_days
+) VALUES (?, ?, ?, ?)
+`
+
+type CreateHookParams struct {
+	Event      string        `json:"event"`
+	Kind       string        `json:"kind"`
+	Target     string        `json:"target"`
+	WithinDays sql.NullInt64 `json:"within_days"`
+}
+
+func (q *Queries) CreateHook(ctx context.Context, arg CreateHookParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createHook,
+		arg.Event,
+		arg.Kind,
+		arg.Target,
+		arg.WithinDays,
+	)
+}
+
+const deleteHook = `-- name: DeleteHook :execresult
+DELETE FROM hooks
+WHERE id = ?
+`
+
+func (q *Queries) DeleteHook(ctx context.Context, id int64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteHook, id)
+}
+
+const getHookByID = `-- name: GetHookByID :one
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+WHERE id = ?
+`
+
+func (q *Queries) GetHookByID(ctx context.Context, id int64) (Hook, error) {
+	row := q.db.QueryRowContext(ctx, getHookByID, id)
+	var i Hook
+	err := row.Scan(
+		&i.ID,
+		&i.Event,
+		&i.Kind,
+		&i.Target,
+		&i.WithinDays,
+		&i.CreatedAtUtc,
+	)
+	return i, err
+}
+
+const listHooks = `-- name: ListHooks :many
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+ORDER BY id
+`
+
+func (q *Queries) ListHooks(ctx context.Context) ([]Hook, error) {
+	rows, err := q.db.QueryContext(ctx, listHooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Hook
+	for rows.Next() {
+		var i Hook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Event,
+			&i.Kind,
+			&i.Target,
+			&i.WithinDays,
+			&i.CreatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHooksByEvent = `-- name: ListHooksByEvent :many
+SELECT id, event, kind, target, within_days, created_at_utc
+FROM hooks
+WHERE event = ?
+ORDER BY id
+`
+
+func (q *Queries) ListHooksByEvent(ctx context.Context, event string) ([]Hook, error) {
+	rows, err := q.db.QueryContext(ctx, listHooksByEvent, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Hook
+	for rows.Next() {
+		var i Hook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Event,
+			&i.Kind,
+			&i.Target,
+			&i.WithinDays,
+			&i.CreatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
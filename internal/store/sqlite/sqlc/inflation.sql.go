@@ -0,0 +1,104 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: inflation.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getInflationIndexPointOnOrBefore = `-- name: GetInflationIndexPointOnOrBefore :one
+SELECT id, currency_code, index_date, index_value, created_at_utc, updated_at_utc
+FROM inflation_index_points
+WHERE currency_code = ?
+  AND index_date <= ?
+ORDER BY index_date DESC
+LIMIT 1
+`
+
+type GetInflationIndexPointOnOrBeforeParams struct {
+	CurrencyCode string `json:"currency_code"`
+	IndexDate    string `json:"index_date"`
+}
+
+func (q *Queries) GetInflationIndexPointOnOrBefore(ctx context.Context, arg GetInflationIndexPointOnOrBeforeParams) (InflationIndexPoint, error) {
+	row := q.db.QueryRowContext(ctx, getInflationIndexPointOnOrBefore, arg.CurrencyCode, arg.IndexDate)
+	var i InflationIndexPoint
+	err := row.Scan(
+		&i.ID,
+		&i.CurrencyCode,
+		&i.IndexDate,
+		&i.IndexValue,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+	)
+	return i, err
+}
+
+const listInflationIndexPoints = `-- name: ListInflationIndexPoints :many
+SELECT id, currency_code, index_date, index_value, created_at_utc, updated_at_utc
+FROM inflation_index_points
+WHERE (?1 IS NULL OR currency_code = ?1)
+ORDER BY currency_code, index_date
+`
+
+func (q *Queries) ListInflationIndexPoints(ctx context.Context, currencyCode interface{}) ([]InflationIndexPoint, error) {
+	rows, err := q.db.QueryContext(ctx, listInflationIndexPoints, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InflationIndexPoint
+	for rows.Next() {
+		var i InflationIndexPoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.CurrencyCode,
+			&i.IndexDate,
+			&i.IndexValue,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertInflationIndexPoint = `-- name: UpsertInflationIndexPoint :execresult
+INSERT INTO inflation_index_points (
+    currency_code,
+    index_date,
+    index_value,
+    updated_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(currency_code, index_date) DO UPDATE SET
+    index_value = excluded.index_value,
+    updated_at_utc = excluded.updated_at_utc
+`
+
+type UpsertInflationIndexPointParams struct {
+	CurrencyCode string `json:"currency_code"`
+	IndexDate    string `json:"index_date"`
+	IndexValue   string `json:"index_value"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
+func (q *Queries) UpsertInflationIndexPoint(ctx context.Context, arg UpsertInflationIndexPointParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, upsertInflationIndexPoint,
+		arg.CurrencyCode,
+		arg.IndexDate,
+		arg.IndexValue,
+		arg.UpdatedAtUtc,
+	)
+}
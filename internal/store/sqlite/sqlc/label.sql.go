@@ -19,7 +19,7 @@ func (q *Queries) CreateLabel(ctx context.Context, name string) (sql.Result, err
 }
 
 const getActiveLabelByID = `-- name: GetActiveLabelByID :one
-SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc
+SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc, archived_at_utc, color, icon
 FROM labels
 WHERE id = ? AND deleted_at_utc IS NULL
 `
@@ -33,19 +33,28 @@ func (q *Queries) GetActiveLabelByID(ctx context.Context, id int64) (Label, erro
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
 		&i.DeletedAtUtc,
+		&i.ArchivedAtUtc,
+		&i.Color,
+		&i.Icon,
 	)
 	return i, err
 }
 
 const listActiveLabels = `-- name: ListActiveLabels :many
-SELECT id, name, created_at_utc, updated_at_utc, deleted_at_utc
-FROM labels
-WHERE deleted_at_utc IS NULL
-ORDER BY lower(name), id
+WITH params AS (SELECT CAST(?1 AS TEXT) AS sort_key)
+SELECT l.id, l.name, l.created_at_utc, l.updated_at_utc, l.deleted_at_utc, l.archived_at_utc, l.color, l.icon
+FROM labels l
+CROSS JOIN params
+WHERE l.deleted_at_utc IS NULL
+ORDER BY
+    CASE WHEN params.sort_key = 'created' THEN l.created_at_utc END,
+    CASE WHEN params.sort_key IN ('id', 'created') THEN l.id END,
+    lower(l.name),
+    l.id
 `
 
-func (q *Queries) ListActiveLabels(ctx context.Context) ([]Label, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveLabels)
+func (q *Queries) ListActiveLabels(ctx context.Context, sortKey string) ([]Label, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveLabels, sortKey)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +68,9 @@ func (q *Queries) ListActiveLabels(ctx context.Context) ([]Label, error) {
 			&i.CreatedAtUtc,
 			&i.UpdatedAtUtc,
 			&i.DeletedAtUtc,
+			&i.ArchivedAtUtc,
+			&i.Color,
+			&i.Icon,
 		); err != nil {
 			return nil, err
 		}
@@ -89,6 +101,22 @@ func (q *Queries) RenameActiveLabel(ctx context.Context, arg RenameActiveLabelPa
 	return q.db.ExecContext(ctx, renameActiveLabel, arg.Name, arg.UpdatedAtUtc, arg.ID)
 }
 
+const setActiveLabelArchivedAt = `-- name: SetActiveLabelArchivedAt :execresult
+UPDATE labels
+SET archived_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+type SetActiveLabelArchivedAtParams struct {
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+}
+
+func (q *Queries) SetActiveLabelArchivedAt(ctx context.Context, arg SetActiveLabelArchivedAtParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setActiveLabelArchivedAt, arg.ArchivedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
+
 const softDeleteLabel = `-- name: SoftDeleteLabel :execresult
 UPDATE labels
 SET deleted_at_utc = ?, updated_at_utc = ?
@@ -119,3 +147,31 @@ type SoftDeleteTransactionLabelLinksByLabelIDParams struct {
 func (q *Queries) SoftDeleteTransactionLabelLinksByLabelID(ctx context.Context, arg SoftDeleteTransactionLabelLinksByLabelIDParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, softDeleteTransactionLabelLinksByLabelID, arg.DeletedAtUtc, arg.LabelID)
 }
+
+const updateActiveLabelAppearance = `-- name: UpdateActiveLabelAppearance :execresult
+UPDATE labels
+SET color = CASE WHEN ?1 = 1 THEN ?2 ELSE color END,
+    icon = CASE WHEN ?3 = 1 THEN ?4 ELSE icon END,
+    updated_at_utc = ?5
+WHERE id = ?6 AND deleted_at_utc IS NULL
+`
+
+type UpdateActiveLabelAppearanceParams struct {
+	SetColor     interface{}    `json:"set_color"`
+	Color        sql.NullString `json:"color"`
+	SetIcon      interface{}    `json:"set_icon"`
+	Icon         sql.NullString `json:"icon"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) UpdateActiveLabelAppearance(ctx context.Context, arg UpdateActiveLabelAppearanceParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateActiveLabelAppearance,
+		arg.SetColor,
+		arg.Color,
+		arg.SetIcon,
+		arg.Icon,
+		arg.UpdatedAtUtc,
+		arg.ID,
+	)
+}
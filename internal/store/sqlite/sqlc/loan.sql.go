@@ -0,0 +1,233 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: loan.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createLoan = `-- name: CreateLoan :execresult
+INSERT INTO loans (
+    name,
+    currency_code,
+    principal_minor,
+    annual_rate_bp,
+    term_months,
+    start_month,
+    note,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateLoanParams struct {
+	Name           sql.NullString `json:"name"`
+	CurrencyCode   string         `json:"currency_code"`
+	PrincipalMinor int64          `json:"principal_minor"`
+	AnnualRateBp   int64          `json:"annual_rate_bp"`
+	TermMonths     int64          `json:"term_months"`
+	StartMonth     string         `json:"start_month"`
+	Note           sql.NullString `json:"note"`
+	UpdatedAtUtc   string         `json:"updated_at_utc"`
+}
+
+func (q *Queries) CreateLoan(ctx context.Context, arg CreateLoanParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createLoan,
+		arg.Name,
+		arg.CurrencyCode,
+		arg.PrincipalMinor,
+		arg.AnnualRateBp,
+		arg.TermMonths,
+		arg.StartMonth,
+		arg.Note,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const createLoanPayment = `-- name: CreateLoanPayment :exec
+INSERT INTO loan_payments (
+    transaction_id,
+    loan_id
+) VALUES (?, ?)
+`
+
+type CreateLoanPaymentParams struct {
+	TransactionID int64 `json:"transaction_id"`
+	LoanID        int64 `json:"loan_id"`
+}
+
+func (q *Queries) CreateLoanPayment(ctx context.Context, arg CreateLoanPaymentParams) error {
+	_, err := q.db.ExecContext(ctx, createLoanPayment, arg.TransactionID, arg.LoanID)
+	return err
+}
+
+const deleteLoanPayment = `-- name: DeleteLoanPayment :execresult
+DELETE FROM loan_payments
+WHERE loan_id = ? AND transaction_id = ?
+`
+
+type DeleteLoanPaymentParams struct {
+	LoanID        int64 `json:"loan_id"`
+	TransactionID int64 `json:"transaction_id"`
+}
+
+func (q *Queries) DeleteLoanPayment(ctx context.Context, arg DeleteLoanPaymentParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteLoanPayment, arg.LoanID, arg.TransactionID)
+}
+
+const getActiveLoanByID = `-- name: GetActiveLoanByID :one
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetActiveLoanByID(ctx context.Context, id int64) (Loan, error) {
+	row := q.db.QueryRowContext(ctx, getActiveLoanByID, id)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.PrincipalMinor,
+		&i.AnnualRateBp,
+		&i.TermMonths,
+		&i.StartMonth,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const getLoanByID = `-- name: GetLoanByID :one
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE id = ?
+`
+
+func (q *Queries) GetLoanByID(ctx context.Context, id int64) (Loan, error) {
+	row := q.db.QueryRowContext(ctx, getLoanByID, id)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CurrencyCode,
+		&i.PrincipalMinor,
+		&i.AnnualRateBp,
+		&i.TermMonths,
+		&i.StartMonth,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const listLoanPaymentEntries = `-- name: ListLoanPaymentEntries :many
+SELECT t.id, t.transaction_date_utc, t.amount_minor, t.currency_code, t.note
+FROM loan_payments lp
+JOIN transactions t ON t.id = lp.transaction_id
+WHERE lp.loan_id = ?
+  AND t.deleted_at_utc IS NULL
+ORDER BY t.transaction_date_utc, t.id
+`
+
+type ListLoanPaymentEntriesRow struct {
+	ID                 int64          `json:"id"`
+	TransactionDateUtc string         `json:"transaction_date_utc"`
+	AmountMinor        int64          `json:"amount_minor"`
+	CurrencyCode       string         `json:"currency_code"`
+	Note               sql.NullString `json:"note"`
+}
+
+func (q *Queries) ListLoanPaymentEntries(ctx context.Context, loanID int64) ([]ListLoanPaymentEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLoanPaymentEntries, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLoanPaymentEntriesRow
+	for rows.Next() {
+		var i ListLoanPaymentEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionDateUtc,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLoans = `-- name: ListLoans :many
+SELECT id, name, currency_code, principal_minor, annual_rate_bp, term_months, start_month, note, created_at_utc, updated_at_utc, deleted_at_utc
+FROM loans
+WHERE (?1 = 1 OR deleted_at_utc IS NULL)
+ORDER BY start_month, id
+`
+
+func (q *Queries) ListLoans(ctx context.Context, includeDeleted interface{}) ([]Loan, error) {
+	rows, err := q.db.QueryContext(ctx, listLoans, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Loan
+	for rows.Next() {
+		var i Loan
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CurrencyCode,
+			&i.PrincipalMinor,
+			&i.AnnualRateBp,
+			&i.TermMonths,
+			&i.StartMonth,
+			&i.Note,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+			&i.DeletedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteLoan = `-- name: SoftDeleteLoan :execresult
+UPDATE loans
+SET deleted_at_utc = ?, updated_at_utc = ?
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+type SoftDeleteLoanParams struct {
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) SoftDeleteLoan(ctx context.Context, arg SoftDeleteLoanParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, softDeleteLoan, arg.DeletedAtUtc, arg.UpdatedAtUtc, arg.ID)
+}
@@ -8,6 +8,18 @@ import (
 	"database/sql"
 )
 
+type Asset struct {
+	ID           int64          `json:"id"`
+	Name         string         `json:"name"`
+	Kind         string         `json:"kind"`
+	CurrencyCode string         `json:"currency_code"`
+	BalanceMinor int64          `json:"balance_minor"`
+	Note         sql.NullString `json:"note"`
+	CreatedAtUtc string         `json:"created_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+}
+
 type AuditEvent struct {
 	ID           int64          `json:"id"`
 	Action       string         `json:"action"`
@@ -34,24 +46,47 @@ type BankAccount struct {
 }
 
 type Card struct {
-	ID           int64          `json:"id"`
-	Nickname     string         `json:"nickname"`
-	Description  sql.NullString `json:"description"`
-	Last4        string         `json:"last4"`
-	Brand        string         `json:"brand"`
-	CardType     string         `json:"card_type"`
-	DueDay       sql.NullInt64  `json:"due_day"`
-	CreatedAtUtc string         `json:"created_at_utc"`
-	UpdatedAtUtc string         `json:"updated_at_utc"`
-	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	ID                  int64          `json:"id"`
+	Nickname            string         `json:"nickname"`
+	Description         sql.NullString `json:"description"`
+	Last4               string         `json:"last4"`
+	Brand               string         `json:"brand"`
+	CardType            string         `json:"card_type"`
+	DueDay              sql.NullInt64  `json:"due_day"`
+	CreatedAtUtc        string         `json:"created_at_utc"`
+	UpdatedAtUtc        string         `json:"updated_at_utc"`
+	DeletedAtUtc        sql.NullString `json:"deleted_at_utc"`
+	ArchivedAtUtc       sql.NullString `json:"archived_at_utc"`
+	DefaultCurrencyCode sql.NullString `json:"default_currency_code"`
+	FxFeeBp             sql.NullInt64  `json:"fx_fee_bp"`
+}
+
+type CardAlias struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	Alias        string `json:"alias"`
+	CreatedAtUtc string `json:"created_at_utc"`
+}
+
+type CardMonthlyLimit struct {
+	ID           int64  `json:"id"`
+	CardID       int64  `json:"card_id"`
+	MonthKey     string `json:"month_key"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	CreatedAtUtc string `json:"created_at_utc"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
 }
 
 type Category struct {
-	ID           int64          `json:"id"`
-	Name         string         `json:"name"`
-	CreatedAtUtc string         `json:"created_at_utc"`
-	UpdatedAtUtc string         `json:"updated_at_utc"`
-	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	DeletedAtUtc  sql.NullString `json:"deleted_at_utc"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	Color         sql.NullString `json:"color"`
+	Icon          sql.NullString `json:"icon"`
 }
 
 type CreditLiabilityEvent struct {
@@ -63,6 +98,51 @@ type CreditLiabilityEvent struct {
 	ReferenceTransactionID sql.NullInt64  `json:"reference_transaction_id"`
 	Note                   sql.NullString `json:"note"`
 	CreatedAtUtc           string         `json:"created_at_utc"`
+	FxFeeMinor             int64          `json:"fx_fee_minor"`
+}
+
+type CustomCurrency struct {
+	Code         string         `json:"code"`
+	Name         sql.NullString `json:"name"`
+	MinorUnit    int64          `json:"minor_unit"`
+	CreatedAtUtc string         `json:"created_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+}
+
+type DataVersion struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
+}
+
+type EntryMonthTotal struct {
+	MonthKey     string `json:"month_key"`
+	CurrencyCode string `json:"currency_code"`
+	IncomeMinor  int64  `json:"income_minor"`
+	ExpenseMinor int64  `json:"expense_minor"`
+	EntryCount   int64  `json:"entry_count"`
+}
+
+type EntrySplit struct {
+	ID            int64          `json:"id"`
+	TransactionID int64          `json:"transaction_id"`
+	Person        string         `json:"person"`
+	ShareBps      int64          `json:"share_bps"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	DeletedAtUtc  sql.NullString `json:"deleted_at_utc"`
+}
+
+type Envelope struct {
+	ID           int64          `json:"id"`
+	Name         string         `json:"name"`
+	CurrencyCode string         `json:"currency_code"`
+	BudgetMinor  int64          `json:"budget_minor"`
+	StartDate    string         `json:"start_date"`
+	EndDate      string         `json:"end_date"`
+	LabelID      int64          `json:"label_id"`
+	Note         sql.NullString `json:"note"`
+	CreatedAtUtc string         `json:"created_at_utc"`
+	UpdatedAtUtc string         `json:"updated_at_utc"`
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
 }
 
 type FxRateSnapshot struct {
@@ -76,12 +156,53 @@ type FxRateSnapshot struct {
 	FetchedAtUtc  string `json:"fetched_at_utc"`
 }
 
+type Hook struct {
+	ID           int64         `json:"id"`
+	Event        string        `json:"event"`
+	Kind         string        `json:"kind"`
+	Target       string        `json:"target"`
+	WithinDays   sql.NullInt64 `json:"within_days"`
+	CreatedAtUtc string        `json:"created_at_utc"`
+}
+
+type InflationIndexPoint struct {
+	ID           int64  `json:"id"`
+	CurrencyCode string `json:"currency_code"`
+	IndexDate    string `json:"index_date"`
+	IndexValue   string `json:"index_value"`
+	CreatedAtUtc string `json:"created_at_utc"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
 type Label struct {
-	ID           int64          `json:"id"`
-	Name         string         `json:"name"`
-	CreatedAtUtc string         `json:"created_at_utc"`
-	UpdatedAtUtc string         `json:"updated_at_utc"`
-	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	DeletedAtUtc  sql.NullString `json:"deleted_at_utc"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+	Color         sql.NullString `json:"color"`
+	Icon          sql.NullString `json:"icon"`
+}
+
+type Loan struct {
+	ID             int64          `json:"id"`
+	Name           sql.NullString `json:"name"`
+	CurrencyCode   string         `json:"currency_code"`
+	PrincipalMinor int64          `json:"principal_minor"`
+	AnnualRateBp   int64          `json:"annual_rate_bp"`
+	TermMonths     int64          `json:"term_months"`
+	StartMonth     string         `json:"start_month"`
+	Note           sql.NullString `json:"note"`
+	CreatedAtUtc   string         `json:"created_at_utc"`
+	UpdatedAtUtc   string         `json:"updated_at_utc"`
+	DeletedAtUtc   sql.NullString `json:"deleted_at_utc"`
+}
+
+type LoanPayment struct {
+	TransactionID int64  `json:"transaction_id"`
+	LoanID        int64  `json:"loan_id"`
+	CreatedAtUtc  string `json:"created_at_utc"`
 }
 
 type MonthlyCap struct {
@@ -94,12 +215,78 @@ type MonthlyCap struct {
 }
 
 type MonthlyCapChange struct {
-	ID             int64         `json:"id"`
-	MonthKey       string        `json:"month_key"`
-	OldAmountMinor sql.NullInt64 `json:"old_amount_minor"`
-	NewAmountMinor int64         `json:"new_amount_minor"`
-	CurrencyCode   string        `json:"currency_code"`
-	ChangedAtUtc   string        `json:"changed_at_utc"`
+	ID                 int64          `json:"id"`
+	MonthKey           string         `json:"month_key"`
+	OldAmountMinor     sql.NullInt64  `json:"old_amount_minor"`
+	NewAmountMinor     int64          `json:"new_amount_minor"`
+	CurrencyCode       string         `json:"currency_code"`
+	ChangedAtUtc       string         `json:"changed_at_utc"`
+	CategoryID         sql.NullInt64  `json:"category_id"`
+	CopiedFromMonthKey sql.NullString `json:"copied_from_month_key"`
+}
+
+type MonthlyCategoryCap struct {
+	ID           int64  `json:"id"`
+	MonthKey     string `json:"month_key"`
+	CategoryID   int64  `json:"category_id"`
+	AmountMinor  int64  `json:"amount_minor"`
+	CurrencyCode string `json:"currency_code"`
+	CreatedAtUtc string `json:"created_at_utc"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
+type NetWorthSnapshot struct {
+	SnapshotDate     string `json:"snapshot_date"`
+	CurrencyCode     string `json:"currency_code"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetWorthMinor    int64  `json:"net_worth_minor"`
+	RecordedAtUtc    string `json:"recorded_at_utc"`
+}
+
+type Operation struct {
+	ID            int64          `json:"id"`
+	Kind          string         `json:"kind"`
+	Status        string         `json:"status"`
+	ParamsJson    string         `json:"params_json"`
+	Total         int64          `json:"total"`
+	Done          int64          `json:"done"`
+	Checkpoint    string         `json:"checkpoint"`
+	LastError     sql.NullString `json:"last_error"`
+	CreatedAtUtc  string         `json:"created_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	FinishedAtUtc sql.NullString `json:"finished_at_utc"`
+}
+
+type OrphanSpendingThreshold struct {
+	CurrencyCode string        `json:"currency_code"`
+	Mode         string        `json:"mode"`
+	PercentBps   sql.NullInt64 `json:"percent_bps"`
+	AmountMinor  sql.NullInt64 `json:"amount_minor"`
+	CreatedAtUtc string        `json:"created_at_utc"`
+	UpdatedAtUtc string        `json:"updated_at_utc"`
+}
+
+type ReportCache struct {
+	CacheKey     string `json:"cache_key"`
+	DataVersion  int64  `json:"data_version"`
+	PayloadJson  string `json:"payload_json"`
+	CreatedAtUtc string `json:"created_at_utc"`
+}
+
+type ReportScheduleRun struct {
+	JobName      string `json:"job_name"`
+	PeriodKey    string `json:"period_key"`
+	FilePath     string `json:"file_path"`
+	LastRunAtUtc string `json:"last_run_at_utc"`
+}
+
+type ReportSnapshot struct {
+	ID           int64          `json:"id"`
+	MonthKey     string         `json:"month_key"`
+	ReportJson   string         `json:"report_json"`
+	FrozenAtUtc  string         `json:"frozen_at_utc"`
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
 }
 
 type SavingsEvent struct {
@@ -146,20 +333,56 @@ type Setting struct {
 	OnboardingCompletedAtUtc   sql.NullString `json:"onboarding_completed_at_utc"`
 	CreatedAtUtc               string         `json:"created_at_utc"`
 	UpdatedAtUtc               string         `json:"updated_at_utc"`
+	AmountFormat               string         `json:"amount_format"`
+	DefaultOutput              sql.NullString `json:"default_output"`
+	DefaultCardID              sql.NullInt64  `json:"default_card_id"`
+	FiscalMonthStartDay        int64          `json:"fiscal_month_start_day"`
+	DefaultRecordedBy          sql.NullString `json:"default_recorded_by"`
+	AutoSnapshot               int64          `json:"auto_snapshot"`
+	FxCacheTtlHours            int64          `json:"fx_cache_ttl_hours"`
+	FxStaleAfterDays           int64          `json:"fx_stale_after_days"`
+	ReportCache                int64          `json:"report_cache"`
+}
+
+type Settlement struct {
+	ID           int64          `json:"id"`
+	FromPerson   string         `json:"from_person"`
+	ToPerson     string         `json:"to_person"`
+	AmountMinor  int64          `json:"amount_minor"`
+	CurrencyCode string         `json:"currency_code"`
+	SettledAtUtc string         `json:"settled_at_utc"`
+	Note         sql.NullString `json:"note"`
+	CreatedAtUtc string         `json:"created_at_utc"`
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+}
+
+type StrictWarningCode struct {
+	Code         string `json:"code"`
+	CreatedAtUtc string `json:"created_at_utc"`
 }
 
 type Transaction struct {
-	ID                 int64          `json:"id"`
-	Type               string         `json:"type"`
-	AmountMinor        int64          `json:"amount_minor"`
-	CurrencyCode       string         `json:"currency_code"`
-	TransactionDateUtc string         `json:"transaction_date_utc"`
-	CategoryID         sql.NullInt64  `json:"category_id"`
-	BankAccountID      sql.NullInt64  `json:"bank_account_id"`
-	Note               sql.NullString `json:"note"`
-	CreatedAtUtc       string         `json:"created_at_utc"`
-	UpdatedAtUtc       string         `json:"updated_at_utc"`
-	DeletedAtUtc       sql.NullString `json:"deleted_at_utc"`
+	ID                    int64          `json:"id"`
+	Type                  string         `json:"type"`
+	AmountMinor           int64          `json:"amount_minor"`
+	CurrencyCode          string         `json:"currency_code"`
+	TransactionDateUtc    string         `json:"transaction_date_utc"`
+	CategoryID            sql.NullInt64  `json:"category_id"`
+	BankAccountID         sql.NullInt64  `json:"bank_account_id"`
+	RefundOfTransactionID sql.NullInt64  `json:"refund_of_transaction_id"`
+	Note                  sql.NullString `json:"note"`
+	Payee                 sql.NullString `json:"payee"`
+	RecordedBy            sql.NullString `json:"recorded_by"`
+	CreatedAtUtc          string         `json:"created_at_utc"`
+	UpdatedAtUtc          string         `json:"updated_at_utc"`
+	DeletedAtUtc          sql.NullString `json:"deleted_at_utc"`
+	Uid                   sql.NullString `json:"uid"`
+	IncomeSource          sql.NullString `json:"income_source"`
+	Location              sql.NullString `json:"location"`
+	OriginalAmountMinor   sql.NullInt64  `json:"original_amount_minor"`
+	OriginalCurrencyCode  sql.NullString `json:"original_currency_code"`
+	FxRate                sql.NullString `json:"fx_rate"`
+	FxRateDate            sql.NullString `json:"fx_rate_date"`
 }
 
 type TransactionLabel struct {
@@ -177,3 +400,11 @@ type TransactionPaymentMethod struct {
 	CreatedAtUtc  string        `json:"created_at_utc"`
 	UpdatedAtUtc  string        `json:"updated_at_utc"`
 }
+
+type TransactionRevision struct {
+	ID            int64  `json:"id"`
+	TransactionID int64  `json:"transaction_id"`
+	Revision      int64  `json:"revision"`
+	StateJson     string `json:"state_json"`
+	ReplacedAtUtc string `json:"replaced_at_utc"`
+}
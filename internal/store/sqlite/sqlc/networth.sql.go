@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: networth.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const deleteNetWorthSnapshotsByDate = `-- name: DeleteNetWorthSnapshotsByDate :exec
+DELETE FROM net_worth_snapshots
+WHERE snapshot_date = ?
+`
+
+func (q *Queries) DeleteNetWorthSnapshotsByDate(ctx context.Context, snapshotDate string) error {
+	_, err := q.db.ExecContext(ctx, deleteNetWorthSnapshotsByDate, snapshotDate)
+	return err
+}
+
+const listNetWorthSnapshots = `-- name: ListNetWorthSnapshots :many
+SELECT snapshot_date, currency_code, assets_minor, liabilities_minor, net_worth_minor, recorded_at_utc
+FROM net_worth_snapshots
+WHERE (?1 IS NULL OR snapshot_date >= ?1)
+  AND (?2 IS NULL OR snapshot_date <= ?2)
+  AND (?3 IS NULL OR currency_code = ?3)
+ORDER BY currency_code ASC, snapshot_date ASC
+`
+
+type ListNetWorthSnapshotsParams struct {
+	DateFrom     interface{} `json:"date_from"`
+	DateTo       interface{} `json:"date_to"`
+	CurrencyCode interface{} `json:"currency_code"`
+}
+
+func (q *Queries) ListNetWorthSnapshots(ctx context.Context, arg ListNetWorthSnapshotsParams) ([]NetWorthSnapshot, error) {
+	rows, err := q.db.QueryContext(ctx, listNetWorthSnapshots, arg.DateFrom, arg.DateTo, arg.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NetWorthSnapshot
+	for rows.Next() {
+		var i NetWorthSnapshot
+		if err := rows.Scan(
+			&i.SnapshotDate,
+			&i.CurrencyCode,
+			&i.AssetsMinor,
+			&i.LiabilitiesMinor,
+			&i.NetWorthMinor,
+			&i.RecordedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertNetWorthSnapshot = `-- name: UpsertNetWorthSnapshot :exec
+INSERT INTO net_worth_snapshots (snapshot_date, currency_code, assets_minor, liabilities_minor, net_worth_minor, recorded_at_utc)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (snapshot_date, currency_code) DO UPDATE SET
+    assets_minor = excluded.assets_minor,
+    liabilities_minor = excluded.liabilities_minor,
+    net_worth_minor = excluded.net_worth_minor,
+    recorded_at_utc = excluded.recorded_at_utc
+`
+
+type UpsertNetWorthSnapshotParams struct {
+	SnapshotDate     string `json:"snapshot_date"`
+	CurrencyCode     string `json:"currency_code"`
+	AssetsMinor      int64  `json:"assets_minor"`
+	LiabilitiesMinor int64  `json:"liabilities_minor"`
+	NetWorthMinor    int64  `json:"net_worth_minor"`
+	RecordedAtUtc    string `json:"recorded_at_utc"`
+}
+
+func (q *Queries) UpsertNetWorthSnapshot(ctx context.Context, arg UpsertNetWorthSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, upsertNetWorthSnapshot,
+		arg.SnapshotDate,
+		arg.CurrencyCode,
+		arg.AssetsMinor,
+		arg.LiabilitiesMinor,
+		arg.NetWorthMinor,
+		arg.RecordedAtUtc,
+	)
+	return err
+}
@@ -0,0 +1,158 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: operation.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createOperation = `-- name: CreateOperation :execresult
+INSERT INTO operations (kind, status, params_json, total, updated_at_utc)
+VALUES (?, 'running', ?, ?, ?)
+`
+
+type CreateOperationParams struct {
+	Kind         string `json:"kind"`
+	ParamsJson   string `json:"params_json"`
+	Total        int64  `json:"total"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+}
+
+func (q *Queries) CreateOperation(ctx context.Context, arg CreateOperationParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createOperation,
+		arg.Kind,
+		arg.ParamsJson,
+		arg.Total,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const getOperation = `-- name: GetOperation :one
+SELECT id, kind, status, params_json, total, done, checkpoint, last_error, created_at_utc, updated_at_utc, finished_at_utc
+FROM operations
+WHERE id = ?
+`
+
+func (q *Queries) GetOperation(ctx context.Context, id int64) (Operation, error) {
+	row := q.db.QueryRowContext(ctx, getOperation, id)
+	var i Operation
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Status,
+		&i.ParamsJson,
+		&i.Total,
+		&i.Done,
+		&i.Checkpoint,
+		&i.LastError,
+		&i.CreatedAtUtc,
+		&i.UpdatedAtUtc,
+		&i.FinishedAtUtc,
+	)
+	return i, err
+}
+
+const listOperations = `-- name: ListOperations :many
+SELECT id, kind, status, params_json, total, done, checkpoint, last_error, created_at_utc, updated_at_utc, finished_at_utc
+FROM operations
+WHERE (?1 = '' OR status = ?1)
+ORDER BY id DESC
+LIMIT ?2
+`
+
+type ListOperationsParams struct {
+	Status interface{} `json:"status"`
+	Limit  int64       `json:"limit"`
+}
+
+func (q *Queries) ListOperations(ctx context.Context, arg ListOperationsParams) ([]Operation, error) {
+	rows, err := q.db.QueryContext(ctx, listOperations, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Operation
+	for rows.Next() {
+		var i Operation
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Status,
+			&i.ParamsJson,
+			&i.Total,
+			&i.Done,
+			&i.Checkpoint,
+			&i.LastError,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+			&i.FinishedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateOperationProgress = `-- name: UpdateOperationProgress :execresult
+UPDATE operations
+SET total = ?, done = ?, checkpoint = ?, updated_at_utc = ?
+WHERE id = ? AND status = 'running'
+`
+
+type UpdateOperationProgressParams struct {
+	Total        int64  `json:"total"`
+	Done         int64  `json:"done"`
+	Checkpoint   string `json:"checkpoint"`
+	UpdatedAtUtc string `json:"updated_at_utc"`
+	ID           int64  `json:"id"`
+}
+
+func (q *Queries) UpdateOperationProgress(ctx context.Context, arg UpdateOperationProgressParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateOperationProgress,
+		arg.Total,
+		arg.Done,
+		arg.Checkpoint,
+		arg.UpdatedAtUtc,
+		arg.ID,
+	)
+}
+
+const updateOperationStatus = `-- name: UpdateOperationStatus :execresult
+UPDATE operations
+SET status = ?1,
+    last_error = ?2,
+    finished_at_utc = ?3,
+    updated_at_utc = ?4
+WHERE id = ?5 AND status = ?6
+`
+
+type UpdateOperationStatusParams struct {
+	Status        string         `json:"status"`
+	LastError     sql.NullString `json:"last_error"`
+	FinishedAtUtc sql.NullString `json:"finished_at_utc"`
+	UpdatedAtUtc  string         `json:"updated_at_utc"`
+	ID            int64          `json:"id"`
+	FromStatus    string         `json:"from_status"`
+}
+
+func (q *Queries) UpdateOperationStatus(ctx context.Context, arg UpdateOperationStatusParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateOperationStatus,
+		arg.Status,
+		arg.LastError,
+		arg.FinishedAtUtc,
+		arg.UpdatedAtUtc,
+		arg.ID,
+		arg.FromStatus,
+	)
+}
@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: report_cache.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const deleteStaleReportCacheEntries = `-- name: DeleteStaleReportCacheEntries :exec
+DELETE FROM report_cache
+WHERE data_version <> ?
+`
+
+func (q *Queries) DeleteStaleReportCacheEntries(ctx context.Context, dataVersion int64) error {
+	_, err := q.db.ExecContext(ctx, deleteStaleReportCacheEntries, dataVersion)
+	return err
+}
+
+const getDataVersion = `-- name: GetDataVersion :one
+SELECT version
+FROM data_version
+WHERE id = 1
+`
+
+func (q *Queries) GetDataVersion(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getDataVersion)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const getReportCacheEntry = `-- name: GetReportCacheEntry :one
+SELECT payload_json
+FROM report_cache
+WHERE cache_key = ? AND data_version = ?
+`
+
+type GetReportCacheEntryParams struct {
+	CacheKey    string `json:"cache_key"`
+	DataVersion int64  `json:"data_version"`
+}
+
+func (q *Queries) GetReportCacheEntry(ctx context.Context, arg GetReportCacheEntryParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getReportCacheEntry, arg.CacheKey, arg.DataVersion)
+	var payload_json string
+	err := row.Scan(&payload_json)
+	return payload_json, err
+}
+
+const upsertReportCacheEntry = `-- name: UpsertReportCacheEntry :exec
+INSERT INTO report_cache (
+    cache_key,
+    data_version,
+    payload_json,
+    created_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(cache_key) DO UPDATE SET
+    data_version = excluded.data_version,
+    payload_json = excluded.payload_json,
+    created_at_utc = excluded.created_at_utc
+`
+
+type UpsertReportCacheEntryParams struct {
+	CacheKey     string `json:"cache_key"`
+	DataVersion  int64  `json:"data_version"`
+	PayloadJson  string `json:"payload_json"`
+	CreatedAtUtc string `json:"created_at_utc"`
+}
+
+func (q *Queries) UpsertReportCacheEntry(ctx context.Context, arg UpsertReportCacheEntryParams) error {
+	_, err := q.db.ExecContext(ctx, upsertReportCacheEntry,
+		arg.CacheKey,
+		arg.DataVersion,
+		arg.PayloadJson,
+		arg.CreatedAtUtc,
+	)
+	return err
+}
@@ -0,0 +1,78 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: report_snapshot.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createReportSnapshot = `-- name: CreateReportSnapshot :execresult
+INSERT INTO report_snapshots (month_key, report_json)
+VALUES (?, ?)
+`
+
+type CreateReportSnapshotParams struct {
+	MonthKey   string `json:"month_key"`
+	ReportJson string `json:"report_json"`
+}
+
+func (q *Queries) CreateReportSnapshot(ctx context.Context, arg CreateReportSnapshotParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createReportSnapshot, arg.MonthKey, arg.ReportJson)
+}
+
+const getActiveReportSnapshotByMonth = `-- name: GetActiveReportSnapshotByMonth :one
+SELECT id, month_key, report_json, frozen_at_utc, deleted_at_utc
+FROM report_snapshots
+WHERE month_key = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetActiveReportSnapshotByMonth(ctx context.Context, monthKey string) (ReportSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getActiveReportSnapshotByMonth, monthKey)
+	var i ReportSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.MonthKey,
+		&i.ReportJson,
+		&i.FrozenAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const getReportSnapshotByID = `-- name: GetReportSnapshotByID :one
+SELECT id, month_key, report_json, frozen_at_utc, deleted_at_utc
+FROM report_snapshots
+WHERE id = ?
+`
+
+func (q *Queries) GetReportSnapshotByID(ctx context.Context, id int64) (ReportSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getReportSnapshotByID, id)
+	var i ReportSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.MonthKey,
+		&i.ReportJson,
+		&i.FrozenAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const softDeleteReportSnapshotByMonth = `-- name: SoftDeleteReportSnapshotByMonth :execresult
+UPDATE report_snapshots
+SET deleted_at_utc = ?
+WHERE month_key = ? AND deleted_at_utc IS NULL
+`
+
+type SoftDeleteReportSnapshotByMonthParams struct {
+	DeletedAtUtc sql.NullString `json:"deleted_at_utc"`
+	MonthKey     string         `json:"month_key"`
+}
+
+func (q *Queries) SoftDeleteReportSnapshotByMonth(ctx context.Context, arg SoftDeleteReportSnapshotByMonthParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, softDeleteReportSnapshotByMonth, arg.DeletedAtUtc, arg.MonthKey)
+}
@@ -3,7 +3,10 @@ CREATE TABLE IF NOT EXISTS categories (
     name TEXT NOT NULL,
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
     updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
-    deleted_at_utc TEXT
+    deleted_at_utc TEXT,
+    archived_at_utc TEXT,
+    color TEXT,
+    icon TEXT
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name_active
@@ -15,7 +18,10 @@ CREATE TABLE IF NOT EXISTS labels (
     name TEXT NOT NULL,
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
     updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
-    deleted_at_utc TEXT
+    deleted_at_utc TEXT,
+    archived_at_utc TEXT,
+    color TEXT,
+    icon TEXT
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_name_active
@@ -30,10 +36,20 @@ CREATE TABLE IF NOT EXISTS transactions (
     transaction_date_utc TEXT NOT NULL,
     category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
     bank_account_id INTEGER REFERENCES bank_accounts(id) ON DELETE SET NULL,
+    refund_of_transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
     note TEXT,
+    payee TEXT,
+    recorded_by TEXT,
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
     updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
-    deleted_at_utc TEXT
+    deleted_at_utc TEXT,
+    uid TEXT,
+    income_source TEXT,
+    location TEXT,
+    original_amount_minor INTEGER,
+    original_currency_code TEXT,
+    fx_rate TEXT,
+    fx_rate_date TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_transactions_date
@@ -45,13 +61,34 @@ CREATE INDEX IF NOT EXISTS idx_transactions_type
 CREATE INDEX IF NOT EXISTS idx_transactions_category
     ON transactions (category_id);
 
-CREATE INDEX IF NOT EXISTS idx_transactions_deleted_date
-    ON transactions (deleted_at_utc, transaction_date_utc);
-
 CREATE INDEX IF NOT EXISTS idx_transactions_bank_account_date
     ON transactions (bank_account_id, transaction_date_utc, id)
     WHERE deleted_at_utc IS NULL AND bank_account_id IS NOT NULL;
 
+CREATE INDEX IF NOT EXISTS idx_transactions_refund_of
+    ON transactions (refund_of_transaction_id)
+    WHERE deleted_at_utc IS NULL AND refund_of_transaction_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_payee_date
+    ON transactions (payee COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND payee IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_recorded_by_date
+    ON transactions (recorded_by COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND recorded_by IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_income_source_date
+    ON transactions (income_source COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND income_source IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_active_date_type_category
+    ON transactions (transaction_date_utc, id, type, category_id)
+    WHERE deleted_at_utc IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_uid_active
+    ON transactions (uid)
+    WHERE deleted_at_utc IS NULL AND uid IS NOT NULL;
+
 CREATE TABLE IF NOT EXISTS cards (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     nickname TEXT NOT NULL,
@@ -63,6 +100,9 @@ CREATE TABLE IF NOT EXISTS cards (
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
     updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
     deleted_at_utc TEXT,
+    archived_at_utc TEXT,
+    default_currency_code TEXT,
+    fx_fee_bp INTEGER,
     CHECK (
         (card_type = 'credit' AND due_day IS NOT NULL) OR
         (card_type = 'debit' AND due_day IS NULL)
@@ -104,6 +144,7 @@ CREATE TABLE IF NOT EXISTS credit_liability_events (
     reference_transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
     note TEXT,
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    fx_fee_minor INTEGER NOT NULL DEFAULT 0,
     CHECK (
         (event_type = 'charge' AND amount_minor_signed > 0) OR
         (event_type = 'payment' AND amount_minor_signed < 0) OR
@@ -154,12 +195,27 @@ CREATE TABLE IF NOT EXISTS monthly_cap_changes (
     old_amount_minor INTEGER,
     new_amount_minor INTEGER NOT NULL,
     currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
-    changed_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+    changed_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    category_id INTEGER,
+    copied_from_month_key TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_monthly_cap_changes_month_changed
     ON monthly_cap_changes (month_key, changed_at_utc);
 
+CREATE TABLE IF NOT EXISTS monthly_category_caps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    month_key TEXT NOT NULL,
+    category_id INTEGER NOT NULL REFERENCES categories(id),
+    amount_minor INTEGER NOT NULL,
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_monthly_category_caps_month_category
+    ON monthly_category_caps (month_key, category_id);
+
 CREATE TABLE IF NOT EXISTS savings_events (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     event_type TEXT NOT NULL CHECK (event_type IN ('transfer_to_savings', 'independent_add')),
@@ -248,7 +304,16 @@ CREATE TABLE IF NOT EXISTS settings (
     orphan_spending_threshold_bps INTEGER NOT NULL DEFAULT 500,
     onboarding_completed_at_utc TEXT,
     created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
-    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    amount_format TEXT NOT NULL DEFAULT 'dot_decimal' CHECK (amount_format IN ('dot_decimal', 'comma_decimal')),
+    default_output TEXT CHECK (default_output IS NULL OR default_output IN ('human', 'json')),
+    default_card_id INTEGER REFERENCES cards(id),
+    fiscal_month_start_day INTEGER NOT NULL DEFAULT 1 CHECK (fiscal_month_start_day BETWEEN 1 AND 28),
+    default_recorded_by TEXT,
+    auto_snapshot INTEGER NOT NULL DEFAULT 1 CHECK (auto_snapshot IN (0, 1)),
+    fx_cache_ttl_hours INTEGER NOT NULL DEFAULT 24 CHECK (fx_cache_ttl_hours BETWEEN 0 AND 8760),
+    fx_stale_after_days INTEGER NOT NULL DEFAULT 7 CHECK (fx_stale_after_days BETWEEN 1 AND 3650),
+    report_cache INTEGER NOT NULL DEFAULT 0 CHECK (report_cache IN (0, 1))
 );
 
 CREATE TABLE IF NOT EXISTS fx_rate_snapshots (
@@ -277,3 +342,232 @@ CREATE TABLE IF NOT EXISTS audit_events (
 
 CREATE INDEX IF NOT EXISTS idx_audit_events_entity_time
     ON audit_events (entity_type, entity_id, created_at_utc);
+
+CREATE TABLE IF NOT EXISTS inflation_index_points (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    index_date TEXT NOT NULL,
+    index_value TEXT NOT NULL,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inflation_index_points_currency_date
+    ON inflation_index_points (currency_code, index_date);
+
+CREATE TABLE IF NOT EXISTS custom_currencies (
+    code TEXT PRIMARY KEY CHECK (length(code) = 3),
+    name TEXT,
+    minor_unit INTEGER NOT NULL CHECK (minor_unit BETWEEN 0 AND 18),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS orphan_spending_thresholds (
+    currency_code TEXT PRIMARY KEY CHECK (currency_code = '*' OR length(currency_code) = 3),
+    mode TEXT NOT NULL CHECK (mode IN ('percent', 'absolute', 'off')),
+    percent_bps INTEGER CHECK (percent_bps IS NULL OR percent_bps > 0),
+    amount_minor INTEGER CHECK (amount_minor IS NULL OR amount_minor > 0),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    CHECK (
+        (mode = 'percent' AND percent_bps IS NOT NULL AND amount_minor IS NULL)
+        OR (mode = 'absolute' AND amount_minor IS NOT NULL AND percent_bps IS NULL AND currency_code <> '*')
+        OR (mode = 'off' AND percent_bps IS NULL AND amount_minor IS NULL)
+    )
+);
+
+CREATE TABLE IF NOT EXISTS operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('running', 'completed', 'failed', 'cancelled')),
+    params_json TEXT NOT NULL DEFAULT '{}',
+    total INTEGER NOT NULL DEFAULT 0 CHECK (total >= 0),
+    done INTEGER NOT NULL DEFAULT 0 CHECK (done >= 0),
+    checkpoint TEXT NOT NULL DEFAULT '',
+    last_error TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    finished_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_status_id
+    ON operations (status, id);
+
+CREATE TABLE IF NOT EXISTS strict_warning_codes (
+    code TEXT PRIMARY KEY CHECK (code IN ('CAP_EXCEEDED', 'CATEGORY_CAP_EXCEEDED', 'FX_ESTIMATE_USED')),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS report_schedule_runs (
+    job_name TEXT PRIMARY KEY,
+    period_key TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    last_run_at_utc TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS hooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event TEXT NOT NULL CHECK (event IN ('cap_exceeded', 'card_due', 'import_completed')),
+    kind TEXT NOT NULL CHECK (kind IN ('command', 'http')),
+    target TEXT NOT NULL CHECK (length(trim(target)) > 0),
+    within_days INTEGER CHECK (within_days IS NULL OR within_days >= 0),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS card_monthly_limits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id INTEGER NOT NULL REFERENCES cards(id) ON DELETE RESTRICT,
+    month_key TEXT NOT NULL,
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_card_monthly_limits_card_month
+    ON card_monthly_limits (card_id, month_key);
+
+CREATE TABLE IF NOT EXISTS entry_splits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    person TEXT NOT NULL CHECK (length(trim(person)) > 0),
+    share_bps INTEGER NOT NULL CHECK (share_bps > 0 AND share_bps <= 10000),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_splits_transaction_active
+    ON entry_splits (transaction_id)
+    WHERE deleted_at_utc IS NULL;
+
+CREATE TABLE IF NOT EXISTS settlements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_person TEXT NOT NULL CHECK (length(trim(from_person)) > 0),
+    to_person TEXT NOT NULL CHECK (length(trim(to_person)) > 0),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    settled_at_utc TEXT NOT NULL,
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_settlements_currency_active
+    ON settlements (currency_code, settled_at_utc, id)
+    WHERE deleted_at_utc IS NULL;
+
+CREATE TABLE IF NOT EXISTS report_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    month_key TEXT NOT NULL CHECK (length(month_key) = 7),
+    report_json TEXT NOT NULL,
+    frozen_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_snapshots_month_active
+    ON report_snapshots (month_key)
+    WHERE deleted_at_utc IS NULL;
+
+CREATE TABLE IF NOT EXISTS transaction_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    state_json TEXT NOT NULL CHECK (json_valid(state_json)),
+    replaced_at_utc TEXT NOT NULL,
+    UNIQUE (transaction_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    snapshot_date TEXT NOT NULL CHECK (length(snapshot_date) = 10),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    assets_minor INTEGER NOT NULL,
+    liabilities_minor INTEGER NOT NULL,
+    net_worth_minor INTEGER NOT NULL,
+    recorded_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (snapshot_date, currency_code)
+);
+
+CREATE TABLE IF NOT EXISTS assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('asset', 'liability')),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    balance_minor INTEGER NOT NULL CHECK (balance_minor >= 0),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assets_name_active
+    ON assets (lower(name))
+    WHERE deleted_at_utc IS NULL;
+
+CREATE TABLE IF NOT EXISTS loans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT,
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    principal_minor INTEGER NOT NULL CHECK (principal_minor > 0),
+    annual_rate_bp INTEGER NOT NULL CHECK (annual_rate_bp >= 0 AND annual_rate_bp <= 10000),
+    term_months INTEGER NOT NULL CHECK (term_months > 0),
+    start_month TEXT NOT NULL CHECK (length(start_month) = 7),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE TABLE IF NOT EXISTS loan_payments (
+    transaction_id INTEGER PRIMARY KEY REFERENCES transactions(id) ON DELETE CASCADE,
+    loan_id INTEGER NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_payments_loan
+    ON loan_payments (loan_id);
+
+CREATE TABLE IF NOT EXISTS envelopes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    budget_minor INTEGER NOT NULL CHECK (budget_minor > 0),
+    start_date TEXT NOT NULL CHECK (length(start_date) = 10),
+    end_date TEXT NOT NULL CHECK (length(end_date) = 10 AND end_date >= start_date),
+    label_id INTEGER NOT NULL REFERENCES labels(id),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE TABLE IF NOT EXISTS card_aliases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id INTEGER NOT NULL REFERENCES cards(id),
+    alias TEXT NOT NULL CHECK (length(trim(alias)) > 0),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_card_aliases_card_alias
+    ON card_aliases (card_id, lower(alias));
+
+CREATE TABLE IF NOT EXISTS data_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL DEFAULT 0 CHECK (version >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS report_cache (
+    cache_key TEXT PRIMARY KEY,
+    data_version INTEGER NOT NULL,
+    payload_json TEXT NOT NULL,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS entry_month_totals (
+    month_key TEXT NOT NULL CHECK (length(month_key) = 7),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    income_minor INTEGER NOT NULL DEFAULT 0,
+    expense_minor INTEGER NOT NULL DEFAULT 0,
+    entry_count INTEGER NOT NULL DEFAULT 0 CHECK (entry_count >= 0),
+    PRIMARY KEY (month_key, currency_code)
+);
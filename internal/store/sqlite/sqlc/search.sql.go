@@ -0,0 +1,205 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: search.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const searchCardsByText = `-- name: SearchCardsByText :many
+SELECT id, nickname, description, last4, card_type
+FROM cards
+WHERE deleted_at_utc IS NULL
+  AND (
+      instr(lower(nickname), lower(?1)) > 0
+      OR (description IS NOT NULL AND instr(lower(description), lower(?1)) > 0)
+  )
+ORDER BY lower(nickname), id
+LIMIT ?2
+`
+
+type SearchCardsByTextParams struct {
+	SearchText string `json:"search_text"`
+	LimitRows  int64  `json:"limit_rows"`
+}
+
+type SearchCardsByTextRow struct {
+	ID          int64          `json:"id"`
+	Nickname    string         `json:"nickname"`
+	Description sql.NullString `json:"description"`
+	Last4       string         `json:"last4"`
+	CardType    string         `json:"card_type"`
+}
+
+func (q *Queries) SearchCardsByText(ctx context.Context, arg SearchCardsByTextParams) ([]SearchCardsByTextRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchCardsByText, arg.SearchText, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchCardsByTextRow
+	for rows.Next() {
+		var i SearchCardsByTextRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nickname,
+			&i.Description,
+			&i.Last4,
+			&i.CardType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchCategoriesByName = `-- name: SearchCategoriesByName :many
+SELECT id, name, archived_at_utc
+FROM categories
+WHERE deleted_at_utc IS NULL
+  AND instr(lower(name), lower(?1)) > 0
+ORDER BY lower(name), id
+LIMIT ?2
+`
+
+type SearchCategoriesByNameParams struct {
+	SearchText string `json:"search_text"`
+	LimitRows  int64  `json:"limit_rows"`
+}
+
+type SearchCategoriesByNameRow struct {
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+}
+
+func (q *Queries) SearchCategoriesByName(ctx context.Context, arg SearchCategoriesByNameParams) ([]SearchCategoriesByNameRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchCategoriesByName, arg.SearchText, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchCategoriesByNameRow
+	for rows.Next() {
+		var i SearchCategoriesByNameRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.ArchivedAtUtc); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchEntriesByNote = `-- name: SearchEntriesByNote :many
+SELECT id, type, amount_minor, currency_code, transaction_date_utc, note
+FROM transactions
+WHERE deleted_at_utc IS NULL
+  AND note IS NOT NULL
+  AND instr(lower(note), lower(?1)) > 0
+ORDER BY transaction_date_utc DESC, id DESC
+LIMIT ?2
+`
+
+type SearchEntriesByNoteParams struct {
+	SearchText string `json:"search_text"`
+	LimitRows  int64  `json:"limit_rows"`
+}
+
+type SearchEntriesByNoteRow struct {
+	ID                 int64          `json:"id"`
+	Type               string         `json:"type"`
+	AmountMinor        int64          `json:"amount_minor"`
+	CurrencyCode       string         `json:"currency_code"`
+	TransactionDateUtc string         `json:"transaction_date_utc"`
+	Note               sql.NullString `json:"note"`
+}
+
+func (q *Queries) SearchEntriesByNote(ctx context.Context, arg SearchEntriesByNoteParams) ([]SearchEntriesByNoteRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchEntriesByNote, arg.SearchText, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchEntriesByNoteRow
+	for rows.Next() {
+		var i SearchEntriesByNoteRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.TransactionDateUtc,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchLabelsByName = `-- name: SearchLabelsByName :many
+SELECT id, name, archived_at_utc
+FROM labels
+WHERE deleted_at_utc IS NULL
+  AND instr(lower(name), lower(?1)) > 0
+ORDER BY lower(name), id
+LIMIT ?2
+`
+
+type SearchLabelsByNameParams struct {
+	SearchText string `json:"search_text"`
+	LimitRows  int64  `json:"limit_rows"`
+}
+
+type SearchLabelsByNameRow struct {
+	ID            int64          `json:"id"`
+	Name          string         `json:"name"`
+	ArchivedAtUtc sql.NullString `json:"archived_at_utc"`
+}
+
+func (q *Queries) SearchLabelsByName(ctx context.Context, arg SearchLabelsByNameParams) ([]SearchLabelsByNameRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchLabelsByName, arg.SearchText, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchLabelsByNameRow
+	for rows.Next() {
+		var i SearchLabelsByNameRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.ArchivedAtUtc); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
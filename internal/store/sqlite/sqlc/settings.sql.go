@@ -10,6 +10,24 @@ import (
 	"database/sql"
 )
 
+const deleteOrphanSpendingThreshold = `-- name: DeleteOrphanSpendingThreshold :execresult
+DELETE FROM orphan_spending_thresholds
+WHERE currency_code = ?
+`
+
+func (q *Queries) DeleteOrphanSpendingThreshold(ctx context.Context, currencyCode string) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteOrphanSpendingThreshold, currencyCode)
+}
+
+const deleteStrictWarningCodes = `-- name: DeleteStrictWarningCodes :exec
+DELETE FROM strict_warning_codes
+`
+
+func (q *Queries) DeleteStrictWarningCodes(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteStrictWarningCodes)
+	return err
+}
+
 const getSettings = `-- name: GetSettings :one
 SELECT id,
        default_currency_code,
@@ -18,7 +36,16 @@ SELECT id,
        orphan_spending_threshold_bps,
        onboarding_completed_at_utc,
        created_at_utc,
-       updated_at_utc
+       updated_at_utc,
+       amount_format,
+       default_output,
+       default_card_id,
+       fiscal_month_start_day,
+       default_recorded_by,
+       auto_snapshot,
+       fx_cache_ttl_hours,
+       fx_stale_after_days,
+       report_cache
 FROM settings
 WHERE id = 1
 `
@@ -35,10 +62,230 @@ func (q *Queries) GetSettings(ctx context.Context) (Setting, error) {
 		&i.OnboardingCompletedAtUtc,
 		&i.CreatedAtUtc,
 		&i.UpdatedAtUtc,
+		&i.AmountFormat,
+		&i.DefaultOutput,
+		&i.DefaultCardID,
+		&i.FiscalMonthStartDay,
+		&i.DefaultRecordedBy,
+		&i.AutoSnapshot,
+		&i.FxCacheTtlHours,
+		&i.FxStaleAfterDays,
+		&i.ReportCache,
 	)
 	return i, err
 }
 
+const insertStrictWarningCode = `-- name: InsertStrictWarningCode :exec
+INSERT INTO strict_warning_codes (code) VALUES (?)
+`
+
+func (q *Queries) InsertStrictWarningCode(ctx context.Context, code string) error {
+	_, err := q.db.ExecContext(ctx, insertStrictWarningCode, code)
+	return err
+}
+
+const listOrphanSpendingThresholds = `-- name: ListOrphanSpendingThresholds :many
+SELECT currency_code, mode, percent_bps, amount_minor, created_at_utc, updated_at_utc
+FROM orphan_spending_thresholds
+ORDER BY currency_code
+`
+
+func (q *Queries) ListOrphanSpendingThresholds(ctx context.Context) ([]OrphanSpendingThreshold, error) {
+	rows, err := q.db.QueryContext(ctx, listOrphanSpendingThresholds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrphanSpendingThreshold
+	for rows.Next() {
+		var i OrphanSpendingThreshold
+		if err := rows.Scan(
+			&i.CurrencyCode,
+			&i.Mode,
+			&i.PercentBps,
+			&i.AmountMinor,
+			&i.CreatedAtUtc,
+			&i.UpdatedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReportScheduleRuns = `-- name: ListReportScheduleRuns :many
+SELECT job_name, period_key, file_path, last_run_at_utc
+FROM report_schedule_runs
+ORDER BY job_name
+`
+
+func (q *Queries) ListReportScheduleRuns(ctx context.Context) ([]ReportScheduleRun, error) {
+	rows, err := q.db.QueryContext(ctx, listReportScheduleRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReportScheduleRun
+	for rows.Next() {
+		var i ReportScheduleRun
+		if err := rows.Scan(
+			&i.JobName,
+			&i.PeriodKey,
+			&i.FilePath,
+			&i.LastRunAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStrictWarningCodes = `-- name: ListStrictWarningCodes :many
+SELECT code
+FROM strict_warning_codes
+ORDER BY code
+`
+
+func (q *Queries) ListStrictWarningCodes(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listStrictWarningCodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		items = append(items, code)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSettingsPreferences = `-- name: UpdateSettingsPreferences :execresult
+UPDATE settings
+SET default_output = ?,
+    default_card_id = ?,
+    fiscal_month_start_day = ?,
+    default_recorded_by = ?,
+    auto_snapshot = ?,
+    fx_cache_ttl_hours = ?,
+    fx_stale_after_days = ?,
+    report_cache = ?,
+    updated_at_utc = ?
+WHERE id = 1
+`
+
+type UpdateSettingsPreferencesParams struct {
+	DefaultOutput       sql.NullString `json:"default_output"`
+	DefaultCardID       sql.NullInt64  `json:"default_card_id"`
+	FiscalMonthStartDay int64          `json:"fiscal_month_start_day"`
+	DefaultRecordedBy   sql.NullString `json:"default_recorded_by"`
+	AutoSnapshot        int64          `json:"auto_snapshot"`
+	FxCacheTtlHours     int64          `json:"fx_cache_ttl_hours"`
+	FxStaleAfterDays    int64          `json:"fx_stale_after_days"`
+	ReportCache         int64          `json:"report_cache"`
+	UpdatedAtUtc        string         `json:"updated_at_utc"`
+}
+
+func (q *Queries) UpdateSettingsPreferences(ctx context.Context, arg UpdateSettingsPreferencesParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateSettingsPreferences,
+		arg.DefaultOutput,
+		arg.DefaultCardID,
+		arg.FiscalMonthStartDay,
+		arg.DefaultRecordedBy,
+		arg.AutoSnapshot,
+		arg.FxCacheTtlHours,
+		arg.FxStaleAfterDays,
+		arg.ReportCache,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const upsertOrphanSpendingThreshold = `-- name: UpsertOrphanSpendingThreshold :execresult
+INSERT INTO orphan_spending_thresholds (
+    currency_code,
+    mode,
+    percent_bps,
+    amount_minor,
+    updated_at_utc
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(currency_code) DO UPDATE SET
+    mode = excluded.mode,
+    percent_bps = excluded.percent_bps,
+    amount_minor = excluded.amount_minor,
+    updated_at_utc = excluded.updated_at_utc
+`
+
+type UpsertOrphanSpendingThresholdParams struct {
+	CurrencyCode string        `json:"currency_code"`
+	Mode         string        `json:"mode"`
+	PercentBps   sql.NullInt64 `json:"percent_bps"`
+	AmountMinor  sql.NullInt64 `json:"amount_minor"`
+	UpdatedAtUtc string        `json:"updated_at_utc"`
+}
+
+func (q *Queries) UpsertOrphanSpendingThreshold(ctx context.Context, arg UpsertOrphanSpendingThresholdParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, upsertOrphanSpendingThreshold,
+		arg.CurrencyCode,
+		arg.Mode,
+		arg.PercentBps,
+		arg.AmountMinor,
+		arg.UpdatedAtUtc,
+	)
+}
+
+const upsertReportScheduleRun = `-- name: UpsertReportScheduleRun :exec
+INSERT INTO report_schedule_runs (
+    job_name,
+    period_key,
+    file_path,
+    last_run_at_utc
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(job_name) DO UPDATE SET
+    period_key = excluded.period_key,
+    file_path = excluded.file_path,
+    last_run_at_utc = excluded.last_run_at_utc
+`
+
+type UpsertReportScheduleRunParams struct {
+	JobName      string `json:"job_name"`
+	PeriodKey    string `json:"period_key"`
+	FilePath     string `json:"file_path"`
+	LastRunAtUtc string `json:"last_run_at_utc"`
+}
+
+func (q *Queries) UpsertReportScheduleRun(ctx context.Context, arg UpsertReportScheduleRunParams) error {
+	_, err := q.db.ExecContext(ctx, upsertReportScheduleRun,
+		arg.JobName,
+		arg.PeriodKey,
+		arg.FilePath,
+		arg.LastRunAtUtc,
+	)
+	return err
+}
+
 const upsertSettings = `-- name: UpsertSettings :execresult
 INSERT INTO settings (
     id,
@@ -47,14 +294,16 @@ INSERT INTO settings (
     orphan_count_threshold,
     orphan_spending_threshold_bps,
     onboarding_completed_at_utc,
+    amount_format,
     updated_at_utc
-) VALUES (1, ?, ?, ?, ?, ?, ?)
+) VALUES (1, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     default_currency_code = excluded.default_currency_code,
     display_timezone = excluded.display_timezone,
     orphan_count_threshold = excluded.orphan_count_threshold,
     orphan_spending_threshold_bps = excluded.orphan_spending_threshold_bps,
     onboarding_completed_at_utc = excluded.onboarding_completed_at_utc,
+    amount_format = excluded.amount_format,
     updated_at_utc = excluded.updated_at_utc
 `
 
@@ -64,6 +313,7 @@ type UpsertSettingsParams struct {
 	OrphanCountThreshold       int64          `json:"orphan_count_threshold"`
 	OrphanSpendingThresholdBps int64          `json:"orphan_spending_threshold_bps"`
 	OnboardingCompletedAtUtc   sql.NullString `json:"onboarding_completed_at_utc"`
+	AmountFormat               string         `json:"amount_format"`
 	UpdatedAtUtc               string         `json:"updated_at_utc"`
 }
 
@@ -74,6 +324,7 @@ func (q *Queries) UpsertSettings(ctx context.Context, arg UpsertSettingsParams)
 		arg.OrphanCountThreshold,
 		arg.OrphanSpendingThresholdBps,
 		arg.OnboardingCompletedAtUtc,
+		arg.AmountFormat,
 		arg.UpdatedAtUtc,
 	)
 }
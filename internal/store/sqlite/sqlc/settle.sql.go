@@ -0,0 +1,214 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: settle.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+const addEntrySplit = `-- name: AddEntrySplit :execresult
+INSERT INTO entry_splits (transaction_id, person, share_bps)
+VALUES (?, ?, ?)
+`
+
+type AddEntrySplitParams struct {
+	TransactionID int64  `json:"transaction_id"`
+	Person        string `json:"person"`
+	ShareBps      int64  `json:"share_bps"`
+}
+
+func (q *Queries) AddEntrySplit(ctx context.Context, arg AddEntrySplitParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, addEntrySplit, arg.TransactionID, arg.Person, arg.ShareBps)
+}
+
+const createSettlement = `-- name: CreateSettlement :execresult
+INSERT INTO settlements (from_person, to_person, amount_minor, currency_code, settled_at_utc, note)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateSettlementParams struct {
+	FromPerson   string         `json:"from_person"`
+	ToPerson     string         `json:"to_person"`
+	AmountMinor  int64          `json:"amount_minor"`
+	CurrencyCode string         `json:"currency_code"`
+	SettledAtUtc string         `json:"settled_at_utc"`
+	Note         sql.NullString `json:"note"`
+}
+
+func (q *Queries) CreateSettlement(ctx context.Context, arg CreateSettlementParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createSettlement,
+		arg.FromPerson,
+		arg.ToPerson,
+		arg.AmountMinor,
+		arg.CurrencyCode,
+		arg.SettledAtUtc,
+		arg.Note,
+	)
+}
+
+const getSettlementByID = `-- name: GetSettlementByID :one
+SELECT id, from_person, to_person, amount_minor, currency_code, settled_at_utc, note, created_at_utc, deleted_at_utc
+FROM settlements
+WHERE id = ? AND deleted_at_utc IS NULL
+`
+
+func (q *Queries) GetSettlementByID(ctx context.Context, id int64) (Settlement, error) {
+	row := q.db.QueryRowContext(ctx, getSettlementByID, id)
+	var i Settlement
+	err := row.Scan(
+		&i.ID,
+		&i.FromPerson,
+		&i.ToPerson,
+		&i.AmountMinor,
+		&i.CurrencyCode,
+		&i.SettledAtUtc,
+		&i.Note,
+		&i.CreatedAtUtc,
+		&i.DeletedAtUtc,
+	)
+	return i, err
+}
+
+const listActiveEntrySplits = `-- name: ListActiveEntrySplits :many
+SELECT id, transaction_id, person, share_bps, created_at_utc, deleted_at_utc
+FROM entry_splits
+WHERE transaction_id = ? AND deleted_at_utc IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListActiveEntrySplits(ctx context.Context, transactionID int64) ([]EntrySplit, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveEntrySplits, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EntrySplit
+	for rows.Next() {
+		var i EntrySplit
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Person,
+			&i.ShareBps,
+			&i.CreatedAtUtc,
+			&i.DeletedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveSettlements = `-- name: ListActiveSettlements :many
+SELECT id, from_person, to_person, amount_minor, currency_code, settled_at_utc, note, created_at_utc, deleted_at_utc
+FROM settlements
+WHERE deleted_at_utc IS NULL
+  AND (?1 IS NULL OR currency_code = ?1)
+ORDER BY settled_at_utc, id
+`
+
+func (q *Queries) ListActiveSettlements(ctx context.Context, currencyCode interface{}) ([]Settlement, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveSettlements, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Settlement
+	for rows.Next() {
+		var i Settlement
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromPerson,
+			&i.ToPerson,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.SettledAtUtc,
+			&i.Note,
+			&i.CreatedAtUtc,
+			&i.DeletedAtUtc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSharedExpenseShares = `-- name: ListSharedExpenseShares :many
+SELECT t.id AS transaction_id,
+       t.recorded_by,
+       CAST(t.amount_minor - COALESCE((
+           SELECT SUM(r.amount_minor)
+           FROM transactions r
+           WHERE r.refund_of_transaction_id = t.id
+             AND r.deleted_at_utc IS NULL
+       ), 0) AS INTEGER) AS amount_minor,
+       t.currency_code,
+       s.person,
+       s.share_bps
+FROM entry_splits s
+INNER JOIN transactions t ON t.id = s.transaction_id
+WHERE s.deleted_at_utc IS NULL
+  AND t.deleted_at_utc IS NULL
+  AND t.type = 'expense'
+  AND t.refund_of_transaction_id IS NULL
+  AND t.recorded_by IS NOT NULL
+  AND (?1 IS NULL OR t.currency_code = ?1)
+ORDER BY t.id, s.id
+`
+
+type ListSharedExpenseSharesRow struct {
+	TransactionID int64          `json:"transaction_id"`
+	RecordedBy    sql.NullString `json:"recorded_by"`
+	AmountMinor   int64          `json:"amount_minor"`
+	CurrencyCode  string         `json:"currency_code"`
+	Person        string         `json:"person"`
+	ShareBps      int64          `json:"share_bps"`
+}
+
+func (q *Queries) ListSharedExpenseShares(ctx context.Context, currencyCode interface{}) ([]ListSharedExpenseSharesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSharedExpenseShares, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSharedExpenseSharesRow
+	for rows.Next() {
+		var i ListSharedExpenseSharesRow
+		if err := rows.Scan(
+			&i.TransactionID,
+			&i.RecordedBy,
+			&i.AmountMinor,
+			&i.CurrencyCode,
+			&i.Person,
+			&i.ShareBps,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,117 @@
+// Package telegram is a minimal Telegram Bot API client: long-polling for
+// messages and sending plain-text replies.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const BaseURL = "https://api.telegram.org"
+
+var ErrTokenRequired = errors.New("telegram bot token is required")
+
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text"`
+}
+
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the bot with token. baseURL defaults to
+// BaseURL; the HTTP client needs a timeout longer than the long-poll wait.
+func NewClient(baseURL, token string, httpClient *http.Client) (*Client, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrTokenRequired
+	}
+	if strings.TrimSpace(baseURL) == "" {
+		baseURL = BaseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 90 * time.Second}
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), token: token, httpClient: httpClient}, nil
+}
+
+// GetUpdates returns the updates after offset-1, waiting up to wait for one
+// to arrive. Passing the last update_id + 1 as offset confirms the earlier
+// ones so they are not delivered again.
+func (c *Client) GetUpdates(ctx context.Context, offset int64, wait time.Duration) ([]Update, error) {
+	var updates []Update
+	err := c.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         int(wait.Seconds()),
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	return updates, err
+}
+
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	return c.call(ctx, "sendMessage", map[string]any{"chat_id": chatID, "text": text}, nil)
+}
+
+func (c *Client) call(ctx context.Context, method string, params map[string]any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	started := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The request URL carries the token; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	slog.DebugContext(ctx, "telegram request", "method", method, "status", resp.StatusCode, "duration_ms", time.Since(started).Milliseconds())
+
+	var payload struct {
+		OK          bool            `json:"ok"`
+		Result      json.RawMessage `json:"result"`
+		Description string          `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("telegram %s: response status %d", method, resp.StatusCode)
+	}
+	if !payload.OK {
+		return fmt.Errorf("telegram %s: %s", method, payload.Description)
+	}
+	if result != nil {
+		if err := json.Unmarshal(payload.Result, result); err != nil {
+			return fmt.Errorf("telegram %s: decode result: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS custom_currencies (
+    code TEXT PRIMARY KEY CHECK (length(code) = 3),
+    name TEXT,
+    minor_unit INTEGER NOT NULL CHECK (minor_unit BETWEEN 0 AND 18),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS custom_currencies;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE settings
+    ADD COLUMN amount_format TEXT NOT NULL DEFAULT 'dot_decimal'
+    CHECK (amount_format IN ('dot_decimal', 'comma_decimal'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE settings DROP COLUMN amount_format;
+
+-- +goose StatementEnd
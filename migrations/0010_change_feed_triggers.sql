@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_transactions_update
+AFTER UPDATE ON transactions
+WHEN OLD.deleted_at_utc IS NULL AND NEW.deleted_at_utc IS NULL
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'update',
+        'entry',
+        CAST(NEW.id AS TEXT),
+        'db_trigger',
+        json_object(
+            'type', NEW.type,
+            'amount_minor', NEW.amount_minor,
+            'currency_code', NEW.currency_code,
+            'transaction_date_utc', NEW.transaction_date_utc,
+            'category_id', NEW.category_id
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_credit_liability_payment_insert
+AFTER INSERT ON credit_liability_events
+WHEN NEW.event_type = 'payment'
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'payment',
+        'card',
+        CAST(NEW.card_id AS TEXT),
+        'db_trigger',
+        json_object(
+            'liability_event_id', NEW.id,
+            'amount_minor', -NEW.amount_minor_signed,
+            'currency_code', NEW.currency_code,
+            'note', NEW.note
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_audit_credit_liability_payment_insert;
+DROP TRIGGER IF EXISTS trg_audit_transactions_update;
+
+-- +goose StatementEnd
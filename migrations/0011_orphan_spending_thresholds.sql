@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS orphan_spending_thresholds (
+    currency_code TEXT PRIMARY KEY CHECK (currency_code = '*' OR length(currency_code) = 3),
+    mode TEXT NOT NULL CHECK (mode IN ('percent', 'absolute', 'off')),
+    percent_bps INTEGER CHECK (percent_bps IS NULL OR percent_bps > 0),
+    amount_minor INTEGER CHECK (amount_minor IS NULL OR amount_minor > 0),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    CHECK (
+        (mode = 'percent' AND percent_bps IS NOT NULL AND amount_minor IS NULL)
+        OR (mode = 'absolute' AND amount_minor IS NOT NULL AND percent_bps IS NULL AND currency_code <> '*')
+        OR (mode = 'off' AND percent_bps IS NULL AND amount_minor IS NULL)
+    )
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS orphan_spending_thresholds;
+
+-- +goose StatementEnd
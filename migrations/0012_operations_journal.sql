@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('running', 'completed', 'failed', 'cancelled')),
+    params_json TEXT NOT NULL DEFAULT '{}',
+    total INTEGER NOT NULL DEFAULT 0 CHECK (total >= 0),
+    done INTEGER NOT NULL DEFAULT 0 CHECK (done >= 0),
+    checkpoint TEXT NOT NULL DEFAULT '',
+    last_error TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    finished_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_status_id
+    ON operations (status, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_operations_status_id;
+DROP TABLE IF EXISTS operations;
+
+-- +goose StatementEnd
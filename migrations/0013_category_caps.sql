@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS monthly_category_caps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    month_key TEXT NOT NULL,
+    category_id INTEGER NOT NULL REFERENCES categories(id),
+    amount_minor INTEGER NOT NULL,
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_monthly_category_caps_month_category
+    ON monthly_category_caps (month_key, category_id);
+
+ALTER TABLE monthly_cap_changes ADD COLUMN category_id INTEGER;
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_monthly_category_caps_insert
+AFTER INSERT ON monthly_category_caps
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'create',
+        'monthly_category_cap',
+        CAST(NEW.id AS TEXT),
+        'db_trigger',
+        json_object(
+            'month_key', NEW.month_key,
+            'category_id', NEW.category_id,
+            'amount_minor', NEW.amount_minor,
+            'currency_code', NEW.currency_code
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_monthly_category_caps_update
+AFTER UPDATE ON monthly_category_caps
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'update',
+        'monthly_category_cap',
+        CAST(NEW.id AS TEXT),
+        'db_trigger',
+        json_object(
+            'month_key', NEW.month_key,
+            'category_id', NEW.category_id,
+            'old_amount_minor', OLD.amount_minor,
+            'new_amount_minor', NEW.amount_minor,
+            'currency_code', NEW.currency_code
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_audit_monthly_category_caps_update;
+DROP TRIGGER IF EXISTS trg_audit_monthly_category_caps_insert;
+ALTER TABLE monthly_cap_changes DROP COLUMN category_id;
+DROP TABLE IF EXISTS monthly_category_caps;
+
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE monthly_cap_changes ADD COLUMN copied_from_month_key TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE monthly_cap_changes DROP COLUMN copied_from_month_key;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS strict_warning_codes (
+    code TEXT PRIMARY KEY CHECK (code IN ('CAP_EXCEEDED', 'CATEGORY_CAP_EXCEEDED', 'FX_ESTIMATE_USED')),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS strict_warning_codes;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS report_schedule_runs (
+    job_name TEXT PRIMARY KEY,
+    period_key TEXT NOT NULL,
+    file_path TEXT NOT NULL,
+    last_run_at_utc TEXT NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS report_schedule_runs;
+
+-- +goose StatementEnd
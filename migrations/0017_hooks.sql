@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS hooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event TEXT NOT NULL CHECK (event IN ('cap_exceeded', 'card_due', 'import_completed')),
+    kind TEXT NOT NULL CHECK (kind IN ('command', 'http')),
+    target TEXT NOT NULL CHECK (length(trim(target)) > 0),
+    within_days INTEGER CHECK (within_days IS NULL OR within_days >= 0),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS hooks;
+
+-- +goose StatementEnd
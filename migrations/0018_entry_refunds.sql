@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN refund_of_transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_refund_of
+    ON transactions (refund_of_transaction_id)
+    WHERE deleted_at_utc IS NULL AND refund_of_transaction_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transactions_refund_of;
+ALTER TABLE transactions DROP COLUMN refund_of_transaction_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN payee TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_payee_date
+    ON transactions (payee COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND payee IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transactions_payee_date;
+ALTER TABLE transactions DROP COLUMN payee;
+
+-- +goose StatementEnd
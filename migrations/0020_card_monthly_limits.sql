@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS card_monthly_limits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id INTEGER NOT NULL REFERENCES cards(id) ON DELETE RESTRICT,
+    month_key TEXT NOT NULL,
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_card_monthly_limits_card_month
+    ON card_monthly_limits (card_id, month_key);
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_card_monthly_limits_insert
+AFTER INSERT ON card_monthly_limits
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'create',
+        'card_monthly_limit',
+        CAST(NEW.id AS TEXT),
+        'db_trigger',
+        json_object(
+            'card_id', NEW.card_id,
+            'month_key', NEW.month_key,
+            'amount_minor', NEW.amount_minor,
+            'currency_code', NEW.currency_code
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_audit_card_monthly_limits_update
+AFTER UPDATE ON card_monthly_limits
+BEGIN
+    INSERT INTO audit_events (action, entity_type, entity_id, source, payload_json, created_at_utc)
+    VALUES (
+        'update',
+        'card_monthly_limit',
+        CAST(NEW.id AS TEXT),
+        'db_trigger',
+        json_object(
+            'card_id', NEW.card_id,
+            'month_key', NEW.month_key,
+            'old_amount_minor', OLD.amount_minor,
+            'new_amount_minor', NEW.amount_minor,
+            'currency_code', NEW.currency_code
+        ),
+        strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+    );
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_audit_card_monthly_limits_update;
+DROP TRIGGER IF EXISTS trg_audit_card_monthly_limits_insert;
+DROP TABLE IF EXISTS card_monthly_limits;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE settings
+    ADD COLUMN default_output TEXT
+    CHECK (default_output IS NULL OR default_output IN ('human', 'json'));
+
+ALTER TABLE settings
+    ADD COLUMN default_card_id INTEGER REFERENCES cards(id);
+
+ALTER TABLE settings
+    ADD COLUMN fiscal_month_start_day INTEGER NOT NULL DEFAULT 1
+    CHECK (fiscal_month_start_day BETWEEN 1 AND 28);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE settings DROP COLUMN fiscal_month_start_day;
+ALTER TABLE settings DROP COLUMN default_card_id;
+ALTER TABLE settings DROP COLUMN default_output;
+
+-- +goose StatementEnd
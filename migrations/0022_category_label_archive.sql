@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE categories ADD COLUMN archived_at_utc TEXT;
+
+ALTER TABLE labels ADD COLUMN archived_at_utc TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE labels DROP COLUMN archived_at_utc;
+ALTER TABLE categories DROP COLUMN archived_at_utc;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN recorded_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_recorded_by_date
+    ON transactions (recorded_by COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND recorded_by IS NOT NULL;
+
+ALTER TABLE settings
+    ADD COLUMN default_recorded_by TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE settings DROP COLUMN default_recorded_by;
+DROP INDEX IF EXISTS idx_transactions_recorded_by_date;
+ALTER TABLE transactions DROP COLUMN recorded_by;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS entry_splits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    person TEXT NOT NULL CHECK (length(trim(person)) > 0),
+    share_bps INTEGER NOT NULL CHECK (share_bps > 0 AND share_bps <= 10000),
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_splits_transaction_active
+    ON entry_splits (transaction_id)
+    WHERE deleted_at_utc IS NULL;
+
+CREATE TABLE IF NOT EXISTS settlements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_person TEXT NOT NULL CHECK (length(trim(from_person)) > 0),
+    to_person TEXT NOT NULL CHECK (length(trim(to_person)) > 0),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    settled_at_utc TEXT NOT NULL,
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_settlements_currency_active
+    ON settlements (currency_code, settled_at_utc, id)
+    WHERE deleted_at_utc IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_settlements_currency_active;
+DROP TABLE IF EXISTS settlements;
+DROP INDEX IF EXISTS idx_entry_splits_transaction_active;
+DROP TABLE IF EXISTS entry_splits;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS report_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    month_key TEXT NOT NULL CHECK (length(month_key) = 7),
+    report_json TEXT NOT NULL,
+    frozen_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_snapshots_month_active
+    ON report_snapshots (month_key)
+    WHERE deleted_at_utc IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_report_snapshots_month_active;
+DROP TABLE IF EXISTS report_snapshots;
+
+-- +goose StatementEnd
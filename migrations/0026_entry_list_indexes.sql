@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Active entries in list order, so paged listing walks the index instead of
+-- sorting and type/category filters are checked before the table lookup. It
+-- replaces the (deleted_at_utc, transaction_date_utc) index, which only ever
+-- served active-entry scans.
+CREATE INDEX IF NOT EXISTS idx_transactions_active_date_type_category
+    ON transactions (transaction_date_utc, id, type, category_id)
+    WHERE deleted_at_utc IS NULL;
+
+DROP INDEX IF EXISTS idx_transactions_deleted_date;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_date
+    ON transactions (deleted_at_utc, transaction_date_utc);
+
+DROP INDEX IF EXISTS idx_transactions_active_date_type_category;
+
+-- +goose StatementEnd
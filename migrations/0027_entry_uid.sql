@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN uid TEXT;
+
+-- Random version-4 UUIDs for existing entries; new entries get theirs from the
+-- application.
+UPDATE transactions
+SET uid = lower(
+    hex(randomblob(4)) || '-' ||
+    hex(randomblob(2)) || '-4' ||
+    substr(hex(randomblob(2)), 2) || '-' ||
+    substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' ||
+    hex(randomblob(6))
+)
+WHERE uid IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_uid_active
+    ON transactions (uid)
+    WHERE deleted_at_utc IS NULL AND uid IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transactions_uid_active;
+ALTER TABLE transactions DROP COLUMN uid;
+
+-- +goose StatementEnd
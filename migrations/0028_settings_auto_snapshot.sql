@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE settings
+    ADD COLUMN auto_snapshot INTEGER NOT NULL DEFAULT 1
+    CHECK (auto_snapshot IN (0, 1));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE settings DROP COLUMN auto_snapshot;
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE transaction_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    state_json TEXT NOT NULL CHECK (json_valid(state_json)),
+    replaced_at_utc TEXT NOT NULL,
+    UNIQUE (transaction_id, revision)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE transaction_revisions;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN income_source TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_income_source_date
+    ON transactions (income_source COLLATE NOCASE, transaction_date_utc, id)
+    WHERE deleted_at_utc IS NULL AND income_source IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transactions_income_source_date;
+ALTER TABLE transactions DROP COLUMN income_source;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    snapshot_date TEXT NOT NULL CHECK (length(snapshot_date) = 10),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    assets_minor INTEGER NOT NULL,
+    liabilities_minor INTEGER NOT NULL,
+    net_worth_minor INTEGER NOT NULL,
+    recorded_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (snapshot_date, currency_code)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS net_worth_snapshots;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('asset', 'liability')),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    balance_minor INTEGER NOT NULL CHECK (balance_minor >= 0),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assets_name_active
+    ON assets (lower(name))
+    WHERE deleted_at_utc IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_assets_name_active;
+DROP TABLE IF EXISTS assets;
+
+-- +goose StatementEnd
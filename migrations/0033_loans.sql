@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS loans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT,
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    principal_minor INTEGER NOT NULL CHECK (principal_minor > 0),
+    annual_rate_bp INTEGER NOT NULL CHECK (annual_rate_bp >= 0 AND annual_rate_bp <= 10000),
+    term_months INTEGER NOT NULL CHECK (term_months > 0),
+    start_month TEXT NOT NULL CHECK (length(start_month) = 7),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+CREATE TABLE IF NOT EXISTS loan_payments (
+    transaction_id INTEGER PRIMARY KEY REFERENCES transactions(id) ON DELETE CASCADE,
+    loan_id INTEGER NOT NULL REFERENCES loans(id) ON DELETE CASCADE,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_payments_loan
+    ON loan_payments (loan_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_loan_payments_loan;
+DROP TABLE IF EXISTS loan_payments;
+DROP TABLE IF EXISTS loans;
+
+-- +goose StatementEnd
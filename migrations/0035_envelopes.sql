@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS envelopes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    budget_minor INTEGER NOT NULL CHECK (budget_minor > 0),
+    start_date TEXT NOT NULL CHECK (length(start_date) = 10),
+    end_date TEXT NOT NULL CHECK (length(end_date) = 10 AND end_date >= start_date),
+    label_id INTEGER NOT NULL REFERENCES labels(id),
+    note TEXT,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    deleted_at_utc TEXT
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS envelopes;
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE categories ADD COLUMN color TEXT;
+ALTER TABLE categories ADD COLUMN icon TEXT;
+
+ALTER TABLE labels ADD COLUMN color TEXT;
+ALTER TABLE labels ADD COLUMN icon TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE labels DROP COLUMN icon;
+ALTER TABLE labels DROP COLUMN color;
+ALTER TABLE categories DROP COLUMN icon;
+ALTER TABLE categories DROP COLUMN color;
+
+-- +goose StatementEnd
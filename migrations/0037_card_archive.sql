@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE cards ADD COLUMN archived_at_utc TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE cards DROP COLUMN archived_at_utc;
+
+-- +goose StatementEnd
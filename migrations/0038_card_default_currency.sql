@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE cards ADD COLUMN default_currency_code TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE cards DROP COLUMN default_currency_code;
+
+-- +goose StatementEnd
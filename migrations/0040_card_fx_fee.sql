@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE cards ADD COLUMN fx_fee_bp INTEGER;
+ALTER TABLE credit_liability_events ADD COLUMN fx_fee_minor INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE credit_liability_events DROP COLUMN fx_fee_minor;
+ALTER TABLE cards DROP COLUMN fx_fee_bp;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE transactions
+    ADD COLUMN original_amount_minor INTEGER;
+
+ALTER TABLE transactions
+    ADD COLUMN original_currency_code TEXT;
+
+ALTER TABLE transactions
+    ADD COLUMN fx_rate TEXT;
+
+ALTER TABLE transactions
+    ADD COLUMN fx_rate_date TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE transactions DROP COLUMN fx_rate_date;
+ALTER TABLE transactions DROP COLUMN fx_rate;
+ALTER TABLE transactions DROP COLUMN original_currency_code;
+ALTER TABLE transactions DROP COLUMN original_amount_minor;
+
+-- +goose StatementEnd
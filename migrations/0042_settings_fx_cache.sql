@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE settings
+    ADD COLUMN fx_cache_ttl_hours INTEGER NOT NULL DEFAULT 24
+    CHECK (fx_cache_ttl_hours BETWEEN 0 AND 8760);
+
+ALTER TABLE settings
+    ADD COLUMN fx_stale_after_days INTEGER NOT NULL DEFAULT 7
+    CHECK (fx_stale_after_days BETWEEN 1 AND 3650);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE settings DROP COLUMN fx_stale_after_days;
+
+ALTER TABLE settings DROP COLUMN fx_cache_ttl_hours;
+
+-- +goose StatementEnd
@@ -0,0 +1,709 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- data_version counts writes: every insert, update or delete on a user data
+-- table bumps it. fx_rate_snapshots, audit_events, operations,
+-- report_schedule_runs and report_cache are left out, so generating a report
+-- never invalidates its own cache entry.
+CREATE TABLE IF NOT EXISTS data_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL DEFAULT 0 CHECK (version >= 0)
+);
+
+INSERT OR IGNORE INTO data_version (id, version) VALUES (1, 0);
+
+CREATE TABLE IF NOT EXISTS report_cache (
+    cache_key TEXT PRIMARY KEY,
+    data_version INTEGER NOT NULL,
+    payload_json TEXT NOT NULL,
+    created_at_utc TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+ALTER TABLE settings
+    ADD COLUMN report_cache INTEGER NOT NULL DEFAULT 0
+    CHECK (report_cache IN (0, 1));
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_assets_insert
+AFTER INSERT ON assets
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_assets_update
+AFTER UPDATE ON assets
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_assets_delete
+AFTER DELETE ON assets
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_balance_account_links_insert
+AFTER INSERT ON balance_account_links
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_balance_account_links_update
+AFTER UPDATE ON balance_account_links
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_balance_account_links_delete
+AFTER DELETE ON balance_account_links
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_bank_accounts_insert
+AFTER INSERT ON bank_accounts
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_bank_accounts_update
+AFTER UPDATE ON bank_accounts
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_bank_accounts_delete
+AFTER DELETE ON bank_accounts
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_aliases_insert
+AFTER INSERT ON card_aliases
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_aliases_update
+AFTER UPDATE ON card_aliases
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_aliases_delete
+AFTER DELETE ON card_aliases
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_monthly_limits_insert
+AFTER INSERT ON card_monthly_limits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_monthly_limits_update
+AFTER UPDATE ON card_monthly_limits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_card_monthly_limits_delete
+AFTER DELETE ON card_monthly_limits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_cards_insert
+AFTER INSERT ON cards
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_cards_update
+AFTER UPDATE ON cards
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_cards_delete
+AFTER DELETE ON cards
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_categories_insert
+AFTER INSERT ON categories
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_categories_update
+AFTER UPDATE ON categories
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_categories_delete
+AFTER DELETE ON categories
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_credit_liability_events_insert
+AFTER INSERT ON credit_liability_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_credit_liability_events_update
+AFTER UPDATE ON credit_liability_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_credit_liability_events_delete
+AFTER DELETE ON credit_liability_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_custom_currencies_insert
+AFTER INSERT ON custom_currencies
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_custom_currencies_update
+AFTER UPDATE ON custom_currencies
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_custom_currencies_delete
+AFTER DELETE ON custom_currencies
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_entry_splits_insert
+AFTER INSERT ON entry_splits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_entry_splits_update
+AFTER UPDATE ON entry_splits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_entry_splits_delete
+AFTER DELETE ON entry_splits
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_envelopes_insert
+AFTER INSERT ON envelopes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_envelopes_update
+AFTER UPDATE ON envelopes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_envelopes_delete
+AFTER DELETE ON envelopes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_hooks_insert
+AFTER INSERT ON hooks
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_hooks_update
+AFTER UPDATE ON hooks
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_hooks_delete
+AFTER DELETE ON hooks
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_inflation_index_points_insert
+AFTER INSERT ON inflation_index_points
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_inflation_index_points_update
+AFTER UPDATE ON inflation_index_points
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_inflation_index_points_delete
+AFTER DELETE ON inflation_index_points
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_labels_insert
+AFTER INSERT ON labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_labels_update
+AFTER UPDATE ON labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_labels_delete
+AFTER DELETE ON labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loan_payments_insert
+AFTER INSERT ON loan_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loan_payments_update
+AFTER UPDATE ON loan_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loan_payments_delete
+AFTER DELETE ON loan_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loans_insert
+AFTER INSERT ON loans
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loans_update
+AFTER UPDATE ON loans
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_loans_delete
+AFTER DELETE ON loans
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_cap_changes_insert
+AFTER INSERT ON monthly_cap_changes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_cap_changes_update
+AFTER UPDATE ON monthly_cap_changes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_cap_changes_delete
+AFTER DELETE ON monthly_cap_changes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_caps_insert
+AFTER INSERT ON monthly_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_caps_update
+AFTER UPDATE ON monthly_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_caps_delete
+AFTER DELETE ON monthly_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_category_caps_insert
+AFTER INSERT ON monthly_category_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_category_caps_update
+AFTER UPDATE ON monthly_category_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_monthly_category_caps_delete
+AFTER DELETE ON monthly_category_caps
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_net_worth_snapshots_insert
+AFTER INSERT ON net_worth_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_net_worth_snapshots_update
+AFTER UPDATE ON net_worth_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_net_worth_snapshots_delete
+AFTER DELETE ON net_worth_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_orphan_spending_thresholds_insert
+AFTER INSERT ON orphan_spending_thresholds
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_orphan_spending_thresholds_update
+AFTER UPDATE ON orphan_spending_thresholds
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_orphan_spending_thresholds_delete
+AFTER DELETE ON orphan_spending_thresholds
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_report_snapshots_insert
+AFTER INSERT ON report_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_report_snapshots_update
+AFTER UPDATE ON report_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_report_snapshots_delete
+AFTER DELETE ON report_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_savings_events_insert
+AFTER INSERT ON savings_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_savings_events_update
+AFTER UPDATE ON savings_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_savings_events_delete
+AFTER DELETE ON savings_events
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payment_executions_insert
+AFTER INSERT ON scheduled_payment_executions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payment_executions_update
+AFTER UPDATE ON scheduled_payment_executions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payment_executions_delete
+AFTER DELETE ON scheduled_payment_executions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payments_insert
+AFTER INSERT ON scheduled_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payments_update
+AFTER UPDATE ON scheduled_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_scheduled_payments_delete
+AFTER DELETE ON scheduled_payments
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settings_insert
+AFTER INSERT ON settings
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settings_update
+AFTER UPDATE ON settings
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settings_delete
+AFTER DELETE ON settings
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settlements_insert
+AFTER INSERT ON settlements
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settlements_update
+AFTER UPDATE ON settlements
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_settlements_delete
+AFTER DELETE ON settlements
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_strict_warning_codes_insert
+AFTER INSERT ON strict_warning_codes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_strict_warning_codes_update
+AFTER UPDATE ON strict_warning_codes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_strict_warning_codes_delete
+AFTER DELETE ON strict_warning_codes
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_labels_insert
+AFTER INSERT ON transaction_labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_labels_update
+AFTER UPDATE ON transaction_labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_labels_delete
+AFTER DELETE ON transaction_labels
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_payment_methods_insert
+AFTER INSERT ON transaction_payment_methods
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_payment_methods_update
+AFTER UPDATE ON transaction_payment_methods
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_payment_methods_delete
+AFTER DELETE ON transaction_payment_methods
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_revisions_insert
+AFTER INSERT ON transaction_revisions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_revisions_update
+AFTER UPDATE ON transaction_revisions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transaction_revisions_delete
+AFTER DELETE ON transaction_revisions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transactions_insert
+AFTER INSERT ON transactions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transactions_update
+AFTER UPDATE ON transactions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_transactions_delete
+AFTER DELETE ON transactions
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_data_version_transactions_delete;
+DROP TRIGGER IF EXISTS trg_data_version_transactions_update;
+DROP TRIGGER IF EXISTS trg_data_version_transactions_insert;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_revisions_delete;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_revisions_update;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_revisions_insert;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_payment_methods_delete;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_payment_methods_update;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_payment_methods_insert;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_labels_delete;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_labels_update;
+DROP TRIGGER IF EXISTS trg_data_version_transaction_labels_insert;
+DROP TRIGGER IF EXISTS trg_data_version_strict_warning_codes_delete;
+DROP TRIGGER IF EXISTS trg_data_version_strict_warning_codes_update;
+DROP TRIGGER IF EXISTS trg_data_version_strict_warning_codes_insert;
+DROP TRIGGER IF EXISTS trg_data_version_settlements_delete;
+DROP TRIGGER IF EXISTS trg_data_version_settlements_update;
+DROP TRIGGER IF EXISTS trg_data_version_settlements_insert;
+DROP TRIGGER IF EXISTS trg_data_version_settings_delete;
+DROP TRIGGER IF EXISTS trg_data_version_settings_update;
+DROP TRIGGER IF EXISTS trg_data_version_settings_insert;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payments_delete;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payments_update;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payments_insert;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payment_executions_delete;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payment_executions_update;
+DROP TRIGGER IF EXISTS trg_data_version_scheduled_payment_executions_insert;
+DROP TRIGGER IF EXISTS trg_data_version_savings_events_delete;
+DROP TRIGGER IF EXISTS trg_data_version_savings_events_update;
+DROP TRIGGER IF EXISTS trg_data_version_savings_events_insert;
+DROP TRIGGER IF EXISTS trg_data_version_report_snapshots_delete;
+DROP TRIGGER IF EXISTS trg_data_version_report_snapshots_update;
+DROP TRIGGER IF EXISTS trg_data_version_report_snapshots_insert;
+DROP TRIGGER IF EXISTS trg_data_version_orphan_spending_thresholds_delete;
+DROP TRIGGER IF EXISTS trg_data_version_orphan_spending_thresholds_update;
+DROP TRIGGER IF EXISTS trg_data_version_orphan_spending_thresholds_insert;
+DROP TRIGGER IF EXISTS trg_data_version_net_worth_snapshots_delete;
+DROP TRIGGER IF EXISTS trg_data_version_net_worth_snapshots_update;
+DROP TRIGGER IF EXISTS trg_data_version_net_worth_snapshots_insert;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_category_caps_delete;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_category_caps_update;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_category_caps_insert;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_caps_delete;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_caps_update;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_caps_insert;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_cap_changes_delete;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_cap_changes_update;
+DROP TRIGGER IF EXISTS trg_data_version_monthly_cap_changes_insert;
+DROP TRIGGER IF EXISTS trg_data_version_loans_delete;
+DROP TRIGGER IF EXISTS trg_data_version_loans_update;
+DROP TRIGGER IF EXISTS trg_data_version_loans_insert;
+DROP TRIGGER IF EXISTS trg_data_version_loan_payments_delete;
+DROP TRIGGER IF EXISTS trg_data_version_loan_payments_update;
+DROP TRIGGER IF EXISTS trg_data_version_loan_payments_insert;
+DROP TRIGGER IF EXISTS trg_data_version_labels_delete;
+DROP TRIGGER IF EXISTS trg_data_version_labels_update;
+DROP TRIGGER IF EXISTS trg_data_version_labels_insert;
+DROP TRIGGER IF EXISTS trg_data_version_inflation_index_points_delete;
+DROP TRIGGER IF EXISTS trg_data_version_inflation_index_points_update;
+DROP TRIGGER IF EXISTS trg_data_version_inflation_index_points_insert;
+DROP TRIGGER IF EXISTS trg_data_version_hooks_delete;
+DROP TRIGGER IF EXISTS trg_data_version_hooks_update;
+DROP TRIGGER IF EXISTS trg_data_version_hooks_insert;
+DROP TRIGGER IF EXISTS trg_data_version_envelopes_delete;
+DROP TRIGGER IF EXISTS trg_data_version_envelopes_update;
+DROP TRIGGER IF EXISTS trg_data_version_envelopes_insert;
+DROP TRIGGER IF EXISTS trg_data_version_entry_splits_delete;
+DROP TRIGGER IF EXISTS trg_data_version_entry_splits_update;
+DROP TRIGGER IF EXISTS trg_data_version_entry_splits_insert;
+DROP TRIGGER IF EXISTS trg_data_version_custom_currencies_delete;
+DROP TRIGGER IF EXISTS trg_data_version_custom_currencies_update;
+DROP TRIGGER IF EXISTS trg_data_version_custom_currencies_insert;
+DROP TRIGGER IF EXISTS trg_data_version_credit_liability_events_delete;
+DROP TRIGGER IF EXISTS trg_data_version_credit_liability_events_update;
+DROP TRIGGER IF EXISTS trg_data_version_credit_liability_events_insert;
+DROP TRIGGER IF EXISTS trg_data_version_categories_delete;
+DROP TRIGGER IF EXISTS trg_data_version_categories_update;
+DROP TRIGGER IF EXISTS trg_data_version_categories_insert;
+DROP TRIGGER IF EXISTS trg_data_version_cards_delete;
+DROP TRIGGER IF EXISTS trg_data_version_cards_update;
+DROP TRIGGER IF EXISTS trg_data_version_cards_insert;
+DROP TRIGGER IF EXISTS trg_data_version_card_monthly_limits_delete;
+DROP TRIGGER IF EXISTS trg_data_version_card_monthly_limits_update;
+DROP TRIGGER IF EXISTS trg_data_version_card_monthly_limits_insert;
+DROP TRIGGER IF EXISTS trg_data_version_card_aliases_delete;
+DROP TRIGGER IF EXISTS trg_data_version_card_aliases_update;
+DROP TRIGGER IF EXISTS trg_data_version_card_aliases_insert;
+DROP TRIGGER IF EXISTS trg_data_version_bank_accounts_delete;
+DROP TRIGGER IF EXISTS trg_data_version_bank_accounts_update;
+DROP TRIGGER IF EXISTS trg_data_version_bank_accounts_insert;
+DROP TRIGGER IF EXISTS trg_data_version_balance_account_links_delete;
+DROP TRIGGER IF EXISTS trg_data_version_balance_account_links_update;
+DROP TRIGGER IF EXISTS trg_data_version_balance_account_links_insert;
+DROP TRIGGER IF EXISTS trg_data_version_assets_delete;
+DROP TRIGGER IF EXISTS trg_data_version_assets_update;
+DROP TRIGGER IF EXISTS trg_data_version_assets_insert;
+
+ALTER TABLE settings DROP COLUMN report_cache;
+
+DROP TABLE IF EXISTS report_cache;
+DROP TABLE IF EXISTS data_version;
+
+-- +goose StatementEnd
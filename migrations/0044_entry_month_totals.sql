@@ -0,0 +1,122 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- entry_month_totals keeps the income and expense totals of active entries
+-- per UTC month and currency. Expense totals follow the effective amount, so
+-- refunds subtract. Triggers on transactions update it in the same
+-- transaction as every write, whichever command made it.
+CREATE TABLE IF NOT EXISTS entry_month_totals (
+    month_key TEXT NOT NULL CHECK (length(month_key) = 7),
+    currency_code TEXT NOT NULL CHECK (length(currency_code) = 3),
+    income_minor INTEGER NOT NULL DEFAULT 0,
+    expense_minor INTEGER NOT NULL DEFAULT 0,
+    entry_count INTEGER NOT NULL DEFAULT 0 CHECK (entry_count >= 0),
+    PRIMARY KEY (month_key, currency_code)
+);
+
+INSERT INTO entry_month_totals (month_key, currency_code, income_minor, expense_minor, entry_count)
+SELECT substr(transaction_date_utc, 1, 7),
+       currency_code,
+       COALESCE(SUM(CASE WHEN type = 'income' THEN amount_minor ELSE 0 END), 0),
+       COALESCE(SUM(CASE
+           WHEN type = 'expense' AND refund_of_transaction_id IS NOT NULL THEN -amount_minor
+           WHEN type = 'expense' THEN amount_minor
+           ELSE 0
+       END), 0),
+       COUNT(*)
+FROM transactions
+WHERE deleted_at_utc IS NULL
+GROUP BY substr(transaction_date_utc, 1, 7), currency_code;
+
+CREATE TRIGGER IF NOT EXISTS trg_entry_month_totals_insert
+AFTER INSERT ON transactions
+WHEN NEW.deleted_at_utc IS NULL
+BEGIN
+    INSERT INTO entry_month_totals (month_key, currency_code, income_minor, expense_minor, entry_count)
+    VALUES (
+        substr(NEW.transaction_date_utc, 1, 7),
+        NEW.currency_code,
+        CASE WHEN NEW.type = 'income' THEN NEW.amount_minor ELSE 0 END,
+        CASE
+            WHEN NEW.type = 'expense' AND NEW.refund_of_transaction_id IS NOT NULL THEN -NEW.amount_minor
+            WHEN NEW.type = 'expense' THEN NEW.amount_minor
+            ELSE 0
+        END,
+        1
+    )
+    ON CONFLICT (month_key, currency_code) DO UPDATE SET
+        income_minor = income_minor + excluded.income_minor,
+        expense_minor = expense_minor + excluded.expense_minor,
+        entry_count = entry_count + 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_entry_month_totals_update
+AFTER UPDATE OF type, amount_minor, currency_code, transaction_date_utc, refund_of_transaction_id, deleted_at_utc ON transactions
+BEGIN
+    UPDATE entry_month_totals
+    SET income_minor = income_minor - CASE WHEN OLD.type = 'income' THEN OLD.amount_minor ELSE 0 END,
+        expense_minor = expense_minor - CASE
+            WHEN OLD.type = 'expense' AND OLD.refund_of_transaction_id IS NOT NULL THEN -OLD.amount_minor
+            WHEN OLD.type = 'expense' THEN OLD.amount_minor
+            ELSE 0
+        END,
+        entry_count = entry_count - 1
+    WHERE OLD.deleted_at_utc IS NULL
+      AND month_key = substr(OLD.transaction_date_utc, 1, 7)
+      AND currency_code = OLD.currency_code;
+
+    DELETE FROM entry_month_totals
+    WHERE month_key = substr(OLD.transaction_date_utc, 1, 7)
+      AND currency_code = OLD.currency_code
+      AND entry_count = 0;
+
+    INSERT INTO entry_month_totals (month_key, currency_code, income_minor, expense_minor, entry_count)
+    SELECT substr(NEW.transaction_date_utc, 1, 7),
+           NEW.currency_code,
+           CASE WHEN NEW.type = 'income' THEN NEW.amount_minor ELSE 0 END,
+           CASE
+               WHEN NEW.type = 'expense' AND NEW.refund_of_transaction_id IS NOT NULL THEN -NEW.amount_minor
+               WHEN NEW.type = 'expense' THEN NEW.amount_minor
+               ELSE 0
+           END,
+           1
+    WHERE NEW.deleted_at_utc IS NULL
+    ON CONFLICT (month_key, currency_code) DO UPDATE SET
+        income_minor = income_minor + excluded.income_minor,
+        expense_minor = expense_minor + excluded.expense_minor,
+        entry_count = entry_count + 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_entry_month_totals_delete
+AFTER DELETE ON transactions
+WHEN OLD.deleted_at_utc IS NULL
+BEGIN
+    UPDATE entry_month_totals
+    SET income_minor = income_minor - CASE WHEN OLD.type = 'income' THEN OLD.amount_minor ELSE 0 END,
+        expense_minor = expense_minor - CASE
+            WHEN OLD.type = 'expense' AND OLD.refund_of_transaction_id IS NOT NULL THEN -OLD.amount_minor
+            WHEN OLD.type = 'expense' THEN OLD.amount_minor
+            ELSE 0
+        END,
+        entry_count = entry_count - 1
+    WHERE month_key = substr(OLD.transaction_date_utc, 1, 7)
+      AND currency_code = OLD.currency_code;
+
+    DELETE FROM entry_month_totals
+    WHERE month_key = substr(OLD.transaction_date_utc, 1, 7)
+      AND currency_code = OLD.currency_code
+      AND entry_count = 0;
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_entry_month_totals_delete;
+DROP TRIGGER IF EXISTS trg_entry_month_totals_update;
+DROP TRIGGER IF EXISTS trg_entry_month_totals_insert;
+
+DROP TABLE IF EXISTS entry_month_totals;
+
+-- +goose StatementEnd
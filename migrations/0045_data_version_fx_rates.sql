@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A refreshed or newly fetched rate changes converted reports, so FX rate
+-- snapshots bump data_version like the ledger tables do.
+CREATE TRIGGER IF NOT EXISTS trg_data_version_fx_rate_snapshots_insert
+AFTER INSERT ON fx_rate_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_fx_rate_snapshots_update
+AFTER UPDATE ON fx_rate_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_data_version_fx_rate_snapshots_delete
+AFTER DELETE ON fx_rate_snapshots
+BEGIN
+    UPDATE data_version SET version = version + 1 WHERE id = 1;
+END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_data_version_fx_rate_snapshots_delete;
+DROP TRIGGER IF EXISTS trg_data_version_fx_rate_snapshots_update;
+DROP TRIGGER IF EXISTS trg_data_version_fx_rate_snapshots_insert;
+
+-- +goose StatementEnd
@@ -0,0 +1,112 @@
+// Package budget is the public Go API of boring-budget: it opens a budget
+// database and exposes the entry, report and portability engines the CLI is
+// built on, so other programs (a GUI, a chat bot) can embed them.
+//
+// Everything exported here follows semantic versioning: names, signatures
+// and the meaning of fields only change in a new major version. Packages
+// under internal/ carry no such promise, which is why this package wraps the
+// services instead of re-exporting them, and why the value types below are
+// aliases whose fields are documented in docs/SPEC.md.
+package budget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"boring-budget/internal/domain"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+)
+
+// Entry types.
+const (
+	EntryTypeIncome  = domain.EntryTypeIncome
+	EntryTypeExpense = domain.EntryTypeExpense
+)
+
+// Report scopes and groupings.
+const (
+	ReportScopeRange     = domain.ReportScopeRange
+	ReportScopeMonthly   = domain.ReportScopeMonthly
+	ReportScopeBimonthly = domain.ReportScopeBimonthly
+	ReportScopeQuarterly = domain.ReportScopeQuarterly
+
+	ReportGroupingDay   = domain.ReportGroupingDay
+	ReportGroupingWeek  = domain.ReportGroupingWeek
+	ReportGroupingMonth = domain.ReportGroupingMonth
+)
+
+// Export and import formats; StdioPath reads stdin or writes stdout.
+const (
+	FormatJSON = service.PortabilityFormatJSON
+	FormatCSV  = service.PortabilityFormatCSV
+	StdioPath  = service.PortabilityStdioPath
+)
+
+type (
+	Entry             = domain.Entry
+	EntryAddInput     = domain.EntryAddInput
+	EntryUpdateInput  = domain.EntryUpdateInput
+	EntryListFilter   = domain.EntryListFilter
+	EntryDeleteResult = domain.EntryDeleteResult
+	EntryHistory      = domain.EntryHistory
+	EntrySplit        = domain.EntrySplit
+	Warning           = domain.Warning
+	Report            = domain.Report
+	ReportPeriodInput = domain.ReportPeriodInput
+)
+
+// Errors the services return, for use with errors.Is.
+var (
+	ErrEntryNotFound          = domain.ErrEntryNotFound
+	ErrEntryRevisionNotFound  = domain.ErrEntryRevisionNotFound
+	ErrInvalidEntryType       = domain.ErrInvalidEntryType
+	ErrInvalidAmountMinor     = domain.ErrInvalidAmountMinor
+	ErrInvalidCurrencyCode    = domain.ErrInvalidCurrencyCode
+	ErrInvalidTransactionDate = domain.ErrInvalidTransactionDate
+	ErrInvalidDateRange       = domain.ErrInvalidDateRange
+	ErrUpdateConflict         = domain.ErrUpdateConflict
+)
+
+// Store is an open budget database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path, creating it when missing, and
+// applies the migrations embedded in this module. ":memory:" opens a private
+// in-memory database.
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, err := sqlitestore.OpenAndMigrate(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// OpenReadOnly opens an existing database file without migrating it and
+// refuses writes, e.g. to read a backup. The services' write methods fail
+// on it.
+func OpenReadOnly(ctx context.Context, path string) (*Store, error) {
+	db, err := sqlitestore.OpenReadOnly(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database. Services built on the store stop working.
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *Store) sqlDB() (*sql.DB, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("budget: store is not open")
+	}
+	return s.db, nil
+}
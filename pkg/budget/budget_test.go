@@ -0,0 +1,117 @@
+package budget_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"boring-budget/pkg/budget"
+)
+
+func TestStoreEntryReportAndPortabilityRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := budget.Open(ctx, filepath.Join(t.TempDir(), "budget.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	entries, err := budget.NewEntryService(store)
+	if err != nil {
+		t.Fatalf("new entry service: %v", err)
+	}
+	added, err := entries.Add(ctx, budget.EntryAddInput{
+		Type:               budget.EntryTypeExpense,
+		AmountMinor:        1250,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-02-03",
+		Note:               "lunch",
+	})
+	if err != nil {
+		t.Fatalf("add entry: %v", err)
+	}
+	if _, err := entries.Add(ctx, budget.EntryAddInput{
+		Type:               budget.EntryTypeIncome,
+		AmountMinor:        300000,
+		CurrencyCode:       "USD",
+		TransactionDateUTC: "2026-02-01",
+	}); err != nil {
+		t.Fatalf("add income: %v", err)
+	}
+
+	amount := int64(1500)
+	updated, err := entries.Update(ctx, budget.EntryUpdateInput{ID: added.Entry.ID, AmountMinor: &amount})
+	if err != nil {
+		t.Fatalf("update entry: %v", err)
+	}
+	if updated.Entry.AmountMinor != 1500 {
+		t.Fatalf("expected updated amount 1500, got %d", updated.Entry.AmountMinor)
+	}
+	history, err := entries.History(ctx, added.Entry.ID)
+	if err != nil {
+		t.Fatalf("entry history: %v", err)
+	}
+	if len(history.Revisions) != 1 || history.Revisions[0].State.AmountMinor != 1250 {
+		t.Fatalf("expected one revision with the old amount, got %+v", history.Revisions)
+	}
+	if _, err := entries.Get(ctx, 999); !errors.Is(err, budget.ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+
+	reports, err := budget.NewReportService(store)
+	if err != nil {
+		t.Fatalf("new report service: %v", err)
+	}
+	result, err := reports.Generate(ctx, budget.ReportRequest{
+		Period:   budget.ReportPeriodInput{Scope: budget.ReportScopeMonthly, MonthKey: "2026-02"},
+		Grouping: budget.ReportGroupingMonth,
+	})
+	if err != nil {
+		t.Fatalf("generate report: %v", err)
+	}
+	if result.Report.Period.MonthKey != "2026-02" {
+		t.Fatalf("expected report for 2026-02, got %+v", result.Report.Period)
+	}
+
+	portability, err := budget.NewPortabilityService(store)
+	if err != nil {
+		t.Fatalf("new portability service: %v", err)
+	}
+	exportPath := filepath.Join(t.TempDir(), "entries.json")
+	count, err := portability.Export(ctx, budget.FormatJSON, exportPath, budget.EntryListFilter{})
+	if err != nil {
+		t.Fatalf("export entries: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 exported entries, got %d", count)
+	}
+
+	other, err := budget.Open(ctx, filepath.Join(t.TempDir(), "other.db"))
+	if err != nil {
+		t.Fatalf("open other store: %v", err)
+	}
+	t.Cleanup(func() { _ = other.Close() })
+	otherPortability, err := budget.NewPortabilityService(other)
+	if err != nil {
+		t.Fatalf("new other portability service: %v", err)
+	}
+	imported, err := otherPortability.Import(ctx, budget.FormatJSON, exportPath, true)
+	if err != nil {
+		t.Fatalf("import entries: %v", err)
+	}
+	if imported.Imported != 2 {
+		t.Fatalf("expected 2 imported entries, got %+v", imported)
+	}
+}
+
+func TestNilStoreRejectsServices(t *testing.T) {
+	t.Parallel()
+
+	var store *budget.Store
+	if _, err := budget.NewEntryService(store); err == nil {
+		t.Fatalf("expected an error for a nil store")
+	}
+}
@@ -0,0 +1,216 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+
+	"boring-budget/internal/fx"
+	"boring-budget/internal/service"
+	sqlitestore "boring-budget/internal/store/sqlite"
+)
+
+type (
+	// EntryResult is a written entry with the warnings (cap exceeded, card
+	// limit reached, ...) the write raised.
+	EntryResult   = service.EntryAddResult
+	ReportRequest = service.ReportRequest
+	ReportResult  = service.ReportResult
+	ImportResult  = service.PortabilityImportResult
+)
+
+// EntryService records and edits entries with the same validation, cap and
+// card-limit warnings, and revision history as the entry commands.
+type EntryService struct {
+	svc *service.EntryService
+}
+
+// NewEntryService returns the entry engine of store.
+func NewEntryService(store *Store) (*EntryService, error) {
+	db, err := store.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	cardSvc, err := service.NewCardService(sqlitestore.NewCardRepo(db))
+	if err != nil {
+		return nil, fmt.Errorf("card service init: %w", err)
+	}
+	labelRepo, err := sqlitestore.NewLabelRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+
+	svc, err := service.NewEntryService(
+		sqlitestore.NewEntryRepo(db),
+		service.WithEntryCapLookup(sqlitestore.NewCapRepo(db)),
+		service.WithEntryCardResolver(cardSvc),
+		service.WithEntryCardLimitLookup(sqlitestore.NewCardRepo(db)),
+		service.WithEntrySubscriptionAlerts(),
+		service.WithEntryBalanceLinkReader(sqlitestore.NewBankAccountRepo(db)),
+		service.WithEntryExpansion(sqlitestore.NewCategoryRepo(db), labelRepo, cardSvc),
+		service.WithEntryBatchDB(db),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("entry service init: %w", err)
+	}
+	return &EntryService{svc: svc}, nil
+}
+
+func (s *EntryService) Add(ctx context.Context, input EntryAddInput) (EntryResult, error) {
+	return s.svc.AddWithWarnings(ctx, input)
+}
+
+// Update changes the fields input sets and stores the previous state as a
+// revision.
+func (s *EntryService) Update(ctx context.Context, input EntryUpdateInput) (EntryResult, error) {
+	return s.svc.UpdateWithWarnings(ctx, input)
+}
+
+func (s *EntryService) Get(ctx context.Context, id int64) (Entry, error) {
+	return s.svc.Get(ctx, id)
+}
+
+func (s *EntryService) List(ctx context.Context, filter EntryListFilter) ([]Entry, error) {
+	return s.svc.List(ctx, filter)
+}
+
+func (s *EntryService) Delete(ctx context.Context, id int64) (EntryDeleteResult, error) {
+	return s.svc.Delete(ctx, id)
+}
+
+func (s *EntryService) History(ctx context.Context, id int64) (EntryHistory, error) {
+	return s.svc.History(ctx, id)
+}
+
+// Revert puts entry id back into the state of revision.
+func (s *EntryService) Revert(ctx context.Context, id, revision int64) (EntryResult, error) {
+	return s.svc.Revert(ctx, id, revision)
+}
+
+// ReportService builds the period reports of the report commands. Reports
+// converting to another currency fetch missing exchange rates online and
+// cache them in the database, as the CLI does. With the report_cache setting
+// on, repeated requests are served from cache until the data changes.
+type ReportService struct {
+	svc *service.ReportService
+}
+
+// NewReportService returns the report engine of store.
+func NewReportService(store *Store) (*ReportService, error) {
+	svc, err := newInternalReportService(store)
+	if err != nil {
+		return nil, err
+	}
+	return &ReportService{svc: svc}, nil
+}
+
+func (s *ReportService) Generate(ctx context.Context, req ReportRequest) (ReportResult, error) {
+	return s.svc.Generate(ctx, req)
+}
+
+func newInternalReportService(store *Store) (*service.ReportService, error) {
+	db, err := store.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	entryRepo := sqlitestore.NewEntryRepo(db)
+	entrySvc, err := service.NewEntryService(entryRepo)
+	if err != nil {
+		return nil, fmt.Errorf("entry service init: %w", err)
+	}
+	capSvc, err := service.NewCapService(sqlitestore.NewCapRepo(db))
+	if err != nil {
+		return nil, fmt.Errorf("cap service init: %w", err)
+	}
+	cardSvc, err := service.NewCardService(sqlitestore.NewCardRepo(db))
+	if err != nil {
+		return nil, fmt.Errorf("card service init: %w", err)
+	}
+	labelRepo, err := sqlitestore.NewLabelRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+
+	settingsRepo := sqlitestore.NewSettingsRepo(db)
+	reportOptions := []service.ReportServiceOption{
+		service.WithReportSettingsReader(settingsRepo),
+		service.WithReportCategoryReader(sqlitestore.NewCategoryRepo(db)),
+		service.WithReportCardDebtReader(cardSvc),
+		service.WithReportInflationIndexReader(sqlitestore.NewInflationRepo(db)),
+		service.WithReportLabelReader(labelRepo),
+		service.WithReportAssetLister(sqlitestore.NewAssetRepo(db)),
+		service.WithReportMonthTotals(entryRepo),
+	}
+	if converter, err := fx.NewConverter(fx.NewFrankfurterClient(nil), sqlitestore.NewFXRepo(db)); err == nil {
+		reportOptions = append(reportOptions, service.WithReportFXConverter(converter))
+	}
+	if settings, err := settingsRepo.Get(context.Background()); err == nil && settings.ReportCache {
+		reportOptions = append(reportOptions, service.WithReportCache(sqlitestore.NewReportCacheRepo(db)))
+	}
+
+	svc, err := service.NewReportService(entrySvc, capSvc, reportOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("report service init: %w", err)
+	}
+	return svc, nil
+}
+
+// PortabilityService exports, imports and backs up data in the formats of
+// the data commands.
+type PortabilityService struct {
+	svc *service.PortabilityService
+}
+
+// NewPortabilityService returns the portability engine of store.
+func NewPortabilityService(store *Store) (*PortabilityService, error) {
+	db, err := store.sqlDB()
+	if err != nil {
+		return nil, err
+	}
+
+	entrySvc, err := service.NewEntryService(
+		sqlitestore.NewEntryRepo(db),
+		service.WithEntryCapLookup(sqlitestore.NewCapRepo(db)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("entry service init: %w", err)
+	}
+	reportSvc, err := newInternalReportService(store)
+	if err != nil {
+		return nil, err
+	}
+	labelRepo, err := sqlitestore.NewLabelRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("label repo init: %w", err)
+	}
+
+	svc, err := service.NewPortabilityService(entrySvc, db,
+		service.WithPortabilityReportService(reportSvc),
+		service.WithPortabilityNaturalKeys(sqlitestore.NewCategoryRepo(db), labelRepo, sqlitestore.NewCardRepo(db)),
+		service.WithPortabilityDataset(sqlitestore.NewDatasetRepo(db)),
+		service.WithPortabilityAudit(sqlitestore.NewAuditEventRepo(db)),
+		service.WithPortabilityOnlineBackup(sqlitestore.NewBackupRepo(db)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("portability service init: %w", err)
+	}
+	return &PortabilityService{svc: svc}, nil
+}
+
+// Export writes the entries matching filter to path as FormatJSON or
+// FormatCSV and returns how many it wrote.
+func (s *PortabilityService) Export(ctx context.Context, format, path string, filter EntryListFilter) (int64, error) {
+	return s.svc.Export(ctx, format, path, filter)
+}
+
+// Import reads entries written by Export. With idempotent set, records
+// already present are skipped instead of added again.
+func (s *PortabilityService) Import(ctx context.Context, format, path string, idempotent bool) (ImportResult, error) {
+	return s.svc.Import(ctx, format, path, idempotent)
+}
+
+// Backup writes a consistent copy of the whole database to path.
+func (s *PortabilityService) Backup(ctx context.Context, path string) error {
+	return s.svc.Backup(ctx, path)
+}